@@ -25,6 +25,7 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	Management_InitializeTenant_FullMethodName = "/proto.Management/InitializeTenant"
 	Management_CleanupTenant_FullMethodName    = "/proto.Management/CleanupTenant"
+	Management_UnarchiveTenant_FullMethodName  = "/proto.Management/UnarchiveTenant"
 )
 
 // ManagementClient is the client API for Management service.
@@ -33,6 +34,7 @@ const (
 type ManagementClient interface {
 	InitializeTenant(ctx context.Context, in *TenantRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	CleanupTenant(ctx context.Context, in *TenantRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	UnarchiveTenant(ctx context.Context, in *TenantRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type managementClient struct {
@@ -63,12 +65,23 @@ func (c *managementClient) CleanupTenant(ctx context.Context, in *TenantRequest,
 	return out, nil
 }
 
+func (c *managementClient) UnarchiveTenant(ctx context.Context, in *TenantRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Management_UnarchiveTenant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ManagementServer is the server API for Management service.
 // All implementations must embed UnimplementedManagementServer
 // for forward compatibility.
 type ManagementServer interface {
 	InitializeTenant(context.Context, *TenantRequest) (*emptypb.Empty, error)
 	CleanupTenant(context.Context, *TenantRequest) (*emptypb.Empty, error)
+	UnarchiveTenant(context.Context, *TenantRequest) (*emptypb.Empty, error)
 	mustEmbedUnimplementedManagementServer()
 }
 
@@ -85,6 +98,9 @@ func (UnimplementedManagementServer) InitializeTenant(context.Context, *TenantRe
 func (UnimplementedManagementServer) CleanupTenant(context.Context, *TenantRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CleanupTenant not implemented")
 }
+func (UnimplementedManagementServer) UnarchiveTenant(context.Context, *TenantRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnarchiveTenant not implemented")
+}
 func (UnimplementedManagementServer) mustEmbedUnimplementedManagementServer() {}
 func (UnimplementedManagementServer) testEmbeddedByValue()                    {}
 
@@ -142,6 +158,24 @@ func _Management_CleanupTenant_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Management_UnarchiveTenant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TenantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServer).UnarchiveTenant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Management_UnarchiveTenant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServer).UnarchiveTenant(ctx, req.(*TenantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Management_ServiceDesc is the grpc.ServiceDesc for Management service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -157,6 +191,10 @@ var Management_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CleanupTenant",
 			Handler:    _Management_CleanupTenant_Handler,
 		},
+		{
+			MethodName: "UnarchiveTenant",
+			Handler:    _Management_UnarchiveTenant_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/v1/management/mgmt.proto",
@@ -6,6 +6,7 @@ package api
 import (
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/labstack/echo/v4"
 	"github.com/oapi-codegen/runtime"
@@ -17,29 +18,107 @@ type ServerInterface interface {
 	// (GET /api/v1/alerts)
 	GetProjectAlerts(ctx echo.Context, params GetProjectAlertsParams) error
 
+	// (GET /api/v1/alerts/by-resource)
+	GetProjectAlertsByResource(ctx echo.Context, params GetProjectAlertsByResourceParams) error
+
 	// (GET /api/v1/alerts/definitions)
-	GetProjectAlertDefinitions(ctx echo.Context) error
+	GetProjectAlertDefinitions(ctx echo.Context, params GetProjectAlertDefinitionsParams) error
+
+	// (GET /api/v1/alerts/definitions/recommendations)
+	GetProjectAlertDefinitionRecommendations(ctx echo.Context) error
+
+	// (GET /api/v1/alerts/definitions/template-functions)
+	GetProjectAlertDefinitionTemplateFunctions(ctx echo.Context) error
 
 	// (GET /api/v1/alerts/definitions/{alertDefinitionID})
-	GetProjectAlertDefinition(ctx echo.Context, alertDefinitionID AlertDefinitionId) error
+	GetProjectAlertDefinition(ctx echo.Context, alertDefinitionID AlertDefinitionId, params GetProjectAlertDefinitionParams) error
 
 	// (PATCH /api/v1/alerts/definitions/{alertDefinitionID})
-	PatchProjectAlertDefinition(ctx echo.Context, alertDefinitionID AlertDefinitionId) error
+	PatchProjectAlertDefinition(ctx echo.Context, alertDefinitionID AlertDefinitionId, params PatchProjectAlertDefinitionParams) error
+
+	// (POST /api/v1/alerts/definitions/{alertDefinitionID}:evaluate)
+	PostProjectAlertDefinitionEvaluate(ctx echo.Context, alertDefinitionID AlertDefinitionId) error
+
+	// (POST /api/v1/alerts/definitions/{alertDefinitionID}:resetDefaults)
+	PostProjectAlertDefinitionResetDefaults(ctx echo.Context, alertDefinitionID AlertDefinitionId) error
+
+	// (POST /api/v1/alerts/definitions/{alertDefinitionID}:simulate)
+	PostProjectAlertDefinitionSimulate(ctx echo.Context, alertDefinitionID AlertDefinitionId) error
 
 	// (GET /api/v1/alerts/definitions/{alertDefinitionID}/template)
 	GetProjectAlertDefinitionRule(ctx echo.Context, alertDefinitionID AlertDefinitionId, params GetProjectAlertDefinitionRuleParams) error
 
+	// (GET /api/v1/alerts/email-template)
+	GetProjectEmailTemplate(ctx echo.Context, params GetProjectEmailTemplateParams) error
+
+	// (PUT /api/v1/alerts/email-template)
+	PutProjectEmailTemplate(ctx echo.Context) error
+
+	// (POST /api/v1/alerts/external)
+	PostProjectExternalAlerts(ctx echo.Context) error
+
+	// (PUT /api/v1/alerts/maintenance-mode)
+	PutProjectMaintenanceMode(ctx echo.Context) error
+
 	// (GET /api/v1/alerts/receivers)
-	GetProjectAlertReceivers(ctx echo.Context) error
+	GetProjectAlertReceivers(ctx echo.Context, params GetProjectAlertReceiversParams) error
+
+	// (GET /api/v1/alerts/receivers/languages)
+	GetProjectAlertReceiverLanguages(ctx echo.Context) error
 
 	// (GET /api/v1/alerts/receivers/{receiverID})
-	GetProjectAlertReceiver(ctx echo.Context, receiverID ReceiverId) error
+	GetProjectAlertReceiver(ctx echo.Context, receiverID ReceiverId, params GetProjectAlertReceiverParams) error
 
 	// (PATCH /api/v1/alerts/receivers/{receiverID})
-	PatchProjectAlertReceiver(ctx echo.Context, receiverID ReceiverId) error
+	PatchProjectAlertReceiver(ctx echo.Context, receiverID ReceiverId, params PatchProjectAlertReceiverParams) error
+
+	// (GET /api/v1/alerts/receivers/{receiverID}/preview)
+	GetProjectAlertReceiverPreview(ctx echo.Context, receiverID ReceiverId) error
+
+	// (GET /api/v1/alerts/receivers/{receiverID}/allowed-recipients)
+	GetProjectAlertReceiverAllowedRecipients(ctx echo.Context, receiverID ReceiverId, params GetProjectAlertReceiverAllowedRecipientsParams) error
+
+	// (POST /api/v1/alerts/receivers:syncRecipients)
+	PostProjectAlertReceiversSyncRecipients(ctx echo.Context) error
+
+	// (GET /api/v1/alerts/{alertFingerprint})
+	GetProjectAlert(ctx echo.Context, alertFingerprint AlertFingerprint) error
+
+	// (POST /api/v1/alerts/{alertFingerprint}/comments)
+	PostProjectAlertComment(ctx echo.Context, alertFingerprint AlertFingerprint) error
+
+	// (POST /api/v1/diff)
+	PostProjectConfigDiff(ctx echo.Context) error
+
+	// (GET /api/v1/hooks)
+	GetProjectEventHooks(ctx echo.Context) error
+
+	// (POST /api/v1/hooks)
+	PostProjectEventHook(ctx echo.Context) error
+
+	// (DELETE /api/v1/hooks/{hookID})
+	DeleteProjectEventHook(ctx echo.Context, hookID HookId) error
+
+	// (GET /api/v1/hooks/{hookID})
+	GetProjectEventHook(ctx echo.Context, hookID HookId) error
+
+	// (PATCH /api/v1/hooks/{hookID})
+	PatchProjectEventHook(ctx echo.Context, hookID HookId) error
+
+	// (GET /api/v1/operations/{operationID})
+	GetProjectOperation(ctx echo.Context, operationID OperationId) error
+
+	// (GET /api/v1/reports)
+	GetProjectReports(ctx echo.Context) error
+
+	// (GET /api/v1/reports/{reportID})
+	GetProjectReport(ctx echo.Context, reportID ReportId, params GetProjectReportParams) error
 
 	// (GET /api/v1/status)
 	GetServiceStatus(ctx echo.Context) error
+
+	// (GET /api/v1/verify)
+	VerifyEmailRecipient(ctx echo.Context, params VerifyEmailRecipientParams) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -95,17 +174,111 @@ func (w *ServerInterfaceWrapper) GetProjectAlerts(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter suppressed: %s", err))
 	}
 
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", ctx.QueryParams(), &params.Format)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter format: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
 	err = w.Handler.GetProjectAlerts(ctx, params)
 	return err
 }
 
+// GetProjectAlertsByResource converts echo context to params.
+func (w *ServerInterfaceWrapper) GetProjectAlertsByResource(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetProjectAlertsByResourceParams
+	// ------------- Optional query parameter "groupBy" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "groupBy", ctx.QueryParams(), &params.GroupBy)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter groupBy: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetProjectAlertsByResource(ctx, params)
+	return err
+}
+
 // GetProjectAlertDefinitions converts echo context to params.
 func (w *ServerInterfaceWrapper) GetProjectAlertDefinitions(ctx echo.Context) error {
 	var err error
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetProjectAlertDefinitionsParams
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", ctx.QueryParams(), &params.Offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offset: %s", err))
+	}
+
+	// ------------- Optional query parameter "sortBy" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sortBy", ctx.QueryParams(), &params.SortBy)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sortBy: %s", err))
+	}
+
+	// ------------- Optional query parameter "order" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "order", ctx.QueryParams(), &params.Order)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter order: %s", err))
+	}
+
+	// ------------- Optional query parameter "fields" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "fields", ctx.QueryParams(), &params.Fields)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter fields: %s", err))
+	}
+
+	// ------------- Optional query parameter "withStatus" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "withStatus", ctx.QueryParams(), &params.WithStatus)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter withStatus: %s", err))
+	}
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", ctx.QueryParams(), &params.Format)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter format: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetProjectAlertDefinitions(ctx, params)
+	return err
+}
+
+// GetProjectAlertDefinitionRecommendations converts echo context to params.
+func (w *ServerInterfaceWrapper) GetProjectAlertDefinitionRecommendations(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetProjectAlertDefinitionRecommendations(ctx)
+	return err
+}
+
+// GetProjectAlertDefinitionTemplateFunctions converts echo context to params.
+func (w *ServerInterfaceWrapper) GetProjectAlertDefinitionTemplateFunctions(ctx echo.Context) error {
+	var err error
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetProjectAlertDefinitions(ctx)
+	err = w.Handler.GetProjectAlertDefinitionTemplateFunctions(ctx)
 	return err
 }
 
@@ -120,8 +293,17 @@ func (w *ServerInterfaceWrapper) GetProjectAlertDefinition(ctx echo.Context) err
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter alertDefinitionID: %s", err))
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetProjectAlertDefinitionParams
+	// ------------- Optional query parameter "fields" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "fields", ctx.QueryParams(), &params.Fields)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter fields: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetProjectAlertDefinition(ctx, alertDefinitionID)
+	err = w.Handler.GetProjectAlertDefinition(ctx, alertDefinitionID, params)
 	return err
 }
 
@@ -136,11 +318,98 @@ func (w *ServerInterfaceWrapper) PatchProjectAlertDefinition(ctx echo.Context) e
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter alertDefinitionID: %s", err))
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PatchProjectAlertDefinitionParams
+	// ------------- Optional query parameter "async" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "async", ctx.QueryParams(), &params.Async)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter async: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PatchProjectAlertDefinition(ctx, alertDefinitionID, params)
+	return err
+}
+
+// PostProjectAlertDefinitionEvaluate converts echo context to params.
+func (w *ServerInterfaceWrapper) PostProjectAlertDefinitionEvaluate(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "alertDefinitionID" -------------
+	var alertDefinitionID AlertDefinitionId
+
+	// The router cannot match a literal colon following a path parameter, hence the custom method is cut off the parameter.
+	param, ok := strings.CutSuffix(ctx.Param("alertDefinitionID"), ":evaluate")
+	if !ok {
+		return echo.ErrNotFound
+	}
+
+	err = runtime.BindStyledParameterWithOptions("simple", "alertDefinitionID", param, &alertDefinitionID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter alertDefinitionID: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostProjectAlertDefinitionEvaluate(ctx, alertDefinitionID)
+	return err
+}
+
+// PostProjectAlertDefinitionResetDefaults converts echo context to params.
+func (w *ServerInterfaceWrapper) PostProjectAlertDefinitionResetDefaults(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "alertDefinitionID" -------------
+	var alertDefinitionID AlertDefinitionId
+
+	// The router cannot match a literal colon following a path parameter, hence the custom method is cut off the parameter.
+	param, ok := strings.CutSuffix(ctx.Param("alertDefinitionID"), ":resetDefaults")
+	if !ok {
+		return echo.ErrNotFound
+	}
+
+	err = runtime.BindStyledParameterWithOptions("simple", "alertDefinitionID", param, &alertDefinitionID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter alertDefinitionID: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostProjectAlertDefinitionResetDefaults(ctx, alertDefinitionID)
+	return err
+}
+
+// PostProjectAlertDefinitionSimulate converts echo context to params.
+func (w *ServerInterfaceWrapper) PostProjectAlertDefinitionSimulate(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "alertDefinitionID" -------------
+	var alertDefinitionID AlertDefinitionId
+
+	// The router cannot match a literal colon following a path parameter, hence the custom method is cut off the parameter.
+	param, ok := strings.CutSuffix(ctx.Param("alertDefinitionID"), ":simulate")
+	if !ok {
+		return echo.ErrNotFound
+	}
+
+	err = runtime.BindStyledParameterWithOptions("simple", "alertDefinitionID", param, &alertDefinitionID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter alertDefinitionID: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.PatchProjectAlertDefinition(ctx, alertDefinitionID)
+	err = w.Handler.PostProjectAlertDefinitionSimulate(ctx, alertDefinitionID)
 	return err
 }
 
+// postProjectAlertDefinitionCustomMethod dispatches the custom methods of alert definitions, which share the route of the
+// alert definition since the router cannot match a literal colon following a path parameter.
+func (w *ServerInterfaceWrapper) postProjectAlertDefinitionCustomMethod(ctx echo.Context) error {
+	if strings.HasSuffix(ctx.Param("alertDefinitionID"), ":evaluate") {
+		return w.PostProjectAlertDefinitionEvaluate(ctx)
+	}
+	if strings.HasSuffix(ctx.Param("alertDefinitionID"), ":simulate") {
+		return w.PostProjectAlertDefinitionSimulate(ctx)
+	}
+	return w.PostProjectAlertDefinitionResetDefaults(ctx)
+}
+
 // GetProjectAlertDefinitionRule converts echo context to params.
 func (w *ServerInterfaceWrapper) GetProjectAlertDefinitionRule(ctx echo.Context) error {
 	var err error
@@ -166,12 +435,103 @@ func (w *ServerInterfaceWrapper) GetProjectAlertDefinitionRule(ctx echo.Context)
 	return err
 }
 
+// GetProjectEmailTemplate converts echo context to params.
+func (w *ServerInterfaceWrapper) GetProjectEmailTemplate(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetProjectEmailTemplateParams
+	// ------------- Optional query parameter "version" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "version", ctx.QueryParams(), &params.Version)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter version: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetProjectEmailTemplate(ctx, params)
+	return err
+}
+
+// PutProjectEmailTemplate converts echo context to params.
+func (w *ServerInterfaceWrapper) PutProjectEmailTemplate(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PutProjectEmailTemplate(ctx)
+	return err
+}
+
+// PostProjectExternalAlerts converts echo context to params.
+func (w *ServerInterfaceWrapper) PostProjectExternalAlerts(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostProjectExternalAlerts(ctx)
+	return err
+}
+
+// PutProjectMaintenanceMode converts echo context to params.
+func (w *ServerInterfaceWrapper) PutProjectMaintenanceMode(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PutProjectMaintenanceMode(ctx)
+	return err
+}
+
 // GetProjectAlertReceivers converts echo context to params.
 func (w *ServerInterfaceWrapper) GetProjectAlertReceivers(ctx echo.Context) error {
 	var err error
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetProjectAlertReceiversParams
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", ctx.QueryParams(), &params.Offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offset: %s", err))
+	}
+
+	// ------------- Optional query parameter "sortBy" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sortBy", ctx.QueryParams(), &params.SortBy)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sortBy: %s", err))
+	}
+
+	// ------------- Optional query parameter "order" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "order", ctx.QueryParams(), &params.Order)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter order: %s", err))
+	}
+
+	// ------------- Optional query parameter "fields" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "fields", ctx.QueryParams(), &params.Fields)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter fields: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetProjectAlertReceivers(ctx, params)
+	return err
+}
+
+// GetProjectAlertReceiverLanguages converts echo context to params.
+func (w *ServerInterfaceWrapper) GetProjectAlertReceiverLanguages(ctx echo.Context) error {
+	var err error
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetProjectAlertReceivers(ctx)
+	err = w.Handler.GetProjectAlertReceiverLanguages(ctx)
 	return err
 }
 
@@ -186,8 +546,17 @@ func (w *ServerInterfaceWrapper) GetProjectAlertReceiver(ctx echo.Context) error
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter receiverID: %s", err))
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetProjectAlertReceiverParams
+	// ------------- Optional query parameter "fields" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "fields", ctx.QueryParams(), &params.Fields)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter fields: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetProjectAlertReceiver(ctx, receiverID)
+	err = w.Handler.GetProjectAlertReceiver(ctx, receiverID, params)
 	return err
 }
 
@@ -202,8 +571,238 @@ func (w *ServerInterfaceWrapper) PatchProjectAlertReceiver(ctx echo.Context) err
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter receiverID: %s", err))
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PatchProjectAlertReceiverParams
+	// ------------- Optional query parameter "preview" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "preview", ctx.QueryParams(), &params.Preview)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter preview: %s", err))
+	}
+
+	// ------------- Optional query parameter "async" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "async", ctx.QueryParams(), &params.Async)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter async: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PatchProjectAlertReceiver(ctx, receiverID, params)
+	return err
+}
+
+// GetProjectAlertReceiverPreview converts echo context to params.
+func (w *ServerInterfaceWrapper) GetProjectAlertReceiverPreview(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "receiverID" -------------
+	var receiverID ReceiverId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "receiverID", ctx.Param("receiverID"), &receiverID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter receiverID: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetProjectAlertReceiverPreview(ctx, receiverID)
+	return err
+}
+
+// GetProjectAlertReceiverAllowedRecipients converts echo context to params.
+func (w *ServerInterfaceWrapper) GetProjectAlertReceiverAllowedRecipients(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "receiverID" -------------
+	var receiverID ReceiverId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "receiverID", ctx.Param("receiverID"), &receiverID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter receiverID: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetProjectAlertReceiverAllowedRecipientsParams
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", ctx.QueryParams(), &params.Offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offset: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetProjectAlertReceiverAllowedRecipients(ctx, receiverID, params)
+	return err
+}
+
+// PostProjectAlertReceiversSyncRecipients converts echo context to params.
+func (w *ServerInterfaceWrapper) PostProjectAlertReceiversSyncRecipients(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostProjectAlertReceiversSyncRecipients(ctx)
+	return err
+}
+
+// GetProjectAlert converts echo context to params.
+func (w *ServerInterfaceWrapper) GetProjectAlert(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "alertFingerprint" -------------
+	var alertFingerprint AlertFingerprint
+
+	err = runtime.BindStyledParameterWithOptions("simple", "alertFingerprint", ctx.Param("alertFingerprint"), &alertFingerprint, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter alertFingerprint: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetProjectAlert(ctx, alertFingerprint)
+	return err
+}
+
+// PostProjectAlertComment converts echo context to params.
+func (w *ServerInterfaceWrapper) PostProjectAlertComment(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "alertFingerprint" -------------
+	var alertFingerprint AlertFingerprint
+
+	err = runtime.BindStyledParameterWithOptions("simple", "alertFingerprint", ctx.Param("alertFingerprint"), &alertFingerprint, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter alertFingerprint: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostProjectAlertComment(ctx, alertFingerprint)
+	return err
+}
+
+// PostProjectConfigDiff converts echo context to params.
+func (w *ServerInterfaceWrapper) PostProjectConfigDiff(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostProjectConfigDiff(ctx)
+	return err
+}
+
+// GetProjectEventHooks converts echo context to params.
+func (w *ServerInterfaceWrapper) GetProjectEventHooks(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetProjectEventHooks(ctx)
+	return err
+}
+
+// PostProjectEventHook converts echo context to params.
+func (w *ServerInterfaceWrapper) PostProjectEventHook(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostProjectEventHook(ctx)
+	return err
+}
+
+// DeleteProjectEventHook converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteProjectEventHook(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "hookID" -------------
+	var hookID HookId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "hookID", ctx.Param("hookID"), &hookID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter hookID: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteProjectEventHook(ctx, hookID)
+	return err
+}
+
+// GetProjectEventHook converts echo context to params.
+func (w *ServerInterfaceWrapper) GetProjectEventHook(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "hookID" -------------
+	var hookID HookId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "hookID", ctx.Param("hookID"), &hookID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter hookID: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetProjectEventHook(ctx, hookID)
+	return err
+}
+
+// PatchProjectEventHook converts echo context to params.
+func (w *ServerInterfaceWrapper) PatchProjectEventHook(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "hookID" -------------
+	var hookID HookId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "hookID", ctx.Param("hookID"), &hookID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter hookID: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PatchProjectEventHook(ctx, hookID)
+	return err
+}
+
+// GetProjectOperation converts echo context to params.
+func (w *ServerInterfaceWrapper) GetProjectOperation(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "operationID" -------------
+	var operationID OperationId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "operationID", ctx.Param("operationID"), &operationID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter operationID: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetProjectOperation(ctx, operationID)
+	return err
+}
+
+// GetProjectReports converts echo context to params.
+func (w *ServerInterfaceWrapper) GetProjectReports(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetProjectReports(ctx)
+	return err
+}
+
+// GetProjectReport converts echo context to params.
+func (w *ServerInterfaceWrapper) GetProjectReport(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "reportID" -------------
+	var reportID ReportId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "reportID", ctx.Param("reportID"), &reportID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter reportID: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetProjectReportParams
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", ctx.QueryParams(), &params.Format)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter format: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.PatchProjectAlertReceiver(ctx, receiverID)
+	err = w.Handler.GetProjectReport(ctx, reportID, params)
 	return err
 }
 
@@ -216,6 +815,24 @@ func (w *ServerInterfaceWrapper) GetServiceStatus(ctx echo.Context) error {
 	return err
 }
 
+// VerifyEmailRecipient converts echo context to params.
+func (w *ServerInterfaceWrapper) VerifyEmailRecipient(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params VerifyEmailRecipientParams
+	// ------------- Required query parameter "token" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "token", ctx.QueryParams(), &params.Token)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter token: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.VerifyEmailRecipient(ctx, params)
+	return err
+}
+
 // This is a simple interface which specifies echo.Route addition functions which
 // are present on both echo.Echo and echo.Group, since we want to allow using
 // either of them for path registration
@@ -245,13 +862,38 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 	}
 
 	router.GET(baseURL+"/api/v1/alerts", wrapper.GetProjectAlerts)
+	router.GET(baseURL+"/api/v1/alerts/by-resource", wrapper.GetProjectAlertsByResource)
 	router.GET(baseURL+"/api/v1/alerts/definitions", wrapper.GetProjectAlertDefinitions)
+	router.GET(baseURL+"/api/v1/alerts/definitions/recommendations", wrapper.GetProjectAlertDefinitionRecommendations)
+	router.GET(baseURL+"/api/v1/alerts/definitions/template-functions", wrapper.GetProjectAlertDefinitionTemplateFunctions)
 	router.GET(baseURL+"/api/v1/alerts/definitions/:alertDefinitionID", wrapper.GetProjectAlertDefinition)
 	router.PATCH(baseURL+"/api/v1/alerts/definitions/:alertDefinitionID", wrapper.PatchProjectAlertDefinition)
+	router.POST(baseURL+"/api/v1/alerts/definitions/:alertDefinitionID", wrapper.postProjectAlertDefinitionCustomMethod)
 	router.GET(baseURL+"/api/v1/alerts/definitions/:alertDefinitionID/template", wrapper.GetProjectAlertDefinitionRule)
+	router.GET(baseURL+"/api/v1/alerts/email-template", wrapper.GetProjectEmailTemplate)
+	router.PUT(baseURL+"/api/v1/alerts/email-template", wrapper.PutProjectEmailTemplate)
+	router.POST(baseURL+"/api/v1/alerts/external", wrapper.PostProjectExternalAlerts)
+	router.PUT(baseURL+"/api/v1/alerts/maintenance-mode", wrapper.PutProjectMaintenanceMode)
 	router.GET(baseURL+"/api/v1/alerts/receivers", wrapper.GetProjectAlertReceivers)
+	router.GET(baseURL+"/api/v1/alerts/receivers/languages", wrapper.GetProjectAlertReceiverLanguages)
 	router.GET(baseURL+"/api/v1/alerts/receivers/:receiverID", wrapper.GetProjectAlertReceiver)
 	router.PATCH(baseURL+"/api/v1/alerts/receivers/:receiverID", wrapper.PatchProjectAlertReceiver)
+	router.GET(baseURL+"/api/v1/alerts/receivers/:receiverID/preview", wrapper.GetProjectAlertReceiverPreview)
+	router.GET(baseURL+"/api/v1/alerts/receivers/:receiverID/allowed-recipients", wrapper.GetProjectAlertReceiverAllowedRecipients)
+	// The colon of the custom method is escaped, as the router would otherwise take it for the start of a path parameter.
+	router.POST(baseURL+"/api/v1/alerts/receivers\\:syncRecipients", wrapper.PostProjectAlertReceiversSyncRecipients)
+	router.GET(baseURL+"/api/v1/alerts/:alertFingerprint", wrapper.GetProjectAlert)
+	router.POST(baseURL+"/api/v1/alerts/:alertFingerprint/comments", wrapper.PostProjectAlertComment)
+	router.POST(baseURL+"/api/v1/diff", wrapper.PostProjectConfigDiff)
+	router.GET(baseURL+"/api/v1/hooks", wrapper.GetProjectEventHooks)
+	router.POST(baseURL+"/api/v1/hooks", wrapper.PostProjectEventHook)
+	router.DELETE(baseURL+"/api/v1/hooks/:hookID", wrapper.DeleteProjectEventHook)
+	router.GET(baseURL+"/api/v1/hooks/:hookID", wrapper.GetProjectEventHook)
+	router.PATCH(baseURL+"/api/v1/hooks/:hookID", wrapper.PatchProjectEventHook)
+	router.GET(baseURL+"/api/v1/operations/:operationID", wrapper.GetProjectOperation)
+	router.GET(baseURL+"/api/v1/reports", wrapper.GetProjectReports)
+	router.GET(baseURL+"/api/v1/reports/:reportID", wrapper.GetProjectReport)
 	router.GET(baseURL+"/api/v1/status", wrapper.GetServiceStatus)
+	router.GET(baseURL+"/api/v1/verify", wrapper.VerifyEmailRecipient)
 
 }
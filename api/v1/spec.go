@@ -0,0 +1,26 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package api //nolint:revive // Keep name as autogenerated
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// openAPISpec is the OpenAPI document the types and server of the API are generated from.
+//
+//go:embed openapi.yaml
+var openAPISpec []byte
+
+// GetOpenAPISpecJSON returns the OpenAPI document of the API, converted to JSON.
+func GetOpenAPISpecJSON() ([]byte, error) {
+	var spec any
+	if err := yaml.Unmarshal(openAPISpec, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI document: %w", err)
+	}
+	return json.Marshal(spec)
+}
@@ -9,6 +9,12 @@ import (
 	openapiTypes "github.com/oapi-codegen/runtime/types"
 )
 
+// Defines values for AlertDefinitionAdjustmentParameter.
+const (
+	AdjustDuration  AlertDefinitionAdjustmentParameter = "duration"
+	AdjustThreshold AlertDefinitionAdjustmentParameter = "threshold"
+)
+
 // Defines values for AlertStatusState.
 const (
 	Active     AlertStatusState = "active"
@@ -16,10 +22,144 @@ const (
 	Suppressed AlertStatusState = "suppressed"
 )
 
+// Defines values for AlertWarningReason.
+const (
+	InvalidSchema AlertWarningReason = "invalid_schema"
+	MalformedUuid AlertWarningReason = "malformed_uuid"
+)
+
+// Defines values for ConfigChange.
+const (
+	ConfigChangeModified  ConfigChange = "modified"
+	ConfigChangeNotFound  ConfigChange = "notFound"
+	ConfigChangeUnchanged ConfigChange = "unchanged"
+)
+
+// Defines values for ErrorCode.
+const (
+	ErrorCodeAlertNotFound               ErrorCode = "ALERT_NOT_FOUND"
+	ErrorCodeAlertmanagerUnavailable     ErrorCode = "ALERTMANAGER_UNAVAILABLE"
+	ErrorCodeArtifactNotFound            ErrorCode = "ARTIFACT_NOT_FOUND"
+	ErrorCodeDeadLetterNotFound          ErrorCode = "DEAD_LETTER_NOT_FOUND"
+	ErrorCodeDefinitionNotFound          ErrorCode = "DEFINITION_NOT_FOUND"
+	ErrorCodeDefinitionTooExpensive      ErrorCode = "DEFINITION_TOO_EXPENSIVE"
+	ErrorCodeDefinitionValueOutOfBounds  ErrorCode = "DEFINITION_VALUE_OUT_OF_BOUNDS"
+	ErrorCodeEmailOverrideNotAllowed     ErrorCode = "EMAIL_OVERRIDE_NOT_ALLOWED"
+	ErrorCodeEmailRelayFailed            ErrorCode = "EMAIL_RELAY_FAILED"
+	ErrorCodeEmailTemplateLimitExceeded  ErrorCode = "EMAIL_TEMPLATE_LIMIT_EXCEEDED"
+	ErrorCodeEmailTemplateNotFound       ErrorCode = "EMAIL_TEMPLATE_NOT_FOUND"
+	ErrorCodeEventHookLimitExceeded      ErrorCode = "EVENT_HOOK_LIMIT_EXCEEDED"
+	ErrorCodeEventHookNotFound           ErrorCode = "EVENT_HOOK_NOT_FOUND"
+	ErrorCodeExternalAlertsNotAllowed    ErrorCode = "EXTERNAL_ALERTS_NOT_ALLOWED"
+	ErrorCodeInternalError               ErrorCode = "INTERNAL_ERROR"
+	ErrorCodeInvalidParameter            ErrorCode = "INVALID_PARAMETER"
+	ErrorCodeInvalidRequestBody          ErrorCode = "INVALID_REQUEST_BODY"
+	ErrorCodeOnCallRelayFailed           ErrorCode = "ONCALL_RELAY_FAILED"
+	ErrorCodeOperationNotFound           ErrorCode = "OPERATION_NOT_FOUND"
+	ErrorCodeProjectIDMissing            ErrorCode = "PROJECT_ID_MISSING"
+	ErrorCodeRateLimited                 ErrorCode = "RATE_LIMITED"
+	ErrorCodeReceiverConfigLimitExceeded ErrorCode = "RECEIVER_CONFIG_LIMIT_EXCEEDED"
+	ErrorCodeReceiverNotFound            ErrorCode = "RECEIVER_NOT_FOUND"
+	ErrorCodeReceiverRouteConflict       ErrorCode = "RECEIVER_ROUTE_CONFLICT"
+	ErrorCodeReceiverTierLimitExceeded   ErrorCode = "RECEIVER_TIER_LIMIT_EXCEEDED"
+	ErrorCodeRecipientNotAllowed         ErrorCode = "RECIPIENT_NOT_ALLOWED"
+	ErrorCodeReportNotFound              ErrorCode = "REPORT_NOT_FOUND"
+	ErrorCodeSilenceNotFound             ErrorCode = "SILENCE_NOT_FOUND"
+	ErrorCodeUnauthorized                ErrorCode = "UNAUTHORIZED"
+	ErrorCodeUnsupportedAPIVersion       ErrorCode = "UNSUPPORTED_API_VERSION"
+	ErrorCodeWebhookSourceNotAllowed     ErrorCode = "WEBHOOK_SOURCE_NOT_ALLOWED"
+)
+
+// Defines values for EventHookEvent.
+const (
+	EventHookEventDefinitionApplied EventHookEvent = "definition.applied"
+	EventHookEventDefinitionError   EventHookEvent = "definition.error"
+	EventHookEventReceiverApplied   EventHookEvent = "receiver.applied"
+	EventHookEventReceiverError     EventHookEvent = "receiver.error"
+)
+
+// Defines values for EventHookPayloadState.
+const (
+	EventHookPayloadStateApplied EventHookPayloadState = "Applied"
+	EventHookPayloadStateError   EventHookPayloadState = "Error"
+)
+
+// Defines values for ExportFormatQueryParam.
+const (
+	ExportFormatCSV  ExportFormatQueryParam = "csv"
+	ExportFormatJSON ExportFormatQueryParam = "json"
+)
+
+// Defines values for GroupByQueryParam.
+const (
+	Cluster    GroupByQueryParam = "cluster"
+	Deployment GroupByQueryParam = "deployment"
+	Host       GroupByQueryParam = "host"
+)
+
+// Defines values for OperationState.
+const (
+	OperationFailed     OperationState = "failed"
+	OperationPending    OperationState = "pending"
+	OperationRunning    OperationState = "running"
+	OperationSucceeded  OperationState = "succeeded"
+	OperationSuperseded OperationState = "superseded"
+)
+
+// Defines values for OperationType.
+const (
+	OperationTypeAlertDefinition OperationType = "alertDefinition"
+	OperationTypeReceiver        OperationType = "receiver"
+)
+
+// Defines values for OrderQueryParam.
+const (
+	Asc  OrderQueryParam = "asc"
+	Desc OrderQueryParam = "desc"
+)
+
+// Defines values for ReceiverSeverity.
+const (
+	Critical ReceiverSeverity = "critical"
+	Info     ReceiverSeverity = "info"
+	None     ReceiverSeverity = "none"
+	Warning  ReceiverSeverity = "warning"
+)
+
+// Defines values for ReceiverSyncResultStatus.
+const (
+	ReceiverSyncFailed    ReceiverSyncResultStatus = "failed"
+	ReceiverSyncUnchanged ReceiverSyncResultStatus = "unchanged"
+	ReceiverSyncUpdated   ReceiverSyncResultStatus = "updated"
+)
+
+// Defines values for RecipientSyncMode.
+const (
+	RecipientSyncModeAdd     RecipientSyncMode = "add"
+	RecipientSyncModeRemove  RecipientSyncMode = "remove"
+	RecipientSyncModeReplace RecipientSyncMode = "replace"
+)
+
+// Defines values for ReportFormatQueryParam.
+const (
+	ReportFormatCSV  ReportFormatQueryParam = "csv"
+	ReportFormatHTML ReportFormatQueryParam = "html"
+	ReportFormatJSON ReportFormatQueryParam = "json"
+)
+
 // Defines values for ServiceStatusState.
 const (
-	Failed ServiceStatusState = "failed"
-	Ready  ServiceStatusState = "ready"
+	Degraded ServiceStatusState = "degraded"
+	Failed   ServiceStatusState = "failed"
+	Ready    ServiceStatusState = "ready"
+)
+
+// Defines values for SortByQueryParam.
+const (
+	Name      SortByQueryParam = "name"
+	Severity  SortByQueryParam = "severity"
+	State     SortByQueryParam = "state"
+	UpdatedAt SortByQueryParam = "updatedAt"
 )
 
 // Defines values for StateDefinition.
@@ -48,32 +188,254 @@ type Alert struct {
 // AlertStatusState defines model for Alert.Status.State.
 type AlertStatusState string
 
+// AlertComment defines model for AlertComment.
+type AlertComment struct {
+	// Author Username of the user who added the comment, empty if requests are not authenticated
+	Author *string `json:"author,omitempty"`
+
+	// Content Text of the comment
+	Content string `json:"content"`
+
+	// CreatedAt Time the comment was added
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Id ID of the comment
+	Id int64 `json:"id"`
+
+	// ParentId ID of the comment of the alert this comment replies to
+	ParentId *int64 `json:"parentId,omitempty"`
+}
+
+// AlertCommentCreate defines model for AlertCommentCreate.
+type AlertCommentCreate struct {
+	// Content Text of the comment
+	Content string `json:"content"`
+
+	// ParentId ID of the comment of the alert the comment replies to
+	ParentId *int64 `json:"parentId,omitempty"`
+}
+
 // AlertDefinition defines model for AlertDefinition.
 type AlertDefinition struct {
-	Id      *openapiTypes.UUID `json:"id,omitempty"`
-	Name    *string            `json:"name,omitempty"`
-	State   *StateDefinition   `json:"state,omitempty"`
-	Values  *map[string]string `json:"values,omitempty"`
-	Version *int               `json:"version,omitempty"`
+	AppliedAt          *time.Time         `json:"appliedAt,omitempty"`
+	CreatedAt          *time.Time         `json:"createdAt,omitempty"`
+	DurationSeconds    *int64             `json:"durationSeconds,omitempty"`
+	Enabled            *bool              `json:"enabled,omitempty"`
+	EvaluationHealth   *EvaluationHealth  `json:"evaluationHealth,omitempty"`
+	FiringCount        *int               `json:"firingCount,omitempty"`
+	Id                 *openapiTypes.UUID `json:"id,omitempty"`
+	Name               *string            `json:"name,omitempty"`
+	State              *StateDefinition   `json:"state,omitempty"`
+	ThresholdAutoTuned *bool              `json:"thresholdAutoTuned,omitempty"`
+	ThresholdValue     *int64             `json:"thresholdValue,omitempty"`
+	UpdatedAt          *time.Time         `json:"updatedAt,omitempty"`
+	Values             *map[string]string `json:"values,omitempty"`
+	Version            *int               `json:"version,omitempty"`
+}
+
+// AlertDefinitionAdjustment defines model for AlertDefinitionAdjustment.
+type AlertDefinitionAdjustment struct {
+	// DurationSeconds Suggested duration of the alert definition, only given for adjustments of the duration
+	DurationSeconds *int64 `json:"durationSeconds,omitempty"`
+
+	// Parameter Value of the alert definition to adjust
+	Parameter AlertDefinitionAdjustmentParameter `json:"parameter"`
+
+	// Reason Why the adjustment is suggested
+	Reason string `json:"reason"`
+}
+
+// AlertDefinitionAdjustmentParameter Value of the alert definition to adjust
+type AlertDefinitionAdjustmentParameter string
+
+// AlertDefinitionEvaluation defines model for AlertDefinitionEvaluation.
+type AlertDefinitionEvaluation struct {
+	// EvaluatedAt Time the expression is evaluated at
+	EvaluatedAt time.Time `json:"evaluatedAt"`
+
+	// Expression Expression of the alert definition, rendered with its current threshold and duration
+	Expression string `json:"expression"`
+
+	// Firings Periods the alert definition would have fired over the last 24 hours with the proposed threshold, from the oldest one, taking its duration into account. Only reported if a threshold is proposed
+	Firings *[]PreviewedFiring `json:"firings,omitempty"`
+
+	// OperandResult Series of the operand the expression compares against the threshold, not reported if the expression does not compare a metric against an upper threshold
+	OperandResult *[]EvaluatedSample `json:"operandResult,omitempty"`
+
+	// Result Series the expression returns, which are the series crossing the threshold of an expression comparing against it
+	Result []EvaluatedSample `json:"result"`
+
+	// Threshold Threshold the expression is rendered with, which is the current threshold of the alert definition unless another one is proposed
+	Threshold int64 `json:"threshold"`
+}
+
+// AlertDefinitionEvaluationRequest defines model for AlertDefinitionEvaluationRequest.
+type AlertDefinitionEvaluationRequest struct {
+	// Threshold Threshold proposed for the alert definition, to render its expression with instead of its current threshold
+	Threshold *int64 `json:"threshold,omitempty"`
 }
 
 // AlertDefinitionList defines model for AlertDefinitionList.
 type AlertDefinitionList struct {
 	AlertDefinitions *[]AlertDefinition `json:"alertDefinitions,omitempty"`
+	TotalCount       int                `json:"totalCount"`
+}
+
+// AlertDefinitionRecommendation defines model for AlertDefinitionRecommendation.
+type AlertDefinitionRecommendation struct {
+	// Adjustments Adjustments suggested to reduce the noise of the alert definition
+	Adjustments       []AlertDefinitionAdjustment `json:"adjustments"`
+	AlertDefinitionId openapiTypes.UUID           `json:"alertDefinitionId"`
+
+	// Firings Number of times the alerts of the alert definition fired over the last 7 days
+	Firings int    `json:"firings"`
+	Name    string `json:"name"`
+
+	// Resolutions Number of times the alerts of the alert definition resolved over the last 7 days
+	Resolutions int `json:"resolutions"`
+
+	// Score Noise score of the alert definition, the number of times its alerts fired per day weighted up by their silence coverage, up to twice as much when always silenced
+	Score float64 `json:"score"`
+
+	// SilenceCoverage Fraction of the time the alerts of the alert definition fired during which they were silenced, from 0 to 1
+	SilenceCoverage float64 `json:"silenceCoverage"`
+}
+
+// AlertDefinitionRecommendationList defines model for AlertDefinitionRecommendationList.
+type AlertDefinitionRecommendationList struct {
+	Recommendations []AlertDefinitionRecommendation `json:"recommendations"`
+
+	// ScoredAt Time the alert definitions were scored at
+	ScoredAt time.Time `json:"scoredAt"`
 }
 
 // AlertDefinitionTemplate defines model for AlertDefinitionTemplate.
 type AlertDefinitionTemplate struct {
 	Alert       *string            `json:"alert,omitempty"`
 	Annotations *map[string]string `json:"annotations,omitempty"`
+	Deployed    *bool              `json:"deployed,omitempty"`
 	Expr        *string            `json:"expr,omitempty"`
 	For         *string            `json:"for,omitempty"`
 	Labels      *map[string]string `json:"labels,omitempty"`
 }
 
+// AlertDefinitionValues defines model for AlertDefinitionValues.
+type AlertDefinitionValues struct {
+	Values *map[string]string `json:"values,omitempty"`
+}
+
+// AlertDefinitionSimulation defines model for AlertDefinitionSimulation.
+type AlertDefinitionSimulation struct {
+	// Labels Labels of the series the simulated alert fires for, such as host_uuid, which the labels and annotations of the alert definition are expanded with. The alertname, threshold, duration, simulated and projectId labels are reserved
+	Labels *map[string]string `json:"labels,omitempty"`
+}
+
+// AlertDetail defines model for AlertDetail.
+type AlertDetail struct {
+	// Acknowledged Tells whether the alert is acknowledged, that is silenced by at least one silence
+	Acknowledged bool  `json:"acknowledged"`
+	Alert        Alert `json:"alert"`
+
+	// Comments Comments users attached to the alert, threaded by the comment they reply to
+	Comments []AlertComment `json:"comments"`
+
+	// Definition Latest version of the alert definition raising the alert, not reported for alerts not raised by an alert definition
+	Definition *AlertDefinition `json:"definition,omitempty"`
+
+	// Firings Periods the alert fired over the last 7 days, from the oldest one. Not reported if the firing history cannot be
+	// retrieved
+	Firings *[]AlertFiring `json:"firings,omitempty"`
+
+	// Silences Silences currently silencing the alert
+	Silences []AlertSilence `json:"silences"`
+}
+
+// AlertFiring defines model for AlertFiring.
+type AlertFiring struct {
+	// EndsAt End of the firing period, not reported if the alert is still firing
+	EndsAt   *time.Time `json:"endsAt,omitempty"`
+	StartsAt time.Time  `json:"startsAt"`
+}
+
 // AlertList defines model for AlertList.
 type AlertList struct {
-	Alerts *[]Alert `json:"alerts,omitempty"`
+	Alerts          *[]Alert         `json:"alerts,omitempty"`
+	MaintenanceMode *MaintenanceMode `json:"maintenanceMode,omitempty"`
+
+	// Warnings Malformed alerts left out of the list, reported so that a single mislabeled rule does not fail the whole list
+	Warnings *[]AlertWarning `json:"warnings,omitempty"`
+}
+
+// AlertResource defines model for AlertResource.
+type AlertResource struct {
+	AlertCount      int     `json:"alertCount"`
+	HighestSeverity *string `json:"highestSeverity,omitempty"`
+	Resource        string  `json:"resource"`
+}
+
+// AlertResourceList defines model for AlertResourceList.
+type AlertResourceList struct {
+	Resources []AlertResource `json:"resources"`
+}
+
+// AlertSilence defines model for AlertSilence.
+type AlertSilence struct {
+	Comment string `json:"comment"`
+
+	// CreatedBy Name of the creator of the silence
+	CreatedBy string    `json:"createdBy"`
+	EndsAt    time.Time `json:"endsAt"`
+
+	// Id ID of the silence in alertmanager
+	Id       string    `json:"id"`
+	StartsAt time.Time `json:"startsAt"`
+}
+
+// AlertWarning defines model for AlertWarning.
+type AlertWarning struct {
+	// Fingerprint Fingerprint of the malformed alert, not reported when it cannot be decoded
+	Fingerprint *string            `json:"fingerprint,omitempty"`
+	Message     string             `json:"message"`
+	Reason      AlertWarningReason `json:"reason"`
+}
+
+// AlertWarningReason defines model for AlertWarning.Reason.
+type AlertWarningReason string
+
+// AllowedRecipientList defines model for AllowedRecipientList.
+type AllowedRecipientList struct {
+	Recipients EmailRecipientList `json:"recipients"`
+	TotalCount int                `json:"totalCount"`
+}
+
+// ConfigBundle defines model for ConfigBundle.
+type ConfigBundle struct {
+	AlertDefinitions *[]AlertDefinition `json:"alertDefinitions,omitempty"`
+	Receivers        *[]Receiver        `json:"receivers,omitempty"`
+}
+
+// ConfigChange defines model for ConfigChange.
+type ConfigChange string
+
+// ConfigDiff defines model for ConfigDiff.
+type ConfigDiff struct {
+	AlertDefinitions []ConfigItemDiff `json:"alertDefinitions"`
+	Receivers        []ConfigItemDiff `json:"receivers"`
+}
+
+// ConfigFieldDiff defines model for ConfigFieldDiff.
+type ConfigFieldDiff struct {
+	Field    string       `json:"field"`
+	Live     *interface{} `json:"live,omitempty"`
+	Proposed *interface{} `json:"proposed,omitempty"`
+}
+
+// ConfigItemDiff defines model for ConfigItemDiff.
+type ConfigItemDiff struct {
+	Change ConfigChange       `json:"change"`
+	Fields *[]ConfigFieldDiff `json:"fields,omitempty"`
+	Id     openapiTypes.UUID  `json:"id"`
+	Name   *string            `json:"name,omitempty"`
 }
 
 // Email defines model for Email.
@@ -84,38 +446,406 @@ type EmailConfig struct {
 	From       *Email  `json:"from,omitempty"`
 	MailServer *string `json:"mailServer,omitempty"`
 	To         *struct {
-		Allowed *EmailRecipientList `json:"allowed,omitempty"`
-		Enabled *EmailRecipientList `json:"enabled,omitempty"`
+		Allowed    *EmailRecipientList `json:"allowed,omitempty"`
+		Enabled    *EmailRecipientList `json:"enabled,omitempty"`
+		Unverified *EmailRecipientList `json:"unverified,omitempty"`
 	} `json:"to,omitempty"`
 }
 
 // EmailConfigTo defines model for EmailConfigTo.
 type EmailConfigTo struct {
-	To struct {
+	From       *Email  `json:"from,omitempty"`
+	MailServer *string `json:"mailServer,omitempty"`
+	To         struct {
 		Enabled EmailRecipientList `json:"enabled"`
 	} `json:"to"`
 }
 
+// EmailLanguageList Languages of the localized email templates of the deployment
+type EmailLanguageList struct {
+	Languages []string `json:"languages"`
+}
+
 // EmailRecipientList defines model for EmailRecipientList.
 type EmailRecipientList = []Email
 
+// EmailTemplate defines model for EmailTemplate.
+type EmailTemplate struct {
+	// Content Template the HTML body of emails is rendered with, which may use the templates of the deployment such as alert.monitor.mail. Empty if the project uses the template of the deployment
+	Content string `json:"content"`
+
+	// CreatedAt Time the version of the email template was created
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+
+	// Version Version of the email template, 0 if the project uses the template of the deployment
+	Version int64 `json:"version"`
+}
+
+// EmailTemplateUpdate defines model for EmailTemplateUpdate.
+type EmailTemplateUpdate struct {
+	// Content Template the HTML body of emails is rendered with, an empty content restoring the template of the deployment
+	Content string `json:"content"`
+}
+
+// EmailVerification defines model for EmailVerification.
+type EmailVerification struct {
+	Email Email `json:"email"`
+}
+
+// ErrorCode Machine-readable code of the error, clients can branch on and localize errors by this code
+type ErrorCode string
+
+// ErrorDetail Detail of an error related to a field of the request
+type ErrorDetail struct {
+	// Field Path of the field of the request the detail relates to, e.g. values.threshold
+	Field string `json:"field"`
+
+	// Max Maximum allowed value of the field
+	Max *int64 `json:"max,omitempty"`
+
+	// Min Minimum allowed value of the field
+	Min *int64 `json:"min,omitempty"`
+
+	// Reason Human-readable reason of the error
+	Reason string `json:"reason"`
+
+	// Value Offending value of the field
+	Value *string `json:"value,omitempty"`
+}
+
+// EvaluatedSample defines model for EvaluatedSample.
+type EvaluatedSample struct {
+	// Labels Labels of the series
+	Labels map[string]string `json:"labels"`
+
+	// Value Value of the series at the time of the evaluation, formatted as by Mimir so that NaN and infinite values are kept
+	Value string `json:"value"`
+}
+
+// EvaluationHealth defines model for EvaluationHealth.
+type EvaluationHealth struct {
+	AverageDuration *float32   `json:"averageDuration,omitempty"`
+	Evaluations     *int       `json:"evaluations,omitempty"`
+	Failures        *int       `json:"failures,omitempty"`
+	LastError       *string    `json:"lastError,omitempty"`
+	LastEvaluatedAt *time.Time `json:"lastEvaluatedAt,omitempty"`
+	MaxDuration     *float32   `json:"maxDuration,omitempty"`
+	Slow            *bool      `json:"slow,omitempty"`
+}
+
+// EventHook defines model for EventHook.
+type EventHook struct {
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Events Events the hook is called on
+	Events []EventHookEvent  `json:"events"`
+	Id     openapiTypes.UUID `json:"id"`
+
+	// Secret Secret the calls of the hook are signed with, only returned when the hook is registered
+	Secret *string `json:"secret,omitempty"`
+
+	// Url Callback URL the events are posted to
+	Url string `json:"url"`
+}
+
+// EventHookCreate defines model for EventHookCreate.
+type EventHookCreate struct {
+	// Events Events the hook is called on, all of them if omitted or empty
+	Events *[]EventHookEvent `json:"events,omitempty"`
+
+	// Url Absolute http or https callback URL the events are posted to
+	Url string `json:"url"`
+}
+
+// EventHookEvent Event of the state transitions of alert definitions and receivers, as they are applied or fail to be applied
+type EventHookEvent string
+
+// EventHookList defines model for EventHookList.
+type EventHookList struct {
+	Hooks []EventHook `json:"hooks"`
+}
+
+// EventHookPayload Body of the calls of event hooks
+type EventHookPayload struct {
+	// Error Error of the failed attempt to apply the alert definition or receiver
+	Error *string `json:"error,omitempty"`
+
+	// Event Event of the state transitions of alert definitions and receivers, as they are applied or fail to be applied
+	Event EventHookEvent `json:"event"`
+
+	// Id ID of the alert definition or receiver
+	Id openapiTypes.UUID `json:"id"`
+
+	// Name Name of the alert definition or receiver
+	Name      string `json:"name"`
+	ProjectId string `json:"projectId"`
+
+	// State State the alert definition or receiver transitioned to
+	State EventHookPayloadState `json:"state"`
+
+	// Timestamp Time of the transition
+	Timestamp time.Time `json:"timestamp"`
+
+	// Version Version of the alert definition or receiver which transitioned
+	Version int64 `json:"version"`
+}
+
+// EventHookPayloadState State the alert definition or receiver transitioned to
+type EventHookPayloadState string
+
+// EventHookUpdate defines model for EventHookUpdate.
+type EventHookUpdate struct {
+	// Events Events the hook is called on, an empty list subscribing to all of them
+	Events *[]EventHookEvent `json:"events,omitempty"`
+
+	// Url Absolute http or https callback URL the events are posted to
+	Url *string `json:"url,omitempty"`
+}
+
+// ExternalAlert defines model for ExternalAlert.
+type ExternalAlert struct {
+	// Annotations Annotations of the alert, annotations prefixed with am_ are reserved
+	Annotations *map[string]string `json:"annotations,omitempty"`
+
+	// EndsAt Time the alert is resolved, if omitted it is resolved once it is no longer pushed
+	EndsAt *time.Time `json:"endsAt,omitempty"`
+
+	// Labels Labels of the alert, in addition to the alertname, severity, alert_category and projectId labels set by the service
+	Labels *map[string]string `json:"labels,omitempty"`
+
+	// Name Name of the alert, given by its alertname label
+	Name string `json:"name"`
+
+	// Severity Severity of the alert: info, warning or critical
+	Severity string `json:"severity"`
+
+	// StartsAt Time the alert started firing, the time it is pushed if omitted
+	StartsAt *time.Time `json:"startsAt,omitempty"`
+}
+
+// ExternalAlertList defines model for ExternalAlertList.
+type ExternalAlertList struct {
+	Alerts []ExternalAlert `json:"alerts"`
+}
+
 // HttpError defines model for HttpError.
 type HttpError struct {
-	Code    int    `json:"code"`
+	Code    int            `json:"code"`
+	Details *[]ErrorDetail `json:"details,omitempty"`
+
+	// ErrorCode Machine-readable code of the error, clients can branch on and localize errors by this code
+	ErrorCode ErrorCode `json:"errorCode"`
+
+	// Message Human-readable description of the error, not meant to be matched by clients
 	Message string `json:"message"`
 }
 
+// MaintenanceMode defines model for MaintenanceMode.
+type MaintenanceMode struct {
+	// Enabled Whether the project is in maintenance mode, its alerts being silenced and notifications paused
+	Enabled bool `json:"enabled"`
+
+	// EndsAt Time the maintenance mode ends
+	EndsAt *time.Time `json:"endsAt,omitempty"`
+
+	// StartsAt Time the maintenance mode started
+	StartsAt *time.Time `json:"startsAt,omitempty"`
+}
+
+// MaintenanceModeUpdate defines model for MaintenanceModeUpdate.
+type MaintenanceModeUpdate struct {
+	// Comment Comment of the silence of the alerts of the project during the maintenance mode
+	Comment *string `json:"comment,omitempty"`
+
+	// Duration Duration of the maintenance mode, e.g. 4h, required when enabled
+	Duration *string `json:"duration,omitempty"`
+
+	// Enabled Whether the project is put in or taken out of maintenance mode
+	Enabled bool `json:"enabled"`
+}
+
+// NoisyAlert defines model for NoisyAlert.
+type NoisyAlert struct {
+	// Group Labels shared by the alerts of the group
+	Group string `json:"group"`
+
+	// Notifications Number of notification emails sent to recipients for the group
+	Notifications int64 `json:"notifications"`
+}
+
+// OnCallConfig defines model for OnCallConfig.
+type OnCallConfig struct {
+	RoutingKey *string `json:"routingKey,omitempty"`
+}
+
+// Operation defines model for Operation.
+type Operation struct {
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+
+	// Done Whether the operation reached its final state
+	Done bool `json:"done"`
+
+	// Error Error of the last failed attempt to apply the update
+	Error *string           `json:"error,omitempty"`
+	Id    openapiTypes.UUID `json:"id"`
+
+	// RetryCount Number of failed attempts to apply the update
+	RetryCount int64 `json:"retryCount"`
+
+	// StartedAt Time the last attempt to apply the update started
+	StartedAt *time.Time     `json:"startedAt,omitempty"`
+	State     OperationState `json:"state"`
+
+	// TargetId ID of the alert definition or receiver the operation updates
+	TargetId openapiTypes.UUID `json:"targetId"`
+	Type     OperationType     `json:"type"`
+
+	// Version Version of the alert definition or receiver the operation applies
+	Version int64 `json:"version"`
+}
+
+// OperationType defines model for Operation.Type.
+type OperationType string
+
+// OperationAccepted defines model for OperationAccepted.
+type OperationAccepted struct {
+	// OperationId ID of the operation the update is awaited by
+	OperationId openapiTypes.UUID      `json:"operationId"`
+	Preview     *ReceiverConfigPreview `json:"preview,omitempty"`
+}
+
+// OperationState defines model for OperationState.
+type OperationState string
+
+// PreviewedFiring defines model for PreviewedFiring.
+type PreviewedFiring struct {
+	// EndsAt End of the firing period, not reported if the alert definition would still be firing
+	EndsAt *time.Time `json:"endsAt,omitempty"`
+
+	// Labels Labels of the series the alert definition would have fired for
+	Labels map[string]string `json:"labels"`
+
+	// StartsAt Time the alert definition would have started firing, once the expression held for its duration
+	StartsAt time.Time `json:"startsAt"`
+}
+
+// QuietHours defines model for QuietHours.
+type QuietHours struct {
+	Enabled  bool    `json:"enabled"`
+	End      *string `json:"end,omitempty"`
+	Location *string `json:"location,omitempty"`
+	Start    *string `json:"start,omitempty"`
+}
+
 // Receiver defines model for Receiver.
 type Receiver struct {
+	AppliedAt   *time.Time         `json:"appliedAt,omitempty"`
+	CreatedAt   *time.Time         `json:"createdAt,omitempty"`
 	EmailConfig *EmailConfig       `json:"emailConfig,omitempty"`
+	Enabled     *bool              `json:"enabled,omitempty"`
 	Id          *openapiTypes.UUID `json:"id,omitempty"`
+	Language    *string            `json:"language,omitempty"`
+	Matchers    *ReceiverMatchers  `json:"matchers,omitempty"`
+	MinSeverity *ReceiverSeverity  `json:"minSeverity,omitempty"`
+	OnCall      *OnCallConfig      `json:"onCall,omitempty"`
+	QuietHours  *QuietHours        `json:"quietHours,omitempty"`
 	State       *StateDefinition   `json:"state,omitempty"`
+	UpdatedAt   *time.Time         `json:"updatedAt,omitempty"`
 	Version     *int               `json:"version,omitempty"`
 }
 
+// ReceiverConfigPreview defines model for ReceiverConfigPreview.
+type ReceiverConfigPreview struct {
+	// Receiver Receiver block with its email_configs and webhook_configs, in YAML
+	Receiver string `json:"receiver"`
+
+	// Route Route block matching the alerts notified through the receiver, in YAML, empty if the receiver is disabled
+	Route string `json:"route"`
+}
+
 // ReceiverList defines model for ReceiverList.
 type ReceiverList struct {
-	Receivers *[]Receiver `json:"receivers,omitempty"`
+	Receivers  *[]Receiver `json:"receivers,omitempty"`
+	TotalCount int         `json:"totalCount"`
+}
+
+// ReceiverMatchers defines model for ReceiverMatchers.
+type ReceiverMatchers = []string
+
+// ReceiverSeverity defines model for ReceiverSeverity.
+type ReceiverSeverity string
+
+// ReceiverSyncResult defines model for ReceiverSyncResult.
+type ReceiverSyncResult struct {
+	// ErrorCode Machine-readable code of the error, clients can branch on and localize errors by this code
+	ErrorCode *ErrorCode        `json:"errorCode,omitempty"`
+	Id        openapiTypes.UUID `json:"id"`
+
+	// Message Human-readable description of the failure
+	Message *string                  `json:"message,omitempty"`
+	Name    string                   `json:"name"`
+	Status  ReceiverSyncResultStatus `json:"status"`
+}
+
+// ReceiverSyncResultStatus defines model for ReceiverSyncResult.Status.
+type ReceiverSyncResultStatus string
+
+// RecipientSync defines model for RecipientSync.
+type RecipientSync struct {
+	Mode       *RecipientSyncMode `json:"mode,omitempty"`
+	Recipients EmailRecipientList `json:"recipients"`
+}
+
+// RecipientSyncMode defines model for RecipientSync.Mode.
+type RecipientSyncMode string
+
+// RecipientSyncResult defines model for RecipientSyncResult.
+type RecipientSyncResult struct {
+	Receivers []ReceiverSyncResult `json:"receivers"`
+}
+
+// Report defines model for Report.
+type Report struct {
+	// AcknowledgedAlerts Number of alerts acknowledged, an alert being acknowledged by its first comment
+	AcknowledgedAlerts int64     `json:"acknowledgedAlerts"`
+	CreatedAt          time.Time `json:"createdAt"`
+
+	// FailedNotifications Number of notification emails which could not be sent to recipients
+	FailedNotifications int64 `json:"failedNotifications"`
+	Id                  int64 `json:"id"`
+
+	// MeanTimeToAcknowledge Mean number of seconds between alerts started firing and their acknowledgement, omitted if no alert was acknowledged
+	MeanTimeToAcknowledge *float64 `json:"meanTimeToAcknowledge,omitempty"`
+
+	// PeriodEnd End of the week the report covers, exclusive
+	PeriodEnd time.Time `json:"periodEnd"`
+
+	// PeriodStart Start of the week the report covers
+	PeriodStart time.Time `json:"periodStart"`
+
+	// SentNotifications Number of notification emails sent to recipients
+	SentNotifications int64 `json:"sentNotifications"`
+
+	// TopAlerts Alert groups notified the most, the noisiest first
+	TopAlerts []NoisyAlert `json:"topAlerts"`
+}
+
+// ReportList defines model for ReportList.
+type ReportList struct {
+	Reports []ReportSummary `json:"reports"`
+}
+
+// ReportSummary defines model for ReportSummary.
+type ReportSummary struct {
+	CreatedAt time.Time `json:"createdAt"`
+	Id        int64     `json:"id"`
+
+	// PeriodEnd End of the week the report covers, exclusive
+	PeriodEnd time.Time `json:"periodEnd"`
+
+	// PeriodStart Start of the week the report covers
+	PeriodStart time.Time `json:"periodStart"`
 }
 
 // ServiceStatus defines model for ServiceStatus.
@@ -126,39 +856,125 @@ type ServiceStatus struct {
 // ServiceStatusState defines model for ServiceStatus.State.
 type ServiceStatusState string
 
+// SimulatedAlert defines model for SimulatedAlert.
+type SimulatedAlert struct {
+	// Annotations Annotations of the simulated alert, which link it to its alert definition by am_uuid
+	Annotations map[string]string `json:"annotations"`
+
+	// EndsAt Time the simulated alert is resolved
+	EndsAt time.Time `json:"endsAt"`
+
+	// Labels Labels of the simulated alert, which include the simulated label
+	Labels map[string]string `json:"labels"`
+
+	// StartsAt Time the simulated alert started firing
+	StartsAt time.Time `json:"startsAt"`
+}
+
 // StateDefinition defines model for StateDefinition.
 type StateDefinition string
 
+// TemplateFunction defines model for TemplateFunction.
+type TemplateFunction struct {
+	Description string `json:"description"`
+
+	// Example Expression template using the function
+	Example string `json:"example"`
+	Name    string `json:"name"`
+
+	// Signature Usage of the function in templates, with its arguments
+	Signature string `json:"signature"`
+}
+
+// TemplateFunctionList defines model for TemplateFunctionList.
+type TemplateFunctionList struct {
+	TemplateFunctions []TemplateFunction `json:"templateFunctions"`
+}
+
 // ActiveAlertsQueryFilter defines model for activeAlertsQueryFilter.
 type ActiveAlertsQueryFilter = bool
 
 // AlertDefinitionId defines model for alertDefinitionId.
 type AlertDefinitionId = openapiTypes.UUID
 
+// AlertFingerprint defines model for alertFingerprint.
+type AlertFingerprint = string
+
 // AlertsQueryFilter defines model for alertsQueryFilter.
 type AlertsQueryFilter = string
 
 // AppQueryFilter defines model for appQueryFilter.
 type AppQueryFilter = string
 
+// AsyncQueryParam defines model for asyncQueryParam.
+type AsyncQueryParam = bool
+
 // ClusterQueryFilter defines model for clusterQueryFilter.
 type ClusterQueryFilter = string
 
+// EmailTemplateVersionQueryParam defines model for emailTemplateVersionQueryParam.
+type EmailTemplateVersionQueryParam = int64
+
+// ExportFormatQueryParam defines model for exportFormatQueryParam.
+type ExportFormatQueryParam string
+
+// FieldsQueryParam defines model for fieldsQueryParam.
+type FieldsQueryParam = []string
+
+// GroupByQueryParam defines model for groupByQueryParam.
+type GroupByQueryParam string
+
+// HookId defines model for hookId.
+type HookId = openapiTypes.UUID
+
 // HostQueryFilter defines model for hostQueryFilter.
 type HostQueryFilter = string
 
+// LimitQueryParam defines model for limitQueryParam.
+type LimitQueryParam = int
+
+// OffsetQueryParam defines model for offsetQueryParam.
+type OffsetQueryParam = int
+
+// OperationId defines model for operationId.
+type OperationId = openapiTypes.UUID
+
+// OrderQueryParam defines model for orderQueryParam.
+type OrderQueryParam string
+
+// PreviewQueryParam defines model for previewQueryParam.
+type PreviewQueryParam = bool
+
 // ReceiverId defines model for receiverId.
 type ReceiverId = openapiTypes.UUID
 
 // RenderedTemplateQueryParam defines model for renderedTemplateQueryParam.
 type RenderedTemplateQueryParam = bool
 
+// ReportFormatQueryParam defines model for reportFormatQueryParam.
+type ReportFormatQueryParam string
+
+// ReportId defines model for reportId.
+type ReportId = int64
+
+// SortByQueryParam defines model for sortByQueryParam.
+type SortByQueryParam string
+
 // SuppressedAlertsQueryFilter defines model for suppressedAlertsQueryFilter.
 type SuppressedAlertsQueryFilter = bool
 
+// VerificationTokenQueryParam defines model for verificationTokenQueryParam.
+type VerificationTokenQueryParam = string
+
+// WithStatusQueryParam defines model for withStatusQueryParam.
+type WithStatusQueryParam = bool
+
 // N400 defines model for 400.
 type N400 = HttpError
 
+// N403 defines model for 403.
+type N403 = HttpError
+
 // N404 defines model for 404.
 type N404 = HttpError
 
@@ -187,11 +1003,57 @@ type GetProjectAlertsParams struct {
 
 	// Suppressed Shows suppressed alerts
 	Suppressed *SuppressedAlertsQueryFilter `form:"suppressed,omitempty" json:"suppressed,omitempty"`
+
+	// Format Format the list is exported in, CSV flattening each item into a row. If omitted, CSV is returned if preferred by the Accept header of the request.
+	Format *ExportFormatQueryParam `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// GetProjectAlertsByResourceParams defines parameters for GetProjectAlertsByResource.
+type GetProjectAlertsByResourceParams struct {
+	// GroupBy Resource the alerts are grouped by, given by the host_uuid, cluster_name or deployment_id label of alerts
+	GroupBy *GroupByQueryParam `form:"groupBy,omitempty" json:"groupBy,omitempty"`
+}
+
+// GetProjectAlertDefinitionsParams defines parameters for GetProjectAlertDefinitions.
+type GetProjectAlertDefinitionsParams struct {
+	// Limit Maximum number of items to return
+	Limit *LimitQueryParam `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of items to skip before starting to collect the result set
+	Offset *OffsetQueryParam `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// SortBy Field the list is sorted by
+	SortBy *SortByQueryParam `form:"sortBy,omitempty" json:"sortBy,omitempty"`
+
+	// Order Sort order of the list
+	Order *OrderQueryParam `form:"order,omitempty" json:"order,omitempty"`
+
+	// Fields Comma-separated list of fields to include in the response, all fields are returned if omitted
+	Fields *FieldsQueryParam `form:"fields,omitempty" json:"fields,omitempty"`
+
+	// WithStatus Specifies if the number of currently firing alerts is reported for each item
+	WithStatus *WithStatusQueryParam `form:"withStatus,omitempty" json:"withStatus,omitempty"`
+
+	// Format Format the list is exported in, CSV flattening each item into a row. If omitted, CSV is returned if preferred by the Accept header of the request.
+	Format *ExportFormatQueryParam `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// GetProjectAlertDefinitionParams defines parameters for GetProjectAlertDefinition.
+type GetProjectAlertDefinitionParams struct {
+	// Fields Comma-separated list of fields to include in the response, all fields are returned if omitted
+	Fields *FieldsQueryParam `form:"fields,omitempty" json:"fields,omitempty"`
+}
+
+// PatchProjectAlertDefinitionParams defines parameters for PatchProjectAlertDefinition.
+type PatchProjectAlertDefinitionParams struct {
+	// Async Specifies if the update is answered with the operation applying it, which can be awaited
+	Async *AsyncQueryParam `form:"async,omitempty" json:"async,omitempty"`
 }
 
 // PatchProjectAlertDefinitionJSONBody defines parameters for PatchProjectAlertDefinition.
 type PatchProjectAlertDefinitionJSONBody struct {
 	Values *struct {
+		AutoTune  *string `json:"autoTune,omitempty"`
 		Duration  *string `json:"duration,omitempty"`
 		Enabled   *string `json:"enabled,omitempty"`
 		Threshold *string `json:"threshold,omitempty"`
@@ -204,9 +1066,81 @@ type GetProjectAlertDefinitionRuleParams struct {
 	Rendered *RenderedTemplateQueryParam `form:"rendered,omitempty" json:"rendered,omitempty"`
 }
 
+// GetProjectEmailTemplateParams defines parameters for GetProjectEmailTemplate.
+type GetProjectEmailTemplateParams struct {
+	// Version Version of the email template, the latest version if omitted
+	Version *EmailTemplateVersionQueryParam `form:"version,omitempty" json:"version,omitempty"`
+}
+
+// GetProjectAlertReceiversParams defines parameters for GetProjectAlertReceivers.
+type GetProjectAlertReceiversParams struct {
+	// Limit Maximum number of items to return
+	Limit *LimitQueryParam `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of items to skip before starting to collect the result set
+	Offset *OffsetQueryParam `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// SortBy Field the list is sorted by
+	SortBy *SortByQueryParam `form:"sortBy,omitempty" json:"sortBy,omitempty"`
+
+	// Order Sort order of the list
+	Order *OrderQueryParam `form:"order,omitempty" json:"order,omitempty"`
+
+	// Fields Comma-separated list of fields to include in the response, all fields are returned if omitted
+	Fields *FieldsQueryParam `form:"fields,omitempty" json:"fields,omitempty"`
+}
+
+// GetProjectAlertReceiverParams defines parameters for GetProjectAlertReceiver.
+type GetProjectAlertReceiverParams struct {
+	// Fields Comma-separated list of fields to include in the response, all fields are returned if omitted
+	Fields *FieldsQueryParam `form:"fields,omitempty" json:"fields,omitempty"`
+}
+
+// PatchProjectAlertReceiverParams defines parameters for PatchProjectAlertReceiver.
+type PatchProjectAlertReceiverParams struct {
+	// Preview Specifies if the alertmanager configuration the update is to be applied with is returned
+	Preview *PreviewQueryParam `form:"preview,omitempty" json:"preview,omitempty"`
+
+	// Async Specifies if the update is answered with the operation applying it, which can be awaited
+	Async *AsyncQueryParam `form:"async,omitempty" json:"async,omitempty"`
+}
+
+// GetProjectAlertReceiverAllowedRecipientsParams defines parameters for GetProjectAlertReceiverAllowedRecipients.
+type GetProjectAlertReceiverAllowedRecipientsParams struct {
+	// Limit Maximum number of items to return
+	Limit *LimitQueryParam `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of items to skip before starting to collect the result set
+	Offset *OffsetQueryParam `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
 // PatchProjectAlertReceiverJSONBody defines parameters for PatchProjectAlertReceiver.
 type PatchProjectAlertReceiverJSONBody struct {
 	EmailConfig EmailConfigTo `json:"emailConfig"`
+
+	// Enabled Whether the receiver is notified, disabling it pauses its notifications without removing its recipients
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Language Language of the email template the emails of the receiver are rendered with, one of the languages of the localized email templates of the deployment, the default template being used if empty
+	Language *string `json:"language,omitempty"`
+
+	// Matchers Label matchers replacing the ones appended to the route of the receiver, an empty list removing them
+	Matchers    *ReceiverMatchers `json:"matchers,omitempty"`
+	MinSeverity *ReceiverSeverity `json:"minSeverity,omitempty"`
+	OnCall      *OnCallConfig     `json:"onCall,omitempty"`
+	QuietHours  *QuietHours       `json:"quietHours,omitempty"`
+}
+
+// GetProjectReportParams defines parameters for GetProjectReport.
+type GetProjectReportParams struct {
+	// Format Format the report is rendered in
+	Format *ReportFormatQueryParam `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// VerifyEmailRecipientParams defines parameters for VerifyEmailRecipient.
+type VerifyEmailRecipientParams struct {
+	// Token Signed token of the link of the verification email sent to the recipient
+	Token VerificationTokenQueryParam `form:"token" json:"token"`
 }
 
 // PatchProjectAlertDefinitionJSONRequestBody defines body for PatchProjectAlertDefinition for application/json ContentType.
@@ -214,3 +1148,33 @@ type PatchProjectAlertDefinitionJSONRequestBody PatchProjectAlertDefinitionJSONB
 
 // PatchProjectAlertReceiverJSONRequestBody defines body for PatchProjectAlertReceiver for application/json ContentType.
 type PatchProjectAlertReceiverJSONRequestBody PatchProjectAlertReceiverJSONBody
+
+// PatchProjectEventHookJSONRequestBody defines body for PatchProjectEventHook for application/json ContentType.
+type PatchProjectEventHookJSONRequestBody = EventHookUpdate
+
+// PostProjectAlertDefinitionEvaluateJSONRequestBody defines body for PostProjectAlertDefinitionEvaluate for application/json ContentType.
+type PostProjectAlertDefinitionEvaluateJSONRequestBody = AlertDefinitionEvaluationRequest
+
+// PostProjectAlertDefinitionSimulateJSONRequestBody defines body for PostProjectAlertDefinitionSimulate for application/json ContentType.
+type PostProjectAlertDefinitionSimulateJSONRequestBody = AlertDefinitionSimulation
+
+// PostProjectAlertCommentJSONRequestBody defines body for PostProjectAlertComment for application/json ContentType.
+type PostProjectAlertCommentJSONRequestBody = AlertCommentCreate
+
+// PostProjectAlertReceiversSyncRecipientsJSONRequestBody defines body for PostProjectAlertReceiversSyncRecipients for application/json ContentType.
+type PostProjectAlertReceiversSyncRecipientsJSONRequestBody = RecipientSync
+
+// PostProjectConfigDiffJSONRequestBody defines body for PostProjectConfigDiff for application/json ContentType.
+type PostProjectConfigDiffJSONRequestBody = ConfigBundle
+
+// PostProjectEventHookJSONRequestBody defines body for PostProjectEventHook for application/json ContentType.
+type PostProjectEventHookJSONRequestBody = EventHookCreate
+
+// PostProjectExternalAlertsJSONRequestBody defines body for PostProjectExternalAlerts for application/json ContentType.
+type PostProjectExternalAlertsJSONRequestBody = ExternalAlertList
+
+// PutProjectEmailTemplateJSONRequestBody defines body for PutProjectEmailTemplate for application/json ContentType.
+type PutProjectEmailTemplateJSONRequestBody = EmailTemplateUpdate
+
+// PutProjectMaintenanceModeJSONRequestBody defines body for PutProjectMaintenanceMode for application/json ContentType.
+type PutProjectMaintenanceModeJSONRequestBody = MaintenanceModeUpdate
@@ -12,13 +12,20 @@ import (
 	"os/signal"
 	"syscall"
 
+	// Embeds the time zone database, required to validate the location of receiver quiet hours.
+	_ "time/tzdata"
+
 	"github.com/google/uuid"
 
 	am "github.com/open-edge-platform/o11y-alerting-monitor/internal/alertmanager"
 	"github.com/open-edge-platform/o11y-alerting-monitor/internal/app"
 	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/controller"
 	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
 	"github.com/open-edge-platform/o11y-alerting-monitor/internal/executor"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/mailrelay"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/mimir"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/network"
 )
 
 func validateLogLevel(value string) error {
@@ -33,10 +40,43 @@ func validateLogLevel(value string) error {
 	return nil
 }
 
+// configSnapshotter takes and restores snapshots of the alerting configuration of all tenants.
+type configSnapshotter interface {
+	TakeSnapshot(ctx context.Context) (string, error)
+	RestoreSnapshot(ctx context.Context, key string) (database.RestoreResult, error)
+}
+
+// runSnapshot takes a snapshot of the alerting configuration or restores the one with the given key, and returns the exit
+// code of the process. The restored configuration is applied by the task executor once alerting monitor is started.
+func runSnapshot(snapshotter configSnapshotter, take bool, restoreKey string) int {
+	ctx := context.Background()
+
+	if take {
+		key, err := snapshotter.TakeSnapshot(ctx)
+		if err != nil {
+			log.Printf("Failed to take configuration snapshot: %v", err)
+			return 1
+		}
+		log.Printf("Took configuration snapshot %q", key)
+	}
+
+	if restoreKey != "" {
+		res, err := snapshotter.RestoreSnapshot(ctx, restoreKey)
+		if err != nil {
+			log.Printf("Failed to restore configuration snapshot: %v", err)
+			return 1
+		}
+		log.Printf("Restored configuration snapshot: %d created, %d updated, %d unchanged", res.Created, res.Updated, res.Unchanged)
+	}
+	return 0
+}
+
 func main() {
 	configFile := flag.String("config", "", "config file path")
 	apiPort := flag.Int("port", 8080, "API service port")
 	logLevel := flag.String("log-level", "info", "API server log level")
+	takeSnapshot := flag.Bool("take-snapshot", false, "snapshot the alerting configuration of all tenants to the object store and exit")
+	restoreSnapshot := flag.String("restore-snapshot", "", "restore the snapshot with the given key, or the latest one if \"latest\", and exit")
 
 	flag.Parse()
 
@@ -45,19 +85,44 @@ func main() {
 		log.Fatalf("Error loading config: %v", err)
 	}
 
+	if err := network.ValidateDownstreams(configuration); err != nil {
+		log.Fatalf("Invalid downstream configuration: %v", err)
+	}
+	if _, err := mimir.NewRuleBackend(&configuration.Mimir); err != nil {
+		log.Fatalf("Invalid rule backend configuration: %v", err)
+	}
+	if err := network.Configure(configuration); err != nil {
+		log.Fatalf("Failed to configure downstream connections: %v", err)
+	}
+
 	err = validateLogLevel(*logLevel)
 	if err != nil {
 		log.Fatal(err.Error())
 	}
 
-	alertManager, err := am.New(configuration.AlertManager)
+	db, err := database.ConnectDB()
 	if err != nil {
-		log.Fatalf("Failed to create alertmanager client: %v", err)
+		log.Fatal(err.Error())
 	}
 
-	db, err := database.ConnectDB()
+	snapshotter := executor.NewConfigSnapshotter(configuration, db, *logLevel)
+	if *takeSnapshot || *restoreSnapshot != "" {
+		os.Exit(runSnapshot(snapshotter, *takeSnapshot, *restoreSnapshot))
+	}
+
+	// Applied artifacts are only encrypted if enabled.
+	var artifactKeys *database.ArtifactKeyRing
+	if configuration.ArtifactEncryption.Enabled {
+		artifactKeys, err = database.LoadArtifactKeyRing(configuration.ArtifactEncryption.ActiveKeyID)
+		if err != nil {
+			log.Fatalf("Failed to load artifact encryption keys: %v", err)
+		}
+	}
+
+	dbService := &database.DBService{DB: db, AlertmanagerShards: configuration.AlertManager.ShardCount(), ArtifactKeys: artifactKeys}
+	alertManager, err := am.NewBackend(configuration.AlertManager, configuration.Tenancy, dbService, dbService, dbService)
 	if err != nil {
-		log.Fatal(err.Error())
+		log.Fatalf("Failed to create alertmanager client: %v", err)
 	}
 
 	// Get pod uuid for executor
@@ -71,11 +136,64 @@ func main() {
 	done := make(chan os.Signal, 1)
 	signal.Notify(done, os.Interrupt, syscall.SIGTERM)
 
-	aEx := executor.NewAsyncExecutor(podUUID, configuration, db, *logLevel, alertManager)
+	aEx := executor.NewAsyncExecutor(podUUID, configuration, db, *logLevel, alertManager, artifactKeys)
 	aEx.Start(context.Background())
 
-	app.StartServer(*apiPort, configuration, *logLevel, db)
+	archiver := executor.NewTenantArchiver(configuration, db, *logLevel, alertManager)
+	archiver.Start(context.Background())
+
+	tuner := executor.NewThresholdTuner(configuration, db, *logLevel)
+	tuner.Start(context.Background())
+
+	evaluationMonitor := executor.NewEvaluationMonitor(configuration, db, *logLevel)
+	evaluationMonitor.Start(context.Background())
+
+	reporter := executor.NewAlertReporter(configuration, db, *logLevel)
+	reporter.Start(context.Background())
+
+	compactor := executor.NewHistoryCompactor(configuration, db, *logLevel)
+	compactor.Start(context.Background())
+
+	rotator := executor.NewArtifactKeyRotator(configuration, db, *logLevel, artifactKeys)
+	rotator.Start(context.Background())
+
+	snapshotter.Start(context.Background())
+
+	// The controller requires access to the Kubernetes API, so it is only created if enabled.
+	var crController *controller.Controller
+	if configuration.Controller.ResyncInterval > 0 {
+		crController, err = controller.New(configuration, db, *logLevel)
+		if err != nil {
+			log.Fatalf("Failed to create custom resource controller: %v", err)
+		}
+		crController.Start(context.Background())
+	}
+
+	// The relay signing emails sent by alertmanager is only created if enabled.
+	var relay *mailrelay.Relay
+	if configuration.EmailSigning.ListenAddress != "" {
+		relay, err = mailrelay.New(configuration, *logLevel)
+		if err != nil {
+			log.Fatalf("Failed to create email signing relay: %v", err)
+		}
+		relay.Start(context.Background())
+	}
+
+	app.StartServer(*apiPort, configuration, *logLevel, db, alertManager, artifactKeys)
 
 	<-done
 	aEx.Stop()
+	archiver.Stop()
+	tuner.Stop()
+	evaluationMonitor.Stop()
+	reporter.Stop()
+	compactor.Stop()
+	rotator.Stop()
+	snapshotter.Stop()
+	if crController != nil {
+		crController.Stop()
+	}
+	if relay != nil {
+		relay.Stop()
+	}
 }
@@ -0,0 +1,310 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/app"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/rules"
+)
+
+const (
+	// bootstrapTimeout bounds the whole bootstrap run, so that a hanging step fails the job instead of blocking the release.
+	bootstrapTimeout = 10 * time.Minute
+
+	// downstreamCheckTimeout is the timeout of each downstream connectivity check.
+	downstreamCheckTimeout = 10 * time.Second
+)
+
+// migrationFileRegexp matches the up migration files, named as expected by golang-migrate.
+var migrationFileRegexp = regexp.MustCompile(`^(\d+)_(.+)\.up\.sql$`)
+
+// bootstrapReport is the machine-readable report of a bootstrap run, written as JSON to stdout.
+type bootstrapReport struct {
+	Success    bool               `json:"success"`
+	Error      string             `json:"error,omitempty"`
+	Migrations migrationReport    `json:"migrations"`
+	Seed       seedReport         `json:"seed"`
+	Downstream []downstreamReport `json:"downstream"`
+}
+
+// migrationReport reports the schema version after migrating and the migrations applied by the run.
+type migrationReport struct {
+	Version int64    `json:"version"`
+	Applied []string `json:"applied"`
+	Error   string   `json:"error,omitempty"`
+}
+
+// seedReport reports the tenants whose catalog of alert definitions and receivers was seeded.
+type seedReport struct {
+	Tenants []tenantReport `json:"tenants"`
+	Error   string         `json:"error,omitempty"`
+}
+
+type tenantReport struct {
+	TenantID     string `json:"tenantID"`
+	RowsAffected int64  `json:"rowsAffected"`
+	Error        string `json:"error,omitempty"`
+}
+
+type downstreamReport struct {
+	Name  string `json:"name"`
+	URL   string `json:"url"`
+	Error string `json:"error,omitempty"`
+}
+
+// downstreamTarget is a readiness endpoint of a downstream service checked by the bootstrap.
+type downstreamTarget struct {
+	name string
+	url  string
+}
+
+// parseDownstreamTargets parses a comma-separated list of downstream readiness endpoints, each given as name=url.
+func parseDownstreamTargets(value string) ([]downstreamTarget, error) {
+	var targets []downstreamTarget
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		name, url, ok := strings.Cut(item, "=")
+		if !ok || name == "" || url == "" {
+			return nil, fmt.Errorf("invalid downstream target %q, expected name=url", item)
+		}
+		targets = append(targets, downstreamTarget{name: name, url: url})
+	}
+	return targets, nil
+}
+
+// runBootstrap runs the bootstrap, writes its report to stdout and returns the exit code of the process.
+func runBootstrap(cfg config.Config, migrationsDir, downstream string) int {
+	var report bootstrapReport
+	defer func() {
+		if err := json.NewEncoder(os.Stdout).Encode(report); err != nil {
+			log.Printf("Failed to write bootstrap report: %v", err)
+		}
+	}()
+
+	targets, err := parseDownstreamTargets(downstream)
+	if err != nil {
+		report.Error = err.Error()
+		return 1
+	}
+
+	rulesCfg, err := rules.LoadRulesConfig(rulesFile)
+	if err != nil {
+		report.Error = fmt.Sprintf("failed to load alert definitions: %v", err)
+		return 1
+	}
+
+	dbConn, err := database.ConnectDB()
+	if err != nil {
+		report.Error = err.Error()
+		return 1
+	}
+
+	sqlDB, err := dbConn.DB()
+	if err != nil {
+		report.Error = err.Error()
+		return 1
+	}
+	defer func() {
+		err := sqlDB.Close()
+		if err != nil {
+			log.Printf("Error appeared when closing database connection: %v", err)
+		}
+	}()
+
+	s := server{
+		rulesCfg:  *rulesCfg,
+		dbService: &database.DBService{DB: dbConn, AlertmanagerShards: cfg.AlertManager.ShardCount()},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), bootstrapTimeout)
+	defer cancel()
+
+	report = s.bootstrap(ctx, migrationsDir, targets, &http.Client{Timeout: downstreamCheckTimeout})
+	if !report.Success {
+		return 1
+	}
+	return 0
+}
+
+// bootstrap migrates the database schema, seeds the catalog of alert definitions and receivers of the default tenant and of
+// all tenants which are not archived, and checks the connectivity to downstream services. Seeding is skipped if migrating fails,
+// while downstream services are always checked so that the report covers all of them.
+func (s *server) bootstrap(ctx context.Context, migrationsDir string, targets []downstreamTarget, client *http.Client) bootstrapReport {
+	report := bootstrapReport{Success: true}
+
+	report.Migrations = migrate(ctx, s.dbService.DB, migrationsDir)
+	if report.Migrations.Error != "" {
+		report.Success = false
+	} else {
+		report.Seed = s.seed(ctx)
+		if report.Seed.Error != "" || slices.ContainsFunc(report.Seed.Tenants, func(t tenantReport) bool { return t.Error != "" }) {
+			report.Success = false
+		}
+	}
+
+	for _, target := range targets {
+		r := downstreamReport{Name: target.name, URL: target.url}
+		if err := checkDownstream(ctx, client, target.url); err != nil {
+			r.Error = err.Error()
+			report.Success = false
+		}
+		report.Downstream = append(report.Downstream, r)
+	}
+	return report
+}
+
+// seed seeds the catalog of alert definitions and receivers of the default tenant and of all tenants which are not archived,
+// adding the alert definitions introduced by the current rules. Failing to seed a tenant does not stop others from being seeded.
+func (s *server) seed(ctx context.Context) seedReport {
+	var report seedReport
+
+	if err := s.initializeEmailCfg(ctx); err != nil {
+		report.Error = fmt.Sprintf("failed to initialize email configuration: %v", err)
+		return report
+	}
+
+	tenantIDs, err := s.dbService.GetActiveTenants(ctx)
+	if err != nil {
+		report.Error = err.Error()
+		return report
+	}
+	if !slices.Contains(tenantIDs, app.DefaultTenantID) {
+		tenantIDs = append([]string{app.DefaultTenantID}, tenantIDs...)
+	}
+
+	for _, tenantID := range tenantIDs {
+		r := tenantReport{TenantID: tenantID}
+		rows, err := s.initDataForTenant(ctx, tenantID)
+		if err != nil {
+			r.Error = err.Error()
+		}
+		r.RowsAffected = rows
+		report.Tenants = append(report.Tenants, r)
+	}
+	return report
+}
+
+// migrate applies the up migrations of the given directory which are newer than the current schema version. The version is
+// tracked in the schema_migrations table the same way as golang-migrate does, so that both can be used on the same database.
+// Each migration is applied in its own transaction along with the update of the version.
+func migrate(ctx context.Context, db *gorm.DB, dir string) migrationReport {
+	report := migrationReport{Applied: []string{}}
+
+	if err := db.WithContext(ctx).Exec(
+		"CREATE TABLE IF NOT EXISTS schema_migrations (version bigint NOT NULL PRIMARY KEY, dirty boolean NOT NULL)",
+	).Error; err != nil {
+		report.Error = fmt.Sprintf("failed to create schema_migrations table: %v", err)
+		return report
+	}
+
+	var current struct {
+		Version int64
+		Dirty   bool
+	}
+	if err := db.WithContext(ctx).Raw("SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&current).Error; err != nil {
+		report.Error = fmt.Sprintf("failed to get schema version: %v", err)
+		return report
+	}
+	report.Version = current.Version
+	if current.Dirty {
+		report.Error = fmt.Sprintf("schema version %d is dirty, it has to be fixed manually", current.Version)
+		return report
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		report.Error = fmt.Sprintf("failed to read migrations: %v", err)
+		return report
+	}
+
+	type migration struct {
+		version int64
+		file    string
+	}
+	var migrations []migration
+	for _, entry := range entries {
+		matches := migrationFileRegexp.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || matches == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(matches[1], 10, 64)
+		if err != nil {
+			report.Error = fmt.Sprintf("invalid version of migration %q: %v", entry.Name(), err)
+			return report
+		}
+		if version > current.Version {
+			migrations = append(migrations, migration{version: version, file: entry.Name()})
+		}
+	}
+	slices.SortFunc(migrations, func(a, b migration) int {
+		return cmp.Compare(a.version, b.version)
+	})
+
+	for _, m := range migrations {
+		stmts, err := os.ReadFile(filepath.Join(dir, m.file))
+		if err != nil {
+			report.Error = fmt.Sprintf("failed to read migration %q: %v", m.file, err)
+			return report
+		}
+
+		if err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec(string(stmts)).Error; err != nil {
+				return err
+			}
+			if err := tx.Exec("DELETE FROM schema_migrations").Error; err != nil {
+				return err
+			}
+			return tx.Exec("INSERT INTO schema_migrations (version, dirty) VALUES (?, ?)", m.version, false).Error
+		}); err != nil {
+			report.Error = fmt.Sprintf("failed to apply migration %q: %v", m.file, err)
+			return report
+		}
+
+		report.Version = m.version
+		report.Applied = append(report.Applied, m.file)
+	}
+	return report
+}
+
+// checkDownstream checks that a readiness endpoint of a downstream service responds with 200 OK.
+func checkDownstream(ctx context.Context, client *http.Client, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach downstream: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("downstream is not ready, got status code %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/rules"
+)
+
+var _ = Describe("Bootstrap", func() {
+	var s *server
+	var migrationsDir string
+
+	writeMigration := func(name, content string) {
+		Expect(os.WriteFile(filepath.Join(migrationsDir, name), []byte(content), 0o600)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		dbConn, err := gorm.Open(sqlite.Open("file:bootstrap?mode=memory&cache=shared"))
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(func() {
+			sqlDB, err := dbConn.DB()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sqlDB.Close()).To(Succeed())
+		})
+
+		rulesCfg, err := rules.LoadRulesConfig("testdata/rules.yaml")
+		Expect(err).ToNot(HaveOccurred())
+
+		s = &server{
+			rulesCfg:  *rulesCfg,
+			dbService: &database.DBService{DB: dbConn},
+		}
+
+		Expect(dbConn.AutoMigrate(
+			&models.AlertDefinition{},
+			&models.AlertThreshold{},
+			&models.AlertDuration{},
+			&models.Task{},
+			&models.EmailAddress{},
+			&models.EmailConfig{},
+			&models.Receiver{},
+			&models.Tenant{},
+			&models.EmailTemplate{},
+		)).ShouldNot(HaveOccurred())
+
+		migrationsDir = GinkgoT().TempDir()
+	})
+
+	It("Apply migrations newer than the schema version", func() {
+		ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
+		defer cancel()
+
+		writeMigration("20250101000000_first.up.sql", "CREATE TABLE first (id integer);")
+		writeMigration("20250101000000_first.down.sql", "DROP TABLE first;")
+		writeMigration("20250201000000_second.up.sql", "CREATE TABLE second (id integer); INSERT INTO second VALUES (1);")
+
+		By("applying all migrations")
+		report := migrate(ctx, s.dbService.DB, migrationsDir)
+		Expect(report.Error).To(BeEmpty())
+		Expect(report.Version).To(BeEquivalentTo(20250201000000))
+		Expect(report.Applied).To(Equal([]string{"20250101000000_first.up.sql", "20250201000000_second.up.sql"}))
+
+		var count int64
+		Expect(s.dbService.DB.WithContext(ctx).Table("second").Count(&count).Error).ShouldNot(HaveOccurred())
+		Expect(count).To(BeEquivalentTo(1))
+
+		By("applying only the new migration")
+		writeMigration("20250301000000_third.up.sql", "CREATE TABLE third (id integer);")
+		report = migrate(ctx, s.dbService.DB, migrationsDir)
+		Expect(report.Error).To(BeEmpty())
+		Expect(report.Version).To(BeEquivalentTo(20250301000000))
+		Expect(report.Applied).To(Equal([]string{"20250301000000_third.up.sql"}))
+	})
+
+	It("Failed migration keeps the previous schema version", func() {
+		ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
+		defer cancel()
+
+		writeMigration("1_first.up.sql", "CREATE TABLE first (id integer);")
+		writeMigration("2_invalid.up.sql", "CREATE TABLE;")
+
+		report := migrate(ctx, s.dbService.DB, migrationsDir)
+		Expect(report.Error).To(ContainSubstring("2_invalid.up.sql"))
+		Expect(report.Version).To(BeEquivalentTo(1))
+
+		var version int64
+		Expect(s.dbService.DB.WithContext(ctx).Raw("SELECT version FROM schema_migrations").Scan(&version).Error).ShouldNot(HaveOccurred())
+		Expect(version).To(BeEquivalentTo(1))
+	})
+
+	It("Seed existing tenants and check downstream services", func() {
+		ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
+		defer cancel()
+
+		ready := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer ready.Close()
+		notReady := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer notReady.Close()
+
+		By("creating an alert definition of an existing tenant")
+		Expect(s.dbService.DB.WithContext(ctx).Create(&models.AlertDefinition{
+			UUID:     uuid.New(),
+			Name:     "alert",
+			Version:  1,
+			State:    models.DefinitionApplied,
+			Category: models.CategoryHealth,
+			TenantID: "tenant",
+		}).Error).ShouldNot(HaveOccurred())
+
+		report := s.bootstrap(ctx, migrationsDir, []downstreamTarget{
+			{name: "ready", url: ready.URL},
+			{name: "not-ready", url: notReady.URL},
+		}, ready.Client())
+
+		Expect(report.Success).To(BeFalse())
+		Expect(report.Migrations.Error).To(BeEmpty())
+		Expect(report.Seed.Error).To(BeEmpty())
+		Expect(report.Seed.Tenants).To(HaveLen(2))
+		Expect(report.Seed.Tenants[0].TenantID).To(Equal("edgenode"))
+		Expect(report.Seed.Tenants[1].TenantID).To(Equal("tenant"))
+		for _, tenant := range report.Seed.Tenants {
+			Expect(tenant.Error).To(BeEmpty())
+			Expect(tenant.RowsAffected).To(BeNumerically(">", 0))
+		}
+
+		Expect(report.Downstream).To(HaveLen(2))
+		Expect(report.Downstream[0].Error).To(BeEmpty())
+		Expect(report.Downstream[1].Error).To(ContainSubstring("503"))
+
+		count, err := (&management{s: s}).count(ctx, models.AlertDefinition{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(count).To(BeEquivalentTo(2*expectedNumberOfAlertDefinitionsPerTenant + 1))
+	})
+
+	It("Parse downstream targets", func() {
+		targets, err := parseDownstreamTargets("mimir=http://mimir/ready, alertmanager=http://alertmanager/-/ready")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(targets).To(Equal([]downstreamTarget{
+			{name: "mimir", url: "http://mimir/ready"},
+			{name: "alertmanager", url: "http://alertmanager/-/ready"},
+		}))
+
+		targets, err = parseDownstreamTargets("")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(targets).To(BeEmpty())
+
+		_, err = parseDownstreamTargets("http://mimir/ready")
+		Expect(err).Should(HaveOccurred())
+	})
+})
@@ -31,12 +31,17 @@ import (
 	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
 	pb "github.com/open-edge-platform/o11y-alerting-monitor/api/v1/management"
 	"github.com/open-edge-platform/o11y-alerting-monitor/internal/app"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
 	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
 	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
 	"github.com/open-edge-platform/o11y-alerting-monitor/internal/mimir"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/network"
 	"github.com/open-edge-platform/o11y-alerting-monitor/internal/rules"
 )
 
+// rulesFile is the path of the rules defining the catalog of alert definitions of tenants.
+const rulesFile = "/config/rules.yaml"
+
 var (
 	tenantIDNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9!_\-.*'()]+$`)
 )
@@ -52,10 +57,35 @@ type server struct {
 }
 
 func main() {
+	configFile := flag.String("config", "", "config file path, downstream connections use the defaults of net/http if not given")
 	port := flag.Int("port", 51001, "gRPC server port")
+	bootstrap := flag.Bool("bootstrap", false, "migrate the database, seed the catalog of existing tenants, check downstream services and exit")
+	migrationsDir := flag.String("migrations", "/migrations", "directory of the database migrations applied by the bootstrap")
+	downstream := flag.String("downstream", "", "comma-separated list of name=url readiness endpoints checked by the bootstrap")
+	migrateDataFile := flag.String("migrate-data", "", "migrate the database, copy all tables of the given SQLite database file into it, verify them and exit")
 	flag.Parse()
 
-	rulesCfg, err := rules.LoadRulesConfig("/config/rules.yaml")
+	// Downstream connections are configured first, since the bootstrap and the data migration connect to downstream services
+	// as well.
+	var configuration config.Config
+	if *configFile != "" {
+		var err error
+		if configuration, err = config.LoadConfig(*configFile); err != nil {
+			log.Panicf("Failed to load config: %v", err)
+		}
+		if err := network.Configure(configuration); err != nil {
+			log.Panicf("Failed to configure downstream connections: %v", err)
+		}
+	}
+
+	if *bootstrap {
+		os.Exit(runBootstrap(configuration, *migrationsDir, *downstream))
+	}
+	if *migrateDataFile != "" {
+		os.Exit(runMigrateData(*migrateDataFile, *migrationsDir))
+	}
+
+	rulesCfg, err := rules.LoadRulesConfig(rulesFile)
 	if err != nil {
 		log.Panicf("Failed to load alert definitions: %v", err)
 	}
@@ -79,7 +109,7 @@ func main() {
 	s := server{
 		rulesCfg:   *rulesCfg,
 		grpcServer: grpc.NewServer(),
-		dbService:  &database.DBService{DB: dbConn},
+		dbService:  &database.DBService{DB: dbConn, AlertmanagerShards: configuration.AlertManager.ShardCount()},
 		port:       *port,
 	}
 
@@ -174,6 +204,22 @@ func (s *server) CleanupTenant(ctx context.Context, req *pb.TenantRequest) (*emp
 	return nil, nil
 }
 
+// UnarchiveTenant ensures that the configuration of an archived tenant is applied again.
+func (s *server) UnarchiveTenant(ctx context.Context, req *pb.TenantRequest) (*emptypb.Empty, error) {
+	log.Printf("Received unarchive request for tenant: %q", req.GetTenant())
+	if err := validateTenantID(req.GetTenant()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err := s.dbService.UnarchiveTenant(ctx, req.GetTenant())
+	if errors.Is(err, database.ErrTenantNotArchived) {
+		return nil, status.Errorf(codes.NotFound, "tenant %q is not archived", req.GetTenant())
+	} else if err != nil {
+		return nil, status.Errorf(codes.Internal, "unarchive of tenant %q failed: %v", req.GetTenant(), err)
+	}
+	return nil, nil
+}
+
 func (s *server) initializeDefaults(ctx context.Context, tenant string) error {
 	err := s.initializeEmailCfg(ctx)
 	if err != nil {
@@ -219,7 +265,7 @@ func (s *server) initializeEmailCfg(ctx context.Context) error {
 	}
 
 	emailConfig := models.EmailConfig{
-		MailServer: fmt.Sprintf("%s:%s", host, port),
+		MailServer: net.JoinHostPort(host, port),
 		From:       sender.ID,
 	}
 	if err := tx.Where(models.EmailConfig{
@@ -316,6 +362,7 @@ func insertAlertDefinition(tx *gorm.DB, interval int64, r rules.Rule, tenant str
 		ThresholdType:     r.Annotations["am_definition_type"],
 		ThresholdUnit:     r.Annotations["am_threshold_unit"],
 		AlertDefinitionID: ad.ID,
+		ThresholdDefault:  &threshold,
 	}
 	res = tx.Where(models.AlertThreshold{
 		AlertDefinitionID: ad.ID,
@@ -345,6 +392,7 @@ func insertAlertDefinition(tx *gorm.DB, interval int64, r rules.Rule, tenant str
 		DurationMin:       durationMin,
 		DurationMax:       durationMax,
 		AlertDefinitionID: ad.ID,
+		DurationDefault:   &duration,
 	}
 	res = tx.Where(models.AlertDuration{
 		AlertDefinitionID: ad.ID,
@@ -481,6 +529,13 @@ func (s *server) cleanupDataForTenant(ctx context.Context, tenant string) (int64
 	}
 	rowsAffected += res.RowsAffected
 
+	// Delete the activity record of the given tenant.
+	res = tx.Where("tenant_id = ?", tenant).Delete(&models.Tenant{})
+	if res.Error != nil {
+		return rowsAffected, res.Error
+	}
+	rowsAffected += res.RowsAffected
+
 	return rowsAffected, tx.Commit().Error
 }
 
@@ -95,6 +95,8 @@ var _ = Describe("Management", Ordered, func() {
 			&models.EmailAddress{},
 			&models.EmailConfig{},
 			&models.Receiver{},
+			&models.Tenant{},
+			&models.EmailTemplate{},
 		)).ShouldNot(HaveOccurred())
 
 		GinkgoT().Setenv("FROM_MAIL", "Foo Bar <foo@bar.com>")
@@ -438,6 +440,35 @@ var _ = Describe("Management", Ordered, func() {
 		Expect(err).ShouldNot(HaveOccurred())
 		Expect(count).To(BeEquivalentTo(1))
 	})
+
+	It("Unarchive archived tenant using gRPC endpoint - tenant should be active again", func() {
+		ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
+		defer cancel()
+
+		archivedDate := time.Now()
+		Expect(mgmt.s.dbService.DB.WithContext(ctx).Create(&models.Tenant{
+			TenantID:         "archived_tenant",
+			LastActivityDate: archivedDate,
+			ArchivedDate:     &archivedDate,
+		}).Error).ShouldNot(HaveOccurred())
+
+		_, err := mgmt.client.UnarchiveTenant(ctx, &pb.TenantRequest{Tenant: "archived_tenant"})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		var tenant models.Tenant
+		Expect(mgmt.s.dbService.DB.WithContext(ctx).First(&tenant, "tenant_id = ?", "archived_tenant").Error).ShouldNot(HaveOccurred())
+		Expect(tenant.IsArchived()).To(BeFalse())
+	})
+
+	It("Unarchive tenant which is not archived using gRPC endpoint - error should appear", func() {
+		ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
+		defer cancel()
+
+		_, err := mgmt.client.UnarchiveTenant(ctx, &pb.TenantRequest{Tenant: "does_not_exist"})
+		Expect(err).To(MatchError(func(err error) bool {
+			return status.Code(err) == codes.NotFound
+		}, "NotFound"))
+	})
 })
 
 func (m *management) count(ctx context.Context, dbType interface{}) (int64, error) {
@@ -0,0 +1,337 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	// Pure Go SQLite driver, as the binaries are built without cgo.
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+const (
+	// migrateDataTimeout bounds the whole data migration run.
+	migrateDataTimeout = time.Hour
+
+	// migrateDataBatchSize is the number of rows read from SQLite and inserted into Postgres at once.
+	migrateDataBatchSize = 500
+)
+
+// migratedTables copies each table of the database, parents before the tables referencing them.
+var migratedTables = []tableCopier{
+	copyTable[models.Tenant],
+	copyTable[models.AlertDefinition],
+	copyTable[models.AlertDuration],
+	copyTable[models.AlertThreshold],
+	copyTable[models.EmailAddress],
+	copyTable[models.EmailConfig],
+	copyTable[models.Receiver],
+	copyTable[models.EmailRecipient],
+	copyTable[models.RecipientOffboarding],
+	copyTable[models.EmailTemplate],
+	copyTable[models.Task],
+	copyTable[models.TaskHistory],
+	copyTable[models.TaskHistoryRollup],
+	copyTable[models.NotificationTask],
+	copyTable[models.AlertComment],
+	copyTable[models.EmailDelivery],
+	copyTable[models.RuleEvaluation],
+	copyTable[models.AlertReport],
+	copyTable[models.AppliedArtifact],
+	copyTable[models.AlertmanagerConfig],
+}
+
+// tableCopier copies the rows of a table from the source database to the target database, and verifies them.
+type tableCopier func(ctx context.Context, src, dst *gorm.DB) tableReport
+
+// migrateDataReport is the machine-readable report of a data migration run, written as JSON to stdout.
+type migrateDataReport struct {
+	Success    bool            `json:"success"`
+	Error      string          `json:"error,omitempty"`
+	Migrations migrationReport `json:"migrations"`
+	Tables     []tableReport   `json:"tables"`
+}
+
+// tableReport reports the rows copied of a table and their checksum, which is the same in both databases once verified.
+type tableReport struct {
+	Table    string `json:"table"`
+	Rows     int64  `json:"rows"`
+	Checksum string `json:"checksum"`
+	Error    string `json:"error,omitempty"`
+}
+
+// runMigrateData copies the data of the SQLite database file into the Postgres database, writes its report to stdout and returns
+// the exit code of the process.
+func runMigrateData(sqliteFile, migrationsDir string) int {
+	var report migrateDataReport
+	defer func() {
+		if err := json.NewEncoder(os.Stdout).Encode(report); err != nil {
+			log.Printf("Failed to write data migration report: %v", err)
+		}
+	}()
+
+	if _, err := os.Stat(sqliteFile); err != nil {
+		report.Error = fmt.Sprintf("failed to find SQLite database: %v", err)
+		return 1
+	}
+	src, err := gorm.Open(sqlite.Open("file:" + sqliteFile + "?mode=ro"))
+	if err != nil {
+		report.Error = fmt.Sprintf("failed to open SQLite database: %v", err)
+		return 1
+	}
+
+	dst, err := database.ConnectDB()
+	if err != nil {
+		report.Error = err.Error()
+		return 1
+	}
+
+	for _, conn := range []*gorm.DB{src, dst} {
+		sqlDB, err := conn.DB()
+		if err != nil {
+			report.Error = err.Error()
+			return 1
+		}
+		defer func() {
+			if err := sqlDB.Close(); err != nil {
+				log.Printf("Error appeared when closing database connection: %v", err)
+			}
+		}()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), migrateDataTimeout)
+	defer cancel()
+
+	report = migrateData(ctx, src, dst, migrationsDir)
+	if !report.Success {
+		return 1
+	}
+	return 0
+}
+
+// migrateData migrates the schema of the target database, then copies all tables of the source database into it. The copy is
+// done in a single transaction, which is rolled back if any table fails to be copied or verified, and is refused if the target
+// database already holds data so that no existing data is mixed with the copied one.
+func migrateData(ctx context.Context, src, dst *gorm.DB, migrationsDir string) migrateDataReport {
+	report := migrateDataReport{Tables: []tableReport{}}
+
+	report.Migrations = migrate(ctx, dst, migrationsDir)
+	if report.Migrations.Error != "" {
+		report.Error = "failed to migrate target database"
+		return report
+	}
+
+	if err := dst.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, copyFn := range migratedTables {
+			r := copyFn(ctx, src, tx)
+			report.Tables = append(report.Tables, r)
+			if r.Error != "" {
+				return fmt.Errorf("failed to copy table %q", r.Table)
+			}
+		}
+		return nil
+	}); err != nil {
+		report.Error = err.Error()
+		return report
+	}
+
+	report.Success = true
+	return report
+}
+
+// copyTable copies the rows of the table of the model T in batches, keeping their primary keys and their times converted to
+// UTC, and checks that the rows read back from the target database match the source ones. Hooks of the model are skipped and
+// all columns are written, so that rows are copied as they are instead of being given default values. Identity sequences of
+// Postgres are moved past the copied keys.
+func copyTable[T any](ctx context.Context, src, dst *gorm.DB) tableReport {
+	stmt := &gorm.Statement{DB: dst}
+	if err := stmt.Parse(new(T)); err != nil {
+		return tableReport{Error: fmt.Sprintf("failed to parse model: %v", err)}
+	}
+	sch := stmt.Schema
+	report := tableReport{Table: sch.Table}
+
+	var existing int64
+	if err := dst.Model(new(T)).Count(&existing).Error; err != nil {
+		report.Error = fmt.Sprintf("failed to count rows of target table: %v", err)
+		return report
+	}
+	if existing > 0 {
+		report.Error = fmt.Sprintf("target table is not empty, it has %d rows", existing)
+		return report
+	}
+
+	order := strings.Join(sch.PrimaryFieldDBNames, ", ")
+	srcSum := newTableChecksum(sch)
+	for offset := 0; ; offset += migrateDataBatchSize {
+		var rows []T
+		if err := src.WithContext(ctx).Order(order).Limit(migrateDataBatchSize).Offset(offset).Find(&rows).Error; err != nil {
+			report.Error = fmt.Sprintf("failed to read rows: %v", err)
+			return report
+		}
+		if len(rows) == 0 {
+			break
+		}
+
+		for i := range rows {
+			if err := timesToUTC(ctx, sch, &rows[i]); err != nil {
+				report.Error = fmt.Sprintf("failed to convert times of row: %v", err)
+				return report
+			}
+			srcSum.add(ctx, &rows[i])
+		}
+		if err := dst.Session(&gorm.Session{SkipHooks: true}).Select("*").Omit(clause.Associations).Create(&rows).Error; err != nil {
+			report.Error = fmt.Sprintf("failed to write rows: %v", err)
+			return report
+		}
+		report.Rows += int64(len(rows))
+	}
+
+	dstSum := newTableChecksum(sch)
+	for offset := 0; ; offset += migrateDataBatchSize {
+		var rows []T
+		if err := dst.Order(order).Limit(migrateDataBatchSize).Offset(offset).Find(&rows).Error; err != nil {
+			report.Error = fmt.Sprintf("failed to read back rows: %v", err)
+			return report
+		}
+		if len(rows) == 0 {
+			break
+		}
+		for i := range rows {
+			dstSum.add(ctx, &rows[i])
+		}
+	}
+
+	report.Checksum = srcSum.String()
+	if srcSum.rows != dstSum.rows || report.Checksum != dstSum.String() {
+		report.Error = fmt.Sprintf("verification failed, copied %d rows with checksum %s, found %d rows with checksum %s",
+			srcSum.rows, report.Checksum, dstSum.rows, dstSum.String())
+		return report
+	}
+
+	if err := resetIdentity(dst, sch); err != nil {
+		report.Error = err.Error()
+	}
+	return report
+}
+
+// timesToUTC converts the times of the given row, a pointer to a model, to UTC, as the timestamp columns of Postgres keep the
+// time of day without its time zone.
+func timesToUTC(ctx context.Context, sch *schema.Schema, row any) error {
+	value := reflect.ValueOf(row).Elem()
+	for _, field := range sch.Fields {
+		if field.DBName == "" {
+			continue
+		}
+		switch v, _ := field.ValueOf(ctx, value); t := v.(type) {
+		case time.Time:
+			if err := field.Set(ctx, value, t.UTC()); err != nil {
+				return err
+			}
+		case *time.Time:
+			if t != nil {
+				if err := field.Set(ctx, value, t.UTC()); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// resetIdentity moves the identity sequence of the auto-incremented primary key of the table past the largest copied key, so
+// that rows created afterwards do not collide with the copied ones. Only Postgres has such sequences.
+func resetIdentity(db *gorm.DB, sch *schema.Schema) error {
+	field := sch.PrioritizedPrimaryField
+	if db.Name() != "postgres" || field == nil || !field.AutoIncrement {
+		return nil
+	}
+
+	if err := db.Exec(
+		fmt.Sprintf("SELECT setval(pg_get_serial_sequence(?, ?), COALESCE(MAX(%[1]s), 1), MAX(%[1]s) IS NOT NULL) FROM %[2]s",
+			db.Statement.Quote(field.DBName), db.Statement.Quote(sch.Table)),
+		sch.Table, field.DBName,
+	).Error; err != nil {
+		return fmt.Errorf("failed to reset identity sequence: %w", err)
+	}
+	return nil
+}
+
+// tableChecksum is a checksum of the rows of a table which does not depend on their order, as the primary keys of both databases
+// may not be sorted the same way. Each row is hashed from the normalized values of its columns, and the hashes of all rows are
+// combined by XOR, rows being unique by their primary key.
+type tableChecksum struct {
+	schema *schema.Schema
+	rows   int64
+	sum    [sha256.Size]byte
+}
+
+func newTableChecksum(sch *schema.Schema) *tableChecksum {
+	return &tableChecksum{schema: sch}
+}
+
+// add adds the given row, a pointer to a model, to the checksum.
+func (c *tableChecksum) add(ctx context.Context, row any) {
+	value := reflect.ValueOf(row).Elem()
+
+	hash := sha256.New()
+	for _, field := range c.schema.Fields {
+		if field.DBName == "" {
+			continue
+		}
+		v, _ := field.ValueOf(ctx, value)
+		fmt.Fprintf(hash, "%s=%s\x1f", field.DBName, checksumValue(v))
+	}
+
+	for i, b := range hash.Sum(nil) {
+		c.sum[i] ^= b
+	}
+	c.rows++
+}
+
+func (c *tableChecksum) String() string {
+	return hex.EncodeToString(c.sum[:])
+}
+
+// checksumValue formats the value of a column for the checksum. Times are compared in UTC at the microsecond precision of Postgres,
+// and values stored as another type, such as UUIDs, are compared as stored.
+func checksumValue(v any) string {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return "NULL"
+		}
+		return checksumValue(rv.Elem().Interface())
+	}
+
+	switch v := v.(type) {
+	case time.Time:
+		return v.UTC().Round(time.Microsecond).Format(time.RFC3339Nano)
+	case []byte:
+		return hex.EncodeToString(v)
+	case driver.Valuer:
+		value, err := v.Value()
+		if err != nil {
+			return fmt.Sprintf("error: %v", err)
+		}
+		return checksumValue(value)
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+var _ = Describe("Data migration", func() {
+	var src, dst *gorm.DB
+
+	open := func(name string) *gorm.DB {
+		conn, err := gorm.Open(sqlite.Open("file:" + name + "?mode=memory&cache=shared"))
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(func() {
+			sqlDB, err := conn.DB()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sqlDB.Close()).To(Succeed())
+		})
+
+		Expect(conn.AutoMigrate(
+			&models.Tenant{},
+			&models.AlertDefinition{},
+			&models.AlertDuration{},
+			&models.AlertThreshold{},
+			&models.EmailAddress{},
+			&models.EmailConfig{},
+			&models.Receiver{},
+			&models.EmailRecipient{},
+			&models.RecipientOffboarding{},
+			&models.EmailTemplate{},
+			&models.Task{},
+			&models.TaskHistory{},
+			&models.TaskHistoryRollup{},
+			&models.NotificationTask{},
+			&models.AlertComment{},
+			&models.EmailDelivery{},
+			&models.RuleEvaluation{},
+			&models.AlertReport{},
+			&models.AppliedArtifact{},
+			&models.AlertmanagerConfig{},
+		)).To(Succeed())
+		return conn
+	}
+
+	id := uuid.New()
+	created := time.Date(2025, 3, 10, 12, 0, 0, 123456000, time.FixedZone("CET", 3600))
+
+	BeforeEach(func() {
+		src = open("migrate-data-src")
+		dst = open("migrate-data-dst")
+
+		Expect(src.Create(&models.Tenant{TenantID: "edgenode", LastActivityDate: created}).Error).To(Succeed())
+		Expect(src.Create(&models.AlertDefinition{
+			ID:           7,
+			UUID:         id,
+			Version:      2,
+			Name:         "HostCPUUsageHigh",
+			State:        models.DefinitionApplied,
+			Category:     models.CategoryPerformance,
+			Severity:     "high",
+			TenantID:     "edgenode",
+			CreationDate: created,
+		}).Error).To(Succeed())
+		Expect(src.Create(&models.Task{
+			ID:                  3,
+			State:               models.TaskApplied,
+			AlertDefinitionUUID: &id,
+			TenantID:            "edgenode",
+			Version:             2,
+			CreationDate:        created,
+		}).Error).To(Succeed())
+	})
+
+	It("Copy and verify all tables", func() {
+		report := migrateData(context.Background(), src, dst, GinkgoT().TempDir())
+		Expect(report.Error).To(BeEmpty())
+		Expect(report.Success).To(BeTrue())
+		Expect(report.Tables).To(HaveLen(len(migratedTables)))
+		Expect(report.Tables[0].Table).To(Equal("tenants"))
+		Expect(report.Tables[0].Rows).To(Equal(int64(1)))
+		Expect(report.Tables[0].Checksum).To(HaveLen(64))
+
+		var definition models.AlertDefinition
+		Expect(dst.Take(&definition).Error).To(Succeed())
+		Expect(definition.ID).To(Equal(int64(7)))
+		Expect(definition.UUID).To(Equal(id))
+		Expect(definition.Enabled).To(BeFalse())
+		Expect(definition.CreationDate).To(BeTemporally("==", created))
+
+		var task models.Task
+		Expect(dst.Take(&task).Error).To(Succeed())
+		Expect(task.AlertDefinitionUUID).To(Equal(&id))
+		Expect(task.ReceiverUUID).To(BeNil())
+	})
+
+	It("Refuse to copy into a database holding data", func() {
+		Expect(dst.Create(&models.Tenant{TenantID: "other", LastActivityDate: created}).Error).To(Succeed())
+
+		report := migrateData(context.Background(), src, dst, GinkgoT().TempDir())
+		Expect(report.Success).To(BeFalse())
+		Expect(report.Tables).To(HaveLen(1))
+		Expect(report.Tables[0].Error).To(ContainSubstring("not empty"))
+
+		var count int64
+		Expect(dst.Model(&models.AlertDefinition{}).Count(&count).Error).To(Succeed())
+		Expect(count).To(BeZero())
+	})
+})
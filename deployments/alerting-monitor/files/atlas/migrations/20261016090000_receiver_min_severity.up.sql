@@ -0,0 +1,5 @@
+-- SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+-- SPDX-License-Identifier: Apache-2.0
+
+-- modify "receivers" table
+ALTER TABLE "public"."receivers" ADD COLUMN "min_severity" text NOT NULL DEFAULT '';
@@ -0,0 +1,5 @@
+-- SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+-- SPDX-License-Identifier: Apache-2.0
+
+-- modify "receivers" table
+ALTER TABLE "public"."receivers" ADD COLUMN "quiet_hours_start" text NOT NULL DEFAULT '', ADD COLUMN "quiet_hours_end" text NOT NULL DEFAULT '', ADD COLUMN "quiet_hours_location" text NOT NULL DEFAULT '';
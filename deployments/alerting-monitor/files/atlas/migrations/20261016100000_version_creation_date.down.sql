@@ -0,0 +1,7 @@
+-- SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+-- SPDX-License-Identifier: Apache-2.0
+
+-- reverse: modify "receivers" table
+ALTER TABLE "public"."receivers" DROP COLUMN "creation_date";
+-- reverse: modify "alert_definitions" table
+ALTER TABLE "public"."alert_definitions" DROP COLUMN "creation_date";
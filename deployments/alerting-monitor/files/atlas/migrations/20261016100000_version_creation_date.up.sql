@@ -0,0 +1,7 @@
+-- SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+-- SPDX-License-Identifier: Apache-2.0
+
+-- modify "alert_definitions" table
+ALTER TABLE "public"."alert_definitions" ADD COLUMN "creation_date" timestamp NULL DEFAULT CURRENT_TIMESTAMP;
+-- modify "receivers" table
+ALTER TABLE "public"."receivers" ADD COLUMN "creation_date" timestamp NULL DEFAULT CURRENT_TIMESTAMP;
@@ -0,0 +1,11 @@
+-- SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+-- SPDX-License-Identifier: Apache-2.0
+
+-- modify "alert_definitions" table
+ALTER TABLE "public"."alert_definitions" ADD COLUMN "applied_date" timestamp NULL;
+-- backfill "alert_definitions" applied dates from completed tasks
+UPDATE "public"."alert_definitions" AS d SET "applied_date" = t."completion_date" FROM "public"."tasks" AS t WHERE t."tenant_id" = d."tenant_id" AND t."alert_definition_uuid" = d."uuid" AND t."version" = d."version" AND t."state" = 'Applied';
+-- modify "receivers" table
+ALTER TABLE "public"."receivers" ADD COLUMN "applied_date" timestamp NULL;
+-- backfill "receivers" applied dates from completed tasks
+UPDATE "public"."receivers" AS r SET "applied_date" = t."completion_date" FROM "public"."tasks" AS t WHERE t."tenant_id" = r."tenant_id" AND t."receiver_uuid" = r."uuid" AND t."version" = r."version" AND t."state" = 'Applied';
@@ -0,0 +1,5 @@
+-- SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+-- SPDX-License-Identifier: Apache-2.0
+
+-- reverse: create "tenants" table
+DROP TABLE "public"."tenants";
@@ -0,0 +1,12 @@
+-- SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+-- SPDX-License-Identifier: Apache-2.0
+
+-- create "tenants" table
+CREATE TABLE "public"."tenants" (
+  "tenant_id" text NOT NULL,
+  "last_activity_date" timestamp NOT NULL,
+  "archived_date" timestamp NULL,
+  PRIMARY KEY ("tenant_id")
+);
+-- register existing tenants as active
+INSERT INTO "public"."tenants" ("tenant_id", "last_activity_date") SELECT DISTINCT "tenant_id", CURRENT_TIMESTAMP FROM "public"."alert_definitions";
@@ -0,0 +1,5 @@
+-- SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+-- SPDX-License-Identifier: Apache-2.0
+
+-- reverse: modify "alert_definitions" table
+ALTER TABLE "public"."alert_definitions" DROP COLUMN "threshold_auto_tuned", DROP COLUMN "auto_tune";
@@ -0,0 +1,5 @@
+-- SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+-- SPDX-License-Identifier: Apache-2.0
+
+-- modify "alert_definitions" table
+ALTER TABLE "public"."alert_definitions" ADD COLUMN "auto_tune" boolean NOT NULL DEFAULT false, ADD COLUMN "threshold_auto_tuned" boolean NOT NULL DEFAULT false;
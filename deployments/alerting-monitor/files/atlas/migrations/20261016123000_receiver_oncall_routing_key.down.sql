@@ -0,0 +1,5 @@
+-- SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+-- SPDX-License-Identifier: Apache-2.0
+
+-- reverse: modify "receivers" table
+ALTER TABLE "public"."receivers" DROP COLUMN "on_call_routing_key";
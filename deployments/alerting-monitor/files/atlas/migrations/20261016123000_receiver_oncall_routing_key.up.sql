@@ -0,0 +1,5 @@
+-- SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+-- SPDX-License-Identifier: Apache-2.0
+
+-- modify "receivers" table
+ALTER TABLE "public"."receivers" ADD COLUMN "on_call_routing_key" text NOT NULL DEFAULT '';
@@ -0,0 +1,5 @@
+-- SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+-- SPDX-License-Identifier: Apache-2.0
+
+-- reverse: modify "tasks" table
+ALTER TABLE "public"."tasks" DROP COLUMN "correlation_id";
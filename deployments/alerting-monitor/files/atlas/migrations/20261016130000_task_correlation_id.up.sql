@@ -0,0 +1,5 @@
+-- SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+-- SPDX-License-Identifier: Apache-2.0
+
+-- modify "tasks" table
+ALTER TABLE "public"."tasks" ADD COLUMN "correlation_id" text NOT NULL DEFAULT '';
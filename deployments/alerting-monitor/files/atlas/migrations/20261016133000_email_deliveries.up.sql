@@ -0,0 +1,19 @@
+-- SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+-- SPDX-License-Identifier: Apache-2.0
+
+-- create "email_deliveries" table
+CREATE TABLE "public"."email_deliveries" (
+  "id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+  "tenant_id" text NOT NULL,
+  "receiver_uuid" uuid NOT NULL,
+  "recipient" text NOT NULL,
+  "group_key" text NOT NULL DEFAULT '',
+  "alert_count" bigint NOT NULL DEFAULT 0,
+  "status" text NOT NULL,
+  "attempts" bigint NOT NULL DEFAULT 0,
+  "error" text NOT NULL DEFAULT '',
+  "creation_date" timestamp NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- create index "idx_email_deliveries_receiver" to table: "email_deliveries"
+CREATE INDEX "idx_email_deliveries_receiver" ON "public"."email_deliveries" ("tenant_id", "receiver_uuid", "creation_date");
@@ -0,0 +1,10 @@
+-- SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+-- SPDX-License-Identifier: Apache-2.0
+
+-- create "alertmanager_configs" table
+CREATE TABLE "public"."alertmanager_configs" (
+  "name" text NOT NULL,
+  "manifest" text NOT NULL,
+  "reload_date" timestamp NOT NULL,
+  PRIMARY KEY ("name")
+);
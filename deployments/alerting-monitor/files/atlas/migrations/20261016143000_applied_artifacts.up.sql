@@ -0,0 +1,15 @@
+-- SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+-- SPDX-License-Identifier: Apache-2.0
+
+-- create "applied_artifacts" table
+CREATE TABLE "public"."applied_artifacts" (
+  "id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+  "kind" text NOT NULL,
+  "name" text NOT NULL,
+  "hash" text NOT NULL,
+  "content" text NOT NULL,
+  "applied_date" timestamp NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- create index "idx_applied_artifacts_name" to table: "applied_artifacts"
+CREATE INDEX "idx_applied_artifacts_name" ON "public"."applied_artifacts" ("kind", "name", "applied_date");
@@ -0,0 +1,15 @@
+-- SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+-- SPDX-License-Identifier: Apache-2.0
+
+-- create "rule_evaluations" table
+CREATE TABLE "public"."rule_evaluations" (
+  "id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+  "tenant_id" text NOT NULL,
+  "alert_definition_uuid" uuid NOT NULL,
+  "duration_seconds" numeric NOT NULL,
+  "error" text NOT NULL DEFAULT '',
+  "evaluation_date" timestamp NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- create index "idx_rule_evaluations_definition" to table: "rule_evaluations"
+CREATE UNIQUE INDEX "idx_rule_evaluations_definition" ON "public"."rule_evaluations" ("tenant_id", "alert_definition_uuid", "evaluation_date");
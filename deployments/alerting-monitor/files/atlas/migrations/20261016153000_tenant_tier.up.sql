@@ -0,0 +1,5 @@
+-- SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+-- SPDX-License-Identifier: Apache-2.0
+
+-- modify "tenants" table
+ALTER TABLE "public"."tenants" ADD COLUMN "tier" text NOT NULL DEFAULT '';
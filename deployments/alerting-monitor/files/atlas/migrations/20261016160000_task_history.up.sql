@@ -0,0 +1,21 @@
+-- SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+-- SPDX-License-Identifier: Apache-2.0
+
+-- create "task_history" table
+CREATE TABLE "public"."task_history" (
+  "id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+  "tenant_id" text NOT NULL,
+  "type" text NOT NULL,
+  "uuid" uuid NOT NULL,
+  "version" bigint NOT NULL,
+  "state" text NOT NULL,
+  "owner_uuid" uuid NULL,
+  "correlation_id" text NOT NULL DEFAULT '',
+  "retry_count" bigint NOT NULL DEFAULT 0,
+  "creation_date" timestamp NOT NULL,
+  "start_date" timestamp NULL,
+  "completion_date" timestamp NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- create index "idx_task_history_entity" to table: "task_history"
+CREATE INDEX "idx_task_history_entity" ON "public"."task_history" ("tenant_id", "uuid", "completion_date");
@@ -0,0 +1,7 @@
+-- SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+-- SPDX-License-Identifier: Apache-2.0
+
+-- reverse: modify "alert_thresholds" table
+ALTER TABLE "public"."alert_thresholds" DROP COLUMN "threshold_default";
+-- reverse: modify "alert_durations" table
+ALTER TABLE "public"."alert_durations" DROP COLUMN "duration_default";
@@ -0,0 +1,16 @@
+-- SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+-- SPDX-License-Identifier: Apache-2.0
+
+-- modify "alert_durations" table
+ALTER TABLE "public"."alert_durations" ADD COLUMN "duration_default" bigint NULL;
+-- modify "alert_thresholds" table
+ALTER TABLE "public"."alert_thresholds" ADD COLUMN "threshold_default" bigint NULL;
+-- backfill the defaults with the values of the first version, which are the ones of the catalog
+UPDATE "public"."alert_durations" AS "d" SET "duration_default" = "first"."duration"
+FROM "public"."alert_definitions" AS "def", "public"."alert_definitions" AS "def1", "public"."alert_durations" AS "first"
+WHERE "d"."alert_definition_id" = "def"."id" AND "def1"."tenant_id" = "def"."tenant_id" AND "def1"."uuid" = "def"."uuid"
+  AND "def1"."version" = 1 AND "first"."alert_definition_id" = "def1"."id" AND "first"."name" = "d"."name";
+UPDATE "public"."alert_thresholds" AS "t" SET "threshold_default" = "first"."threshold"
+FROM "public"."alert_definitions" AS "def", "public"."alert_definitions" AS "def1", "public"."alert_thresholds" AS "first"
+WHERE "t"."alert_definition_id" = "def"."id" AND "def1"."tenant_id" = "def"."tenant_id" AND "def1"."uuid" = "def"."uuid"
+  AND "def1"."version" = 1 AND "first"."alert_definition_id" = "def1"."id" AND "first"."name" = "t"."name";
@@ -0,0 +1,5 @@
+-- SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+-- SPDX-License-Identifier: Apache-2.0
+
+-- reverse: modify "tenants" table
+ALTER TABLE "public"."tenants" DROP COLUMN "maintenance_silence_id", DROP COLUMN "maintenance_end", DROP COLUMN "maintenance_start";
@@ -0,0 +1,14 @@
+-- SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+-- SPDX-License-Identifier: Apache-2.0
+
+-- create "email_templates" table
+CREATE TABLE "public"."email_templates" (
+  "id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+  "tenant_id" text NOT NULL,
+  "version" bigint NOT NULL,
+  "content" text NOT NULL DEFAULT '',
+  "creation_date" timestamp NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- create index "idx_email_templates_version" to table: "email_templates"
+CREATE UNIQUE INDEX "idx_email_templates_version" ON "public"."email_templates" ("tenant_id", "version");
@@ -0,0 +1,16 @@
+-- SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+-- SPDX-License-Identifier: Apache-2.0
+
+-- create "alert_comments" table
+CREATE TABLE "public"."alert_comments" (
+  "id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+  "tenant_id" text NOT NULL,
+  "fingerprint" text NOT NULL,
+  "parent_id" bigint NULL,
+  "author" text NOT NULL DEFAULT '',
+  "content" text NOT NULL,
+  "creation_date" timestamp NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- create index "idx_alert_comments_fingerprint" to table: "alert_comments"
+CREATE INDEX "idx_alert_comments_fingerprint" ON "public"."alert_comments" ("tenant_id", "fingerprint");
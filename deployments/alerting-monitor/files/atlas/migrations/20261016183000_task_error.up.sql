@@ -0,0 +1,5 @@
+-- SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+-- SPDX-License-Identifier: Apache-2.0
+
+-- modify "tasks" table
+ALTER TABLE "public"."tasks" ADD COLUMN "error" text NOT NULL DEFAULT '';
@@ -0,0 +1,10 @@
+-- SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+-- SPDX-License-Identifier: Apache-2.0
+
+-- create "executors" table
+CREATE TABLE "public"."executors" (
+  "uuid" uuid NOT NULL,
+  "start_date" timestamp NOT NULL,
+  "heartbeat_date" timestamp NOT NULL,
+  PRIMARY KEY ("uuid")
+);
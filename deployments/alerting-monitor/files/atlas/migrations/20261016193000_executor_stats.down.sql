@@ -0,0 +1,5 @@
+-- SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+-- SPDX-License-Identifier: Apache-2.0
+
+-- reverse: modify "executors" table
+ALTER TABLE "public"."executors" DROP COLUMN "last_poll_date", DROP COLUMN "processed_tasks";
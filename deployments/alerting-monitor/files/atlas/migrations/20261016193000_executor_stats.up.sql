@@ -0,0 +1,5 @@
+-- SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+-- SPDX-License-Identifier: Apache-2.0
+
+-- modify "executors" table
+ALTER TABLE "public"."executors" ADD COLUMN "processed_tasks" bigint NOT NULL DEFAULT 0, ADD COLUMN "last_poll_date" timestamp NULL;
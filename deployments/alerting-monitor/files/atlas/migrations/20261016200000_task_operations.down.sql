@@ -0,0 +1,11 @@
+-- SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+-- SPDX-License-Identifier: Apache-2.0
+
+-- reverse: create index "idx_task_history_operation_id" to table: "task_history"
+DROP INDEX "public"."idx_task_history_operation_id";
+-- reverse: modify "task_history" table
+ALTER TABLE "public"."task_history" DROP COLUMN "error", DROP COLUMN "operation_id";
+-- reverse: create index "idx_tasks_operation_id" to table: "tasks"
+DROP INDEX "public"."idx_tasks_operation_id";
+-- reverse: modify "tasks" table
+ALTER TABLE "public"."tasks" DROP COLUMN "operation_id";
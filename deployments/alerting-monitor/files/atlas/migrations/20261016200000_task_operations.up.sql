@@ -0,0 +1,11 @@
+-- SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+-- SPDX-License-Identifier: Apache-2.0
+
+-- modify "tasks" table
+ALTER TABLE "public"."tasks" ADD COLUMN "operation_id" uuid NULL;
+-- create index "idx_tasks_operation_id" to table: "tasks"
+CREATE UNIQUE INDEX "idx_tasks_operation_id" ON "public"."tasks" ("operation_id");
+-- modify "task_history" table
+ALTER TABLE "public"."task_history" ADD COLUMN "operation_id" uuid NULL, ADD COLUMN "error" text NOT NULL DEFAULT '';
+-- create index "idx_task_history_operation_id" to table: "task_history"
+CREATE INDEX "idx_task_history_operation_id" ON "public"."task_history" ("operation_id");
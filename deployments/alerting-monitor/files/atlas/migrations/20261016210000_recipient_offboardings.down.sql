@@ -0,0 +1,7 @@
+-- SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+-- SPDX-License-Identifier: Apache-2.0
+
+-- reverse: create index "idx_recipient_offboardings_email" to table: "recipient_offboardings"
+DROP INDEX "public"."idx_recipient_offboardings_email";
+-- reverse: create "recipient_offboardings" table
+DROP TABLE "public"."recipient_offboardings";
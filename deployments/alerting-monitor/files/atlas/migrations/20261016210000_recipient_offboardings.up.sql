@@ -0,0 +1,16 @@
+-- SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+-- SPDX-License-Identifier: Apache-2.0
+
+-- create "recipient_offboardings" table
+CREATE TABLE "public"."recipient_offboardings" (
+  "id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+  "email" text NOT NULL,
+  "tenant_id" text NOT NULL,
+  "receiver_uuid" uuid NOT NULL,
+  "receiver_name" text NOT NULL,
+  "version" bigint NOT NULL,
+  "creation_date" timestamp NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- create index "idx_recipient_offboardings_email" to table: "recipient_offboardings"
+CREATE INDEX "idx_recipient_offboardings_email" ON "public"."recipient_offboardings" ("email");
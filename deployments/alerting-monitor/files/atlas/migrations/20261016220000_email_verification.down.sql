@@ -0,0 +1,5 @@
+-- SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+-- SPDX-License-Identifier: Apache-2.0
+
+-- reverse: modify "email_addresses" table
+ALTER TABLE "public"."email_addresses" DROP COLUMN "verification_sent_date", DROP COLUMN "verification_pending";
@@ -0,0 +1,5 @@
+-- SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+-- SPDX-License-Identifier: Apache-2.0
+
+-- modify "email_addresses" table
+ALTER TABLE "public"."email_addresses" ADD COLUMN "verification_pending" boolean NOT NULL DEFAULT false, ADD COLUMN "verification_sent_date" timestamp NULL;
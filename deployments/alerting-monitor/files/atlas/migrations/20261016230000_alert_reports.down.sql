@@ -0,0 +1,9 @@
+-- SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+-- SPDX-License-Identifier: Apache-2.0
+
+-- reverse: create index "idx_alert_reports_period" to table: "alert_reports"
+DROP INDEX "public"."idx_alert_reports_period";
+-- reverse: create "alert_reports" table
+DROP TABLE "public"."alert_reports";
+-- reverse: modify "alert_comments" table
+ALTER TABLE "public"."alert_comments" DROP COLUMN "alert_starts_at";
@@ -0,0 +1,17 @@
+-- SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+-- SPDX-License-Identifier: Apache-2.0
+
+-- modify "alert_comments" table
+ALTER TABLE "public"."alert_comments" ADD COLUMN "alert_starts_at" timestamp NULL;
+-- create "alert_reports" table
+CREATE TABLE "public"."alert_reports" (
+  "id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+  "tenant_id" text NOT NULL,
+  "period_start" timestamp NOT NULL,
+  "period_end" timestamp NOT NULL,
+  "content" text NOT NULL,
+  "creation_date" timestamp NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- create index "idx_alert_reports_period" to table: "alert_reports"
+CREATE UNIQUE INDEX "idx_alert_reports_period" ON "public"."alert_reports" ("tenant_id", "period_start");
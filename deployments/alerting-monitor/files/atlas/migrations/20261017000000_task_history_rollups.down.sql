@@ -0,0 +1,7 @@
+-- SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+-- SPDX-License-Identifier: Apache-2.0
+
+-- reverse: create index "idx_task_history_rollups_day" to table: "task_history_rollups"
+DROP INDEX "public"."idx_task_history_rollups_day";
+-- reverse: create "task_history_rollups" table
+DROP TABLE "public"."task_history_rollups";
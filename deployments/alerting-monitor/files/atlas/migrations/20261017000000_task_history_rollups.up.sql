@@ -0,0 +1,21 @@
+-- SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+-- SPDX-License-Identifier: Apache-2.0
+
+-- create "task_history_rollups" table
+CREATE TABLE "public"."task_history_rollups" (
+  "id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+  "tenant_id" text NOT NULL,
+  "type" text NOT NULL,
+  "uuid" uuid NOT NULL,
+  "day" timestamp NOT NULL,
+  "applied" bigint NOT NULL DEFAULT 0,
+  "invalid" bigint NOT NULL DEFAULT 0,
+  "retries" bigint NOT NULL DEFAULT 0,
+  "last_version" bigint NOT NULL DEFAULT 0,
+  "total_queue_duration" bigint NOT NULL DEFAULT 0,
+  "total_apply_duration" bigint NOT NULL DEFAULT 0,
+  "max_apply_duration" bigint NOT NULL DEFAULT 0,
+  PRIMARY KEY ("id")
+);
+-- create index "idx_task_history_rollups_day" to table: "task_history_rollups"
+CREATE UNIQUE INDEX "idx_task_history_rollups_day" ON "public"."task_history_rollups" ("tenant_id", "uuid", "day");
@@ -0,0 +1,7 @@
+-- SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+-- SPDX-License-Identifier: Apache-2.0
+
+-- reverse: create index "idx_notification_tasks_pending" to table: "notification_tasks"
+DROP INDEX "public"."idx_notification_tasks_pending";
+-- reverse: create "notification_tasks" table
+DROP TABLE "public"."notification_tasks";
@@ -0,0 +1,23 @@
+-- SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+-- SPDX-License-Identifier: Apache-2.0
+
+-- create "notification_tasks" table
+CREATE TABLE "public"."notification_tasks" (
+  "id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+  "owner_uuid" uuid NULL,
+  "state" text NOT NULL,
+  "tenant_id" text NOT NULL,
+  "receiver_uuid" uuid NOT NULL,
+  "channel" text NOT NULL,
+  "recipient" text NOT NULL DEFAULT '',
+  "payload" text NOT NULL,
+  "retry_count" bigint NOT NULL DEFAULT 0,
+  "error" text NOT NULL DEFAULT '',
+  "creation_date" timestamp NOT NULL,
+  "next_attempt_date" timestamp NOT NULL,
+  "start_date" timestamp NULL,
+  "completion_date" timestamp NULL,
+  PRIMARY KEY ("id")
+);
+-- create index "idx_notification_tasks_pending" to table: "notification_tasks"
+CREATE INDEX "idx_notification_tasks_pending" ON "public"."notification_tasks" ("state", "next_attempt_date");
@@ -0,0 +1,5 @@
+-- SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+-- SPDX-License-Identifier: Apache-2.0
+
+-- reverse: modify "applied_artifacts" table
+ALTER TABLE "public"."applied_artifacts" DROP COLUMN "key_id";
@@ -0,0 +1,5 @@
+-- SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+-- SPDX-License-Identifier: Apache-2.0
+
+-- modify "applied_artifacts" table
+ALTER TABLE "public"."applied_artifacts" ADD COLUMN "key_id" text NOT NULL DEFAULT '';
@@ -0,0 +1,17 @@
+-- SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+-- SPDX-License-Identifier: Apache-2.0
+
+-- create "event_hooks" table
+CREATE TABLE "public"."event_hooks" (
+  "id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+  "uuid" uuid NOT NULL,
+  "tenant_id" text NOT NULL,
+  "url" text NOT NULL,
+  "events" text NOT NULL DEFAULT '',
+  "creation_date" timestamp NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- create index "idx_event_hooks_uuid" to table: "event_hooks"
+CREATE UNIQUE INDEX "idx_event_hooks_uuid" ON "public"."event_hooks" ("uuid");
+-- create index "idx_event_hooks_tenant_id" to table: "event_hooks"
+CREATE INDEX "idx_event_hooks_tenant_id" ON "public"."event_hooks" ("tenant_id");
@@ -0,0 +1,5 @@
+-- SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+-- SPDX-License-Identifier: Apache-2.0
+
+-- reverse: pin "tenants" tracked before sharding to the first shard
+-- the pinned shards are kept, as they cannot be told apart from the ones assigned to new tenants
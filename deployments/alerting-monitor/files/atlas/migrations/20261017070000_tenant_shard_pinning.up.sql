@@ -0,0 +1,5 @@
+-- SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+-- SPDX-License-Identifier: Apache-2.0
+
+-- pin "tenants" tracked before sharding to the first shard, which holds their receivers
+UPDATE "public"."tenants" SET "alertmanager_shard" = 0 WHERE "alertmanager_shard" IS NULL;
@@ -11,6 +11,20 @@ CREATE TYPE "public"."alert_definition_state" AS ENUM ('New', 'Modified', 'Pendi
 CREATE TYPE "public"."receiver_state" AS ENUM ('New', 'Modified', 'Pending', 'Applied', 'Error');
 -- Create enum type "task_state"
 CREATE TYPE "public"."task_state" AS ENUM ('New', 'Taken', 'Applied', 'Error', 'Invalid');
+-- Create "alert_comments" table
+CREATE TABLE "public"."alert_comments" (
+  "id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+  "tenant_id" text NOT NULL,
+  "fingerprint" text NOT NULL,
+  "parent_id" bigint NULL,
+  "author" text NOT NULL DEFAULT '',
+  "content" text NOT NULL,
+  "creation_date" timestamp NOT NULL,
+  "alert_starts_at" timestamp NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_alert_comments_fingerprint" to table: "alert_comments"
+CREATE INDEX "idx_alert_comments_fingerprint" ON "public"."alert_comments" ("tenant_id", "fingerprint");
 -- Create "alert_definitions" table
 CREATE TABLE "public"."alert_definitions" (
   "id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY,
@@ -25,6 +39,10 @@ CREATE TABLE "public"."alert_definitions" (
   "severity" text NULL,
   "alert_interval" bigint NULL,
   "tenant_id" text NOT NULL DEFAULT 'edgenode',
+  "creation_date" timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  "applied_date" timestamp NULL,
+  "auto_tune" boolean NOT NULL DEFAULT false,
+  "threshold_auto_tuned" boolean NOT NULL DEFAULT false,
   PRIMARY KEY ("id"),
   CONSTRAINT "alert_definitions_name_severity_version_tenant_key" UNIQUE ("name", "severity", "version", "tenant_id"),
   CONSTRAINT "alert_definitions_uuid_version_tenant_key" UNIQUE ("uuid", "version", "tenant_id")
@@ -37,10 +55,23 @@ CREATE TABLE "public"."alert_durations" (
   "duration_min" bigint NULL,
   "duration_max" bigint NULL,
   "alert_definition_id" bigint NOT NULL,
+  "duration_default" bigint NULL,
   PRIMARY KEY ("id"),
   CONSTRAINT "alert_durations_alert_definition_id_name_key" UNIQUE ("alert_definition_id", "name"),
   CONSTRAINT "alert_durations_alert_definition_id_fkey" FOREIGN KEY ("alert_definition_id") REFERENCES "public"."alert_definitions" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION
 );
+-- Create "alert_reports" table
+CREATE TABLE "public"."alert_reports" (
+  "id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+  "tenant_id" text NOT NULL,
+  "period_start" timestamp NOT NULL,
+  "period_end" timestamp NOT NULL,
+  "content" text NOT NULL,
+  "creation_date" timestamp NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_alert_reports_period" to table: "alert_reports"
+CREATE UNIQUE INDEX "idx_alert_reports_period" ON "public"."alert_reports" ("tenant_id", "period_start");
 -- Create "alert_thresholds" table
 CREATE TABLE "public"."alert_thresholds" (
   "id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY,
@@ -51,16 +82,39 @@ CREATE TABLE "public"."alert_thresholds" (
   "threshold_type" text NULL,
   "threshold_unit" text NULL,
   "alert_definition_id" bigint NOT NULL,
+  "threshold_default" bigint NULL,
   PRIMARY KEY ("id"),
   CONSTRAINT "alert_thresholds_alert_definition_id_name_key" UNIQUE ("alert_definition_id", "name"),
   CONSTRAINT "alert_thresholds_alert_definition_id_fkey" FOREIGN KEY ("alert_definition_id") REFERENCES "public"."alert_definitions" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION
 );
+-- Create "alertmanager_configs" table
+CREATE TABLE "public"."alertmanager_configs" (
+  "name" text NOT NULL,
+  "manifest" text NOT NULL,
+  "reload_date" timestamp NOT NULL,
+  PRIMARY KEY ("name")
+);
+-- Create "applied_artifacts" table
+CREATE TABLE "public"."applied_artifacts" (
+  "id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+  "kind" text NOT NULL,
+  "name" text NOT NULL,
+  "hash" text NOT NULL,
+  "content" text NOT NULL,
+  "applied_date" timestamp NOT NULL,
+  "key_id" text NOT NULL DEFAULT '',
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_applied_artifacts_name" to table: "applied_artifacts"
+CREATE INDEX "idx_applied_artifacts_name" ON "public"."applied_artifacts" ("kind", "name", "applied_date");
 -- Create "email_addresses" table
 CREATE TABLE "public"."email_addresses" (
   "id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY,
   "email" text NOT NULL,
   "first_name" text NOT NULL,
   "last_name" text NOT NULL,
+  "verification_pending" boolean NOT NULL DEFAULT false,
+  "verification_sent_date" timestamp NULL,
   PRIMARY KEY ("id"),
   CONSTRAINT "email_addresses_email_key" UNIQUE ("email")
 );
@@ -72,6 +126,76 @@ CREATE TABLE "public"."email_configs" (
   PRIMARY KEY ("id"),
   CONSTRAINT "email_configs_from_fkey" FOREIGN KEY ("from") REFERENCES "public"."email_addresses" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION
 );
+-- Create "email_deliveries" table
+CREATE TABLE "public"."email_deliveries" (
+  "id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+  "tenant_id" text NOT NULL,
+  "receiver_uuid" uuid NOT NULL,
+  "recipient" text NOT NULL,
+  "group_key" text NOT NULL DEFAULT '',
+  "alert_count" bigint NOT NULL DEFAULT 0,
+  "status" text NOT NULL,
+  "attempts" bigint NOT NULL DEFAULT 0,
+  "error" text NOT NULL DEFAULT '',
+  "creation_date" timestamp NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_email_deliveries_receiver" to table: "email_deliveries"
+CREATE INDEX "idx_email_deliveries_receiver" ON "public"."email_deliveries" ("tenant_id", "receiver_uuid", "creation_date");
+-- Create "email_templates" table
+CREATE TABLE "public"."email_templates" (
+  "id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+  "tenant_id" text NOT NULL,
+  "version" bigint NOT NULL,
+  "content" text NOT NULL DEFAULT '',
+  "creation_date" timestamp NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_email_templates_version" to table: "email_templates"
+CREATE UNIQUE INDEX "idx_email_templates_version" ON "public"."email_templates" ("tenant_id", "version");
+-- Create "event_hooks" table
+CREATE TABLE "public"."event_hooks" (
+  "id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+  "uuid" uuid NOT NULL,
+  "tenant_id" text NOT NULL,
+  "url" text NOT NULL,
+  "events" text NOT NULL DEFAULT '',
+  "creation_date" timestamp NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_event_hooks_tenant_id" to table: "event_hooks"
+CREATE INDEX "idx_event_hooks_tenant_id" ON "public"."event_hooks" ("tenant_id");
+-- Create index "idx_event_hooks_uuid" to table: "event_hooks"
+CREATE UNIQUE INDEX "idx_event_hooks_uuid" ON "public"."event_hooks" ("uuid");
+-- Create "executors" table
+CREATE TABLE "public"."executors" (
+  "uuid" uuid NOT NULL,
+  "start_date" timestamp NOT NULL,
+  "heartbeat_date" timestamp NOT NULL,
+  "processed_tasks" bigint NOT NULL DEFAULT 0,
+  "last_poll_date" timestamp NULL,
+  PRIMARY KEY ("uuid")
+);
+-- Create "notification_tasks" table
+CREATE TABLE "public"."notification_tasks" (
+  "id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+  "owner_uuid" uuid NULL,
+  "state" text NOT NULL,
+  "tenant_id" text NOT NULL,
+  "receiver_uuid" uuid NOT NULL,
+  "channel" text NOT NULL,
+  "recipient" text NOT NULL DEFAULT '',
+  "payload" text NOT NULL,
+  "retry_count" bigint NOT NULL DEFAULT 0,
+  "error" text NOT NULL DEFAULT '',
+  "creation_date" timestamp NOT NULL,
+  "next_attempt_date" timestamp NOT NULL,
+  "start_date" timestamp NULL,
+  "completion_date" timestamp NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_notification_tasks_pending" to table: "notification_tasks"
+CREATE INDEX "idx_notification_tasks_pending" ON "public"."notification_tasks" ("state", "next_attempt_date");
 -- Create "receivers" table
 CREATE TABLE "public"."receivers" (
   "id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY,
@@ -81,6 +205,16 @@ CREATE TABLE "public"."receivers" (
   "version" bigint NOT NULL,
   "email_config_id" bigint NOT NULL,
   "tenant_id" text NOT NULL DEFAULT 'edgenode',
+  "min_severity" text NOT NULL DEFAULT '',
+  "quiet_hours_start" text NOT NULL DEFAULT '',
+  "quiet_hours_end" text NOT NULL DEFAULT '',
+  "quiet_hours_location" text NOT NULL DEFAULT '',
+  "creation_date" timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  "applied_date" timestamp NULL,
+  "on_call_routing_key" text NOT NULL DEFAULT '',
+  "language" text NOT NULL DEFAULT '',
+  "disabled" boolean NOT NULL DEFAULT false,
+  "matchers" text NOT NULL DEFAULT '',
   PRIMARY KEY ("id"),
   CONSTRAINT "receivers_name_version_tenant_key" UNIQUE ("name", "version", "tenant_id"),
   CONSTRAINT "receivers_uuid_version_tenant_key" UNIQUE ("uuid", "version", "tenant_id"),
@@ -96,6 +230,71 @@ CREATE TABLE "public"."email_recipients" (
   CONSTRAINT "email_recipients_email_address_id_fkey" FOREIGN KEY ("email_address_id") REFERENCES "public"."email_addresses" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION,
   CONSTRAINT "email_recipients_receiver_id_fkey" FOREIGN KEY ("receiver_id") REFERENCES "public"."receivers" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION
 );
+-- Create "recipient_offboardings" table
+CREATE TABLE "public"."recipient_offboardings" (
+  "id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+  "email" text NOT NULL,
+  "tenant_id" text NOT NULL,
+  "receiver_uuid" uuid NOT NULL,
+  "receiver_name" text NOT NULL,
+  "version" bigint NOT NULL,
+  "creation_date" timestamp NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_recipient_offboardings_email" to table: "recipient_offboardings"
+CREATE INDEX "idx_recipient_offboardings_email" ON "public"."recipient_offboardings" ("email");
+-- Create "rule_evaluations" table
+CREATE TABLE "public"."rule_evaluations" (
+  "id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+  "tenant_id" text NOT NULL,
+  "alert_definition_uuid" uuid NOT NULL,
+  "duration_seconds" numeric NOT NULL,
+  "error" text NOT NULL DEFAULT '',
+  "evaluation_date" timestamp NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_rule_evaluations_definition" to table: "rule_evaluations"
+CREATE UNIQUE INDEX "idx_rule_evaluations_definition" ON "public"."rule_evaluations" ("tenant_id", "alert_definition_uuid", "evaluation_date");
+-- Create "task_history" table
+CREATE TABLE "public"."task_history" (
+  "id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+  "tenant_id" text NOT NULL,
+  "type" text NOT NULL,
+  "uuid" uuid NOT NULL,
+  "version" bigint NOT NULL,
+  "state" text NOT NULL,
+  "owner_uuid" uuid NULL,
+  "correlation_id" text NOT NULL DEFAULT '',
+  "retry_count" bigint NOT NULL DEFAULT 0,
+  "creation_date" timestamp NOT NULL,
+  "start_date" timestamp NULL,
+  "completion_date" timestamp NOT NULL,
+  "operation_id" uuid NULL,
+  "error" text NOT NULL DEFAULT '',
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_task_history_entity" to table: "task_history"
+CREATE INDEX "idx_task_history_entity" ON "public"."task_history" ("tenant_id", "uuid", "completion_date");
+-- Create index "idx_task_history_operation_id" to table: "task_history"
+CREATE INDEX "idx_task_history_operation_id" ON "public"."task_history" ("operation_id");
+-- Create "task_history_rollups" table
+CREATE TABLE "public"."task_history_rollups" (
+  "id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+  "tenant_id" text NOT NULL,
+  "type" text NOT NULL,
+  "uuid" uuid NOT NULL,
+  "day" timestamp NOT NULL,
+  "applied" bigint NOT NULL DEFAULT 0,
+  "invalid" bigint NOT NULL DEFAULT 0,
+  "retries" bigint NOT NULL DEFAULT 0,
+  "last_version" bigint NOT NULL DEFAULT 0,
+  "total_queue_duration" bigint NOT NULL DEFAULT 0,
+  "total_apply_duration" bigint NOT NULL DEFAULT 0,
+  "max_apply_duration" bigint NOT NULL DEFAULT 0,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_task_history_rollups_day" to table: "task_history_rollups"
+CREATE UNIQUE INDEX "idx_task_history_rollups_day" ON "public"."task_history_rollups" ("tenant_id", "uuid", "day");
 -- Create "tasks" table
 CREATE TABLE "public"."tasks" (
   "id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY,
@@ -109,6 +308,9 @@ CREATE TABLE "public"."tasks" (
   "start_date" timestamp NULL,
   "completion_date" timestamp NULL,
   "retry_count" bigint NULL DEFAULT 0,
+  "correlation_id" text NOT NULL DEFAULT '',
+  "error" text NOT NULL DEFAULT '',
+  "operation_id" uuid NULL,
   PRIMARY KEY ("id"),
   CONSTRAINT "tasks_tenant_id_alert_definition_uuid_version_key" UNIQUE ("tenant_id", "alert_definition_uuid", "version"),
   CONSTRAINT "tasks_tenant_id_receiver_uuid_version_key" UNIQUE ("tenant_id", "receiver_uuid", "version"),
@@ -116,3 +318,17 @@ CREATE TABLE "public"."tasks" (
   CONSTRAINT "tasks_tenant_id_receiver_uuid_version_fkey" FOREIGN KEY ("tenant_id", "receiver_uuid", "version") REFERENCES "public"."receivers" ("tenant_id", "uuid", "version") ON UPDATE NO ACTION ON DELETE NO ACTION,
   CONSTRAINT "tasks_check" CHECK (((alert_definition_uuid IS NULL) AND (receiver_uuid IS NOT NULL)) OR ((alert_definition_uuid IS NOT NULL) AND (receiver_uuid IS NULL)))
 );
+-- Create index "idx_tasks_operation_id" to table: "tasks"
+CREATE UNIQUE INDEX "idx_tasks_operation_id" ON "public"."tasks" ("operation_id");
+-- Create "tenants" table
+CREATE TABLE "public"."tenants" (
+  "tenant_id" text NOT NULL,
+  "last_activity_date" timestamp NOT NULL,
+  "archived_date" timestamp NULL,
+  "alertmanager_shard" bigint NULL,
+  "tier" text NOT NULL DEFAULT '',
+  "maintenance_start" timestamp NULL,
+  "maintenance_end" timestamp NULL,
+  "maintenance_silence_id" text NOT NULL DEFAULT '',
+  PRIMARY KEY ("tenant_id")
+);
@@ -14,13 +14,15 @@ get_valid_roles(s) := roleNames if {
 	roleNames := [s, projectRoleName]
 }
 
-# alrt-r and <project-id>_alrt-r should allow to read api/v1/alerts and api/v1/alerts/definitions
+# alrt-r and <project-id>_alrt-r should allow to read api/v1/alerts, api/v1/alerts/by-resource, api/v1/alerts/<fingerprint>,
+# api/v1/alerts/definitions, api/v1/operations/<uuid>, api/v1/reports and the alertmanager compatible endpoints under
+# compat/alertmanager
 allow_alrt_r if {
     allowed := get_valid_roles("alrt-r")
     some role in input.roles
 	role in allowed
 	input.method == "GET"
-	input.path == ["api", "v1", "alerts"]
+	input.path in [["api", "v1", "alerts"], ["api", "v1", "alerts", "by-resource"]]
 }
 
 allow_alrt_r if {
@@ -31,13 +33,88 @@ allow_alrt_r if {
 	array.slice(input.path, 0, 4) == ["api", "v1", "alerts", "definitions"]
 }
 
-# alrt-rw and <project-id>_alrt-rw should allow to read and write to api/v1/alerts and api/v1/alerts/definitions
+allow_alrt_r if {
+    allowed := get_valid_roles("alrt-r")
+    some role in input.roles
+	role in allowed
+	input.method == "GET"
+	count(input.path) == 4
+	array.slice(input.path, 0, 3) == ["api", "v1", "alerts"]
+	regex.match(`^[0-9a-f]{16}$`, input.path[3])
+}
+
+allow_alrt_r if {
+    allowed := get_valid_roles("alrt-r")
+    some role in input.roles
+	role in allowed
+	input.method == "GET"
+	array.slice(input.path, 0, 2) == ["compat", "alertmanager"]
+}
+
+allow_alrt_r if {
+    allowed := get_valid_roles("alrt-r")
+    some role in input.roles
+	role in allowed
+	input.method == "GET"
+	count(input.path) == 4
+	array.slice(input.path, 0, 3) == ["api", "v1", "operations"]
+}
+
+allow_alrt_r if {
+    allowed := get_valid_roles("alrt-r")
+    some role in input.roles
+	role in allowed
+	input.method == "GET"
+	count(input.path) in [3, 4]
+	array.slice(input.path, 0, 3) == ["api", "v1", "reports"]
+}
+
+# alrt-rw and <project-id>_alrt-rw should allow to read api/v1/alerts and api/v1/alerts/by-resource, to push to
+# api/v1/alerts/external, to set api/v1/alerts/maintenance-mode, to read and comment api/v1/alerts/<fingerprint>, to read and write to api/v1/alerts/definitions and the alertmanager compatible endpoints under
+# compat/alertmanager, to read api/v1/operations/<uuid> and api/v1/reports, and to manage the event hooks under api/v1/hooks
 allow_alrt_rw if {
     allowed := get_valid_roles("alrt-rw")
     some role in input.roles
 	role in allowed
 	input.method == "GET"
-	input.path == ["api", "v1", "alerts"]
+	input.path in [["api", "v1", "alerts"], ["api", "v1", "alerts", "by-resource"]]
+}
+
+allow_alrt_rw if {
+    allowed := get_valid_roles("alrt-rw")
+    some role in input.roles
+	role in allowed
+	input.method == "POST"
+	input.path == ["api", "v1", "alerts", "external"]
+}
+
+allow_alrt_rw if {
+    allowed := get_valid_roles("alrt-rw")
+    some role in input.roles
+	role in allowed
+	input.method == "PUT"
+	input.path == ["api", "v1", "alerts", "maintenance-mode"]
+}
+
+allow_alrt_rw if {
+    allowed := get_valid_roles("alrt-rw")
+    some role in input.roles
+	role in allowed
+	input.method == "GET"
+	count(input.path) == 4
+	array.slice(input.path, 0, 3) == ["api", "v1", "alerts"]
+	regex.match(`^[0-9a-f]{16}$`, input.path[3])
+}
+
+allow_alrt_rw if {
+    allowed := get_valid_roles("alrt-rw")
+    some role in input.roles
+	role in allowed
+	input.method == "POST"
+	count(input.path) == 5
+	array.slice(input.path, 0, 3) == ["api", "v1", "alerts"]
+	regex.match(`^[0-9a-f]{16}$`, input.path[3])
+	input.path[4] == "comments"
 }
 
 allow_alrt_rw if {
@@ -48,10 +125,107 @@ allow_alrt_rw if {
 	array.slice(input.path, 0, 4) == ["api", "v1", "alerts", "definitions"]
 }
 
-# alrt-rx-rw should allow to read and write to api/v1/alerts/receivers
+allow_alrt_rw if {
+    allowed := get_valid_roles("alrt-rw")
+    some role in input.roles
+	role in allowed
+	input.method == "POST"
+	array.slice(input.path, 0, 4) == ["api", "v1", "alerts", "definitions"]
+	some method in [":evaluate", ":resetDefaults", ":simulate"]
+	endswith(input.path[4], method)
+}
+
+allow_alrt_rw if {
+    allowed := get_valid_roles("alrt-rw")
+    some role in input.roles
+	role in allowed
+	input.method in ["GET", "POST", "DELETE"]
+	array.slice(input.path, 0, 2) == ["compat", "alertmanager"]
+}
+
+allow_alrt_rw if {
+    allowed := get_valid_roles("alrt-rw")
+    some role in input.roles
+	role in allowed
+	input.method == "GET"
+	count(input.path) == 4
+	array.slice(input.path, 0, 3) == ["api", "v1", "operations"]
+}
+
+allow_alrt_rw if {
+    allowed := get_valid_roles("alrt-rw")
+    some role in input.roles
+	role in allowed
+	input.method == "GET"
+	count(input.path) in [3, 4]
+	array.slice(input.path, 0, 3) == ["api", "v1", "reports"]
+}
+
+allow_alrt_rw if {
+    allowed := get_valid_roles("alrt-rw")
+    some role in input.roles
+	role in allowed
+	input.method in ["GET", "POST", "PATCH", "DELETE"]
+	count(input.path) in [3, 4]
+	array.slice(input.path, 0, 3) == ["api", "v1", "hooks"]
+}
+
+# alrt-rx-rw should allow to read and write to api/v1/alerts/receivers and api/v1/alerts/email-template, to sync recipients
+# with api/v1/alerts/receivers:syncRecipients, to read api/v1/operations/<uuid>, and along with alrt-r or alrt-rw to compare
+# configuration bundles with api/v1/diff, as they hold both alert definitions and receivers
 allow_alert_rx_rw if {
     some role in input.roles
 	role == "alrt-rx-rw"
     input.method in ["GET", "PATCH"]
 	array.slice(input.path, 0, 4) == ["api", "v1", "alerts", "receivers"]
 }
+
+allow_alert_rx_rw if {
+    some role in input.roles
+	role == "alrt-rx-rw"
+    input.method == "POST"
+	input.path == ["api", "v1", "alerts", "receivers:syncRecipients"]
+}
+
+allow_alert_rx_rw if {
+    some role in input.roles
+	role == "alrt-rx-rw"
+    input.method in ["GET", "PUT"]
+	input.path == ["api", "v1", "alerts", "email-template"]
+}
+
+allow_alert_rx_rw if {
+    some role in input.roles
+	role == "alrt-rx-rw"
+    input.method == "GET"
+	count(input.path) == 4
+	array.slice(input.path, 0, 3) == ["api", "v1", "operations"]
+}
+
+allow_alert_rx_rw if {
+    some role in input.roles
+	role == "alrt-rx-rw"
+	some definitions_role in input.roles
+	definitions_role in array.concat(get_valid_roles("alrt-r"), get_valid_roles("alrt-rw"))
+    input.method == "POST"
+	input.path == ["api", "v1", "diff"]
+}
+
+# alrt-admin should allow to access the debug endpoints under debug/*, it is not granted by project roles
+allow_alrt_admin if {
+    some role in input.roles
+	role == "alrt-admin"
+	input.method in ["GET", "POST", "PUT"]
+	input.path[0] == "debug"
+}
+
+# alrt-admin and <project-id>_alrt-admin should allow to override the sender address and mail server of receivers with PATCH
+# api/v1/alerts/receivers/*, it is only queried by alerting monitor along with the update of a receiver overriding them
+allow_email_override if {
+    allowed := get_valid_roles("alrt-admin")
+    some role in input.roles
+	role in allowed
+	input.emailOverride == true
+	input.method == "PATCH"
+	array.slice(input.path, 0, 4) == ["api", "v1", "alerts", "receivers"]
+}
@@ -46,3 +46,12 @@ allow_alert_receivers_write if {
 	input.method == "PATCH"
 	array.slice(input.path, 0, 5) == ["edgenode", "api", "v1", "alerts", "receivers"]
 }
+
+allow_debug if {
+	# alerts admin role
+	# allows access to the debug endpoints under debug/*
+	some role in input.roles
+	role == "alerts-admin-role"
+	input.method in ["GET", "POST"]
+	input.path[0] == "debug"
+}
@@ -15,12 +15,99 @@ get_valid_roles(s) := roleNames if {
 
 allow_alerts_read if {
 	# alerts read role
-	# allows access to api/v1/alerts only
+	# allows access to api/v1/alerts and api/v1/alerts/by-resource only
 	authorizedRoles := get_valid_roles("alerts-read-role")
 	some role in input.roles
 	role in authorizedRoles
 	input.method == "GET"
-	input.path == ["api", "v1", "alerts"]
+	input.path in [["api", "v1", "alerts"], ["api", "v1", "alerts", "by-resource"]]
+}
+
+allow_alerts_read if {
+	# alerts read role
+	# allows access to GET api/v1/alerts/<fingerprint>
+	authorizedRoles := get_valid_roles("alerts-read-role")
+	some role in input.roles
+	role in authorizedRoles
+	input.method == "GET"
+	count(input.path) == 4
+	array.slice(input.path, 0, 3) == ["api", "v1", "alerts"]
+	regex.match(`^[0-9a-f]{16}$`, input.path[3])
+}
+
+allow_alerts_read if {
+	# alerts read role
+	# allows access to GET compat/alertmanager/*
+	authorizedRoles := get_valid_roles("alerts-read-role")
+	some role in input.roles
+	role in authorizedRoles
+	input.method == "GET"
+	array.slice(input.path, 0, 2) == ["compat", "alertmanager"]
+}
+
+allow_alerts_read if {
+	# alerts read role
+	# allows access to GET api/v1/reports and api/v1/reports/<id>, the weekly reports of the alert volume
+	authorizedRoles := get_valid_roles("alerts-read-role")
+	some role in input.roles
+	role in authorizedRoles
+	input.method == "GET"
+	count(input.path) in [3, 4]
+	array.slice(input.path, 0, 3) == ["api", "v1", "reports"]
+}
+
+allow_alerts_write if {
+	# alerts write role
+	# allows access to POST api/v1/alerts/external only
+	authorizedRoles := get_valid_roles("alerts-write-role")
+	some role in input.roles
+	role in authorizedRoles
+	input.method == "POST"
+	input.path == ["api", "v1", "alerts", "external"]
+}
+
+allow_alerts_write if {
+	# alerts write role
+	# allows access to PUT api/v1/alerts/maintenance-mode only
+	authorizedRoles := get_valid_roles("alerts-write-role")
+	some role in input.roles
+	role in authorizedRoles
+	input.method == "PUT"
+	input.path == ["api", "v1", "alerts", "maintenance-mode"]
+}
+
+allow_alerts_write if {
+	# alerts write role
+	# allows access to POST api/v1/alerts/<fingerprint>/comments, commenting alerts
+	authorizedRoles := get_valid_roles("alerts-write-role")
+	some role in input.roles
+	role in authorizedRoles
+	input.method == "POST"
+	count(input.path) == 5
+	array.slice(input.path, 0, 3) == ["api", "v1", "alerts"]
+	regex.match(`^[0-9a-f]{16}$`, input.path[3])
+	input.path[4] == "comments"
+}
+
+allow_alerts_write if {
+	# alerts write role
+	# allows access to POST and DELETE compat/alertmanager/*, silencing alerts
+	authorizedRoles := get_valid_roles("alerts-write-role")
+	some role in input.roles
+	role in authorizedRoles
+	input.method in ["POST", "DELETE"]
+	array.slice(input.path, 0, 2) == ["compat", "alertmanager"]
+}
+
+allow_alerts_write if {
+	# alerts write role
+	# allows access to GET, POST, PATCH and DELETE api/v1/hooks and api/v1/hooks/<uuid>, the event hooks of the project
+	authorizedRoles := get_valid_roles("alerts-write-role")
+	some role in input.roles
+	role in authorizedRoles
+	input.method in ["GET", "POST", "PATCH", "DELETE"]
+	count(input.path) in [3, 4]
+	array.slice(input.path, 0, 3) == ["api", "v1", "hooks"]
 }
 
 allow_alert_definitions_read if {
@@ -33,6 +120,17 @@ allow_alert_definitions_read if {
 	array.slice(input.path, 0, 4) == ["api", "v1", "alerts", "definitions"]
 }
 
+allow_alert_definitions_read if {
+	# alerts read role
+	# allows access to GET api/v1/operations/<uuid>, awaiting the asynchronous update of alert definitions
+	authorizedRoles := get_valid_roles("alert-definitions-read-role")
+	some role in input.roles
+	role in authorizedRoles
+	input.method == "GET"
+	count(input.path) == 4
+	array.slice(input.path, 0, 3) == ["api", "v1", "operations"]
+}
+
 allow_alert_definitions_write if {
 	# alerts write role
 	# allows access to PATCH api/v1/alerts/definitions/*
@@ -43,6 +141,19 @@ allow_alert_definitions_write if {
 	array.slice(input.path, 0, 4) == ["api", "v1", "alerts", "definitions"]
 }
 
+allow_alert_definitions_write if {
+	# alerts write role
+	# allows access to POST api/v1/alerts/definitions/<uuid>:evaluate, api/v1/alerts/definitions/<uuid>:resetDefaults and
+	# api/v1/alerts/definitions/<uuid>:simulate
+	authorizedRoles := get_valid_roles("alert-definitions-write-role")
+	some role in input.roles
+	role in authorizedRoles
+	input.method == "POST"
+	array.slice(input.path, 0, 4) == ["api", "v1", "alerts", "definitions"]
+	some method in [":evaluate", ":resetDefaults", ":simulate"]
+	endswith(input.path[4], method)
+}
+
 allow_alert_receivers_read if {
 	# alerts receiver read role
 	# allows access to GET api/v1/alerts/receivers/*
@@ -52,6 +163,16 @@ allow_alert_receivers_read if {
 	array.slice(input.path, 0, 4) == ["api", "v1", "alerts", "receivers"]
 }
 
+allow_alert_receivers_read if {
+	# alerts receiver read role
+	# allows access to GET api/v1/operations/<uuid>, awaiting the asynchronous update of receivers
+	some role in input.roles
+	role == "alert-receivers-read-role"
+	input.method == "GET"
+	count(input.path) == 4
+	array.slice(input.path, 0, 3) == ["api", "v1", "operations"]
+}
+
 allow_alert_receivers_write if {
 	# alerts receiver write role
 	# allows access to PATCH api/v1/alerts/receivers/*
@@ -60,3 +181,63 @@ allow_alert_receivers_write if {
 	input.method == "PATCH"
 	array.slice(input.path, 0, 4) == ["api", "v1", "alerts", "receivers"]
 }
+
+allow_alert_receivers_write if {
+	# alerts receiver write role
+	# allows access to POST api/v1/alerts/receivers:syncRecipients
+	some role in input.roles
+	role == "alert-receivers-write-role"
+	input.method == "POST"
+	input.path == ["api", "v1", "alerts", "receivers:syncRecipients"]
+}
+
+allow_alert_receivers_read if {
+	# alerts receiver read role
+	# allows access to GET api/v1/alerts/email-template
+	some role in input.roles
+	role == "alert-receivers-read-role"
+	input.method == "GET"
+	input.path == ["api", "v1", "alerts", "email-template"]
+}
+
+allow_alert_receivers_read if {
+	# alerts receiver read role
+	# allows access to POST api/v1/diff along with the alert definitions read role, comparing configuration bundles holding
+	# both alert definitions and receivers
+	some role in input.roles
+	role == "alert-receivers-read-role"
+	some definitions_role in input.roles
+	definitions_role in get_valid_roles("alert-definitions-read-role")
+	input.method == "POST"
+	input.path == ["api", "v1", "diff"]
+}
+
+allow_alert_receivers_write if {
+	# alerts receiver write role
+	# allows access to PUT api/v1/alerts/email-template
+	some role in input.roles
+	role == "alert-receivers-write-role"
+	input.method == "PUT"
+	input.path == ["api", "v1", "alerts", "email-template"]
+}
+
+allow_debug if {
+	# alerts admin role
+	# allows access to the debug endpoints under debug/*, it is not granted by project roles
+	some role in input.roles
+	role == "alerts-admin-role"
+	input.method in ["GET", "POST", "PUT"]
+	input.path[0] == "debug"
+}
+
+allow_email_override if {
+	# alerts admin role
+	# allows overriding the sender address and mail server of receivers with PATCH api/v1/alerts/receivers/*, it is only
+	# queried by alerting monitor along with the update of a receiver overriding them
+	authorizedRoles := get_valid_roles("alerts-admin-role")
+	some role in input.roles
+	role in authorizedRoles
+	input.emailOverride == true
+	input.method == "PATCH"
+	array.slice(input.path, 0, 4) == ["api", "v1", "alerts", "receivers"]
+}
@@ -4,22 +4,44 @@ import future.keywords
 
 # paths
 alerts_path := ["api", "v1", "alerts"]
+alerts_by_resource_path := ["api", "v1", "alerts", "by-resource"]
+alerts_external_path := ["api", "v1", "alerts", "external"]
+alerts_maintenance_mode_path := ["api", "v1", "alerts", "maintenance-mode"]
+alerts_email_template_path := ["api", "v1", "alerts", "email-template"]
+alerts_instance_path := ["api", "v1", "alerts", "0b4c5e3a1f2d6789"]
+alerts_instance_comments_path := ["api", "v1", "alerts", "0b4c5e3a1f2d6789", "comments"]
+compat_silences_path := ["compat", "alertmanager", "api", "v2", "silences"]
+compat_silence_uuid_path := ["compat", "alertmanager", "api", "v2", "silence", "some-uuid-here"]
 alerts_definitions_path := ["api", "v1", "alerts", "definitions"]
 alerts_definitions_uuid_path := ["api", "v1", "alerts", "definitions", "some-uuid-here"]
+alerts_definitions_uuid_evaluate_path := ["api", "v1", "alerts", "definitions", "some-uuid-here:evaluate"]
+alerts_definitions_uuid_reset_path := ["api", "v1", "alerts", "definitions", "some-uuid-here:resetDefaults"]
+alerts_definitions_uuid_simulate_path := ["api", "v1", "alerts", "definitions", "some-uuid-here:simulate"]
 alerts_definitions_uuid_template_path := ["api", "v1", "alerts", "definitions", "some-uuid-here", "template"]
 alerts_receivers_path := ["api", "v1", "alerts", "receivers"]
 alerts_receivers_uuid_path := ["api", "v1", "alerts", "receivers", "some-uuid-here"]
 alerts_receivers_uuid_template_path := ["api", "v1", "alerts", "receivers", "some-uuid-here", "template"]
+alerts_receivers_uuid_preview_path := ["api", "v1", "alerts", "receivers", "some-uuid-here", "preview"]
+alerts_receivers_uuid_allowed_recipients_path := ["api", "v1", "alerts", "receivers", "some-uuid-here", "allowed-recipients"]
+alerts_receivers_sync_recipients_path := ["api", "v1", "alerts", "receivers:syncRecipients"]
+operations_uuid_path := ["api", "v1", "operations", "some-uuid-here"]
+reports_path := ["api", "v1", "reports"]
+reports_id_path := ["api", "v1", "reports", "42"]
+hooks_path := ["api", "v1", "hooks"]
+hooks_uuid_path := ["api", "v1", "hooks", "some-uuid-here"]
+diff_path := ["api", "v1", "diff"]
 # NOTE: current opa policies do not enforce a valid UUID structure, hence the "some-uuid-here"
 
-all_get_paths := [alerts_path, alerts_definitions_path, alerts_definitions_uuid_path, alerts_definitions_uuid_template_path, alerts_receivers_path, alerts_receivers_uuid_path, alerts_receivers_uuid_template_path]
+all_get_paths := [alerts_path, alerts_by_resource_path, alerts_definitions_path, alerts_definitions_uuid_path, alerts_definitions_uuid_template_path, alerts_receivers_path, alerts_receivers_uuid_path, alerts_receivers_uuid_template_path]
 all_patch_paths := [alerts_definitions_uuid_path, alerts_definitions_uuid_template_path, alerts_receivers_path, alerts_receivers_uuid_path, alerts_receivers_uuid_template_path]
 
 # roles
 alerts_r := ["11111111-1111-1111-1111-111111111111_alerts-read-role"]
+alerts_w := ["11111111-1111-1111-1111-111111111111_alerts-write-role"]
 alert_definitions_r := ["11111111-1111-1111-1111-111111111111_alert-definitions-read-role"]
 alert_definitions_w := ["11111111-1111-1111-1111-111111111111_alert-definitions-write-role"]
 alerts_admin_r := ["alerts-read-role"]
+alerts_admin_w := ["alerts-write-role"]
 alert_admin_definitions_r := ["alert-definitions-read-role"]
 alert_admin_definitions_w := ["alert-definitions-write-role"]
 alert_admin_receivers_r := ["alert-receivers-read-role"]
@@ -38,6 +60,66 @@ test_alerts_endpoint if {
     not allow_alert_definitions_write with input as {"roles":alert_definitions_w, "method":"GET", "path":alerts_path, "project": "11111111-1111-1111-1111-111111111111"}
     not allow_alert_receivers_read with input as {"roles":alert_admin_receivers_r, "method":"GET", "path":alerts_path, "project": "11111111-1111-1111-1111-111111111111"}
     not allow_alert_receivers_write with input as {"roles":alert_admin_receivers_w, "method":"GET", "path":alerts_path, "project": "11111111-1111-1111-1111-111111111111"}
+
+    # /edgenode/api/v1/alerts/by-resource
+    allow_alerts_read with input as {"roles":alerts_r, "method":"GET", "path":alerts_by_resource_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alert_definitions_read with input as {"roles":alert_definitions_r, "method":"GET", "path":alerts_by_resource_path, "project": "11111111-1111-1111-1111-111111111111"}
+}
+
+test_alerts_external_endpoint if {
+    # /edgenode/api/v1/alerts/external
+    allow_alerts_write with input as {"roles":alerts_w, "method":"POST", "path":alerts_external_path, "project": "11111111-1111-1111-1111-111111111111"}
+    allow_alerts_write with input as {"roles":alerts_admin_w, "method":"POST", "path":alerts_external_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alerts_write with input as {"roles":alerts_w, "method":"GET", "path":alerts_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alerts_write with input as {"roles":alerts_w, "method":"POST", "path":alerts_definitions_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alerts_write with input as {"roles":["22222222-2222-2222-2222-222222222222_alerts-write-role"], "method":"POST", "path":alerts_external_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alerts_read with input as {"roles":alerts_r, "method":"POST", "path":alerts_external_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alert_definitions_write with input as {"roles":alert_definitions_w, "method":"POST", "path":alerts_external_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alert_receivers_write with input as {"roles":alert_admin_receivers_w, "method":"POST", "path":alerts_external_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alerts_write with input as {"roles":unauthorized_role, "method":"POST", "path":alerts_external_path, "project": "11111111-1111-1111-1111-111111111111"}
+}
+
+test_alerts_instances_endpoint if {
+    # /edgenode/api/v1/alerts/<fingerprint>
+    allow_alerts_read with input as {"roles":alerts_r, "method":"GET", "path":alerts_instance_path, "project": "11111111-1111-1111-1111-111111111111"}
+    allow_alerts_read with input as {"roles":alerts_admin_r, "method":"GET", "path":alerts_instance_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alerts_read with input as {"roles":alerts_r, "method":"GET", "path":alerts_instance_comments_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alerts_read with input as {"roles":alerts_r, "method":"GET", "path":alerts_email_template_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alert_definitions_read with input as {"roles":alert_definitions_r, "method":"GET", "path":alerts_instance_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alert_receivers_read with input as {"roles":alert_admin_receivers_r, "method":"GET", "path":alerts_instance_path, "project": "11111111-1111-1111-1111-111111111111"}
+
+    # /edgenode/api/v1/alerts/<fingerprint>/comments
+    allow_alerts_write with input as {"roles":alerts_w, "method":"POST", "path":alerts_instance_comments_path, "project": "11111111-1111-1111-1111-111111111111"}
+    allow_alerts_write with input as {"roles":alerts_admin_w, "method":"POST", "path":alerts_instance_comments_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alerts_write with input as {"roles":alerts_w, "method":"POST", "path":alerts_instance_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alerts_read with input as {"roles":alerts_r, "method":"POST", "path":alerts_instance_comments_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alert_receivers_write with input as {"roles":alert_admin_receivers_w, "method":"POST", "path":alerts_instance_comments_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alerts_write with input as {"roles":unauthorized_role, "method":"POST", "path":alerts_instance_comments_path, "project": "11111111-1111-1111-1111-111111111111"}
+}
+
+test_alerts_maintenance_mode_endpoint if {
+    # /edgenode/api/v1/alerts/maintenance-mode
+    allow_alerts_write with input as {"roles":alerts_w, "method":"PUT", "path":alerts_maintenance_mode_path, "project": "11111111-1111-1111-1111-111111111111"}
+    allow_alerts_write with input as {"roles":alerts_admin_w, "method":"PUT", "path":alerts_maintenance_mode_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alerts_write with input as {"roles":["22222222-2222-2222-2222-222222222222_alerts-write-role"], "method":"PUT", "path":alerts_maintenance_mode_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alerts_read with input as {"roles":alerts_r, "method":"PUT", "path":alerts_maintenance_mode_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alert_definitions_write with input as {"roles":alert_definitions_w, "method":"PUT", "path":alerts_maintenance_mode_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alert_receivers_write with input as {"roles":alert_admin_receivers_w, "method":"PUT", "path":alerts_maintenance_mode_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alerts_write with input as {"roles":unauthorized_role, "method":"PUT", "path":alerts_maintenance_mode_path, "project": "11111111-1111-1111-1111-111111111111"}
+}
+
+test_compat_alertmanager_endpoints if {
+    # /compat/alertmanager/api/v2/silences
+    allow_alerts_read with input as {"roles":alerts_r, "method":"GET", "path":compat_silences_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alerts_read with input as {"roles":alerts_r, "method":"POST", "path":compat_silences_path, "project": "11111111-1111-1111-1111-111111111111"}
+    allow_alerts_write with input as {"roles":alerts_w, "method":"POST", "path":compat_silences_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alerts_write with input as {"roles":alerts_w, "method":"GET", "path":compat_silences_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alert_definitions_read with input as {"roles":alert_definitions_r, "method":"GET", "path":compat_silences_path, "project": "11111111-1111-1111-1111-111111111111"}
+
+    # /compat/alertmanager/api/v2/silence/<uuid>
+    allow_alerts_write with input as {"roles":alerts_w, "method":"DELETE", "path":compat_silence_uuid_path, "project": "11111111-1111-1111-1111-111111111111"}
+    allow_alerts_write with input as {"roles":alerts_admin_w, "method":"DELETE", "path":compat_silence_uuid_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alerts_write with input as {"roles":unauthorized_role, "method":"DELETE", "path":compat_silence_uuid_path, "project": "11111111-1111-1111-1111-111111111111"}
 }
 
 test_alerts_definitions_get_endpoint if {
@@ -92,6 +174,34 @@ test_alerts_definitions_patch_endpoint if {
     not allow_alert_receivers_write with input as {"roles":alert_admin_receivers_w, "method":"PATCH", "path":alerts_definitions_uuid_template_path, "project": "11111111-1111-1111-1111-111111111111"}
 }
 
+test_alerts_definitions_reset_defaults_endpoint if {
+    # /edgenode/api/v1/alerts/definitions/<uuid>:resetDefaults
+    not allow_alerts_read with input as {"roles":alerts_r, "method":"POST", "path":alerts_definitions_uuid_reset_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alert_definitions_read with input as {"roles":alert_definitions_r, "method":"POST", "path":alerts_definitions_uuid_reset_path, "project": "11111111-1111-1111-1111-111111111111"}
+    allow_alert_definitions_write with input as {"roles":alert_definitions_w, "method":"POST", "path":alerts_definitions_uuid_reset_path, "project": "11111111-1111-1111-1111-111111111111"}
+    allow_alert_definitions_write with input as {"roles":alert_admin_definitions_w, "method":"POST", "path":alerts_definitions_uuid_reset_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alert_receivers_write with input as {"roles":alert_admin_receivers_w, "method":"POST", "path":alerts_definitions_uuid_reset_path, "project": "11111111-1111-1111-1111-111111111111"}
+
+    # POST is only allowed for resetting defaults
+    not allow_alert_definitions_write with input as {"roles":alert_definitions_w, "method":"POST", "path":alerts_definitions_uuid_path, "project": "11111111-1111-1111-1111-111111111111"}
+}
+
+test_alerts_definitions_simulate_endpoint if {
+    # /edgenode/api/v1/alerts/definitions/<uuid>:simulate
+    not allow_alert_definitions_read with input as {"roles":alert_definitions_r, "method":"POST", "path":alerts_definitions_uuid_simulate_path, "project": "11111111-1111-1111-1111-111111111111"}
+    allow_alert_definitions_write with input as {"roles":alert_definitions_w, "method":"POST", "path":alerts_definitions_uuid_simulate_path, "project": "11111111-1111-1111-1111-111111111111"}
+    allow_alert_definitions_write with input as {"roles":alert_admin_definitions_w, "method":"POST", "path":alerts_definitions_uuid_simulate_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alert_receivers_write with input as {"roles":alert_admin_receivers_w, "method":"POST", "path":alerts_definitions_uuid_simulate_path, "project": "11111111-1111-1111-1111-111111111111"}
+}
+
+test_alerts_definitions_evaluate_endpoint if {
+    # /edgenode/api/v1/alerts/definitions/<uuid>:evaluate
+    not allow_alert_definitions_read with input as {"roles":alert_definitions_r, "method":"POST", "path":alerts_definitions_uuid_evaluate_path, "project": "11111111-1111-1111-1111-111111111111"}
+    allow_alert_definitions_write with input as {"roles":alert_definitions_w, "method":"POST", "path":alerts_definitions_uuid_evaluate_path, "project": "11111111-1111-1111-1111-111111111111"}
+    allow_alert_definitions_write with input as {"roles":alert_admin_definitions_w, "method":"POST", "path":alerts_definitions_uuid_evaluate_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alert_receivers_write with input as {"roles":alert_admin_receivers_w, "method":"POST", "path":alerts_definitions_uuid_evaluate_path, "project": "11111111-1111-1111-1111-111111111111"}
+}
+
 test_alerts_receivers_get_endpoint if {
     # /edgenode/api/v1/alerts/receivers
     not allow_alerts_read with input as {"roles":alerts_admin_r, "method":"GET", "path":alerts_receivers_path, "project": "11111111-1111-1111-1111-111111111111"}
@@ -113,6 +223,19 @@ test_alerts_receivers_get_endpoint if {
     not allow_alert_definitions_write with input as {"roles":alert_admin_definitions_w, "method":"GET", "path":alerts_receivers_uuid_template_path, "project": "11111111-1111-1111-1111-111111111111"}
     #allow_alert_receivers_read with input as {"roles":alert_admin_receivers_r, "method":"GET", "path":alerts_receivers_uuid_template_path, "project": "11111111-1111-1111-1111-111111111111"}
     not allow_alert_receivers_write with input as {"roles":alert_admin_receivers_w, "method":"GET", "path":alerts_receivers_uuid_template_path, "project": "11111111-1111-1111-1111-111111111111"}
+
+    # /edgenode/api/v1/alerts/receivers/<uuid>/preview
+    not allow_alerts_read with input as {"roles":alerts_admin_r, "method":"GET", "path":alerts_receivers_uuid_preview_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alert_definitions_read with input as {"roles":alert_admin_definitions_r, "method":"GET", "path":alerts_receivers_uuid_preview_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alert_definitions_write with input as {"roles":alert_admin_definitions_w, "method":"GET", "path":alerts_receivers_uuid_preview_path, "project": "11111111-1111-1111-1111-111111111111"}
+    allow_alert_receivers_read with input as {"roles":alert_admin_receivers_r, "method":"GET", "path":alerts_receivers_uuid_preview_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alert_receivers_write with input as {"roles":alert_admin_receivers_w, "method":"GET", "path":alerts_receivers_uuid_preview_path, "project": "11111111-1111-1111-1111-111111111111"}
+
+    # /edgenode/api/v1/alerts/receivers/<uuid>/allowed-recipients
+    not allow_alerts_read with input as {"roles":alerts_admin_r, "method":"GET", "path":alerts_receivers_uuid_allowed_recipients_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alert_definitions_read with input as {"roles":alert_admin_definitions_r, "method":"GET", "path":alerts_receivers_uuid_allowed_recipients_path, "project": "11111111-1111-1111-1111-111111111111"}
+    allow_alert_receivers_read with input as {"roles":alert_admin_receivers_r, "method":"GET", "path":alerts_receivers_uuid_allowed_recipients_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alert_receivers_write with input as {"roles":alert_admin_receivers_w, "method":"GET", "path":alerts_receivers_uuid_allowed_recipients_path, "project": "11111111-1111-1111-1111-111111111111"}
 }
 
 test_alerts_receivers_patch_endpoint if {
@@ -138,7 +261,75 @@ test_alerts_receivers_patch_endpoint if {
     allow_alert_receivers_write with input as {"roles":alert_admin_receivers_w, "method":"PATCH", "path":alerts_receivers_uuid_template_path, "project": "11111111-1111-1111-1111-111111111111"}
 }
 
+test_alerts_receivers_sync_recipients_endpoint if {
+    # /edgenode/api/v1/alerts/receivers:syncRecipients
+    not allow_alerts_write with input as {"roles":alerts_admin_w, "method":"POST", "path":alerts_receivers_sync_recipients_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alert_definitions_write with input as {"roles":alert_admin_definitions_w, "method":"POST", "path":alerts_receivers_sync_recipients_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alert_receivers_read with input as {"roles":alert_admin_receivers_r, "method":"POST", "path":alerts_receivers_sync_recipients_path, "project": "11111111-1111-1111-1111-111111111111"}
+    allow_alert_receivers_write with input as {"roles":alert_admin_receivers_w, "method":"POST", "path":alerts_receivers_sync_recipients_path, "project": "11111111-1111-1111-1111-111111111111"}
+
+    # POST is only allowed for syncing recipients
+    not allow_alert_receivers_write with input as {"roles":alert_admin_receivers_w, "method":"POST", "path":alerts_receivers_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alert_receivers_write with input as {"roles":alert_admin_receivers_w, "method":"POST", "path":alerts_receivers_uuid_path, "project": "11111111-1111-1111-1111-111111111111"}
+}
+
+test_alerts_email_template_endpoint if {
+    # /edgenode/api/v1/alerts/email-template
+    not allow_alerts_read with input as {"roles":alerts_admin_r, "method":"GET", "path":alerts_email_template_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alerts_write with input as {"roles":alerts_admin_w, "method":"PUT", "path":alerts_email_template_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alert_definitions_write with input as {"roles":alert_admin_definitions_w, "method":"PUT", "path":alerts_email_template_path, "project": "11111111-1111-1111-1111-111111111111"}
+    allow_alert_receivers_read with input as {"roles":alert_admin_receivers_r, "method":"GET", "path":alerts_email_template_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alert_receivers_read with input as {"roles":alert_admin_receivers_r, "method":"PUT", "path":alerts_email_template_path, "project": "11111111-1111-1111-1111-111111111111"}
+    allow_alert_receivers_write with input as {"roles":alert_admin_receivers_w, "method":"PUT", "path":alerts_email_template_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alert_receivers_write with input as {"roles":alert_admin_receivers_w, "method":"GET", "path":alerts_email_template_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alert_receivers_write with input as {"roles":unauthorized_role, "method":"PUT", "path":alerts_email_template_path, "project": "11111111-1111-1111-1111-111111111111"}
+}
+
 # disallow all policies for an unauthorized role
+test_operations_endpoint if {
+    # /api/v1/operations/<uuid>
+    allow_alert_definitions_read with input as {"roles":alert_definitions_r, "method":"GET", "path":operations_uuid_path, "project": "11111111-1111-1111-1111-111111111111"}
+    allow_alert_definitions_read with input as {"roles":alert_admin_definitions_r, "method":"GET", "path":operations_uuid_path, "project": "11111111-1111-1111-1111-111111111111"}
+    allow_alert_receivers_read with input as {"roles":alert_admin_receivers_r, "method":"GET", "path":operations_uuid_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alerts_read with input as {"roles":alerts_r, "method":"GET", "path":operations_uuid_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alert_definitions_write with input as {"roles":alert_definitions_w, "method":"GET", "path":operations_uuid_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alert_definitions_read with input as {"roles":alert_definitions_r, "method":"PATCH", "path":operations_uuid_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alert_definitions_read with input as {"roles":alert_definitions_r, "method":"GET", "path":["api", "v1", "operations"], "project": "11111111-1111-1111-1111-111111111111"}
+}
+
+test_reports_endpoints if {
+    # /api/v1/reports and /api/v1/reports/<id>
+    allow_alerts_read with input as {"roles":alerts_r, "method":"GET", "path":reports_path, "project": "11111111-1111-1111-1111-111111111111"}
+    allow_alerts_read with input as {"roles":alerts_admin_r, "method":"GET", "path":reports_id_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alerts_read with input as {"roles":alerts_r, "method":"POST", "path":reports_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alerts_read with input as {"roles":alerts_r, "method":"GET", "path":["api", "v1", "reports", "42", "content"], "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alerts_read with input as {"roles":["22222222-2222-2222-2222-222222222222_alerts-read-role"], "method":"GET", "path":reports_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alert_definitions_read with input as {"roles":alert_definitions_r, "method":"GET", "path":reports_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alerts_read with input as {"roles":unauthorized_role, "method":"GET", "path":reports_id_path, "project": "11111111-1111-1111-1111-111111111111"}
+}
+
+test_hooks_endpoints if {
+    # /api/v1/hooks and /api/v1/hooks/<uuid>
+    allow_alerts_write with input as {"roles":alerts_w, "method":"GET", "path":hooks_path, "project": "11111111-1111-1111-1111-111111111111"}
+    allow_alerts_write with input as {"roles":alerts_w, "method":"POST", "path":hooks_path, "project": "11111111-1111-1111-1111-111111111111"}
+    allow_alerts_write with input as {"roles":alerts_admin_w, "method":"GET", "path":hooks_uuid_path, "project": "11111111-1111-1111-1111-111111111111"}
+    allow_alerts_write with input as {"roles":alerts_w, "method":"PATCH", "path":hooks_uuid_path, "project": "11111111-1111-1111-1111-111111111111"}
+    allow_alerts_write with input as {"roles":alerts_w, "method":"DELETE", "path":hooks_uuid_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alerts_write with input as {"roles":alerts_w, "method":"GET", "path":["api", "v1", "hooks", "some-uuid-here", "events"], "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alerts_read with input as {"roles":alerts_r, "method":"GET", "path":hooks_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alerts_write with input as {"roles":unauthorized_role, "method":"POST", "path":hooks_path, "project": "11111111-1111-1111-1111-111111111111"}
+}
+
+test_diff_endpoint if {
+    # /api/v1/diff
+    allow_alert_receivers_read with input as {"roles":array.concat(alert_definitions_r, alert_admin_receivers_r), "method":"POST", "path":diff_path, "project": "11111111-1111-1111-1111-111111111111"}
+    allow_alert_receivers_read with input as {"roles":array.concat(alert_admin_definitions_r, alert_admin_receivers_r), "method":"POST", "path":diff_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alert_receivers_read with input as {"roles":alert_admin_receivers_r, "method":"POST", "path":diff_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alert_receivers_read with input as {"roles":array.concat(alert_definitions_r, alert_admin_receivers_r), "method":"GET", "path":diff_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alert_definitions_read with input as {"roles":alert_definitions_r, "method":"POST", "path":diff_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_alert_receivers_read with input as {"roles":array.concat(["22222222-2222-2222-2222-222222222222_alert-definitions-read-role"], alert_admin_receivers_r), "method":"POST", "path":diff_path, "project": "11111111-1111-1111-1111-111111111111"}
+}
+
 test_unauthorized_alerts_read if {
     some path in all_get_paths
     not allow_alerts_read with input as {"roles":unauthorized_role, "method":"GET", "path":path, "project": "11111111-1111-1111-1111-111111111111"}
@@ -156,3 +347,25 @@ test_unauthorized_alerts_patch if {
     not allow_alert_receivers_read with input as {"roles":unauthorized_role, "method":"PATCH", "path":path, "project": "11111111-1111-1111-1111-111111111111"}
     not allow_alert_receivers_write with input as {"roles":unauthorized_role, "method":"PATCH", "path":path, "project": "11111111-1111-1111-1111-111111111111"}
 }
+
+test_debug_endpoints if {
+    allow_debug with input as {"roles":["alerts-admin-role"], "method":"GET", "path":["debug", "vars"], "project": ""}
+    allow_debug with input as {"roles":["alerts-admin-role"], "method":"GET", "path":["debug", "pprof", "goroutine"], "project": ""}
+    allow_debug with input as {"roles":["alerts-admin-role"], "method":"POST", "path":["debug", "pprof", "symbol"], "project": ""}
+    allow_debug with input as {"roles":["alerts-admin-role"], "method":"GET", "path":["debug", "artifacts", "1", "diff"], "project": ""}
+    allow_debug with input as {"roles":["alerts-admin-role"], "method":"PUT", "path":["debug", "tenants", "11111111-1111-1111-1111-111111111111", "tier"], "project": ""}
+    allow_debug with input as {"roles":["alerts-admin-role"], "method":"GET", "path":["debug", "tenants", "11111111-1111-1111-1111-111111111111", "unlinked-alerts"], "project": ""}
+    not allow_debug with input as {"roles":["alerts-admin-role"], "method":"GET", "path":alerts_path, "project": ""}
+    not allow_debug with input as {"roles":["11111111-1111-1111-1111-111111111111_alerts-admin-role"], "method":"GET", "path":["debug", "vars"], "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_debug with input as {"roles":alert_admin_definitions_w, "method":"GET", "path":["debug", "vars"], "project": ""}
+    not allow_alerts_read with input as {"roles":alerts_admin_r, "method":"GET", "path":["debug", "vars"], "project": ""}
+}
+
+test_email_override if {
+    allow_email_override with input as {"roles":["alerts-admin-role"], "method":"PATCH", "path":alerts_receivers_uuid_path, "project": "11111111-1111-1111-1111-111111111111", "emailOverride": true}
+    allow_email_override with input as {"roles":["11111111-1111-1111-1111-111111111111_alerts-admin-role"], "method":"PATCH", "path":alerts_receivers_uuid_path, "project": "11111111-1111-1111-1111-111111111111", "emailOverride": true}
+    not allow_email_override with input as {"roles":["22222222-2222-2222-2222-222222222222_alerts-admin-role"], "method":"PATCH", "path":alerts_receivers_uuid_path, "project": "11111111-1111-1111-1111-111111111111", "emailOverride": true}
+    not allow_email_override with input as {"roles":["alerts-admin-role"], "method":"PATCH", "path":alerts_receivers_uuid_path, "project": "11111111-1111-1111-1111-111111111111"}
+    not allow_email_override with input as {"roles":["alerts-admin-role"], "method":"PATCH", "path":alerts_definitions_uuid_path, "project": "11111111-1111-1111-1111-111111111111", "emailOverride": true}
+    not allow_email_override with input as {"roles":alert_admin_receivers_w, "method":"PATCH", "path":alerts_receivers_uuid_path, "project": "11111111-1111-1111-1111-111111111111", "emailOverride": true}
+}
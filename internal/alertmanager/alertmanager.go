@@ -5,20 +5,26 @@ package alertmanager
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
 
 	"gopkg.in/yaml.v2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/app"
 	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/correlation"
 	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
 )
 
 const (
-	// secret name of the secret that has the alertmanager configuration.
+	// secret name of the secret that has the alertmanager configuration, unless configured otherwise.
 	secretName = "alert-monitor-config"
 )
 
@@ -28,15 +34,58 @@ type AlertmanagerConfigurator interface {
 	UpdateReceiverConfig(ctx context.Context, receiver models.DBReceiver) error
 }
 
-// AlertManager refers to a standalone alertmanager instance. Implements UpdateReceiverConfig interface.
+// TenantConfigRemover removes the configuration of a tenant from an alertmanager instance, as long as the tenant has no
+// active alerts.
+type TenantConfigRemover interface {
+	HasActiveAlerts(ctx context.Context, tenantID string) (bool, error)
+	RemoveTenantConfig(ctx context.Context, tenantID string) error
+}
+
+// TenantShardResolver maps tenants to the alertmanager shard holding their receivers and alerts.
+type TenantShardResolver interface {
+	GetTenantShard(ctx context.Context, tenantID string, shards int) (int, error)
+}
+
+// AppliedConfigRecorder records the configuration manifests successfully applied to alertmanager instances, so that they can
+// be compared and rolled back to after an incident.
+type AppliedConfigRecorder interface {
+	RecordAppliedArtifact(ctx context.Context, kind models.AppliedArtifactKind, name string, content []byte) error
+}
+
+// NotificationBackend applies receivers to the notification stack alerts are routed by, and validates and previews them.
+type NotificationBackend interface {
+	AlertmanagerConfigurator
+	TenantConfigRemover
+	ValidateReceiverConfig(ctx context.Context, receiver models.DBReceiver) error
+	PreviewReceiverConfig(ctx context.Context, receiver models.DBReceiver) (api.ReceiverConfigPreview, error)
+}
+
+// Notification backends selectable by the backend of the alertmanager configuration.
+const (
+	BackendAlertmanager = "alertmanager"
+	BackendGrafana      = "grafana"
+)
+
+// AlertManager refers to a standalone alertmanager instance, or to a set of instances tenants are sharded across.
+// Implements the AlertmanagerConfigurator and TenantConfigRemover interfaces.
 type AlertManager struct {
-	client kubernetes.Interface
+	client    kubernetes.Interface
+	shards    TenantShardResolver
+	knownGood KnownGoodConfigStore
+	applied   AppliedConfigRecorder
 
-	config config.AlertManagerConfig
+	config  config.AlertManagerConfig
+	tenancy config.TenancyConfig
 }
 
-// New returns an AlertManager with the given configuration providing access to the Kubernetes API.
-func New(conf config.AlertManagerConfig) (*AlertManager, error) {
+// New returns an AlertManager with the given configuration providing access to the Kubernetes API. Routes match tenants by
+// the tenant label of the given tenancy configuration. The shards resolver maps tenants to alertmanager instances if more than
+// one is configured, and the known good configuration store holds the configuration rolled back to if a new one fails to reload.
+// The applied configuration recorder keeps every manifest applied.
+func New(
+	conf config.AlertManagerConfig, tenancy config.TenancyConfig, shards TenantShardResolver, knownGood KnownGoodConfigStore,
+	applied AppliedConfigRecorder,
+) (*AlertManager, error) {
 	c, err := rest.InClusterConfig()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get kubernetes incluster config: %w", err)
@@ -48,35 +97,249 @@ func New(conf config.AlertManagerConfig) (*AlertManager, error) {
 	}
 
 	return &AlertManager{
-		client: kubeClient,
-		config: conf,
+		client:    kubeClient,
+		shards:    shards,
+		knownGood: knownGood,
+		applied:   applied,
+		config:    conf,
+		tenancy:   tenancy,
 	}, nil
 }
 
+// NewBackend returns the notification backend selected by the given configuration, alertmanager if none is selected, with the
+// arguments of New for alertmanager.
+func NewBackend(
+	conf config.AlertManagerConfig, tenancy config.TenancyConfig, shards TenantShardResolver, knownGood KnownGoodConfigStore,
+	applied AppliedConfigRecorder,
+) (NotificationBackend, error) {
+	switch conf.Backend {
+	case "", BackendAlertmanager:
+		return New(conf, tenancy, shards, knownGood, applied)
+	case BackendGrafana:
+		return NewGrafana(conf, tenancy)
+	default:
+		return nil, fmt.Errorf("unknown notification backend %q, expected one of: %v, %v", conf.Backend, BackendAlertmanager, BackendGrafana)
+	}
+}
+
+// tenantConfig returns the configuration of the alertmanager instance serving the shard of the given tenant.
+func (am *AlertManager) tenantConfig(ctx context.Context, tenantID string) (config.AlertManagerConfig, error) {
+	if am.config.ShardCount() == 1 {
+		return am.config.Shard(0)
+	}
+
+	shard, err := am.shards.GetTenantShard(ctx, tenantID, am.config.ShardCount())
+	if err != nil {
+		return config.AlertManagerConfig{}, fmt.Errorf("failed to get alertmanager shard of tenant %q: %w", tenantID, err)
+	}
+	return am.config.Shard(shard)
+}
+
 // UpdateReceiverConfig updates the configuration of the alertmanager manifest to match the list of email recipients
-// of the given receiver.
+// of the given receiver. Transient Kubernetes API errors are retried as given by the configuration, whereas permanent
+// errors, such as an invalid manifest, are returned right away. The registered notification channels are then applied.
 func (am *AlertManager) UpdateReceiverConfig(ctx context.Context, receiver models.DBReceiver) error {
-	manifest, err := getConfigManifest(ctx, am.config.Namespace, am.client)
+	conf, err := am.tenantConfig(ctx, receiver.TenantID)
+	if err != nil {
+		return err
+	}
+
+	if err := retryTransient(ctx, conf.ApplyRetry, func() error {
+		return am.updateReceiverConfig(ctx, conf, receiver)
+	}); err != nil {
+		return err
+	}
+	return applyChannels(ctx, receiver)
+}
+
+// updateReceiverConfig gets the config manifest of the given alertmanager instance, applies the receiver and sets it back,
+// once validated by the staging alertmanager if configured. The manifest is then read back to verify that the receiver was
+// applied as expected, and rolled back if alertmanager does not reload it. The reloaded manifest is recorded as applied.
+func (am *AlertManager) updateReceiverConfig(ctx context.Context, conf config.AlertManagerConfig, receiver models.DBReceiver) error {
+	manifest, err := getConfigManifest(ctx, conf.Namespace, configSecretName(conf), am.client)
+	if err != nil {
+		return fmt.Errorf("failed to get alertmanager config manifest: %w", err)
+	}
+
+	updatedManifest, err := manifest.ApplyReceiver(receiver, conf, am.tenancy)
+	if err != nil {
+		return fmt.Errorf("failed to apply receiver to alertmanager manifest: %w", err)
+	}
+
+	if err := updatedManifest.checkLimits(conf); err != nil {
+		return fmt.Errorf("alertmanager manifest with receiver applied is rejected: %w", err)
+	}
+	if err := updatedManifest.checkRoutes(receiver, am.tenancy); err != nil {
+		return fmt.Errorf("alertmanager manifest with receiver applied is rejected: %w", err)
+	}
+
+	if err := am.validateOnStaging(ctx, *updatedManifest); err != nil {
+		return err
+	}
+
+	err = setConfigManifest(ctx, am.client, *updatedManifest, conf.Namespace, configSecretName(conf))
+	if err != nil {
+		return fmt.Errorf("failed to set alertmanager config manifest: %w", err)
+	}
+
+	appliedManifest, err := getConfigManifest(ctx, conf.Namespace, configSecretName(conf), am.client)
+	if err != nil {
+		return fmt.Errorf("failed to read back alertmanager config manifest: %w", err)
+	}
+
+	if err := appliedManifest.VerifyReceiver(*updatedManifest, receiver); err != nil {
+		return fmt.Errorf("failed to verify alertmanager config manifest: %w", err)
+	}
+	if err := am.awaitReload(ctx, conf, *updatedManifest); err != nil {
+		return err
+	}
+	return am.recordApplied(ctx, conf, *updatedManifest)
+}
+
+// ValidateReceiverConfig verifies that the given receiver can notify through the registered notification channels, and that
+// the alertmanager manifest with it applied does not exceed the size of a Kubernetes secret nor the configured limits, nor has
+// conflicting routes. An error wrapping app.ErrInvalidChannelConfig, app.ErrConfigLimitExceeded or app.ErrRouteConflict is
+// returned otherwise.
+func (am *AlertManager) ValidateReceiverConfig(ctx context.Context, receiver models.DBReceiver) error {
+	if err := validateChannels(receiver); err != nil {
+		return err
+	}
+
+	conf, err := am.tenantConfig(ctx, receiver.TenantID)
+	if err != nil {
+		return err
+	}
+
+	manifest, err := getConfigManifest(ctx, conf.Namespace, configSecretName(conf), am.client)
 	if err != nil {
 		return fmt.Errorf("failed to get alertmanager config manifest: %w", err)
 	}
 
-	updatedManifest, err := manifest.ApplyReceiver(receiver, am.config)
+	updatedManifest, err := manifest.ApplyReceiver(receiver, conf, am.tenancy)
 	if err != nil {
 		return fmt.Errorf("failed to apply receiver to alertmanager manifest: %w", err)
 	}
 
-	err = setConfigManifest(ctx, am.client, *updatedManifest, am.config.Namespace)
+	if err := updatedManifest.checkLimits(conf); err != nil {
+		return err
+	}
+	return updatedManifest.checkRoutes(receiver, am.tenancy)
+}
+
+// PreviewReceiverConfig returns the receiver and route blocks of the alertmanager manifest the given receiver is to be applied
+// with, as rendered by applying it to the current manifest of the alertmanager instance of its tenant.
+func (am *AlertManager) PreviewReceiverConfig(ctx context.Context, receiver models.DBReceiver) (api.ReceiverConfigPreview, error) {
+	conf, err := am.tenantConfig(ctx, receiver.TenantID)
+	if err != nil {
+		return api.ReceiverConfigPreview{}, err
+	}
+
+	manifest, err := getConfigManifest(ctx, conf.Namespace, configSecretName(conf), am.client)
+	if err != nil {
+		return api.ReceiverConfigPreview{}, fmt.Errorf("failed to get alertmanager config manifest: %w", err)
+	}
+
+	updatedManifest, err := manifest.ApplyReceiver(receiver, conf, am.tenancy)
+	if err != nil {
+		return api.ReceiverConfigPreview{}, fmt.Errorf("failed to apply receiver to alertmanager manifest: %w", err)
+	}
+
+	receiverBlock, routeBlock, err := updatedManifest.RenderReceiver(receiver)
+	if err != nil {
+		return api.ReceiverConfigPreview{}, err
+	}
+	return api.ReceiverConfigPreview{Receiver: receiverBlock, Route: routeBlock}, nil
+}
+
+// RemoveTenantConfig removes the receivers, routes and quiet hours of the given tenant from the alertmanager manifest. The
+// manifest is rolled back if alertmanager does not reload it.
+func (am *AlertManager) RemoveTenantConfig(ctx context.Context, tenantID string) error {
+	conf, err := am.tenantConfig(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+
+	manifest, err := getConfigManifest(ctx, conf.Namespace, configSecretName(conf), am.client)
+	if err != nil {
+		return fmt.Errorf("failed to get alertmanager config manifest: %w", err)
+	}
+
+	updatedManifest := manifest.RemoveTenant(tenantID, am.tenancy)
+	err = setConfigManifest(ctx, am.client, *updatedManifest, conf.Namespace, configSecretName(conf))
 	if err != nil {
 		return fmt.Errorf("failed to set alertmanager config manifest: %w", err)
 	}
+	if err := am.awaitReload(ctx, conf, *updatedManifest); err != nil {
+		return err
+	}
+	return am.recordApplied(ctx, conf, *updatedManifest)
+}
+
+// recordApplied records the given manifest as applied to the given alertmanager instance. Nothing is done if no applied
+// configuration recorder is set.
+func (am *AlertManager) recordApplied(ctx context.Context, conf config.AlertManagerConfig, manifest configManifest) error {
+	if am.applied == nil {
+		return nil
+	}
+
+	data, err := yaml.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("failed to marshal alertmanager config manifest: %w", err)
+	}
+
+	name := conf.Namespace + "/" + configSecretName(conf)
+	if err := am.applied.RecordAppliedArtifact(ctx, models.ArtifactAlertmanagerManifest, name, data); err != nil {
+		return fmt.Errorf("failed to record applied alertmanager config manifest: %w", err)
+	}
 	return nil
 }
 
+// HasActiveAlerts tells whether alertmanager holds any active alert of the given tenant.
+func (am *AlertManager) HasActiveAlerts(ctx context.Context, tenantID string) (bool, error) {
+	conf, err := am.tenantConfig(ctx, tenantID)
+	if err != nil {
+		return false, err
+	}
+
+	params := make(url.Values)
+	params.Add("active", "true")
+	params.Add("filter", app.TenantFilter(am.tenancy.TenantLabel(), tenantID))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/api/v2/alerts?%s", conf.URL, params.Encode()), nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+	correlation.SetHeader(req)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("alertmanager returned status code: %v", resp.StatusCode)
+	}
+
+	var alerts []json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&alerts); err != nil {
+		return false, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return len(alerts) > 0, nil
+}
+
+// configSecretName returns the name of the secret holding the configuration of the given alertmanager instance.
+func configSecretName(conf config.AlertManagerConfig) string {
+	if conf.SecretName == "" {
+		return secretName
+	}
+	return conf.SecretName
+}
+
 // getConfigManifest takes a client with access to Kubernetes API and returns the config manifest of the
 // alertmanager instance, which is stored as a secret.
-func getConfigManifest(ctx context.Context, namespace string, client kubernetes.Interface) (*configManifest, error) {
-	secret, err := client.CoreV1().Secrets(namespace).Get(ctx, secretName, metav1.GetOptions{})
+func getConfigManifest(ctx context.Context, namespace, name string, client kubernetes.Interface) (*configManifest, error) {
+	secret, err := client.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get alertmanager config secret: %w", err)
 	}
@@ -96,13 +359,13 @@ func getConfigManifest(ctx context.Context, namespace string, client kubernetes.
 
 // setConfigManifest takes a client with access to Kubernetes API and a config manifest. It sets the
 // alertmanager config secret to match the given manifest.
-func setConfigManifest(ctx context.Context, client kubernetes.Interface, manifest configManifest, namespace string) error {
+func setConfigManifest(ctx context.Context, client kubernetes.Interface, manifest configManifest, namespace, name string) error {
 	data, err := yaml.Marshal(manifest)
 	if err != nil {
 		return fmt.Errorf("failed to marshal the content of the config secret: %w", err)
 	}
 
-	secret, err := client.CoreV1().Secrets(namespace).Get(ctx, secretName, metav1.GetOptions{})
+	secret, err := client.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		return fmt.Errorf("failed to get alertmanager config secret: %w", err)
 	}
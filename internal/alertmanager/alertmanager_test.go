@@ -4,11 +4,18 @@
 package alertmanager
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -21,6 +28,15 @@ import (
 
 const testNamespace = "orch-infra"
 
+type TenantShardResolverMock struct {
+	mock.Mock
+}
+
+func (m *TenantShardResolverMock) GetTenantShard(ctx context.Context, tenantID string, shards int) (int, error) {
+	args := m.Called(ctx, tenantID, shards)
+	return args.Int(0), args.Error(1)
+}
+
 func TestGetConfigManifest(t *testing.T) {
 	t.Run("Failed to get alertmanager config secret due to error", func(t *testing.T) {
 		fakeClient := testclient.NewClientset()
@@ -29,7 +45,7 @@ func TestGetConfigManifest(t *testing.T) {
 			return true, nil, errors.New("mock error")
 		})
 
-		manifest, err := getConfigManifest(t.Context(), testNamespace, fakeClient)
+		manifest, err := getConfigManifest(t.Context(), testNamespace, secretName, fakeClient)
 		require.Nil(t, manifest)
 		require.ErrorContains(t, err, "failed to get alertmanager config secret")
 	})
@@ -45,7 +61,7 @@ func TestGetConfigManifest(t *testing.T) {
 			},
 		})
 
-		manifest, err := getConfigManifest(t.Context(), testNamespace, fakeClient)
+		manifest, err := getConfigManifest(t.Context(), testNamespace, secretName, fakeClient)
 		require.Nil(t, manifest)
 		require.ErrorContains(t, err, "failed to get alertmanager config secret")
 		require.ErrorContains(t, err, fmt.Sprintf("secrets %q not found", secretName))
@@ -62,7 +78,7 @@ func TestGetConfigManifest(t *testing.T) {
 			},
 		})
 
-		manifest, err := getConfigManifest(t.Context(), testNamespace, fakeClient)
+		manifest, err := getConfigManifest(t.Context(), testNamespace, secretName, fakeClient)
 		require.Nil(t, manifest)
 		require.ErrorContains(t, err, "failed to get alertmanager config secret")
 		require.ErrorContains(t, err, fmt.Sprintf("secrets %q not found", secretName))
@@ -79,7 +95,7 @@ func TestGetConfigManifest(t *testing.T) {
 			},
 		})
 
-		manifest, err := getConfigManifest(t.Context(), testNamespace, fakeClient)
+		manifest, err := getConfigManifest(t.Context(), testNamespace, secretName, fakeClient)
 		require.Nil(t, manifest)
 		require.ErrorContains(t, err, "config secret does not have \"custom.yaml\" field")
 	})
@@ -95,7 +111,7 @@ func TestGetConfigManifest(t *testing.T) {
 			},
 		})
 
-		manifest, err := getConfigManifest(t.Context(), testNamespace, fakeClient)
+		manifest, err := getConfigManifest(t.Context(), testNamespace, secretName, fakeClient)
 		require.Nil(t, manifest)
 		require.ErrorContains(t, err, "failed to unmarshal the content of the config secret")
 	})
@@ -116,7 +132,7 @@ func TestGetConfigManifest(t *testing.T) {
 			},
 		})
 
-		manifest, err := getConfigManifest(t.Context(), testNamespace, fakeClient)
+		manifest, err := getConfigManifest(t.Context(), testNamespace, secretName, fakeClient)
 		require.NoError(t, err)
 		require.Equal(t, &configManifest{
 			Receivers: []receiver{
@@ -141,7 +157,7 @@ func TestSetConfigManifest(t *testing.T) {
 			return true, nil, errors.New("mock error")
 		})
 
-		err := setConfigManifest(t.Context(), fakeClient, configManifest{}, testNamespace)
+		err := setConfigManifest(t.Context(), fakeClient, configManifest{}, testNamespace, secretName)
 
 		require.ErrorContains(t, err, "failed to get alertmanager config secret")
 	})
@@ -168,7 +184,7 @@ func TestSetConfigManifest(t *testing.T) {
 					},
 				},
 			},
-		}, testNamespace)
+		}, testNamespace, secretName)
 
 		require.ErrorContains(t, err, "failed to get alertmanager config secret")
 		require.ErrorContains(t, err, fmt.Sprintf("secrets %q not found", secretName))
@@ -196,7 +212,7 @@ func TestSetConfigManifest(t *testing.T) {
 					},
 				},
 			},
-		}, testNamespace)
+		}, testNamespace, secretName)
 
 		require.ErrorContains(t, err, "failed to get alertmanager config secret")
 		require.ErrorContains(t, err, fmt.Sprintf("secrets %q not found", secretName))
@@ -222,7 +238,7 @@ func TestSetConfigManifest(t *testing.T) {
 			return true, nil, errors.New("mock error")
 		})
 
-		err := setConfigManifest(t.Context(), fakeClient, configManifest{}, testNamespace)
+		err := setConfigManifest(t.Context(), fakeClient, configManifest{}, testNamespace, secretName)
 
 		require.ErrorContains(t, err, "failed to update alertmanager config secret")
 	})
@@ -253,9 +269,9 @@ func TestSetConfigManifest(t *testing.T) {
 
 		fakeClient := testclient.NewClientset(secret)
 
-		require.NoError(t, setConfigManifest(t.Context(), fakeClient, manifest, testNamespace))
+		require.NoError(t, setConfigManifest(t.Context(), fakeClient, manifest, testNamespace, secretName))
 
-		updatedManifest, err := getConfigManifest(t.Context(), testNamespace, fakeClient)
+		updatedManifest, err := getConfigManifest(t.Context(), testNamespace, secretName, fakeClient)
 		require.NoError(t, err)
 		require.Equal(t, manifest, *updatedManifest)
 	})
@@ -403,7 +419,7 @@ route:
 		err := am.UpdateReceiverConfig(t.Context(), dbReceiver)
 		require.NoError(t, err)
 
-		updatedManifest, err := getConfigManifest(t.Context(), testNamespace, fakeClient)
+		updatedManifest, err := getConfigManifest(t.Context(), testNamespace, secretName, fakeClient)
 		require.NoError(t, err)
 		require.Equal(t, &configManifest{
 			Receivers: []receiver{
@@ -438,3 +454,413 @@ route:
 		}, updatedManifest)
 	})
 }
+
+func TestReceiverConfig_UpdateReceiverConfigSharded(t *testing.T) {
+	const shardSecretName = "alert-monitor-config-1"
+
+	data := []byte(`receivers:
+  - name: tenant-receiver-1
+route:
+  routes:
+    - receiver: tenant-receiver-1`)
+
+	newClient := func() *testclient.Clientset {
+		return testclient.NewClientset(
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: testNamespace},
+				Data:       map[string][]byte{"custom.yaml": data},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: shardSecretName, Namespace: testNamespace},
+				Data:       map[string][]byte{"custom.yaml": data},
+			},
+		)
+	}
+
+	conf := config.AlertManagerConfig{
+		Namespace: testNamespace,
+		Shards: []config.AlertManagerShardConfig{{
+			Namespace:  testNamespace,
+			SecretName: shardSecretName,
+		}},
+	}
+
+	dbReceiver := models.DBReceiver{
+		Name:     "receiver",
+		TenantID: "tenant",
+		Version:  2,
+	}
+
+	t.Run("UpdatesSecretOfTenantShard", func(t *testing.T) {
+		fakeClient := newClient()
+		shardsMock := new(TenantShardResolverMock)
+		shardsMock.On("GetTenantShard", mock.Anything, "tenant", 2).Return(1, nil)
+
+		am := &AlertManager{
+			client: fakeClient,
+			shards: shardsMock,
+			config: conf,
+		}
+
+		require.NoError(t, am.UpdateReceiverConfig(t.Context(), dbReceiver))
+		shardsMock.AssertExpectations(t)
+
+		shardManifest, err := getConfigManifest(t.Context(), testNamespace, shardSecretName, fakeClient)
+		require.NoError(t, err)
+		require.Equal(t, "tenant-receiver-2", shardManifest.Receivers[0].Name)
+
+		manifest, err := getConfigManifest(t.Context(), testNamespace, secretName, fakeClient)
+		require.NoError(t, err)
+		require.Equal(t, "tenant-receiver-1", manifest.Receivers[0].Name)
+	})
+
+	t.Run("FailToGetShard", func(t *testing.T) {
+		shardsMock := new(TenantShardResolverMock)
+		shardsMock.On("GetTenantShard", mock.Anything, "tenant", 2).Return(0, errors.New("mock error"))
+
+		am := &AlertManager{
+			client: newClient(),
+			shards: shardsMock,
+			config: conf,
+		}
+
+		err := am.UpdateReceiverConfig(t.Context(), dbReceiver)
+		require.ErrorContains(t, err, `failed to get alertmanager shard of tenant "tenant"`)
+	})
+
+	t.Run("ShardNotConfigured", func(t *testing.T) {
+		shardsMock := new(TenantShardResolverMock)
+		shardsMock.On("GetTenantShard", mock.Anything, "tenant", 2).Return(2, nil)
+
+		am := &AlertManager{
+			client: newClient(),
+			shards: shardsMock,
+			config: conf,
+		}
+
+		err := am.UpdateReceiverConfig(t.Context(), dbReceiver)
+		require.ErrorContains(t, err, "alertmanager shard 2 is not configured")
+	})
+}
+
+func TestReceiverConfig_UpdateReceiverConfigVerify(t *testing.T) {
+	data := []byte(`receivers:
+  - name: tenant-receiver-1
+route:
+  routes:
+    - receiver: tenant-receiver-1`)
+
+	fakeClient := testclient.NewClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: testNamespace},
+		Data:       map[string][]byte{"custom.yaml": data},
+	})
+
+	// mock an update of the config secret that is acknowledged but not persisted.
+	fakeClient.PrependReactor("update", "secrets", func(action ktesting.Action) (handled bool, ret runtime.Object, err error) {
+		return true, action.(ktesting.UpdateAction).GetObject(), nil
+	})
+
+	am := &AlertManager{
+		client: fakeClient,
+		config: config.AlertManagerConfig{
+			Namespace: testNamespace,
+		},
+	}
+
+	err := am.UpdateReceiverConfig(t.Context(), models.DBReceiver{
+		Name:     "receiver",
+		TenantID: "tenant",
+		Version:  2,
+	})
+	require.ErrorIs(t, err, ErrConfigMismatch)
+	require.ErrorContains(t, err, "failed to verify alertmanager config manifest")
+}
+
+type KnownGoodConfigStoreMock struct {
+	mock.Mock
+}
+
+func (m *KnownGoodConfigStoreMock) GetKnownGoodAlertmanagerConfig(ctx context.Context, name string) ([]byte, error) {
+	args := m.Called(ctx, name)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+func (m *KnownGoodConfigStoreMock) SetKnownGoodAlertmanagerConfig(ctx context.Context, name string, manifest []byte) error {
+	args := m.Called(ctx, name, manifest)
+	return args.Error(0)
+}
+
+type AppliedConfigRecorderMock struct {
+	mock.Mock
+}
+
+func (m *AppliedConfigRecorderMock) RecordAppliedArtifact(ctx context.Context, kind models.AppliedArtifactKind, name string, content []byte) error {
+	args := m.Called(ctx, kind, name, content)
+	return args.Error(0)
+}
+
+// newMetricsServer mocks the metrics endpoint of an alertmanager instance, which reports the hash of the content of the given
+// config secret as loaded if reloading succeeds, and the hash of the given initial content along with a failed reload
+// otherwise.
+func newMetricsServer(t *testing.T, client *testclient.Clientset, name string, reloads bool, initial []byte) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/metrics", r.URL.Path)
+
+		loaded, successful := initial, 0
+		if reloads {
+			secret, err := client.CoreV1().Secrets(testNamespace).Get(r.Context(), name, metav1.GetOptions{})
+			require.NoError(t, err)
+			loaded, successful = secret.Data["custom.yaml"], 1
+		}
+		fmt.Fprintf(w, `# HELP alertmanager_config_hash Hash of the currently loaded alertmanager configuration.
+# TYPE alertmanager_config_hash gauge
+alertmanager_config_hash %s
+# HELP alertmanager_config_last_reload_successful Whether the last configuration reload attempt was successful.
+# TYPE alertmanager_config_last_reload_successful gauge
+alertmanager_config_last_reload_successful %d
+`, strconv.FormatFloat(configHash(loaded), 'g', -1, 64), successful)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestReceiverConfig_UpdateReceiverConfigReload(t *testing.T) {
+	const stagingSecretName = "alert-monitor-config-staging"
+
+	reloadPollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { reloadPollInterval = time.Second })
+
+	data := []byte(`receivers:
+  - name: tenant-receiver-1
+route:
+  routes:
+    - receiver: tenant-receiver-1`)
+
+	newClient := func() *testclient.Clientset {
+		return testclient.NewClientset(
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: testNamespace},
+				Data:       map[string][]byte{"custom.yaml": data},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: stagingSecretName, Namespace: testNamespace},
+				Data:       map[string][]byte{"custom.yaml": data},
+			},
+		)
+	}
+
+	dbReceiver := models.DBReceiver{
+		Name:     "receiver",
+		TenantID: "tenant",
+		Version:  2,
+	}
+
+	t.Run("ReloadedConfigRecordedAsKnownGood", func(t *testing.T) {
+		fakeClient := newClient()
+		knownGoodMock := new(KnownGoodConfigStoreMock)
+		knownGoodMock.On("SetKnownGoodAlertmanagerConfig", mock.Anything, testNamespace+"/"+secretName, mock.Anything).Return(nil)
+
+		am := &AlertManager{
+			client:    fakeClient,
+			knownGood: knownGoodMock,
+			config: config.AlertManagerConfig{
+				URL:           newMetricsServer(t, fakeClient, secretName, true, nil).URL,
+				Namespace:     testNamespace,
+				ReloadTimeout: time.Second,
+			},
+		}
+
+		require.NoError(t, am.UpdateReceiverConfig(t.Context(), dbReceiver))
+
+		secret, err := fakeClient.CoreV1().Secrets(testNamespace).Get(t.Context(), secretName, metav1.GetOptions{})
+		require.NoError(t, err)
+		knownGoodMock.AssertCalled(t, "SetKnownGoodAlertmanagerConfig", mock.Anything, testNamespace+"/"+secretName, secret.Data["custom.yaml"])
+	})
+
+	t.Run("ReloadedConfigRecordedAsApplied", func(t *testing.T) {
+		fakeClient := newClient()
+		knownGoodMock := new(KnownGoodConfigStoreMock)
+		knownGoodMock.On("SetKnownGoodAlertmanagerConfig", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		appliedMock := new(AppliedConfigRecorderMock)
+		appliedMock.On("RecordAppliedArtifact", mock.Anything, models.ArtifactAlertmanagerManifest, testNamespace+"/"+secretName, mock.Anything).
+			Return(nil)
+
+		am := &AlertManager{
+			client:    fakeClient,
+			knownGood: knownGoodMock,
+			applied:   appliedMock,
+			config: config.AlertManagerConfig{
+				URL:           newMetricsServer(t, fakeClient, secretName, true, nil).URL,
+				Namespace:     testNamespace,
+				ReloadTimeout: time.Second,
+			},
+		}
+
+		require.NoError(t, am.UpdateReceiverConfig(t.Context(), dbReceiver))
+
+		secret, err := fakeClient.CoreV1().Secrets(testNamespace).Get(t.Context(), secretName, metav1.GetOptions{})
+		require.NoError(t, err)
+		appliedMock.AssertCalled(t, "RecordAppliedArtifact", mock.Anything, models.ArtifactAlertmanagerManifest,
+			testNamespace+"/"+secretName, secret.Data["custom.yaml"])
+	})
+
+	t.Run("FailedReloadNotRecordedAsApplied", func(t *testing.T) {
+		fakeClient := newClient()
+		knownGoodMock := new(KnownGoodConfigStoreMock)
+		knownGoodMock.On("GetKnownGoodAlertmanagerConfig", mock.Anything, testNamespace+"/"+secretName).Return(data, nil)
+		appliedMock := new(AppliedConfigRecorderMock)
+
+		am := &AlertManager{
+			client:    fakeClient,
+			knownGood: knownGoodMock,
+			applied:   appliedMock,
+			config: config.AlertManagerConfig{
+				URL:           newMetricsServer(t, fakeClient, secretName, false, data).URL,
+				Namespace:     testNamespace,
+				ReloadTimeout: 100 * time.Millisecond,
+			},
+		}
+
+		require.ErrorIs(t, am.UpdateReceiverConfig(t.Context(), dbReceiver), ErrReloadFailed)
+		appliedMock.AssertNotCalled(t, "RecordAppliedArtifact", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("FailedReloadRolledBack", func(t *testing.T) {
+		fakeClient := newClient()
+		knownGoodMock := new(KnownGoodConfigStoreMock)
+		knownGoodMock.On("GetKnownGoodAlertmanagerConfig", mock.Anything, testNamespace+"/"+secretName).Return(data, nil)
+
+		am := &AlertManager{
+			client:    fakeClient,
+			knownGood: knownGoodMock,
+			config: config.AlertManagerConfig{
+				URL:           newMetricsServer(t, fakeClient, secretName, false, data).URL,
+				Namespace:     testNamespace,
+				ReloadTimeout: 100 * time.Millisecond,
+			},
+		}
+
+		err := am.UpdateReceiverConfig(t.Context(), dbReceiver)
+		require.ErrorIs(t, err, ErrReloadFailed)
+		require.ErrorContains(t, err, "rolled back to the last known good configuration")
+
+		manifest, err := getConfigManifest(t.Context(), testNamespace, secretName, fakeClient)
+		require.NoError(t, err)
+		require.Equal(t, "tenant-receiver-1", manifest.Receivers[0].Name)
+		knownGoodMock.AssertNotCalled(t, "SetKnownGoodAlertmanagerConfig", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("NoKnownGoodConfig", func(t *testing.T) {
+		fakeClient := newClient()
+		knownGoodMock := new(KnownGoodConfigStoreMock)
+		knownGoodMock.On("GetKnownGoodAlertmanagerConfig", mock.Anything, mock.Anything).Return(nil, errors.New("mock error"))
+
+		am := &AlertManager{
+			client:    fakeClient,
+			knownGood: knownGoodMock,
+			config: config.AlertManagerConfig{
+				URL:           newMetricsServer(t, fakeClient, secretName, false, data).URL,
+				Namespace:     testNamespace,
+				ReloadTimeout: 100 * time.Millisecond,
+			},
+		}
+
+		err := am.UpdateReceiverConfig(t.Context(), dbReceiver)
+		require.ErrorIs(t, err, ErrReloadFailed)
+		require.ErrorContains(t, err, "failed to roll back alertmanager config manifest: mock error")
+	})
+
+	t.Run("RejectedByStaging", func(t *testing.T) {
+		fakeClient := newClient()
+
+		am := &AlertManager{
+			client: fakeClient,
+			config: config.AlertManagerConfig{
+				Namespace:     testNamespace,
+				ReloadTimeout: 100 * time.Millisecond,
+				Staging: config.AlertManagerShardConfig{
+					URL:        newMetricsServer(t, fakeClient, stagingSecretName, false, data).URL,
+					SecretName: stagingSecretName,
+				},
+			},
+		}
+
+		err := am.UpdateReceiverConfig(t.Context(), dbReceiver)
+		require.ErrorIs(t, err, ErrReloadFailed)
+		require.ErrorContains(t, err, "alertmanager manifest is rejected by staging alertmanager")
+
+		// The manifest is not applied to the alertmanager serving the tenants.
+		manifest, err := getConfigManifest(t.Context(), testNamespace, secretName, fakeClient)
+		require.NoError(t, err)
+		require.Equal(t, "tenant-receiver-1", manifest.Receivers[0].Name)
+
+		stagingManifest, err := getConfigManifest(t.Context(), testNamespace, stagingSecretName, fakeClient)
+		require.NoError(t, err)
+		require.Equal(t, "tenant-receiver-2", stagingManifest.Receivers[0].Name)
+	})
+
+	t.Run("ValidatedByStaging", func(t *testing.T) {
+		fakeClient := newClient()
+
+		am := &AlertManager{
+			client: fakeClient,
+			config: config.AlertManagerConfig{
+				Namespace: testNamespace,
+				Staging: config.AlertManagerShardConfig{
+					URL:        newMetricsServer(t, fakeClient, stagingSecretName, true, nil).URL,
+					SecretName: stagingSecretName,
+				},
+			},
+		}
+
+		require.NoError(t, am.UpdateReceiverConfig(t.Context(), dbReceiver))
+
+		manifest, err := getConfigManifest(t.Context(), testNamespace, secretName, fakeClient)
+		require.NoError(t, err)
+		require.Equal(t, "tenant-receiver-2", manifest.Receivers[0].Name)
+	})
+}
+
+func TestWaitForReload(t *testing.T) {
+	reloadPollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { reloadPollInterval = time.Second })
+
+	manifest := configManifest{Receivers: []receiver{{Name: "tenant-receiver-1"}}}
+	data, err := yaml.Marshal(manifest)
+	require.NoError(t, err)
+
+	newServer := func(metrics string) string {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			fmt.Fprint(w, metrics)
+		}))
+		t.Cleanup(server.Close)
+		return server.URL
+	}
+	hash := strconv.FormatFloat(configHash(data), 'g', -1, 64)
+
+	t.Run("Reloaded", func(t *testing.T) {
+		url := newServer("alertmanager_config_hash " + hash + "\nalertmanager_config_last_reload_successful 1\n")
+		require.NoError(t, waitForReload(t.Context(), url, manifest, time.Second))
+	})
+
+	t.Run("LastReloadFailed", func(t *testing.T) {
+		url := newServer("alertmanager_config_hash " + hash + "\nalertmanager_config_last_reload_successful 0\n")
+		err := waitForReload(t.Context(), url, manifest, 50*time.Millisecond)
+		require.ErrorIs(t, err, ErrReloadFailed)
+		require.ErrorContains(t, err, "last reload of alertmanager failed")
+	})
+
+	t.Run("OtherConfigLoaded", func(t *testing.T) {
+		url := newServer("alertmanager_config_hash 1.234e+14\nalertmanager_config_last_reload_successful 1\n")
+		require.ErrorIs(t, waitForReload(t.Context(), url, manifest, 50*time.Millisecond), ErrReloadFailed)
+	})
+
+	t.Run("MetricsMissing", func(t *testing.T) {
+		err := waitForReload(t.Context(), newServer("go_goroutines 42\n"), manifest, 50*time.Millisecond)
+		require.ErrorIs(t, err, ErrReloadFailed)
+		require.ErrorContains(t, err, "does not report the state of its configuration reloads")
+	})
+}
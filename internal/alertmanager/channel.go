@@ -0,0 +1,254 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package alertmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/app"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/notify/email"
+)
+
+const (
+	emailConfigsKey   = "email_configs"
+	webhookConfigsKey = "webhook_configs"
+)
+
+// Channel is a type of notification channel receivers notify through, such as email or Grafana OnCall. A channel renders the
+// alertmanager integrations of a receiver from its fields and from its own configuration.
+type Channel interface {
+	// Name is the unique name of the channel, as listed in the channels allowed by tenant tiers.
+	Name() string
+
+	// Validate verifies that the given receiver, with the values about to be stored, can notify through the channel.
+	Validate(recv models.DBReceiver) error
+
+	// Render returns the alertmanager integrations notifying the given receiver through the channel, none if the receiver
+	// does not notify through it.
+	Render(recv models.DBReceiver, conf config.AlertManagerConfig) ([]Integration, error)
+
+	// Apply is called once the given receiver is applied to alertmanager, for channels provisioning it in an external system.
+	Apply(ctx context.Context, recv models.DBReceiver) error
+}
+
+// Integration is the configuration of an alertmanager integration rendered by a channel, under the given key of the receiver
+// section, e.g. webhook_configs or pagerduty_configs. Config is rendered as YAML.
+type Integration struct {
+	Key    string
+	Config any
+}
+
+var (
+	channelsMu sync.RWMutex
+	channels   []Channel
+)
+
+func init() {
+	RegisterChannel(emailChannel{})
+	RegisterChannel(onCallChannel{})
+}
+
+// RegisterChannel registers a notification channel. It is meant to be called from init functions, so that a distribution adds
+// its own channels by importing the package registering them from cmd/alerting-monitor. Integrations are rendered in the order
+// channels are registered. It panics if a channel of the same name is already registered.
+func RegisterChannel(c Channel) {
+	channelsMu.Lock()
+	defer channelsMu.Unlock()
+
+	for _, registered := range channels {
+		if registered.Name() == c.Name() {
+			panic(fmt.Sprintf("notification channel %q is already registered", c.Name()))
+		}
+	}
+	channels = append(channels, c)
+}
+
+// Channels returns the names of the registered notification channels.
+func Channels() []string {
+	channelsMu.RLock()
+	defer channelsMu.RUnlock()
+
+	names := make([]string, 0, len(channels))
+	for _, c := range channels {
+		names = append(names, c.Name())
+	}
+	return names
+}
+
+func registeredChannels() []Channel {
+	channelsMu.RLock()
+	defer channelsMu.RUnlock()
+
+	return append([]Channel(nil), channels...)
+}
+
+// validateChannels verifies that the given receiver can notify through every registered channel. An error wrapping
+// app.ErrInvalidChannelConfig is returned otherwise.
+func validateChannels(recv models.DBReceiver) error {
+	for _, c := range registeredChannels() {
+		if err := c.Validate(recv); err != nil {
+			return fmt.Errorf("channel %q: %w: %w", c.Name(), app.ErrInvalidChannelConfig, err)
+		}
+	}
+	return nil
+}
+
+// renderReceiver returns the receiver section of the given receiver, with the integrations rendered by every registered channel.
+func renderReceiver(name string, recv models.DBReceiver, conf config.AlertManagerConfig) (receiver, error) {
+	r := receiver{Name: name}
+	for _, c := range registeredChannels() {
+		integrations, err := c.Render(recv, conf)
+		if err != nil {
+			return receiver{}, fmt.Errorf("failed to render channel %q: %w", c.Name(), err)
+		}
+		for _, in := range integrations {
+			if err := r.addIntegration(in); err != nil {
+				return receiver{}, fmt.Errorf("invalid integration of channel %q: %w", c.Name(), err)
+			}
+		}
+	}
+	return r, nil
+}
+
+// applyChannels calls every registered channel once the given receiver is applied to alertmanager.
+func applyChannels(ctx context.Context, recv models.DBReceiver) error {
+	var errs []error
+	for _, c := range registeredChannels() {
+		if err := c.Apply(ctx, recv); err != nil {
+			errs = append(errs, fmt.Errorf("failed to apply channel %q: %w", c.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// addIntegration adds an integration to the receiver. Its configuration goes through YAML, so that the configuration of
+// channels registered by distributions is rendered as read back from alertmanager.
+func (r *receiver) addIntegration(in Integration) error {
+	data, err := yaml.Marshal(in.Config)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", in.Key, err)
+	}
+
+	switch in.Key {
+	case "", "name":
+		return fmt.Errorf("invalid integration key %q", in.Key)
+	case emailConfigsKey:
+		var c emailConfig
+		if err := yaml.UnmarshalStrict(data, &c); err != nil {
+			return fmt.Errorf("invalid %s: %w", in.Key, err)
+		}
+		r.EmailConfigs = append(r.EmailConfigs, c)
+	case webhookConfigsKey:
+		var c webhookConfig
+		if err := yaml.UnmarshalStrict(data, &c); err != nil {
+			return fmt.Errorf("invalid %s: %w", in.Key, err)
+		}
+		r.WebhookConfigs = append(r.WebhookConfigs, c)
+	default:
+		var c any
+		if err := yaml.Unmarshal(data, &c); err != nil {
+			return fmt.Errorf("invalid %s: %w", in.Key, err)
+		}
+		if r.Integrations == nil {
+			r.Integrations = make(map[string][]any)
+		}
+		r.Integrations[in.Key] = append(r.Integrations[in.Key], c)
+	}
+	return nil
+}
+
+// emailChannel notifies the email recipients of receivers. When emails are sent by alerting monitor, alertmanager relays
+// notifications to it instead.
+type emailChannel struct{}
+
+func (emailChannel) Name() string {
+	return config.ChannelEmail
+}
+
+func (emailChannel) Validate(models.DBReceiver) error {
+	return nil
+}
+
+func (emailChannel) Render(recv models.DBReceiver, conf config.AlertManagerConfig) ([]Integration, error) {
+	// Recipients pending verification of their email address are not notified.
+	to := recv.Notified()
+	if len(to) == 0 {
+		return nil, nil
+	}
+
+	if conf.EmailRelayURL != "" {
+		return []Integration{{Key: webhookConfigsKey, Config: newRelayWebhookConfig(recv, conf.EmailRelayURL, emailRelayPath, "EMAIL_RELAY_TOKEN")}}, nil
+	}
+
+	// When emails are signed, alertmanager sends them to the in-cluster signing relay, which requires TLS on its behalf.
+	requireTLS := conf.RequireTLS && conf.SigningRelayHost == ""
+
+	// The template of the tenant, if any, is rendered by alertmanager in place of the template of the deployment, which is
+	// the localized one of the language of the receiver if the deployment defines it.
+	html := emailHTMLTemplate
+	switch {
+	case recv.EmailTemplate != "":
+		html = recv.EmailTemplate
+	case recv.Language != "" && slices.Contains(conf.EmailLanguages, recv.Language):
+		html = fmt.Sprintf(`{{ template %q . }}`, email.LocalizedTemplateName(recv.Language))
+	}
+
+	// The sender and mail server of the receiver are set on each of its emails, as the ones of the global section are shared
+	// by all receivers while they may be overridden per receiver. Signed emails are sent to the signing relay of the global
+	// section regardless.
+	smarthost := recv.MailServer
+	if conf.SigningRelayHost != "" {
+		smarthost = ""
+	}
+
+	integrations := make([]Integration, len(to))
+	for i := range to {
+		c := emailConfig{
+			SendResolved: true,
+			To:           to[i],
+			From:         recv.From,
+			Smarthost:    smarthost,
+			HTML:         html,
+			RequireTLS:   requireTLS,
+		}
+		c.TLSConfig.InsecureSkipVerify = conf.InsecureSkipVerify
+		integrations[i] = Integration{Key: emailConfigsKey, Config: c}
+	}
+	return integrations, nil
+}
+
+func (emailChannel) Apply(context.Context, models.DBReceiver) error {
+	return nil
+}
+
+// onCallChannel relays the alerts of receivers with a routing key to Grafana OnCall, through the relay endpoint of alerting
+// monitor. Alerts are not relayed if the endpoint is not configured.
+type onCallChannel struct{}
+
+func (onCallChannel) Name() string {
+	return config.ChannelOnCall
+}
+
+func (onCallChannel) Validate(models.DBReceiver) error {
+	return nil
+}
+
+func (onCallChannel) Render(recv models.DBReceiver, conf config.AlertManagerConfig) ([]Integration, error) {
+	if recv.OnCallRoutingKey == "" || conf.OnCallRelayURL == "" {
+		return nil, nil
+	}
+	return []Integration{{Key: webhookConfigsKey, Config: newRelayWebhookConfig(recv, conf.OnCallRelayURL, onCallRelayPath, "ONCALL_RELAY_TOKEN")}}, nil
+}
+
+func (onCallChannel) Apply(context.Context, models.DBReceiver) error {
+	return nil
+}
@@ -0,0 +1,193 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package alertmanager
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/app"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+// pagerChannel pages the receivers named "pager" through a PagerDuty integration, as a channel registered by a distribution.
+type pagerChannel struct {
+	applied []string
+}
+
+func (*pagerChannel) Name() string {
+	return "pager"
+}
+
+func (*pagerChannel) Validate(recv models.DBReceiver) error {
+	if recv.Name == "pager" && recv.OnCallRoutingKey == "" {
+		return errors.New("routing key is required")
+	}
+	return nil
+}
+
+func (*pagerChannel) Render(recv models.DBReceiver, _ config.AlertManagerConfig) ([]Integration, error) {
+	if recv.Name != "pager" {
+		return nil, nil
+	}
+	return []Integration{{
+		Key: "pagerduty_configs",
+		Config: struct {
+			RoutingKey   string `yaml:"routing_key"`
+			SendResolved bool   `yaml:"send_resolved"`
+		}{RoutingKey: recv.OnCallRoutingKey, SendResolved: true},
+	}}, nil
+}
+
+func (c *pagerChannel) Apply(_ context.Context, recv models.DBReceiver) error {
+	c.applied = append(c.applied, recv.Name)
+	return nil
+}
+
+// registerTestChannel registers the given channel until the end of the test.
+func registerTestChannel(t *testing.T, c Channel) {
+	registered := registeredChannels()
+	t.Cleanup(func() {
+		channelsMu.Lock()
+		defer channelsMu.Unlock()
+		channels = registered
+	})
+	RegisterChannel(c)
+}
+
+func TestRegisterChannel(t *testing.T) {
+	registerTestChannel(t, &pagerChannel{})
+
+	require.Equal(t, []string{config.ChannelEmail, config.ChannelOnCall, "pager"}, Channels())
+	require.Panics(t, func() { RegisterChannel(emailChannel{}) })
+}
+
+func TestChannels(t *testing.T) {
+	pager := &pagerChannel{}
+	registerTestChannel(t, pager)
+
+	recv := models.DBReceiver{
+		Name:             "pager",
+		TenantID:         "tenant",
+		Version:          2,
+		To:               []string{"test user <test@user.com>"},
+		OnCallRoutingKey: "routing-key",
+	}
+
+	t.Run("Integrations of registered channels are rendered", func(t *testing.T) {
+		manifest := configManifest{
+			Route:     route{Receiver: "default", Routes: []subRoute{{Receiver: "tenant-pager-1"}}},
+			Receivers: []receiver{{Name: "default"}, {Name: "tenant-pager-1"}},
+		}
+
+		expected, err := manifest.ApplyReceiver(recv, config.AlertManagerConfig{}, config.TenancyConfig{})
+		require.NoError(t, err)
+		require.Len(t, expected.Receivers[1].EmailConfigs, 1)
+		require.Equal(t, []any{map[any]any{"routing_key": "routing-key", "send_resolved": true}},
+			expected.Receivers[1].Integrations["pagerduty_configs"])
+
+		data, err := yaml.Marshal(expected)
+		require.NoError(t, err)
+		require.Contains(t, string(data), "pagerduty_configs:\n  - routing_key: routing-key\n")
+
+		var applied configManifest
+		require.NoError(t, yaml.Unmarshal(data, &applied))
+		require.NoError(t, applied.VerifyReceiver(*expected, recv))
+	})
+
+	t.Run("Receiver is validated by registered channels", func(t *testing.T) {
+		invalid := recv
+		invalid.OnCallRoutingKey = ""
+		require.ErrorIs(t, validateChannels(invalid), app.ErrInvalidChannelConfig)
+		require.NoError(t, validateChannels(recv))
+	})
+
+	t.Run("Registered channels are applied", func(t *testing.T) {
+		require.NoError(t, applyChannels(t.Context(), recv))
+		require.Equal(t, []string{"pager"}, pager.applied)
+	})
+
+	t.Run("Integration of a built-in key is rendered as such", func(t *testing.T) {
+		var r receiver
+		require.NoError(t, r.addIntegration(Integration{
+			Key:    webhookConfigsKey,
+			Config: map[string]any{"url": "http://pager:8080/hook", "send_resolved": true},
+		}))
+		require.Equal(t, []webhookConfig{{URL: "http://pager:8080/hook", SendResolved: true}}, r.WebhookConfigs)
+
+		require.Error(t, r.addIntegration(Integration{Key: webhookConfigsKey, Config: map[string]any{"unknown": true}}))
+		require.Error(t, r.addIntegration(Integration{Key: "name", Config: "pager"}))
+	})
+}
+
+func TestEmailChannel_Render(t *testing.T) {
+	recv := models.DBReceiver{
+		Name:     "receiver",
+		TenantID: "tenant",
+		Version:  1,
+		To:       []string{"test user <test@user.com>"},
+	}
+
+	t.Run("Emails are rendered with the template of the deployment", func(t *testing.T) {
+		integrations, err := emailChannel{}.Render(recv, config.AlertManagerConfig{})
+		require.NoError(t, err)
+		require.Len(t, integrations, 1)
+		require.Equal(t, emailHTMLTemplate, integrations[0].Config.(emailConfig).HTML)
+	})
+
+	t.Run("Emails are rendered with the template of the tenant", func(t *testing.T) {
+		withTemplate := recv
+		withTemplate.EmailTemplate = `<p>{{ .Alerts | len }} alerts</p>`
+
+		integrations, err := emailChannel{}.Render(withTemplate, config.AlertManagerConfig{})
+		require.NoError(t, err)
+		require.Len(t, integrations, 1)
+		require.Equal(t, `<p>{{ .Alerts | len }} alerts</p>`, integrations[0].Config.(emailConfig).HTML)
+	})
+
+	t.Run("Emails are rendered with the localized template of the receiver language", func(t *testing.T) {
+		withLanguage := recv
+		withLanguage.Language = "de"
+		conf := config.AlertManagerConfig{EmailLanguages: []string{"de", "fr"}}
+
+		integrations, err := emailChannel{}.Render(withLanguage, conf)
+		require.NoError(t, err)
+		require.Len(t, integrations, 1)
+		require.Equal(t, `{{ template "alert.monitor.mail.de" . }}`, integrations[0].Config.(emailConfig).HTML)
+
+		// Languages without a localized template fall back to the template of the deployment.
+		withLanguage.Language = "ja"
+		integrations, err = emailChannel{}.Render(withLanguage, conf)
+		require.NoError(t, err)
+		require.Equal(t, emailHTMLTemplate, integrations[0].Config.(emailConfig).HTML)
+	})
+
+	t.Run("Recipients pending verification are not notified", func(t *testing.T) {
+		withUnverified := recv
+		withUnverified.To = []string{"test user <test@user.com>", "new user <new@user.com>"}
+		withUnverified.Unverified = []string{"new user <new@user.com>"}
+
+		integrations, err := emailChannel{}.Render(withUnverified, config.AlertManagerConfig{})
+		require.NoError(t, err)
+		require.Len(t, integrations, 1)
+		require.Equal(t, "test user <test@user.com>", integrations[0].Config.(emailConfig).To)
+
+		withUnverified.Unverified = withUnverified.To
+		integrations, err = emailChannel{}.Render(withUnverified, config.AlertManagerConfig{})
+		require.NoError(t, err)
+		require.Empty(t, integrations)
+	})
+
+	t.Run("Emails sent by alerting monitor are relayed to it", func(t *testing.T) {
+		integrations, err := emailChannel{}.Render(recv, config.AlertManagerConfig{EmailRelayURL: "http://alerting-monitor:8080"})
+		require.NoError(t, err)
+		require.Len(t, integrations, 1)
+		require.Equal(t, webhookConfigsKey, integrations[0].Key)
+	})
+}
@@ -0,0 +1,727 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package alertmanager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/app"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/correlation"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+const (
+	grafanaProvisioningPath = "/api/v1/provisioning"
+	grafanaAlertsPath       = "/api/alertmanager/grafana/api/v2/alerts"
+)
+
+// errGrafanaNotFound is returned when an object of the provisioning API of Grafana does not exist.
+var errGrafanaNotFound = errors.New("not found in grafana")
+
+// matcherRegex matches the route matchers rendered for receivers, e.g. severity=~"critical|warning".
+var matcherRegex = regexp.MustCompile(`^([a-zA-Z_][a-zA-Z0-9_]*)(=~|!~|!=|=)(".*")$`)
+
+// grafanaContactPoint is a contact point of the provisioning API of Grafana. The contact points of the same name make up a
+// receiver, each of them being one of its integrations.
+type grafanaContactPoint struct {
+	UID                   string         `json:"uid,omitempty" yaml:"uid,omitempty"`
+	Name                  string         `json:"name" yaml:"name"`
+	Type                  string         `json:"type" yaml:"type"`
+	Settings              map[string]any `json:"settings" yaml:"settings"`
+	DisableResolveMessage bool           `json:"disableResolveMessage" yaml:"disableResolveMessage,omitempty"`
+}
+
+// grafanaRoute is a notification policy of the provisioning API of Grafana, as rendered for a receiver. Matchers are given by
+// label name, operator and value.
+type grafanaRoute struct {
+	Receiver          string         `json:"receiver,omitempty" yaml:"receiver,omitempty"`
+	ObjectMatchers    [][3]string    `json:"object_matchers,omitempty" yaml:"object_matchers,omitempty"`
+	MuteTimeIntervals []string       `json:"mute_time_intervals,omitempty" yaml:"mute_time_intervals,omitempty"`
+	Routes            []grafanaRoute `json:"routes,omitempty" yaml:"routes,omitempty"`
+	Continue          bool           `json:"continue,omitempty" yaml:"continue,omitempty"`
+}
+
+// grafanaPolicyTree is the notification policy tree of Grafana. Its fields and child routes are kept as decoded, so that
+// the ones not managed by alerting monitor are put back unchanged.
+type grafanaPolicyTree map[string]any
+
+// Grafana refers to a Grafana instance whose alerting is provisioned with receivers through its provisioning API, for
+// deployments standardizing on Grafana-managed alerting. A receiver is provisioned as contact points named after it, one
+// per integration rendered by the registered notification channels, and as a child route of the notification policy tree
+// with the quiet hours and maintenance window muting it as mute timings. The optional GRAFANA_TOKEN environment variable holds
+// the token of the service account alerting monitor authenticates to Grafana with.
+// Implements the AlertmanagerConfigurator and TenantConfigRemover interfaces.
+type Grafana struct {
+	url   string
+	token string
+
+	config  config.AlertManagerConfig
+	tenancy config.TenancyConfig
+}
+
+// NewGrafana returns a Grafana provisioned with receivers as given by the configuration. Routes match tenants by the tenant
+// label of the given tenancy configuration.
+func NewGrafana(conf config.AlertManagerConfig, tenancy config.TenancyConfig) (*Grafana, error) {
+	if conf.Grafana.URL == "" {
+		return nil, errors.New("url of grafana is not set")
+	}
+
+	return &Grafana{
+		url:     strings.TrimSuffix(conf.Grafana.URL, "/"),
+		token:   os.Getenv("GRAFANA_TOKEN"),
+		config:  conf,
+		tenancy: tenancy,
+	}, nil
+}
+
+// UpdateReceiverConfig provisions the contact points, route and mute timings of the given receiver, and removes the ones it
+// does not use anymore. A receiver without integrations has no route, since Grafana requires receivers to have contact points.
+// The route is read back to verify that it was applied as expected. Transient errors are retried as given by the
+// configuration. The registered notification channels are then applied.
+func (g *Grafana) UpdateReceiverConfig(ctx context.Context, receiver models.DBReceiver) error {
+	if err := retryTransient(ctx, g.config.ApplyRetry, func() error {
+		return g.updateReceiverConfig(ctx, receiver)
+	}); err != nil {
+		return err
+	}
+	return applyChannels(ctx, receiver)
+}
+
+func (g *Grafana) updateReceiverConfig(ctx context.Context, recv models.DBReceiver) error {
+	name := fmt.Sprintf("%s-%s", recv.TenantID, recv.Name)
+	contactPoints, route, intervals, err := g.renderReceiver(recv)
+	if err != nil {
+		return err
+	}
+
+	// The route is checked against the other routes before anything is provisioned.
+	tree, err := g.getPolicyTree(ctx)
+	if err != nil {
+		return err
+	}
+	if err := tree.setRoute(name, route, receiverTenantMatcher(recv.TenantID, g.tenancy)); err != nil {
+		return err
+	}
+	if err := tree.checkRoutes(recv, g.tenancy); err != nil {
+		return err
+	}
+
+	// Contact points and mute timings are provisioned before the route referencing them.
+	if len(contactPoints) > 0 {
+		if err := g.putContactPoints(ctx, name, contactPoints); err != nil {
+			return err
+		}
+	}
+	for _, interval := range intervals {
+		if err := g.putMuteTiming(ctx, interval); err != nil {
+			return err
+		}
+	}
+
+	if err := g.putPolicyTree(ctx, tree); err != nil {
+		return err
+	}
+
+	// Contact points and mute timings are removed once no route references them anymore.
+	if len(contactPoints) == 0 {
+		if err := g.deleteContactPoints(ctx, name); err != nil {
+			return err
+		}
+	}
+	if route == nil || !recv.QuietHours.IsSet() {
+		if err := g.deleteMuteTiming(ctx, quietHoursIntervalName(name)); err != nil {
+			return err
+		}
+	}
+	maintenanceName := maintenanceIntervalName(recv.TenantID)
+	if (route == nil || recv.Maintenance == nil) && !tree.mutesWith(maintenanceName) {
+		if err := g.deleteMuteTiming(ctx, maintenanceName); err != nil {
+			return err
+		}
+	}
+
+	applied, err := g.getPolicyTree(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read back grafana notification policies: %w", err)
+	}
+	if err := compareRendered("route", name, route, applied.route(name)); err != nil {
+		return fmt.Errorf("failed to verify grafana notification policies: %w", err)
+	}
+	return nil
+}
+
+// ValidateReceiverConfig verifies that the given receiver can notify through the registered notification channels, that its
+// integrations can be provisioned as contact points, and that the notification policy tree with it applied does not exceed
+// the configured limits nor has conflicting routes. An error wrapping app.ErrInvalidChannelConfig, app.ErrConfigLimitExceeded
+// or app.ErrRouteConflict is returned otherwise.
+func (g *Grafana) ValidateReceiverConfig(ctx context.Context, receiver models.DBReceiver) error {
+	if err := validateChannels(receiver); err != nil {
+		return err
+	}
+
+	contactPoints, route, _, err := g.renderReceiver(receiver)
+	if err != nil {
+		return fmt.Errorf("%w: %w", app.ErrInvalidChannelConfig, err)
+	}
+
+	tree, err := g.getPolicyTree(ctx)
+	if err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%s-%s", receiver.TenantID, receiver.Name)
+	if err := tree.setRoute(name, route, receiverTenantMatcher(receiver.TenantID, g.tenancy)); err != nil {
+		return err
+	}
+	if err := tree.checkRoutes(receiver, g.tenancy); err != nil {
+		return err
+	}
+	return g.checkLimits(len(tree.children()), contactPoints)
+}
+
+// PreviewReceiverConfig returns the contact points and the route the given receiver is to be provisioned with.
+func (g *Grafana) PreviewReceiverConfig(_ context.Context, receiver models.DBReceiver) (api.ReceiverConfigPreview, error) {
+	contactPoints, route, _, err := g.renderReceiver(receiver)
+	if err != nil {
+		return api.ReceiverConfigPreview{}, err
+	}
+
+	receiverData, err := yaml.Marshal(contactPoints)
+	if err != nil {
+		return api.ReceiverConfigPreview{}, fmt.Errorf("failed to marshal contact points: %w", err)
+	}
+
+	var routeData []byte
+	if route != nil {
+		if routeData, err = yaml.Marshal(route); err != nil {
+			return api.ReceiverConfigPreview{}, fmt.Errorf("failed to marshal route: %w", err)
+		}
+	}
+	return api.ReceiverConfigPreview{Receiver: string(receiverData), Route: string(routeData)}, nil
+}
+
+// RemoveTenantConfig removes the routes of the given tenant from the notification policy tree, along with the contact points
+// and mute timings only referenced by these routes.
+func (g *Grafana) RemoveTenantConfig(ctx context.Context, tenantID string) error {
+	tree, err := g.getPolicyTree(ctx)
+	if err != nil {
+		return err
+	}
+
+	matcher := [3]string{g.tenancy.TenantLabel(), "=~", tenantID}
+	removedReceivers := make(map[string]bool)
+	removedIntervals := make(map[string]bool)
+	var kept []any
+	for _, child := range tree.children() {
+		route, err := decodeGrafanaRoute(child)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(route.ObjectMatchers, matcher) {
+			kept = append(kept, child)
+			continue
+		}
+		removedReceivers[route.Receiver] = true
+		for _, name := range route.MuteTimeIntervals {
+			removedIntervals[name] = true
+		}
+	}
+	tree["routes"] = kept
+
+	if err := g.putPolicyTree(ctx, tree); err != nil {
+		return err
+	}
+
+	// Contact points and mute timings still referenced by any remaining route are kept.
+	for _, child := range tree.children() {
+		route, err := decodeGrafanaRoute(child)
+		if err != nil {
+			return err
+		}
+		delete(removedReceivers, route.Receiver)
+	}
+	if receiver, ok := tree["receiver"].(string); ok {
+		delete(removedReceivers, receiver)
+	}
+
+	var errs []error
+	for _, name := range slices.Sorted(maps.Keys(removedReceivers)) {
+		errs = append(errs, g.deleteContactPoints(ctx, name))
+	}
+	for _, name := range slices.Sorted(maps.Keys(removedIntervals)) {
+		if !tree.mutesWith(name) {
+			errs = append(errs, g.deleteMuteTiming(ctx, name))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// HasActiveAlerts tells whether the alertmanager of Grafana holds any active alert of the given tenant.
+func (g *Grafana) HasActiveAlerts(ctx context.Context, tenantID string) (bool, error) {
+	params := make(url.Values)
+	params.Add("active", "true")
+	params.Add("filter", app.TenantFilter(g.tenancy.TenantLabel(), tenantID))
+
+	var alerts []json.RawMessage
+	if err := g.do(ctx, http.MethodGet, grafanaAlertsPath+"?"+params.Encode(), nil, &alerts); err != nil {
+		return false, err
+	}
+	return len(alerts) > 0, nil
+}
+
+// renderReceiver returns the contact points, the route and the mute timings the given receiver is provisioned with. The route
+// is nil if the receiver has no integrations or is disabled, its contact points being kept in the latter case.
+func (g *Grafana) renderReceiver(recv models.DBReceiver) ([]grafanaContactPoint, *grafanaRoute, []timeInterval, error) {
+	name := fmt.Sprintf("%s-%s", recv.TenantID, recv.Name)
+	rendered, err := renderReceiver(name, recv, g.config)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	contactPoints, err := newGrafanaContactPoints(rendered)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(contactPoints) == 0 {
+		return nil, nil, nil, nil
+	}
+	if recv.Disabled {
+		return contactPoints, nil, nil, nil
+	}
+
+	r, intervals := receiverRoute(recv, name, g.tenancy)
+	route, err := newGrafanaRoute(r)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return contactPoints, &route, intervals, nil
+}
+
+// checkLimits verifies that the given number of routes and the email recipients of the given contact points do not exceed the
+// limits given by the configuration. An error wrapping app.ErrConfigLimitExceeded is returned otherwise, and the rejection is
+// counted.
+func (g *Grafana) checkLimits(routes int, contactPoints []grafanaContactPoint) error {
+	if g.config.MaxRoutes > 0 && routes > g.config.MaxRoutes {
+		configRejections.WithLabelValues(limitRoutes).Inc()
+		return fmt.Errorf("%d routes exceed the limit of %d: %w", routes, g.config.MaxRoutes, app.ErrConfigLimitExceeded)
+	}
+
+	if g.config.MaxRecipientsPerRoute > 0 {
+		for _, cp := range contactPoints {
+			addresses, _ := cp.Settings["addresses"].(string)
+			if n := len(strings.Split(addresses, ";")); cp.Type == "email" && n > g.config.MaxRecipientsPerRoute {
+				configRejections.WithLabelValues(limitRecipients).Inc()
+				return fmt.Errorf("%d recipients of route %q exceed the limit of %d: %w",
+					n, cp.Name, g.config.MaxRecipientsPerRoute, app.ErrConfigLimitExceeded)
+			}
+		}
+	}
+	return nil
+}
+
+// newGrafanaContactPoints returns the contact points of the integrations of the given receiver. Its email recipients are
+// notified by a single email contact point, which sends them separate emails. The integrations of channels registered by
+// distributions are provisioned as contact points of the type named by their key, with their configuration as settings,
+// which must then be the settings Grafana takes for the type.
+func newGrafanaContactPoints(r receiver) ([]grafanaContactPoint, error) {
+	var contactPoints []grafanaContactPoint
+	if len(r.EmailConfigs) > 0 {
+		addresses := make([]string, len(r.EmailConfigs))
+		for i, c := range r.EmailConfigs {
+			addresses[i] = c.To
+		}
+		contactPoints = append(contactPoints, grafanaContactPoint{
+			Name: r.Name,
+			Type: "email",
+			Settings: map[string]any{
+				"addresses":   strings.Join(addresses, ";"),
+				"singleEmail": false,
+				"message":     r.EmailConfigs[0].HTML,
+			},
+			DisableResolveMessage: !r.EmailConfigs[0].SendResolved,
+		})
+	}
+
+	for _, c := range r.WebhookConfigs {
+		settings := map[string]any{"url": c.URL}
+		if c.HTTPConfig != nil && c.HTTPConfig.Authorization != nil {
+			settings["authorization_scheme"] = c.HTTPConfig.Authorization.Type
+			settings["authorization_credentials"] = c.HTTPConfig.Authorization.Credentials
+		}
+		contactPoints = append(contactPoints, grafanaContactPoint{
+			Name:                  r.Name,
+			Type:                  "webhook",
+			Settings:              settings,
+			DisableResolveMessage: !c.SendResolved,
+		})
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(r.Integrations)) {
+		for _, c := range r.Integrations[key] {
+			settings, ok := jsonValue(c).(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("invalid %s of receiver %q, expected a mapping", key, r.Name)
+			}
+			sendResolved, ok := settings["send_resolved"].(bool)
+			delete(settings, "send_resolved")
+			contactPoints = append(contactPoints, grafanaContactPoint{
+				Name:                  r.Name,
+				Type:                  strings.TrimSuffix(key, "_configs"),
+				Settings:              settings,
+				DisableResolveMessage: ok && !sendResolved,
+			})
+		}
+	}
+	return contactPoints, nil
+}
+
+// jsonValue returns the given value decoded from YAML with its mappings keyed by strings, so that it can be encoded to JSON.
+func jsonValue(v any) any {
+	switch v := v.(type) {
+	case map[any]any:
+		m := make(map[string]any, len(v))
+		for key, value := range v {
+			m[fmt.Sprint(key)] = jsonValue(value)
+		}
+		return m
+	case []any:
+		values := make([]any, len(v))
+		for i, value := range v {
+			values[i] = jsonValue(value)
+		}
+		return values
+	default:
+		return v
+	}
+}
+
+// newGrafanaRoute returns the notification policy of the given route, with its matchers split into label name, operator and
+// value.
+func newGrafanaRoute(r subRoute) (grafanaRoute, error) {
+	route := grafanaRoute{
+		Receiver:          r.Receiver,
+		MuteTimeIntervals: r.MuteTimeIntervals,
+		Continue:          r.Continue,
+	}
+	for _, matcher := range r.Matchers {
+		m := matcherRegex.FindStringSubmatch(matcher)
+		if m == nil {
+			return grafanaRoute{}, fmt.Errorf("invalid matcher %q of route %q", matcher, r.Receiver)
+		}
+		value, err := strconv.Unquote(m[3])
+		if err != nil {
+			return grafanaRoute{}, fmt.Errorf("invalid value of matcher %q of route %q: %w", matcher, r.Receiver, err)
+		}
+		route.ObjectMatchers = append(route.ObjectMatchers, [3]string{m[1], m[2], value})
+	}
+	for _, child := range r.Routes {
+		childRoute, err := newGrafanaRoute(child)
+		if err != nil {
+			return grafanaRoute{}, err
+		}
+		route.Routes = append(route.Routes, childRoute)
+	}
+	return route, nil
+}
+
+// decodeGrafanaRoute decodes a child route of the notification policy tree.
+func decodeGrafanaRoute(child any) (grafanaRoute, error) {
+	data, err := json.Marshal(child)
+	if err != nil {
+		return grafanaRoute{}, fmt.Errorf("failed to marshal grafana route: %w", err)
+	}
+
+	var route grafanaRoute
+	if err := json.Unmarshal(data, &route); err != nil {
+		return grafanaRoute{}, fmt.Errorf("failed to unmarshal grafana route: %w", err)
+	}
+	return route, nil
+}
+
+// children returns the child routes of the root of the notification policy tree.
+func (t grafanaPolicyTree) children() []any {
+	children, _ := t["routes"].([]any)
+	return children
+}
+
+// route returns the child route of the root of the notification policy tree to the given receiver, nil if there is none or
+// it cannot be decoded.
+func (t grafanaPolicyTree) route(receiver string) *grafanaRoute {
+	for _, child := range t.children() {
+		if route, err := decodeGrafanaRoute(child); err == nil && route.Receiver == receiver {
+			return &route
+		}
+	}
+	return nil
+}
+
+// setRoute replaces the child route of the root of the notification policy tree to the given receiver with the given route,
+// which is appended if there is none, and removed if nil. Like in alertmanager manifests, a route which continues is placed
+// before the first route of the tenant matched by the given tenant matcher which does not, and a route which stopped
+// continuing is moved after the other routes of the tenant.
+func (t grafanaPolicyTree) setRoute(receiver string, route *grafanaRoute, tenantMatcher string) error {
+	children := slices.Clone(t.children())
+	routes := make([]subRoute, len(children))
+	index := -1
+	for i, child := range children {
+		r, err := decodeGrafanaRoute(child)
+		if err != nil {
+			return err
+		}
+		routes[i] = r.matchedRoute()
+		if r.Receiver == receiver && index < 0 {
+			index = i
+		}
+	}
+
+	switch {
+	case route == nil && index >= 0:
+		children = slices.Delete(children, index, index+1)
+	case route == nil:
+	case route.Continue:
+		if index >= 0 {
+			children = slices.Delete(children, index, index+1)
+			routes = slices.Delete(routes, index, index+1)
+		}
+		at := slices.IndexFunc(routes, func(r subRoute) bool {
+			return !r.Continue && slices.Contains(r.Matchers, tenantMatcher)
+		})
+		if at < 0 {
+			at = len(children)
+		}
+		children = slices.Insert(children, at, any(route))
+	case index >= 0 && !routes[index].Continue:
+		children[index] = route
+	default:
+		if index >= 0 {
+			children = slices.Delete(children, index, index+1)
+		}
+		children = append(children, route)
+	}
+	t["routes"] = children
+	return nil
+}
+
+// mutesWith reports whether any route of the notification policy tree is muted by the named mute timing.
+func (t grafanaPolicyTree) mutesWith(intervalName string) bool {
+	var routes []subRoute
+	for _, child := range t.children() {
+		route, err := decodeGrafanaRoute(child)
+		if err != nil {
+			// Mute timings referenced by routes which cannot be decoded are assumed to be in use.
+			return true
+		}
+		routes = append(routes, route.subRoute())
+	}
+	return mutesWith(routes, intervalName)
+}
+
+// checkRoutes verifies that the route of the given receiver, if it has one, neither shadows nor is shadowed by the route of
+// another receiver of its tenant in the notification policy tree. An error wrapping app.ErrRouteConflict is returned otherwise.
+func (t grafanaPolicyTree) checkRoutes(recv models.DBReceiver, tenancy config.TenancyConfig) error {
+	var routes []subRoute
+	for _, child := range t.children() {
+		route, err := decodeGrafanaRoute(child)
+		if err != nil {
+			return err
+		}
+		routes = append(routes, route.matchedRoute())
+	}
+
+	name := fmt.Sprintf("%s-%s", recv.TenantID, recv.Name)
+	if other := conflictingRoute(routes, name, receiverTenantMatcher(recv.TenantID, tenancy)); other != "" {
+		return fmt.Errorf("route of receiver %q is rejected: %w", recv.Name,
+			&app.RouteConflictError{Receiver: strings.TrimPrefix(other, recv.TenantID+"-")})
+	}
+	return nil
+}
+
+// matchedRoute returns the route without its child routes, as far as the alerts it matches. Matchers are rendered back as
+// given to newGrafanaRoute, so that they compare with the matchers rendered for receivers.
+func (r grafanaRoute) matchedRoute() subRoute {
+	route := subRoute{Receiver: r.Receiver, Continue: r.Continue}
+	for _, m := range r.ObjectMatchers {
+		route.Matchers = append(route.Matchers, fmt.Sprintf("%s%s%q", m[0], m[1], m[2]))
+	}
+	return route
+}
+
+// subRoute returns the route without its matchers, as far as the receivers and mute timings it references.
+func (r grafanaRoute) subRoute() subRoute {
+	route := subRoute{Receiver: r.Receiver, MuteTimeIntervals: r.MuteTimeIntervals}
+	for _, child := range r.Routes {
+		route.Routes = append(route.Routes, child.subRoute())
+	}
+	return route
+}
+
+// getPolicyTree gets the notification policy tree of Grafana.
+func (g *Grafana) getPolicyTree(ctx context.Context) (grafanaPolicyTree, error) {
+	var tree grafanaPolicyTree
+	if err := g.do(ctx, http.MethodGet, grafanaProvisioningPath+"/policies", nil, &tree); err != nil {
+		return nil, fmt.Errorf("failed to get grafana notification policies: %w", err)
+	}
+	return tree, nil
+}
+
+// putPolicyTree replaces the notification policy tree of Grafana, which rejects a tree referencing unknown contact points or
+// mute timings.
+func (g *Grafana) putPolicyTree(ctx context.Context, tree grafanaPolicyTree) error {
+	if err := g.do(ctx, http.MethodPut, grafanaProvisioningPath+"/policies", tree, nil); err != nil {
+		return fmt.Errorf("failed to set grafana notification policies: %w", err)
+	}
+	return nil
+}
+
+// getContactPoints gets the contact points of the given name.
+func (g *Grafana) getContactPoints(ctx context.Context, name string) ([]grafanaContactPoint, error) {
+	var contactPoints []grafanaContactPoint
+	path := grafanaProvisioningPath + "/contact-points?" + url.Values{"name": {name}}.Encode()
+	if err := g.do(ctx, http.MethodGet, path, nil, &contactPoints); err != nil {
+		return nil, fmt.Errorf("failed to get grafana contact points %q: %w", name, err)
+	}
+	return contactPoints, nil
+}
+
+// putContactPoints replaces the contact points of the given name with the given ones. Existing contact points are updated in
+// place, since a contact point referenced by a route cannot be deleted, and the surplus ones are deleted.
+func (g *Grafana) putContactPoints(ctx context.Context, name string, contactPoints []grafanaContactPoint) error {
+	existing, err := g.getContactPoints(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	for i, cp := range contactPoints {
+		if i < len(existing) {
+			cp.UID = existing[i].UID
+			err = g.do(ctx, http.MethodPut, grafanaProvisioningPath+"/contact-points/"+url.PathEscape(cp.UID), cp, nil)
+		} else {
+			err = g.do(ctx, http.MethodPost, grafanaProvisioningPath+"/contact-points", cp, nil)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to set grafana contact point %q: %w", name, err)
+		}
+	}
+
+	for _, cp := range existing[min(len(contactPoints), len(existing)):] {
+		if err := g.deleteContactPoint(ctx, cp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteContactPoints deletes the contact points of the given name.
+func (g *Grafana) deleteContactPoints(ctx context.Context, name string) error {
+	existing, err := g.getContactPoints(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	for _, cp := range existing {
+		if err := g.deleteContactPoint(ctx, cp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteContactPoint deletes the given contact point. A contact point that does not exist is not considered an error.
+func (g *Grafana) deleteContactPoint(ctx context.Context, cp grafanaContactPoint) error {
+	err := g.do(ctx, http.MethodDelete, grafanaProvisioningPath+"/contact-points/"+url.PathEscape(cp.UID), nil, nil)
+	if err != nil && !errors.Is(err, errGrafanaNotFound) {
+		return fmt.Errorf("failed to delete grafana contact point %q: %w", cp.Name, err)
+	}
+	return nil
+}
+
+// putMuteTiming creates or replaces the mute timing of the given time interval.
+func (g *Grafana) putMuteTiming(ctx context.Context, interval timeInterval) error {
+	path := grafanaProvisioningPath + "/mute-timings/" + url.PathEscape(interval.Name)
+	err := g.do(ctx, http.MethodGet, path, nil, nil)
+	switch {
+	case errors.Is(err, errGrafanaNotFound):
+		err = g.do(ctx, http.MethodPost, grafanaProvisioningPath+"/mute-timings", interval, nil)
+	case err == nil:
+		err = g.do(ctx, http.MethodPut, path, interval, nil)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to set grafana mute timing %q: %w", interval.Name, err)
+	}
+	return nil
+}
+
+// deleteMuteTiming deletes the mute timing of the given name. A mute timing that does not exist is not considered an error.
+func (g *Grafana) deleteMuteTiming(ctx context.Context, name string) error {
+	err := g.do(ctx, http.MethodDelete, grafanaProvisioningPath+"/mute-timings/"+url.PathEscape(name), nil, nil)
+	if err != nil && !errors.Is(err, errGrafanaNotFound) {
+		return fmt.Errorf("failed to delete grafana mute timing %q: %w", name, err)
+	}
+	return nil
+}
+
+// do sends a request with the given body encoded as JSON, if not nil, to the given path of the API of Grafana, and decodes
+// the JSON response into out, if not nil. An error wrapping errGrafanaNotFound is returned if Grafana responds with status
+// code 404, and an error for any other status code than a successful one.
+func (g *Grafana) do(ctx context.Context, method, path string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, g.url+path, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if g.token != "" {
+		req.Header.Set("Authorization", "Bearer "+g.token)
+	}
+	correlation.SetHeader(req)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return fmt.Errorf("%s %s: %w", method, path, errGrafanaNotFound)
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("grafana returned status code %v: %s", resp.StatusCode, bytes.TrimSpace(msg))
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
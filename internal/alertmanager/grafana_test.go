@@ -0,0 +1,386 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package alertmanager
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/app"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+// fakeGrafana serves the provisioning API of Grafana from memory. Like Grafana, it rejects deleting contact points and mute
+// timings referenced by the notification policy tree, and trees referencing unknown ones.
+type fakeGrafana struct {
+	mu            sync.Mutex
+	nextUID       int
+	contactPoints map[string]grafanaContactPoint
+	muteTimings   map[string]timeInterval
+	tree          grafanaPolicyTree
+}
+
+func newFakeGrafana(t *testing.T) (*fakeGrafana, *httptest.Server) {
+	t.Helper()
+
+	f := &fakeGrafana{
+		contactPoints: map[string]grafanaContactPoint{"default": {UID: "default", Name: "default-email", Type: "email"}},
+		muteTimings:   map[string]timeInterval{},
+		tree: grafanaPolicyTree{
+			"receiver":   "default-email",
+			"group_wait": "30s",
+			"routes": []any{
+				map[string]any{"receiver": "default-email", "object_matchers": []any{[]any{"team", "=", "ops"}}, "continue": true},
+			},
+		},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer grafana-token", r.Header.Get("Authorization"))
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.serve(t, w, r)
+	}))
+	t.Cleanup(server.Close)
+	return f, server
+}
+
+func (f *fakeGrafana) serve(t *testing.T, w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, grafanaProvisioningPath)
+	switch {
+	case r.Method == http.MethodGet && path == "/policies":
+		require.NoError(t, json.NewEncoder(w).Encode(f.tree))
+	case r.Method == http.MethodPut && path == "/policies":
+		var tree grafanaPolicyTree
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&tree))
+		for _, child := range tree.children() {
+			route, err := decodeGrafanaRoute(child)
+			require.NoError(t, err)
+			if !f.hasReceiver(route.Receiver) {
+				http.Error(w, "unknown receiver "+route.Receiver, http.StatusBadRequest)
+				return
+			}
+			for _, name := range route.MuteTimeIntervals {
+				if _, ok := f.muteTimings[name]; !ok {
+					http.Error(w, "unknown mute timing "+name, http.StatusBadRequest)
+					return
+				}
+			}
+		}
+		f.tree = tree
+		w.WriteHeader(http.StatusAccepted)
+	case r.Method == http.MethodGet && path == "/contact-points":
+		var found []grafanaContactPoint
+		for _, cp := range f.contactPoints {
+			if cp.Name == r.URL.Query().Get("name") {
+				found = append(found, cp)
+			}
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(found))
+	case r.Method == http.MethodPost && path == "/contact-points":
+		var cp grafanaContactPoint
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&cp))
+		f.nextUID++
+		cp.UID = fmt.Sprintf("uid-%d", f.nextUID)
+		f.contactPoints[cp.UID] = cp
+		w.WriteHeader(http.StatusAccepted)
+	case strings.HasPrefix(path, "/contact-points/"):
+		uid := strings.TrimPrefix(path, "/contact-points/")
+		existing, ok := f.contactPoints[uid]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case http.MethodPut:
+			var cp grafanaContactPoint
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&cp))
+			f.contactPoints[uid] = cp
+		case http.MethodDelete:
+			delete(f.contactPoints, uid)
+			if f.tree.route(existing.Name) != nil && !f.hasReceiver(existing.Name) {
+				f.contactPoints[uid] = existing
+				http.Error(w, "contact point is in use", http.StatusConflict)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusAccepted)
+	case r.Method == http.MethodPost && path == "/mute-timings":
+		var interval timeInterval
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&interval))
+		f.muteTimings[interval.Name] = interval
+		w.WriteHeader(http.StatusCreated)
+	case strings.HasPrefix(path, "/mute-timings/"):
+		name := strings.TrimPrefix(path, "/mute-timings/")
+		if _, ok := f.muteTimings[name]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case http.MethodPut:
+			var interval timeInterval
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&interval))
+			f.muteTimings[name] = interval
+		case http.MethodDelete:
+			if f.tree.mutesWith(name) {
+				http.Error(w, "mute timing is in use", http.StatusConflict)
+				return
+			}
+			delete(f.muteTimings, name)
+		}
+		w.WriteHeader(http.StatusOK)
+	default:
+		t.Errorf("unexpected request %v %v", r.Method, r.URL.Path)
+	}
+}
+
+func (f *fakeGrafana) hasReceiver(name string) bool {
+	for _, cp := range f.contactPoints {
+		if cp.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *fakeGrafana) namedContactPoints(name string) []grafanaContactPoint {
+	var found []grafanaContactPoint
+	for _, cp := range f.contactPoints {
+		if cp.Name == name {
+			found = append(found, cp)
+		}
+	}
+	return found
+}
+
+func TestGrafana_UpdateReceiverConfig(t *testing.T) {
+	t.Setenv("GRAFANA_TOKEN", "grafana-token")
+	fake, server := newFakeGrafana(t)
+
+	g, err := NewGrafana(config.AlertManagerConfig{Grafana: config.GrafanaAlertingConfig{URL: server.URL}}, config.TenancyConfig{})
+	require.NoError(t, err)
+
+	recv := models.DBReceiver{
+		UUID:        uuid.New(),
+		Name:        "alert-monitor-config",
+		Version:     2,
+		TenantID:    "tenant",
+		To:          []string{"a@example.com", "b@example.com"},
+		MinSeverity: models.ReceiverSeverity(models.SeverityCritical),
+		QuietHours:  models.QuietHours{Start: "22:00", End: "06:00", Location: "UTC"},
+	}
+
+	t.Run("Receiver is provisioned", func(t *testing.T) {
+		require.NoError(t, g.UpdateReceiverConfig(t.Context(), recv))
+
+		contactPoints := fake.namedContactPoints("tenant-alert-monitor-config")
+		require.Len(t, contactPoints, 1)
+		require.Equal(t, "email", contactPoints[0].Type)
+		require.Equal(t, "a@example.com;b@example.com", contactPoints[0].Settings["addresses"])
+		require.False(t, contactPoints[0].DisableResolveMessage)
+
+		require.Contains(t, fake.muteTimings, "tenant-alert-monitor-config-quiet-hours")
+		require.Equal(t, []timeRange{{StartTime: "22:00", EndTime: "24:00"}, {StartTime: "00:00", EndTime: "06:00"}},
+			fake.muteTimings["tenant-alert-monitor-config-quiet-hours"].TimeIntervals[0].Times)
+
+		route := fake.tree.route("tenant-alert-monitor-config")
+		require.NotNil(t, route)
+		require.Equal(t, [][3]string{
+			{"alert_category", "=~", "health|performance"},
+			{"projectId", "=~", "tenant"},
+			{"severity", "=~", "critical"},
+		}, route.ObjectMatchers)
+		require.Equal(t, []string{"tenant-alert-monitor-config-quiet-hours"}, route.MuteTimeIntervals)
+		require.Equal(t, [][3]string{{"severity", "=", "critical"}}, route.Routes[0].ObjectMatchers)
+
+		// The routes and fields of the tree not managed by alerting monitor are kept.
+		require.Equal(t, "30s", fake.tree["group_wait"])
+		require.Len(t, fake.tree.children(), 2)
+		require.Equal(t, true, fake.tree.children()[0].(map[string]any)["continue"])
+	})
+
+	t.Run("Receiver is updated in place", func(t *testing.T) {
+		uid := fake.namedContactPoints("tenant-alert-monitor-config")[0].UID
+
+		updated := recv
+		updated.Version = 3
+		updated.To = []string{"c@example.com"}
+		updated.QuietHours = models.QuietHours{}
+		require.NoError(t, g.UpdateReceiverConfig(t.Context(), updated))
+
+		contactPoints := fake.namedContactPoints("tenant-alert-monitor-config")
+		require.Len(t, contactPoints, 1)
+		require.Equal(t, uid, contactPoints[0].UID)
+		require.Equal(t, "c@example.com", contactPoints[0].Settings["addresses"])
+		require.NotContains(t, fake.muteTimings, "tenant-alert-monitor-config-quiet-hours")
+		require.Empty(t, fake.tree.route("tenant-alert-monitor-config").MuteTimeIntervals)
+		require.Len(t, fake.tree.children(), 2)
+	})
+
+	t.Run("Disabled receiver keeps its contact points without route", func(t *testing.T) {
+		disabled := recv
+		disabled.Disabled = true
+		require.NoError(t, g.UpdateReceiverConfig(t.Context(), disabled))
+
+		require.Len(t, fake.namedContactPoints("tenant-alert-monitor-config"), 1)
+		require.Nil(t, fake.tree.route("tenant-alert-monitor-config"))
+		require.NotContains(t, fake.muteTimings, "tenant-alert-monitor-config-quiet-hours")
+		require.Len(t, fake.tree.children(), 1)
+	})
+
+	t.Run("Receiver without recipients is removed", func(t *testing.T) {
+		updated := recv
+		updated.To = nil
+		require.NoError(t, g.UpdateReceiverConfig(t.Context(), updated))
+
+		require.Empty(t, fake.namedContactPoints("tenant-alert-monitor-config"))
+		require.Nil(t, fake.tree.route("tenant-alert-monitor-config"))
+		require.Len(t, fake.tree.children(), 1)
+	})
+}
+
+func TestGrafana_RemoveTenantConfig(t *testing.T) {
+	t.Setenv("GRAFANA_TOKEN", "grafana-token")
+	fake, server := newFakeGrafana(t)
+
+	g, err := NewGrafana(config.AlertManagerConfig{Grafana: config.GrafanaAlertingConfig{URL: server.URL}}, config.TenancyConfig{})
+	require.NoError(t, err)
+
+	window := &models.MaintenanceWindow{}
+	for _, tenantID := range []string{"tenant", "other"} {
+		require.NoError(t, g.UpdateReceiverConfig(t.Context(), models.DBReceiver{
+			Name:        "alert-monitor-config",
+			TenantID:    tenantID,
+			To:          []string{"a@example.com"},
+			QuietHours:  models.QuietHours{Start: "22:00", End: "06:00"},
+			Maintenance: window,
+		}))
+	}
+	require.Len(t, fake.tree.children(), 3)
+	require.Len(t, fake.muteTimings, 4)
+
+	require.NoError(t, g.RemoveTenantConfig(t.Context(), "tenant"))
+	require.Len(t, fake.tree.children(), 2)
+	require.Nil(t, fake.tree.route("tenant-alert-monitor-config"))
+	require.NotNil(t, fake.tree.route("other-alert-monitor-config"))
+	require.Empty(t, fake.namedContactPoints("tenant-alert-monitor-config"))
+	require.Len(t, fake.namedContactPoints("other-alert-monitor-config"), 1)
+	require.NotContains(t, fake.muteTimings, "tenant-alert-monitor-config-quiet-hours")
+	require.NotContains(t, fake.muteTimings, "tenant-maintenance")
+	require.Contains(t, fake.muteTimings, "other-maintenance")
+}
+
+func TestGrafana_ValidateReceiverConfig(t *testing.T) {
+	t.Setenv("GRAFANA_TOKEN", "grafana-token")
+	_, server := newFakeGrafana(t)
+
+	recv := models.DBReceiver{Name: "alert-monitor-config", TenantID: "tenant", To: []string{"a@example.com", "b@example.com"}}
+	tests := map[string]struct {
+		conf          config.AlertManagerConfig
+		expectedError error
+	}{
+		"Within limits": {
+			conf: config.AlertManagerConfig{MaxRoutes: 2, MaxRecipientsPerRoute: 2},
+		},
+		"Too many routes": {
+			conf:          config.AlertManagerConfig{MaxRoutes: 1},
+			expectedError: errors.New("2 routes exceed the limit of 1"),
+		},
+		"Too many recipients": {
+			conf:          config.AlertManagerConfig{MaxRecipientsPerRoute: 1},
+			expectedError: errors.New(`2 recipients of route "tenant-alert-monitor-config" exceed the limit of 1`),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test.conf.Grafana.URL = server.URL
+			g, err := NewGrafana(test.conf, config.TenancyConfig{})
+			require.NoError(t, err)
+
+			err = g.ValidateReceiverConfig(t.Context(), recv)
+			if test.expectedError != nil {
+				require.ErrorIs(t, err, app.ErrConfigLimitExceeded)
+				require.ErrorContains(t, err, test.expectedError.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+
+	t.Run("Route conflicting with the route of another receiver", func(t *testing.T) {
+		f, server := newFakeGrafana(t)
+		other, _ := receiverRoute(models.DBReceiver{Name: "other", TenantID: "tenant"}, "tenant-other", config.TenancyConfig{})
+		route, err := newGrafanaRoute(other)
+		require.NoError(t, err)
+		f.tree["routes"] = append(f.tree.children(), route)
+
+		var conf config.AlertManagerConfig
+		conf.Grafana.URL = server.URL
+		g, err := NewGrafana(conf, config.TenancyConfig{})
+		require.NoError(t, err)
+
+		err = g.ValidateReceiverConfig(t.Context(), recv)
+		var conflict *app.RouteConflictError
+		require.ErrorAs(t, err, &conflict)
+		require.Equal(t, "other", conflict.Receiver)
+	})
+}
+
+func TestGrafana_PreviewReceiverConfig(t *testing.T) {
+	t.Setenv("ONCALL_RELAY_TOKEN", "relay-token")
+
+	g, err := NewGrafana(config.AlertManagerConfig{
+		Grafana:        config.GrafanaAlertingConfig{URL: "http://grafana:3000"},
+		OnCallRelayURL: "http://alerting-monitor:8080",
+	}, config.TenancyConfig{})
+	require.NoError(t, err)
+
+	id := uuid.New()
+	preview, err := g.PreviewReceiverConfig(t.Context(), models.DBReceiver{
+		UUID:             id,
+		Name:             "alert-monitor-config",
+		TenantID:         "tenant",
+		OnCallRoutingKey: "routing-key",
+	})
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf(`- name: tenant-alert-monitor-config
+  type: webhook
+  settings:
+    authorization_credentials: relay-token
+    authorization_scheme: Bearer
+    url: http://alerting-monitor:8080/api/v1/oncall/relay/tenant/%v
+`, id), preview.Receiver)
+	require.Equal(t, `receiver: tenant-alert-monitor-config
+object_matchers:
+- - alert_category
+  - =~
+  - health|performance
+- - projectId
+  - =~
+  - tenant
+`, preview.Route)
+}
+
+func TestNewBackend(t *testing.T) {
+	backend, err := NewBackend(config.AlertManagerConfig{Backend: BackendGrafana, Grafana: config.GrafanaAlertingConfig{URL: "http://grafana:3000"}},
+		config.TenancyConfig{}, nil, nil, nil)
+	require.NoError(t, err)
+	require.IsType(t, &Grafana{}, backend)
+
+	_, err = NewBackend(config.AlertManagerConfig{Backend: BackendGrafana}, config.TenancyConfig{}, nil, nil, nil)
+	require.ErrorContains(t, err, "url of grafana is not set")
+
+	_, err = NewBackend(config.AlertManagerConfig{Backend: "opsgenie"}, config.TenancyConfig{}, nil, nil, nil)
+	require.ErrorContains(t, err, `unknown notification backend "opsgenie"`)
+}
@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package alertmanager
+
+import (
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"gopkg.in/yaml.v2"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/app"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+)
+
+const (
+	// maxManifestSize is the maximum size of the data of a Kubernetes secret, which holds the alertmanager config manifest.
+	maxManifestSize = 1 << 20
+
+	limitSize       = "size"
+	limitRoutes     = "routes"
+	limitRecipients = "recipients"
+)
+
+// configRejections counts the receiver changes rejected because the resulting config manifest would exceed a limit.
+var configRejections = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "alerting_monitor_alertmanager_config_rejections_total",
+	Help: "Number of receiver changes rejected because the alertmanager configuration would exceed a limit.",
+}, []string{"limit"})
+
+// checkLimits verifies that the config manifest fits into a Kubernetes secret, and does not exceed the number of routes
+// and recipients per route given by the configuration. An error wrapping app.ErrConfigLimitExceeded is returned otherwise,
+// and the rejection is counted.
+func (m configManifest) checkLimits(conf config.AlertManagerConfig) error {
+	limit, err := m.exceededLimit(conf)
+	if limit != "" {
+		configRejections.WithLabelValues(limit).Inc()
+	}
+	return err
+}
+
+// exceededLimit returns the name of the first limit exceeded by the config manifest, along with an error describing it.
+func (m configManifest) exceededLimit(conf config.AlertManagerConfig) (string, error) {
+	if conf.MaxRoutes > 0 && len(m.Route.Routes) > conf.MaxRoutes {
+		return limitRoutes, fmt.Errorf("%d routes exceed the limit of %d: %w", len(m.Route.Routes), conf.MaxRoutes, app.ErrConfigLimitExceeded)
+	}
+
+	if conf.MaxRecipientsPerRoute > 0 {
+		recipients := make(map[string]int, len(m.Receivers))
+		for _, r := range m.Receivers {
+			recipients[r.Name] = len(r.EmailConfigs)
+		}
+		for _, r := range m.Route.Routes {
+			if n := recipients[r.Receiver]; n > conf.MaxRecipientsPerRoute {
+				return limitRecipients, fmt.Errorf("%d recipients of route %q exceed the limit of %d: %w",
+					n, r.Receiver, conf.MaxRecipientsPerRoute, app.ErrConfigLimitExceeded)
+			}
+		}
+	}
+
+	data, err := yaml.Marshal(m)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal the config manifest: %w", err)
+	}
+	if len(data) > maxManifestSize {
+		return limitSize, fmt.Errorf("size of %d bytes exceeds the limit of %d bytes: %w", len(data), maxManifestSize, app.ErrConfigLimitExceeded)
+	}
+
+	return "", nil
+}
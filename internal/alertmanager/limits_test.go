@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package alertmanager
+
+import (
+	"strings"
+	"testing"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/app"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+)
+
+// rejections returns the number of rejections counted for the given limit.
+func rejections(t *testing.T, limit string) float64 {
+	var m dto.Metric
+	require.NoError(t, configRejections.WithLabelValues(limit).Write(&m))
+	return m.GetCounter().GetValue()
+}
+
+func TestConfigManifest_CheckLimits(t *testing.T) {
+	manifest := configManifest{
+		Route: route{
+			Receiver: "default",
+			Routes: []subRoute{
+				{Receiver: "tenant-receiver-1"},
+				{Receiver: "other-receiver-1"},
+			},
+		},
+		Receivers: []receiver{
+			{Name: "default"},
+			{Name: "tenant-receiver-1", EmailConfigs: []emailConfig{{To: "first"}, {To: "second"}}},
+			{Name: "other-receiver-1", EmailConfigs: []emailConfig{{To: "first"}}},
+		},
+	}
+
+	t.Run("WithinLimits", func(t *testing.T) {
+		require.NoError(t, manifest.checkLimits(config.AlertManagerConfig{
+			MaxRoutes:             2,
+			MaxRecipientsPerRoute: 2,
+		}))
+	})
+
+	t.Run("NoLimits", func(t *testing.T) {
+		require.NoError(t, manifest.checkLimits(config.AlertManagerConfig{}))
+	})
+
+	t.Run("TooManyRoutes", func(t *testing.T) {
+		before := rejections(t, limitRoutes)
+
+		err := manifest.checkLimits(config.AlertManagerConfig{MaxRoutes: 1})
+		require.ErrorIs(t, err, app.ErrConfigLimitExceeded)
+		require.ErrorContains(t, err, "2 routes exceed the limit of 1")
+		require.InDelta(t, before+1, rejections(t, limitRoutes), 0)
+	})
+
+	t.Run("TooManyRecipientsPerRoute", func(t *testing.T) {
+		before := rejections(t, limitRecipients)
+
+		err := manifest.checkLimits(config.AlertManagerConfig{MaxRecipientsPerRoute: 1})
+		require.ErrorIs(t, err, app.ErrConfigLimitExceeded)
+		require.ErrorContains(t, err, `2 recipients of route "tenant-receiver-1" exceed the limit of 1`)
+		require.InDelta(t, before+1, rejections(t, limitRecipients), 0)
+	})
+
+	t.Run("TooLarge", func(t *testing.T) {
+		before := rejections(t, limitSize)
+
+		large := manifest
+		large.Templates = []string{strings.Repeat("x", maxManifestSize)}
+
+		err := large.checkLimits(config.AlertManagerConfig{})
+		require.ErrorIs(t, err, app.ErrConfigLimitExceeded)
+		require.ErrorContains(t, err, "exceeds the limit of 1048576 bytes")
+		require.InDelta(t, before+1, rejections(t, limitSize), 0)
+	})
+}
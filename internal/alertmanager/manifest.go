@@ -4,21 +4,31 @@
 package alertmanager
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"os"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 
+	"gopkg.in/yaml.v2"
+
 	"github.com/open-edge-platform/o11y-alerting-monitor/internal/app"
 	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
 	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/mailrelay"
 )
 
+// ErrConfigMismatch is returned when the alertmanager configuration read back after being applied does not match the expected one.
+var ErrConfigMismatch = errors.New("applied alertmanager configuration does not match the expected one")
+
 const (
 	alertCategoryMatcher = `alert_category=~"health|performance"`
 	emailHTMLTemplate    = `{{ template "alert.monitor.mail" . }}`
+	onCallRelayPath      = "/api/v1/oncall/relay"
+	emailRelayPath       = "/api/v1/email/relay"
 )
 
 // global represents the global section of an alertmanager configuration file.
@@ -27,12 +37,17 @@ type global struct {
 	SMTPHost         string `yaml:"smtp_smarthost"`
 	SMTPAuthUsername string `yaml:"smtp_auth_username,omitempty"`
 	SMTPAuthPassword string `yaml:"smtp_auth_password,omitempty"`
+	SMTPAuthSecret   string `yaml:"smtp_auth_secret,omitempty"`
 }
 
 // subRoute represents a node in a routing tree and its children of an alertmanager configuration file.
 type subRoute struct {
-	Matchers []string `yaml:"matchers,omitempty"`
-	Receiver string   `yaml:"receiver"`
+	Matchers          []string   `yaml:"matchers,omitempty"`
+	Receiver          string     `yaml:"receiver"`
+	MuteTimeIntervals []string   `yaml:"mute_time_intervals,omitempty"`
+	Routes            []subRoute `yaml:"routes,omitempty"`
+	// Continue tells whether the alerts matching the route are matched against its next sibling routes as well.
+	Continue bool `yaml:"continue,omitempty"`
 }
 
 // route represents the route section of an alertmanager configuration file. It describes how alerts are routed, aggregated, throttled and muted based on time.
@@ -49,6 +64,8 @@ type route struct {
 type emailConfig struct {
 	SendResolved bool   `yaml:"send_resolved,omitempty"`
 	To           string `yaml:"to"`
+	From         string `yaml:"from,omitempty"`
+	Smarthost    string `yaml:"smarthost,omitempty"`
 	HTML         string `yaml:"html"`
 	RequireTLS   bool   `yaml:"require_tls"`
 	TLSConfig    struct {
@@ -56,10 +73,33 @@ type emailConfig struct {
 	} `yaml:"tls_config,omitempty"`
 }
 
+// authorization represents the authorization subsection of the HTTP client configuration of an alertmanager configuration file.
+type authorization struct {
+	Type        string `yaml:"type,omitempty"`
+	Credentials string `yaml:"credentials,omitempty"`
+}
+
+// httpConfig represents the http_config subsection of an alertmanager configuration file.
+type httpConfig struct {
+	Authorization *authorization `yaml:"authorization,omitempty"`
+}
+
+// webhookConfig represents the webhook_config subsection of an alertmanager configuration file. It is used to relay the
+// alerts of a receiver to Grafana OnCall through alerting monitor.
+type webhookConfig struct {
+	SendResolved bool        `yaml:"send_resolved"`
+	URL          string      `yaml:"url"`
+	HTTPConfig   *httpConfig `yaml:"http_config,omitempty"`
+}
+
 // receiver represents the receiver section of an alertmanager configuration file. It describes the notification destinations (receivers).
+// Integrations holds the configurations of the other integrations, by their key, such as those rendered by channels registered
+// by distributions.
 type receiver struct {
-	Name         string        `yaml:"name"`
-	EmailConfigs []emailConfig `yaml:"email_configs,omitempty"`
+	Name           string           `yaml:"name"`
+	EmailConfigs   []emailConfig    `yaml:"email_configs,omitempty"`
+	WebhookConfigs []webhookConfig  `yaml:"webhook_configs,omitempty"`
+	Integrations   map[string][]any `yaml:",inline"`
 }
 
 // inhibitRule represents the inhibit_rule section of an alertmanager configuration file.
@@ -70,19 +110,48 @@ type inhibitRule struct {
 	Equal          []string `yaml:"equal,omitempty"`
 }
 
+// timeRange represents a range of time of the day of a time interval. Time intervals are also the mute timings of Grafana
+// Alerting, whose provisioning API takes them as JSON.
+type timeRange struct {
+	StartTime string `yaml:"start_time" json:"start_time"`
+	EndTime   string `yaml:"end_time" json:"end_time"`
+}
+
+// timeIntervalSpec represents a single time interval definition, evaluated in the given location.
+type timeIntervalSpec struct {
+	Times       []timeRange `yaml:"times,omitempty" json:"times,omitempty"`
+	DaysOfMonth []string    `yaml:"days_of_month,omitempty" json:"days_of_month,omitempty"`
+	Months      []string    `yaml:"months,omitempty" json:"months,omitempty"`
+	Years       []string    `yaml:"years,omitempty" json:"years,omitempty"`
+	Location    string      `yaml:"location,omitempty" json:"location,omitempty"`
+}
+
+// timeInterval represents a named time interval of an alertmanager configuration file, which can be referenced by routes
+// to mute notifications.
+type timeInterval struct {
+	Name          string             `yaml:"name" json:"name"`
+	TimeIntervals []timeIntervalSpec `yaml:"time_intervals" json:"time_intervals"`
+}
+
 // configManifest represents the configuration fields of an alertmanager configuration file.
 type configManifest struct {
-	Global       global        `yaml:"global,omitempty"`
-	Route        route         `yaml:"route"`
-	Receivers    []receiver    `yaml:"receivers"`
-	InhibitRules []inhibitRule `yaml:"inhibit_rules,omitempty"`
-	Templates    []string      `yaml:"templates,omitempty"`
+	Global        global         `yaml:"global,omitempty"`
+	Route         route          `yaml:"route"`
+	Receivers     []receiver     `yaml:"receivers"`
+	InhibitRules  []inhibitRule  `yaml:"inhibit_rules,omitempty"`
+	TimeIntervals []timeInterval `yaml:"time_intervals,omitempty"`
+	Templates     []string       `yaml:"templates,omitempty"`
 }
 
 // ApplyReceiver returns a modified version of an existing alertmanager config manifest. Sets SMTP config fields of the global section,
-// email recipient list for each receiver, and routes based on the given input arguments.
-func (m configManifest) ApplyReceiver(recv models.DBReceiver, conf config.AlertManagerConfig) (*configManifest, error) {
-	manifest := m
+// email recipient list for each receiver, and routes based on the given input arguments. Routes match tenants by the tenant
+// label of the given tenancy configuration, the routes matching tenants by a previous tenant label being migrated to it.
+// Disabled receivers are kept without route, so that their notifications are paused until they are enabled again. The routes
+// of receivers with matchers come before the other routes of their tenant.
+func (m configManifest) ApplyReceiver(
+	recv models.DBReceiver, conf config.AlertManagerConfig, tenancy config.TenancyConfig,
+) (*configManifest, error) {
+	manifest := m.migrateTenantLabel(tenancy)
 
 	// Set global config fields.
 	manifest.Global = global{
@@ -90,40 +159,33 @@ func (m configManifest) ApplyReceiver(recv models.DBReceiver, conf config.AlertM
 		SMTPHost: recv.MailServer,
 	}
 
-	// username and password are optional based on helm values.
-	if username := os.Getenv("SMTP_USERNAME"); len(username) != 0 {
-		manifest.Global.SMTPAuthUsername = username
-	}
+	// When emails are signed, alertmanager sends them to the in-cluster signing relay, which authenticates to the mail
+	// server on its behalf. Alertmanager authenticates to the relay with CRAM-MD5, which only requires the secret.
+	if conf.SigningRelayHost != "" {
+		manifest.Global.SMTPHost = conf.SigningRelayHost
+		manifest.Global.SMTPAuthUsername = mailrelay.Username
+		manifest.Global.SMTPAuthSecret = os.Getenv("EMAIL_SIGNING_RELAY_SECRET")
+	} else {
+		// username and password are optional based on helm values.
+		if username := os.Getenv("SMTP_USERNAME"); len(username) != 0 {
+			manifest.Global.SMTPAuthUsername = username
+		}
 
-	if password := os.Getenv("SMTP_PASSWORD"); len(password) != 0 {
-		manifest.Global.SMTPAuthPassword = password
+		if password := os.Getenv("SMTP_PASSWORD"); len(password) != 0 {
+			manifest.Global.SMTPAuthPassword = password
+		}
 	}
 
 	if len(m.Receivers) == 0 {
 		return nil, errors.New("alertmanager config manifest does not have receivers")
 	}
 
-	// Create receiver email config.
-	emailConfigs := make([]emailConfig, len(recv.To))
-	for i := range recv.To {
-		emailConfigs[i] = emailConfig{
-			SendResolved: true,
-			To:           recv.To[i],
-			HTML:         emailHTMLTemplate,
-			RequireTLS:   conf.RequireTLS,
-			TLSConfig: struct {
-				InsecureSkipVerify bool `yaml:"insecure_skip_verify,omitempty"`
-			}{
-				InsecureSkipVerify: conf.InsecureSkipVerify,
-			},
-		}
-	}
-
+	// The integrations of the receiver are rendered by the registered notification channels.
 	receiverName := fmt.Sprintf("%s-%s", recv.TenantID, recv.Name)
 	receiverNameWithVersion := fmt.Sprintf("%s-%d", receiverName, recv.Version)
-	newReceiver := receiver{
-		Name:         receiverNameWithVersion,
-		EmailConfigs: emailConfigs,
+	newReceiver, err := renderReceiver(receiverNameWithVersion, recv, conf)
+	if err != nil {
+		return nil, err
 	}
 
 	// When upgrading from single tenant to multitenant version of alerting monitor, alertmanager secret
@@ -138,7 +200,9 @@ func (m configManifest) ApplyReceiver(recv models.DBReceiver, conf config.AlertM
 		manifest.Receivers[index] = newReceiver
 	}
 
-	if len(manifest.Route.Routes) == 0 {
+	// The routes of disabled receivers are removed, so a manifest without routes is valid as long as it has the root route
+	// they are added to.
+	if len(manifest.Route.Routes) == 0 && manifest.Route.Receiver == "" {
 		return nil, errors.New("alertmanager config manifest does not have routes")
 	}
 
@@ -149,35 +213,424 @@ func (m configManifest) ApplyReceiver(recv models.DBReceiver, conf config.AlertM
 		return strings.Contains(r.Receiver, receiverName) || strings.Contains(fmt.Sprintf("%s-%s", recv.TenantID, r.Receiver), receiverName)
 	})
 
-	var projectIDMatcher string
-	// Special case where the legacy single tenant receiver should match exactly empty projectId,
-	// otherwise any subsequent patch would overwrite the projectId label to match to it's tenant,
+	newRoute, intervals := receiverRoute(recv, receiverNameWithVersion, tenancy)
+	if recv.Disabled {
+		// The maintenance window of the tenant is kept for the other routes of the tenant, the quiet hours of the receiver are
+		// removed along with its route.
+		intervals = slices.DeleteFunc(intervals, func(t timeInterval) bool {
+			return t.Name != maintenanceIntervalName(recv.TenantID)
+		})
+	}
+
+	// The quiet hours of the receiver and the maintenance window of its tenant are replaced by the ones of the receiver.
+	intervalName := quietHoursIntervalName(receiverName)
+	maintenanceName := maintenanceIntervalName(recv.TenantID)
+	manifest.TimeIntervals = slices.DeleteFunc(slices.Clone(manifest.TimeIntervals), func(t timeInterval) bool {
+		return t.Name == intervalName || (recv.Maintenance != nil && t.Name == maintenanceName)
+	})
+	manifest.TimeIntervals = append(manifest.TimeIntervals, intervals...)
+
+	switch {
+	case recv.Disabled && index >= 0:
+		// Remove the route of the disabled receiver, its notifications being paused
+		manifest.Route.Routes = slices.Delete(slices.Clone(manifest.Route.Routes), index, index+1)
+	case recv.Disabled:
+	case newRoute.Continue:
+		// Move the route with matchers before the other routes of the tenant
+		tenantMatcher := receiverTenantMatcher(recv.TenantID, tenancy)
+		manifest.Route.Routes = insertContinuedRoute(manifest.Route.Routes, index, newRoute, tenantMatcher)
+	case index < 0 || manifest.Route.Routes[index].Continue:
+		// Add a new route, the route which had matchers being moved after the other routes of the tenant
+		routes := slices.Clone(manifest.Route.Routes)
+		if index >= 0 {
+			routes = slices.Delete(routes, index, index+1)
+		}
+		manifest.Route.Routes = append(routes, newRoute)
+	default:
+		// Overwrite the existing route
+		manifest.Route.Routes[index] = newRoute
+	}
+
+	// The maintenance time interval is removed once no route of the tenant mutes with it anymore.
+	if (recv.Maintenance == nil || recv.Disabled) && !mutesWith(manifest.Route.Routes, maintenanceName) {
+		manifest.TimeIntervals = slices.DeleteFunc(slices.Clone(manifest.TimeIntervals), func(t timeInterval) bool {
+			return t.Name == maintenanceName
+		})
+	}
+
+	return &manifest, nil
+}
+
+// receiverRoute returns the route of the given receiver to the receiver of the given name, along with the time intervals muting
+// it, which are its quiet hours and the maintenance window of its tenant if set. Routes match tenants by the tenant label of
+// the given tenancy configuration.
+func receiverRoute(recv models.DBReceiver, name string, tenancy config.TenancyConfig) (subRoute, []timeInterval) {
+	matchers := []string{
+		alertCategoryMatcher,
+		receiverTenantMatcher(recv.TenantID, tenancy),
+	}
+	if m := severityMatcher(recv.MinSeverity); m != "" {
+		matchers = append(matchers, m)
+	}
+
+	// The matchers of the receiver narrow its route down to some of the alerts of its tenant, which continue to the other
+	// routes of the tenant so that the receivers notified of all its alerts still are.
+	route := subRoute{
+		Receiver: name,
+		Matchers: append(matchers, recv.Matchers...),
+		Continue: len(recv.Matchers) > 0,
+	}
+
+	// Quiet hours are set as a time interval that mutes the receiver route. Critical alerts are routed through a child
+	// route, which is not muted, so that they are still notified during quiet hours.
+	var intervals []timeInterval
+	if recv.QuietHours.IsSet() {
+		intervalName := quietHoursIntervalName(fmt.Sprintf("%s-%s", recv.TenantID, recv.Name))
+		intervals = append(intervals, newQuietHoursInterval(intervalName, recv.QuietHours))
+		route.MuteTimeIntervals = []string{intervalName}
+		route.Routes = []subRoute{
+			{
+				Receiver: name,
+				Matchers: []string{fmt.Sprintf(`severity=%q`, models.SeverityCritical)},
+			},
+		}
+	}
+
+	// The maintenance mode of the tenant is set as a time interval, shared by the receivers of the tenant, that mutes the
+	// receiver route and its child routes regardless of the severity of alerts.
+	if recv.Maintenance != nil {
+		maintenanceName := maintenanceIntervalName(recv.TenantID)
+		intervals = append(intervals, newMaintenanceInterval(maintenanceName, *recv.Maintenance))
+		route.MuteTimeIntervals = append(route.MuteTimeIntervals, maintenanceName)
+		for i := range route.Routes {
+			route.Routes[i].MuteTimeIntervals = append(route.Routes[i].MuteTimeIntervals, maintenanceName)
+		}
+	}
+	return route, intervals
+}
+
+// insertContinuedRoute returns the given routes with the given route, which continues to its next sibling routes, in place of
+// the route at the given index if any, and before the first route of the tenant matched by the given tenant matcher which does
+// not continue, so that the alerts of the tenant reach it before being routed to the other routes of the tenant.
+func insertContinuedRoute(routes []subRoute, index int, route subRoute, tenantMatcher string) []subRoute {
+	routes = slices.Clone(routes)
+	if index >= 0 {
+		routes = slices.Delete(routes, index, index+1)
+	}
+	at := slices.IndexFunc(routes, func(r subRoute) bool {
+		return !r.Continue && slices.Contains(r.Matchers, tenantMatcher)
+	})
+	if at < 0 {
+		at = len(routes)
+	}
+	return slices.Insert(routes, at, route)
+}
+
+// mutesWith reports whether any of the given routes, or of their child routes, is muted by the named time interval.
+func mutesWith(routes []subRoute, intervalName string) bool {
+	return slices.ContainsFunc(routes, func(r subRoute) bool {
+		return slices.Contains(r.MuteTimeIntervals, intervalName) || mutesWith(r.Routes, intervalName)
+	})
+}
+
+// newRelayWebhookConfig returns the webhook configuration which sends the alerts of the given receiver to a relay endpoint
+// of alerting monitor, such as the Grafana OnCall or email relay. The relay token, read from the given environment variable,
+// is optional based on helm values.
+func newRelayWebhookConfig(recv models.DBReceiver, relayURL, relayPath, tokenEnv string) webhookConfig {
+	webhook := webhookConfig{
+		SendResolved: true,
+		URL:          fmt.Sprintf("%s%s/%s/%s", strings.TrimSuffix(relayURL, "/"), relayPath, recv.TenantID, recv.UUID),
+	}
+
+	if token := os.Getenv(tokenEnv); len(token) != 0 {
+		webhook.HTTPConfig = &httpConfig{
+			Authorization: &authorization{
+				Type:        "Bearer",
+				Credentials: token,
+			},
+		}
+	}
+	return webhook
+}
+
+// RemoveTenant returns a modified version of an existing alertmanager config manifest without the routes matching alerts of the
+// given tenant, along with the receivers and time intervals only referenced by these routes. Routes matching tenants by a
+// previous tenant label of the given tenancy configuration are migrated to its tenant label first.
+func (m configManifest) RemoveTenant(tenantID string, tenancy config.TenancyConfig) *configManifest {
+	manifest := m.migrateTenantLabel(tenancy)
+
+	matcher := tenantMatcher(tenancy.TenantLabel(), tenantID)
+	removedReceivers := make(map[string]bool)
+	removedIntervals := make(map[string]bool)
+	manifest.Route.Routes = slices.DeleteFunc(slices.Clone(manifest.Route.Routes), func(r subRoute) bool {
+		if !slices.Contains(r.Matchers, matcher) {
+			return false
+		}
+		removedReceivers[r.Receiver] = true
+		for _, name := range r.MuteTimeIntervals {
+			removedIntervals[name] = true
+		}
+		return true
+	})
+
+	// Receivers and time intervals still referenced by any remaining route are kept.
+	delete(removedReceivers, manifest.Route.Receiver)
+	for _, r := range manifest.Route.Routes {
+		delete(removedReceivers, r.Receiver)
+		for _, name := range r.MuteTimeIntervals {
+			delete(removedIntervals, name)
+		}
+	}
+
+	manifest.Receivers = slices.DeleteFunc(slices.Clone(manifest.Receivers), func(r receiver) bool {
+		return removedReceivers[r.Name]
+	})
+	manifest.TimeIntervals = slices.DeleteFunc(slices.Clone(manifest.TimeIntervals), func(t timeInterval) bool {
+		return removedIntervals[t.Name]
+	})
+
+	return &manifest
+}
+
+// tenantMatcher returns the route matcher matching the alerts of the given tenant by the given tenant label.
+func tenantMatcher(label, tenantID string) string {
+	return fmt.Sprintf(`%s=~"%v"`, label, tenantID)
+}
+
+// receiverTenantMatcher returns the route matcher matching the alerts of the given tenant by the tenant label of the given
+// tenancy configuration, as the routes of its receivers are rendered with.
+func receiverTenantMatcher(tenantID string, tenancy config.TenancyConfig) string {
+	// Special case where the legacy single tenant receiver should match exactly empty tenant label,
+	// otherwise any subsequent patch would overwrite the tenant label to match to it's tenant,
 	// and no alerts would be triggered as a result (no alerts with such label).
-	if recv.TenantID == app.DefaultTenantID {
-		projectIDMatcher = `projectId=~""`
-	} else {
-		projectIDMatcher = fmt.Sprintf(`projectId=~"%v"`, recv.TenantID)
+	if tenantID == app.DefaultTenantID {
+		tenantID = ""
 	}
+	return tenantMatcher(tenancy.TenantLabel(), tenantID)
+}
 
-	if index < 0 {
-		// Add a new route
-		manifest.Route.Routes = append(manifest.Route.Routes, subRoute{
-			Receiver: receiverNameWithVersion,
-			Matchers: []string{
-				alertCategoryMatcher,
-				projectIDMatcher,
+// migrateTenantLabel returns the manifest with the route matchers matching tenants by any of the previous tenant labels of the
+// given tenancy configuration rewritten to match them by its tenant label, so that routes created before the tenant label was
+// changed keep matching the alerts of their tenant.
+func (m configManifest) migrateTenantLabel(tenancy config.TenancyConfig) configManifest {
+	label := tenancy.TenantLabel()
+	manifest := m
+	manifest.Route.Routes = slices.Clone(m.Route.Routes)
+	for i, r := range manifest.Route.Routes {
+		matchers := slices.Clone(r.Matchers)
+		for j, matcher := range matchers {
+			for _, previous := range tenancy.PreviousLabels {
+				if value, ok := strings.CutPrefix(matcher, previous+"=~"); ok && previous != label {
+					matchers[j] = label + "=~" + value
+				}
+			}
+		}
+		manifest.Route.Routes[i].Matchers = matchers
+	}
+	return manifest
+}
+
+// severityMatcher returns a route matcher that only matches alerts with a severity equal or higher than the given minimum severity.
+// An empty string is returned when no minimum severity is set.
+func severityMatcher(minSeverity models.ReceiverSeverity) string {
+	levels := minSeverity.AtLeast()
+	if len(levels) == 0 {
+		return ""
+	}
+
+	values := make([]string, len(levels))
+	for i, l := range levels {
+		values[i] = string(l)
+	}
+	return fmt.Sprintf(`severity=~"%s"`, strings.Join(values, "|"))
+}
+
+// quietHoursIntervalName returns the name of the time interval holding the quiet hours of the given receiver.
+func quietHoursIntervalName(receiverName string) string {
+	return fmt.Sprintf("%s-quiet-hours", receiverName)
+}
+
+// newQuietHoursInterval returns a time interval matching the given quiet hours. Since alertmanager time ranges cannot span
+// midnight, quiet hours ending before they start are split into two time ranges.
+func newQuietHoursInterval(name string, quietHours models.QuietHours) timeInterval {
+	// Times are normalized to HH:MM so they can be compared. Quiet hours are validated before being stored.
+	start, _ := time.Parse(models.QuietHoursTimeFormat, quietHours.Start)
+	end, _ := time.Parse(models.QuietHoursTimeFormat, quietHours.End)
+
+	times := []timeRange{
+		{
+			StartTime: start.Format(models.QuietHoursTimeFormat),
+			EndTime:   end.Format(models.QuietHoursTimeFormat),
+		},
+	}
+	if end.Before(start) {
+		times = []timeRange{
+			{
+				StartTime: start.Format(models.QuietHoursTimeFormat),
+				EndTime:   "24:00",
 			},
-		})
-	} else {
-		// Overwrite the existing route
-		manifest.Route.Routes[index] = subRoute{
-			Receiver: receiverNameWithVersion,
-			Matchers: []string{
-				alertCategoryMatcher,
-				projectIDMatcher,
+		}
+		if end.Hour() != 0 || end.Minute() != 0 {
+			times = append(times, timeRange{
+				StartTime: "00:00",
+				EndTime:   end.Format(models.QuietHoursTimeFormat),
+			})
+		}
+	}
+
+	return timeInterval{
+		Name: name,
+		TimeIntervals: []timeIntervalSpec{
+			{
+				Times:    times,
+				Location: quietHours.Location,
 			},
+		},
+	}
+}
+
+// maintenanceIntervalName returns the name of the time interval holding the maintenance window of the given tenant.
+func maintenanceIntervalName(tenantID string) string {
+	return fmt.Sprintf("%s-maintenance", tenantID)
+}
+
+// newMaintenanceInterval returns a time interval matching the given maintenance window, in UTC and to the minute. Since
+// alertmanager time intervals are recurring, the window is split into a time interval per day, each bound to its date.
+func newMaintenanceInterval(name string, window models.MaintenanceWindow) timeInterval {
+	start := window.Start.UTC().Truncate(time.Minute)
+	end := window.End.UTC()
+	if !end.Truncate(time.Minute).Equal(end) {
+		end = end.Truncate(time.Minute).Add(time.Minute)
+	}
+
+	var specs []timeIntervalSpec
+	for day := start.Truncate(24 * time.Hour); day.Before(end); day = day.Add(24 * time.Hour) {
+		from, to := day, day.Add(24*time.Hour)
+		if start.After(from) {
+			from = start
+		}
+		if end.Before(to) {
+			to = end
 		}
+
+		endTime := to.Format(models.QuietHoursTimeFormat)
+		if to.Equal(day.Add(24 * time.Hour)) {
+			endTime = "24:00"
+		}
+		specs = append(specs, timeIntervalSpec{
+			Times: []timeRange{
+				{
+					StartTime: from.Format(models.QuietHoursTimeFormat),
+					EndTime:   endTime,
+				},
+			},
+			DaysOfMonth: []string{strconv.Itoa(day.Day())},
+			Months:      []string{strconv.Itoa(int(day.Month()))},
+			Years:       []string{strconv.Itoa(day.Year())},
+			Location:    "UTC",
+		})
 	}
 
-	return &manifest, nil
+	return timeInterval{
+		Name:          name,
+		TimeIntervals: specs,
+	}
+}
+
+// VerifyReceiver checks that the manifest, as read back from alertmanager, holds the receiver, route, quiet hours and maintenance
+// window of the given receiver as rendered in the expected manifest. An error wrapping ErrConfigMismatch is returned if any of them differs.
+func (m configManifest) VerifyReceiver(expected configManifest, recv models.DBReceiver) error {
+	receiverName := fmt.Sprintf("%s-%s", recv.TenantID, recv.Name)
+	receiverNameWithVersion := fmt.Sprintf("%s-%d", receiverName, recv.Version)
+	intervalName := quietHoursIntervalName(receiverName)
+
+	receiverOf := func(r receiver) string { return r.Name }
+	routeOf := func(r subRoute) string { return r.Receiver }
+	intervalOf := func(t timeInterval) string { return t.Name }
+
+	if err := compareRendered("receiver", receiverNameWithVersion,
+		findNamed(expected.Receivers, receiverNameWithVersion, receiverOf),
+		findNamed(m.Receivers, receiverNameWithVersion, receiverOf)); err != nil {
+		return err
+	}
+	if err := compareRendered("route", receiverNameWithVersion,
+		findNamed(expected.Route.Routes, receiverNameWithVersion, routeOf),
+		findNamed(m.Route.Routes, receiverNameWithVersion, routeOf)); err != nil {
+		return err
+	}
+	if err := compareRendered("time interval", intervalName,
+		findNamed(expected.TimeIntervals, intervalName, intervalOf),
+		findNamed(m.TimeIntervals, intervalName, intervalOf)); err != nil {
+		return err
+	}
+	maintenanceName := maintenanceIntervalName(recv.TenantID)
+	return compareRendered("time interval", maintenanceName,
+		findNamed(expected.TimeIntervals, maintenanceName, intervalOf),
+		findNamed(m.TimeIntervals, maintenanceName, intervalOf))
+}
+
+// RenderReceiver returns the receiver and route blocks of the given receiver in the manifest, rendered in YAML as they are set
+// in the alertmanager configuration. The route block is empty if the receiver is disabled.
+func (m configManifest) RenderReceiver(recv models.DBReceiver) (string, string, error) {
+	receiverNameWithVersion := fmt.Sprintf("%s-%s-%d", recv.TenantID, recv.Name, recv.Version)
+
+	rendered := findNamed(m.Receivers, receiverNameWithVersion, func(r receiver) string { return r.Name })
+	if rendered == nil {
+		return "", "", fmt.Errorf("receiver %q not found", receiverNameWithVersion)
+	}
+	receiverData, err := yaml.Marshal(rendered)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to marshal receiver %q: %w", receiverNameWithVersion, err)
+	}
+
+	route := findNamed(m.Route.Routes, receiverNameWithVersion, func(r subRoute) string { return r.Receiver })
+	if route == nil && recv.Disabled {
+		return string(receiverData), "", nil
+	} else if route == nil {
+		return "", "", fmt.Errorf("route %q not found", receiverNameWithVersion)
+	}
+	routeData, err := yaml.Marshal(route)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to marshal route %q: %w", receiverNameWithVersion, err)
+	}
+
+	return string(receiverData), string(routeData), nil
+}
+
+// findNamed returns the first item with the given name, or nil if there is none.
+func findNamed[T any](items []T, name string, nameOf func(T) string) *T {
+	index := slices.IndexFunc(items, func(item T) bool {
+		return nameOf(item) == name
+	})
+	if index < 0 {
+		return nil
+	}
+	return &items[index]
+}
+
+// compareRendered compares the expected and the applied item of the given kind and name by their YAML rendering, which
+// does not distinguish empty from missing fields, as these are dropped when the manifest is stored.
+func compareRendered[T any](kind, name string, expected, applied *T) error {
+	switch {
+	case expected == nil && applied == nil:
+		return nil
+	case expected == nil:
+		return fmt.Errorf("unexpected %s %q found: %w", kind, name, ErrConfigMismatch)
+	case applied == nil:
+		return fmt.Errorf("%s %q not found: %w", kind, name, ErrConfigMismatch)
+	}
+
+	expectedData, err := yaml.Marshal(expected)
+	if err != nil {
+		return fmt.Errorf("failed to marshal expected %s %q: %w", kind, name, err)
+	}
+	appliedData, err := yaml.Marshal(applied)
+	if err != nil {
+		return fmt.Errorf("failed to marshal applied %s %q: %w", kind, name, err)
+	}
+
+	if !bytes.Equal(expectedData, appliedData) {
+		return fmt.Errorf("%s %q differs from the expected one: %w", kind, name, ErrConfigMismatch)
+	}
+	return nil
 }
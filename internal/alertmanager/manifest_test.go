@@ -4,15 +4,25 @@
 package alertmanager
 
 import (
+	"bytes"
 	"fmt"
+	"maps"
+	"os"
+	"strconv"
 	"testing"
+	"text/template"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/prometheus/prometheus/promql/parser"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v2"
 
 	"github.com/open-edge-platform/o11y-alerting-monitor/internal/app"
 	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
 	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/mailrelay"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/rules"
 )
 
 func TestConfigManifest_ApplyReceiver(t *testing.T) {
@@ -36,7 +46,7 @@ func TestConfigManifest_ApplyReceiver(t *testing.T) {
 			InsecureSkipVerify: true,
 		}
 
-		manifestOut, err := manifestIn.ApplyReceiver(dbReceiver, conf)
+		manifestOut, err := manifestIn.ApplyReceiver(dbReceiver, conf, config.TenancyConfig{})
 
 		require.ErrorContains(t, err, "alertmanager config manifest does not have receivers")
 		require.Nil(t, manifestOut)
@@ -70,7 +80,7 @@ func TestConfigManifest_ApplyReceiver(t *testing.T) {
 			InsecureSkipVerify: true,
 		}
 
-		manifestOut, err := manifestIn.ApplyReceiver(dbReceiver, conf)
+		manifestOut, err := manifestIn.ApplyReceiver(dbReceiver, conf, config.TenancyConfig{})
 
 		require.ErrorContains(t, err, "alertmanager config manifest does not have routes")
 		require.Nil(t, manifestOut)
@@ -113,7 +123,7 @@ func TestConfigManifest_ApplyReceiver(t *testing.T) {
 				InsecureSkipVerify: true,
 			}
 
-			manifestOut, err := manifestIn.ApplyReceiver(dbReceiver, conf)
+			manifestOut, err := manifestIn.ApplyReceiver(dbReceiver, conf, config.TenancyConfig{})
 
 			require.NoError(t, err)
 			require.Equal(t, &configManifest{
@@ -206,7 +216,7 @@ tls_config:
 				InsecureSkipVerify: true,
 			}
 
-			manifestOut, err := manifestIn.ApplyReceiver(dbReceiver, conf)
+			manifestOut, err := manifestIn.ApplyReceiver(dbReceiver, conf, config.TenancyConfig{})
 
 			require.NoError(t, err)
 			require.Equal(t, &configManifest{
@@ -301,7 +311,7 @@ tls_config:
 				InsecureSkipVerify: true,
 			}
 
-			manifestOut, err := manifestIn.ApplyReceiver(dbReceiver, conf)
+			manifestOut, err := manifestIn.ApplyReceiver(dbReceiver, conf, config.TenancyConfig{})
 
 			require.NoError(t, err)
 			require.Equal(t, &configManifest{
@@ -399,7 +409,7 @@ tls_config:
 			manifestOut, err := manifestIn.ApplyReceiver(dbReceiver, config.AlertManagerConfig{
 				RequireTLS:         true,
 				InsecureSkipVerify: true,
-			})
+			}, config.TenancyConfig{})
 
 			receiverName := fmt.Sprintf("%s-%s-%d", dbReceiver.TenantID, dbReceiver.Name, dbReceiver.Version)
 
@@ -493,7 +503,7 @@ tls_config:
 			InsecureSkipVerify: true,
 		}
 
-		manifestOut, err := manifestIn.ApplyReceiver(dbReceiver, conf)
+		manifestOut, err := manifestIn.ApplyReceiver(dbReceiver, conf, config.TenancyConfig{})
 
 		require.NoError(t, err)
 		require.Equal(t, &configManifest{
@@ -586,7 +596,7 @@ tls_config:
 			InsecureSkipVerify: true,
 		}
 
-		manifestOut, err := manifestIn.ApplyReceiver(dbReceiver, conf)
+		manifestOut, err := manifestIn.ApplyReceiver(dbReceiver, conf, config.TenancyConfig{})
 
 		require.NoError(t, err)
 		require.Equal(t, &configManifest{
@@ -679,7 +689,7 @@ tls_config:
 			InsecureSkipVerify: true,
 		}
 
-		manifestOut, err := manifestIn.ApplyReceiver(dbReceiver, conf)
+		manifestOut, err := manifestIn.ApplyReceiver(dbReceiver, conf, config.TenancyConfig{})
 
 		require.NoError(t, err)
 		require.Equal(t, &configManifest{
@@ -770,7 +780,7 @@ tls_config:
 		manifestOut, err := manifestIn.ApplyReceiver(dbReceiver, config.AlertManagerConfig{
 			RequireTLS:         true,
 			InsecureSkipVerify: true,
-		})
+		}, config.TenancyConfig{})
 
 		receiverName := fmt.Sprintf("%s-%s-%d", dbReceiver.TenantID, dbReceiver.Name, dbReceiver.Version)
 
@@ -873,7 +883,7 @@ tls_config:
 			InsecureSkipVerify: false,
 		}
 
-		manifestOut, err := manifestIn.ApplyReceiver(dbReceiver, conf)
+		manifestOut, err := manifestIn.ApplyReceiver(dbReceiver, conf, config.TenancyConfig{})
 
 		require.NoError(t, err)
 		require.Equal(t, &configManifest{
@@ -888,6 +898,8 @@ tls_config:
 						{
 							SendResolved: true,
 							To:           dbReceiver.To[0],
+							From:         dbReceiver.From,
+							Smarthost:    dbReceiver.MailServer,
 							HTML:         emailHTMLTemplate,
 							RequireTLS:   conf.RequireTLS,
 							TLSConfig: struct {
@@ -959,7 +971,7 @@ tls_config:
 			InsecureSkipVerify: false,
 		}
 
-		manifestOut, err := manifestIn.ApplyReceiver(dbReceiver, conf)
+		manifestOut, err := manifestIn.ApplyReceiver(dbReceiver, conf, config.TenancyConfig{})
 
 		require.NoError(t, err)
 		require.Equal(t, &configManifest{
@@ -976,6 +988,8 @@ tls_config:
 						{
 							SendResolved: true,
 							To:           dbReceiver.To[0],
+							From:         dbReceiver.From,
+							Smarthost:    dbReceiver.MailServer,
 							HTML:         emailHTMLTemplate,
 							RequireTLS:   conf.RequireTLS,
 							TLSConfig: struct {
@@ -1000,4 +1014,992 @@ tls_config:
 			},
 		}, manifestOut)
 	})
+
+	t.Run("SetSMTPGlobalConfigWithSigningRelay", func(t *testing.T) {
+		t.Setenv("SMTP_USERNAME", "admin")
+		t.Setenv("SMTP_PASSWORD", "1234")
+
+		dbReceiver := models.DBReceiver{
+			Name:     "receiver",
+			TenantID: "tenant",
+			Version:  3,
+			To: []string{
+				"test user <test@user.com>",
+			},
+			From:       "sender user <sender@user.com>",
+			MailServer: "smtp.com:443",
+		}
+
+		receiverName := fmt.Sprintf("%s-%s-%d", dbReceiver.TenantID, dbReceiver.Name, dbReceiver.Version)
+
+		manifestIn := configManifest{
+			Receivers: []receiver{
+				{
+					Name: "tenant-receiver-1",
+				},
+			},
+			Route: route{
+				Routes: []subRoute{
+					{
+						Receiver: "tenant-receiver-1",
+					},
+				},
+			},
+		}
+
+		conf := config.AlertManagerConfig{
+			RequireTLS:       true,
+			SigningRelayHost: "alerting-monitor:2525",
+		}
+		t.Setenv("SMTP_USERNAME", "smtp-user")
+		t.Setenv("EMAIL_SIGNING_RELAY_SECRET", "relay-secret")
+
+		manifestOut, err := manifestIn.ApplyReceiver(dbReceiver, conf, config.TenancyConfig{})
+
+		// Credentials of the mail server and TLS are left to the signing relay, alertmanager authenticates to the relay.
+		require.NoError(t, err)
+		require.Equal(t, global{
+			SMTPFrom:         dbReceiver.From,
+			SMTPHost:         conf.SigningRelayHost,
+			SMTPAuthUsername: mailrelay.Username,
+			SMTPAuthSecret:   "relay-secret",
+		}, manifestOut.Global)
+		require.Equal(t, []receiver{
+			{
+				Name: receiverName,
+				EmailConfigs: []emailConfig{
+					{
+						SendResolved: true,
+						To:           dbReceiver.To[0],
+						From:         dbReceiver.From,
+						HTML:         emailHTMLTemplate,
+						RequireTLS:   false,
+					},
+				},
+			},
+		}, manifestOut.Receivers)
+	})
+
+	t.Run("SetReceiverWithMinSeverity", func(t *testing.T) {
+		dbReceiver := models.DBReceiver{
+			Name:     "receiver",
+			TenantID: "tenant",
+			Version:  2,
+			To: []string{
+				"test user <test@user.com>",
+			},
+			MinSeverity: models.SeverityWarning,
+		}
+
+		receiverName := fmt.Sprintf("%s-%s-%d", dbReceiver.TenantID, dbReceiver.Name, dbReceiver.Version)
+
+		manifestIn := configManifest{
+			Receivers: []receiver{
+				{
+					Name: "tenant-receiver-1",
+				},
+			},
+			Route: route{
+				Routes: []subRoute{
+					{
+						Receiver: "tenant-receiver-1",
+					},
+				},
+			},
+		}
+
+		manifestOut, err := manifestIn.ApplyReceiver(dbReceiver, config.AlertManagerConfig{}, config.TenancyConfig{})
+
+		require.NoError(t, err)
+		require.Equal(t, []subRoute{
+			{
+				Receiver: receiverName,
+				Matchers: []string{
+					alertCategoryMatcher,
+					`projectId=~"tenant"`,
+					`severity=~"warning|critical"`,
+				},
+			},
+		}, manifestOut.Route.Routes)
+	})
+
+	t.Run("SetReceiverWithQuietHours", func(t *testing.T) {
+		dbReceiver := models.DBReceiver{
+			Name:     "receiver",
+			TenantID: "tenant",
+			Version:  2,
+			To: []string{
+				"test user <test@user.com>",
+			},
+			QuietHours: models.QuietHours{
+				Start:    "22:00",
+				End:      "06:00",
+				Location: "Europe/Warsaw",
+			},
+		}
+
+		receiverName := fmt.Sprintf("%s-%s-%d", dbReceiver.TenantID, dbReceiver.Name, dbReceiver.Version)
+		intervalName := "tenant-receiver-quiet-hours"
+
+		manifestIn := configManifest{
+			Receivers: []receiver{
+				{
+					Name: "tenant-receiver-1",
+				},
+			},
+			Route: route{
+				Routes: []subRoute{
+					{
+						Receiver: "tenant-receiver-1",
+					},
+				},
+			},
+			TimeIntervals: []timeInterval{
+				{
+					Name: intervalName,
+				},
+			},
+		}
+
+		manifestOut, err := manifestIn.ApplyReceiver(dbReceiver, config.AlertManagerConfig{}, config.TenancyConfig{})
+
+		require.NoError(t, err)
+		require.Equal(t, []subRoute{
+			{
+				Receiver: receiverName,
+				Matchers: []string{
+					alertCategoryMatcher,
+					`projectId=~"tenant"`,
+				},
+				MuteTimeIntervals: []string{intervalName},
+				Routes: []subRoute{
+					{
+						Receiver: receiverName,
+						Matchers: []string{`severity="critical"`},
+					},
+				},
+			},
+		}, manifestOut.Route.Routes)
+		require.Equal(t, []timeInterval{
+			{
+				Name: intervalName,
+				TimeIntervals: []timeIntervalSpec{
+					{
+						Times: []timeRange{
+							{StartTime: "22:00", EndTime: "24:00"},
+							{StartTime: "00:00", EndTime: "06:00"},
+						},
+						Location: "Europe/Warsaw",
+					},
+				},
+			},
+		}, manifestOut.TimeIntervals)
+
+		// Unsetting the quiet hours removes the time interval.
+		dbReceiver.QuietHours = models.QuietHours{}
+		manifestOut, err = manifestOut.ApplyReceiver(dbReceiver, config.AlertManagerConfig{}, config.TenancyConfig{})
+
+		require.NoError(t, err)
+		require.Empty(t, manifestOut.TimeIntervals)
+		require.Empty(t, manifestOut.Route.Routes[0].MuteTimeIntervals)
+	})
+
+	t.Run("SetReceiverInMaintenanceMode", func(t *testing.T) {
+		dbReceiver := models.DBReceiver{
+			Name:     "receiver",
+			TenantID: "tenant",
+			Version:  2,
+			To: []string{
+				"test user <test@user.com>",
+			},
+			QuietHours: models.QuietHours{
+				Start: "22:00",
+				End:   "06:00",
+			},
+			Maintenance: &models.MaintenanceWindow{
+				Start: time.Date(2026, 10, 16, 22, 30, 0, 0, time.UTC),
+				End:   time.Date(2026, 10, 17, 2, 0, 0, 0, time.UTC),
+			},
+		}
+
+		receiverName := fmt.Sprintf("%s-%s-%d", dbReceiver.TenantID, dbReceiver.Name, dbReceiver.Version)
+		manifestIn := configManifest{
+			Receivers: []receiver{
+				{
+					Name: "tenant-receiver-1",
+				},
+			},
+			Route: route{
+				Routes: []subRoute{
+					{
+						Receiver: "tenant-receiver-1",
+					},
+				},
+			},
+		}
+
+		manifestOut, err := manifestIn.ApplyReceiver(dbReceiver, config.AlertManagerConfig{}, config.TenancyConfig{})
+
+		require.NoError(t, err)
+		require.Equal(t, []subRoute{
+			{
+				Receiver: receiverName,
+				Matchers: []string{
+					alertCategoryMatcher,
+					`projectId=~"tenant"`,
+				},
+				MuteTimeIntervals: []string{"tenant-receiver-quiet-hours", "tenant-maintenance"},
+				Routes: []subRoute{
+					{
+						Receiver:          receiverName,
+						Matchers:          []string{`severity="critical"`},
+						MuteTimeIntervals: []string{"tenant-maintenance"},
+					},
+				},
+			},
+		}, manifestOut.Route.Routes)
+		require.Len(t, manifestOut.TimeIntervals, 2)
+		require.Equal(t, "tenant-maintenance", manifestOut.TimeIntervals[1].Name)
+		require.NoError(t, manifestOut.VerifyReceiver(*manifestOut, dbReceiver))
+
+		// Taking the tenant out of maintenance mode removes the time interval.
+		dbReceiver.Maintenance = nil
+		manifestOut, err = manifestOut.ApplyReceiver(dbReceiver, config.AlertManagerConfig{}, config.TenancyConfig{})
+
+		require.NoError(t, err)
+		require.Len(t, manifestOut.TimeIntervals, 1)
+		require.Equal(t, []string{"tenant-receiver-quiet-hours"}, manifestOut.Route.Routes[0].MuteTimeIntervals)
+		require.Empty(t, manifestOut.Route.Routes[0].Routes[0].MuteTimeIntervals)
+	})
+
+	t.Run("DisableReceiver", func(t *testing.T) {
+		dbReceiver := models.DBReceiver{
+			Name:     "receiver",
+			TenantID: "tenant",
+			Version:  2,
+			To: []string{
+				"test user <test@user.com>",
+			},
+			QuietHours: models.QuietHours{
+				Start: "22:00",
+				End:   "06:00",
+			},
+			Maintenance: &models.MaintenanceWindow{
+				Start: time.Date(2026, 10, 16, 22, 30, 0, 0, time.UTC),
+				End:   time.Date(2026, 10, 17, 2, 0, 0, 0, time.UTC),
+			},
+			Disabled: true,
+		}
+
+		receiverName := fmt.Sprintf("%s-%s-%d", dbReceiver.TenantID, dbReceiver.Name, dbReceiver.Version)
+		manifestIn := configManifest{
+			Receivers: []receiver{
+				{Name: "null"},
+				{Name: "tenant-receiver-1"},
+				{Name: "tenant-other-1"},
+			},
+			Route: route{
+				Receiver: "null",
+				Routes: []subRoute{
+					{
+						Receiver:          "tenant-receiver-1",
+						MuteTimeIntervals: []string{"tenant-receiver-quiet-hours"},
+					},
+					{
+						Receiver:          "tenant-other-1",
+						MuteTimeIntervals: []string{"tenant-maintenance"},
+					},
+				},
+			},
+			TimeIntervals: []timeInterval{
+				{Name: "tenant-receiver-quiet-hours"},
+				{Name: "tenant-maintenance"},
+			},
+		}
+
+		manifestOut, err := manifestIn.ApplyReceiver(dbReceiver, config.AlertManagerConfig{}, config.TenancyConfig{})
+
+		// The receiver keeps its recipients without route, the maintenance window still muting the other route of the tenant.
+		require.NoError(t, err)
+		require.Equal(t, receiverName, manifestOut.Receivers[1].Name)
+		require.Len(t, manifestOut.Receivers[1].EmailConfigs, 1)
+		require.Equal(t, []subRoute{{Receiver: "tenant-other-1", MuteTimeIntervals: []string{"tenant-maintenance"}}}, manifestOut.Route.Routes)
+		require.Len(t, manifestOut.TimeIntervals, 1)
+		require.Equal(t, "tenant-maintenance", manifestOut.TimeIntervals[0].Name)
+		require.NoError(t, manifestOut.VerifyReceiver(*manifestOut, dbReceiver))
+
+		// Once the other route is removed, the manifest has no route left but the receiver can be enabled again.
+		manifestOut.Route.Routes = nil
+		manifestOut, err = manifestOut.ApplyReceiver(dbReceiver, config.AlertManagerConfig{}, config.TenancyConfig{})
+		require.NoError(t, err)
+		require.Empty(t, manifestOut.Route.Routes)
+		require.Empty(t, manifestOut.TimeIntervals)
+
+		dbReceiver.Disabled = false
+		manifestOut, err = manifestOut.ApplyReceiver(dbReceiver, config.AlertManagerConfig{}, config.TenancyConfig{})
+		require.NoError(t, err)
+		require.Len(t, manifestOut.Route.Routes, 1)
+		require.Equal(t, receiverName, manifestOut.Route.Routes[0].Receiver)
+		require.Len(t, manifestOut.TimeIntervals, 2)
+	})
+
+	t.Run("SetReceiverWithMatchers", func(t *testing.T) {
+		dbReceiver := models.DBReceiver{
+			Name:     "receiver",
+			TenantID: "tenant",
+			Version:  2,
+			To:       []string{"test user <test@user.com>"},
+			Matchers: []string{`cluster_name="edge-1"`},
+		}
+
+		tenantRoute := func(name string) subRoute {
+			return subRoute{Receiver: name, Matchers: []string{alertCategoryMatcher, `projectId=~"tenant"`}}
+		}
+		manifestIn := configManifest{
+			Receivers: []receiver{{Name: "null"}, {Name: "tenant-receiver-1"}, {Name: "tenant-other-1"}},
+			Route: route{
+				Receiver: "null",
+				Routes: []subRoute{
+					{Receiver: "another-1", Matchers: []string{alertCategoryMatcher, `projectId=~"another"`}},
+					tenantRoute("tenant-other-1"),
+					tenantRoute("tenant-receiver-1"),
+				},
+			},
+		}
+
+		// The route with matchers continues, and is moved before the other routes of the tenant.
+		manifestOut, err := manifestIn.ApplyReceiver(dbReceiver, config.AlertManagerConfig{}, config.TenancyConfig{})
+		require.NoError(t, err)
+		require.Equal(t, []subRoute{
+			manifestIn.Route.Routes[0],
+			{
+				Receiver: "tenant-receiver-2",
+				Matchers: []string{alertCategoryMatcher, `projectId=~"tenant"`, `cluster_name="edge-1"`},
+				Continue: true,
+			},
+			tenantRoute("tenant-other-1"),
+		}, manifestOut.Route.Routes)
+		require.NoError(t, manifestOut.checkRoutes(dbReceiver, config.TenancyConfig{}))
+
+		// Once its matchers are removed, the route is moved after the other routes of the tenant.
+		dbReceiver.Version = 3
+		dbReceiver.Matchers = nil
+		manifestOut, err = manifestOut.ApplyReceiver(dbReceiver, config.AlertManagerConfig{}, config.TenancyConfig{})
+		require.NoError(t, err)
+		require.Equal(t, []subRoute{
+			manifestIn.Route.Routes[0],
+			tenantRoute("tenant-other-1"),
+			tenantRoute("tenant-receiver-3"),
+		}, manifestOut.Route.Routes)
+	})
+
+	t.Run("SetReceiverWithOnCallRoutingKey", func(t *testing.T) {
+		t.Setenv("ONCALL_RELAY_TOKEN", "relay-token")
+
+		dbReceiver := models.DBReceiver{
+			UUID:     uuid.MustParse("2e2ccb6c-1c83-4e5d-9b2f-8f0e5c3a1d44"),
+			Name:     "receiver",
+			TenantID: "tenant",
+			Version:  2,
+			To: []string{
+				"test user <test@user.com>",
+			},
+			OnCallRoutingKey: "routing-key",
+		}
+
+		manifestIn := configManifest{
+			Receivers: []receiver{
+				{
+					Name: "tenant-receiver-1",
+				},
+			},
+			Route: route{
+				Routes: []subRoute{
+					{
+						Receiver: "tenant-receiver-1",
+					},
+				},
+			},
+		}
+
+		// Alerts are not relayed if the relay endpoint is not configured.
+		manifestOut, err := manifestIn.ApplyReceiver(dbReceiver, config.AlertManagerConfig{}, config.TenancyConfig{})
+		require.NoError(t, err)
+		require.Empty(t, manifestOut.Receivers[0].WebhookConfigs)
+
+		manifestOut, err = manifestIn.ApplyReceiver(dbReceiver, config.AlertManagerConfig{OnCallRelayURL: "http://alerting-monitor:8080/"}, config.TenancyConfig{})
+		require.NoError(t, err)
+		require.Equal(t, []webhookConfig{
+			{
+				SendResolved: true,
+				URL:          "http://alerting-monitor:8080/api/v1/oncall/relay/tenant/2e2ccb6c-1c83-4e5d-9b2f-8f0e5c3a1d44",
+				HTTPConfig: &httpConfig{
+					Authorization: &authorization{
+						Type:        "Bearer",
+						Credentials: "relay-token",
+					},
+				},
+			},
+		}, manifestOut.Receivers[0].WebhookConfigs)
+		require.Len(t, manifestOut.Receivers[0].EmailConfigs, 1)
+
+		// Removing the routing key stops relaying alerts.
+		dbReceiver.OnCallRoutingKey = ""
+		manifestOut, err = manifestOut.ApplyReceiver(dbReceiver, config.AlertManagerConfig{OnCallRelayURL: "http://alerting-monitor:8080"}, config.TenancyConfig{})
+		require.NoError(t, err)
+		require.Empty(t, manifestOut.Receivers[0].WebhookConfigs)
+	})
+
+	t.Run("SetReceiverWithEmailRelay", func(t *testing.T) {
+		t.Setenv("EMAIL_RELAY_TOKEN", "relay-token")
+
+		dbReceiver := models.DBReceiver{
+			UUID:     uuid.MustParse("2e2ccb6c-1c83-4e5d-9b2f-8f0e5c3a1d44"),
+			Name:     "receiver",
+			TenantID: "tenant",
+			Version:  2,
+			To: []string{
+				"test user <test@user.com>",
+			},
+			OnCallRoutingKey: "routing-key",
+		}
+
+		manifestIn := configManifest{
+			Receivers: []receiver{
+				{
+					Name: "tenant-receiver-1",
+				},
+			},
+			Route: route{
+				Routes: []subRoute{
+					{
+						Receiver: "tenant-receiver-1",
+					},
+				},
+			},
+		}
+
+		conf := config.AlertManagerConfig{
+			EmailRelayURL:  "http://alerting-monitor:8080",
+			OnCallRelayURL: "http://alerting-monitor:8080",
+		}
+
+		// Emails are sent by alerting monitor instead of alertmanager, alongside the Grafana OnCall relay.
+		manifestOut, err := manifestIn.ApplyReceiver(dbReceiver, conf, config.TenancyConfig{})
+		require.NoError(t, err)
+		require.Empty(t, manifestOut.Receivers[0].EmailConfigs)
+		require.Len(t, manifestOut.Receivers[0].WebhookConfigs, 2)
+		require.Equal(t, webhookConfig{
+			SendResolved: true,
+			URL:          "http://alerting-monitor:8080/api/v1/email/relay/tenant/2e2ccb6c-1c83-4e5d-9b2f-8f0e5c3a1d44",
+			HTTPConfig: &httpConfig{
+				Authorization: &authorization{
+					Type:        "Bearer",
+					Credentials: "relay-token",
+				},
+			},
+		}, manifestOut.Receivers[0].WebhookConfigs[0])
+		require.Equal(t, "http://alerting-monitor:8080/api/v1/oncall/relay/tenant/2e2ccb6c-1c83-4e5d-9b2f-8f0e5c3a1d44",
+			manifestOut.Receivers[0].WebhookConfigs[1].URL)
+
+		// Receivers without recipients do not relay emails.
+		dbReceiver.To = nil
+		dbReceiver.OnCallRoutingKey = ""
+		manifestOut, err = manifestOut.ApplyReceiver(dbReceiver, conf, config.TenancyConfig{})
+		require.NoError(t, err)
+		require.Empty(t, manifestOut.Receivers[0].EmailConfigs)
+		require.Empty(t, manifestOut.Receivers[0].WebhookConfigs)
+	})
+}
+
+func TestConfigManifest_RemoveTenant(t *testing.T) {
+	manifestIn := configManifest{
+		Route: route{
+			Receiver: "default",
+			Routes: []subRoute{
+				{
+					Receiver:          "tenant-receiver-2",
+					Matchers:          []string{alertCategoryMatcher, `projectId=~"tenant"`},
+					MuteTimeIntervals: []string{"tenant-receiver-quiet-hours"},
+				},
+				{
+					Receiver: "tenant-other-receiver-1",
+					Matchers: []string{alertCategoryMatcher, `projectId=~"tenant-other"`},
+				},
+			},
+		},
+		Receivers: []receiver{
+			{Name: "default"},
+			{Name: "tenant-receiver-2"},
+			{Name: "tenant-other-receiver-1"},
+		},
+		TimeIntervals: []timeInterval{
+			{Name: "tenant-receiver-quiet-hours"},
+		},
+	}
+
+	manifestOut := manifestIn.RemoveTenant("tenant", config.TenancyConfig{})
+
+	require.Equal(t, []subRoute{
+		{
+			Receiver: "tenant-other-receiver-1",
+			Matchers: []string{alertCategoryMatcher, `projectId=~"tenant-other"`},
+		},
+	}, manifestOut.Route.Routes)
+	require.Equal(t, []receiver{
+		{Name: "default"},
+		{Name: "tenant-other-receiver-1"},
+	}, manifestOut.Receivers)
+	require.Empty(t, manifestOut.TimeIntervals)
+
+	// The input manifest is left unmodified.
+	require.Len(t, manifestIn.Route.Routes, 2)
+	require.Len(t, manifestIn.Receivers, 3)
+	require.Len(t, manifestIn.TimeIntervals, 1)
+}
+
+func TestConfigManifest_TenantLabel(t *testing.T) {
+	tenancy := config.TenancyConfig{Label: "org_id", PreviousLabels: []string{"projectId"}}
+	newManifest := func() configManifest {
+		return configManifest{
+			Route: route{
+				Receiver: "default",
+				Routes: []subRoute{
+					{
+						Receiver: "tenant-receiver-1",
+						Matchers: []string{alertCategoryMatcher, `projectId=~"tenant"`},
+					},
+					{
+						Receiver: "tenant-other-receiver-1",
+						Matchers: []string{alertCategoryMatcher, `projectId=~"tenant-other"`, `severity=~"critical"`},
+					},
+				},
+			},
+			Receivers: []receiver{
+				{Name: "default"},
+				{Name: "tenant-receiver-1"},
+				{Name: "tenant-other-receiver-1"},
+			},
+		}
+	}
+
+	t.Run("Routes are migrated to the tenant label when a receiver is applied", func(t *testing.T) {
+		manifestIn := newManifest()
+		manifestOut, err := manifestIn.ApplyReceiver(models.DBReceiver{
+			Name:     "receiver",
+			Version:  2,
+			TenantID: "tenant",
+		}, config.AlertManagerConfig{}, tenancy)
+		require.NoError(t, err)
+
+		require.Equal(t, []subRoute{
+			{
+				Receiver: "tenant-receiver-2",
+				Matchers: []string{alertCategoryMatcher, `org_id=~"tenant"`},
+			},
+			{
+				Receiver: "tenant-other-receiver-1",
+				Matchers: []string{alertCategoryMatcher, `org_id=~"tenant-other"`, `severity=~"critical"`},
+			},
+		}, manifestOut.Route.Routes)
+
+		// The routes of the input manifest are left unmodified.
+		require.Equal(t, newManifest().Route.Routes, manifestIn.Route.Routes)
+	})
+
+	t.Run("Routes matching the tenant by a previous label are removed", func(t *testing.T) {
+		manifestOut := newManifest().RemoveTenant("tenant", tenancy)
+
+		require.Equal(t, []subRoute{
+			{
+				Receiver: "tenant-other-receiver-1",
+				Matchers: []string{alertCategoryMatcher, `org_id=~"tenant-other"`, `severity=~"critical"`},
+			},
+		}, manifestOut.Route.Routes)
+	})
+
+	t.Run("Routes are not migrated without previous labels", func(t *testing.T) {
+		manifestOut := newManifest().RemoveTenant("tenant", config.TenancyConfig{Label: "org_id"})
+		require.Equal(t, newManifest().Route.Routes, manifestOut.Route.Routes)
+	})
+}
+
+func TestConfigManifest_VerifyReceiver(t *testing.T) {
+	recv := models.DBReceiver{
+		Name:     "receiver",
+		TenantID: "tenant",
+		Version:  2,
+		To:       []string{"first user <first@user.com>"},
+		QuietHours: models.QuietHours{
+			Start:    "22:00",
+			End:      "06:00",
+			Location: "UTC",
+		},
+	}
+
+	// newManifest returns a manifest holding the first version of the receiver. A new one is used by each test, since
+	// applying a receiver overwrites the existing receiver and route in place.
+	newManifest := func() configManifest {
+		return configManifest{
+			Route: route{
+				Receiver: "default",
+				Routes:   []subRoute{{Receiver: "tenant-receiver-1"}},
+			},
+			Receivers: []receiver{
+				{Name: "default"},
+				{Name: "tenant-receiver-1"},
+			},
+		}
+	}
+
+	// roundTrip returns a copy of the given manifest as stored in the config secret.
+	roundTrip := func(t *testing.T, m configManifest) configManifest {
+		data, err := yaml.Marshal(m)
+		require.NoError(t, err)
+
+		var out configManifest
+		require.NoError(t, yaml.Unmarshal(data, &out))
+		return out
+	}
+
+	t.Run("ReceiverApplied", func(t *testing.T) {
+		expected, err := newManifest().ApplyReceiver(recv, config.AlertManagerConfig{}, config.TenancyConfig{})
+		require.NoError(t, err)
+
+		applied := roundTrip(t, *expected)
+		require.NoError(t, applied.VerifyReceiver(*expected, recv))
+	})
+
+	t.Run("ReceiverWithoutRecipientsApplied", func(t *testing.T) {
+		recv := recv
+		recv.To = nil
+		expected, err := newManifest().ApplyReceiver(recv, config.AlertManagerConfig{}, config.TenancyConfig{})
+		require.NoError(t, err)
+
+		applied := roundTrip(t, *expected)
+		require.NoError(t, applied.VerifyReceiver(*expected, recv))
+	})
+
+	t.Run("ReceiverNotApplied", func(t *testing.T) {
+		expected, err := newManifest().ApplyReceiver(recv, config.AlertManagerConfig{}, config.TenancyConfig{})
+		require.NoError(t, err)
+
+		applied := roundTrip(t, newManifest())
+		err = applied.VerifyReceiver(*expected, recv)
+		require.ErrorIs(t, err, ErrConfigMismatch)
+		require.ErrorContains(t, err, `receiver "tenant-receiver-2" not found`)
+	})
+
+	t.Run("RouteDiffers", func(t *testing.T) {
+		expected, err := newManifest().ApplyReceiver(recv, config.AlertManagerConfig{}, config.TenancyConfig{})
+		require.NoError(t, err)
+
+		applied := roundTrip(t, *expected)
+		applied.Route.Routes[0].MuteTimeIntervals = nil
+		err = applied.VerifyReceiver(*expected, recv)
+		require.ErrorIs(t, err, ErrConfigMismatch)
+		require.ErrorContains(t, err, `route "tenant-receiver-2" differs from the expected one`)
+	})
+
+	t.Run("QuietHoursNotRemoved", func(t *testing.T) {
+		withQuietHours, err := newManifest().ApplyReceiver(recv, config.AlertManagerConfig{}, config.TenancyConfig{})
+		require.NoError(t, err)
+
+		recv := recv
+		recv.Version = 3
+		recv.QuietHours = models.QuietHours{}
+		expected, err := roundTrip(t, *withQuietHours).ApplyReceiver(recv, config.AlertManagerConfig{}, config.TenancyConfig{})
+		require.NoError(t, err)
+
+		applied := roundTrip(t, *expected)
+		applied.TimeIntervals = withQuietHours.TimeIntervals
+		err = applied.VerifyReceiver(*expected, recv)
+		require.ErrorIs(t, err, ErrConfigMismatch)
+		require.ErrorContains(t, err, `unexpected time interval "tenant-receiver-quiet-hours" found`)
+	})
+}
+
+func TestConfigManifest_RenderReceiver(t *testing.T) {
+	recv := models.DBReceiver{
+		Name:     "receiver",
+		TenantID: "tenant",
+		Version:  2,
+		To:       []string{"first user <first@user.com>"},
+	}
+	// newManifest returns a manifest holding the first version of the receiver. A new one is used by each test, since
+	// applying a receiver overwrites the existing receiver and route in place.
+	newManifest := func() configManifest {
+		return configManifest{
+			Route: route{
+				Receiver: "default",
+				Routes:   []subRoute{{Receiver: "tenant-receiver-1"}},
+			},
+			Receivers: []receiver{
+				{Name: "default"},
+				{Name: "tenant-receiver-1"},
+			},
+		}
+	}
+
+	t.Run("ReceiverApplied", func(t *testing.T) {
+		applied, err := newManifest().ApplyReceiver(recv, config.AlertManagerConfig{}, config.TenancyConfig{})
+		require.NoError(t, err)
+
+		receiverBlock, routeBlock, err := applied.RenderReceiver(recv)
+		require.NoError(t, err)
+		require.Contains(t, receiverBlock, "name: tenant-receiver-2\n")
+		require.Contains(t, receiverBlock, "email_configs:\n")
+		require.Contains(t, receiverBlock, "to: first user <first@user.com>\n")
+		require.Contains(t, routeBlock, "receiver: tenant-receiver-2\n")
+		require.Contains(t, routeBlock, "projectId=~\"tenant\"")
+	})
+
+	t.Run("ReceiverNotApplied", func(t *testing.T) {
+		_, _, err := newManifest().RenderReceiver(recv)
+		require.ErrorContains(t, err, `receiver "tenant-receiver-2" not found`)
+	})
+
+	t.Run("DisabledReceiverApplied", func(t *testing.T) {
+		disabled := recv
+		disabled.Disabled = true
+		applied, err := newManifest().ApplyReceiver(disabled, config.AlertManagerConfig{}, config.TenancyConfig{})
+		require.NoError(t, err)
+
+		receiverBlock, routeBlock, err := applied.RenderReceiver(disabled)
+		require.NoError(t, err)
+		require.Contains(t, receiverBlock, "to: first user <first@user.com>\n")
+		require.Empty(t, routeBlock)
+	})
+}
+
+func TestSeverityMatcher(t *testing.T) {
+	for _, tc := range []struct {
+		severity models.ReceiverSeverity
+		expected string
+	}{
+		{severity: models.SeverityNone, expected: ""},
+		{severity: models.SeverityInfo, expected: `severity=~"info|warning|critical"`},
+		{severity: models.SeverityWarning, expected: `severity=~"warning|critical"`},
+		{severity: models.SeverityCritical, expected: `severity=~"critical"`},
+	} {
+		t.Run(string(tc.severity), func(t *testing.T) {
+			require.Equal(t, tc.expected, severityMatcher(tc.severity))
+		})
+	}
+}
+
+// loadShippedRules renders the rules shipped with the chart with its default values, providing the few template functions
+// of Helm they use.
+func loadShippedRules(t *testing.T) rules.RulesConfig {
+	t.Helper()
+
+	toInt := func(v any) int {
+		i, err := strconv.Atoi(fmt.Sprint(v))
+		require.NoError(t, err)
+		return i
+	}
+	tmpl, err := template.New("rules.yaml").Funcs(template.FuncMap{
+		"default": func(d, v any) any {
+			if v == nil {
+				return d
+			}
+			return v
+		},
+		"int":   toInt,
+		"mul":   func(a, b any) int { return toInt(a) * toInt(b) },
+		"quote": strconv.Quote,
+	}).ParseFiles("../../deployments/alerting-monitor/files/rules/rules.yaml")
+	require.NoError(t, err)
+
+	var values map[string]any
+	data, err := os.ReadFile("../../deployments/alerting-monitor/values.yaml")
+	require.NoError(t, err)
+	require.NoError(t, yaml.Unmarshal(data, &values))
+
+	var out bytes.Buffer
+	require.NoError(t, tmpl.Execute(&out, map[string]any{"Values": values}))
+
+	var conf rules.RulesConfig
+	require.NoError(t, yaml.Unmarshal(out.Bytes(), &conf))
+	require.NotEmpty(t, conf.Groups)
+	return conf
+}
+
+// routeMatches reports whether the given labels match all the matchers of the given route.
+func routeMatches(t *testing.T, r subRoute, labels map[string]string) bool {
+	t.Helper()
+
+	for _, m := range r.Matchers {
+		matchers, err := parser.NewParser(parser.Options{}).ParseMetricSelector("{" + m + "}")
+		require.NoError(t, err)
+		for _, matcher := range matchers {
+			if !matcher.Matches(labels[matcher.Name]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestReceiverRoute_ShippedRules(t *testing.T) {
+	conf := loadShippedRules(t)
+	for _, group := range conf.Groups {
+		for _, rule := range group.Rules {
+			t.Run(rule.Alert, func(t *testing.T) {
+				severity := models.ReceiverSeverity(rule.Labels["severity"])
+				require.NotEqual(t, models.SeverityNone, severity, "rule has no severity label")
+				require.NoError(t, severity.Validate())
+
+				labels := map[string]string{"alertname": rule.Alert, config.DefaultTenantLabel: "tenant"}
+				maps.Copy(labels, rule.Labels)
+				routed := rule.Labels["alert_category"] != string(models.CategoryMaintenance)
+
+				for _, minSeverity := range []models.ReceiverSeverity{
+					models.SeverityNone, models.SeverityInfo, models.SeverityWarning, models.SeverityCritical,
+				} {
+					recv := models.DBReceiver{Name: "receiver", TenantID: "tenant", MinSeverity: minSeverity}
+					route, _ := receiverRoute(recv, "tenant-receiver-1", config.TenancyConfig{})
+					require.Equal(t, routed && severity.Rank() >= minSeverity.Rank(), routeMatches(t, route, labels),
+						"minimum severity %q", minSeverity)
+				}
+
+				// Critical alerts are still notified during quiet hours through the child route of the receiver route.
+				recv := models.DBReceiver{
+					Name:       "receiver",
+					TenantID:   "tenant",
+					QuietHours: models.QuietHours{Start: "22:00", End: "06:00", Location: "UTC"},
+				}
+				route, _ := receiverRoute(recv, "tenant-receiver-1", config.TenancyConfig{})
+				require.Len(t, route.Routes, 1)
+				require.Equal(t, routed && severity == models.SeverityCritical,
+					routeMatches(t, route, labels) && routeMatches(t, route.Routes[0], labels))
+			})
+		}
+	}
+}
+
+func TestNewQuietHoursInterval(t *testing.T) {
+	for name, tc := range map[string]struct {
+		quietHours models.QuietHours
+		expected   []timeRange
+	}{
+		"WithinDay": {
+			quietHours: models.QuietHours{Start: "12:00", End: "13:30"},
+			expected:   []timeRange{{StartTime: "12:00", EndTime: "13:30"}},
+		},
+		"SpanningMidnight": {
+			quietHours: models.QuietHours{Start: "22:00", End: "6:00"},
+			expected:   []timeRange{{StartTime: "22:00", EndTime: "24:00"}, {StartTime: "00:00", EndTime: "06:00"}},
+		},
+		"EndingAtMidnight": {
+			quietHours: models.QuietHours{Start: "22:00", End: "00:00"},
+			expected:   []timeRange{{StartTime: "22:00", EndTime: "24:00"}},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			interval := newQuietHoursInterval("interval", tc.quietHours)
+			require.Equal(t, "interval", interval.Name)
+			require.Len(t, interval.TimeIntervals, 1)
+			require.Equal(t, tc.expected, interval.TimeIntervals[0].Times)
+		})
+	}
+}
+
+func TestNewMaintenanceInterval(t *testing.T) {
+	for name, tc := range map[string]struct {
+		window   models.MaintenanceWindow
+		expected []timeIntervalSpec
+	}{
+		"WithinDay": {
+			window: models.MaintenanceWindow{
+				Start: time.Date(2026, 10, 16, 12, 0, 30, 0, time.UTC),
+				End:   time.Date(2026, 10, 16, 13, 29, 30, 0, time.UTC),
+			},
+			expected: []timeIntervalSpec{
+				{
+					Times:       []timeRange{{StartTime: "12:00", EndTime: "13:30"}},
+					DaysOfMonth: []string{"16"}, Months: []string{"10"}, Years: []string{"2026"}, Location: "UTC",
+				},
+			},
+		},
+		"SpanningDays": {
+			window: models.MaintenanceWindow{
+				Start: time.Date(2026, 12, 30, 22, 0, 0, 0, time.FixedZone("CET", 3600)),
+				End:   time.Date(2027, 1, 1, 6, 0, 0, 0, time.UTC),
+			},
+			expected: []timeIntervalSpec{
+				{
+					Times:       []timeRange{{StartTime: "21:00", EndTime: "24:00"}},
+					DaysOfMonth: []string{"30"}, Months: []string{"12"}, Years: []string{"2026"}, Location: "UTC",
+				},
+				{
+					Times:       []timeRange{{StartTime: "00:00", EndTime: "24:00"}},
+					DaysOfMonth: []string{"31"}, Months: []string{"12"}, Years: []string{"2026"}, Location: "UTC",
+				},
+				{
+					Times:       []timeRange{{StartTime: "00:00", EndTime: "06:00"}},
+					DaysOfMonth: []string{"1"}, Months: []string{"1"}, Years: []string{"2027"}, Location: "UTC",
+				},
+			},
+		},
+		"EndingAtMidnight": {
+			window: models.MaintenanceWindow{
+				Start: time.Date(2026, 10, 16, 22, 0, 0, 0, time.UTC),
+				End:   time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC),
+			},
+			expected: []timeIntervalSpec{
+				{
+					Times:       []timeRange{{StartTime: "22:00", EndTime: "24:00"}},
+					DaysOfMonth: []string{"16"}, Months: []string{"10"}, Years: []string{"2026"}, Location: "UTC",
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			interval := newMaintenanceInterval("interval", tc.window)
+			require.Equal(t, "interval", interval.Name)
+			require.Equal(t, tc.expected, interval.TimeIntervals)
+		})
+	}
+}
+
+// BenchmarkConfigManifest_ApplyReceiver measures applying and rendering a receiver into a manifest holding the receivers
+// of 1000 tenants, to catch regressions of the manifest rendering at scale.
+func BenchmarkConfigManifest_ApplyReceiver(b *testing.B) {
+	const tenants = 1000
+
+	manifest := configManifest{
+		Route:     route{Receiver: "default"},
+		Receivers: []receiver{{Name: "default"}},
+	}
+	for i := range tenants {
+		name := fmt.Sprintf("tenant-%04d-receiver-1", i)
+		manifest.Receivers = append(manifest.Receivers, receiver{
+			Name:         name,
+			EmailConfigs: []emailConfig{{To: "user <user@example.com>", HTML: emailHTMLTemplate}},
+		})
+		manifest.Route.Routes = append(manifest.Route.Routes, subRoute{
+			Receiver: name,
+			Matchers: []string{alertCategoryMatcher, fmt.Sprintf(`projectId=~"tenant-%04d"`, i)},
+		})
+	}
+
+	recv := models.DBReceiver{
+		Name:     "receiver",
+		Version:  2,
+		To:       []string{"first user <first@user.com>", "second user <second@user.com>"},
+		TenantID: fmt.Sprintf("tenant-%04d", tenants/2),
+	}
+	conf := config.AlertManagerConfig{RequireTLS: true}
+
+	b.ResetTimer()
+	for range b.N {
+		out, err := manifest.ApplyReceiver(recv, conf, config.TenancyConfig{})
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, err := yaml.Marshal(out); err != nil {
+			b.Fatal(err)
+		}
+	}
 }
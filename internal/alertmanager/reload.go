@@ -0,0 +1,203 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package alertmanager
+
+import (
+	"bufio"
+	"context"
+	"crypto/md5" //nolint:gosec // The hash is the one computed by alertmanager, it is not used for security.
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/correlation"
+)
+
+// ErrReloadFailed is returned when alertmanager does not reload an applied configuration in time, usually because it is invalid.
+var ErrReloadFailed = errors.New("alertmanager did not reload the configuration")
+
+// defaultStagingTimeout is how long to wait for the staging alertmanager to reload a configuration if no reload timeout is configured.
+const defaultStagingTimeout = time.Minute
+
+// reloadPollInterval is the interval between checks of the configuration reloads of alertmanager.
+var reloadPollInterval = time.Second
+
+// KnownGoodConfigStore stores the configuration manifest each alertmanager instance last reloaded successfully, which is
+// restored if a newer one fails to reload.
+type KnownGoodConfigStore interface {
+	GetKnownGoodAlertmanagerConfig(ctx context.Context, name string) ([]byte, error)
+	SetKnownGoodAlertmanagerConfig(ctx context.Context, name string, manifest []byte) error
+}
+
+// validateOnStaging applies the given manifest to the staging alertmanager instance and waits for it to reload it, so that
+// an invalid manifest is rejected before reaching the instance serving the tenants. Nothing is done if no staging instance
+// is configured.
+func (am *AlertManager) validateOnStaging(ctx context.Context, manifest configManifest) error {
+	staging := am.config.Staging
+	if staging.URL == "" {
+		return nil
+	}
+
+	conf := config.AlertManagerConfig{URL: staging.URL, Namespace: staging.Namespace, SecretName: staging.SecretName}
+	if conf.Namespace == "" {
+		conf.Namespace = am.config.Namespace
+	}
+	timeout := am.config.ReloadTimeout
+	if timeout <= 0 {
+		timeout = defaultStagingTimeout
+	}
+
+	if err := setConfigManifest(ctx, am.client, manifest, conf.Namespace, configSecretName(conf)); err != nil {
+		return fmt.Errorf("failed to set staging alertmanager config manifest: %w", err)
+	}
+	if err := waitForReload(ctx, conf.URL, manifest, timeout); err != nil {
+		return fmt.Errorf("alertmanager manifest is rejected by staging alertmanager: %w", err)
+	}
+	return nil
+}
+
+// awaitReload waits for the given alertmanager instance to reload the given manifest, and records it as the last known good
+// one. If it is not reloaded in time, the last known good manifest is applied back. Nothing is done if no reload timeout is
+// configured.
+func (am *AlertManager) awaitReload(ctx context.Context, conf config.AlertManagerConfig, manifest configManifest) error {
+	if conf.ReloadTimeout <= 0 {
+		return nil
+	}
+
+	name := conf.Namespace + "/" + configSecretName(conf)
+	reloadErr := waitForReload(ctx, conf.URL, manifest, conf.ReloadTimeout)
+	if reloadErr == nil {
+		data, err := yaml.Marshal(manifest)
+		if err != nil {
+			return fmt.Errorf("failed to marshal alertmanager config manifest: %w", err)
+		}
+		return am.knownGood.SetKnownGoodAlertmanagerConfig(ctx, name, data)
+	}
+
+	data, err := am.knownGood.GetKnownGoodAlertmanagerConfig(ctx, name)
+	if err != nil {
+		return errors.Join(reloadErr, fmt.Errorf("failed to roll back alertmanager config manifest: %w", err))
+	}
+
+	var knownGood configManifest
+	if err := yaml.Unmarshal(data, &knownGood); err != nil {
+		return errors.Join(reloadErr, fmt.Errorf("failed to unmarshal known good alertmanager config manifest: %w", err))
+	}
+	if err := setConfigManifest(ctx, am.client, knownGood, conf.Namespace, configSecretName(conf)); err != nil {
+		return errors.Join(reloadErr, fmt.Errorf("failed to roll back alertmanager config manifest: %w", err))
+	}
+	return fmt.Errorf("%w: rolled back to the last known good configuration", reloadErr)
+}
+
+// waitForReload polls the metrics of the alertmanager instance at the given URL until it reports that it successfully
+// reloaded the given manifest. The configuration alertmanager reports by its status is re-serialized, with defaults filled
+// in and secrets masked, so the reload is told by the hash of the loaded configuration file, which is that of the manifest
+// as written to the config secret. ErrReloadFailed is returned if it is not reloaded within the given timeout.
+func waitForReload(ctx context.Context, url string, manifest configManifest, timeout time.Duration) error {
+	data, err := yaml.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("failed to marshal alertmanager config manifest: %w", err)
+	}
+	hash := configHash(data)
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(reloadPollInterval)
+	defer ticker.Stop()
+
+	var lastErr error
+	for {
+		state, err := reloadState(ctx, url)
+		switch {
+		case err != nil:
+			// The request cut short by the timeout does not hide why earlier ones failed.
+			if ctx.Err() == nil {
+				lastErr = err
+			}
+		case !state.successful:
+			lastErr = errors.New("last reload of alertmanager failed")
+		case state.hash == hash:
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if lastErr != nil {
+				// The error is not wrapped, so that a failed reload is not mistaken for a transient error and retried.
+				return fmt.Errorf("%w within %v: %v", ErrReloadFailed, timeout, lastErr)
+			}
+			return fmt.Errorf("%w within %v", ErrReloadFailed, timeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+// configHash returns the hash alertmanager reports by the alertmanager_config_hash metric for the given configuration file:
+// the first 48 bits of its MD5 sum, as a little-endian integer, so that it fits the mantissa of a float64.
+func configHash(data []byte) float64 {
+	sum := md5.Sum(data)
+	b := make([]byte, 8)
+	copy(b, sum[:6])
+	return float64(binary.LittleEndian.Uint64(b))
+}
+
+// alertmanagerReloadState is the state of the last reload of the configuration of alertmanager, as reported by its metrics.
+type alertmanagerReloadState struct {
+	hash       float64
+	successful bool
+}
+
+// reloadState gets the hash of the configuration loaded by the alertmanager instance at the given URL, and whether its last
+// reload was successful, from the alertmanager_config_hash and alertmanager_config_last_reload_successful metrics.
+func reloadState(ctx context.Context, url string) (alertmanagerReloadState, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url+"/metrics", nil)
+	if err != nil {
+		return alertmanagerReloadState{}, fmt.Errorf("failed to create request: %w", err)
+	}
+	correlation.SetHeader(req)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return alertmanagerReloadState{}, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return alertmanagerReloadState{}, fmt.Errorf("alertmanager returned status code: %v", resp.StatusCode)
+	}
+
+	var state alertmanagerReloadState
+	var hashFound, successFound bool
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		name, value, ok := strings.Cut(scanner.Text(), " ")
+		if !ok || (name != "alertmanager_config_hash" && name != "alertmanager_config_last_reload_successful") {
+			continue
+		}
+		v, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return alertmanagerReloadState{}, fmt.Errorf("invalid value of metric %s: %w", name, err)
+		}
+		if name == "alertmanager_config_hash" {
+			state.hash, hashFound = v, true
+		} else {
+			state.successful, successFound = v == 1, true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return alertmanagerReloadState{}, fmt.Errorf("failed to read metrics: %w", err)
+	}
+	if !hashFound || !successFound {
+		return alertmanagerReloadState{}, errors.New("alertmanager does not report the state of its configuration reloads")
+	}
+	return state, nil
+}
@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package alertmanager
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+)
+
+// retryTransient calls fn until it succeeds, returns a permanent error, or the retries given by the configuration are exhausted.
+// The wait between attempts doubles from the initial backoff up to the maximum backoff, and is randomly shortened by up to half
+// so that concurrent executors do not retry in lockstep.
+func retryTransient(ctx context.Context, conf config.RetryConfig, fn func() error) error {
+	backoff := conf.InitialBackoff
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= conf.MaxRetries || !isTransient(err) {
+			return err
+		}
+
+		wait := backoff/2 + rand.N(backoff/2+1) //nolint:gosec // Jitter does not need a secure random source.
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait):
+		}
+
+		backoff *= 2
+		if conf.MaxBackoff > 0 && backoff > conf.MaxBackoff {
+			backoff = conf.MaxBackoff
+		}
+	}
+}
+
+// isTransient tells whether an error returned while getting or updating the alertmanager config secret is worth retrying,
+// such as conflicting updates, throttling, timeouts or network failures. Any other error, such as an invalid manifest or
+// a missing secret, is permanent.
+func isTransient(err error) bool {
+	var netErr net.Error
+	return apierrors.IsConflict(err) ||
+		apierrors.IsTooManyRequests(err) ||
+		apierrors.IsServerTimeout(err) ||
+		apierrors.IsTimeout(err) ||
+		apierrors.IsServiceUnavailable(err) ||
+		apierrors.IsInternalError(err) ||
+		errors.As(err, &netErr)
+}
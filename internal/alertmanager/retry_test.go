@@ -0,0 +1,158 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package alertmanager
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	ktesting "k8s.io/client-go/testing"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+var secretsResource = schema.GroupResource{Resource: "secrets"}
+
+func TestRetryTransient(t *testing.T) {
+	retryConf := config.RetryConfig{
+		MaxRetries:     3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     2 * time.Millisecond,
+	}
+
+	// failing returns a function failing with the given errors in order before succeeding, and counting its calls.
+	failing := func(calls *int, errs ...error) func() error {
+		return func() error {
+			*calls++
+			if *calls <= len(errs) {
+				return errs[*calls-1]
+			}
+			return nil
+		}
+	}
+
+	t.Run("RetriesTransientErrors", func(t *testing.T) {
+		var calls int
+		err := retryTransient(t.Context(), retryConf, failing(&calls,
+			apierrors.NewConflict(secretsResource, secretName, errors.New("mock error")),
+			apierrors.NewTooManyRequests("mock error", 1),
+		))
+		require.NoError(t, err)
+		require.Equal(t, 3, calls)
+	})
+
+	t.Run("FailsFastOnPermanentError", func(t *testing.T) {
+		var calls int
+		err := retryTransient(t.Context(), retryConf, failing(&calls, apierrors.NewForbidden(secretsResource, secretName, errors.New("mock error"))))
+		require.True(t, apierrors.IsForbidden(err))
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("GivesUpAfterMaxRetries", func(t *testing.T) {
+		var calls int
+		errs := make([]error, 5)
+		for i := range errs {
+			errs[i] = apierrors.NewServiceUnavailable("mock error")
+		}
+
+		err := retryTransient(t.Context(), retryConf, failing(&calls, errs...))
+		require.True(t, apierrors.IsServiceUnavailable(err))
+		require.Equal(t, 4, calls)
+	})
+
+	t.Run("NoRetriesByDefault", func(t *testing.T) {
+		var calls int
+		err := retryTransient(t.Context(), config.RetryConfig{}, failing(&calls, apierrors.NewServiceUnavailable("mock error")))
+		require.True(t, apierrors.IsServiceUnavailable(err))
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("StopsWhenContextIsDone", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+
+		var calls int
+		err := retryTransient(ctx, config.RetryConfig{MaxRetries: 3, InitialBackoff: time.Hour}, failing(&calls,
+			apierrors.NewServiceUnavailable("mock error"),
+		))
+		require.True(t, apierrors.IsServiceUnavailable(err))
+		require.Equal(t, 1, calls)
+	})
+}
+
+func TestReceiverConfig_UpdateReceiverConfigRetry(t *testing.T) {
+	data := []byte(`receivers:
+  - name: tenant-receiver-1
+route:
+  routes:
+    - receiver: tenant-receiver-1`)
+
+	newClient := func(updateErr error) (*testclient.Clientset, *int) {
+		fakeClient := testclient.NewClientset(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: testNamespace},
+			Data:       map[string][]byte{"custom.yaml": data},
+		})
+
+		var updates int
+		fakeClient.PrependReactor("update", "secrets", func(_ ktesting.Action) (handled bool, ret runtime.Object, err error) {
+			updates++
+			if updates == 1 {
+				return true, nil, updateErr
+			}
+			return false, nil, nil
+		})
+		return fakeClient, &updates
+	}
+
+	conf := config.AlertManagerConfig{
+		Namespace: testNamespace,
+		ApplyRetry: config.RetryConfig{
+			MaxRetries:     2,
+			InitialBackoff: time.Millisecond,
+		},
+	}
+
+	dbReceiver := models.DBReceiver{
+		Name:     "receiver",
+		TenantID: "tenant",
+		Version:  2,
+	}
+
+	t.Run("RetriesConflictingUpdate", func(t *testing.T) {
+		fakeClient, updates := newClient(apierrors.NewConflict(secretsResource, secretName, errors.New("mock error")))
+		am := &AlertManager{
+			client: fakeClient,
+			config: conf,
+		}
+
+		require.NoError(t, am.UpdateReceiverConfig(t.Context(), dbReceiver))
+		require.Equal(t, 2, *updates)
+
+		manifest, err := getConfigManifest(t.Context(), testNamespace, secretName, fakeClient)
+		require.NoError(t, err)
+		require.Equal(t, "tenant-receiver-2", manifest.Receivers[0].Name)
+	})
+
+	t.Run("FailsOnPermanentError", func(t *testing.T) {
+		fakeClient, updates := newClient(apierrors.NewForbidden(secretsResource, secretName, errors.New("mock error")))
+		am := &AlertManager{
+			client: fakeClient,
+			config: conf,
+		}
+
+		err := am.UpdateReceiverConfig(t.Context(), dbReceiver)
+		require.ErrorContains(t, err, "failed to set alertmanager config manifest")
+		require.Equal(t, 1, *updates)
+	})
+}
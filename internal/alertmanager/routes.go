@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package alertmanager
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/app"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+// checkRoutes verifies that the route of the given receiver, if it has one, neither shadows nor is shadowed by the route of
+// another receiver of its tenant. An error wrapping app.ErrRouteConflict is returned otherwise.
+func (m configManifest) checkRoutes(recv models.DBReceiver, tenancy config.TenancyConfig) error {
+	name := fmt.Sprintf("%s-%s-%d", recv.TenantID, recv.Name, recv.Version)
+	other := conflictingRoute(m.Route.Routes, name, receiverTenantMatcher(recv.TenantID, tenancy))
+	if other == "" {
+		return nil
+	}
+
+	// Routes are named by the tenant, name and version of their receiver, the routes of legacy receivers by their name only.
+	other = strings.TrimPrefix(other, recv.TenantID+"-")
+	if i := strings.LastIndex(other, "-"); i > 0 {
+		other = other[:i]
+	}
+	return fmt.Errorf("route of receiver %q is rejected: %w", recv.Name, &app.RouteConflictError{Receiver: other})
+}
+
+// conflictingRoute returns the receiver of the route of the tenant matched by the given tenant matcher which conflicts with
+// the route to the given receiver among the given sibling routes, or an empty string if there is none or the receiver has no
+// route. Alerts are only routed to the first sibling route matching them, unless it continues, so that a route matching all
+// the alerts another route matches leaves it none: it shadows the other route if it comes before it without continuing, and is
+// shadowed by it otherwise.
+func conflictingRoute(routes []subRoute, receiver, tenantMatcher string) string {
+	index := slices.IndexFunc(routes, func(r subRoute) bool { return r.Receiver == receiver })
+	if index < 0 {
+		return ""
+	}
+
+	route := routes[index]
+	for i, r := range routes {
+		if i == index || !slices.Contains(r.Matchers, tenantMatcher) {
+			continue
+		}
+		if (i < index && !r.Continue && coversRoute(r.Matchers, route.Matchers)) ||
+			(i > index && !route.Continue && coversRoute(route.Matchers, r.Matchers)) {
+			return r.Receiver
+		}
+	}
+	return ""
+}
+
+// coversRoute reports whether a route with the given matchers matches every alert a route with the other given matchers
+// matches, which is the case if each of its matchers covers one of the other matchers.
+func coversRoute(matchers, others []string) bool {
+	return !slices.ContainsFunc(matchers, func(m string) bool {
+		return !slices.ContainsFunc(others, func(o string) bool { return coversMatcher(m, o) })
+	})
+}
+
+// coversMatcher reports whether the given matcher matches every alert the other given matcher matches. Besides identical
+// matchers, regex matchers rendered as alternatives, e.g. severity=~"warning|critical", cover the matchers of the same label
+// with some of their alternatives, so that the severity matchers of receivers compare by the severities they match.
+func coversMatcher(matcher, other string) bool {
+	if matcher == other {
+		return true
+	}
+
+	m := matcherRegex.FindStringSubmatch(matcher)
+	o := matcherRegex.FindStringSubmatch(other)
+	if m == nil || o == nil || m[1] != o[1] || m[2] != "=~" || (o[2] != "=~" && o[2] != "=") {
+		return false
+	}
+	value, err := strconv.Unquote(m[3])
+	if err != nil {
+		return false
+	}
+	otherValue, err := strconv.Unquote(o[3])
+	if err != nil {
+		return false
+	}
+	alternatives := strings.Split(value, "|")
+	if o[2] == "=" {
+		return slices.Contains(alternatives, otherValue)
+	}
+	return !slices.ContainsFunc(strings.Split(otherValue, "|"), func(v string) bool {
+		return !slices.Contains(alternatives, v)
+	})
+}
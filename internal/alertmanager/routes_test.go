@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package alertmanager
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/app"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+func TestConfigManifest_CheckRoutes(t *testing.T) {
+	var tenancy config.TenancyConfig
+	recv := models.DBReceiver{Name: "receiver", TenantID: "tenant", Version: 2, MinSeverity: models.SeverityWarning}
+
+	// manifest returns a manifest with the routes of the given receivers, in order.
+	manifest := func(receivers ...models.DBReceiver) configManifest {
+		var m configManifest
+		for _, r := range receivers {
+			route, _ := receiverRoute(r, fmt.Sprintf("%s-%s-%d", r.TenantID, r.Name, r.Version), tenancy)
+			m.Route.Routes = append(m.Route.Routes, route)
+		}
+		return m
+	}
+
+	for name, tc := range map[string]struct {
+		manifest configManifest
+		conflict string
+	}{
+		"Receiver without other route": {
+			manifest: manifest(recv),
+		},
+		"Receiver without route": {
+			manifest: manifest(models.DBReceiver{Name: "other", TenantID: "tenant", Version: 1}),
+		},
+		"Route with the same matchers as a previous route": {
+			manifest: manifest(models.DBReceiver{Name: "other", TenantID: "tenant", Version: 1, MinSeverity: models.SeverityWarning}, recv),
+			conflict: "other",
+		},
+		"Route with the same matchers as a next route": {
+			manifest: manifest(recv, models.DBReceiver{Name: "other", TenantID: "tenant", Version: 1, MinSeverity: models.SeverityWarning}),
+			conflict: "other",
+		},
+		"Route shadowed by a previous route of all severities": {
+			manifest: manifest(models.DBReceiver{Name: "other", TenantID: "tenant", Version: 1}, recv),
+			conflict: "other",
+		},
+		"Route shadowing a next route of critical alerts": {
+			manifest: manifest(recv, models.DBReceiver{Name: "other", TenantID: "tenant", Version: 1, MinSeverity: models.SeverityCritical}),
+			conflict: "other",
+		},
+		"Route after a previous route of critical alerts": {
+			manifest: manifest(models.DBReceiver{Name: "other", TenantID: "tenant", Version: 1, MinSeverity: models.SeverityCritical}, recv),
+		},
+		"Route after a previous route continuing": {
+			manifest: configManifest{Route: route{Routes: append([]subRoute{{
+				Receiver: "tenant-other-1",
+				Matchers: []string{alertCategoryMatcher, `projectId=~"tenant"`},
+				Continue: true,
+			}}, manifest(recv).Route.Routes...)}},
+		},
+		"Route with the same matchers as the route of another tenant": {
+			manifest: manifest(models.DBReceiver{Name: "receiver", TenantID: "other", Version: 1, MinSeverity: models.SeverityWarning}, recv),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := tc.manifest.checkRoutes(recv, tenancy)
+			if tc.conflict == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, app.ErrRouteConflict)
+			var conflict *app.RouteConflictError
+			require.ErrorAs(t, err, &conflict)
+			require.Equal(t, tc.conflict, conflict.Receiver)
+		})
+	}
+}
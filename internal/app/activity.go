@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	db "github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+)
+
+const (
+	// activityRecordInterval is the minimum interval between two records of the API activity of the same tenant.
+	activityRecordInterval = time.Minute
+)
+
+// activityRecorder records the API activity of the tenants given by the ActiveProjectID header of requests, so that the
+// configuration of inactive tenants can be archived. The activity of a tenant is recorded at most once per activityRecordInterval.
+type activityRecorder struct {
+	tenants db.TenantManager
+
+	mu       sync.Mutex
+	recorded map[api.TenantID]time.Time
+}
+
+func newActivityRecorder(tenants db.TenantManager) *activityRecorder {
+	return &activityRecorder{
+		tenants:  tenants,
+		recorded: make(map[api.TenantID]time.Time),
+	}
+}
+
+func (a *activityRecorder) record(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tenantID := c.Request().Header.Get(activeProjectIDHeader)
+		if !skipAuth(c) && len(strings.TrimSpace(tenantID)) != 0 && a.due(tenantID) {
+			if err := a.tenants.SetTenantActivity(c.Request().Context(), tenantID); err != nil {
+				logError(c, fmt.Sprintf("Failed to record activity of tenant %q", tenantID), err)
+				a.forget(tenantID)
+			}
+		}
+		return next(c)
+	}
+}
+
+// due tells whether the activity of the given tenant has to be recorded, marking it as recorded if so.
+func (a *activityRecorder) due(tenantID api.TenantID) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := clock.TimeNowFn()
+	if last, ok := a.recorded[tenantID]; ok && now.Sub(last) < activityRecordInterval {
+		return false
+	}
+	a.recorded[tenantID] = now
+	return true
+}
+
+// forget clears the last record of the activity of the given tenant, so that it is recorded again on the next request.
+func (a *activityRecorder) forget(tenantID api.TenantID) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	delete(a.recorded, tenantID)
+}
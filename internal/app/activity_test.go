@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+)
+
+type TenantMock struct {
+	mock.Mock
+}
+
+func (m *TenantMock) SetTenantActivity(ctx context.Context, tenantID api.TenantID) error {
+	args := m.Called(ctx, tenantID)
+	return args.Error(0)
+}
+
+func (m *TenantMock) GetInactiveTenants(ctx context.Context, dur time.Duration) ([]api.TenantID, error) {
+	args := m.Called(ctx, dur)
+	return args.Get(0).([]api.TenantID), args.Error(1)
+}
+
+func (m *TenantMock) ArchiveTenant(ctx context.Context, tenantID api.TenantID) error {
+	args := m.Called(ctx, tenantID)
+	return args.Error(0)
+}
+
+func (m *TenantMock) UnarchiveTenant(ctx context.Context, tenantID api.TenantID) error {
+	args := m.Called(ctx, tenantID)
+	return args.Error(0)
+}
+
+func TestActivityRecorder(t *testing.T) {
+	clock.SetFakeClock()
+	defer clock.UnsetFakeClock()
+	clock.FakeClock.Set(time.Now())
+
+	tenantMock := new(TenantMock)
+	tenantMock.On("SetTenantActivity", mock.Anything, "tenant").Return(nil)
+
+	e := echo.New()
+	handler := newActivityRecorder(tenantMock).record(func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	request := func(tenantID string) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/alerts", nil)
+		if tenantID != "" {
+			req.Header.Set("ActiveProjectID", tenantID)
+		}
+		rec := httptest.NewRecorder()
+		require.NoError(t, handler(e.NewContext(req, rec)))
+		require.Equal(t, http.StatusOK, rec.Code)
+	}
+
+	// Requests without a tenant are not recorded.
+	request("")
+	tenantMock.AssertNotCalled(t, "SetTenantActivity", mock.Anything, mock.Anything)
+
+	// Activity is recorded once per interval.
+	request("tenant")
+	request("tenant")
+	tenantMock.AssertNumberOfCalls(t, "SetTenantActivity", 1)
+
+	clock.FakeClock.Add(activityRecordInterval)
+	request("tenant")
+	tenantMock.AssertNumberOfCalls(t, "SetTenantActivity", 2)
+}
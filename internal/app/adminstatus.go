@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+// adminStatusEndpoint is the endpoint telling the administrative state of the service. It is under /debug, so that it is only
+// granted to administrators.
+const adminStatusEndpoint = "/debug/status"
+
+// adminStatus is the administrative state of the service, as served by the admin status endpoint.
+type adminStatus struct {
+	TaskKinds []taskKindStatus `json:"taskKinds"`
+}
+
+// taskKindStatus tells whether the tasks of a kind are applied by the task executor, or paused by its configuration.
+type taskKindStatus struct {
+	Kind   models.TaskType `json:"kind"`
+	Paused bool            `json:"paused"`
+}
+
+// adminStatusViewer serves the administrative state of the service, so that it can be confirmed which kinds of tasks are
+// paused, such as during an upgrade of Mimir.
+type adminStatusViewer struct {
+	executorConfig config.TaskExecutorConfig
+}
+
+func newAdminStatusViewer(executorConfig config.TaskExecutorConfig) *adminStatusViewer {
+	return &adminStatusViewer{executorConfig: executorConfig}
+}
+
+// register registers the admin status endpoint.
+func (v *adminStatusViewer) register(e *echo.Echo) {
+	e.GET(adminStatusEndpoint, v.get)
+}
+
+// get handles the request for the administrative state of the service.
+func (v *adminStatusViewer) get(ctx echo.Context) error {
+	status := adminStatus{TaskKinds: []taskKindStatus{}}
+	for _, kind := range []models.TaskType{models.TypeAlertDefinition, models.TypeReceiver, models.TypeNotification} {
+		status.TaskKinds = append(status.TaskKinds, taskKindStatus{
+			Kind:   kind,
+			Paused: v.executorConfig.KindPaused(string(kind)),
+		})
+	}
+	return ctx.JSON(http.StatusOK, status)
+}
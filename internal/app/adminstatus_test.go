@@ -0,0 +1,33 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+func TestAdminStatusViewer(t *testing.T) {
+	e := echo.New()
+	newAdminStatusViewer(config.TaskExecutorConfig{PausedKinds: []string{"AlertDefinition", "Notification"}}).register(e)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/status", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var status adminStatus
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+	require.Equal(t, []taskKindStatus{
+		{Kind: models.TypeAlertDefinition, Paused: true},
+		{Kind: models.TypeReceiver, Paused: false},
+		{Kind: models.TypeNotification, Paused: true},
+	}, status.TaskKinds)
+}
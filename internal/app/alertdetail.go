@@ -0,0 +1,319 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/correlation"
+)
+
+const (
+	errHTTPAlertNotFound            = "alert not found"
+	errHTTPFailedToGetAlertInstance = "failed to get alert instance"
+	errHTTPFailedToGetAlertSilences = "failed to get alert silences"
+
+	// alertFiringLookback is how far back the firing history of an alert goes.
+	alertFiringLookback = 7 * 24 * time.Hour
+	// alertFiringStep is the resolution of the firing history of an alert. Samples of the ALERTS series of the alert further
+	// apart than it belong to distinct firing periods.
+	alertFiringStep = time.Minute
+)
+
+// silencedAlert holds the fields of an alert of the alertmanager v2 API telling the silences silencing it, along with its labels
+// as they are before redaction.
+type silencedAlert struct {
+	Fingerprint string            `json:"fingerprint"`
+	Labels      map[string]string `json:"labels"`
+	Status      struct {
+		SilencedBy []string `json:"silencedBy"`
+	} `json:"status"`
+}
+
+// alertmanagerSilence is a silence of the alertmanager v2 API.
+type alertmanagerSilence struct {
+	silenceMatchers
+	ID        string    `json:"id"`
+	CreatedBy string    `json:"createdBy"`
+	Comment   string    `json:"comment"`
+	StartsAt  time.Time `json:"startsAt"`
+	EndsAt    time.Time `json:"endsAt"`
+}
+
+// GetAlert gets the active alert of a tenant with the given fingerprint, along with the latest version of the alert definition
+// raising it, the silences silencing it, the comments attached to it and the periods it fired over the lookback. The firing
+// history is left out if Mimir is not configured or cannot be queried, since the alert is still worth triaging without it.
+func (w *ServerInterfaceHandler) GetAlert(ctx echo.Context, tenantID api.TenantID, fingerprint api.AlertFingerprint) error {
+	alert, httpErr := w.getAlertInstance(ctx, tenantID, fingerprint)
+	if httpErr != nil {
+		return ctx.JSON(httpErr.Code, httpErr)
+	}
+	detail := api.AlertDetail{Alert: *alert}
+
+	if alert.AlertDefinitionId != nil {
+		ad, err := w.definitions.GetLatestAlertDefinition(ctx.Request().Context(), tenantID, *alert.AlertDefinitionId)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			logError(ctx, fmt.Sprintf("Failed to retrieve alert definition of alert %q", fingerprint), err)
+			return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+				Code:      http.StatusInternalServerError,
+				Message:   errHTTPFailedToGetAlertDefinition,
+				ErrorCode: api.ErrorCodeInternalError,
+			})
+		} else if err == nil {
+			def := alertDefinitionToAPI(ad)
+			detail.Definition = &def
+		}
+	}
+
+	labels, silences, httpErr := w.getAlertSilences(ctx, tenantID, fingerprint)
+	if httpErr != nil {
+		return ctx.JSON(httpErr.Code, httpErr)
+	}
+	detail.Silences = silences
+	detail.Acknowledged = len(silences) > 0
+
+	comments, err := w.alertComments.GetAlertComments(ctx.Request().Context(), tenantID, fingerprint)
+	if err != nil {
+		logError(ctx, fmt.Sprintf("Failed to get comments of alert %q", fingerprint), err)
+		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToGetAlertComments,
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+	detail.Comments = make([]api.AlertComment, 0, len(comments))
+	for _, comment := range comments {
+		detail.Comments = append(detail.Comments, alertComment(comment))
+	}
+
+	if w.configuration.Mimir.QueryURL != "" {
+		firings, err := w.queryAlertFirings(ctx.Request().Context(), tenantID, labels)
+		if err != nil {
+			logError(ctx, fmt.Sprintf("Failed to query firing history of alert %q", fingerprint), err)
+		} else {
+			detail.Firings = &firings
+		}
+	}
+	return ctx.JSON(http.StatusOK, detail)
+}
+
+// getAlertInstance gets the active alert of the given tenant with the given fingerprint from alertmanager.
+func (w *ServerInterfaceHandler) getAlertInstance(ctx echo.Context, tenantID api.TenantID, fingerprint api.AlertFingerprint) (*api.Alert, *api.HttpError) {
+	alerts, httpErr := w.getAlerts(ctx, tenantID, api.GetProjectAlertsParams{})
+	if httpErr != nil {
+		httpErr.Message = errHTTPFailedToGetAlertInstance
+		return nil, httpErr
+	}
+
+	if alerts.Alerts != nil {
+		for _, alert := range *alerts.Alerts {
+			if alert.Fingerprint != nil && *alert.Fingerprint == fingerprint {
+				return &alert, nil
+			}
+		}
+	}
+	return nil, alertNotFound(ctx, tenantID, fingerprint)
+}
+
+// getAlertSilences gets the labels of the active alert of the given tenant with the given fingerprint, as they are before
+// redaction, and the silences of the tenant silencing it.
+func (w *ServerInterfaceHandler) getAlertSilences(ctx echo.Context, tenantID api.TenantID, fingerprint api.AlertFingerprint) (map[string]string, []api.AlertSilence, *api.HttpError) {
+	unavailable := func(err error) *api.HttpError {
+		logError(ctx, fmt.Sprintf("Failed to get silences of alert %q", fingerprint), err)
+		return &api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToGetAlertSilences,
+			ErrorCode: api.ErrorCodeAlertmanagerUnavailable,
+		}
+	}
+
+	compat := newAlertmanagerCompat(w)
+	status, body, err := compat.forward(ctx, tenantID, http.MethodGet, "/alerts", url.Values{"filter": {TenantFilter(w.tenantLabel(), tenantID)}}, nil)
+	if err == nil && status != http.StatusOK {
+		err = fmt.Errorf("alertmanager returned HTTP status code: %v", status)
+	}
+	if err != nil {
+		return nil, nil, unavailable(err)
+	}
+
+	var alerts []silencedAlert
+	if err := json.Unmarshal(body, &alerts); err != nil {
+		return nil, nil, unavailable(fmt.Errorf("failed to unmarshal alerts: %w", err))
+	}
+	idx := slices.IndexFunc(alerts, func(a silencedAlert) bool { return a.Fingerprint == fingerprint })
+	if idx < 0 {
+		// The alert was resolved since it was listed.
+		return nil, nil, alertNotFound(ctx, tenantID, fingerprint)
+	}
+	alert := alerts[idx]
+
+	silences := make([]api.AlertSilence, 0, len(alert.Status.SilencedBy))
+	if len(alert.Status.SilencedBy) == 0 {
+		return alert.Labels, silences, nil
+	}
+
+	status, body, err = compat.forward(ctx, tenantID, http.MethodGet, "/silences", nil, nil)
+	if err == nil && status != http.StatusOK {
+		err = fmt.Errorf("alertmanager returned HTTP status code: %v", status)
+	}
+	if err != nil {
+		return nil, nil, unavailable(err)
+	}
+
+	var amSilences []alertmanagerSilence
+	if err := json.Unmarshal(body, &amSilences); err != nil {
+		return nil, nil, unavailable(fmt.Errorf("failed to unmarshal silences: %w", err))
+	}
+	for _, s := range amSilences {
+		// Silences of other tenants are left out, even though their matchers may match the alert.
+		if !slices.Contains(alert.Status.SilencedBy, s.ID) || !s.ownedBy(w.tenantLabel(), tenantID) {
+			continue
+		}
+		silences = append(silences, api.AlertSilence{
+			Id:        s.ID,
+			CreatedBy: s.CreatedBy,
+			Comment:   s.Comment,
+			StartsAt:  s.StartsAt.UTC(),
+			EndsAt:    s.EndsAt.UTC(),
+		})
+	}
+	return alert.Labels, silences, nil
+}
+
+// queryAlertFirings queries Mimir for the periods the alert with the given labels fired over the lookback, from the ALERTS series
+// the ruler records for it. The end of the last period is left out if the alert was still firing at the end of the query.
+func (w *ServerInterfaceHandler) queryAlertFirings(ctx context.Context, tenantID api.TenantID, labels map[string]string) ([]api.AlertFiring, error) {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	matchers := []string{`alertstate="firing"`}
+	for _, name := range names {
+		matchers = append(matchers, name+"="+strconv.Quote(labels[name]))
+	}
+	query := "ALERTS{" + strings.Join(matchers, ",") + "}"
+
+	end := clock.TimeNowFn().UTC().Truncate(alertFiringStep)
+	start := end.Add(-alertFiringLookback)
+	params := url.Values{
+		"query": {query},
+		"start": {strconv.FormatInt(start.Unix(), 10)},
+		"end":   {strconv.FormatInt(end.Unix(), 10)},
+		"step":  {strconv.FormatInt(int64(alertFiringStep/time.Second), 10)},
+	}
+
+	body, err := w.queryMimir(ctx, tenantID, "query_range", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Status string `json:"status"`
+		Data   struct {
+			Result []struct {
+				Values [][2]any `json:"values"`
+			} `json:"result"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal received data: %w", err)
+	}
+	if result.Status != "success" {
+		return nil, fmt.Errorf("query of firing history failed with status %q", result.Status)
+	}
+
+	var samples []time.Time
+	for _, series := range result.Data.Result {
+		for _, value := range series.Values {
+			ts, ok := value[0].(float64)
+			if !ok {
+				return nil, fmt.Errorf("unexpected sample timestamp %v", value[0])
+			}
+			samples = append(samples, time.UnixMilli(int64(ts*1000)).UTC())
+		}
+	}
+	return alertFirings(samples, end), nil
+}
+
+// queryMimir sends the given parameters to the given endpoint of the Prometheus query API of Mimir, scoped to the series of the
+// given tenant, and returns the body of the response.
+func (w *ServerInterfaceHandler) queryMimir(ctx context.Context, tenantID api.TenantID, endpoint string, params url.Values) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		fmt.Sprintf("%v/prometheus/api/v1/%v?%v", w.configuration.Mimir.QueryURL, endpoint, params.Encode()), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	// For backward compatibility, the series of the default tenant are stored under a distinct Mimir tenant.
+	mimirTenantID := tenantID
+	if mimirTenantID == DefaultTenantID {
+		mimirTenantID = "edgenode-system"
+	}
+	req.Header.Set("X-Scope-OrgID", mimirTenantID)
+	correlation.SetHeader(req)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("mimir returned HTTP status code: %v", resp.StatusCode)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	return body, nil
+}
+
+// alertFirings groups the given times the alert was sampled firing at into firing periods, from the oldest one. Samples further
+// apart than the step of the firing history belong to distinct periods. The end of the last period is left out if it reaches the
+// given end of the history, the alert still firing.
+func alertFirings(samples []time.Time, end time.Time) []api.AlertFiring {
+	slices.SortFunc(samples, func(a, b time.Time) int { return a.Compare(b) })
+	samples = slices.Compact(samples)
+
+	firings := make([]api.AlertFiring, 0)
+	for i, sample := range samples {
+		if i == 0 || sample.Sub(samples[i-1]) > alertFiringStep {
+			firings = append(firings, api.AlertFiring{StartsAt: sample})
+		}
+		if i == len(samples)-1 || samples[i+1].Sub(sample) > alertFiringStep {
+			if end.Sub(sample) >= alertFiringStep {
+				endsAt := sample
+				firings[len(firings)-1].EndsAt = &endsAt
+			}
+		}
+	}
+	return firings
+}
+
+// alertNotFound logs that the alert of the given tenant with the given fingerprint is not active, and returns the error for it.
+func alertNotFound(ctx echo.Context, tenantID api.TenantID, fingerprint api.AlertFingerprint) *api.HttpError {
+	logWarn(ctx, fmt.Sprintf("Alert %q of tenant %q not found", fingerprint, tenantID))
+	return &api.HttpError{
+		Code:      http.StatusNotFound,
+		Message:   errHTTPAlertNotFound,
+		ErrorCode: api.ErrorCodeAlertNotFound,
+	}
+}
@@ -0,0 +1,191 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/testutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+func TestGetAlert(t *testing.T) {
+	conn, err := gorm.Open(sqlite.Open(":memory:"))
+	require.NoError(t, err)
+	require.NoError(t, conn.AutoMigrate(&models.AlertComment{}))
+
+	clock.SetFakeClock()
+	defer clock.UnsetFakeClock()
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	clock.FakeClock.Set(now)
+
+	tenantID := "edgenode"
+	fingerprint := "0b4c5e3a1f2d6789"
+	definitionID := uuid.New()
+	silenceStart := now.Add(-time.Hour)
+	silenceEnd := now.Add(time.Hour)
+
+	require.NoError(t, conn.Create(&models.AlertComment{
+		TenantID:     tenantID,
+		Fingerprint:  fingerprint,
+		Author:       "lp-admin-user",
+		Content:      "Looking into it",
+		CreationDate: now,
+	}).Error)
+
+	alertManager := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/alerts":
+			require.Contains(t, r.URL.Query()["filter"], `projectId="`+tenantID+`"`)
+			fmt.Fprintf(w, `[{"fingerprint":%q,"labels":{"alertname":"HostCPUUsageHigh","alert_category":"performance",`+
+				`"projectId":%q,"host_uuid":"host-1"},"annotations":{"am_uuid":%q,"description":"CPU usage is high"},`+
+				`"status":{"state":"suppressed","silencedBy":["owned","foreign"]}}]`, fingerprint, tenantID, definitionID)
+		case "/api/v2/silences":
+			fmt.Fprintf(w, `[`+
+				`{"id":"owned","matchers":[{"name":"projectId","value":%[1]q,"isRegex":false}],"createdBy":"admin",`+
+				`"comment":"Maintenance of host-1","startsAt":%[2]q,"endsAt":%[3]q,"status":{"state":"active"}},`+
+				`{"id":"foreign","matchers":[{"name":"alertname","value":"HostCPUUsageHigh","isRegex":false}],"createdBy":"other",`+
+				`"comment":"","startsAt":%[2]q,"endsAt":%[3]q,"status":{"state":"active"}},`+
+				`{"id":"unrelated","matchers":[{"name":"projectId","value":%[1]q,"isRegex":false}],"createdBy":"admin",`+
+				`"comment":"","startsAt":%[2]q,"endsAt":%[3]q,"status":{"state":"active"}}]`,
+				tenantID, silenceStart.Format(time.RFC3339), silenceEnd.Format(time.RFC3339))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer alertManager.Close()
+
+	firstFiring := now.Add(-48 * time.Hour)
+	mimir := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/prometheus/api/v1/query_range", r.URL.Path)
+		require.Equal(t, "edgenode-system", r.Header.Get("X-Scope-OrgID"))
+		require.Equal(t, `ALERTS{alertstate="firing",alert_category="performance",alertname="HostCPUUsageHigh",`+
+			`host_uuid="host-1",projectId="edgenode"}`, r.URL.Query().Get("query"))
+		require.Equal(t, "60", r.URL.Query().Get("step"))
+
+		var values []string
+		for _, ts := range []time.Time{
+			firstFiring, firstFiring.Add(time.Minute), firstFiring.Add(2 * time.Minute),
+			now.Add(-time.Minute), now,
+		} {
+			values = append(values, fmt.Sprintf(`[%d,"1"]`, ts.Unix()))
+		}
+		fmt.Fprintf(w, `{"status":"success","data":{"resultType":"matrix","result":[{"metric":{},"values":[%s]}]}}`,
+			strings.Join(values, ","))
+	}))
+	defer mimir.Close()
+
+	configfile := conf
+	configfile.AlertManager.URL = alertManager.URL
+	configfile.Mimir.QueryURL = mimir.URL
+
+	get := func(t *testing.T, handler *ServerInterfaceHandler) *testutil.CompletedRequest {
+		server := echo.New()
+		api.RegisterHandlers(server, handler)
+		return testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get("/api/v1/alerts/"+fingerprint).
+			GoWithHTTPHandler(t, server)
+	}
+
+	t.Run("Alert with full context", func(t *testing.T) {
+		mDefinition := &DefinitionMock{}
+		mDefinition.On("GetLatestAlertDefinition", mock.Anything, tenantID, definitionID).Return(&models.DBAlertDefinition{
+			ID:      definitionID,
+			Name:    "HostCPUUsageHigh",
+			State:   models.DefinitionApplied,
+			Version: 2,
+		}, nil).Once()
+
+		result := get(t, &ServerInterfaceHandler{
+			configuration: configfile,
+			definitions:   mDefinition,
+			alertComments: &database.DBService{DB: conn},
+		})
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+
+		var detail api.AlertDetail
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &detail))
+		require.Equal(t, fingerprint, *detail.Alert.Fingerprint)
+		require.Equal(t, definitionID, *detail.Alert.AlertDefinitionId)
+		require.Equal(t, "CPU usage is high", (*detail.Alert.Annotations)["description"])
+
+		require.NotNil(t, detail.Definition)
+		require.Equal(t, definitionID, *detail.Definition.Id)
+		require.Equal(t, 2, *detail.Definition.Version)
+
+		require.True(t, detail.Acknowledged)
+		require.Equal(t, []api.AlertSilence{{
+			Id:        "owned",
+			CreatedBy: "admin",
+			Comment:   "Maintenance of host-1",
+			StartsAt:  silenceStart,
+			EndsAt:    silenceEnd,
+		}}, detail.Silences)
+
+		require.Len(t, detail.Comments, 1)
+		require.Equal(t, "Looking into it", detail.Comments[0].Content)
+
+		firstEnd := firstFiring.Add(2 * time.Minute)
+		require.NotNil(t, detail.Firings)
+		require.Equal(t, []api.AlertFiring{
+			{StartsAt: firstFiring, EndsAt: &firstEnd},
+			{StartsAt: now.Add(-time.Minute)},
+		}, *detail.Firings)
+		mDefinition.AssertExpectations(t)
+	})
+
+	t.Run("Firing history unavailable - history is left out", func(t *testing.T) {
+		mDefinition := &DefinitionMock{}
+		mDefinition.On("GetLatestAlertDefinition", mock.Anything, tenantID, definitionID).
+			Return(nil, fmt.Errorf("mock error: %w", gorm.ErrRecordNotFound)).Once()
+
+		configfile := configfile
+		configfile.Mimir.QueryURL = "http://127.0.0.1:0"
+		result := get(t, &ServerInterfaceHandler{
+			configuration: configfile,
+			definitions:   mDefinition,
+			alertComments: &database.DBService{DB: conn},
+		})
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+
+		var detail api.AlertDetail
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &detail))
+		require.Nil(t, detail.Definition)
+		require.Nil(t, detail.Firings)
+		require.True(t, detail.Acknowledged)
+		mDefinition.AssertExpectations(t)
+	})
+
+	t.Run("Failed to get alert definition - code should be 500", func(t *testing.T) {
+		mDefinition := &DefinitionMock{}
+		mDefinition.On("GetLatestAlertDefinition", mock.Anything, tenantID, definitionID).Return(nil, fmt.Errorf("mock error")).Once()
+
+		result := get(t, &ServerInterfaceHandler{
+			configuration: configfile,
+			definitions:   mDefinition,
+			alertComments: &database.DBService{DB: conn},
+		})
+
+		var httpErr api.HttpError
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &httpErr))
+		require.Equal(t, http.StatusInternalServerError, httpErr.Code)
+		require.Equal(t, errHTTPFailedToGetAlertDefinition, httpErr.Message)
+		mDefinition.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+)
+
+const errHTTPFailedToGetAllowedRecipients = "failed to get allowed email recipients"
+
+// cachedUserList caches the users of the identity provider the allowed email recipients are derived from, as they are the
+// same for all tenants and are got on every read and update of receivers. Failures to get them are not cached.
+type cachedUserList struct {
+	m2m M2MConnection
+	ttl time.Duration
+
+	mu      sync.Mutex
+	users   []user
+	expires time.Time
+}
+
+// newCachedUserList returns the connection to the identity provider caching the users of the given one for the given period,
+// or the given one if the period is not positive.
+func newCachedUserList(m2m M2MConnection, ttl time.Duration) M2MConnection {
+	if ttl <= 0 {
+		return m2m
+	}
+	return &cachedUserList{m2m: m2m, ttl: ttl}
+}
+
+// GetUserList returns the users of the identity provider, caching them for the period of the cache. The returned list must
+// not be modified.
+func (c *cachedUserList) GetUserList(ctx echo.Context) ([]user, error) {
+	now := clock.TimeNowFn()
+
+	c.mu.Lock()
+	users, expires := c.users, c.expires
+	c.mu.Unlock()
+	if users != nil && now.Before(expires) {
+		return users, nil
+	}
+
+	users, err := c.m2m.GetUserList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if users == nil {
+		users = []user{}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.users, c.expires = users, now.Add(c.ttl)
+	return users, nil
+}
+
+// GetAlertReceiverAllowedRecipients returns the email recipients the given receiver can be set to notify, sorted so that they
+// are paginated consistently. The receiver of the tenant must exist.
+func (w *ServerInterfaceHandler) GetAlertReceiverAllowedRecipients(
+	ctx echo.Context, tenantID api.TenantID, id api.ReceiverId, params api.GetProjectAlertReceiverAllowedRecipientsParams,
+) error {
+	opts, err := parseListOptions(params.Limit, params.Offset, nil, nil)
+	if err != nil {
+		logError(ctx, "Invalid pagination parameters", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPBadRequest,
+			ErrorCode: api.ErrorCodeInvalidParameter,
+		})
+	}
+
+	if _, err := w.receivers.GetLatestReceiverWithEmailConfig(ctx.Request().Context(), tenantID, id); errors.Is(err, gorm.ErrRecordNotFound) {
+		logError(ctx, fmt.Sprintf("Alert receiver not found: %q", id), err)
+		return ctx.JSON(http.StatusNotFound, api.HttpError{
+			Code:      http.StatusNotFound,
+			Message:   errHTTPAlertReceiverNotFound,
+			ErrorCode: api.ErrorCodeReceiverNotFound,
+		})
+	} else if err != nil {
+		logError(ctx, fmt.Sprintf("Failed to get alert receiver with UUID: %q", id), err)
+		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToGetAlertReceiver,
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+
+	users, err := w.m2m.GetUserList(ctx)
+	if err != nil {
+		logError(ctx, "Failed to get allowed email recipients", err)
+		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToGetAllowedRecipients,
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+
+	allowed := convertEmailFormat(users)
+	slices.Sort(allowed)
+
+	total := len(allowed)
+	recipients := allowed[min(opts.Offset, total):]
+	if opts.Limit > 0 && opts.Limit < len(recipients) {
+		recipients = recipients[:opts.Limit]
+	}
+	if recipients == nil {
+		recipients = api.EmailRecipientList{}
+	}
+	return ctx.JSON(http.StatusOK, api.AllowedRecipientList{Recipients: recipients, TotalCount: total})
+}
@@ -0,0 +1,156 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/testutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+func TestGetAlertReceiverAllowedRecipients(t *testing.T) {
+	tenantID := "edgenode"
+	id := uuid.New()
+	uri := fmt.Sprintf("/api/v1/alerts/receivers/%v/allowed-recipients", id)
+	users := []user{
+		{FirstName: "Zoe", LastName: "Doe", Email: "zoe@example.com"},
+		{FirstName: "Ann", LastName: "Doe", Email: "ann@example.com"},
+		{FirstName: "Bob", LastName: "Doe", Email: "bob@example.com"},
+		{Username: "service-account"},
+	}
+
+	newServer := func(receivers *ReceiverMock, m2m M2MConnection) *echo.Echo {
+		server := echo.New()
+		api.RegisterHandlers(server, &ServerInterfaceHandler{receivers: receivers, m2m: m2m})
+		return server
+	}
+
+	for name, tc := range map[string]struct {
+		query      string
+		recipients api.EmailRecipientList
+	}{
+		"All allowed recipients sorted": {
+			recipients: api.EmailRecipientList{"Ann Doe <ann@example.com>", "Bob Doe <bob@example.com>", "Zoe Doe <zoe@example.com>"},
+		},
+		"Paginated allowed recipients": {
+			query:      "?offset=1&limit=1",
+			recipients: api.EmailRecipientList{"Bob Doe <bob@example.com>"},
+		},
+		"Offset past the allowed recipients": {
+			query:      "?offset=5",
+			recipients: api.EmailRecipientList{},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			mReceiver := &ReceiverMock{}
+			mReceiver.On("GetLatestReceiverWithEmailConfig", mock.Anything, tenantID, id).Return(&models.DBReceiver{UUID: id}, nil).Once()
+			mM2M := &M2MAuthenticatorMock{}
+			mM2M.On("GetUserList", mock.Anything).Return(users, nil).Once()
+
+			result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get(uri+tc.query).
+				GoWithHTTPHandler(t, newServer(mReceiver, mM2M))
+			require.Equal(t, http.StatusOK, result.Recorder.Code)
+
+			var list api.AllowedRecipientList
+			require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &list))
+			require.Equal(t, api.AllowedRecipientList{Recipients: tc.recipients, TotalCount: 3}, list)
+			mReceiver.AssertExpectations(t)
+			mM2M.AssertExpectations(t)
+		})
+	}
+
+	t.Run("Invalid limit - code should be 400", func(t *testing.T) {
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get(uri+"?limit=0").
+			GoWithHTTPHandler(t, newServer(&ReceiverMock{}, &M2MAuthenticatorMock{}))
+		require.Equal(t, http.StatusBadRequest, result.Recorder.Code)
+	})
+
+	t.Run("Receiver not found - code should be 404", func(t *testing.T) {
+		mReceiver := &ReceiverMock{}
+		mReceiver.On("GetLatestReceiverWithEmailConfig", mock.Anything, tenantID, id).Return(nil, fmt.Errorf("mock error: %w", gorm.ErrRecordNotFound)).Once()
+		mM2M := &M2MAuthenticatorMock{}
+
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get(uri).GoWithHTTPHandler(t, newServer(mReceiver, mM2M))
+
+		var httpErr api.HttpError
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &httpErr))
+		require.Equal(t, http.StatusNotFound, httpErr.Code)
+		require.Equal(t, api.ErrorCodeReceiverNotFound, httpErr.ErrorCode)
+		mM2M.AssertNotCalled(t, "GetUserList", mock.Anything)
+	})
+
+	t.Run("Failed to get users - code should be 500", func(t *testing.T) {
+		mReceiver := &ReceiverMock{}
+		mReceiver.On("GetLatestReceiverWithEmailConfig", mock.Anything, tenantID, id).Return(&models.DBReceiver{UUID: id}, nil).Once()
+		mM2M := &M2MAuthenticatorMock{}
+		mM2M.On("GetUserList", mock.Anything).Return(nil, errors.New("mock error")).Once()
+
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get(uri).GoWithHTTPHandler(t, newServer(mReceiver, mM2M))
+
+		var httpErr api.HttpError
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &httpErr))
+		require.Equal(t, http.StatusInternalServerError, httpErr.Code)
+		require.Equal(t, errHTTPFailedToGetAllowedRecipients, httpErr.Message)
+	})
+}
+
+func TestCachedUserList(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	clock.TimeNowFn = func() time.Time { return now }
+	defer func() { clock.TimeNowFn = time.Now }()
+
+	ctx := echo.New().NewContext(nil, nil)
+	users := []user{{FirstName: "Ann", LastName: "Doe", Email: "ann@example.com"}}
+
+	t.Run("Users are cached until they expire", func(t *testing.T) {
+		mM2M := &M2MAuthenticatorMock{}
+		mM2M.On("GetUserList", mock.Anything).Return(users, nil).Twice()
+		cache := newCachedUserList(mM2M, time.Minute)
+
+		for range 2 {
+			got, err := cache.GetUserList(ctx)
+			require.NoError(t, err)
+			require.Equal(t, users, got)
+		}
+		mM2M.AssertNumberOfCalls(t, "GetUserList", 1)
+
+		now = now.Add(time.Minute)
+		_, err := cache.GetUserList(ctx)
+		require.NoError(t, err)
+		mM2M.AssertNumberOfCalls(t, "GetUserList", 2)
+	})
+
+	t.Run("Failures are not cached", func(t *testing.T) {
+		mM2M := &M2MAuthenticatorMock{}
+		mM2M.On("GetUserList", mock.Anything).Return(nil, errors.New("mock error")).Once()
+		mM2M.On("GetUserList", mock.Anything).Return(users, nil).Once()
+		cache := newCachedUserList(mM2M, time.Minute)
+
+		_, err := cache.GetUserList(ctx)
+		require.Error(t, err)
+		got, err := cache.GetUserList(ctx)
+		require.NoError(t, err)
+		require.Equal(t, users, got)
+		mM2M.AssertExpectations(t)
+	})
+
+	t.Run("Users are not cached without period", func(t *testing.T) {
+		mM2M := &M2MAuthenticatorMock{}
+		require.Same(t, mM2M, newCachedUserList(mM2M, 0))
+	})
+}
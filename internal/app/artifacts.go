@@ -0,0 +1,208 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pmezard/go-difflib/difflib"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	db "github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+const (
+	// artifactsEndpoint is the prefix of the endpoints serving the configurations applied to alertmanager and Mimir. It is
+	// under /debug, so that it is only granted to administrators.
+	artifactsEndpoint = "/debug/artifacts"
+
+	defaultArtifactLimit = 20
+	maxArtifactLimit     = 100
+)
+
+var (
+	errArtifactBadRequest = api.HttpError{
+		Code:      http.StatusBadRequest,
+		Message:   errHTTPBadRequest,
+		ErrorCode: api.ErrorCodeInvalidParameter,
+	}
+	errArtifactInternal = api.HttpError{
+		Code:      http.StatusInternalServerError,
+		Message:   "failed to get applied artifacts",
+		ErrorCode: api.ErrorCodeInternalError,
+	}
+)
+
+// appliedArtifact is an applied configuration as served by the artifact endpoints. Content is only set when a single
+// artifact is requested.
+type appliedArtifact struct {
+	ID          int64                      `json:"id"`
+	Kind        models.AppliedArtifactKind `json:"kind"`
+	Name        string                     `json:"name"`
+	Hash        string                     `json:"hash"`
+	AppliedDate time.Time                  `json:"appliedDate"`
+	Content     string                     `json:"content,omitempty"`
+}
+
+// artifactViewer serves the rendered alertmanager manifests and Mimir rule groups applied over time, so that the last known
+// good configuration can be found and compared to the current one after an incident.
+type artifactViewer struct {
+	artifacts db.AppliedArtifactManager
+}
+
+func newArtifactViewer(artifacts db.AppliedArtifactManager) *artifactViewer {
+	return &artifactViewer{artifacts: artifacts}
+}
+
+// register registers the artifact endpoints.
+func (v *artifactViewer) register(e *echo.Echo) {
+	g := e.Group(artifactsEndpoint)
+	g.GET("", v.list)
+	g.GET("/:id", v.get)
+	g.GET("/:id/diff", v.diff)
+}
+
+// list handles the request for the latest applied artifacts, optionally filtered by the kind and name query parameters.
+func (v *artifactViewer) list(ctx echo.Context) error {
+	kind := models.AppliedArtifactKind(ctx.QueryParam("kind"))
+	switch kind {
+	case "", models.ArtifactAlertmanagerManifest, models.ArtifactMimirRuleGroup:
+	default:
+		logWarn(ctx, fmt.Sprintf("Invalid applied artifact kind: %q", kind))
+		return artifactBadRequest(ctx)
+	}
+
+	limit := defaultArtifactLimit
+	if param := ctx.QueryParam("limit"); param != "" {
+		l, err := strconv.Atoi(param)
+		if err != nil || l < 1 || l > maxArtifactLimit {
+			logWarn(ctx, fmt.Sprintf("Invalid applied artifact limit: %q", param))
+			return artifactBadRequest(ctx)
+		}
+		limit = l
+	}
+
+	artifacts, err := v.artifacts.GetAppliedArtifacts(ctx.Request().Context(), kind, ctx.QueryParam("name"), limit)
+	if err != nil {
+		logError(ctx, "Failed to get applied artifacts", err)
+		return artifactInternalError(ctx)
+	}
+
+	list := make([]appliedArtifact, 0, len(artifacts))
+	for _, artifact := range artifacts {
+		list = append(list, appliedArtifactToAPI(artifact, false))
+	}
+	return ctx.JSON(http.StatusOK, list)
+}
+
+// get handles the request for a single applied artifact, including its content.
+func (v *artifactViewer) get(ctx echo.Context) error {
+	artifact, httpErr := v.getArtifact(ctx, ctx.Param("id"))
+	if httpErr != nil {
+		return ctx.JSON(httpErr.Code, httpErr)
+	}
+	return ctx.JSON(http.StatusOK, appliedArtifactToAPI(*artifact, true))
+}
+
+// diff handles the request for the unified diff of an applied artifact against the one given by the against query parameter,
+// or against the artifact of the same kind and name applied before it otherwise. The first artifact applied is diffed against
+// empty content.
+func (v *artifactViewer) diff(ctx echo.Context) error {
+	artifact, httpErr := v.getArtifact(ctx, ctx.Param("id"))
+	if httpErr != nil {
+		return ctx.JSON(httpErr.Code, httpErr)
+	}
+
+	var base *models.AppliedArtifact
+	if against := ctx.QueryParam("against"); against != "" {
+		if base, httpErr = v.getArtifact(ctx, against); httpErr != nil {
+			return ctx.JSON(httpErr.Code, httpErr)
+		}
+	} else {
+		var err error
+		base, err = v.artifacts.GetPreviousAppliedArtifact(ctx.Request().Context(), *artifact)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			base = nil
+		} else if err != nil {
+			logError(ctx, fmt.Sprintf("Failed to get artifact applied before %d", artifact.ID), err)
+			return artifactInternalError(ctx)
+		}
+	}
+
+	diff := difflib.UnifiedDiff{
+		B:        difflib.SplitLines(artifact.Content),
+		ToFile:   artifactLabel(*artifact),
+		FromFile: "/dev/null",
+		Context:  3,
+	}
+	if base != nil {
+		diff.A = difflib.SplitLines(base.Content)
+		diff.FromFile = artifactLabel(*base)
+	}
+
+	text, err := difflib.GetUnifiedDiffString(diff)
+	if err != nil {
+		logError(ctx, "Failed to diff applied artifacts", err)
+		return artifactInternalError(ctx)
+	}
+	return ctx.String(http.StatusOK, text)
+}
+
+// getArtifact gets the applied artifact with the given ID. The error to respond with is returned if the artifact cannot be
+// retrieved.
+func (v *artifactViewer) getArtifact(ctx echo.Context, param string) (*models.AppliedArtifact, *api.HttpError) {
+	id, err := strconv.ParseInt(param, 10, 64)
+	if err != nil {
+		logWarn(ctx, fmt.Sprintf("Invalid applied artifact ID: %q", param))
+		return nil, &errArtifactBadRequest
+	}
+
+	artifact, err := v.artifacts.GetAppliedArtifact(ctx.Request().Context(), id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		logError(ctx, fmt.Sprintf("Applied artifact not found: %d", id), err)
+		return nil, &api.HttpError{
+			Code:      http.StatusNotFound,
+			Message:   "applied artifact not found",
+			ErrorCode: api.ErrorCodeArtifactNotFound,
+		}
+	} else if err != nil {
+		logError(ctx, fmt.Sprintf("Failed to get applied artifact %d", id), err)
+		return nil, &errArtifactInternal
+	}
+	return artifact, nil
+}
+
+func artifactBadRequest(ctx echo.Context) error {
+	return ctx.JSON(http.StatusBadRequest, errArtifactBadRequest)
+}
+
+func artifactInternalError(ctx echo.Context) error {
+	return ctx.JSON(http.StatusInternalServerError, errArtifactInternal)
+}
+
+// artifactLabel returns the label of an applied artifact in the header of a diff.
+func artifactLabel(artifact models.AppliedArtifact) string {
+	return fmt.Sprintf("%s/%s@%d (%s)", artifact.Kind, artifact.Name, artifact.ID, artifact.AppliedDate.UTC().Format(time.RFC3339))
+}
+
+func appliedArtifactToAPI(artifact models.AppliedArtifact, withContent bool) appliedArtifact {
+	res := appliedArtifact{
+		ID:          artifact.ID,
+		Kind:        artifact.Kind,
+		Name:        artifact.Name,
+		Hash:        artifact.Hash,
+		AppliedDate: artifact.AppliedDate,
+	}
+	if withContent {
+		res.Content = artifact.Content
+	}
+	return res
+}
@@ -0,0 +1,113 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+func TestArtifactViewer(t *testing.T) {
+	const manifestName = "orch-infra/alert-monitor-config"
+
+	conn, err := gorm.Open(sqlite.Open(":memory:"))
+	require.NoError(t, err)
+	require.NoError(t, conn.AutoMigrate(&models.AppliedArtifact{}))
+	dbService := &database.DBService{DB: conn}
+
+	for _, content := range []string{"receivers:\n- name: a\n", "receivers:\n- name: b\n"} {
+		require.NoError(t, dbService.RecordAppliedArtifact(t.Context(), models.ArtifactAlertmanagerManifest, manifestName, []byte(content)))
+	}
+	require.NoError(t, dbService.RecordAppliedArtifact(t.Context(), models.ArtifactMimirRuleGroup, "edgenode/group", []byte("rules: []\n")))
+
+	e := echo.New()
+	newArtifactViewer(dbService).register(e)
+
+	get := func(uri string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, uri, nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("List artifacts of a kind", func(t *testing.T) {
+		rec := get(artifactsEndpoint + "?kind=AlertmanagerManifest")
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var list []appliedArtifact
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+		require.Len(t, list, 2)
+		require.Equal(t, int64(2), list[0].ID)
+		require.Equal(t, manifestName, list[0].Name)
+		require.Empty(t, list[0].Content)
+	})
+
+	t.Run("Get artifact with content", func(t *testing.T) {
+		rec := get(artifactsEndpoint + "/3")
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var artifact appliedArtifact
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &artifact))
+		require.Equal(t, models.ArtifactMimirRuleGroup, artifact.Kind)
+		require.Equal(t, "rules: []\n", artifact.Content)
+	})
+
+	t.Run("Diff artifact against the previous one", func(t *testing.T) {
+		rec := get(artifactsEndpoint + "/2/diff")
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Contains(t, rec.Body.String(), fmt.Sprintf("--- AlertmanagerManifest/%s@1", manifestName))
+		require.Contains(t, rec.Body.String(), "-- name: a\n+- name: b\n")
+	})
+
+	t.Run("Diff first artifact against empty content", func(t *testing.T) {
+		rec := get(artifactsEndpoint + "/1/diff")
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.True(t, strings.HasPrefix(rec.Body.String(), "--- /dev/null\n"))
+	})
+
+	t.Run("Diff artifact against a given one", func(t *testing.T) {
+		rec := get(artifactsEndpoint + "/1/diff?against=2")
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Contains(t, rec.Body.String(), "-- name: b\n+- name: a\n")
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		tests := map[string]struct {
+			uri       string
+			code      int
+			errorCode api.ErrorCode
+		}{
+			"unknown kind":      {uri: artifactsEndpoint + "?kind=Unknown", code: http.StatusBadRequest, errorCode: api.ErrorCodeInvalidParameter},
+			"invalid limit":     {uri: artifactsEndpoint + "?limit=0", code: http.StatusBadRequest, errorCode: api.ErrorCodeInvalidParameter},
+			"invalid ID":        {uri: artifactsEndpoint + "/abc", code: http.StatusBadRequest, errorCode: api.ErrorCodeInvalidParameter},
+			"artifact missing":  {uri: artifactsEndpoint + "/10", code: http.StatusNotFound, errorCode: api.ErrorCodeArtifactNotFound},
+			"base missing":      {uri: artifactsEndpoint + "/1/diff?against=10", code: http.StatusNotFound, errorCode: api.ErrorCodeArtifactNotFound},
+			"diffed ID invalid": {uri: artifactsEndpoint + "/abc/diff", code: http.StatusBadRequest, errorCode: api.ErrorCodeInvalidParameter},
+		}
+
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				rec := get(test.uri)
+				require.Equal(t, test.code, rec.Code)
+
+				var httpErr api.HttpError
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &httpErr))
+				require.Equal(t, test.errorCode, httpErr.ErrorCode)
+			})
+		}
+	})
+}
@@ -65,29 +65,12 @@ func ensureAuthorized(c echo.Context) error {
 		return nil
 	}
 
-	authorizationHeader := c.Request().Header.Get("Authorization")
-	token, err := getB64JWT(authorizationHeader)
-	if err != nil {
-		return err
-	}
-
-	roles, err := extractRolesFromJWT(token)
+	input, err := authzInput(c)
 	if err != nil {
 		return err
 	}
 
-	project := c.Request().Header.Get("ActiveProjectID")
-
-	authPayload := map[string]map[string]interface{}{
-		"input": {
-			"roles":   roles,
-			"project": project,
-			"method":  c.Request().Method,
-			"path":    trimPath(c.Request().URL.Path),
-		},
-	}
-
-	resp, err := checkAuthz(authPayload)
+	resp, err := checkAuthz(map[string]map[string]interface{}{"input": input})
 	if err != nil {
 		return fmt.Errorf("unable to check authorization: %w", err)
 	}
@@ -102,6 +85,47 @@ func ensureAuthorized(c echo.Context) error {
 	return errors.New("access denied, no policy allowed this request")
 }
 
+// authorizeEmailOverride ensures the request is allowed to override the sender address and mail server of the emails of a
+// receiver, which only the allow_email_override policy grants.
+func authorizeEmailOverride(c echo.Context) error {
+	input, err := authzInput(c)
+	if err != nil {
+		return err
+	}
+	input["emailOverride"] = true
+
+	resp, err := checkAuthz(map[string]map[string]interface{}{"input": input})
+	if err != nil {
+		return fmt.Errorf("unable to check authorization: %w", err)
+	}
+	if !resp.Result["allow_email_override"] {
+		return errors.New("access denied, email configuration override is not allowed")
+	}
+	return nil
+}
+
+// authzInput returns the input of the authorization policies of a request, made of the roles of its token, its project, and
+// its method and path.
+func authzInput(c echo.Context) (map[string]interface{}, error) {
+	authorizationHeader := c.Request().Header.Get("Authorization")
+	token, err := getB64JWT(authorizationHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := extractRolesFromJWT(token)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"roles":   roles,
+		"project": c.Request().Header.Get(activeProjectIDHeader),
+		"method":  c.Request().Method,
+		"path":    trimPath(c.Request().URL.Path),
+	}, nil
+}
+
 func trimPath(url string) []string {
 	tmppath := strings.TrimSpace(url)
 	path := strings.Split(tmppath, "/")
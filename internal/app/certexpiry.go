@@ -0,0 +1,335 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/smtp"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/correlation"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/network"
+)
+
+const (
+	// certExpiryEndpoint is the endpoint serving the expiry of the certificates of the downstream endpoints, as of the last
+	// check. It is under /debug, so that it is only granted to administrators.
+	certExpiryEndpoint = "/debug/certificates"
+
+	// certExpiryAlertName is the name of the alerts raised for the certificates approaching their expiry.
+	certExpiryAlertName = "DownstreamCertificateExpiring"
+
+	defaultCertExpiryTimeout = 10 * time.Second
+
+	certExpiryWarning  = "warning"
+	certExpiryCritical = "critical"
+)
+
+// certEndpoint is a downstream endpoint whose TLS certificate is checked.
+type certEndpoint struct {
+	// name identifies the endpoint in logs, metrics and alerts, such as mimir-ruler.
+	name       string
+	addr       string
+	serverName string
+	// startTLS tells whether the connection is upgraded to TLS with the STARTTLS command of SMTP, rather than starting with a
+	// TLS handshake.
+	startTLS bool
+}
+
+// certExpiry is the expiry of the certificate of a downstream endpoint, as served by the certificates endpoint. The severity
+// is set once the certificate is within the warning or critical threshold of its expiry.
+type certExpiry struct {
+	Endpoint string    `json:"endpoint"`
+	Address  string    `json:"address"`
+	NotAfter time.Time `json:"notAfter"`
+	Severity string    `json:"severity,omitempty"`
+}
+
+// certExpiryChecker checks the expiry of the TLS certificates of the downstream endpoints, since expired certificates are a
+// recurring cause of notifications silently lost. Certificates approaching their expiry are logged, and raised as alerts of
+// the default tenant, which resolve once the certificates are renewed. The status of the service is degraded while a
+// certificate is within the critical threshold of its expiry.
+type certExpiryChecker struct {
+	handler   *ServerInterfaceHandler
+	conf      config.CertExpiryConfig
+	endpoints []certEndpoint
+	client    *http.Client
+	// certificates returns the certificates presented by an endpoint.
+	certificates func(ctx context.Context, endpoint certEndpoint) ([]*x509.Certificate, error)
+
+	mu       sync.RWMutex
+	expiries []certExpiry
+}
+
+func newCertExpiryChecker(handler *ServerInterfaceHandler, conf config.CertExpiryConfig) *certExpiryChecker {
+	if conf.Timeout <= 0 {
+		conf.Timeout = defaultCertExpiryTimeout
+	}
+	c := &certExpiryChecker{
+		handler:   handler,
+		conf:      conf,
+		endpoints: certEndpoints(handler.configuration),
+		client:    http.DefaultClient,
+		expiries:  []certExpiry{},
+	}
+	c.certificates = c.peerCertificates
+	return c
+}
+
+// certEndpoints returns the downstream endpoints of the given configuration presenting a TLS certificate: the alertmanager
+// instances and Mimir APIs with HTTPS URLs, and the mail server given by the SMART_HOST and SMART_PORT environment variables
+// and the ones it fails over to, which are connected to with TLS on port 465 and upgraded with STARTTLS otherwise.
+func certEndpoints(conf config.Config) []certEndpoint {
+	var endpoints []certEndpoint
+	appendHTTPS := func(name, rawURL string) {
+		u, err := url.Parse(rawURL)
+		if err != nil || u.Scheme != "https" {
+			return
+		}
+		port := u.Port()
+		if port == "" {
+			port = "443"
+		}
+		endpoints = append(endpoints, certEndpoint{name: name, addr: net.JoinHostPort(u.Hostname(), port), serverName: u.Hostname()})
+	}
+
+	for shard := range conf.AlertManager.ShardCount() {
+		shardConf, err := conf.AlertManager.Shard(shard)
+		if err != nil {
+			continue
+		}
+		name := "alertmanager"
+		if conf.AlertManager.ShardCount() > 1 {
+			name = fmt.Sprintf("alertmanager-%d", shard)
+		}
+		appendHTTPS(name, shardConf.URL)
+	}
+	appendHTTPS("mimir-ruler", conf.Mimir.RulerURL)
+	appendHTTPS("mimir-query", conf.Mimir.QueryURL)
+
+	if host, port := os.Getenv("SMART_HOST"), os.Getenv("SMART_PORT"); network.ValidateMailServer(host, port) == nil {
+		endpoints = append(endpoints, certEndpoint{name: "smtp", addr: net.JoinHostPort(host, port), serverName: host, startTLS: port != "465"})
+	}
+	for i, server := range conf.MailFailover.Servers {
+		port := strconv.Itoa(server.Port)
+		if network.ValidateMailServer(server.Host, port) != nil {
+			continue
+		}
+		endpoints = append(endpoints, certEndpoint{name: fmt.Sprintf("smtp-failover-%d", i), addr: net.JoinHostPort(server.Host, port),
+			serverName: server.Host, startTLS: port != "465"})
+	}
+	return endpoints
+}
+
+// register registers the certificates endpoint.
+func (c *certExpiryChecker) register(e *echo.Echo) {
+	e.GET(certExpiryEndpoint, c.list)
+}
+
+// run checks the certificates every interval, until the context is done.
+func (c *certExpiryChecker) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		c.check(ctx, interval)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// check checks the certificates of all endpoints, sets the certificate expiry metric, and raises alerts for the certificates
+// approaching their expiry. The alerts last three intervals, so that they resolve once the certificates are renewed. The
+// endpoints whose certificates cannot be retrieved are skipped.
+func (c *certExpiryChecker) check(ctx context.Context, interval time.Duration) []certExpiry {
+	now := clock.TimeNowFn()
+	expiries := make([]certExpiry, 0, len(c.endpoints))
+	for _, endpoint := range c.endpoints {
+		certs, err := c.certificates(ctx, endpoint)
+		if err != nil {
+			slog.Error("Failed to get TLS certificate to check its expiry", slog.String("endpoint", endpoint.name), slog.Any("error", err))
+			continue
+		}
+		if len(certs) == 0 {
+			continue
+		}
+
+		// The chain expires with its first certificate to expire, be it the leaf or an intermediate.
+		notAfter := certs[0].NotAfter
+		for _, cert := range certs[1:] {
+			if cert.NotAfter.Before(notAfter) {
+				notAfter = cert.NotAfter
+			}
+		}
+		certificateExpiry.WithLabelValues(endpoint.name).Set(float64(notAfter.Unix()))
+
+		expiry := certExpiry{Endpoint: endpoint.name, Address: endpoint.addr, NotAfter: notAfter.UTC(), Severity: c.severity(now, notAfter)}
+		if expiry.Severity != "" {
+			slog.Warn("TLS certificate of downstream endpoint expires soon", slog.String("endpoint", endpoint.name),
+				slog.Time("notAfter", notAfter), slog.String("severity", expiry.Severity))
+		}
+		expiries = append(expiries, expiry)
+	}
+
+	c.mu.Lock()
+	c.expiries = expiries
+	c.mu.Unlock()
+
+	if err := c.raiseAlerts(ctx, expiries, now.Add(3*interval)); err != nil {
+		slog.Error("Failed to raise certificate expiry alerts", slog.Any("error", err))
+	}
+	return expiries
+}
+
+// severity returns the severity of a certificate expiring at the given time, empty if it is not within the warning threshold
+// of its expiry.
+func (c *certExpiryChecker) severity(now, notAfter time.Time) string {
+	switch remaining := notAfter.Sub(now); {
+	case remaining <= c.conf.CriticalThreshold:
+		return certExpiryCritical
+	case remaining <= c.conf.WarningThreshold:
+		return certExpiryWarning
+	default:
+		return ""
+	}
+}
+
+// degraded tells whether a certificate was within the critical threshold of its expiry as of the last check.
+func (c *certExpiryChecker) degraded() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, expiry := range c.expiries {
+		if expiry.Severity == certExpiryCritical {
+			return true
+		}
+	}
+	return false
+}
+
+// raiseAlerts pushes an alert of the default tenant, ending at the given time, for every certificate approaching its expiry
+// to the alertmanager instance of the default tenant.
+func (c *certExpiryChecker) raiseAlerts(ctx context.Context, expiries []certExpiry, endsAt time.Time) error {
+	alerts := make([]postableAlert, 0)
+	for _, expiry := range expiries {
+		if expiry.Severity == "" {
+			continue
+		}
+		alerts = append(alerts, postableAlert{
+			Labels: map[string]string{
+				"alertname":             certExpiryAlertName,
+				"severity":              expiry.Severity,
+				"endpoint":              expiry.Endpoint,
+				"alert_category":        externalAlertCategory,
+				c.handler.tenantLabel(): DefaultTenantID,
+			},
+			Annotations: map[string]string{
+				"summary": fmt.Sprintf("The TLS certificate of %s (%s) expires at %s", expiry.Endpoint, expiry.Address,
+					expiry.NotAfter.Format(time.RFC3339)),
+			},
+			EndsAt: &endsAt,
+		})
+	}
+	if len(alerts) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(alerts)
+	if err != nil {
+		return fmt.Errorf("failed to marshal alerts: %w", err)
+	}
+	amURL, err := c.handler.alertManagerURL(ctx, DefaultTenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get alertmanager shard: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, amURL+"/api/v2/alerts", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	correlation.SetHeader(req)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("alertmanager returned HTTP status code: %v", resp.StatusCode)
+	}
+	return nil
+}
+
+// peerCertificates returns the certificates presented by the given endpoint in the TLS handshake. No certificate is returned
+// for a mail server not supporting STARTTLS.
+func (c *certExpiryChecker) peerCertificates(ctx context.Context, endpoint certEndpoint) ([]*x509.Certificate, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.conf.Timeout)
+	defer cancel()
+
+	conn, err := network.DialContext(ctx, "tcp", endpoint.addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to set deadline: %w", err)
+		}
+	}
+
+	//nolint:gosec // Certificates are only inspected for their expiry, which fails their verification once they have expired.
+	tlsConf := &tls.Config{ServerName: endpoint.serverName, InsecureSkipVerify: true}
+	if !endpoint.startTLS {
+		tlsConn := tls.Client(conn, tlsConf)
+		defer tlsConn.Close()
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			return nil, fmt.Errorf("failed TLS handshake: %w", err)
+		}
+		return tlsConn.ConnectionState().PeerCertificates, nil
+	}
+
+	client, err := smtp.NewClient(conn, endpoint.serverName)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to start SMTP session: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); !ok {
+		return nil, nil
+	}
+	if err := client.StartTLS(tlsConf); err != nil {
+		return nil, fmt.Errorf("failed to start TLS: %w", err)
+	}
+	state, _ := client.TLSConnectionState()
+	return state.PeerCertificates, nil
+}
+
+// list handles the request for the expiry of the certificates of the downstream endpoints, as of the last check.
+func (c *certExpiryChecker) list(ctx echo.Context) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return ctx.JSON(http.StatusOK, c.expiries)
+}
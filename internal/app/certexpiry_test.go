@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+)
+
+func TestCertEndpoints(t *testing.T) {
+	t.Setenv("SMART_HOST", "smtp.example.com")
+	t.Setenv("SMART_PORT", "587")
+
+	configfile := conf
+	configfile.AlertManager.URL = "https://alertmanager.example.com"
+	configfile.Mimir.RulerURL = "http://mimir-ruler:8080"
+	configfile.Mimir.QueryURL = "https://mimir-query:8443/prometheus"
+	configfile.MailFailover.Servers = []config.MailServerConfig{{Host: "fd00::25", Port: 465}}
+
+	require.Equal(t, []certEndpoint{
+		{name: "alertmanager", addr: "alertmanager.example.com:443", serverName: "alertmanager.example.com"},
+		{name: "mimir-query", addr: "mimir-query:8443", serverName: "mimir-query"},
+		{name: "smtp", addr: "smtp.example.com:587", serverName: "smtp.example.com", startTLS: true},
+		{name: "smtp-failover-0", addr: "[fd00::25]:465", serverName: "fd00::25"},
+	}, certEndpoints(configfile))
+
+	t.Setenv("SMART_PORT", "465")
+	require.False(t, certEndpoints(configfile)[2].startTLS)
+}
+
+func TestCertExpiryChecker_Check(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	clock.SetFakeClock()
+	defer clock.UnsetFakeClock()
+	clock.FakeClock.Set(now)
+
+	var pushed []postableAlert
+	alertSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v2/alerts", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&pushed))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer alertSrv.Close()
+
+	configfile := conf
+	configfile.AlertManager.URL = alertSrv.URL
+	handler := NewServerInterfaceHandler(configfile, &gorm.DB{}, nil, nil)
+
+	notAfter := map[string]time.Time{
+		"alertmanager": now.Add(60 * 24 * time.Hour),
+		"mimir-ruler":  now.Add(10 * 24 * time.Hour),
+		"smtp":         now.Add(-time.Hour),
+	}
+	c := newCertExpiryChecker(handler, config.CertExpiryConfig{WarningThreshold: 30 * 24 * time.Hour, CriticalThreshold: 7 * 24 * time.Hour})
+	c.endpoints = []certEndpoint{{name: "alertmanager"}, {name: "mimir-ruler"}, {name: "mimir-query"}, {name: "smtp"}}
+	c.certificates = func(_ context.Context, endpoint certEndpoint) ([]*x509.Certificate, error) {
+		if endpoint.name == "mimir-query" {
+			return nil, errors.New("connection refused")
+		}
+		// The intermediate certificate expires after the leaf.
+		return []*x509.Certificate{{NotAfter: notAfter[endpoint.name]}, {NotAfter: now.Add(365 * 24 * time.Hour)}}, nil
+	}
+
+	t.Run("Certificates close to their expiry raise alerts", func(t *testing.T) {
+		require.False(t, c.degraded())
+
+		expiries := c.check(t.Context(), time.Hour)
+		require.Equal(t, []certExpiry{
+			{Endpoint: "alertmanager", NotAfter: notAfter["alertmanager"]},
+			{Endpoint: "mimir-ruler", NotAfter: notAfter["mimir-ruler"], Severity: certExpiryWarning},
+			{Endpoint: "smtp", NotAfter: notAfter["smtp"], Severity: certExpiryCritical},
+		}, expiries)
+		require.True(t, c.degraded())
+
+		require.Len(t, pushed, 2)
+		require.Equal(t, map[string]string{
+			"alertname":      certExpiryAlertName,
+			"severity":       certExpiryWarning,
+			"endpoint":       "mimir-ruler",
+			"alert_category": externalAlertCategory,
+			"projectId":      DefaultTenantID,
+		}, pushed[0].Labels)
+		require.Equal(t, certExpiryCritical, pushed[1].Labels["severity"])
+		require.Equal(t, now.Add(3*time.Hour), pushed[1].EndsAt.UTC())
+	})
+
+	t.Run("Expiries of the last check are listed", func(t *testing.T) {
+		e := echo.New()
+		c.register(e)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, certExpiryEndpoint, nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var expiries []certExpiry
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &expiries))
+		require.Len(t, expiries, 3)
+		require.Equal(t, "smtp", expiries[2].Endpoint)
+	})
+
+	t.Run("Renewed certificates are not degraded", func(t *testing.T) {
+		pushed = nil
+		notAfter["mimir-ruler"] = now.Add(90 * 24 * time.Hour)
+		notAfter["smtp"] = now.Add(90 * 24 * time.Hour)
+
+		c.check(t.Context(), time.Hour)
+		require.False(t, c.degraded())
+		require.Nil(t, pushed)
+	})
+}
+
+func TestCertExpiryChecker_PeerCertificates(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer srv.Close()
+
+	c := newCertExpiryChecker(NewServerInterfaceHandler(conf, &gorm.DB{}, nil, nil), config.CertExpiryConfig{})
+	certs, err := c.peerCertificates(t.Context(), certEndpoint{name: "alertmanager", addr: srv.Listener.Addr().String(),
+		serverName: "example.com"})
+	require.NoError(t, err)
+	require.NotEmpty(t, certs)
+	require.Equal(t, srv.Certificate().NotAfter, certs[0].NotAfter)
+}
@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+const (
+	errHTTPFailedToGetAlertComments   = "failed to get alert comments"
+	errHTTPFailedToAddAlertComment    = "failed to add alert comment"
+	errHTTPFailedToCheckAlertInstance = "failed to check alert instance"
+
+	// maxAlertCommentLength is the maximum number of characters of a comment of an alert.
+	maxAlertCommentLength = 4096
+)
+
+// AddAlertComment attaches a comment to the active alert of a tenant with the given fingerprint, or replies to one of its
+// comments. The author of the comment is the user the access token of the request was issued to.
+func (w *ServerInterfaceHandler) AddAlertComment(ctx echo.Context, tenantID api.TenantID, fingerprint api.AlertFingerprint) error {
+	var reqBody api.PostProjectAlertCommentJSONRequestBody
+
+	dec := json.NewDecoder(ctx.Request().Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&reqBody); err != nil {
+		logError(ctx, "Failed to parse body of alert comment", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPBadRequest,
+			ErrorCode: api.ErrorCodeInvalidRequestBody,
+		})
+	}
+
+	lang := responseLanguage(ctx)
+	if strings.TrimSpace(reqBody.Content) == "" || utf8.RuneCountInString(reqBody.Content) > maxAlertCommentLength {
+		logWarn(ctx, fmt.Sprintf("Invalid length of comment of alert %q: %d", fingerprint, utf8.RuneCountInString(reqBody.Content)))
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   localize(lang, msgBadRequest),
+			ErrorCode: api.ErrorCodeInvalidRequestBody,
+			Details: &[]api.ErrorDetail{{
+				Field:  "content",
+				Reason: localize(lang, msgAlertCommentLength, maxAlertCommentLength),
+			}},
+		})
+	}
+
+	// Comments can only be attached to alerts which are active, so that the fingerprint is known to be one of the tenant.
+	alert, httpErr := w.getAlertInstance(ctx, tenantID, fingerprint)
+	if httpErr != nil {
+		if httpErr.Code != http.StatusNotFound {
+			httpErr.Message = errHTTPFailedToCheckAlertInstance
+		}
+		return ctx.JSON(httpErr.Code, httpErr)
+	}
+
+	comment := models.AlertComment{
+		TenantID:     tenantID,
+		Fingerprint:  fingerprint,
+		ParentID:     reqBody.ParentId,
+		Author:       requestUsername(ctx),
+		Content:      reqBody.Content,
+		CreationDate: clock.TimeNowFn().UTC(),
+	}
+	if alert.StartsAt != nil {
+		startsAt := alert.StartsAt.UTC()
+		comment.AlertStartsAt = &startsAt
+	}
+	err := w.alertComments.AddAlertComment(ctx.Request().Context(), &comment)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		value := strconv.FormatInt(*reqBody.ParentId, 10)
+		logWarn(ctx, fmt.Sprintf("Comment %s to reply to is not a comment of alert %q", value, fingerprint))
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   localize(lang, msgBadRequest),
+			ErrorCode: api.ErrorCodeInvalidRequestBody,
+			Details: &[]api.ErrorDetail{{
+				Field:  "parentId",
+				Reason: localize(lang, msgAlertCommentParentNotFound),
+				Value:  &value,
+			}},
+		})
+	} else if err != nil {
+		logError(ctx, fmt.Sprintf("Failed to add comment to alert %q", fingerprint), err)
+		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToAddAlertComment,
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+	return ctx.JSON(http.StatusCreated, alertComment(comment))
+}
+
+// requestUsername returns the username of the user the access token of the request was issued to, empty if the request is not
+// authenticated.
+func requestUsername(ctx echo.Context) string {
+	token, err := getB64JWT(ctx.Request().Header.Get("Authorization"))
+	if err != nil {
+		return ""
+	}
+	username, err := extractUsernameFromJWT(token)
+	if err != nil {
+		logError(ctx, "Failed to extract username from access token", err)
+		return ""
+	}
+	return username
+}
+
+// alertComment returns the given comment of an alert as served by the API.
+func alertComment(comment models.AlertComment) api.AlertComment {
+	apiComment := api.AlertComment{
+		Id:        comment.ID,
+		ParentId:  comment.ParentID,
+		Content:   comment.Content,
+		CreatedAt: comment.CreationDate.UTC(),
+	}
+	if comment.Author != "" {
+		apiComment.Author = &comment.Author
+	}
+	return apiComment
+}
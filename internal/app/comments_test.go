@@ -0,0 +1,163 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/testutil"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+func TestAlertComments(t *testing.T) {
+	conn, err := gorm.Open(sqlite.Open(":memory:"))
+	require.NoError(t, err)
+	require.NoError(t, conn.AutoMigrate(&models.AlertComment{}))
+
+	clock.SetFakeClock()
+	defer clock.UnsetFakeClock()
+	now := time.Now().UTC().Truncate(time.Second)
+	clock.FakeClock.Set(now)
+
+	tenantID := "edgenode"
+	fingerprint := "0b4c5e3a1f2d6789"
+
+	alertManager := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/alerts" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if !strings.Contains(r.URL.Query().Get("filter"), tenantID) {
+			fmt.Fprint(w, "[]")
+			return
+		}
+		fmt.Fprintf(w, `[{"fingerprint":%q,"labels":{"alertname":"HostCPUUsageHigh","alert_category":"performance","projectId":%q},`+
+			`"annotations":{},"startsAt":"2025-03-10T12:00:00Z","status":{"state":"active"}}]`, fingerprint, tenantID)
+	}))
+	defer alertManager.Close()
+
+	configfile := conf
+	configfile.AlertManager.URL = alertManager.URL
+
+	server := echo.New()
+	api.RegisterHandlers(server, &ServerInterfaceHandler{
+		configuration: configfile,
+		alertComments: &database.DBService{DB: conn},
+	})
+
+	get := func(t *testing.T, tenantID, fingerprint string) *testutil.CompletedRequest {
+		return testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get("/api/v1/alerts/"+fingerprint).
+			GoWithHTTPHandler(t, server)
+	}
+	post := func(t *testing.T, tenantID, fingerprint string, body any) *testutil.CompletedRequest {
+		return testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).WithHeader("Authorization", "Bearer "+validRolesToken).
+			Post("/api/v1/alerts/"+fingerprint+"/comments").WithJsonBody(body).GoWithHTTPHandler(t, server)
+	}
+
+	t.Run("Alert without comments", func(t *testing.T) {
+		result := get(t, tenantID, fingerprint)
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+
+		var detail api.AlertDetail
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &detail))
+		require.Equal(t, fingerprint, *detail.Alert.Fingerprint)
+		require.NotNil(t, detail.Comments)
+		require.Empty(t, detail.Comments)
+	})
+
+	t.Run("Add threaded comments", func(t *testing.T) {
+		result := post(t, tenantID, fingerprint, api.AlertCommentCreate{Content: "Looking into it"})
+		require.Equal(t, http.StatusCreated, result.Recorder.Code)
+
+		var comment api.AlertComment
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &comment))
+		require.Equal(t, "Looking into it", comment.Content)
+		require.Equal(t, "lp-admin-user", *comment.Author)
+		require.Nil(t, comment.ParentId)
+		require.Equal(t, now, comment.CreatedAt)
+
+		// The start of the alert is recorded, the comment acknowledging it.
+		var stored models.AlertComment
+		require.NoError(t, conn.Take(&stored, comment.Id).Error)
+		require.NotNil(t, stored.AlertStartsAt)
+		require.True(t, time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC).Equal(*stored.AlertStartsAt))
+
+		result = post(t, tenantID, fingerprint, api.AlertCommentCreate{Content: "Host rebooted", ParentId: &comment.Id})
+		require.Equal(t, http.StatusCreated, result.Recorder.Code)
+
+		var reply api.AlertComment
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &reply))
+		require.Equal(t, comment.Id, *reply.ParentId)
+
+		result = get(t, tenantID, fingerprint)
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+
+		var detail api.AlertDetail
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &detail))
+		require.Equal(t, []api.AlertComment{comment, reply}, detail.Comments)
+	})
+
+	t.Run("Alert not found - code should be 404", func(t *testing.T) {
+		for name, result := range map[string]*testutil.CompletedRequest{
+			"get unknown fingerprint":   get(t, tenantID, "ffffffffffffffff"),
+			"get alert of other tenant": get(t, "other", fingerprint),
+			"comment unknown alert":     post(t, tenantID, "ffffffffffffffff", api.AlertCommentCreate{Content: "comment"}),
+		} {
+			var httpErr api.HttpError
+			require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &httpErr), name)
+			require.Equal(t, http.StatusNotFound, httpErr.Code, name)
+			require.Equal(t, api.ErrorCodeAlertNotFound, httpErr.ErrorCode, name)
+		}
+	})
+
+	t.Run("Invalid comments - code should be 400", func(t *testing.T) {
+		unknownParent := int64(100)
+		for name, body := range map[string]any{
+			"empty content":    api.AlertCommentCreate{Content: " "},
+			"too long content": api.AlertCommentCreate{Content: strings.Repeat("a", maxAlertCommentLength+1)},
+			"unknown parent":   api.AlertCommentCreate{Content: "reply", ParentId: &unknownParent},
+			"unknown field":    map[string]any{"content": "comment", "author": "someone"},
+		} {
+			result := post(t, tenantID, fingerprint, body)
+
+			var httpErr api.HttpError
+			require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &httpErr), name)
+			require.Equal(t, http.StatusBadRequest, httpErr.Code, name)
+			require.Equal(t, api.ErrorCodeInvalidRequestBody, httpErr.ErrorCode, name)
+		}
+	})
+
+	t.Run("Alertmanager unavailable - code should be 500", func(t *testing.T) {
+		configfile := conf
+		configfile.AlertManager.URL = "http://127.0.0.1:0"
+		server := echo.New()
+		api.RegisterHandlers(server, &ServerInterfaceHandler{
+			configuration: configfile,
+			alertComments: &database.DBService{DB: conn},
+		})
+
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get("/api/v1/alerts/"+fingerprint).
+			GoWithHTTPHandler(t, server)
+
+		var httpErr api.HttpError
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &httpErr))
+		require.Equal(t, http.StatusInternalServerError, httpErr.Code)
+		require.Equal(t, api.ErrorCodeAlertmanagerUnavailable, httpErr.ErrorCode)
+		require.Equal(t, errHTTPFailedToGetAlertInstance, httpErr.Message)
+	})
+}
@@ -0,0 +1,418 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/correlation"
+)
+
+const (
+	// alertmanagerCompatEndpoint is the prefix of the subset of the Alertmanager v2 API served to tools like amtool. Requests
+	// are scoped to the tenant given by the ActiveProjectID header.
+	alertmanagerCompatEndpoint = "/compat/alertmanager/api/v2"
+
+	errHTTPFailedToProxyAlertmanager = "failed to proxy alertmanager request"
+	errHTTPSilenceNotFound           = "silence not found"
+)
+
+// matcherValueEscaper escapes the value of an alertmanager matcher, as alertmanager does when formatting matchers.
+var matcherValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// TenantFilter returns the filter of the alertmanager alerts API matching the alerts of the given tenant by the given tenant
+// label, so that alerts are filtered by alertmanager rather than after being downloaded. The tenant is quoted, as it comes from
+// the ActiveProjectID header and may not be a valid unquoted matcher value.
+func TenantFilter(label string, tenantID api.TenantID) string {
+	return label + `="` + matcherValueEscaper.Replace(tenantID) + `"`
+}
+
+// tenantLabel returns the label scoping alerts and silences to a tenant, as configured.
+func (w *ServerInterfaceHandler) tenantLabel() string {
+	return w.configuration.Tenancy.TenantLabel()
+}
+
+// silenceMatcher is a matcher of an alertmanager silence.
+type silenceMatcher struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	IsRegex bool   `json:"isRegex"`
+	IsEqual *bool  `json:"isEqual,omitempty"`
+}
+
+// silenceMatchers holds the matchers of an alertmanager silence, the other fields of silences being proxied as they are.
+type silenceMatchers struct {
+	Matchers []silenceMatcher `json:"matchers"`
+}
+
+// ownedBy reports whether the silence only silences alerts of the given tenant, told by the given tenant label.
+func (s silenceMatchers) ownedBy(label string, tenantID api.TenantID) bool {
+	return slices.ContainsFunc(s.Matchers, func(m silenceMatcher) bool {
+		return m.Name == label && m.Value == tenantID && !m.IsRegex && (m.IsEqual == nil || *m.IsEqual)
+	})
+}
+
+// alertmanagerCompat serves the alerts, silences and status surfaces of the Alertmanager v2 API, proxied to the alertmanager
+// of the tenant of each request. Alerts are filtered by the tenant label and redacted as those of the alerts API, silences
+// are scoped to the tenant by a matcher of the tenant label, and the configuration of alertmanager is left out of its status
+// as it holds the receivers of every tenant.
+type alertmanagerCompat struct {
+	handler *ServerInterfaceHandler
+	client  *http.Client
+}
+
+func newAlertmanagerCompat(handler *ServerInterfaceHandler) *alertmanagerCompat {
+	return &alertmanagerCompat{
+		handler: handler,
+		client:  http.DefaultClient,
+	}
+}
+
+// register registers the Alertmanager compatible endpoints.
+func (c *alertmanagerCompat) register(e *echo.Echo) {
+	g := e.Group(alertmanagerCompatEndpoint)
+	g.GET("/alerts", c.alerts)
+	g.GET("/alerts/groups", c.alerts)
+	g.GET("/silences", c.silences)
+	g.POST("/silences", c.postSilence)
+	g.GET("/silence/:silenceID", c.silence)
+	g.DELETE("/silence/:silenceID", c.deleteSilence)
+	g.GET("/status", c.status)
+}
+
+// alerts handles the requests for alerts and alert groups, filtered to the alerts of the tenant and redacted.
+func (c *alertmanagerCompat) alerts(ctx echo.Context) error {
+	tenantID, err := extractProjectID(ctx)
+	if err != nil {
+		return compatProjectIDMissing(ctx, err)
+	}
+
+	query := ctx.QueryParams()
+	query.Add("filter", TenantFilter(c.handler.tenantLabel(), tenantID))
+
+	path := strings.TrimPrefix(ctx.Request().URL.Path, alertmanagerCompatEndpoint)
+	status, body, err := c.forward(ctx, tenantID, http.MethodGet, path, query, nil)
+	if err != nil {
+		return compatAlertmanagerUnavailable(ctx, err)
+	}
+	if status != http.StatusOK {
+		return ctx.JSONBlob(status, body)
+	}
+
+	var items []map[string]json.RawMessage
+	if err := json.Unmarshal(body, &items); err != nil {
+		return compatAlertmanagerUnavailable(ctx, fmt.Errorf("failed to unmarshal alerts: %w", err))
+	}
+
+	redaction := c.handler.configuration.RedactionPatterns
+	if path == "/alerts/groups" {
+		for _, group := range items {
+			var alerts []map[string]json.RawMessage
+			if err := json.Unmarshal(group["alerts"], &alerts); err != nil {
+				return compatAlertmanagerUnavailable(ctx, fmt.Errorf("failed to unmarshal alerts of group: %w", err))
+			}
+			if err := redactCompatAlerts(alerts, redaction); err != nil {
+				return compatAlertmanagerUnavailable(ctx, err)
+			}
+			if group["alerts"], err = json.Marshal(alerts); err != nil {
+				return compatAlertmanagerUnavailable(ctx, fmt.Errorf("failed to marshal alerts of group: %w", err))
+			}
+		}
+	}
+	// The labels of alert groups are those the alerts are grouped by, which are redacted as the labels of alerts.
+	if err := redactCompatAlerts(items, redaction); err != nil {
+		return compatAlertmanagerUnavailable(ctx, err)
+	}
+	return ctx.JSON(http.StatusOK, items)
+}
+
+// redactCompatAlerts redacts the labels and annotations of the given alertmanager alerts as those of the alerts API, the other
+// fields of the alerts being proxied as they are.
+func redactCompatAlerts(items []map[string]json.RawMessage, patterns *config.RedactionPatterns) error {
+	alerts := make([]api.Alert, len(items))
+	for i, item := range items {
+		if raw, ok := item["labels"]; ok {
+			if err := json.Unmarshal(raw, &alerts[i].Labels); err != nil {
+				return fmt.Errorf("failed to unmarshal labels of alert: %w", err)
+			}
+		}
+		if raw, ok := item["annotations"]; ok {
+			if err := json.Unmarshal(raw, &alerts[i].Annotations); err != nil {
+				return fmt.Errorf("failed to unmarshal annotations of alert: %w", err)
+			}
+		}
+	}
+
+	redactAlerts(&alerts, patterns)
+
+	for i, item := range items {
+		if alerts[i].Labels != nil {
+			raw, err := json.Marshal(alerts[i].Labels)
+			if err != nil {
+				return fmt.Errorf("failed to marshal labels of alert: %w", err)
+			}
+			item["labels"] = raw
+		}
+		if alerts[i].Annotations != nil {
+			raw, err := json.Marshal(alerts[i].Annotations)
+			if err != nil {
+				return fmt.Errorf("failed to marshal annotations of alert: %w", err)
+			}
+			item["annotations"] = raw
+		}
+	}
+	return nil
+}
+
+// silences handles the request for silences, leaving out the silences of other tenants.
+func (c *alertmanagerCompat) silences(ctx echo.Context) error {
+	tenantID, err := extractProjectID(ctx)
+	if err != nil {
+		return compatProjectIDMissing(ctx, err)
+	}
+
+	status, body, err := c.forward(ctx, tenantID, http.MethodGet, "/silences", ctx.QueryParams(), nil)
+	if err != nil {
+		return compatAlertmanagerUnavailable(ctx, err)
+	}
+	if status != http.StatusOK {
+		return ctx.JSONBlob(status, body)
+	}
+
+	var silences []json.RawMessage
+	if err := json.Unmarshal(body, &silences); err != nil {
+		return compatAlertmanagerUnavailable(ctx, fmt.Errorf("failed to unmarshal silences: %w", err))
+	}
+
+	owned := make([]json.RawMessage, 0, len(silences))
+	for _, raw := range silences {
+		var s silenceMatchers
+		if err := json.Unmarshal(raw, &s); err == nil && s.ownedBy(c.handler.tenantLabel(), tenantID) {
+			owned = append(owned, raw)
+		}
+	}
+	return ctx.JSON(http.StatusOK, owned)
+}
+
+// postSilence handles the request creating or updating a silence. The matchers of the silence are scoped to the tenant,
+// replacing any matcher of the tenant label, and only silences of the tenant can be updated.
+func (c *alertmanagerCompat) postSilence(ctx echo.Context) error {
+	tenantID, err := extractProjectID(ctx)
+	if err != nil {
+		return compatProjectIDMissing(ctx, err)
+	}
+
+	var silence map[string]json.RawMessage
+	if err := json.NewDecoder(ctx.Request().Body).Decode(&silence); err != nil {
+		logError(ctx, "Failed to parse body of silence", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPBadRequest,
+			ErrorCode: api.ErrorCodeInvalidRequestBody,
+		})
+	}
+
+	var matchers silenceMatchers
+	if raw, ok := silence["matchers"]; ok {
+		if err := json.Unmarshal(raw, &matchers.Matchers); err != nil {
+			logError(ctx, "Failed to parse matchers of silence", err)
+			return ctx.JSON(http.StatusBadRequest, api.HttpError{
+				Code:      http.StatusBadRequest,
+				Message:   errHTTPBadRequest,
+				ErrorCode: api.ErrorCodeInvalidRequestBody,
+			})
+		}
+	}
+
+	var id string
+	if raw, ok := silence["id"]; ok {
+		_ = json.Unmarshal(raw, &id)
+	}
+	if id != "" {
+		if _, httpErr := c.getSilence(ctx, tenantID, id); httpErr != nil {
+			return ctx.JSON(httpErr.Code, httpErr)
+		}
+	}
+
+	isEqual := true
+	label := c.handler.tenantLabel()
+	matchers.Matchers = slices.DeleteFunc(matchers.Matchers, func(m silenceMatcher) bool { return m.Name == label })
+	matchers.Matchers = append(matchers.Matchers, silenceMatcher{Name: label, Value: tenantID, IsEqual: &isEqual})
+
+	raw, err := json.Marshal(matchers.Matchers)
+	if err != nil {
+		return compatAlertmanagerUnavailable(ctx, fmt.Errorf("failed to marshal matchers: %w", err))
+	}
+	silence["matchers"] = raw
+
+	body, err := json.Marshal(silence)
+	if err != nil {
+		return compatAlertmanagerUnavailable(ctx, fmt.Errorf("failed to marshal silence: %w", err))
+	}
+
+	status, body, err := c.forward(ctx, tenantID, http.MethodPost, "/silences", nil, body)
+	if err != nil {
+		return compatAlertmanagerUnavailable(ctx, err)
+	}
+	return ctx.JSONBlob(status, body)
+}
+
+// silence handles the request for a silence of the tenant.
+func (c *alertmanagerCompat) silence(ctx echo.Context) error {
+	tenantID, err := extractProjectID(ctx)
+	if err != nil {
+		return compatProjectIDMissing(ctx, err)
+	}
+
+	body, httpErr := c.getSilence(ctx, tenantID, ctx.Param("silenceID"))
+	if httpErr != nil {
+		return ctx.JSON(httpErr.Code, httpErr)
+	}
+	return ctx.JSONBlob(http.StatusOK, body)
+}
+
+// deleteSilence handles the request expiring a silence of the tenant.
+func (c *alertmanagerCompat) deleteSilence(ctx echo.Context) error {
+	tenantID, err := extractProjectID(ctx)
+	if err != nil {
+		return compatProjectIDMissing(ctx, err)
+	}
+
+	id := ctx.Param("silenceID")
+	if _, httpErr := c.getSilence(ctx, tenantID, id); httpErr != nil {
+		return ctx.JSON(httpErr.Code, httpErr)
+	}
+
+	status, body, err := c.forward(ctx, tenantID, http.MethodDelete, "/silence/"+url.PathEscape(id), nil, nil)
+	if err != nil {
+		return compatAlertmanagerUnavailable(ctx, err)
+	}
+	if status == http.StatusOK && len(body) == 0 {
+		return ctx.NoContent(status)
+	}
+	return ctx.JSONBlob(status, body)
+}
+
+// status handles the request for the status of alertmanager, without its configuration.
+func (c *alertmanagerCompat) status(ctx echo.Context) error {
+	tenantID, err := extractProjectID(ctx)
+	if err != nil {
+		return compatProjectIDMissing(ctx, err)
+	}
+
+	status, body, err := c.forward(ctx, tenantID, http.MethodGet, "/status", nil, nil)
+	if err != nil {
+		return compatAlertmanagerUnavailable(ctx, err)
+	}
+	if status != http.StatusOK {
+		return ctx.JSONBlob(status, body)
+	}
+
+	var amStatus map[string]json.RawMessage
+	if err := json.Unmarshal(body, &amStatus); err != nil {
+		return compatAlertmanagerUnavailable(ctx, fmt.Errorf("failed to unmarshal status: %w", err))
+	}
+	amStatus["config"] = json.RawMessage(`{"original":""}`)
+	return ctx.JSON(http.StatusOK, amStatus)
+}
+
+// getSilence gets the silence with the given ID if it is a silence of the tenant. The error to respond with is returned
+// otherwise, silences of other tenants being reported as not found.
+func (c *alertmanagerCompat) getSilence(ctx echo.Context, tenantID api.TenantID, id string) ([]byte, *api.HttpError) {
+	status, body, err := c.forward(ctx, tenantID, http.MethodGet, "/silence/"+url.PathEscape(id), nil, nil)
+	if err != nil {
+		logError(ctx, "Failed to reach alertmanager", err)
+		return nil, &api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToProxyAlertmanager,
+			ErrorCode: api.ErrorCodeAlertmanagerUnavailable,
+		}
+	}
+
+	var s silenceMatchers
+	if status == http.StatusOK {
+		if err := json.Unmarshal(body, &s); err != nil {
+			logError(ctx, "Failed to parse silence", err)
+			return nil, &api.HttpError{
+				Code:      http.StatusInternalServerError,
+				Message:   errHTTPFailedToProxyAlertmanager,
+				ErrorCode: api.ErrorCodeInternalError,
+			}
+		}
+	}
+
+	if status != http.StatusOK || !s.ownedBy(c.handler.tenantLabel(), tenantID) {
+		logWarn(ctx, fmt.Sprintf("Silence %q of tenant %q not found, alertmanager returned HTTP status code: %v", id, tenantID, status))
+		return nil, &api.HttpError{
+			Code:      http.StatusNotFound,
+			Message:   errHTTPSilenceNotFound,
+			ErrorCode: api.ErrorCodeSilenceNotFound,
+		}
+	}
+	return body, nil
+}
+
+// forward sends a request to the given path of the v2 API of the alertmanager of the tenant, returning the status code and
+// body of its response.
+func (c *alertmanagerCompat) forward(ctx echo.Context, tenantID api.TenantID, method, path string, query url.Values, body []byte) (int, []byte, error) {
+	amURL, err := c.handler.alertManagerURL(ctx.Request().Context(), tenantID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	u := amURL + "/api/v2" + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx.Request().Context(), method, u, bytes.NewReader(body))
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	}
+	correlation.SetHeader(req)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	return resp.StatusCode, respBody, nil
+}
+
+func compatProjectIDMissing(ctx echo.Context, err error) error {
+	logError(ctx, "Failed to extract projectID", err)
+	return ctx.JSON(http.StatusBadRequest, api.HttpError{
+		Code:      http.StatusBadRequest,
+		Message:   errHTTPFailedToExtractProjectID,
+		ErrorCode: api.ErrorCodeProjectIDMissing,
+	})
+}
+
+func compatAlertmanagerUnavailable(ctx echo.Context, err error) error {
+	logError(ctx, "Failed to proxy alertmanager request", err)
+	return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+		Code:      http.StatusInternalServerError,
+		Message:   errHTTPFailedToProxyAlertmanager,
+		ErrorCode: api.ErrorCodeAlertmanagerUnavailable,
+	})
+}
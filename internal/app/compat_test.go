@@ -0,0 +1,187 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+)
+
+const (
+	tenantSilence = `{"id":"tenant-silence","status":{"state":"active"},"matchers":[{"name":"alertname","value":"HostDown","isRegex":false},` +
+		`{"name":"projectId","value":"tenant","isRegex":false,"isEqual":true}]}`
+	otherSilence = `{"id":"other-silence","status":{"state":"active"},"matchers":[{"name":"alertname","value":"HostDown","isRegex":false},` +
+		`{"name":"projectId","value":"other-tenant","isRegex":false,"isEqual":true}]}`
+	regexSilence = `{"id":"regex-silence","status":{"state":"active"},"matchers":[{"name":"projectId","value":"tenant|other-tenant","isRegex":true}]}`
+
+	compatAlert = `{"fingerprint":"f1","receivers":[{"name":"tenant"}],"status":{"state":"active"},` +
+		`"labels":{"alertname":"HostDown","projectId":"tenant","token":"secret","host":"node-10.0.0.1"},` +
+		`"annotations":{"description":"Host 10.0.0.1 is down","runbook":"internal"}}`
+	redactedCompatAlert = `{"fingerprint":"f1","receivers":[{"name":"tenant"}],"status":{"state":"active"},` +
+		`"labels":{"alertname":"HostDown","projectId":"tenant","host":"node-[REDACTED]"},` +
+		`"annotations":{"description":"Host [REDACTED] is down"}}`
+)
+
+func TestAlertmanagerCompat(t *testing.T) {
+	var posted map[string]any
+	var deleted, filters []string
+	alertManager := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v2/alerts":
+			filters = r.URL.Query()["filter"]
+			fmt.Fprintf(w, "[%s]", compatAlert)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v2/alerts/groups":
+			filters = r.URL.Query()["filter"]
+			fmt.Fprintf(w, `[{"labels":{"alertname":"HostDown","token":"secret"},"receiver":{"name":"tenant"},"alerts":[%s]}]`, compatAlert)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v2/silences":
+			fmt.Fprintf(w, "[%s,%s,%s]", tenantSilence, otherSilence, regexSilence)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v2/silence/tenant-silence":
+			fmt.Fprint(w, tenantSilence)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v2/silence/other-silence":
+			fmt.Fprint(w, otherSilence)
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/api/v2/silence/"):
+			deleted = append(deleted, strings.TrimPrefix(r.URL.Path, "/api/v2/silence/"))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v2/silences":
+			body, _ := io.ReadAll(r.Body)
+			if json.Unmarshal(body, &posted) != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(w, `{"silenceID":"new-silence"}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v2/status":
+			fmt.Fprint(w, `{"cluster":{"status":"ready"},"config":{"original":"receivers: []"},"versionInfo":{"version":"0.28.0"}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer alertManager.Close()
+
+	configfile := conf
+	configfile.AlertManager.URL = alertManager.URL
+	redaction, err := config.RedactionConfig{
+		Labels:      []string{"token"},
+		Annotations: []string{"runbook"},
+		Values:      []string{`\d+\.\d+\.\d+\.\d+`},
+	}.Compile()
+	require.NoError(t, err)
+	configfile.RedactionPatterns = redaction
+
+	e := echo.New()
+	newAlertmanagerCompat(NewServerInterfaceHandler(configfile, &gorm.DB{}, nil, nil)).register(e)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, alertmanagerCompatEndpoint+path, strings.NewReader(body))
+		req.Header.Set("ActiveProjectID", "tenant")
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("Alerts are filtered by tenant", func(t *testing.T) {
+		for _, path := range []string{"/alerts?filter=severity%3Dcritical", "/alerts/groups?filter=severity%3Dcritical"} {
+			rec := do(http.MethodGet, path, "")
+			require.Equal(t, http.StatusOK, rec.Code)
+			require.Equal(t, []string{"severity=critical", `projectId="tenant"`}, filters)
+		}
+	})
+
+	t.Run("Alerts are redacted", func(t *testing.T) {
+		rec := do(http.MethodGet, "/alerts", "")
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.JSONEq(t, "["+redactedCompatAlert+"]", rec.Body.String())
+
+		rec = do(http.MethodGet, "/alerts/groups", "")
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.JSONEq(t, `[{"labels":{"alertname":"HostDown"},"receiver":{"name":"tenant"},"alerts":[`+redactedCompatAlert+`]}]`,
+			rec.Body.String())
+	})
+
+	t.Run("Silences of other tenants are left out", func(t *testing.T) {
+		rec := do(http.MethodGet, "/silences", "")
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.JSONEq(t, "["+tenantSilence+"]", rec.Body.String())
+	})
+
+	t.Run("Get silence of the tenant", func(t *testing.T) {
+		rec := do(http.MethodGet, "/silence/tenant-silence", "")
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.JSONEq(t, tenantSilence, rec.Body.String())
+	})
+
+	t.Run("Silence of another tenant is not found", func(t *testing.T) {
+		rec := do(http.MethodGet, "/silence/other-silence", "")
+		require.Equal(t, http.StatusNotFound, rec.Code)
+
+		var httpErr api.HttpError
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &httpErr))
+		require.Equal(t, api.ErrorCodeSilenceNotFound, httpErr.ErrorCode)
+
+		rec = do(http.MethodDelete, "/silence/other-silence", "")
+		require.Equal(t, http.StatusNotFound, rec.Code)
+		require.Empty(t, deleted)
+	})
+
+	t.Run("Expire silence of the tenant", func(t *testing.T) {
+		rec := do(http.MethodDelete, "/silence/tenant-silence", "")
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, []string{"tenant-silence"}, deleted)
+	})
+
+	t.Run("Created silence is scoped to the tenant", func(t *testing.T) {
+		rec := do(http.MethodPost, "/silences", `{"matchers":[{"name":"alertname","value":"HostDown","isRegex":false},`+
+			`{"name":"projectId","value":".+","isRegex":true}],"startsAt":"2025-01-01T00:00:00Z","endsAt":"2025-01-01T01:00:00Z",`+
+			`"createdBy":"amtool","comment":"maintenance"}`)
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.JSONEq(t, `{"silenceID":"new-silence"}`, rec.Body.String())
+
+		require.Equal(t, []any{
+			map[string]any{"name": "alertname", "value": "HostDown", "isRegex": false},
+			map[string]any{"name": "projectId", "value": "tenant", "isRegex": false, "isEqual": true},
+		}, posted["matchers"])
+		require.Equal(t, "maintenance", posted["comment"])
+	})
+
+	t.Run("Silence of another tenant cannot be updated", func(t *testing.T) {
+		posted = nil
+		rec := do(http.MethodPost, "/silences", `{"id":"other-silence","matchers":[{"name":"alertname","value":"HostDown","isRegex":false}]}`)
+		require.Equal(t, http.StatusNotFound, rec.Code)
+		require.Nil(t, posted)
+	})
+
+	t.Run("Status is served without the configuration", func(t *testing.T) {
+		rec := do(http.MethodGet, "/status", "")
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.JSONEq(t, `{"cluster":{"status":"ready"},"config":{"original":""},"versionInfo":{"version":"0.28.0"}}`, rec.Body.String())
+	})
+
+	t.Run("Requests without project - code should be 400", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, alertmanagerCompatEndpoint+"/alerts", nil))
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestTenantFilter(t *testing.T) {
+	for tenantID, expected := range map[string]string{
+		"edgenode":                             `projectId="edgenode"`,
+		"5f1a7bd4-53a0-4f1f-a7c2-7d1a0e3a5b21": `projectId="5f1a7bd4-53a0-4f1f-a7c2-7d1a0e3a5b21"`,
+		`a",alertname=~".*`:                    `projectId="a\",alertname=~\".*"`,
+		"tenant\\\n":                           `projectId="tenant\\\n"`,
+	} {
+		require.Equal(t, expected, TenantFilter(config.DefaultTenantLabel, tenantID), tenantID)
+	}
+	require.Equal(t, `org_id="edgenode"`, TenantFilter("org_id", "edgenode"))
+}
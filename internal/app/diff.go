@@ -0,0 +1,254 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"reflect"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"golang.org/x/text/language"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	db "github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+const errHTTPFailedToDiffConfig = "failed to compare configuration bundle"
+
+// DiffConfig compares a configuration bundle, holding alert definitions and receivers as exported as JSON by their list
+// endpoints, with the live configuration of a tenant without applying it. Alert definitions and receivers are matched by ID,
+// and only the values they set are compared, after being normalized as by their update, so that a bundle exported from the
+// tenant has no changes. Those of the tenant left out of the bundle are not reported, as they would be left unchanged.
+func (w *ServerInterfaceHandler) DiffConfig(ctx echo.Context, tenantID api.TenantID) error {
+	var reqBody api.PostProjectConfigDiffJSONRequestBody
+
+	dec := json.NewDecoder(ctx.Request().Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&reqBody); err != nil {
+		logError(ctx, "Failed to parse body of configuration bundle", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPBadRequest,
+			ErrorCode: api.ErrorCodeInvalidRequestBody,
+		})
+	}
+
+	var definitions []api.AlertDefinition
+	if reqBody.AlertDefinitions != nil {
+		definitions = *reqBody.AlertDefinitions
+	}
+	var receivers []api.Receiver
+	if reqBody.Receivers != nil {
+		receivers = *reqBody.Receivers
+	}
+	lang := responseLanguage(ctx)
+	diff := api.ConfigDiff{
+		AlertDefinitions: make([]api.ConfigItemDiff, 0, len(definitions)),
+		Receivers:        make([]api.ConfigItemDiff, 0, len(receivers)),
+	}
+
+	if len(definitions) > 0 {
+		dbDefinitions, _, err := w.definitions.GetLatestAlertDefinitionList(ctx.Request().Context(), tenantID, db.ListOptions{})
+		if err != nil {
+			logError(ctx, errHTTPFailedToGetAlertDefinitions, err)
+			return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+				Code:      http.StatusInternalServerError,
+				Message:   errHTTPFailedToDiffConfig,
+				ErrorCode: api.ErrorCodeInternalError,
+			})
+		}
+		// Maintenance alert definitions are not listed, so they cannot be part of a bundle.
+		live := make(map[uuid.UUID]*models.DBAlertDefinition, len(dbDefinitions))
+		for _, d := range dbDefinitions {
+			if d.Category != models.CategoryMaintenance {
+				live[d.ID] = d
+			}
+		}
+
+		for i, def := range definitions {
+			field := fmt.Sprintf("alertDefinitions[%d]", i)
+			if def.Id == nil {
+				return configBundleError(ctx, lang, field+".id", msgConfigBundleIDMissing)
+			}
+			item, err := diffAlertDefinition(def, live[*def.Id])
+			if err != nil {
+				logError(ctx, fmt.Sprintf("Invalid values of alert definition %q of configuration bundle", *def.Id), err)
+				return configBundleError(ctx, lang, field+".values", msgInvalidConfigBundleValues)
+			}
+			diff.AlertDefinitions = append(diff.AlertDefinitions, item)
+		}
+	}
+
+	if len(receivers) > 0 {
+		dbRecvs, _, err := w.receivers.GetLatestReceiverListWithEmailConfig(ctx.Request().Context(), tenantID, db.ListOptions{})
+		if err != nil {
+			logError(ctx, "Failed to get alert receivers", err)
+			return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+				Code:      http.StatusInternalServerError,
+				Message:   errHTTPFailedToDiffConfig,
+				ErrorCode: api.ErrorCodeInternalError,
+			})
+		}
+		live := make(map[uuid.UUID]*models.DBReceiver, len(dbRecvs))
+		for _, recv := range dbRecvs {
+			live[recv.UUID] = recv
+		}
+
+		for i, recv := range receivers {
+			field := fmt.Sprintf("receivers[%d]", i)
+			if recv.Id == nil {
+				return configBundleError(ctx, lang, field+".id", msgConfigBundleIDMissing)
+			}
+			item, err := diffReceiver(recv, live[*recv.Id])
+			if err != nil {
+				logError(ctx, fmt.Sprintf("Invalid values of alert receiver %q of configuration bundle", *recv.Id), err)
+				return configBundleError(ctx, lang, field, msgInvalidConfigBundleValues)
+			}
+			diff.Receivers = append(diff.Receivers, item)
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, diff)
+}
+
+// configBundleError responds to a request with an invalid configuration bundle, the given field of which is invalid.
+func configBundleError(ctx echo.Context, lang language.Tag, field string, key messageKey) error {
+	reason := localize(lang, key)
+	logWarn(ctx, fmt.Sprintf("Invalid configuration bundle: %v: %v", field, reason))
+	return ctx.JSON(http.StatusBadRequest, api.HttpError{
+		Code:      http.StatusBadRequest,
+		Message:   localize(lang, msgBadRequest),
+		ErrorCode: api.ErrorCodeInvalidRequestBody,
+		Details:   &[]api.ErrorDetail{{Field: field, Reason: reason}},
+	})
+}
+
+// diffAlertDefinition compares an alert definition of a configuration bundle with its live version, nil if the tenant has no
+// such alert definition. The values of the bundle are merged into the live ones, as by an update.
+func diffAlertDefinition(def api.AlertDefinition, live *models.DBAlertDefinition) (api.ConfigItemDiff, error) {
+	item := api.ConfigItemDiff{Id: *def.Id, Name: def.Name}
+	if live == nil {
+		item.Change = api.ConfigChangeNotFound
+		return item, nil
+	}
+	item.Name = &live.Name
+
+	var fields []api.ConfigFieldDiff
+	if def.Values != nil && len(*def.Values) > 0 {
+		req := api.PatchProjectAlertDefinitionJSONBody{Values: &struct {
+			AutoTune  *string `json:"autoTune,omitempty"`
+			Duration  *string `json:"duration,omitempty"`
+			Enabled   *string `json:"enabled,omitempty"`
+			Threshold *string `json:"threshold,omitempty"`
+		}{}}
+		for key, value := range *def.Values {
+			switch key {
+			case "autoTune":
+				req.Values.AutoTune = &value
+			case "duration":
+				req.Values.Duration = &value
+			case "enabled":
+				req.Values.Enabled = &value
+			case "threshold":
+				req.Values.Threshold = &value
+			default:
+				return item, fmt.Errorf("unknown value %q", key)
+			}
+		}
+		values, err := ParseAlertDefinitionValues(req)
+		if err != nil {
+			return item, err
+		}
+
+		current := formatAlertDefinitionValues(live.Values)
+		proposed := formatAlertDefinitionValues(mergeAlertDefinitionValues(live.Values, *values))
+		for _, key := range slices.Sorted(maps.Keys(proposed)) {
+			diffConfigField(&fields, "values."+key, configValue(current, key), configValue(proposed, key))
+		}
+	}
+
+	item.Change = api.ConfigChangeUnchanged
+	if len(fields) > 0 {
+		item.Change, item.Fields = api.ConfigChangeModified, &fields
+	}
+	return item, nil
+}
+
+// diffReceiver compares a receiver of a configuration bundle with its live version, nil if the tenant has no such receiver.
+// The values of the bundle are applied to the live ones, as by an update, except that the recipients are left unchanged if
+// the bundle does not set them. Recipients are compared regardless of their order.
+func diffReceiver(recv api.Receiver, live *models.DBReceiver) (api.ConfigItemDiff, error) {
+	item := api.ConfigItemDiff{Id: *recv.Id}
+	if live == nil {
+		item.Change = api.ConfigChangeNotFound
+		return item, nil
+	}
+
+	req := api.PatchProjectAlertReceiverJSONBody{
+		Enabled:     recv.Enabled,
+		Language:    recv.Language,
+		Matchers:    recv.Matchers,
+		MinSeverity: recv.MinSeverity,
+		OnCall:      recv.OnCall,
+		QuietHours:  recv.QuietHours,
+	}
+	hasRecipients := false
+	if recv.EmailConfig != nil {
+		req.EmailConfig.From = recv.EmailConfig.From
+		req.EmailConfig.MailServer = recv.EmailConfig.MailServer
+		if recv.EmailConfig.To != nil && recv.EmailConfig.To.Enabled != nil {
+			req.EmailConfig.To.Enabled = *recv.EmailConfig.To.Enabled
+			hasRecipients = true
+		}
+	}
+	values, err := ParseReceiverValues(req)
+	if err != nil {
+		return item, err
+	}
+
+	proposed := projectReceiver(*live, values)
+	if !hasRecipients {
+		proposed.To = live.To
+	}
+
+	var fields []api.ConfigFieldDiff
+	diffConfigField(&fields, "emailConfig.to.enabled", slices.Sorted(slices.Values(live.To)), slices.Sorted(slices.Values(proposed.To)))
+	diffConfigField(&fields, "emailConfig.from", live.From, proposed.From)
+	diffConfigField(&fields, "emailConfig.mailServer", live.MailServer, proposed.MailServer)
+	diffConfigField(&fields, "minSeverity", receiverSeverityToAPI(live.MinSeverity), receiverSeverityToAPI(proposed.MinSeverity))
+	diffConfigField(&fields, "quietHours", quietHoursToAPI(live.QuietHours), quietHoursToAPI(proposed.QuietHours))
+	diffConfigField(&fields, "onCall", onCallToAPI(live.OnCallRoutingKey), onCallToAPI(proposed.OnCallRoutingKey))
+	diffConfigField(&fields, "language", languageToAPI(live.Language), languageToAPI(proposed.Language))
+	diffConfigField(&fields, "enabled", enabledToAPI(live.Disabled), enabledToAPI(proposed.Disabled))
+	diffConfigField(&fields, "matchers", matchersToAPI(live.Matchers), matchersToAPI(proposed.Matchers))
+
+	item.Change = api.ConfigChangeUnchanged
+	if len(fields) > 0 {
+		item.Change, item.Fields = api.ConfigChangeModified, &fields
+	}
+	return item, nil
+}
+
+// diffConfigField adds the given field to the changed fields unless its live and proposed values are equal, values being
+// given in their API representation.
+func diffConfigField(fields *[]api.ConfigFieldDiff, field string, live, proposed any) {
+	if reflect.DeepEqual(live, proposed) {
+		return
+	}
+	*fields = append(*fields, api.ConfigFieldDiff{Field: field, Live: &live, Proposed: &proposed})
+}
+
+// configValue returns the given value of an alert definition, nil if it is not set.
+func configValue(values map[string]string, key string) *string {
+	if value, ok := values[key]; ok {
+		return &value
+	}
+	return nil
+}
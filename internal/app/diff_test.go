@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/testutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+func TestDiffConfig(t *testing.T) {
+	tenantID := "edgenode"
+	alice := "alice smith <alice@example.com>"
+	bob := "bob jones <bob@example.com>"
+
+	duration, threshold, enabled := int64(300), int64(80), true
+	definition := &models.DBAlertDefinition{
+		ID:       uuid.New(),
+		Name:     "HighCPUUsage",
+		Category: models.CategoryPerformance,
+		Values:   models.DBAlertDefinitionValues{Duration: &duration, Threshold: &threshold, Enabled: &enabled},
+	}
+	maintenance := &models.DBAlertDefinition{ID: uuid.New(), Name: "MaintenanceWindow", Category: models.CategoryMaintenance}
+	receiver := &models.DBReceiver{UUID: uuid.New(), Name: "receiver", TenantID: tenantID, To: []string{alice, bob},
+		From: "Open Edge Platform Alert <alerts@example.com>", MinSeverity: models.SeverityWarning}
+
+	mDefinition := &DefinitionMock{}
+	mDefinition.On("GetLatestAlertDefinitionList", mock.Anything, tenantID, database.ListOptions{}).
+		Return([]*models.DBAlertDefinition{definition, maintenance}, int64(2), nil)
+	mReceiver := &ReceiverMock{}
+	mReceiver.On("GetLatestReceiverListWithEmailConfig", mock.Anything, tenantID, database.ListOptions{}).
+		Return([]*models.DBReceiver{receiver}, int64(1), nil)
+
+	server := echo.New()
+	api.RegisterHandlers(server, &ServerInterfaceHandler{
+		definitions: mDefinition,
+		receivers:   mReceiver,
+	})
+
+	post := func(t *testing.T, body string) *testutil.CompletedRequest {
+		return testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Post("/api/v1/diff").
+			WithContentType("application/json").WithBody([]byte(body)).GoWithHTTPHandler(t, server)
+	}
+
+	t.Run("Exported configuration is unchanged", func(t *testing.T) {
+		// Values are normalized, so an equivalent duration and recipients in another order are unchanged.
+		result := post(t, `{
+			"alertDefinitions": [{"id": "`+definition.ID.String()+`", "name": "HighCPUUsage", "version": 3,
+				"values": {"duration": "300s", "threshold": "80", "enabled": "true"}, "durationSeconds": 300}],
+			"receivers": [{"id": "`+receiver.UUID.String()+`", "version": 2, "minSeverity": "warning", "enabled": true,
+				"emailConfig": {"from": "Open Edge Platform Alert <alerts@example.com>", "to": {"enabled": ["`+bob+`", "`+alice+`"]}}}]
+		}`)
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+
+		var diff api.ConfigDiff
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &diff))
+		require.Equal(t, []api.ConfigItemDiff{{Id: definition.ID, Name: &definition.Name, Change: api.ConfigChangeUnchanged}}, diff.AlertDefinitions)
+		require.Equal(t, []api.ConfigItemDiff{{Id: receiver.UUID, Change: api.ConfigChangeUnchanged}}, diff.Receivers)
+	})
+
+	t.Run("Changed values are reported", func(t *testing.T) {
+		unknown := uuid.New()
+		result := post(t, `{
+			"alertDefinitions": [
+				{"id": "`+definition.ID.String()+`", "values": {"threshold": "90", "autoTune": "false"}},
+				{"id": "`+maintenance.ID.String()+`", "values": {"enabled": "false"}},
+				{"id": "`+unknown.String()+`", "name": "Unknown"}
+			],
+			"receivers": [{"id": "`+receiver.UUID.String()+`", "enabled": false, "emailConfig": {"to": {"enabled": ["`+alice+`"]}}}]
+		}`)
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+		require.JSONEq(t, `{
+			"alertDefinitions": [
+				{"id": "`+definition.ID.String()+`", "name": "HighCPUUsage", "change": "modified", "fields": [
+					{"field": "values.autoTune", "live": null, "proposed": "false"},
+					{"field": "values.threshold", "live": "80", "proposed": "90"}
+				]},
+				{"id": "`+maintenance.ID.String()+`", "change": "notFound"},
+				{"id": "`+unknown.String()+`", "name": "Unknown", "change": "notFound"}
+			],
+			"receivers": [
+				{"id": "`+receiver.UUID.String()+`", "change": "modified", "fields": [
+					{"field": "emailConfig.to.enabled", "live": ["`+alice+`", "`+bob+`"], "proposed": ["`+alice+`"]},
+					{"field": "enabled", "live": true, "proposed": false}
+				]}
+			]
+		}`, result.Recorder.Body.String())
+	})
+
+	t.Run("Invalid bundle", func(t *testing.T) {
+		for name, tc := range map[string]struct {
+			body  string
+			field string
+		}{
+			"Alert definition without ID": {
+				body:  `{"alertDefinitions": [{"values": {"threshold": "90"}}]}`,
+				field: "alertDefinitions[0].id",
+			},
+			"Invalid value of alert definition": {
+				body:  `{"alertDefinitions": [{"id": "` + definition.ID.String() + `", "values": {"threshold": "high"}}]}`,
+				field: "alertDefinitions[0].values",
+			},
+			"Invalid recipient of receiver": {
+				body:  `{"receivers": [{"id": "` + receiver.UUID.String() + `", "emailConfig": {"to": {"enabled": ["alice"]}}}]}`,
+				field: "receivers[0]",
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				result := post(t, tc.body)
+				require.Equal(t, http.StatusBadRequest, result.Recorder.Code)
+
+				var httpErr api.HttpError
+				require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &httpErr))
+				require.Equal(t, api.ErrorCodeInvalidRequestBody, httpErr.ErrorCode)
+				require.NotNil(t, httpErr.Details)
+				require.Equal(t, tc.field, (*httpErr.Details)[0].Field)
+			})
+		}
+	})
+}
@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+)
+
+const (
+	openAPIEndpoint = "/api/v1/openapi.json"
+	docsEndpoint    = "/api/v1/docs"
+	// docsInitializerPath is the path of the script rendering the API documentation with Swagger UI, relative to the
+	// documentation endpoint. It is not inlined in the page, so that the page runs no inline script.
+	docsInitializerPath = "/swagger-initializer.js"
+)
+
+// swaggerUIFiles holds the assets of the swagger-ui-dist package at the version of swaggerui/VERSION, downloaded by make
+// swagger-ui when alerting monitor is built, so that the documentation page loads no script from outside the API.
+//
+//go:embed swaggerui
+var swaggerUIFiles embed.FS
+
+// swaggerUIAssets holds the assets of Swagger UI served along with the documentation page.
+var swaggerUIAssets fs.FS = swaggerUIFiles
+
+// swaggerUIAssetTypes are the content types of the assets of Swagger UI served along with the documentation page, by name.
+var swaggerUIAssetTypes = map[string]string{
+	"swagger-ui-bundle.js": "text/javascript; charset=UTF-8",
+	"swagger-ui.css":       "text/css; charset=UTF-8",
+}
+
+var docsPage = template.Must(template.New("docs").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Alerting Monitor API</title>
+<link rel="stylesheet" href="{{ .SwaggerUIURL }}/swagger-ui.css">
+</head>
+<body>
+<div id="swagger-ui"></div>
+<script src="{{ .SwaggerUIURL }}/swagger-ui-bundle.js"></script>
+<script src="{{ .InitializerURL }}"></script>
+</body>
+</html>
+`))
+
+// docsInitializer renders the OpenAPI document with Swagger UI. The document is referred to relatively to the documentation
+// page, so that it is found behind proxies serving the API under a prefix.
+const docsInitializer = `window.ui = SwaggerUIBundle({ url: "openapi.json", dom_id: "#swagger-ui" });
+`
+
+// registerAPIDocs registers the endpoints serving the OpenAPI document of the API and its interactive documentation, rendered
+// by Swagger UI. Swagger UI is served from its embedded assets, unless it is loaded from the configured URL. The documentation
+// page sets its own Content-Security-Policy header, allowing the scripts and styles of Swagger UI along with the OpenAPI
+// document to be loaded.
+func registerAPIDocs(e *echo.Echo, conf config.APIDocsConfig) error {
+	spec, err := api.GetOpenAPISpecJSON()
+	if err != nil {
+		return err
+	}
+
+	// The assets are referred to relatively to the documentation page, as the OpenAPI document is.
+	swaggerUIURL, assetSrc := "docs", "'self'"
+	scriptSrc := assetSrc
+	if conf.SwaggerUIURL == "" {
+		for name, contentType := range swaggerUIAssetTypes {
+			asset, err := fs.ReadFile(swaggerUIAssets, "swaggerui/"+name)
+			if err != nil {
+				return fmt.Errorf("assets of Swagger UI are not embedded, they are downloaded by make swagger-ui: %w", err)
+			}
+			e.GET(docsEndpoint+"/"+name, func(ctx echo.Context) error {
+				return ctx.Blob(http.StatusOK, contentType, asset)
+			})
+		}
+	} else {
+		swaggerUIURL = strings.TrimSuffix(conf.SwaggerUIURL, "/")
+		u, err := url.Parse(swaggerUIURL)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return fmt.Errorf("invalid Swagger UI URL %q", conf.SwaggerUIURL)
+		}
+		assetSrc = u.Scheme + "://" + u.Host
+		scriptSrc = "'self' " + assetSrc
+	}
+
+	var page bytes.Buffer
+	if err := docsPage.Execute(&page, struct {
+		SwaggerUIURL   string
+		InitializerURL string
+	}{
+		SwaggerUIURL:   swaggerUIURL,
+		InitializerURL: "docs" + docsInitializerPath,
+	}); err != nil {
+		return fmt.Errorf("failed to render API documentation page: %w", err)
+	}
+	csp := fmt.Sprintf("default-src 'none'; script-src %s; style-src %[2]s; img-src %[2]s data:; connect-src 'self'; "+
+		"frame-ancestors 'none'", scriptSrc, assetSrc)
+
+	e.GET(openAPIEndpoint, func(ctx echo.Context) error {
+		return ctx.JSONBlob(http.StatusOK, spec)
+	})
+	e.GET(docsEndpoint, func(ctx echo.Context) error {
+		ctx.Response().Header().Set(echo.HeaderContentSecurityPolicy, csp)
+		return ctx.HTMLBlob(http.StatusOK, page.Bytes())
+	})
+	e.GET(docsEndpoint+docsInitializerPath, func(ctx echo.Context) error {
+		return ctx.Blob(http.StatusOK, "text/javascript; charset=UTF-8", []byte(docsInitializer))
+	})
+	return nil
+}
@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"testing/fstest"
+
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+)
+
+func TestAPIDocs(t *testing.T) {
+	e := echo.New()
+	require.NoError(t, registerAPIDocs(e, config.APIDocsConfig{Enabled: true, SwaggerUIURL: "https://mirror.example.com/swagger-ui/"}))
+
+	t.Run("OpenAPI document", func(t *testing.T) {
+		result := testutil.NewRequest().Get("/api/v1/openapi.json").GoWithHTTPHandler(t, e)
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+		require.Equal(t, echo.MIMEApplicationJSON, result.Recorder.Header().Get(echo.HeaderContentType))
+
+		var spec struct {
+			OpenAPI string                     `json:"openapi"`
+			Paths   map[string]json.RawMessage `json:"paths"`
+		}
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &spec))
+		require.NotEmpty(t, spec.OpenAPI)
+		require.Contains(t, spec.Paths, "/api/v1/alerts")
+	})
+
+	t.Run("Documentation page", func(t *testing.T) {
+		result := testutil.NewRequest().Get("/api/v1/docs").GoWithHTTPHandler(t, e)
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+		require.Equal(t, echo.MIMETextHTMLCharsetUTF8, result.Recorder.Header().Get(echo.HeaderContentType))
+		require.Contains(t, result.Recorder.Body.String(), `<script src="https://mirror.example.com/swagger-ui/swagger-ui-bundle.js">`)
+		require.Contains(t, result.Recorder.Body.String(), `<script src="docs/swagger-initializer.js">`)
+		require.Contains(t, result.Recorder.Header().Get(echo.HeaderContentSecurityPolicy), "script-src 'self' https://mirror.example.com;")
+
+		result = testutil.NewRequest().Get("/api/v1/docs/swagger-initializer.js").GoWithHTTPHandler(t, e)
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+		require.Contains(t, result.Recorder.Body.String(), `url: "openapi.json"`)
+	})
+
+	t.Run("Invalid Swagger UI URL", func(t *testing.T) {
+		require.Error(t, registerAPIDocs(echo.New(), config.APIDocsConfig{Enabled: true, SwaggerUIURL: "/swagger-ui"}))
+	})
+}
+
+func TestAPIDocs_EmbeddedSwaggerUI(t *testing.T) {
+	embedded := swaggerUIAssets
+	defer func() { swaggerUIAssets = embedded }()
+
+	t.Run("Swagger UI not embedded", func(t *testing.T) {
+		swaggerUIAssets = fstest.MapFS{"swaggerui/VERSION": {Data: []byte("5.17.14\n")}}
+		require.ErrorContains(t, registerAPIDocs(echo.New(), config.APIDocsConfig{Enabled: true}), "make swagger-ui")
+	})
+
+	swaggerUIAssets = fstest.MapFS{
+		"swaggerui/swagger-ui-bundle.js": {Data: []byte("window.SwaggerUIBundle = function() {};")},
+		"swaggerui/swagger-ui.css":       {Data: []byte(".swagger-ui {}")},
+	}
+	e := echo.New()
+	require.NoError(t, registerAPIDocs(e, config.APIDocsConfig{Enabled: true}))
+
+	t.Run("Documentation page", func(t *testing.T) {
+		result := testutil.NewRequest().Get("/api/v1/docs").GoWithHTTPHandler(t, e)
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+		require.Contains(t, result.Recorder.Body.String(), `<script src="docs/swagger-ui-bundle.js">`)
+		require.Contains(t, result.Recorder.Body.String(), `<link rel="stylesheet" href="docs/swagger-ui.css">`)
+		require.Equal(t, "default-src 'none'; script-src 'self'; style-src 'self'; img-src 'self' data:; connect-src 'self'; frame-ancestors 'none'",
+			result.Recorder.Header().Get(echo.HeaderContentSecurityPolicy))
+	})
+
+	t.Run("Swagger UI assets", func(t *testing.T) {
+		result := testutil.NewRequest().Get("/api/v1/docs/swagger-ui-bundle.js").GoWithHTTPHandler(t, e)
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+		require.Equal(t, "text/javascript; charset=UTF-8", result.Recorder.Header().Get(echo.HeaderContentType))
+		require.Equal(t, "window.SwaggerUIBundle = function() {};", result.Recorder.Body.String())
+
+		result = testutil.NewRequest().Get("/api/v1/docs/swagger-ui.css").GoWithHTTPHandler(t, e)
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+		require.Equal(t, "text/css; charset=UTF-8", result.Recorder.Header().Get(echo.HeaderContentType))
+	})
+}
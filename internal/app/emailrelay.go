@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	db "github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/notify/email"
+)
+
+// emailRelayEndpoint is the prefix of the endpoint alertmanager sends the notifications of a receiver to, for alerting monitor
+// to send their emails.
+const emailRelayEndpoint = "/api/v1/email/relay"
+
+// emailSender sends the email of a notification to the recipients of a receiver.
+type emailSender interface {
+	Send(ctx context.Context, recv *models.DBReceiver, data email.Data) error
+}
+
+// emailRelay sends the emails of the alertmanager webhook payloads of receivers on behalf of alertmanager.
+type emailRelay struct {
+	receivers db.ReceiverHandlerManager
+	sender    emailSender
+	// metadata gets the metadata of tenants the alerts of emails are annotated with. Alerts are not annotated if nil.
+	metadata tenantMetadataGetter
+}
+
+func newEmailRelay(receivers db.ReceiverHandlerManager, sender emailSender, metadata tenantMetadataGetter) *emailRelay {
+	return &emailRelay{
+		receivers: receivers,
+		sender:    sender,
+		metadata:  metadata,
+	}
+}
+
+// relay handles the alertmanager webhook payload of the receiver given by the path parameters. Alertmanager retries the
+// notification if the email could not be sent to any recipient, so an error status is returned in that case only. Requests
+// are authenticated by the webhook authenticator of the relay beforehand.
+func (r *emailRelay) relay(ctx echo.Context) error {
+	tenantID := ctx.Param("tenantID")
+	id, err := uuid.Parse(ctx.Param("receiverID"))
+	if err != nil {
+		logError(ctx, "Invalid receiver ID of email relay request", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPBadRequest,
+			ErrorCode: api.ErrorCodeInvalidParameter,
+		})
+	}
+
+	var payload email.Data
+	if err := json.NewDecoder(ctx.Request().Body).Decode(&payload); err != nil {
+		logError(ctx, "Failed to parse alertmanager webhook payload", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPBadRequest,
+			ErrorCode: api.ErrorCodeInvalidRequestBody,
+		})
+	}
+
+	recv, err := r.receivers.GetLatestReceiverWithEmailConfig(ctx.Request().Context(), tenantID, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		logError(ctx, fmt.Sprintf("Alert receiver not found: %q", id), err)
+		return ctx.JSON(http.StatusNotFound, api.HttpError{
+			Code:      http.StatusNotFound,
+			Message:   errHTTPAlertReceiverNotFound,
+			ErrorCode: api.ErrorCodeReceiverNotFound,
+		})
+	} else if err != nil {
+		logError(ctx, fmt.Sprintf("Failed to get alert receiver with UUID: %q", id), err)
+		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToGetAlertReceiver,
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+
+	if metadata, ok := getTenantMetadata(ctx, r.metadata, tenantID); ok {
+		for i := range payload.Alerts {
+			payload.Alerts[i].Annotations = metadata.Annotate(payload.Alerts[i].Annotations)
+		}
+		payload.CommonAnnotations = metadata.Annotate(payload.CommonAnnotations)
+	}
+
+	// Emails are sent to the recipients of the latest version of the receiver, which may have changed since alertmanager
+	// sent the notification.
+	if err := r.sender.Send(ctx.Request().Context(), recv, payload); err != nil {
+		logError(ctx, fmt.Sprintf("Failed to send emails of receiver %q", id), err)
+		return ctx.JSON(http.StatusBadGateway, api.HttpError{
+			Code:      http.StatusBadGateway,
+			Message:   "failed to send emails",
+			ErrorCode: api.ErrorCodeEmailRelayFailed,
+		})
+	}
+	return ctx.NoContent(http.StatusOK)
+}
@@ -0,0 +1,179 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/notify/email"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/tenantmeta"
+)
+
+type EmailSenderMock struct {
+	mock.Mock
+}
+
+func (m *EmailSenderMock) Send(ctx context.Context, recv *models.DBReceiver, data email.Data) error {
+	args := m.Called(ctx, recv, data)
+	return args.Error(0)
+}
+
+func TestEmailRelay(t *testing.T) {
+	id := uuid.New()
+	tenantID := "tenant"
+	uri := fmt.Sprintf("%s/%s/%s", emailRelayEndpoint, tenantID, id)
+	// Relays cannot be served without a token or signing key.
+	t.Setenv("EMAIL_RELAY_TOKEN", "relay-token")
+	recv := &models.DBReceiver{UUID: id, TenantID: tenantID, To: []string{"foo bar <foo@bar.com>"}}
+
+	newServer := func(relay *emailRelay) *echo.Echo {
+		auth, err := newWebhookAuthenticator(config.WebhookAuthConfig{}, "EMAIL_RELAY_TOKEN")
+		require.NoError(t, err)
+		e := echo.New()
+		e.POST(emailRelayEndpoint+"/:tenantID/:receiverID", relay.relay, auth.authenticate)
+		return e
+	}
+
+	post := func(e *echo.Echo, uri, body, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, uri, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("Sends emails of the notification", func(t *testing.T) {
+		mReceiver := &ReceiverMock{}
+		mReceiver.On("GetLatestReceiverWithEmailConfig", mock.Anything, tenantID, id).Return(recv, nil).Once()
+
+		mSender := &EmailSenderMock{}
+		mSender.On("Send", mock.Anything, recv, mock.MatchedBy(func(data email.Data) bool {
+			return data.Status == "firing" && len(data.Alerts) == 2 && len(data.Alerts.Firing()) == 1 &&
+				data.Alerts[0].Labels["alertname"] == "HighCPUUsage"
+		})).Return(nil).Once()
+
+		rec := post(newServer(newEmailRelay(mReceiver, mSender, nil)), uri, alertmanagerWebhookPayload, "relay-token")
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		mReceiver.AssertExpectations(t)
+		mSender.AssertExpectations(t)
+	})
+
+	t.Run("Annotates alerts with tenant metadata", func(t *testing.T) {
+		mReceiver := &ReceiverMock{}
+		mReceiver.On("GetLatestReceiverWithEmailConfig", mock.Anything, tenantID, id).Return(recv, nil).Once()
+
+		mMetadata := &TenantMetadataMock{}
+		mMetadata.On("Get", mock.Anything, tenantID).Return(tenantmeta.Metadata{DisplayName: "Factory-Munich"}, nil).Once()
+
+		mSender := &EmailSenderMock{}
+		mSender.On("Send", mock.Anything, recv, mock.MatchedBy(func(data email.Data) bool {
+			return data.Alerts[0].Annotations["project_name"] == "Factory-Munich" &&
+				data.CommonAnnotations["project_name"] == "Factory-Munich"
+		})).Return(nil).Once()
+
+		rec := post(newServer(newEmailRelay(mReceiver, mSender, mMetadata)), uri, alertmanagerWebhookPayload, "relay-token")
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		mMetadata.AssertExpectations(t)
+		mSender.AssertExpectations(t)
+	})
+
+	t.Run("Tenant metadata not available", func(t *testing.T) {
+		mReceiver := &ReceiverMock{}
+		mReceiver.On("GetLatestReceiverWithEmailConfig", mock.Anything, tenantID, id).Return(recv, nil).Once()
+
+		mMetadata := &TenantMetadataMock{}
+		mMetadata.On("Get", mock.Anything, tenantID).Return(tenantmeta.Metadata{}, errors.New("mock error")).Once()
+
+		mSender := &EmailSenderMock{}
+		mSender.On("Send", mock.Anything, recv, mock.MatchedBy(func(data email.Data) bool {
+			_, ok := data.Alerts[0].Annotations["project_name"]
+			return !ok
+		})).Return(nil).Once()
+
+		rec := post(newServer(newEmailRelay(mReceiver, mSender, mMetadata)), uri, alertmanagerWebhookPayload, "relay-token")
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		mSender.AssertExpectations(t)
+	})
+
+	t.Run("Emails not delivered", func(t *testing.T) {
+		mReceiver := &ReceiverMock{}
+		mReceiver.On("GetLatestReceiverWithEmailConfig", mock.Anything, tenantID, id).Return(recv, nil).Once()
+
+		mSender := &EmailSenderMock{}
+		mSender.On("Send", mock.Anything, recv, mock.Anything).Return(fmt.Errorf("%w: mock error", email.ErrNotDelivered)).Once()
+
+		rec := post(newServer(newEmailRelay(mReceiver, mSender, nil)), uri, alertmanagerWebhookPayload, "relay-token")
+
+		require.Equal(t, http.StatusBadGateway, rec.Code)
+		require.Contains(t, rec.Body.String(), "EMAIL_RELAY_FAILED")
+		mSender.AssertExpectations(t)
+	})
+
+	t.Run("Receiver not found", func(t *testing.T) {
+		mReceiver := &ReceiverMock{}
+		mReceiver.On("GetLatestReceiverWithEmailConfig", mock.Anything, tenantID, id).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		rec := post(newServer(newEmailRelay(mReceiver, &EmailSenderMock{}, nil)), uri, alertmanagerWebhookPayload, "relay-token")
+
+		require.Equal(t, http.StatusNotFound, rec.Code)
+		mReceiver.AssertExpectations(t)
+	})
+
+	t.Run("Failed to get receiver", func(t *testing.T) {
+		mReceiver := &ReceiverMock{}
+		mReceiver.On("GetLatestReceiverWithEmailConfig", mock.Anything, tenantID, id).Return(nil, errors.New("mock error")).Once()
+
+		rec := post(newServer(newEmailRelay(mReceiver, &EmailSenderMock{}, nil)), uri, alertmanagerWebhookPayload, "relay-token")
+
+		require.Equal(t, http.StatusInternalServerError, rec.Code)
+		mReceiver.AssertExpectations(t)
+	})
+
+	t.Run("Invalid receiver ID", func(t *testing.T) {
+		rec := post(newServer(newEmailRelay(&ReceiverMock{}, &EmailSenderMock{}, nil)),
+			fmt.Sprintf("%s/%s/invalid", emailRelayEndpoint, tenantID), alertmanagerWebhookPayload, "relay-token")
+
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("Invalid payload", func(t *testing.T) {
+		rec := post(newServer(newEmailRelay(&ReceiverMock{}, &EmailSenderMock{}, nil)), uri, `{"alerts":`, "relay-token")
+
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("Relay token", func(t *testing.T) {
+		mReceiver := &ReceiverMock{}
+		mReceiver.On("GetLatestReceiverWithEmailConfig", mock.Anything, tenantID, id).Return(recv, nil).Once()
+
+		mSender := &EmailSenderMock{}
+		mSender.On("Send", mock.Anything, recv, mock.Anything).Return(nil).Once()
+
+		e := newServer(newEmailRelay(mReceiver, mSender, nil))
+		require.Equal(t, http.StatusUnauthorized, post(e, uri, alertmanagerWebhookPayload, "").Code)
+		require.Equal(t, http.StatusUnauthorized, post(e, uri, alertmanagerWebhookPayload, "other-token").Code)
+		require.Equal(t, http.StatusOK, post(e, uri, alertmanagerWebhookPayload, "relay-token").Code)
+		mReceiver.AssertExpectations(t)
+		mSender.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/notify/email"
+)
+
+const (
+	errHTTPFailedToGetEmailTemplate = "failed to get email template"
+	errHTTPEmailTemplateNotFound    = "email template not found"
+	errHTTPFailedToSetEmailTemplate = "failed to set email template"
+
+	// maxEmailTemplateSize is the maximum size in bytes of the email template of a tenant, which is rendered into the
+	// alertmanager configuration for each of its email receivers.
+	maxEmailTemplateSize = 64 * 1024
+)
+
+// GetEmailTemplate gets a version of the email template of a tenant, its latest version if none is requested. Version 0 with an
+// empty content is returned if the tenant has never set its email template.
+func (w *ServerInterfaceHandler) GetEmailTemplate(ctx echo.Context, tenantID api.TenantID, params api.GetProjectEmailTemplateParams) error {
+	var version int64
+	if params.Version != nil {
+		version = *params.Version
+		if version < 1 {
+			logWarn(ctx, fmt.Sprintf("Invalid email template version of tenant %q: %d", tenantID, version))
+			return ctx.JSON(http.StatusBadRequest, api.HttpError{
+				Code:      http.StatusBadRequest,
+				Message:   errHTTPBadRequest,
+				ErrorCode: api.ErrorCodeInvalidParameter,
+			})
+		}
+	}
+
+	tmpl, err := w.emailTemplates.GetEmailTemplate(ctx.Request().Context(), tenantID, version)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound) && version == 0:
+		return ctx.JSON(http.StatusOK, api.EmailTemplate{})
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		logError(ctx, fmt.Sprintf("Email template version %d of tenant %q not found", version, tenantID), err)
+		return ctx.JSON(http.StatusNotFound, api.HttpError{
+			Code:      http.StatusNotFound,
+			Message:   errHTTPEmailTemplateNotFound,
+			ErrorCode: api.ErrorCodeEmailTemplateNotFound,
+		})
+	case err != nil:
+		logError(ctx, fmt.Sprintf("Failed to get email template of tenant %q", tenantID), err)
+		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToGetEmailTemplate,
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+	return ctx.JSON(http.StatusOK, emailTemplate(tmpl))
+}
+
+// SetEmailTemplate creates a new version of the email template of a tenant, which its receivers render the HTML body of their
+// emails with once they are applied again. The template is rejected unless it renders the email of a sample alert, or at least
+// parses if the email templates of the deployment are not loaded. An empty content restores the template of the deployment.
+func (w *ServerInterfaceHandler) SetEmailTemplate(ctx echo.Context, tenantID api.TenantID) error {
+	var reqBody api.PutProjectEmailTemplateJSONRequestBody
+
+	dec := json.NewDecoder(ctx.Request().Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&reqBody); err != nil {
+		logError(ctx, "Failed to parse body of email template", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPBadRequest,
+			ErrorCode: api.ErrorCodeInvalidRequestBody,
+		})
+	}
+
+	if reason, errorCode := w.validateEmailTemplate(ctx, tenantID, reqBody.Content); reason != "" {
+		logWarn(ctx, fmt.Sprintf("Invalid email template of tenant %q: %s", tenantID, reason))
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   localize(responseLanguage(ctx), msgBadRequest),
+			ErrorCode: errorCode,
+			Details: &[]api.ErrorDetail{{
+				Field:  "content",
+				Reason: reason,
+			}},
+		})
+	}
+
+	tmpl, err := w.emailTemplates.SetEmailTemplate(ctx.Request().Context(), tenantID, reqBody.Content)
+	if err != nil {
+		logError(ctx, fmt.Sprintf("Failed to set email template of tenant %q", tenantID), err)
+		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToSetEmailTemplate,
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+	return ctx.JSON(http.StatusOK, emailTemplate(tmpl))
+}
+
+// validateEmailTemplate returns the localized reason the given email template of a tenant is rejected for, empty if it is valid,
+// and the error code of the rejection, which tells templates exceeding the rendering limits apart.
+func (w *ServerInterfaceHandler) validateEmailTemplate(ctx echo.Context, tenantID api.TenantID, content string) (string, api.ErrorCode) {
+	lang := responseLanguage(ctx)
+	if len(content) > maxEmailTemplateSize {
+		return localize(lang, msgEmailTemplateTooLarge, maxEmailTemplateSize), api.ErrorCodeInvalidRequestBody
+	}
+	if content == "" {
+		return "", ""
+	}
+
+	var err error
+	if w.emailTemplate != nil {
+		_, _, err = w.emailTemplate.Render(previewEmailData(&models.DBReceiver{
+			Name:     "receiver",
+			Version:  1,
+			TenantID: tenantID,
+		}, w.tenantLabel()), content, "")
+	} else {
+		err = email.ValidateHTML(content)
+	}
+	if errors.Is(err, email.ErrRenderLimit) {
+		return localize(lang, msgInvalidEmailTemplate, err), api.ErrorCodeEmailTemplateLimitExceeded
+	} else if err != nil {
+		return localize(lang, msgInvalidEmailTemplate, err), api.ErrorCodeInvalidRequestBody
+	}
+	return "", ""
+}
+
+// emailTemplate returns the given version of the email template of a tenant as served by the API.
+func emailTemplate(tmpl *models.EmailTemplate) api.EmailTemplate {
+	createdAt := tmpl.CreationDate.UTC()
+	return api.EmailTemplate{
+		Version:   tmpl.Version,
+		Content:   tmpl.Content,
+		CreatedAt: &createdAt,
+	}
+}
@@ -0,0 +1,192 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/testutil"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/notify/email"
+)
+
+func TestEmailTemplate(t *testing.T) {
+	conn, err := gorm.Open(sqlite.Open(":memory:"))
+	require.NoError(t, err)
+	require.NoError(t, conn.AutoMigrate(&models.Receiver{}, &models.Task{}, &models.EmailTemplate{}))
+
+	clock.SetFakeClock()
+	defer clock.UnsetFakeClock()
+	now := time.Now().UTC().Truncate(time.Second)
+	clock.FakeClock.Set(now)
+
+	tenantID := "edgenode"
+	receiverID := uuid.New()
+	require.NoError(t, conn.Create(&models.Receiver{
+		UUID:     receiverID,
+		Name:     "receiver",
+		State:    models.ReceiverApplied,
+		Version:  1,
+		TenantID: tenantID,
+	}).Error)
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "email.tmpl"), []byte(previewTemplate), 0o600))
+	tmpl, err := email.NewTemplate(filepath.Join(dir, "*.tmpl"))
+	require.NoError(t, err)
+
+	server := echo.New()
+	api.RegisterHandlers(server, &ServerInterfaceHandler{
+		emailTemplates: &database.DBService{DB: conn},
+		emailTemplate:  tmpl,
+	})
+
+	get := func(t *testing.T, query string) *testutil.CompletedRequest {
+		return testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get("/api/v1/alerts/email-template"+query).
+			GoWithHTTPHandler(t, server)
+	}
+	put := func(t *testing.T, body any) *testutil.CompletedRequest {
+		return testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Put("/api/v1/alerts/email-template").
+			WithJsonBody(body).GoWithHTTPHandler(t, server)
+	}
+
+	t.Run("Template of the deployment is used by default", func(t *testing.T) {
+		result := get(t, "")
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+
+		var got api.EmailTemplate
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &got))
+		require.Equal(t, api.EmailTemplate{}, got)
+	})
+
+	t.Run("Set versions of the template", func(t *testing.T) {
+		for i, content := range []string{
+			`<p>Project notification</p>{{ template "alert.monitor.mail" . }}`,
+			`<p>{{ .Status }}</p>`,
+		} {
+			result := put(t, api.EmailTemplateUpdate{Content: content})
+			require.Equal(t, http.StatusOK, result.Recorder.Code)
+
+			var got api.EmailTemplate
+			require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &got))
+			require.Equal(t, int64(i+1), got.Version)
+			require.Equal(t, content, got.Content)
+			require.Equal(t, now, *got.CreatedAt)
+		}
+
+		var tasks []models.Task
+		require.NoError(t, conn.Where("receiver_uuid = ?", receiverID).Find(&tasks).Error)
+		require.Len(t, tasks, 1)
+		require.Equal(t, models.TaskNew, tasks[0].State)
+
+		result := get(t, "")
+		var got api.EmailTemplate
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &got))
+		require.Equal(t, int64(2), got.Version)
+
+		result = get(t, "?version=1")
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &got))
+		require.Equal(t, int64(1), got.Version)
+		require.Equal(t, `<p>Project notification</p>{{ template "alert.monitor.mail" . }}`, got.Content)
+	})
+
+	t.Run("Version not found - code should be 404", func(t *testing.T) {
+		result := get(t, "?version=5")
+
+		var httpErr api.HttpError
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &httpErr))
+		require.Equal(t, http.StatusNotFound, httpErr.Code)
+		require.Equal(t, api.ErrorCodeEmailTemplateNotFound, httpErr.ErrorCode)
+	})
+
+	t.Run("Invalid version - code should be 400", func(t *testing.T) {
+		result := get(t, "?version=0")
+
+		var httpErr api.HttpError
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &httpErr))
+		require.Equal(t, http.StatusBadRequest, httpErr.Code)
+		require.Equal(t, api.ErrorCodeInvalidParameter, httpErr.ErrorCode)
+	})
+
+	t.Run("Invalid templates - code should be 400", func(t *testing.T) {
+		for name, content := range map[string]string{
+			"unparsable":       `{{ .Status `,
+			"undefined":        `{{ template "undefined" . }}`,
+			"failed execution": `{{ .Undefined.Field }}`,
+			"too large":        strings.Repeat("a", maxEmailTemplateSize+1),
+		} {
+			t.Run(name, func(t *testing.T) {
+				result := put(t, api.EmailTemplateUpdate{Content: content})
+
+				var httpErr api.HttpError
+				require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &httpErr))
+				require.Equal(t, http.StatusBadRequest, httpErr.Code)
+				require.Equal(t, api.ErrorCodeInvalidRequestBody, httpErr.ErrorCode)
+				require.NotNil(t, httpErr.Details)
+				require.Equal(t, "content", (*httpErr.Details)[0].Field)
+			})
+		}
+	})
+
+	t.Run("Template exceeding the rendering limits - code should be 400", func(t *testing.T) {
+		content := `{{ define "loop" }}` + strings.Repeat("a", 1024) + `{{ template "loop" . }}{{ end }}{{ template "loop" . }}`
+		result := put(t, api.EmailTemplateUpdate{Content: content})
+
+		var httpErr api.HttpError
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &httpErr))
+		require.Equal(t, http.StatusBadRequest, httpErr.Code)
+		require.Equal(t, api.ErrorCodeEmailTemplateLimitExceeded, httpErr.ErrorCode)
+		require.NotNil(t, httpErr.Details)
+		require.Contains(t, (*httpErr.Details)[0].Reason, "email rendering limit exceeded")
+	})
+
+	t.Run("Unknown field - code should be 400", func(t *testing.T) {
+		result := put(t, map[string]any{"content": "", "name": "custom"})
+
+		var httpErr api.HttpError
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &httpErr))
+		require.Equal(t, http.StatusBadRequest, httpErr.Code)
+		require.Equal(t, api.ErrorCodeInvalidRequestBody, httpErr.ErrorCode)
+	})
+
+	t.Run("Restore template of the deployment", func(t *testing.T) {
+		result := put(t, api.EmailTemplateUpdate{})
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+
+		var got api.EmailTemplate
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &got))
+		require.Equal(t, int64(3), got.Version)
+		require.Empty(t, got.Content)
+	})
+
+	t.Run("Templates are parsed without the templates of the deployment", func(t *testing.T) {
+		server := echo.New()
+		api.RegisterHandlers(server, &ServerInterfaceHandler{
+			emailTemplates: &database.DBService{DB: conn},
+		})
+
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Put("/api/v1/alerts/email-template").
+			WithJsonBody(api.EmailTemplateUpdate{Content: `{{ .Status `}).GoWithHTTPHandler(t, server)
+		require.Equal(t, http.StatusBadRequest, result.Recorder.Code)
+
+		result = testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Put("/api/v1/alerts/email-template").
+			WithJsonBody(api.EmailTemplateUpdate{Content: `{{ template "alert.monitor.mail" . }}`}).GoWithHTTPHandler(t, server)
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+	})
+}
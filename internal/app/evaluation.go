@@ -0,0 +1,251 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/rules"
+)
+
+const (
+	errHTTPEvaluationUnavailable           = "evaluation of alert definitions is not available"
+	errHTTPFailedToEvaluateAlertDefinition = "failed to evaluate alert definition"
+
+	// thresholdPreviewLookback is how far back the firings of an alert definition with a proposed threshold are previewed.
+	thresholdPreviewLookback = 24 * time.Hour
+)
+
+// EvaluateAlertDefinition evaluates the expression of an alert definition, rendered with its current values, as an instant
+// query of Mimir scoped to the tenant, so that users can check how close its series are to the threshold before changing it.
+// The operand compared against the threshold is evaluated as well, since the expression only returns the series crossing it.
+// If a threshold is proposed, the expression is rendered with it instead, and the periods it would have fired over the last
+// day are previewed.
+func (w *ServerInterfaceHandler) EvaluateAlertDefinition(ctx echo.Context, tenantID api.TenantID, id api.AlertDefinitionId) error {
+	var reqBody api.PostProjectAlertDefinitionEvaluateJSONRequestBody
+
+	// The body is optional, the expression is then only evaluated with the current threshold.
+	dec := json.NewDecoder(ctx.Request().Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&reqBody); err != nil && !errors.Is(err, io.EOF) {
+		logError(ctx, "Failed to parse body of alert definition evaluation", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPBadRequest,
+			ErrorCode: api.ErrorCodeInvalidRequestBody,
+		})
+	}
+
+	if w.configuration.Mimir.QueryURL == "" {
+		logWarn(ctx, "Mimir query URL is not configured, alert definitions cannot be evaluated")
+		return ctx.JSON(http.StatusServiceUnavailable, api.HttpError{
+			Code:      http.StatusServiceUnavailable,
+			Message:   errHTTPEvaluationUnavailable,
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+
+	ad, err := w.definitions.GetLatestAlertDefinition(ctx.Request().Context(), tenantID, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		logError(ctx, fmt.Sprintf("Alert definition not found: %q", id), err)
+		return ctx.JSON(http.StatusNotFound, api.HttpError{
+			Code:      http.StatusNotFound,
+			Message:   errHTTPAlertDefinitionNotFound,
+			ErrorCode: api.ErrorCodeDefinitionNotFound,
+		})
+	} else if err != nil {
+		logError(ctx, fmt.Sprintf("Failed to retrieve alert definition: %q", id), err)
+		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToEvaluateAlertDefinition,
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+
+	values := mergeAlertDefinitionValues(ad.Values, models.DBAlertDefinitionValues{Threshold: reqBody.Threshold})
+	tmpl, err := renderTemplate(values, ad.Template)
+	if err != nil {
+		logError(ctx, fmt.Sprintf("Failed to render alert definition template: %q", id), err)
+		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToEvaluateAlertDefinition,
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+
+	evaluation := api.AlertDefinitionEvaluation{
+		Expression:  *tmpl.Expr,
+		Threshold:   *values.Threshold,
+		EvaluatedAt: clock.TimeNowFn().UTC().Truncate(time.Second),
+	}
+	evaluation.Result, err = w.queryInstantVector(ctx.Request().Context(), tenantID, evaluation.Expression, evaluation.EvaluatedAt)
+	if err != nil {
+		logError(ctx, fmt.Sprintf("Failed to evaluate expression of alert definition: %q", id), err)
+		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToEvaluateAlertDefinition,
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+
+	// Expressions not comparing a metric against an upper threshold have no operand to report.
+	if operand, err := rules.ThresholdOperand(evaluation.Expression); err == nil {
+		result, err := w.queryInstantVector(ctx.Request().Context(), tenantID, operand, evaluation.EvaluatedAt)
+		if err != nil {
+			logError(ctx, fmt.Sprintf("Failed to evaluate threshold operand of alert definition: %q", id), err)
+			return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+				Code:      http.StatusInternalServerError,
+				Message:   errHTTPFailedToEvaluateAlertDefinition,
+				ErrorCode: api.ErrorCodeInternalError,
+			})
+		}
+		evaluation.OperandResult = &result
+	}
+
+	if reqBody.Threshold != nil {
+		firings, err := w.previewFirings(ctx.Request().Context(), tenantID, evaluation.Expression,
+			time.Duration(*values.Duration)*time.Second, evaluation.EvaluatedAt)
+		if err != nil {
+			logError(ctx, fmt.Sprintf("Failed to preview firings of alert definition: %q", id), err)
+			return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+				Code:      http.StatusInternalServerError,
+				Message:   errHTTPFailedToEvaluateAlertDefinition,
+				ErrorCode: api.ErrorCodeInternalError,
+			})
+		}
+		evaluation.Firings = &firings
+	}
+
+	return ctx.JSON(http.StatusOK, evaluation)
+}
+
+// previewFirings evaluates the given expression of an alert definition as a range query of Mimir scoped to the series of the
+// given tenant, over the lookback of the threshold preview until the given time, and returns the periods the alert definition
+// would have fired with the given duration, from the oldest one. As by the ruler, a series only fires once the expression held
+// for the duration, and periods already started at the beginning of the lookback are taken as starting with it.
+func (w *ServerInterfaceHandler) previewFirings(ctx context.Context, tenantID api.TenantID, expr string, duration time.Duration,
+	at time.Time) ([]api.PreviewedFiring, error) {
+	end := at.Truncate(alertFiringStep)
+	body, err := w.queryMimir(ctx, tenantID, "query_range", url.Values{
+		"query": {expr},
+		"start": {strconv.FormatInt(end.Add(-thresholdPreviewLookback).Unix(), 10)},
+		"end":   {strconv.FormatInt(end.Unix(), 10)},
+		"step":  {strconv.FormatInt(int64(alertFiringStep/time.Second), 10)},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Status string `json:"status"`
+		Data   struct {
+			Result []struct {
+				Metric map[string]string `json:"metric"`
+				Values [][2]any          `json:"values"`
+			} `json:"result"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal received data: %w", err)
+	}
+	if result.Status != "success" {
+		return nil, fmt.Errorf("range query of expression failed with status %q", result.Status)
+	}
+
+	firings := make([]api.PreviewedFiring, 0)
+	for _, series := range result.Data.Result {
+		samples := make([]time.Time, 0, len(series.Values))
+		for _, value := range series.Values {
+			ts, ok := value[0].(float64)
+			if !ok {
+				return nil, fmt.Errorf("unexpected sample timestamp %v", value[0])
+			}
+			samples = append(samples, time.UnixMilli(int64(ts*1000)).UTC())
+		}
+
+		labels := series.Metric
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		for _, period := range alertFirings(samples, end) {
+			// The period is pending rather than firing until the expression held for the duration.
+			periodEnd := end
+			if period.EndsAt != nil {
+				periodEnd = *period.EndsAt
+			}
+			if periodEnd.Sub(period.StartsAt) < duration {
+				continue
+			}
+			firings = append(firings, api.PreviewedFiring{
+				Labels:   labels,
+				StartsAt: period.StartsAt.Add(duration),
+				EndsAt:   period.EndsAt,
+			})
+		}
+	}
+
+	slices.SortStableFunc(firings, func(a, b api.PreviewedFiring) int { return a.StartsAt.Compare(b.StartsAt) })
+	return firings, nil
+}
+
+// queryInstantVector evaluates the given expression at the given time as an instant query of Mimir scoped to the series of the
+// given tenant, and returns the samples of the resulting vector. Expressions evaluating to another type than a vector fail.
+func (w *ServerInterfaceHandler) queryInstantVector(ctx context.Context, tenantID api.TenantID, expr string, at time.Time) ([]api.EvaluatedSample, error) {
+	body, err := w.queryMimir(ctx, tenantID, "query", url.Values{
+		"query": {expr},
+		"time":  {strconv.FormatInt(at.Unix(), 10)},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Status string `json:"status"`
+		Data   struct {
+			ResultType string `json:"resultType"`
+			Result     []struct {
+				Metric map[string]string `json:"metric"`
+				Value  [2]any            `json:"value"`
+			} `json:"result"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal received data: %w", err)
+	}
+	if result.Status != "success" {
+		return nil, fmt.Errorf("query of expression failed with status %q", result.Status)
+	}
+	if result.Data.ResultType != "vector" {
+		return nil, fmt.Errorf("expression %q evaluates to a %v rather than a vector", expr, result.Data.ResultType)
+	}
+
+	samples := make([]api.EvaluatedSample, 0, len(result.Data.Result))
+	for _, series := range result.Data.Result {
+		value, ok := series.Value[1].(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected sample value %v", series.Value[1])
+		}
+		labels := series.Metric
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		samples = append(samples, api.EvaluatedSample{Labels: labels, Value: value})
+	}
+	return samples, nil
+}
@@ -0,0 +1,266 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/testutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+const evaluationTemplate = `alert: HostCPUUsageHigh
+expr: avg by (host_uuid, projectId) (cpu_usage_percent) > [[ .Threshold ]]
+for: 1m
+labels:
+  threshold: "80"
+  duration: 1m
+  alert_category: performance
+annotations:
+  am_uuid: 2102456a-8cf3-40a1-b9e0-5f8c9f970fe4
+`
+
+func TestEvaluateAlertDefinition(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	clock.SetFakeClock()
+	defer clock.UnsetFakeClock()
+	clock.FakeClock.Set(now)
+
+	expr := "avg by (host_uuid, projectId) (cpu_usage_percent) > 90"
+	operand := "avg by (host_uuid, projectId) (cpu_usage_percent)"
+
+	mimirStatus := http.StatusOK
+	var queries []string
+	mimir := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "edgenode-system", r.Header.Get("X-Scope-OrgID"))
+		queries = append(queries, r.URL.Query().Get("query"))
+		w.WriteHeader(mimirStatus)
+
+		if r.URL.Path == "/prometheus/api/v1/query_range" {
+			require.Equal(t, fmt.Sprint(now.Add(-24*time.Hour).Unix()), r.URL.Query().Get("start"))
+			require.Equal(t, fmt.Sprint(now.Unix()), r.URL.Query().Get("end"))
+			require.Equal(t, "60", r.URL.Query().Get("step"))
+
+			// Samples of the series crossing the proposed threshold, every minute of the given periods.
+			values := func(periods ...[2]time.Time) string {
+				var samples []string
+				for _, period := range periods {
+					for ts := period[0]; !ts.After(period[1]); ts = ts.Add(time.Minute) {
+						samples = append(samples, fmt.Sprintf(`[%d,"97"]`, ts.Unix()))
+					}
+				}
+				return strings.Join(samples, ",")
+			}
+			fmt.Fprintf(w, `{"status":"success","data":{"resultType":"matrix","result":[`+
+				`{"metric":{"host_uuid":"host-1","projectId":"edgenode"},"values":[%s]},`+
+				`{"metric":{"host_uuid":"host-2","projectId":"edgenode"},"values":[%s]}]}}`,
+				values([2]time.Time{now.Add(-10 * time.Hour), now.Add(-10*time.Hour + 4*time.Minute)}, [2]time.Time{now.Add(-2 * time.Minute), now}),
+				values([2]time.Time{now.Add(-5 * time.Hour), now.Add(-5 * time.Hour)}))
+			return
+		}
+		require.Equal(t, "/prometheus/api/v1/query", r.URL.Path)
+		require.Equal(t, fmt.Sprint(now.Unix()), r.URL.Query().Get("time"))
+
+		switch r.URL.Query().Get("query") {
+		case expr:
+			fmt.Fprintf(w, `{"status":"success","data":{"resultType":"vector","result":[`+
+				`{"metric":{"host_uuid":"host-1","projectId":"edgenode"},"value":[%[1]d,"95.5"]}]}}`, now.Unix())
+		case operand:
+			fmt.Fprintf(w, `{"status":"success","data":{"resultType":"vector","result":[`+
+				`{"metric":{"host_uuid":"host-1","projectId":"edgenode"},"value":[%[1]d,"95.5"]},`+
+				`{"metric":{"host_uuid":"host-2","projectId":"edgenode"},"value":[%[1]d,"87"]}]}}`, now.Unix())
+		case "scalar(edge_host_status) == 90":
+			fmt.Fprintf(w, `{"status":"success","data":{"resultType":"scalar","result":[%d,"1"]}}`, now.Unix())
+		default:
+			fmt.Fprint(w, `{"status":"success","data":{"resultType":"vector","result":[]}}`)
+		}
+	}))
+	defer mimir.Close()
+
+	configfile := conf
+	configfile.Mimir.QueryURL = mimir.URL
+
+	threshold := int64(90)
+	duration := int64(60)
+	definition := &models.DBAlertDefinition{
+		Template: evaluationTemplate,
+		Values:   models.DBAlertDefinitionValues{Threshold: &threshold, Duration: &duration},
+	}
+
+	post := func(configuration config.Config, mDefinition *DefinitionMock, id uuid.UUID, body string) *httptest.ResponseRecorder {
+		t.Helper()
+
+		e := echo.New()
+		api.RegisterHandlers(e, &ServerInterfaceHandler{configuration: configuration, definitions: mDefinition})
+		req := testutil.NewRequest().WithHeader("ActiveProjectID", "edgenode").Post(fmt.Sprintf("/api/v1/alerts/definitions/%v:evaluate", id))
+		if body != "" {
+			req = req.WithBody([]byte(body))
+		}
+		return req.GoWithHTTPHandler(t, e).Recorder
+	}
+
+	t.Run("Expression and threshold operand are evaluated - code should be 200", func(t *testing.T) {
+		queries = nil
+		id := uuid.New()
+		mDefinition := &DefinitionMock{}
+		mDefinition.On("GetLatestAlertDefinition", mock.Anything, "edgenode", id).Return(definition, nil).Once()
+
+		rec := post(configfile, mDefinition, id, "")
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var evaluation api.AlertDefinitionEvaluation
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &evaluation))
+		require.Equal(t, expr, evaluation.Expression)
+		require.Equal(t, threshold, evaluation.Threshold)
+		require.Equal(t, now, evaluation.EvaluatedAt.UTC())
+		require.Equal(t, []api.EvaluatedSample{
+			{Labels: map[string]string{"host_uuid": "host-1", "projectId": "edgenode"}, Value: "95.5"},
+		}, evaluation.Result)
+		require.NotNil(t, evaluation.OperandResult)
+		require.Equal(t, []api.EvaluatedSample{
+			{Labels: map[string]string{"host_uuid": "host-1", "projectId": "edgenode"}, Value: "95.5"},
+			{Labels: map[string]string{"host_uuid": "host-2", "projectId": "edgenode"}, Value: "87"},
+		}, *evaluation.OperandResult)
+		require.Equal(t, []string{expr, operand}, queries)
+		require.True(t, mDefinition.AssertExpectations(t))
+	})
+
+	t.Run("Proposed threshold is previewed - code should be 200", func(t *testing.T) {
+		queries = nil
+		id := uuid.New()
+		mDefinition := &DefinitionMock{}
+		mDefinition.On("GetLatestAlertDefinition", mock.Anything, "edgenode", id).Return(definition, nil).Once()
+
+		rec := post(configfile, mDefinition, id, `{"threshold":95}`)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var evaluation api.AlertDefinitionEvaluation
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &evaluation))
+		proposed := "avg by (host_uuid, projectId) (cpu_usage_percent) > 95"
+		require.Equal(t, proposed, evaluation.Expression)
+		require.Equal(t, int64(95), evaluation.Threshold)
+		require.Equal(t, []string{proposed, operand, proposed}, queries)
+
+		// The series fires once the expression held for the duration of the alert definition, the single sample of host-2
+		// leaving it pending.
+		endsAt := now.Add(-10*time.Hour + 4*time.Minute)
+		labels := map[string]string{"host_uuid": "host-1", "projectId": "edgenode"}
+		require.NotNil(t, evaluation.Firings)
+		require.Len(t, *evaluation.Firings, 2)
+		require.Equal(t, labels, (*evaluation.Firings)[0].Labels)
+		require.Equal(t, now.Add(-10*time.Hour+time.Minute), (*evaluation.Firings)[0].StartsAt.UTC())
+		require.Equal(t, endsAt, (*evaluation.Firings)[0].EndsAt.UTC())
+		require.Equal(t, labels, (*evaluation.Firings)[1].Labels)
+		require.Equal(t, now.Add(-time.Minute), (*evaluation.Firings)[1].StartsAt.UTC())
+		require.Nil(t, (*evaluation.Firings)[1].EndsAt)
+		require.True(t, mDefinition.AssertExpectations(t))
+	})
+
+	t.Run("Invalid body - code should be 400", func(t *testing.T) {
+		mDefinition := &DefinitionMock{}
+
+		rec := post(configfile, mDefinition, uuid.New(), `{"threshold":"high"}`)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		mDefinition.AssertNotCalled(t, "GetLatestAlertDefinition", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Expression without threshold comparison - code should be 200", func(t *testing.T) {
+		queries = nil
+		id := uuid.New()
+		mDefinition := &DefinitionMock{}
+		mDefinition.On("GetLatestAlertDefinition", mock.Anything, "edgenode", id).Return(&models.DBAlertDefinition{
+			Template: "alert: HostStatusError\nexpr: edge_host_status == [[ .Threshold ]]\n",
+			Values:   definition.Values,
+		}, nil).Once()
+
+		rec := post(configfile, mDefinition, id, "")
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var evaluation api.AlertDefinitionEvaluation
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &evaluation))
+		require.Empty(t, evaluation.Result)
+		require.Nil(t, evaluation.OperandResult)
+		require.Nil(t, evaluation.Firings, "firings are only previewed for a proposed threshold")
+		require.Equal(t, []string{"edge_host_status == 90"}, queries)
+		require.True(t, mDefinition.AssertExpectations(t))
+	})
+
+	t.Run("Expression evaluating to a scalar - code should be 500", func(t *testing.T) {
+		id := uuid.New()
+		mDefinition := &DefinitionMock{}
+		mDefinition.On("GetLatestAlertDefinition", mock.Anything, "edgenode", id).Return(&models.DBAlertDefinition{
+			Template: "alert: HostStatusError\nexpr: scalar(edge_host_status) == [[ .Threshold ]]\n",
+			Values:   definition.Values,
+		}, nil).Once()
+
+		rec := post(configfile, mDefinition, id, "")
+		require.Equal(t, http.StatusInternalServerError, rec.Code)
+		require.True(t, mDefinition.AssertExpectations(t))
+	})
+
+	t.Run("Mimir query URL not configured - code should be 503", func(t *testing.T) {
+		mDefinition := &DefinitionMock{}
+
+		rec := post(conf, mDefinition, uuid.New(), "")
+		require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		mDefinition.AssertNotCalled(t, "GetLatestAlertDefinition", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Alert definition not found - code should be 404", func(t *testing.T) {
+		id := uuid.New()
+		mDefinition := &DefinitionMock{}
+		mDefinition.On("GetLatestAlertDefinition", mock.Anything, "edgenode", id).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		rec := post(configfile, mDefinition, id, "")
+		require.Equal(t, http.StatusNotFound, rec.Code)
+
+		httpErr := &api.HttpError{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), httpErr))
+		require.Equal(t, api.ErrorCodeDefinitionNotFound, httpErr.ErrorCode)
+		require.True(t, mDefinition.AssertExpectations(t))
+	})
+
+	t.Run("Failed to get alert definition - code should be 500", func(t *testing.T) {
+		id := uuid.New()
+		mDefinition := &DefinitionMock{}
+		mDefinition.On("GetLatestAlertDefinition", mock.Anything, "edgenode", id).Return(nil, errors.New("mock error")).Once()
+
+		rec := post(configfile, mDefinition, id, "")
+		require.Equal(t, http.StatusInternalServerError, rec.Code)
+		require.True(t, mDefinition.AssertExpectations(t))
+	})
+
+	t.Run("Mimir fails to evaluate the expression - code should be 500", func(t *testing.T) {
+		mimirStatus = http.StatusBadRequest
+		defer func() { mimirStatus = http.StatusOK }()
+
+		id := uuid.New()
+		mDefinition := &DefinitionMock{}
+		mDefinition.On("GetLatestAlertDefinition", mock.Anything, "edgenode", id).Return(definition, nil).Once()
+
+		rec := post(configfile, mDefinition, id, "")
+		require.Equal(t, http.StatusInternalServerError, rec.Code)
+
+		httpErr := &api.HttpError{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), httpErr))
+		require.Equal(t, errHTTPFailedToEvaluateAlertDefinition, httpErr.Message)
+		require.True(t, mDefinition.AssertExpectations(t))
+	})
+}
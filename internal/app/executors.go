@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	db "github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+)
+
+// executorsEndpoint is the endpoint listing the task executor instances. It is under /debug, so that it is only granted to
+// administrators.
+const executorsEndpoint = "/debug/executors"
+
+// executorInstance is a task executor instance as served by the executors endpoint. An executor is alive if its last heartbeat
+// is within the heartbeat timeout.
+type executorInstance struct {
+	OwnerUUID      uuid.UUID  `json:"ownerUuid"`
+	Alive          bool       `json:"alive"`
+	StartDate      time.Time  `json:"startDate"`
+	HeartbeatDate  time.Time  `json:"heartbeatDate"`
+	LastPollDate   *time.Time `json:"lastPollDate"`
+	ProcessedTasks int64      `json:"processedTasks"`
+}
+
+// executorViewer serves the task executor instances registered by their heartbeat, so that it can be confirmed that tasks
+// are processed in deployments with several replicas.
+type executorViewer struct {
+	executors        db.ExecutorRegistry
+	heartbeatTimeout time.Duration
+}
+
+func newExecutorViewer(executors db.ExecutorRegistry, heartbeatTimeout time.Duration) *executorViewer {
+	return &executorViewer{executors: executors, heartbeatTimeout: heartbeatTimeout}
+}
+
+// register registers the executors endpoint.
+func (v *executorViewer) register(e *echo.Echo) {
+	e.GET(executorsEndpoint, v.list)
+}
+
+// list handles the request for the task executor instances, oldest first.
+func (v *executorViewer) list(ctx echo.Context) error {
+	executors, err := v.executors.GetExecutors(ctx.Request().Context())
+	if err != nil {
+		logError(ctx, "Failed to get executors", err)
+		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   "failed to get executors",
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+
+	deadline := clock.TimeNowFn().Add(-v.heartbeatTimeout)
+	list := make([]executorInstance, 0, len(executors))
+	for _, e := range executors {
+		list = append(list, executorInstance{
+			OwnerUUID:      e.UUID,
+			Alive:          !e.HeartbeatDate.Before(deadline),
+			StartDate:      e.StartDate,
+			HeartbeatDate:  e.HeartbeatDate,
+			LastPollDate:   e.LastPollDate,
+			ProcessedTasks: e.ProcessedTasks,
+		})
+	}
+	return ctx.JSON(http.StatusOK, list)
+}
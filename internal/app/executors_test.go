@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+func TestExecutorViewer(t *testing.T) {
+	conn, err := gorm.Open(sqlite.Open(":memory:"))
+	require.NoError(t, err)
+	require.NoError(t, conn.AutoMigrate(&models.Executor{}))
+	dbService := &database.DBService{DB: conn}
+
+	clock.SetFakeClock()
+	defer clock.UnsetFakeClock()
+	now := time.Now().UTC().Truncate(time.Second)
+	clock.FakeClock.Set(now.Add(-time.Hour))
+
+	dead, alive := uuid.New(), uuid.New()
+	require.NoError(t, dbService.RecordExecutorHeartbeat(t.Context(), dead))
+	clock.FakeClock.Set(now.Add(-10 * time.Minute))
+	require.NoError(t, dbService.RecordExecutorHeartbeat(t.Context(), alive))
+
+	clock.FakeClock.Set(now)
+	require.NoError(t, dbService.RecordExecutorHeartbeat(t.Context(), alive))
+	require.NoError(t, dbService.RecordExecutorPoll(t.Context(), alive, 3))
+	require.NoError(t, dbService.RecordExecutorPoll(t.Context(), alive, 2))
+	require.NoError(t, dbService.RecordExecutorPoll(t.Context(), uuid.New(), 1))
+
+	get := func(t *testing.T, executors database.ExecutorRegistry) *httptest.ResponseRecorder {
+		e := echo.New()
+		newExecutorViewer(executors, time.Minute).register(e)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/executors", nil))
+		return rec
+	}
+
+	t.Run("List executors", func(t *testing.T) {
+		rec := get(t, dbService)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var executors []executorInstance
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &executors))
+		require.Len(t, executors, 2)
+
+		require.Equal(t, dead, executors[0].OwnerUUID)
+		require.False(t, executors[0].Alive)
+		require.Nil(t, executors[0].LastPollDate)
+		require.Zero(t, executors[0].ProcessedTasks)
+
+		require.Equal(t, alive, executors[1].OwnerUUID)
+		require.True(t, executors[1].Alive)
+		require.Equal(t, now.Add(-10*time.Minute), executors[1].StartDate)
+		require.Equal(t, now, executors[1].HeartbeatDate)
+		require.Equal(t, now, *executors[1].LastPollDate)
+		require.Equal(t, int64(5), executors[1].ProcessedTasks)
+	})
+
+	t.Run("Failed to get executors - code should be 500", func(t *testing.T) {
+		broken, err := gorm.Open(sqlite.Open(":memory:"))
+		require.NoError(t, err)
+		rec := get(t, &database.DBService{DB: broken})
+
+		var httpErr api.HttpError
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &httpErr))
+		require.Equal(t, http.StatusInternalServerError, httpErr.Code)
+	})
+}
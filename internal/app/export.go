@@ -0,0 +1,219 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"maps"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+)
+
+const (
+	errHTTPInvalidExportFormat = "invalid export format"
+	errHTTPFailedToExportList  = "failed to export list"
+)
+
+// mimeTextCSVCharsetUTF8 is the content type of the lists and reports exported as CSV.
+const mimeTextCSVCharsetUTF8 = "text/csv; charset=UTF-8"
+
+var (
+	// alertExportColumns are the columns alerts exported as CSV start with, followed by a column per label and annotation.
+	alertExportColumns = []string{"fingerprint", "alertDefinitionId", "state", "startsAt", "endsAt", "updatedAt"}
+	// alertDefinitionExportColumns are the columns alert definitions exported as CSV start with, out of the selected fields,
+	// followed by a column per value and evaluation health field.
+	alertDefinitionExportColumns = []string{
+		"id", "name", "version", "state", "enabled", "durationSeconds", "thresholdValue", "thresholdAutoTuned", "firingCount",
+		"createdAt", "updatedAt", "appliedAt",
+	}
+)
+
+// exportRow is an item of a list exported as CSV, flattened into the values of its columns. Nested fields are flattened into
+// columns named by their path, such as labels.severity.
+type exportRow map[string]string
+
+// exportFormat returns the format a list is exported in, given by the format query parameter or else negotiated from the
+// Accept header of the request.
+func exportFormat(ctx echo.Context, format *api.ExportFormatQueryParam) (api.ExportFormatQueryParam, *api.HttpError) {
+	if format == nil {
+		if prefersCSV(ctx.Request().Header.Get(echo.HeaderAccept)) {
+			return api.ExportFormatCSV, nil
+		}
+		return api.ExportFormatJSON, nil
+	}
+
+	switch *format {
+	case api.ExportFormatJSON, api.ExportFormatCSV:
+		return *format, nil
+	}
+	value := string(*format)
+	logWarn(ctx, fmt.Sprintf("Invalid export format: %q", value))
+	return "", &api.HttpError{
+		Code:      http.StatusBadRequest,
+		Message:   errHTTPInvalidExportFormat,
+		ErrorCode: api.ErrorCodeInvalidParameter,
+		Details: &[]api.ErrorDetail{{
+			Field:  "format",
+			Reason: "must be one of json, csv",
+			Value:  &value,
+		}},
+	}
+}
+
+// prefersCSV tells whether an Accept header prefers text/csv to application/json. Media ranges with wildcards accept both, in
+// which case JSON is served, as it is when the header is missing or invalid.
+func prefersCSV(accept string) bool {
+	var csvQuality, jsonQuality float64
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(mediaRange)
+		if err != nil {
+			continue
+		}
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			if quality, err = strconv.ParseFloat(q, 64); err != nil {
+				continue
+			}
+		}
+
+		switch mediaType {
+		case "text/csv":
+			csvQuality = max(csvQuality, quality)
+		case echo.MIMEApplicationJSON:
+			jsonQuality = max(jsonQuality, quality)
+		}
+	}
+	return csvQuality > jsonQuality
+}
+
+// exportCSV responds with a list exported as CSV, as an attachment named after the list and the current date. The given
+// columns come first, followed by the other columns of the rows sorted by name.
+func exportCSV(ctx echo.Context, list string, columns []string, rows []exportRow) error {
+	others := make(map[string]bool)
+	for _, row := range rows {
+		for column := range row {
+			if !slices.Contains(columns, column) {
+				others[column] = true
+			}
+		}
+	}
+	header := append(slices.Clone(columns), slices.Sorted(maps.Keys(others))...)
+
+	records := make([][]string, 0, len(rows)+1)
+	records = append(records, header)
+	for _, row := range rows {
+		record := make([]string, 0, len(header))
+		for _, column := range header {
+			record = append(record, csvCell(row[column]))
+		}
+		records = append(records, record)
+	}
+
+	var body bytes.Buffer
+	if err := csv.NewWriter(&body).WriteAll(records); err != nil {
+		logError(ctx, fmt.Sprintf("Failed to export %s", list), err)
+		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToExportList,
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+
+	filename := fmt.Sprintf("%s-%s.csv", list, clock.TimeNowFn().UTC().Format(time.DateOnly))
+	ctx.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+	return ctx.Blob(http.StatusOK, mimeTextCSVCharsetUTF8, body.Bytes())
+}
+
+// csvCell neutralizes a value starting like a spreadsheet formula, such as a label set by an application, by prefixing it with
+// a quote, so that it is not evaluated when the export is opened in a spreadsheet. Numbers are left as they are.
+func csvCell(value string) string {
+	if value == "" || !strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return value
+	}
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return value
+	}
+	return "'" + value
+}
+
+// exportValue formats an optional field of an exported item, times in RFC 3339 and UTC.
+func exportValue[T any](v *T) string {
+	if v == nil {
+		return ""
+	}
+	if t, ok := any(*v).(time.Time); ok {
+		return t.UTC().Format(time.RFC3339)
+	}
+	return fmt.Sprint(*v)
+}
+
+// addExportColumns flattens a map field of an exported item into a column per key, prefixed with the name of the field.
+func addExportColumns(row exportRow, field string, values *map[string]string) {
+	if values == nil {
+		return
+	}
+	for k, v := range *values {
+		row[field+"."+k] = v
+	}
+}
+
+// alertToExportRow flattens an alert into the row it is exported as.
+func alertToExportRow(alert api.Alert) exportRow {
+	row := exportRow{
+		"fingerprint":       exportValue(alert.Fingerprint),
+		"alertDefinitionId": exportValue(alert.AlertDefinitionId),
+		"startsAt":          exportValue(alert.StartsAt),
+		"endsAt":            exportValue(alert.EndsAt),
+		"updatedAt":         exportValue(alert.UpdatedAt),
+	}
+	if alert.Status != nil {
+		row["state"] = exportValue(alert.Status.State)
+	}
+	addExportColumns(row, "labels", alert.Labels)
+	addExportColumns(row, "annotations", alert.Annotations)
+	return row
+}
+
+// alertDefinitionToExportRow flattens an alert definition, whose fields not selected are cleared, into the row it is exported
+// as.
+func alertDefinitionToExportRow(def api.AlertDefinition) exportRow {
+	row := exportRow{
+		"id":                 exportValue(def.Id),
+		"name":               exportValue(def.Name),
+		"version":            exportValue(def.Version),
+		"state":              exportValue(def.State),
+		"enabled":            exportValue(def.Enabled),
+		"durationSeconds":    exportValue(def.DurationSeconds),
+		"thresholdValue":     exportValue(def.ThresholdValue),
+		"thresholdAutoTuned": exportValue(def.ThresholdAutoTuned),
+		"firingCount":        exportValue(def.FiringCount),
+		"createdAt":          exportValue(def.CreatedAt),
+		"updatedAt":          exportValue(def.UpdatedAt),
+		"appliedAt":          exportValue(def.AppliedAt),
+	}
+	addExportColumns(row, "values", def.Values)
+	if health := def.EvaluationHealth; health != nil {
+		row["evaluationHealth.evaluations"] = exportValue(health.Evaluations)
+		row["evaluationHealth.failures"] = exportValue(health.Failures)
+		row["evaluationHealth.lastError"] = exportValue(health.LastError)
+		row["evaluationHealth.lastEvaluatedAt"] = exportValue(health.LastEvaluatedAt)
+		row["evaluationHealth.averageDuration"] = exportValue(health.AverageDuration)
+		row["evaluationHealth.maxDuration"] = exportValue(health.MaxDuration)
+		row["evaluationHealth.slow"] = exportValue(health.Slow)
+	}
+	// The fields not selected are left out, rather than exported as empty columns.
+	maps.DeleteFunc(row, func(_, v string) bool { return v == "" })
+	return row
+}
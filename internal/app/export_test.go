@@ -0,0 +1,182 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/testutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+func TestPrefersCSV(t *testing.T) {
+	for accept, expected := range map[string]bool{
+		"":                                   false,
+		"*/*":                                false,
+		"application/json":                   false,
+		"text/csv":                           true,
+		"Text/CSV; charset=utf-8":            true,
+		"text/csv, application/json":         false,
+		"application/json;q=0.5, text/csv":   true,
+		"text/csv;q=0.5, application/json":   false,
+		"text/csv;q=invalid":                 false,
+		"application/json;q=0, text/csv;q=0": false,
+	} {
+		require.Equal(t, expected, prefersCSV(accept), accept)
+	}
+}
+
+func TestCSVCell(t *testing.T) {
+	for value, expected := range map[string]string{
+		"":                   "",
+		"disk usage is high": "disk usage is high",
+		"-5":                 "-5",
+		"+1.5":               "+1.5",
+		"=HYPERLINK(\"x\")":  "'=HYPERLINK(\"x\")",
+		"@SUM(A1)":           "'@SUM(A1)",
+		"-2+3":               "'-2+3",
+	} {
+		require.Equal(t, expected, csvCell(value), value)
+	}
+}
+
+func TestExportAlerts(t *testing.T) {
+	clock.TimeNowFn = func() time.Time { return time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC) }
+	defer func() { clock.TimeNowFn = time.Now }()
+
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `[{"annotations":{"am_uuid":"d3867dfb-e172-4fe6-bfdb-05603618a179","description":"=cmd|' /C calc'!A0"},`+
+			`"endsAt":"2024-01-23T16:13:45.535+01:00","fingerprint":"0c8d24dab761f647","receivers":[{"name":"web.hook"}],`+
+			`"startsAt":"2024-01-23T16:08:45.535+01:00","status":{"inhibitedBy":[],"silencedBy":[],"state":"active"},`+
+			`"updatedAt":"2024-01-23T16:08:45.535+01:00","labels":{"alert_category":"performance","alertname":"HighCPU","host_uuid":"93bf6804-52a3-4ba1-a919-c7ef65a9cdef"}}]`)
+	}))
+	defer svr.Close()
+
+	configuration := conf
+	configuration.AlertManager.URL = svr.URL
+	handler := NewServerInterfaceHandler(configuration, &gorm.DB{}, nil, nil)
+	handler.maintenance = nil
+	server := echo.New()
+	api.RegisterHandlers(server, handler)
+
+	expected := [][]string{
+		{
+			"fingerprint", "alertDefinitionId", "state", "startsAt", "endsAt", "updatedAt",
+			"annotations.description", "labels.alert_category", "labels.alertname", "labels.host_uuid",
+		},
+		{
+			"0c8d24dab761f647", "d3867dfb-e172-4fe6-bfdb-05603618a179", "active", "2024-01-23T15:08:45Z", "2024-01-23T15:13:45Z",
+			"2024-01-23T15:08:45Z", "'=cmd|' /C calc'!A0", "performance", "HighCPU", "93bf6804-52a3-4ba1-a919-c7ef65a9cdef",
+		},
+	}
+
+	t.Run("Format query parameter", func(t *testing.T) {
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", "edgenode").Get("/api/v1/alerts?format=csv").
+			GoWithHTTPHandler(t, server)
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+		require.Equal(t, mimeTextCSVCharsetUTF8, result.Recorder.Header().Get(echo.HeaderContentType))
+		require.Equal(t, `attachment; filename="alerts-2026-10-16.csv"`, result.Recorder.Header().Get(echo.HeaderContentDisposition))
+
+		records, err := csv.NewReader(result.Recorder.Body).ReadAll()
+		require.NoError(t, err)
+		require.Equal(t, expected, records)
+	})
+
+	t.Run("Accept header", func(t *testing.T) {
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", "edgenode").WithHeader(echo.HeaderAccept, "text/csv").
+			Get("/api/v1/alerts").GoWithHTTPHandler(t, server)
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+
+		records, err := csv.NewReader(result.Recorder.Body).ReadAll()
+		require.NoError(t, err)
+		require.Equal(t, expected, records)
+	})
+
+	t.Run("Format query parameter takes precedence over Accept header", func(t *testing.T) {
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", "edgenode").WithHeader(echo.HeaderAccept, "text/csv").
+			Get("/api/v1/alerts?format=json").GoWithHTTPHandler(t, server)
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+		require.Equal(t, echo.MIMEApplicationJSON, result.Recorder.Header().Get(echo.HeaderContentType))
+	})
+
+	t.Run("Invalid format", func(t *testing.T) {
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", "edgenode").Get("/api/v1/alerts?format=xlsx").
+			GoWithHTTPHandler(t, server)
+		require.Equal(t, http.StatusBadRequest, result.Recorder.Code)
+	})
+}
+
+func TestExportAlertDefinitions(t *testing.T) {
+	clock.TimeNowFn = func() time.Time { return time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC) }
+	defer func() { clock.TimeNowFn = time.Now }()
+
+	tenantID := "edgenode"
+	dur := int64(30)
+	thres := int64(80)
+	enabled := true
+	dbDef := &models.DBAlertDefinition{
+		ID:      uuid.MustParse("3fa85f64-5717-4562-b3fc-2c963f66afa6"),
+		Name:    "HostCPUUsageWarning",
+		State:   models.DefinitionApplied,
+		Version: 2,
+		Values: models.DBAlertDefinitionValues{
+			Duration:  &dur,
+			Threshold: &thres,
+			Enabled:   &enabled,
+		},
+		Category: models.CategoryHealth,
+		TenantID: tenantID,
+	}
+
+	mDefinition := &DefinitionMock{}
+	mDefinition.On("GetLatestAlertDefinitionList", mock.Anything, tenantID, database.ListOptions{}).
+		Return([]*models.DBAlertDefinition{dbDef}, int64(1), nil)
+	server := echo.New()
+	api.RegisterHandlers(server, &ServerInterfaceHandler{definitions: mDefinition})
+
+	t.Run("All fields", func(t *testing.T) {
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get("/api/v1/alerts/definitions?format=csv").
+			GoWithHTTPHandler(t, server)
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+		require.Equal(t, `attachment; filename="alert-definitions-2026-10-16.csv"`,
+			result.Recorder.Header().Get(echo.HeaderContentDisposition))
+
+		records, err := csv.NewReader(result.Recorder.Body).ReadAll()
+		require.NoError(t, err)
+		require.Equal(t, [][]string{
+			{
+				"id", "name", "version", "state", "enabled", "durationSeconds", "thresholdValue", "thresholdAutoTuned", "firingCount",
+				"createdAt", "updatedAt", "appliedAt", "values.duration", "values.enabled", "values.threshold",
+			},
+			{
+				"3fa85f64-5717-4562-b3fc-2c963f66afa6", "HostCPUUsageWarning", "2", "Applied", "true", "30", "80", "false", "",
+				"", "", "", "30s", "true", "80",
+			},
+		}, records)
+	})
+
+	t.Run("Selected fields", func(t *testing.T) {
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).
+			Get("/api/v1/alerts/definitions?format=csv&fields=name,thresholdValue").GoWithHTTPHandler(t, server)
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+
+		records, err := csv.NewReader(result.Recorder.Body).ReadAll()
+		require.NoError(t, err)
+		require.Equal(t, [][]string{{"name", "thresholdValue"}, {"HostCPUUsageWarning", "80"}}, records)
+	})
+}
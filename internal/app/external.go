@@ -0,0 +1,211 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/text/language"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/correlation"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+const (
+	errHTTPFailedToPushExternalAlerts = "failed to push external alerts"
+	errHTTPExternalAlertsNotAllowed   = "external alerts are not allowed for the project"
+
+	// externalAlertCategory is the category of external alerts, so that they are listed and routed to the receivers of the
+	// tenant like the alerts of alert definitions.
+	externalAlertCategory = "health"
+)
+
+var (
+	externalAlertNameRegex = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+	externalLabelNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+	// reservedExternalLabels are the labels set by the service on external alerts, along with the tenant label.
+	reservedExternalLabels = []string{"alertname", "severity", "alert_category"}
+)
+
+// errAlertmanagerUnavailable is returned when alertmanager cannot be reached or fails to accept the alerts pushed to it.
+var errAlertmanagerUnavailable = errors.New("alertmanager is unavailable")
+
+// reservedAnnotationPrefix is the prefix of the annotations the service annotates alerts with, e.g. am_uuid.
+const reservedAnnotationPrefix = "am_"
+
+// postableAlert is an alert as pushed to the alerts endpoint of alertmanager.
+type postableAlert struct {
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	StartsAt    *time.Time        `json:"startsAt,omitempty"`
+	EndsAt      *time.Time        `json:"endsAt,omitempty"`
+}
+
+func (w *ServerInterfaceHandler) PostExternalAlerts(ctx echo.Context, tenantID api.TenantID) error {
+	conf := w.configuration.ExternalAlerts
+	if !conf.AllowsTenant(tenantID) {
+		logWarn(ctx, fmt.Sprintf("External alerts are not allowed for tenant %q", tenantID))
+		return ctx.JSON(http.StatusForbidden, api.HttpError{
+			Code:      http.StatusForbidden,
+			Message:   errHTTPExternalAlertsNotAllowed,
+			ErrorCode: api.ErrorCodeExternalAlertsNotAllowed,
+		})
+	}
+
+	var reqBody api.PostProjectExternalAlertsJSONRequestBody
+
+	dec := json.NewDecoder(ctx.Request().Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&reqBody); err != nil {
+		logError(ctx, "Failed to parse body of external alerts", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPBadRequest,
+			ErrorCode: api.ErrorCodeInvalidRequestBody,
+		})
+	}
+
+	lang := responseLanguage(ctx)
+	if details := validateExternalAlerts(lang, reqBody.Alerts, conf.MaxAlerts, w.tenantLabel()); len(details) > 0 {
+		logWarn(ctx, fmt.Sprintf("Invalid external alerts of tenant %q: %s: %s", tenantID, details[0].Field, details[0].Reason))
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   localize(lang, msgInvalidExternalAlerts),
+			ErrorCode: api.ErrorCodeInvalidRequestBody,
+			Details:   &details,
+		})
+	}
+
+	if err := w.pushAlerts(ctx.Request().Context(), tenantID, externalAlertsToPostable(reqBody.Alerts, w.tenantLabel(), tenantID)); err != nil {
+		logError(ctx, "Failed to push external alerts", err)
+		errorCode := api.ErrorCodeInternalError
+		if errors.Is(err, errAlertmanagerUnavailable) {
+			errorCode = api.ErrorCodeAlertmanagerUnavailable
+		}
+		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToPushExternalAlerts,
+			ErrorCode: errorCode,
+		})
+	}
+
+	return ctx.NoContent(http.StatusAccepted)
+}
+
+// pushAlerts pushes the given alerts to the alertmanager instance of the given tenant. Errors reaching alertmanager, or of
+// alertmanager failing to accept the alerts, wrap errAlertmanagerUnavailable.
+func (w *ServerInterfaceHandler) pushAlerts(ctx context.Context, tenantID api.TenantID, alerts []postableAlert) error {
+	body, err := json.Marshal(alerts)
+	if err != nil {
+		return fmt.Errorf("failed to marshal alerts: %w", err)
+	}
+
+	amURL, err := w.alertManagerURL(ctx, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get alertmanager shard: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, amURL+"/api/v2/alerts", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create alertmanager request: %w", err)
+	}
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	correlation.SetHeader(req)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errAlertmanagerUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: alertmanager returned HTTP status code: %v", errAlertmanagerUnavailable, resp.StatusCode)
+	}
+	return nil
+}
+
+// validateExternalAlerts validates the external alerts pushed by a tenant, returning the details of every invalid field with
+// reasons in the given language. Alerts are not limited in number if maxAlerts is not positive, and may not set the given tenant
+// label.
+func validateExternalAlerts(lang language.Tag, alerts []api.ExternalAlert, maxAlerts int, tenantLabel string) []api.ErrorDetail {
+	if len(alerts) == 0 || (maxAlerts > 0 && len(alerts) > maxAlerts) {
+		return []api.ErrorDetail{{
+			Field:  "alerts",
+			Reason: localize(lang, msgExternalAlertCount, max(maxAlerts, 1)),
+		}}
+	}
+
+	var details []api.ErrorDetail
+	invalid := func(field string, key messageKey, value string) {
+		details = append(details, api.ErrorDetail{Field: field, Reason: localize(lang, key), Value: &value})
+	}
+
+	for i, alert := range alerts {
+		field := fmt.Sprintf("alerts[%d]", i)
+		if !externalAlertNameRegex.MatchString(alert.Name) {
+			invalid(field+".name", msgInvalidExternalAlertName, alert.Name)
+		}
+		if models.ReceiverSeverity(alert.Severity).Rank() < 0 {
+			invalid(field+".severity", msgInvalidExternalAlertSeverity, alert.Severity)
+		}
+		if alert.Labels != nil {
+			for _, name := range slices.Sorted(maps.Keys(*alert.Labels)) {
+				if !externalLabelNameRegex.MatchString(name) || strings.HasPrefix(name, "__") || slices.Contains(reservedExternalLabels, name) ||
+					name == tenantLabel {
+					invalid(field+".labels."+name, msgReservedExternalAlertLabel, name)
+				}
+			}
+		}
+		if alert.Annotations != nil {
+			for _, name := range slices.Sorted(maps.Keys(*alert.Annotations)) {
+				if strings.HasPrefix(name, reservedAnnotationPrefix) {
+					invalid(field+".annotations."+name, msgReservedExternalAlertAnnotation, name)
+				}
+			}
+		}
+		if alert.StartsAt != nil && alert.EndsAt != nil && alert.EndsAt.Before(*alert.StartsAt) {
+			invalid(field+".endsAt", msgInvalidExternalAlertEndTime, alert.EndsAt.Format(time.RFC3339))
+		}
+	}
+	return details
+}
+
+// externalAlertsToPostable converts the external alerts pushed by a tenant into alerts pushed to alertmanager, labeled with
+// the tenant ID by the given tenant label so that they are listed and routed like the alerts of the tenant's alert definitions.
+func externalAlertsToPostable(alerts []api.ExternalAlert, tenantLabel string, tenantID api.TenantID) []postableAlert {
+	postable := make([]postableAlert, len(alerts))
+	for i, alert := range alerts {
+		labels := make(map[string]string, len(reservedExternalLabels)+1)
+		if alert.Labels != nil {
+			maps.Copy(labels, *alert.Labels)
+		}
+		labels["alertname"] = alert.Name
+		labels["severity"] = alert.Severity
+		labels[tenantLabel] = tenantID
+		labels["alert_category"] = externalAlertCategory
+
+		postable[i] = postableAlert{
+			Labels:   labels,
+			StartsAt: alert.StartsAt,
+			EndsAt:   alert.EndsAt,
+		}
+		if alert.Annotations != nil {
+			postable[i].Annotations = *alert.Annotations
+		}
+	}
+	return postable
+}
@@ -0,0 +1,160 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/testutil"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+)
+
+func TestPostExternalAlerts(t *testing.T) {
+	var pushed []postableAlert
+	alertManager := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v2/alerts" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil || json.Unmarshal(body, &pushed) != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer alertManager.Close()
+
+	configfile := conf
+	configfile.AlertManager.URL = alertManager.URL
+	configfile.ExternalAlerts = config.ExternalAlertsConfig{
+		Enabled:   true,
+		Tenants:   []string{"edgenode"},
+		MaxAlerts: 2,
+	}
+
+	e := echo.New()
+	api.RegisterHandlers(e, NewServerInterfaceHandler(configfile, &gorm.DB{}, nil, nil))
+
+	post := func(tenantID string, body string) *httptest.ResponseRecorder {
+		return testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Post("/api/v1/alerts/external").
+			WithBody([]byte(body)).GoWithHTTPHandler(t, e).Recorder
+	}
+
+	t.Run("Alerts are pushed labeled with the tenant - code should be 202", func(t *testing.T) {
+		rec := post("edgenode", `{"alerts":[{"name":"DiskAlmostFull","severity":"warning","labels":{"host_uuid":"93bf6804"},`+
+			`"annotations":{"description":"Disk usage is above 90%"}}]}`)
+		require.Equal(t, http.StatusAccepted, rec.Code)
+
+		require.Equal(t, []postableAlert{{
+			Labels: map[string]string{
+				"alertname":      "DiskAlmostFull",
+				"severity":       "warning",
+				"projectId":      "edgenode",
+				"alert_category": "health",
+				"host_uuid":      "93bf6804",
+			},
+			Annotations: map[string]string{"description": "Disk usage is above 90%"},
+		}}, pushed)
+	})
+
+	t.Run("Alerts of a tenant which is not allowed - code should be 403", func(t *testing.T) {
+		rec := post("other-tenant", `{"alerts":[{"name":"DiskAlmostFull","severity":"warning"}]}`)
+		require.Equal(t, http.StatusForbidden, rec.Code)
+
+		var httpErr api.HttpError
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &httpErr))
+		require.Equal(t, api.ErrorCodeExternalAlertsNotAllowed, httpErr.ErrorCode)
+	})
+
+	t.Run("Invalid alerts - code should be 400", func(t *testing.T) {
+		rec := post("edgenode", `{"alerts":[{"name":"DiskAlmostFull","severity":"warning","labels":{"projectId":"other-tenant"}}]}`)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+
+		var httpErr api.HttpError
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &httpErr))
+		require.Equal(t, api.ErrorCodeInvalidRequestBody, httpErr.ErrorCode)
+		require.NotNil(t, httpErr.Details)
+		require.Equal(t, "alerts[0].labels.projectId", (*httpErr.Details)[0].Field)
+	})
+
+	t.Run("Unknown fields - code should be 400", func(t *testing.T) {
+		rec := post("edgenode", `{"alerts":[{"name":"DiskAlmostFull","severity":"warning","generatorURL":"http://app"}]}`)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("Alertmanager fails - code should be 500", func(t *testing.T) {
+		failing := configfile
+		failing.AlertManager.URL = "http://localhost:49152"
+
+		e := echo.New()
+		api.RegisterHandlers(e, NewServerInterfaceHandler(failing, &gorm.DB{}, nil, nil))
+
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", "edgenode").Post("/api/v1/alerts/external").
+			WithBody([]byte(`{"alerts":[{"name":"DiskAlmostFull","severity":"warning"}]}`)).GoWithHTTPHandler(t, e)
+		require.Equal(t, http.StatusInternalServerError, result.Recorder.Code)
+
+		var httpErr api.HttpError
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &httpErr))
+		require.Equal(t, api.ErrorCodeAlertmanagerUnavailable, httpErr.ErrorCode)
+	})
+}
+
+func TestValidateExternalAlerts(t *testing.T) {
+	labels := func(l map[string]string) *map[string]string { return &l }
+
+	tests := map[string]struct {
+		alerts []api.ExternalAlert
+		fields []string
+	}{
+		"valid alert": {
+			alerts: []api.ExternalAlert{{Name: "DiskAlmostFull", Severity: "critical", Labels: labels(map[string]string{"host_uuid": "id"})}},
+		},
+		"no alerts": {
+			fields: []string{"alerts"},
+		},
+		"too many alerts": {
+			alerts: []api.ExternalAlert{{Name: "A", Severity: "info"}, {Name: "B", Severity: "info"}, {Name: "C", Severity: "info"}},
+			fields: []string{"alerts"},
+		},
+		"invalid name and severity": {
+			alerts: []api.ExternalAlert{{Name: "disk-full", Severity: "none"}},
+			fields: []string{"alerts[0].name", "alerts[0].severity"},
+		},
+		"reserved and invalid labels": {
+			alerts: []api.ExternalAlert{{Name: "DiskAlmostFull", Severity: "info", Labels: labels(map[string]string{
+				"__name__": "x", "alert_category": "maintenance", "host-uuid": "id", "severity": "critical",
+			})}},
+			fields: []string{
+				"alerts[0].labels.__name__", "alerts[0].labels.alert_category", "alerts[0].labels.host-uuid", "alerts[0].labels.severity",
+			},
+		},
+		"reserved annotation": {
+			alerts: []api.ExternalAlert{{Name: "DiskAlmostFull", Severity: "info", Annotations: labels(map[string]string{"am_uuid": "id"})}},
+			fields: []string{"alerts[0].annotations.am_uuid"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			details := validateExternalAlerts(language.English, test.alerts, 2, config.DefaultTenantLabel)
+
+			fields := make([]string, 0, len(details))
+			for _, d := range details {
+				fields = append(fields, d.Field)
+			}
+			require.ElementsMatch(t, test.fields, fields)
+		})
+	}
+}
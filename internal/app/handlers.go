@@ -4,15 +4,14 @@
 package app
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
-	"strconv"
+	"slices"
 	"strings"
-	"time"
 
 	"github.com/labstack/echo/v4"
 	"gopkg.in/yaml.v3"
@@ -20,14 +19,56 @@ import (
 
 	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
 	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/correlation"
 	db "github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
 	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/rules"
 )
 
+// ReceiverConfigValidator validates that a receiver can notify through the notification channels and be applied to the
+// alertmanager configuration without exceeding its limits, and previews the configuration it is applied with.
+type ReceiverConfigValidator interface {
+	ValidateReceiverConfig(ctx context.Context, receiver models.DBReceiver) error
+	PreviewReceiverConfig(ctx context.Context, receiver models.DBReceiver) (api.ReceiverConfigPreview, error)
+}
+
 type ServerInterfaceHandler struct {
-	receivers   db.ReceiverHandlerManager
-	definitions db.AlertDefinitionHandlerManager
-	m2m         M2MConnection
+	receivers    db.ReceiverHandlerManager
+	definitions  db.AlertDefinitionHandlerManager
+	shards       db.TenantShardManager
+	m2m          M2MConnection
+	receiversCfg ReceiverConfigValidator
+	// tenantMetadata gets the metadata of tenants their alerts are annotated with. Alerts are not annotated if nil.
+	tenantMetadata tenantMetadataGetter
+	// evaluations gets the evaluation health of alert definitions. It is not reported if nil.
+	evaluations db.RuleEvaluationReporter
+	// tiers gets the service level of tenants their receivers are limited by. Receivers are not limited if nil.
+	tiers *tenantTiers
+	// maintenance gets and sets the maintenance mode of tenants. It is not reported along with alerts if nil.
+	maintenance db.TenantMaintenanceManager
+	// emailTemplate renders the emails of receivers. Emails cannot be previewed if nil.
+	emailTemplate emailRenderer
+	// emailTemplates gets and sets the email templates of tenants.
+	emailTemplates db.EmailTemplateManager
+	// alertComments gets and adds the comments of the alerts of tenants.
+	alertComments db.AlertCommentManager
+	// operations gets the operations applying the updates of alert definitions and receivers. They cannot be awaited if nil.
+	operations db.OperationReporter
+	// verifier asks new email recipients to confirm their address before they are notified. They are notified without
+	// verification if nil.
+	verifier *emailVerifier
+	// reports gets the weekly reports of the alert volume of tenants. They cannot be got if nil.
+	reports db.AlertReportReader
+	// hooks manages the event hooks of tenants. They cannot be managed if nil.
+	hooks *eventHooks
+	// certExpiry checks the expiry of the certificates of the downstream endpoints, which degrades the status of the service
+	// as they approach their expiry. The status is not degraded if nil.
+	certExpiry *certExpiryChecker
+	// noise scores the alert definitions of tenants by how noisy their alerts are. They cannot be scored if nil.
+	noise *noiseScorer
+	// authorizeEmailOverride authorizes the override of the sender address and mail server of the emails of receivers. They
+	// cannot be overridden if nil.
+	authorizeEmailOverride func(echo.Context) error
 
 	configuration config.Config
 }
@@ -39,6 +80,7 @@ const (
 	errHTTPFailedToGetAlertDefinition         = "failed to get alert definition"
 	errHTTPBadRequest                         = "bad request"
 	errHTTPFailedToPatchAlertDefinition       = "failed to patch alert definition"
+	errHTTPFailedToResetAlertDefinition       = "failed to reset alert definition"
 	errHTTPAlertDefinitionTemplateNotFound    = "alert definition template not found"
 	errHTTPFailedToGetAlertDefinitionTemplate = "failed to get alert definition template"
 	errHTTPFailedToGetAlertReceivers          = "failed to get alert receivers"
@@ -46,29 +88,115 @@ const (
 	errHTTPAlertReceiverNotFound              = "alert receiver not found"
 	errHTTPFailedToPatchAlertReceivers        = "failed to patch alert receivers"
 	errHTTPFailedToExtractProjectID           = "failed to extract projectID"
+	errHTTPReceiverConfigLimitExceeded        = "alert receiver exceeds alertmanager configuration limits"
+	errHTTPAlertDefinitionValueOutOfBounds    = "alert definition value/s out-of-bounds"
+	errHTTPEmailOverrideNotAllowed            = "email configuration override not allowed"
 )
 
-func NewServerInterfaceHandler(configuration config.Config, dbConn *gorm.DB, m2m M2MConnection) *ServerInterfaceHandler {
+func NewServerInterfaceHandler(configuration config.Config, dbConn *gorm.DB, m2m M2MConnection, receiversCfg ReceiverConfigValidator) *ServerInterfaceHandler {
 	return &ServerInterfaceHandler{
 		configuration: configuration,
 		receivers: &db.DBService{
-			DB: dbConn,
+			DB:                 dbConn,
+			AlertmanagerShards: configuration.AlertManager.ShardCount(),
 		},
 		definitions: &db.DBService{
-			DB: dbConn,
+			DB:                 dbConn,
+			AlertmanagerShards: configuration.AlertManager.ShardCount(),
+		},
+		shards: &db.DBService{
+			DB:                 dbConn,
+			AlertmanagerShards: configuration.AlertManager.ShardCount(),
+		},
+		m2m:            m2m,
+		receiversCfg:   receiversCfg,
+		tenantMetadata: newTenantMetadataGetter(configuration.TenantMetadata),
+		evaluations: &db.DBService{
+			DB:                 dbConn,
+			AlertmanagerShards: configuration.AlertManager.ShardCount(),
+		},
+		tiers: newTenantTiers(configuration.TenantTiers, &db.DBService{
+			DB:                 dbConn,
+			AlertmanagerShards: configuration.AlertManager.ShardCount(),
+		}),
+		maintenance: &db.DBService{
+			DB:                 dbConn,
+			AlertmanagerShards: configuration.AlertManager.ShardCount(),
+		},
+		emailTemplates: &db.DBService{
+			DB:                 dbConn,
+			AlertmanagerShards: configuration.AlertManager.ShardCount(),
+		},
+		alertComments: &db.DBService{
+			DB:                 dbConn,
+			AlertmanagerShards: configuration.AlertManager.ShardCount(),
+		},
+		operations: &db.DBService{
+			DB:                 dbConn,
+			AlertmanagerShards: configuration.AlertManager.ShardCount(),
 		},
-		m2m: m2m,
+		reports: &db.DBService{
+			DB:                 dbConn,
+			AlertmanagerShards: configuration.AlertManager.ShardCount(),
+		},
+		authorizeEmailOverride: authorizeEmailOverride,
 	}
 }
 
 func (w *ServerInterfaceHandler) GetAlerts(ctx echo.Context, tenantID api.TenantID, params api.GetProjectAlertsParams) error {
+	format, httpErr := exportFormat(ctx, params.Format)
+	if httpErr != nil {
+		return ctx.JSON(httpErr.Code, httpErr)
+	}
+
+	alerts, httpErr := w.getAlerts(ctx, tenantID, params)
+	if httpErr != nil {
+		return ctx.JSON(httpErr.Code, httpErr)
+	}
+
+	if format == api.ExportFormatCSV {
+		rows := make([]exportRow, 0, len(*alerts.Alerts))
+		for _, a := range *alerts.Alerts {
+			rows = append(rows, alertToExportRow(a))
+		}
+		return exportCSV(ctx, "alerts", alertExportColumns, rows)
+	}
+
+	// The maintenance mode of the tenant is reported, since its alerts are all silenced meanwhile.
+	if w.maintenance != nil {
+		window, err := w.maintenance.GetTenantMaintenance(ctx.Request().Context(), tenantID)
+		if err != nil {
+			logError(ctx, "Failed to get maintenance mode", err)
+			return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+				Code:      http.StatusInternalServerError,
+				Message:   errHTTPFailedToGetAlerts,
+				ErrorCode: api.ErrorCodeInternalError,
+			})
+		}
+		alerts.MaintenanceMode = maintenanceMode(window)
+	}
+
+	// Response formatted as AlertList structure
+	return ctx.JSONPretty(http.StatusOK, alerts, "\t")
+}
+
+// getAlerts retrieves the alerts of the given tenant from alertmanager, as served by the alerts endpoint.
+func (w *ServerInterfaceHandler) getAlerts(ctx echo.Context, tenantID api.TenantID, params api.GetProjectAlertsParams) (*api.AlertList, *api.HttpError) {
 	unmarshalledResponse := new(api.AlertList)
 	conf := w.configuration
-	urlRaw := conf.AlertManager.URL
+	urlRaw, err := w.alertManagerURL(ctx.Request().Context(), tenantID)
+	if err != nil {
+		logError(ctx, "Failed to get alertmanager shard", err)
+		return nil, &api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToGetAlerts,
+			ErrorCode: api.ErrorCodeInternalError,
+		}
+	}
 	outparams := getAlertsParamsToURL(params)
 
-	// Filtering by tenant
-	outparams.Add("filter", "projectId="+tenantID)
+	// Filtering by tenant in alertmanager, rather than after the alerts are downloaded
+	outparams.Add("filter", TenantFilter(w.tenantLabel(), tenantID))
 
 	// Sending GET request to alertmanager
 	encodedParams := outparams.Encode()
@@ -81,19 +209,32 @@ func (w *ServerInterfaceHandler) GetAlerts(ctx echo.Context, tenantID api.Tenant
 	u, err := url.Parse(urlRaw)
 	if err != nil {
 		logError(ctx, "Error parsing alertmanager URL", err)
-		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
-			Code:    http.StatusInternalServerError,
-			Message: errHTTPFailedToGetAlerts,
-		})
+		return nil, &api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToGetAlerts,
+			ErrorCode: api.ErrorCodeInternalError,
+		}
 	}
 
-	resp, err := http.Get(u.String())
+	req, err := http.NewRequestWithContext(ctx.Request().Context(), http.MethodGet, u.String(), nil)
+	if err != nil {
+		logError(ctx, "Error creating alertmanager request", err)
+		return nil, &api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToGetAlerts,
+			ErrorCode: api.ErrorCodeInternalError,
+		}
+	}
+	correlation.SetHeader(req)
+
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		logError(ctx, "Failed to reach alertmanager", err)
-		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
-			Code:    http.StatusInternalServerError,
-			Message: errHTTPFailedToGetAlerts,
-		})
+		return nil, &api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToGetAlerts,
+			ErrorCode: api.ErrorCodeAlertmanagerUnavailable,
+		}
 	}
 
 	defer resp.Body.Close()
@@ -101,116 +242,221 @@ func (w *ServerInterfaceHandler) GetAlerts(ctx echo.Context, tenantID api.Tenant
 	// Check if GET request have http code 200
 	if resp.StatusCode != http.StatusOK {
 		logWarn(ctx, fmt.Sprintf("Alertmanager returned HTTP status code: %v", resp.StatusCode))
-		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
-			Code:    http.StatusInternalServerError,
-			Message: errHTTPFailedToGetAlerts,
-		})
+		return nil, &api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToGetAlerts,
+			ErrorCode: api.ErrorCodeAlertmanagerUnavailable,
+		}
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	// The response is decoded as it is read, as alertmanager may return tens of thousands of alerts.
+	alerts, warnings, err := decodeAlerts(resp.Body)
 	if err != nil {
-		logError(ctx, "Failed to read response body", err)
-		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
-			Code:    http.StatusInternalServerError,
-			Message: errHTTPFailedToGetAlerts,
+		logError(ctx, "Error unmarshalling response body", err)
+		return nil, &api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToGetAlerts,
+			ErrorCode: api.ErrorCodeInternalError,
+		}
+	}
+	unmarshalledResponse.Alerts = &alerts
+
+	// Malformed alerts are left out and reported, rather than failing the list of the whole tenant.
+	if len(warnings) > 0 {
+		for _, warning := range warnings {
+			malformedAlerts.WithLabelValues(tenantID, string(warning.Reason)).Inc()
+			logWarn(ctx, fmt.Sprintf("Malformed alert of tenant %q left out: %v", tenantID, warning.Message))
+		}
+		unmarshalledResponse.Warnings = &warnings
+	}
+
+	if metadata, ok := getTenantMetadata(ctx, w.tenantMetadata, tenantID); ok {
+		annotateAlerts(unmarshalledResponse.Alerts, metadata)
+	}
+
+	redactAlerts(unmarshalledResponse.Alerts, conf.RedactionPatterns)
+
+	return unmarshalledResponse, nil
+}
+
+// GetAlertsByResource groups the active alerts of the given tenant, which are not suppressed, by the resource they are raised for.
+func (w *ServerInterfaceHandler) GetAlertsByResource(ctx echo.Context, tenantID api.TenantID, params api.GetProjectAlertsByResourceParams) error {
+	groupBy := api.Host
+	if params.GroupBy != nil {
+		groupBy = *params.GroupBy
+	}
+	label, ok := resourceLabels[groupBy]
+	if !ok {
+		logWarn(ctx, fmt.Sprintf("Invalid groupBy parameter: %q", groupBy))
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPBadRequest,
+			ErrorCode: api.ErrorCodeInvalidParameter,
 		})
 	}
 
-	err = json.Unmarshal(body, &unmarshalledResponse.Alerts)
+	active, suppressed := true, false
+	alerts, httpErr := w.getAlerts(ctx, tenantID, api.GetProjectAlertsParams{Active: &active, Suppressed: &suppressed})
+	if httpErr != nil {
+		return ctx.JSON(httpErr.Code, httpErr)
+	}
+
+	return ctx.JSON(http.StatusOK, api.AlertResourceList{Resources: groupAlertsByResource(*alerts.Alerts, label)})
+}
+
+func (w *ServerInterfaceHandler) GetAlertDefinitions(ctx echo.Context, tenantID api.TenantID, params api.GetProjectAlertDefinitionsParams) error {
+	opts, err := parseListOptions(params.Limit, params.Offset, params.SortBy, params.Order)
 	if err != nil {
-		logError(ctx, "Error unmarshalling response body", err)
-		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
-			Code:    http.StatusInternalServerError,
-			Message: errHTTPFailedToGetAlerts,
+		logError(ctx, "Invalid pagination or sorting parameters", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPBadRequest,
+			ErrorCode: api.ErrorCodeInvalidParameter,
 		})
 	}
 
-	err = filterAnnotations(unmarshalledResponse.Alerts)
+	fields, err := parseFields(params.Fields, alertDefinitionFields)
 	if err != nil {
-		logError(ctx, "Error filtering annotations", err)
-		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
-			Code:    http.StatusInternalServerError,
-			Message: errHTTPFailedToGetAlerts,
+		logError(ctx, "Invalid fields parameter", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPBadRequest,
+			ErrorCode: api.ErrorCodeInvalidParameter,
 		})
 	}
 
-	filterOutMaintenanceAlerts(unmarshalledResponse.Alerts)
-
-	// Response formatted as AlertList structure
-	return ctx.JSONPretty(http.StatusOK, unmarshalledResponse, "\t")
-}
+	format, httpErr := exportFormat(ctx, params.Format)
+	if httpErr != nil {
+		return ctx.JSON(httpErr.Code, httpErr)
+	}
 
-func (w *ServerInterfaceHandler) GetAlertDefinitions(ctx echo.Context, tenantID api.TenantID) error {
-	dbDefinitions, err := w.definitions.GetLatestAlertDefinitionList(ctx.Request().Context(), tenantID)
+	dbDefinitions, total, err := w.definitions.GetLatestAlertDefinitionList(ctx.Request().Context(), tenantID, opts)
 	if err != nil {
 		logError(ctx, errHTTPFailedToGetAlertDefinitions, err)
 		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
-			Code:    http.StatusInternalServerError,
-			Message: errHTTPFailedToGetAlertDefinitions,
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToGetAlertDefinitions,
+			ErrorCode: api.ErrorCodeInternalError,
 		})
 	}
 
+	var firingCounts map[api.AlertDefinitionId]int
+	if params.WithStatus != nil && *params.WithStatus && fields.has("firingCount") {
+		var amURL string
+		amURL, err = w.alertManagerURL(ctx.Request().Context(), tenantID)
+		if err == nil {
+			firingCounts, err = getFiringAlertCounts(ctx.Request().Context(), amURL, w.tenantLabel(), tenantID)
+		}
+		if err != nil {
+			logError(ctx, "Failed to get firing alerts from alertmanager", err)
+			return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+				Code:      http.StatusInternalServerError,
+				Message:   errHTTPFailedToGetAlertDefinitions,
+				ErrorCode: api.ErrorCodeInternalError,
+			})
+		}
+	}
+
+	var evaluationHealth map[api.AlertDefinitionId]models.RuleEvaluationHealth
+	if w.evaluations != nil && fields.has("evaluationHealth") {
+		ids := make([]api.AlertDefinitionId, 0, len(dbDefinitions))
+		for _, d := range dbDefinitions {
+			ids = append(ids, d.ID)
+		}
+		evaluationHealth, err = w.evaluations.GetRuleEvaluationHealth(ctx.Request().Context(), tenantID, ids)
+		if err != nil {
+			logError(ctx, "Failed to get evaluation health of alert definitions", err)
+			return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+				Code:      http.StatusInternalServerError,
+				Message:   errHTTPFailedToGetAlertDefinitions,
+				ErrorCode: api.ErrorCodeInternalError,
+			})
+		}
+	}
+
 	definitions := make([]api.AlertDefinition, 0, len(dbDefinitions))
 	for _, d := range dbDefinitions {
 		if d.Category == models.CategoryMaintenance {
 			continue
 		}
-		uuid := d.ID
-		name := d.Name
-		state := api.StateDefinition(d.State)
-		values := map[string]string{
-			"duration":  FormatDuration(time.Duration(*d.Values.Duration) * time.Second),
-			"threshold": strconv.FormatInt(*d.Values.Threshold, 10),
-			"enabled":   strconv.FormatBool(*d.Values.Enabled),
+		def := alertDefinitionToAPI(d)
+		if firingCounts != nil {
+			firingCount := firingCounts[d.ID]
+			def.FiringCount = &firingCount
 		}
-		version := int(d.Version)
-		definitions = append(definitions, api.AlertDefinition{
-			Id:      &uuid,
-			Name:    &name,
-			State:   &state,
-			Values:  &values,
-			Version: &version,
-		})
+		if health, ok := evaluationHealth[d.ID]; ok {
+			def.EvaluationHealth = evaluationHealthToAPI(health, w.configuration.RuleEvaluation.SlowThreshold)
+		}
+		definitions = append(definitions, selectAlertDefinitionFields(def, fields))
+	}
+
+	if format == api.ExportFormatCSV {
+		columns := slices.DeleteFunc(slices.Clone(alertDefinitionExportColumns), func(c string) bool { return !fields.has(c) })
+		rows := make([]exportRow, 0, len(definitions))
+		for _, d := range definitions {
+			rows = append(rows, alertDefinitionToExportRow(d))
+		}
+		return exportCSV(ctx, "alert-definitions", columns, rows)
 	}
 
 	return ctx.JSON(http.StatusOK, api.AlertDefinitionList{
 		AlertDefinitions: &definitions,
+		TotalCount:       int(total),
 	})
 }
 
-func (w *ServerInterfaceHandler) GetAlertDefinition(ctx echo.Context, tenantID api.TenantID, id api.AlertDefinitionId) error {
+func (w *ServerInterfaceHandler) GetAlertDefinition(
+	ctx echo.Context, tenantID api.TenantID, id api.AlertDefinitionId, params api.GetProjectAlertDefinitionParams,
+) error {
+	fields, err := parseFields(params.Fields, alertDefinitionFields)
+	if err != nil {
+		logError(ctx, "Invalid fields parameter", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPBadRequest,
+			ErrorCode: api.ErrorCodeInvalidParameter,
+		})
+	}
+
 	ad, err := w.definitions.GetLatestAlertDefinition(ctx.Request().Context(), tenantID, id)
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		logError(ctx, fmt.Sprintf("Alert definition not found: %q", id), err)
 		return ctx.JSON(http.StatusNotFound, api.HttpError{
-			Code:    http.StatusNotFound,
-			Message: errHTTPAlertDefinitionNotFound,
+			Code:      http.StatusNotFound,
+			Message:   errHTTPAlertDefinitionNotFound,
+			ErrorCode: api.ErrorCodeDefinitionNotFound,
 		})
 	} else if err != nil {
 		logError(ctx, fmt.Sprintf("Failed to retrieve alert definition: %q", id), err)
 		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
-			Code:    http.StatusInternalServerError,
-			Message: errHTTPFailedToGetAlertDefinition,
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToGetAlertDefinition,
+			ErrorCode: api.ErrorCodeInternalError,
 		})
 	}
 
-	state := api.StateDefinition(ad.State)
-	values := map[string]string{
-		"threshold": strconv.FormatInt(*ad.Values.Threshold, 10),
-		"duration":  FormatDuration(time.Duration(*ad.Values.Duration) * time.Second),
-		"enabled":   strconv.FormatBool(*ad.Values.Enabled),
+	def := alertDefinitionToAPI(ad)
+
+	if w.evaluations != nil && fields.has("evaluationHealth") {
+		evaluationHealth, err := w.evaluations.GetRuleEvaluationHealth(ctx.Request().Context(), tenantID, []api.AlertDefinitionId{ad.ID})
+		if err != nil {
+			logError(ctx, fmt.Sprintf("Failed to get evaluation health of alert definition: %q", id), err)
+			return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+				Code:      http.StatusInternalServerError,
+				Message:   errHTTPFailedToGetAlertDefinition,
+				ErrorCode: api.ErrorCodeInternalError,
+			})
+		}
+		if health, ok := evaluationHealth[ad.ID]; ok {
+			def.EvaluationHealth = evaluationHealthToAPI(health, w.configuration.RuleEvaluation.SlowThreshold)
+		}
 	}
-	version := int(ad.Version)
-	return ctx.JSON(http.StatusOK, api.AlertDefinition{
-		Id:      &ad.ID,
-		Name:    &ad.Name,
-		State:   &state,
-		Values:  &values,
-		Version: &version,
-	})
+	return ctx.JSON(http.StatusOK, selectAlertDefinitionFields(def, fields))
 }
 
-func (w *ServerInterfaceHandler) PatchAlertDefinition(ctx echo.Context, tenantID api.TenantID, id api.AlertDefinitionId) error {
+// PatchAlertDefinition updates the values of an alert definition, which are applied asynchronously. If requested, the operation
+// applying them is returned instead of the values, so that it can be awaited.
+func (w *ServerInterfaceHandler) PatchAlertDefinition(ctx echo.Context, tenantID api.TenantID, id api.AlertDefinitionId, params api.PatchProjectAlertDefinitionParams) error {
 	var reqBody api.PatchProjectAlertDefinitionJSONBody
 
 	dec := json.NewDecoder(ctx.Request().Body)
@@ -218,44 +464,138 @@ func (w *ServerInterfaceHandler) PatchAlertDefinition(ctx echo.Context, tenantID
 	if err := dec.Decode(&reqBody); err != nil {
 		logError(ctx, "Failed to parse body of alert definition", err)
 		return ctx.JSON(http.StatusBadRequest, api.HttpError{
-			Code:    http.StatusBadRequest,
-			Message: errHTTPBadRequest,
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPBadRequest,
+			ErrorCode: api.ErrorCodeInvalidRequestBody,
 		})
 	}
 
-	values, err := parseAlertDefinitionValues(reqBody)
+	values, err := ParseAlertDefinitionValues(reqBody)
 	if err != nil {
 		logError(ctx, "Failed to parse alert definition values", err)
-		return ctx.JSON(http.StatusBadRequest, api.HttpError{
-			Code:    http.StatusBadRequest,
-			Message: errHTTPFailedToPatchAlertDefinition,
-		})
+		lang := responseLanguage(ctx)
+		httpErr := api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   localize(lang, msgFailedToPatchAlertDefinition),
+			ErrorCode: api.ErrorCodeInvalidRequestBody,
+		}
+		if errors.Is(err, errInvalidDuration) {
+			httpErr.Details = &[]api.ErrorDetail{{
+				Field:  "values.duration",
+				Reason: localize(lang, msgInvalidDuration),
+				Value:  reqBody.Values.Duration,
+			}}
+		}
+		return ctx.JSON(http.StatusBadRequest, httpErr)
+	}
+
+	if limits, ok := w.configuration.Mimir.ExpressionCost.TenantLimits(tenantID); ok {
+		ad, err := w.definitions.GetLatestAlertDefinition(ctx.Request().Context(), tenantID, id)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logError(ctx, fmt.Sprintf("Alert definition not found: %q", id), err)
+			return ctx.JSON(http.StatusNotFound, api.HttpError{
+				Code:      http.StatusNotFound,
+				Message:   errHTTPAlertDefinitionNotFound,
+				ErrorCode: api.ErrorCodeDefinitionNotFound,
+			})
+		} else if err != nil {
+			logError(ctx, fmt.Sprintf("Failed to retrieve alert definition: %q", id), err)
+			return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+				Code:      http.StatusInternalServerError,
+				Message:   errHTTPFailedToPatchAlertDefinition,
+				ErrorCode: api.ErrorCodeInternalError,
+			})
+		}
+
+		err = checkExpressionCost(mergeAlertDefinitionValues(ad.Values, *values), ad.Template, limits)
+		switch {
+		case errors.Is(err, rules.ErrExpressionTooExpensive) && w.configuration.Mimir.ExpressionCost.Enforce:
+			logError(ctx, fmt.Sprintf("Expression of alert definition is too expensive: %q", id), err)
+			return ctx.JSON(http.StatusBadRequest, api.HttpError{
+				Code:      http.StatusBadRequest,
+				Message:   localize(responseLanguage(ctx), msgExpressionTooExpensive),
+				ErrorCode: api.ErrorCodeDefinitionTooExpensive,
+			})
+		case errors.Is(err, rules.ErrExpressionTooExpensive):
+			logWarn(ctx, fmt.Sprintf("Expression of alert definition %q is likely to overload the ruler: %v", id, err))
+		case err != nil:
+			logError(ctx, fmt.Sprintf("Failed to estimate the cost of the expression of alert definition: %q", id), err)
+			return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+				Code:      http.StatusInternalServerError,
+				Message:   errHTTPFailedToPatchAlertDefinition,
+				ErrorCode: api.ErrorCodeInternalError,
+			})
+		}
 	}
 
-	if err := w.definitions.SetAlertDefinitionValues(ctx.Request().Context(), tenantID, id, *values); err != nil {
+	updateCtx, operationID := withOperation(ctx.Request().Context(), params.Async != nil && *params.Async)
+	if err := w.definitions.SetAlertDefinitionValues(updateCtx, tenantID, id, *values); err != nil {
 		switch {
 		case errors.Is(err, gorm.ErrRecordNotFound):
 			logError(ctx, fmt.Sprintf("Alert definition not found: %q", id), err)
 			return ctx.JSON(http.StatusNotFound, api.HttpError{
-				Code:    http.StatusNotFound,
-				Message: errHTTPAlertDefinitionNotFound,
+				Code:      http.StatusNotFound,
+				Message:   errHTTPAlertDefinitionNotFound,
+				ErrorCode: api.ErrorCodeDefinitionNotFound,
 			})
 		case errors.Is(err, db.ErrValueOutOfBounds):
-			logError(ctx, fmt.Sprintf("Alert definition value/s are out-of-bounds: %q", id), err)
+			RecordOutOfBoundsRejection(ctx.Request().Context(), tenantID, id, err)
+			lang := responseLanguage(ctx)
 			return ctx.JSON(http.StatusBadRequest, api.HttpError{
-				Code:    http.StatusBadRequest,
-				Message: "alert definition value/s out-of-bounds",
+				Code:      http.StatusBadRequest,
+				Message:   localize(lang, msgAlertDefinitionValueOutOfBounds),
+				ErrorCode: api.ErrorCodeDefinitionValueOutOfBounds,
+				Details:   outOfBoundsDetails(lang, err),
 			})
 		default:
 			logError(ctx, fmt.Sprintf("Failed to set alert definition values: %q", id), err)
 			return ctx.JSON(http.StatusInternalServerError, api.HttpError{
-				Code:    http.StatusInternalServerError,
-				Message: errHTTPFailedToPatchAlertDefinition,
+				Code:      http.StatusInternalServerError,
+				Message:   errHTTPFailedToPatchAlertDefinition,
+				ErrorCode: api.ErrorCodeInternalError,
 			})
 		}
 	}
 
-	return ctx.NoContent(http.StatusNoContent)
+	if operationID != nil {
+		return ctx.JSON(http.StatusAccepted, api.OperationAccepted{OperationId: *operationID})
+	}
+
+	// The values are echoed back in their canonical form, e.g. a duration of "90" is set as "1m30s".
+	formatted := formatAlertDefinitionValues(*values)
+	return ctx.JSON(http.StatusOK, api.AlertDefinitionValues{Values: &formatted})
+}
+
+func (w *ServerInterfaceHandler) ResetAlertDefinitionDefaults(ctx echo.Context, tenantID api.TenantID, id api.AlertDefinitionId) error {
+	values, err := w.definitions.ResetAlertDefinitionValues(ctx.Request().Context(), tenantID, id)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		logError(ctx, fmt.Sprintf("Alert definition not found: %q", id), err)
+		return ctx.JSON(http.StatusNotFound, api.HttpError{
+			Code:      http.StatusNotFound,
+			Message:   errHTTPAlertDefinitionNotFound,
+			ErrorCode: api.ErrorCodeDefinitionNotFound,
+		})
+	case errors.Is(err, db.ErrValueOutOfBounds):
+		RecordOutOfBoundsRejection(ctx.Request().Context(), tenantID, id, err)
+		lang := responseLanguage(ctx)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   localize(lang, msgAlertDefinitionValueOutOfBounds),
+			ErrorCode: api.ErrorCodeDefinitionValueOutOfBounds,
+			Details:   outOfBoundsDetails(lang, err),
+		})
+	case err != nil:
+		logError(ctx, fmt.Sprintf("Failed to reset alert definition values: %q", id), err)
+		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToResetAlertDefinition,
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+
+	formatted := formatAlertDefinitionValues(*values)
+	return ctx.JSON(http.StatusOK, api.AlertDefinitionValues{Values: &formatted})
 }
 
 func (w *ServerInterfaceHandler) GetAlertDefinitionRule(ctx echo.Context, tenantID api.TenantID, id api.AlertDefinitionId,
@@ -264,14 +604,16 @@ func (w *ServerInterfaceHandler) GetAlertDefinitionRule(ctx echo.Context, tenant
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		logError(ctx, fmt.Sprintf("Alert definition not found: %q", id), err)
 		return ctx.JSON(http.StatusNotFound, api.HttpError{
-			Code:    http.StatusNotFound,
-			Message: errHTTPAlertDefinitionTemplateNotFound,
+			Code:      http.StatusNotFound,
+			Message:   errHTTPAlertDefinitionTemplateNotFound,
+			ErrorCode: api.ErrorCodeDefinitionNotFound,
 		})
 	} else if err != nil {
 		logError(ctx, fmt.Sprintf("Failed to retrieve alert definition template: %q", id), err)
 		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
-			Code:    http.StatusInternalServerError,
-			Message: errHTTPFailedToGetAlertDefinitionTemplate,
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToGetAlertDefinitionTemplate,
+			ErrorCode: api.ErrorCodeInternalError,
 		})
 	}
 
@@ -286,8 +628,9 @@ func (w *ServerInterfaceHandler) GetAlertDefinitionRule(ctx echo.Context, tenant
 		if err := yaml.Unmarshal([]byte(ad.Template), &apiResponse); err != nil {
 			logError(ctx, fmt.Sprintf("Failed to unmarshal template into template api response struct: %q", id), err)
 			return ctx.JSON(http.StatusInternalServerError, api.HttpError{
-				Code:    http.StatusInternalServerError,
-				Message: errHTTPFailedToGetAlertDefinitionTemplate,
+				Code:      http.StatusInternalServerError,
+				Message:   errHTTPFailedToGetAlertDefinitionTemplate,
+				ErrorCode: api.ErrorCodeInternalError,
 			})
 		}
 		return ctx.JSON(http.StatusOK, apiResponse)
@@ -297,33 +640,64 @@ func (w *ServerInterfaceHandler) GetAlertDefinitionRule(ctx echo.Context, tenant
 	if err != nil {
 		logError(ctx, fmt.Sprintf("Failed to render alert definition template: %q", id), err)
 		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
-			Code:    http.StatusInternalServerError,
-			Message: errHTTPFailedToGetAlertDefinitionTemplate,
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToGetAlertDefinitionTemplate,
+			ErrorCode: api.ErrorCodeInternalError,
 		})
 	}
 
+	// The rule of a disabled alert definition is removed from Mimir rather than evaluated.
+	deployed := ad.Values.Enabled == nil || *ad.Values.Enabled
+	apiResponse.Deployed = &deployed
+
 	return ctx.JSON(http.StatusOK, apiResponse)
 }
 
-func (w *ServerInterfaceHandler) GetAlertReceivers(ctx echo.Context, tenantID api.TenantID) error {
-	dbRecvs, err := w.receivers.GetLatestReceiverListWithEmailConfig(ctx.Request().Context(), tenantID)
+func (w *ServerInterfaceHandler) GetAlertReceivers(ctx echo.Context, tenantID api.TenantID, params api.GetProjectAlertReceiversParams) error {
+	opts, err := parseListOptions(params.Limit, params.Offset, params.SortBy, params.Order)
 	if err != nil {
-		logError(ctx, "Failed to get alert receivers", err)
-		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
-			Code:    http.StatusInternalServerError,
-			Message: errHTTPFailedToGetAlertReceivers,
+		logError(ctx, "Invalid pagination or sorting parameters", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPBadRequest,
+			ErrorCode: api.ErrorCodeInvalidParameter,
+		})
+	}
+
+	fields, err := parseFields(params.Fields, receiverFields)
+	if err != nil {
+		logError(ctx, "Invalid fields parameter", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPBadRequest,
+			ErrorCode: api.ErrorCodeInvalidParameter,
 		})
 	}
 
-	allowedEmailRecipients, err := getAllowedEmailList(ctx, w.m2m)
+	dbRecvs, total, err := w.receivers.GetLatestReceiverListWithEmailConfig(ctx.Request().Context(), tenantID, opts)
 	if err != nil {
-		logError(ctx, "Failed to get allowed email recipient list", err)
+		logError(ctx, "Failed to get alert receivers", err)
 		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
-			Code:    http.StatusInternalServerError,
-			Message: errHTTPFailedToGetAlertReceivers,
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToGetAlertReceivers,
+			ErrorCode: api.ErrorCodeInternalError,
 		})
 	}
 
+	// The allowed email recipients are only needed when the email configuration is selected.
+	var allowedEmailRecipients api.EmailRecipientList
+	if fields.has("emailConfig") {
+		allowedEmailRecipients, err = getAllowedEmailList(ctx, w.m2m)
+		if err != nil {
+			logError(ctx, "Failed to get allowed email recipient list", err)
+			return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+				Code:      http.StatusInternalServerError,
+				Message:   errHTTPFailedToGetAlertReceivers,
+				ErrorCode: api.ErrorCodeInternalError,
+			})
+		}
+	}
+
 	receivers := make([]api.Receiver, len(dbRecvs))
 	for i, recv := range dbRecvs {
 		uuid := recv.UUID
@@ -332,80 +706,123 @@ func (w *ServerInterfaceHandler) GetAlertReceivers(ctx echo.Context, tenantID ap
 		mailServer := recv.MailServer
 		from := recv.From
 		to := recv.To
-		receivers[i] = api.Receiver{
-			Id:      &uuid,
-			State:   &state,
-			Version: &version,
+		receivers[i] = selectReceiverFields(api.Receiver{
+			Id:          &uuid,
+			State:       &state,
+			Version:     &version,
+			MinSeverity: receiverSeverityToAPI(recv.MinSeverity),
+			QuietHours:  quietHoursToAPI(recv.QuietHours),
+			OnCall:      onCallToAPI(recv.OnCallRoutingKey),
+			Language:    languageToAPI(recv.Language),
+			Enabled:     enabledToAPI(recv.Disabled),
+			Matchers:    matchersToAPI(recv.Matchers),
+			CreatedAt:   timeToAPI(recv.CreatedAt),
+			UpdatedAt:   timeToAPI(recv.UpdatedAt),
+			AppliedAt:   timePtrToAPI(recv.AppliedAt),
 			EmailConfig: &api.EmailConfig{
 				From:       &from,
 				MailServer: &mailServer,
 				To: &struct {
-					Allowed *api.EmailRecipientList `json:"allowed,omitempty"`
-					Enabled *api.EmailRecipientList `json:"enabled,omitempty"`
+					Allowed    *api.EmailRecipientList `json:"allowed,omitempty"`
+					Enabled    *api.EmailRecipientList `json:"enabled,omitempty"`
+					Unverified *api.EmailRecipientList `json:"unverified,omitempty"`
 				}{
-					Allowed: &allowedEmailRecipients,
-					Enabled: &to,
+					Allowed:    &allowedEmailRecipients,
+					Enabled:    &to,
+					Unverified: unverifiedRecipientsToAPI(recv.Unverified),
 				},
 			},
-		}
+		}, fields)
 	}
 
-	return ctx.JSON(http.StatusOK, api.ReceiverList{Receivers: &receivers})
+	return ctx.JSON(http.StatusOK, api.ReceiverList{Receivers: &receivers, TotalCount: int(total)})
 }
 
-func (w *ServerInterfaceHandler) GetAlertReceiver(ctx echo.Context, tenantID api.TenantID, id api.ReceiverId) error {
+func (w *ServerInterfaceHandler) GetAlertReceiver(ctx echo.Context, tenantID api.TenantID, id api.ReceiverId, params api.GetProjectAlertReceiverParams) error {
+	fields, err := parseFields(params.Fields, receiverFields)
+	if err != nil {
+		logError(ctx, "Invalid fields parameter", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPBadRequest,
+			ErrorCode: api.ErrorCodeInvalidParameter,
+		})
+	}
+
 	recv, err := w.receivers.GetLatestReceiverWithEmailConfig(ctx.Request().Context(), tenantID, id)
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		logError(ctx, fmt.Sprintf("Alert receiver not found: %q", id), err)
 		return ctx.JSON(http.StatusNotFound, api.HttpError{
-			Code:    http.StatusNotFound,
-			Message: errHTTPAlertReceiverNotFound,
+			Code:      http.StatusNotFound,
+			Message:   errHTTPAlertReceiverNotFound,
+			ErrorCode: api.ErrorCodeReceiverNotFound,
 		})
 	} else if err != nil {
 		logError(ctx, fmt.Sprintf("Failed to get alert receiver with UUID: %q", id), err)
 		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
-			Code:    http.StatusInternalServerError,
-			Message: errHTTPFailedToGetAlertReceiver,
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToGetAlertReceiver,
+			ErrorCode: api.ErrorCodeInternalError,
 		})
 	}
 
-	allowedEmailRecipients, err := getAllowedEmailList(ctx, w.m2m)
-	if err != nil {
-		logError(ctx, "Failed to get allowed email recipient list", err)
-		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
-			Code:    http.StatusInternalServerError,
-			Message: errHTTPFailedToGetAlertReceiver,
-		})
+	// The allowed email recipients are only needed when the email configuration is selected.
+	var allowedEmailRecipients api.EmailRecipientList
+	if fields.has("emailConfig") {
+		allowedEmailRecipients, err = getAllowedEmailList(ctx, w.m2m)
+		if err != nil {
+			logError(ctx, "Failed to get allowed email recipient list", err)
+			return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+				Code:      http.StatusInternalServerError,
+				Message:   errHTTPFailedToGetAlertReceiver,
+				ErrorCode: api.ErrorCodeInternalError,
+			})
+		}
 	}
 
 	state := api.StateDefinition(recv.State)
-	return ctx.JSON(http.StatusOK, api.Receiver{
-		Id:      &recv.UUID,
-		Version: &recv.Version,
-		State:   &state,
+	return ctx.JSON(http.StatusOK, selectReceiverFields(api.Receiver{
+		Id:          &recv.UUID,
+		Version:     &recv.Version,
+		State:       &state,
+		MinSeverity: receiverSeverityToAPI(recv.MinSeverity),
+		QuietHours:  quietHoursToAPI(recv.QuietHours),
+		OnCall:      onCallToAPI(recv.OnCallRoutingKey),
+		Language:    languageToAPI(recv.Language),
+		Enabled:     enabledToAPI(recv.Disabled),
+		Matchers:    matchersToAPI(recv.Matchers),
+		CreatedAt:   timeToAPI(recv.CreatedAt),
+		UpdatedAt:   timeToAPI(recv.UpdatedAt),
+		AppliedAt:   timePtrToAPI(recv.AppliedAt),
 		EmailConfig: &api.EmailConfig{
 			MailServer: &recv.MailServer,
 			From:       &recv.From,
 			To: &struct {
-				Allowed *api.EmailRecipientList `json:"allowed,omitempty"`
-				Enabled *api.EmailRecipientList `json:"enabled,omitempty"`
+				Allowed    *api.EmailRecipientList `json:"allowed,omitempty"`
+				Enabled    *api.EmailRecipientList `json:"enabled,omitempty"`
+				Unverified *api.EmailRecipientList `json:"unverified,omitempty"`
 			}{
-				Allowed: &allowedEmailRecipients,
-				Enabled: &recv.To,
+				Allowed:    &allowedEmailRecipients,
+				Enabled:    &recv.To,
+				Unverified: unverifiedRecipientsToAPI(recv.Unverified),
 			},
 		},
-	})
+	}, fields))
 }
 
-func (w *ServerInterfaceHandler) PatchAlertReceiver(ctx echo.Context, tenantID api.TenantID, id api.ReceiverId) error {
+// PatchAlertReceiver updates the values of a receiver, which are applied asynchronously. If requested, the operation applying
+// them is returned, so that it can be awaited, along with the receiver and route blocks of the alertmanager configuration the
+// update is to be applied with if previewed, so that its effect can be verified before it is applied.
+func (w *ServerInterfaceHandler) PatchAlertReceiver(ctx echo.Context, tenantID api.TenantID, id api.ReceiverId, params api.PatchProjectAlertReceiverParams) error {
 	var reqBody api.PatchProjectAlertReceiverJSONBody
 	dec := json.NewDecoder(ctx.Request().Body)
 	dec.DisallowUnknownFields()
 	if err := dec.Decode(&reqBody); err != nil {
 		logError(ctx, "Failed to parse body of alert receiver", err)
 		return ctx.JSON(http.StatusBadRequest, api.HttpError{
-			Code:    http.StatusBadRequest,
-			Message: errHTTPBadRequest,
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPBadRequest,
+			ErrorCode: api.ErrorCodeInvalidRequestBody,
 		})
 	}
 
@@ -413,64 +830,329 @@ func (w *ServerInterfaceHandler) PatchAlertReceiver(ctx echo.Context, tenantID a
 	if err != nil {
 		logError(ctx, "Failed to get allowed email recipients", err)
 		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
-			Code:    http.StatusInternalServerError,
-			Message: errHTTPFailedToPatchAlertReceivers,
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToPatchAlertReceivers,
+			ErrorCode: api.ErrorCodeInternalError,
 		})
 	}
 
 	// Ensures email recipients are allowed.
 	if err := validateRecipients(reqBody.EmailConfig.To.Enabled, allowed); err != nil {
 		logError(ctx, "Email recipient list contains not allowed email recipient/s", err)
+		lang := responseLanguage(ctx)
 		return ctx.JSON(http.StatusBadRequest, api.HttpError{
-			Code:    http.StatusBadRequest,
-			Message: errHTTPBadRequest,
+			Code:      http.StatusBadRequest,
+			Message:   localize(lang, msgBadRequest),
+			ErrorCode: api.ErrorCodeRecipientNotAllowed,
+			Details:   notAllowedRecipientDetails(lang, "emailConfig.to.enabled", reqBody.EmailConfig.To.Enabled, allowed),
 		})
 	}
 
-	emailRecipients, err := parseEmailRecipients(reqBody.EmailConfig.To.Enabled)
+	values, err := ParseReceiverValues(reqBody)
 	if err != nil {
-		logError(ctx, "Failed to parse email recipients", err)
+		logError(ctx, "Failed to parse alert receiver values", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPBadRequest,
+			ErrorCode: api.ErrorCodeInvalidRequestBody,
+		})
+	}
+
+	// Ensures the email language is one of the localized email templates of the deployment.
+	languages := w.configuration.AlertManager.EmailLanguages
+	if values.Language != nil && *values.Language != "" && !slices.Contains(languages, *values.Language) {
+		logError(ctx, "Failed to validate alert receiver values", fmt.Errorf("unsupported email language: %q", *values.Language))
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   localize(responseLanguage(ctx), msgUnsupportedEmailLanguage, strings.Join(languages, ", ")),
+			ErrorCode: api.ErrorCodeInvalidRequestBody,
+		})
+	}
+
+	if httpErr := w.checkEmailOverride(ctx, tenantID, values); httpErr != nil {
+		return ctx.JSON(httpErr.Code, httpErr)
+	}
+
+	if httpErr := w.checkReceiverTier(ctx, tenantID, id, values); httpErr != nil {
+		return ctx.JSON(httpErr.Code, httpErr)
+	}
+
+	err = w.validateReceiverConfig(ctx.Request().Context(), tenantID, id, values)
+	if errors.Is(err, ErrConfigLimitExceeded) {
+		logError(ctx, fmt.Sprintf("Alert receiver %q exceeds alertmanager configuration limits", id), err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPReceiverConfigLimitExceeded,
+			ErrorCode: api.ErrorCodeReceiverConfigLimitExceeded,
+		})
+	} else if errors.Is(err, ErrRouteConflict) {
+		logError(ctx, fmt.Sprintf("Alert receiver %q has a conflicting alertmanager route", id), err)
+		httpErr := routeConflictError(ctx, err)
+		return ctx.JSON(httpErr.Code, httpErr)
+	} else if errors.Is(err, ErrInvalidChannelConfig) {
+		logError(ctx, fmt.Sprintf("Alert receiver %q has an invalid notification channel configuration", id), err)
 		return ctx.JSON(http.StatusBadRequest, api.HttpError{
-			Code:    http.StatusBadRequest,
-			Message: errHTTPBadRequest,
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPBadRequest,
+			ErrorCode: api.ErrorCodeInvalidRequestBody,
 		})
+	} else if errors.Is(err, gorm.ErrRecordNotFound) {
+		logError(ctx, fmt.Sprintf("Alert receiver not found: %q", id), err)
+		return ctx.JSON(http.StatusNotFound, api.HttpError{
+			Code:      http.StatusNotFound,
+			Message:   errHTTPAlertReceiverNotFound,
+			ErrorCode: api.ErrorCodeReceiverNotFound,
+		})
+	} else if err != nil {
+		logError(ctx, fmt.Sprintf("Failed to validate alertmanager configuration of receiver with UUID: %q", id), err)
+		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToPatchAlertReceivers,
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+
+	// The configuration is previewed before the update, so that the update is not stored if it cannot be previewed.
+	var preview *api.ReceiverConfigPreview
+	if params.Preview != nil && *params.Preview {
+		if w.receiversCfg == nil {
+			logWarn(ctx, "Alertmanager configuration is not available, receivers cannot be previewed")
+			return ctx.JSON(http.StatusServiceUnavailable, api.HttpError{
+				Code:      http.StatusServiceUnavailable,
+				Message:   errHTTPReceiverConfigPreviewUnavailable,
+				ErrorCode: api.ErrorCodeInternalError,
+			})
+		}
+
+		preview, err = w.previewReceiverConfig(ctx.Request().Context(), tenantID, id, values)
+		if err != nil {
+			logError(ctx, fmt.Sprintf("Failed to preview alertmanager configuration of receiver with UUID: %q", id), err)
+			return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+				Code:      http.StatusInternalServerError,
+				Message:   errHTTPFailedToPatchAlertReceivers,
+				ErrorCode: api.ErrorCodeInternalError,
+			})
+		}
 	}
 
-	err = w.receivers.SetReceiverEmailRecipients(ctx.Request().Context(), tenantID, id, emailRecipients)
+	updateCtx, operationID := withOperation(ctx.Request().Context(), preview != nil || (params.Async != nil && *params.Async))
+	if w.verifier != nil {
+		updateCtx = db.NewEmailVerificationContext(updateCtx)
+	}
+	err = w.receivers.SetReceiverValues(updateCtx, tenantID, id, values)
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		logError(ctx, fmt.Sprintf("Alert receiver not found: %q", id), err)
 		return ctx.JSON(http.StatusNotFound, api.HttpError{
-			Code:    http.StatusNotFound,
-			Message: errHTTPAlertReceiverNotFound,
+			Code:      http.StatusNotFound,
+			Message:   errHTTPAlertReceiverNotFound,
+			ErrorCode: api.ErrorCodeReceiverNotFound,
 		})
 	} else if err != nil {
-		logError(ctx, fmt.Sprintf("Failed to update email recipients for receiver with UUID: %q", id), err)
+		logError(ctx, fmt.Sprintf("Failed to update values for receiver with UUID: %q", id), err)
 		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
-			Code:    http.StatusInternalServerError,
-			Message: errHTTPFailedToPatchAlertReceivers,
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToPatchAlertReceivers,
+			ErrorCode: api.ErrorCodeInternalError,
 		})
 	}
 
+	if w.verifier != nil {
+		w.verifier.sendVerifications(ctx, values.Recipients)
+	}
+
+	if operationID != nil {
+		return ctx.JSON(http.StatusAccepted, api.OperationAccepted{OperationId: *operationID, Preview: preview})
+	}
 	return ctx.NoContent(http.StatusNoContent)
 }
 
+// checkReceiverTier verifies that the given values of a receiver are within the service level of the tier of its tenant. The
+// error to respond with is returned otherwise. Nothing is checked if tenants are not limited by tiers.
+func (w *ServerInterfaceHandler) checkReceiverTier(ctx echo.Context, tenantID api.TenantID, id api.ReceiverId, values models.DBReceiverValues) *api.HttpError {
+	if w.tiers == nil {
+		return nil
+	}
+
+	level, ok, err := w.tiers.serviceLevel(ctx.Request().Context(), tenantID)
+	if err != nil {
+		logError(ctx, fmt.Sprintf("Failed to get tier of tenant %q", tenantID), err)
+		return &api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToPatchAlertReceivers,
+			ErrorCode: api.ErrorCodeInternalError,
+		}
+	}
+	if !ok {
+		return nil
+	}
+
+	if err := checkReceiverServiceLevel(level, values); err != nil {
+		logError(ctx, fmt.Sprintf("Alert receiver %q exceeds the service level of the tenant tier", id), err)
+		return &api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   localize(responseLanguage(ctx), msgReceiverTierLimitExceeded),
+			ErrorCode: api.ErrorCodeReceiverTierLimitExceeded,
+		}
+	}
+	return nil
+}
+
+// checkEmailOverride verifies that the sender address and mail server of the emails of a receiver, if given, can be
+// overridden by the request. Only the administrators of the tenants of the email override configuration can override them,
+// and mail servers must be one of the allowed ones, as emails are sent with the SMTP credentials of the deployment. Senders
+// cannot be overridden when emails are signed, as the signing relay only relays emails from the sender of the deployment.
+func (w *ServerInterfaceHandler) checkEmailOverride(ctx echo.Context, tenantID api.TenantID, values models.DBReceiverValues) *api.HttpError {
+	if values.Sender == nil && values.MailServer == nil {
+		return nil
+	}
+
+	override := w.configuration.EmailOverride
+	var err error
+	switch {
+	case !override.AllowsTenant(tenantID):
+		err = fmt.Errorf("tenant %q is not allowed to override it", tenantID)
+	case w.authorizeEmailOverride == nil:
+		err = errors.New("override cannot be authorized")
+	default:
+		err = w.authorizeEmailOverride(ctx)
+	}
+	if err != nil {
+		logError(ctx, "Failed to authorize email configuration override", err)
+		return &api.HttpError{
+			Code:      http.StatusForbidden,
+			Message:   errHTTPEmailOverrideNotAllowed,
+			ErrorCode: api.ErrorCodeEmailOverrideNotAllowed,
+		}
+	}
+
+	alertManager := w.configuration.AlertManager
+	if values.Sender != nil && alertManager.SigningRelayHost != "" {
+		logError(ctx, "Failed to override email configuration", errors.New("sender cannot be overridden when emails are signed"))
+		return &api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   localize(responseLanguage(ctx), msgSenderNotOverridable),
+			ErrorCode: api.ErrorCodeInvalidRequestBody,
+		}
+	}
+	if values.MailServer == nil {
+		return nil
+	}
+	if alertManager.EmailRelayURL != "" || alertManager.SigningRelayHost != "" {
+		logError(ctx, "Failed to override email configuration", errors.New("mail server cannot be overridden when emails are relayed"))
+		return &api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   localize(responseLanguage(ctx), msgMailServerNotOverridable),
+			ErrorCode: api.ErrorCodeInvalidRequestBody,
+		}
+	}
+	if !slices.Contains(override.MailServers, *values.MailServer) {
+		logError(ctx, "Failed to override email configuration", fmt.Errorf("mail server %q is not allowed", *values.MailServer))
+		lang := responseLanguage(ctx)
+		return &api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   localize(lang, msgBadRequest),
+			ErrorCode: api.ErrorCodeInvalidRequestBody,
+			Details: &[]api.ErrorDetail{{
+				Field:  "emailConfig.mailServer",
+				Reason: localize(lang, msgMailServerNotAllowed, strings.Join(override.MailServers, ", ")),
+				Value:  values.MailServer,
+			}},
+		}
+	}
+	return nil
+}
+
+// routeConflictError returns the error of a receiver whose alertmanager route conflicts with the route of another receiver, as
+// told by the given error, with its message in the language of the response.
+func routeConflictError(ctx echo.Context, err error) *api.HttpError {
+	var conflict *RouteConflictError
+	receiver := ""
+	if errors.As(err, &conflict) {
+		receiver = conflict.Receiver
+	}
+	return &api.HttpError{
+		Code:      http.StatusConflict,
+		Message:   localize(responseLanguage(ctx), msgReceiverRouteConflict, receiver),
+		ErrorCode: api.ErrorCodeReceiverRouteConflict,
+	}
+}
+
+// validateReceiverConfig verifies that the alertmanager configuration does not exceed its limits once the given values
+// are applied to the latest version of a receiver. Nothing is validated if there is no validator.
+func (w *ServerInterfaceHandler) validateReceiverConfig(ctx context.Context, tenantID api.TenantID, id api.ReceiverId, values models.DBReceiverValues) error {
+	if w.receiversCfg == nil {
+		return nil
+	}
+
+	recv, err := w.receivers.GetLatestReceiverWithEmailConfig(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+
+	return w.receiversCfg.ValidateReceiverConfig(ctx, projectReceiver(*recv, values))
+}
+
+// previewReceiverConfig returns the receiver and route blocks of the alertmanager configuration the given values are to be
+// applied with, once applied to the latest version of a receiver.
+func (w *ServerInterfaceHandler) previewReceiverConfig(ctx context.Context, tenantID api.TenantID, id api.ReceiverId, values models.DBReceiverValues) (*api.ReceiverConfigPreview, error) {
+	recv, err := w.receivers.GetLatestReceiverWithEmailConfig(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	preview, err := w.receiversCfg.PreviewReceiverConfig(ctx, projectReceiver(*recv, values))
+	if err != nil {
+		return nil, err
+	}
+	return &preview, nil
+}
+
+// alertManagerURL returns the URL of the alertmanager instance serving the shard of the given tenant.
+func (w *ServerInterfaceHandler) alertManagerURL(ctx context.Context, tenantID api.TenantID) (string, error) {
+	conf := w.configuration.AlertManager
+	if conf.ShardCount() == 1 {
+		return conf.URL, nil
+	}
+
+	shard, err := w.shards.GetTenantShard(ctx, tenantID, conf.ShardCount())
+	if err != nil {
+		return "", fmt.Errorf("failed to get alertmanager shard of tenant %q: %w", tenantID, err)
+	}
+
+	shardConf, err := conf.Shard(shard)
+	if err != nil {
+		return "", err
+	}
+	return shardConf.URL, nil
+}
+
 // GetStatus does not depend on tenantID thus here is a blank identifier.
 func (w *ServerInterfaceHandler) GetStatus(ctx echo.Context, _ api.TenantID) error {
 	conf := w.configuration
 
-	alertManagerStatus, err := getAlertManagerStatus(conf.AlertManager.URL)
-	if err != nil {
-		logError(ctx, "Failed to get alert manager status", err)
-		return ctx.JSON(http.StatusOK, &api.ServiceStatus{
-			State: api.Failed,
-		})
-	}
+	for shard := range conf.AlertManager.ShardCount() {
+		shardConf, err := conf.AlertManager.Shard(shard)
+		if err != nil {
+			logError(ctx, "Failed to get alert manager shard", err)
+			return ctx.JSON(http.StatusOK, &api.ServiceStatus{
+				State: api.Failed,
+			})
+		}
 
-	if alertManagerStatus != "ready" {
-		logWarn(ctx, "Alert manager not ready")
-		return ctx.JSON(http.StatusOK, &api.ServiceStatus{
-			State: api.Failed,
-		})
+		alertManagerStatus, err := getAlertManagerStatus(shardConf.URL)
+		if err != nil {
+			logError(ctx, "Failed to get alert manager status", err)
+			return ctx.JSON(http.StatusOK, &api.ServiceStatus{
+				State: api.Failed,
+			})
+		}
+
+		if alertManagerStatus != "ready" {
+			logWarn(ctx, fmt.Sprintf("Alert manager of shard %d not ready", shard))
+			return ctx.JSON(http.StatusOK, &api.ServiceStatus{
+				State: api.Failed,
+			})
+		}
 	}
 
 	mimirRulerStatusOK, err := isMimirRulerReachable(conf.Mimir.RulerURL)
@@ -488,6 +1170,13 @@ func (w *ServerInterfaceHandler) GetStatus(ctx echo.Context, _ api.TenantID) err
 		})
 	}
 
+	if w.certExpiry != nil && w.certExpiry.degraded() {
+		logWarn(ctx, "TLS certificate of a downstream endpoint is close to its expiry")
+		return ctx.JSON(http.StatusOK, &api.ServiceStatus{
+			State: api.Degraded,
+		})
+	}
+
 	return ctx.JSON(http.StatusOK, &api.ServiceStatus{
 		State: api.Ready,
 	})
@@ -498,51 +1187,113 @@ func (w *ServerInterfaceHandler) GetProjectAlerts(ctx echo.Context, params api.G
 	if err != nil {
 		logError(ctx, "Failed to extract projectID", err)
 		return ctx.JSON(http.StatusBadRequest, api.HttpError{
-			Code:    http.StatusBadRequest,
-			Message: errHTTPFailedToExtractProjectID,
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPFailedToExtractProjectID,
+			ErrorCode: api.ErrorCodeProjectIDMissing,
 		})
 	}
 
 	return w.GetAlerts(ctx, projectID, params)
 }
 
-func (w *ServerInterfaceHandler) GetProjectAlertDefinitions(ctx echo.Context) error {
+func (w *ServerInterfaceHandler) GetProjectAlertsByResource(ctx echo.Context, params api.GetProjectAlertsByResourceParams) error {
+	projectID, err := extractProjectID(ctx)
+	if err != nil {
+		logError(ctx, "Failed to extract projectID", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPFailedToExtractProjectID,
+			ErrorCode: api.ErrorCodeProjectIDMissing,
+		})
+	}
+
+	return w.GetAlertsByResource(ctx, projectID, params)
+}
+
+func (w *ServerInterfaceHandler) GetProjectAlertDefinitions(ctx echo.Context, params api.GetProjectAlertDefinitionsParams) error {
+	projectID, err := extractProjectID(ctx)
+	if err != nil {
+		logError(ctx, "Failed to extract projectID", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPFailedToExtractProjectID,
+			ErrorCode: api.ErrorCodeProjectIDMissing,
+		})
+	}
+
+	return w.GetAlertDefinitions(ctx, projectID, params)
+}
+
+func (w *ServerInterfaceHandler) GetProjectAlertDefinition(
+	ctx echo.Context, alertDefinitionID api.AlertDefinitionId, params api.GetProjectAlertDefinitionParams,
+) error {
+	projectID, err := extractProjectID(ctx)
+	if err != nil {
+		logError(ctx, "Failed to extract projectID", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPFailedToExtractProjectID,
+			ErrorCode: api.ErrorCodeProjectIDMissing,
+		})
+	}
+
+	return w.GetAlertDefinition(ctx, projectID, alertDefinitionID, params)
+}
+
+func (w *ServerInterfaceHandler) PatchProjectAlertDefinition(ctx echo.Context, alertDefinitionID api.AlertDefinitionId, params api.PatchProjectAlertDefinitionParams) error {
+	projectID, err := extractProjectID(ctx)
+	if err != nil {
+		logError(ctx, "Failed to extract projectID", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPFailedToExtractProjectID,
+			ErrorCode: api.ErrorCodeProjectIDMissing,
+		})
+	}
+
+	return w.PatchAlertDefinition(ctx, projectID, alertDefinitionID, params)
+}
+
+func (w *ServerInterfaceHandler) PostProjectAlertDefinitionEvaluate(ctx echo.Context, alertDefinitionID api.AlertDefinitionId) error {
 	projectID, err := extractProjectID(ctx)
 	if err != nil {
 		logError(ctx, "Failed to extract projectID", err)
 		return ctx.JSON(http.StatusBadRequest, api.HttpError{
-			Code:    http.StatusBadRequest,
-			Message: errHTTPFailedToExtractProjectID,
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPFailedToExtractProjectID,
+			ErrorCode: api.ErrorCodeProjectIDMissing,
 		})
 	}
 
-	return w.GetAlertDefinitions(ctx, projectID)
+	return w.EvaluateAlertDefinition(ctx, projectID, alertDefinitionID)
 }
 
-func (w *ServerInterfaceHandler) GetProjectAlertDefinition(ctx echo.Context, alertDefinitionID api.AlertDefinitionId) error {
+func (w *ServerInterfaceHandler) PostProjectAlertDefinitionResetDefaults(ctx echo.Context, alertDefinitionID api.AlertDefinitionId) error {
 	projectID, err := extractProjectID(ctx)
 	if err != nil {
 		logError(ctx, "Failed to extract projectID", err)
 		return ctx.JSON(http.StatusBadRequest, api.HttpError{
-			Code:    http.StatusBadRequest,
-			Message: errHTTPFailedToExtractProjectID,
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPFailedToExtractProjectID,
+			ErrorCode: api.ErrorCodeProjectIDMissing,
 		})
 	}
 
-	return w.GetAlertDefinition(ctx, projectID, alertDefinitionID)
+	return w.ResetAlertDefinitionDefaults(ctx, projectID, alertDefinitionID)
 }
 
-func (w *ServerInterfaceHandler) PatchProjectAlertDefinition(ctx echo.Context, alertDefinitionID api.AlertDefinitionId) error {
+func (w *ServerInterfaceHandler) PostProjectAlertDefinitionSimulate(ctx echo.Context, alertDefinitionID api.AlertDefinitionId) error {
 	projectID, err := extractProjectID(ctx)
 	if err != nil {
 		logError(ctx, "Failed to extract projectID", err)
 		return ctx.JSON(http.StatusBadRequest, api.HttpError{
-			Code:    http.StatusBadRequest,
-			Message: errHTTPFailedToExtractProjectID,
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPFailedToExtractProjectID,
+			ErrorCode: api.ErrorCodeProjectIDMissing,
 		})
 	}
 
-	return w.PatchAlertDefinition(ctx, projectID, alertDefinitionID)
+	return w.SimulateAlertDefinition(ctx, projectID, alertDefinitionID)
 }
 
 func (w *ServerInterfaceHandler) GetProjectAlertDefinitionRule(
@@ -552,51 +1303,266 @@ func (w *ServerInterfaceHandler) GetProjectAlertDefinitionRule(
 	if err != nil {
 		logError(ctx, "Failed to extract projectID", err)
 		return ctx.JSON(http.StatusBadRequest, api.HttpError{
-			Code:    http.StatusBadRequest,
-			Message: errHTTPFailedToExtractProjectID,
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPFailedToExtractProjectID,
+			ErrorCode: api.ErrorCodeProjectIDMissing,
 		})
 	}
 
 	return w.GetAlertDefinitionRule(ctx, projectID, alertDefinitionID, params)
 }
 
-func (w *ServerInterfaceHandler) GetProjectAlertReceivers(ctx echo.Context) error {
+// GetProjectAlertDefinitionTemplateFunctions lists the functions available to the expression templates of alert definitions,
+// which are the same for all projects.
+func (w *ServerInterfaceHandler) GetProjectAlertDefinitionTemplateFunctions(ctx echo.Context) error {
+	functions := rules.TemplateFunctions()
+	list := api.TemplateFunctionList{TemplateFunctions: make([]api.TemplateFunction, 0, len(functions))}
+	for _, f := range functions {
+		list.TemplateFunctions = append(list.TemplateFunctions, api.TemplateFunction{
+			Name:        f.Name,
+			Signature:   f.Signature,
+			Description: f.Description,
+			Example:     f.Example,
+		})
+	}
+	return ctx.JSON(http.StatusOK, list)
+}
+
+// GetProjectAlertDefinitionRecommendations gets the alert definitions of the project scored by how noisy their alerts were.
+func (w *ServerInterfaceHandler) GetProjectAlertDefinitionRecommendations(ctx echo.Context) error {
+	projectID, err := extractProjectID(ctx)
+	if err != nil {
+		logError(ctx, "Failed to extract projectID", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPFailedToExtractProjectID,
+			ErrorCode: api.ErrorCodeProjectIDMissing,
+		})
+	}
+
+	return w.GetAlertDefinitionRecommendations(ctx, projectID)
+}
+
+// GetProjectAlertReceiverLanguages lists the languages of the localized email templates of the deployment, which are the same
+// for all projects.
+func (w *ServerInterfaceHandler) GetProjectAlertReceiverLanguages(ctx echo.Context) error {
+	languages := w.configuration.AlertManager.EmailLanguages
+	if languages == nil {
+		languages = []string{}
+	}
+	return ctx.JSON(http.StatusOK, api.EmailLanguageList{Languages: languages})
+}
+
+func (w *ServerInterfaceHandler) GetProjectEmailTemplate(ctx echo.Context, params api.GetProjectEmailTemplateParams) error {
+	projectID, err := extractProjectID(ctx)
+	if err != nil {
+		logError(ctx, "Failed to extract projectID", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPFailedToExtractProjectID,
+			ErrorCode: api.ErrorCodeProjectIDMissing,
+		})
+	}
+
+	return w.GetEmailTemplate(ctx, projectID, params)
+}
+
+func (w *ServerInterfaceHandler) PutProjectEmailTemplate(ctx echo.Context) error {
+	projectID, err := extractProjectID(ctx)
+	if err != nil {
+		logError(ctx, "Failed to extract projectID", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPFailedToExtractProjectID,
+			ErrorCode: api.ErrorCodeProjectIDMissing,
+		})
+	}
+
+	return w.SetEmailTemplate(ctx, projectID)
+}
+
+func (w *ServerInterfaceHandler) PostProjectExternalAlerts(ctx echo.Context) error {
+	projectID, err := extractProjectID(ctx)
+	if err != nil {
+		logError(ctx, "Failed to extract projectID", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPFailedToExtractProjectID,
+			ErrorCode: api.ErrorCodeProjectIDMissing,
+		})
+	}
+
+	return w.PostExternalAlerts(ctx, projectID)
+}
+
+func (w *ServerInterfaceHandler) GetProjectAlertReceivers(ctx echo.Context, params api.GetProjectAlertReceiversParams) error {
+	projectID, err := extractProjectID(ctx)
+	if err != nil {
+		logError(ctx, "Failed to extract projectID", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPFailedToExtractProjectID,
+			ErrorCode: api.ErrorCodeProjectIDMissing,
+		})
+	}
+
+	return w.GetAlertReceivers(ctx, projectID, params)
+}
+
+func (w *ServerInterfaceHandler) GetProjectAlertReceiver(ctx echo.Context, receiverID api.ReceiverId, params api.GetProjectAlertReceiverParams) error {
 	projectID, err := extractProjectID(ctx)
 	if err != nil {
 		logError(ctx, "Failed to extract projectID", err)
 		return ctx.JSON(http.StatusBadRequest, api.HttpError{
-			Code:    http.StatusBadRequest,
-			Message: errHTTPFailedToExtractProjectID,
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPFailedToExtractProjectID,
+			ErrorCode: api.ErrorCodeProjectIDMissing,
 		})
 	}
 
-	return w.GetAlertReceivers(ctx, projectID)
+	return w.GetAlertReceiver(ctx, projectID, receiverID, params)
 }
 
-func (w *ServerInterfaceHandler) GetProjectAlertReceiver(ctx echo.Context, receiverID api.ReceiverId) error {
+func (w *ServerInterfaceHandler) GetProjectAlertReceiverPreview(ctx echo.Context, receiverID api.ReceiverId) error {
 	projectID, err := extractProjectID(ctx)
 	if err != nil {
 		logError(ctx, "Failed to extract projectID", err)
 		return ctx.JSON(http.StatusBadRequest, api.HttpError{
-			Code:    http.StatusBadRequest,
-			Message: errHTTPFailedToExtractProjectID,
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPFailedToExtractProjectID,
+			ErrorCode: api.ErrorCodeProjectIDMissing,
 		})
 	}
 
-	return w.GetAlertReceiver(ctx, projectID, receiverID)
+	return w.PreviewAlertReceiver(ctx, projectID, receiverID)
 }
 
-func (w *ServerInterfaceHandler) PatchProjectAlertReceiver(ctx echo.Context, receiverID api.ReceiverId) error {
+func (w *ServerInterfaceHandler) GetProjectAlertReceiverAllowedRecipients(
+	ctx echo.Context, receiverID api.ReceiverId, params api.GetProjectAlertReceiverAllowedRecipientsParams,
+) error {
 	projectID, err := extractProjectID(ctx)
 	if err != nil {
 		logError(ctx, "Failed to extract projectID", err)
 		return ctx.JSON(http.StatusBadRequest, api.HttpError{
-			Code:    http.StatusBadRequest,
-			Message: errHTTPFailedToExtractProjectID,
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPFailedToExtractProjectID,
+			ErrorCode: api.ErrorCodeProjectIDMissing,
 		})
 	}
 
-	return w.PatchAlertReceiver(ctx, projectID, receiverID)
+	return w.GetAlertReceiverAllowedRecipients(ctx, projectID, receiverID, params)
+}
+
+func (w *ServerInterfaceHandler) GetProjectAlert(ctx echo.Context, alertFingerprint api.AlertFingerprint) error {
+	projectID, err := extractProjectID(ctx)
+	if err != nil {
+		logError(ctx, "Failed to extract projectID", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPFailedToExtractProjectID,
+			ErrorCode: api.ErrorCodeProjectIDMissing,
+		})
+	}
+
+	return w.GetAlert(ctx, projectID, alertFingerprint)
+}
+
+func (w *ServerInterfaceHandler) PostProjectAlertComment(ctx echo.Context, alertFingerprint api.AlertFingerprint) error {
+	projectID, err := extractProjectID(ctx)
+	if err != nil {
+		logError(ctx, "Failed to extract projectID", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPFailedToExtractProjectID,
+			ErrorCode: api.ErrorCodeProjectIDMissing,
+		})
+	}
+
+	return w.AddAlertComment(ctx, projectID, alertFingerprint)
+}
+
+func (w *ServerInterfaceHandler) PutProjectMaintenanceMode(ctx echo.Context) error {
+	projectID, err := extractProjectID(ctx)
+	if err != nil {
+		logError(ctx, "Failed to extract projectID", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPFailedToExtractProjectID,
+			ErrorCode: api.ErrorCodeProjectIDMissing,
+		})
+	}
+
+	return w.SetMaintenanceMode(ctx, projectID)
+}
+
+func (w *ServerInterfaceHandler) PatchProjectAlertReceiver(ctx echo.Context, receiverID api.ReceiverId, params api.PatchProjectAlertReceiverParams) error {
+	projectID, err := extractProjectID(ctx)
+	if err != nil {
+		logError(ctx, "Failed to extract projectID", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPFailedToExtractProjectID,
+			ErrorCode: api.ErrorCodeProjectIDMissing,
+		})
+	}
+
+	return w.PatchAlertReceiver(ctx, projectID, receiverID, params)
+}
+
+func (w *ServerInterfaceHandler) GetProjectOperation(ctx echo.Context, operationID api.OperationId) error {
+	projectID, err := extractProjectID(ctx)
+	if err != nil {
+		logError(ctx, "Failed to extract projectID", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPFailedToExtractProjectID,
+			ErrorCode: api.ErrorCodeProjectIDMissing,
+		})
+	}
+
+	return w.GetOperation(ctx, projectID, operationID)
+}
+
+func (w *ServerInterfaceHandler) PostProjectAlertReceiversSyncRecipients(ctx echo.Context) error {
+	projectID, err := extractProjectID(ctx)
+	if err != nil {
+		logError(ctx, "Failed to extract projectID", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPFailedToExtractProjectID,
+			ErrorCode: api.ErrorCodeProjectIDMissing,
+		})
+	}
+
+	return w.SyncAlertReceiverRecipients(ctx, projectID)
+}
+
+func (w *ServerInterfaceHandler) GetProjectReports(ctx echo.Context) error {
+	projectID, err := extractProjectID(ctx)
+	if err != nil {
+		logError(ctx, "Failed to extract projectID", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPFailedToExtractProjectID,
+			ErrorCode: api.ErrorCodeProjectIDMissing,
+		})
+	}
+
+	return w.GetReports(ctx, projectID)
+}
+
+func (w *ServerInterfaceHandler) GetProjectReport(ctx echo.Context, reportID api.ReportId, params api.GetProjectReportParams) error {
+	projectID, err := extractProjectID(ctx)
+	if err != nil {
+		logError(ctx, "Failed to extract projectID", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPFailedToExtractProjectID,
+			ErrorCode: api.ErrorCodeProjectIDMissing,
+		})
+	}
+
+	return w.GetReport(ctx, projectID, reportID, params)
 }
 
 func (w *ServerInterfaceHandler) GetServiceStatus(ctx echo.Context) error {
@@ -605,7 +1571,7 @@ func (w *ServerInterfaceHandler) GetServiceStatus(ctx echo.Context) error {
 }
 
 func extractProjectID(ctx echo.Context) (string, error) {
-	projectID := ctx.Request().Header.Get("ActiveProjectID")
+	projectID := ctx.Request().Header.Get(activeProjectIDHeader)
 
 	if len(strings.TrimSpace(projectID)) == 0 {
 		return "", errors.New("projectID cannot be empty")
@@ -613,3 +1579,87 @@ func extractProjectID(ctx echo.Context) (string, error) {
 
 	return projectID, nil
 }
+
+func (w *ServerInterfaceHandler) GetProjectEventHooks(ctx echo.Context) error {
+	projectID, err := extractProjectID(ctx)
+	if err != nil {
+		logError(ctx, "Failed to extract projectID", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPFailedToExtractProjectID,
+			ErrorCode: api.ErrorCodeProjectIDMissing,
+		})
+	}
+
+	return w.GetEventHooks(ctx, projectID)
+}
+
+func (w *ServerInterfaceHandler) PostProjectEventHook(ctx echo.Context) error {
+	projectID, err := extractProjectID(ctx)
+	if err != nil {
+		logError(ctx, "Failed to extract projectID", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPFailedToExtractProjectID,
+			ErrorCode: api.ErrorCodeProjectIDMissing,
+		})
+	}
+
+	return w.CreateEventHook(ctx, projectID)
+}
+
+func (w *ServerInterfaceHandler) DeleteProjectEventHook(ctx echo.Context, hookID api.HookId) error {
+	projectID, err := extractProjectID(ctx)
+	if err != nil {
+		logError(ctx, "Failed to extract projectID", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPFailedToExtractProjectID,
+			ErrorCode: api.ErrorCodeProjectIDMissing,
+		})
+	}
+
+	return w.DeleteEventHook(ctx, projectID, hookID)
+}
+
+func (w *ServerInterfaceHandler) GetProjectEventHook(ctx echo.Context, hookID api.HookId) error {
+	projectID, err := extractProjectID(ctx)
+	if err != nil {
+		logError(ctx, "Failed to extract projectID", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPFailedToExtractProjectID,
+			ErrorCode: api.ErrorCodeProjectIDMissing,
+		})
+	}
+
+	return w.GetEventHook(ctx, projectID, hookID)
+}
+
+func (w *ServerInterfaceHandler) PatchProjectEventHook(ctx echo.Context, hookID api.HookId) error {
+	projectID, err := extractProjectID(ctx)
+	if err != nil {
+		logError(ctx, "Failed to extract projectID", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPFailedToExtractProjectID,
+			ErrorCode: api.ErrorCodeProjectIDMissing,
+		})
+	}
+
+	return w.UpdateEventHook(ctx, projectID, hookID)
+}
+
+func (w *ServerInterfaceHandler) PostProjectConfigDiff(ctx echo.Context) error {
+	projectID, err := extractProjectID(ctx)
+	if err != nil {
+		logError(ctx, "Failed to extract projectID", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPFailedToExtractProjectID,
+			ErrorCode: api.ErrorCodeProjectIDMissing,
+		})
+	}
+
+	return w.DiffConfig(ctx, projectID)
+}
@@ -213,7 +213,7 @@ func FuzzPatchAlertReceiverRandomInput(f *testing.F) {
 
 	// Mocking the Receiver.
 	mReceiver := &ReceiverMock{}
-	mReceiver.On("SetReceiverEmailRecipients", mock.Anything, tenantID, id, mock.Anything).Return(nil)
+	mReceiver.On("SetReceiverValues", mock.Anything, tenantID, id, mock.Anything).Return(nil)
 
 	api.RegisterHandlers(e, &ServerInterfaceHandler{
 		m2m:       mM2M,
@@ -259,7 +259,7 @@ func FuzzPatchAlertReceiverAddress(f *testing.F) {
 
 	// Mocking the Receiver.
 	mReceiver := &ReceiverMock{}
-	mReceiver.On("SetReceiverEmailRecipients", mock.Anything, tenantID, id, mock.Anything).Return(nil)
+	mReceiver.On("SetReceiverValues", mock.Anything, tenantID, id, mock.Anything).Return(nil)
 
 	api.RegisterHandlers(e, &ServerInterfaceHandler{
 		m2m:       mM2M,
@@ -12,13 +12,17 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/oapi-codegen/testutil"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
 	"gopkg.in/yaml.v3"
 	"gorm.io/gorm"
 
@@ -26,6 +30,7 @@ import (
 	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
 	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
 	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/tenantmeta"
 )
 
 const alertManagerResponse =
@@ -89,15 +94,6 @@ const alertMonitorExpectedResponse =
 	"\"host_uuid\":\"93bf6804-52a3-4ba1-a919-c7ef65a9cdef\",\"node\":\"bar\"," +
 	"\"deployment_id\":\"1c87a656-594d-4300-b4ad-630914e11856\"}}]"
 
-const alertMonitorResponseBadUUID = "[{\"annotations\":{\"am_test\":\"test\",\"am_uuid\":\"bad\"}," +
-	"\"endsAt\":\"2024-01-23T16:13:45.535+01:00\",\"fingerprint\":\"0c8d24dab761f647\"," +
-	"\"receivers\":[{\"name\":\"web.hook\"}],\"startsAt\":\"2024-01-23T16:08:45.535+01:00\"," +
-	"\"status\":{\"inhibitedBy\":[],\"silencedBy\":[],\"state\":\"active\"}," +
-	"\"updatedAt\":\"2024-01-23T16:08:45.535+01:00\"," +
-	"\"labels\":{\"alertname\":\"foo2\",\"cluster_name\":\"test\",\"alert_category\":\"test\"," +
-	"\"host_uuid\":\"93bf6804-52a3-4ba1-a919-c7ef65a9cdef\",\"node\":\"bar\"," +
-	"\"deployment_id\":\"1c87a656-594d-4300-b4ad-630914e11856\"}}]"
-
 const emptyAlertManagerResponse = "[]"
 
 const badAlertManagerResponse = "bad response"
@@ -202,14 +198,6 @@ func TestGetAlerts(t *testing.T) {
 			expectedCode:        http.StatusInternalServerError,
 			expected:            "",
 		},
-		"Test response when alert manager returns invalid uuid - code should be 500": {
-			server:              true,
-			header:              header{"ActiveProjectID", "edgenode"},
-			managerResponse:     alertMonitorResponseBadUUID,
-			managerResponseCode: http.StatusOK,
-			expectedCode:        http.StatusInternalServerError,
-			expected:            "",
-		},
 		"Test response when alert manager return non 200 code - code should be 500": {
 			server:              true,
 			header:              header{"ActiveProjectID", "edgenode"},
@@ -261,7 +249,8 @@ func TestGetAlerts(t *testing.T) {
 				configfile.AlertManager.URL = svr.URL
 				defer svr.Close()
 			}
-			serverInterface := NewServerInterfaceHandler(configfile, &gorm.DB{}, nil)
+			serverInterface := NewServerInterfaceHandler(configfile, &gorm.DB{}, nil, nil)
+			serverInterface.maintenance = nil
 
 			// Registering API call handlers
 			api.RegisterHandlers(e, serverInterface)
@@ -276,6 +265,180 @@ func TestGetAlerts(t *testing.T) {
 	}
 }
 
+func TestGetAlertsMalformed(t *testing.T) {
+	fingerprint := "3fb057ade094c97a"
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v2/alerts" {
+			// Valid alerts followed by alerts with a malformed start time, without labels and with a malformed am_uuid annotation.
+			fmt.Fprint(w, strings.TrimSuffix(alertManagerResponse, "]")+","+
+				`{"fingerprint":"1d9e35ebc872a758","labels":{"alertname":"foo3","alert_category":"test"},"annotations":{},`+
+				`"startsAt":"yesterday"},`+
+				`{"fingerprint":"2eaf46fcd983b869","annotations":{}},`+
+				`{"fingerprint":"3fb057ade094c97a","labels":{"alertname":"foo4","alert_category":"test"},`+
+				`"annotations":{"am_uuid":"bad"}}]`)
+		}
+	}))
+	defer svr.Close()
+
+	configfile := conf
+	configfile.AlertManager.URL = svr.URL
+
+	e := echo.New()
+	serverInterface := NewServerInterfaceHandler(configfile, &gorm.DB{}, nil, nil)
+	serverInterface.maintenance = nil
+	api.RegisterHandlers(e, serverInterface)
+
+	result := testutil.NewRequest().WithHeader("ActiveProjectID", "malformed").Get("/api/v1/alerts").GoWithHTTPHandler(t, e)
+	require.Equal(t, http.StatusOK, result.Recorder.Code)
+
+	var alerts api.AlertList
+	require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &alerts))
+
+	var expected []api.Alert
+	require.NoError(t, json.Unmarshal([]byte(alertMonitorExpectedResponse), &expected))
+	require.Equal(t, expected, *alerts.Alerts)
+
+	require.NotNil(t, alerts.Warnings)
+	warnings := *alerts.Warnings
+	require.Len(t, warnings, 3)
+	require.Equal(t, api.AlertWarning{
+		Fingerprint: &fingerprint,
+		Reason:      api.MalformedUuid,
+		Message:     `am_uuid annotation "bad" is not a valid UUID`,
+	}, warnings[2])
+	for i, fp := range []string{"1d9e35ebc872a758", "2eaf46fcd983b869"} {
+		require.Equal(t, api.InvalidSchema, warnings[i].Reason)
+		require.Equal(t, fp, *warnings[i].Fingerprint)
+	}
+
+	require.InDelta(t, 2, promtestutil.ToFloat64(malformedAlerts.WithLabelValues("malformed", string(api.InvalidSchema))), 0)
+	require.InDelta(t, 1, promtestutil.ToFloat64(malformedAlerts.WithLabelValues("malformed", string(api.MalformedUuid))), 0)
+}
+
+type TenantShardMock struct {
+	mock.Mock
+}
+
+func (m *TenantShardMock) GetTenantShard(ctx context.Context, tenantID api.TenantID, shards int) (int, error) {
+	args := m.Called(ctx, tenantID, shards)
+	return args.Int(0), args.Error(1)
+}
+
+func TestGetAlertsSharded(t *testing.T) {
+	newAlertManager := func(response string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/api/v2/alerts" {
+				w.WriteHeader(http.StatusOK)
+				fmt.Fprint(w, response)
+			}
+		}))
+	}
+
+	firstShard := newAlertManager(emptyAlertManagerResponse)
+	defer firstShard.Close()
+	secondShard := newAlertManager(alertManagerResponse)
+	defer secondShard.Close()
+
+	configfile := conf
+	configfile.AlertManager.URL = firstShard.URL
+	configfile.AlertManager.Shards = []config.AlertManagerShardConfig{{URL: secondShard.URL}}
+
+	t.Run("Alerts are requested from the shard of the tenant", func(t *testing.T) {
+		shardMock := new(TenantShardMock)
+		shardMock.On("GetTenantShard", mock.Anything, "edgenode", 2).Return(1, nil)
+
+		e := echo.New()
+		serverInterface := NewServerInterfaceHandler(configfile, &gorm.DB{}, nil, nil)
+		serverInterface.maintenance = nil
+		serverInterface.shards = shardMock
+		api.RegisterHandlers(e, serverInterface)
+
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", "edgenode").Get("/api/v1/alerts").GoWithHTTPHandler(t, e)
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+		assertResponse(t, alertMonitorExpectedResponse, result.Recorder.Body)
+		shardMock.AssertExpectations(t)
+	})
+
+	t.Run("Failure to get the shard of the tenant - code should be 500", func(t *testing.T) {
+		shardMock := new(TenantShardMock)
+		shardMock.On("GetTenantShard", mock.Anything, "edgenode", 2).Return(0, errors.New("mock error"))
+
+		e := echo.New()
+		serverInterface := NewServerInterfaceHandler(configfile, &gorm.DB{}, nil, nil)
+		serverInterface.shards = shardMock
+		api.RegisterHandlers(e, serverInterface)
+
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", "edgenode").Get("/api/v1/alerts").GoWithHTTPHandler(t, e)
+		require.Equal(t, http.StatusInternalServerError, result.Recorder.Code)
+	})
+}
+
+type TenantMetadataMock struct {
+	mock.Mock
+}
+
+func (m *TenantMetadataMock) Get(ctx context.Context, tenantID string) (tenantmeta.Metadata, error) {
+	args := m.Called(ctx, tenantID)
+	return args.Get(0).(tenantmeta.Metadata), args.Error(1)
+}
+
+func TestGetAlertsTenantMetadata(t *testing.T) {
+	alertManager := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v2/alerts" {
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, alertManagerResponse)
+		}
+	}))
+	defer alertManager.Close()
+
+	configfile := conf
+	configfile.AlertManager.URL = alertManager.URL
+
+	getAlerts := func(t *testing.T, metadata tenantMetadataGetter) api.AlertList {
+		t.Helper()
+
+		e := echo.New()
+		serverInterface := NewServerInterfaceHandler(configfile, &gorm.DB{}, nil, nil)
+		serverInterface.maintenance = nil
+		serverInterface.tenantMetadata = metadata
+		api.RegisterHandlers(e, serverInterface)
+
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", "edgenode").Get("/api/v1/alerts").GoWithHTTPHandler(t, e)
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+
+		var alerts api.AlertList
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &alerts))
+		return alerts
+	}
+
+	t.Run("Alerts are annotated with tenant metadata", func(t *testing.T) {
+		metadataMock := new(TenantMetadataMock)
+		metadataMock.On("Get", mock.Anything, "edgenode").
+			Return(tenantmeta.Metadata{DisplayName: "Factory-Munich", Region: "eu-central"}, nil).Once()
+
+		alerts := getAlerts(t, metadataMock)
+		require.Len(t, *alerts.Alerts, 3)
+		for _, alert := range *alerts.Alerts {
+			require.Equal(t, map[string]string{
+				"project_name":   "Factory-Munich",
+				"project_region": "eu-central",
+			}, *alert.Annotations)
+		}
+		metadataMock.AssertExpectations(t)
+	})
+
+	t.Run("Alerts are returned without tenant metadata if not available", func(t *testing.T) {
+		metadataMock := new(TenantMetadataMock)
+		metadataMock.On("Get", mock.Anything, "edgenode").Return(tenantmeta.Metadata{}, errors.New("mock error")).Once()
+
+		alerts := getAlerts(t, metadataMock)
+		require.Len(t, *alerts.Alerts, 3)
+		for _, alert := range *alerts.Alerts {
+			require.Empty(t, *alert.Annotations)
+		}
+	})
+}
+
 func assertResponse(t *testing.T, expected string, responseBody *bytes.Buffer) {
 	unmarshalledResponse := new(api.AlertList)
 	unmarshalledExpected := new(api.AlertList)
@@ -301,12 +464,14 @@ type DefinitionMock struct {
 	mock.Mock
 }
 
-func (m *DefinitionMock) GetLatestAlertDefinitionList(ctx context.Context, tenantID api.TenantID) ([]*models.DBAlertDefinition, error) {
-	args := m.Called(ctx, tenantID)
+func (m *DefinitionMock) GetLatestAlertDefinitionList(
+	ctx context.Context, tenantID api.TenantID, opts database.ListOptions,
+) ([]*models.DBAlertDefinition, int64, error) {
+	args := m.Called(ctx, tenantID, opts)
 	if args.Get(0) == nil {
-		return nil, args.Error(1)
+		return nil, 0, args.Error(2)
 	}
-	return args.Get(0).([]*models.DBAlertDefinition), args.Error(1)
+	return args.Get(0).([]*models.DBAlertDefinition), args.Get(1).(int64), args.Error(2)
 }
 
 func (m *DefinitionMock) GetLatestAlertDefinition(ctx context.Context, tenantID api.TenantID, id uuid.UUID) (*models.DBAlertDefinition, error) {
@@ -322,13 +487,94 @@ func (m *DefinitionMock) SetAlertDefinitionValues(ctx context.Context, tenantID
 	return args.Error(0)
 }
 
+func (m *DefinitionMock) ResetAlertDefinitionValues(ctx context.Context, tenantID api.TenantID, id uuid.UUID) (*models.DBAlertDefinitionValues, error) {
+	args := m.Called(ctx, tenantID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.DBAlertDefinitionValues), args.Error(1)
+}
+
+type RuleEvaluationReporterMock struct {
+	mock.Mock
+}
+
+func (m *RuleEvaluationReporterMock) GetRuleEvaluationHealth(
+	ctx context.Context, tenantID api.TenantID, ids []uuid.UUID,
+) (map[uuid.UUID]models.RuleEvaluationHealth, error) {
+	args := m.Called(ctx, tenantID, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]models.RuleEvaluationHealth), args.Error(1)
+}
+
+func TestGetAlertsByResource(t *testing.T) {
+	alertManager := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Only active alerts, which are not suppressed, are grouped.
+		query := r.URL.Query()
+		if r.URL.Path != "/api/v2/alerts" || query.Get("active") != "true" || query.Get("silenced") != "false" || query.Get("inhibited") != "false" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, alertManagerResponse)
+	}))
+	defer alertManager.Close()
+
+	configfile := conf
+	configfile.AlertManager.URL = alertManager.URL
+
+	e := echo.New()
+	api.RegisterHandlers(e, NewServerInterfaceHandler(configfile, &gorm.DB{}, nil, nil))
+
+	tests := map[string]struct {
+		query    string
+		expected api.AlertResourceList
+	}{
+		"Alerts are grouped by host by default": {
+			expected: api.AlertResourceList{Resources: []api.AlertResource{{Resource: "93bf6804-52a3-4ba1-a919-c7ef65a9cdef", AlertCount: 3}}},
+		},
+		"Alerts are grouped by cluster": {
+			query:    "?groupBy=cluster",
+			expected: api.AlertResourceList{Resources: []api.AlertResource{{Resource: "test", AlertCount: 3}}},
+		},
+		"Alerts are grouped by deployment": {
+			query:    "?groupBy=deployment",
+			expected: api.AlertResourceList{Resources: []api.AlertResource{{Resource: "1c87a656-594d-4300-b4ad-630914e11856", AlertCount: 3}}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := testutil.NewRequest().WithHeader("ActiveProjectID", "edgenode").Get("/api/v1/alerts/by-resource"+test.query).
+				GoWithHTTPHandler(t, e)
+			require.Equal(t, http.StatusOK, result.Recorder.Code)
+
+			var resources api.AlertResourceList
+			require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &resources))
+			require.Equal(t, test.expected, resources)
+		})
+	}
+
+	t.Run("Alerts cannot be grouped by an unknown resource - code should be 400", func(t *testing.T) {
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", "edgenode").Get("/api/v1/alerts/by-resource?groupBy=node").
+			GoWithHTTPHandler(t, e)
+		require.Equal(t, http.StatusBadRequest, result.Recorder.Code)
+
+		var httpErr api.HttpError
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &httpErr))
+		require.Equal(t, api.ErrorCodeInvalidParameter, httpErr.ErrorCode)
+	})
+}
+
 func TestGetAlertDefinitions(t *testing.T) {
 	t.Run("Failed to get alert definitions from database", func(t *testing.T) {
 		mDefinition := &DefinitionMock{}
 		tenantID := "edgenode"
 
 		// mock getting alert definitions from database.
-		mDefinition.On("GetLatestAlertDefinitionList", mock.Anything, tenantID).Return(nil, errors.New("error mock")).Once()
+		mDefinition.On("GetLatestAlertDefinitionList", mock.Anything, tenantID, database.ListOptions{}).Return(nil, int64(0), errors.New("error mock")).Once()
 
 		handler := &ServerInterfaceHandler{
 			definitions: mDefinition,
@@ -350,6 +596,7 @@ func TestGetAlertDefinitions(t *testing.T) {
 
 		require.Equal(t, http.StatusInternalServerError, httpErr.Code)
 		require.Contains(t, httpErr.Message, errHTTPFailedToGetAlertDefinitions)
+		require.Equal(t, api.ErrorCodeInternalError, httpErr.ErrorCode)
 
 		require.True(t, mDefinition.AssertExpectations(t))
 	})
@@ -359,7 +606,8 @@ func TestGetAlertDefinitions(t *testing.T) {
 		tenantID := "edgenode"
 
 		// mock getting alert definitions from database.
-		mDefinition.On("GetLatestAlertDefinitionList", mock.Anything, tenantID).Return([]*models.DBAlertDefinition{}, nil).Once()
+		mDefinition.On("GetLatestAlertDefinitionList", mock.Anything, tenantID, database.ListOptions{}).
+			Return([]*models.DBAlertDefinition{}, int64(0), nil).Once()
 
 		handler := &ServerInterfaceHandler{
 			definitions: mDefinition,
@@ -407,6 +655,7 @@ func TestGetAlertDefinitions(t *testing.T) {
 				Duration:  &dur,
 				Threshold: &thres,
 				Enabled:   &enabled,
+				AutoTune:  new(bool),
 			},
 			Category: models.CategoryHealth,
 			TenantID: tenantID,
@@ -415,7 +664,8 @@ func TestGetAlertDefinitions(t *testing.T) {
 		mDefinition := &DefinitionMock{}
 
 		// mock getting alert definitions from database.
-		mDefinition.On("GetLatestAlertDefinitionList", mock.Anything, tenantID).Return([]*models.DBAlertDefinition{dbDef}, nil).Once()
+		mDefinition.On("GetLatestAlertDefinitionList", mock.Anything, tenantID, database.ListOptions{}).
+			Return([]*models.DBAlertDefinition{dbDef}, int64(1), nil).Once()
 
 		handler := &ServerInterfaceHandler{
 			definitions: mDefinition,
@@ -446,12 +696,18 @@ func TestGetAlertDefinitions(t *testing.T) {
 					"duration":  "10s",
 					"threshold": "100",
 					"enabled":   "true",
+					"autoTune":  "false",
 				},
-				Version: &versionExp,
+				DurationSeconds:    dbDef.Values.Duration,
+				ThresholdValue:     dbDef.Values.Threshold,
+				Enabled:            dbDef.Values.Enabled,
+				Version:            &versionExp,
+				ThresholdAutoTuned: new(bool),
 			},
 		}
 		definitionsListExp := &api.AlertDefinitionList{
 			AlertDefinitions: &definitionsExp,
+			TotalCount:       1,
 		}
 
 		definitions := []api.AlertDefinition{}
@@ -478,6 +734,7 @@ func TestGetAlertDefinitions(t *testing.T) {
 				Duration:  &dur1,
 				Threshold: &thres1,
 				Enabled:   &enabled1,
+				AutoTune:  new(bool),
 			},
 			Category: models.CategoryHealth,
 			TenantID: tenantID1,
@@ -496,6 +753,7 @@ func TestGetAlertDefinitions(t *testing.T) {
 				Duration:  &dur2,
 				Threshold: &thres2,
 				Enabled:   &enabled2,
+				AutoTune:  new(bool),
 			},
 			Category: models.CategoryHealth,
 			TenantID: tenantID2,
@@ -504,9 +762,12 @@ func TestGetAlertDefinitions(t *testing.T) {
 		mDefinition := &DefinitionMock{}
 
 		// mock getting alert definitions from database.
-		mDefinition.On("GetLatestAlertDefinitionList", mock.Anything, tenantID1).Return([]*models.DBAlertDefinition{dbDef1}, nil).Once()
-		mDefinition.On("GetLatestAlertDefinitionList", mock.Anything, tenantID2).Return([]*models.DBAlertDefinition{dbDef2}, nil).Once()
-		mDefinition.On("GetLatestAlertDefinitionList", mock.Anything, "wrong_tenant").Return([]*models.DBAlertDefinition{}, nil).Once()
+		mDefinition.On("GetLatestAlertDefinitionList", mock.Anything, tenantID1, database.ListOptions{}).
+			Return([]*models.DBAlertDefinition{dbDef1}, int64(1), nil).Once()
+		mDefinition.On("GetLatestAlertDefinitionList", mock.Anything, tenantID2, database.ListOptions{}).
+			Return([]*models.DBAlertDefinition{dbDef2}, int64(1), nil).Once()
+		mDefinition.On("GetLatestAlertDefinitionList", mock.Anything, "wrong_tenant", database.ListOptions{}).
+			Return([]*models.DBAlertDefinition{}, int64(0), nil).Once()
 
 		handler := &ServerInterfaceHandler{
 			definitions: mDefinition,
@@ -537,12 +798,18 @@ func TestGetAlertDefinitions(t *testing.T) {
 					"duration":  "10s",
 					"threshold": "100",
 					"enabled":   "true",
+					"autoTune":  "false",
 				},
-				Version: &versionExp,
+				DurationSeconds:    dbDef1.Values.Duration,
+				ThresholdValue:     dbDef1.Values.Threshold,
+				Enabled:            dbDef1.Values.Enabled,
+				Version:            &versionExp,
+				ThresholdAutoTuned: new(bool),
 			},
 		}
 		definitionsListExp := &api.AlertDefinitionList{
 			AlertDefinitions: &definitionsExp,
+			TotalCount:       1,
 		}
 
 		definitions := []api.AlertDefinition{}
@@ -573,12 +840,18 @@ func TestGetAlertDefinitions(t *testing.T) {
 					"duration":  "10s",
 					"threshold": "100",
 					"enabled":   "true",
+					"autoTune":  "false",
 				},
-				Version: &versionExp,
+				DurationSeconds:    dbDef2.Values.Duration,
+				ThresholdValue:     dbDef2.Values.Threshold,
+				Enabled:            dbDef2.Values.Enabled,
+				Version:            &versionExp,
+				ThresholdAutoTuned: new(bool),
 			},
 		}
 		definitionsListExp = &api.AlertDefinitionList{
 			AlertDefinitions: &definitionsExp,
+			TotalCount:       1,
 		}
 
 		definitions = []api.AlertDefinition{}
@@ -625,7 +898,8 @@ func TestGetAlertDefinitions(t *testing.T) {
 		mDefinition := &DefinitionMock{}
 
 		// mock getting alert definitions from database.
-		mDefinition.On("GetLatestAlertDefinitionList", mock.Anything, tenantID).Return([]*models.DBAlertDefinition{dbDef}, nil).Once()
+		mDefinition.On("GetLatestAlertDefinitionList", mock.Anything, tenantID, database.ListOptions{}).
+			Return([]*models.DBAlertDefinition{dbDef}, int64(0), nil).Once()
 
 		handler := &ServerInterfaceHandler{
 			definitions: mDefinition,
@@ -682,6 +956,7 @@ func TestGetAlertDefinitions(t *testing.T) {
 				Duration:  &dur,
 				Threshold: &thres,
 				Enabled:   &enabled,
+				AutoTune:  new(bool),
 			},
 			Category: models.CategoryHealth,
 			TenantID: tenantID,
@@ -690,7 +965,8 @@ func TestGetAlertDefinitions(t *testing.T) {
 		mDefinition := &DefinitionMock{}
 
 		// mock getting alert definitions from database.
-		mDefinition.On("GetLatestAlertDefinitionList", mock.Anything, tenantID).Return([]*models.DBAlertDefinition{dbMaintenanceDef, dbDef}, nil).Once()
+		mDefinition.On("GetLatestAlertDefinitionList", mock.Anything, tenantID, database.ListOptions{}).
+			Return([]*models.DBAlertDefinition{dbMaintenanceDef, dbDef}, int64(1), nil).Once()
 
 		handler := &ServerInterfaceHandler{
 			definitions: mDefinition,
@@ -720,12 +996,18 @@ func TestGetAlertDefinitions(t *testing.T) {
 					"duration":  "10s",
 					"threshold": "100",
 					"enabled":   "true",
+					"autoTune":  "false",
 				},
-				Version: &versionExp,
+				DurationSeconds:    dbDef.Values.Duration,
+				ThresholdValue:     dbDef.Values.Threshold,
+				Enabled:            dbDef.Values.Enabled,
+				Version:            &versionExp,
+				ThresholdAutoTuned: new(bool),
 			},
 		}
 		definitionsListExp := &api.AlertDefinitionList{
 			AlertDefinitions: &definitionsExp,
+			TotalCount:       1,
 		}
 
 		definitions := []api.AlertDefinition{}
@@ -737,17 +1019,37 @@ func TestGetAlertDefinitions(t *testing.T) {
 
 		require.True(t, mDefinition.AssertExpectations(t))
 	})
-}
 
-func TestGetAlertDefinition(t *testing.T) {
-	t.Run("Alert definition not found", func(t *testing.T) {
-		id := uuid.New()
+	t.Run("Pagination and sorting parameters are passed to database and total count is returned", func(t *testing.T) {
+		tenantID := "edgenode"
+		dur := int64(10)
+		thres := int64(100)
+		enabled := true
+		dbDef := &models.DBAlertDefinition{
+			ID:    uuid.New(),
+			Name:  "alert3",
+			State: "applied",
+			Values: models.DBAlertDefinitionValues{
+				Duration:  &dur,
+				Threshold: &thres,
+				Enabled:   &enabled,
+				AutoTune:  new(bool),
+			},
+			Version:  1,
+			Category: models.CategoryHealth,
+			TenantID: tenantID,
+		}
 
 		mDefinition := &DefinitionMock{}
-		tenantID := "edgenode"
 
-		// mock getting alert definition from database.
-		mDefinition.On("GetLatestAlertDefinition", mock.Anything, tenantID, id).Return(nil, fmt.Errorf("mock error: %w", gorm.ErrRecordNotFound)).Once()
+		// mock getting the second page of alert definitions from database.
+		mDefinition.On("GetLatestAlertDefinitionList", mock.Anything, tenantID, database.ListOptions{
+			Limit:  2,
+			Offset: 2,
+			SortBy: database.SortByUpdatedAt,
+			Order:  database.SortDescending,
+		}).
+			Return([]*models.DBAlertDefinition{dbDef}, int64(3), nil).Once()
 
 		handler := &ServerInterfaceHandler{
 			definitions: mDefinition,
@@ -759,77 +1061,72 @@ func TestGetAlertDefinition(t *testing.T) {
 		// Registering API call handlers
 		api.RegisterHandlers(server, handler)
 
-		uri := fmt.Sprintf("/api/v1/alerts/definitions/%v", id.String())
-		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get(uri).GoWithHTTPHandler(t, server)
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get("/api/v1/alerts/definitions?limit=2&offset=2&sortBy=updatedAt&order=desc").
+			GoWithHTTPHandler(t, server)
+
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
 
 		body, err := io.ReadAll(result.Recorder.Body)
 		require.NoError(t, err)
 
-		httpErr := &api.HttpError{}
-		require.NoError(t, json.Unmarshal(body, httpErr))
-
-		require.Equal(t, http.StatusNotFound, httpErr.Code)
-		require.Contains(t, httpErr.Message, errHTTPAlertDefinitionNotFound)
+		definitions := []api.AlertDefinition{}
+		definitionsList := &api.AlertDefinitionList{
+			AlertDefinitions: &definitions,
+		}
+		require.NoError(t, json.Unmarshal(body, definitionsList))
+		require.Len(t, definitions, 1)
+		require.Equal(t, dbDef.ID, *definitions[0].Id)
+		require.Equal(t, 3, definitionsList.TotalCount)
 
 		require.True(t, mDefinition.AssertExpectations(t))
 	})
 
-	t.Run("Failed to retrieve alert definition by UUID from database", func(t *testing.T) {
-		id := uuid.New()
-
-		mDefinition := &DefinitionMock{}
+	t.Run("Invalid pagination or sorting parameters", func(t *testing.T) {
 		tenantID := "edgenode"
 
-		// mock getting alert definition from database.
-		mDefinition.On("GetLatestAlertDefinition", mock.Anything, tenantID, id).Return(nil, errors.New("error mock")).Once()
-
-		handler := &ServerInterfaceHandler{
-			definitions: mDefinition,
-		}
-
-		// Creating new Echo server
-		server := echo.New()
-
-		// Registering API call handlers
-		api.RegisterHandlers(server, handler)
-
-		uri := fmt.Sprintf("/api/v1/alerts/definitions/%v", id.String())
-		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get(uri).GoWithHTTPHandler(t, server)
+		for _, query := range []string{"limit=0", "limit=-1", "offset=-1", "sortBy=template", "order=up"} {
+			mDefinition := &DefinitionMock{}
+			handler := &ServerInterfaceHandler{
+				definitions: mDefinition,
+			}
 
-		body, err := io.ReadAll(result.Recorder.Body)
-		require.NoError(t, err)
+			// Creating new Echo server
+			server := echo.New()
 
-		httpErr := &api.HttpError{}
-		require.NoError(t, json.Unmarshal(body, httpErr))
+			// Registering API call handlers
+			api.RegisterHandlers(server, handler)
 
-		require.Equal(t, http.StatusInternalServerError, httpErr.Code)
-		require.Contains(t, httpErr.Message, errHTTPFailedToGetAlertDefinition)
+			result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get("/api/v1/alerts/definitions?"+query).
+				GoWithHTTPHandler(t, server)
 
-		require.True(t, mDefinition.AssertExpectations(t))
+			require.Equal(t, http.StatusBadRequest, result.Recorder.Code, query)
+			require.True(t, mDefinition.AssertExpectations(t))
+		}
 	})
 
-	t.Run("Succeeded to retrieve alert definition by UUID from database", func(t *testing.T) {
-		id := uuid.New()
-
-		mDefinition := &DefinitionMock{}
+	t.Run("Only selected fields are returned", func(t *testing.T) {
 		tenantID := "edgenode"
-
-		// mock getting alert definition from database.
 		dur := int64(10)
 		thres := int64(100)
 		enabled := true
 		dbDef := &models.DBAlertDefinition{
-			ID:    id,
+			ID:    uuid.New(),
 			Name:  "alert1",
 			State: "applied",
 			Values: models.DBAlertDefinitionValues{
 				Duration:  &dur,
 				Threshold: &thres,
 				Enabled:   &enabled,
+				AutoTune:  new(bool),
 			},
+			Version:  2,
+			Category: models.CategoryHealth,
 			TenantID: tenantID,
 		}
-		mDefinition.On("GetLatestAlertDefinition", mock.Anything, tenantID, id).Return(dbDef, nil).Once()
+
+		mDefinition := &DefinitionMock{}
+		mDefinition.On("GetLatestAlertDefinitionList", mock.Anything, tenantID, database.ListOptions{}).
+			Return([]*models.DBAlertDefinition{dbDef}, int64(1), nil).Once()
 
 		handler := &ServerInterfaceHandler{
 			definitions: mDefinition,
@@ -841,44 +1138,39 @@ func TestGetAlertDefinition(t *testing.T) {
 		// Registering API call handlers
 		api.RegisterHandlers(server, handler)
 
-		uri := fmt.Sprintf("/api/v1/alerts/definitions/%v", id.String())
-		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get(uri).GoWithHTTPHandler(t, server)
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get("/api/v1/alerts/definitions?fields=id,name,state").
+			GoWithHTTPHandler(t, server)
+
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
 
 		body, err := io.ReadAll(result.Recorder.Body)
 		require.NoError(t, err)
 
 		stateExp := api.StateDefinition(dbDef.State)
-		versionExp := int(dbDef.Version)
-		definitionExp := &api.AlertDefinition{
-			Id:    &dbDef.ID,
-			Name:  &dbDef.Name,
-			State: &stateExp,
-			Values: &map[string]string{
-				"duration":  "10s",
-				"threshold": "100",
-				"enabled":   "true",
+		definitionsExp := []api.AlertDefinition{
+			{
+				Id:    &dbDef.ID,
+				Name:  &dbDef.Name,
+				State: &stateExp,
 			},
-			Version: &versionExp,
+		}
+		definitionsListExp := &api.AlertDefinitionList{
+			AlertDefinitions: &definitionsExp,
+			TotalCount:       1,
 		}
 
-		definition := &api.AlertDefinition{}
-		require.NoError(t, json.Unmarshal(body, definition))
-		require.Equal(t, definitionExp, definition)
+		definitions := []api.AlertDefinition{}
+		definitionsList := &api.AlertDefinitionList{
+			AlertDefinitions: &definitions,
+		}
+		require.NoError(t, json.Unmarshal(body, definitionsList))
+		require.Equal(t, definitionsListExp, definitionsList)
 
 		require.True(t, mDefinition.AssertExpectations(t))
 	})
-}
-
-func TestGetAlertDefinitionTemplate(t *testing.T) {
-	t.Run("Alert definition template not found", func(t *testing.T) {
-		id := uuid.New()
 
+	t.Run("Unknown field is rejected", func(t *testing.T) {
 		mDefinition := &DefinitionMock{}
-		tenantID := "edgenode"
-
-		// mock getting alert definition from database.
-		mDefinition.On("GetLatestAlertDefinition", mock.Anything, tenantID, id).Return(nil, fmt.Errorf("mock error: %w", gorm.ErrRecordNotFound)).Once()
-
 		handler := &ServerInterfaceHandler{
 			definitions: mDefinition,
 		}
@@ -889,29 +1181,120 @@ func TestGetAlertDefinitionTemplate(t *testing.T) {
 		// Registering API call handlers
 		api.RegisterHandlers(server, handler)
 
-		uri := fmt.Sprintf("/api/v1/alerts/definitions/%v/template", id.String())
-		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get(uri).GoWithHTTPHandler(t, server)
-
-		body, err := io.ReadAll(result.Recorder.Body)
-		require.NoError(t, err)
-
-		httpErr := &api.HttpError{}
-		require.NoError(t, json.Unmarshal(body, httpErr))
-
-		require.Equal(t, http.StatusNotFound, httpErr.Code)
-		require.Contains(t, httpErr.Message, errHTTPAlertDefinitionTemplateNotFound)
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", "edgenode").Get("/api/v1/alerts/definitions?fields=id,template").
+			GoWithHTTPHandler(t, server)
 
+		require.Equal(t, http.StatusBadRequest, result.Recorder.Code)
 		require.True(t, mDefinition.AssertExpectations(t))
 	})
 
-	t.Run("Failed to retrieve alert definition template by UUID from database", func(t *testing.T) {
-		id := uuid.New()
-
-		mDefinition := &DefinitionMock{}
+	t.Run("Firing alert counts are reported when requested", func(t *testing.T) {
 		tenantID := "edgenode"
+		dur := int64(10)
+		thres := int64(100)
+		enabled := true
+		newDBDef := func(name string) *models.DBAlertDefinition {
+			return &models.DBAlertDefinition{
+				ID:    uuid.New(),
+				Name:  name,
+				State: "applied",
+				Values: models.DBAlertDefinitionValues{
+					Duration:  &dur,
+					Threshold: &thres,
+					Enabled:   &enabled,
+					AutoTune:  new(bool),
+				},
+				Version:  1,
+				Category: models.CategoryHealth,
+				TenantID: tenantID,
+			}
+		}
+		dbDef1 := newDBDef("alert1")
+		dbDef2 := newDBDef("alert2")
 
-		// mock getting alert definition from database.
-		mDefinition.On("GetLatestAlertDefinition", mock.Anything, tenantID, id).Return(nil, errors.New("error mock")).Once()
+		mDefinition := &DefinitionMock{}
+		mDefinition.On("GetLatestAlertDefinitionList", mock.Anything, tenantID, database.ListOptions{}).
+			Return([]*models.DBAlertDefinition{dbDef1, dbDef2}, int64(2), nil).Once()
+
+		amSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "/api/v2/alerts", r.URL.Path)
+			require.Equal(t, "true", r.URL.Query().Get("active"))
+			require.Equal(t, "false", r.URL.Query().Get("silenced"))
+			require.Equal(t, "false", r.URL.Query().Get("inhibited"))
+			require.Equal(t, `projectId="`+tenantID+`"`, r.URL.Query().Get("filter"))
+			fmt.Fprintf(w, `[{"annotations":{"am_uuid":%q}},{"annotations":{"am_uuid":%q}},{"annotations":{"summary":"test"}}]`,
+				dbDef1.ID, dbDef1.ID)
+		}))
+		defer amSrv.Close()
+
+		handler := &ServerInterfaceHandler{
+			definitions: mDefinition,
+			configuration: config.Config{
+				AlertManager: config.AlertManagerConfig{URL: amSrv.URL},
+			},
+		}
+
+		server := echo.New()
+		api.RegisterHandlers(server, handler)
+
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get("/api/v1/alerts/definitions?withStatus=true&fields=id,firingCount").
+			GoWithHTTPHandler(t, server)
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+
+		body, err := io.ReadAll(result.Recorder.Body)
+		require.NoError(t, err)
+
+		definitions := []api.AlertDefinition{}
+		require.NoError(t, json.Unmarshal(body, &api.AlertDefinitionList{AlertDefinitions: &definitions}))
+
+		firingCount1, firingCount2 := 2, 0
+		require.Equal(t, []api.AlertDefinition{
+			{Id: &dbDef1.ID, FiringCount: &firingCount1},
+			{Id: &dbDef2.ID, FiringCount: &firingCount2},
+		}, definitions)
+
+		require.True(t, mDefinition.AssertExpectations(t))
+	})
+
+	t.Run("Failed to get firing alert counts from alertmanager", func(t *testing.T) {
+		tenantID := "edgenode"
+
+		mDefinition := &DefinitionMock{}
+		mDefinition.On("GetLatestAlertDefinitionList", mock.Anything, tenantID, database.ListOptions{}).
+			Return([]*models.DBAlertDefinition{}, int64(0), nil).Once()
+
+		amSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer amSrv.Close()
+
+		handler := &ServerInterfaceHandler{
+			definitions: mDefinition,
+			configuration: config.Config{
+				AlertManager: config.AlertManagerConfig{URL: amSrv.URL},
+			},
+		}
+
+		server := echo.New()
+		api.RegisterHandlers(server, handler)
+
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get("/api/v1/alerts/definitions?withStatus=true").
+			GoWithHTTPHandler(t, server)
+		require.Equal(t, http.StatusInternalServerError, result.Recorder.Code)
+
+		require.True(t, mDefinition.AssertExpectations(t))
+	})
+}
+
+func TestGetAlertDefinition(t *testing.T) {
+	t.Run("Alert definition not found", func(t *testing.T) {
+		id := uuid.New()
+
+		mDefinition := &DefinitionMock{}
+		tenantID := "edgenode"
+
+		// mock getting alert definition from database.
+		mDefinition.On("GetLatestAlertDefinition", mock.Anything, tenantID, id).Return(nil, fmt.Errorf("mock error: %w", gorm.ErrRecordNotFound)).Once()
 
 		handler := &ServerInterfaceHandler{
 			definitions: mDefinition,
@@ -923,7 +1306,7 @@ func TestGetAlertDefinitionTemplate(t *testing.T) {
 		// Registering API call handlers
 		api.RegisterHandlers(server, handler)
 
-		uri := fmt.Sprintf("/api/v1/alerts/definitions/%v/template", id.String())
+		uri := fmt.Sprintf("/api/v1/alerts/definitions/%v", id.String())
 		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get(uri).GoWithHTTPHandler(t, server)
 
 		body, err := io.ReadAll(result.Recorder.Body)
@@ -932,30 +1315,21 @@ func TestGetAlertDefinitionTemplate(t *testing.T) {
 		httpErr := &api.HttpError{}
 		require.NoError(t, json.Unmarshal(body, httpErr))
 
-		require.Equal(t, http.StatusInternalServerError, httpErr.Code)
-		require.Contains(t, httpErr.Message, errHTTPFailedToGetAlertDefinitionTemplate)
+		require.Equal(t, http.StatusNotFound, httpErr.Code)
+		require.Contains(t, httpErr.Message, errHTTPAlertDefinitionNotFound)
+		require.Equal(t, api.ErrorCodeDefinitionNotFound, httpErr.ErrorCode)
 
 		require.True(t, mDefinition.AssertExpectations(t))
 	})
 
-	t.Run("Succeeded to get alert def template with rendered false", func(t *testing.T) {
+	t.Run("Failed to retrieve alert definition by UUID from database", func(t *testing.T) {
 		id := uuid.New()
 
 		mDefinition := &DefinitionMock{}
 		tenantID := "edgenode"
 
-		// mock getting alert definition template from database.
-		dur := int64(60)
-		thres := int64(80)
-		dbDef := &models.DBAlertDefinition{
-			Template: alertDefTemplateNotRendered,
-			Values: models.DBAlertDefinitionValues{
-				Duration:  &dur,
-				Threshold: &thres,
-			},
-			TenantID: tenantID,
-		}
-		mDefinition.On("GetLatestAlertDefinition", mock.Anything, tenantID, id).Return(dbDef, nil).Once()
+		// mock getting alert definition from database.
+		mDefinition.On("GetLatestAlertDefinition", mock.Anything, tenantID, id).Return(nil, errors.New("error mock")).Once()
 
 		handler := &ServerInterfaceHandler{
 			definitions: mDefinition,
@@ -967,40 +1341,40 @@ func TestGetAlertDefinitionTemplate(t *testing.T) {
 		// Registering API call handlers
 		api.RegisterHandlers(server, handler)
 
-		uri := fmt.Sprintf("/api/v1/alerts/definitions/%v/template?rendered=false", id.String())
+		uri := fmt.Sprintf("/api/v1/alerts/definitions/%v", id.String())
 		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get(uri).GoWithHTTPHandler(t, server)
 
 		body, err := io.ReadAll(result.Recorder.Body)
 		require.NoError(t, err)
 
-		var outTemplate api.AlertDefinitionTemplate
-		err = yaml.Unmarshal(body, &outTemplate) //nolint:musttag // api.AlertDefinitionTemplate contains autogenerated code
-		require.NoError(t, err, "failed to unmarshal body response into template")
+		httpErr := &api.HttpError{}
+		require.NoError(t, json.Unmarshal(body, httpErr))
 
-		var expectedTemplate api.AlertDefinitionTemplate
-		err = yaml.Unmarshal([]byte(dbDef.Template), &expectedTemplate) //nolint:musttag // api.AlertDefinitionTemplate contains autogenerated code
-		require.NoError(t, err, "failed to unmarshal expected body to yaml")
+		require.Equal(t, http.StatusInternalServerError, httpErr.Code)
+		require.Contains(t, httpErr.Message, errHTTPFailedToGetAlertDefinition)
 
-		require.Equal(t, expectedTemplate, outTemplate)
 		require.True(t, mDefinition.AssertExpectations(t))
 	})
 
-	t.Run("Succeeded to get alert def template with rendered true", func(t *testing.T) {
+	t.Run("Succeeded to retrieve alert definition by UUID from database", func(t *testing.T) {
 		id := uuid.New()
 
 		mDefinition := &DefinitionMock{}
 		tenantID := "edgenode"
 
-		// mock getting alert definition template from database.
-		dur := int64(60)
-		thres := int64(80)
+		// mock getting alert definition from database.
+		dur := int64(10)
+		thres := int64(100)
 		enabled := true
 		dbDef := &models.DBAlertDefinition{
-			Template: alertDefTemplateRendered,
+			ID:    id,
+			Name:  "alert1",
+			State: "applied",
 			Values: models.DBAlertDefinitionValues{
 				Duration:  &dur,
 				Threshold: &thres,
 				Enabled:   &enabled,
+				AutoTune:  new(bool),
 			},
 			TenantID: tenantID,
 		}
@@ -1016,79 +1390,114 @@ func TestGetAlertDefinitionTemplate(t *testing.T) {
 		// Registering API call handlers
 		api.RegisterHandlers(server, handler)
 
-		uri := fmt.Sprintf("/api/v1/alerts/definitions/%v/template?rendered=true", id.String())
+		uri := fmt.Sprintf("/api/v1/alerts/definitions/%v", id.String())
 		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get(uri).GoWithHTTPHandler(t, server)
 
 		body, err := io.ReadAll(result.Recorder.Body)
 		require.NoError(t, err)
 
-		var outTemplate api.AlertDefinitionTemplate
-		err = yaml.Unmarshal(body, &outTemplate) //nolint:musttag // api.AlertDefinitionTemplate contains autogenerated code
-		require.NoError(t, err, "failed to unmarshal body response into template")
+		stateExp := api.StateDefinition(dbDef.State)
+		versionExp := int(dbDef.Version)
+		definitionExp := &api.AlertDefinition{
+			Id:    &dbDef.ID,
+			Name:  &dbDef.Name,
+			State: &stateExp,
+			Values: &map[string]string{
+				"duration":  "10s",
+				"threshold": "100",
+				"enabled":   "true",
+				"autoTune":  "false",
+			},
+			DurationSeconds:    dbDef.Values.Duration,
+			ThresholdValue:     dbDef.Values.Threshold,
+			Enabled:            dbDef.Values.Enabled,
+			Version:            &versionExp,
+			ThresholdAutoTuned: new(bool),
+		}
 
-		var expectedTemplate api.AlertDefinitionTemplate
-		err = yaml.Unmarshal([]byte(dbDef.Template), &expectedTemplate) //nolint:musttag // api.AlertDefinitionTemplate contains autogenerated code
-		require.NoError(t, err, "failed to unmarshal expected body to yaml")
+		definition := &api.AlertDefinition{}
+		require.NoError(t, json.Unmarshal(body, definition))
+		require.Equal(t, definitionExp, definition)
 
-		require.Equal(t, expectedTemplate, outTemplate)
 		require.True(t, mDefinition.AssertExpectations(t))
 	})
 
-	t.Run("Failed to get alert def template with rendered false due to unmarshalling", func(t *testing.T) {
+	t.Run("Typed values are returned", func(t *testing.T) {
 		id := uuid.New()
-
-		mDefinition := &DefinitionMock{}
 		tenantID := "edgenode"
 
-		// mock getting alert definition template from database.
+		dur := int64(90)
+		thres := int64(75)
+		enabled := false
 		dbDef := &models.DBAlertDefinition{
-			Template: "invalid yaml -",
+			ID:    id,
+			Name:  "alert1",
+			State: "applied",
+			Values: models.DBAlertDefinitionValues{
+				Duration:  &dur,
+				Threshold: &thres,
+				Enabled:   &enabled,
+				AutoTune:  new(bool),
+			},
+			TenantID: tenantID,
 		}
+		mDefinition := &DefinitionMock{}
 		mDefinition.On("GetLatestAlertDefinition", mock.Anything, tenantID, id).Return(dbDef, nil).Once()
 
-		handler := &ServerInterfaceHandler{
-			definitions: mDefinition,
-		}
-
-		// Creating new Echo server
 		server := echo.New()
+		api.RegisterHandlers(server, &ServerInterfaceHandler{definitions: mDefinition})
 
-		// Registering API call handlers
-		api.RegisterHandlers(server, handler)
-
-		uri := fmt.Sprintf("/api/v1/alerts/definitions/%v/template?rendered=false", id.String())
+		uri := fmt.Sprintf("/api/v1/alerts/definitions/%v?fields=values,durationSeconds,thresholdValue,enabled", id.String())
 		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get(uri).GoWithHTTPHandler(t, server)
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
 
 		body, err := io.ReadAll(result.Recorder.Body)
 		require.NoError(t, err)
 
-		httpErr := &api.HttpError{}
-		require.NoError(t, json.Unmarshal(body, httpErr))
-
-		require.Equal(t, http.StatusInternalServerError, httpErr.Code)
-		require.Contains(t, httpErr.Message, errHTTPFailedToGetAlertDefinitionTemplate)
+		definition := &api.AlertDefinition{}
+		require.NoError(t, json.Unmarshal(body, definition))
+		require.Equal(t, &api.AlertDefinition{
+			Values: &map[string]string{
+				"duration":  "1m30s",
+				"threshold": "75",
+				"enabled":   "false",
+				"autoTune":  "false",
+			},
+			DurationSeconds: &dur,
+			ThresholdValue:  &thres,
+			Enabled:         &enabled,
+		}, definition)
 
 		require.True(t, mDefinition.AssertExpectations(t))
 	})
 
-	t.Run("Failed to get alert def template due to bad expression", func(t *testing.T) {
+	t.Run("Creation, update and application times are returned", func(t *testing.T) {
 		id := uuid.New()
 
 		mDefinition := &DefinitionMock{}
 		tenantID := "edgenode"
 
-		// mock getting alert definition template from database.
-		dur := int64(60)
-		thres := int64(80)
+		dur := int64(10)
+		thres := int64(100)
 		enabled := true
+		createdAt := time.Date(2025, time.March, 1, 10, 0, 0, 0, time.UTC)
+		updatedAt := createdAt.Add(2 * time.Hour)
+		appliedAt := createdAt.Add(time.Hour)
 		dbDef := &models.DBAlertDefinition{
-			Template: alertDefTemplateBadExpression,
+			ID:    id,
+			Name:  "alert1",
+			State: "applied",
 			Values: models.DBAlertDefinitionValues{
 				Duration:  &dur,
 				Threshold: &thres,
 				Enabled:   &enabled,
+				AutoTune:  new(bool),
 			},
-			TenantID: tenantID,
+			Version:   2,
+			TenantID:  tenantID,
+			CreatedAt: createdAt,
+			UpdatedAt: updatedAt,
+			AppliedAt: &appliedAt,
 		}
 		mDefinition.On("GetLatestAlertDefinition", mock.Anything, tenantID, id).Return(dbDef, nil).Once()
 
@@ -1096,48 +1505,116 @@ func TestGetAlertDefinitionTemplate(t *testing.T) {
 			definitions: mDefinition,
 		}
 
-		// Creating new Echo server
 		server := echo.New()
-
-		// Registering API call handlers
 		api.RegisterHandlers(server, handler)
 
-		uri := fmt.Sprintf("/api/v1/alerts/definitions/%v/template?rendered=true", id.String())
+		uri := fmt.Sprintf("/api/v1/alerts/definitions/%v?fields=id,createdAt,updatedAt,appliedAt", id.String())
 		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get(uri).GoWithHTTPHandler(t, server)
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
 
 		body, err := io.ReadAll(result.Recorder.Body)
 		require.NoError(t, err)
 
-		httpErr := &api.HttpError{}
-		require.NoError(t, json.Unmarshal(body, httpErr))
-
-		require.Equal(t, http.StatusInternalServerError, httpErr.Code)
-		require.Contains(t, httpErr.Message, errHTTPFailedToGetAlertDefinitionTemplate)
+		definition := &api.AlertDefinition{}
+		require.NoError(t, json.Unmarshal(body, definition))
+		require.Equal(t, &api.AlertDefinition{
+			Id:        &id,
+			CreatedAt: &createdAt,
+			UpdatedAt: &updatedAt,
+			AppliedAt: &appliedAt,
+		}, definition)
 
 		require.True(t, mDefinition.AssertExpectations(t))
 	})
 
-	t.Run("Succeeded to get alert def template with rendered true where duration is templated", func(t *testing.T) {
+	t.Run("Evaluation health is returned", func(t *testing.T) {
 		id := uuid.New()
-
-		mDefinition := &DefinitionMock{}
 		tenantID := "edgenode"
 
-		// mock getting alert definition template from database.
-		dur := int64(60)
-		thres := int64(80)
+		dur := int64(10)
+		thres := int64(100)
 		enabled := true
 		dbDef := &models.DBAlertDefinition{
-			Template: alertDefTemplateRenderedDuration,
+			ID:    id,
+			Name:  "alert1",
+			State: "applied",
 			Values: models.DBAlertDefinitionValues{
 				Duration:  &dur,
 				Threshold: &thres,
 				Enabled:   &enabled,
+				AutoTune:  new(bool),
 			},
 			TenantID: tenantID,
 		}
+		mDefinition := &DefinitionMock{}
 		mDefinition.On("GetLatestAlertDefinition", mock.Anything, tenantID, id).Return(dbDef, nil).Once()
 
+		lastEvaluation := time.Date(2026, time.October, 16, 12, 0, 0, 0, time.UTC)
+		mEvaluations := &RuleEvaluationReporterMock{}
+		mEvaluations.On("GetRuleEvaluationHealth", mock.Anything, tenantID, []uuid.UUID{id}).Return(map[uuid.UUID]models.RuleEvaluationHealth{
+			id: {
+				Evaluations:        4,
+				Failures:           1,
+				AvgDurationSeconds: 6.5,
+				MaxDurationSeconds: 12,
+				LastEvaluationDate: lastEvaluation,
+				LastError:          "query timed out",
+			},
+		}, nil).Once()
+
+		handler := &ServerInterfaceHandler{
+			definitions: mDefinition,
+			evaluations: mEvaluations,
+			configuration: config.Config{
+				RuleEvaluation: config.RuleEvaluationConfig{SlowThreshold: 5 * time.Second},
+			},
+		}
+
+		server := echo.New()
+		api.RegisterHandlers(server, handler)
+
+		uri := fmt.Sprintf("/api/v1/alerts/definitions/%v?fields=id,evaluationHealth", id.String())
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get(uri).GoWithHTTPHandler(t, server)
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+
+		body, err := io.ReadAll(result.Recorder.Body)
+		require.NoError(t, err)
+
+		evaluations, failures := 4, 1
+		avg, maxDuration := float32(6.5), float32(12)
+		lastError := "query timed out"
+		slow := true
+
+		definition := &api.AlertDefinition{}
+		require.NoError(t, json.Unmarshal(body, definition))
+		require.Equal(t, &api.AlertDefinition{
+			Id: &id,
+			EvaluationHealth: &api.EvaluationHealth{
+				Evaluations:     &evaluations,
+				Failures:        &failures,
+				AverageDuration: &avg,
+				MaxDuration:     &maxDuration,
+				LastEvaluatedAt: &lastEvaluation,
+				LastError:       &lastError,
+				Slow:            &slow,
+			},
+		}, definition)
+
+		require.True(t, mDefinition.AssertExpectations(t))
+		require.True(t, mEvaluations.AssertExpectations(t))
+	})
+}
+
+func TestGetAlertDefinitionTemplate(t *testing.T) {
+	t.Run("Alert definition template not found", func(t *testing.T) {
+		id := uuid.New()
+
+		mDefinition := &DefinitionMock{}
+		tenantID := "edgenode"
+
+		// mock getting alert definition from database.
+		mDefinition.On("GetLatestAlertDefinition", mock.Anything, tenantID, id).Return(nil, fmt.Errorf("mock error: %w", gorm.ErrRecordNotFound)).Once()
+
 		handler := &ServerInterfaceHandler{
 			definitions: mDefinition,
 		}
@@ -1148,134 +1625,73 @@ func TestGetAlertDefinitionTemplate(t *testing.T) {
 		// Registering API call handlers
 		api.RegisterHandlers(server, handler)
 
-		uri := fmt.Sprintf("/api/v1/alerts/definitions/%v/template?rendered=true", id.String())
+		uri := fmt.Sprintf("/api/v1/alerts/definitions/%v/template", id.String())
 		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get(uri).GoWithHTTPHandler(t, server)
 
 		body, err := io.ReadAll(result.Recorder.Body)
 		require.NoError(t, err)
 
-		var outTemplate api.AlertDefinitionTemplate
-		err = yaml.Unmarshal(body, &outTemplate) //nolint:musttag // api.AlertDefinitionTemplate contains autogenerated code
-		require.NoError(t, err, "failed to unmarshal body response into template")
+		httpErr := &api.HttpError{}
+		require.NoError(t, json.Unmarshal(body, httpErr))
 
-		var expectedTemplate api.AlertDefinitionTemplate
-		err = yaml.Unmarshal([]byte(dbDef.Template), &expectedTemplate) //nolint:musttag // api.AlertDefinitionTemplate contains autogenerated code
-		require.NoError(t, err, "failed to unmarshal expected body to yaml")
+		require.Equal(t, http.StatusNotFound, httpErr.Code)
+		require.Contains(t, httpErr.Message, errHTTPAlertDefinitionTemplateNotFound)
 
-		require.Equal(t, expectedTemplate, outTemplate)
 		require.True(t, mDefinition.AssertExpectations(t))
 	})
-}
 
-func stringPtr(s string) *string { return &s }
+	t.Run("Failed to retrieve alert definition template by UUID from database", func(t *testing.T) {
+		id := uuid.New()
 
-func int64Ptr(i int64) *int64 { return &i }
+		mDefinition := &DefinitionMock{}
+		tenantID := "edgenode"
 
-func boolPtr(b bool) *bool { return &b }
+		// mock getting alert definition from database.
+		mDefinition.On("GetLatestAlertDefinition", mock.Anything, tenantID, id).Return(nil, errors.New("error mock")).Once()
 
-func TestPatchAlertDefinition(t *testing.T) {
-	testCases := []struct {
-		name     string
-		payload  []byte
-		httpCode int
-		errMsg   string
-	}{
-		{
-			name:    "Request body missing values field",
-			payload: []byte(`{"threshold":"10","duration":"8m","enabled":true}`),
-			errMsg:  errHTTPBadRequest,
-		},
-		{
-			name:    "Request body has unknown fields",
-			payload: []byte(`{"vals":{"threshold":"10","duration":"8m","enabled":true}}`),
-			errMsg:  errHTTPBadRequest,
-		},
-		{
-			name:    "Request body has unknown value fields",
-			payload: []byte(`{"values":{"threshold":"10","time":"8m","enabled":true}}`),
-			errMsg:  errHTTPBadRequest,
-		},
-		{
-			name:    "Request body has no values to set",
-			payload: []byte(`{"values":{}}`),
-			errMsg:  errHTTPFailedToPatchAlertDefinition,
-		},
-		{
-			name:    "Duration value format is invalid",
-			payload: []byte(`{"values":{"duration":"2sec"}}`),
-			errMsg:  errHTTPFailedToPatchAlertDefinition,
-		},
-		{
-			name:    "Duration value cannot be fraction of a second",
-			payload: []byte(`{"values":{"duration":"100ms"}}`),
-			errMsg:  errHTTPFailedToPatchAlertDefinition,
-		},
-		{
-			name:    "Duration value cannot be zero",
-			payload: []byte(`{"values":{"duration":"0m"}}`),
-			errMsg:  errHTTPFailedToPatchAlertDefinition,
-		},
-		{
-			name:    "Enabled value is not a boolean",
-			payload: []byte(`{"values":{"enabled":"yes"}}`),
-			errMsg:  errHTTPFailedToPatchAlertDefinition,
-		},
-		{
-			name:    "Threshold value is a non numeric string",
-			payload: []byte(`{"values":{"threshold":"ten"}}`),
-			errMsg:  errHTTPFailedToPatchAlertDefinition,
-		},
-		{
-			name:    "Duration value string has invalid format",
-			payload: []byte(`{"values":{"duration":"one second"}}`),
-			errMsg:  errHTTPFailedToPatchAlertDefinition,
-		},
-	}
+		handler := &ServerInterfaceHandler{
+			definitions: mDefinition,
+		}
 
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			handler := &ServerInterfaceHandler{}
-			tenantID := "edgenode"
+		// Creating new Echo server
+		server := echo.New()
 
-			// Creating new Echo server
-			server := echo.New()
+		// Registering API call handlers
+		api.RegisterHandlers(server, handler)
 
-			// Registering API call handlers
-			api.RegisterHandlers(server, handler)
+		uri := fmt.Sprintf("/api/v1/alerts/definitions/%v/template", id.String())
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get(uri).GoWithHTTPHandler(t, server)
 
-			request := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).
-				Patch("/api/v1/alerts/definitions/01e74407-0327-4e36-93cb-85801c098ba5").WithBody(tc.payload)
-			result := request.GoWithHTTPHandler(t, server)
+		body, err := io.ReadAll(result.Recorder.Body)
+		require.NoError(t, err)
 
-			body, err := io.ReadAll(result.Recorder.Body)
-			require.NoError(t, err)
+		httpErr := &api.HttpError{}
+		require.NoError(t, json.Unmarshal(body, httpErr))
 
-			httpErr := &api.HttpError{}
-			require.NoError(t, json.Unmarshal(body, httpErr))
+		require.Equal(t, http.StatusInternalServerError, httpErr.Code)
+		require.Contains(t, httpErr.Message, errHTTPFailedToGetAlertDefinitionTemplate)
 
-			require.Equal(t, http.StatusBadRequest, httpErr.Code)
-			require.Contains(t, httpErr.Message, tc.errMsg)
-		})
-	}
+		require.True(t, mDefinition.AssertExpectations(t))
+	})
 
-	t.Run("Alert definition not found", func(t *testing.T) {
+	t.Run("Succeeded to get alert def template with rendered false", func(t *testing.T) {
 		id := uuid.New()
-		tenantID := "edgenode"
-
-		threshold := int64(10)
-		duration := int64(45)
-		enabled := true
-
-		values := models.DBAlertDefinitionValues{
-			Threshold: &threshold,
-			Duration:  &duration,
-			Enabled:   &enabled,
-		}
 
 		mDefinition := &DefinitionMock{}
+		tenantID := "edgenode"
 
-		// mock setting values to alert definition.
-		mDefinition.On("SetAlertDefinitionValues", mock.Anything, tenantID, id, values).Return(fmt.Errorf("mock error: %w", gorm.ErrRecordNotFound)).Once()
+		// mock getting alert definition template from database.
+		dur := int64(60)
+		thres := int64(80)
+		dbDef := &models.DBAlertDefinition{
+			Template: alertDefTemplateNotRendered,
+			Values: models.DBAlertDefinitionValues{
+				Duration:  &dur,
+				Threshold: &thres,
+			},
+			TenantID: tenantID,
+		}
+		mDefinition.On("GetLatestAlertDefinition", mock.Anything, tenantID, id).Return(dbDef, nil).Once()
 
 		handler := &ServerInterfaceHandler{
 			definitions: mDefinition,
@@ -1287,42 +1703,44 @@ func TestPatchAlertDefinition(t *testing.T) {
 		// Registering API call handlers
 		api.RegisterHandlers(server, handler)
 
-		bodyStr := fmt.Sprintf(`{"values":{"threshold":"%d","duration":"%ds","enabled":"%v"}}`, threshold, duration, enabled)
-
-		uri := fmt.Sprintf("/api/v1/alerts/definitions/%v", id.String())
-		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Patch(uri).WithBody([]byte(bodyStr)).GoWithHTTPHandler(t, server)
+		uri := fmt.Sprintf("/api/v1/alerts/definitions/%v/template?rendered=false", id.String())
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get(uri).GoWithHTTPHandler(t, server)
 
 		body, err := io.ReadAll(result.Recorder.Body)
 		require.NoError(t, err)
 
-		httpErr := &api.HttpError{}
-		require.NoError(t, json.Unmarshal(body, httpErr))
+		var outTemplate api.AlertDefinitionTemplate
+		err = yaml.Unmarshal(body, &outTemplate) //nolint:musttag // api.AlertDefinitionTemplate contains autogenerated code
+		require.NoError(t, err, "failed to unmarshal body response into template")
 
-		require.Equal(t, http.StatusNotFound, httpErr.Code)
-		require.Contains(t, httpErr.Message, errHTTPAlertDefinitionNotFound)
+		var expectedTemplate api.AlertDefinitionTemplate
+		err = yaml.Unmarshal([]byte(dbDef.Template), &expectedTemplate) //nolint:musttag // api.AlertDefinitionTemplate contains autogenerated code
+		require.NoError(t, err, "failed to unmarshal expected body to yaml")
 
+		require.Equal(t, expectedTemplate, outTemplate)
 		require.True(t, mDefinition.AssertExpectations(t))
 	})
 
-	t.Run("Alert definition value is out-of-bounds", func(t *testing.T) {
+	t.Run("Succeeded to get alert def template with rendered true", func(t *testing.T) {
 		id := uuid.New()
+
+		mDefinition := &DefinitionMock{}
 		tenantID := "edgenode"
 
-		threshold := int64(10)
-		duration := int64(45)
+		// mock getting alert definition template from database.
+		dur := int64(60)
+		thres := int64(80)
 		enabled := true
-
-		values := models.DBAlertDefinitionValues{
-			Threshold: &threshold,
-			Duration:  &duration,
-			Enabled:   &enabled,
+		dbDef := &models.DBAlertDefinition{
+			Template: alertDefTemplateRendered,
+			Values: models.DBAlertDefinitionValues{
+				Duration:  &dur,
+				Threshold: &thres,
+				Enabled:   &enabled,
+			},
+			TenantID: tenantID,
 		}
-
-		mDefinition := &DefinitionMock{}
-
-		// mock setting values to alert definition.
-		mDefinition.On("SetAlertDefinitionValues", mock.Anything, tenantID, id, values).
-			Return(fmt.Errorf("error mock: %w", database.ErrValueOutOfBounds)).Once()
+		mDefinition.On("GetLatestAlertDefinition", mock.Anything, tenantID, id).Return(dbDef, nil).Once()
 
 		handler := &ServerInterfaceHandler{
 			definitions: mDefinition,
@@ -1334,41 +1752,36 @@ func TestPatchAlertDefinition(t *testing.T) {
 		// Registering API call handlers
 		api.RegisterHandlers(server, handler)
 
-		bodyStr := fmt.Sprintf(`{"values":{"threshold":"%d","duration":"%ds","enabled":"%v"}}`, threshold, duration, enabled)
-
-		uri := fmt.Sprintf("/api/v1/alerts/definitions/%v", id.String())
-		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Patch(uri).WithBody([]byte(bodyStr)).GoWithHTTPHandler(t, server)
+		uri := fmt.Sprintf("/api/v1/alerts/definitions/%v/template?rendered=true", id.String())
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get(uri).GoWithHTTPHandler(t, server)
 
 		body, err := io.ReadAll(result.Recorder.Body)
 		require.NoError(t, err)
 
-		httpErr := &api.HttpError{}
-		require.NoError(t, json.Unmarshal(body, httpErr))
+		var outTemplate api.AlertDefinitionTemplate
+		err = yaml.Unmarshal(body, &outTemplate) //nolint:musttag // api.AlertDefinitionTemplate contains autogenerated code
+		require.NoError(t, err, "failed to unmarshal body response into template")
 
-		require.Equal(t, http.StatusBadRequest, httpErr.Code)
-		require.Contains(t, httpErr.Message, "alert definition value/s out-of-bounds")
+		var expectedTemplate api.AlertDefinitionTemplate
+		err = yaml.Unmarshal([]byte(dbDef.Template), &expectedTemplate) //nolint:musttag // api.AlertDefinitionTemplate contains autogenerated code
+		require.NoError(t, err, "failed to unmarshal expected body to yaml")
 
+		expectedTemplate.Deployed = &enabled
+		require.Equal(t, expectedTemplate, outTemplate)
 		require.True(t, mDefinition.AssertExpectations(t))
 	})
 
-	t.Run("Failed setting values to alert definition", func(t *testing.T) {
+	t.Run("Failed to get alert def template with rendered false due to unmarshalling", func(t *testing.T) {
 		id := uuid.New()
-		tenantID := "edgenode"
-
-		threshold := int64(10)
-		duration := int64(45)
-		enabled := true
-
-		values := models.DBAlertDefinitionValues{
-			Threshold: &threshold,
-			Duration:  &duration,
-			Enabled:   &enabled,
-		}
 
 		mDefinition := &DefinitionMock{}
+		tenantID := "edgenode"
 
-		// mock setting values to alert definition.
-		mDefinition.On("SetAlertDefinitionValues", mock.Anything, tenantID, id, values).Return(errors.New("mock error")).Once()
+		// mock getting alert definition template from database.
+		dbDef := &models.DBAlertDefinition{
+			Template: "invalid yaml -",
+		}
+		mDefinition.On("GetLatestAlertDefinition", mock.Anything, tenantID, id).Return(dbDef, nil).Once()
 
 		handler := &ServerInterfaceHandler{
 			definitions: mDefinition,
@@ -1380,10 +1793,8 @@ func TestPatchAlertDefinition(t *testing.T) {
 		// Registering API call handlers
 		api.RegisterHandlers(server, handler)
 
-		bodyStr := fmt.Sprintf(`{"values":{"threshold":"%d","duration":"%ds","enabled":"%v"}}`, threshold, duration, enabled)
-
-		uri := fmt.Sprintf("/api/v1/alerts/definitions/%v", id.String())
-		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Patch(uri).WithBody([]byte(bodyStr)).GoWithHTTPHandler(t, server)
+		uri := fmt.Sprintf("/api/v1/alerts/definitions/%v/template?rendered=false", id.String())
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get(uri).GoWithHTTPHandler(t, server)
 
 		body, err := io.ReadAll(result.Recorder.Body)
 		require.NoError(t, err)
@@ -1392,29 +1803,31 @@ func TestPatchAlertDefinition(t *testing.T) {
 		require.NoError(t, json.Unmarshal(body, httpErr))
 
 		require.Equal(t, http.StatusInternalServerError, httpErr.Code)
-		require.Contains(t, httpErr.Message, errHTTPFailedToPatchAlertDefinition)
+		require.Contains(t, httpErr.Message, errHTTPFailedToGetAlertDefinitionTemplate)
 
 		require.True(t, mDefinition.AssertExpectations(t))
 	})
 
-	t.Run("Succeeded setting values to alert definition", func(t *testing.T) {
+	t.Run("Failed to get alert def template due to bad expression", func(t *testing.T) {
 		id := uuid.New()
+
+		mDefinition := &DefinitionMock{}
 		tenantID := "edgenode"
 
-		threshold := int64(10)
-		duration := int64(45)
+		// mock getting alert definition template from database.
+		dur := int64(60)
+		thres := int64(80)
 		enabled := true
-
-		values := models.DBAlertDefinitionValues{
-			Threshold: &threshold,
-			Duration:  &duration,
-			Enabled:   &enabled,
+		dbDef := &models.DBAlertDefinition{
+			Template: alertDefTemplateBadExpression,
+			Values: models.DBAlertDefinitionValues{
+				Duration:  &dur,
+				Threshold: &thres,
+				Enabled:   &enabled,
+			},
+			TenantID: tenantID,
 		}
-
-		mDefinition := &DefinitionMock{}
-
-		// mock setting values to alert definition.
-		mDefinition.On("SetAlertDefinitionValues", mock.Anything, tenantID, id, values).Return(nil).Once()
+		mDefinition.On("GetLatestAlertDefinition", mock.Anything, tenantID, id).Return(dbDef, nil).Once()
 
 		handler := &ServerInterfaceHandler{
 			definitions: mDefinition,
@@ -1426,66 +1839,1286 @@ func TestPatchAlertDefinition(t *testing.T) {
 		// Registering API call handlers
 		api.RegisterHandlers(server, handler)
 
-		bodyStr := fmt.Sprintf(`{"values":{"threshold":"%d","duration":"%ds","enabled":"%v"}}`, threshold, duration, enabled)
+		uri := fmt.Sprintf("/api/v1/alerts/definitions/%v/template?rendered=true", id.String())
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get(uri).GoWithHTTPHandler(t, server)
 
-		uri := fmt.Sprintf("/api/v1/alerts/definitions/%v", id.String())
-		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Patch(uri).WithBody([]byte(bodyStr)).GoWithHTTPHandler(t, server)
-		require.Equal(t, http.StatusNoContent, result.Recorder.Code)
+		body, err := io.ReadAll(result.Recorder.Body)
+		require.NoError(t, err)
+
+		httpErr := &api.HttpError{}
+		require.NoError(t, json.Unmarshal(body, httpErr))
+
+		require.Equal(t, http.StatusInternalServerError, httpErr.Code)
+		require.Contains(t, httpErr.Message, errHTTPFailedToGetAlertDefinitionTemplate)
 
 		require.True(t, mDefinition.AssertExpectations(t))
 	})
-}
 
-// ReceiverMock represents a mock for receiver database operations. Implements ReceiverManager interface.
-type ReceiverMock struct {
-	mock.Mock
-}
+	t.Run("Succeeded to get alert def template with rendered true where duration is templated", func(t *testing.T) {
+		id := uuid.New()
 
-func (m *ReceiverMock) GetLatestReceiverWithEmailConfig(ctx context.Context, tenantID api.TenantID, id uuid.UUID) (*models.DBReceiver, error) {
-	args := m.Called(ctx, tenantID, id)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
+		mDefinition := &DefinitionMock{}
+		tenantID := "edgenode"
+
+		// mock getting alert definition template from database.
+		dur := int64(60)
+		thres := int64(80)
+		enabled := true
+		dbDef := &models.DBAlertDefinition{
+			Template: alertDefTemplateRenderedDuration,
+			Values: models.DBAlertDefinitionValues{
+				Duration:  &dur,
+				Threshold: &thres,
+				Enabled:   &enabled,
+			},
+			TenantID: tenantID,
+		}
+		mDefinition.On("GetLatestAlertDefinition", mock.Anything, tenantID, id).Return(dbDef, nil).Once()
+
+		handler := &ServerInterfaceHandler{
+			definitions: mDefinition,
+		}
+
+		// Creating new Echo server
+		server := echo.New()
+
+		// Registering API call handlers
+		api.RegisterHandlers(server, handler)
+
+		uri := fmt.Sprintf("/api/v1/alerts/definitions/%v/template?rendered=true", id.String())
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get(uri).GoWithHTTPHandler(t, server)
+
+		body, err := io.ReadAll(result.Recorder.Body)
+		require.NoError(t, err)
+
+		var outTemplate api.AlertDefinitionTemplate
+		err = yaml.Unmarshal(body, &outTemplate) //nolint:musttag // api.AlertDefinitionTemplate contains autogenerated code
+		require.NoError(t, err, "failed to unmarshal body response into template")
+
+		var expectedTemplate api.AlertDefinitionTemplate
+		err = yaml.Unmarshal([]byte(dbDef.Template), &expectedTemplate) //nolint:musttag // api.AlertDefinitionTemplate contains autogenerated code
+		require.NoError(t, err, "failed to unmarshal expected body to yaml")
+
+		expectedTemplate.Deployed = &enabled
+		require.Equal(t, expectedTemplate, outTemplate)
+		require.True(t, mDefinition.AssertExpectations(t))
+	})
+
+	t.Run("Succeeded to get rendered alert def template of disabled alert definition", func(t *testing.T) {
+		id := uuid.New()
+
+		mDefinition := &DefinitionMock{}
+		tenantID := "edgenode"
+
+		// mock getting alert definition template from database.
+		dur := int64(60)
+		thres := int64(80)
+		enabled := false
+		dbDef := &models.DBAlertDefinition{
+			Template: alertDefTemplateRendered,
+			Values: models.DBAlertDefinitionValues{
+				Duration:  &dur,
+				Threshold: &thres,
+				Enabled:   &enabled,
+			},
+			TenantID: tenantID,
+		}
+		mDefinition.On("GetLatestAlertDefinition", mock.Anything, tenantID, id).Return(dbDef, nil).Once()
+
+		handler := &ServerInterfaceHandler{
+			definitions: mDefinition,
+		}
+
+		// Creating new Echo server
+		server := echo.New()
+
+		// Registering API call handlers
+		api.RegisterHandlers(server, handler)
+
+		uri := fmt.Sprintf("/api/v1/alerts/definitions/%v/template?rendered=true", id.String())
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get(uri).GoWithHTTPHandler(t, server)
+
+		body, err := io.ReadAll(result.Recorder.Body)
+		require.NoError(t, err)
+
+		var outTemplate api.AlertDefinitionTemplate
+		err = yaml.Unmarshal(body, &outTemplate) //nolint:musttag // api.AlertDefinitionTemplate contains autogenerated code
+		require.NoError(t, err, "failed to unmarshal body response into template")
+
+		var expectedTemplate api.AlertDefinitionTemplate
+		err = yaml.Unmarshal([]byte(dbDef.Template), &expectedTemplate) //nolint:musttag // api.AlertDefinitionTemplate contains autogenerated code
+		require.NoError(t, err, "failed to unmarshal expected body to yaml")
+
+		expectedTemplate.Deployed = &enabled
+		require.Equal(t, expectedTemplate, outTemplate)
+		require.True(t, mDefinition.AssertExpectations(t))
+	})
+}
+
+func stringPtr(s string) *string { return &s }
+
+func int64Ptr(i int64) *int64 { return &i }
+
+func boolPtr(b bool) *bool { return &b }
+
+func TestGetAlertDefinitionTemplateFunctions(t *testing.T) {
+	serverInterface := NewServerInterfaceHandler(conf, &gorm.DB{}, nil, nil)
+	e := echo.New()
+	api.RegisterHandlers(e, serverInterface)
+
+	// The route of the functions takes precedence over the route of alert definitions by ID.
+	result := testutil.NewRequest().Get("/api/v1/alerts/definitions/template-functions").GoWithHTTPHandler(t, e)
+	require.Equal(t, http.StatusOK, result.Recorder.Code)
+
+	var list api.TemplateFunctionList
+	require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &list))
+	names := make([]string, 0, len(list.TemplateFunctions))
+	for _, f := range list.TemplateFunctions {
+		require.NotEmpty(t, f.Description)
+		require.NotEmpty(t, f.Example)
+		names = append(names, f.Name)
 	}
-	return args.Get(0).(*models.DBReceiver), args.Error(1)
+	require.Equal(t, []string{"convert", "humanizeDuration", "ceil", "floor", "sanitizeLabel"}, names)
+}
+
+func TestGetAlertReceiverLanguages(t *testing.T) {
+	configuration := conf
+	configuration.AlertManager.EmailLanguages = []string{"de", "fr"}
+	serverInterface := NewServerInterfaceHandler(configuration, &gorm.DB{}, nil, nil)
+	e := echo.New()
+	api.RegisterHandlers(e, serverInterface)
+
+	// The route of the languages takes precedence over the route of alert receivers by ID.
+	result := testutil.NewRequest().Get("/api/v1/alerts/receivers/languages").GoWithHTTPHandler(t, e)
+	require.Equal(t, http.StatusOK, result.Recorder.Code)
+
+	var list api.EmailLanguageList
+	require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &list))
+	require.Equal(t, []string{"de", "fr"}, list.Languages)
 }
 
-func (m *ReceiverMock) GetLatestReceiverListWithEmailConfig(ctx context.Context, tenantID api.TenantID) ([]*models.DBReceiver, error) {
-	args := m.Called(ctx, tenantID)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).([]*models.DBReceiver), args.Error(1)
-}
+func TestPatchAlertDefinition(t *testing.T) {
+	testCases := []struct {
+		name     string
+		payload  []byte
+		httpCode int
+		errMsg   string
+	}{
+		{
+			name:    "Request body missing values field",
+			payload: []byte(`{"threshold":"10","duration":"8m","enabled":true}`),
+			errMsg:  errHTTPBadRequest,
+		},
+		{
+			name:    "Request body has unknown fields",
+			payload: []byte(`{"vals":{"threshold":"10","duration":"8m","enabled":true}}`),
+			errMsg:  errHTTPBadRequest,
+		},
+		{
+			name:    "Request body has unknown value fields",
+			payload: []byte(`{"values":{"threshold":"10","time":"8m","enabled":true}}`),
+			errMsg:  errHTTPBadRequest,
+		},
+		{
+			name:    "Request body has no values to set",
+			payload: []byte(`{"values":{}}`),
+			errMsg:  errHTTPFailedToPatchAlertDefinition,
+		},
+		{
+			name:    "Duration value format is invalid",
+			payload: []byte(`{"values":{"duration":"2sec"}}`),
+			errMsg:  errHTTPFailedToPatchAlertDefinition,
+		},
+		{
+			name:    "Duration value cannot be fraction of a second",
+			payload: []byte(`{"values":{"duration":"100ms"}}`),
+			errMsg:  errHTTPFailedToPatchAlertDefinition,
+		},
+		{
+			name:    "Duration value cannot be zero",
+			payload: []byte(`{"values":{"duration":"0m"}}`),
+			errMsg:  errHTTPFailedToPatchAlertDefinition,
+		},
+		{
+			name:    "Duration value cannot be negative",
+			payload: []byte(`{"values":{"duration":"-90"}}`),
+			errMsg:  errHTTPFailedToPatchAlertDefinition,
+		},
+		{
+			name:    "Enabled value is not a boolean",
+			payload: []byte(`{"values":{"enabled":"yes"}}`),
+			errMsg:  errHTTPFailedToPatchAlertDefinition,
+		},
+		{
+			name:    "Threshold value is a non numeric string",
+			payload: []byte(`{"values":{"threshold":"ten"}}`),
+			errMsg:  errHTTPFailedToPatchAlertDefinition,
+		},
+		{
+			name:    "Duration value string has invalid format",
+			payload: []byte(`{"values":{"duration":"one second"}}`),
+			errMsg:  errHTTPFailedToPatchAlertDefinition,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := &ServerInterfaceHandler{}
+			tenantID := "edgenode"
+
+			// Creating new Echo server
+			server := echo.New()
+
+			// Registering API call handlers
+			api.RegisterHandlers(server, handler)
+
+			request := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).
+				Patch("/api/v1/alerts/definitions/01e74407-0327-4e36-93cb-85801c098ba5").WithBody(tc.payload)
+			result := request.GoWithHTTPHandler(t, server)
+
+			body, err := io.ReadAll(result.Recorder.Body)
+			require.NoError(t, err)
+
+			httpErr := &api.HttpError{}
+			require.NoError(t, json.Unmarshal(body, httpErr))
+
+			require.Equal(t, http.StatusBadRequest, httpErr.Code)
+			require.Contains(t, httpErr.Message, tc.errMsg)
+		})
+	}
+
+	t.Run("Duration value is invalid in requested language", func(t *testing.T) {
+		handler := &ServerInterfaceHandler{}
+		tenantID := "edgenode"
+
+		// Creating new Echo server
+		server := echo.New()
+
+		// Registering API call handlers
+		api.RegisterHandlers(server, handler)
+
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).WithHeader("Accept-Language", "de-DE,de;q=0.9,en;q=0.8").
+			Patch("/api/v1/alerts/definitions/01e74407-0327-4e36-93cb-85801c098ba5").
+			WithBody([]byte(`{"values":{"duration":"2sec"}}`)).GoWithHTTPHandler(t, server)
+
+		body, err := io.ReadAll(result.Recorder.Body)
+		require.NoError(t, err)
+
+		httpErr := &api.HttpError{}
+		require.NoError(t, json.Unmarshal(body, httpErr))
+
+		require.Equal(t, "de", result.Recorder.Header().Get("Content-Language"))
+		require.Equal(t, http.StatusBadRequest, httpErr.Code)
+		require.Equal(t, "Alarmdefinition konnte nicht aktualisiert werden", httpErr.Message)
+		require.Equal(t, api.ErrorCodeInvalidRequestBody, httpErr.ErrorCode)
+		duration := "2sec"
+		require.Equal(t, &[]api.ErrorDetail{{
+			Field:  "values.duration",
+			Reason: messageCatalog[language.German][msgInvalidDuration],
+			Value:  &duration,
+		}}, httpErr.Details)
+	})
+
+	t.Run("Alert definition not found", func(t *testing.T) {
+		id := uuid.New()
+		tenantID := "edgenode"
+
+		threshold := int64(10)
+		duration := int64(45)
+		enabled := true
+
+		values := models.DBAlertDefinitionValues{
+			Threshold: &threshold,
+			Duration:  &duration,
+			Enabled:   &enabled,
+		}
+
+		mDefinition := &DefinitionMock{}
+
+		// mock setting values to alert definition.
+		mDefinition.On("SetAlertDefinitionValues", mock.Anything, tenantID, id, values).Return(fmt.Errorf("mock error: %w", gorm.ErrRecordNotFound)).Once()
+
+		handler := &ServerInterfaceHandler{
+			definitions: mDefinition,
+		}
+
+		// Creating new Echo server
+		server := echo.New()
+
+		// Registering API call handlers
+		api.RegisterHandlers(server, handler)
+
+		bodyStr := fmt.Sprintf(`{"values":{"threshold":"%d","duration":"%ds","enabled":"%v"}}`, threshold, duration, enabled)
+
+		uri := fmt.Sprintf("/api/v1/alerts/definitions/%v", id.String())
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Patch(uri).WithBody([]byte(bodyStr)).GoWithHTTPHandler(t, server)
+
+		body, err := io.ReadAll(result.Recorder.Body)
+		require.NoError(t, err)
+
+		httpErr := &api.HttpError{}
+		require.NoError(t, json.Unmarshal(body, httpErr))
+
+		require.Equal(t, http.StatusNotFound, httpErr.Code)
+		require.Contains(t, httpErr.Message, errHTTPAlertDefinitionNotFound)
+
+		require.True(t, mDefinition.AssertExpectations(t))
+	})
+
+	t.Run("Alert definition value is out-of-bounds", func(t *testing.T) {
+		id := uuid.New()
+		tenantID := "edgenode"
+
+		threshold := int64(10)
+		duration := int64(45)
+		enabled := true
+
+		values := models.DBAlertDefinitionValues{
+			Threshold: &threshold,
+			Duration:  &duration,
+			Enabled:   &enabled,
+		}
+
+		mDefinition := &DefinitionMock{}
+
+		// mock setting values to alert definition.
+		mDefinition.On("SetAlertDefinitionValues", mock.Anything, tenantID, id, values).
+			Return(fmt.Errorf("error mock: %w", &database.OutOfBoundsError{Value: "duration", Min: 15, Max: 30})).Once()
+
+		handler := &ServerInterfaceHandler{
+			definitions: mDefinition,
+		}
+
+		// Creating new Echo server
+		server := echo.New()
+
+		// Registering API call handlers
+		api.RegisterHandlers(server, handler)
+
+		bodyStr := fmt.Sprintf(`{"values":{"threshold":"%d","duration":"%ds","enabled":"%v"}}`, threshold, duration, enabled)
+
+		uri := fmt.Sprintf("/api/v1/alerts/definitions/%v", id.String())
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Patch(uri).WithBody([]byte(bodyStr)).GoWithHTTPHandler(t, server)
+
+		body, err := io.ReadAll(result.Recorder.Body)
+		require.NoError(t, err)
+
+		httpErr := &api.HttpError{}
+		require.NoError(t, json.Unmarshal(body, httpErr))
+
+		require.Equal(t, http.StatusBadRequest, httpErr.Code)
+		require.Contains(t, httpErr.Message, "alert definition value/s out-of-bounds")
+		require.Equal(t, api.ErrorCodeDefinitionValueOutOfBounds, httpErr.ErrorCode)
+		durationMin, durationMax := int64(15), int64(30)
+		require.Equal(t, &[]api.ErrorDetail{{
+			Field:  "values.duration",
+			Reason: "duration value must be within [15, 30] seconds",
+			Min:    &durationMin,
+			Max:    &durationMax,
+		}}, httpErr.Details)
+
+		require.True(t, mDefinition.AssertExpectations(t))
+	})
+
+	t.Run("Failed setting values to alert definition", func(t *testing.T) {
+		id := uuid.New()
+		tenantID := "edgenode"
+
+		threshold := int64(10)
+		duration := int64(45)
+		enabled := true
+
+		values := models.DBAlertDefinitionValues{
+			Threshold: &threshold,
+			Duration:  &duration,
+			Enabled:   &enabled,
+		}
+
+		mDefinition := &DefinitionMock{}
+
+		// mock setting values to alert definition.
+		mDefinition.On("SetAlertDefinitionValues", mock.Anything, tenantID, id, values).Return(errors.New("mock error")).Once()
+
+		handler := &ServerInterfaceHandler{
+			definitions: mDefinition,
+		}
+
+		// Creating new Echo server
+		server := echo.New()
+
+		// Registering API call handlers
+		api.RegisterHandlers(server, handler)
+
+		bodyStr := fmt.Sprintf(`{"values":{"threshold":"%d","duration":"%ds","enabled":"%v"}}`, threshold, duration, enabled)
+
+		uri := fmt.Sprintf("/api/v1/alerts/definitions/%v", id.String())
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Patch(uri).WithBody([]byte(bodyStr)).GoWithHTTPHandler(t, server)
+
+		body, err := io.ReadAll(result.Recorder.Body)
+		require.NoError(t, err)
+
+		httpErr := &api.HttpError{}
+		require.NoError(t, json.Unmarshal(body, httpErr))
+
+		require.Equal(t, http.StatusInternalServerError, httpErr.Code)
+		require.Contains(t, httpErr.Message, errHTTPFailedToPatchAlertDefinition)
+
+		require.True(t, mDefinition.AssertExpectations(t))
+	})
+
+	t.Run("Succeeded setting values to alert definition", func(t *testing.T) {
+		id := uuid.New()
+		tenantID := "edgenode"
+
+		threshold := int64(10)
+		duration := int64(45)
+		enabled := true
+
+		values := models.DBAlertDefinitionValues{
+			Threshold: &threshold,
+			Duration:  &duration,
+			Enabled:   &enabled,
+		}
+
+		mDefinition := &DefinitionMock{}
+
+		// mock setting values to alert definition.
+		mDefinition.On("SetAlertDefinitionValues", mock.Anything, tenantID, id, values).Return(nil).Once()
+
+		handler := &ServerInterfaceHandler{
+			definitions: mDefinition,
+		}
+
+		// Creating new Echo server
+		server := echo.New()
+
+		// Registering API call handlers
+		api.RegisterHandlers(server, handler)
+
+		bodyStr := fmt.Sprintf(`{"values":{"threshold":"%d","duration":"%ds","enabled":"%v"}}`, threshold, duration, enabled)
+
+		uri := fmt.Sprintf("/api/v1/alerts/definitions/%v", id.String())
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Patch(uri).WithBody([]byte(bodyStr)).GoWithHTTPHandler(t, server)
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+
+		var res api.AlertDefinitionValues
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &res))
+		require.Equal(t, &map[string]string{"threshold": "10", "duration": "45s", "enabled": "true"}, res.Values)
+
+		require.True(t, mDefinition.AssertExpectations(t))
+	})
+
+	t.Run("Succeeded setting values to alert definition asynchronously", func(t *testing.T) {
+		id := uuid.New()
+		tenantID := "edgenode"
+
+		threshold := int64(10)
+		values := models.DBAlertDefinitionValues{
+			Threshold: &threshold,
+		}
+
+		var operationID *uuid.UUID
+		mDefinition := &DefinitionMock{}
+		mDefinition.On("SetAlertDefinitionValues", mock.Anything, tenantID, id, values).Run(func(args mock.Arguments) {
+			operationID = database.OperationFromContext(args.Get(0).(context.Context))
+		}).Return(nil).Once()
+
+		// Creating new Echo server
+		server := echo.New()
+
+		// Registering API call handlers
+		api.RegisterHandlers(server, &ServerInterfaceHandler{
+			definitions: mDefinition,
+		})
+
+		uri := fmt.Sprintf("/api/v1/alerts/definitions/%v?async=true", id.String())
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Patch(uri).WithBody([]byte(`{"values":{"threshold":"10"}}`)).GoWithHTTPHandler(t, server)
+		require.Equal(t, http.StatusAccepted, result.Recorder.Code)
+
+		var res api.OperationAccepted
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &res))
+		require.NotNil(t, operationID)
+		require.Equal(t, *operationID, res.OperationId)
+		require.Nil(t, res.Preview)
+
+		require.True(t, mDefinition.AssertExpectations(t))
+	})
+
+	t.Run("Duration value is normalized", func(t *testing.T) {
+		tests := map[string]struct {
+			seconds  int64
+			expected string
+		}{
+			"90":      {seconds: 90, expected: "1m30s"},
+			"1.5m":    {seconds: 90, expected: "1m30s"},
+			"90500ms": {seconds: 90, expected: "1m30s"},
+			" 2h0m ":  {seconds: 7200, expected: "2h"},
+		}
+
+		for input, test := range tests {
+			t.Run(input, func(t *testing.T) {
+				id := uuid.New()
+				tenantID := "edgenode"
+
+				mDefinition := &DefinitionMock{}
+				mDefinition.On("SetAlertDefinitionValues", mock.Anything, tenantID, id, models.DBAlertDefinitionValues{Duration: &test.seconds}).
+					Return(nil).Once()
+
+				handler := &ServerInterfaceHandler{
+					definitions: mDefinition,
+				}
+
+				server := echo.New()
+				api.RegisterHandlers(server, handler)
+
+				body, err := json.Marshal(map[string]any{"values": map[string]string{"duration": input}})
+				require.NoError(t, err)
+
+				uri := fmt.Sprintf("/api/v1/alerts/definitions/%v", id.String())
+				result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Patch(uri).WithBody(body).GoWithHTTPHandler(t, server)
+				require.Equal(t, http.StatusOK, result.Recorder.Code)
+
+				var res api.AlertDefinitionValues
+				require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &res))
+				require.Equal(t, &map[string]string{"duration": test.expected}, res.Values)
+
+				require.True(t, mDefinition.AssertExpectations(t))
+			})
+		}
+	})
+
+	t.Run("Expression of alert definition exceeds cost limits", func(t *testing.T) {
+		threshold := int64(10)
+		duration := int64(45)
+		enabled := true
+		values := models.DBAlertDefinitionValues{
+			Threshold: &threshold,
+			Duration:  &duration,
+			Enabled:   &enabled,
+		}
+		dbDef := &models.DBAlertDefinition{
+			Template: `alert: HighCPUUsage
+expr: max_over_time(cpu_usage{hostGuid=~"host-.*"}[1h]) > [[ .Threshold ]]
+for: '[[ .Duration ]]'
+labels:
+  threshold: "80"
+  duration: 30s
+`,
+			Values: models.DBAlertDefinitionValues{
+				Threshold: new(int64),
+				Duration:  new(int64),
+				Enabled:   new(bool),
+				AutoTune:  new(bool),
+			},
+		}
+
+		for _, enforce := range []bool{true, false} {
+			t.Run(fmt.Sprintf("enforced %v", enforce), func(t *testing.T) {
+				id := uuid.New()
+				tenantID := "edgenode"
+
+				mDefinition := &DefinitionMock{}
+				mDefinition.On("GetLatestAlertDefinition", mock.Anything, tenantID, id).Return(dbDef, nil).Once()
+				if !enforce {
+					mDefinition.On("SetAlertDefinitionValues", mock.Anything, tenantID, id, values).Return(nil).Once()
+				}
+
+				handler := &ServerInterfaceHandler{
+					definitions: mDefinition,
+					configuration: config.Config{
+						Mimir: config.MimirConfig{
+							ExpressionCost: config.ExpressionCostConfig{
+								Enforce:     enforce,
+								DefaultTier: "standard",
+								Tiers: map[string]config.ExpressionCostLimits{
+									"standard": {MaxRange: 30 * time.Minute},
+								},
+							},
+						},
+					},
+				}
+
+				server := echo.New()
+				api.RegisterHandlers(server, handler)
+
+				bodyStr := fmt.Sprintf(`{"values":{"threshold":"%d","duration":"%ds","enabled":"%v"}}`, threshold, duration, enabled)
+
+				uri := fmt.Sprintf("/api/v1/alerts/definitions/%v", id.String())
+				result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Patch(uri).WithBody([]byte(bodyStr)).GoWithHTTPHandler(t, server)
+
+				// Expressions exceeding limits which are not enforced are only warned about.
+				if !enforce {
+					require.Equal(t, http.StatusOK, result.Recorder.Code)
+					require.True(t, mDefinition.AssertExpectations(t))
+					return
+				}
+
+				httpErr := &api.HttpError{}
+				require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), httpErr))
+				require.Equal(t, http.StatusBadRequest, httpErr.Code)
+				require.Equal(t, api.ErrorCodeDefinitionTooExpensive, httpErr.ErrorCode)
+				require.Equal(t, "alert definition expression is too expensive to evaluate", httpErr.Message)
+
+				require.True(t, mDefinition.AssertExpectations(t))
+			})
+		}
+	})
+}
+
+func TestResetAlertDefinitionDefaults(t *testing.T) {
+	post := func(t *testing.T, mDefinition *DefinitionMock, uri string) *httptest.ResponseRecorder {
+		t.Helper()
+
+		handler := &ServerInterfaceHandler{
+			definitions: mDefinition,
+		}
+
+		// Creating new Echo server
+		server := echo.New()
+
+		// Registering API call handlers
+		api.RegisterHandlers(server, handler)
+
+		return testutil.NewRequest().WithHeader("ActiveProjectID", "edgenode").Post(uri).GoWithHTTPHandler(t, server).Recorder
+	}
+
+	t.Run("Alert definition values are reset to defaults", func(t *testing.T) {
+		id := uuid.New()
+
+		threshold := int64(80)
+		duration := int64(30)
+		enabled := true
+		autoTune := false
+
+		mDefinition := &DefinitionMock{}
+		mDefinition.On("ResetAlertDefinitionValues", mock.Anything, "edgenode", id).Return(&models.DBAlertDefinitionValues{
+			Threshold: &threshold,
+			Duration:  &duration,
+			Enabled:   &enabled,
+			AutoTune:  &autoTune,
+		}, nil).Once()
+
+		rec := post(t, mDefinition, fmt.Sprintf("/api/v1/alerts/definitions/%v:resetDefaults", id.String()))
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var values api.AlertDefinitionValues
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &values))
+		require.Equal(t, &map[string]string{
+			"threshold": "80",
+			"duration":  "30s",
+			"enabled":   "true",
+			"autoTune":  "false",
+		}, values.Values)
+
+		require.True(t, mDefinition.AssertExpectations(t))
+	})
+
+	t.Run("Alert definition not found", func(t *testing.T) {
+		id := uuid.New()
+
+		mDefinition := &DefinitionMock{}
+		mDefinition.On("ResetAlertDefinitionValues", mock.Anything, "edgenode", id).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		rec := post(t, mDefinition, fmt.Sprintf("/api/v1/alerts/definitions/%v:resetDefaults", id.String()))
+
+		httpErr := &api.HttpError{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), httpErr))
+		require.Equal(t, http.StatusNotFound, httpErr.Code)
+		require.Equal(t, api.ErrorCodeDefinitionNotFound, httpErr.ErrorCode)
+
+		require.True(t, mDefinition.AssertExpectations(t))
+	})
+
+	t.Run("Failed resetting alert definition values", func(t *testing.T) {
+		id := uuid.New()
+
+		mDefinition := &DefinitionMock{}
+		mDefinition.On("ResetAlertDefinitionValues", mock.Anything, "edgenode", id).Return(nil, errors.New("mock error")).Once()
+
+		rec := post(t, mDefinition, fmt.Sprintf("/api/v1/alerts/definitions/%v:resetDefaults", id.String()))
+
+		httpErr := &api.HttpError{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), httpErr))
+		require.Equal(t, http.StatusInternalServerError, httpErr.Code)
+		require.Contains(t, httpErr.Message, errHTTPFailedToResetAlertDefinition)
+
+		require.True(t, mDefinition.AssertExpectations(t))
+	})
+
+	t.Run("Post without the reset verb - code should be 404", func(t *testing.T) {
+		mDefinition := &DefinitionMock{}
+
+		rec := post(t, mDefinition, fmt.Sprintf("/api/v1/alerts/definitions/%v", uuid.New().String()))
+		require.Equal(t, http.StatusNotFound, rec.Code)
+
+		mDefinition.AssertNotCalled(t, "ResetAlertDefinitionValues", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+// ReceiverMock represents a mock for receiver database operations. Implements ReceiverManager interface.
+type ReceiverConfigValidatorMock struct {
+	mock.Mock
+}
+
+func (m *ReceiverConfigValidatorMock) ValidateReceiverConfig(ctx context.Context, receiver models.DBReceiver) error {
+	args := m.Called(ctx, receiver)
+	return args.Error(0)
+}
+
+func (m *ReceiverConfigValidatorMock) PreviewReceiverConfig(ctx context.Context, receiver models.DBReceiver) (api.ReceiverConfigPreview, error) {
+	args := m.Called(ctx, receiver)
+	return args.Get(0).(api.ReceiverConfigPreview), args.Error(1)
+}
+
+type ReceiverMock struct {
+	mock.Mock
+}
+
+func (m *ReceiverMock) GetLatestReceiverWithEmailConfig(ctx context.Context, tenantID api.TenantID, id uuid.UUID) (*models.DBReceiver, error) {
+	args := m.Called(ctx, tenantID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.DBReceiver), args.Error(1)
+}
+
+func (m *ReceiverMock) GetLatestReceiverListWithEmailConfig(
+	ctx context.Context, tenantID api.TenantID, opts database.ListOptions,
+) ([]*models.DBReceiver, int64, error) {
+	args := m.Called(ctx, tenantID, opts)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*models.DBReceiver), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *ReceiverMock) SetReceiverValues(ctx context.Context, tenantID api.TenantID, id uuid.UUID, values models.DBReceiverValues) error {
+	args := m.Called(ctx, tenantID, id, values)
+	return args.Error(0)
+}
+
+func (m *ReceiverMock) SetReceiversRecipients(ctx context.Context, tenantID api.TenantID, recipients map[uuid.UUID][]models.EmailAddress) error {
+	args := m.Called(ctx, tenantID, recipients)
+	return args.Error(0)
+}
+
+func (m *ReceiverMock) GetReceiverWithEmailConfig(ctx context.Context, tenantID api.TenantID, id uuid.UUID, version int64) (*models.DBReceiver, error) {
+	args := m.Called(ctx, tenantID, id, version)
+	return args.Get(0).(*models.DBReceiver), args.Error(1)
+}
+
+func TestGetAlertReceivers(t *testing.T) {
+	t.Run("Failed to get receivers from database", func(t *testing.T) {
+		mReceiver := &ReceiverMock{}
+		tenantID := "edgenode"
+
+		// mock getting receivers from database.
+		mReceiver.On("GetLatestReceiverListWithEmailConfig", mock.Anything, tenantID, database.ListOptions{}).
+			Return(nil, int64(0), errors.New("error mock")).Once()
+
+		handler := &ServerInterfaceHandler{
+			receivers: mReceiver,
+		}
+
+		// Creating new Echo server
+		server := echo.New()
+
+		// Registering API call handlers
+		api.RegisterHandlers(server, handler)
+
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get("/api/v1/alerts/receivers").GoWithHTTPHandler(t, server)
+
+		body, err := io.ReadAll(result.Recorder.Body)
+		require.NoError(t, err)
+
+		httpErr := &api.HttpError{}
+		require.NoError(t, json.Unmarshal(body, httpErr))
+
+		require.Equal(t, http.StatusInternalServerError, httpErr.Code)
+		require.Contains(t, httpErr.Message, errHTTPFailedToGetAlertReceivers)
+
+		require.True(t, mReceiver.AssertExpectations(t))
+	})
+
+	t.Run("Get receivers among many tenants", func(t *testing.T) {
+		firstName := "test"
+		lastName := "user"
+		email := "test-1@user.com"
+
+		mM2M := &M2MAuthenticatorMock{}
+		mM2M.On("GetUserList", mock.Anything).Return([]user{
+			{
+				FirstName: firstName,
+				LastName:  lastName,
+				Email:     email,
+			},
+		}, nil)
+
+		uuid1 := uuid.New()
+		tenantID1 := "first_tenant"
+		recv1 := &models.DBReceiver{
+			UUID:    uuid1,
+			Name:    "test-receiver-1",
+			Version: 3,
+			To: []string{
+				"test user <test-1@user.com>",
+			},
+			From:       "sender user <sender@user.com>",
+			MailServer: "smtp.com:443",
+			TenantID:   tenantID1,
+		}
+
+		uuid2 := uuid.New()
+		tenantID2 := "second_tenant"
+		recv2 := &models.DBReceiver{
+			UUID:    uuid2,
+			Name:    "test-receiver-2",
+			Version: 3,
+			To: []string{
+				"test user <test-1@user.com>",
+			},
+			From:       "sender user <sender@user.com>",
+			MailServer: "smtp.com:443",
+			TenantID:   tenantID2,
+			Disabled:   true,
+			Matchers:   []string{`cluster_name="edge-1"`},
+		}
+
+		mReceiver := &ReceiverMock{}
+		mReceiver.On("GetLatestReceiverListWithEmailConfig", mock.Anything, tenantID1, database.ListOptions{}).
+			Return([]*models.DBReceiver{recv1}, int64(1), nil).Once()
+		mReceiver.On("GetLatestReceiverListWithEmailConfig", mock.Anything, tenantID2, database.ListOptions{}).
+			Return([]*models.DBReceiver{recv2}, int64(1), nil).Once()
+		mReceiver.On("GetLatestReceiverListWithEmailConfig", mock.Anything, "wrong_tenant", database.ListOptions{}).
+			Return([]*models.DBReceiver{}, int64(0), nil).Once()
+
+		// Creating new Echo server
+		server := echo.New()
+
+		// Registering API call handlers
+		api.RegisterHandlers(server, &ServerInterfaceHandler{
+			m2m:       mM2M,
+			receivers: mReceiver,
+		})
+
+		// Getting receiver from first tenant
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID1).Get("/api/v1/alerts/receivers").GoWithHTTPHandler(t, server)
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+
+		body, err := io.ReadAll(result.Recorder.Body)
+		require.NoError(t, err)
+
+		stateExp := api.StateDefinition(recv1.State)
+		versionExp := recv1.Version
+		mailServer := recv1.MailServer
+		from := recv1.From
+		to := recv1.To
+
+		receiversExp := []api.Receiver{
+			{
+				Id:      &recv1.UUID,
+				State:   &stateExp,
+				Version: &versionExp,
+				Enabled: boolPtr(true),
+				EmailConfig: &api.EmailConfig{
+					From:       &from,
+					MailServer: &mailServer,
+					To: &struct {
+						Allowed    *api.EmailRecipientList `json:"allowed,omitempty"`
+						Enabled    *api.EmailRecipientList `json:"enabled,omitempty"`
+						Unverified *api.EmailRecipientList `json:"unverified,omitempty"`
+					}{
+						Allowed: &to,
+						Enabled: &to,
+					},
+				},
+			},
+		}
+		receiversListExp := &api.ReceiverList{
+			Receivers:  &receiversExp,
+			TotalCount: 1,
+		}
+
+		receivers := []api.Receiver{}
+		receiversList := &api.ReceiverList{
+			Receivers: &receivers,
+		}
+
+		require.NoError(t, json.Unmarshal(body, receiversList))
+		require.Equal(t, receiversListExp, receiversList)
+
+		// // Getting receiver from second tenant
+		result = testutil.NewRequest().WithHeader("ActiveProjectID", tenantID2).Get("/api/v1/alerts/receivers").GoWithHTTPHandler(t, server)
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+
+		body, err = io.ReadAll(result.Recorder.Body)
+		require.NoError(t, err)
+
+		stateExp = api.StateDefinition(recv2.State)
+		versionExp = recv2.Version
+		mailServer = recv2.MailServer
+		from = recv2.From
+		to = recv2.To
+
+		receiversExp = []api.Receiver{
+			{
+				Id:       &recv2.UUID,
+				State:    &stateExp,
+				Version:  &versionExp,
+				Enabled:  boolPtr(false),
+				Matchers: &recv2.Matchers,
+				EmailConfig: &api.EmailConfig{
+					From:       &from,
+					MailServer: &mailServer,
+					To: &struct {
+						Allowed    *api.EmailRecipientList `json:"allowed,omitempty"`
+						Enabled    *api.EmailRecipientList `json:"enabled,omitempty"`
+						Unverified *api.EmailRecipientList `json:"unverified,omitempty"`
+					}{
+						Allowed: &to,
+						Enabled: &to,
+					},
+				},
+			},
+		}
+		receiversListExp = &api.ReceiverList{
+			Receivers:  &receiversExp,
+			TotalCount: 1,
+		}
+
+		receivers = []api.Receiver{}
+		receiversList = &api.ReceiverList{
+			Receivers: &receivers,
+		}
+
+		require.NoError(t, json.Unmarshal(body, receiversList))
+		require.Equal(t, receiversListExp, receiversList)
+
+		// Getting no receivers
+		result = testutil.NewRequest().WithHeader("ActiveProjectID", "wrong_tenant").Get("/api/v1/alerts/receivers").GoWithHTTPHandler(t, server)
+
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+
+		body, err = io.ReadAll(result.Recorder.Body)
+		require.NoError(t, err)
+
+		receiversExp = []api.Receiver{}
+		receiversListExp = &api.ReceiverList{
+			Receivers: &receiversExp,
+		}
+
+		receivers = []api.Receiver{}
+		receiversList = &api.ReceiverList{
+			Receivers: &receivers,
+		}
+
+		require.NoError(t, json.Unmarshal(body, receiversList))
+		require.Equal(t, receiversListExp, receiversList)
+		require.True(t, mReceiver.AssertExpectations(t))
+	})
+	t.Run("Pagination and sorting parameters are passed to database and total count is returned", func(t *testing.T) {
+		mM2M := &M2MAuthenticatorMock{}
+		mM2M.On("GetUserList", mock.Anything).Return([]user{
+			{
+				FirstName: "foo",
+				LastName:  "bar",
+				Email:     "foo@bar.com",
+			},
+		}, nil)
+
+		tenantID := "edgenode"
+		recv := &models.DBReceiver{
+			UUID:       uuid.New(),
+			Name:       "test-receiver-2",
+			Version:    1,
+			From:       "sender user <sender@user.com>",
+			MailServer: "smtp.com:443",
+			TenantID:   tenantID,
+		}
+
+		mReceiver := &ReceiverMock{}
+		mReceiver.On("GetLatestReceiverListWithEmailConfig", mock.Anything, tenantID, database.ListOptions{
+			Limit:  1,
+			Offset: 1,
+			SortBy: database.SortBySeverity,
+			Order:  database.SortAscending,
+		}).
+			Return([]*models.DBReceiver{recv}, int64(2), nil).Once()
+
+		// Creating new Echo server
+		server := echo.New()
+
+		// Registering API call handlers
+		api.RegisterHandlers(server, &ServerInterfaceHandler{
+			m2m:       mM2M,
+			receivers: mReceiver,
+		})
+
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get("/api/v1/alerts/receivers?limit=1&offset=1&sortBy=severity&order=asc").
+			GoWithHTTPHandler(t, server)
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+
+		body, err := io.ReadAll(result.Recorder.Body)
+		require.NoError(t, err)
+
+		receivers := []api.Receiver{}
+		receiversList := &api.ReceiverList{
+			Receivers: &receivers,
+		}
+		require.NoError(t, json.Unmarshal(body, receiversList))
+		require.Len(t, receivers, 1)
+		require.Equal(t, recv.UUID, *receivers[0].Id)
+		require.Equal(t, 2, receiversList.TotalCount)
+
+		require.True(t, mReceiver.AssertExpectations(t))
+	})
+
+	t.Run("Invalid pagination parameters", func(t *testing.T) {
+		mReceiver := &ReceiverMock{}
+
+		// Creating new Echo server
+		server := echo.New()
+
+		// Registering API call handlers
+		api.RegisterHandlers(server, &ServerInterfaceHandler{
+			receivers: mReceiver,
+		})
+
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", "edgenode").Get("/api/v1/alerts/receivers?limit=0").GoWithHTTPHandler(t, server)
+		require.Equal(t, http.StatusBadRequest, result.Recorder.Code)
+
+		require.True(t, mReceiver.AssertExpectations(t))
+	})
+
+	t.Run("Allowed recipients are not retrieved when email config is not selected", func(t *testing.T) {
+		mM2M := &M2MAuthenticatorMock{}
+
+		tenantID := "edgenode"
+		recv := &models.DBReceiver{
+			UUID:        uuid.New(),
+			Name:        "test-receiver",
+			State:       models.ReceiverApplied,
+			Version:     1,
+			From:        "sender user <sender@user.com>",
+			MailServer:  "smtp.com:443",
+			MinSeverity: models.SeverityWarning,
+			TenantID:    tenantID,
+		}
+
+		mReceiver := &ReceiverMock{}
+		mReceiver.On("GetLatestReceiverListWithEmailConfig", mock.Anything, tenantID, database.ListOptions{}).
+			Return([]*models.DBReceiver{recv}, int64(1), nil).Once()
+
+		// Creating new Echo server
+		server := echo.New()
+
+		// Registering API call handlers
+		api.RegisterHandlers(server, &ServerInterfaceHandler{
+			m2m:       mM2M,
+			receivers: mReceiver,
+		})
+
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get("/api/v1/alerts/receivers?fields=id,minSeverity").
+			GoWithHTTPHandler(t, server)
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+
+		body, err := io.ReadAll(result.Recorder.Body)
+		require.NoError(t, err)
+
+		minSeverityExp := api.Warning
+		receiversListExp := &api.ReceiverList{
+			Receivers: &[]api.Receiver{
+				{
+					Id:          &recv.UUID,
+					MinSeverity: &minSeverityExp,
+				},
+			},
+			TotalCount: 1,
+		}
+
+		receivers := []api.Receiver{}
+		receiversList := &api.ReceiverList{
+			Receivers: &receivers,
+		}
+		require.NoError(t, json.Unmarshal(body, receiversList))
+		require.Equal(t, receiversListExp, receiversList)
+
+		require.True(t, mReceiver.AssertExpectations(t))
+		mM2M.AssertNotCalled(t, "GetUserList", mock.Anything)
+	})
+}
+
+func TestGetAlertReceiver(t *testing.T) {
+	t.Run("Receiver not found", func(t *testing.T) {
+		id := uuid.New()
+		mReceiver := &ReceiverMock{}
+		tenantID := "edgenode"
+
+		// mock getting receiver by UUID from database.
+		mReceiver.On("GetLatestReceiverWithEmailConfig", mock.Anything, tenantID, id).Return(nil, fmt.Errorf("mock error: %w", gorm.ErrRecordNotFound)).Once()
+
+		handler := &ServerInterfaceHandler{
+			receivers: mReceiver,
+		}
+
+		// Creating new Echo server
+		server := echo.New()
+
+		// Registering API call handlers
+		api.RegisterHandlers(server, handler)
+
+		uri := fmt.Sprintf("/api/v1/alerts/receivers/%v", id.String())
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get(uri).GoWithHTTPHandler(t, server)
+
+		body, err := io.ReadAll(result.Recorder.Body)
+		require.NoError(t, err)
+
+		httpErr := &api.HttpError{}
+		require.NoError(t, json.Unmarshal(body, httpErr))
+
+		require.Equal(t, http.StatusNotFound, httpErr.Code)
+		require.Contains(t, httpErr.Message, errHTTPAlertReceiverNotFound)
+
+		require.True(t, mReceiver.AssertExpectations(t))
+	})
+
+	t.Run("Failed to retrieve receiver by UUID from database", func(t *testing.T) {
+		id := uuid.New()
+		mReceiver := &ReceiverMock{}
+		tenantID := "edgenode"
+
+		// mock getting receiver by UUID from database.
+		mReceiver.On("GetLatestReceiverWithEmailConfig", mock.Anything, tenantID, id).Return(nil, errors.New("mock error")).Once()
+
+		handler := &ServerInterfaceHandler{
+			receivers: mReceiver,
+		}
+
+		// Creating new Echo server
+		server := echo.New()
+
+		// Registering API call handlers
+		api.RegisterHandlers(server, handler)
+
+		uri := fmt.Sprintf("/api/v1/alerts/receivers/%v", id.String())
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get(uri).GoWithHTTPHandler(t, server)
+
+		body, err := io.ReadAll(result.Recorder.Body)
+		require.NoError(t, err)
+
+		httpErr := &api.HttpError{}
+		require.NoError(t, json.Unmarshal(body, httpErr))
+
+		require.Equal(t, http.StatusInternalServerError, httpErr.Code)
+		require.Contains(t, httpErr.Message, errHTTPFailedToGetAlertReceiver)
+
+		require.True(t, mReceiver.AssertExpectations(t))
+	})
+}
+
+func TestPatchAlertReceiver(t *testing.T) {
+	t.Run("Invalid request body", func(t *testing.T) {
+		id := uuid.New()
+		tenantID := "edgenode"
+
+		// Creating new Echo server
+		server := echo.New()
+
+		// Registering API call handlers
+		api.RegisterHandlers(server, &ServerInterfaceHandler{})
+
+		invalidBody := []byte(`{"emailConfig":{"to":["firstName lastName <emailtext@sampppple.com>"]}}`)
+
+		uri := fmt.Sprintf("/api/v1/alerts/receivers/%v", id.String())
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Patch(uri).WithBody(invalidBody).GoWithHTTPHandler(t, server)
+
+		body, err := io.ReadAll(result.Recorder.Body)
+		require.NoError(t, err)
+
+		httpErr := &api.HttpError{}
+		require.NoError(t, json.Unmarshal(body, httpErr))
+
+		require.Equal(t, http.StatusBadRequest, httpErr.Code)
+		require.Contains(t, httpErr.Message, errHTTPBadRequest)
+		require.Equal(t, http.StatusBadRequest, result.Recorder.Code)
+	})
+
+	t.Run("Request body contains unknown extra fields", func(t *testing.T) {
+		id := uuid.New()
+		tenantID := "edgenode"
+
+		// Creating new Echo server
+		server := echo.New()
+
+		// Registering API call handlers
+		api.RegisterHandlers(server, &ServerInterfaceHandler{})
 
-func (m *ReceiverMock) SetReceiverEmailRecipients(ctx context.Context, tenantID api.TenantID, id uuid.UUID, recipients []models.EmailAddress) error {
-	args := m.Called(ctx, tenantID, id, recipients)
-	return args.Error(0)
-}
+		invalidBody := []byte(`{"emailConfig":{"to":{"enabled":["first user <first.user@email.com>"], "allowed":["second user second.user@email.com"]}}}`)
 
-func (m *ReceiverMock) GetReceiverWithEmailConfig(ctx context.Context, tenantID api.TenantID, id uuid.UUID, version int64) (*models.DBReceiver, error) {
-	args := m.Called(ctx, tenantID, id, version)
-	return args.Get(0).(*models.DBReceiver), args.Error(1)
-}
+		uri := fmt.Sprintf("/api/v1/alerts/receivers/%v", id.String())
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Patch(uri).WithBody(invalidBody).GoWithHTTPHandler(t, server)
 
-func TestGetAlertReceivers(t *testing.T) {
-	t.Run("Failed to get receivers from database", func(t *testing.T) {
-		mReceiver := &ReceiverMock{}
+		body, err := io.ReadAll(result.Recorder.Body)
+		require.NoError(t, err)
+
+		httpErr := &api.HttpError{}
+		require.NoError(t, json.Unmarshal(body, httpErr))
+
+		require.Equal(t, http.StatusBadRequest, httpErr.Code)
+		require.Contains(t, httpErr.Message, errHTTPBadRequest)
+	})
+
+	t.Run("Fail to get allowed email recipients", func(t *testing.T) {
+		id := uuid.New()
 		tenantID := "edgenode"
 
-		// mock getting receivers from database.
-		mReceiver.On("GetLatestReceiverListWithEmailConfig", mock.Anything, tenantID).Return(nil, errors.New("error mock")).Once()
+		mM2M := &M2MAuthenticatorMock{}
+		mM2M.On("GetUserList", mock.Anything).Return(nil, errors.New("mock error")).Once()
 
-		handler := &ServerInterfaceHandler{
-			receivers: mReceiver,
-		}
+		// Creating new Echo server
+		server := echo.New()
+
+		// Registering API call handlers
+		api.RegisterHandlers(server, &ServerInterfaceHandler{
+			m2m: mM2M,
+		})
+
+		body := []byte(`{"emailConfig":{"to":{"enabled":["bar foo <foo@bar>"]}}}`)
+
+		uri := fmt.Sprintf("/api/v1/alerts/receivers/%v", id.String())
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Patch(uri).WithBody(body).GoWithHTTPHandler(t, server)
+
+		body, err := io.ReadAll(result.Recorder.Body)
+		require.NoError(t, err)
+
+		httpErr := &api.HttpError{}
+		require.NoError(t, json.Unmarshal(body, httpErr))
+
+		require.Equal(t, http.StatusInternalServerError, httpErr.Code)
+		require.Contains(t, httpErr.Message, errHTTPFailedToPatchAlertReceivers)
+
+		require.True(t, mM2M.AssertExpectations(t))
+	})
+
+	t.Run("Allowed email recipients is empty", func(t *testing.T) {
+		id := uuid.New()
+		tenantID := "edgenode"
+
+		mM2M := &M2MAuthenticatorMock{}
+		mM2M.On("GetUserList", mock.Anything).Return([]user{}, nil).Once()
 
 		// Creating new Echo server
 		server := echo.New()
 
 		// Registering API call handlers
-		api.RegisterHandlers(server, handler)
+		api.RegisterHandlers(server, &ServerInterfaceHandler{
+			m2m: mM2M,
+		})
 
-		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get("/api/v1/alerts/receivers").GoWithHTTPHandler(t, server)
+		body := []byte(`{"emailConfig":{"to":{"enabled":["bar foo <foo@bar>"]}}}`)
+
+		uri := fmt.Sprintf("/api/v1/alerts/receivers/%v", id.String())
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Patch(uri).WithBody(body).GoWithHTTPHandler(t, server)
 
 		body, err := io.ReadAll(result.Recorder.Body)
 		require.NoError(t, err)
@@ -1494,198 +3127,181 @@ func TestGetAlertReceivers(t *testing.T) {
 		require.NoError(t, json.Unmarshal(body, httpErr))
 
 		require.Equal(t, http.StatusInternalServerError, httpErr.Code)
-		require.Contains(t, httpErr.Message, errHTTPFailedToGetAlertReceivers)
+		require.Contains(t, httpErr.Message, errHTTPFailedToPatchAlertReceivers)
 
-		require.True(t, mReceiver.AssertExpectations(t))
+		require.True(t, mM2M.AssertExpectations(t))
 	})
 
-	t.Run("Get receivers among many tenants", func(t *testing.T) {
-		firstName := "test"
-		lastName := "user"
-		email := "test-1@user.com"
+	t.Run("Email recipient not allowed", func(t *testing.T) {
+		id := uuid.New()
+		tenantID := "edgenode"
 
 		mM2M := &M2MAuthenticatorMock{}
 		mM2M.On("GetUserList", mock.Anything).Return([]user{
 			{
-				FirstName: firstName,
-				LastName:  lastName,
-				Email:     email,
-			},
-		}, nil)
-
-		uuid1 := uuid.New()
-		tenantID1 := "first_tenant"
-		recv1 := &models.DBReceiver{
-			UUID:    uuid1,
-			Name:    "test-receiver-1",
-			Version: 3,
-			To: []string{
-				"test user <test-1@user.com>",
-			},
-			From:       "sender user <sender@user.com>",
-			MailServer: "smtp.com:443",
-			TenantID:   tenantID1,
-		}
-
-		uuid2 := uuid.New()
-		tenantID2 := "second_tenant"
-		recv2 := &models.DBReceiver{
-			UUID:    uuid2,
-			Name:    "test-receiver-2",
-			Version: 3,
-			To: []string{
-				"test user <test-1@user.com>",
+				FirstName: "foo",
+				LastName:  "bar",
+				Email:     "foo@bar.com",
 			},
-			From:       "sender user <sender@user.com>",
-			MailServer: "smtp.com:443",
-			TenantID:   tenantID2,
-		}
-
-		mReceiver := &ReceiverMock{}
-		mReceiver.On("GetLatestReceiverListWithEmailConfig", mock.Anything, tenantID1).Return([]*models.DBReceiver{recv1}, nil).Once()
-		mReceiver.On("GetLatestReceiverListWithEmailConfig", mock.Anything, tenantID2).Return([]*models.DBReceiver{recv2}, nil).Once()
-		mReceiver.On("GetLatestReceiverListWithEmailConfig", mock.Anything, "wrong_tenant").Return([]*models.DBReceiver{}, nil).Once()
+		}, nil).Once()
 
 		// Creating new Echo server
 		server := echo.New()
 
 		// Registering API call handlers
 		api.RegisterHandlers(server, &ServerInterfaceHandler{
-			m2m:       mM2M,
-			receivers: mReceiver,
+			m2m: mM2M,
 		})
 
-		// Getting receiver from first tenant
-		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID1).Get("/api/v1/alerts/receivers").GoWithHTTPHandler(t, server)
-		require.Equal(t, http.StatusOK, result.Recorder.Code)
+		body := []byte(`{"emailConfig":{"to":{"enabled":["bar foo <foo@bar>"]}}}`)
+
+		uri := fmt.Sprintf("/api/v1/alerts/receivers/%v", id.String())
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Patch(uri).WithBody(body).GoWithHTTPHandler(t, server)
 
 		body, err := io.ReadAll(result.Recorder.Body)
 		require.NoError(t, err)
 
-		stateExp := api.StateDefinition(recv1.State)
-		versionExp := recv1.Version
-		mailServer := recv1.MailServer
-		from := recv1.From
-		to := recv1.To
+		httpErr := &api.HttpError{}
+		require.NoError(t, json.Unmarshal(body, httpErr))
 
-		receiversExp := []api.Receiver{
+		require.Equal(t, http.StatusBadRequest, httpErr.Code)
+		require.Contains(t, httpErr.Message, errHTTPBadRequest)
+		require.Equal(t, api.ErrorCodeRecipientNotAllowed, httpErr.ErrorCode)
+		recipient := "bar foo <foo@bar>"
+		require.Equal(t, &[]api.ErrorDetail{{
+			Field:  "emailConfig.to.enabled",
+			Reason: "email recipient is not allowed",
+			Value:  &recipient,
+		}}, httpErr.Details)
+
+		require.True(t, mM2M.AssertExpectations(t))
+	})
+
+	t.Run("Alert receiver exceeds the service level of the tenant tier", func(t *testing.T) {
+		id := uuid.New()
+		tenantID := "edgenode"
+
+		mM2M := &M2MAuthenticatorMock{}
+		mM2M.On("GetUserList", mock.Anything).Return([]user{
 			{
-				Id:      &recv1.UUID,
-				State:   &stateExp,
-				Version: &versionExp,
-				EmailConfig: &api.EmailConfig{
-					From:       &from,
-					MailServer: &mailServer,
-					To: &struct {
-						Allowed *api.EmailRecipientList `json:"allowed,omitempty"`
-						Enabled *api.EmailRecipientList `json:"enabled,omitempty"`
-					}{
-						Allowed: &to,
-						Enabled: &to,
-					},
-				},
+				FirstName: "foo",
+				LastName:  "bar",
+				Email:     "foo@bar.com",
 			},
-		}
-		receiversListExp := &api.ReceiverList{
-			Receivers: &receiversExp,
-		}
+		}, nil).Once()
 
-		receivers := []api.Receiver{}
-		receiversList := &api.ReceiverList{
-			Receivers: &receivers,
-		}
+		tierMock := &TenantTierMock{}
+		tierMock.On("GetTenantTier", mock.Anything, tenantID).Return("", nil).Once()
 
-		require.NoError(t, json.Unmarshal(body, receiversList))
-		require.Equal(t, receiversListExp, receiversList)
+		// Creating new Echo server
+		server := echo.New()
 
-		// // Getting receiver from second tenant
-		result = testutil.NewRequest().WithHeader("ActiveProjectID", tenantID2).Get("/api/v1/alerts/receivers").GoWithHTTPHandler(t, server)
-		require.Equal(t, http.StatusOK, result.Recorder.Code)
+		// Registering API call handlers
+		api.RegisterHandlers(server, &ServerInterfaceHandler{
+			m2m: mM2M,
+			tiers: newTenantTiers(config.TenantTiersConfig{
+				DefaultTier: "basic",
+				Tiers:       map[string]config.TierConfig{"basic": {Channels: []string{config.ChannelEmail}}},
+			}, tierMock),
+		})
 
-		body, err = io.ReadAll(result.Recorder.Body)
+		body := []byte(`{"emailConfig":{"to":{"enabled":["foo bar <foo@bar.com>"]}},"onCall":{"routingKey":"key"}}`)
+
+		uri := fmt.Sprintf("/api/v1/alerts/receivers/%v", id.String())
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Patch(uri).WithBody(body).GoWithHTTPHandler(t, server)
+
+		body, err := io.ReadAll(result.Recorder.Body)
 		require.NoError(t, err)
 
-		stateExp = api.StateDefinition(recv2.State)
-		versionExp = recv2.Version
-		mailServer = recv2.MailServer
-		from = recv2.From
-		to = recv2.To
+		httpErr := &api.HttpError{}
+		require.NoError(t, json.Unmarshal(body, httpErr))
 
-		receiversExp = []api.Receiver{
+		require.Equal(t, http.StatusBadRequest, httpErr.Code)
+		require.Equal(t, api.ErrorCodeReceiverTierLimitExceeded, httpErr.ErrorCode)
+
+		require.True(t, mM2M.AssertExpectations(t))
+		require.True(t, tierMock.AssertExpectations(t))
+	})
+
+	t.Run("Duplicated email recipients", func(t *testing.T) {
+		id := uuid.New()
+		tenantID := "edgenode"
+
+		mM2M := &M2MAuthenticatorMock{}
+		mM2M.On("GetUserList", mock.Anything).Return([]user{
 			{
-				Id:      &recv2.UUID,
-				State:   &stateExp,
-				Version: &versionExp,
-				EmailConfig: &api.EmailConfig{
-					From:       &from,
-					MailServer: &mailServer,
-					To: &struct {
-						Allowed *api.EmailRecipientList `json:"allowed,omitempty"`
-						Enabled *api.EmailRecipientList `json:"enabled,omitempty"`
-					}{
-						Allowed: &to,
-						Enabled: &to,
-					},
-				},
+				FirstName: "foo",
+				LastName:  "bar",
+				Email:     "foo@bar.com",
 			},
-		}
-		receiversListExp = &api.ReceiverList{
-			Receivers: &receiversExp,
-		}
+		}, nil).Once()
 
-		receivers = []api.Receiver{}
-		receiversList = &api.ReceiverList{
-			Receivers: &receivers,
-		}
+		// Creating new Echo server
+		server := echo.New()
 
-		require.NoError(t, json.Unmarshal(body, receiversList))
-		require.Equal(t, receiversListExp, receiversList)
+		// Registering API call handlers
+		api.RegisterHandlers(server, &ServerInterfaceHandler{
+			m2m: mM2M,
+		})
 
-		// Getting no receivers
-		result = testutil.NewRequest().WithHeader("ActiveProjectID", "wrong_tenant").Get("/api/v1/alerts/receivers").GoWithHTTPHandler(t, server)
+		body := []byte(`{"emailConfig":{"to":{"enabled":["foo bar <foo@bar.com>", "foo bar <foo@bar.com>"]}}}`)
 
-		require.Equal(t, http.StatusOK, result.Recorder.Code)
+		uri := fmt.Sprintf("/api/v1/alerts/receivers/%v", id.String())
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Patch(uri).WithBody(body).GoWithHTTPHandler(t, server)
 
-		body, err = io.ReadAll(result.Recorder.Body)
+		body, err := io.ReadAll(result.Recorder.Body)
 		require.NoError(t, err)
 
-		receiversExp = []api.Receiver{}
-		receiversListExp = &api.ReceiverList{
-			Receivers: &receiversExp,
-		}
+		httpErr := &api.HttpError{}
+		require.NoError(t, json.Unmarshal(body, httpErr))
 
-		receivers = []api.Receiver{}
-		receiversList = &api.ReceiverList{
-			Receivers: &receivers,
-		}
+		require.Equal(t, http.StatusBadRequest, httpErr.Code)
+		require.Contains(t, httpErr.Message, errHTTPBadRequest)
 
-		require.NoError(t, json.Unmarshal(body, receiversList))
-		require.Equal(t, receiversListExp, receiversList)
-		require.True(t, mReceiver.AssertExpectations(t))
+		require.True(t, mM2M.AssertExpectations(t))
 	})
-}
 
-func TestGetAlertReceiver(t *testing.T) {
 	t.Run("Receiver not found", func(t *testing.T) {
 		id := uuid.New()
-		mReceiver := &ReceiverMock{}
 		tenantID := "edgenode"
 
-		// mock getting receiver by UUID from database.
-		mReceiver.On("GetLatestReceiverWithEmailConfig", mock.Anything, tenantID, id).Return(nil, fmt.Errorf("mock error: %w", gorm.ErrRecordNotFound)).Once()
+		firstName := "foo"
+		lastName := "bar"
+		email := "foo@bar.com"
 
-		handler := &ServerInterfaceHandler{
-			receivers: mReceiver,
-		}
+		mM2M := &M2MAuthenticatorMock{}
+		mM2M.On("GetUserList", mock.Anything).Return([]user{
+			{
+				FirstName: firstName,
+				LastName:  lastName,
+				Email:     email,
+			},
+		}, nil).Once()
+
+		mReceiver := &ReceiverMock{}
+		mReceiver.On("SetReceiverValues", mock.Anything, tenantID, id, models.DBReceiverValues{
+			Recipients: []models.EmailAddress{
+				{
+					FirstName: firstName,
+					LastName:  lastName,
+					Email:     email,
+				},
+			},
+		}).Return(fmt.Errorf("mock error: %w", gorm.ErrRecordNotFound)).Once()
 
 		// Creating new Echo server
 		server := echo.New()
 
 		// Registering API call handlers
-		api.RegisterHandlers(server, handler)
+		api.RegisterHandlers(server, &ServerInterfaceHandler{
+			m2m:       mM2M,
+			receivers: mReceiver,
+		})
+
+		body := []byte(`{"emailConfig":{"to":{"enabled":["foo bar <foo@bar.com>"]}}}`)
 
 		uri := fmt.Sprintf("/api/v1/alerts/receivers/%v", id.String())
-		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get(uri).GoWithHTTPHandler(t, server)
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Patch(uri).WithBody(body).GoWithHTTPHandler(t, server)
 
 		body, err := io.ReadAll(result.Recorder.Body)
 		require.NoError(t, err)
@@ -1696,29 +3312,51 @@ func TestGetAlertReceiver(t *testing.T) {
 		require.Equal(t, http.StatusNotFound, httpErr.Code)
 		require.Contains(t, httpErr.Message, errHTTPAlertReceiverNotFound)
 
+		require.True(t, mM2M.AssertExpectations(t))
 		require.True(t, mReceiver.AssertExpectations(t))
 	})
 
-	t.Run("Failed to retrieve receiver by UUID from database", func(t *testing.T) {
+	t.Run("Fail to set email recipients", func(t *testing.T) {
 		id := uuid.New()
-		mReceiver := &ReceiverMock{}
 		tenantID := "edgenode"
 
-		// mock getting receiver by UUID from database.
-		mReceiver.On("GetLatestReceiverWithEmailConfig", mock.Anything, tenantID, id).Return(nil, errors.New("mock error")).Once()
+		firstName := "foo"
+		lastName := "bar"
+		email := "foo@bar.com"
 
-		handler := &ServerInterfaceHandler{
-			receivers: mReceiver,
-		}
+		mM2M := &M2MAuthenticatorMock{}
+		mM2M.On("GetUserList", mock.Anything).Return([]user{
+			{
+				FirstName: firstName,
+				LastName:  lastName,
+				Email:     email,
+			},
+		}, nil).Once()
+
+		mReceiver := &ReceiverMock{}
+		mReceiver.On("SetReceiverValues", mock.Anything, tenantID, id, models.DBReceiverValues{
+			Recipients: []models.EmailAddress{
+				{
+					FirstName: firstName,
+					LastName:  lastName,
+					Email:     email,
+				},
+			},
+		}).Return(errors.New("mock error")).Once()
 
 		// Creating new Echo server
 		server := echo.New()
 
 		// Registering API call handlers
-		api.RegisterHandlers(server, handler)
+		api.RegisterHandlers(server, &ServerInterfaceHandler{
+			m2m:       mM2M,
+			receivers: mReceiver,
+		})
+
+		body := []byte(`{"emailConfig":{"to":{"enabled":["foo bar <foo@bar.com>"]}}}`)
 
 		uri := fmt.Sprintf("/api/v1/alerts/receivers/%v", id.String())
-		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get(uri).GoWithHTTPHandler(t, server)
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Patch(uri).WithBody(body).GoWithHTTPHandler(t, server)
 
 		body, err := io.ReadAll(result.Recorder.Body)
 		require.NoError(t, err)
@@ -1727,27 +3365,105 @@ func TestGetAlertReceiver(t *testing.T) {
 		require.NoError(t, json.Unmarshal(body, httpErr))
 
 		require.Equal(t, http.StatusInternalServerError, httpErr.Code)
-		require.Contains(t, httpErr.Message, errHTTPFailedToGetAlertReceiver)
+		require.Contains(t, httpErr.Message, errHTTPFailedToPatchAlertReceivers)
 
+		require.True(t, mM2M.AssertExpectations(t))
 		require.True(t, mReceiver.AssertExpectations(t))
 	})
-}
 
-func TestPatchAlertReceiver(t *testing.T) {
-	t.Run("Invalid request body", func(t *testing.T) {
+	t.Run("Succeeded to update email recipients", func(t *testing.T) {
 		id := uuid.New()
 		tenantID := "edgenode"
 
+		firstName := "foo"
+		lastName := "bar"
+		email := "foo@bar.com"
+
+		mM2M := &M2MAuthenticatorMock{}
+		mM2M.On("GetUserList", mock.Anything).Return([]user{
+			{
+				FirstName: firstName,
+				LastName:  lastName,
+				Email:     email,
+			},
+		}, nil).Once()
+
+		mReceiver := &ReceiverMock{}
+		mReceiver.On("SetReceiverValues", mock.Anything, tenantID, id, models.DBReceiverValues{
+			Recipients: []models.EmailAddress{
+				{
+					FirstName: firstName,
+					LastName:  lastName,
+					Email:     email,
+				},
+			},
+		}).Return(nil).Once()
+
 		// Creating new Echo server
 		server := echo.New()
 
 		// Registering API call handlers
-		api.RegisterHandlers(server, &ServerInterfaceHandler{})
+		api.RegisterHandlers(server, &ServerInterfaceHandler{
+			m2m:       mM2M,
+			receivers: mReceiver,
+		})
 
-		invalidBody := []byte(`{"emailConfig":{"to":["firstName lastName <emailtext@sampppple.com>"]}}`)
+		body := []byte(`{"emailConfig":{"to":{"enabled":["foo bar <foo@bar.com>"]}}}`)
 
 		uri := fmt.Sprintf("/api/v1/alerts/receivers/%v", id.String())
-		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Patch(uri).WithBody(invalidBody).GoWithHTTPHandler(t, server)
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Patch(uri).WithBody(body).GoWithHTTPHandler(t, server)
+
+		require.Equal(t, http.StatusNoContent, result.Recorder.Code)
+
+		require.True(t, mM2M.AssertExpectations(t))
+		require.True(t, mReceiver.AssertExpectations(t))
+	})
+
+	t.Run("Receiver exceeds alertmanager configuration limits", func(t *testing.T) {
+		id := uuid.New()
+		tenantID := "edgenode"
+
+		mM2M := &M2MAuthenticatorMock{}
+		mM2M.On("GetUserList", mock.Anything).Return([]user{
+			{
+				FirstName: "foo",
+				LastName:  "bar",
+				Email:     "foo@bar.com",
+			},
+		}, nil).Once()
+
+		recv := &models.DBReceiver{
+			UUID:     id,
+			Name:     "receiver",
+			Version:  1,
+			TenantID: tenantID,
+		}
+		mReceiver := &ReceiverMock{}
+		mReceiver.On("GetLatestReceiverWithEmailConfig", mock.Anything, tenantID, id).Return(recv, nil).Once()
+
+		mValidator := &ReceiverConfigValidatorMock{}
+		mValidator.On("ValidateReceiverConfig", mock.Anything, models.DBReceiver{
+			UUID:     id,
+			Name:     "receiver",
+			Version:  2,
+			TenantID: tenantID,
+			To:       []string{"foo bar <foo@bar.com>"},
+		}).Return(fmt.Errorf("too many routes: %w", ErrConfigLimitExceeded)).Once()
+
+		// Creating new Echo server
+		server := echo.New()
+
+		// Registering API call handlers
+		api.RegisterHandlers(server, &ServerInterfaceHandler{
+			m2m:          mM2M,
+			receivers:    mReceiver,
+			receiversCfg: mValidator,
+		})
+
+		body := []byte(`{"emailConfig":{"to":{"enabled":["foo bar <foo@bar.com>"]}}}`)
+
+		uri := fmt.Sprintf("/api/v1/alerts/receivers/%v", id.String())
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Patch(uri).WithBody(body).GoWithHTTPHandler(t, server)
 
 		body, err := io.ReadAll(result.Recorder.Body)
 		require.NoError(t, err)
@@ -1755,42 +3471,176 @@ func TestPatchAlertReceiver(t *testing.T) {
 		httpErr := &api.HttpError{}
 		require.NoError(t, json.Unmarshal(body, httpErr))
 
+		require.Equal(t, http.StatusBadRequest, result.Recorder.Code)
 		require.Equal(t, http.StatusBadRequest, httpErr.Code)
-		require.Contains(t, httpErr.Message, errHTTPBadRequest)
+		require.Equal(t, errHTTPReceiverConfigLimitExceeded, httpErr.Message)
+
+		require.True(t, mM2M.AssertExpectations(t))
+		require.True(t, mReceiver.AssertExpectations(t))
+		require.True(t, mValidator.AssertExpectations(t))
+		mReceiver.AssertNotCalled(t, "SetReceiverValues", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Receiver route conflicts with the route of another receiver", func(t *testing.T) {
+		id := uuid.New()
+		tenantID := "edgenode"
+
+		mM2M := &M2MAuthenticatorMock{}
+		mM2M.On("GetUserList", mock.Anything).Return([]user{{FirstName: "foo", LastName: "bar", Email: "foo@bar.com"}}, nil).Once()
+
+		mReceiver := &ReceiverMock{}
+		mReceiver.On("GetLatestReceiverWithEmailConfig", mock.Anything, tenantID, id).
+			Return(&models.DBReceiver{UUID: id, Name: "receiver", Version: 1, TenantID: tenantID}, nil).Once()
+
+		mValidator := &ReceiverConfigValidatorMock{}
+		mValidator.On("ValidateReceiverConfig", mock.Anything, mock.Anything).
+			Return(fmt.Errorf("rejected: %w", &RouteConflictError{Receiver: "other"})).Once()
+
+		server := echo.New()
+		api.RegisterHandlers(server, &ServerInterfaceHandler{
+			m2m:          mM2M,
+			receivers:    mReceiver,
+			receiversCfg: mValidator,
+		})
+
+		body := []byte(`{"emailConfig":{"to":{"enabled":["foo bar <foo@bar.com>"]}},"minSeverity":"warning"}`)
+
+		uri := fmt.Sprintf("/api/v1/alerts/receivers/%v", id.String())
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Patch(uri).WithBody(body).GoWithHTTPHandler(t, server)
+
+		httpErr := &api.HttpError{}
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), httpErr))
+		require.Equal(t, http.StatusConflict, result.Recorder.Code)
+		require.Equal(t, api.ErrorCodeReceiverRouteConflict, httpErr.ErrorCode)
+		require.Equal(t, `alert receiver route conflicts with the route of receiver "other", alerts would only be notified to one of them`,
+			httpErr.Message)
+
+		require.True(t, mValidator.AssertExpectations(t))
+		mReceiver.AssertNotCalled(t, "SetReceiverValues", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Invalid minimum severity", func(t *testing.T) {
+		id := uuid.New()
+		tenantID := "edgenode"
+
+		mM2M := &M2MAuthenticatorMock{}
+		mM2M.On("GetUserList", mock.Anything).Return([]user{
+			{
+				FirstName: "foo",
+				LastName:  "bar",
+				Email:     "foo@bar.com",
+			},
+		}, nil).Once()
+
+		// Creating new Echo server
+		server := echo.New()
+
+		// Registering API call handlers
+		api.RegisterHandlers(server, &ServerInterfaceHandler{
+			m2m: mM2M,
+		})
+
+		body := []byte(`{"emailConfig":{"to":{"enabled":["foo bar <foo@bar.com>"]}},"minSeverity":"urgent"}`)
+
+		uri := fmt.Sprintf("/api/v1/alerts/receivers/%v", id.String())
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Patch(uri).WithBody(body).GoWithHTTPHandler(t, server)
+
 		require.Equal(t, http.StatusBadRequest, result.Recorder.Code)
+		require.True(t, mM2M.AssertExpectations(t))
 	})
 
-	t.Run("Request body contains unknown extra fields", func(t *testing.T) {
+	t.Run("Quiet hours enabled without start and end times", func(t *testing.T) {
 		id := uuid.New()
 		tenantID := "edgenode"
 
+		mM2M := &M2MAuthenticatorMock{}
+		mM2M.On("GetUserList", mock.Anything).Return([]user{
+			{
+				FirstName: "foo",
+				LastName:  "bar",
+				Email:     "foo@bar.com",
+			},
+		}, nil).Once()
+
 		// Creating new Echo server
 		server := echo.New()
 
 		// Registering API call handlers
-		api.RegisterHandlers(server, &ServerInterfaceHandler{})
+		api.RegisterHandlers(server, &ServerInterfaceHandler{
+			m2m: mM2M,
+		})
 
-		invalidBody := []byte(`{"emailConfig":{"to":{"enabled":["first user <first.user@email.com>"], "allowed":["second user second.user@email.com"]}}}`)
+		body := []byte(`{"emailConfig":{"to":{"enabled":["foo bar <foo@bar.com>"]}},"quietHours":{"enabled":true}}`)
 
 		uri := fmt.Sprintf("/api/v1/alerts/receivers/%v", id.String())
-		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Patch(uri).WithBody(invalidBody).GoWithHTTPHandler(t, server)
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Patch(uri).WithBody(body).GoWithHTTPHandler(t, server)
 
-		body, err := io.ReadAll(result.Recorder.Body)
-		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, result.Recorder.Code)
+		require.True(t, mM2M.AssertExpectations(t))
+	})
+
+	t.Run("Succeeded to update email recipients and quiet hours", func(t *testing.T) {
+		id := uuid.New()
+		tenantID := "edgenode"
+
+		mM2M := &M2MAuthenticatorMock{}
+		mM2M.On("GetUserList", mock.Anything).Return([]user{
+			{
+				FirstName: "foo",
+				LastName:  "bar",
+				Email:     "foo@bar.com",
+			},
+		}, nil).Once()
+
+		mReceiver := &ReceiverMock{}
+		mReceiver.On("SetReceiverValues", mock.Anything, tenantID, id, models.DBReceiverValues{
+			Recipients: []models.EmailAddress{
+				{
+					FirstName: "foo",
+					LastName:  "bar",
+					Email:     "foo@bar.com",
+				},
+			},
+			QuietHours: &models.QuietHours{
+				Start:    "22:00",
+				End:      "06:00",
+				Location: "Europe/Warsaw",
+			},
+		}).Return(nil).Once()
+
+		// Creating new Echo server
+		server := echo.New()
+
+		// Registering API call handlers
+		api.RegisterHandlers(server, &ServerInterfaceHandler{
+			m2m:       mM2M,
+			receivers: mReceiver,
+		})
+
+		body := []byte(`{"emailConfig":{"to":{"enabled":["foo bar <foo@bar.com>"]}},` +
+			`"quietHours":{"enabled":true,"start":"22:00","end":"06:00","location":"Europe/Warsaw"}}`)
+
+		uri := fmt.Sprintf("/api/v1/alerts/receivers/%v", id.String())
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Patch(uri).WithBody(body).GoWithHTTPHandler(t, server)
 
-		httpErr := &api.HttpError{}
-		require.NoError(t, json.Unmarshal(body, httpErr))
+		require.Equal(t, http.StatusNoContent, result.Recorder.Code)
 
-		require.Equal(t, http.StatusBadRequest, httpErr.Code)
-		require.Contains(t, httpErr.Message, errHTTPBadRequest)
+		require.True(t, mM2M.AssertExpectations(t))
+		require.True(t, mReceiver.AssertExpectations(t))
 	})
 
-	t.Run("Fail to get allowed email recipients", func(t *testing.T) {
+	t.Run("Invalid Grafana OnCall routing key", func(t *testing.T) {
 		id := uuid.New()
 		tenantID := "edgenode"
 
 		mM2M := &M2MAuthenticatorMock{}
-		mM2M.On("GetUserList", mock.Anything).Return(nil, errors.New("mock error")).Once()
+		mM2M.On("GetUserList", mock.Anything).Return([]user{
+			{
+				FirstName: "foo",
+				LastName:  "bar",
+				Email:     "foo@bar.com",
+			},
+		}, nil).Once()
 
 		// Creating new Echo server
 		server := echo.New()
@@ -1800,56 +3650,62 @@ func TestPatchAlertReceiver(t *testing.T) {
 			m2m: mM2M,
 		})
 
-		body := []byte(`{"emailConfig":{"to":{"enabled":["bar foo <foo@bar>"]}}}`)
+		body := []byte(`{"emailConfig":{"to":{"enabled":["foo bar <foo@bar.com>"]}},"onCall":{"routingKey":"../key"}}`)
 
 		uri := fmt.Sprintf("/api/v1/alerts/receivers/%v", id.String())
 		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Patch(uri).WithBody(body).GoWithHTTPHandler(t, server)
 
-		body, err := io.ReadAll(result.Recorder.Body)
-		require.NoError(t, err)
-
-		httpErr := &api.HttpError{}
-		require.NoError(t, json.Unmarshal(body, httpErr))
-
-		require.Equal(t, http.StatusInternalServerError, httpErr.Code)
-		require.Contains(t, httpErr.Message, errHTTPFailedToPatchAlertReceivers)
-
+		require.Equal(t, http.StatusBadRequest, result.Recorder.Code)
 		require.True(t, mM2M.AssertExpectations(t))
 	})
 
-	t.Run("Allowed email recipients is empty", func(t *testing.T) {
+	t.Run("Succeeded to update email recipients and Grafana OnCall routing key", func(t *testing.T) {
 		id := uuid.New()
 		tenantID := "edgenode"
 
 		mM2M := &M2MAuthenticatorMock{}
-		mM2M.On("GetUserList", mock.Anything).Return([]user{}, nil).Once()
+		mM2M.On("GetUserList", mock.Anything).Return([]user{
+			{
+				FirstName: "foo",
+				LastName:  "bar",
+				Email:     "foo@bar.com",
+			},
+		}, nil).Once()
+
+		routingKey := "Xk2_routing-key"
+		mReceiver := &ReceiverMock{}
+		mReceiver.On("SetReceiverValues", mock.Anything, tenantID, id, models.DBReceiverValues{
+			Recipients: []models.EmailAddress{
+				{
+					FirstName: "foo",
+					LastName:  "bar",
+					Email:     "foo@bar.com",
+				},
+			},
+			OnCallRoutingKey: &routingKey,
+		}).Return(nil).Once()
 
 		// Creating new Echo server
 		server := echo.New()
 
 		// Registering API call handlers
 		api.RegisterHandlers(server, &ServerInterfaceHandler{
-			m2m: mM2M,
+			m2m:       mM2M,
+			receivers: mReceiver,
 		})
 
-		body := []byte(`{"emailConfig":{"to":{"enabled":["bar foo <foo@bar>"]}}}`)
+		body := []byte(`{"emailConfig":{"to":{"enabled":["foo bar <foo@bar.com>"]}},"onCall":{"routingKey":"Xk2_routing-key"}}`)
 
 		uri := fmt.Sprintf("/api/v1/alerts/receivers/%v", id.String())
 		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Patch(uri).WithBody(body).GoWithHTTPHandler(t, server)
 
-		body, err := io.ReadAll(result.Recorder.Body)
-		require.NoError(t, err)
-
-		httpErr := &api.HttpError{}
-		require.NoError(t, json.Unmarshal(body, httpErr))
-
-		require.Equal(t, http.StatusInternalServerError, httpErr.Code)
-		require.Contains(t, httpErr.Message, errHTTPFailedToPatchAlertReceivers)
+		require.Equal(t, http.StatusNoContent, result.Recorder.Code)
 
 		require.True(t, mM2M.AssertExpectations(t))
+		require.True(t, mReceiver.AssertExpectations(t))
 	})
 
-	t.Run("Email recipient not allowed", func(t *testing.T) {
+	t.Run("Failed to update alert receiver with an unsupported email language", func(t *testing.T) {
 		id := uuid.New()
 		tenantID := "edgenode"
 
@@ -1862,32 +3718,34 @@ func TestPatchAlertReceiver(t *testing.T) {
 			},
 		}, nil).Once()
 
+		configuration := conf
+		configuration.AlertManager.EmailLanguages = []string{"de", "fr"}
+
 		// Creating new Echo server
 		server := echo.New()
 
 		// Registering API call handlers
 		api.RegisterHandlers(server, &ServerInterfaceHandler{
-			m2m: mM2M,
+			m2m:           mM2M,
+			configuration: configuration,
 		})
 
-		body := []byte(`{"emailConfig":{"to":{"enabled":["bar foo <foo@bar>"]}}}`)
+		body := []byte(`{"emailConfig":{"to":{"enabled":["foo bar <foo@bar.com>"]}},"language":"ja"}`)
 
 		uri := fmt.Sprintf("/api/v1/alerts/receivers/%v", id.String())
-		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Patch(uri).WithBody(body).GoWithHTTPHandler(t, server)
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).WithHeader("Accept-Language", "de").
+			Patch(uri).WithBody(body).GoWithHTTPHandler(t, server)
 
-		body, err := io.ReadAll(result.Recorder.Body)
-		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, result.Recorder.Code)
 
 		httpErr := &api.HttpError{}
-		require.NoError(t, json.Unmarshal(body, httpErr))
-
-		require.Equal(t, http.StatusBadRequest, httpErr.Code)
-		require.Contains(t, httpErr.Message, errHTTPBadRequest)
-
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), httpErr))
+		require.Equal(t, api.ErrorCodeInvalidRequestBody, httpErr.ErrorCode)
+		require.Equal(t, "E-Mail-Sprache wird nicht unterstützt, erwartet wird eine von: de, fr", httpErr.Message)
 		require.True(t, mM2M.AssertExpectations(t))
 	})
 
-	t.Run("Duplicated email recipients", func(t *testing.T) {
+	t.Run("Succeeded to update email recipients and email language", func(t *testing.T) {
 		id := uuid.New()
 		tenantID := "edgenode"
 
@@ -1900,32 +3758,189 @@ func TestPatchAlertReceiver(t *testing.T) {
 			},
 		}, nil).Once()
 
+		language := "fr"
+		mReceiver := &ReceiverMock{}
+		mReceiver.On("SetReceiverValues", mock.Anything, tenantID, id, models.DBReceiverValues{
+			Recipients: []models.EmailAddress{
+				{
+					FirstName: "foo",
+					LastName:  "bar",
+					Email:     "foo@bar.com",
+				},
+			},
+			Language: &language,
+		}).Return(nil).Once()
+
+		configuration := conf
+		configuration.AlertManager.EmailLanguages = []string{"de", "fr"}
+
 		// Creating new Echo server
 		server := echo.New()
 
 		// Registering API call handlers
 		api.RegisterHandlers(server, &ServerInterfaceHandler{
-			m2m: mM2M,
+			m2m:           mM2M,
+			receivers:     mReceiver,
+			configuration: configuration,
 		})
 
-		body := []byte(`{"emailConfig":{"to":{"enabled":["foo bar <foo@bar.com>", "foo bar <foo@bar.com>"]}}}`)
+		body := []byte(`{"emailConfig":{"to":{"enabled":["foo bar <foo@bar.com>"]}},"language":"fr"}`)
 
 		uri := fmt.Sprintf("/api/v1/alerts/receivers/%v", id.String())
 		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Patch(uri).WithBody(body).GoWithHTTPHandler(t, server)
 
-		body, err := io.ReadAll(result.Recorder.Body)
-		require.NoError(t, err)
+		require.Equal(t, http.StatusNoContent, result.Recorder.Code)
 
-		httpErr := &api.HttpError{}
-		require.NoError(t, json.Unmarshal(body, httpErr))
+		require.True(t, mM2M.AssertExpectations(t))
+		require.True(t, mReceiver.AssertExpectations(t))
+	})
 
-		require.Equal(t, http.StatusBadRequest, httpErr.Code)
-		require.Contains(t, httpErr.Message, errHTTPBadRequest)
+	t.Run("Failed to override email configuration", func(t *testing.T) {
+		id := uuid.New()
+		tenantID := "edgenode"
+
+		for name, tc := range map[string]struct {
+			body      string
+			tenants   []string
+			authorize func(echo.Context) error
+			relayed   bool
+			code      int
+			errorCode api.ErrorCode
+		}{
+			"Tenant not allowed - code should be 403": {
+				body:      `{"emailConfig":{"from":"tenant alerts <tenant@example.com>","to":{"enabled":[]}}}`,
+				authorize: func(echo.Context) error { return nil },
+				code:      http.StatusForbidden,
+				errorCode: api.ErrorCodeEmailOverrideNotAllowed,
+			},
+			"Override not authorized - code should be 403": {
+				body:      `{"emailConfig":{"from":"tenant alerts <tenant@example.com>","to":{"enabled":[]}}}`,
+				tenants:   []string{tenantID},
+				authorize: func(echo.Context) error { return errors.New("mock error") },
+				code:      http.StatusForbidden,
+				errorCode: api.ErrorCodeEmailOverrideNotAllowed,
+			},
+			"Mail server not allowed - code should be 400": {
+				body:      `{"emailConfig":{"mailServer":"smtp.attacker.com:25","to":{"enabled":[]}}}`,
+				tenants:   []string{tenantID},
+				authorize: func(echo.Context) error { return nil },
+				code:      http.StatusBadRequest,
+				errorCode: api.ErrorCodeInvalidRequestBody,
+			},
+			"Sender of signed emails - code should be 400": {
+				body:      `{"emailConfig":{"from":"tenant alerts <tenant@example.com>","to":{"enabled":[]}}}`,
+				tenants:   []string{tenantID},
+				authorize: func(echo.Context) error { return nil },
+				relayed:   true,
+				code:      http.StatusBadRequest,
+				errorCode: api.ErrorCodeInvalidRequestBody,
+			},
+			"Mail server of relayed emails - code should be 400": {
+				body:      `{"emailConfig":{"mailServer":"smtp-eu.example.com:587","to":{"enabled":[]}}}`,
+				tenants:   []string{tenantID},
+				authorize: func(echo.Context) error { return nil },
+				relayed:   true,
+				code:      http.StatusBadRequest,
+				errorCode: api.ErrorCodeInvalidRequestBody,
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				mM2M := &M2MAuthenticatorMock{}
+				mM2M.On("GetUserList", mock.Anything).Return([]user{{FirstName: "foo", LastName: "bar", Email: "foo@bar.com"}}, nil).Once()
+
+				configuration := conf
+				configuration.EmailOverride = config.EmailOverrideConfig{Tenants: tc.tenants, MailServers: []string{"smtp-eu.example.com:587"}}
+				if tc.relayed {
+					configuration.AlertManager.SigningRelayHost = "alerting-monitor:2525"
+				}
+
+				server := echo.New()
+				mReceiver := &ReceiverMock{}
+				api.RegisterHandlers(server, &ServerInterfaceHandler{
+					m2m:                    mM2M,
+					receivers:              mReceiver,
+					authorizeEmailOverride: tc.authorize,
+					configuration:          configuration,
+				})
+
+				uri := fmt.Sprintf("/api/v1/alerts/receivers/%v", id.String())
+				result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Patch(uri).WithBody([]byte(tc.body)).
+					GoWithHTTPHandler(t, server)
+
+				httpErr := &api.HttpError{}
+				require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), httpErr))
+				require.Equal(t, tc.code, httpErr.Code)
+				require.Equal(t, tc.errorCode, httpErr.ErrorCode)
+				mReceiver.AssertNotCalled(t, "SetReceiverValues", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			})
+		}
+	})
+
+	t.Run("Succeeded to override email configuration", func(t *testing.T) {
+		id := uuid.New()
+		tenantID := "edgenode"
+
+		mM2M := &M2MAuthenticatorMock{}
+		mM2M.On("GetUserList", mock.Anything).Return([]user{{FirstName: "foo", LastName: "bar", Email: "foo@bar.com"}}, nil).Once()
+
+		mailServer := "smtp-eu.example.com:587"
+		mReceiver := &ReceiverMock{}
+		mReceiver.On("SetReceiverValues", mock.Anything, tenantID, id, models.DBReceiverValues{
+			Recipients: []models.EmailAddress{},
+			Sender:     &models.EmailAddress{FirstName: "tenant", LastName: "alerts", Email: "tenant@example.com"},
+			MailServer: &mailServer,
+		}).Return(nil).Once()
+
+		configuration := conf
+		configuration.EmailOverride = config.EmailOverrideConfig{Tenants: []string{tenantID}, MailServers: []string{mailServer}}
 
+		server := echo.New()
+		api.RegisterHandlers(server, &ServerInterfaceHandler{
+			m2m:                    mM2M,
+			receivers:              mReceiver,
+			authorizeEmailOverride: func(echo.Context) error { return nil },
+			configuration:          configuration,
+		})
+
+		body := []byte(`{"emailConfig":{"from":"tenant alerts <tenant@example.com>","mailServer":"smtp-eu.example.com:587","to":{"enabled":[]}}}`)
+
+		uri := fmt.Sprintf("/api/v1/alerts/receivers/%v", id.String())
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Patch(uri).WithBody(body).GoWithHTTPHandler(t, server)
+
+		require.Equal(t, http.StatusNoContent, result.Recorder.Code)
 		require.True(t, mM2M.AssertExpectations(t))
+		require.True(t, mReceiver.AssertExpectations(t))
 	})
 
-	t.Run("Receiver not found", func(t *testing.T) {
+	t.Run("Succeeded to disable alert receiver", func(t *testing.T) {
+		id := uuid.New()
+		tenantID := "edgenode"
+
+		mM2M := &M2MAuthenticatorMock{}
+		mM2M.On("GetUserList", mock.Anything).Return([]user{{FirstName: "foo", LastName: "bar", Email: "foo@bar.com"}}, nil).Once()
+
+		mReceiver := &ReceiverMock{}
+		mReceiver.On("SetReceiverValues", mock.Anything, tenantID, id, models.DBReceiverValues{
+			Recipients: []models.EmailAddress{{FirstName: "foo", LastName: "bar", Email: "foo@bar.com"}},
+			Disabled:   boolPtr(true),
+		}).Return(nil).Once()
+
+		server := echo.New()
+		api.RegisterHandlers(server, &ServerInterfaceHandler{
+			m2m:       mM2M,
+			receivers: mReceiver,
+		})
+
+		body := []byte(`{"emailConfig":{"to":{"enabled":["foo bar <foo@bar.com>"]}},"enabled":false}`)
+
+		uri := fmt.Sprintf("/api/v1/alerts/receivers/%v", id.String())
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Patch(uri).WithBody(body).GoWithHTTPHandler(t, server)
+
+		require.Equal(t, http.StatusNoContent, result.Recorder.Code)
+		require.True(t, mReceiver.AssertExpectations(t))
+	})
+
+	t.Run("Succeeded to update email recipients and minimum severity", func(t *testing.T) {
 		id := uuid.New()
 		tenantID := "edgenode"
 
@@ -1942,14 +3957,18 @@ func TestPatchAlertReceiver(t *testing.T) {
 			},
 		}, nil).Once()
 
+		minSeverity := models.SeverityCritical
 		mReceiver := &ReceiverMock{}
-		mReceiver.On("SetReceiverEmailRecipients", mock.Anything, tenantID, id, []models.EmailAddress{
-			{
-				FirstName: firstName,
-				LastName:  lastName,
-				Email:     email,
+		mReceiver.On("SetReceiverValues", mock.Anything, tenantID, id, models.DBReceiverValues{
+			Recipients: []models.EmailAddress{
+				{
+					FirstName: firstName,
+					LastName:  lastName,
+					Email:     email,
+				},
 			},
-		}).Return(fmt.Errorf("mock error: %w", gorm.ErrRecordNotFound)).Once()
+			MinSeverity: &minSeverity,
+		}).Return(nil).Once()
 
 		// Creating new Echo server
 		server := echo.New()
@@ -1960,102 +3979,105 @@ func TestPatchAlertReceiver(t *testing.T) {
 			receivers: mReceiver,
 		})
 
-		body := []byte(`{"emailConfig":{"to":{"enabled":["foo bar <foo@bar.com>"]}}}`)
+		body := []byte(`{"emailConfig":{"to":{"enabled":["foo bar <foo@bar.com>"]}},"minSeverity":"critical"}`)
 
 		uri := fmt.Sprintf("/api/v1/alerts/receivers/%v", id.String())
 		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Patch(uri).WithBody(body).GoWithHTTPHandler(t, server)
 
-		body, err := io.ReadAll(result.Recorder.Body)
-		require.NoError(t, err)
-
-		httpErr := &api.HttpError{}
-		require.NoError(t, json.Unmarshal(body, httpErr))
-
-		require.Equal(t, http.StatusNotFound, httpErr.Code)
-		require.Contains(t, httpErr.Message, errHTTPAlertReceiverNotFound)
+		require.Equal(t, http.StatusNoContent, result.Recorder.Code)
 
 		require.True(t, mM2M.AssertExpectations(t))
 		require.True(t, mReceiver.AssertExpectations(t))
 	})
 
-	t.Run("Fail to set email recipients", func(t *testing.T) {
+	t.Run("Succeeded to update email recipients with a preview of the configuration", func(t *testing.T) {
 		id := uuid.New()
 		tenantID := "edgenode"
 
-		firstName := "foo"
-		lastName := "bar"
-		email := "foo@bar.com"
-
 		mM2M := &M2MAuthenticatorMock{}
 		mM2M.On("GetUserList", mock.Anything).Return([]user{
 			{
-				FirstName: firstName,
-				LastName:  lastName,
-				Email:     email,
+				FirstName: "foo",
+				LastName:  "bar",
+				Email:     "foo@bar.com",
 			},
 		}, nil).Once()
 
+		recv := &models.DBReceiver{
+			UUID:     id,
+			Name:     "receiver",
+			Version:  1,
+			TenantID: tenantID,
+		}
+		projected := models.DBReceiver{
+			UUID:     id,
+			Name:     "receiver",
+			Version:  2,
+			TenantID: tenantID,
+			To:       []string{"foo bar <foo@bar.com>"},
+		}
+		preview := api.ReceiverConfigPreview{
+			Receiver: "name: edgenode-receiver-2\nemail_configs:\n- to: foo bar <foo@bar.com>\n",
+			Route:    "receiver: edgenode-receiver-2\n",
+		}
+
 		mReceiver := &ReceiverMock{}
-		mReceiver.On("SetReceiverEmailRecipients", mock.Anything, tenantID, id, []models.EmailAddress{
-			{
-				FirstName: firstName,
-				LastName:  lastName,
-				Email:     email,
+		mReceiver.On("GetLatestReceiverWithEmailConfig", mock.Anything, tenantID, id).Return(recv, nil).Twice()
+		mReceiver.On("SetReceiverValues", mock.Anything, tenantID, id, models.DBReceiverValues{
+			Recipients: []models.EmailAddress{
+				{
+					FirstName: "foo",
+					LastName:  "bar",
+					Email:     "foo@bar.com",
+				},
 			},
-		}).Return(errors.New("mock error")).Once()
+		}).Return(nil).Once()
+
+		mValidator := &ReceiverConfigValidatorMock{}
+		mValidator.On("ValidateReceiverConfig", mock.Anything, projected).Return(nil).Once()
+		mValidator.On("PreviewReceiverConfig", mock.Anything, projected).Return(preview, nil).Once()
 
 		// Creating new Echo server
 		server := echo.New()
 
 		// Registering API call handlers
 		api.RegisterHandlers(server, &ServerInterfaceHandler{
-			m2m:       mM2M,
-			receivers: mReceiver,
+			m2m:          mM2M,
+			receivers:    mReceiver,
+			receiversCfg: mValidator,
 		})
 
 		body := []byte(`{"emailConfig":{"to":{"enabled":["foo bar <foo@bar.com>"]}}}`)
 
-		uri := fmt.Sprintf("/api/v1/alerts/receivers/%v", id.String())
+		uri := fmt.Sprintf("/api/v1/alerts/receivers/%v?preview=true", id.String())
 		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Patch(uri).WithBody(body).GoWithHTTPHandler(t, server)
 
-		body, err := io.ReadAll(result.Recorder.Body)
-		require.NoError(t, err)
-
-		httpErr := &api.HttpError{}
-		require.NoError(t, json.Unmarshal(body, httpErr))
+		require.Equal(t, http.StatusAccepted, result.Recorder.Code)
 
-		require.Equal(t, http.StatusInternalServerError, httpErr.Code)
-		require.Contains(t, httpErr.Message, errHTTPFailedToPatchAlertReceivers)
+		var res api.OperationAccepted
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &res))
+		require.NotEqual(t, uuid.Nil, res.OperationId)
+		require.Equal(t, &preview, res.Preview)
 
 		require.True(t, mM2M.AssertExpectations(t))
 		require.True(t, mReceiver.AssertExpectations(t))
+		require.True(t, mValidator.AssertExpectations(t))
 	})
 
-	t.Run("Succeeded to update email recipients", func(t *testing.T) {
+	t.Run("Preview of the configuration is not available", func(t *testing.T) {
 		id := uuid.New()
 		tenantID := "edgenode"
 
-		firstName := "foo"
-		lastName := "bar"
-		email := "foo@bar.com"
-
 		mM2M := &M2MAuthenticatorMock{}
 		mM2M.On("GetUserList", mock.Anything).Return([]user{
 			{
-				FirstName: firstName,
-				LastName:  lastName,
-				Email:     email,
+				FirstName: "foo",
+				LastName:  "bar",
+				Email:     "foo@bar.com",
 			},
 		}, nil).Once()
 
 		mReceiver := &ReceiverMock{}
-		mReceiver.On("SetReceiverEmailRecipients", mock.Anything, tenantID, id, []models.EmailAddress{
-			{
-				FirstName: firstName,
-				LastName:  lastName,
-				Email:     email,
-			},
-		}).Return(nil).Once()
 
 		// Creating new Echo server
 		server := echo.New()
@@ -2068,13 +4090,17 @@ func TestPatchAlertReceiver(t *testing.T) {
 
 		body := []byte(`{"emailConfig":{"to":{"enabled":["foo bar <foo@bar.com>"]}}}`)
 
-		uri := fmt.Sprintf("/api/v1/alerts/receivers/%v", id.String())
+		uri := fmt.Sprintf("/api/v1/alerts/receivers/%v?preview=true", id.String())
 		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Patch(uri).WithBody(body).GoWithHTTPHandler(t, server)
 
-		require.Equal(t, http.StatusNoContent, result.Recorder.Code)
+		httpErr := &api.HttpError{}
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), httpErr))
+
+		require.Equal(t, http.StatusServiceUnavailable, result.Recorder.Code)
+		require.Equal(t, errHTTPReceiverConfigPreviewUnavailable, httpErr.Message)
 
 		require.True(t, mM2M.AssertExpectations(t))
-		require.True(t, mReceiver.AssertExpectations(t))
+		mReceiver.AssertNotCalled(t, "SetReceiverValues", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 	})
 }
 
@@ -2082,7 +4108,7 @@ func TestGetStatus(t *testing.T) {
 	t.Run("Error - Could not reach alert manager", func(t *testing.T) {
 		configfile := conf
 		configfile.AlertManager.URL = "dummy-alert-manager:8080"
-		serverInterface := NewServerInterfaceHandler(configfile, &gorm.DB{}, nil)
+		serverInterface := NewServerInterfaceHandler(configfile, &gorm.DB{}, nil, nil)
 
 		// Creating new Echo server
 		e := echo.New()
@@ -2119,7 +4145,7 @@ func TestGetStatus(t *testing.T) {
 		defer server.Close()
 
 		configfile.AlertManager.URL = server.URL
-		serverInterface := NewServerInterfaceHandler(configfile, &gorm.DB{}, nil)
+		serverInterface := NewServerInterfaceHandler(configfile, &gorm.DB{}, nil, nil)
 
 		// Creating new Echo server
 		e := echo.New()
@@ -2159,7 +4185,7 @@ func TestGetStatus(t *testing.T) {
 		defer server.Close()
 
 		configfile.AlertManager.URL = server.URL
-		serverInterface := NewServerInterfaceHandler(configfile, &gorm.DB{}, nil)
+		serverInterface := NewServerInterfaceHandler(configfile, &gorm.DB{}, nil, nil)
 
 		// Registering API call handlers
 		api.RegisterHandlers(e, serverInterface)
@@ -2204,7 +4230,7 @@ func TestGetStatus(t *testing.T) {
 
 		configfile.AlertManager.URL = alertSrv.URL
 		configfile.Mimir.RulerURL = mimirSrv.URL
-		serverInterface := NewServerInterfaceHandler(configfile, &gorm.DB{}, nil)
+		serverInterface := NewServerInterfaceHandler(configfile, &gorm.DB{}, nil, nil)
 
 		// Registering API call handlers
 		api.RegisterHandlers(e, serverInterface)
@@ -2257,7 +4283,7 @@ func TestGetStatus(t *testing.T) {
 		configfile.AlertManager.URL = alertSrv.URL
 		configfile.Mimir.RulerURL = mimirSrv.URL
 		configfile.Mimir.Namespace = namespace
-		serverInterface := NewServerInterfaceHandler(configfile, &gorm.DB{}, nil)
+		serverInterface := NewServerInterfaceHandler(configfile, &gorm.DB{}, nil, nil)
 
 		// Registering API call handlers
 		api.RegisterHandlers(e, serverInterface)
@@ -2273,4 +4299,47 @@ func TestGetStatus(t *testing.T) {
 		require.NoError(t, err, "Unexpected error unmarshalling response: %v", err)
 		require.Equal(t, api.Ready, status.State)
 	})
+
+	t.Run("Status Degraded - Certificate close to its expiry", func(t *testing.T) {
+		configfile := conf
+
+		e := echo.New()
+
+		alertSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/api/v2/status" {
+				w.WriteHeader(http.StatusOK)
+				err := json.NewEncoder(w).Encode(alertManagerInfo{
+					Cluster: alertManagerStatus{
+						Status: "ready",
+					},
+				})
+				require.NoError(t, err)
+			}
+		}))
+		defer alertSrv.Close()
+
+		namespace := "test-namespace"
+		mimirSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/prometheus/config/v1/rules/"+namespace {
+				w.WriteHeader(http.StatusOK)
+			}
+		}))
+		defer mimirSrv.Close()
+
+		configfile.AlertManager.URL = alertSrv.URL
+		configfile.Mimir.RulerURL = mimirSrv.URL
+		configfile.Mimir.Namespace = namespace
+		serverInterface := NewServerInterfaceHandler(configfile, &gorm.DB{}, nil, nil)
+		serverInterface.certExpiry = newCertExpiryChecker(serverInterface, config.CertExpiryConfig{})
+		serverInterface.certExpiry.expiries = []certExpiry{{Endpoint: "smtp", Severity: certExpiryCritical}}
+
+		api.RegisterHandlers(e, serverInterface)
+
+		result := testutil.NewRequest().Get("/api/v1/status").GoWithHTTPHandler(t, e)
+		require.Equal(t, http.StatusOK, result.Recorder.Code, "Response code does not equal 200")
+
+		status := &api.ServiceStatus{}
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &status))
+		require.Equal(t, api.Degraded, status.State)
+	})
 }
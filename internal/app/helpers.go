@@ -4,11 +4,14 @@
 package app
 
 import (
+	"cmp"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"math"
 	"net/http"
 	"net/url"
 	"regexp"
@@ -16,28 +19,77 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/gommon/log"
+	"golang.org/x/text/language"
 	"gopkg.in/yaml.v2"
 
 	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/correlation"
+	db "github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
 	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/network"
 	"github.com/open-edge-platform/o11y-alerting-monitor/internal/rules"
 )
 
 const (
 	DefaultTenantID = "edgenode"
 	statusEndpoint  = "/api/v1/status"
+	metricsEndpoint = "/metrics"
+	// verifyEndpoint is the endpoint recipients confirm their email address at, with the token of the link of the verification
+	// email sent to them instead of a JWT.
+	verifyEndpoint = "/api/v1/verify"
 )
 
+// ErrConfigLimitExceeded is returned when a change would make the alertmanager configuration exceed its limits.
+var ErrConfigLimitExceeded = errors.New("alertmanager configuration limit exceeded")
+
+// ErrRouteConflict is returned when a change would make the alertmanager route of a receiver shadow, or be shadowed by, the
+// route of another receiver of its tenant.
+var ErrRouteConflict = errors.New("alertmanager route conflict")
+
+// RouteConflictError tells which receiver the alertmanager route of a receiver conflicts with. It matches ErrRouteConflict.
+type RouteConflictError struct {
+	// Receiver is the name of the receiver of the conflicting route.
+	Receiver string
+}
+
+func (e *RouteConflictError) Error() string {
+	return fmt.Sprintf("%s with the route of receiver %q", ErrRouteConflict, e.Receiver)
+}
+
+func (e *RouteConflictError) Is(target error) bool {
+	return target == ErrRouteConflict
+}
+
+// ErrInvalidChannelConfig is returned when a receiver cannot notify through a notification channel with the given values.
+var ErrInvalidChannelConfig = errors.New("invalid notification channel configuration")
+
 // Regex used to check and parse the fields of an email address.
 var EmailRegex = regexp.MustCompile(`^(.*?)\s*(\S+)\s+<(.*)>`)
 
 // Fallback regex to facilitate upgrade procedure with old email address format.
 var SimpleEmailRegex = regexp.MustCompile(`(?:<)?([^<>\s@]+@[^<>\s@]+\.[^<>\s@]+)(?:>)?`)
 
+// Regex used to check the routing key of a Grafana OnCall integration, which is part of the URL alerts are relayed to.
+var routingKeyRegex = regexp.MustCompile(`^[A-Za-z0-9_-]*$`)
+
+// Regex used to check the email language of a receiver, which is a BCP 47 language tag such as de or pt-BR.
+var languageRegex = regexp.MustCompile(`^[a-z]{2,3}(-[A-Za-z0-9]{1,8})*$`)
+
+// Regex used to check and parse the label matchers of a receiver, such as cluster_name="edge-1".
+var receiverMatcherRegex = regexp.MustCompile(`^([a-zA-Z_][a-zA-Z0-9_]*)(=~|!~|!=|=)(".*")$`)
+
+// maxReceiverMatchers is the maximum number of label matchers of a receiver.
+const maxReceiverMatchers = 8
+
+// reservedMatcherLabels are the labels alerts are routed to receivers by, which cannot be matched by receiver matchers.
+var reservedMatcherLabels = []string{"alert_category", "severity"}
+
 // Convert parameters form request to alert manager format.
 func getAlertsParamsToURL(params api.GetProjectAlertsParams) url.Values {
 	outparams := make(url.Values)
@@ -75,41 +127,204 @@ func getAlertsParamsToURL(params api.GetProjectAlertsParams) url.Values {
 	return outparams
 }
 
-// Helper to delete every unneeded annotations from Alert Manager response.
-func filterAnnotations(alerts *[]api.Alert) error {
-	// Iterate through alerts.
-	for i := range *alerts {
-		// Iterate through annotations in alert.
-		for k, v := range *(*alerts)[i].Annotations {
-			// Check if map key has am_ prefix.
-			if strings.HasPrefix(k, "am_") {
-				// Check if key is am_uuid and copy it to AlertDefinitionId field.
-				if k == "am_uuid" {
-					parsedUUID, err := uuid.Parse(v)
-					if err != nil {
-						return err
+// decodeAlerts stream-decodes the array of alerts of an Alert Manager response one alert at a time, so that responses with
+// tens of thousands of alerts are not buffered whole in memory. Alerts are filtered as they are decoded: malformed alerts are
+// left out and reported as warnings instead of failing the whole response, and maintenance alerts are left out. Only a
+// response which is not an array of alerts is an error.
+func decodeAlerts(r io.Reader) ([]api.Alert, []api.AlertWarning, error) {
+	dec := json.NewDecoder(r)
+	if tok, err := dec.Token(); err != nil {
+		return nil, nil, err
+	} else if tok != json.Delim('[') {
+		return nil, nil, fmt.Errorf("unexpected token %v, expected an array of alerts", tok)
+	}
+
+	alerts := make([]api.Alert, 0)
+	var warnings []api.AlertWarning
+	for dec.More() {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, nil, err
+		}
+
+		alert, warning := decodeAlert(raw)
+		if warning == nil {
+			warning = filterAlertAnnotations(&alert)
+		}
+		if warning != nil {
+			warnings = append(warnings, *warning)
+			continue
+		}
+		if isMaintenanceAlert(alert) {
+			continue
+		}
+		alerts = append(alerts, alert)
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return nil, nil, err
+	}
+	return alerts, warnings, nil
+}
+
+// decodeAlert decodes a single alert of an Alert Manager response. Alerts not matching the alert schema, or without fingerprint,
+// labels or annotations, which the alerts endpoint relies on, are reported as a warning.
+func decodeAlert(raw json.RawMessage) (api.Alert, *api.AlertWarning) {
+	var alert api.Alert
+	err := json.Unmarshal(raw, &alert)
+	if err == nil && (alert.Fingerprint == nil || alert.Labels == nil || alert.Annotations == nil) {
+		err = errors.New("missing fingerprint, labels or annotations")
+	}
+	if err == nil {
+		return alert, nil
+	}
+
+	// The fingerprint is reported if it can be told, even though the rest of the alert is malformed.
+	var id struct {
+		Fingerprint *string `json:"fingerprint"`
+	}
+	_ = json.Unmarshal(raw, &id)
+	return api.Alert{}, &api.AlertWarning{
+		Fingerprint: id.Fingerprint,
+		Reason:      api.InvalidSchema,
+		Message:     fmt.Sprintf("alert does not match the alert schema: %v", err),
+	}
+}
+
+// Helper to delete every unneeded annotations of an alert from Alert Manager response. Alerts whose am_uuid annotation is not a
+// valid UUID cannot be linked to their alert definition, they are reported as a warning.
+func filterAlertAnnotations(alert *api.Alert) *api.AlertWarning {
+	var warning *api.AlertWarning
+	// Iterate through annotations in alert.
+	for k, v := range *alert.Annotations {
+		// Check if map key has am_ prefix.
+		if strings.HasPrefix(k, "am_") {
+			// Check if key is am_uuid and copy it to AlertDefinitionId field.
+			if k == "am_uuid" {
+				if parsedUUID, err := uuid.Parse(v); err == nil {
+					alert.AlertDefinitionId = &parsedUUID
+				} else {
+					warning = &api.AlertWarning{
+						Fingerprint: alert.Fingerprint,
+						Reason:      api.MalformedUuid,
+						Message:     fmt.Sprintf("am_uuid annotation %q is not a valid UUID", v),
 					}
-					(*alerts)[i].AlertDefinitionId = &parsedUUID
 				}
-				// Delete unnecessary annotation.
-				delete(*(*alerts)[i].Annotations, k)
 			}
+			// Delete unnecessary annotation.
+			delete(*alert.Annotations, k)
 		}
 	}
-	return nil
+	return warning
+}
+
+// Helper to tell maintenance alerts, which are alerts with "alert_category" equal to "maintenance" or without "alert_category".
+func isMaintenanceAlert(alert api.Alert) bool {
+	alertCategory, ok := (*alert.Labels)["alert_category"]
+	return !ok || alertCategory == "maintenance"
+}
+
+// resourceLabels maps the resources alerts are grouped by to the label of alerts identifying them.
+var resourceLabels = map[api.GroupByQueryParam]string{
+	api.Host:       "host_uuid",
+	api.Cluster:    "cluster_name",
+	api.Deployment: "deployment_id",
+}
+
+// groupAlertsByResource groups the given alerts by the value of the given label, counting the alerts of each resource and
+// keeping their highest severity. Alerts without the label are left out. Resources are sorted from the most to the least
+// severe one, then from the one with the most alerts.
+func groupAlertsByResource(alerts []api.Alert, label string) []api.AlertResource {
+	type group struct {
+		resource api.AlertResource
+		rank     int
+	}
+
+	var groups []*group
+	byResource := make(map[string]*group)
+	for _, alert := range alerts {
+		if alert.Labels == nil || (*alert.Labels)[label] == "" {
+			continue
+		}
+
+		resource := (*alert.Labels)[label]
+		g, ok := byResource[resource]
+		if !ok {
+			g = &group{resource: api.AlertResource{Resource: resource}, rank: -1}
+			byResource[resource] = g
+			groups = append(groups, g)
+		}
+		g.resource.AlertCount++
+
+		severity := models.ReceiverSeverity((*alert.Labels)["severity"])
+		if rank := severity.Rank(); rank > g.rank {
+			g.rank = rank
+			g.resource.HighestSeverity = (*string)(&severity)
+		}
+	}
+
+	slices.SortFunc(groups, func(a, b *group) int {
+		return cmp.Or(
+			cmp.Compare(b.rank, a.rank),
+			cmp.Compare(b.resource.AlertCount, a.resource.AlertCount),
+			strings.Compare(a.resource.Resource, b.resource.Resource),
+		)
+	})
+
+	resources := make([]api.AlertResource, len(groups))
+	for i, g := range groups {
+		resources[i] = g.resource
+	}
+	return resources
 }
 
-// Helper to remove maintenance alerts.
-func filterOutMaintenanceAlerts(alerts *[]api.Alert) {
-	*alerts = slices.DeleteFunc(*alerts, func(alert api.Alert) bool {
-		alertCategory, ok := (*alert.Labels)["alert_category"]
-		if ok && alertCategory != "maintenance" {
-			return false // don't remove alert with "alert_category" different from "maintenance"
+// redactedValue replaces the parts of label and annotation values that match a redaction pattern.
+const redactedValue = "[REDACTED]"
+
+// redactAlerts removes the labels and annotations of the given alerts according to the compiled redaction patterns, and masks
+// the parts of their values matching the value patterns. Alerts are left as they are if there are no patterns.
+func redactAlerts(alerts *[]api.Alert, patterns *config.RedactionPatterns) {
+	if patterns == nil {
+		return
+	}
+
+	for i := range *alerts {
+		alert := &(*alerts)[i]
+		if alert.Labels != nil {
+			for k := range *alert.Labels {
+				if (len(patterns.AllowedLabels) != 0 && !slices.Contains(patterns.AllowedLabels, k)) || matchesAny(patterns.Labels, k) {
+					delete(*alert.Labels, k)
+				}
+			}
+			redactValues(*alert.Labels, patterns.Values)
 		}
-		return true // remove alert with "alert_category" equal to "maintenance" or without "alert_category"
+
+		if alert.Annotations != nil {
+			for k := range *alert.Annotations {
+				if matchesAny(patterns.Annotations, k) {
+					delete(*alert.Annotations, k)
+				}
+			}
+			redactValues(*alert.Annotations, patterns.Values)
+		}
+	}
+}
+
+func matchesAny(patterns []*regexp.Regexp, s string) bool {
+	return slices.ContainsFunc(patterns, func(re *regexp.Regexp) bool {
+		return re.MatchString(s)
 	})
 }
 
+func redactValues(m map[string]string, patterns []*regexp.Regexp) {
+	for k, v := range m {
+		for _, re := range patterns {
+			v = re.ReplaceAllString(v, redactedValue)
+		}
+		m[k] = v
+	}
+}
+
 type alertManagerStatus struct {
 	Status string `json:"status"`
 }
@@ -149,6 +364,63 @@ func getAlertManagerStatus(serverURL string) (string, error) {
 	return info.Cluster.Status, nil
 }
 
+// getFiringAlertCounts gets the number of alerts of a tenant currently firing in alert manager, that is, active and neither
+// silenced nor inhibited, per alert definition UUID. Alerts of the tenant are told by the given tenant label.
+func getFiringAlertCounts(ctx context.Context, serverURL, label string, tenantID api.TenantID) (map[uuid.UUID]int, error) {
+	params := make(url.Values)
+	params.Add("active", "true")
+	params.Add("silenced", "false")
+	params.Add("inhibited", "false")
+	params.Add("filter", TenantFilter(label, tenantID))
+
+	u, err := url.Parse(fmt.Sprintf("%s/api/v2/alerts?%s", serverURL, params.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse alert manager url: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	correlation.SetHeader(req)
+
+	// Send request to alert manager: GET /api/v2/alerts
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Check if response code 200
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("alert manager returned status code: %v", resp.StatusCode)
+	}
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var alerts []api.Alert
+	if err := json.Unmarshal(b, &alerts); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	counts := make(map[uuid.UUID]int)
+	for _, alert := range alerts {
+		if alert.Annotations == nil {
+			continue
+		}
+		// Alerts are linked to the alert definition they originate from by the am_uuid annotation.
+		id, err := uuid.Parse((*alert.Annotations)["am_uuid"])
+		if err != nil {
+			continue
+		}
+		counts[id]++
+	}
+	return counts, nil
+}
+
 func isMimirRulerReachable(serverURL string) (bool, error) {
 	u, err := url.Parse(fmt.Sprintf("%s%s", serverURL, "/ready"))
 	if err != nil {
@@ -170,7 +442,18 @@ func isMimirRulerReachable(serverURL string) (bool, error) {
 }
 
 func skipAuth(c echo.Context) bool {
-	if c.Request().URL.Path == statusEndpoint && c.Request().Method == http.MethodGet {
+	path := c.Request().URL.Path
+	if (path == statusEndpoint || path == verifyEndpoint) && c.Request().Method == http.MethodGet {
+		return true
+	}
+	// The documentation of the API is public, it is only served if enabled.
+	if (path == openAPIEndpoint || path == docsEndpoint || strings.HasPrefix(path, docsEndpoint+"/")) && c.Request().Method == http.MethodGet {
+		return true
+	}
+	// Alertmanager does not hold a JWT, the Grafana OnCall and email relays authenticate its callbacks
+	// with their webhook authenticator instead.
+	if (strings.HasPrefix(path, onCallRelayEndpoint+"/") || strings.HasPrefix(path, emailRelayEndpoint+"/")) &&
+		c.Request().Method == http.MethodPost {
 		return true
 	}
 	return false
@@ -181,12 +464,9 @@ func skipLog(c echo.Context) bool {
 	path := c.Request().URL.Path
 	method := c.Request().Method
 
-	if (strings.HasPrefix(userAgent, "curl") || strings.HasPrefix(userAgent, "kube-probe")) &&
+	return (strings.HasPrefix(userAgent, "curl") || strings.HasPrefix(userAgent, "kube-probe")) &&
 		path == statusEndpoint &&
-		method == http.MethodGet {
-		return true
-	}
-	return false
+		method == http.MethodGet
 }
 
 func getAllowedEmailList(ctx echo.Context, m2m M2MConnection) (api.EmailRecipientList, error) {
@@ -222,12 +502,52 @@ func validateRecipients(recipients, allowed api.EmailRecipientList) error {
 	return nil
 }
 
-func parseAlertDefinitionValues(req api.PatchProjectAlertDefinitionJSONBody) (*models.DBAlertDefinitionValues, error) {
+// notAllowedRecipientDetails returns the details of the email recipients of the given field of a request which are not allowed,
+// with reasons in the given language.
+func notAllowedRecipientDetails(lang language.Tag, field string, recipients, allowed api.EmailRecipientList) *[]api.ErrorDetail {
+	var details []api.ErrorDetail
+	for _, recipient := range recipients {
+		if !slices.Contains(allowed, recipient) {
+			details = append(details, api.ErrorDetail{
+				Field:  field,
+				Reason: localize(lang, msgRecipientNotAllowed),
+				Value:  &recipient,
+			})
+		}
+	}
+	return &details
+}
+
+// outOfBoundsDetails returns the details of an error setting values of an alert definition out of their bounds, with reasons
+// in the given language, nil if the error does not tell which value is out of bounds.
+func outOfBoundsDetails(lang language.Tag, err error) *[]api.ErrorDetail {
+	var oob *db.OutOfBoundsError
+	if !errors.As(err, &oob) {
+		return nil
+	}
+
+	key := msgThresholdOutOfBounds
+	if oob.Value == "duration" {
+		key = msgDurationOutOfBounds
+	}
+	return &[]api.ErrorDetail{{
+		Field:  "values." + oob.Value,
+		Reason: localize(lang, key, oob.Min, oob.Max),
+		Min:    &oob.Min,
+		Max:    &oob.Max,
+	}}
+}
+
+// errInvalidDuration is returned when the duration value of an alert definition patch request is not a valid duration.
+var errInvalidDuration = errors.New("invalid duration")
+
+// ParseAlertDefinitionValues converts the values of an alert definition patch request into their database representation.
+func ParseAlertDefinitionValues(req api.PatchProjectAlertDefinitionJSONBody) (*models.DBAlertDefinitionValues, error) {
 	if req.Values == nil {
 		return nil, errors.New("request values is nil")
 	}
 
-	if req.Values.Duration == nil && req.Values.Threshold == nil && req.Values.Enabled == nil {
+	if req.Values.Duration == nil && req.Values.Threshold == nil && req.Values.Enabled == nil && req.Values.AutoTune == nil {
 		return nil, errors.New("request should contain at least one value to be set")
 	}
 
@@ -235,13 +555,13 @@ func parseAlertDefinitionValues(req api.PatchProjectAlertDefinitionJSONBody) (*m
 
 	if req.Values.Duration != nil {
 		durationStr := *req.Values.Duration
-		duration, err := time.ParseDuration(durationStr)
+		duration, err := ParseDuration(durationStr)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse duration value: %w", err)
+			return nil, fmt.Errorf("failed to parse duration value: %w: %w", errInvalidDuration, err)
 		}
 		durationSecs := int64(duration.Seconds())
-		if durationSecs == 0 {
-			return nil, fmt.Errorf("duration should be a non zero value in the order of seconds: %q", durationStr)
+		if durationSecs <= 0 {
+			return nil, fmt.Errorf("duration should be a positive value in the order of seconds: %q: %w", durationStr, errInvalidDuration)
 		}
 		values.Duration = &durationSecs
 	}
@@ -262,9 +582,125 @@ func parseAlertDefinitionValues(req api.PatchProjectAlertDefinitionJSONBody) (*m
 		values.Enabled = &enabled
 	}
 
+	if req.Values.AutoTune != nil {
+		autoTune, err := strconv.ParseBool(*req.Values.AutoTune)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse autoTune value: %w", err)
+		}
+		values.AutoTune = &autoTune
+	}
+
 	return &values, nil
 }
 
+// ParseReceiverValues converts an alert receiver patch request into the database representation of the receiver values. Email
+// recipients are not checked against the allowed ones.
+func ParseReceiverValues(req api.PatchProjectAlertReceiverJSONBody) (models.DBReceiverValues, error) {
+	emailRecipients, err := parseEmailRecipients(req.EmailConfig.To.Enabled)
+	if err != nil {
+		return models.DBReceiverValues{}, fmt.Errorf("failed to parse email recipients: %w", err)
+	}
+
+	values := models.DBReceiverValues{
+		Recipients: emailRecipients,
+	}
+
+	if req.MinSeverity != nil {
+		minSeverity, err := parseReceiverSeverity(*req.MinSeverity)
+		if err != nil {
+			return models.DBReceiverValues{}, fmt.Errorf("failed to parse minimum severity: %w", err)
+		}
+		values.MinSeverity = &minSeverity
+	}
+
+	if req.QuietHours != nil {
+		quietHours, err := parseQuietHours(*req.QuietHours)
+		if err != nil {
+			return models.DBReceiverValues{}, fmt.Errorf("failed to parse quiet hours: %w", err)
+		}
+		values.QuietHours = &quietHours
+	}
+
+	if req.OnCall != nil {
+		routingKey, err := parseOnCallConfig(*req.OnCall)
+		if err != nil {
+			return models.DBReceiverValues{}, fmt.Errorf("failed to parse Grafana OnCall configuration: %w", err)
+		}
+		values.OnCallRoutingKey = &routingKey
+	}
+
+	if req.Language != nil {
+		if *req.Language != "" && !languageRegex.MatchString(*req.Language) {
+			return models.DBReceiverValues{}, fmt.Errorf("invalid email language: %q", *req.Language)
+		}
+		values.Language = req.Language
+	}
+
+	if req.Enabled != nil {
+		disabled := !*req.Enabled
+		values.Disabled = &disabled
+	}
+
+	if req.Matchers != nil {
+		matchers, err := parseReceiverMatchers(*req.Matchers)
+		if err != nil {
+			return models.DBReceiverValues{}, fmt.Errorf("failed to parse matchers: %w", err)
+		}
+		values.Matchers = &matchers
+	}
+
+	if req.EmailConfig.From != nil {
+		firstName, lastName, email, err := GetEmailSender(*req.EmailConfig.From)
+		if err != nil {
+			return models.DBReceiverValues{}, fmt.Errorf("failed to parse email sender: %w", err)
+		}
+		values.Sender = &models.EmailAddress{FirstName: firstName, LastName: lastName, Email: email}
+	}
+
+	if req.EmailConfig.MailServer != nil {
+		if err := network.ValidateHostPort(*req.EmailConfig.MailServer); err != nil {
+			return models.DBReceiverValues{}, fmt.Errorf("invalid mail server %q: %w", *req.EmailConfig.MailServer, err)
+		}
+		values.MailServer = req.EmailConfig.MailServer
+	}
+
+	return values, nil
+}
+
+// projectReceiver returns the next version of the given receiver, with the given values applied.
+func projectReceiver(recv models.DBReceiver, values models.DBReceiverValues) models.DBReceiver {
+	recv.Version++
+	recv.To = make([]string, len(values.Recipients))
+	for i, r := range values.Recipients {
+		recv.To[i] = r.String()
+	}
+	if values.MinSeverity != nil {
+		recv.MinSeverity = *values.MinSeverity
+	}
+	if values.QuietHours != nil {
+		recv.QuietHours = *values.QuietHours
+	}
+	if values.OnCallRoutingKey != nil {
+		recv.OnCallRoutingKey = *values.OnCallRoutingKey
+	}
+	if values.Language != nil {
+		recv.Language = *values.Language
+	}
+	if values.Sender != nil {
+		recv.From = values.Sender.String()
+	}
+	if values.MailServer != nil {
+		recv.MailServer = *values.MailServer
+	}
+	if values.Disabled != nil {
+		recv.Disabled = *values.Disabled
+	}
+	if values.Matchers != nil {
+		recv.Matchers = *values.Matchers
+	}
+	return recv
+}
+
 func parseEmailRecipients(recipientList []string) ([]models.EmailAddress, error) {
 	res := make([]models.EmailAddress, 0, len(recipientList))
 	emailMap := make(map[string]struct{})
@@ -291,6 +727,162 @@ func parseEmailRecipients(recipientList []string) ([]models.EmailAddress, error)
 	return res, nil
 }
 
+// parseReceiverSeverity converts the API representation of a receiver minimum severity into its database representation.
+func parseReceiverSeverity(severity api.ReceiverSeverity) (models.ReceiverSeverity, error) {
+	if severity == api.None {
+		return models.SeverityNone, nil
+	}
+
+	s := models.ReceiverSeverity(severity)
+	if err := s.Validate(); err != nil {
+		return "", err
+	}
+	return s, nil
+}
+
+// receiverSeverityToAPI returns the API representation of a receiver minimum severity. It returns nil if no minimum
+// severity is set.
+func receiverSeverityToAPI(severity models.ReceiverSeverity) *api.ReceiverSeverity {
+	if severity == models.SeverityNone {
+		return nil
+	}
+
+	s := api.ReceiverSeverity(severity)
+	return &s
+}
+
+// parseQuietHours converts the API representation of receiver quiet hours into its database representation. Disabled quiet
+// hours are represented by empty values.
+func parseQuietHours(quietHours api.QuietHours) (models.QuietHours, error) {
+	if !quietHours.Enabled {
+		return models.QuietHours{}, nil
+	}
+
+	if quietHours.Start == nil || quietHours.End == nil {
+		return models.QuietHours{}, errors.New("quiet hours start and end times are required when enabled")
+	}
+
+	res := models.QuietHours{
+		Start: *quietHours.Start,
+		End:   *quietHours.End,
+	}
+	if quietHours.Location != nil {
+		res.Location = *quietHours.Location
+	}
+
+	if err := res.Validate(); err != nil {
+		return models.QuietHours{}, err
+	}
+	return res, nil
+}
+
+// parseReceiverMatchers validates the label matchers of a receiver and returns them with their values quoted alike, so that
+// they are rendered and compared consistently. Values must not hold control characters, and the ones of regex matchers must
+// be valid regular expressions.
+func parseReceiverMatchers(matchers []string) ([]string, error) {
+	if len(matchers) > maxReceiverMatchers {
+		return nil, fmt.Errorf("%d matchers exceed the limit of %d", len(matchers), maxReceiverMatchers)
+	}
+
+	res := make([]string, 0, len(matchers))
+	for _, matcher := range matchers {
+		m := receiverMatcherRegex.FindStringSubmatch(strings.TrimSpace(matcher))
+		if m == nil {
+			return nil, fmt.Errorf("invalid matcher %q, expected a label name, an operator and a quoted value", matcher)
+		}
+		if slices.Contains(reservedMatcherLabels, m[1]) {
+			return nil, fmt.Errorf("label %q of matcher %q is reserved", m[1], matcher)
+		}
+
+		value, err := strconv.Unquote(m[3])
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of matcher %q: %w", matcher, err)
+		}
+		if strings.ContainsFunc(value, unicode.IsControl) {
+			return nil, fmt.Errorf("value of matcher %q holds control characters", matcher)
+		}
+		if m[2] == "=~" || m[2] == "!~" {
+			if _, err := regexp.Compile("^(?:" + value + ")$"); err != nil {
+				return nil, fmt.Errorf("invalid regular expression of matcher %q: %w", matcher, err)
+			}
+		}
+
+		matcher = m[1] + m[2] + strconv.Quote(value)
+		if slices.Contains(res, matcher) {
+			return nil, fmt.Errorf("duplicate matcher %q", matcher)
+		}
+		res = append(res, matcher)
+	}
+	return res, nil
+}
+
+// matchersToAPI returns the API representation of the label matchers of a receiver. It returns nil if there are none.
+func matchersToAPI(matchers []string) *api.ReceiverMatchers {
+	if len(matchers) == 0 {
+		return nil
+	}
+	return &matchers
+}
+
+// quietHoursToAPI returns the API representation of receiver quiet hours. It returns nil if no quiet hours are set.
+func quietHoursToAPI(quietHours models.QuietHours) *api.QuietHours {
+	if !quietHours.IsSet() {
+		return nil
+	}
+
+	return &api.QuietHours{
+		Enabled:  true,
+		Start:    &quietHours.Start,
+		End:      &quietHours.End,
+		Location: &quietHours.Location,
+	}
+}
+
+// parseOnCallConfig returns the Grafana OnCall routing key of the given configuration. An empty routing key stops relaying
+// the alerts of a receiver to Grafana OnCall.
+func parseOnCallConfig(onCall api.OnCallConfig) (string, error) {
+	if onCall.RoutingKey == nil {
+		return "", nil
+	}
+
+	if !routingKeyRegex.MatchString(*onCall.RoutingKey) {
+		return "", fmt.Errorf("invalid Grafana OnCall routing key: %q", *onCall.RoutingKey)
+	}
+	return *onCall.RoutingKey, nil
+}
+
+// onCallToAPI returns the API representation of the Grafana OnCall configuration of a receiver. It returns nil if no
+// routing key is set.
+func onCallToAPI(routingKey string) *api.OnCallConfig {
+	if routingKey == "" {
+		return nil
+	}
+	return &api.OnCallConfig{RoutingKey: &routingKey}
+}
+
+// languageToAPI returns the API representation of the email language of a receiver. It returns nil if no language is set.
+func languageToAPI(language string) *string {
+	if language == "" {
+		return nil
+	}
+	return &language
+}
+
+// enabledToAPI returns the API representation of whether a receiver is enabled, which is always set.
+func enabledToAPI(disabled bool) *bool {
+	enabled := !disabled
+	return &enabled
+}
+
+// unverifiedRecipientsToAPI converts the recipients of a receiver pending verification of their email address to their API
+// representation, nil if there are none.
+func unverifiedRecipientsToAPI(unverified []string) *api.EmailRecipientList {
+	if len(unverified) == 0 {
+		return nil
+	}
+	return &unverified
+}
+
 func logWarn(ctx echo.Context, message string) {
 	slog.LogAttrs(ctx.Request().Context(), slog.LevelWarn, message,
 		slog.String("path", ctx.Path()),
@@ -298,9 +890,16 @@ func logWarn(ctx echo.Context, message string) {
 	)
 }
 
+// setCorrelationID sets the ID of a request as the correlation ID of its context, so that it is propagated to the calls made
+// to downstream services and to the tasks created while handling the request.
+func setCorrelationID(ctx echo.Context, id string) {
+	ctx.SetRequest(ctx.Request().WithContext(correlation.NewContext(ctx.Request().Context(), id)))
+}
+
 func logError(ctx echo.Context, message string, err error) {
 	slog.LogAttrs(ctx.Request().Context(), slog.LevelError, message,
 		slog.String("path", ctx.Path()),
+		slog.String("request_id", correlation.FromContext(ctx.Request().Context())),
 		slog.String("error", err.Error()),
 		slog.String("component", "alerting-monitor"),
 	)
@@ -330,6 +929,80 @@ func renderTemplate(values models.DBAlertDefinitionValues, template string) (api
 	return tmpl, nil
 }
 
+// mergeAlertDefinitionValues returns the current values of an alert definition, overridden by the values set by a patch request.
+func mergeAlertDefinitionValues(current, patch models.DBAlertDefinitionValues) models.DBAlertDefinitionValues {
+	if patch.Duration != nil {
+		current.Duration = patch.Duration
+	}
+	if patch.Threshold != nil {
+		current.Threshold = patch.Threshold
+	}
+	if patch.Enabled != nil {
+		current.Enabled = patch.Enabled
+	}
+	if patch.AutoTune != nil {
+		current.AutoTune = patch.AutoTune
+	}
+	return current
+}
+
+// checkExpressionCost checks the estimated cost of the expression of an alert definition template, rendered with the given values,
+// against the given limits. The expression of a disabled alert definition is not checked, since it is not evaluated.
+func checkExpressionCost(values models.DBAlertDefinitionValues, template string, limits config.ExpressionCostLimits) error {
+	if values.Enabled != nil && !*values.Enabled {
+		return nil
+	}
+
+	tmpl, err := renderTemplate(values, template)
+	if err != nil {
+		return err
+	}
+
+	cost, err := rules.EstimateCost(*tmpl.Expr)
+	if err != nil {
+		return err
+	}
+	return cost.Check(limits)
+}
+
+// formatAlertDefinitionValues returns the given values of an alert definition in their canonical string form, as served by the
+// values of alert definitions. Values which are not set are left out.
+func formatAlertDefinitionValues(values models.DBAlertDefinitionValues) map[string]string {
+	formatted := make(map[string]string)
+	if values.Duration != nil {
+		formatted["duration"] = FormatDuration(time.Duration(*values.Duration) * time.Second)
+	}
+	if values.Threshold != nil {
+		formatted["threshold"] = strconv.FormatInt(*values.Threshold, 10)
+	}
+	if values.Enabled != nil {
+		formatted["enabled"] = strconv.FormatBool(*values.Enabled)
+	}
+	if values.AutoTune != nil {
+		formatted["autoTune"] = strconv.FormatBool(*values.AutoTune)
+	}
+	return formatted
+}
+
+// ParseDuration parses a duration given either in any format accepted by time.ParseDuration, e.g. "1m30s" or "1.5m", or as a
+// plain number of seconds, e.g. "90". Surrounding whitespace is ignored, and the duration is truncated to whole seconds.
+func ParseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if secs, err := strconv.ParseInt(s, 10, 64); err == nil {
+		if secs > int64(math.MaxInt64/time.Second) || secs < int64(math.MinInt64/time.Second) {
+			return 0, fmt.Errorf("duration %q is out of range", s)
+		}
+		return time.Duration(secs) * time.Second, nil
+	}
+
+	dur, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	return dur.Truncate(time.Second), nil
+}
+
+// FormatDuration returns the canonical form of a duration in whole hours, minutes and seconds, e.g. "1m30s".
 func FormatDuration(dur time.Duration) string {
 	hours := dur / time.Hour
 	minutes := (dur % time.Hour) / time.Minute
@@ -370,3 +1043,231 @@ func GetEmailSender(from string) (firstName, lastName, email string, err error)
 
 	return "", "", "", fmt.Errorf("invalid format for email 'from' value: %q", from)
 }
+
+// parseListOptions validates the pagination and sorting query parameters and converts them to database list options.
+func parseListOptions(
+	limit *api.LimitQueryParam, offset *api.OffsetQueryParam, sortBy *api.SortByQueryParam, order *api.OrderQueryParam,
+) (db.ListOptions, error) {
+	var opts db.ListOptions
+	if limit != nil {
+		if *limit < 1 {
+			return db.ListOptions{}, fmt.Errorf("limit must be greater than zero, got %d", *limit)
+		}
+		opts.Limit = *limit
+	}
+	if offset != nil {
+		if *offset < 0 {
+			return db.ListOptions{}, fmt.Errorf("offset must not be negative, got %d", *offset)
+		}
+		opts.Offset = *offset
+	}
+	if sortBy != nil {
+		switch *sortBy {
+		case api.Name:
+			opts.SortBy = db.SortByName
+		case api.Severity:
+			opts.SortBy = db.SortBySeverity
+		case api.State:
+			opts.SortBy = db.SortByState
+		case api.UpdatedAt:
+			opts.SortBy = db.SortByUpdatedAt
+		default:
+			return db.ListOptions{}, fmt.Errorf("unsupported sort field %q", *sortBy)
+		}
+	}
+	if order != nil {
+		switch *order {
+		case api.Asc:
+			opts.Order = db.SortAscending
+		case api.Desc:
+			opts.Order = db.SortDescending
+		default:
+			return db.ListOptions{}, fmt.Errorf("unsupported sort order %q", *order)
+		}
+	}
+	return opts, nil
+}
+
+var (
+	// alertDefinitionFields are the alert definition fields that can be selected with the fields query parameter.
+	alertDefinitionFields = []string{
+		"appliedAt", "createdAt", "durationSeconds", "enabled", "evaluationHealth", "firingCount", "id", "name", "state",
+		"thresholdAutoTuned", "thresholdValue", "updatedAt", "values", "version",
+	}
+	// receiverFields are the receiver fields that can be selected with the fields query parameter.
+	receiverFields = []string{"appliedAt", "createdAt", "emailConfig", "enabled", "id", "language", "matchers", "minSeverity", "onCall", "quietHours", "state", "updatedAt", "version"}
+)
+
+// fieldSet is the set of fields selected with the fields query parameter. A nil set selects all fields.
+type fieldSet map[string]bool
+
+func (f fieldSet) has(field string) bool {
+	return f == nil || f[field]
+}
+
+// parseFields validates the fields query parameter against the allowed fields and converts it to a set.
+func parseFields(fields *api.FieldsQueryParam, allowed []string) (fieldSet, error) {
+	if fields == nil {
+		return nil, nil
+	}
+
+	set := make(fieldSet, len(*fields))
+	for _, field := range *fields {
+		field = strings.TrimSpace(field)
+		if !slices.Contains(allowed, field) {
+			return nil, fmt.Errorf("unknown field %q, allowed fields are: %s", field, strings.Join(allowed, ", "))
+		}
+		set[field] = true
+	}
+	return set, nil
+}
+
+// selectAlertDefinitionFields clears the fields of an alert definition that are not selected.
+func selectAlertDefinitionFields(def api.AlertDefinition, fields fieldSet) api.AlertDefinition {
+	if !fields.has("appliedAt") {
+		def.AppliedAt = nil
+	}
+	if !fields.has("createdAt") {
+		def.CreatedAt = nil
+	}
+	if !fields.has("durationSeconds") {
+		def.DurationSeconds = nil
+	}
+	if !fields.has("enabled") {
+		def.Enabled = nil
+	}
+	if !fields.has("evaluationHealth") {
+		def.EvaluationHealth = nil
+	}
+	if !fields.has("firingCount") {
+		def.FiringCount = nil
+	}
+	if !fields.has("id") {
+		def.Id = nil
+	}
+	if !fields.has("name") {
+		def.Name = nil
+	}
+	if !fields.has("state") {
+		def.State = nil
+	}
+	if !fields.has("thresholdAutoTuned") {
+		def.ThresholdAutoTuned = nil
+	}
+	if !fields.has("thresholdValue") {
+		def.ThresholdValue = nil
+	}
+	if !fields.has("updatedAt") {
+		def.UpdatedAt = nil
+	}
+	if !fields.has("values") {
+		def.Values = nil
+	}
+	if !fields.has("version") {
+		def.Version = nil
+	}
+	return def
+}
+
+// selectReceiverFields clears the fields of a receiver that are not selected.
+func selectReceiverFields(recv api.Receiver, fields fieldSet) api.Receiver {
+	if !fields.has("appliedAt") {
+		recv.AppliedAt = nil
+	}
+	if !fields.has("createdAt") {
+		recv.CreatedAt = nil
+	}
+	if !fields.has("emailConfig") {
+		recv.EmailConfig = nil
+	}
+	if !fields.has("enabled") {
+		recv.Enabled = nil
+	}
+	if !fields.has("id") {
+		recv.Id = nil
+	}
+	if !fields.has("language") {
+		recv.Language = nil
+	}
+	if !fields.has("matchers") {
+		recv.Matchers = nil
+	}
+	if !fields.has("minSeverity") {
+		recv.MinSeverity = nil
+	}
+	if !fields.has("onCall") {
+		recv.OnCall = nil
+	}
+	if !fields.has("quietHours") {
+		recv.QuietHours = nil
+	}
+	if !fields.has("state") {
+		recv.State = nil
+	}
+	if !fields.has("updatedAt") {
+		recv.UpdatedAt = nil
+	}
+	if !fields.has("version") {
+		recv.Version = nil
+	}
+	return recv
+}
+
+// alertDefinitionToAPI converts the given version of an alert definition to its API representation, without the firing count
+// and evaluation health, which are not stored along with it.
+func alertDefinitionToAPI(ad *models.DBAlertDefinition) api.AlertDefinition {
+	state := api.StateDefinition(ad.State)
+	values := formatAlertDefinitionValues(ad.Values)
+	version := int(ad.Version)
+	return api.AlertDefinition{
+		Id:                 &ad.ID,
+		Name:               &ad.Name,
+		State:              &state,
+		Values:             &values,
+		DurationSeconds:    ad.Values.Duration,
+		ThresholdValue:     ad.Values.Threshold,
+		Enabled:            ad.Values.Enabled,
+		Version:            &version,
+		CreatedAt:          timeToAPI(ad.CreatedAt),
+		UpdatedAt:          timeToAPI(ad.UpdatedAt),
+		AppliedAt:          timePtrToAPI(ad.AppliedAt),
+		ThresholdAutoTuned: &ad.ThresholdAutoTuned,
+	}
+}
+
+// timeToAPI returns a pointer to the given time in UTC, or nil if the time is not set.
+func timeToAPI(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	t = t.UTC()
+	return &t
+}
+
+// timePtrToAPI returns a pointer to the given time in UTC, or nil if the time is nil or not set.
+func timePtrToAPI(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	return timeToAPI(*t)
+}
+
+// evaluationHealthToAPI converts the evaluation health of an alert definition to its API representation. The rule of the alert
+// definition is reported as slow if its evaluations took longer than the given threshold on average, unless the threshold is zero.
+func evaluationHealthToAPI(health models.RuleEvaluationHealth, slowThreshold time.Duration) *api.EvaluationHealth {
+	avg := float32(health.AvgDurationSeconds)
+	maxDuration := float32(health.MaxDurationSeconds)
+	slow := slowThreshold > 0 && health.AvgDurationSeconds > slowThreshold.Seconds()
+	res := &api.EvaluationHealth{
+		Evaluations:     &health.Evaluations,
+		Failures:        &health.Failures,
+		AverageDuration: &avg,
+		MaxDuration:     &maxDuration,
+		LastEvaluatedAt: timeToAPI(health.LastEvaluationDate),
+		Slow:            &slow,
+	}
+	if health.LastError != "" {
+		res.LastError = &health.LastError
+	}
+	return res
+}
@@ -11,13 +11,18 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
 	"github.com/stretchr/testify/require"
 
 	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/correlation"
 	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
 )
 
@@ -120,8 +125,9 @@ func TestFilterAnnotations(t *testing.T) {
 	err = json.Unmarshal([]byte(filterAnnotationsExpected), &unmarshalledExpected.Alerts)
 	require.NoError(t, err, "Error unmarshalling expected json")
 
-	err = filterAnnotations(unmarshalledInput.Alerts)
-	require.NoError(t, err, "Error filtering annotations")
+	for i := range *unmarshalledInput.Alerts {
+		require.Nil(t, filterAlertAnnotations(&(*unmarshalledInput.Alerts)[i]), "Malformed am_uuid annotation reported")
+	}
 	require.Equal(t, unmarshalledExpected, unmarshalledInput, "Output data is different from expected")
 }
 
@@ -379,9 +385,44 @@ func TestParseEmailRecipients(t *testing.T) {
 	}, nil, errors.New("duplicate email recipient"))
 }
 
+func TestParseReceiverMatchers(t *testing.T) {
+	f := func(in []string, exp []string, expErr error) {
+		t.Helper()
+
+		out, err := parseReceiverMatchers(in)
+		require.Equal(t, exp, out)
+		if expErr != nil {
+			require.ErrorContains(t, err, expErr.Error())
+		} else {
+			require.NoError(t, err)
+		}
+	}
+
+	// Positive test cases.
+	f([]string{}, []string{}, nil)
+	f([]string{`cluster_name="edge-1"`, ` host_uuid=~"a.*|b.*" `, `deployment_id!="\x61pp"`}, []string{
+		`cluster_name="edge-1"`,
+		`host_uuid=~"a.*|b.*"`,
+		`deployment_id!="app"`,
+	}, nil)
+
+	// Invalid matchers.
+	f([]string{"cluster_name=edge-1"}, nil, errors.New("invalid matcher"))
+	f([]string{`cluster-name="edge-1"`}, nil, errors.New("invalid matcher"))
+	f([]string{`severity="critical"`}, nil, errors.New(`label "severity" of matcher`))
+	f([]string{`alert_category!~"health"`}, nil, errors.New(`label "alert_category" of matcher`))
+	f([]string{`cluster_name="edge"1"`}, nil, errors.New("invalid value of matcher"))
+	f([]string{`cluster_name="edge\n1"`}, nil, errors.New("holds control characters"))
+	f([]string{`cluster_name=~"edge-(1"`}, nil, errors.New("invalid regular expression"))
+	f([]string{`cluster_name="edge-1"`, `cluster_name = "edge-1"`}, nil, errors.New("invalid matcher"))
+	f([]string{`cluster_name="edge-1"`, `cluster_name="\x65dge-1"`}, nil, errors.New("duplicate matcher"))
+	f(slices.Repeat([]string{`cluster_name="edge-1"`}, maxReceiverMatchers+1), nil, errors.New("9 matchers exceed the limit of 8"))
+}
+
 func TestSkipAuth(t *testing.T) {
 	testCases := []struct {
 		name     string
+		method   string
 		endpoint string
 		expSkip  bool
 	}{
@@ -390,11 +431,43 @@ func TestSkipAuth(t *testing.T) {
 			endpoint: "/api/v1/status",
 			expSkip:  true,
 		},
+		{
+			name:     "Metrics",
+			endpoint: "/metrics",
+			expSkip:  false,
+		},
 		{
 			name:     "False",
 			endpoint: "/api/v1/service",
 			expSkip:  false,
 		},
+		{
+			name:     "Grafana OnCall relay",
+			method:   http.MethodPost,
+			endpoint: "/api/v1/oncall/relay/tenant/2e2ccb6c-1c83-4e5d-9b2f-8f0e5c3a1d44",
+			expSkip:  true,
+		},
+		{
+			name:     "Grafana OnCall relay with GET method",
+			endpoint: "/api/v1/oncall/relay/tenant/2e2ccb6c-1c83-4e5d-9b2f-8f0e5c3a1d44",
+			expSkip:  false,
+		},
+		{
+			name:     "Email relay",
+			method:   http.MethodPost,
+			endpoint: "/api/v1/email/relay/tenant/2e2ccb6c-1c83-4e5d-9b2f-8f0e5c3a1d44",
+			expSkip:  true,
+		},
+		{
+			name:     "OpenAPI document",
+			endpoint: "/api/v1/openapi.json",
+			expSkip:  true,
+		},
+		{
+			name:     "API documentation",
+			endpoint: "/api/v1/docs/swagger-initializer.js",
+			expSkip:  true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -402,8 +475,13 @@ func TestSkipAuth(t *testing.T) {
 			// Create new Echo server
 			e := echo.New()
 
+			method := tc.method
+			if method == "" {
+				method = http.MethodGet
+			}
+
 			// Create request
-			r, err := http.NewRequest(http.MethodGet, tc.endpoint, nil)
+			r, err := http.NewRequest(method, tc.endpoint, nil)
 			require.NoError(t, err)
 
 			// Create request context
@@ -413,7 +491,7 @@ func TestSkipAuth(t *testing.T) {
 	}
 }
 
-func TestFilterOutMaintenanceAlerts(t *testing.T) {
+func TestIsMaintenanceAlert(t *testing.T) {
 	unmarshalledInput := new(api.AlertList)
 	unmarshalledExpected := new(api.AlertList)
 
@@ -423,10 +501,185 @@ func TestFilterOutMaintenanceAlerts(t *testing.T) {
 	err = json.Unmarshal([]byte(filterWithMaintenanceAlertExpected), &unmarshalledExpected.Alerts)
 	require.NoError(t, err, "Error unmarshalling expected json")
 
-	filterOutMaintenanceAlerts(unmarshalledInput.Alerts)
+	*unmarshalledInput.Alerts = slices.DeleteFunc(*unmarshalledInput.Alerts, isMaintenanceAlert)
 	require.Equal(t, unmarshalledExpected, unmarshalledInput, "Output data is different from expected")
 }
 
+func TestDecodeAlerts(t *testing.T) {
+	t.Run("Alerts are filtered as they are decoded", func(t *testing.T) {
+		alerts, warnings, err := decodeAlerts(strings.NewReader(filterWithMaintenanceAlertTestData))
+		require.NoError(t, err)
+		require.Empty(t, warnings)
+
+		var expected []api.Alert
+		require.NoError(t, json.Unmarshal([]byte(filterWithMaintenanceAlertExpected), &expected))
+		require.Equal(t, expected, alerts)
+	})
+
+	t.Run("Response is not an array of alerts", func(t *testing.T) {
+		for _, body := range []string{`{"alerts":[]}`, `[{"fingerprint":"0c8d24dab761f647"`, "bad response"} {
+			_, _, err := decodeAlerts(strings.NewReader(body))
+			require.Error(t, err, body)
+		}
+	})
+}
+
+// BenchmarkDecodeAlerts measures decoding an Alert Manager response of 50000 alerts, half of them maintenance alerts, to keep
+// the memory used by large alert sets in check.
+func BenchmarkDecodeAlerts(b *testing.B) {
+	const alerts = 50000
+
+	var body strings.Builder
+	body.WriteString("[")
+	for i := range alerts {
+		if i > 0 {
+			body.WriteString(",")
+		}
+		category := "performance"
+		if i%2 == 0 {
+			category = "maintenance"
+		}
+		fmt.Fprintf(&body, `{"annotations":{"am_uuid":"c6b2a291-a9a2-49d2-930f-f865457b1aa8","description":"CPU usage is high"},`+
+			`"endsAt":"2024-01-23T16:13:45.535+01:00","fingerprint":"%016x","startsAt":"2024-01-23T16:08:45.535+01:00",`+
+			`"status":{"inhibitedBy":[],"silencedBy":[],"state":"active"},"updatedAt":"2024-01-23T16:08:45.535+01:00",`+
+			`"labels":{"alertname":"HostCPUUsageHigh","alert_category":%q,"host_uuid":"host-%d","projectId":"tenant"}}`,
+			i, category, i)
+	}
+	body.WriteString("]")
+	response := body.String()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		decoded, _, err := decodeAlerts(strings.NewReader(response))
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(decoded) != alerts/2 {
+			b.Fatalf("decoded %d alerts, expected %d", len(decoded), alerts/2)
+		}
+	}
+}
+
+func TestGroupAlertsByResource(t *testing.T) {
+	alert := func(labels map[string]string) api.Alert {
+		return api.Alert{Labels: &labels}
+	}
+	severity := func(s string) *string {
+		return &s
+	}
+
+	alerts := []api.Alert{
+		alert(map[string]string{"host_uuid": "host-a", "severity": "warning"}),
+		alert(map[string]string{"host_uuid": "host-b", "severity": "info"}),
+		alert(map[string]string{"host_uuid": "host-b", "severity": "info"}),
+		alert(map[string]string{"host_uuid": "host-c", "severity": "critical"}),
+		alert(map[string]string{"host_uuid": "host-c", "severity": "unknown"}),
+		alert(map[string]string{"host_uuid": "host-d"}),
+		alert(map[string]string{"host_uuid": "host-e", "severity": "info"}),
+		alert(map[string]string{"cluster_name": "cluster", "severity": "critical"}),
+		{},
+	}
+
+	require.Equal(t, []api.AlertResource{
+		{Resource: "host-c", AlertCount: 2, HighestSeverity: severity("critical")},
+		{Resource: "host-a", AlertCount: 1, HighestSeverity: severity("warning")},
+		{Resource: "host-b", AlertCount: 2, HighestSeverity: severity("info")},
+		{Resource: "host-e", AlertCount: 1, HighestSeverity: severity("info")},
+		{Resource: "host-d", AlertCount: 1},
+	}, groupAlertsByResource(alerts, "host_uuid"))
+
+	require.Empty(t, groupAlertsByResource(alerts, "deployment_id"))
+}
+
+func TestRedactAlerts(t *testing.T) {
+	newAlerts := func() *[]api.Alert {
+		return &[]api.Alert{
+			{
+				Labels: &map[string]string{
+					"alertname":     "HostCPUUsage",
+					"host_uuid":     "93bf6804-52a3-4ba1-a919-c7ef65a9cdef",
+					"internal_node": "node-1",
+					"instance":      "10.0.12.5:9100",
+				},
+				Annotations: &map[string]string{
+					"description": "CPU usage of host at 10.0.12.5 is high",
+					"runbook_url": "https://internal.wiki/runbook",
+				},
+			},
+		}
+	}
+
+	compile := func(conf config.RedactionConfig) *config.RedactionPatterns {
+		patterns, err := conf.Compile()
+		require.NoError(t, err)
+		return patterns
+	}
+
+	t.Run("NoRedaction", func(t *testing.T) {
+		alerts := newAlerts()
+		redactAlerts(alerts, nil)
+		require.Equal(t, newAlerts(), alerts)
+
+		redactAlerts(alerts, compile(config.RedactionConfig{}))
+		require.Equal(t, newAlerts(), alerts)
+	})
+
+	t.Run("RedactNamesAndValues", func(t *testing.T) {
+		alerts := newAlerts()
+		redactAlerts(alerts, compile(config.RedactionConfig{
+			Labels:      []string{"internal_.*"},
+			Annotations: []string{"runbook_url"},
+			Values:      []string{`\b10\.\d{1,3}\.\d{1,3}\.\d{1,3}\b`},
+		}))
+		require.Equal(t, &[]api.Alert{
+			{
+				Labels: &map[string]string{
+					"alertname": "HostCPUUsage",
+					"host_uuid": "93bf6804-52a3-4ba1-a919-c7ef65a9cdef",
+					"instance":  "[REDACTED]:9100",
+				},
+				Annotations: &map[string]string{
+					"description": "CPU usage of host at [REDACTED] is high",
+				},
+			},
+		}, alerts)
+	})
+
+	t.Run("AllowedLabels", func(t *testing.T) {
+		alerts := newAlerts()
+		redactAlerts(alerts, compile(config.RedactionConfig{
+			AllowedLabels: []string{"alertname", "host_uuid"},
+		}))
+		require.Equal(t, map[string]string{
+			"alertname": "HostCPUUsage",
+			"host_uuid": "93bf6804-52a3-4ba1-a919-c7ef65a9cdef",
+		}, *(*alerts)[0].Labels)
+	})
+}
+
+func TestParseFields(t *testing.T) {
+	t.Run("AllFieldsWhenOmitted", func(t *testing.T) {
+		fields, err := parseFields(nil, receiverFields)
+		require.NoError(t, err)
+		require.Nil(t, fields)
+		require.True(t, fields.has("emailConfig"))
+	})
+
+	t.Run("SelectedFields", func(t *testing.T) {
+		fields, err := parseFields(&api.FieldsQueryParam{"id", " state"}, receiverFields)
+		require.NoError(t, err)
+		require.True(t, fields.has("id"))
+		require.True(t, fields.has("state"))
+		require.False(t, fields.has("emailConfig"))
+	})
+
+	t.Run("UnknownField", func(t *testing.T) {
+		_, err := parseFields(&api.FieldsQueryParam{"id", "template"}, alertDefinitionFields)
+		require.ErrorContains(t, err, `unknown field "template"`)
+	})
+}
+
 func TestParseAlertDefinitionValues(t *testing.T) {
 	testCases := []struct {
 		name      string
@@ -445,6 +698,7 @@ func TestParseAlertDefinitionValues(t *testing.T) {
 			name: "Request does not have any value to set",
 			request: api.PatchProjectAlertDefinitionJSONBody{
 				Values: &struct {
+					AutoTune  *string `json:"autoTune,omitempty"`
 					Duration  *string `json:"duration,omitempty"`
 					Enabled   *string `json:"enabled,omitempty"`
 					Threshold *string `json:"threshold,omitempty"`
@@ -460,11 +714,12 @@ func TestParseAlertDefinitionValues(t *testing.T) {
 			name: "Duration value of the request does not have a valid format",
 			request: api.PatchProjectAlertDefinitionJSONBody{
 				Values: &struct {
+					AutoTune  *string `json:"autoTune,omitempty"`
 					Duration  *string `json:"duration,omitempty"`
 					Enabled   *string `json:"enabled,omitempty"`
 					Threshold *string `json:"threshold,omitempty"`
 				}{
-					Duration:  stringPtr("12"),
+					Duration:  stringPtr("12 minutes"),
 					Enabled:   nil,
 					Threshold: nil,
 				},
@@ -475,6 +730,7 @@ func TestParseAlertDefinitionValues(t *testing.T) {
 			name: "Duration value of the request not in the order of seconds",
 			request: api.PatchProjectAlertDefinitionJSONBody{
 				Values: &struct {
+					AutoTune  *string `json:"autoTune,omitempty"`
 					Duration  *string `json:"duration,omitempty"`
 					Enabled   *string `json:"enabled,omitempty"`
 					Threshold *string `json:"threshold,omitempty"`
@@ -484,12 +740,13 @@ func TestParseAlertDefinitionValues(t *testing.T) {
 					Threshold: nil,
 				},
 			},
-			err: errors.New("duration should be a non zero value in the order of seconds"),
+			err: errors.New("duration should be a positive value in the order of seconds"),
 		},
 		{
 			name: "Duration value of the request is zero",
 			request: api.PatchProjectAlertDefinitionJSONBody{
 				Values: &struct {
+					AutoTune  *string `json:"autoTune,omitempty"`
 					Duration  *string `json:"duration,omitempty"`
 					Enabled   *string `json:"enabled,omitempty"`
 					Threshold *string `json:"threshold,omitempty"`
@@ -499,12 +756,13 @@ func TestParseAlertDefinitionValues(t *testing.T) {
 					Threshold: nil,
 				},
 			},
-			err: errors.New("duration should be a non zero value in the order of seconds"),
+			err: errors.New("duration should be a positive value in the order of seconds"),
 		},
 		{
 			name: "Threshold value of the request is non numeric",
 			request: api.PatchProjectAlertDefinitionJSONBody{
 				Values: &struct {
+					AutoTune  *string `json:"autoTune,omitempty"`
 					Duration  *string `json:"duration,omitempty"`
 					Enabled   *string `json:"enabled,omitempty"`
 					Threshold *string `json:"threshold,omitempty"`
@@ -520,6 +778,7 @@ func TestParseAlertDefinitionValues(t *testing.T) {
 			name: "Enabled value of the request is not a boolean",
 			request: api.PatchProjectAlertDefinitionJSONBody{
 				Values: &struct {
+					AutoTune  *string `json:"autoTune,omitempty"`
 					Duration  *string `json:"duration,omitempty"`
 					Enabled   *string `json:"enabled,omitempty"`
 					Threshold *string `json:"threshold,omitempty"`
@@ -531,10 +790,41 @@ func TestParseAlertDefinitionValues(t *testing.T) {
 			},
 			err: errors.New("failed to parse enabled value"),
 		},
+		{
+			name: "AutoTune value of the request is not a boolean",
+			request: api.PatchProjectAlertDefinitionJSONBody{
+				Values: &struct {
+					AutoTune  *string `json:"autoTune,omitempty"`
+					Duration  *string `json:"duration,omitempty"`
+					Enabled   *string `json:"enabled,omitempty"`
+					Threshold *string `json:"threshold,omitempty"`
+				}{
+					AutoTune: stringPtr("sometimes"),
+				},
+			},
+			err: errors.New("failed to parse autoTune value"),
+		},
+		{
+			name: "Succeeded to parse request auto-tune value",
+			request: api.PatchProjectAlertDefinitionJSONBody{
+				Values: &struct {
+					AutoTune  *string `json:"autoTune,omitempty"`
+					Duration  *string `json:"duration,omitempty"`
+					Enabled   *string `json:"enabled,omitempty"`
+					Threshold *string `json:"threshold,omitempty"`
+				}{
+					AutoTune: stringPtr("true"),
+				},
+			},
+			valuesExp: &models.DBAlertDefinitionValues{
+				AutoTune: boolPtr(true),
+			},
+		},
 		{
 			name: "Succeeded to parse request values",
 			request: api.PatchProjectAlertDefinitionJSONBody{
 				Values: &struct {
+					AutoTune  *string `json:"autoTune,omitempty"`
 					Duration  *string `json:"duration,omitempty"`
 					Enabled   *string `json:"enabled,omitempty"`
 					Threshold *string `json:"threshold,omitempty"`
@@ -554,7 +844,7 @@ func TestParseAlertDefinitionValues(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			valuesOut, err := parseAlertDefinitionValues(tc.request)
+			valuesOut, err := ParseAlertDefinitionValues(tc.request)
 			require.Equal(t, tc.valuesExp, valuesOut)
 			if tc.err != nil {
 				require.ErrorContains(t, err, tc.err.Error())
@@ -565,6 +855,36 @@ func TestParseAlertDefinitionValues(t *testing.T) {
 	}
 }
 
+func TestParseDuration(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected time.Duration
+		err      bool
+	}{
+		{input: "90", expected: 90 * time.Second},
+		{input: " 45 ", expected: 45 * time.Second},
+		{input: "1m30s", expected: 90 * time.Second},
+		{input: "1.5m", expected: 90 * time.Second},
+		{input: "1h", expected: time.Hour},
+		{input: "2500ms", expected: 2 * time.Second},
+		{input: "-30", expected: -30 * time.Second},
+		{input: "99999999999999", err: true},
+		{input: "2sec", err: true},
+		{input: "", err: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			out, err := ParseDuration(tc.input)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, out)
+		})
+	}
+}
+
 func TestFormatDuration(t *testing.T) {
 	testCases := []struct {
 		name     string
@@ -653,3 +973,33 @@ func TestValidateRecipients(t *testing.T) {
 		fmt.Errorf("email recipient is not allowed: %q", "foo1 bar <foo@bar.com>"),
 	)
 }
+
+func TestSetCorrelationID(t *testing.T) {
+	e := echo.New()
+	e.Use(middleware.RequestIDWithConfig(middleware.RequestIDConfig{
+		TargetHeader:     correlation.Header,
+		RequestIDHandler: setCorrelationID,
+	}))
+	e.GET("/", func(ctx echo.Context) error {
+		return ctx.String(http.StatusOK, correlation.FromContext(ctx.Request().Context()))
+	})
+
+	t.Run("PropagateRequestID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(correlation.Header, "request-id")
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		require.Equal(t, "request-id", rec.Body.String())
+		require.Equal(t, "request-id", rec.Header().Get(correlation.Header))
+	})
+
+	t.Run("GenerateRequestID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		require.NotEmpty(t, rec.Body.String())
+		require.Equal(t, rec.Body.String(), rec.Header().Get(correlation.Header))
+	})
+}
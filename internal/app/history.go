@@ -0,0 +1,175 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	db "github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+const (
+	// historyEndpoint is the prefix of the endpoint serving the history of the changes applied to alert definitions and
+	// receivers. It is under /debug, so that it is only granted to administrators.
+	historyEndpoint = "/debug/history"
+
+	defaultHistoryLimit = 50
+	maxHistoryLimit     = 500
+
+	defaultTrendDays = 90
+	maxTrendDays     = 3660
+)
+
+// appliedChange is a completed task as served by the history endpoint. Durations are in seconds.
+type appliedChange struct {
+	Type           models.TaskType  `json:"type"`
+	UUID           uuid.UUID        `json:"uuid"`
+	Version        int64            `json:"version"`
+	State          models.TaskState `json:"state"`
+	Executor       uuid.UUID        `json:"executor"`
+	CorrelationID  string           `json:"correlationId,omitempty"`
+	RetryCount     int64            `json:"retryCount"`
+	CreationDate   time.Time        `json:"creationDate"`
+	CompletionDate time.Time        `json:"completionDate"`
+	QueueDuration  float64          `json:"queueDuration"`
+	ApplyDuration  float64          `json:"applyDuration"`
+}
+
+// dailyChanges are the changes applied to an alert definition or receiver on a day, as served by the daily trend endpoint.
+// Durations are in seconds.
+type dailyChanges struct {
+	Day               time.Time `json:"day"`
+	Applied           int64     `json:"applied"`
+	Invalid           int64     `json:"invalid"`
+	Retries           int64     `json:"retries"`
+	LastVersion       int64     `json:"lastVersion"`
+	MeanQueueDuration float64   `json:"meanQueueDuration"`
+	MeanApplyDuration float64   `json:"meanApplyDuration"`
+	MaxApplyDuration  float64   `json:"maxApplyDuration"`
+}
+
+// historyViewer serves the history of the changes applied to alert definitions and receivers by the executor, including the
+// tasks deleted by the task retention, so that it can be told who changed what and when, and how long it took to apply.
+type historyViewer struct {
+	history db.TaskHistoryManager
+}
+
+func newHistoryViewer(history db.TaskHistoryManager) *historyViewer {
+	return &historyViewer{history: history}
+}
+
+// register registers the history endpoint.
+func (v *historyViewer) register(e *echo.Echo) {
+	e.GET(historyEndpoint+"/:tenantID/:uuid", v.list)
+	e.GET(historyEndpoint+"/:tenantID/:uuid/daily", v.daily)
+}
+
+// list handles the request for the latest changes applied to an alert definition or receiver of a tenant, limited by the
+// limit query parameter.
+func (v *historyViewer) list(ctx echo.Context) error {
+	tenantID := ctx.Param("tenantID")
+	id, err := uuid.Parse(ctx.Param("uuid"))
+	if err != nil {
+		logWarn(ctx, fmt.Sprintf("Invalid history UUID: %q", ctx.Param("uuid")))
+		return ctx.JSON(http.StatusBadRequest, errArtifactBadRequest)
+	}
+
+	limit := defaultHistoryLimit
+	if param := ctx.QueryParam("limit"); param != "" {
+		l, err := strconv.Atoi(param)
+		if err != nil || l < 1 || l > maxHistoryLimit {
+			logWarn(ctx, fmt.Sprintf("Invalid history limit: %q", param))
+			return ctx.JSON(http.StatusBadRequest, errArtifactBadRequest)
+		}
+		limit = l
+	}
+
+	history, err := v.history.GetTaskHistory(ctx.Request().Context(), tenantID, id, limit)
+	if err != nil {
+		logError(ctx, fmt.Sprintf("Failed to get history of %q for tenant %q", id, tenantID), err)
+		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   "failed to get history",
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+
+	list := make([]appliedChange, 0, len(history))
+	for _, h := range history {
+		list = append(list, appliedChange{
+			Type:           h.Type,
+			UUID:           h.UUID,
+			Version:        h.Version,
+			State:          h.State,
+			Executor:       h.OwnerUUID,
+			CorrelationID:  h.CorrelationID,
+			RetryCount:     h.RetryCount,
+			CreationDate:   h.CreationDate,
+			CompletionDate: h.CompletionDate,
+			QueueDuration:  h.QueueDuration().Seconds(),
+			ApplyDuration:  h.ApplyDuration().Seconds(),
+		})
+	}
+	return ctx.JSON(http.StatusOK, list)
+}
+
+// daily handles the request for the daily trend of the changes applied to an alert definition or receiver of a tenant over
+// the number of days of the days query parameter, including today. It is served from the daily rollups the history is
+// compacted into past its retention, so that long-term trends remain available.
+func (v *historyViewer) daily(ctx echo.Context) error {
+	tenantID := ctx.Param("tenantID")
+	id, err := uuid.Parse(ctx.Param("uuid"))
+	if err != nil {
+		logWarn(ctx, fmt.Sprintf("Invalid history UUID: %q", ctx.Param("uuid")))
+		return ctx.JSON(http.StatusBadRequest, errArtifactBadRequest)
+	}
+
+	days := defaultTrendDays
+	if param := ctx.QueryParam("days"); param != "" {
+		d, err := strconv.Atoi(param)
+		if err != nil || d < 1 || d > maxTrendDays {
+			logWarn(ctx, fmt.Sprintf("Invalid history days: %q", param))
+			return ctx.JSON(http.StatusBadRequest, errArtifactBadRequest)
+		}
+		days = d
+	}
+	since := clock.TimeNowFn().UTC().AddDate(0, 0, 1-days)
+
+	trend, err := v.history.GetTaskHistoryTrend(ctx.Request().Context(), tenantID, id, since)
+	if err != nil {
+		logError(ctx, fmt.Sprintf("Failed to get history trend of %q for tenant %q", id, tenantID), err)
+		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   "failed to get history",
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+
+	list := make([]dailyChanges, 0, len(trend))
+	for _, r := range trend {
+		changes := dailyChanges{
+			Day:              r.Day,
+			Applied:          r.Applied,
+			Invalid:          r.Invalid,
+			Retries:          r.Retries,
+			LastVersion:      r.LastVersion,
+			MaxApplyDuration: r.MaxApplyDuration.Seconds(),
+		}
+		if completed := r.Completed(); completed > 0 {
+			changes.MeanQueueDuration = r.TotalQueueDuration.Seconds() / float64(completed)
+			changes.MeanApplyDuration = r.TotalApplyDuration.Seconds() / float64(completed)
+		}
+		list = append(list, changes)
+	}
+	return ctx.JSON(http.StatusOK, list)
+}
@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+func TestHistoryViewer(t *testing.T) {
+	conn, err := gorm.Open(sqlite.Open(":memory:"))
+	require.NoError(t, err)
+	require.NoError(t, conn.AutoMigrate(&models.Task{}, &models.TaskHistory{}, &models.TaskHistoryRollup{}))
+	dbService := &database.DBService{DB: conn}
+
+	clock.SetFakeClock()
+	defer clock.UnsetFakeClock()
+	now := time.Now().UTC().Truncate(time.Second)
+	clock.FakeClock.Set(now)
+
+	receiverID := uuid.New()
+	for version := int64(1); version <= 3; version++ {
+		start := now.Add(time.Duration(version) * time.Minute)
+		require.NoError(t, conn.Create(&models.Task{
+			ReceiverUUID:   &receiverID,
+			TenantID:       "edgenode",
+			Version:        version,
+			State:          models.TaskApplied,
+			CorrelationID:  "request",
+			CreationDate:   start.Add(-2 * time.Second),
+			StartDate:      start,
+			CompletionDate: start.Add(5 * time.Second),
+		}).Error)
+	}
+	require.NoError(t, conn.Create(&models.Task{
+		ReceiverUUID: &receiverID,
+		TenantID:     "edgenode",
+		Version:      4,
+		State:        models.TaskNew,
+	}).Error)
+
+	// The first two versions are archived into the task history.
+	clock.FakeClock.Set(now.Add(3 * time.Minute))
+	deleted, err := dbService.DeleteNotPendingTasksExceedingDuration(t.Context(), 0, database.TaskCleanupOptions{BatchSize: 1})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), deleted)
+
+	var archived int64
+	require.NoError(t, conn.Model(&models.TaskHistory{}).Count(&archived).Error)
+	require.Equal(t, int64(2), archived)
+
+	e := echo.New()
+	newHistoryViewer(dbService).register(e)
+
+	get := func(uri string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, uri, nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("History includes archived and completed tasks", func(t *testing.T) {
+		rec := get(historyEndpoint + "/edgenode/" + receiverID.String())
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var list []appliedChange
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+		require.Len(t, list, 3)
+		for i, change := range list {
+			require.Equal(t, int64(3-i), change.Version)
+			require.Equal(t, models.TypeReceiver, change.Type)
+			require.Equal(t, "request", change.CorrelationID)
+			require.InDelta(t, 2, change.QueueDuration, 0.001)
+			require.InDelta(t, 5, change.ApplyDuration, 0.001)
+		}
+	})
+
+	t.Run("History is limited", func(t *testing.T) {
+		rec := get(historyEndpoint + "/edgenode/" + receiverID.String() + "?limit=1")
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var list []appliedChange
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+		require.Len(t, list, 1)
+		require.Equal(t, int64(3), list[0].Version)
+	})
+
+	t.Run("History of another tenant is empty", func(t *testing.T) {
+		rec := get(historyEndpoint + "/other-tenant/" + receiverID.String())
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.JSONEq(t, "[]", rec.Body.String())
+	})
+
+	t.Run("Daily trend includes the compacted history", func(t *testing.T) {
+		// The archived versions are compacted into the rollup of their day, along with a rollup of the previous day.
+		compacted, _, err := dbService.CompactTaskHistory(t.Context(), now.Add(time.Hour), time.Time{}, 0)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), compacted)
+		require.NoError(t, conn.Create(&models.TaskHistoryRollup{TenantID: "edgenode", Type: models.TypeReceiver, UUID: receiverID,
+			Day: models.RollupDay(now).AddDate(0, 0, -1), Applied: 1}).Error)
+
+		rec := get(historyEndpoint + "/edgenode/" + receiverID.String() + "/daily")
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var list []dailyChanges
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+		// The versions may be completed the day after the current one, near midnight.
+		require.GreaterOrEqual(t, len(list), 2)
+		require.Equal(t, models.RollupDay(now).AddDate(0, 0, -1), list[0].Day)
+		var applied int64
+		for _, changes := range list[1:] {
+			applied += changes.Applied
+			require.InDelta(t, 2, changes.MeanQueueDuration, 0.001)
+			require.InDelta(t, 5, changes.MeanApplyDuration, 0.001)
+			require.InDelta(t, 5, changes.MaxApplyDuration, 0.001)
+		}
+		require.Equal(t, int64(3), applied)
+
+		rec = get(historyEndpoint + "/edgenode/" + receiverID.String() + "/daily?days=1")
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+		require.NotEmpty(t, list)
+		for _, changes := range list {
+			require.False(t, changes.Day.Before(models.RollupDay(now)))
+		}
+	})
+
+	t.Run("Invalid parameters - code should be 400", func(t *testing.T) {
+		require.Equal(t, http.StatusBadRequest, get(historyEndpoint+"/edgenode/not-a-uuid").Code)
+		require.Equal(t, http.StatusBadRequest, get(historyEndpoint+"/edgenode/"+receiverID.String()+"?limit=0").Code)
+		require.Equal(t, http.StatusBadRequest, get(historyEndpoint+"/edgenode/not-a-uuid/daily").Code)
+		require.Equal(t, http.StatusBadRequest, get(historyEndpoint+"/edgenode/"+receiverID.String()+"/daily?days=0").Code)
+	})
+}
@@ -0,0 +1,373 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"golang.org/x/text/language"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	db "github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+const (
+	errHTTPEventHooksUnavailable   = "event hooks are not available"
+	errHTTPEventHookNotFound       = "event hook not found"
+	errHTTPFailedToGetEventHooks   = "failed to get event hooks"
+	errHTTPFailedToGetEventHook    = "failed to get event hook"
+	errHTTPFailedToCreateEventHook = "failed to create event hook"
+	errHTTPFailedToUpdateEventHook = "failed to update event hook"
+	errHTTPFailedToDeleteEventHook = "failed to delete event hook"
+
+	// defaultEventHookTimeout is the timeout of the calls of event hooks if not configured.
+	defaultEventHookTimeout = 10 * time.Second
+)
+
+// eventHookEvents are the events hooks can subscribe to.
+var eventHookEvents = []api.EventHookEvent{
+	api.EventHookEventDefinitionApplied,
+	api.EventHookEventDefinitionError,
+	api.EventHookEventReceiverApplied,
+	api.EventHookEventReceiverError,
+}
+
+// eventHooks manages the callback URLs tenants register to be notified of the state transitions of their alert definitions
+// and receivers, and delivers the events queued for them. Calls are signed like the callbacks of alertmanager, with a secret
+// per hook derived from the signing key and the UUID of the hook, so that secrets are not stored.
+type eventHooks struct {
+	hooks    db.EventHookManager
+	key      []byte
+	maxHooks int
+	client   *http.Client
+}
+
+// newEventHooks creates a new eventHooks, loading the signing key from the EVENT_HOOK_SIGNING_KEY environment variable.
+func newEventHooks(conf config.EventHooksConfig, hooks db.EventHookManager) (*eventHooks, error) {
+	key := os.Getenv("EVENT_HOOK_SIGNING_KEY")
+	if key == "" {
+		return nil, errors.New("event hook signing key is not set")
+	}
+
+	timeout := conf.Timeout
+	if timeout <= 0 {
+		timeout = defaultEventHookTimeout
+	}
+	return &eventHooks{
+		hooks:    hooks,
+		key:      []byte(key),
+		maxHooks: conf.MaxHooks,
+		client:   &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// secret returns the secret the calls of the hook with the given UUID are signed with, the hex-encoded HMAC-SHA256 of its UUID.
+func (h *eventHooks) secret(id uuid.UUID) string {
+	mac := hmac.New(sha256.New, h.key)
+	mac.Write([]byte(id.String()))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// deliver posts an event queued for delivery to the callback URL of its hook, signed with the secret of the hook. The event
+// fails unless the callback answers with a success status, so that it is retried.
+func (h *eventHooks) deliver(ctx context.Context, task models.NotificationTask) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, task.Recipient, strings.NewReader(task.Payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	timestamp := strconv.FormatInt(clock.TimeNowFn().Unix(), 10)
+	signature := signWebhook([]byte(h.secret(task.ReceiverUUID)), timestamp, []byte(task.Payload))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhookTimestampHeader, timestamp)
+	req.Header.Set(webhookSignatureHeader, webhookSignaturePrefix+hex.EncodeToString(signature))
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("got unexpected status code: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// GetEventHooks gets the event hooks of a tenant, in the order they were registered.
+func (w *ServerInterfaceHandler) GetEventHooks(ctx echo.Context, tenantID api.TenantID) error {
+	if httpErr := w.checkEventHooks(ctx); httpErr != nil {
+		return ctx.JSON(httpErr.Code, httpErr)
+	}
+
+	hooks, err := w.hooks.hooks.GetEventHooks(ctx.Request().Context(), tenantID)
+	if err != nil {
+		logError(ctx, "Failed to get event hooks", err)
+		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToGetEventHooks,
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+
+	list := api.EventHookList{Hooks: make([]api.EventHook, 0, len(hooks))}
+	for _, hook := range hooks {
+		list.Hooks = append(list.Hooks, eventHook(hook))
+	}
+	return ctx.JSON(http.StatusOK, list)
+}
+
+// GetEventHook gets an event hook of a tenant given its UUID.
+func (w *ServerInterfaceHandler) GetEventHook(ctx echo.Context, tenantID api.TenantID, id api.HookId) error {
+	if httpErr := w.checkEventHooks(ctx); httpErr != nil {
+		return ctx.JSON(httpErr.Code, httpErr)
+	}
+
+	hook, err := w.hooks.hooks.GetEventHook(ctx.Request().Context(), tenantID, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		logError(ctx, fmt.Sprintf("Event hook not found: %q", id), err)
+		return ctx.JSON(http.StatusNotFound, api.HttpError{
+			Code:      http.StatusNotFound,
+			Message:   errHTTPEventHookNotFound,
+			ErrorCode: api.ErrorCodeEventHookNotFound,
+		})
+	} else if err != nil {
+		logError(ctx, fmt.Sprintf("Failed to get event hook: %q", id), err)
+		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToGetEventHook,
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+	return ctx.JSON(http.StatusOK, eventHook(*hook))
+}
+
+// CreateEventHook registers an event hook of a tenant, returning the secret its calls are signed with. Hooks subscribe to all
+// the events unless some are given.
+func (w *ServerInterfaceHandler) CreateEventHook(ctx echo.Context, tenantID api.TenantID) error {
+	if httpErr := w.checkEventHooks(ctx); httpErr != nil {
+		return ctx.JSON(httpErr.Code, httpErr)
+	}
+
+	var reqBody api.PostProjectEventHookJSONRequestBody
+
+	dec := json.NewDecoder(ctx.Request().Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&reqBody); err != nil {
+		logError(ctx, "Failed to parse body of event hook", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPBadRequest,
+			ErrorCode: api.ErrorCodeInvalidRequestBody,
+		})
+	}
+
+	var events []api.EventHookEvent
+	if reqBody.Events != nil {
+		events = *reqBody.Events
+	}
+	lang := responseLanguage(ctx)
+	if httpErr := validateEventHook(lang, reqBody.Url, events); httpErr != nil {
+		logWarn(ctx, fmt.Sprintf("Invalid event hook: %v", (*httpErr.Details)[0].Reason))
+		return ctx.JSON(httpErr.Code, httpErr)
+	}
+
+	hook := models.EventHook{
+		UUID:     uuid.New(),
+		TenantID: tenantID,
+		URL:      reqBody.Url,
+		Events:   joinEventHookEvents(events),
+	}
+	err := w.hooks.hooks.CreateEventHook(ctx.Request().Context(), &hook, w.hooks.maxHooks)
+	if errors.Is(err, db.ErrEventHookLimitExceeded) {
+		logWarn(ctx, fmt.Sprintf("Event hook limit exceeded: %v", err))
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   localize(lang, msgEventHookLimitExceeded, w.hooks.maxHooks),
+			ErrorCode: api.ErrorCodeEventHookLimitExceeded,
+		})
+	} else if err != nil {
+		logError(ctx, "Failed to create event hook", err)
+		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToCreateEventHook,
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+
+	resp := eventHook(hook)
+	secret := w.hooks.secret(hook.UUID)
+	resp.Secret = &secret
+	return ctx.JSON(http.StatusCreated, resp)
+}
+
+// UpdateEventHook sets the callback URL and/or the events of an event hook of a tenant given its UUID. Its secret is unchanged.
+func (w *ServerInterfaceHandler) UpdateEventHook(ctx echo.Context, tenantID api.TenantID, id api.HookId) error {
+	if httpErr := w.checkEventHooks(ctx); httpErr != nil {
+		return ctx.JSON(httpErr.Code, httpErr)
+	}
+
+	var reqBody api.PatchProjectEventHookJSONRequestBody
+
+	dec := json.NewDecoder(ctx.Request().Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&reqBody); err != nil {
+		logError(ctx, "Failed to parse body of event hook", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPBadRequest,
+			ErrorCode: api.ErrorCodeInvalidRequestBody,
+		})
+	}
+
+	hook, err := w.hooks.hooks.GetEventHook(ctx.Request().Context(), tenantID, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		logError(ctx, fmt.Sprintf("Event hook not found: %q", id), err)
+		return ctx.JSON(http.StatusNotFound, api.HttpError{
+			Code:      http.StatusNotFound,
+			Message:   errHTTPEventHookNotFound,
+			ErrorCode: api.ErrorCodeEventHookNotFound,
+		})
+	} else if err != nil {
+		logError(ctx, fmt.Sprintf("Failed to get event hook: %q", id), err)
+		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToUpdateEventHook,
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+
+	if reqBody.Url != nil {
+		hook.URL = *reqBody.Url
+	}
+	var events []api.EventHookEvent
+	if reqBody.Events != nil {
+		events = *reqBody.Events
+		hook.Events = joinEventHookEvents(events)
+	}
+	if httpErr := validateEventHook(responseLanguage(ctx), hook.URL, events); httpErr != nil {
+		logWarn(ctx, fmt.Sprintf("Invalid event hook: %v", (*httpErr.Details)[0].Reason))
+		return ctx.JSON(httpErr.Code, httpErr)
+	}
+
+	err = w.hooks.hooks.UpdateEventHook(ctx.Request().Context(), hook)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		logError(ctx, fmt.Sprintf("Event hook not found: %q", id), err)
+		return ctx.JSON(http.StatusNotFound, api.HttpError{
+			Code:      http.StatusNotFound,
+			Message:   errHTTPEventHookNotFound,
+			ErrorCode: api.ErrorCodeEventHookNotFound,
+		})
+	} else if err != nil {
+		logError(ctx, fmt.Sprintf("Failed to update event hook: %q", id), err)
+		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToUpdateEventHook,
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+	return ctx.JSON(http.StatusOK, eventHook(*hook))
+}
+
+// DeleteEventHook deletes an event hook of a tenant given its UUID, along with its events pending delivery.
+func (w *ServerInterfaceHandler) DeleteEventHook(ctx echo.Context, tenantID api.TenantID, id api.HookId) error {
+	if httpErr := w.checkEventHooks(ctx); httpErr != nil {
+		return ctx.JSON(httpErr.Code, httpErr)
+	}
+
+	err := w.hooks.hooks.DeleteEventHook(ctx.Request().Context(), tenantID, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		logError(ctx, fmt.Sprintf("Event hook not found: %q", id), err)
+		return ctx.JSON(http.StatusNotFound, api.HttpError{
+			Code:      http.StatusNotFound,
+			Message:   errHTTPEventHookNotFound,
+			ErrorCode: api.ErrorCodeEventHookNotFound,
+		})
+	} else if err != nil {
+		logError(ctx, fmt.Sprintf("Failed to delete event hook: %q", id), err)
+		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToDeleteEventHook,
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// checkEventHooks returns the error answering requests to the event hooks endpoints if event hooks are not enabled.
+func (w *ServerInterfaceHandler) checkEventHooks(ctx echo.Context) *api.HttpError {
+	if w.hooks != nil {
+		return nil
+	}
+	logWarn(ctx, "Event hooks are not available")
+	return &api.HttpError{
+		Code:      http.StatusServiceUnavailable,
+		Message:   errHTTPEventHooksUnavailable,
+		ErrorCode: api.ErrorCodeInternalError,
+	}
+}
+
+// validateEventHook validates the callback URL and events of an event hook. The URL must be an absolute http or https URL.
+func validateEventHook(lang language.Tag, rawURL string, events []api.EventHookEvent) *api.HttpError {
+	var field, reason string
+	if u, err := url.Parse(rawURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		field, reason = "url", localize(lang, msgInvalidEventHookURL)
+	} else if i := slices.IndexFunc(events, func(e api.EventHookEvent) bool { return !slices.Contains(eventHookEvents, e) }); i >= 0 {
+		field, reason = "events", localize(lang, msgInvalidEventHookEvent)
+	} else {
+		return nil
+	}
+	return &api.HttpError{
+		Code:      http.StatusBadRequest,
+		Message:   localize(lang, msgBadRequest),
+		ErrorCode: api.ErrorCodeInvalidRequestBody,
+		Details:   &[]api.ErrorDetail{{Field: field, Reason: reason}},
+	}
+}
+
+// joinEventHookEvents returns the events an event hook subscribes to as stored, sorted and without duplicates.
+func joinEventHookEvents(events []api.EventHookEvent) string {
+	joined := make([]string, 0, len(events))
+	for _, e := range events {
+		joined = append(joined, string(e))
+	}
+	slices.Sort(joined)
+	return strings.Join(slices.Compact(joined), ",")
+}
+
+// eventHook converts an event hook into its API representation, without its secret. Hooks subscribing to all the events list
+// them all.
+func eventHook(hook models.EventHook) api.EventHook {
+	events := slices.Clone(eventHookEvents)
+	if hook.Events != "" {
+		events = events[:0]
+		for _, e := range strings.Split(hook.Events, ",") {
+			events = append(events, api.EventHookEvent(e))
+		}
+	}
+	return api.EventHook{
+		Id:        hook.UUID,
+		Url:       hook.URL,
+		Events:    events,
+		CreatedAt: hook.CreationDate.UTC(),
+	}
+}
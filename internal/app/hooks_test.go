@@ -0,0 +1,177 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/testutil"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+func TestEventHooks(t *testing.T) {
+	conn, err := gorm.Open(sqlite.Open(":memory:"))
+	require.NoError(t, err)
+	require.NoError(t, conn.AutoMigrate(&models.EventHook{}, &models.NotificationTask{}))
+
+	t.Setenv("EVENT_HOOK_SIGNING_KEY", "signing-key")
+	hooks, err := newEventHooks(config.EventHooksConfig{Enabled: true, MaxHooks: 2}, &database.DBService{DB: conn})
+	require.NoError(t, err)
+
+	server := echo.New()
+	api.RegisterHandlers(server, &ServerInterfaceHandler{hooks: hooks})
+
+	request := func(tenantID string) *testutil.RequestBuilder {
+		return testutil.NewRequest().WithHeader("ActiveProjectID", tenantID)
+	}
+
+	var created api.EventHook
+	t.Run("Create hook", func(t *testing.T) {
+		result := request("edgenode").Post("/api/v1/hooks").
+			WithJsonBody(api.EventHookCreate{Url: "https://ci.example.com/hooks"}).GoWithHTTPHandler(t, server)
+		require.Equal(t, http.StatusCreated, result.Recorder.Code)
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &created))
+		require.Equal(t, "https://ci.example.com/hooks", created.Url)
+		require.Equal(t, eventHookEvents, created.Events)
+		require.NotNil(t, created.Secret)
+		require.Equal(t, hooks.secret(created.Id), *created.Secret)
+	})
+
+	t.Run("Invalid hook", func(t *testing.T) {
+		for name, body := range map[string]string{
+			"Relative URL":  `{"url":"/hooks"}`,
+			"Other scheme":  `{"url":"ftp://ci.example.com/hooks"}`,
+			"Unknown event": `{"url":"https://ci.example.com/hooks","events":["definition.deleted"]}`,
+			"Unknown field": `{"url":"https://ci.example.com/hooks","secret":"secret"}`,
+		} {
+			t.Run(name, func(t *testing.T) {
+				result := request("edgenode").Post("/api/v1/hooks").WithContentType("application/json").
+					WithBody([]byte(body)).GoWithHTTPHandler(t, server)
+				require.Equal(t, http.StatusBadRequest, result.Recorder.Code)
+			})
+		}
+	})
+
+	t.Run("Update hook", func(t *testing.T) {
+		events := []api.EventHookEvent{api.EventHookEventReceiverError, api.EventHookEventDefinitionError, api.EventHookEventReceiverError}
+		result := request("edgenode").Patch("/api/v1/hooks/"+created.Id.String()).
+			WithJsonBody(api.EventHookUpdate{Events: &events}).GoWithHTTPHandler(t, server)
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+
+		var hook api.EventHook
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &hook))
+		require.Equal(t, []api.EventHookEvent{api.EventHookEventDefinitionError, api.EventHookEventReceiverError}, hook.Events)
+		require.Equal(t, created.Url, hook.Url)
+		require.Nil(t, hook.Secret)
+
+		result = request("other").Patch("/api/v1/hooks/"+created.Id.String()).
+			WithJsonBody(api.EventHookUpdate{Events: &events}).GoWithHTTPHandler(t, server)
+		require.Equal(t, http.StatusNotFound, result.Recorder.Code)
+	})
+
+	t.Run("List of hooks", func(t *testing.T) {
+		result := request("edgenode").Get("/api/v1/hooks").GoWithHTTPHandler(t, server)
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+
+		var list api.EventHookList
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &list))
+		require.Len(t, list.Hooks, 1)
+		require.Equal(t, created.Id, list.Hooks[0].Id)
+
+		result = request("other").Get("/api/v1/hooks").GoWithHTTPHandler(t, server)
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+		require.JSONEq(t, `{"hooks":[]}`, result.Recorder.Body.String())
+	})
+
+	t.Run("Hook limit", func(t *testing.T) {
+		result := request("edgenode").Post("/api/v1/hooks").
+			WithJsonBody(api.EventHookCreate{Url: "http://ci.example.com/other"}).GoWithHTTPHandler(t, server)
+		require.Equal(t, http.StatusCreated, result.Recorder.Code)
+
+		result = request("edgenode").Post("/api/v1/hooks").
+			WithJsonBody(api.EventHookCreate{Url: "http://ci.example.com/another"}).GoWithHTTPHandler(t, server)
+		require.Equal(t, http.StatusBadRequest, result.Recorder.Code)
+
+		var httpErr api.HttpError
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &httpErr))
+		require.Equal(t, api.ErrorCodeEventHookLimitExceeded, httpErr.ErrorCode)
+	})
+
+	t.Run("Delete hook", func(t *testing.T) {
+		result := request("other").Delete("/api/v1/hooks/"+created.Id.String()).GoWithHTTPHandler(t, server)
+		require.Equal(t, http.StatusNotFound, result.Recorder.Code)
+
+		result = request("edgenode").Delete("/api/v1/hooks/"+created.Id.String()).GoWithHTTPHandler(t, server)
+		require.Equal(t, http.StatusNoContent, result.Recorder.Code)
+
+		result = request("edgenode").Get("/api/v1/hooks/"+created.Id.String()).GoWithHTTPHandler(t, server)
+		require.Equal(t, http.StatusNotFound, result.Recorder.Code)
+	})
+
+	t.Run("Hooks not enabled", func(t *testing.T) {
+		server := echo.New()
+		api.RegisterHandlers(server, &ServerInterfaceHandler{})
+
+		result := request("edgenode").Get("/api/v1/hooks").GoWithHTTPHandler(t, server)
+		require.Equal(t, http.StatusServiceUnavailable, result.Recorder.Code)
+	})
+}
+
+func TestEventHooks_Deliver(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	clock.TimeNowFn = func() time.Time { return now }
+	defer func() { clock.TimeNowFn = time.Now }()
+
+	t.Setenv("EVENT_HOOK_SIGNING_KEY", "signing-key")
+	hooks, err := newEventHooks(config.EventHooksConfig{Enabled: true}, nil)
+	require.NoError(t, err)
+
+	id := uuid.New()
+	payload := `{"event":"definition.applied","projectId":"edgenode"}`
+	status := http.StatusNoContent
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Equal(t, payload, string(body))
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		// Receivers verify the calls with the secret returned on the creation of the hook.
+		ts := r.Header.Get(webhookTimestampHeader)
+		require.Equal(t, strconv.FormatInt(now.Unix(), 10), ts)
+		require.Equal(t, webhookSignaturePrefix+hex.EncodeToString(signWebhook([]byte(hooks.secret(id)), ts, body)),
+			r.Header.Get(webhookSignatureHeader))
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	task := models.NotificationTask{ReceiverUUID: id, Channel: models.NotificationHook, Recipient: srv.URL, Payload: payload}
+	require.NoError(t, hooks.deliver(context.Background(), task))
+
+	status = http.StatusServiceUnavailable
+	require.Error(t, hooks.deliver(context.Background(), task))
+}
+
+func TestNewEventHooks_MissingKey(t *testing.T) {
+	t.Setenv("EVENT_HOOK_SIGNING_KEY", "")
+	_, err := newEventHooks(config.EventHooksConfig{Enabled: true}, nil)
+	require.Error(t, err)
+}
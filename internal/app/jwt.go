@@ -17,7 +17,8 @@ type RealmAccess struct {
 }
 
 type JWTPayload struct {
-	RealmAccess RealmAccess `json:"realm_access"`
+	RealmAccess       RealmAccess `json:"realm_access"`
+	PreferredUsername string      `json:"preferred_username"`
 }
 
 var r = regexp.MustCompile(`^Bearer (\S+)$`)
@@ -46,7 +47,7 @@ func restorePadding(input string) string {
 	return input
 }
 
-func extractRolesFromJWT(jwt string) ([]string, error) {
+func decodeJWTPayload(jwt string) ([]byte, error) {
 	jwtSplit := strings.Split(jwt, ".")
 
 	if len(jwtSplit) != 3 {
@@ -57,6 +58,14 @@ func extractRolesFromJWT(jwt string) ([]string, error) {
 	if err != nil {
 		return nil, fmt.Errorf("unable to decode: %w", err)
 	}
+	return payloadBytes, nil
+}
+
+func extractRolesFromJWT(jwt string) ([]string, error) {
+	payloadBytes, err := decodeJWTPayload(jwt)
+	if err != nil {
+		return nil, err
+	}
 
 	roles, err := getRoles(payloadBytes)
 	if err != nil {
@@ -64,3 +73,17 @@ func extractRolesFromJWT(jwt string) ([]string, error) {
 	}
 	return roles, nil
 }
+
+// extractUsernameFromJWT returns the preferred username of the user the given token was issued to.
+func extractUsernameFromJWT(jwt string) (string, error) {
+	payloadBytes, err := decodeJWTPayload(jwt)
+	if err != nil {
+		return "", err
+	}
+
+	var payload JWTPayload
+	if err := json.Unmarshal(payloadBytes, &payload); err != nil {
+		return "", fmt.Errorf("unable to get username: %w", err)
+	}
+	return payload.PreferredUsername, nil
+}
@@ -120,3 +120,15 @@ func TestExtractRolesFromJWT(t *testing.T) {
 		}
 	}
 }
+
+func TestExtractUsernameFromJWT(t *testing.T) {
+	username, err := extractUsernameFromJWT(validRolesToken)
+	require.NoError(t, err)
+	require.Equal(t, "lp-admin-user", username)
+
+	_, err = extractUsernameFromJWT(invalidTokenTooManyParts)
+	require.Error(t, err)
+
+	_, err = extractUsernameFromJWT(invalidTokenBadDecoding)
+	require.Error(t, err)
+}
@@ -0,0 +1,237 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/correlation"
+	db "github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+)
+
+const (
+	// alertLinkageEndpoint is the prefix of the endpoint checking the linkage of the active alerts of a tenant to its alert
+	// definitions. It is under /debug, so that it is only granted to administrators.
+	alertLinkageEndpoint = "/debug/tenants"
+
+	// linkageMalformedUUID is the reason of alerts whose am_uuid annotation is not a valid UUID.
+	linkageMalformedUUID = "malformed_uuid"
+	// linkageUnknownDefinition is the reason of alerts whose am_uuid annotation is not the UUID of an alert definition of their
+	// tenant, such as the alerts of rules left behind in Mimir.
+	linkageUnknownDefinition = "unknown_definition"
+)
+
+// unlinkedAlert is an active alert not linked to any alert definition of its tenant, as served by the linkage endpoint.
+type unlinkedAlert struct {
+	Fingerprint string `json:"fingerprint"`
+	AlertName   string `json:"alertname"`
+	UUID        string `json:"uuid"`
+	Reason      string `json:"reason"`
+}
+
+// linkageAlert holds the fields of an alert of the alertmanager v2 API its linkage is checked with.
+type linkageAlert struct {
+	Fingerprint string            `json:"fingerprint"`
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+}
+
+// alertLinkageChecker checks that the active alerts raised by alert definitions, as told by their am_uuid annotation, are
+// linked to an alert definition of their tenant. Unlinked alerts are logged and counted by the unlinked alerts metric, either
+// periodically for all tenants with alert definitions or on request for a single tenant.
+type alertLinkageChecker struct {
+	handler *ServerInterfaceHandler
+	states  db.ConfigStateReporter
+	client  *http.Client
+}
+
+func newAlertLinkageChecker(handler *ServerInterfaceHandler, states db.ConfigStateReporter) *alertLinkageChecker {
+	return &alertLinkageChecker{
+		handler: handler,
+		states:  states,
+		client:  http.DefaultClient,
+	}
+}
+
+// register registers the linkage endpoint.
+func (c *alertLinkageChecker) register(e *echo.Echo) {
+	e.GET(alertLinkageEndpoint+"/:tenantID/unlinked-alerts", c.list)
+}
+
+// run checks the alerts of all tenants with alert definitions every interval, until the context is done.
+func (c *alertLinkageChecker) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.checkAll(ctx)
+		}
+	}
+}
+
+// checkAll checks the alerts of all tenants with alert definitions. The unlinked alerts metric is reset first, so that tenants
+// which no longer have alert definitions are left out of it. Tenants whose alerts cannot be retrieved are skipped.
+func (c *alertLinkageChecker) checkAll(ctx context.Context) {
+	states, err := c.states.GetLatestAlertDefinitionStates(ctx)
+	if err != nil {
+		slog.Error("Failed to get alert definitions to check the linkage of alerts", slog.Any("error", err))
+		return
+	}
+
+	definitions := make(map[api.TenantID]map[uuid.UUID]bool)
+	for _, def := range states {
+		if definitions[def.TenantID] == nil {
+			definitions[def.TenantID] = make(map[uuid.UUID]bool)
+		}
+		definitions[def.TenantID][def.UUID] = true
+	}
+
+	checked := make(map[api.TenantID][]unlinkedAlert, len(definitions))
+	for tenantID, ids := range definitions {
+		alerts, err := c.activeAlerts(ctx, tenantID)
+		if err != nil {
+			slog.Error("Failed to get alerts to check their linkage", slog.String("tenant", tenantID), slog.Any("error", err))
+			continue
+		}
+		checked[tenantID] = findUnlinkedAlerts(alerts, ids)
+	}
+
+	unlinkedAlerts.Reset()
+	for tenantID, unlinked := range checked {
+		recordUnlinkedAlerts(ctx, tenantID, unlinked)
+	}
+}
+
+// check checks the alerts of the given tenant against its alert definitions.
+func (c *alertLinkageChecker) check(ctx context.Context, tenantID api.TenantID) ([]unlinkedAlert, error) {
+	defs, _, err := c.handler.definitions.GetLatestAlertDefinitionList(ctx, tenantID, db.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alert definitions: %w", err)
+	}
+	ids := make(map[uuid.UUID]bool, len(defs))
+	for _, def := range defs {
+		ids[def.ID] = true
+	}
+
+	alerts, err := c.activeAlerts(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	unlinked := findUnlinkedAlerts(alerts, ids)
+	recordUnlinkedAlerts(ctx, tenantID, unlinked)
+	return unlinked, nil
+}
+
+// list handles the request for the unlinked alerts of a tenant, checking its alerts.
+func (c *alertLinkageChecker) list(ctx echo.Context) error {
+	tenantID := ctx.Param("tenantID")
+	unlinked, err := c.check(ctx.Request().Context(), tenantID)
+	if err != nil {
+		logError(ctx, fmt.Sprintf("Failed to check linkage of alerts of tenant %q", tenantID), err)
+		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   "failed to check alert linkage",
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+	return ctx.JSON(http.StatusOK, unlinked)
+}
+
+// activeAlerts gets the alerts of the given tenant from its alertmanager, with their internal annotations.
+func (c *alertLinkageChecker) activeAlerts(ctx context.Context, tenantID api.TenantID) ([]linkageAlert, error) {
+	amURL, err := c.handler.alertManagerURL(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alertmanager shard: %w", err)
+	}
+
+	query := url.Values{"filter": {TenantFilter(c.handler.tenantLabel(), tenantID)}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, amURL+"/api/v2/alerts?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	correlation.SetHeader(req)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("alertmanager returned HTTP status code: %v", resp.StatusCode)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	var alerts []linkageAlert
+	if err := json.Unmarshal(body, &alerts); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal alerts: %w", err)
+	}
+	return alerts, nil
+}
+
+// findUnlinkedAlerts returns the given alerts whose am_uuid annotation is malformed or is not one of the given UUIDs of alert
+// definitions. Alerts without the annotation are not raised by alert definitions, and are thus not checked.
+func findUnlinkedAlerts(alerts []linkageAlert, definitions map[uuid.UUID]bool) []unlinkedAlert {
+	unlinked := make([]unlinkedAlert, 0)
+	for _, alert := range alerts {
+		value, ok := alert.Annotations["am_uuid"]
+		if !ok {
+			continue
+		}
+
+		reason := ""
+		if id, err := uuid.Parse(value); err != nil {
+			reason = linkageMalformedUUID
+		} else if !definitions[id] {
+			reason = linkageUnknownDefinition
+		}
+		if reason != "" {
+			unlinked = append(unlinked, unlinkedAlert{
+				Fingerprint: alert.Fingerprint,
+				AlertName:   alert.Labels["alertname"],
+				UUID:        value,
+				Reason:      reason,
+			})
+		}
+	}
+	return unlinked
+}
+
+// recordUnlinkedAlerts sets the unlinked alerts metric of the given tenant to its unlinked alerts, and logs each of them.
+func recordUnlinkedAlerts(ctx context.Context, tenantID api.TenantID, unlinked []unlinkedAlert) {
+	counts := map[string]float64{linkageMalformedUUID: 0, linkageUnknownDefinition: 0}
+	for _, alert := range unlinked {
+		counts[alert.Reason]++
+		slog.LogAttrs(ctx, slog.LevelWarn, "Alert not linked to any alert definition",
+			slog.String("tenant", tenantID),
+			slog.String("fingerprint", alert.Fingerprint),
+			slog.String("alertname", alert.AlertName),
+			slog.String("uuid", alert.UUID),
+			slog.String("reason", alert.Reason),
+			slog.String("component", "alerting-monitor"),
+		)
+	}
+	for reason, count := range counts {
+		unlinkedAlerts.WithLabelValues(tenantID, reason).Set(count)
+	}
+}
@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+func TestAlertLinkageChecker(t *testing.T) {
+	linked := uuid.MustParse("6f1c2d4e-7a8b-4c9d-8e0f-1a2b3c4d5e6f")
+	orphaned := uuid.MustParse("0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d")
+
+	alertManager := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/alerts" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.URL.Query().Get("filter") {
+		case `projectId="tenant"`:
+			fmt.Fprintf(w, `[`+
+				`{"fingerprint":"0000000000000001","labels":{"alertname":"HostCPUUsageHigh"},"annotations":{"am_uuid":%q}},`+
+				`{"fingerprint":"0000000000000002","labels":{"alertname":"Orphaned"},"annotations":{"am_uuid":%q}},`+
+				`{"fingerprint":"0000000000000003","labels":{"alertname":"Malformed"},"annotations":{"am_uuid":"bad"}},`+
+				`{"fingerprint":"0000000000000004","labels":{"alertname":"DiskAlmostFull"},"annotations":{}}]`, linked, orphaned)
+		case `projectId="unreachable"`:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			fmt.Fprint(w, "[]")
+		}
+	}))
+	defer alertManager.Close()
+
+	configfile := conf
+	configfile.AlertManager.URL = alertManager.URL
+
+	expected := []unlinkedAlert{
+		{Fingerprint: "0000000000000002", AlertName: "Orphaned", UUID: orphaned.String(), Reason: linkageUnknownDefinition},
+		{Fingerprint: "0000000000000003", AlertName: "Malformed", UUID: "bad", Reason: linkageMalformedUUID},
+	}
+
+	t.Run("Unlinked alerts of a tenant", func(t *testing.T) {
+		mDefinition := &DefinitionMock{}
+		mDefinition.On("GetLatestAlertDefinitionList", mock.Anything, "tenant", database.ListOptions{}).
+			Return([]*models.DBAlertDefinition{{ID: linked}}, int64(1), nil).Once()
+
+		e := echo.New()
+		newAlertLinkageChecker(&ServerInterfaceHandler{configuration: configfile, definitions: mDefinition}, nil).register(e)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/tenants/tenant/unlinked-alerts", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var unlinked []unlinkedAlert
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &unlinked))
+		require.Equal(t, expected, unlinked)
+
+		require.InDelta(t, 1, promtestutil.ToFloat64(unlinkedAlerts.WithLabelValues("tenant", linkageUnknownDefinition)), 0)
+		require.InDelta(t, 1, promtestutil.ToFloat64(unlinkedAlerts.WithLabelValues("tenant", linkageMalformedUUID)), 0)
+		mDefinition.AssertExpectations(t)
+	})
+
+	t.Run("Alerts cannot be retrieved - code should be 500", func(t *testing.T) {
+		mDefinition := &DefinitionMock{}
+		mDefinition.On("GetLatestAlertDefinitionList", mock.Anything, "unreachable", database.ListOptions{}).
+			Return([]*models.DBAlertDefinition{}, int64(0), nil).Once()
+
+		e := echo.New()
+		newAlertLinkageChecker(&ServerInterfaceHandler{configuration: configfile, definitions: mDefinition}, nil).register(e)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/tenants/unreachable/unlinked-alerts", nil))
+
+		var httpErr api.HttpError
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &httpErr))
+		require.Equal(t, http.StatusInternalServerError, httpErr.Code)
+		mDefinition.AssertExpectations(t)
+	})
+
+	t.Run("Check of all tenants", func(t *testing.T) {
+		unlinkedAlerts.WithLabelValues("removed", linkageUnknownDefinition).Set(3)
+
+		statesMock := new(ConfigStateMock)
+		statesMock.On("GetLatestAlertDefinitionStates", mock.Anything).Return([]models.AlertDefinition{
+			{TenantID: "tenant", UUID: linked},
+			{TenantID: "other", UUID: orphaned},
+			{TenantID: "unreachable", UUID: orphaned},
+		}, nil).Once()
+
+		newAlertLinkageChecker(&ServerInterfaceHandler{configuration: configfile}, statesMock).checkAll(context.Background())
+
+		require.NoError(t, promtestutil.CollectAndCompare(unlinkedAlerts, strings.NewReader(`
+# HELP alerting_monitor_unlinked_alerts Number of active alerts of a tenant whose am_uuid annotation does not match any of its alert definitions.
+# TYPE alerting_monitor_unlinked_alerts gauge
+alerting_monitor_unlinked_alerts{reason="malformed_uuid",tenant="other"} 0
+alerting_monitor_unlinked_alerts{reason="malformed_uuid",tenant="tenant"} 1
+alerting_monitor_unlinked_alerts{reason="unknown_definition",tenant="other"} 0
+alerting_monitor_unlinked_alerts{reason="unknown_definition",tenant="tenant"} 1
+`)))
+		statesMock.AssertExpectations(t)
+	})
+
+	t.Run("Alert definitions cannot be retrieved - metric is left as is", func(t *testing.T) {
+		statesMock := new(ConfigStateMock)
+		statesMock.On("GetLatestAlertDefinitionStates", mock.Anything).Return([]models.AlertDefinition(nil), errors.New("mock error")).Once()
+
+		newAlertLinkageChecker(&ServerInterfaceHandler{configuration: configfile}, statesMock).checkAll(context.Background())
+
+		require.InDelta(t, 1, promtestutil.ToFloat64(unlinkedAlerts.WithLabelValues("tenant", linkageMalformedUUID)), 0)
+		statesMock.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,216 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+const (
+	errHTTPFailedToSetMaintenanceMode = "failed to set maintenance mode"
+
+	// minMaintenanceDuration is the minimum duration of the maintenance mode, the routes of receivers being muted to the minute.
+	minMaintenanceDuration = time.Minute
+	// defaultMaxMaintenanceDuration is the maximum duration of the maintenance mode, unless configured otherwise.
+	defaultMaxMaintenanceDuration = 24 * time.Hour
+
+	// maintenanceSilenceCreator is the creator of the silences of the alerts of tenants in maintenance mode.
+	maintenanceSilenceCreator = "alerting-monitor"
+)
+
+// postableSilence is a silence as created through the silences endpoint of alertmanager.
+type postableSilence struct {
+	Matchers  []silenceMatcher `json:"matchers"`
+	StartsAt  time.Time        `json:"startsAt"`
+	EndsAt    time.Time        `json:"endsAt"`
+	CreatedBy string           `json:"createdBy"`
+	Comment   string           `json:"comment"`
+}
+
+// SetMaintenanceMode puts a tenant in maintenance mode for the requested duration, or takes it out of maintenance mode. In
+// maintenance mode, the alerts of the tenant are silenced by a silence matching the tenant label, and the routes of its
+// receivers are muted once they are applied again. A tenant already in maintenance mode has its silence replaced.
+func (w *ServerInterfaceHandler) SetMaintenanceMode(ctx echo.Context, tenantID api.TenantID) error {
+	var reqBody api.PutProjectMaintenanceModeJSONRequestBody
+
+	dec := json.NewDecoder(ctx.Request().Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&reqBody); err != nil {
+		logError(ctx, "Failed to parse body of maintenance mode", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPBadRequest,
+			ErrorCode: api.ErrorCodeInvalidRequestBody,
+		})
+	}
+
+	current, err := w.maintenance.GetTenantMaintenance(ctx.Request().Context(), tenantID)
+	if err != nil {
+		logError(ctx, fmt.Sprintf("Failed to get maintenance mode of tenant %q", tenantID), err)
+		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToSetMaintenanceMode,
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+
+	if !reqBody.Enabled {
+		if current == nil {
+			return ctx.JSON(http.StatusOK, maintenanceMode(nil))
+		}
+		if err := w.maintenance.SetTenantMaintenance(ctx.Request().Context(), tenantID, nil); err != nil {
+			logError(ctx, fmt.Sprintf("Failed to take tenant %q out of maintenance mode", tenantID), err)
+			return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+				Code:      http.StatusInternalServerError,
+				Message:   errHTTPFailedToSetMaintenanceMode,
+				ErrorCode: api.ErrorCodeInternalError,
+			})
+		}
+		w.expireMaintenanceSilence(ctx, tenantID, current.SilenceID)
+		return ctx.JSON(http.StatusOK, maintenanceMode(nil))
+	}
+
+	maxDuration := w.configuration.MaintenanceMode.MaxDuration
+	if maxDuration <= 0 {
+		maxDuration = defaultMaxMaintenanceDuration
+	}
+
+	var duration time.Duration
+	if reqBody.Duration != nil {
+		duration, err = ParseDuration(*reqBody.Duration)
+	}
+	if reqBody.Duration == nil || err != nil || duration < minMaintenanceDuration || duration > maxDuration {
+		lang := responseLanguage(ctx)
+		value := ""
+		if reqBody.Duration != nil {
+			value = *reqBody.Duration
+		}
+		logWarn(ctx, fmt.Sprintf("Invalid maintenance mode duration of tenant %q: %q", tenantID, value))
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   localize(lang, msgBadRequest),
+			ErrorCode: api.ErrorCodeInvalidRequestBody,
+			Details: &[]api.ErrorDetail{{
+				Field:  "duration",
+				Reason: localize(lang, msgMaintenanceDurationOutOfBounds, FormatDuration(maxDuration)),
+				Value:  &value,
+			}},
+		})
+	}
+
+	comment := fmt.Sprintf("Maintenance mode of project %q", tenantID)
+	if reqBody.Comment != nil && *reqBody.Comment != "" {
+		comment = *reqBody.Comment
+	}
+
+	start := clock.TimeNowFn().UTC()
+	window := models.MaintenanceWindow{
+		Start: start,
+		End:   start.Add(duration),
+	}
+	window.SilenceID, err = w.createMaintenanceSilence(ctx, tenantID, window, comment)
+	if err != nil {
+		logError(ctx, fmt.Sprintf("Failed to silence alerts of tenant %q", tenantID), err)
+		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToSetMaintenanceMode,
+			ErrorCode: api.ErrorCodeAlertmanagerUnavailable,
+		})
+	}
+
+	if err := w.maintenance.SetTenantMaintenance(ctx.Request().Context(), tenantID, &window); err != nil {
+		logError(ctx, fmt.Sprintf("Failed to put tenant %q in maintenance mode", tenantID), err)
+		w.expireMaintenanceSilence(ctx, tenantID, window.SilenceID)
+		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToSetMaintenanceMode,
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+
+	// The silence of the maintenance mode being replaced is expired once the new one is in place, so that no alert is notified
+	// in between.
+	if current != nil {
+		w.expireMaintenanceSilence(ctx, tenantID, current.SilenceID)
+	}
+	return ctx.JSON(http.StatusOK, maintenanceMode(&window))
+}
+
+// createMaintenanceSilence creates the silence of the alerts of a tenant during the given maintenance window, returning its ID.
+func (w *ServerInterfaceHandler) createMaintenanceSilence(ctx echo.Context, tenantID api.TenantID, window models.MaintenanceWindow,
+	comment string) (string, error) {
+	isEqual := true
+	body, err := json.Marshal(postableSilence{
+		Matchers:  []silenceMatcher{{Name: w.tenantLabel(), Value: tenantID, IsEqual: &isEqual}},
+		StartsAt:  window.Start,
+		EndsAt:    window.End,
+		CreatedBy: maintenanceSilenceCreator,
+		Comment:   comment,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal silence: %w", err)
+	}
+
+	status, respBody, err := newAlertmanagerCompat(w).forward(ctx, tenantID, http.MethodPost, "/silences", nil, body)
+	if err != nil {
+		return "", err
+	}
+	if status != http.StatusOK {
+		return "", fmt.Errorf("alertmanager returned HTTP status code: %v", status)
+	}
+
+	var created struct {
+		SilenceID string `json:"silenceID"`
+	}
+	if err := json.Unmarshal(respBody, &created); err != nil {
+		return "", fmt.Errorf("failed to unmarshal created silence: %w", err)
+	}
+	if created.SilenceID == "" {
+		return "", errors.New("alertmanager returned no silence ID")
+	}
+	return created.SilenceID, nil
+}
+
+// expireMaintenanceSilence expires the silence of a maintenance mode. Failures are only logged, since the silence ends with
+// the maintenance window anyway.
+func (w *ServerInterfaceHandler) expireMaintenanceSilence(ctx echo.Context, tenantID api.TenantID, silenceID string) {
+	if silenceID == "" {
+		return
+	}
+
+	status, _, err := newAlertmanagerCompat(w).forward(ctx, tenantID, http.MethodDelete, "/silence/"+url.PathEscape(silenceID), nil, nil)
+	if err != nil {
+		logError(ctx, fmt.Sprintf("Failed to expire maintenance silence %q of tenant %q", silenceID, tenantID), err)
+		return
+	}
+	if status != http.StatusOK {
+		logWarn(ctx, fmt.Sprintf("Failed to expire maintenance silence %q of tenant %q, alertmanager returned HTTP status code: %v",
+			silenceID, tenantID, status))
+	}
+}
+
+// maintenanceMode returns the maintenance mode of a tenant as served by the API given its maintenance window, which is nil
+// if the tenant is not in maintenance mode.
+func maintenanceMode(window *models.MaintenanceWindow) *api.MaintenanceMode {
+	if window == nil {
+		return &api.MaintenanceMode{Enabled: false}
+	}
+	start, end := window.Start.UTC(), window.End.UTC()
+	return &api.MaintenanceMode{
+		Enabled:  true,
+		StartsAt: &start,
+		EndsAt:   &end,
+	}
+}
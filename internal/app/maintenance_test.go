@@ -0,0 +1,193 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+func TestSetMaintenanceMode(t *testing.T) {
+	conn, err := gorm.Open(sqlite.Open(":memory:"))
+	require.NoError(t, err)
+	require.NoError(t, conn.AutoMigrate(&models.Receiver{}, &models.Task{}, &models.Tenant{}))
+	dbService := &database.DBService{DB: conn}
+
+	clock.SetFakeClock()
+	defer clock.UnsetFakeClock()
+	now := time.Now().UTC().Truncate(time.Second)
+	clock.FakeClock.Set(now)
+
+	receiverID := uuid.New()
+	require.NoError(t, conn.Create(&models.Receiver{
+		UUID:     receiverID,
+		Name:     "receiver",
+		State:    models.ReceiverApplied,
+		Version:  1,
+		TenantID: "edgenode",
+	}).Error)
+
+	var posted []postableSilence
+	var expired []string
+	alertManager := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v2/silences":
+			var silence postableSilence
+			body, _ := io.ReadAll(r.Body)
+			if json.Unmarshal(body, &silence) != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			posted = append(posted, silence)
+			fmt.Fprintf(w, `{"silenceID":"silence-%d"}`, len(posted))
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/api/v2/silence/"):
+			expired = append(expired, strings.TrimPrefix(r.URL.Path, "/api/v2/silence/"))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v2/alerts":
+			fmt.Fprint(w, "[]")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer alertManager.Close()
+
+	configfile := conf
+	configfile.AlertManager.URL = alertManager.URL
+	configfile.MaintenanceMode.MaxDuration = 8 * time.Hour
+
+	handler := &ServerInterfaceHandler{
+		configuration: configfile,
+		maintenance:   dbService,
+	}
+
+	server := echo.New()
+	api.RegisterHandlers(server, handler)
+
+	put := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/alerts/maintenance-mode", strings.NewReader(body))
+		req.Header.Set("ActiveProjectID", "edgenode")
+		rec := httptest.NewRecorder()
+		server.ServeHTTP(rec, req)
+		return rec
+	}
+
+	getAlerts := func() api.AlertList {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/alerts", nil)
+		req.Header.Set("ActiveProjectID", "edgenode")
+		rec := httptest.NewRecorder()
+		server.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var alerts api.AlertList
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &alerts))
+		return alerts
+	}
+
+	requeued := func() []models.Task {
+		var tasks []models.Task
+		require.NoError(t, conn.Where("receiver_uuid = ?", receiverID).Where("state = ?", models.TaskNew).Find(&tasks).Error)
+		require.NoError(t, conn.Where("1 = 1").Delete(&models.Task{}).Error)
+		return tasks
+	}
+
+	t.Run("Tenant is not in maintenance mode", func(t *testing.T) {
+		require.Equal(t, &api.MaintenanceMode{Enabled: false}, getAlerts().MaintenanceMode)
+	})
+
+	t.Run("Put tenant in maintenance mode", func(t *testing.T) {
+		rec := put(`{"enabled":true,"duration":"2h","comment":"site maintenance"}`)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		end := now.Add(2 * time.Hour)
+		var mode api.MaintenanceMode
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &mode))
+		require.True(t, mode.Enabled)
+		require.True(t, now.Equal(*mode.StartsAt))
+		require.True(t, end.Equal(*mode.EndsAt))
+
+		require.Len(t, posted, 1)
+		require.Equal(t, []silenceMatcher{{Name: config.DefaultTenantLabel, Value: "edgenode", IsEqual: &[]bool{true}[0]}}, posted[0].Matchers)
+		require.True(t, end.Equal(posted[0].EndsAt))
+		require.Equal(t, "site maintenance", posted[0].Comment)
+
+		window, err := dbService.GetTenantMaintenance(t.Context(), "edgenode")
+		require.NoError(t, err)
+		require.Equal(t, "silence-1", window.SilenceID)
+
+		// The receiver of the tenant is applied again, so that its route is muted.
+		require.Len(t, requeued(), 1)
+
+		require.Equal(t, &mode, getAlerts().MaintenanceMode)
+	})
+
+	t.Run("Maintenance mode is extended", func(t *testing.T) {
+		rec := put(`{"enabled":true,"duration":"4h"}`)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		require.Len(t, posted, 2)
+		require.Equal(t, `Maintenance mode of project "edgenode"`, posted[1].Comment)
+		require.Equal(t, []string{"silence-1"}, expired)
+		require.Len(t, requeued(), 1)
+	})
+
+	t.Run("Invalid duration - code should be 400", func(t *testing.T) {
+		for _, body := range []string{
+			`{"enabled":true}`,
+			`{"enabled":true,"duration":"30s"}`,
+			`{"enabled":true,"duration":"9h"}`,
+			`{"enabled":true,"duration":"soon"}`,
+		} {
+			rec := put(body)
+			require.Equal(t, http.StatusBadRequest, rec.Code, body)
+
+			var httpErr api.HttpError
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &httpErr))
+			require.Equal(t, api.ErrorCodeInvalidRequestBody, httpErr.ErrorCode)
+			require.Equal(t, "duration", (*httpErr.Details)[0].Field)
+			require.Equal(t, "maintenance mode duration must be between 1m and 8h", (*httpErr.Details)[0].Reason)
+		}
+		require.Len(t, posted, 2)
+	})
+
+	t.Run("Take tenant out of maintenance mode", func(t *testing.T) {
+		rec := put(`{"enabled":false}`)
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.JSONEq(t, `{"enabled":false}`, rec.Body.String())
+
+		require.Equal(t, []string{"silence-1", "silence-2"}, expired)
+		require.Len(t, requeued(), 1)
+		require.Equal(t, &api.MaintenanceMode{Enabled: false}, getAlerts().MaintenanceMode)
+
+		// Taking a tenant not in maintenance mode out of it changes nothing.
+		rec = put(`{"enabled":false}`)
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Len(t, expired, 2)
+		require.Empty(t, requeued())
+	})
+
+	t.Run("Maintenance mode ends", func(t *testing.T) {
+		rec := put(`{"enabled":true,"duration":"1h"}`)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		clock.FakeClock.Set(now.Add(time.Hour))
+		require.Equal(t, &api.MaintenanceMode{Enabled: false}, getAlerts().MaintenanceMode)
+	})
+}
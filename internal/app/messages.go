@@ -0,0 +1,297 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/text/language"
+)
+
+// messageKey identifies a human-readable message of the catalog of validation failures of write endpoints.
+type messageKey int
+
+const (
+	msgBadRequest messageKey = iota
+	msgFailedToPatchAlertDefinition
+	msgAlertDefinitionValueOutOfBounds
+	msgDurationOutOfBounds
+	msgThresholdOutOfBounds
+	msgInvalidDuration
+	msgRecipientNotAllowed
+	msgExpressionTooExpensive
+	msgReceiverTierLimitExceeded
+	msgInvalidExternalAlerts
+	msgExternalAlertCount
+	msgInvalidExternalAlertName
+	msgInvalidExternalAlertSeverity
+	msgReservedExternalAlertLabel
+	msgReservedExternalAlertAnnotation
+	msgInvalidExternalAlertEndTime
+	msgMaintenanceDurationOutOfBounds
+	msgEmailTemplateTooLarge
+	msgInvalidEmailTemplate
+	msgAlertCommentLength
+	msgAlertCommentParentNotFound
+	msgUnsupportedEmailLanguage
+	msgInvalidSimulatedAlertLabels
+	msgMailServerNotAllowed
+	msgMailServerNotOverridable
+	msgSenderNotOverridable
+	msgReceiverRouteConflict
+	msgInvalidEventHookURL
+	msgInvalidEventHookEvent
+	msgEventHookLimitExceeded
+	msgConfigBundleIDMissing
+	msgInvalidConfigBundleValues
+)
+
+// supportedLanguages are the languages of the message catalog, the first one being used when none of them is accepted.
+var supportedLanguages = []language.Tag{
+	language.English,
+	language.German,
+	language.Spanish,
+	language.French,
+	language.Japanese,
+	language.SimplifiedChinese,
+}
+
+var languageMatcher = language.NewMatcher(supportedLanguages)
+
+// messageCatalog holds the messages of every supported language. English messages are the ones returned before messages
+// were localized, so that responses to requests without Accept-Language are unchanged.
+var messageCatalog = map[language.Tag]map[messageKey]string{
+	language.English: {
+		msgBadRequest:                      errHTTPBadRequest,
+		msgFailedToPatchAlertDefinition:    errHTTPFailedToPatchAlertDefinition,
+		msgAlertDefinitionValueOutOfBounds: errHTTPAlertDefinitionValueOutOfBounds,
+		msgDurationOutOfBounds:             "duration value must be within [%d, %d] seconds",
+		msgThresholdOutOfBounds:            "threshold value must be within [%d, %d]",
+		msgInvalidDuration:                 "duration must be a positive number of seconds, minutes or hours, e.g. 90, 30s, 5m or 1h30m",
+		msgRecipientNotAllowed:             "email recipient is not allowed",
+		msgExpressionTooExpensive:          "alert definition expression is too expensive to evaluate",
+		msgReceiverTierLimitExceeded:       "alert receiver exceeds the service level of the project tier",
+		msgInvalidExternalAlerts:           "external alerts are invalid",
+		msgExternalAlertCount:              "between 1 and %d alerts must be pushed at once",
+		msgInvalidExternalAlertName:        "name must start with a letter followed by letters, digits or underscores",
+		msgInvalidExternalAlertSeverity:    "severity must be info, warning or critical",
+		msgReservedExternalAlertLabel:      "label name is invalid or reserved",
+		msgReservedExternalAlertAnnotation: "annotations prefixed with am_ are reserved",
+		msgInvalidExternalAlertEndTime:     "end time must not be before start time",
+		msgMaintenanceDurationOutOfBounds:  "maintenance mode duration must be between 1m and %s",
+		msgEmailTemplateTooLarge:           "email template must not exceed %d bytes",
+		msgInvalidEmailTemplate:            "email template is invalid: %s",
+		msgAlertCommentLength:              "comment must be between 1 and %d characters",
+		msgAlertCommentParentNotFound:      "comment to reply to is not a comment of the alert",
+		msgUnsupportedEmailLanguage:        "email language is not supported, expected one of: %s",
+		msgInvalidSimulatedAlertLabels:     "labels of the simulated alert are invalid",
+		msgMailServerNotAllowed:            "mail server is not allowed, expected one of: %s",
+		msgMailServerNotOverridable:        "mail server cannot be overridden as emails are relayed by alerting monitor",
+		msgSenderNotOverridable:            "sender cannot be overridden as emails are signed by alerting monitor",
+		msgReceiverRouteConflict:           "alert receiver route conflicts with the route of receiver %q, alerts would only be notified to one of them",
+		msgInvalidEventHookURL:             "callback URL must be an absolute http or https URL",
+		msgInvalidEventHookEvent:           "event must be one of definition.applied, definition.error, receiver.applied or receiver.error",
+		msgEventHookLimitExceeded:          "project must not have more than %d event hooks",
+		msgConfigBundleIDMissing:           "ID of the alert definition or receiver is missing",
+		msgInvalidConfigBundleValues:       "values of the alert definition or receiver are invalid",
+	},
+	language.German: {
+		msgBadRequest:                      "ungültige Anfrage",
+		msgFailedToPatchAlertDefinition:    "Alarmdefinition konnte nicht aktualisiert werden",
+		msgAlertDefinitionValueOutOfBounds: "Wert(e) der Alarmdefinition außerhalb des zulässigen Bereichs",
+		msgDurationOutOfBounds:             "Dauer muss zwischen %d und %d Sekunden liegen",
+		msgThresholdOutOfBounds:            "Schwellenwert muss zwischen %d und %d liegen",
+		msgInvalidDuration:                 "Dauer muss eine positive Anzahl von Sekunden, Minuten oder Stunden sein, z. B. 90, 30s, 5m oder 1h30m",
+		msgRecipientNotAllowed:             "E-Mail-Empfänger ist nicht zulässig",
+		msgExpressionTooExpensive:          "Ausdruck der Alarmdefinition ist zu aufwendig auszuwerten",
+		msgReceiverTierLimitExceeded:       "Alarmempfänger überschreitet das Service-Level der Projektstufe",
+		msgInvalidExternalAlerts:           "externe Alarme sind ungültig",
+		msgExternalAlertCount:              "es müssen zwischen 1 und %d Alarme auf einmal gesendet werden",
+		msgInvalidExternalAlertName:        "Name muss mit einem Buchstaben beginnen, gefolgt von Buchstaben, Ziffern oder Unterstrichen",
+		msgInvalidExternalAlertSeverity:    "Schweregrad muss info, warning oder critical sein",
+		msgReservedExternalAlertLabel:      "Labelname ist ungültig oder reserviert",
+		msgReservedExternalAlertAnnotation: "Annotationen mit dem Präfix am_ sind reserviert",
+		msgInvalidExternalAlertEndTime:     "Endzeit darf nicht vor der Startzeit liegen",
+		msgMaintenanceDurationOutOfBounds:  "Dauer des Wartungsmodus muss zwischen 1m und %s liegen",
+		msgEmailTemplateTooLarge:           "E-Mail-Vorlage darf %d Bytes nicht überschreiten",
+		msgInvalidEmailTemplate:            "E-Mail-Vorlage ist ungültig: %s",
+		msgAlertCommentLength:              "Kommentar muss zwischen 1 und %d Zeichen lang sein",
+		msgAlertCommentParentNotFound:      "zu beantwortender Kommentar ist kein Kommentar des Alarms",
+		msgUnsupportedEmailLanguage:        "E-Mail-Sprache wird nicht unterstützt, erwartet wird eine von: %s",
+		msgInvalidSimulatedAlertLabels:     "Labels des simulierten Alarms sind ungültig",
+		msgMailServerNotAllowed:            "Mailserver ist nicht erlaubt, erwartet wird einer von: %s",
+		msgMailServerNotOverridable:        "Mailserver kann nicht überschrieben werden, da E-Mails vom Alerting Monitor weitergeleitet werden",
+		msgSenderNotOverridable:            "Absender kann nicht überschrieben werden, da E-Mails vom Alerting Monitor signiert werden",
+		msgReceiverRouteConflict:           "Route des Alarmempfängers steht im Konflikt mit der Route des Empfängers %q, Alarme würden nur an einen von beiden gemeldet",
+		msgInvalidEventHookURL:             "Callback-URL muss eine absolute http- oder https-URL sein",
+		msgInvalidEventHookEvent:           "Ereignis muss definition.applied, definition.error, receiver.applied oder receiver.error sein",
+		msgEventHookLimitExceeded:          "Projekt darf nicht mehr als %d Event-Hooks haben",
+		msgConfigBundleIDMissing:           "ID der Alarmdefinition oder des Empfängers fehlt",
+		msgInvalidConfigBundleValues:       "Werte der Alarmdefinition oder des Empfängers sind ungültig",
+	},
+	language.Spanish: {
+		msgBadRequest:                      "solicitud incorrecta",
+		msgFailedToPatchAlertDefinition:    "no se pudo actualizar la definición de alerta",
+		msgAlertDefinitionValueOutOfBounds: "valor(es) de la definición de alerta fuera de rango",
+		msgDurationOutOfBounds:             "la duración debe estar entre %d y %d segundos",
+		msgThresholdOutOfBounds:            "el umbral debe estar entre %d y %d",
+		msgInvalidDuration:                 "la duración debe ser un número positivo de segundos, minutos u horas, p. ej. 90, 30s, 5m o 1h30m",
+		msgRecipientNotAllowed:             "el destinatario de correo electrónico no está permitido",
+		msgExpressionTooExpensive:          "la expresión de la definición de alerta es demasiado costosa de evaluar",
+		msgReceiverTierLimitExceeded:       "el receptor de alertas excede el nivel de servicio del nivel del proyecto",
+		msgInvalidExternalAlerts:           "las alertas externas no son válidas",
+		msgExternalAlertCount:              "se deben enviar entre 1 y %d alertas a la vez",
+		msgInvalidExternalAlertName:        "el nombre debe empezar por una letra seguida de letras, dígitos o guiones bajos",
+		msgInvalidExternalAlertSeverity:    "la gravedad debe ser info, warning o critical",
+		msgReservedExternalAlertLabel:      "el nombre de la etiqueta no es válido o está reservado",
+		msgReservedExternalAlertAnnotation: "las anotaciones con el prefijo am_ están reservadas",
+		msgInvalidExternalAlertEndTime:     "la hora de fin no puede ser anterior a la hora de inicio",
+		msgMaintenanceDurationOutOfBounds:  "la duración del modo de mantenimiento debe estar entre 1m y %s",
+		msgEmailTemplateTooLarge:           "la plantilla de correo electrónico no debe superar %d bytes",
+		msgInvalidEmailTemplate:            "la plantilla de correo electrónico no es válida: %s",
+		msgAlertCommentLength:              "el comentario debe tener entre 1 y %d caracteres",
+		msgAlertCommentParentNotFound:      "el comentario a responder no es un comentario de la alerta",
+		msgUnsupportedEmailLanguage:        "el idioma del correo electrónico no es compatible, se espera uno de: %s",
+		msgInvalidSimulatedAlertLabels:     "las etiquetas de la alerta simulada no son válidas",
+		msgMailServerNotAllowed:            "el servidor de correo no está permitido, se espera uno de: %s",
+		msgMailServerNotOverridable:        "el servidor de correo no se puede reemplazar porque alerting monitor retransmite los correos electrónicos",
+		msgSenderNotOverridable:            "el remitente no se puede reemplazar porque alerting monitor firma los correos electrónicos",
+		msgReceiverRouteConflict:           "la ruta del receptor de alertas entra en conflicto con la ruta del receptor %q, las alertas solo se notificarían a uno de ellos",
+		msgInvalidEventHookURL:             "la URL de callback debe ser una URL http o https absoluta",
+		msgInvalidEventHookEvent:           "el evento debe ser definition.applied, definition.error, receiver.applied o receiver.error",
+		msgEventHookLimitExceeded:          "el proyecto no debe tener más de %d hooks de eventos",
+		msgConfigBundleIDMissing:           "falta el ID de la definición de alerta o del receptor",
+		msgInvalidConfigBundleValues:       "los valores de la definición de alerta o del receptor no son válidos",
+	},
+	language.French: {
+		msgBadRequest:                      "requête invalide",
+		msgFailedToPatchAlertDefinition:    "échec de la mise à jour de la définition d'alerte",
+		msgAlertDefinitionValueOutOfBounds: "valeur(s) de la définition d'alerte hors limites",
+		msgDurationOutOfBounds:             "la durée doit être comprise entre %d et %d secondes",
+		msgThresholdOutOfBounds:            "le seuil doit être compris entre %d et %d",
+		msgInvalidDuration:                 "la durée doit être un nombre positif de secondes, minutes ou heures, par ex. 90, 30s, 5m ou 1h30m",
+		msgRecipientNotAllowed:             "le destinataire de l'e-mail n'est pas autorisé",
+		msgExpressionTooExpensive:          "l'expression de la définition d'alerte est trop coûteuse à évaluer",
+		msgReceiverTierLimitExceeded:       "le destinataire d'alertes dépasse le niveau de service du palier du projet",
+		msgInvalidExternalAlerts:           "les alertes externes sont invalides",
+		msgExternalAlertCount:              "entre 1 et %d alertes doivent être envoyées à la fois",
+		msgInvalidExternalAlertName:        "le nom doit commencer par une lettre suivie de lettres, chiffres ou tirets bas",
+		msgInvalidExternalAlertSeverity:    "la sévérité doit être info, warning ou critical",
+		msgReservedExternalAlertLabel:      "le nom de l'étiquette est invalide ou réservé",
+		msgReservedExternalAlertAnnotation: "les annotations préfixées par am_ sont réservées",
+		msgInvalidExternalAlertEndTime:     "l'heure de fin ne doit pas précéder l'heure de début",
+		msgMaintenanceDurationOutOfBounds:  "la durée du mode maintenance doit être comprise entre 1m et %s",
+		msgEmailTemplateTooLarge:           "le modèle d'e-mail ne doit pas dépasser %d octets",
+		msgInvalidEmailTemplate:            "le modèle d'e-mail n'est pas valide : %s",
+		msgAlertCommentLength:              "le commentaire doit contenir entre 1 et %d caractères",
+		msgAlertCommentParentNotFound:      "le commentaire auquel répondre n'est pas un commentaire de l'alerte",
+		msgUnsupportedEmailLanguage:        "la langue de l'e-mail n'est pas prise en charge, attendu l'une de : %s",
+		msgInvalidSimulatedAlertLabels:     "les étiquettes de l'alerte simulée sont invalides",
+		msgMailServerNotAllowed:            "le serveur de messagerie n'est pas autorisé, attendu l'un de : %s",
+		msgMailServerNotOverridable:        "le serveur de messagerie ne peut pas être remplacé car les e-mails sont relayés par alerting monitor",
+		msgSenderNotOverridable:            "l'expéditeur ne peut pas être remplacé car les e-mails sont signés par alerting monitor",
+		msgReceiverRouteConflict:           "la route du récepteur d'alertes est en conflit avec la route du récepteur %q, les alertes ne seraient notifiées qu'à l'un d'eux",
+		msgInvalidEventHookURL:             "l'URL de rappel doit être une URL http ou https absolue",
+		msgInvalidEventHookEvent:           "l'événement doit être definition.applied, definition.error, receiver.applied ou receiver.error",
+		msgEventHookLimitExceeded:          "le projet ne doit pas avoir plus de %d hooks d'événements",
+		msgConfigBundleIDMissing:           "l'ID de la définition d'alerte ou du destinataire est manquant",
+		msgInvalidConfigBundleValues:       "les valeurs de la définition d'alerte ou du destinataire sont invalides",
+	},
+	language.Japanese: {
+		msgBadRequest:                      "不正なリクエストです",
+		msgFailedToPatchAlertDefinition:    "アラート定義の更新に失敗しました",
+		msgAlertDefinitionValueOutOfBounds: "アラート定義の値が範囲外です",
+		msgDurationOutOfBounds:             "期間は %d 秒から %d 秒の間で指定してください",
+		msgThresholdOutOfBounds:            "しきい値は %d から %d の間で指定してください",
+		msgInvalidDuration:                 "期間は正の秒数、分数または時間数で指定してください（例: 90、30s、5m、1h30m）",
+		msgRecipientNotAllowed:             "このメール受信者は許可されていません",
+		msgExpressionTooExpensive:          "アラート定義の式は評価コストが高すぎます",
+		msgReceiverTierLimitExceeded:       "アラート受信者がプロジェクトのティアのサービスレベルを超えています",
+		msgInvalidExternalAlerts:           "外部アラートが不正です",
+		msgExternalAlertCount:              "一度に送信できるアラートは 1 件から %d 件です",
+		msgInvalidExternalAlertName:        "名前は英字で始まり、英字、数字またはアンダースコアで構成してください",
+		msgInvalidExternalAlertSeverity:    "重大度は info、warning または critical で指定してください",
+		msgReservedExternalAlertLabel:      "ラベル名が不正か予約されています",
+		msgReservedExternalAlertAnnotation: "am_ で始まるアノテーションは予約されています",
+		msgInvalidExternalAlertEndTime:     "終了時刻は開始時刻より前にできません",
+		msgMaintenanceDurationOutOfBounds:  "メンテナンスモードの期間は 1m から %s の間でなければなりません",
+		msgEmailTemplateTooLarge:           "メールテンプレートは %d バイトを超えてはなりません",
+		msgInvalidEmailTemplate:            "メールテンプレートが無効です: %s",
+		msgAlertCommentLength:              "コメントは1文字以上%d文字以下である必要があります",
+		msgAlertCommentParentNotFound:      "返信先のコメントはこのアラートのコメントではありません",
+		msgUnsupportedEmailLanguage:        "サポートされていないメール言語です。次のいずれかを指定してください: %s",
+		msgInvalidSimulatedAlertLabels:     "シミュレートされたアラートのラベルが無効です",
+		msgMailServerNotAllowed:            "メールサーバーは許可されていません。次のいずれかを指定してください: %s",
+		msgMailServerNotOverridable:        "メールは alerting monitor によって中継されるため、メールサーバーを上書きできません",
+		msgSenderNotOverridable:            "メールは alerting monitor によって署名されるため、送信者を上書きできません",
+		msgReceiverRouteConflict:           "アラート受信者のルートが受信者 %q のルートと競合しています。アラートはどちらか一方にのみ通知されます",
+		msgInvalidEventHookURL:             "コールバックURLは絶対的なhttpまたはhttpsのURLである必要があります",
+		msgInvalidEventHookEvent:           "イベントは definition.applied、definition.error、receiver.applied、receiver.error のいずれかである必要があります",
+		msgEventHookLimitExceeded:          "プロジェクトのイベントフックは%d個以下である必要があります",
+		msgConfigBundleIDMissing:           "アラート定義または受信者のIDがありません",
+		msgInvalidConfigBundleValues:       "アラート定義または受信者の値が無効です",
+	},
+	language.SimplifiedChinese: {
+		msgBadRequest:                      "请求无效",
+		msgFailedToPatchAlertDefinition:    "更新告警定义失败",
+		msgAlertDefinitionValueOutOfBounds: "告警定义的值超出范围",
+		msgDurationOutOfBounds:             "持续时间必须介于 %d 到 %d 秒之间",
+		msgThresholdOutOfBounds:            "阈值必须介于 %d 到 %d 之间",
+		msgInvalidDuration:                 "持续时间必须是正数的秒、分钟或小时，例如 90、30s、5m 或 1h30m",
+		msgRecipientNotAllowed:             "不允许的电子邮件收件人",
+		msgExpressionTooExpensive:          "告警定义的表达式评估开销过大",
+		msgReceiverTierLimitExceeded:       "告警接收者超出了项目等级的服务级别",
+		msgInvalidExternalAlerts:           "外部告警无效",
+		msgExternalAlertCount:              "每次必须推送 1 到 %d 条告警",
+		msgInvalidExternalAlertName:        "名称必须以字母开头，后跟字母、数字或下划线",
+		msgInvalidExternalAlertSeverity:    "严重级别必须是 info、warning 或 critical",
+		msgReservedExternalAlertLabel:      "标签名称无效或为保留名称",
+		msgReservedExternalAlertAnnotation: "以 am_ 为前缀的注解为保留注解",
+		msgInvalidExternalAlertEndTime:     "结束时间不能早于开始时间",
+		msgMaintenanceDurationOutOfBounds:  "维护模式的持续时间必须介于 1m 和 %s 之间",
+		msgEmailTemplateTooLarge:           "电子邮件模板不得超过 %d 字节",
+		msgInvalidEmailTemplate:            "电子邮件模板无效：%s",
+		msgAlertCommentLength:              "评论长度必须在 1 到 %d 个字符之间",
+		msgAlertCommentParentNotFound:      "要回复的评论不是该告警的评论",
+		msgUnsupportedEmailLanguage:        "不支持的电子邮件语言，应为以下之一：%s",
+		msgInvalidSimulatedAlertLabels:     "模拟告警的标签无效",
+		msgMailServerNotAllowed:            "不允许使用该邮件服务器，应为以下之一：%s",
+		msgMailServerNotOverridable:        "由于电子邮件由 alerting monitor 中继，无法覆盖邮件服务器",
+		msgSenderNotOverridable:            "由于电子邮件由 alerting monitor 签名，无法覆盖发件人",
+		msgReceiverRouteConflict:           "告警接收器的路由与接收器 %q 的路由冲突，告警只会通知其中一个",
+		msgInvalidEventHookURL:             "回调 URL 必须是绝对的 http 或 https URL",
+		msgInvalidEventHookEvent:           "事件必须是 definition.applied、definition.error、receiver.applied 或 receiver.error 之一",
+		msgEventHookLimitExceeded:          "项目的事件钩子不得超过 %d 个",
+		msgConfigBundleIDMissing:           "缺少告警定义或接收者的 ID",
+		msgInvalidConfigBundleValues:       "告警定义或接收者的值无效",
+	},
+}
+
+// negotiateLanguage returns the supported language best matching the value of an Accept-Language header, English if none
+// matches or the value is invalid.
+func negotiateLanguage(acceptLanguage string) language.Tag {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return supportedLanguages[0]
+	}
+	_, index, confidence := languageMatcher.Match(tags...)
+	if confidence == language.No {
+		return supportedLanguages[0]
+	}
+	return supportedLanguages[index]
+}
+
+// responseLanguage negotiates the language of the localized messages of a response from the Accept-Language header of the
+// request, and sets the Content-Language header of the response accordingly.
+func responseLanguage(ctx echo.Context) language.Tag {
+	lang := negotiateLanguage(ctx.Request().Header.Get("Accept-Language"))
+	ctx.Response().Header().Set("Content-Language", lang.String())
+	return lang
+}
+
+// localize returns the message of the catalog in the given language, formatted with the given arguments.
+func localize(lang language.Tag, key messageKey, args ...any) string {
+	return fmt.Sprintf(messageCatalog[lang][key], args...)
+}
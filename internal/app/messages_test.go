@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func TestNegotiateLanguage(t *testing.T) {
+	testCases := []struct {
+		name           string
+		acceptLanguage string
+		expected       language.Tag
+	}{
+		{
+			name:     "No Accept-Language header",
+			expected: language.English,
+		},
+		{
+			name:           "Exact match",
+			acceptLanguage: "fr",
+			expected:       language.French,
+		},
+		{
+			name:           "Regional variant",
+			acceptLanguage: "de-DE,de;q=0.9,en;q=0.8",
+			expected:       language.German,
+		},
+		{
+			name:           "Preferred language by quality",
+			acceptLanguage: "en;q=0.5,ja;q=0.9",
+			expected:       language.Japanese,
+		},
+		{
+			name:           "Chinese script",
+			acceptLanguage: "zh-CN",
+			expected:       language.SimplifiedChinese,
+		},
+		{
+			name:           "Unsupported language",
+			acceptLanguage: "xx",
+			expected:       language.English,
+		},
+		{
+			name:           "Invalid header value",
+			acceptLanguage: "1;q=abc",
+			expected:       language.English,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, negotiateLanguage(tc.acceptLanguage))
+		})
+	}
+}
+
+func TestMessageCatalog(t *testing.T) {
+	for _, lang := range supportedLanguages {
+		require.Len(t, messageCatalog[lang], len(messageCatalog[language.English]), "language %v misses messages", lang)
+	}
+	require.Len(t, messageCatalog, len(supportedLanguages))
+	require.Equal(t, "duration value must be within [15, 30] seconds", localize(language.English, msgDurationOutOfBounds, 15, 30))
+}
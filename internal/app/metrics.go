@@ -0,0 +1,159 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/correlation"
+	db "github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+const (
+	// configStateQueryTimeout is the timeout of the database queries run on every scrape of the configuration state metrics.
+	configStateQueryTimeout = 10 * time.Second
+	// metricsReadHeaderTimeout is the timeout of reading the headers of the requests of scrapes.
+	metricsReadHeaderTimeout = 10 * time.Second
+)
+
+// newMetricsServer returns the server of the Prometheus metrics listening on the given address, which only serves the metrics.
+func newMetricsServer(address string) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("GET "+metricsEndpoint, promhttp.Handler())
+	return &http.Server{Addr: address, Handler: mux, ReadHeaderTimeout: metricsReadHeaderTimeout}
+}
+
+var (
+	definitionStates = []models.AlertDefinitionState{
+		models.DefinitionNew, models.DefinitionModified, models.DefinitionPending, models.DefinitionApplied, models.DefinitionError,
+	}
+	receiverStates = []models.ReceiverState{
+		models.ReceiverNew, models.ReceiverModified, models.ReceiverPending, models.ReceiverApplied, models.ReceiverError,
+	}
+)
+
+// outOfBoundsRejections counts the changes of alert definition values rejected because a value is out of its bounds, so that
+// bounds too tight for real deployments can be found.
+var outOfBoundsRejections = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "alerting_monitor_definition_value_out_of_bounds_total",
+	Help: "Number of changes of alert definition values rejected because a value is out of its bounds.",
+}, []string{"tenant", "definition", "value"})
+
+// unlinkedAlerts is the number of active alerts of tenants whose am_uuid annotation does not match any of their alert definitions,
+// per reason, as of the last linkage check of each tenant.
+var unlinkedAlerts = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "alerting_monitor_unlinked_alerts",
+	Help: "Number of active alerts of a tenant whose am_uuid annotation does not match any of its alert definitions.",
+}, []string{"tenant", "reason"})
+
+// malformedAlerts counts the malformed alerts left out of the alert lists of tenants, per reason, so that mislabeled rules
+// can be found.
+var malformedAlerts = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "alerting_monitor_malformed_alerts_total",
+	Help: "Number of malformed alerts left out of the alert lists of a tenant.",
+}, []string{"tenant", "reason"})
+
+// certificateExpiry is the expiry of the TLS certificate of each downstream endpoint, as of the last check of its certificate.
+var certificateExpiry = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "alerting_monitor_certificate_expiry_timestamp_seconds",
+	Help: "Expiry of the TLS certificate presented by a downstream endpoint, in seconds since the Unix epoch.",
+}, []string{"endpoint"})
+
+// configStateCollector exports the state of the latest version of the alert definitions and receivers of all tenants, so that
+// the health of their application can be tracked without calling the REST API. Each alert definition and receiver has a series
+// per state, set to 1 for its current state and 0 otherwise. States are read from the database on every scrape.
+type configStateCollector struct {
+	states db.ConfigStateReporter
+
+	definitionState *prometheus.Desc
+	receiverState   *prometheus.Desc
+}
+
+func newConfigStateCollector(states db.ConfigStateReporter) *configStateCollector {
+	return &configStateCollector{
+		states: states,
+		definitionState: prometheus.NewDesc("alerting_monitor_definition_state",
+			"State of the latest version of an alert definition, 1 for the current state and 0 otherwise.",
+			[]string{"tenant", "uuid", "name", "state"}, nil),
+		receiverState: prometheus.NewDesc("alerting_monitor_receiver_state",
+			"State of the latest version of a receiver, 1 for the current state and 0 otherwise.",
+			[]string{"tenant", "uuid", "name", "state"}, nil),
+	}
+}
+
+func (c *configStateCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.definitionState
+	ch <- c.receiverState
+}
+
+// Collect sends the state metrics of alert definitions and receivers. Failing to read either from the database is logged and
+// their metrics are left out of the scrape, so that the other metrics are still exported.
+func (c *configStateCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), configStateQueryTimeout)
+	defer cancel()
+
+	if defs, err := c.states.GetLatestAlertDefinitionStates(ctx); err != nil {
+		slog.Error("Failed to get alert definition states", slog.Any("error", err))
+	} else {
+		for _, def := range defs {
+			for _, state := range definitionStates {
+				ch <- prometheus.MustNewConstMetric(c.definitionState, prometheus.GaugeValue, stateValue(def.State == state),
+					def.TenantID, def.UUID.String(), def.Name, string(state))
+			}
+		}
+	}
+
+	if recvs, err := c.states.GetLatestReceiverStates(ctx); err != nil {
+		slog.Error("Failed to get receiver states", slog.Any("error", err))
+	} else {
+		for _, recv := range recvs {
+			for _, state := range receiverStates {
+				ch <- prometheus.MustNewConstMetric(c.receiverState, prometheus.GaugeValue, stateValue(recv.State == state),
+					recv.TenantID, recv.UUID.String(), recv.Name, string(state))
+			}
+		}
+	}
+}
+
+// stateValue returns the value of the series of a state, which is 1 for the current state and 0 otherwise.
+func stateValue(current bool) float64 {
+	if current {
+		return 1
+	}
+	return 0
+}
+
+// RecordOutOfBoundsRejection counts and logs the rejection of a change of the values of an alert definition because of
+// db.ErrValueOutOfBounds, along with the tenant, the alert definition, and the requested value and its bounds if the error
+// tells them.
+func RecordOutOfBoundsRejection(ctx context.Context, tenantID api.TenantID, id uuid.UUID, err error) {
+	var oob *db.OutOfBoundsError
+	if !errors.As(err, &oob) {
+		oob = &db.OutOfBoundsError{}
+	}
+	outOfBoundsRejections.WithLabelValues(tenantID, oob.Definition, oob.Value).Inc()
+
+	slog.LogAttrs(ctx, slog.LevelWarn, "Alert definition value out of bounds rejected",
+		slog.String("tenant", tenantID),
+		slog.String("uuid", id.String()),
+		slog.String("definition", oob.Definition),
+		slog.String("value", oob.Value),
+		slog.Int64("requested", oob.Requested),
+		slog.Int64("min", oob.Min),
+		slog.Int64("max", oob.Max),
+		slog.String("request_id", correlation.FromContext(ctx)),
+		slog.String("component", "alerting-monitor"),
+	)
+}
@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+type ConfigStateMock struct {
+	mock.Mock
+}
+
+func (m *ConfigStateMock) GetLatestAlertDefinitionStates(ctx context.Context) ([]models.AlertDefinition, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]models.AlertDefinition), args.Error(1)
+}
+
+func (m *ConfigStateMock) GetLatestReceiverStates(ctx context.Context) ([]models.Receiver, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]models.Receiver), args.Error(1)
+}
+
+func TestConfigStateCollector(t *testing.T) {
+	defUUID := uuid.MustParse("6f1c2d4e-7a8b-4c9d-8e0f-1a2b3c4d5e6f")
+	recvUUID := uuid.MustParse("0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d")
+
+	t.Run("States of alert definitions and receivers", func(t *testing.T) {
+		statesMock := new(ConfigStateMock)
+		statesMock.On("GetLatestAlertDefinitionStates", mock.Anything).Return([]models.AlertDefinition{
+			{TenantID: "tenant", UUID: defUUID, Name: "HighCPUUsage", State: models.DefinitionError},
+		}, nil).Once()
+		statesMock.On("GetLatestReceiverStates", mock.Anything).Return([]models.Receiver{
+			{TenantID: "tenant", UUID: recvUUID, Name: "alert-monitor-config", State: models.ReceiverApplied},
+		}, nil).Once()
+
+		expected := `
+# HELP alerting_monitor_definition_state State of the latest version of an alert definition, 1 for the current state and 0 otherwise.
+# TYPE alerting_monitor_definition_state gauge
+alerting_monitor_definition_state{name="HighCPUUsage",state="Applied",tenant="tenant",uuid="6f1c2d4e-7a8b-4c9d-8e0f-1a2b3c4d5e6f"} 0
+alerting_monitor_definition_state{name="HighCPUUsage",state="Error",tenant="tenant",uuid="6f1c2d4e-7a8b-4c9d-8e0f-1a2b3c4d5e6f"} 1
+alerting_monitor_definition_state{name="HighCPUUsage",state="Modified",tenant="tenant",uuid="6f1c2d4e-7a8b-4c9d-8e0f-1a2b3c4d5e6f"} 0
+alerting_monitor_definition_state{name="HighCPUUsage",state="New",tenant="tenant",uuid="6f1c2d4e-7a8b-4c9d-8e0f-1a2b3c4d5e6f"} 0
+alerting_monitor_definition_state{name="HighCPUUsage",state="Pending",tenant="tenant",uuid="6f1c2d4e-7a8b-4c9d-8e0f-1a2b3c4d5e6f"} 0
+# HELP alerting_monitor_receiver_state State of the latest version of a receiver, 1 for the current state and 0 otherwise.
+# TYPE alerting_monitor_receiver_state gauge
+alerting_monitor_receiver_state{name="alert-monitor-config",state="Applied",tenant="tenant",uuid="0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d"} 1
+alerting_monitor_receiver_state{name="alert-monitor-config",state="Error",tenant="tenant",uuid="0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d"} 0
+alerting_monitor_receiver_state{name="alert-monitor-config",state="Modified",tenant="tenant",uuid="0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d"} 0
+alerting_monitor_receiver_state{name="alert-monitor-config",state="New",tenant="tenant",uuid="0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d"} 0
+alerting_monitor_receiver_state{name="alert-monitor-config",state="Pending",tenant="tenant",uuid="0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d"} 0
+`
+		require.NoError(t, testutil.CollectAndCompare(newConfigStateCollector(statesMock), strings.NewReader(expected)))
+		statesMock.AssertExpectations(t)
+	})
+
+	t.Run("Failing to get alert definition states leaves out their metrics", func(t *testing.T) {
+		statesMock := new(ConfigStateMock)
+		statesMock.On("GetLatestAlertDefinitionStates", mock.Anything).Return([]models.AlertDefinition(nil), errors.New("mock error")).Once()
+		statesMock.On("GetLatestReceiverStates", mock.Anything).Return([]models.Receiver{
+			{TenantID: "tenant", UUID: recvUUID, Name: "alert-monitor-config", State: models.ReceiverPending},
+		}, nil).Once()
+
+		collector := newConfigStateCollector(statesMock)
+		require.Equal(t, len(receiverStates), testutil.CollectAndCount(collector))
+		statesMock.AssertExpectations(t)
+	})
+}
+
+func TestRecordOutOfBoundsRejection(t *testing.T) {
+	id := uuid.New()
+	counter := outOfBoundsRejections.WithLabelValues("tenant", "HighCPUUsage", "threshold")
+	before := testutil.ToFloat64(counter)
+
+	RecordOutOfBoundsRejection(t.Context(), "tenant", id, fmt.Errorf("failed to set threshold: %w",
+		&database.OutOfBoundsError{Definition: "HighCPUUsage", Value: "threshold", Requested: 120, Min: 0, Max: 100}))
+	require.InDelta(t, before+1, testutil.ToFloat64(counter), 0)
+
+	unknown := outOfBoundsRejections.WithLabelValues("tenant", "", "")
+	before = testutil.ToFloat64(unknown)
+	RecordOutOfBoundsRejection(t.Context(), "tenant", id, database.ErrValueOutOfBounds)
+	require.InDelta(t, before+1, testutil.ToFloat64(unknown), 0)
+}
+
+func TestMetricsServer(t *testing.T) {
+	handler := newMetricsServer(":9090").Handler
+
+	t.Run("Serve metrics", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, metricsEndpoint, nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Contains(t, rec.Body.String(), "go_goroutines")
+	})
+
+	t.Run("API is not served", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, statusEndpoint, nil))
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
@@ -0,0 +1,364 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"gopkg.in/yaml.v2"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/correlation"
+	db "github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/rules"
+)
+
+const (
+	errHTTPNoiseScoringUnavailable       = "noise scoring of alert definitions is not available"
+	errHTTPFailedToScoreAlertDefinitions = "failed to score alert definitions"
+)
+
+// noiseScorer scores the alert definitions of tenants by how noisy their alerts were over the lookback of the firing history:
+// how often they fired and resolved, from the ALERTS series of Mimir, and how much of the time they fired they were silenced,
+// from the silences of alertmanager, as alerts are acknowledged by silencing them. Scores are kept for the recommendations
+// endpoint, either computed periodically for all tenants with alert definitions or on request for a tenant not scored yet.
+type noiseScorer struct {
+	handler *ServerInterfaceHandler
+	states  db.ConfigStateReporter
+	config  config.NoiseScoringConfig
+	client  *http.Client
+
+	mu     sync.Mutex
+	scores map[api.TenantID]api.AlertDefinitionRecommendationList
+}
+
+func newNoiseScorer(handler *ServerInterfaceHandler, states db.ConfigStateReporter, cfg config.NoiseScoringConfig) *noiseScorer {
+	return &noiseScorer{
+		handler: handler,
+		states:  states,
+		config:  cfg,
+		client:  http.DefaultClient,
+		scores:  make(map[api.TenantID]api.AlertDefinitionRecommendationList),
+	}
+}
+
+// run scores the alert definitions of all tenants every interval, until the context is done.
+func (s *noiseScorer) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.scoreAll(ctx)
+		}
+	}
+}
+
+// scoreAll scores the alert definitions of all tenants with alert definitions. Tenants which no longer have alert definitions
+// are dropped, and tenants whose alert definitions cannot be scored keep their previous scores.
+func (s *noiseScorer) scoreAll(ctx context.Context) {
+	states, err := s.states.GetLatestAlertDefinitionStates(ctx)
+	if err != nil {
+		slog.Error("Failed to get alert definitions to score their noise", slog.Any("error", err))
+		return
+	}
+
+	s.mu.Lock()
+	previous := s.scores
+	s.mu.Unlock()
+
+	scores := make(map[api.TenantID]api.AlertDefinitionRecommendationList)
+	for _, def := range states {
+		if _, ok := scores[def.TenantID]; ok {
+			continue
+		}
+		list, err := s.score(ctx, def.TenantID)
+		if err != nil {
+			slog.Error("Failed to score noise of alert definitions", slog.String("tenant", def.TenantID), slog.Any("error", err))
+			if list, ok := previous[def.TenantID]; ok {
+				scores[def.TenantID] = list
+			}
+			continue
+		}
+		scores[def.TenantID] = list
+	}
+
+	s.mu.Lock()
+	s.scores = scores
+	s.mu.Unlock()
+}
+
+// recommendations returns the scores of the alert definitions of the given tenant, scoring them if they were not scored yet.
+// Scores computed on request are only kept if alert definitions are scored periodically, so that they are refreshed.
+func (s *noiseScorer) recommendations(ctx context.Context, tenantID api.TenantID) (api.AlertDefinitionRecommendationList, error) {
+	s.mu.Lock()
+	list, ok := s.scores[tenantID]
+	s.mu.Unlock()
+	if ok {
+		return list, nil
+	}
+
+	list, err := s.score(ctx, tenantID)
+	if err != nil {
+		return api.AlertDefinitionRecommendationList{}, err
+	}
+	if s.config.Interval > 0 {
+		s.mu.Lock()
+		s.scores[tenantID] = list
+		s.mu.Unlock()
+	}
+	return list, nil
+}
+
+// score scores the enabled alert definitions of the given tenant, the noisiest first. The alerts of an alert definition are
+// matched by the alert name of its rule, both in the ALERTS series and by the matchers of silences.
+func (s *noiseScorer) score(ctx context.Context, tenantID api.TenantID) (api.AlertDefinitionRecommendationList, error) {
+	defs, _, err := s.handler.definitions.GetLatestAlertDefinitionList(ctx, tenantID, db.ListOptions{})
+	if err != nil {
+		return api.AlertDefinitionRecommendationList{}, fmt.Errorf("failed to get alert definitions: %w", err)
+	}
+
+	silences, err := s.silences(ctx, tenantID)
+	if err != nil {
+		return api.AlertDefinitionRecommendationList{}, err
+	}
+
+	now := clock.TimeNowFn().UTC()
+	list := api.AlertDefinitionRecommendationList{
+		Recommendations: make([]api.AlertDefinitionRecommendation, 0, len(defs)),
+		ScoredAt:        now,
+	}
+	for _, d := range defs {
+		if d.Category == models.CategoryMaintenance || (d.Values.Enabled != nil && !*d.Values.Enabled) {
+			continue
+		}
+
+		var rule rules.Rule
+		if err := yaml.Unmarshal([]byte(d.Template), &rule); err != nil {
+			return api.AlertDefinitionRecommendationList{}, fmt.Errorf("failed to unmarshal template of alert definition %q: %w",
+				d.ID, err)
+		}
+		firings, err := s.handler.queryAlertFirings(ctx, tenantID, map[string]string{"alertname": rule.Alert})
+		if err != nil {
+			return api.AlertDefinitionRecommendationList{}, fmt.Errorf("failed to query firing history of alert definition %q: %w",
+				d.ID, err)
+		}
+
+		matching := slices.DeleteFunc(slices.Clone(silences), func(silence alertmanagerSilence) bool {
+			return !silence.matchesAlertName(rule.Alert)
+		})
+		list.Recommendations = append(list.Recommendations, scoreAlertDefinition(d, firings, matching, now, s.config))
+	}
+
+	slices.SortStableFunc(list.Recommendations, func(a, b api.AlertDefinitionRecommendation) int {
+		return cmp.Or(cmp.Compare(b.Score, a.Score), cmp.Compare(a.Name, b.Name))
+	})
+	return list, nil
+}
+
+// silences gets the silences of the given tenant from its alertmanager, expired ones included as long as alertmanager keeps
+// them.
+func (s *noiseScorer) silences(ctx context.Context, tenantID api.TenantID) ([]alertmanagerSilence, error) {
+	amURL, err := s.handler.alertManagerURL(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alertmanager shard: %w", err)
+	}
+
+	query := url.Values{"filter": {TenantFilter(s.handler.tenantLabel(), tenantID)}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, amURL+"/api/v2/silences?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	correlation.SetHeader(req)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("alertmanager returned HTTP status code: %v", resp.StatusCode)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	var silences []alertmanagerSilence
+	if err := json.Unmarshal(body, &silences); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal silences: %w", err)
+	}
+	// Silences of other tenants are left out, even though their matchers may match the alerts of the tenant.
+	return slices.DeleteFunc(silences, func(silence alertmanagerSilence) bool {
+		return !silence.ownedBy(s.handler.tenantLabel(), tenantID)
+	}), nil
+}
+
+// matchesAlertName reports whether the matchers of the alert name of the silence match the given alert name. Silences without
+// a matcher of the alert name do not match, as they are not specific to the alerts of an alert definition.
+func (s silenceMatchers) matchesAlertName(name string) bool {
+	found := false
+	for _, m := range s.Matchers {
+		if m.Name != "alertname" {
+			continue
+		}
+		matched := m.Value == name
+		if m.IsRegex {
+			re, err := regexp.Compile("^(?:" + m.Value + ")$")
+			matched = err == nil && re.MatchString(name)
+		}
+		if m.IsEqual != nil && !*m.IsEqual {
+			matched = !matched
+		}
+		if !matched {
+			return false
+		}
+		found = true
+	}
+	return found
+}
+
+// scoreAlertDefinition scores an alert definition from the periods its alerts fired over the lookback until the given time,
+// and the silences matching its alerts. The score is the number of firings per day, weighted up by the fraction of the firing
+// time the alerts were silenced. Raising the duration is suggested if the alerts fire as often as the flapping rate, by the
+// median length of the resolved firings so that about half of them would not have fired. Raising the threshold is suggested
+// if the alerts were silenced for as much as the silence coverage of the time they fired, since they are not acted on.
+func scoreAlertDefinition(d *models.DBAlertDefinition, firings []api.AlertFiring, silences []alertmanagerSilence, now time.Time,
+	cfg config.NoiseScoringConfig) api.AlertDefinitionRecommendation {
+	rec := api.AlertDefinitionRecommendation{
+		AlertDefinitionId: d.ID,
+		Name:              d.Name,
+		Firings:           len(firings),
+		Adjustments:       make([]api.AlertDefinitionAdjustment, 0),
+	}
+
+	var firingTime, silencedTime time.Duration
+	var lengths []time.Duration
+	for _, firing := range firings {
+		// The end of a resolved firing is its last sample, it fired until the next one.
+		end := now
+		if firing.EndsAt != nil {
+			end = firing.EndsAt.Add(alertFiringStep)
+			lengths = append(lengths, end.Sub(firing.StartsAt))
+			rec.Resolutions++
+		}
+		firingTime += end.Sub(firing.StartsAt)
+		silencedTime += silencedDuration(firing.StartsAt, end, silences)
+	}
+	if firingTime > 0 {
+		rec.SilenceCoverage = float64(silencedTime) / float64(firingTime)
+	}
+
+	perDay := float64(len(firings)) / alertFiringLookback.Hours() * 24
+	rec.Score = perDay * (1 + rec.SilenceCoverage)
+
+	if cfg.FlappingRate > 0 && perDay >= cfg.FlappingRate && len(lengths) > 0 {
+		slices.Sort(lengths)
+		// The median is rounded up to the minute, the resolution of the firing history.
+		duration := (lengths[len(lengths)/2] + alertFiringStep - 1).Truncate(alertFiringStep)
+		if d.Values.Duration != nil {
+			duration += time.Duration(*d.Values.Duration) * time.Second
+		}
+		seconds := int64(duration / time.Second)
+		rec.Adjustments = append(rec.Adjustments, api.AlertDefinitionAdjustment{
+			Parameter: api.AdjustDuration,
+			Reason: fmt.Sprintf("alerts fired %.1f times per day, about half of them would not have fired with a duration of %v",
+				perDay, duration),
+			DurationSeconds: &seconds,
+		})
+	}
+	if cfg.SilenceCoverage > 0 && firingTime > 0 && rec.SilenceCoverage >= cfg.SilenceCoverage {
+		rec.Adjustments = append(rec.Adjustments, api.AlertDefinitionAdjustment{
+			Parameter: api.AdjustThreshold,
+			Reason: fmt.Sprintf("alerts were silenced %.0f%% of the time they fired, so they are not acted on at the current threshold",
+				rec.SilenceCoverage*100),
+		})
+	}
+	return rec
+}
+
+// silencedDuration returns the time between the given start and end during which at least one of the given silences was
+// active.
+func silencedDuration(start, end time.Time, silences []alertmanagerSilence) time.Duration {
+	type interval struct{ start, end time.Time }
+	intervals := make([]interval, 0, len(silences))
+	for _, silence := range silences {
+		from, to := maxTime(start, silence.StartsAt), minTime(end, silence.EndsAt)
+		if from.Before(to) {
+			intervals = append(intervals, interval{from, to})
+		}
+	}
+	slices.SortFunc(intervals, func(a, b interval) int { return a.start.Compare(b.start) })
+
+	// Overlapping silences are merged, so that the time they both silence is only counted once.
+	var silenced time.Duration
+	var covered time.Time
+	for _, i := range intervals {
+		if i.start.Before(covered) {
+			i.start = covered
+		}
+		if i.start.Before(i.end) {
+			silenced += i.end.Sub(i.start)
+			covered = i.end
+		}
+	}
+	return silenced
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+// GetAlertDefinitionRecommendations gets the alert definitions of a tenant scored by how noisy their alerts were, along with
+// the adjustments of their threshold or duration suggested to reduce the noise.
+func (w *ServerInterfaceHandler) GetAlertDefinitionRecommendations(ctx echo.Context, tenantID api.TenantID) error {
+	if w.noise == nil {
+		logWarn(ctx, "Mimir query URL is not configured, alert definitions cannot be scored")
+		return ctx.JSON(http.StatusServiceUnavailable, api.HttpError{
+			Code:      http.StatusServiceUnavailable,
+			Message:   errHTTPNoiseScoringUnavailable,
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+
+	list, err := w.noise.recommendations(ctx.Request().Context(), tenantID)
+	if err != nil {
+		logError(ctx, "Failed to score noise of alert definitions", err)
+		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToScoreAlertDefinitions,
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+	return ctx.JSON(http.StatusOK, list)
+}
@@ -0,0 +1,274 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/testutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+func TestScoreAlertDefinition(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	duration := int64(300)
+	def := &models.DBAlertDefinition{
+		ID:     uuid.New(),
+		Name:   "HostCPUUsageHigh",
+		Values: models.DBAlertDefinitionValues{Duration: &duration},
+	}
+	cfg := config.NoiseScoringConfig{FlappingRate: 10, SilenceCoverage: 0.5}
+
+	t.Run("Flapping alerts", func(t *testing.T) {
+		// 10 firings per day, each sampled over 5 minutes.
+		var firings []api.AlertFiring
+		for i := range 70 {
+			start := now.Add(-time.Duration(i+1) * 2 * time.Hour)
+			end := start.Add(4 * time.Minute)
+			firings = append(firings, api.AlertFiring{StartsAt: start, EndsAt: &end})
+		}
+
+		rec := scoreAlertDefinition(def, firings, nil, now, cfg)
+		require.Equal(t, def.ID, rec.AlertDefinitionId)
+		require.Equal(t, 70, rec.Firings)
+		require.Equal(t, 70, rec.Resolutions)
+		require.Zero(t, rec.SilenceCoverage)
+		require.InDelta(t, 10, rec.Score, 1e-9)
+
+		seconds := int64(600)
+		require.Equal(t, []api.AlertDefinitionAdjustment{{
+			Parameter:       api.AdjustDuration,
+			Reason:          "alerts fired 10.0 times per day, about half of them would not have fired with a duration of 10m0s",
+			DurationSeconds: &seconds,
+		}}, rec.Adjustments)
+	})
+
+	t.Run("Silenced alerts", func(t *testing.T) {
+		resolvedAt := now.Add(-9*time.Hour - time.Minute)
+		firings := []api.AlertFiring{
+			{StartsAt: now.Add(-10 * time.Hour), EndsAt: &resolvedAt},
+			{StartsAt: now.Add(-time.Hour)},
+		}
+		// Overlapping silences only silence the firing still going on, which is half of the firing time.
+		silences := []alertmanagerSilence{
+			{StartsAt: now.Add(-90 * time.Minute), EndsAt: now.Add(time.Hour)},
+			{StartsAt: now.Add(-30 * time.Minute), EndsAt: now},
+		}
+
+		rec := scoreAlertDefinition(def, firings, silences, now, cfg)
+		require.Equal(t, 2, rec.Firings)
+		require.Equal(t, 1, rec.Resolutions)
+		require.InDelta(t, 0.5, rec.SilenceCoverage, 1e-9)
+		require.InDelta(t, 2.0/7*1.5, rec.Score, 1e-9)
+		require.Equal(t, []api.AlertDefinitionAdjustment{{
+			Parameter: api.AdjustThreshold,
+			Reason:    "alerts were silenced 50% of the time they fired, so they are not acted on at the current threshold",
+		}}, rec.Adjustments)
+	})
+
+	t.Run("Quiet alerts", func(t *testing.T) {
+		require.Equal(t, api.AlertDefinitionRecommendation{
+			AlertDefinitionId: def.ID,
+			Name:              def.Name,
+			Adjustments:       []api.AlertDefinitionAdjustment{},
+		}, scoreAlertDefinition(def, nil, nil, now, cfg))
+	})
+}
+
+func TestSilenceMatchesAlertName(t *testing.T) {
+	notEqual := false
+	for name, tc := range map[string]struct {
+		matchers []silenceMatcher
+		expected bool
+	}{
+		"Equal":            {[]silenceMatcher{{Name: "alertname", Value: "HostCPUUsageHigh"}}, true},
+		"Other alert name": {[]silenceMatcher{{Name: "alertname", Value: "HostMemoryUsageHigh"}}, false},
+		"Regex":            {[]silenceMatcher{{Name: "alertname", Value: "Host.*High", IsRegex: true}}, true},
+		"Partial regex":    {[]silenceMatcher{{Name: "alertname", Value: "Host", IsRegex: true}}, false},
+		"Not equal":        {[]silenceMatcher{{Name: "alertname", Value: "HostMemoryUsageHigh", IsEqual: &notEqual}}, true},
+		"No alert name":    {[]silenceMatcher{{Name: "projectId", Value: "edgenode"}}, false},
+		"All alert names must match": {[]silenceMatcher{
+			{Name: "alertname", Value: "Host.*", IsRegex: true},
+			{Name: "alertname", Value: "HostMemoryUsageHigh"},
+		}, false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expected, silenceMatchers{Matchers: tc.matchers}.matchesAlertName("HostCPUUsageHigh"))
+		})
+	}
+}
+
+func TestGetAlertDefinitionRecommendations(t *testing.T) {
+	clock.SetFakeClock()
+	defer clock.UnsetFakeClock()
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	clock.FakeClock.Set(now)
+
+	tenantID := "edgenode"
+	noisyID, quietID := uuid.New(), uuid.New()
+	duration := int64(60)
+	disabled := false
+
+	alertManager := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v2/silences", r.URL.Path)
+		require.Contains(t, r.URL.Query()["filter"], `projectId="`+tenantID+`"`)
+		fmt.Fprintf(w, `[`+
+			`{"id":"owned","matchers":[{"name":"projectId","value":%[1]q,"isRegex":false},`+
+			`{"name":"alertname","value":"HostCPUUsageHigh","isRegex":false}],"startsAt":%[2]q,"endsAt":%[3]q},`+
+			`{"id":"foreign","matchers":[{"name":"alertname","value":"HostCPUUsageHigh","isRegex":false}],`+
+			`"startsAt":%[2]q,"endsAt":%[3]q}]`,
+			tenantID, now.Add(-24*time.Hour).Format(time.RFC3339), now.Add(time.Hour).Format(time.RFC3339))
+	}))
+	defer alertManager.Close()
+
+	var queries atomic.Int32
+	mimir := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries.Add(1)
+		require.Equal(t, "/prometheus/api/v1/query_range", r.URL.Path)
+
+		// The noisy alerts fire for a minute every other hour and are silenced since a day ago, the quiet ones never fire.
+		var values []string
+		if r.URL.Query().Get("query") == `ALERTS{alertstate="firing",alertname="HostCPUUsageHigh"}` {
+			for i := range 84 {
+				values = append(values, fmt.Sprintf(`[%d,"1"]`, now.Add(-time.Duration(i+1)*2*time.Hour).Unix()))
+			}
+		} else {
+			require.Equal(t, `ALERTS{alertstate="firing",alertname="HostMemoryUsageHigh"}`, r.URL.Query().Get("query"))
+		}
+		fmt.Fprintf(w, `{"status":"success","data":{"resultType":"matrix","result":[{"metric":{},"values":[%s]}]}}`,
+			strings.Join(values, ","))
+	}))
+	defer mimir.Close()
+
+	configfile := conf
+	configfile.AlertManager.URL = alertManager.URL
+	configfile.Mimir.QueryURL = mimir.URL
+
+	definitions := func() *DefinitionMock {
+		mDefinition := &DefinitionMock{}
+		mDefinition.On("GetLatestAlertDefinitionList", mock.Anything, tenantID, database.ListOptions{}).
+			Return([]*models.DBAlertDefinition{
+				{
+					ID:       quietID,
+					Name:     "HostMemoryUsageHigh",
+					Template: "alert: HostMemoryUsageHigh\nexpr: memory_usage > {{ .Threshold }}\n",
+					Values:   models.DBAlertDefinitionValues{Duration: &duration},
+				},
+				{
+					ID:       noisyID,
+					Name:     "HostCPUUsageHigh",
+					Template: "alert: HostCPUUsageHigh\nexpr: cpu_usage > {{ .Threshold }}\n",
+					Values:   models.DBAlertDefinitionValues{Duration: &duration},
+				},
+				{
+					ID:       uuid.New(),
+					Name:     "HostDiskUsageHigh",
+					Template: "alert: HostDiskUsageHigh\nexpr: disk_usage > {{ .Threshold }}\n",
+					Values:   models.DBAlertDefinitionValues{Duration: &duration, Enabled: &disabled},
+				},
+				{
+					ID:       uuid.New(),
+					Name:     "HostMaintenance",
+					Template: "alert: HostMaintenance\nexpr: maintenance > 0\n",
+					Category: models.CategoryMaintenance,
+				},
+			}, int64(4), nil)
+		return mDefinition
+	}
+
+	get := func(t *testing.T, handler *ServerInterfaceHandler) *testutil.CompletedRequest {
+		server := echo.New()
+		api.RegisterHandlers(server, handler)
+		return testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get("/api/v1/alerts/definitions/recommendations").
+			GoWithHTTPHandler(t, server)
+	}
+
+	t.Run("Alert definitions are scored, the noisiest first", func(t *testing.T) {
+		mDefinition := definitions()
+		handler := &ServerInterfaceHandler{configuration: configfile, definitions: mDefinition}
+		handler.noise = newNoiseScorer(handler, nil, config.NoiseScoringConfig{FlappingRate: 10, SilenceCoverage: 0.1})
+
+		result := get(t, handler)
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+
+		var list api.AlertDefinitionRecommendationList
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &list))
+		require.True(t, now.Equal(list.ScoredAt))
+		require.Len(t, list.Recommendations, 2)
+
+		noisy := list.Recommendations[0]
+		require.Equal(t, noisyID, noisy.AlertDefinitionId)
+		require.Equal(t, 84, noisy.Firings)
+		require.Equal(t, 84, noisy.Resolutions)
+		// 12 of the firings are within the day of the silence owned by the tenant.
+		require.InDelta(t, 12.0/84, noisy.SilenceCoverage, 1e-9)
+		require.InDelta(t, 12*(1+12.0/84), noisy.Score, 1e-9)
+		require.Len(t, noisy.Adjustments, 2)
+		require.Equal(t, api.AdjustDuration, noisy.Adjustments[0].Parameter)
+		require.Equal(t, int64(120), *noisy.Adjustments[0].DurationSeconds)
+		require.Equal(t, api.AdjustThreshold, noisy.Adjustments[1].Parameter)
+
+		require.Equal(t, api.AlertDefinitionRecommendation{
+			AlertDefinitionId: quietID,
+			Name:              "HostMemoryUsageHigh",
+			Adjustments:       []api.AlertDefinitionAdjustment{},
+		}, list.Recommendations[1])
+		mDefinition.AssertExpectations(t)
+	})
+
+	t.Run("Scores are kept until the next scoring", func(t *testing.T) {
+		statesMock := new(ConfigStateMock)
+		statesMock.On("GetLatestAlertDefinitionStates", mock.Anything).Return([]models.AlertDefinition{
+			{UUID: noisyID, TenantID: tenantID},
+			{UUID: quietID, TenantID: tenantID},
+		}, nil).Once()
+
+		handler := &ServerInterfaceHandler{configuration: configfile, definitions: definitions()}
+		handler.noise = newNoiseScorer(handler, statesMock, config.NoiseScoringConfig{Interval: time.Hour})
+		handler.noise.scoreAll(context.Background())
+		statesMock.AssertExpectations(t)
+
+		queried := queries.Load()
+		result := get(t, handler)
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+		require.Equal(t, queried, queries.Load(), "scores should be served without querying Mimir again")
+
+		var list api.AlertDefinitionRecommendationList
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &list))
+		require.Len(t, list.Recommendations, 2)
+		require.Empty(t, list.Recommendations[0].Adjustments, "adjustments should not be suggested if turned off")
+	})
+
+	t.Run("Mimir is not configured", func(t *testing.T) {
+		result := get(t, &ServerInterfaceHandler{configuration: conf})
+		require.Equal(t, http.StatusServiceUnavailable, result.Recorder.Code)
+	})
+
+	t.Run("Alertmanager is unavailable", func(t *testing.T) {
+		unavailable := configfile
+		unavailable.AlertManager.URL = "http://127.0.0.1:0"
+
+		handler := &ServerInterfaceHandler{configuration: unavailable, definitions: definitions()}
+		handler.noise = newNoiseScorer(handler, nil, config.NoiseScoringConfig{})
+		result := get(t, handler)
+		require.Equal(t, http.StatusInternalServerError, result.Recorder.Code)
+	})
+}
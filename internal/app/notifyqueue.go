@@ -0,0 +1,249 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	db "github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+const (
+	// deadLettersEndpoint is the endpoint serving the notifications whose retries are exhausted. It is under /debug, so that
+	// it is only granted to administrators.
+	deadLettersEndpoint = "/debug/notifications/dead-letters"
+
+	defaultDeadLetterLimit = 50
+	maxDeadLetterLimit     = 500
+
+	// Defaults of the notification queue if not configured.
+	defaultNotificationPollInterval = 30 * time.Second
+	defaultNotificationBatchSize    = 50
+
+	// notificationTakenTimeout is the time after which a notification left taken, by a replica which died while delivering
+	// it, is taken again.
+	notificationTakenTimeout = 10 * time.Minute
+	// notificationCleanupInterval is the interval between deletions of the notifications past retention.
+	notificationCleanupInterval = time.Hour
+)
+
+// notificationDeliverer delivers a notification queued for retry through its channel.
+type notificationDeliverer func(ctx context.Context, task models.NotificationTask) error
+
+// deadLetter is a notification whose retries are exhausted, as served by the dead letters endpoint. Its payload is left out,
+// as is the recipient of Grafana OnCall notifications, which is the routing key of the receiver.
+type deadLetter struct {
+	ID             int64                      `json:"id"`
+	TenantID       string                     `json:"tenantId"`
+	ReceiverUUID   uuid.UUID                  `json:"receiverId"`
+	Channel        models.NotificationChannel `json:"channel"`
+	Recipient      string                     `json:"recipient,omitempty"`
+	Attempts       int64                      `json:"attempts"`
+	Error          string                     `json:"error"`
+	CreationDate   time.Time                  `json:"creationDate"`
+	CompletionDate time.Time                  `json:"completionDate"`
+}
+
+// notificationQueue delivers the notifications of the email and Grafana OnCall relays queued for retry after their delivery
+// failed, retrying them with exponential backoff until their retries are exhausted, after which they are kept as dead letters.
+// Notifications are not delivered while the Notification kind of tasks is paused.
+type notificationQueue struct {
+	conf      config.NotificationQueueConfig
+	paused    bool
+	ownerUUID uuid.UUID
+	queue     db.NotificationQueue
+	// deliverers deliver the notifications of each channel. Notifications of a channel without a deliverer fail.
+	deliverers map[models.NotificationChannel]notificationDeliverer
+}
+
+func newNotificationQueue(conf config.NotificationQueueConfig, paused bool, queue db.NotificationQueue) *notificationQueue {
+	if conf.PollInterval <= 0 {
+		conf.PollInterval = defaultNotificationPollInterval
+	}
+	if conf.BatchSize <= 0 {
+		conf.BatchSize = defaultNotificationBatchSize
+	}
+	return &notificationQueue{
+		conf:       conf,
+		paused:     paused,
+		ownerUUID:  uuid.New(),
+		queue:      queue,
+		deliverers: make(map[models.NotificationChannel]notificationDeliverer),
+	}
+}
+
+// register registers the dead letters endpoints.
+func (q *notificationQueue) register(e *echo.Echo) {
+	e.GET(deadLettersEndpoint, q.listDeadLetters)
+	e.POST(deadLettersEndpoint+"/:id/redrive", q.redrive)
+}
+
+// run delivers the notifications due every poll interval, and deletes those past retention every cleanup interval, until the
+// context is done.
+func (q *notificationQueue) run(ctx context.Context) {
+	if q.paused {
+		slog.Warn("Notifications queued for retry are not delivered, as the Notification kind of tasks is paused")
+	}
+
+	poll := time.NewTicker(q.conf.PollInterval)
+	defer poll.Stop()
+	cleanup := time.NewTicker(notificationCleanupInterval)
+	defer cleanup.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-poll.C:
+			if !q.paused {
+				q.deliverDue(ctx)
+			}
+		case <-cleanup.C:
+			q.cleanUp(ctx)
+		}
+	}
+}
+
+// deliverDue takes the notifications due for delivery and delivers them. Notifications which fail again are retried after a
+// backoff, or set as dead letters once their retries are exhausted.
+func (q *notificationQueue) deliverDue(ctx context.Context) {
+	tasks, err := q.queue.TakeDueNotifications(ctx, q.ownerUUID, q.conf.BatchSize, notificationTakenTimeout)
+	if err != nil {
+		slog.Error("Failed to take notifications due for delivery", slog.Any("error", err))
+		return
+	}
+
+	for _, task := range tasks {
+		attrs := []any{slog.Int64("notification", task.ID), slog.String("tenant", task.TenantID),
+			slog.String("receiver", task.ReceiverUUID.String()), slog.String("channel", string(task.Channel))}
+
+		deliverErr := errors.New("no deliverer of the notification channel")
+		if deliver, ok := q.deliverers[task.Channel]; ok {
+			deliverErr = deliver(ctx, task)
+		}
+
+		switch {
+		case deliverErr == nil:
+			err = q.queue.SetNotificationDelivered(ctx, task)
+			slog.Info("Delivered notification queued for retry", attrs...)
+		case task.RetryCount >= int64(q.conf.Retry.MaxRetries):
+			err = q.queue.DeadLetterNotification(ctx, task, deliverErr)
+			slog.Error("Failed to deliver notification, retries are exhausted", append(attrs, slog.Any("error", deliverErr))...)
+		default:
+			err = q.queue.RetryNotification(ctx, task, deliverErr, clock.TimeNowFn().Add(q.backoff(task.RetryCount)))
+			slog.Warn("Failed to deliver notification, retrying later", append(attrs, slog.Any("error", deliverErr))...)
+		}
+		if err != nil {
+			slog.Error("Failed to update notification queued for retry", append(attrs, slog.Any("error", err))...)
+		}
+	}
+}
+
+// backoff returns the wait before the next attempt to deliver a notification which failed after the given number of retries,
+// doubled for each retry from the initial backoff, and capped by the maximum backoff if any.
+func (q *notificationQueue) backoff(retries int64) time.Duration {
+	backoff := q.conf.Retry.InitialBackoff
+	for range retries {
+		backoff *= 2
+		if q.conf.Retry.MaxBackoff > 0 && backoff >= q.conf.Retry.MaxBackoff {
+			return q.conf.Retry.MaxBackoff
+		}
+	}
+	return backoff
+}
+
+// cleanUp deletes the delivered notifications and dead letters past retention, unless they are kept forever.
+func (q *notificationQueue) cleanUp(ctx context.Context) {
+	if q.conf.Retention <= 0 {
+		return
+	}
+	deleted, err := q.queue.DeleteCompletedNotifications(ctx, clock.TimeNowFn().Add(-q.conf.Retention))
+	if err != nil {
+		slog.Error("Failed to delete notifications past retention", slog.Any("error", err))
+	} else if deleted > 0 {
+		slog.Info("Deleted notifications past retention", slog.Int64("deleted", deleted))
+	}
+}
+
+// listDeadLetters handles the request for the latest dead letters, of the tenant of the tenant query parameter if any,
+// limited by the limit query parameter.
+func (q *notificationQueue) listDeadLetters(ctx echo.Context) error {
+	limit := defaultDeadLetterLimit
+	if param := ctx.QueryParam("limit"); param != "" {
+		l, err := strconv.Atoi(param)
+		if err != nil || l < 1 || l > maxDeadLetterLimit {
+			logWarn(ctx, fmt.Sprintf("Invalid dead letters limit: %q", param))
+			return ctx.JSON(http.StatusBadRequest, errArtifactBadRequest)
+		}
+		limit = l
+	}
+
+	tasks, err := q.queue.GetDeadLetterNotifications(ctx.Request().Context(), ctx.QueryParam("tenant"), limit)
+	if err != nil {
+		logError(ctx, "Failed to get dead letter notifications", err)
+		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   "failed to get dead letter notifications",
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+
+	list := make([]deadLetter, 0, len(tasks))
+	for _, task := range tasks {
+		letter := deadLetter{
+			ID:             task.ID,
+			TenantID:       task.TenantID,
+			ReceiverUUID:   task.ReceiverUUID,
+			Channel:        task.Channel,
+			Attempts:       task.RetryCount,
+			Error:          task.Error,
+			CreationDate:   task.CreationDate,
+			CompletionDate: task.CompletionDate,
+		}
+		if task.Channel == models.NotificationEmail {
+			letter.Recipient = task.Recipient
+		}
+		list = append(list, letter)
+	}
+	return ctx.JSON(http.StatusOK, list)
+}
+
+// redrive handles the request to queue a dead letter for delivery again.
+func (q *notificationQueue) redrive(ctx echo.Context) error {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		logWarn(ctx, fmt.Sprintf("Invalid dead letter ID: %q", ctx.Param("id")))
+		return ctx.JSON(http.StatusBadRequest, errArtifactBadRequest)
+	}
+
+	if err := q.queue.RedriveNotification(ctx.Request().Context(), id); errors.Is(err, gorm.ErrRecordNotFound) {
+		return ctx.JSON(http.StatusNotFound, api.HttpError{
+			Code:      http.StatusNotFound,
+			Message:   "dead letter not found",
+			ErrorCode: api.ErrorCodeDeadLetterNotFound,
+		})
+	} else if err != nil {
+		logError(ctx, fmt.Sprintf("Failed to redrive dead letter %d", id), err)
+		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   "failed to redrive dead letter",
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+	return ctx.NoContent(http.StatusAccepted)
+}
@@ -0,0 +1,216 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+type NotificationQueueMock struct {
+	mock.Mock
+}
+
+func (m *NotificationQueueMock) EnqueueNotifications(ctx context.Context, tasks []models.NotificationTask) error {
+	args := m.Called(ctx, tasks)
+	return args.Error(0)
+}
+
+func (m *NotificationQueueMock) TakeDueNotifications(ctx context.Context, ownerUUID uuid.UUID, limit int,
+	takenTimeout time.Duration) ([]models.NotificationTask, error) {
+	args := m.Called(ctx, ownerUUID, limit, takenTimeout)
+	return args.Get(0).([]models.NotificationTask), args.Error(1)
+}
+
+func (m *NotificationQueueMock) SetNotificationDelivered(ctx context.Context, task models.NotificationTask) error {
+	args := m.Called(ctx, task)
+	return args.Error(0)
+}
+
+func (m *NotificationQueueMock) RetryNotification(ctx context.Context, task models.NotificationTask, cause error, at time.Time) error {
+	args := m.Called(ctx, task, cause, at)
+	return args.Error(0)
+}
+
+func (m *NotificationQueueMock) DeadLetterNotification(ctx context.Context, task models.NotificationTask, cause error) error {
+	args := m.Called(ctx, task, cause)
+	return args.Error(0)
+}
+
+func (m *NotificationQueueMock) GetDeadLetterNotifications(ctx context.Context, tenantID api.TenantID, limit int) ([]models.NotificationTask, error) {
+	args := m.Called(ctx, tenantID, limit)
+	return args.Get(0).([]models.NotificationTask), args.Error(1)
+}
+
+func (m *NotificationQueueMock) RedriveNotification(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *NotificationQueueMock) DeleteCompletedNotifications(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func testNotificationQueueConfig() config.NotificationQueueConfig {
+	return config.NotificationQueueConfig{
+		Enabled: true,
+		Retry: config.RetryConfig{
+			MaxRetries:     3,
+			InitialBackoff: time.Minute,
+			MaxBackoff:     5 * time.Minute,
+		},
+		Retention: 24 * time.Hour,
+	}
+}
+
+func TestNotificationQueue_DeliverDue(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	clock.SetFakeClock()
+	defer clock.UnsetFakeClock()
+	clock.FakeClock.Set(now)
+
+	delivered := models.NotificationTask{ID: 1, Channel: models.NotificationEmail}
+	failed := models.NotificationTask{ID: 2, Channel: models.NotificationOnCall, RetryCount: 1}
+	exhausted := models.NotificationTask{ID: 3, Channel: models.NotificationOnCall, RetryCount: 3}
+	unknown := models.NotificationTask{ID: 4, Channel: "teams"}
+
+	mQueue := &NotificationQueueMock{}
+	q := newNotificationQueue(testNotificationQueueConfig(), false, mQueue)
+	mQueue.On("TakeDueNotifications", mock.Anything, q.ownerUUID, defaultNotificationBatchSize, notificationTakenTimeout).
+		Return([]models.NotificationTask{delivered, failed, exhausted, unknown}, nil).Once()
+	mQueue.On("SetNotificationDelivered", mock.Anything, delivered).Return(nil).Once()
+	mQueue.On("RetryNotification", mock.Anything, failed, mock.Anything, now.Add(2*time.Minute)).Return(nil).Once()
+	mQueue.On("DeadLetterNotification", mock.Anything, exhausted, mock.Anything).Return(nil).Once()
+	mQueue.On("RetryNotification", mock.Anything, unknown, mock.Anything, now.Add(time.Minute)).Return(nil).Once()
+
+	unavailable := errors.New("service unavailable")
+	q.deliverers[models.NotificationEmail] = func(context.Context, models.NotificationTask) error { return nil }
+	q.deliverers[models.NotificationOnCall] = func(context.Context, models.NotificationTask) error { return unavailable }
+	q.deliverDue(t.Context())
+
+	mQueue.AssertExpectations(t)
+	require.Equal(t, unavailable, mQueue.Calls[2].Arguments.Get(2))
+}
+
+func TestNotificationQueue_Backoff(t *testing.T) {
+	q := newNotificationQueue(testNotificationQueueConfig(), false, &NotificationQueueMock{})
+	require.Equal(t, time.Minute, q.backoff(0))
+	require.Equal(t, 2*time.Minute, q.backoff(1))
+	require.Equal(t, 4*time.Minute, q.backoff(2))
+	require.Equal(t, 5*time.Minute, q.backoff(3))
+	require.Equal(t, 5*time.Minute, q.backoff(50))
+}
+
+func TestNotificationQueue_CleanUp(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	clock.SetFakeClock()
+	defer clock.UnsetFakeClock()
+	clock.FakeClock.Set(now)
+
+	mQueue := &NotificationQueueMock{}
+	mQueue.On("DeleteCompletedNotifications", mock.Anything, now.Add(-24*time.Hour)).Return(int64(2), nil).Once()
+	newNotificationQueue(testNotificationQueueConfig(), false, mQueue).cleanUp(t.Context())
+	mQueue.AssertExpectations(t)
+
+	// Notifications are kept forever without retention.
+	conf := testNotificationQueueConfig()
+	conf.Retention = 0
+	newNotificationQueue(conf, false, mQueue).cleanUp(t.Context())
+	mQueue.AssertNumberOfCalls(t, "DeleteCompletedNotifications", 1)
+}
+
+func TestNotificationQueue_DeadLetters(t *testing.T) {
+	newServer := func(mQueue *NotificationQueueMock) *echo.Echo {
+		e := echo.New()
+		newNotificationQueue(testNotificationQueueConfig(), false, mQueue).register(e)
+		return e
+	}
+
+	serve := func(e *echo.Echo, method, uri string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(method, uri, nil))
+		return rec
+	}
+
+	t.Run("List dead letters", func(t *testing.T) {
+		receiverID := uuid.New()
+		mQueue := &NotificationQueueMock{}
+		mQueue.On("GetDeadLetterNotifications", mock.Anything, "tenant", 10).Return([]models.NotificationTask{
+			{ID: 1, TenantID: "tenant", ReceiverUUID: receiverID, Channel: models.NotificationEmail, Recipient: "foo@bar.com",
+				RetryCount: 4, Error: "421 Service not available", Payload: "{}"},
+			{ID: 2, TenantID: "tenant", ReceiverUUID: receiverID, Channel: models.NotificationOnCall, Recipient: "routing-key",
+				RetryCount: 4, Error: "got unexpected status code: 503", Payload: "{}"},
+		}, nil).Once()
+
+		rec := serve(newServer(mQueue), http.MethodGet, deadLettersEndpoint+"?tenant=tenant&limit=10")
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var letters []deadLetter
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &letters))
+		require.Len(t, letters, 2)
+		require.Equal(t, "foo@bar.com", letters[0].Recipient)
+		require.Equal(t, int64(4), letters[0].Attempts)
+		// The routing key of Grafana OnCall receivers is not disclosed.
+		require.Empty(t, letters[1].Recipient)
+		require.NotContains(t, rec.Body.String(), "routing-key")
+		mQueue.AssertExpectations(t)
+	})
+
+	t.Run("Invalid limit", func(t *testing.T) {
+		rec := serve(newServer(&NotificationQueueMock{}), http.MethodGet, deadLettersEndpoint+"?limit=1000")
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("Failed to get dead letters", func(t *testing.T) {
+		mQueue := &NotificationQueueMock{}
+		mQueue.On("GetDeadLetterNotifications", mock.Anything, "", defaultDeadLetterLimit).
+			Return([]models.NotificationTask(nil), errors.New("database unavailable")).Once()
+
+		rec := serve(newServer(mQueue), http.MethodGet, deadLettersEndpoint)
+		require.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+
+	t.Run("Redrive dead letter", func(t *testing.T) {
+		mQueue := &NotificationQueueMock{}
+		mQueue.On("RedriveNotification", mock.Anything, int64(7)).Return(nil).Once()
+
+		rec := serve(newServer(mQueue), http.MethodPost, deadLettersEndpoint+"/7/redrive")
+		require.Equal(t, http.StatusAccepted, rec.Code)
+		mQueue.AssertExpectations(t)
+	})
+
+	t.Run("Dead letter not found", func(t *testing.T) {
+		mQueue := &NotificationQueueMock{}
+		mQueue.On("RedriveNotification", mock.Anything, int64(7)).Return(gorm.ErrRecordNotFound).Once()
+
+		rec := serve(newServer(mQueue), http.MethodPost, deadLettersEndpoint+"/7/redrive")
+		require.Equal(t, http.StatusNotFound, rec.Code)
+
+		var httpErr api.HttpError
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &httpErr))
+		require.Equal(t, api.ErrorCodeDeadLetterNotFound, httpErr.ErrorCode)
+	})
+
+	t.Run("Invalid dead letter ID", func(t *testing.T) {
+		rec := serve(newServer(&NotificationQueueMock{}), http.MethodPost, deadLettersEndpoint+"/abc/redrive")
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/mail"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	db "github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+// recipientOffboardingEndpoint is the endpoint removing an email recipient from the receivers of all tenants, and listing the
+// receivers it was removed from. It is under /debug, so that it is only granted to administrators.
+const recipientOffboardingEndpoint = "/debug/recipient-offboardings"
+
+// recipientOffboardingRequest is the body of the request offboarding an email recipient, given by its bare email address.
+type recipientOffboardingRequest struct {
+	Email string `json:"email"`
+}
+
+// offboardedReceiver is a receiver an email recipient was removed from, as served by the recipient offboarding endpoint.
+type offboardedReceiver struct {
+	TenantID     string    `json:"tenantId"`
+	ReceiverUUID uuid.UUID `json:"receiverUuid"`
+	ReceiverName string    `json:"receiverName"`
+	Version      int64     `json:"version"`
+	CreationDate time.Time `json:"creationDate"`
+}
+
+// recipientOffboarder removes the email recipients of people who left the organization from the receivers of all tenants at
+// once, and keeps track of the receivers they were removed from.
+type recipientOffboarder struct {
+	offboardings db.RecipientOffboardingManager
+}
+
+func newRecipientOffboarder(offboardings db.RecipientOffboardingManager) *recipientOffboarder {
+	return &recipientOffboarder{offboardings: offboardings}
+}
+
+// register registers the recipient offboarding endpoint.
+func (o *recipientOffboarder) register(e *echo.Echo) {
+	e.POST(recipientOffboardingEndpoint, o.offboard)
+	e.GET(recipientOffboardingEndpoint, o.list)
+}
+
+// offboard handles the request removing an email recipient from the receivers of all tenants, and returns the receivers it
+// was removed from. Receivers the recipient was already removed from are left as they are, so the request can be repeated.
+func (o *recipientOffboarder) offboard(ctx echo.Context) error {
+	var req recipientOffboardingRequest
+	dec := json.NewDecoder(ctx.Request().Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		logError(ctx, "Failed to parse body of recipient offboarding", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPBadRequest,
+			ErrorCode: api.ErrorCodeInvalidRequestBody,
+		})
+	}
+	if err := validateOffboardedEmail(req.Email); err != nil {
+		logError(ctx, "Invalid email of recipient offboarding", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPBadRequest,
+			ErrorCode: api.ErrorCodeInvalidRequestBody,
+		})
+	}
+
+	records, err := o.offboardings.OffboardRecipient(ctx.Request().Context(), req.Email)
+	if err != nil {
+		logError(ctx, fmt.Sprintf("Failed to offboard recipient %q", req.Email), err)
+		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   "failed to offboard recipient",
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+
+	for _, r := range records {
+		slog.InfoContext(ctx.Request().Context(), "removed offboarded recipient from receiver",
+			slog.String("email", r.Email),
+			slog.String("tenant", r.TenantID),
+			slog.String("receiver", r.ReceiverUUID.String()),
+			slog.Int64("version", r.Version),
+		)
+	}
+	return ctx.JSON(http.StatusOK, offboardedReceivers(records))
+}
+
+// list handles the request for the receivers the email recipient given by the email query parameter was removed from, latest
+// first.
+func (o *recipientOffboarder) list(ctx echo.Context) error {
+	email := ctx.QueryParam("email")
+	if err := validateOffboardedEmail(email); err != nil {
+		logWarn(ctx, fmt.Sprintf("Invalid email of recipient offboardings: %q", email))
+		return ctx.JSON(http.StatusBadRequest, errArtifactBadRequest)
+	}
+
+	records, err := o.offboardings.GetRecipientOffboardings(ctx.Request().Context(), email)
+	if err != nil {
+		logError(ctx, fmt.Sprintf("Failed to get offboardings of recipient %q", email), err)
+		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   "failed to get recipient offboardings",
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+	return ctx.JSON(http.StatusOK, offboardedReceivers(records))
+}
+
+// validateOffboardedEmail checks that the given email of an offboarded recipient is a bare email address, without name.
+func validateOffboardedEmail(email string) error {
+	addr, err := mail.ParseAddress(email)
+	if err != nil {
+		return fmt.Errorf("invalid email address %q: %w", email, err)
+	}
+	if addr.Name != "" || addr.Address != email {
+		return errors.New("email must be given as a bare email address")
+	}
+	return nil
+}
+
+func offboardedReceivers(records []models.RecipientOffboarding) []offboardedReceiver {
+	list := make([]offboardedReceiver, 0, len(records))
+	for _, r := range records {
+		list = append(list, offboardedReceiver{
+			TenantID:     r.TenantID,
+			ReceiverUUID: r.ReceiverUUID,
+			ReceiverName: r.ReceiverName,
+			Version:      r.Version,
+			CreationDate: r.CreationDate,
+		})
+	}
+	return list
+}
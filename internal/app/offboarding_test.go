@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+func TestRecipientOffboarder(t *testing.T) {
+	conn, err := gorm.Open(sqlite.Open(":memory:"))
+	require.NoError(t, err)
+	require.NoError(t, conn.AutoMigrate(&models.EmailAddress{}, &models.EmailConfig{}, &models.Receiver{}, &models.EmailRecipient{},
+		&models.Task{}, &models.Tenant{}, &models.EmailTemplate{}, &models.RecipientOffboarding{}))
+	dbService := &database.DBService{DB: conn}
+
+	leaver := models.EmailAddress{FirstName: "leaving", LastName: "user", Email: "leaving.user@example.com"}
+	staying := models.EmailAddress{FirstName: "staying", LastName: "user", Email: "staying.user@example.com"}
+	require.NoError(t, conn.Create(&[]*models.EmailAddress{&leaver, &staying}).Error)
+	config := models.EmailConfig{MailServer: "smtp.example.com:587", From: staying.ID}
+	require.NoError(t, conn.Create(&config).Error)
+
+	// The leaver is a recipient of a receiver of each tenant, and was a recipient of an older version of a third receiver.
+	createReceiver := func(tenantID, name string, version int64, recipients ...models.EmailAddress) models.Receiver {
+		recv := models.Receiver{UUID: uuid.New(), Name: name, Version: version, State: models.ReceiverApplied, EmailConfigID: config.ID, TenantID: tenantID}
+		require.NoError(t, conn.Create(&recv).Error)
+		for _, r := range recipients {
+			require.NoError(t, conn.Create(&models.EmailRecipient{ReceiverID: recv.ID, EmailAddressID: r.ID}).Error)
+		}
+		return recv
+	}
+	first := createReceiver("tenant-a", "ops", 1, leaver, staying)
+	second := createReceiver("tenant-b", "oncall", 4, leaver)
+	former := createReceiver("tenant-b", "former", 1, leaver)
+	formerLatest := former
+	formerLatest.ID, formerLatest.Version = 0, 2
+	require.NoError(t, conn.Create(&formerLatest).Error)
+
+	e := echo.New()
+	newRecipientOffboarder(dbService).register(e)
+	serve := func(req *http.Request) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	offboard := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/debug/recipient-offboardings", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		return serve(req)
+	}
+
+	t.Run("Recipient is removed from the latest version of receivers of all tenants", func(t *testing.T) {
+		rec := offboard(`{"email":"leaving.user@example.com"}`)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var receivers []offboardedReceiver
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &receivers))
+		require.Len(t, receivers, 2)
+		require.Equal(t, first.UUID, receivers[0].ReceiverUUID)
+		require.Equal(t, "tenant-a", receivers[0].TenantID)
+		require.Equal(t, int64(2), receivers[0].Version)
+		require.Equal(t, second.UUID, receivers[1].ReceiverUUID)
+		require.Equal(t, int64(5), receivers[1].Version)
+
+		recv, err := dbService.GetLatestReceiverWithEmailConfig(t.Context(), "tenant-a", first.UUID)
+		require.NoError(t, err)
+		require.Equal(t, []string{staying.String()}, recv.To)
+
+		recv, err = dbService.GetLatestReceiverWithEmailConfig(t.Context(), "tenant-b", second.UUID)
+		require.NoError(t, err)
+		require.Empty(t, recv.To)
+
+		var tasks int64
+		require.NoError(t, conn.Model(&models.Task{}).Where("state = ?", models.TaskNew).Count(&tasks).Error)
+		require.Equal(t, int64(2), tasks)
+	})
+
+	t.Run("Offboarding is repeatable", func(t *testing.T) {
+		rec := offboard(`{"email":"leaving.user@example.com"}`)
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.JSONEq(t, `[]`, rec.Body.String())
+	})
+
+	t.Run("Affected receivers are recorded", func(t *testing.T) {
+		rec := serve(httptest.NewRequest(http.MethodGet, "/debug/recipient-offboardings?email=leaving.user%40example.com", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var receivers []offboardedReceiver
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &receivers))
+		require.Len(t, receivers, 2)
+		require.ElementsMatch(t, []uuid.UUID{first.UUID, second.UUID}, []uuid.UUID{receivers[0].ReceiverUUID, receivers[1].ReceiverUUID})
+	})
+
+	t.Run("Invalid email", func(t *testing.T) {
+		for _, body := range []string{
+			`{"email":"leaving user <leaving.user@example.com>"}`,
+			`{"email":"leaving.user"}`,
+			`{"address":"leaving.user@example.com"}`,
+		} {
+			rec := offboard(body)
+			require.Equal(t, http.StatusBadRequest, rec.Code, body)
+		}
+
+		rec := serve(httptest.NewRequest(http.MethodGet, "/debug/recipient-offboardings", nil))
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
@@ -0,0 +1,225 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	db "github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+const (
+	// onCallRelayEndpoint is the prefix of the endpoint alertmanager sends the alerts of a receiver to, to be relayed to Grafana OnCall.
+	onCallRelayEndpoint = "/api/v1/oncall/relay"
+
+	onCallStateAlerting = "alerting"
+	onCallStateOK       = "ok"
+)
+
+// alertmanagerWebhook is the payload alertmanager sends to webhook receivers.
+type alertmanagerWebhook struct {
+	Status string              `json:"status"`
+	Alerts []alertmanagerAlert `json:"alerts"`
+}
+
+// alertmanagerAlert is a single alert of the payload alertmanager sends to webhook receivers.
+type alertmanagerAlert struct {
+	Status       string            `json:"status"`
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations"`
+	GeneratorURL string            `json:"generatorURL"`
+	Fingerprint  string            `json:"fingerprint"`
+}
+
+// onCallAlert is the payload of the Grafana OnCall formatted webhook integration.
+type onCallAlert struct {
+	AlertUID              string `json:"alert_uid"`
+	Title                 string `json:"title"`
+	State                 string `json:"state"`
+	Message               string `json:"message,omitempty"`
+	LinkToUpstreamDetails string `json:"link_to_upstream_details,omitempty"`
+}
+
+// onCallRelay converts the alertmanager webhook payloads of receivers with a Grafana OnCall routing key into the schema of
+// the Grafana OnCall formatted webhook integration, and sends them to the integration given by the routing key.
+type onCallRelay struct {
+	receivers db.ReceiverHandlerManager
+	client    *http.Client
+	url       string
+	// queue queues the alerts which could not be relayed for retry. They are not queued if nil.
+	queue db.NotificationEnqueuer
+}
+
+// errOnCallRejected is returned when Grafana OnCall rejects an alert with a client error, which is not worth retrying.
+var errOnCallRejected = errors.New("alert rejected by Grafana OnCall")
+
+func newOnCallRelay(conf config.OnCallConfig, receivers db.ReceiverHandlerManager) *onCallRelay {
+	return &onCallRelay{
+		receivers: receivers,
+		client:    &http.Client{Timeout: conf.Timeout},
+		url:       strings.TrimSuffix(conf.URL, "/"),
+	}
+}
+
+// relay handles the alertmanager webhook payload of the receiver given by the path parameters. Alertmanager retries the
+// notification if relaying fails, so an error status is returned if any alert is not accepted by Grafana OnCall, unless the
+// alerts left to relay are queued for retry. Requests are authenticated by the webhook authenticator of the relay beforehand.
+func (r *onCallRelay) relay(ctx echo.Context) error {
+	tenantID := ctx.Param("tenantID")
+	id, err := uuid.Parse(ctx.Param("receiverID"))
+	if err != nil {
+		logError(ctx, "Invalid receiver ID of Grafana OnCall relay request", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPBadRequest,
+			ErrorCode: api.ErrorCodeInvalidParameter,
+		})
+	}
+
+	var payload alertmanagerWebhook
+	if err := json.NewDecoder(ctx.Request().Body).Decode(&payload); err != nil {
+		logError(ctx, "Failed to parse alertmanager webhook payload", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPBadRequest,
+			ErrorCode: api.ErrorCodeInvalidRequestBody,
+		})
+	}
+
+	recv, err := r.receivers.GetLatestReceiverWithEmailConfig(ctx.Request().Context(), tenantID, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		logError(ctx, fmt.Sprintf("Alert receiver not found: %q", id), err)
+		return ctx.JSON(http.StatusNotFound, api.HttpError{
+			Code:      http.StatusNotFound,
+			Message:   errHTTPAlertReceiverNotFound,
+			ErrorCode: api.ErrorCodeReceiverNotFound,
+		})
+	} else if err != nil {
+		logError(ctx, fmt.Sprintf("Failed to get alert receiver with UUID: %q", id), err)
+		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToGetAlertReceiver,
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+
+	// The routing key may have been removed after alertmanager sent the notification, in which case alerts are dropped.
+	if recv.OnCallRoutingKey == "" {
+		logWarn(ctx, fmt.Sprintf("Dropping alerts of receiver %q without Grafana OnCall routing key", id))
+		return ctx.NoContent(http.StatusOK)
+	}
+
+	for i, alert := range payload.Alerts {
+		if err := r.send(ctx.Request().Context(), recv.OnCallRoutingKey, toOnCallAlert(alert)); err != nil {
+			if r.queue != nil && !errors.Is(err, errOnCallRejected) {
+				queueErr := r.enqueue(ctx, tenantID, id, recv.OnCallRoutingKey, payload.Alerts[i:])
+				if queueErr == nil {
+					logWarn(ctx, fmt.Sprintf("Queued alerts of receiver %q for retry after failing to relay them to Grafana OnCall: %v", id, err))
+					return ctx.NoContent(http.StatusOK)
+				}
+				logError(ctx, fmt.Sprintf("Failed to queue alerts of receiver %q for retry", id), queueErr)
+			}
+			logError(ctx, fmt.Sprintf("Failed to relay alerts of receiver %q to Grafana OnCall", id), err)
+			return ctx.JSON(http.StatusBadGateway, api.HttpError{
+				Code:      http.StatusBadGateway,
+				Message:   "failed to relay alerts to Grafana OnCall",
+				ErrorCode: api.ErrorCodeOnCallRelayFailed,
+			})
+		}
+	}
+	return ctx.NoContent(http.StatusOK)
+}
+
+// enqueue queues the given alerts for retry, each as a notification task.
+func (r *onCallRelay) enqueue(ctx echo.Context, tenantID string, id uuid.UUID, routingKey string, alerts []alertmanagerAlert) error {
+	tasks := make([]models.NotificationTask, 0, len(alerts))
+	for _, alert := range alerts {
+		payload, err := json.Marshal(toOnCallAlert(alert))
+		if err != nil {
+			return fmt.Errorf("failed to marshal alert: %w", err)
+		}
+		tasks = append(tasks, models.NotificationTask{
+			TenantID:     tenantID,
+			ReceiverUUID: id,
+			Channel:      models.NotificationOnCall,
+			Recipient:    routingKey,
+			Payload:      string(payload),
+		})
+	}
+	return r.queue.EnqueueNotifications(ctx.Request().Context(), tasks)
+}
+
+// redeliver relays an alert queued for retry to the Grafana OnCall integration of its routing key.
+func (r *onCallRelay) redeliver(ctx context.Context, task models.NotificationTask) error {
+	var alert onCallAlert
+	if err := json.Unmarshal([]byte(task.Payload), &alert); err != nil {
+		return fmt.Errorf("invalid queued alert: %w", err)
+	}
+	return r.send(ctx, task.Recipient, alert)
+}
+
+// send posts an alert to the Grafana OnCall formatted webhook integration of the given routing key. An error wrapping
+// errOnCallRejected is returned if Grafana OnCall rejects the alert with a client error other than rate limiting.
+func (r *onCallRelay) send(ctx context.Context, routingKey string, alert onCallAlert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return fmt.Errorf("failed to marshal alert: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/%s/", r.url, routingKey), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest && resp.StatusCode < http.StatusInternalServerError &&
+		resp.StatusCode != http.StatusTooManyRequests {
+		return fmt.Errorf("%w: got status code %d", errOnCallRejected, resp.StatusCode)
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("got unexpected status code: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// toOnCallAlert converts an alertmanager alert into the schema of the Grafana OnCall formatted webhook integration. The
+// alert fingerprint is used as the alert UID, so that Grafana OnCall groups firing and resolved notifications of an alert.
+func toOnCallAlert(alert alertmanagerAlert) onCallAlert {
+	state := onCallStateAlerting
+	if alert.Status == "resolved" {
+		state = onCallStateOK
+	}
+
+	message := alert.Annotations["description"]
+	if message == "" {
+		message = alert.Annotations["summary"]
+	}
+
+	return onCallAlert{
+		AlertUID:              alert.Fingerprint,
+		Title:                 alert.Labels["alertname"],
+		State:                 state,
+		Message:               message,
+		LinkToUpstreamDetails: alert.GeneratorURL,
+	}
+}
@@ -0,0 +1,252 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+const alertmanagerWebhookPayload = `{
+  "version": "4",
+  "status": "firing",
+  "receiver": "tenant-receiver-1",
+  "alerts": [
+    {
+      "status": "firing",
+      "labels": {"alertname": "HighCPUUsage", "projectId": "tenant"},
+      "annotations": {"summary": "CPU usage is high", "description": "CPU usage is above 80%"},
+      "generatorURL": "http://mimir/graph",
+      "fingerprint": "a1b2c3"
+    },
+    {
+      "status": "resolved",
+      "labels": {"alertname": "HighMemoryUsage", "projectId": "tenant"},
+      "annotations": {"summary": "Memory usage is high"},
+      "fingerprint": "d4e5f6"
+    }
+  ]
+}`
+
+func TestOnCallRelay(t *testing.T) {
+	id := uuid.New()
+	tenantID := "tenant"
+	uri := fmt.Sprintf("%s/%s/%s", onCallRelayEndpoint, tenantID, id)
+	// Relays cannot be served without a token or signing key.
+	t.Setenv("ONCALL_RELAY_TOKEN", "relay-token")
+
+	newServer := func(relay *onCallRelay) *echo.Echo {
+		auth, err := newWebhookAuthenticator(config.WebhookAuthConfig{}, "ONCALL_RELAY_TOKEN")
+		require.NoError(t, err)
+		e := echo.New()
+		e.POST(onCallRelayEndpoint+"/:tenantID/:receiverID", relay.relay, auth.authenticate)
+		return e
+	}
+
+	post := func(e *echo.Echo, uri, body, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, uri, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("Relays alerts to the integration of the routing key", func(t *testing.T) {
+		var received []onCallAlert
+		onCall := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "/integrations/v1/formatted_webhook/routing-key/", r.URL.Path)
+
+			var alert onCallAlert
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&alert))
+			received = append(received, alert)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer onCall.Close()
+
+		mReceiver := &ReceiverMock{}
+		mReceiver.On("GetLatestReceiverWithEmailConfig", mock.Anything, tenantID, id).
+			Return(&models.DBReceiver{UUID: id, TenantID: tenantID, OnCallRoutingKey: "routing-key"}, nil).Once()
+
+		relay := newOnCallRelay(config.OnCallConfig{URL: onCall.URL + "/integrations/v1/formatted_webhook/", Timeout: time.Second}, mReceiver)
+		rec := post(newServer(relay), uri, alertmanagerWebhookPayload, "relay-token")
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, []onCallAlert{
+			{
+				AlertUID:              "a1b2c3",
+				Title:                 "HighCPUUsage",
+				State:                 onCallStateAlerting,
+				Message:               "CPU usage is above 80%",
+				LinkToUpstreamDetails: "http://mimir/graph",
+			},
+			{
+				AlertUID: "d4e5f6",
+				Title:    "HighMemoryUsage",
+				State:    onCallStateOK,
+				Message:  "Memory usage is high",
+			},
+		}, received)
+		mReceiver.AssertExpectations(t)
+	})
+
+	t.Run("Grafana OnCall rejects alerts", func(t *testing.T) {
+		onCall := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer onCall.Close()
+
+		mReceiver := &ReceiverMock{}
+		mReceiver.On("GetLatestReceiverWithEmailConfig", mock.Anything, tenantID, id).
+			Return(&models.DBReceiver{UUID: id, TenantID: tenantID, OnCallRoutingKey: "routing-key"}, nil).Once()
+
+		relay := newOnCallRelay(config.OnCallConfig{URL: onCall.URL, Timeout: time.Second}, mReceiver)
+		rec := post(newServer(relay), uri, alertmanagerWebhookPayload, "relay-token")
+
+		require.Equal(t, http.StatusBadGateway, rec.Code)
+		mReceiver.AssertExpectations(t)
+	})
+
+	t.Run("Alerts queued for retry", func(t *testing.T) {
+		var calls int
+		onCall := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			calls++
+			if calls > 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		}))
+		defer onCall.Close()
+
+		mReceiver := &ReceiverMock{}
+		mReceiver.On("GetLatestReceiverWithEmailConfig", mock.Anything, tenantID, id).
+			Return(&models.DBReceiver{UUID: id, TenantID: tenantID, OnCallRoutingKey: "routing-key"}, nil).Once()
+		mQueue := &NotificationQueueMock{}
+		mQueue.On("EnqueueNotifications", mock.Anything, mock.Anything).Return(nil).Once()
+
+		relay := newOnCallRelay(config.OnCallConfig{URL: onCall.URL, Timeout: time.Second}, mReceiver)
+		relay.queue = mQueue
+		rec := post(newServer(relay), uri, alertmanagerWebhookPayload, "relay-token")
+
+		// The alert relayed before the failure is not queued.
+		require.Equal(t, http.StatusOK, rec.Code)
+		tasks := mQueue.Calls[0].Arguments.Get(1).([]models.NotificationTask)
+		require.Len(t, tasks, 1)
+		require.Equal(t, models.NotificationOnCall, tasks[0].Channel)
+		require.Equal(t, "routing-key", tasks[0].Recipient)
+		require.Equal(t, id, tasks[0].ReceiverUUID)
+		require.Contains(t, tasks[0].Payload, `"alert_uid":"d4e5f6"`)
+		mReceiver.AssertExpectations(t)
+	})
+
+	t.Run("Alerts rejected by Grafana OnCall are not queued", func(t *testing.T) {
+		onCall := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer onCall.Close()
+
+		mReceiver := &ReceiverMock{}
+		mReceiver.On("GetLatestReceiverWithEmailConfig", mock.Anything, tenantID, id).
+			Return(&models.DBReceiver{UUID: id, TenantID: tenantID, OnCallRoutingKey: "routing-key"}, nil).Once()
+		mQueue := &NotificationQueueMock{}
+
+		relay := newOnCallRelay(config.OnCallConfig{URL: onCall.URL, Timeout: time.Second}, mReceiver)
+		relay.queue = mQueue
+		rec := post(newServer(relay), uri, alertmanagerWebhookPayload, "relay-token")
+
+		require.Equal(t, http.StatusBadGateway, rec.Code)
+		mQueue.AssertNotCalled(t, "EnqueueNotifications", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Receiver without routing key", func(t *testing.T) {
+		mReceiver := &ReceiverMock{}
+		mReceiver.On("GetLatestReceiverWithEmailConfig", mock.Anything, tenantID, id).
+			Return(&models.DBReceiver{UUID: id, TenantID: tenantID}, nil).Once()
+
+		relay := newOnCallRelay(config.OnCallConfig{URL: "http://127.0.0.1:0", Timeout: time.Second}, mReceiver)
+		rec := post(newServer(relay), uri, alertmanagerWebhookPayload, "relay-token")
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		mReceiver.AssertExpectations(t)
+	})
+
+	t.Run("Receiver not found", func(t *testing.T) {
+		mReceiver := &ReceiverMock{}
+		mReceiver.On("GetLatestReceiverWithEmailConfig", mock.Anything, tenantID, id).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		relay := newOnCallRelay(config.OnCallConfig{}, mReceiver)
+		rec := post(newServer(relay), uri, alertmanagerWebhookPayload, "relay-token")
+
+		require.Equal(t, http.StatusNotFound, rec.Code)
+		mReceiver.AssertExpectations(t)
+	})
+
+	t.Run("Failed to get receiver", func(t *testing.T) {
+		mReceiver := &ReceiverMock{}
+		mReceiver.On("GetLatestReceiverWithEmailConfig", mock.Anything, tenantID, id).Return(nil, errors.New("mock error")).Once()
+
+		relay := newOnCallRelay(config.OnCallConfig{}, mReceiver)
+		rec := post(newServer(relay), uri, alertmanagerWebhookPayload, "relay-token")
+
+		require.Equal(t, http.StatusInternalServerError, rec.Code)
+		mReceiver.AssertExpectations(t)
+	})
+
+	t.Run("Invalid receiver ID", func(t *testing.T) {
+		relay := newOnCallRelay(config.OnCallConfig{}, &ReceiverMock{})
+		rec := post(newServer(relay), fmt.Sprintf("%s/%s/invalid", onCallRelayEndpoint, tenantID), alertmanagerWebhookPayload, "relay-token")
+
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("Invalid payload", func(t *testing.T) {
+		relay := newOnCallRelay(config.OnCallConfig{}, &ReceiverMock{})
+		rec := post(newServer(relay), uri, `{"alerts":`, "relay-token")
+
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("Relay token", func(t *testing.T) {
+		mReceiver := &ReceiverMock{}
+		mReceiver.On("GetLatestReceiverWithEmailConfig", mock.Anything, tenantID, id).
+			Return(&models.DBReceiver{UUID: id, TenantID: tenantID}, nil).Once()
+
+		e := newServer(newOnCallRelay(config.OnCallConfig{}, mReceiver))
+		require.Equal(t, http.StatusUnauthorized, post(e, uri, alertmanagerWebhookPayload, "").Code)
+		require.Equal(t, http.StatusUnauthorized, post(e, uri, alertmanagerWebhookPayload, "other-token").Code)
+		require.Equal(t, http.StatusOK, post(e, uri, alertmanagerWebhookPayload, "relay-token").Code)
+		mReceiver.AssertExpectations(t)
+	})
+}
+
+func TestParseOnCallConfig(t *testing.T) {
+	key := "Xk2_routing-key"
+	routingKey, err := parseOnCallConfig(api.OnCallConfig{RoutingKey: &key})
+	require.NoError(t, err)
+	require.Equal(t, key, routingKey)
+
+	routingKey, err = parseOnCallConfig(api.OnCallConfig{})
+	require.NoError(t, err)
+	require.Empty(t, routingKey)
+
+	invalid := "../key"
+	_, err = parseOnCallConfig(api.OnCallConfig{RoutingKey: &invalid})
+	require.ErrorContains(t, err, "invalid Grafana OnCall routing key")
+}
@@ -0,0 +1,112 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	db "github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+const (
+	errHTTPOperationNotFound     = "operation not found"
+	errHTTPFailedToGetOperation  = "failed to get operation"
+	errHTTPOperationsUnavailable = "operations are not available"
+)
+
+// withOperation returns the context an update is made with, along with the ID of the operation applying it if the update is
+// answered with its operation, so that the task of the update records the operation it is awaited by.
+func withOperation(ctx context.Context, requested bool) (context.Context, *api.OperationId) {
+	if !requested {
+		return ctx, nil
+	}
+	id := uuid.New()
+	return db.NewOperationContext(ctx, id), &id
+}
+
+// GetOperation gets the progress and final state of an operation of a tenant, which applies an update of an alert definition
+// or receiver made asynchronously.
+func (w *ServerInterfaceHandler) GetOperation(ctx echo.Context, tenantID api.TenantID, id api.OperationId) error {
+	if w.operations == nil {
+		logWarn(ctx, "Operations are not available")
+		return ctx.JSON(http.StatusServiceUnavailable, api.HttpError{
+			Code:      http.StatusServiceUnavailable,
+			Message:   errHTTPOperationsUnavailable,
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+
+	task, err := w.operations.GetOperation(ctx.Request().Context(), tenantID, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		logError(ctx, fmt.Sprintf("Operation not found: %q", id), err)
+		return ctx.JSON(http.StatusNotFound, api.HttpError{
+			Code:      http.StatusNotFound,
+			Message:   errHTTPOperationNotFound,
+			ErrorCode: api.ErrorCodeOperationNotFound,
+		})
+	} else if err != nil {
+		logError(ctx, fmt.Sprintf("Failed to get operation: %q", id), err)
+		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToGetOperation,
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+	return ctx.JSON(http.StatusOK, operationToAPI(id, *task))
+}
+
+// operationToAPI converts the summary of the task of an operation to the operation served by the API. Tasks set as invalid
+// without error were not applied because a later version of their alert definition or receiver superseded them, while the
+// others failed once out of retries.
+func operationToAPI(id api.OperationId, task models.TaskHistory) api.Operation {
+	op := api.Operation{
+		Id:         id,
+		TargetId:   task.UUID,
+		Version:    task.Version,
+		CreatedAt:  task.CreationDate,
+		RetryCount: task.RetryCount,
+	}
+
+	switch task.Type {
+	case models.TypeAlertDefinition:
+		op.Type = api.OperationTypeAlertDefinition
+	case models.TypeReceiver:
+		op.Type = api.OperationTypeReceiver
+	}
+
+	switch task.State {
+	case models.TaskTaken:
+		op.State = api.OperationRunning
+	case models.TaskApplied:
+		op.State = api.OperationSucceeded
+	case models.TaskInvalid:
+		op.State = api.OperationSuperseded
+		if task.Error != "" {
+			op.State = api.OperationFailed
+		}
+	default:
+		op.State = api.OperationPending
+	}
+	op.Done = op.State == api.OperationSucceeded || op.State == api.OperationFailed || op.State == api.OperationSuperseded
+
+	if !task.StartDate.IsZero() {
+		op.StartedAt = &task.StartDate
+	}
+	if op.Done && !task.CompletionDate.IsZero() {
+		op.CompletedAt = &task.CompletionDate
+	}
+	if task.Error != "" {
+		op.Error = &task.Error
+	}
+	return op
+}
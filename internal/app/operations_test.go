@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/testutil"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+func TestGetOperation(t *testing.T) {
+	conn, err := gorm.Open(sqlite.Open(":memory:"))
+	require.NoError(t, err)
+	require.NoError(t, conn.AutoMigrate(&models.Task{}, &models.TaskHistory{}))
+
+	tenantID := "edgenode"
+	created := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	definitionID := uuid.New()
+	receiverID := uuid.New()
+
+	running := uuid.New()
+	require.NoError(t, conn.Create(&models.Task{
+		State:               models.TaskTaken,
+		AlertDefinitionUUID: &definitionID,
+		TenantID:            tenantID,
+		Version:             2,
+		CreationDate:        created,
+		StartDate:           created.Add(time.Second),
+		RetryCount:          1,
+		Error:               "failed to reach mimir",
+		OperationID:         &running,
+	}).Error)
+
+	archived := uuid.New()
+	require.NoError(t, conn.Create(&models.TaskHistory{
+		TenantID:       tenantID,
+		Type:           models.TypeReceiver,
+		UUID:           receiverID,
+		Version:        3,
+		State:          models.TaskApplied,
+		CreationDate:   created,
+		StartDate:      created.Add(time.Second),
+		CompletionDate: created.Add(2 * time.Second),
+		OperationID:    &archived,
+	}).Error)
+
+	server := echo.New()
+	api.RegisterHandlers(server, &ServerInterfaceHandler{
+		operations: &database.DBService{DB: conn},
+	})
+
+	get := func(t *testing.T, tenantID string, id uuid.UUID) *testutil.CompletedRequest {
+		return testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get("/api/v1/operations/"+id.String()).
+			GoWithHTTPHandler(t, server)
+	}
+
+	t.Run("Operation of a task being applied", func(t *testing.T) {
+		result := get(t, tenantID, running)
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+
+		var op api.Operation
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &op))
+		require.Equal(t, running, op.Id)
+		require.Equal(t, api.OperationTypeAlertDefinition, op.Type)
+		require.Equal(t, definitionID, op.TargetId)
+		require.Equal(t, int64(2), op.Version)
+		require.Equal(t, api.OperationRunning, op.State)
+		require.False(t, op.Done)
+		require.Equal(t, int64(1), op.RetryCount)
+		require.Equal(t, "failed to reach mimir", *op.Error)
+		require.Nil(t, op.CompletedAt)
+	})
+
+	t.Run("Operation of an archived task", func(t *testing.T) {
+		result := get(t, tenantID, archived)
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+
+		var op api.Operation
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &op))
+		require.Equal(t, api.OperationTypeReceiver, op.Type)
+		require.Equal(t, receiverID, op.TargetId)
+		require.Equal(t, api.OperationSucceeded, op.State)
+		require.True(t, op.Done)
+		require.True(t, created.Add(2*time.Second).Equal(*op.CompletedAt))
+		require.Nil(t, op.Error)
+	})
+
+	t.Run("Operation of another tenant is not found", func(t *testing.T) {
+		result := get(t, "other", running)
+		require.Equal(t, http.StatusNotFound, result.Recorder.Code)
+
+		var httpErr api.HttpError
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &httpErr))
+		require.Equal(t, api.ErrorCodeOperationNotFound, httpErr.ErrorCode)
+	})
+
+	t.Run("Operations are not available", func(t *testing.T) {
+		server := echo.New()
+		api.RegisterHandlers(server, &ServerInterfaceHandler{})
+
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get("/api/v1/operations/"+running.String()).
+			GoWithHTTPHandler(t, server)
+		require.Equal(t, http.StatusServiceUnavailable, result.Recorder.Code)
+	})
+}
+
+func TestOperationToAPI(t *testing.T) {
+	for name, tc := range map[string]struct {
+		state models.TaskState
+		err   string
+		want  api.OperationState
+		done  bool
+	}{
+		"new task":               {state: models.TaskNew, want: api.OperationPending},
+		"task to retry":          {state: models.TaskError, err: "failed", want: api.OperationPending},
+		"taken task":             {state: models.TaskTaken, want: api.OperationRunning},
+		"applied task":           {state: models.TaskApplied, want: api.OperationSucceeded, done: true},
+		"task out of retries":    {state: models.TaskInvalid, err: "failed", want: api.OperationFailed, done: true},
+		"task of older versions": {state: models.TaskInvalid, want: api.OperationSuperseded, done: true},
+	} {
+		op := operationToAPI(uuid.New(), models.TaskHistory{State: tc.state, Error: tc.err, CompletionDate: time.Now()})
+		require.Equal(t, tc.want, op.State, name)
+		require.Equal(t, tc.done, op.Done, name)
+		require.Equal(t, tc.done, op.CompletedAt != nil, name)
+	}
+}
@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/notify/email"
+)
+
+const (
+	errHTTPEmailPreviewUnavailable          = "email preview is not available"
+	errHTTPReceiverConfigPreviewUnavailable = "alertmanager configuration preview is not available"
+	errHTTPFailedToPreviewAlertReceiver     = "failed to preview alert receiver"
+)
+
+// emailRenderer renders the subject and HTML body of the email of a notification, with the given HTML body template or
+// the template of the deployment in the given language if empty.
+type emailRenderer interface {
+	Render(data email.Data, text, language string) (string, string, error)
+}
+
+// PreviewAlertReceiver renders the HTML body of the email the given receiver notifies with, for a sample alert of the tenant,
+// so that admins can see what notifications look like before enabling the receiver.
+func (w *ServerInterfaceHandler) PreviewAlertReceiver(ctx echo.Context, tenantID api.TenantID, id api.ReceiverId) error {
+	if w.emailTemplate == nil {
+		logWarn(ctx, "Email templates are not loaded, emails cannot be previewed")
+		return ctx.JSON(http.StatusServiceUnavailable, api.HttpError{
+			Code:      http.StatusServiceUnavailable,
+			Message:   errHTTPEmailPreviewUnavailable,
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+
+	recv, err := w.receivers.GetLatestReceiverWithEmailConfig(ctx.Request().Context(), tenantID, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		logError(ctx, fmt.Sprintf("Alert receiver not found: %q", id), err)
+		return ctx.JSON(http.StatusNotFound, api.HttpError{
+			Code:      http.StatusNotFound,
+			Message:   errHTTPAlertReceiverNotFound,
+			ErrorCode: api.ErrorCodeReceiverNotFound,
+		})
+	} else if err != nil {
+		logError(ctx, fmt.Sprintf("Failed to get alert receiver with UUID: %q", id), err)
+		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToGetAlertReceiver,
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+
+	data := previewEmailData(recv, w.tenantLabel())
+	// The sample alert is annotated with the metadata of the tenant, as the alerts of sent emails are.
+	if metadata, ok := getTenantMetadata(ctx, w.tenantMetadata, tenantID); ok {
+		for i := range data.Alerts {
+			data.Alerts[i].Annotations = metadata.Annotate(data.Alerts[i].Annotations)
+		}
+		data.CommonAnnotations = metadata.Annotate(data.CommonAnnotations)
+	}
+
+	_, body, err := w.emailTemplate.Render(data, recv.EmailTemplate, recv.Language)
+	if errors.Is(err, email.ErrRenderLimit) {
+		logWarn(ctx, fmt.Sprintf("Email template of alert receiver %q exceeds the rendering limits: %v", id, err))
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   err.Error(),
+			ErrorCode: api.ErrorCodeEmailTemplateLimitExceeded,
+		})
+	} else if err != nil {
+		logError(ctx, fmt.Sprintf("Failed to render email of alert receiver %q", id), err)
+		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToPreviewAlertReceiver,
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+	return ctx.HTML(http.StatusOK, body)
+}
+
+// previewEmailData returns the alertmanager notification of a sample firing alert of the tenant of the given receiver, as
+// raised by the host CPU usage alert definition and labeled with the tenant by the given tenant label.
+func previewEmailData(recv *models.DBReceiver, tenantLabel string) email.Data {
+	labels := email.KV{
+		"alertname":      "CPUUsageExceedsThreshold",
+		"alert_category": "performance",
+		"alert_context":  "host",
+		"duration":       "30s",
+		"threshold":      "80",
+		"host_uuid":      "00000000-0000-0000-0000-000000000000",
+		tenantLabel:      recv.TenantID,
+	}
+	annotations := email.KV{
+		"description":  "Host 00000000-0000-0000-0000-000000000000 CPU usage is over the threshold.",
+		"display_name": "Host CPU Usage Exceeds Threshold",
+	}
+	groupLabels := email.KV{
+		"alertname": labels["alertname"],
+	}
+
+	return email.Data{
+		Receiver: fmt.Sprintf("%s-%s-%d", recv.TenantID, recv.Name, recv.Version),
+		Status:   "firing",
+		Alerts: email.Alerts{
+			{
+				Status:      "firing",
+				Labels:      labels,
+				Annotations: annotations,
+				StartsAt:    clock.TimeNowFn().UTC(),
+			},
+		},
+		GroupLabels:       groupLabels,
+		CommonLabels:      labels,
+		CommonAnnotations: annotations,
+		GroupKey:          fmt.Sprintf(`{}:{alertname=%q}`, labels["alertname"]),
+	}
+}
@@ -0,0 +1,145 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/testutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/notify/email"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/tenantmeta"
+)
+
+const previewTemplate = `{{ define "alert.monitor.mail" }}<h1>{{ template "__subject" . }}</h1>{{ range .Alerts.Firing }}` +
+	`<p>{{ .Annotations.project_name }}: {{ .Annotations.display_name }}</p>{{ end }}{{ end }}`
+
+func TestPreviewAlertReceiver(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "email.tmpl"), []byte(previewTemplate), 0o600))
+	tmpl, err := email.NewTemplate(filepath.Join(dir, "*.tmpl"))
+	require.NoError(t, err)
+
+	tenantID := "edgenode"
+	id := uuid.New()
+	uri := fmt.Sprintf("/api/v1/alerts/receivers/%v/preview", id)
+
+	newServer := func(handler *ServerInterfaceHandler) *echo.Echo {
+		server := echo.New()
+		api.RegisterHandlers(server, handler)
+		return server
+	}
+
+	t.Run("Render email of sample alert", func(t *testing.T) {
+		mReceiver := &ReceiverMock{}
+		mReceiver.On("GetLatestReceiverWithEmailConfig", mock.Anything, tenantID, id).Return(&models.DBReceiver{
+			UUID:     id,
+			Name:     "receiver",
+			Version:  2,
+			TenantID: tenantID,
+		}, nil).Once()
+		metadataMock := &TenantMetadataMock{}
+		metadataMock.On("Get", mock.Anything, tenantID).Return(tenantmeta.Metadata{DisplayName: "Edge Project"}, nil).Once()
+
+		server := newServer(&ServerInterfaceHandler{
+			receivers:      mReceiver,
+			tenantMetadata: metadataMock,
+			emailTemplate:  tmpl,
+		})
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get(uri).GoWithHTTPHandler(t, server)
+
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+		require.Equal(t, echo.MIMETextHTMLCharsetUTF8, result.Recorder.Header().Get(echo.HeaderContentType))
+		require.Equal(t, "<h1>[FIRING:1] CPUUsageExceedsThreshold (performance host 30s 00000000-0000-0000-0000-000000000000 edgenode 80)</h1>"+
+			"<p>Edge Project: Host CPU Usage Exceeds Threshold</p>", result.Recorder.Body.String())
+		mReceiver.AssertExpectations(t)
+		metadataMock.AssertExpectations(t)
+	})
+
+	t.Run("Receiver not found - code should be 404", func(t *testing.T) {
+		mReceiver := &ReceiverMock{}
+		mReceiver.On("GetLatestReceiverWithEmailConfig", mock.Anything, tenantID, id).Return(nil, fmt.Errorf("mock error: %w", gorm.ErrRecordNotFound)).Once()
+
+		server := newServer(&ServerInterfaceHandler{
+			receivers:     mReceiver,
+			emailTemplate: tmpl,
+		})
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get(uri).GoWithHTTPHandler(t, server)
+
+		var httpErr api.HttpError
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &httpErr))
+		require.Equal(t, http.StatusNotFound, httpErr.Code)
+		require.Equal(t, api.ErrorCodeReceiverNotFound, httpErr.ErrorCode)
+		mReceiver.AssertExpectations(t)
+	})
+
+	t.Run("Failed to get receiver - code should be 500", func(t *testing.T) {
+		mReceiver := &ReceiverMock{}
+		mReceiver.On("GetLatestReceiverWithEmailConfig", mock.Anything, tenantID, id).Return(nil, errors.New("mock error")).Once()
+
+		server := newServer(&ServerInterfaceHandler{
+			receivers:     mReceiver,
+			emailTemplate: tmpl,
+		})
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get(uri).GoWithHTTPHandler(t, server)
+
+		var httpErr api.HttpError
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &httpErr))
+		require.Equal(t, http.StatusInternalServerError, httpErr.Code)
+		require.Equal(t, errHTTPFailedToGetAlertReceiver, httpErr.Message)
+		mReceiver.AssertExpectations(t)
+	})
+
+	t.Run("Template exceeding the rendering limits - code should be 400", func(t *testing.T) {
+		mReceiver := &ReceiverMock{}
+		mReceiver.On("GetLatestReceiverWithEmailConfig", mock.Anything, tenantID, id).Return(&models.DBReceiver{
+			UUID:          id,
+			Name:          "receiver",
+			Version:       2,
+			TenantID:      tenantID,
+			EmailTemplate: `{{ define "loop" }}` + strings.Repeat("a", 1024) + `{{ template "loop" . }}{{ end }}{{ template "loop" . }}`,
+		}, nil).Once()
+
+		server := newServer(&ServerInterfaceHandler{
+			receivers:     mReceiver,
+			emailTemplate: tmpl,
+		})
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get(uri).GoWithHTTPHandler(t, server)
+
+		var httpErr api.HttpError
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &httpErr))
+		require.Equal(t, http.StatusBadRequest, httpErr.Code)
+		require.Equal(t, api.ErrorCodeEmailTemplateLimitExceeded, httpErr.ErrorCode)
+		mReceiver.AssertExpectations(t)
+	})
+
+	t.Run("Email templates not loaded - code should be 503", func(t *testing.T) {
+		mReceiver := &ReceiverMock{}
+
+		server := newServer(&ServerInterfaceHandler{
+			receivers: mReceiver,
+		})
+		result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get(uri).GoWithHTTPHandler(t, server)
+
+		var httpErr api.HttpError
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &httpErr))
+		require.Equal(t, http.StatusServiceUnavailable, httpErr.Code)
+		require.Equal(t, errHTTPEmailPreviewUnavailable, httpErr.Message)
+		mReceiver.AssertNotCalled(t, "GetLatestReceiverWithEmailConfig", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"database/sql"
+	"encoding/json"
+	"expvar"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	profilingEndpoint   = "/debug/pprof"
+	diagnosticsEndpoint = "/debug/vars"
+)
+
+// registerProfiling registers the pprof endpoints, so that CPU, heap and goroutine profiles can be taken from a running
+// server, for instance while it is under load, along with the runtime diagnostics endpoint.
+func registerProfiling(e *echo.Echo, sqlDB *sql.DB) {
+	g := e.Group(profilingEndpoint)
+	g.GET("/cmdline", echo.WrapHandler(http.HandlerFunc(pprof.Cmdline)))
+	g.GET("/profile", echo.WrapHandler(http.HandlerFunc(pprof.Profile)))
+	g.GET("/symbol", echo.WrapHandler(http.HandlerFunc(pprof.Symbol)))
+	g.POST("/symbol", echo.WrapHandler(http.HandlerFunc(pprof.Symbol)))
+	g.GET("/trace", echo.WrapHandler(http.HandlerFunc(pprof.Trace)))
+	// Index serves the named profiles, such as heap and goroutine, besides the index itself.
+	g.GET("/*", echo.WrapHandler(http.HandlerFunc(pprof.Index)))
+
+	e.GET(diagnosticsEndpoint, diagnostics(sqlDB))
+}
+
+// diagnostics returns a handler serving the variables published through expvar, such as memory statistics and the timing
+// of the task loop of the executor, along with the number of goroutines and the statistics of the database connection pool.
+func diagnostics(sqlDB *sql.DB) echo.HandlerFunc {
+	return func(ctx echo.Context) error {
+		vars := make(map[string]any)
+		expvar.Do(func(kv expvar.KeyValue) {
+			vars[kv.Key] = json.RawMessage(kv.Value.String())
+		})
+		vars["goroutines"] = runtime.NumGoroutine()
+		vars["db"] = sqlDB.Stats()
+
+		return ctx.JSON(http.StatusOK, vars)
+	}
+}
@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestRegisterProfiling(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"))
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	defer sqlDB.Close()
+
+	e := echo.New()
+	registerProfiling(e, sqlDB)
+
+	for _, uri := range []string{profilingEndpoint + "/", profilingEndpoint + "/goroutine?debug=1", profilingEndpoint + "/cmdline"} {
+		req := httptest.NewRequest(http.MethodGet, uri, nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code, uri)
+	}
+
+	t.Run("Diagnostics", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, diagnosticsEndpoint, nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var vars map[string]json.RawMessage
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &vars))
+		require.Contains(t, vars, "memstats")
+		require.Contains(t, vars, "db")
+
+		var goroutines int
+		require.NoError(t, json.Unmarshal(vars["goroutines"], &goroutines))
+		require.Positive(t, goroutines)
+	})
+}
@@ -0,0 +1,202 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	db "github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+const errHTTPFailedToSyncRecipients = "failed to sync recipients of alert receivers"
+
+// SyncAlertReceiverRecipients applies a list of email recipients to all receivers of a tenant at once, such as removing an
+// offboarded employee from every receiver. Each receiver is validated as if it was patched, receivers failing validation being
+// reported and left unchanged, while the others are updated together. The outcome for each receiver is returned.
+func (w *ServerInterfaceHandler) SyncAlertReceiverRecipients(ctx echo.Context, tenantID api.TenantID) error {
+	var reqBody api.RecipientSync
+	dec := json.NewDecoder(ctx.Request().Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&reqBody); err != nil {
+		logError(ctx, "Failed to parse body of recipient sync", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPBadRequest,
+			ErrorCode: api.ErrorCodeInvalidRequestBody,
+		})
+	}
+
+	mode := api.RecipientSyncModeReplace
+	if reqBody.Mode != nil {
+		mode = *reqBody.Mode
+	}
+	recipients, err := parseEmailRecipients(reqBody.Recipients)
+	if err == nil && !slices.Contains([]api.RecipientSyncMode{api.RecipientSyncModeReplace, api.RecipientSyncModeAdd, api.RecipientSyncModeRemove}, mode) {
+		err = fmt.Errorf("invalid mode: %q", mode)
+	}
+	if err != nil {
+		logError(ctx, "Failed to parse recipient sync", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPBadRequest,
+			ErrorCode: api.ErrorCodeInvalidRequestBody,
+		})
+	}
+
+	// Removed recipients need not be allowed, as they are usually no longer users, e.g. once offboarded.
+	if mode != api.RecipientSyncModeRemove {
+		allowed, err := getAllowedEmailList(ctx, w.m2m)
+		if err != nil {
+			logError(ctx, "Failed to get allowed email recipients", err)
+			return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+				Code:      http.StatusInternalServerError,
+				Message:   errHTTPFailedToSyncRecipients,
+				ErrorCode: api.ErrorCodeInternalError,
+			})
+		}
+
+		if err := validateRecipients(reqBody.Recipients, allowed); err != nil {
+			logError(ctx, "Email recipient list contains not allowed email recipient/s", err)
+			lang := responseLanguage(ctx)
+			return ctx.JSON(http.StatusBadRequest, api.HttpError{
+				Code:      http.StatusBadRequest,
+				Message:   localize(lang, msgBadRequest),
+				ErrorCode: api.ErrorCodeRecipientNotAllowed,
+				Details:   notAllowedRecipientDetails(lang, "recipients", reqBody.Recipients, allowed),
+			})
+		}
+	}
+
+	recvs, _, err := w.receivers.GetLatestReceiverListWithEmailConfig(ctx.Request().Context(), tenantID, db.ListOptions{})
+	if err != nil {
+		logError(ctx, "Failed to get alert receivers", err)
+		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToSyncRecipients,
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+
+	results := make([]api.ReceiverSyncResult, 0, len(recvs))
+	updates := make(map[uuid.UUID][]models.EmailAddress)
+	for _, recv := range recvs {
+		result := api.ReceiverSyncResult{
+			Id:     recv.UUID,
+			Name:   recv.Name,
+			Status: api.ReceiverSyncUnchanged,
+		}
+
+		synced, changed, err := syncRecipients(recv.To, recipients, mode)
+		if err != nil {
+			logError(ctx, fmt.Sprintf("Failed to parse email recipients of receiver %q", recv.UUID), err)
+			result = failedSync(result, &api.HttpError{Message: errHTTPFailedToSyncRecipients, ErrorCode: api.ErrorCodeInternalError})
+		} else if changed {
+			if httpErr := w.checkSyncedReceiver(ctx, tenantID, recv.UUID, models.DBReceiverValues{Recipients: synced}); httpErr != nil {
+				result = failedSync(result, httpErr)
+			} else {
+				updates[recv.UUID] = synced
+				result.Status = api.ReceiverSyncUpdated
+			}
+		}
+		results = append(results, result)
+	}
+
+	if len(updates) > 0 {
+		updateCtx := ctx.Request().Context()
+		if w.verifier != nil {
+			updateCtx = db.NewEmailVerificationContext(updateCtx)
+		}
+		if err := w.receivers.SetReceiversRecipients(updateCtx, tenantID, updates); err != nil {
+			logError(ctx, fmt.Sprintf("Failed to set recipients of alert receivers of tenant %q", tenantID), err)
+			return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+				Code:      http.StatusInternalServerError,
+				Message:   errHTTPFailedToSyncRecipients,
+				ErrorCode: api.ErrorCodeInternalError,
+			})
+		}
+
+		if w.verifier != nil && mode != api.RecipientSyncModeRemove {
+			w.verifier.sendVerifications(ctx, recipients)
+		}
+	}
+	return ctx.JSON(http.StatusOK, api.RecipientSyncResult{Receivers: results})
+}
+
+// checkSyncedReceiver verifies that the given values of a receiver are within the service level of the tier of its tenant and
+// can be applied to the alertmanager configuration, as a patch of the receiver is. The error the receiver fails with is
+// returned otherwise.
+func (w *ServerInterfaceHandler) checkSyncedReceiver(ctx echo.Context, tenantID api.TenantID, id api.ReceiverId, values models.DBReceiverValues) *api.HttpError {
+	if httpErr := w.checkReceiverTier(ctx, tenantID, id, values); httpErr != nil {
+		return httpErr
+	}
+
+	err := w.validateReceiverConfig(ctx.Request().Context(), tenantID, id, values)
+	switch {
+	case errors.Is(err, ErrConfigLimitExceeded):
+		logError(ctx, fmt.Sprintf("Alert receiver %q exceeds alertmanager configuration limits", id), err)
+		return &api.HttpError{Message: errHTTPReceiverConfigLimitExceeded, ErrorCode: api.ErrorCodeReceiverConfigLimitExceeded}
+	case errors.Is(err, ErrRouteConflict):
+		logError(ctx, fmt.Sprintf("Alert receiver %q has a conflicting alertmanager route", id), err)
+		return routeConflictError(ctx, err)
+	case errors.Is(err, ErrInvalidChannelConfig):
+		logError(ctx, fmt.Sprintf("Alert receiver %q has an invalid notification channel configuration", id), err)
+		return &api.HttpError{Message: errHTTPBadRequest, ErrorCode: api.ErrorCodeInvalidRequestBody}
+	case err != nil:
+		logError(ctx, fmt.Sprintf("Failed to validate alertmanager configuration of receiver with UUID: %q", id), err)
+		return &api.HttpError{Message: errHTTPFailedToSyncRecipients, ErrorCode: api.ErrorCodeInternalError}
+	}
+	return nil
+}
+
+// syncRecipients returns the recipients of a receiver, given as formatted in the API, once the given recipients are applied
+// to them in the given mode, and whether they changed. Recipients are matched by email address.
+func syncRecipients(current []string, recipients []models.EmailAddress, mode api.RecipientSyncMode) ([]models.EmailAddress, bool, error) {
+	existing, err := parseEmailRecipients(current)
+	if err != nil {
+		return nil, false, err
+	}
+
+	hasEmail := func(list []models.EmailAddress, email string) bool {
+		return slices.ContainsFunc(list, func(r models.EmailAddress) bool { return r.Email == email })
+	}
+
+	var synced []models.EmailAddress
+	switch mode {
+	case api.RecipientSyncModeAdd:
+		synced = slices.Clone(existing)
+		for _, r := range recipients {
+			if !hasEmail(existing, r.Email) {
+				synced = append(synced, r)
+			}
+		}
+	case api.RecipientSyncModeRemove:
+		synced = slices.DeleteFunc(slices.Clone(existing), func(r models.EmailAddress) bool {
+			return hasEmail(recipients, r.Email)
+		})
+	default:
+		synced = slices.Clone(recipients)
+	}
+
+	changed := len(synced) != len(existing) || slices.ContainsFunc(synced, func(r models.EmailAddress) bool {
+		return !slices.Contains(existing, r)
+	})
+	return synced, changed, nil
+}
+
+// failedSync returns the given outcome of a receiver, failed with the given error.
+func failedSync(result api.ReceiverSyncResult, httpErr *api.HttpError) api.ReceiverSyncResult {
+	result.Status = api.ReceiverSyncFailed
+	result.ErrorCode = &httpErr.ErrorCode
+	result.Message = &httpErr.Message
+	return result
+}
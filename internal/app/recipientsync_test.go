@@ -0,0 +1,185 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/testutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+func TestSyncAlertReceiverRecipients(t *testing.T) {
+	tenantID := "edgenode"
+	alice := "alice smith <alice@example.com>"
+	bob := "bob jones <bob@example.com>"
+
+	post := func(t *testing.T, server *echo.Echo, body string) *testutil.CompletedRequest {
+		return testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Post("/api/v1/alerts/receivers:syncRecipients").
+			WithBody([]byte(body)).GoWithHTTPHandler(t, server)
+	}
+
+	t.Run("Offboarded recipient is removed from all receivers", func(t *testing.T) {
+		both := &models.DBReceiver{UUID: uuid.New(), Name: "both", Version: 1, TenantID: tenantID, To: []string{alice, bob}}
+		aliceOnly := &models.DBReceiver{UUID: uuid.New(), Name: "alice-only", Version: 3, TenantID: tenantID, To: []string{alice}}
+		tooLarge := &models.DBReceiver{UUID: uuid.New(), Name: "too-large", Version: 2, TenantID: tenantID, To: []string{bob}}
+
+		mReceiver := &ReceiverMock{}
+		mReceiver.On("GetLatestReceiverListWithEmailConfig", mock.Anything, tenantID, database.ListOptions{}).
+			Return([]*models.DBReceiver{both, aliceOnly, tooLarge}, int64(3), nil).Once()
+		mReceiver.On("GetLatestReceiverWithEmailConfig", mock.Anything, tenantID, both.UUID).Return(both, nil).Once()
+		mReceiver.On("GetLatestReceiverWithEmailConfig", mock.Anything, tenantID, tooLarge.UUID).Return(tooLarge, nil).Once()
+		mReceiver.On("SetReceiversRecipients", mock.Anything, tenantID, map[uuid.UUID][]models.EmailAddress{
+			both.UUID: {{FirstName: "alice", LastName: "smith", Email: "alice@example.com"}},
+		}).Return(nil).Once()
+
+		mValidator := &ReceiverConfigValidatorMock{}
+		mValidator.On("ValidateReceiverConfig", mock.Anything, mock.MatchedBy(func(r models.DBReceiver) bool {
+			return r.UUID == both.UUID
+		})).Return(nil).Once()
+		mValidator.On("ValidateReceiverConfig", mock.Anything, mock.MatchedBy(func(r models.DBReceiver) bool {
+			return r.UUID == tooLarge.UUID
+		})).Return(fmt.Errorf("too many routes: %w", ErrConfigLimitExceeded)).Once()
+
+		// Removed recipients are not checked against the allowed ones, so no m2m authenticator is needed.
+		server := echo.New()
+		api.RegisterHandlers(server, &ServerInterfaceHandler{
+			receivers:    mReceiver,
+			receiversCfg: mValidator,
+		})
+
+		result := post(t, server, `{"recipients":["`+bob+`"],"mode":"remove"}`)
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+
+		var res api.RecipientSyncResult
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &res))
+		require.Len(t, res.Receivers, 3)
+
+		require.Equal(t, both.UUID, res.Receivers[0].Id)
+		require.Equal(t, api.ReceiverSyncUpdated, res.Receivers[0].Status)
+
+		require.Equal(t, aliceOnly.UUID, res.Receivers[1].Id)
+		require.Equal(t, api.ReceiverSyncUnchanged, res.Receivers[1].Status)
+
+		require.Equal(t, tooLarge.UUID, res.Receivers[2].Id)
+		require.Equal(t, api.ReceiverSyncFailed, res.Receivers[2].Status)
+		require.Equal(t, api.ErrorCodeReceiverConfigLimitExceeded, *res.Receivers[2].ErrorCode)
+		require.Equal(t, errHTTPReceiverConfigLimitExceeded, *res.Receivers[2].Message)
+
+		require.True(t, mReceiver.AssertExpectations(t))
+		require.True(t, mValidator.AssertExpectations(t))
+	})
+
+	t.Run("No receiver changed", func(t *testing.T) {
+		recv := &models.DBReceiver{UUID: uuid.New(), Name: "receiver", Version: 1, TenantID: tenantID, To: []string{alice}}
+
+		mM2M := &M2MAuthenticatorMock{}
+		mM2M.On("GetUserList", mock.Anything).Return([]user{{FirstName: "alice", LastName: "smith", Email: "alice@example.com"}}, nil).Once()
+
+		mReceiver := &ReceiverMock{}
+		mReceiver.On("GetLatestReceiverListWithEmailConfig", mock.Anything, tenantID, database.ListOptions{}).
+			Return([]*models.DBReceiver{recv}, int64(1), nil).Once()
+
+		server := echo.New()
+		api.RegisterHandlers(server, &ServerInterfaceHandler{
+			m2m:       mM2M,
+			receivers: mReceiver,
+		})
+
+		result := post(t, server, `{"recipients":["`+alice+`"],"mode":"add"}`)
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+
+		var res api.RecipientSyncResult
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &res))
+		require.Equal(t, []api.ReceiverSyncResult{{Id: recv.UUID, Name: "receiver", Status: api.ReceiverSyncUnchanged}}, res.Receivers)
+
+		require.True(t, mM2M.AssertExpectations(t))
+		require.True(t, mReceiver.AssertExpectations(t))
+		mReceiver.AssertNotCalled(t, "SetReceiversRecipients", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Email recipient not allowed", func(t *testing.T) {
+		mM2M := &M2MAuthenticatorMock{}
+		mM2M.On("GetUserList", mock.Anything).Return([]user{{FirstName: "alice", LastName: "smith", Email: "alice@example.com"}}, nil).Once()
+
+		server := echo.New()
+		api.RegisterHandlers(server, &ServerInterfaceHandler{
+			m2m: mM2M,
+		})
+
+		result := post(t, server, `{"recipients":["`+alice+`","`+bob+`"]}`)
+		require.Equal(t, http.StatusBadRequest, result.Recorder.Code)
+
+		var httpErr api.HttpError
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &httpErr))
+		require.Equal(t, api.ErrorCodeRecipientNotAllowed, httpErr.ErrorCode)
+		require.Equal(t, &[]api.ErrorDetail{{
+			Field:  "recipients",
+			Reason: "email recipient is not allowed",
+			Value:  &bob,
+		}}, httpErr.Details)
+
+		require.True(t, mM2M.AssertExpectations(t))
+	})
+
+	t.Run("Invalid request body", func(t *testing.T) {
+		server := echo.New()
+		api.RegisterHandlers(server, &ServerInterfaceHandler{})
+
+		for _, body := range []string{
+			`{"recipients":["alice@example.com"]}`,
+			`{"recipients":["` + alice + `"],"mode":"merge"}`,
+			`{"recipients":["` + alice + `"],"receivers":[]}`,
+		} {
+			result := post(t, server, body)
+			require.Equal(t, http.StatusBadRequest, result.Recorder.Code, body)
+
+			var httpErr api.HttpError
+			require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &httpErr))
+			require.Equal(t, api.ErrorCodeInvalidRequestBody, httpErr.ErrorCode, body)
+		}
+	})
+}
+
+func TestSyncRecipients(t *testing.T) {
+	alice := models.EmailAddress{FirstName: "alice", LastName: "smith", Email: "alice@example.com"}
+	bob := models.EmailAddress{FirstName: "bob", LastName: "jones", Email: "bob@example.com"}
+	renamed := models.EmailAddress{FirstName: "alice", LastName: "brown", Email: "alice@example.com"}
+
+	for name, tc := range map[string]struct {
+		current    []models.EmailAddress
+		recipients []models.EmailAddress
+		mode       api.RecipientSyncMode
+		want       []models.EmailAddress
+		changed    bool
+	}{
+		"replace":                   {current: []models.EmailAddress{alice}, recipients: []models.EmailAddress{bob}, mode: api.RecipientSyncModeReplace, want: []models.EmailAddress{bob}, changed: true},
+		"replace with same":         {current: []models.EmailAddress{alice, bob}, recipients: []models.EmailAddress{bob, alice}, mode: api.RecipientSyncModeReplace, want: []models.EmailAddress{bob, alice}},
+		"replace renamed recipient": {current: []models.EmailAddress{alice}, recipients: []models.EmailAddress{renamed}, mode: api.RecipientSyncModeReplace, want: []models.EmailAddress{renamed}, changed: true},
+		"add":                       {current: []models.EmailAddress{alice}, recipients: []models.EmailAddress{bob}, mode: api.RecipientSyncModeAdd, want: []models.EmailAddress{alice, bob}, changed: true},
+		"add existing email":        {current: []models.EmailAddress{alice}, recipients: []models.EmailAddress{renamed}, mode: api.RecipientSyncModeAdd, want: []models.EmailAddress{alice}},
+		"remove":                    {current: []models.EmailAddress{alice, bob}, recipients: []models.EmailAddress{renamed}, mode: api.RecipientSyncModeRemove, want: []models.EmailAddress{bob}, changed: true},
+		"remove missing":            {current: []models.EmailAddress{bob}, recipients: []models.EmailAddress{alice}, mode: api.RecipientSyncModeRemove, want: []models.EmailAddress{bob}},
+	} {
+		current := make([]string, 0, len(tc.current))
+		for _, r := range tc.current {
+			current = append(current, r.String())
+		}
+
+		got, changed, err := syncRecipients(current, tc.recipients, tc.mode)
+		require.NoError(t, err, name)
+		require.Equal(t, tc.want, got, name)
+		require.Equal(t, tc.changed, changed, name)
+	}
+}
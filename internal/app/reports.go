@@ -0,0 +1,165 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/report"
+)
+
+const (
+	errHTTPReportNotFound      = "report not found"
+	errHTTPFailedToGetReport   = "failed to get report"
+	errHTTPFailedToGetReports  = "failed to get reports"
+	errHTTPReportsUnavailable  = "reports are not available"
+	errHTTPInvalidReportFormat = "invalid report format"
+)
+
+// GetReports gets the weekly reports of the alert volume of a tenant, the latest first.
+func (w *ServerInterfaceHandler) GetReports(ctx echo.Context, tenantID api.TenantID) error {
+	if w.reports == nil {
+		logWarn(ctx, "Reports are not available")
+		return ctx.JSON(http.StatusServiceUnavailable, api.HttpError{
+			Code:      http.StatusServiceUnavailable,
+			Message:   errHTTPReportsUnavailable,
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+
+	reports, err := w.reports.GetAlertReports(ctx.Request().Context(), tenantID)
+	if err != nil {
+		logError(ctx, "Failed to get reports", err)
+		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToGetReports,
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+
+	list := api.ReportList{Reports: make([]api.ReportSummary, 0, len(reports))}
+	for _, r := range reports {
+		list.Reports = append(list.Reports, api.ReportSummary{
+			Id:          r.ID,
+			PeriodStart: r.PeriodStart.UTC(),
+			PeriodEnd:   r.PeriodEnd.UTC(),
+			CreatedAt:   r.CreationDate.UTC(),
+		})
+	}
+	return ctx.JSON(http.StatusOK, list)
+}
+
+// GetReport gets a weekly report of the alert volume of a tenant, rendered in the requested format.
+func (w *ServerInterfaceHandler) GetReport(ctx echo.Context, tenantID api.TenantID, id api.ReportId, params api.GetProjectReportParams) error {
+	if w.reports == nil {
+		logWarn(ctx, "Reports are not available")
+		return ctx.JSON(http.StatusServiceUnavailable, api.HttpError{
+			Code:      http.StatusServiceUnavailable,
+			Message:   errHTTPReportsUnavailable,
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+
+	format := api.ReportFormatJSON
+	if params.Format != nil {
+		format = *params.Format
+	}
+	switch format {
+	case api.ReportFormatJSON, api.ReportFormatHTML, api.ReportFormatCSV:
+	default:
+		value := string(format)
+		logWarn(ctx, fmt.Sprintf("Invalid report format: %q", format))
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPInvalidReportFormat,
+			ErrorCode: api.ErrorCodeInvalidParameter,
+			Details: &[]api.ErrorDetail{{
+				Field:  "format",
+				Reason: "must be one of json, html, csv",
+				Value:  &value,
+			}},
+		})
+	}
+
+	stored, err := w.reports.GetAlertReport(ctx.Request().Context(), tenantID, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		logError(ctx, fmt.Sprintf("Report not found: %d", id), err)
+		return ctx.JSON(http.StatusNotFound, api.HttpError{
+			Code:      http.StatusNotFound,
+			Message:   errHTTPReportNotFound,
+			ErrorCode: api.ErrorCodeReportNotFound,
+		})
+	} else if err != nil {
+		logError(ctx, fmt.Sprintf("Failed to get report: %d", id), err)
+		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToGetReport,
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+
+	rpt, err := report.New(*stored)
+	if err != nil {
+		logError(ctx, fmt.Sprintf("Failed to decode report: %d", id), err)
+		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToGetReport,
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+
+	var body []byte
+	switch format {
+	case api.ReportFormatHTML:
+		body, err = rpt.HTML()
+		if err == nil {
+			return ctx.Blob(http.StatusOK, echo.MIMETextHTMLCharsetUTF8, body)
+		}
+	case api.ReportFormatCSV:
+		body, err = rpt.CSV()
+		if err == nil {
+			ctx.Response().Header().Set(echo.HeaderContentDisposition,
+				fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("report-%s.csv", rpt.PeriodStart.Format("2006-01-02"))))
+			return ctx.Blob(http.StatusOK, mimeTextCSVCharsetUTF8, body)
+		}
+	default:
+		return ctx.JSON(http.StatusOK, reportToAPI(rpt))
+	}
+
+	logError(ctx, fmt.Sprintf("Failed to render report: %d", id), err)
+	return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+		Code:      http.StatusInternalServerError,
+		Message:   errHTTPFailedToGetReport,
+		ErrorCode: api.ErrorCodeInternalError,
+	})
+}
+
+// reportToAPI converts a report to the report served by the API. The mean time to acknowledge alerts is left out if no alert
+// was acknowledged over the period of the report.
+func reportToAPI(rpt *report.Report) api.Report {
+	res := api.Report{
+		Id:                  rpt.ID,
+		PeriodStart:         rpt.PeriodStart,
+		PeriodEnd:           rpt.PeriodEnd,
+		CreatedAt:           rpt.CreationDate,
+		SentNotifications:   rpt.SentNotifications,
+		FailedNotifications: rpt.FailedNotifications,
+		TopAlerts:           make([]api.NoisyAlert, 0, len(rpt.TopAlerts)),
+		AcknowledgedAlerts:  rpt.AcknowledgedAlerts,
+	}
+	for _, a := range rpt.TopAlerts {
+		res.TopAlerts = append(res.TopAlerts, api.NoisyAlert{Group: a.Group, Notifications: a.Notifications})
+	}
+	if rpt.AcknowledgedAlerts > 0 {
+		seconds := rpt.MeanTimeToAcknowledge.Seconds()
+		res.MeanTimeToAcknowledge = &seconds
+	}
+	return res
+}
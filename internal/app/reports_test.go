@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/testutil"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+func TestGetReports(t *testing.T) {
+	conn, err := gorm.Open(sqlite.Open(":memory:"))
+	require.NoError(t, err)
+	require.NoError(t, conn.AutoMigrate(&models.AlertReport{}))
+
+	tenantID := "edgenode"
+	start := time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)
+	report := models.AlertReport{
+		TenantID:    tenantID,
+		PeriodStart: start,
+		PeriodEnd:   start.AddDate(0, 0, 7),
+		Content: `{"sentNotifications":5,"failedNotifications":1,"topAlerts":[{"group":"{alertname=\"HighCPU\"}","notifications":3}],` +
+			`"acknowledgedAlerts":2,"meanTimeToAcknowledge":1200000000000}`,
+		CreationDate: start.AddDate(0, 0, 7).Add(time.Hour),
+	}
+	require.NoError(t, conn.Create(&report).Error)
+	empty := models.AlertReport{
+		TenantID:     tenantID,
+		PeriodStart:  start.AddDate(0, 0, -7),
+		PeriodEnd:    start,
+		Content:      `{"sentNotifications":0,"failedNotifications":0,"topAlerts":[],"acknowledgedAlerts":0,"meanTimeToAcknowledge":0}`,
+		CreationDate: start.Add(time.Hour),
+	}
+	require.NoError(t, conn.Create(&empty).Error)
+
+	server := echo.New()
+	api.RegisterHandlers(server, &ServerInterfaceHandler{
+		reports: &database.DBService{DB: conn},
+	})
+
+	get := func(t *testing.T, tenantID string, uri string) *testutil.CompletedRequest {
+		return testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Get(uri).GoWithHTTPHandler(t, server)
+	}
+	uri := "/api/v1/reports/" + strconv.FormatInt(report.ID, 10)
+
+	t.Run("List of reports", func(t *testing.T) {
+		result := get(t, tenantID, "/api/v1/reports")
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+
+		var list api.ReportList
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &list))
+		require.Equal(t, []api.ReportSummary{
+			{Id: report.ID, PeriodStart: report.PeriodStart, PeriodEnd: report.PeriodEnd, CreatedAt: report.CreationDate},
+			{Id: empty.ID, PeriodStart: empty.PeriodStart, PeriodEnd: empty.PeriodEnd, CreatedAt: empty.CreationDate},
+		}, list.Reports)
+
+		result = get(t, "other", "/api/v1/reports")
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+		require.JSONEq(t, `{"reports":[]}`, result.Recorder.Body.String())
+	})
+
+	t.Run("Report as JSON", func(t *testing.T) {
+		result := get(t, tenantID, uri)
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+
+		var res api.Report
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &res))
+		mtta := 1200.0
+		require.Equal(t, api.Report{
+			Id:                    report.ID,
+			PeriodStart:           report.PeriodStart,
+			PeriodEnd:             report.PeriodEnd,
+			CreatedAt:             report.CreationDate,
+			SentNotifications:     5,
+			FailedNotifications:   1,
+			TopAlerts:             []api.NoisyAlert{{Group: `{alertname="HighCPU"}`, Notifications: 3}},
+			AcknowledgedAlerts:    2,
+			MeanTimeToAcknowledge: &mtta,
+		}, res)
+
+		// The mean time to acknowledge is left out if no alert was acknowledged.
+		result = get(t, tenantID, "/api/v1/reports/"+strconv.FormatInt(empty.ID, 10))
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+		require.NotContains(t, result.Recorder.Body.String(), "meanTimeToAcknowledge")
+	})
+
+	t.Run("Report as HTML", func(t *testing.T) {
+		result := get(t, tenantID, uri+"?format=html")
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+		require.Equal(t, echo.MIMETextHTMLCharsetUTF8, result.Recorder.Header().Get(echo.HeaderContentType))
+		require.Contains(t, result.Recorder.Body.String(), "<h2>Alert report of project edgenode</h2>")
+	})
+
+	t.Run("Report as CSV", func(t *testing.T) {
+		result := get(t, tenantID, uri+"?format=csv")
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+		require.Equal(t, "text/csv; charset=UTF-8", result.Recorder.Header().Get(echo.HeaderContentType))
+		require.Equal(t, `attachment; filename="report-2026-10-05.csv"`, result.Recorder.Header().Get(echo.HeaderContentDisposition))
+		require.Contains(t, result.Recorder.Body.String(), "summary,sentNotifications,5\n")
+	})
+
+	t.Run("Invalid format", func(t *testing.T) {
+		result := get(t, tenantID, uri+"?format=pdf")
+		require.Equal(t, http.StatusBadRequest, result.Recorder.Code)
+
+		var httpErr api.HttpError
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &httpErr))
+		require.Equal(t, api.ErrorCodeInvalidParameter, httpErr.ErrorCode)
+	})
+
+	t.Run("Report of another tenant", func(t *testing.T) {
+		result := get(t, "other", uri)
+		require.Equal(t, http.StatusNotFound, result.Recorder.Code)
+
+		var httpErr api.HttpError
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &httpErr))
+		require.Equal(t, api.ErrorCodeReportNotFound, httpErr.ErrorCode)
+	})
+
+	t.Run("Missing project", func(t *testing.T) {
+		result := testutil.NewRequest().Get("/api/v1/reports").GoWithHTTPHandler(t, server)
+		require.Equal(t, http.StatusBadRequest, result.Recorder.Code)
+	})
+}
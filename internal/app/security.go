@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+)
+
+// newCORS returns the middleware answering the cross-origin requests of the given configuration, including preflight requests,
+// which are answered before being authorized as browsers send them without credentials. It returns nil if no origin is allowed.
+func newCORS(conf config.CORSConfig) echo.MiddlewareFunc {
+	if len(conf.AllowedOrigins) == 0 {
+		return nil
+	}
+	return middleware.CORSWithConfig(middleware.CORSConfig{
+		AllowOrigins: conf.AllowedOrigins,
+		AllowMethods: conf.AllowedMethods,
+		AllowHeaders: conf.AllowedHeaders,
+		MaxAge:       int(conf.MaxAge.Seconds()),
+		// The version and deprecation headers are exposed to browsers, so that web consoles can tell deprecated versions.
+		ExposeHeaders: []string{apiVersionHeader, deprecationHeader, sunsetHeader, linkHeader},
+	})
+}
+
+// newSecurityHeaders returns the middleware setting the standard security headers of the given configuration on responses. It
+// returns nil if security headers are disabled.
+func newSecurityHeaders(conf config.SecurityHeadersConfig) echo.MiddlewareFunc {
+	if !conf.Enabled {
+		return nil
+	}
+	return middleware.SecureWithConfig(middleware.SecureConfig{
+		ContentTypeNosniff:    "nosniff",
+		XFrameOptions:         "DENY",
+		HSTSMaxAge:            int(conf.HSTSMaxAge.Seconds()),
+		ContentSecurityPolicy: conf.ContentSecurityPolicy,
+		ReferrerPolicy:        "no-referrer",
+	})
+}
@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+)
+
+func TestSecurityMiddlewares(t *testing.T) {
+	serve := func(req *http.Request, middlewares ...echo.MiddlewareFunc) *httptest.ResponseRecorder {
+		e := echo.New()
+		for _, m := range middlewares {
+			if m != nil {
+				e.Use(m)
+			}
+		}
+		e.GET("/api/v1/alerts", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("CORS disabled without allowed origins", func(t *testing.T) {
+		require.Nil(t, newCORS(config.CORSConfig{AllowedMethods: []string{http.MethodGet}}))
+	})
+
+	t.Run("Preflight request of allowed origin", func(t *testing.T) {
+		cors := newCORS(config.CORSConfig{
+			AllowedOrigins: []string{"https://console.example.com"},
+			AllowedMethods: []string{http.MethodGet, http.MethodPost},
+			MaxAge:         10 * time.Minute,
+		})
+
+		req := httptest.NewRequest(http.MethodOptions, "/api/v1/alerts", nil)
+		req.Header.Set(echo.HeaderOrigin, "https://console.example.com")
+		req.Header.Set(echo.HeaderAccessControlRequestMethod, http.MethodGet)
+		rec := serve(req, cors)
+
+		require.Equal(t, http.StatusNoContent, rec.Code)
+		require.Equal(t, "https://console.example.com", rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+		require.Equal(t, "GET,POST", rec.Header().Get(echo.HeaderAccessControlAllowMethods))
+		require.Equal(t, "600", rec.Header().Get(echo.HeaderAccessControlMaxAge))
+	})
+
+	t.Run("Request of other origin", func(t *testing.T) {
+		cors := newCORS(config.CORSConfig{AllowedOrigins: []string{"https://console.example.com"}})
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/alerts", nil)
+		req.Header.Set(echo.HeaderOrigin, "https://other.example.com")
+		rec := serve(req, cors)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Empty(t, rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+	})
+
+	t.Run("Security headers", func(t *testing.T) {
+		secure := newSecurityHeaders(config.SecurityHeadersConfig{
+			Enabled:               true,
+			HSTSMaxAge:            time.Hour,
+			ContentSecurityPolicy: "default-src 'none'",
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/alerts", nil)
+		req.Header.Set(echo.HeaderXForwardedProto, "https")
+		rec := serve(req, secure)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, "nosniff", rec.Header().Get(echo.HeaderXContentTypeOptions))
+		require.Equal(t, "DENY", rec.Header().Get(echo.HeaderXFrameOptions))
+		require.Equal(t, "no-referrer", rec.Header().Get(echo.HeaderReferrerPolicy))
+		require.Equal(t, "max-age=3600; includeSubdomains", rec.Header().Get(echo.HeaderStrictTransportSecurity))
+		require.Equal(t, "default-src 'none'", rec.Header().Get(echo.HeaderContentSecurityPolicy))
+	})
+
+	t.Run("Security headers disabled", func(t *testing.T) {
+		require.Nil(t, newSecurityHeaders(config.SecurityHeadersConfig{}))
+	})
+}
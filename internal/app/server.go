@@ -17,15 +17,22 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/labstack/gommon/log"
+	"github.com/prometheus/client_golang/prometheus"
 	"gorm.io/gorm"
 
 	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
 	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/correlation"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/notify/email"
 )
 
 var logger *slog.Logger
 
-func StartServer(port int, conf config.Config, logLvl string, db *gorm.DB) {
+func StartServer(
+	port int, conf config.Config, logLvl string, db *gorm.DB, receiversCfg ReceiverConfigValidator, artifactKeys *database.ArtifactKeyRing,
+) {
 	// Creating new Echo server
 	e := echo.New()
 
@@ -53,7 +60,9 @@ func StartServer(port int, conf config.Config, logLvl string, db *gorm.DB) {
 		e.Logger.Panic(err)
 	}
 
-	serverInterface := NewServerInterfaceHandler(conf, db, m2m)
+	serverInterface := NewServerInterfaceHandler(conf, db, newCachedUserList(m2m, conf.AllowedRecipients.CacheTTL), receiversCfg)
+	// Tenants registered by any of the database services are assigned one of the alertmanager shards of the configuration.
+	shards := conf.AlertManager.ShardCount()
 
 	sqlDB, err := db.DB()
 	if err != nil {
@@ -63,11 +72,132 @@ func StartServer(port int, conf config.Config, logLvl string, db *gorm.DB) {
 
 	// Registering API call handlers
 	api.RegisterHandlers(e, serverInterface)
+	prometheus.MustRegister(newConfigStateCollector(&database.DBService{DB: db, AlertmanagerShards: shards}))
+	if conf.Profiling.Enabled {
+		registerProfiling(e, sqlDB)
+	}
+	if conf.APIDocs.Enabled {
+		if err := registerAPIDocs(e, conf.APIDocs); err != nil {
+			e.Logger.Panic(err)
+		}
+	}
+	newArtifactViewer(&database.DBService{DB: db, AlertmanagerShards: shards, ArtifactKeys: artifactKeys}).register(e)
+	newHistoryViewer(&database.DBService{DB: db, AlertmanagerShards: shards}).register(e)
+	newExecutorViewer(&database.DBService{DB: db, AlertmanagerShards: shards}, conf.TaskExecutor.HeartbeatTimeout).register(e)
+	newRecipientOffboarder(&database.DBService{DB: db, AlertmanagerShards: shards}).register(e)
+	newAdminStatusViewer(conf.TaskExecutor).register(e)
+	serverInterface.tiers.register(e)
+	linkage := newAlertLinkageChecker(serverInterface, &database.DBService{DB: db, AlertmanagerShards: shards})
+	linkage.register(e)
+	if conf.AlertLinkage.CheckInterval > 0 {
+		go linkage.run(ctx, conf.AlertLinkage.CheckInterval)
+	}
+	if conf.Mimir.QueryURL != "" {
+		noise := newNoiseScorer(serverInterface, &database.DBService{DB: db, AlertmanagerShards: shards}, conf.NoiseScoring)
+		serverInterface.noise = noise
+		if conf.NoiseScoring.Interval > 0 {
+			go noise.run(ctx, conf.NoiseScoring.Interval)
+		}
+	}
+	registerTimeTravel(e, conf.TimeTravel)
+	if conf.ClockSkew.CheckInterval > 0 {
+		go newSkewDetector(conf, &database.DBService{DB: db, AlertmanagerShards: shards}).Run(ctx, conf.ClockSkew.CheckInterval)
+	}
+	if conf.CertExpiry.CheckInterval > 0 {
+		certExpiry := newCertExpiryChecker(serverInterface, conf.CertExpiry)
+		certExpiry.register(e)
+		serverInterface.certExpiry = certExpiry
+		go certExpiry.run(ctx, conf.CertExpiry.CheckInterval)
+	}
+	newAlertmanagerCompat(serverInterface).register(e)
+	// The notifications of the relays are only queued for retry if enabled, each relay delivering its queued notifications.
+	var queued database.NotificationEnqueuer
+	var notifications *notificationQueue
+	if conf.NotificationQueue.Enabled {
+		queued = &database.DBService{DB: db, AlertmanagerShards: shards}
+		notifications = newNotificationQueue(conf.NotificationQueue, conf.TaskExecutor.KindPaused(string(models.TypeNotification)),
+			&database.DBService{DB: db, AlertmanagerShards: shards})
+		notifications.register(e)
+	}
+	if conf.OnCall.URL != "" {
+		auth, err := newWebhookAuthenticator(conf.WebhookAuth, "ONCALL_RELAY_TOKEN")
+		if err != nil {
+			e.Logger.Panic(err)
+		}
+		relay := newOnCallRelay(conf.OnCall, &database.DBService{DB: db, AlertmanagerShards: shards})
+		relay.queue = queued
+		if notifications != nil {
+			notifications.deliverers[models.NotificationOnCall] = relay.redeliver
+		}
+		e.POST(onCallRelayEndpoint+"/:tenantID/:receiverID", relay.relay, auth.authenticate)
+	}
+	if conf.EmailRelay.Enabled {
+		// The email templates are only mounted along with the email relay, so emails can only be previewed then.
+		if serverInterface.emailTemplate, err = email.NewTemplate(conf.EmailRelay.TemplateFiles); err != nil {
+			e.Logger.Panic(err)
+		}
+		sender, err := email.NewSender(conf, &database.DBService{DB: db, AlertmanagerShards: shards}, queued, logger)
+		if err != nil {
+			e.Logger.Panic(err)
+		}
+		if notifications != nil {
+			notifications.deliverers[models.NotificationEmail] = sender.Redeliver
+		}
+		auth, err := newWebhookAuthenticator(conf.WebhookAuth, "EMAIL_RELAY_TOKEN")
+		if err != nil {
+			e.Logger.Panic(err)
+		}
+		relay := newEmailRelay(&database.DBService{DB: db, AlertmanagerShards: shards}, sender, serverInterface.tenantMetadata)
+		e.POST(emailRelayEndpoint+"/:tenantID/:receiverID", relay.relay, auth.authenticate)
+	}
+	// The events of the hooks are delivered by the notification queue, so hooks cannot be enabled without it.
+	if conf.EventHooks.Enabled {
+		if notifications == nil {
+			e.Logger.Panic("event hooks require the notification queue to be enabled")
+		}
+		if serverInterface.hooks, err = newEventHooks(conf.EventHooks, &database.DBService{DB: db, AlertmanagerShards: shards}); err != nil {
+			e.Logger.Panic(err)
+		}
+		notifications.deliverers[models.NotificationHook] = serverInterface.hooks.deliver
+	}
+	if notifications != nil {
+		go notifications.run(ctx)
+	}
+	if conf.EmailVerification.Enabled {
+		sender, err := email.NewVerifier(conf)
+		if err != nil {
+			e.Logger.Panic(err)
+		}
+		verifications := &database.DBService{DB: db, AlertmanagerShards: shards}
+		if serverInterface.verifier, err = newEmailVerifier(conf.EmailVerification, sender, verifications); err != nil {
+			e.Logger.Panic(err)
+		}
+	}
 	authenticationHandler := NewAuthenticationHandler(conf.Authentication.OidcServer, conf.Authentication.OidcServerRealm)
+	tenants, err := newTenantResolver(conf.Tenancy)
+	if err != nil {
+		e.Logger.Panic(err)
+	}
 
+	// The version of the API is negotiated before routing, as it selects the handlers serving the request.
+	e.Pre(newAPIVersioning(conf.APIVersioning).negotiate)
 	// Midd
+	e.Use(middleware.RequestIDWithConfig(middleware.RequestIDConfig{
+		TargetHeader:     correlation.Header,
+		RequestIDHandler: setCorrelationID,
+	}))
+	// Security headers are set on every response, and cross-origin preflight requests are answered before being authorized.
+	if secure := newSecurityHeaders(conf.SecurityHeaders); secure != nil {
+		e.Use(secure)
+	}
+	if cors := newCORS(conf.CORS); cors != nil {
+		e.Use(cors)
+	}
+	e.Use(tenants.resolve)
 	e.Use(authorize)
 	e.Use(authenticationHandler.authenticate)
+	e.Use(newActivityRecorder(&database.DBService{DB: db, AlertmanagerShards: shards}).record)
+	e.Use(serverInterface.tiers.limit)
 	e.Use(middleware.Recover())
 	e.Use(middleware.RequestLoggerWithConfig(
 		middleware.RequestLoggerConfig{
@@ -79,6 +209,7 @@ func StartServer(port int, conf config.Config, logLvl string, db *gorm.DB) {
 			LogError:     true,
 			LogUserAgent: true,
 			LogMethod:    true,
+			LogRequestID: true,
 			LogValuesFunc: func(_ echo.Context, v middleware.RequestLoggerValues) error {
 				if v.Error != nil {
 					logger.LogAttrs(context.Background(), slog.LevelError, "REQUEST_ERROR",
@@ -86,6 +217,7 @@ func StartServer(port int, conf config.Config, logLvl string, db *gorm.DB) {
 						slog.Int("status", v.Status),
 						slog.String("user-agent", v.UserAgent),
 						slog.String("method", v.Method),
+						slog.String("request_id", v.RequestID),
 						slog.String("error", v.Error.Error()),
 					)
 				} else {
@@ -94,6 +226,7 @@ func StartServer(port int, conf config.Config, logLvl string, db *gorm.DB) {
 						slog.Int("status", v.Status),
 						slog.String("user-agent", v.UserAgent),
 						slog.String("method", v.Method),
+						slog.String("request_id", v.RequestID),
 					)
 				}
 				return nil
@@ -110,6 +243,16 @@ func StartServer(port int, conf config.Config, logLvl string, db *gorm.DB) {
 			e.Logger.Panic("Server shutdown")
 		}
 	}()
+	// Metrics are served on their own listener, so that they are not exposed along with the API.
+	var metrics *http.Server
+	if conf.Metrics.ListenAddress != "" {
+		metrics = newMetricsServer(conf.Metrics.ListenAddress)
+		go func() {
+			if err := metrics.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				e.Logger.Panic("Metrics server shutdown")
+			}
+		}()
+	}
 
 	// Graceful shutdown in 5 seconds after interrupt
 	quit := make(chan os.Signal, 1)
@@ -117,6 +260,11 @@ func StartServer(port int, conf config.Config, logLvl string, db *gorm.DB) {
 	<-quit
 	ctxTimeout, cancelTimeout := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancelTimeout()
+	if metrics != nil {
+		if err := metrics.Shutdown(ctxTimeout); err != nil {
+			e.Logger.Panic(err)
+		}
+	}
 	if err := e.Shutdown(ctxTimeout); err != nil {
 		e.Logger.Panic(err)
 	}
@@ -0,0 +1,217 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v2"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/rules"
+)
+
+const (
+	errHTTPFailedToSimulateAlertDefinition = "failed to simulate alert definition"
+
+	// simulatedAlertLabel labels the alerts simulated for alert definitions, so that they are told apart from, and do not
+	// replace, the alerts raised by the alert definitions.
+	simulatedAlertLabel = "simulated"
+
+	// defaultSimulationDuration is how long a simulated alert fires if not configured.
+	defaultSimulationDuration = 5 * time.Minute
+)
+
+// reservedSimulationLabels are the labels set by the service on simulated alerts, along with the tenant label.
+var reservedSimulationLabels = []string{"alertname", "threshold", "duration", simulatedAlertLabel}
+
+// SimulateAlertDefinition pushes a synthetic alert of an alert definition to alertmanager, which fires for the configured
+// duration, so that users can verify the routing and notifications of the alerts of the alert definition end to end.
+func (w *ServerInterfaceHandler) SimulateAlertDefinition(ctx echo.Context, tenantID api.TenantID, id api.AlertDefinitionId) error {
+	var reqBody api.PostProjectAlertDefinitionSimulateJSONRequestBody
+
+	// The body is optional, the simulated alert then fires for a series without labels.
+	dec := json.NewDecoder(ctx.Request().Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&reqBody); err != nil && !errors.Is(err, io.EOF) {
+		logError(ctx, "Failed to parse body of alert definition simulation", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPBadRequest,
+			ErrorCode: api.ErrorCodeInvalidRequestBody,
+		})
+	}
+
+	var labels map[string]string
+	if reqBody.Labels != nil {
+		labels = *reqBody.Labels
+	}
+
+	lang := responseLanguage(ctx)
+	if details := validateSimulationLabels(lang, labels, w.tenantLabel()); len(details) > 0 {
+		logWarn(ctx, fmt.Sprintf("Invalid labels of simulated alert of tenant %q: %s", tenantID, details[0].Field))
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   localize(lang, msgInvalidSimulatedAlertLabels),
+			ErrorCode: api.ErrorCodeInvalidRequestBody,
+			Details:   &details,
+		})
+	}
+
+	ad, err := w.definitions.GetLatestAlertDefinition(ctx.Request().Context(), tenantID, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		logError(ctx, fmt.Sprintf("Alert definition not found: %q", id), err)
+		return ctx.JSON(http.StatusNotFound, api.HttpError{
+			Code:      http.StatusNotFound,
+			Message:   errHTTPAlertDefinitionNotFound,
+			ErrorCode: api.ErrorCodeDefinitionNotFound,
+		})
+	} else if err != nil {
+		logError(ctx, fmt.Sprintf("Failed to retrieve alert definition: %q", id), err)
+		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToSimulateAlertDefinition,
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+
+	duration := w.configuration.AlertSimulation.Duration
+	if duration <= 0 {
+		duration = defaultSimulationDuration
+	}
+	startsAt := clock.TimeNowFn().UTC()
+	endsAt := startsAt.Add(duration)
+
+	alert, err := simulatedAlert(*ad, labels, w.tenantLabel(), tenantID)
+	if err != nil {
+		logError(ctx, fmt.Sprintf("Failed to simulate alert of alert definition: %q", id), err)
+		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToSimulateAlertDefinition,
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+	alert.StartsAt = &startsAt
+	alert.EndsAt = &endsAt
+
+	if err := w.pushAlerts(ctx.Request().Context(), tenantID, []postableAlert{alert}); err != nil {
+		logError(ctx, fmt.Sprintf("Failed to push simulated alert of alert definition: %q", id), err)
+		errorCode := api.ErrorCodeInternalError
+		if errors.Is(err, errAlertmanagerUnavailable) {
+			errorCode = api.ErrorCodeAlertmanagerUnavailable
+		}
+		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToSimulateAlertDefinition,
+			ErrorCode: errorCode,
+		})
+	}
+
+	return ctx.JSON(http.StatusAccepted, api.SimulatedAlert{
+		Labels:      alert.Labels,
+		Annotations: alert.Annotations,
+		StartsAt:    startsAt,
+		EndsAt:      endsAt,
+	})
+}
+
+// validateSimulationLabels validates the labels of the series a simulated alert fires for, returning the details of every
+// invalid label with reasons in the given language. The labels set by the service and the given tenant label are reserved.
+func validateSimulationLabels(lang language.Tag, labels map[string]string, tenantLabel string) []api.ErrorDetail {
+	var details []api.ErrorDetail
+	for _, name := range slices.Sorted(maps.Keys(labels)) {
+		if !externalLabelNameRegex.MatchString(name) || strings.HasPrefix(name, "__") || slices.Contains(reservedSimulationLabels, name) ||
+			name == tenantLabel {
+			value := name
+			details = append(details, api.ErrorDetail{
+				Field:  "labels." + name,
+				Reason: localize(lang, msgReservedExternalAlertLabel),
+				Value:  &value,
+			})
+		}
+	}
+	return details
+}
+
+// simulatedAlert returns the alert the given alert definition raises for a series with the given labels of the given tenant,
+// labeled as simulated. Its labels and annotations are expanded as Prometheus expands the ones of alerting rules, the
+// threshold of the alert definition standing for the value of the series.
+func simulatedAlert(ad models.DBAlertDefinition, labels map[string]string, tenantLabel string, tenantID api.TenantID) (postableAlert, error) {
+	if ad.Values.Threshold == nil || ad.Values.Duration == nil {
+		return postableAlert{}, fmt.Errorf("threshold or duration are nil: %v", ad.Values)
+	}
+
+	var rule rules.Rule
+	if err := yaml.Unmarshal([]byte(ad.Template), &rule); err != nil {
+		return postableAlert{}, fmt.Errorf("failed to unmarshal template: %w", err)
+	}
+
+	series := make(map[string]string, len(labels)+1)
+	maps.Copy(series, labels)
+	series[tenantLabel] = tenantID
+
+	threshold := strconv.FormatInt(*ad.Values.Threshold, 10)
+	alertLabels := maps.Clone(series)
+	for name, value := range rule.Labels {
+		// Labels expanded to an empty value are dropped, as by Prometheus.
+		if value = expandAlertTemplate(value, series, threshold); value != "" {
+			alertLabels[name] = value
+		} else {
+			delete(alertLabels, name)
+		}
+	}
+	// The values of the alert definition are set as Mimir rules are rendered with them.
+	alertLabels["threshold"] = threshold
+	alertLabels["duration"] = time.Duration(*ad.Values.Duration * int64(time.Second)).String()
+	alertLabels["alertname"] = rule.Alert
+	alertLabels[tenantLabel] = tenantID
+	alertLabels[simulatedAlertLabel] = "true"
+
+	annotations := make(map[string]string, len(rule.Annotations))
+	for name, value := range rule.Annotations {
+		annotations[name] = expandAlertTemplate(value, series, threshold)
+	}
+
+	return postableAlert{Labels: alertLabels, Annotations: annotations}, nil
+}
+
+// expandAlertTemplate expands the template of a label or annotation of an alerting rule with the $labels and $value variables
+// of Prometheus. Templates using the functions of Prometheus, which are not available, are returned unexpanded.
+func expandAlertTemplate(text string, labels map[string]string, value string) string {
+	if !strings.Contains(text, "{{") {
+		return text
+	}
+
+	defs := "{{$labels := .Labels}}{{$externalLabels := .ExternalLabels}}{{$value := .Value}}"
+	tmpl, err := template.New("alert").Option("missingkey=zero").Parse(defs + text)
+	if err != nil {
+		return text
+	}
+
+	var b strings.Builder
+	data := struct {
+		Labels         map[string]string
+		ExternalLabels map[string]string
+		Value          string
+	}{Labels: labels, ExternalLabels: map[string]string{}, Value: value}
+	if err := tmpl.Execute(&b, data); err != nil {
+		return text
+	}
+	return b.String()
+}
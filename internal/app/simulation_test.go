@@ -0,0 +1,197 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/testutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+const simulationTemplate = `alert: HostStatusError
+expr: sum by (host_uuid, projectId) (edge_host_status{status="Error"} == [[ .Threshold ]])
+for: 1m
+labels:
+  threshold: "1"
+  duration: 1m
+  alert_category: health
+  host_uuid: "{{$labels.host_uuid}}"
+annotations:
+  summary: Detected error on host {{$labels.host_uuid}} above {{ $value }}.
+  description: Error on host {{ $labels.host_uuid | humanize }}.
+  am_uuid: 2102456a-8cf3-40a1-b9e0-5f8c9f970fe4
+`
+
+func TestSimulateAlertDefinition(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	clock.SetFakeClock()
+	defer clock.UnsetFakeClock()
+	clock.FakeClock.Set(now)
+
+	var pushed []postableAlert
+	alertManagerStatus := http.StatusOK
+	alertManager := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v2/alerts", r.URL.Path)
+		pushed = nil
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&pushed))
+		w.WriteHeader(alertManagerStatus)
+	}))
+	defer alertManager.Close()
+
+	configfile := conf
+	configfile.AlertManager.URL = alertManager.URL
+	configfile.AlertSimulation.Duration = 10 * time.Minute
+
+	threshold := int64(3)
+	duration := int64(120)
+	definition := &models.DBAlertDefinition{
+		Template: simulationTemplate,
+		Values:   models.DBAlertDefinitionValues{Threshold: &threshold, Duration: &duration},
+	}
+
+	post := func(mDefinition *DefinitionMock, id uuid.UUID, body string) *httptest.ResponseRecorder {
+		t.Helper()
+
+		handler := NewServerInterfaceHandler(configfile, &gorm.DB{}, nil, nil)
+		handler.definitions = mDefinition
+
+		e := echo.New()
+		api.RegisterHandlers(e, handler)
+
+		req := testutil.NewRequest().WithHeader("ActiveProjectID", "edgenode").Post(fmt.Sprintf("/api/v1/alerts/definitions/%v:simulate", id))
+		if body != "" {
+			req = req.WithBody([]byte(body))
+		}
+		return req.GoWithHTTPHandler(t, e).Recorder
+	}
+
+	t.Run("Simulated alert is pushed - code should be 202", func(t *testing.T) {
+		id := uuid.New()
+		mDefinition := &DefinitionMock{}
+		mDefinition.On("GetLatestAlertDefinition", mock.Anything, "edgenode", id).Return(definition, nil).Once()
+
+		rec := post(mDefinition, id, `{"labels":{"host_uuid":"93bf6804"}}`)
+		require.Equal(t, http.StatusAccepted, rec.Code)
+
+		startsAt, endsAt := now, now.Add(10*time.Minute)
+		expected := postableAlert{
+			Labels: map[string]string{
+				"alertname":      "HostStatusError",
+				"threshold":      "3",
+				"duration":       "2m0s",
+				"alert_category": "health",
+				"host_uuid":      "93bf6804",
+				"projectId":      "edgenode",
+				"simulated":      "true",
+			},
+			Annotations: map[string]string{
+				"summary": "Detected error on host 93bf6804 above 3.",
+				// Templates using the functions of Prometheus are not expanded.
+				"description": "Error on host {{ $labels.host_uuid | humanize }}.",
+				"am_uuid":     "2102456a-8cf3-40a1-b9e0-5f8c9f970fe4",
+			},
+			StartsAt: &startsAt,
+			EndsAt:   &endsAt,
+		}
+		require.Len(t, pushed, 1)
+		require.Equal(t, expected.Labels, pushed[0].Labels)
+		require.Equal(t, expected.Annotations, pushed[0].Annotations)
+		require.True(t, endsAt.Equal(*pushed[0].EndsAt))
+
+		var simulated api.SimulatedAlert
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &simulated))
+		require.Equal(t, expected.Labels, simulated.Labels)
+		require.Equal(t, endsAt, simulated.EndsAt.UTC())
+		require.True(t, mDefinition.AssertExpectations(t))
+	})
+
+	t.Run("Simulated alert is pushed without body - code should be 202", func(t *testing.T) {
+		id := uuid.New()
+		mDefinition := &DefinitionMock{}
+		mDefinition.On("GetLatestAlertDefinition", mock.Anything, "edgenode", id).Return(definition, nil).Once()
+
+		rec := post(mDefinition, id, "")
+		require.Equal(t, http.StatusAccepted, rec.Code)
+		require.Len(t, pushed, 1)
+		require.NotContains(t, pushed[0].Labels, "host_uuid")
+		require.True(t, mDefinition.AssertExpectations(t))
+	})
+
+	t.Run("Reserved labels are rejected - code should be 400", func(t *testing.T) {
+		mDefinition := &DefinitionMock{}
+
+		rec := post(mDefinition, uuid.New(), `{"labels":{"projectId":"other","simulated":"false","host_uuid":"93bf6804"}}`)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+
+		httpErr := &api.HttpError{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), httpErr))
+		require.Equal(t, api.ErrorCodeInvalidRequestBody, httpErr.ErrorCode)
+		require.NotNil(t, httpErr.Details)
+		require.Len(t, *httpErr.Details, 2)
+		require.Equal(t, "labels.projectId", (*httpErr.Details)[0].Field)
+		require.Equal(t, "labels.simulated", (*httpErr.Details)[1].Field)
+		mDefinition.AssertNotCalled(t, "GetLatestAlertDefinition", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Alert definition not found - code should be 404", func(t *testing.T) {
+		id := uuid.New()
+		mDefinition := &DefinitionMock{}
+		mDefinition.On("GetLatestAlertDefinition", mock.Anything, "edgenode", id).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		rec := post(mDefinition, id, "")
+		require.Equal(t, http.StatusNotFound, rec.Code)
+		require.True(t, mDefinition.AssertExpectations(t))
+	})
+
+	t.Run("Failed to get alert definition - code should be 500", func(t *testing.T) {
+		id := uuid.New()
+		mDefinition := &DefinitionMock{}
+		mDefinition.On("GetLatestAlertDefinition", mock.Anything, "edgenode", id).Return(nil, errors.New("mock error")).Once()
+
+		rec := post(mDefinition, id, "")
+		require.Equal(t, http.StatusInternalServerError, rec.Code)
+		require.True(t, mDefinition.AssertExpectations(t))
+	})
+
+	t.Run("Alertmanager fails to accept the simulated alert - code should be 500", func(t *testing.T) {
+		alertManagerStatus = http.StatusBadRequest
+		defer func() { alertManagerStatus = http.StatusOK }()
+
+		id := uuid.New()
+		mDefinition := &DefinitionMock{}
+		mDefinition.On("GetLatestAlertDefinition", mock.Anything, "edgenode", id).Return(definition, nil).Once()
+
+		rec := post(mDefinition, id, "")
+		require.Equal(t, http.StatusInternalServerError, rec.Code)
+
+		httpErr := &api.HttpError{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), httpErr))
+		require.Equal(t, api.ErrorCodeAlertmanagerUnavailable, httpErr.ErrorCode)
+		require.True(t, mDefinition.AssertExpectations(t))
+	})
+}
+
+func TestExpandAlertTemplate(t *testing.T) {
+	labels := map[string]string{"host_uuid": "93bf6804"}
+
+	require.Equal(t, "plain text", expandAlertTemplate("plain text", labels, "1"))
+	require.Equal(t, "Host 93bf6804 at 1", expandAlertTemplate("Host {{ $labels.host_uuid }} at {{ $value }}", labels, "1"))
+	require.Equal(t, "Cluster ", expandAlertTemplate("Cluster {{ $labels.cluster }}", labels, "1"))
+	require.Equal(t, "{{ $value | humanize }}", expandAlertTemplate("{{ $value | humanize }}", labels, "1"))
+}
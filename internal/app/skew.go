@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/correlation"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+)
+
+// newSkewDetector returns the detector of the skew of the local clock against the clocks of the database and of alertmanager.
+func newSkewDetector(conf config.Config, db *database.DBService) *clock.SkewDetector {
+	return clock.NewSkewDetector(conf.ClockSkew.Threshold,
+		clock.SkewSource{Name: "database", Now: db.Now},
+		clock.SkewSource{Name: "alertmanager", Now: func(ctx context.Context) (time.Time, error) {
+			return alertManagerTime(ctx, http.DefaultClient, conf.AlertManager.URL)
+		}},
+	)
+}
+
+// alertManagerTime returns the current time of the alertmanager at the given URL, as told by the Date header of its health
+// endpoint. The header has a resolution of a second.
+func alertManagerTime(ctx context.Context, client *http.Client, amURL string) (time.Time, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, amURL+"/-/healthy", nil)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to create request: %w", err)
+	}
+	correlation.SetHeader(req)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return time.Time{}, fmt.Errorf("alertmanager returned HTTP status code: %v", resp.StatusCode)
+	}
+	date, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse alertmanager time: %w", err)
+	}
+	return date, nil
+}
@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertManagerTime(t *testing.T) {
+	date := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	alertManager := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/-/healthy" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Date", date.Format(http.TimeFormat))
+	}))
+	defer alertManager.Close()
+
+	t.Run("Time of alertmanager", func(t *testing.T) {
+		now, err := alertManagerTime(context.Background(), http.DefaultClient, alertManager.URL)
+		require.NoError(t, err)
+		require.True(t, date.Equal(now))
+	})
+
+	t.Run("Alertmanager unavailable - error is returned", func(t *testing.T) {
+		_, err := alertManagerTime(context.Background(), http.DefaultClient, alertManager.URL+"/unknown")
+		require.ErrorContains(t, err, "alertmanager returned HTTP status code: 404")
+	})
+}
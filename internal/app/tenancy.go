@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+)
+
+const (
+	// activeProjectIDHeader is the header the tenant of API requests is read from by the handlers and middlewares.
+	activeProjectIDHeader = "ActiveProjectID"
+
+	tenantSourceHeader = "header"
+	tenantSourceClaim  = "claim"
+)
+
+// tenantSource is a source the tenant of API requests is resolved from, either a header or a claim of the access token.
+type tenantSource struct {
+	kind string
+	name string
+}
+
+// tenantResolver resolves the tenant of API requests from the configured chain of sources, and sets it as the ActiveProjectID
+// header, so that authorization, handlers and middlewares all see the same tenant whichever source it came from.
+type tenantResolver struct {
+	sources []tenantSource
+}
+
+// newTenantResolver returns a tenant resolver of the sources of the given tenancy configuration, which are only the
+// ActiveProjectID header if none is configured.
+func newTenantResolver(conf config.TenancyConfig) (*tenantResolver, error) {
+	sources := conf.Sources
+	if len(sources) == 0 {
+		sources = []string{tenantSourceHeader + ":" + activeProjectIDHeader}
+	}
+
+	resolver := &tenantResolver{}
+	for _, s := range sources {
+		kind, name, ok := strings.Cut(s, ":")
+		if !ok || name == "" || (kind != tenantSourceHeader && kind != tenantSourceClaim) {
+			return nil, fmt.Errorf("invalid tenant source %q, expected header:<name> or claim:<name>", s)
+		}
+		resolver.sources = append(resolver.sources, tenantSource{kind: kind, name: name})
+	}
+	return resolver, nil
+}
+
+// resolve sets the ActiveProjectID header of requests to the tenant of the first source with a value. Requests without any
+// are left as they are, and are rejected by the handlers requiring a tenant.
+func (r *tenantResolver) resolve(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if tenantID, ok := r.tenantOf(c.Request()); ok {
+			c.Request().Header.Set(activeProjectIDHeader, tenantID)
+		}
+		return next(c)
+	}
+}
+
+// tenantOf returns the tenant of the given request from the first source with a value, and whether any has one.
+func (r *tenantResolver) tenantOf(req *http.Request) (api.TenantID, bool) {
+	var claims map[string]any
+	for _, source := range r.sources {
+		var value string
+		switch source.kind {
+		case tenantSourceHeader:
+			value = req.Header.Get(source.name)
+		case tenantSourceClaim:
+			if claims == nil {
+				claims = tokenClaims(req)
+			}
+			value = claimValue(claims, source.name)
+		}
+		if value = strings.TrimSpace(value); value != "" {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// tokenClaims returns the claims of the access token of the given request. It is empty if the request has no valid token.
+func tokenClaims(req *http.Request) map[string]any {
+	claims := make(map[string]any)
+	token, err := getB64JWT(req.Header.Get("Authorization"))
+	if err != nil {
+		return claims
+	}
+	payload, err := decodeJWTPayload(token)
+	if err != nil {
+		return claims
+	}
+	_ = json.Unmarshal(payload, &claims)
+	return claims
+}
+
+// claimValue returns the string value of the given claim, whose path may be dotted to read a claim nested in objects. It is
+// empty if the claim is not set or is not a string.
+func claimValue(claims map[string]any, path string) string {
+	var value any = claims
+	for _, key := range strings.Split(path, ".") {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return ""
+		}
+		value = object[key]
+	}
+	s, _ := value.(string)
+	return s
+}
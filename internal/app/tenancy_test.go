@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+)
+
+func TestTenantResolver(t *testing.T) {
+	token := "header." + base64.RawStdEncoding.EncodeToString([]byte(`{"project_id":"claim-tenant","tenant":{"id":"nested-tenant"}}`)) +
+		".signature"
+
+	resolver, err := newTenantResolver(config.TenancyConfig{
+		Sources: []string{"header:ActiveProjectID", "header:X-Scope-OrgID", "claim:project_id"},
+	})
+	require.NoError(t, err)
+
+	resolve := func(t *testing.T, resolver *tenantResolver, headers map[string]string) string {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/alerts", nil)
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+
+		var tenantID string
+		handler := resolver.resolve(func(c echo.Context) error {
+			tenantID, _ = extractProjectID(c)
+			return nil
+		})
+		require.NoError(t, handler(e.NewContext(req, httptest.NewRecorder())))
+		return tenantID
+	}
+
+	for name, test := range map[string]struct {
+		headers  map[string]string
+		expected string
+	}{
+		"ActiveProjectID header takes precedence": {
+			headers: map[string]string{
+				"ActiveProjectID": "header-tenant", "X-Scope-OrgID": "org-tenant", "Authorization": "Bearer " + token,
+			},
+			expected: "header-tenant",
+		},
+		"X-Scope-OrgID header is used without ActiveProjectID": {
+			headers:  map[string]string{"ActiveProjectID": " ", "X-Scope-OrgID": "org-tenant", "Authorization": "Bearer " + token},
+			expected: "org-tenant",
+		},
+		"Token claim is used without headers": {
+			headers:  map[string]string{"Authorization": "Bearer " + token},
+			expected: "claim-tenant",
+		},
+		"No source has a value": {
+			headers:  map[string]string{"Authorization": "Bearer invalid"},
+			expected: "",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, test.expected, resolve(t, resolver, test.headers))
+		})
+	}
+
+	t.Run("Nested token claim", func(t *testing.T) {
+		resolver, err := newTenantResolver(config.TenancyConfig{Sources: []string{"claim:tenant.id"}})
+		require.NoError(t, err)
+		require.Equal(t, "nested-tenant", resolve(t, resolver, map[string]string{"Authorization": "Bearer " + token}))
+	})
+
+	t.Run("Only the ActiveProjectID header by default", func(t *testing.T) {
+		resolver, err := newTenantResolver(config.TenancyConfig{})
+		require.NoError(t, err)
+		require.Empty(t, resolve(t, resolver, map[string]string{"X-Scope-OrgID": "org-tenant"}))
+		require.Equal(t, "header-tenant", resolve(t, resolver, map[string]string{"ActiveProjectID": "header-tenant"}))
+	})
+
+	t.Run("Invalid sources", func(t *testing.T) {
+		for _, source := range []string{"ActiveProjectID", "header:", "query:tenant"} {
+			_, err := newTenantResolver(config.TenancyConfig{Sources: []string{source}})
+			require.Error(t, err, source)
+		}
+	})
+}
@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/tenantmeta"
+)
+
+// tenantMetadataGetter gets the metadata of tenants, such as the display name of their project, alerts are annotated with.
+type tenantMetadataGetter interface {
+	Get(ctx context.Context, tenantID string) (tenantmeta.Metadata, error)
+}
+
+// newTenantMetadataGetter returns the getter of the metadata of tenants, or nil if alerts are not annotated with it.
+func newTenantMetadataGetter(conf config.TenantMetadataConfig) tenantMetadataGetter {
+	if conf.URL == "" {
+		return nil
+	}
+	return tenantmeta.New(conf)
+}
+
+// getTenantMetadata returns the metadata of the given tenant, and whether it is available. Failing to get it is only logged,
+// as alerts are still meaningful without it.
+func getTenantMetadata(ctx echo.Context, getter tenantMetadataGetter, tenantID api.TenantID) (tenantmeta.Metadata, bool) {
+	if getter == nil {
+		return tenantmeta.Metadata{}, false
+	}
+
+	metadata, err := getter.Get(ctx.Request().Context(), tenantID)
+	if err != nil {
+		logWarn(ctx, fmt.Sprintf("Failed to get metadata of tenant %q: %v", tenantID, err))
+		return tenantmeta.Metadata{}, false
+	}
+	return metadata, true
+}
+
+// annotateAlerts adds the metadata of their tenant to the annotations of the given alerts.
+func annotateAlerts(alerts *[]api.Alert, metadata tenantmeta.Metadata) {
+	for i := range *alerts {
+		var annotations map[string]string
+		if (*alerts)[i].Annotations != nil {
+			annotations = *(*alerts)[i].Annotations
+		}
+		if annotations = metadata.Annotate(annotations); annotations != nil {
+			(*alerts)[i].Annotations = &annotations
+		}
+	}
+}
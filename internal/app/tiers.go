@@ -0,0 +1,227 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/time/rate"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	db "github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+const (
+	// tenantTiersEndpoint is the prefix of the endpoints assigning tiers to tenants. It is under /debug, so that it is only
+	// granted to administrators.
+	tenantTiersEndpoint = "/debug/tenants"
+
+	// tierCacheTTL is the period the tier of a tenant is cached for. A tier assigned through another replica takes effect
+	// on this one after it.
+	tierCacheTTL = time.Minute
+)
+
+// tenantTier is the tier of a tenant as served and assigned by the tier endpoints. Default tells whether the tenant has no
+// assigned tier, and is thus of the default tier.
+type tenantTier struct {
+	Tier    string `json:"tier"`
+	Default bool   `json:"default,omitempty"`
+}
+
+type cachedTier struct {
+	tier    string
+	expires time.Time
+}
+
+// tenantTiers resolves the service level tenants are entitled to from the tier assigned to them, limits the rate of their
+// API requests accordingly and serves the endpoints assigning tiers to tenants.
+type tenantTiers struct {
+	conf  config.TenantTiersConfig
+	tiers db.TenantTierManager
+
+	mu       sync.Mutex
+	cached   map[api.TenantID]cachedTier
+	limiters map[api.TenantID]*rate.Limiter
+}
+
+func newTenantTiers(conf config.TenantTiersConfig, tiers db.TenantTierManager) *tenantTiers {
+	return &tenantTiers{
+		conf:     conf,
+		tiers:    tiers,
+		cached:   make(map[api.TenantID]cachedTier),
+		limiters: make(map[api.TenantID]*rate.Limiter),
+	}
+}
+
+// register registers the tier endpoints.
+func (t *tenantTiers) register(e *echo.Echo) {
+	g := e.Group(tenantTiersEndpoint)
+	g.GET("/:tenantID/tier", t.get)
+	g.PUT("/:tenantID/tier", t.set)
+}
+
+// serviceLevel returns the service level of the tier of the given tenant, and whether the tier is configured. Tenants of a
+// tier which is not configured are not limited.
+func (t *tenantTiers) serviceLevel(ctx context.Context, tenantID api.TenantID) (config.TierConfig, bool, error) {
+	tier, err := t.tier(ctx, tenantID)
+	if err != nil {
+		return config.TierConfig{}, false, err
+	}
+	level, ok := t.conf.Tier(tier)
+	return level, ok, nil
+}
+
+// tier returns the tier assigned to the given tenant, caching it for tierCacheTTL.
+func (t *tenantTiers) tier(ctx context.Context, tenantID api.TenantID) (string, error) {
+	now := clock.TimeNowFn()
+
+	t.mu.Lock()
+	cached, ok := t.cached[tenantID]
+	t.mu.Unlock()
+	if ok && now.Before(cached.expires) {
+		return cached.tier, nil
+	}
+
+	tier, err := t.tiers.GetTenantTier(ctx, tenantID)
+	if err != nil {
+		return "", err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.cached[tenantID] = cachedTier{tier: tier, expires: now.Add(tierCacheTTL)}
+	return tier, nil
+}
+
+// limit limits the rate of API requests of the tenants given by the ActiveProjectID header of requests to the rate of their
+// tier. Requests are let through if the tier of the tenant cannot be retrieved.
+func (t *tenantTiers) limit(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		tenantID := c.Request().Header.Get(activeProjectIDHeader)
+		if skipAuth(c) || len(strings.TrimSpace(tenantID)) == 0 {
+			return next(c)
+		}
+
+		level, ok, err := t.serviceLevel(c.Request().Context(), tenantID)
+		if err != nil {
+			logError(c, fmt.Sprintf("Failed to get tier of tenant %q", tenantID), err)
+			return next(c)
+		}
+		if !ok || level.RequestsPerSecond <= 0 {
+			return next(c)
+		}
+
+		if !t.limiter(tenantID, level).Allow() {
+			logWarn(c, fmt.Sprintf("Tenant %q exceeds the request rate of its tier", tenantID))
+			return c.JSON(http.StatusTooManyRequests, api.HttpError{
+				Code:      http.StatusTooManyRequests,
+				Message:   "too many requests",
+				ErrorCode: api.ErrorCodeRateLimited,
+			})
+		}
+		return next(c)
+	}
+}
+
+// limiter returns the rate limiter of the given tenant, updated to the rate of its current service level.
+func (t *tenantTiers) limiter(tenantID api.TenantID, level config.TierConfig) *rate.Limiter {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	limit, burst := rate.Limit(level.RequestsPerSecond), max(level.Burst, 1)
+	limiter, ok := t.limiters[tenantID]
+	if !ok {
+		limiter = rate.NewLimiter(limit, burst)
+		t.limiters[tenantID] = limiter
+	} else if limiter.Limit() != limit || limiter.Burst() != burst {
+		limiter.SetLimit(limit)
+		limiter.SetBurst(burst)
+	}
+	return limiter
+}
+
+// get handles the request for the tier of a tenant.
+func (t *tenantTiers) get(ctx echo.Context) error {
+	tenantID := ctx.Param("tenantID")
+	tier, err := t.tiers.GetTenantTier(ctx.Request().Context(), tenantID)
+	if err != nil {
+		logError(ctx, fmt.Sprintf("Failed to get tier of tenant %q", tenantID), err)
+		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   "failed to get tenant tier",
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+
+	if tier == "" {
+		return ctx.JSON(http.StatusOK, tenantTier{Tier: t.conf.DefaultTier, Default: true})
+	}
+	return ctx.JSON(http.StatusOK, tenantTier{Tier: tier})
+}
+
+// set handles the request assigning a tier to a tenant. The tier has to be configured, an empty tier unassigns the tier of
+// the tenant so that it is of the default tier.
+func (t *tenantTiers) set(ctx echo.Context) error {
+	tenantID := ctx.Param("tenantID")
+
+	var body tenantTier
+	dec := json.NewDecoder(ctx.Request().Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&body); err != nil {
+		logError(ctx, "Failed to parse body of tenant tier", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPBadRequest,
+			ErrorCode: api.ErrorCodeInvalidRequestBody,
+		})
+	}
+
+	if _, ok := t.conf.Tiers[body.Tier]; body.Tier != "" && !ok {
+		logWarn(ctx, fmt.Sprintf("Unknown tier: %q", body.Tier))
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPBadRequest,
+			ErrorCode: api.ErrorCodeInvalidParameter,
+		})
+	}
+
+	if err := t.tiers.SetTenantTier(ctx.Request().Context(), tenantID, body.Tier); err != nil {
+		logError(ctx, fmt.Sprintf("Failed to set tier of tenant %q", tenantID), err)
+		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   "failed to set tenant tier",
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.cached, tenantID)
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// checkReceiverServiceLevel verifies that a receiver with the given values notifies through channels and recipients within
+// the given service level.
+func checkReceiverServiceLevel(level config.TierConfig, values models.DBReceiverValues) error {
+	if len(values.Recipients) > 0 && !level.AllowsChannel(config.ChannelEmail) {
+		return fmt.Errorf("channel %q is not allowed", config.ChannelEmail)
+	}
+	if level.MaxEmailRecipients > 0 && len(values.Recipients) > level.MaxEmailRecipients {
+		return fmt.Errorf("%d email recipients exceed the maximum of %d", len(values.Recipients), level.MaxEmailRecipients)
+	}
+	if values.OnCallRoutingKey != nil && *values.OnCallRoutingKey != "" && !level.AllowsChannel(config.ChannelOnCall) {
+		return fmt.Errorf("channel %q is not allowed", config.ChannelOnCall)
+	}
+	return nil
+}
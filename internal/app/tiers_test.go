@@ -0,0 +1,202 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+type TenantTierMock struct {
+	mock.Mock
+}
+
+func (m *TenantTierMock) GetTenantTier(ctx context.Context, tenantID api.TenantID) (string, error) {
+	args := m.Called(ctx, tenantID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *TenantTierMock) SetTenantTier(ctx context.Context, tenantID api.TenantID, tier string) error {
+	args := m.Called(ctx, tenantID, tier)
+	return args.Error(0)
+}
+
+var testTiersConfig = config.TenantTiersConfig{
+	DefaultTier: "basic",
+	Tiers: map[string]config.TierConfig{
+		"basic": {
+			MaxEmailRecipients: 1,
+			Channels:           []string{config.ChannelEmail},
+			RequestsPerSecond:  1,
+			Burst:              2,
+		},
+		"premium": {},
+	},
+}
+
+func TestTenantTiersLimit(t *testing.T) {
+	clock.SetFakeClock()
+	defer clock.UnsetFakeClock()
+	clock.FakeClock.Set(time.Now())
+
+	tierMock := new(TenantTierMock)
+	tierMock.On("GetTenantTier", mock.Anything, "basic-tenant").Return("", nil).Once()
+	tierMock.On("GetTenantTier", mock.Anything, "premium-tenant").Return("premium", nil).Once()
+
+	e := echo.New()
+	handler := newTenantTiers(testTiersConfig, tierMock).limit(func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	request := func(tenantID string) int {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/alerts", nil)
+		req.Header.Set("ActiveProjectID", tenantID)
+		rec := httptest.NewRecorder()
+		require.NoError(t, handler(e.NewContext(req, rec)))
+		return rec.Code
+	}
+
+	// The tier of a tenant is cached, so that it is only retrieved once.
+	for range 2 {
+		require.Equal(t, http.StatusOK, request("basic-tenant"))
+	}
+	require.Equal(t, http.StatusTooManyRequests, request("basic-tenant"))
+
+	for range 5 {
+		require.Equal(t, http.StatusOK, request("premium-tenant"))
+	}
+
+	require.True(t, tierMock.AssertExpectations(t))
+}
+
+func TestTenantTiersEndpoints(t *testing.T) {
+	tierMock := new(TenantTierMock)
+	tierMock.On("GetTenantTier", mock.Anything, "tenant").Return("", nil).Once()
+	tierMock.On("SetTenantTier", mock.Anything, "tenant", "premium").Return(nil).Once()
+
+	e := echo.New()
+	newTenantTiers(testTiersConfig, tierMock).register(e)
+
+	do := func(method string, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, tenantTiersEndpoint+"/tenant/tier", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("Get default tier of a tenant", func(t *testing.T) {
+		rec := do(http.MethodGet, "")
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var tier tenantTier
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &tier))
+		require.Equal(t, tenantTier{Tier: "basic", Default: true}, tier)
+	})
+
+	t.Run("Assign tier to a tenant", func(t *testing.T) {
+		rec := do(http.MethodPut, `{"tier":"premium"}`)
+		require.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("Fail to assign unknown tier", func(t *testing.T) {
+		rec := do(http.MethodPut, `{"tier":"gold"}`)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+
+		var httpErr api.HttpError
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &httpErr))
+		require.Equal(t, api.ErrorCodeInvalidParameter, httpErr.ErrorCode)
+	})
+
+	require.True(t, tierMock.AssertExpectations(t))
+}
+
+func TestTenantTiersEndpoints_AlertmanagerShard(t *testing.T) {
+	conn, err := gorm.Open(sqlite.Open(":memory:"))
+	require.NoError(t, err)
+	require.NoError(t, conn.AutoMigrate(&models.Tenant{}))
+	dbService := &database.DBService{DB: conn}
+
+	configfile := conf
+	configfile.TenantTiers = testTiersConfig
+	configfile.AlertManager.Shards = []config.AlertManagerShardConfig{{}, {}, {}}
+
+	e := echo.New()
+	NewServerInterfaceHandler(configfile, conn, nil, nil).tiers.register(e)
+
+	// The shard serving the tenant before it is registered is the one it is assigned out of the shards of the configuration.
+	expected, err := dbService.GetTenantShard(context.Background(), "tenant", configfile.AlertManager.ShardCount())
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPut, tenantTiersEndpoint+"/tenant/tier", strings.NewReader(`{"tier":"premium"}`))
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusNoContent, rec.Code)
+
+	var tenant models.Tenant
+	require.NoError(t, conn.Where("tenant_id = ?", "tenant").Take(&tenant).Error)
+	require.Equal(t, &expected, tenant.AlertmanagerShard)
+
+	// The assigned shard is kept when the number of shards changes.
+	shard, err := dbService.GetTenantShard(context.Background(), "tenant", 1)
+	require.NoError(t, err)
+	require.Equal(t, expected, shard)
+}
+
+func TestCheckReceiverServiceLevel(t *testing.T) {
+	basic := testTiersConfig.Tiers["basic"]
+	routingKey := "key"
+
+	tests := map[string]struct {
+		level  config.TierConfig
+		values models.DBReceiverValues
+		err    string
+	}{
+		"within service level": {
+			level:  basic,
+			values: models.DBReceiverValues{Recipients: []models.EmailAddress{{Email: "foo@bar.com"}}},
+		},
+		"too many email recipients": {
+			level:  basic,
+			values: models.DBReceiverValues{Recipients: []models.EmailAddress{{Email: "foo@bar.com"}, {Email: "bar@foo.com"}}},
+			err:    "2 email recipients exceed the maximum of 1",
+		},
+		"channel not allowed": {
+			level:  basic,
+			values: models.DBReceiverValues{OnCallRoutingKey: &routingKey},
+			err:    `channel "oncall" is not allowed`,
+		},
+		"unlimited tier": {
+			level:  testTiersConfig.Tiers["premium"],
+			values: models.DBReceiverValues{OnCallRoutingKey: &routingKey},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := checkReceiverServiceLevel(test.level, test.values)
+			if test.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.err)
+			}
+		})
+	}
+}
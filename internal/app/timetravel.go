@@ -0,0 +1,109 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build timetravel
+
+package app
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+)
+
+// clockEndpoint is the endpoint moving the clock of alerting monitor in time travel mode. It is under /debug, so that it is
+// only granted to administrators.
+const clockEndpoint = "/debug/clock"
+
+// clockState is the state of the clock as served by the clock endpoint.
+type clockState struct {
+	Now       time.Time `json:"now"`
+	Travelled string    `json:"travelled"`
+}
+
+// clockTravel is the move of the clock requested to the clock endpoint, either by a duration or to a time.
+type clockTravel struct {
+	Advance string     `json:"advance,omitempty"`
+	Time    *time.Time `json:"time,omitempty"`
+}
+
+// registerTimeTravel enables time travel mode and registers the clock endpoint if enabled by the configuration, so that QA
+// can exercise retention, timeouts and backoffs without waiting. Time travel is only available in builds with the timetravel
+// build tag.
+func registerTimeTravel(e *echo.Echo, conf config.TimeTravelConfig) {
+	if !conf.Enabled {
+		return
+	}
+	slog.Warn("Time travel mode is enabled, the clock can be moved through " + clockEndpoint)
+	clock.EnableTimeTravel()
+
+	e.GET(clockEndpoint, getClock)
+	e.POST(clockEndpoint, travel)
+	e.DELETE(clockEndpoint, resetClock)
+}
+
+func getClock(ctx echo.Context) error {
+	return ctx.JSON(http.StatusOK, currentClockState())
+}
+
+// travel handles the request moving the clock, by the duration to advance it by, backwards if negative, or to a time.
+func travel(ctx echo.Context) error {
+	var body clockTravel
+	dec := json.NewDecoder(ctx.Request().Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&body); err != nil {
+		logError(ctx, "Failed to parse body of clock travel", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPBadRequest,
+			ErrorCode: api.ErrorCodeInvalidRequestBody,
+		})
+	}
+
+	switch {
+	case body.Advance != "" && body.Time == nil:
+		d, err := time.ParseDuration(body.Advance)
+		if err != nil {
+			logError(ctx, "Failed to parse duration of clock travel", err)
+			return ctx.JSON(http.StatusBadRequest, api.HttpError{
+				Code:      http.StatusBadRequest,
+				Message:   errHTTPBadRequest,
+				ErrorCode: api.ErrorCodeInvalidParameter,
+			})
+		}
+		clock.Travel(d)
+	case body.Advance == "" && body.Time != nil:
+		clock.TravelTo(*body.Time)
+	default:
+		logWarn(ctx, "Clock travel requires either a duration to advance by or a time")
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPBadRequest,
+			ErrorCode: api.ErrorCodeInvalidRequestBody,
+		})
+	}
+
+	state := currentClockState()
+	slog.Warn("Clock travelled", slog.Time("now", state.Now), slog.String("travelled", state.Travelled))
+	return ctx.JSON(http.StatusOK, state)
+}
+
+// resetClock handles the request bringing the clock back to the current local time.
+func resetClock(ctx echo.Context) error {
+	clock.ResetTravel()
+	return ctx.JSON(http.StatusOK, currentClockState())
+}
+
+func currentClockState() clockState {
+	return clockState{
+		Now:       clock.TimeNowFn().UTC(),
+		Travelled: clock.Travelled().String(),
+	}
+}
@@ -0,0 +1,22 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !timetravel
+
+package app
+
+import (
+	"log/slog"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+)
+
+// registerTimeTravel warns that time travel mode is not available if enabled by the configuration, as the build lacks the
+// timetravel build tag.
+func registerTimeTravel(_ *echo.Echo, conf config.TimeTravelConfig) {
+	if conf.Enabled {
+		slog.Warn("Time travel mode is not available in this build, it requires the timetravel build tag")
+	}
+}
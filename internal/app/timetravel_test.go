@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build timetravel
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+)
+
+func TestTimeTravel(t *testing.T) {
+	e := echo.New()
+	registerTimeTravel(e, config.TimeTravelConfig{Enabled: true})
+	defer clock.UnsetFakeClock()
+	defer clock.ResetTravel()
+
+	serve := func(method, body string) (int, clockState) {
+		req := httptest.NewRequest(method, clockEndpoint, strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		var state clockState
+		if rec.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &state))
+		}
+		return rec.Code, state
+	}
+
+	t.Run("Advance clock", func(t *testing.T) {
+		code, state := serve(http.MethodPost, `{"advance":"48h"}`)
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, "48h0m0s", state.Travelled)
+		require.WithinDuration(t, time.Now().Add(48*time.Hour), state.Now, time.Second)
+		require.WithinDuration(t, time.Now().Add(48*time.Hour), clock.TimeNowFn(), time.Second)
+	})
+
+	t.Run("Set clock", func(t *testing.T) {
+		target := time.Now().Add(90 * 24 * time.Hour).UTC().Truncate(time.Second)
+		code, state := serve(http.MethodPost, `{"time":"`+target.Format(time.RFC3339)+`"}`)
+		require.Equal(t, http.StatusOK, code)
+		require.WithinDuration(t, target, state.Now, time.Second)
+
+		code, state = serve(http.MethodGet, "")
+		require.Equal(t, http.StatusOK, code)
+		require.WithinDuration(t, target, state.Now, time.Second)
+	})
+
+	t.Run("Reset clock", func(t *testing.T) {
+		code, state := serve(http.MethodDelete, "")
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, "0s", state.Travelled)
+		require.WithinDuration(t, time.Now(), clock.TimeNowFn(), time.Second)
+	})
+
+	t.Run("Invalid travel - code should be 400", func(t *testing.T) {
+		for _, body := range []string{`{}`, `{"advance":"tomorrow"}`, `{"advance":"1h","time":"2025-01-01T00:00:00Z"}`, `{"days":1}`} {
+			code, _ := serve(http.MethodPost, body)
+			require.Equal(t, http.StatusBadRequest, code, body)
+		}
+	})
+}
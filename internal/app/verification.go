@@ -0,0 +1,209 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	db "github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+const (
+	errHTTPEmailVerificationUnavailable = "email verification is not available"
+	errHTTPInvalidVerificationToken     = "invalid or expired verification token"
+	errHTTPFailedToVerifyEmail          = "failed to verify email address"
+)
+
+// defaultVerificationLinkTTL is the period verification links are valid for if not configured.
+const defaultVerificationLinkTTL = 72 * time.Hour
+
+// errInvalidVerificationToken is returned when a verification token is malformed, not signed with the verification key, or
+// expired.
+var errInvalidVerificationToken = errors.New("invalid verification token")
+
+// verificationSender sends the emails asking recipients to confirm their email address.
+type verificationSender interface {
+	SendVerification(ctx context.Context, to *mail.Address, link string, expiry time.Time) error
+}
+
+// emailVerifier asks the new recipients of receivers to confirm their email address, with a link to the verification endpoint
+// holding a token signed with the verification key. Recipients are not notified until their address is confirmed.
+type emailVerifier struct {
+	key       []byte
+	url       *url.URL
+	linkTTL   time.Duration
+	sender    verificationSender
+	addresses db.EmailVerificationManager
+}
+
+// newEmailVerifier creates a new emailVerifier, loading the verification key from the EMAIL_VERIFICATION_KEY environment
+// variable.
+func newEmailVerifier(conf config.EmailVerificationConfig, sender verificationSender, addresses db.EmailVerificationManager) (*emailVerifier, error) {
+	key := os.Getenv("EMAIL_VERIFICATION_KEY")
+	if key == "" {
+		return nil, errors.New("email verification key is not set")
+	}
+
+	u, err := url.Parse(conf.URL)
+	if err != nil || !u.IsAbs() {
+		return nil, fmt.Errorf("invalid email verification URL %q", conf.URL)
+	}
+
+	linkTTL := conf.LinkTTL
+	if linkTTL <= 0 {
+		linkTTL = defaultVerificationLinkTTL
+	}
+
+	return &emailVerifier{
+		key:       []byte(key),
+		url:       u,
+		linkTTL:   linkTTL,
+		sender:    sender,
+		addresses: addresses,
+	}, nil
+}
+
+// token returns the token verifying the given email address until the given expiry. It holds the address and the expiry,
+// along with their HMAC-SHA256 signature with the verification key.
+func (v *emailVerifier) token(email string, expiry time.Time) string {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(email)) + "." + strconv.FormatInt(expiry.Unix(), 10)
+	return payload + "." + base64.RawURLEncoding.EncodeToString(v.sign(payload))
+}
+
+// parseToken returns the email address the given token verifies. An error wrapping errInvalidVerificationToken is returned if
+// the token is malformed, not signed with the verification key, or expired.
+func (v *emailVerifier) parseToken(token string) (string, error) {
+	i := strings.LastIndex(token, ".")
+	if i < 0 {
+		return "", fmt.Errorf("%w: malformed token", errInvalidVerificationToken)
+	}
+	payload := token[:i]
+
+	signature, err := base64.RawURLEncoding.DecodeString(token[i+1:])
+	if err != nil || !hmac.Equal(signature, v.sign(payload)) {
+		return "", fmt.Errorf("%w: invalid signature", errInvalidVerificationToken)
+	}
+
+	encodedEmail, encodedExpiry, ok := strings.Cut(payload, ".")
+	if !ok {
+		return "", fmt.Errorf("%w: malformed token", errInvalidVerificationToken)
+	}
+	expiry, err := strconv.ParseInt(encodedExpiry, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("%w: malformed expiry", errInvalidVerificationToken)
+	}
+	if !clock.TimeNowFn().Before(time.Unix(expiry, 0)) {
+		return "", fmt.Errorf("%w: expired", errInvalidVerificationToken)
+	}
+
+	email, err := base64.RawURLEncoding.DecodeString(encodedEmail)
+	if err != nil {
+		return "", fmt.Errorf("%w: malformed email", errInvalidVerificationToken)
+	}
+	return string(email), nil
+}
+
+func (v *emailVerifier) sign(payload string) []byte {
+	mac := hmac.New(sha256.New, v.key)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
+
+// link returns the link of the verification endpoint verifying the given email address until the given expiry.
+func (v *emailVerifier) link(email string, expiry time.Time) string {
+	u := *v.url
+	query := u.Query()
+	query.Set("token", v.token(email, expiry))
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// sendVerifications sends a verification email to the given recipients that are pending verification, unless one was sent to
+// them within the period links are valid for. Failures are only logged, as the update the recipients were added by is already
+// stored. Recipients are sent a new link once the previous one expired, when added to a receiver again.
+func (v *emailVerifier) sendVerifications(ctx echo.Context, recipients []models.EmailAddress) {
+	emails := make([]string, 0, len(recipients))
+	for _, r := range recipients {
+		emails = append(emails, r.Email)
+	}
+
+	addresses, err := v.addresses.ClaimEmailVerifications(ctx.Request().Context(), emails, v.linkTTL)
+	if err != nil {
+		logError(ctx, "Failed to get email recipients pending verification", err)
+		return
+	}
+
+	expiry := clock.TimeNowFn().Add(v.linkTTL)
+	for _, a := range addresses {
+		to := &mail.Address{Name: strings.TrimSpace(a.FirstName + " " + a.LastName), Address: a.Email}
+		if err := v.sender.SendVerification(ctx.Request().Context(), to, v.link(a.Email, expiry), expiry); err != nil {
+			logError(ctx, fmt.Sprintf("Failed to send verification email to %q", a.Email), err)
+			continue
+		}
+		slog.InfoContext(ctx.Request().Context(), "sent verification email", slog.String("email", a.Email))
+	}
+}
+
+// VerifyEmailRecipient confirms the email address of a recipient with the token of the link of the verification email sent to
+// it, so that the receivers it is a recipient of notify it. Confirming an address already verified succeeds as well.
+func (w *ServerInterfaceHandler) VerifyEmailRecipient(ctx echo.Context, params api.VerifyEmailRecipientParams) error {
+	if w.verifier == nil {
+		logWarn(ctx, "Email verification is not enabled")
+		return ctx.JSON(http.StatusServiceUnavailable, api.HttpError{
+			Code:      http.StatusServiceUnavailable,
+			Message:   errHTTPEmailVerificationUnavailable,
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+
+	email, err := w.verifier.parseToken(params.Token)
+	if err != nil {
+		logError(ctx, "Invalid email verification token", err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPInvalidVerificationToken,
+			ErrorCode: api.ErrorCodeInvalidParameter,
+		})
+	}
+
+	err = w.verifier.addresses.VerifyEmailAddress(ctx.Request().Context(), email)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		logError(ctx, fmt.Sprintf("Email address to verify not found: %q", email), err)
+		return ctx.JSON(http.StatusBadRequest, api.HttpError{
+			Code:      http.StatusBadRequest,
+			Message:   errHTTPInvalidVerificationToken,
+			ErrorCode: api.ErrorCodeInvalidParameter,
+		})
+	} else if err != nil {
+		logError(ctx, fmt.Sprintf("Failed to verify email address %q", email), err)
+		return ctx.JSON(http.StatusInternalServerError, api.HttpError{
+			Code:      http.StatusInternalServerError,
+			Message:   errHTTPFailedToVerifyEmail,
+			ErrorCode: api.ErrorCodeInternalError,
+		})
+	}
+
+	slog.InfoContext(ctx.Request().Context(), "verified email address", slog.String("email", email))
+	return ctx.JSON(http.StatusOK, api.EmailVerification{Email: email})
+}
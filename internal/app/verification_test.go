@@ -0,0 +1,177 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/testutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+type VerificationSenderMock struct {
+	mock.Mock
+}
+
+func (m *VerificationSenderMock) SendVerification(ctx context.Context, to *mail.Address, link string, expiry time.Time) error {
+	args := m.Called(ctx, to, link, expiry)
+	return args.Error(0)
+}
+
+func TestEmailVerifier_Token(t *testing.T) {
+	t.Setenv("EMAIL_VERIFICATION_KEY", "verification-key")
+	verifier, err := newEmailVerifier(config.EmailVerificationConfig{URL: "https://alerts.example.com/api/v1/verify"}, nil, nil)
+	require.NoError(t, err)
+
+	t.Run("Valid token", func(t *testing.T) {
+		email, err := verifier.parseToken(verifier.token("alice@example.com", time.Now().Add(time.Hour)))
+		require.NoError(t, err)
+		require.Equal(t, "alice@example.com", email)
+	})
+
+	t.Run("Expired token", func(t *testing.T) {
+		_, err := verifier.parseToken(verifier.token("alice@example.com", time.Now().Add(-time.Second)))
+		require.ErrorIs(t, err, errInvalidVerificationToken)
+	})
+
+	t.Run("Tampered token", func(t *testing.T) {
+		token := verifier.token("alice@example.com", time.Now().Add(time.Hour))
+		email, rest, _ := strings.Cut(token, ".")
+		forged, _, _ := strings.Cut(verifier.token("mallory@example.com", time.Now().Add(time.Hour)), ".")
+		unsigned := token[:strings.LastIndex(token, ".")]
+
+		for _, token := range []string{
+			forged + "." + rest,
+			email + ".4102444800." + strings.SplitN(rest, ".", 2)[1],
+			unsigned,
+			"",
+		} {
+			_, err := verifier.parseToken(token)
+			require.ErrorIs(t, err, errInvalidVerificationToken, token)
+		}
+	})
+
+	t.Run("Token signed with another key", func(t *testing.T) {
+		t.Setenv("EMAIL_VERIFICATION_KEY", "another-key")
+		other, err := newEmailVerifier(config.EmailVerificationConfig{URL: "https://alerts.example.com/api/v1/verify"}, nil, nil)
+		require.NoError(t, err)
+
+		_, err = verifier.parseToken(other.token("alice@example.com", time.Now().Add(time.Hour)))
+		require.ErrorIs(t, err, errInvalidVerificationToken)
+	})
+
+	t.Run("Missing key or URL", func(t *testing.T) {
+		_, err := newEmailVerifier(config.EmailVerificationConfig{URL: "/api/v1/verify"}, nil, nil)
+		require.Error(t, err)
+
+		t.Setenv("EMAIL_VERIFICATION_KEY", "")
+		_, err = newEmailVerifier(config.EmailVerificationConfig{URL: "https://alerts.example.com/api/v1/verify"}, nil, nil)
+		require.Error(t, err)
+	})
+}
+
+func TestVerifyEmailRecipient(t *testing.T) {
+	conn, err := gorm.Open(sqlite.Open(":memory:"))
+	require.NoError(t, err)
+	require.NoError(t, conn.AutoMigrate(&models.EmailAddress{}, &models.EmailConfig{}, &models.Receiver{}, &models.EmailRecipient{},
+		&models.Task{}, &models.Tenant{}, &models.EmailTemplate{}))
+	dbService := &database.DBService{DB: conn}
+
+	tenantID := "edgenode"
+	sender := models.EmailAddress{FirstName: "alerts", LastName: "sender", Email: "alerts@example.com"}
+	require.NoError(t, conn.Create(&sender).Error)
+	emailConfig := models.EmailConfig{MailServer: "smtp.example.com:587", From: sender.ID}
+	require.NoError(t, conn.Create(&emailConfig).Error)
+	id := uuid.New()
+	require.NoError(t, conn.Create(&models.Receiver{UUID: id, Name: "receiver", Version: 1, State: models.ReceiverApplied,
+		EmailConfigID: emailConfig.ID, TenantID: tenantID}).Error)
+
+	t.Setenv("EMAIL_VERIFICATION_KEY", "verification-key")
+	mSender := &VerificationSenderMock{}
+	verifier, err := newEmailVerifier(config.EmailVerificationConfig{URL: "https://alerts.example.com/api/v1/verify", LinkTTL: time.Hour},
+		mSender, dbService)
+	require.NoError(t, err)
+
+	mM2M := &M2MAuthenticatorMock{}
+	mM2M.On("GetUserList", mock.Anything).Return([]user{{FirstName: "alice", LastName: "smith", Email: "alice@example.com"}}, nil)
+
+	server := echo.New()
+	api.RegisterHandlers(server, &ServerInterfaceHandler{
+		m2m:       mM2M,
+		receivers: dbService,
+		verifier:  verifier,
+	})
+
+	var link string
+	t.Run("New recipient is sent a verification email and not notified", func(t *testing.T) {
+		mSender.On("SendVerification", mock.Anything, &mail.Address{Name: "alice smith", Address: "alice@example.com"}, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) { link = args.String(2) }).Return(nil).Once()
+
+		body := `{"emailConfig":{"to":{"enabled":["alice smith <alice@example.com>"]}}}`
+		for range 2 {
+			result := testutil.NewRequest().WithHeader("ActiveProjectID", tenantID).Patch("/api/v1/alerts/receivers/"+id.String()).
+				WithBody([]byte(body)).GoWithHTTPHandler(t, server)
+			require.Equal(t, http.StatusNoContent, result.Recorder.Code)
+		}
+		// The verification email is not sent again while its link is valid.
+		require.True(t, mSender.AssertExpectations(t))
+		require.True(t, strings.HasPrefix(link, "https://alerts.example.com/api/v1/verify?token="))
+
+		recv, err := dbService.GetLatestReceiverWithEmailConfig(t.Context(), tenantID, id)
+		require.NoError(t, err)
+		require.Equal(t, []string{"alice smith <alice@example.com>"}, recv.Unverified)
+		require.Empty(t, recv.Notified())
+	})
+
+	t.Run("Recipient is notified once verified", func(t *testing.T) {
+		u, err := url.Parse(link)
+		require.NoError(t, err)
+
+		result := testutil.NewRequest().Get("/api/v1/verify?"+u.RawQuery).GoWithHTTPHandler(t, server)
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+
+		var res api.EmailVerification
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &res))
+		require.Equal(t, "alice@example.com", res.Email)
+
+		recv, err := dbService.GetLatestReceiverWithEmailConfig(t.Context(), tenantID, id)
+		require.NoError(t, err)
+		require.Empty(t, recv.Unverified)
+		require.Equal(t, []string{"alice smith <alice@example.com>"}, recv.Notified())
+	})
+
+	t.Run("Invalid token", func(t *testing.T) {
+		result := testutil.NewRequest().Get("/api/v1/verify?token=invalid").GoWithHTTPHandler(t, server)
+		require.Equal(t, http.StatusBadRequest, result.Recorder.Code)
+
+		var httpErr api.HttpError
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &httpErr))
+		require.Equal(t, api.ErrorCodeInvalidParameter, httpErr.ErrorCode)
+	})
+
+	t.Run("Verification not enabled", func(t *testing.T) {
+		server := echo.New()
+		api.RegisterHandlers(server, &ServerInterfaceHandler{})
+
+		result := testutil.NewRequest().Get("/api/v1/verify?token=invalid").GoWithHTTPHandler(t, server)
+		require.Equal(t, http.StatusServiceUnavailable, result.Recorder.Code)
+	})
+}
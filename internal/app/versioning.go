@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+)
+
+const (
+	apiVersionHeader    = "API-Version"
+	acceptVersionHeader = "Accept-Version"
+	deprecationHeader   = "Deprecation"
+	sunsetHeader        = "Sunset"
+	linkHeader          = "Link"
+)
+
+const errHTTPUnsupportedAPIVersion = "unsupported API version"
+
+// apiPathPrefix is the prefix of the paths of the API, followed by the version of the API and the path of the endpoint.
+const apiPathPrefix = "/api/"
+
+// apiVersions are the versions of the API served, the handlers of a version being registered under /api/<version>.
+var apiVersions = []string{"v1"}
+
+// apiVersioning selects the version of the API serving requests, and announces the deprecation of versions to clients.
+type apiVersioning struct {
+	versions     []string
+	deprecations map[string]config.APIDeprecationConfig
+}
+
+func newAPIVersioning(conf config.APIVersioningConfig) *apiVersioning {
+	return &apiVersioning{
+		versions:     apiVersions,
+		deprecations: conf.Deprecations,
+	}
+}
+
+// negotiate serves the requests of the API by the handlers of the version of the Accept-Version header, if any, rather than
+// the version of their path, so that clients can switch versions without changing the URLs they call. It is meant to run
+// before routing, and leaves the requests whose path holds no version served as they are. Requests for a version not served
+// are rejected. The version serving a request is returned in the API-Version header, along with the deprecation headers of the
+// version if it is deprecated.
+func (v *apiVersioning) negotiate(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(ctx echo.Context) error {
+		req := ctx.Request()
+		rest, ok := strings.CutPrefix(req.URL.Path, apiPathPrefix)
+		if !ok {
+			return next(ctx)
+		}
+		pathVersion, endpoint, _ := strings.Cut(rest, "/")
+		if !slices.Contains(v.versions, pathVersion) {
+			return next(ctx)
+		}
+
+		version := pathVersion
+		if accept := req.Header.Get(acceptVersionHeader); accept != "" {
+			version = normalizeAPIVersion(accept)
+			if !slices.Contains(v.versions, version) {
+				logWarn(ctx, fmt.Sprintf("Unsupported API version: %q", accept))
+				return ctx.JSON(http.StatusNotAcceptable, api.HttpError{
+					Code:      http.StatusNotAcceptable,
+					Message:   errHTTPUnsupportedAPIVersion,
+					ErrorCode: api.ErrorCodeUnsupportedAPIVersion,
+					Details: &[]api.ErrorDetail{{
+						Field:  acceptVersionHeader,
+						Reason: "must be one of " + strings.Join(v.versions, ", "),
+						Value:  &accept,
+					}},
+				})
+			}
+			if version != pathVersion {
+				req.URL.Path = apiPathPrefix + version + "/" + endpoint
+				req.URL.RawPath = ""
+			}
+		}
+
+		header := ctx.Response().Header()
+		header.Set(apiVersionHeader, version)
+		if deprecation, ok := v.deprecations[version]; ok {
+			// The Deprecation header holds the time as a structured field date, the Sunset header as an HTTP date.
+			header.Set(deprecationHeader, fmt.Sprintf("@%d", deprecation.Date.Unix()))
+			if !deprecation.Sunset.IsZero() {
+				header.Set(sunsetHeader, deprecation.Sunset.UTC().Format(http.TimeFormat))
+			}
+			if deprecation.Link != "" {
+				header.Add(linkHeader, fmt.Sprintf("<%s>; rel=\"deprecation\"", deprecation.Link))
+			}
+		}
+		return next(ctx)
+	}
+}
+
+// normalizeAPIVersion returns the version of the API of the value of an Accept-Version header, which may omit the v prefix of
+// versions.
+func normalizeAPIVersion(value string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if !strings.HasPrefix(value, "v") {
+		value = "v" + value
+	}
+	return value
+}
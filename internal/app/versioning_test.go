@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+)
+
+func TestAPIVersioning(t *testing.T) {
+	versioning := newAPIVersioning(config.APIVersioningConfig{
+		Deprecations: map[string]config.APIDeprecationConfig{
+			"v1": {
+				Date:   time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC),
+				Sunset: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+				Link:   "https://docs.example.com/migrate-to-v2",
+			},
+		},
+	})
+	// The handlers of a second version are registered alongside the handlers of the first one.
+	versioning.versions = []string{"v1", "v2"}
+
+	e := echo.New()
+	e.Pre(versioning.negotiate)
+	for _, version := range versioning.versions {
+		e.GET("/api/"+version+"/alerts", func(ctx echo.Context) error { return ctx.String(http.StatusOK, version) })
+	}
+	e.GET("/metrics", func(ctx echo.Context) error { return ctx.NoContent(http.StatusOK) })
+
+	t.Run("Version of the path", func(t *testing.T) {
+		result := testutil.NewRequest().Get("/api/v2/alerts").GoWithHTTPHandler(t, e)
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+		require.Equal(t, "v2", result.Recorder.Body.String())
+		require.Equal(t, "v2", result.Recorder.Header().Get(apiVersionHeader))
+		require.Empty(t, result.Recorder.Header().Get(deprecationHeader))
+	})
+
+	t.Run("Deprecated version", func(t *testing.T) {
+		result := testutil.NewRequest().Get("/api/v1/alerts").GoWithHTTPHandler(t, e)
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+		require.Equal(t, "v1", result.Recorder.Header().Get(apiVersionHeader))
+		require.Equal(t, "@1782864000", result.Recorder.Header().Get(deprecationHeader))
+		require.Equal(t, "Fri, 01 Jan 2027 00:00:00 GMT", result.Recorder.Header().Get(sunsetHeader))
+		require.Equal(t, `<https://docs.example.com/migrate-to-v2>; rel="deprecation"`, result.Recorder.Header().Get(linkHeader))
+	})
+
+	t.Run("Version selected by the Accept-Version header", func(t *testing.T) {
+		for _, accept := range []string{"v2", "2", " V2 "} {
+			result := testutil.NewRequest().WithHeader(acceptVersionHeader, accept).Get("/api/v1/alerts").GoWithHTTPHandler(t, e)
+			require.Equal(t, http.StatusOK, result.Recorder.Code)
+			require.Equal(t, "v2", result.Recorder.Body.String(), accept)
+			require.Equal(t, "v2", result.Recorder.Header().Get(apiVersionHeader))
+		}
+	})
+
+	t.Run("Unsupported version", func(t *testing.T) {
+		result := testutil.NewRequest().WithHeader(acceptVersionHeader, "v3").Get("/api/v1/alerts").GoWithHTTPHandler(t, e)
+		require.Equal(t, http.StatusNotAcceptable, result.Recorder.Code)
+
+		var httpErr api.HttpError
+		require.NoError(t, json.Unmarshal(result.Recorder.Body.Bytes(), &httpErr))
+		require.Equal(t, api.ErrorCodeUnsupportedAPIVersion, httpErr.ErrorCode)
+	})
+
+	t.Run("Endpoints outside of the API", func(t *testing.T) {
+		result := testutil.NewRequest().WithHeader(acceptVersionHeader, "v3").Get("/metrics").GoWithHTTPHandler(t, e)
+		require.Equal(t, http.StatusOK, result.Recorder.Code)
+		require.Empty(t, result.Recorder.Header().Get(apiVersionHeader))
+	})
+}
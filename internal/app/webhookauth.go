@@ -0,0 +1,179 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/netip"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+)
+
+const (
+	webhookSignatureHeader = "X-Webhook-Signature"
+	webhookTimestampHeader = "X-Webhook-Timestamp"
+	webhookSignaturePrefix = "sha256="
+
+	// defaultWebhookSignatureTolerance is the maximum age of the timestamp of signed callbacks if not configured.
+	defaultWebhookSignatureTolerance = 5 * time.Minute
+)
+
+// webhookAuthenticator authenticates the alertmanager webhook callbacks of a relay, by the address of their peer, and by
+// either the relay token or an HMAC-SHA256 signature of their timestamp and body.
+type webhookAuthenticator struct {
+	// allowed are the networks callbacks are accepted from. Callbacks are accepted from any address if empty.
+	allowed []netip.Prefix
+	// token is the token alertmanager authenticates to the relay with. Callbacks are not authenticated by token if empty, in
+	// which case signingKey is set.
+	token string
+	// signingKey is the key of the signatures of callbacks. Callbacks are not authenticated by signature if empty.
+	signingKey []byte
+	tolerance  time.Duration
+}
+
+// newWebhookAuthenticator returns the authenticator of the callbacks of a relay, whose token is read from the given environment
+// variable. The signing key is read from the WEBHOOK_SIGNING_KEY environment variable. Either of them must be set, as relays
+// would otherwise accept forged alerts for any tenant.
+func newWebhookAuthenticator(conf config.WebhookAuthConfig, tokenEnv string) (*webhookAuthenticator, error) {
+	token, signingKey := os.Getenv(tokenEnv), os.Getenv("WEBHOOK_SIGNING_KEY")
+	if token == "" && signingKey == "" {
+		return nil, fmt.Errorf("webhook callbacks cannot be authenticated: neither %s nor WEBHOOK_SIGNING_KEY is set", tokenEnv)
+	}
+
+	allowed := make([]netip.Prefix, 0, len(conf.AllowedCIDRs))
+	for _, cidr := range conf.AllowedCIDRs {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			// A single address is allowed as is.
+			addr, addrErr := netip.ParseAddr(cidr)
+			if addrErr != nil {
+				return nil, fmt.Errorf("invalid allowed CIDR %q of webhook callbacks: %w", cidr, err)
+			}
+			prefix = netip.PrefixFrom(addr, addr.BitLen())
+		}
+		allowed = append(allowed, prefix.Masked())
+	}
+
+	tolerance := conf.SignatureTolerance
+	if tolerance <= 0 {
+		tolerance = defaultWebhookSignatureTolerance
+	}
+	return &webhookAuthenticator{
+		allowed:    allowed,
+		token:      token,
+		signingKey: []byte(signingKey),
+		tolerance:  tolerance,
+	}, nil
+}
+
+// authenticate rejects the callbacks whose peer is not allowed, and those authenticated neither by the relay token nor by a
+// valid signature. The address of the peer of the connection is used rather than forwarded addresses,
+// which callers can forge.
+func (a *webhookAuthenticator) authenticate(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(ctx echo.Context) error {
+		req := ctx.Request()
+		if !a.allows(req.RemoteAddr) {
+			logWarn(ctx, fmt.Sprintf("Rejected webhook callback from address not allowed: %q", req.RemoteAddr))
+			return ctx.JSON(http.StatusForbidden, api.HttpError{
+				Code:      http.StatusForbidden,
+				Message:   "webhook callbacks are not allowed from this address",
+				ErrorCode: api.ErrorCodeWebhookSourceNotAllowed,
+			})
+		}
+
+		if a.token != "" && subtle.ConstantTimeCompare([]byte(req.Header.Get("Authorization")), []byte("Bearer "+a.token)) == 1 {
+			return next(ctx)
+		}
+		if len(a.signingKey) > 0 && req.Header.Get(webhookSignatureHeader) != "" {
+			err := a.verifySignature(req)
+			if err == nil {
+				return next(ctx)
+			}
+			logWarn(ctx, fmt.Sprintf("Invalid signature of webhook callback: %v", err))
+		}
+
+		logWarn(ctx, "Failed to authenticate webhook callback")
+		return ctx.JSON(http.StatusUnauthorized, api.HttpError{
+			Code:      http.StatusUnauthorized,
+			Message:   http.StatusText(http.StatusUnauthorized),
+			ErrorCode: api.ErrorCodeUnauthorized,
+		})
+	}
+}
+
+// allows tells whether callbacks are accepted from the given address of a peer.
+func (a *webhookAuthenticator) allows(remoteAddr string) bool {
+	if len(a.allowed) == 0 {
+		return true
+	}
+	addrPort, err := netip.ParseAddrPort(remoteAddr)
+	if err != nil {
+		return false
+	}
+	addr := addrPort.Addr().Unmap()
+	for _, prefix := range a.allowed {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// verifySignature verifies that the signature of a callback is the HMAC-SHA256 of its timestamp, a dot and its body, and that
+// its timestamp is within the tolerance of the current time. The body is restored for the handler of the callback.
+func (a *webhookAuthenticator) verifySignature(req *http.Request) error {
+	timestamp := req.Header.Get(webhookTimestampHeader)
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp %q", timestamp)
+	}
+	if age := clock.TimeNowFn().Sub(time.Unix(seconds, 0)).Abs(); age > a.tolerance {
+		return fmt.Errorf("timestamp %q is out of tolerance", timestamp)
+	}
+
+	signature, ok := strings.CutPrefix(req.Header.Get(webhookSignatureHeader), webhookSignaturePrefix)
+	if !ok {
+		return errors.New("unsupported signature scheme")
+	}
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return errors.New("signature is not hex encoded")
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read body: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	if !hmac.Equal(expected, signWebhook(a.signingKey, timestamp, body)) {
+		return errors.New("signature mismatch")
+	}
+	return nil
+}
+
+// signWebhook returns the HMAC-SHA256 signature of a callback with the given timestamp and body.
+func signWebhook(key []byte, timestamp string, body []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+)
+
+func TestWebhookAuthenticator(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	clock.TimeNowFn = func() time.Time { return now }
+	defer func() { clock.TimeNowFn = time.Now }()
+
+	body := `{"alerts":[]}`
+	newServer := func(t *testing.T, conf config.WebhookAuthConfig) *echo.Echo {
+		t.Helper()
+		auth, err := newWebhookAuthenticator(conf, "TEST_RELAY_TOKEN")
+		require.NoError(t, err)
+
+		e := echo.New()
+		e.POST("/relay", func(ctx echo.Context) error {
+			// The body remains readable by the handler once the signature is verified.
+			b, err := io.ReadAll(ctx.Request().Body)
+			require.NoError(t, err)
+			require.Equal(t, body, string(b))
+			return ctx.NoContent(http.StatusOK)
+		}, auth.authenticate)
+		return e
+	}
+	post := func(e *echo.Echo, remoteAddr string, header map[string]string) int {
+		req := httptest.NewRequest(http.MethodPost, "/relay", strings.NewReader(body))
+		req.RemoteAddr = remoteAddr
+		for k, v := range header {
+			req.Header.Set(k, v)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	signed := func(key string, timestamp time.Time) map[string]string {
+		ts := strconv.FormatInt(timestamp.Unix(), 10)
+		return map[string]string{
+			webhookTimestampHeader: ts,
+			webhookSignatureHeader: webhookSignaturePrefix + hex.EncodeToString(signWebhook([]byte(key), ts, []byte(body))),
+		}
+	}
+
+	t.Run("Token or signing key required", func(t *testing.T) {
+		t.Setenv("TEST_RELAY_TOKEN", "")
+		t.Setenv("WEBHOOK_SIGNING_KEY", "")
+		_, err := newWebhookAuthenticator(config.WebhookAuthConfig{}, "TEST_RELAY_TOKEN")
+		require.ErrorContains(t, err, "neither TEST_RELAY_TOKEN nor WEBHOOK_SIGNING_KEY is set")
+	})
+
+	t.Run("Allowed CIDRs", func(t *testing.T) {
+		t.Setenv("TEST_RELAY_TOKEN", "relay-token")
+		e := newServer(t, config.WebhookAuthConfig{AllowedCIDRs: []string{"10.0.0.0/8", "192.0.2.7", "fd00::/8"}})
+		token := map[string]string{"Authorization": "Bearer relay-token"}
+		require.Equal(t, http.StatusOK, post(e, "10.1.2.3:1234", token))
+		require.Equal(t, http.StatusOK, post(e, "192.0.2.7:1234", token))
+		require.Equal(t, http.StatusOK, post(e, "[fd00::1]:1234", token))
+		require.Equal(t, http.StatusOK, post(e, "[::ffff:10.1.2.3]:1234", token))
+		require.Equal(t, http.StatusForbidden, post(e, "192.0.2.8:1234", token))
+		// Forwarded addresses are not trusted.
+		require.Equal(t, http.StatusForbidden, post(e, "192.0.2.8:1234", map[string]string{
+			"Authorization": "Bearer relay-token", echo.HeaderXForwardedFor: "10.1.2.3",
+		}))
+	})
+
+	t.Run("Invalid allowed CIDR", func(t *testing.T) {
+		t.Setenv("TEST_RELAY_TOKEN", "relay-token")
+		_, err := newWebhookAuthenticator(config.WebhookAuthConfig{AllowedCIDRs: []string{"10.0.0.0/33"}}, "TEST_RELAY_TOKEN")
+		require.Error(t, err)
+	})
+
+	t.Run("Token or signature", func(t *testing.T) {
+		t.Setenv("TEST_RELAY_TOKEN", "relay-token")
+		t.Setenv("WEBHOOK_SIGNING_KEY", "signing-key")
+		e := newServer(t, config.WebhookAuthConfig{})
+
+		require.Equal(t, http.StatusUnauthorized, post(e, "192.0.2.1:1234", nil))
+		require.Equal(t, http.StatusOK, post(e, "192.0.2.1:1234", map[string]string{"Authorization": "Bearer relay-token"}))
+		require.Equal(t, http.StatusUnauthorized, post(e, "192.0.2.1:1234", map[string]string{"Authorization": "Bearer other-token"}))
+
+		require.Equal(t, http.StatusOK, post(e, "192.0.2.1:1234", signed("signing-key", now.Add(-time.Minute))))
+		require.Equal(t, http.StatusUnauthorized, post(e, "192.0.2.1:1234", signed("other-key", now)))
+		// Signed callbacks cannot be replayed past the tolerance.
+		require.Equal(t, http.StatusUnauthorized, post(e, "192.0.2.1:1234", signed("signing-key", now.Add(-10*time.Minute))))
+
+		header := signed("signing-key", now)
+		header[webhookSignatureHeader] = strings.TrimPrefix(header[webhookSignatureHeader], webhookSignaturePrefix)
+		require.Equal(t, http.StatusUnauthorized, post(e, "192.0.2.1:1234", header))
+	})
+
+	t.Run("Signature only", func(t *testing.T) {
+		t.Setenv("WEBHOOK_SIGNING_KEY", "signing-key")
+		e := newServer(t, config.WebhookAuthConfig{SignatureTolerance: 30 * time.Minute})
+
+		require.Equal(t, http.StatusUnauthorized, post(e, "192.0.2.1:1234", nil))
+		require.Equal(t, http.StatusOK, post(e, "192.0.2.1:1234", signed("signing-key", now.Add(-10*time.Minute))))
+	})
+}
@@ -4,6 +4,7 @@
 package clock
 
 import (
+	"sync/atomic"
 	"time"
 
 	"github.com/jmhodges/clock"
@@ -28,6 +29,37 @@ func UnsetFakeClock() {
 	TimeNowFn = time.Now
 }
 
+// travelled is the duration the clock has travelled by in time travel mode.
+var travelled atomic.Int64
+
+// EnableTimeTravel makes TimeNowFn return the current local time shifted by the duration travelled, so that time-sensitive
+// code, such as retention and timeouts, can be exercised without waiting. It is only meant for debug builds.
+func EnableTimeTravel() {
+	TimeNowFn = func() time.Time {
+		return time.Now().Add(Travelled())
+	}
+}
+
+// Travel moves the clock by the given duration in time travel mode, backwards if negative.
+func Travel(d time.Duration) {
+	travelled.Add(int64(d))
+}
+
+// TravelTo moves the clock to the given time in time travel mode.
+func TravelTo(t time.Time) {
+	travelled.Store(int64(time.Until(t)))
+}
+
+// Travelled returns the duration the clock has travelled by in time travel mode.
+func Travelled() time.Duration {
+	return time.Duration(travelled.Load())
+}
+
+// ResetTravel brings the clock back to the current local time in time travel mode.
+func ResetTravel() {
+	travelled.Store(0)
+}
+
 func init() {
 	TimeNowFn = time.Now
 	FakeClock = clock.NewFake()
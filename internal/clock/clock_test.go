@@ -0,0 +1,30 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeTravel(t *testing.T) {
+	EnableTimeTravel()
+	defer UnsetFakeClock()
+	defer ResetTravel()
+
+	Travel(2 * time.Hour)
+	Travel(-30 * time.Minute)
+	require.Equal(t, 90*time.Minute, Travelled())
+	require.WithinDuration(t, time.Now().Add(90*time.Minute), TimeNowFn(), time.Second)
+
+	target := time.Now().Add(-24 * time.Hour)
+	TravelTo(target)
+	require.WithinDuration(t, target, TimeNowFn(), time.Second)
+
+	ResetTravel()
+	require.Zero(t, Travelled())
+	require.WithinDuration(t, time.Now(), TimeNowFn(), time.Second)
+}
@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package clock
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// clockSkew is the skew of the local clock against the clock of each source, as last measured.
+var clockSkew = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "alerting_monitor_clock_skew_seconds",
+	Help: "Skew of the local clock against the clock of a source, positive if the source is ahead, as last measured.",
+}, []string{"source"})
+
+// SkewSource is a clock the local clock is compared against, such as the clock of the database or of alertmanager.
+type SkewSource struct {
+	// Name is the name of the source, as reported by logs and metrics.
+	Name string
+	// Now returns the current time of the source.
+	Now func(ctx context.Context) (time.Time, error)
+}
+
+// SkewDetector detects the drift of the local clock from the clocks of its sources, which breaks the timeouts of tasks and the
+// retention of records computed from both. A warning is logged when the skew against a source exceeds the threshold.
+type SkewDetector struct {
+	threshold time.Duration
+	sources   []SkewSource
+}
+
+// NewSkewDetector returns a skew detector warning about skews exceeding the given threshold.
+func NewSkewDetector(threshold time.Duration, sources ...SkewSource) *SkewDetector {
+	return &SkewDetector{
+		threshold: threshold,
+		sources:   sources,
+	}
+}
+
+// Run checks the skew against the sources every interval, until the context is done.
+func (d *SkewDetector) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		d.Check(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check measures the skew of the local clock against each source, sets the skew metric and logs a warning for skews exceeding
+// the threshold. The skews are returned by source name, leaving out the sources whose time cannot be retrieved.
+func (d *SkewDetector) Check(ctx context.Context) map[string]time.Duration {
+	skews := make(map[string]time.Duration, len(d.sources))
+	for _, source := range d.sources {
+		skew, uncertainty, err := measureSkew(ctx, source)
+		if err != nil {
+			slog.Error("Failed to get time to check clock skew", slog.String("source", source.Name), slog.Any("error", err))
+			continue
+		}
+		skews[source.Name] = skew
+		clockSkew.WithLabelValues(source.Name).Set(skew.Seconds())
+
+		if skew.Abs()-uncertainty > d.threshold {
+			slog.LogAttrs(ctx, slog.LevelWarn, "Clock skew exceeds threshold",
+				slog.String("source", source.Name),
+				slog.Duration("skew", skew),
+				slog.Duration("threshold", d.threshold),
+				slog.String("component", "alerting-monitor"),
+			)
+		}
+	}
+	return skews
+}
+
+// measureSkew returns the skew of the local clock against the given source, taking the local time halfway through the round
+// trip to the source. Half of the round trip is returned as the uncertainty of the skew.
+func measureSkew(ctx context.Context, source SkewSource) (time.Duration, time.Duration, error) {
+	before := TimeNowFn()
+	remote, err := source.Now(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	rtt := TimeNowFn().Sub(before)
+	return remote.Sub(before.Add(rtt / 2)), rtt / 2, nil
+}
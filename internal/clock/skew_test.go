@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package clock
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSkewDetector(t *testing.T) {
+	SetFakeClock()
+	defer UnsetFakeClock()
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	FakeClock.Set(now)
+
+	source := func(name string, skew time.Duration) SkewSource {
+		return SkewSource{Name: name, Now: func(context.Context) (time.Time, error) {
+			return now.Add(skew), nil
+		}}
+	}
+
+	detector := NewSkewDetector(5*time.Second,
+		source("database", 2*time.Second),
+		source("alertmanager", -time.Minute),
+		SkewSource{Name: "unreachable", Now: func(context.Context) (time.Time, error) {
+			return time.Time{}, errors.New("mock error")
+		}},
+	)
+
+	skews := detector.Check(context.Background())
+	require.Equal(t, map[string]time.Duration{
+		"database":     2 * time.Second,
+		"alertmanager": -time.Minute,
+	}, skews)
+	require.InDelta(t, 2, promtestutil.ToFloat64(clockSkew.WithLabelValues("database")), 0)
+	require.InDelta(t, -60, promtestutil.ToFloat64(clockSkew.WithLabelValues("alertmanager")), 0)
+}
+
+func TestMeasureSkew(t *testing.T) {
+	SetFakeClock()
+	defer UnsetFakeClock()
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	FakeClock.Set(now)
+
+	// The source answers after a round trip of 2 seconds, its time being taken halfway through.
+	skew, uncertainty, err := measureSkew(context.Background(), SkewSource{Name: "slow", Now: func(context.Context) (time.Time, error) {
+		FakeClock.Add(2 * time.Second)
+		return now.Add(10 * time.Second), nil
+	}})
+	require.NoError(t, err)
+	require.Equal(t, 9*time.Second, skew)
+	require.Equal(t, time.Second, uncertainty)
+}
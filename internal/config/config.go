@@ -6,6 +6,8 @@ package config
 import (
 	"fmt"
 	"os"
+	"regexp"
+	"slices"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -16,11 +18,177 @@ type AlertManagerConfig struct {
 	RequireTLS         bool   `yaml:"requireTLS"`
 	InsecureSkipVerify bool   `yaml:"insecureSkipVerify"`
 	Namespace          string `yaml:"namespace"`
+	// MaxRoutes is the maximum number of routes of the alertmanager configuration. There is no limit if zero.
+	MaxRoutes int `yaml:"maxRoutes"`
+	// MaxRecipientsPerRoute is the maximum number of email recipients notified by a single route. There is no limit if zero.
+	MaxRecipientsPerRoute int `yaml:"maxRecipientsPerRoute"`
+	// SecretName is the name of the secret holding the alertmanager configuration.
+	SecretName string `yaml:"secretName"`
+	// Shards are the additional alertmanager instances tenants are sharded across. The instance above is the first shard.
+	Shards []AlertManagerShardConfig `yaml:"shards"`
+	// ApplyRetry defines how transient Kubernetes API errors are retried when applying the alertmanager configuration.
+	ApplyRetry RetryConfig `yaml:"applyRetry"`
+	// OnCallRelayURL is the base URL of alerting monitor alertmanager sends alerts to, to be relayed to Grafana OnCall.
+	// Alerts are not relayed if empty.
+	OnCallRelayURL string `yaml:"onCallRelayURL"`
+	// SigningRelayHost is the host:port of the email signing relay of alerting monitor alertmanager sends emails through, to
+	// be signed before being sent to the mail server. Emails are sent to the mail server directly if empty.
+	SigningRelayHost string `yaml:"signingRelayHost"`
+	// EmailRelayURL is the base URL of alerting monitor alertmanager sends the notifications of receivers to, for alerting
+	// monitor to send their emails instead of alertmanager. Emails are sent by alertmanager if empty.
+	EmailRelayURL string `yaml:"emailRelayURL"`
+	// EmailLanguages are the languages of the localized email templates of the deployment receivers may select, each defining
+	// the "alert.monitor.mail.<language>" template along with the default "alert.monitor.mail" template.
+	EmailLanguages []string `yaml:"emailLanguages"`
+	// ReloadTimeout is how long to wait for alertmanager to reload an applied configuration. The configuration last reloaded
+	// successfully is restored if it is not reloaded in time. Reloads are not awaited if zero.
+	ReloadTimeout time.Duration `yaml:"reloadTimeout"`
+	// Staging is the alertmanager instance configurations are validated against, by waiting for it to reload them, before
+	// being applied. Configurations are not validated if its URL is empty.
+	Staging AlertManagerShardConfig `yaml:"staging"`
+	// Backend is the notification backend receivers are applied to, "alertmanager", the default, or "grafana" for Grafana
+	// Alerting, provisioned through the API of the Grafana instance given by Grafana. Shards, staging and reloads only apply
+	// to alertmanager.
+	Backend string `yaml:"backend"`
+	// Grafana is the Grafana instance receivers are provisioned in by the grafana backend.
+	Grafana GrafanaAlertingConfig `yaml:"grafana"`
+}
+
+// GrafanaAlertingConfig defines the Grafana instance whose alerting is provisioned with receivers, as contact points, notification
+// policies and mute timings.
+type GrafanaAlertingConfig struct {
+	URL string `yaml:"url"`
+}
+
+// RetryConfig defines how transient errors are retried with an exponential backoff and jitter.
+type RetryConfig struct {
+	// MaxRetries is the number of retries after the first attempt. Errors are not retried if zero.
+	MaxRetries int `yaml:"maxRetries"`
+	// InitialBackoff is the wait before the first retry, which is doubled for each subsequent retry.
+	InitialBackoff time.Duration `yaml:"initialBackoff"`
+	// MaxBackoff caps the wait between retries. The wait is not capped if zero.
+	MaxBackoff time.Duration `yaml:"maxBackoff"`
+}
+
+// AlertManagerShardConfig defines an alertmanager instance serving a shard of the tenants.
+type AlertManagerShardConfig struct {
+	URL        string `yaml:"url"`
+	Namespace  string `yaml:"namespace"`
+	SecretName string `yaml:"secretName"`
+}
+
+// ShardCount returns the number of alertmanager instances tenants are sharded across.
+func (c AlertManagerConfig) ShardCount() int {
+	return len(c.Shards) + 1
+}
+
+// Shard returns the configuration of the alertmanager instance serving the given shard. Limits and TLS settings are shared
+// by all shards.
+func (c AlertManagerConfig) Shard(shard int) (AlertManagerConfig, error) {
+	if shard < 0 || shard >= c.ShardCount() {
+		return AlertManagerConfig{}, fmt.Errorf("alertmanager shard %d is not configured", shard)
+	}
+
+	conf := c
+	conf.Shards = nil
+	if shard > 0 {
+		s := c.Shards[shard-1]
+		conf.URL = s.URL
+		conf.Namespace = s.Namespace
+		conf.SecretName = s.SecretName
+	}
+	return conf, nil
 }
 
 type MimirConfig struct {
+	// Backend is the ruler the rule groups of alert definitions are stored in, one of "mimir", the default, "cortex",
+	// "thanos" and "prometheus". Thanos Ruler and Prometheus load them from rule files written to RulesDir, and are told to
+	// reload them through their lifecycle API at RulerURL.
+	Backend string `yaml:"backend"`
+	// RulesDir is the directory rule files are written to, for the backends loading rule groups from rule files.
+	RulesDir  string `yaml:"rulesDir"`
 	Namespace string `yaml:"namespace"`
 	RulerURL  string `yaml:"rulerURL"`
+	// QueryURL is the URL of the Mimir query API, used to compute the statistics of alerting metrics and to evaluate the
+	// expressions of alert definitions on demand.
+	QueryURL string `yaml:"queryURL"`
+	// ExpressionCost defines the limits on the estimated cost of the expressions of alert definitions evaluated by the ruler.
+	ExpressionCost ExpressionCostConfig `yaml:"expressionCost"`
+}
+
+// ExpressionCostConfig defines the limits on the statically estimated cost of the expressions of alert definitions, per tier
+// of tenants. Expressions are not checked if no tier is configured.
+type ExpressionCostConfig struct {
+	// Enforce tells whether expressions exceeding the limits are rejected. They are only warned about otherwise.
+	Enforce bool `yaml:"enforce"`
+	// DefaultTier is the tier of the tenants not listed in TenantTiers.
+	DefaultTier string `yaml:"defaultTier"`
+	// TenantTiers maps tenants to their tier.
+	TenantTiers map[string]string `yaml:"tenantTiers"`
+	// Tiers maps tiers to their limits.
+	Tiers map[string]ExpressionCostLimits `yaml:"tiers"`
+}
+
+// ExpressionCostLimits are the limits on the estimated cost of an expression. A limit is not checked if zero.
+type ExpressionCostLimits struct {
+	// MaxSelectors is the maximum number of series selectors of an expression.
+	MaxSelectors int `yaml:"maxSelectors"`
+	// MaxRange is the maximum range of data an expression looks back over, including the ranges of enclosing subqueries.
+	MaxRange time.Duration `yaml:"maxRange"`
+	// MaxRegexMatchers is the maximum number of regular expression label matchers of an expression.
+	MaxRegexMatchers int `yaml:"maxRegexMatchers"`
+}
+
+// TenantLimits returns the expression cost limits of the tier of the given tenant, and whether the tier has limits.
+func (c ExpressionCostConfig) TenantLimits(tenant string) (ExpressionCostLimits, bool) {
+	tier, ok := c.TenantTiers[tenant]
+	if !ok {
+		tier = c.DefaultTier
+	}
+	limits, ok := c.Tiers[tier]
+	return limits, ok
+}
+
+// Notification channels of receivers a tier can be allowed to notify through.
+const (
+	ChannelEmail  = "email"
+	ChannelOnCall = "oncall"
+)
+
+// TenantTiersConfig defines the service levels tenants are entitled to, per tier. Tiers are assigned to tenants through the
+// admin API and stored in the database.
+type TenantTiersConfig struct {
+	// DefaultTier is the tier of the tenants without an assigned tier. They are not limited if it is not configured.
+	DefaultTier string                `yaml:"defaultTier"`
+	Tiers       map[string]TierConfig `yaml:"tiers"`
+}
+
+// TierConfig defines the service level of a tier of tenants. A limit is not enforced if zero or empty.
+type TierConfig struct {
+	// MaxEmailRecipients is the maximum number of email recipients of a receiver.
+	MaxEmailRecipients int `yaml:"maxEmailRecipients"`
+	// MinEvaluationInterval is the minimum interval between evaluations of the rules of alert definitions. Shorter intervals
+	// of alert definitions are raised to it.
+	MinEvaluationInterval time.Duration `yaml:"minEvaluationInterval"`
+	// Channels are the notification channels receivers are allowed to notify through, out of "email" and "oncall".
+	Channels []string `yaml:"channels"`
+	// RequestsPerSecond is the sustained rate of API requests of a tenant, with bursts of up to Burst requests.
+	RequestsPerSecond float64 `yaml:"requestsPerSecond"`
+	Burst             int     `yaml:"burst"`
+}
+
+// Tier returns the configuration of the given tier, or of the default tier if empty, and whether it is configured.
+func (c TenantTiersConfig) Tier(tier string) (TierConfig, bool) {
+	if tier == "" {
+		tier = c.DefaultTier
+	}
+	conf, ok := c.Tiers[tier]
+	return conf, ok
+}
+
+// AllowsChannel tells whether receivers of the tier are allowed to notify through the given channel.
+func (c TierConfig) AllowsChannel(channel string) bool {
+	return len(c.Channels) == 0 || slices.Contains(c.Channels, channel)
 }
 
 type VaultConfig struct {
@@ -35,6 +203,421 @@ type TaskExecutorConfig struct {
 	TaskTimeout   time.Duration `yaml:"taskTimeout"`
 	RetentionTime time.Duration `yaml:"retentionTime"`
 	PoolingRate   time.Duration `yaml:"dbPoolingRate"`
+	// TenantLimit is the maximum number of UUIDs of a tenant whose tasks are taken at once, out of UUIDLimit. UUIDs are taken
+	// round-robin across tenants, so that a tenant with many pending tasks does not starve the others. No limit if zero.
+	TenantLimit int `yaml:"tenantLimit"`
+	// CleanupBatchSize is the number of tasks past retention deleted at once. The default batch size is used if zero.
+	CleanupBatchSize int `yaml:"cleanupBatchSize"`
+	// CleanupBatchPause is the pause between the batches of tasks deleted, so that the tasks table is not locked for long.
+	CleanupBatchPause time.Duration `yaml:"cleanupBatchPause"`
+	// HeartbeatTimeout is the time after which an executor without a heartbeat is considered dead, its Taken tasks being
+	// reclaimed by the next executor to start. Heartbeats are disabled if zero.
+	HeartbeatTimeout time.Duration `yaml:"heartbeatTimeout"`
+	// HeartbeatInterval is the interval at which executors send a heartbeat, a third of HeartbeatTimeout if zero. It must be
+	// at most a third of HeartbeatTimeout, so that a late heartbeat does not get a live executor considered dead.
+	HeartbeatInterval time.Duration `yaml:"heartbeatInterval"`
+	// PausedKinds are the kinds of tasks not taken by executors, out of "AlertDefinition", "Receiver" and "Notification". Tasks
+	// of a paused kind stay pending until it is resumed, such as alert definitions during an upgrade of Mimir.
+	PausedKinds []string `yaml:"pausedKinds"`
+}
+
+// minHeartbeatsPerTimeout is the minimum number of heartbeats an executor sends within the heartbeat timeout.
+const minHeartbeatsPerTimeout = 3
+
+// Heartbeat returns the interval at which executors send a heartbeat.
+func (c TaskExecutorConfig) Heartbeat() time.Duration {
+	if c.HeartbeatInterval > 0 {
+		return c.HeartbeatInterval
+	}
+	return c.HeartbeatTimeout / minHeartbeatsPerTimeout
+}
+
+// Validate checks that the heartbeat timeout is far above the heartbeat interval, so that a live executor is not considered dead.
+func (c TaskExecutorConfig) Validate() error {
+	if c.HeartbeatTimeout <= 0 {
+		return nil
+	}
+	if interval := c.Heartbeat(); interval <= 0 || c.HeartbeatTimeout < minHeartbeatsPerTimeout*interval {
+		return fmt.Errorf("heartbeat timeout %v of task executors must be at least %d times their heartbeat interval %v",
+			c.HeartbeatTimeout, minHeartbeatsPerTimeout, interval)
+	}
+	return nil
+}
+
+// KindPaused tells whether the tasks of the given kind are paused.
+func (c TaskExecutorConfig) KindPaused(kind string) bool {
+	return slices.Contains(c.PausedKinds, kind)
+}
+
+// RedactionConfig defines how labels and annotations of alerts are redacted before being returned to tenants.
+type RedactionConfig struct {
+	// AllowedLabels is the list of label names returned to tenants. If empty, all labels are allowed.
+	AllowedLabels []string `yaml:"allowedLabels"`
+	// Labels and Annotations are regular expressions matching the names of labels and annotations to remove.
+	Labels      []string `yaml:"labels"`
+	Annotations []string `yaml:"annotations"`
+	// Values are regular expressions matching the parts of label and annotation values to mask.
+	Values []string `yaml:"values"`
+}
+
+// RedactionPatterns holds the compiled patterns of a RedactionConfig, so that they are compiled once rather than on every request.
+// The patterns of the configuration are compiled by LoadConfig, which fails on an invalid pattern.
+type RedactionPatterns struct {
+	AllowedLabels []string
+	// Labels and Annotations match whole label and annotation names.
+	Labels      []*regexp.Regexp
+	Annotations []*regexp.Regexp
+	Values      []*regexp.Regexp
+}
+
+// Compile compiles the patterns of the redaction configuration, failing on the first invalid one.
+func (c RedactionConfig) Compile() (*RedactionPatterns, error) {
+	labels, err := compileNamePatterns(c.Labels)
+	if err != nil {
+		return nil, fmt.Errorf("invalid label redaction pattern: %w", err)
+	}
+
+	annotations, err := compileNamePatterns(c.Annotations)
+	if err != nil {
+		return nil, fmt.Errorf("invalid annotation redaction pattern: %w", err)
+	}
+
+	values := make([]*regexp.Regexp, len(c.Values))
+	for i, p := range c.Values {
+		if values[i], err = regexp.Compile(p); err != nil {
+			return nil, fmt.Errorf("invalid value redaction pattern: %w", err)
+		}
+	}
+
+	return &RedactionPatterns{
+		AllowedLabels: c.AllowedLabels,
+		Labels:        labels,
+		Annotations:   annotations,
+		Values:        values,
+	}, nil
+}
+
+// compileNamePatterns compiles the given patterns so that they match whole label or annotation names.
+func compileNamePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	res := make([]*regexp.Regexp, len(patterns))
+	for i, p := range patterns {
+		re, err := regexp.Compile(fmt.Sprintf("^(?:%s)$", p))
+		if err != nil {
+			return nil, err
+		}
+		res[i] = re
+	}
+	return res, nil
+}
+
+// TenantArchivalConfig defines when the configuration of tenants without API activity and active alerts is archived.
+type TenantArchivalConfig struct {
+	// InactivityPeriod is the period without API activity after which a tenant is archived. Archival is disabled if zero.
+	InactivityPeriod time.Duration `yaml:"inactivityPeriod"`
+	// CheckInterval is the interval between checks for inactive tenants.
+	CheckInterval time.Duration `yaml:"checkInterval"`
+}
+
+// ThresholdAutoTuneConfig defines how the thresholds of alert definitions with auto-tuning turned on are recomputed.
+type ThresholdAutoTuneConfig struct {
+	// CheckInterval is the interval between recomputations of the thresholds. Auto-tuning is disabled if zero.
+	CheckInterval time.Duration `yaml:"checkInterval"`
+	// Lookback is the period of metric history the statistics are computed over.
+	Lookback time.Duration `yaml:"lookback"`
+	// Quantile is the quantile of the metric over the lookback period the threshold is based on.
+	Quantile float64 `yaml:"quantile"`
+	// Margin is the fraction added on top of the quantile, e.g. 0.1 sets the threshold 10% above it.
+	Margin float64 `yaml:"margin"`
+}
+
+// OnCallConfig defines the Grafana OnCall instance alerts of receivers with a routing key are relayed to.
+type OnCallConfig struct {
+	// URL is the base URL of the Grafana OnCall formatted webhook integrations, the routing key of a receiver is appended to it.
+	URL string `yaml:"url"`
+	// Timeout is the timeout of requests relaying alerts to Grafana OnCall.
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// ControllerConfig defines how the AlertDefinition and AlertReceiver custom resources are reconciled.
+type ControllerConfig struct {
+	// ResyncInterval is the interval between reconciliations of the custom resources. The controller is disabled if zero.
+	ResyncInterval time.Duration `yaml:"resyncInterval"`
+	// Namespace is the namespace the custom resources are read from.
+	Namespace string `yaml:"namespace"`
+}
+
+// ProfilingConfig defines whether the debug endpoints of the server, serving pprof profiles and runtime diagnostics, are exposed.
+type ProfilingConfig struct {
+	Enabled bool `yaml:"enabled"`
+}
+
+// MetricsConfig defines the internal listener the Prometheus metrics are served on, apart from the API, since their labels hold
+// the IDs of tenants.
+type MetricsConfig struct {
+	// ListenAddress is the address the metrics are served on. Metrics are not served if empty.
+	ListenAddress string `yaml:"listenAddress"`
+}
+
+// EmailSigningConfig defines the SMTP relay which signs the emails sent by alertmanager with S/MIME before sending them to
+// the mail server.
+type EmailSigningConfig struct {
+	// ListenAddress is the address the relay listens on. The relay is disabled if empty.
+	ListenAddress string `yaml:"listenAddress"`
+	// CertificateFile is the path of the PEM encoded signing certificate, optionally followed by its intermediate certificates.
+	CertificateFile string `yaml:"certificateFile"`
+	// KeyFile is the path of the PEM encoded private key of the signing certificate.
+	KeyFile string `yaml:"keyFile"`
+	// Timeout is the timeout of a relayed SMTP session, both with alertmanager and with the mail server.
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// EmailRelayConfig defines how alerting monitor sends the emails of the notifications alertmanager relays to it.
+type EmailRelayConfig struct {
+	// Enabled tells whether the endpoint alertmanager relays notifications to is served.
+	Enabled bool `yaml:"enabled"`
+	// TemplateFiles is the pattern of the alertmanager template files emails are rendered with.
+	TemplateFiles string `yaml:"templateFiles"`
+	// Timeout is the timeout of sending an email to a single recipient.
+	Timeout time.Duration `yaml:"timeout"`
+	// Retry defines how transient errors sending an email to a recipient are retried.
+	Retry RetryConfig `yaml:"retry"`
+	// DeliveryRetention is the period the outcome of sending emails to recipients is kept for.
+	DeliveryRetention time.Duration `yaml:"deliveryRetention"`
+}
+
+// EmailVerificationConfig defines the verification of the email addresses of new recipients of receivers, which are not
+// notified until they confirm their address with the link of the verification email sent to them. The key verification
+// links are signed with is given by the EMAIL_VERIFICATION_KEY environment variable.
+type EmailVerificationConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// URL is the external URL of the verification endpoint, /api/v1/verify, the links of verification emails point to.
+	URL string `yaml:"url"`
+	// LinkTTL is the period verification links are valid for. A recipient still pending verification is sent a new link,
+	// when added to a receiver again, once the previous one expired.
+	LinkTTL time.Duration `yaml:"linkTTL"`
+	// Timeout is the timeout of sending a verification email.
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// TenantMetadataConfig defines the orchestrator endpoint the metadata of tenants, such as the display name of their project,
+// is fetched from, to annotate their alerts with it.
+type TenantMetadataConfig struct {
+	// URL is the base URL of the endpoint, the tenant ID is appended to it. Alerts are not annotated if empty.
+	URL string `yaml:"url"`
+	// Timeout is the timeout of requests fetching the metadata of a tenant.
+	Timeout time.Duration `yaml:"timeout"`
+	// CacheTTL is the period the metadata of a tenant is cached for.
+	CacheTTL time.Duration `yaml:"cacheTTL"`
+}
+
+// SnapshotConfig defines how the alerting configuration of all tenants is periodically snapshotted to an S3-compatible object
+// store for disaster recovery. The access key of the object store is given by the SNAPSHOT_ACCESS_KEY_ID and
+// SNAPSHOT_SECRET_ACCESS_KEY environment variables.
+type SnapshotConfig struct {
+	// Interval is the interval between snapshots. Snapshots are not taken periodically if zero.
+	Interval time.Duration `yaml:"interval"`
+	// Endpoint is the URL of the object store, snapshots are stored at Endpoint/Bucket/Prefix.
+	Endpoint string `yaml:"endpoint"`
+	Bucket   string `yaml:"bucket"`
+	Prefix   string `yaml:"prefix"`
+	Region   string `yaml:"region"`
+	// Timeout is the timeout of requests to the object store.
+	Timeout time.Duration `yaml:"timeout"`
+	// TrustedKeys is the pattern of the PEM-encoded public keys snapshots must be signed with to be restored. The detached
+	// signature of a snapshot, as written by cosign sign-blob, is stored under its key suffixed with .sig. Snapshots are
+	// restored without being verified if empty.
+	TrustedKeys string `yaml:"trustedKeys"`
+}
+
+// RuleEvaluationConfig defines how the evaluation of the rule groups of alert definitions by Mimir ruler is monitored.
+type RuleEvaluationConfig struct {
+	// ScrapeInterval is the interval between scrapes of the last evaluation of the rule groups from Mimir ruler. Evaluations
+	// are not monitored if zero.
+	ScrapeInterval time.Duration `yaml:"scrapeInterval"`
+	// Window is the rolling window the evaluation health of alert definitions is reported over.
+	Window time.Duration `yaml:"window"`
+	// SlowThreshold is the evaluation duration above which the expression of an alert definition is reported as too expensive.
+	// Evaluations are not reported as slow if zero.
+	SlowThreshold time.Duration `yaml:"slowThreshold"`
+}
+
+// ReportsConfig defines the weekly reports of the alert volume of tenants, summarizing the notifications sent to the recipients
+// of their receivers and how fast their alerts were acknowledged. Notifications are only counted when emails are sent by
+// alerting monitor, the email relay recording their delivery for the retention period.
+type ReportsConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// CheckInterval is the interval between checks for tenants whose report of the last week is not generated yet.
+	CheckInterval time.Duration `yaml:"checkInterval"`
+	// TopAlerts is the number of noisiest alert groups of reports.
+	TopAlerts int `yaml:"topAlerts"`
+	// Email tells whether reports are emailed to the recipients of the receivers of their tenant once generated.
+	Email bool `yaml:"email"`
+	// Timeout is the timeout of sending a report to a single recipient.
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// APIDocsConfig defines whether the OpenAPI document of the API and its interactive documentation are served, so that
+// integrators can discover the API from a running deployment.
+type APIDocsConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// SwaggerUIURL is the URL of a Swagger UI distribution the documentation page loads its scripts and styles from instead of
+	// the assets of Swagger UI embedded in alerting monitor, which are served if empty.
+	SwaggerUIURL string `yaml:"swaggerUIURL"`
+}
+
+// APIVersioningConfig defines the deprecation of the versions of the API, which is announced to clients by the headers of the
+// responses of deprecated versions.
+type APIVersioningConfig struct {
+	// Deprecations holds the deprecation of versions of the API, by version, such as v1.
+	Deprecations map[string]APIDeprecationConfig `yaml:"deprecations"`
+}
+
+// APIDeprecationConfig defines the deprecation of a version of the API.
+type APIDeprecationConfig struct {
+	// Date is the time the version is deprecated from, returned in the Deprecation header.
+	Date time.Time `yaml:"date"`
+	// Sunset is the time the version is removed at, returned in the Sunset header. It is not returned if zero.
+	Sunset time.Time `yaml:"sunset"`
+	// Link is the URL of the documentation of the migration to another version, returned in a Link header. It is not returned
+	// if empty.
+	Link string `yaml:"link"`
+}
+
+// HistoryRetentionConfig defines the tiered retention of the task history: the summaries of completed tasks are kept for
+// RawRetention, after which they are compacted into daily rollups per alert definition and receiver, kept for RollupRetention,
+// so that long-term trends of the changes applied remain available while the task history stays bounded.
+type HistoryRetentionConfig struct {
+	// CompactionInterval is the interval between compactions of the task history. The task history is not compacted if zero.
+	CompactionInterval time.Duration `yaml:"compactionInterval"`
+	// RawRetention is the time the summaries of completed tasks are kept for before being compacted into daily rollups.
+	RawRetention time.Duration `yaml:"rawRetention"`
+	// RollupRetention is the time daily rollups are kept for. They are not deleted if zero.
+	RollupRetention time.Duration `yaml:"rollupRetention"`
+	// BatchSize is the number of summaries compacted per transaction.
+	BatchSize int `yaml:"batchSize"`
+}
+
+// WebhookAuthConfig defines how the alertmanager webhook callbacks of the Grafana OnCall and email relays are authenticated,
+// so that forged alerts are neither relayed nor recorded. Callbacks are authenticated by either the relay token of the relay,
+// or an HMAC-SHA256 signature keyed by the WEBHOOK_SIGNING_KEY environment variable, if any of them is set.
+type WebhookAuthConfig struct {
+	// AllowedCIDRs lists the networks callbacks are accepted from, matched against the address of the peer of the connection.
+	// Callbacks are accepted from any address if empty.
+	AllowedCIDRs []string `yaml:"allowedCIDRs"`
+	// SignatureTolerance is the maximum difference between the timestamp of a signed callback and the current time, so that
+	// signed callbacks cannot be replayed later on.
+	SignatureTolerance time.Duration `yaml:"signatureTolerance"`
+}
+
+// NotificationQueueConfig defines the persistent queue of the notifications sent by alerting monitor on behalf of alertmanager,
+// through the email and Grafana OnCall relays, so that notifications are not lost when their delivery keeps failing during an
+// outage of the downstream service. Failed notifications are queued as tasks of the "Notification" kind, retried with backoff,
+// and kept as dead letters once their retries are exhausted, until they are redriven or past retention.
+type NotificationQueueConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// PollInterval is the interval between polls for notifications due for delivery.
+	PollInterval time.Duration `yaml:"pollInterval"`
+	// BatchSize is the number of notifications delivered per poll.
+	BatchSize int `yaml:"batchSize"`
+	// Retry defines the retries of queued notifications, a notification becoming a dead letter once they are exhausted.
+	Retry RetryConfig `yaml:"retry"`
+	// Retention is the time delivered notifications and dead letters are kept for.
+	Retention time.Duration `yaml:"retention"`
+}
+
+// EventHooksConfig defines the callback URLs tenants register under /api/v1/hooks, which are called when their alert definitions
+// and receivers are applied or fail to be applied, so that external automation can react to it without polling their state.
+// Events are delivered through the notification queue, which must be enabled, and signed like the callbacks of alertmanager
+// with a secret derived per hook from the key given by the EVENT_HOOK_SIGNING_KEY environment variable.
+type EventHooksConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// MaxHooks is the maximum number of hooks of a tenant. It is not limited if zero.
+	MaxHooks int `yaml:"maxHooks"`
+	// Timeout is the timeout of a call of a callback URL.
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// ArtifactEncryptionConfig defines the encryption at rest of the content of the applied artifacts, the rendered alertmanager
+// manifests and Mimir rule groups kept in the database, so that they cannot be read from a dump of the database. Content is
+// encrypted with AES-256-GCM, with a key derived per tenant from a key of the key ring given by the ARTIFACT_ENCRYPTION_KEYS
+// environment variable, a comma-separated list of key IDs and base64-encoded keys of at least 32 bytes, as in "id=key".
+type ArtifactEncryptionConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// ActiveKeyID is the ID of the key of the key ring new artifacts are encrypted with. Artifacts encrypted with another key
+	// of the key ring, or stored in plain text, are re-encrypted with it every rotation interval, so that a key can be rotated
+	// by adding a new key to the key ring and making it active, and removed once no artifact is encrypted with it anymore.
+	ActiveKeyID string `yaml:"activeKeyId"`
+	// RotationInterval is the interval between re-encryptions of the artifacts not encrypted with the active key.
+	RotationInterval time.Duration `yaml:"rotationInterval"`
+	// BatchSize is the number of artifacts re-encrypted per transaction.
+	BatchSize int `yaml:"batchSize"`
+}
+
+// CertExpiryConfig defines the monitoring of the expiry of the TLS certificates of the downstream endpoints: the alertmanager
+// instances and Mimir APIs served over HTTPS, and the mail server given by the SMART_HOST and SMART_PORT environment variables.
+// Alerts are raised to the default tenant as certificates approach their expiry, since expired certificates silently break
+// the delivery of notifications.
+type CertExpiryConfig struct {
+	// CheckInterval is the interval between checks of the certificates. Certificates are not checked if zero.
+	CheckInterval time.Duration `yaml:"checkInterval"`
+	// WarningThreshold is the time before the expiry of a certificate from which a warning alert is raised.
+	WarningThreshold time.Duration `yaml:"warningThreshold"`
+	// CriticalThreshold is the time before the expiry of a certificate from which a critical alert is raised, and the status
+	// of the service is degraded.
+	CriticalThreshold time.Duration `yaml:"criticalThreshold"`
+	// Timeout is the timeout of the TLS handshake with each endpoint.
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// NetworkConfig defines the connections to the downstream services, alertmanager, Mimir and the mail server, for sites running
+// IPv4-only, IPv6-only or dual-stack networks.
+type NetworkConfig struct {
+	// IPFamily is the IP family of the connections: ipv4, ipv6, or dual to connect over both, which is the default.
+	IPFamily string `yaml:"ipFamily"`
+	// FallbackDelay is how long to wait for a connection over the primary family of a dual-stack host before trying the
+	// secondary family. It defaults to 300ms if zero, and the fallback is disabled if negative.
+	FallbackDelay time.Duration `yaml:"fallbackDelay"`
+	// Proxy defines the HTTP proxies of the requests to the downstream services.
+	Proxy ProxyConfig `yaml:"proxy"`
+}
+
+// ProxyConfig defines the HTTP proxies the downstream services are called through, for networks where they are only reachable
+// through proxies. The proxies of the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables are used if none of HTTPProxy,
+// HTTPSProxy and NoProxy is set.
+type ProxyConfig struct {
+	// HTTPProxy is the URL of the proxy of the requests to http URLs. They are not proxied if empty.
+	HTTPProxy string `yaml:"httpProxy"`
+	// HTTPSProxy is the URL of the proxy of the requests to https URLs. They are not proxied if empty.
+	HTTPSProxy string `yaml:"httpsProxy"`
+	// NoProxy lists the hosts called directly, comma-separated as in NO_PROXY: host names, domains matching their subdomains,
+	// IP addresses and CIDR ranges, optionally with a port, or * for all hosts.
+	NoProxy string `yaml:"noProxy"`
+	// Downstreams overrides the proxy of downstream services by name: alertmanager, mimir, grafana, keycloak, oncall,
+	// tenantMetadata or snapshot. The value is either the URL of the proxy the hosts of the service are called through
+	// regardless of NoProxy, or direct to call them without proxy.
+	Downstreams map[string]string `yaml:"downstreams"`
+}
+
+// MailFailoverConfig defines the mail servers emails fail over to when the mail server given by the SMART_HOST and SMART_PORT
+// environment variables is unavailable, so that an outage of a single mail relay does not stop notifications. It applies to
+// the emails sent by alerting monitor: those of the email relay and the email signing relay, and its own emails, but not to
+// the emails alertmanager sends to the mail server directly, as it only supports a single mail server.
+type MailFailoverConfig struct {
+	// Servers are the mail servers emails fail over to, tried by ascending priority after the mail server of the environment,
+	// which has priority zero. They are authenticated to with the same SMTP_USERNAME and SMTP_PASSWORD credentials.
+	Servers []MailServerConfig `yaml:"servers"`
+	// Cooldown is how long a mail server is tried after the others once an email failed to be sent to it because of a
+	// transient error, so that retries do not wait for it to time out again. Failed mail servers are not deprioritized if zero.
+	Cooldown time.Duration `yaml:"cooldown"`
+}
+
+// MailServerConfig defines a mail server emails fail over to.
+type MailServerConfig struct {
+	// Host is the host name or IP address of the mail server. IPv6 addresses are not enclosed in brackets.
+	Host string `yaml:"host"`
+	Port int    `yaml:"port"`
+	// Priority orders the mail servers, the lowest priority being tried first.
+	Priority int `yaml:"priority"`
 }
 
 type Config struct {
@@ -48,7 +631,189 @@ type Config struct {
 		OidcServer      string `yaml:"oidcServer"`
 		OidcServerRealm string `yaml:"oidcServerRealm"`
 	} `yaml:"authentication"`
-	TaskExecutor TaskExecutorConfig `yaml:"taskExecutor"`
+	TaskExecutor       TaskExecutorConfig       `yaml:"taskExecutor"`
+	Redaction          RedactionConfig          `yaml:"redaction"`
+	RedactionPatterns  *RedactionPatterns       `yaml:"-"`
+	TenantArchival     TenantArchivalConfig     `yaml:"tenantArchival"`
+	ThresholdAutoTune  ThresholdAutoTuneConfig  `yaml:"thresholdAutoTune"`
+	OnCall             OnCallConfig             `yaml:"onCall"`
+	Controller         ControllerConfig         `yaml:"controller"`
+	Profiling          ProfilingConfig          `yaml:"profiling"`
+	EmailSigning       EmailSigningConfig       `yaml:"emailSigning"`
+	EmailRelay         EmailRelayConfig         `yaml:"emailRelay"`
+	EmailVerification  EmailVerificationConfig  `yaml:"emailVerification"`
+	TenantMetadata     TenantMetadataConfig     `yaml:"tenantMetadata"`
+	Snapshot           SnapshotConfig           `yaml:"snapshot"`
+	RuleEvaluation     RuleEvaluationConfig     `yaml:"ruleEvaluation"`
+	TenantTiers        TenantTiersConfig        `yaml:"tenantTiers"`
+	ExternalAlerts     ExternalAlertsConfig     `yaml:"externalAlerts"`
+	MaintenanceMode    MaintenanceModeConfig    `yaml:"maintenanceMode"`
+	AlertLinkage       AlertLinkageConfig       `yaml:"alertLinkage"`
+	NoiseScoring       NoiseScoringConfig       `yaml:"noiseScoring"`
+	Tenancy            TenancyConfig            `yaml:"tenancy"`
+	CORS               CORSConfig               `yaml:"cors"`
+	SecurityHeaders    SecurityHeadersConfig    `yaml:"securityHeaders"`
+	ClockSkew          ClockSkewConfig          `yaml:"clockSkew"`
+	TimeTravel         TimeTravelConfig         `yaml:"timeTravel"`
+	TaskArchive        TaskArchiveConfig        `yaml:"taskArchive"`
+	Reports            ReportsConfig            `yaml:"reports"`
+	APIDocs            APIDocsConfig            `yaml:"apiDocs"`
+	APIVersioning      APIVersioningConfig      `yaml:"apiVersioning"`
+	HistoryRetention   HistoryRetentionConfig   `yaml:"historyRetention"`
+	WebhookAuth        WebhookAuthConfig        `yaml:"webhookAuth"`
+	NotificationQueue  NotificationQueueConfig  `yaml:"notificationQueue"`
+	EventHooks         EventHooksConfig         `yaml:"eventHooks"`
+	ArtifactEncryption ArtifactEncryptionConfig `yaml:"artifactEncryption"`
+	CertExpiry         CertExpiryConfig         `yaml:"certExpiry"`
+	Network            NetworkConfig            `yaml:"network"`
+	MailFailover       MailFailoverConfig       `yaml:"mailFailover"`
+	AlertSimulation    AlertSimulationConfig    `yaml:"alertSimulation"`
+	AllowedRecipients  AllowedRecipientsConfig  `yaml:"allowedRecipients"`
+	EmailOverride      EmailOverrideConfig      `yaml:"emailOverride"`
+	Metrics            MetricsConfig            `yaml:"metrics"`
+}
+
+// ExternalAlertsConfig defines the endpoint tenants push alerts to from applications without Prometheus, so that they are listed
+// and notified like the alerts of alert definitions.
+type ExternalAlertsConfig struct {
+	// Enabled enables the endpoint.
+	Enabled bool `yaml:"enabled"`
+	// Tenants lists the tenants allowed to push alerts. All tenants are allowed if empty.
+	Tenants []string `yaml:"tenants"`
+	// MaxAlerts is the maximum number of alerts pushed by a single request. It is not limited if zero.
+	MaxAlerts int `yaml:"maxAlerts"`
+}
+
+// AllowsTenant tells whether the given tenant is allowed to push alerts.
+func (c ExternalAlertsConfig) AllowsTenant(tenantID string) bool {
+	return c.Enabled && (len(c.Tenants) == 0 || slices.Contains(c.Tenants, tenantID))
+}
+
+// AlertSimulationConfig defines the simulation of the alerts of alert definitions, which pushes a synthetic alert of an alert
+// definition so that users can verify the routing and notifications of its alerts.
+type AlertSimulationConfig struct {
+	// Duration is how long a simulated alert fires before it is resolved. It defaults to 5 minutes if zero.
+	Duration time.Duration `yaml:"duration"`
+}
+
+// AllowedRecipientsConfig defines the email recipients receivers are allowed to notify, which are the users of the identity
+// provider.
+type AllowedRecipientsConfig struct {
+	// CacheTTL is the period the users of the identity provider are cached for, as they are got on every read and update of
+	// receivers. A user added to the identity provider may only be allowed after it. They are not cached if zero.
+	CacheTTL time.Duration `yaml:"cacheTTL"`
+}
+
+// EmailOverrideConfig defines the tenants whose administrators can override the sender address and mail server of the emails
+// of their receivers, in place of the ones of the deployment.
+type EmailOverrideConfig struct {
+	// Tenants lists the tenants allowed to override the email configuration of their receivers. None is allowed if empty.
+	Tenants []string `yaml:"tenants"`
+	// MailServers lists the mail servers, as host:port, receivers can be set to send their emails to. Mail servers cannot be
+	// overridden if empty, as alertmanager authenticates to them with the SMTP credentials of the deployment.
+	MailServers []string `yaml:"mailServers"`
+}
+
+// AllowsTenant tells whether the given tenant is allowed to override the email configuration of its receivers.
+func (c EmailOverrideConfig) AllowsTenant(tenantID string) bool {
+	return slices.Contains(c.Tenants, tenantID)
+}
+
+// MaintenanceModeConfig defines the maintenance mode of tenants, which silences all of their alerts and mutes the routes of
+// their receivers for a bounded duration.
+type MaintenanceModeConfig struct {
+	// MaxDuration is the maximum duration of the maintenance mode.
+	MaxDuration time.Duration `yaml:"maxDuration"`
+}
+
+// AlertLinkageConfig defines the check that the active alerts raised by alert definitions are linked to an alert definition of
+// their tenant.
+type AlertLinkageConfig struct {
+	// CheckInterval is the interval between checks of the alerts of all tenants. Alerts are only checked on request if zero.
+	CheckInterval time.Duration `yaml:"checkInterval"`
+}
+
+// NoiseScoringConfig defines how alert definitions are scored by how noisy their alerts are, from how often they fired and
+// how much of the time they fired they were silenced, to recommend adjustments of their threshold or duration.
+type NoiseScoringConfig struct {
+	// Interval is the interval between scorings of the alert definitions of all tenants. Alert definitions are only scored on
+	// request if zero.
+	Interval time.Duration `yaml:"interval"`
+	// FlappingRate is the number of firings per day from which raising the duration of an alert definition is recommended.
+	// It is never recommended if zero.
+	FlappingRate float64 `yaml:"flappingRate"`
+	// SilenceCoverage is the fraction of the time the alerts of an alert definition fired during which they were silenced,
+	// from which raising its threshold is recommended. It is never recommended if zero.
+	SilenceCoverage float64 `yaml:"silenceCoverage"`
+}
+
+// DefaultTenantLabel is the label of alerts telling their tenant, unless configured otherwise.
+const DefaultTenantLabel = "projectId"
+
+// TenancyConfig defines the label scoping alerts, silences and the routes of alertmanager to a tenant.
+type TenancyConfig struct {
+	// Label is the label of alerts telling their tenant, such as org_id or namespace. It is DefaultTenantLabel if empty.
+	Label string `yaml:"label"`
+	// PreviousLabels are the labels formerly used for tenancy. The routes of alertmanager matching the tenant by any of them are
+	// migrated to Label whenever the alertmanager configuration is updated.
+	PreviousLabels []string `yaml:"previousLabels"`
+	// Sources are the sources the tenant of API requests is resolved from, in order of precedence, as header:<name> or
+	// claim:<name> of the access token. The first source with a value gives the tenant. It is header:ActiveProjectID if empty.
+	Sources []string `yaml:"sources"`
+}
+
+// TenantLabel returns the label of alerts telling their tenant.
+func (c TenancyConfig) TenantLabel() string {
+	if c.Label == "" {
+		return DefaultTenantLabel
+	}
+	return c.Label
+}
+
+// CORSConfig defines the cross-origin requests allowed by the API, so that web consoles can call it directly from browsers.
+type CORSConfig struct {
+	// AllowedOrigins are the origins allowed to call the API, "*" allowing any origin. Cross-origin requests are not allowed
+	// if empty.
+	AllowedOrigins []string `yaml:"allowedOrigins"`
+	// AllowedMethods are the methods allowed in cross-origin requests. They are the methods of the API if empty.
+	AllowedMethods []string `yaml:"allowedMethods"`
+	// AllowedHeaders are the headers allowed in cross-origin requests. The headers requested by browsers are allowed if empty.
+	AllowedHeaders []string `yaml:"allowedHeaders"`
+	// MaxAge is how long browsers may cache the response to a preflight request. It is not cached if zero.
+	MaxAge time.Duration `yaml:"maxAge"`
+}
+
+// SecurityHeadersConfig defines the standard security headers set on the responses of the API.
+type SecurityHeadersConfig struct {
+	// Enabled enables the security headers.
+	Enabled bool `yaml:"enabled"`
+	// HSTSMaxAge is the max age of the Strict-Transport-Security header, which is only set on requests made over TLS. It is not
+	// set if zero.
+	HSTSMaxAge time.Duration `yaml:"hstsMaxAge"`
+	// ContentSecurityPolicy is the Content-Security-Policy header. It is not set if empty.
+	ContentSecurityPolicy string `yaml:"contentSecurityPolicy"`
+}
+
+// ClockSkewConfig defines the detection of the skew of the local clock against the clocks of the database and of alertmanager.
+type ClockSkewConfig struct {
+	// CheckInterval is the interval between checks of the skew. Skew is not checked if zero.
+	CheckInterval time.Duration `yaml:"checkInterval"`
+	// Threshold is the skew beyond which a warning is logged.
+	Threshold time.Duration `yaml:"threshold"`
+}
+
+// TimeTravelConfig defines whether the clock of alerting monitor can be moved through the debug API, to exercise retention,
+// timeouts and backoffs. It is only honored by builds with the timetravel build tag, which are not meant for production.
+type TimeTravelConfig struct {
+	Enabled bool `yaml:"enabled"`
+}
+
+// TaskArchiveConfig defines the archival of Invalid tasks, along with the error of their last failed attempt, to the object
+// store of snapshots before they are deleted past retention, for long-term forensic retention.
+type TaskArchiveConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Prefix is the prefix of the keys of the archived tasks in the bucket of snapshots.
+	Prefix string `yaml:"prefix"`
 }
 
 func LoadConfig(file string) (Config, error) {
@@ -62,5 +827,12 @@ func LoadConfig(file string) (Config, error) {
 	if err != nil {
 		return Config{}, fmt.Errorf("failed to unmarshal: %w", err)
 	}
+
+	if config.RedactionPatterns, err = config.Redaction.Compile(); err != nil {
+		return Config{}, err
+	}
+	if err := config.TaskExecutor.Validate(); err != nil {
+		return Config{}, err
+	}
 	return config, nil
 }
@@ -4,6 +4,8 @@
 package config
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -16,8 +18,33 @@ func TestLoadConfig(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, "http://localhost:9093", configFile.AlertManager.URL, "Read value different from expected")
 		require.Equal(t, "test-namespace", configFile.AlertManager.Namespace, "Read value different from expected")
+		require.Equal(t, []AlertManagerShardConfig{{
+			URL:        "http://localhost:9094",
+			Namespace:  "test-namespace",
+			SecretName: "alert-monitor-config-1",
+		}}, configFile.AlertManager.Shards, "Read value different from expected")
+		require.Equal(t, RetryConfig{
+			MaxRetries:     3,
+			InitialBackoff: 500 * time.Millisecond,
+			MaxBackoff:     5 * time.Second,
+		}, configFile.AlertManager.ApplyRetry, "Read value different from expected")
+		require.Equal(t, []string{"de", "fr"}, configFile.AlertManager.EmailLanguages, "Read value different from expected")
+		require.Equal(t, "grafana", configFile.AlertManager.Backend, "Read value different from expected")
+		require.Equal(t, "http://localhost:3000", configFile.AlertManager.Grafana.URL, "Read value different from expected")
 		require.Equal(t, "http://localhost:8081", configFile.Mimir.RulerURL, "Read value different from expected")
+		require.Equal(t, "http://localhost:8082", configFile.Mimir.QueryURL, "Read value different from expected")
 		require.Equal(t, "test-namespace", configFile.Mimir.Namespace, "Read value different from expected")
+		require.Equal(t, "prometheus", configFile.Mimir.Backend, "Read value different from expected")
+		require.Equal(t, "/etc/prometheus/rules", configFile.Mimir.RulesDir, "Read value different from expected")
+		require.Equal(t, ExpressionCostConfig{
+			Enforce:     true,
+			DefaultTier: "standard",
+			TenantTiers: map[string]string{"premium-tenant": "premium"},
+			Tiers: map[string]ExpressionCostLimits{
+				"standard": {MaxSelectors: 5, MaxRange: 24 * time.Hour, MaxRegexMatchers: 2},
+				"premium":  {MaxSelectors: 20, MaxRange: 168 * time.Hour},
+			},
+		}, configFile.Mimir.ExpressionCost, "Read value different from expected")
 		require.Equal(t, "host-manager-m2m-client", configFile.Keycloak.M2MClient, "Read value different from expected")
 		require.Equal(t, "https://keycloak.kind.internal", configFile.Authentication.OidcServer, "Read value different from expected")
 		require.Equal(t, "master", configFile.Authentication.OidcServerRealm, "Read value different from expected")
@@ -26,6 +53,216 @@ func TestLoadConfig(t *testing.T) {
 		require.Equal(t, 10*time.Minute, configFile.TaskExecutor.TaskTimeout, "Read value different from expected")
 		require.Equal(t, 3, configFile.TaskExecutor.UUIDLimit, "Read value different from expected")
 		require.Equal(t, 10*time.Second, configFile.TaskExecutor.PoolingRate, "Read value different from expected")
+		require.Equal(t, 2, configFile.TaskExecutor.TenantLimit, "Read value different from expected")
+		require.Equal(t, 200, configFile.TaskExecutor.CleanupBatchSize, "Read value different from expected")
+		require.Equal(t, 100*time.Millisecond, configFile.TaskExecutor.CleanupBatchPause, "Read value different from expected")
+		require.Equal(t, time.Minute, configFile.TaskExecutor.HeartbeatTimeout, "Read value different from expected")
+		require.Equal(t, 15*time.Second, configFile.TaskExecutor.HeartbeatInterval, "Read value different from expected")
+		require.Equal(t, []string{"AlertDefinition"}, configFile.TaskExecutor.PausedKinds, "Read value different from expected")
+		require.Equal(t, RedactionConfig{
+			AllowedLabels: []string{"alertname", "host_uuid"},
+			Labels:        []string{"internal_.*"},
+			Annotations:   []string{"runbook_url"},
+			Values:        []string{`\b10\.\d{1,3}\.\d{1,3}\.\d{1,3}\b`},
+		}, configFile.Redaction, "Read value different from expected")
+		require.NotNil(t, configFile.RedactionPatterns, "Redaction patterns not compiled")
+		require.Len(t, configFile.RedactionPatterns.Values, 1, "Redaction patterns not compiled")
+		require.Equal(t, 2160*time.Hour, configFile.TenantArchival.InactivityPeriod, "Read value different from expected")
+		require.Equal(t, time.Hour, configFile.TenantArchival.CheckInterval, "Read value different from expected")
+		require.Equal(t, ThresholdAutoTuneConfig{
+			CheckInterval: 24 * time.Hour,
+			Lookback:      168 * time.Hour,
+			Quantile:      0.99,
+			Margin:        0.1,
+		}, configFile.ThresholdAutoTune, "Read value different from expected")
+		require.Equal(t, "http://localhost:8080", configFile.AlertManager.OnCallRelayURL, "Read value different from expected")
+		require.Equal(t, OnCallConfig{
+			URL:     "http://localhost:8083/integrations/v1/formatted_webhook",
+			Timeout: 10 * time.Second,
+		}, configFile.OnCall, "Read value different from expected")
+		require.Equal(t, ControllerConfig{
+			ResyncInterval: time.Minute,
+			Namespace:      "test-namespace",
+		}, configFile.Controller, "Read value different from expected")
+		require.True(t, configFile.Profiling.Enabled, "Read value different from expected")
+		require.Equal(t, ":9090", configFile.Metrics.ListenAddress, "Read value different from expected")
+		require.Equal(t, "localhost:2525", configFile.AlertManager.SigningRelayHost, "Read value different from expected")
+		require.Equal(t, EmailSigningConfig{
+			ListenAddress:   ":2525",
+			CertificateFile: "/etc/email-signing/tls.crt",
+			KeyFile:         "/etc/email-signing/tls.key",
+			Timeout:         30 * time.Second,
+		}, configFile.EmailSigning, "Read value different from expected")
+		require.Equal(t, "http://localhost:8080", configFile.AlertManager.EmailRelayURL, "Read value different from expected")
+		require.Equal(t, 2*time.Minute, configFile.AlertManager.ReloadTimeout, "Read value different from expected")
+		require.Equal(t, AlertManagerShardConfig{
+			URL:        "http://localhost:9095",
+			Namespace:  "test-staging",
+			SecretName: "alert-monitor-config-staging",
+		}, configFile.AlertManager.Staging, "Read value different from expected")
+		require.Equal(t, EmailRelayConfig{
+			Enabled:       true,
+			TemplateFiles: "/etc/alertmanager/templates/*.tmpl",
+			Timeout:       30 * time.Second,
+			Retry: RetryConfig{
+				MaxRetries:     3,
+				InitialBackoff: time.Second,
+				MaxBackoff:     10 * time.Second,
+			},
+			DeliveryRetention: 240 * time.Hour,
+		}, configFile.EmailRelay, "Read value different from expected")
+		require.Equal(t, TenantMetadataConfig{
+			URL:      "http://orchestrator:8080/v1/projects",
+			Timeout:  5 * time.Second,
+			CacheTTL: 10 * time.Minute,
+		}, configFile.TenantMetadata, "Read value different from expected")
+		require.Equal(t, SnapshotConfig{
+			Interval:    24 * time.Hour,
+			Endpoint:    "http://minio:9000",
+			Bucket:      "alerting-monitor",
+			Prefix:      "snapshots/",
+			Region:      "us-east-1",
+			Timeout:     time.Minute,
+			TrustedKeys: "/etc/snapshot-trusted-keys/*.pub",
+		}, configFile.Snapshot, "Read value different from expected")
+		require.Equal(t, RuleEvaluationConfig{
+			ScrapeInterval: time.Minute,
+			Window:         time.Hour,
+			SlowThreshold:  5 * time.Second,
+		}, configFile.RuleEvaluation, "Read value different from expected")
+		require.Equal(t, ReportsConfig{
+			Enabled:       true,
+			CheckInterval: 30 * time.Minute,
+			TopAlerts:     5,
+			Email:         true,
+			Timeout:       30 * time.Second,
+		}, configFile.Reports, "Read value different from expected")
+		require.Equal(t, APIDocsConfig{
+			Enabled:      true,
+			SwaggerUIURL: "https://mirror.example.com/swagger-ui-dist",
+		}, configFile.APIDocs, "Read value different from expected")
+		require.Equal(t, APIVersioningConfig{
+			Deprecations: map[string]APIDeprecationConfig{
+				"v1": {
+					Date:   time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC),
+					Sunset: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+					Link:   "https://docs.example.com/alerting-monitor/migrate-to-v2",
+				},
+			},
+		}, configFile.APIVersioning, "Read value different from expected")
+		require.Equal(t, HistoryRetentionConfig{
+			CompactionInterval: time.Hour,
+			RawRetention:       720 * time.Hour,
+			RollupRetention:    8760 * time.Hour,
+			BatchSize:          500,
+		}, configFile.HistoryRetention, "Read value different from expected")
+		require.Equal(t, WebhookAuthConfig{
+			AllowedCIDRs:       []string{"10.0.0.0/8", "fd00::/8"},
+			SignatureTolerance: 5 * time.Minute,
+		}, configFile.WebhookAuth, "Read value different from expected")
+		require.Equal(t, NotificationQueueConfig{
+			Enabled:      true,
+			PollInterval: 30 * time.Second,
+			BatchSize:    50,
+			Retry:        RetryConfig{MaxRetries: 10, InitialBackoff: time.Minute, MaxBackoff: time.Hour},
+			Retention:    168 * time.Hour,
+		}, configFile.NotificationQueue, "Read value different from expected")
+		require.Equal(t, EventHooksConfig{
+			Enabled:  true,
+			MaxHooks: 10,
+			Timeout:  5 * time.Second,
+		}, configFile.EventHooks, "Read value different from expected")
+		require.Equal(t, ArtifactEncryptionConfig{
+			Enabled:          true,
+			ActiveKeyID:      "2026-10",
+			RotationInterval: time.Hour,
+			BatchSize:        100,
+		}, configFile.ArtifactEncryption, "Read value different from expected")
+		require.Equal(t, CertExpiryConfig{
+			CheckInterval:     6 * time.Hour,
+			WarningThreshold:  720 * time.Hour,
+			CriticalThreshold: 168 * time.Hour,
+			Timeout:           10 * time.Second,
+		}, configFile.CertExpiry, "Read value different from expected")
+		require.Equal(t, NetworkConfig{
+			IPFamily:      "dual",
+			FallbackDelay: 300 * time.Millisecond,
+			Proxy: ProxyConfig{
+				HTTPSProxy: "http://proxy.example.com:3128",
+				NoProxy:    "localhost,.svc.cluster.local",
+				Downstreams: map[string]string{
+					"alertmanager": "direct",
+					"keycloak":     "http://keycloak-proxy.example.com:3128",
+				},
+			},
+		}, configFile.Network, "Read value different from expected")
+		require.Equal(t, MailFailoverConfig{
+			Servers: []MailServerConfig{
+				{Host: "smtp-backup.example.com", Port: 587, Priority: 10},
+				{Host: "fd00::25", Port: 25, Priority: 20},
+			},
+			Cooldown: 5 * time.Minute,
+		}, configFile.MailFailover, "Read value different from expected")
+		require.Equal(t, TenantTiersConfig{
+			DefaultTier: "basic",
+			Tiers: map[string]TierConfig{
+				"basic": {
+					MaxEmailRecipients:    5,
+					MinEvaluationInterval: time.Minute,
+					Channels:              []string{"email"},
+					RequestsPerSecond:     5,
+					Burst:                 10,
+				},
+				"premium": {MinEvaluationInterval: 15 * time.Second},
+			},
+		}, configFile.TenantTiers, "Read value different from expected")
+		require.Equal(t, ExternalAlertsConfig{
+			Enabled:   true,
+			Tenants:   []string{"edge-tenant"},
+			MaxAlerts: 50,
+		}, configFile.ExternalAlerts, "Read value different from expected")
+		require.Equal(t, AlertSimulationConfig{Duration: 10 * time.Minute}, configFile.AlertSimulation, "Read value different from expected")
+		require.Equal(t, AllowedRecipientsConfig{CacheTTL: 30 * time.Second}, configFile.AllowedRecipients, "Read value different from expected")
+		require.Equal(t, EmailOverrideConfig{
+			Tenants:     []string{"edgenode"},
+			MailServers: []string{"smtp-eu.example.com:587"},
+		}, configFile.EmailOverride, "Read value different from expected")
+		require.Equal(t, MaintenanceModeConfig{
+			MaxDuration: 72 * time.Hour,
+		}, configFile.MaintenanceMode, "Read value different from expected")
+		require.Equal(t, AlertLinkageConfig{
+			CheckInterval: 10 * time.Minute,
+		}, configFile.AlertLinkage, "Read value different from expected")
+		require.Equal(t, NoiseScoringConfig{
+			Interval:        time.Hour,
+			FlappingRate:    10,
+			SilenceCoverage: 0.5,
+		}, configFile.NoiseScoring, "Read value different from expected")
+		require.Equal(t, TenancyConfig{
+			Label:          "org_id",
+			PreviousLabels: []string{"projectId"},
+			Sources:        []string{"header:ActiveProjectID", "header:X-Scope-OrgID", "claim:project_id"},
+		}, configFile.Tenancy, "Read value different from expected")
+		require.Equal(t, CORSConfig{
+			AllowedOrigins: []string{"https://console.example.com"},
+			AllowedMethods: []string{"GET", "POST"},
+			AllowedHeaders: []string{"Authorization", "ActiveProjectID"},
+			MaxAge:         10 * time.Minute,
+		}, configFile.CORS, "Read value different from expected")
+		require.Equal(t, SecurityHeadersConfig{
+			Enabled:               true,
+			HSTSMaxAge:            8760 * time.Hour,
+			ContentSecurityPolicy: "default-src 'none'",
+		}, configFile.SecurityHeaders, "Read value different from expected")
+		require.Equal(t, ClockSkewConfig{
+			CheckInterval: 5 * time.Minute,
+			Threshold:     5 * time.Second,
+		}, configFile.ClockSkew, "Read value different from expected")
+		require.True(t, configFile.TimeTravel.Enabled, "Read value different from expected")
+		require.Equal(t, TaskArchiveConfig{
+			Enabled: true,
+			Prefix:  "alerting-monitor/tasks/",
+		}, configFile.TaskArchive, "Read value different from expected")
 	})
 
 	t.Run("Invalid config file name", func(t *testing.T) {
@@ -37,4 +274,152 @@ func TestLoadConfig(t *testing.T) {
 		_, err := LoadConfig("_testdata/test_config_malformed.yaml")
 		require.Error(t, err)
 	})
+
+	t.Run("Invalid redaction pattern", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(t, os.WriteFile(file, []byte("redaction:\n  values: [\"(\"]\n"), 0o600))
+		_, err := LoadConfig(file)
+		require.ErrorContains(t, err, "invalid value redaction pattern")
+	})
+
+	t.Run("Heartbeat interval too close to timeout", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(t, os.WriteFile(file, []byte("taskExecutor:\n  heartbeatTimeout: 1m\n  heartbeatInterval: 30s\n"), 0o600))
+		_, err := LoadConfig(file)
+		require.ErrorContains(t, err, "heartbeat timeout 1m0s of task executors must be at least 3 times their heartbeat interval 30s")
+	})
+}
+
+func TestTaskExecutorConfig_Heartbeat(t *testing.T) {
+	require.Equal(t, 20*time.Second, TaskExecutorConfig{HeartbeatTimeout: time.Minute}.Heartbeat())
+	require.Equal(t, 10*time.Second, TaskExecutorConfig{HeartbeatTimeout: time.Minute, HeartbeatInterval: 10 * time.Second}.Heartbeat())
+
+	require.NoError(t, TaskExecutorConfig{}.Validate())
+	require.NoError(t, TaskExecutorConfig{HeartbeatTimeout: time.Minute, HeartbeatInterval: 20 * time.Second}.Validate())
+	require.Error(t, TaskExecutorConfig{HeartbeatTimeout: 2 * time.Nanosecond}.Validate())
+}
+
+func TestRedactionConfig_Compile(t *testing.T) {
+	patterns, err := RedactionConfig{
+		AllowedLabels: []string{"alertname"},
+		Labels:        []string{"internal_.*"},
+	}.Compile()
+	require.NoError(t, err)
+	require.Equal(t, []string{"alertname"}, patterns.AllowedLabels)
+	require.Len(t, patterns.Labels, 1)
+	require.True(t, patterns.Labels[0].MatchString("internal_node"))
+	require.False(t, patterns.Labels[0].MatchString("not_internal_node"), "name patterns match whole names")
+
+	for name, conf := range map[string]RedactionConfig{
+		"label":      {Labels: []string{"("}},
+		"annotation": {Annotations: []string{"("}},
+		"value":      {Values: []string{"("}},
+	} {
+		_, err := conf.Compile()
+		require.ErrorContains(t, err, "invalid "+name+" redaction pattern")
+	}
+}
+
+func TestAlertManagerConfig_Shard(t *testing.T) {
+	conf := AlertManagerConfig{
+		URL:       "http://alertmanager-0:9093",
+		Namespace: "observability",
+		MaxRoutes: 10,
+		Shards: []AlertManagerShardConfig{{
+			URL:        "http://alertmanager-1:9093",
+			Namespace:  "observability-1",
+			SecretName: "alert-monitor-config-1",
+		}},
+	}
+
+	require.Equal(t, 2, conf.ShardCount())
+
+	t.Run("First shard", func(t *testing.T) {
+		shard, err := conf.Shard(0)
+		require.NoError(t, err)
+		require.Equal(t, AlertManagerConfig{
+			URL:       "http://alertmanager-0:9093",
+			Namespace: "observability",
+			MaxRoutes: 10,
+		}, shard)
+	})
+
+	t.Run("Additional shard", func(t *testing.T) {
+		shard, err := conf.Shard(1)
+		require.NoError(t, err)
+		require.Equal(t, AlertManagerConfig{
+			URL:        "http://alertmanager-1:9093",
+			Namespace:  "observability-1",
+			SecretName: "alert-monitor-config-1",
+			MaxRoutes:  10,
+		}, shard)
+	})
+
+	t.Run("Shard not configured", func(t *testing.T) {
+		_, err := conf.Shard(2)
+		require.ErrorContains(t, err, "alertmanager shard 2 is not configured")
+	})
+}
+
+func TestExpressionCostConfig_TenantLimits(t *testing.T) {
+	conf := ExpressionCostConfig{
+		DefaultTier: "standard",
+		TenantTiers: map[string]string{"premium-tenant": "premium", "unlimited-tenant": "unlimited"},
+		Tiers: map[string]ExpressionCostLimits{
+			"standard": {MaxSelectors: 5},
+			"premium":  {MaxSelectors: 20},
+		},
+	}
+
+	limits, ok := conf.TenantLimits("premium-tenant")
+	require.True(t, ok)
+	require.Equal(t, 20, limits.MaxSelectors)
+
+	limits, ok = conf.TenantLimits("other-tenant")
+	require.True(t, ok)
+	require.Equal(t, 5, limits.MaxSelectors)
+
+	// Tenants of a tier without limits are not checked.
+	_, ok = conf.TenantLimits("unlimited-tenant")
+	require.False(t, ok)
+}
+
+func TestTenantTiersConfig_Tier(t *testing.T) {
+	conf := TenantTiersConfig{
+		DefaultTier: "basic",
+		Tiers: map[string]TierConfig{
+			"basic":   {Channels: []string{"email"}},
+			"premium": {},
+		},
+	}
+
+	tier, ok := conf.Tier("")
+	require.True(t, ok)
+	require.True(t, tier.AllowsChannel("email"))
+	require.False(t, tier.AllowsChannel("oncall"))
+
+	tier, ok = conf.Tier("premium")
+	require.True(t, ok)
+	require.True(t, tier.AllowsChannel("oncall"))
+
+	_, ok = conf.Tier("unknown")
+	require.False(t, ok)
+}
+
+func TestExternalAlertsConfig_AllowsTenant(t *testing.T) {
+	conf := ExternalAlertsConfig{Enabled: true, Tenants: []string{"edge-tenant"}}
+	require.True(t, conf.AllowsTenant("edge-tenant"))
+	require.False(t, conf.AllowsTenant("other-tenant"))
+
+	// All tenants are allowed if none is listed.
+	conf.Tenants = nil
+	require.True(t, conf.AllowsTenant("other-tenant"))
+
+	conf.Enabled = false
+	require.False(t, conf.AllowsTenant("edge-tenant"))
+}
+
+func TestTenancyConfig_TenantLabel(t *testing.T) {
+	require.Equal(t, DefaultTenantLabel, TenancyConfig{}.TenantLabel())
+	require.Equal(t, "org_id", TenancyConfig{Label: "org_id"}.TenantLabel())
 }
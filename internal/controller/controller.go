@@ -0,0 +1,380 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Package controller reconciles AlertDefinition and AlertReceiver custom resources into alert definitions and receivers, so
+// that their values can be managed declaratively in addition to the REST API.
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/app"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+// configState is the version and state of the latest version of an alert definition or receiver.
+type configState struct {
+	version int64
+	state   string
+}
+
+// configKey identifies an alert definition or receiver across tenants, as their UUIDs are only unique within a tenant.
+type configKey struct {
+	tenantID string
+	uuid     uuid.UUID
+}
+
+// Controller periodically reconciles the AlertDefinition and AlertReceiver custom resources of a namespace. The values given by
+// a custom resource are set through the same database layer as the REST API, creating a new version of the alert definition or
+// receiver along with a task which applies it. The status of the custom resource reports whether the latest version has been
+// applied.
+//
+// Values are set again if they were changed by other means, unless the latest version set by the controller failed to be applied,
+// which is only retried once the spec of the custom resource changes. Unlike the REST API, email recipients of receivers are not
+// checked against the allowed ones, as custom resources are only accessible to users authorized by Kubernetes.
+type Controller struct {
+	controllerConfig config.ControllerConfig
+	logger           *slog.Logger
+	quit             chan struct{}
+
+	client      dynamic.Interface
+	definitions database.AlertDefinitionHandlerManager
+	receivers   database.ReceiverHandlerManager
+	states      database.ConfigStateReporter
+}
+
+// New creates a new Controller, initializing the controller configuration, the client of the Kubernetes API, and the connection
+// to the database where alert definitions and receivers are stored.
+func New(cfg config.Config, dbConn *gorm.DB, loglevel string) (*Controller, error) {
+	c, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kubernetes incluster config: %w", err)
+	}
+
+	client, err := dynamic.NewForConfig(c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new dynamic client: %w", err)
+	}
+
+	opts := setLogLvl(loglevel)
+	dbService := &database.DBService{DB: dbConn, AlertmanagerShards: cfg.AlertManager.ShardCount()}
+	return &Controller{
+		controllerConfig: cfg.Controller,
+		logger:           slog.New(slog.NewTextHandler(os.Stdout, &opts)),
+		quit:             make(chan struct{}),
+
+		client:      client,
+		definitions: dbService,
+		receivers:   dbService,
+		states:      dbService,
+	}, nil
+}
+
+// Start allows the receiver to start reconciling custom resources periodically by means of a ticker.
+// NOTE: Once this method is invoked, to stop reconciling, we need to explicitly call Stop method from the receiver.
+func (c *Controller) Start(ctx context.Context) {
+	if c.controllerConfig.ResyncInterval <= 0 {
+		c.logger.Info("Custom resource controller is disabled")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(c.controllerConfig.ResyncInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-c.quit:
+				c.logger.Info("Received signal: stopping controller")
+				return
+			case <-ticker.C:
+				c.reconcile(ctx)
+			}
+		}
+	}()
+}
+
+// Stop allows the receiver to stop reconciling custom resources.
+func (c *Controller) Stop() {
+	close(c.quit)
+}
+
+// reconcile reconciles all AlertDefinition and AlertReceiver custom resources of the namespace.
+func (c *Controller) reconcile(ctx context.Context) {
+	defStates, err := c.definitionStates(ctx)
+	if err != nil {
+		c.logger.Error("failed to get alert definition states", slog.Any("error", err))
+	} else {
+		c.reconcileResources(ctx, alertDefinitionResource, func(obj *unstructured.Unstructured) (resourceStatus, error) {
+			res, err := fromUnstructured[alertDefinitionSpec](obj)
+			if err != nil {
+				return resourceStatus{}, err
+			}
+			return c.reconcileDefinition(ctx, res, defStates)
+		})
+	}
+
+	recvStates, err := c.receiverStates(ctx)
+	if err != nil {
+		c.logger.Error("failed to get receiver states", slog.Any("error", err))
+	} else {
+		c.reconcileResources(ctx, alertReceiverResource, func(obj *unstructured.Unstructured) (resourceStatus, error) {
+			res, err := fromUnstructured[alertReceiverSpec](obj)
+			if err != nil {
+				return resourceStatus{}, err
+			}
+			return c.reconcileReceiver(ctx, res, recvStates)
+		})
+	}
+}
+
+// reconcileResources reconciles the custom resources of the given kind by means of the given function, and updates their status
+// if it changed. Failing to reconcile a custom resource does not stop others from being reconciled.
+func (c *Controller) reconcileResources(
+	ctx context.Context, gvr schema.GroupVersionResource, reconcileFn func(*unstructured.Unstructured) (resourceStatus, error),
+) {
+	client := c.client.Resource(gvr).Namespace(c.controllerConfig.Namespace)
+	list, err := client.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		c.logger.Error(fmt.Sprintf("failed to list %s", gvr.Resource), slog.Any("error", err))
+		return
+	}
+
+	for i := range list.Items {
+		obj := &list.Items[i]
+		status, err := reconcileFn(obj)
+		if err != nil {
+			c.logger.Error(fmt.Sprintf("failed to reconcile %s %q", gvr.Resource, obj.GetName()), slog.Any("error", err))
+			continue
+		}
+
+		changed, err := setStatus(obj, status)
+		if err != nil {
+			c.logger.Error(fmt.Sprintf("failed to set status of %s %q", gvr.Resource, obj.GetName()), slog.Any("error", err))
+			continue
+		}
+		if !changed {
+			continue
+		}
+		if _, err := client.UpdateStatus(ctx, obj, metav1.UpdateOptions{}); err != nil {
+			c.logger.Error(fmt.Sprintf("failed to update status of %s %q", gvr.Resource, obj.GetName()), slog.Any("error", err))
+		}
+	}
+}
+
+// reconcileDefinition sets the values of the alert definition given by an AlertDefinition custom resource, if they differ from
+// the current ones, and returns the resulting status of the custom resource. Errors are only returned if reconciliation should
+// be retried without changes to the custom resource.
+func (c *Controller) reconcileDefinition(
+	ctx context.Context, res *resource[alertDefinitionSpec], states map[configKey]configState,
+) (resourceStatus, error) {
+	status := res.Status
+	status.ObservedGeneration = res.Metadata.Generation
+
+	defs, _, err := c.definitions.GetLatestAlertDefinitionList(ctx, res.Spec.TenantID, database.ListOptions{})
+	if err != nil {
+		return resourceStatus{}, err
+	}
+
+	index := slices.IndexFunc(defs, func(def *models.DBAlertDefinition) bool {
+		return def.Name == res.Spec.Name
+	})
+	if index < 0 {
+		status.ID = nil
+		setAppliedCondition(&status, metav1.ConditionFalse, reasonNotFound,
+			fmt.Sprintf("alert definition %q of tenant %q not found", res.Spec.Name, res.Spec.TenantID))
+		return status, nil
+	}
+	def := defs[index]
+	status.ID = &def.ID
+
+	values, err := app.ParseAlertDefinitionValues(res.Spec.PatchProjectAlertDefinitionJSONBody)
+	if err != nil {
+		setAppliedCondition(&status, metav1.ConditionFalse, reasonInvalid, err.Error())
+		return status, nil
+	}
+
+	latest := states[configKey{tenantID: res.Spec.TenantID, uuid: def.ID}]
+	if definitionValuesDiffer(def.Values, *values) && retryAllowed(res.Metadata.Generation, res.Status.ObservedGeneration, latest.state) {
+		err := c.definitions.SetAlertDefinitionValues(ctx, res.Spec.TenantID, def.ID, *values)
+		if errors.Is(err, database.ErrValueOutOfBounds) {
+			app.RecordOutOfBoundsRejection(ctx, res.Spec.TenantID, def.ID, err)
+			setAppliedCondition(&status, metav1.ConditionFalse, reasonInvalid, err.Error())
+			return status, nil
+		} else if err != nil {
+			return resourceStatus{}, err
+		}
+		latest = configState{version: latest.version + 1, state: string(models.DefinitionNew)}
+	}
+
+	status.Version = latest.version
+	setStateCondition(&status, latest.state)
+	return status, nil
+}
+
+// reconcileReceiver sets the values of the receiver given by an AlertReceiver custom resource, if they differ from the current
+// ones, and returns the resulting status of the custom resource. Errors are only returned if reconciliation should be retried
+// without changes to the custom resource.
+func (c *Controller) reconcileReceiver(
+	ctx context.Context, res *resource[alertReceiverSpec], states map[configKey]configState,
+) (resourceStatus, error) {
+	status := res.Status
+	status.ObservedGeneration = res.Metadata.Generation
+
+	recvs, _, err := c.receivers.GetLatestReceiverListWithEmailConfig(ctx, res.Spec.TenantID, database.ListOptions{})
+	if err != nil {
+		return resourceStatus{}, err
+	}
+
+	index := slices.IndexFunc(recvs, func(recv *models.DBReceiver) bool {
+		return recv.Name == res.Spec.Name
+	})
+	if index < 0 {
+		status.ID = nil
+		setAppliedCondition(&status, metav1.ConditionFalse, reasonNotFound,
+			fmt.Sprintf("receiver %q of tenant %q not found", res.Spec.Name, res.Spec.TenantID))
+		return status, nil
+	}
+	recv := recvs[index]
+	status.ID = &recv.UUID
+
+	values, err := app.ParseReceiverValues(res.Spec.PatchProjectAlertReceiverJSONBody)
+	if err != nil {
+		setAppliedCondition(&status, metav1.ConditionFalse, reasonInvalid, err.Error())
+		return status, nil
+	}
+
+	latest := states[configKey{tenantID: res.Spec.TenantID, uuid: recv.UUID}]
+	if receiverValuesDiffer(*recv, values) && retryAllowed(res.Metadata.Generation, res.Status.ObservedGeneration, latest.state) {
+		if err := c.receivers.SetReceiverValues(ctx, res.Spec.TenantID, recv.UUID, values); err != nil {
+			return resourceStatus{}, err
+		}
+		latest = configState{version: latest.version + 1, state: string(models.ReceiverNew)}
+	}
+
+	status.Version = latest.version
+	setStateCondition(&status, latest.state)
+	return status, nil
+}
+
+// definitionStates returns the version and state of the latest version of all alert definitions.
+func (c *Controller) definitionStates(ctx context.Context) (map[configKey]configState, error) {
+	defs, err := c.states.GetLatestAlertDefinitionStates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	states := make(map[configKey]configState, len(defs))
+	for _, def := range defs {
+		states[configKey{tenantID: def.TenantID, uuid: def.UUID}] = configState{version: def.Version, state: string(def.State)}
+	}
+	return states, nil
+}
+
+// receiverStates returns the version and state of the latest version of all receivers.
+func (c *Controller) receiverStates(ctx context.Context) (map[configKey]configState, error) {
+	recvs, err := c.states.GetLatestReceiverStates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	states := make(map[configKey]configState, len(recvs))
+	for _, recv := range recvs {
+		states[configKey{tenantID: recv.TenantID, uuid: recv.UUID}] = configState{version: recv.Version, state: string(recv.State)}
+	}
+	return states, nil
+}
+
+// retryAllowed reports whether values may be set again. A latest version which failed to be applied is only retried once the
+// spec of the custom resource changes, so that a new version is not created on every reconciliation.
+func retryAllowed(generation, observedGeneration int64, state string) bool {
+	return generation != observedGeneration || state != string(models.DefinitionError)
+}
+
+// definitionValuesDiffer reports whether any of the given values differs from the current values of an alert definition.
+func definitionValuesDiffer(current, values models.DBAlertDefinitionValues) bool {
+	differ := func(current, value *int64) bool {
+		return value != nil && (current == nil || *current != *value)
+	}
+	differBool := func(current, value *bool) bool {
+		return value != nil && (current == nil || *current != *value)
+	}
+	return differ(current.Duration, values.Duration) || differ(current.Threshold, values.Threshold) ||
+		differBool(current.Enabled, values.Enabled) || differBool(current.AutoTune, values.AutoTune)
+}
+
+// receiverValuesDiffer reports whether any of the given values differs from the current values of a receiver.
+func receiverValuesDiffer(current models.DBReceiver, values models.DBReceiverValues) bool {
+	recipients := make([]string, len(values.Recipients))
+	for i, r := range values.Recipients {
+		recipients[i] = r.String()
+	}
+	slices.Sort(recipients)
+	to := slices.Sorted(slices.Values(current.To))
+
+	return !slices.Equal(to, recipients) ||
+		(values.MinSeverity != nil && *values.MinSeverity != current.MinSeverity) ||
+		(values.QuietHours != nil && *values.QuietHours != current.QuietHours) ||
+		(values.OnCallRoutingKey != nil && *values.OnCallRoutingKey != current.OnCallRoutingKey) ||
+		(values.Language != nil && *values.Language != current.Language) ||
+		// The names of an existing sender address are kept, so the sender is compared by email only.
+		(values.Sender != nil && !strings.HasSuffix(current.From, fmt.Sprintf("<%s>", values.Sender.Email))) ||
+		(values.MailServer != nil && *values.MailServer != current.MailServer) ||
+		(values.Disabled != nil && *values.Disabled != current.Disabled) ||
+		(values.Matchers != nil && !slices.Equal(*values.Matchers, current.Matchers))
+}
+
+// setStateCondition sets the Applied condition of a status from the state of the latest version of an alert definition or
+// receiver. Both share the same states.
+func setStateCondition(status *resourceStatus, state string) {
+	switch state {
+	case string(models.DefinitionApplied):
+		setAppliedCondition(status, metav1.ConditionTrue, state, fmt.Sprintf("version %d is applied", status.Version))
+	case string(models.DefinitionError):
+		setAppliedCondition(status, metav1.ConditionFalse, state, fmt.Sprintf("version %d failed to be applied", status.Version))
+	default:
+		setAppliedCondition(status, metav1.ConditionUnknown, state, fmt.Sprintf("version %d is being applied", status.Version))
+	}
+}
+
+func setLogLvl(logLvl string) slog.HandlerOptions {
+	switch logLvl {
+	case "debug":
+		return slog.HandlerOptions{
+			Level: slog.LevelDebug,
+		}
+	case "info":
+		return slog.HandlerOptions{
+			Level: slog.LevelInfo,
+		}
+	case "warn":
+		return slog.HandlerOptions{
+			Level: slog.LevelWarn,
+		}
+	case "error":
+		return slog.HandlerOptions{
+			Level: slog.LevelError,
+		}
+	default:
+		return slog.HandlerOptions{
+			Level: slog.LevelInfo,
+		}
+	}
+}
@@ -0,0 +1,511 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+const namespace = "orch-infra"
+
+type AlertDefinitionMock struct {
+	mock.Mock
+}
+
+func (m *AlertDefinitionMock) GetLatestAlertDefinitionList(
+	ctx context.Context, tenantID api.TenantID, opts database.ListOptions,
+) ([]*models.DBAlertDefinition, int64, error) {
+	args := m.Called(ctx, tenantID, opts)
+	return args.Get(0).([]*models.DBAlertDefinition), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *AlertDefinitionMock) GetLatestAlertDefinition(ctx context.Context, tenantID api.TenantID, id uuid.UUID) (*models.DBAlertDefinition, error) {
+	args := m.Called(ctx, tenantID, id)
+	return args.Get(0).(*models.DBAlertDefinition), args.Error(1)
+}
+
+func (m *AlertDefinitionMock) SetAlertDefinitionValues(
+	ctx context.Context, tenantID api.TenantID, id uuid.UUID, values models.DBAlertDefinitionValues,
+) error {
+	args := m.Called(ctx, tenantID, id, values)
+	return args.Error(0)
+}
+
+func (m *AlertDefinitionMock) ResetAlertDefinitionValues(
+	ctx context.Context, tenantID api.TenantID, id uuid.UUID,
+) (*models.DBAlertDefinitionValues, error) {
+	args := m.Called(ctx, tenantID, id)
+	return args.Get(0).(*models.DBAlertDefinitionValues), args.Error(1)
+}
+
+type ReceiverMock struct {
+	mock.Mock
+}
+
+func (m *ReceiverMock) GetLatestReceiverListWithEmailConfig(
+	ctx context.Context, tenantID api.TenantID, opts database.ListOptions,
+) ([]*models.DBReceiver, int64, error) {
+	args := m.Called(ctx, tenantID, opts)
+	return args.Get(0).([]*models.DBReceiver), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *ReceiverMock) GetLatestReceiverWithEmailConfig(ctx context.Context, tenantID api.TenantID, id uuid.UUID) (*models.DBReceiver, error) {
+	args := m.Called(ctx, tenantID, id)
+	return args.Get(0).(*models.DBReceiver), args.Error(1)
+}
+
+func (m *ReceiverMock) SetReceiverValues(ctx context.Context, tenantID api.TenantID, id uuid.UUID, values models.DBReceiverValues) error {
+	args := m.Called(ctx, tenantID, id, values)
+	return args.Error(0)
+}
+
+func (m *ReceiverMock) SetReceiversRecipients(ctx context.Context, tenantID api.TenantID, recipients map[uuid.UUID][]models.EmailAddress) error {
+	args := m.Called(ctx, tenantID, recipients)
+	return args.Error(0)
+}
+
+type ConfigStateMock struct {
+	mock.Mock
+}
+
+func (m *ConfigStateMock) GetLatestAlertDefinitionStates(ctx context.Context) ([]models.AlertDefinition, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]models.AlertDefinition), args.Error(1)
+}
+
+func (m *ConfigStateMock) GetLatestReceiverStates(ctx context.Context) ([]models.Receiver, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]models.Receiver), args.Error(1)
+}
+
+func newCustomResource(kind, name string, generation int64, spec map[string]any) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "alerting.edge-orchestrator.intel.com/v1alpha1",
+		"kind":       kind,
+		"metadata": map[string]any{
+			"name":       name,
+			"namespace":  namespace,
+			"generation": generation,
+		},
+		"spec": spec,
+	}}
+	return obj
+}
+
+func newController(objects ...runtime.Object) (*Controller, *AlertDefinitionMock, *ReceiverMock, *ConfigStateMock) {
+	client := fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		alertDefinitionResource: "AlertDefinitionList",
+		alertReceiverResource:   "AlertReceiverList",
+	}, objects...)
+
+	definitions := new(AlertDefinitionMock)
+	receivers := new(ReceiverMock)
+	states := new(ConfigStateMock)
+	return &Controller{
+		controllerConfig: config.ControllerConfig{Namespace: namespace},
+		logger:           slog.New(slog.NewTextHandler(os.Stdout, nil)),
+		quit:             make(chan struct{}),
+		client:           client,
+		definitions:      definitions,
+		receivers:        receivers,
+		states:           states,
+	}, definitions, receivers, states
+}
+
+func getStatus(t *testing.T, c *Controller, gvr schema.GroupVersionResource, name string) resourceStatus {
+	obj, err := c.client.Resource(gvr).Namespace(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	require.NoError(t, err)
+
+	res, err := fromUnstructured[struct{}](obj)
+	require.NoError(t, err)
+	return res.Status
+}
+
+func requireCondition(t *testing.T, status resourceStatus, conditionStatus metav1.ConditionStatus, reason string) {
+	require.Len(t, status.Conditions, 1)
+	require.Equal(t, conditionApplied, status.Conditions[0].Type)
+	require.Equal(t, conditionStatus, status.Conditions[0].Status)
+	require.Equal(t, reason, status.Conditions[0].Reason)
+}
+
+func TestController_ReconcileDefinitions(t *testing.T) {
+	tenantID := "tenant"
+	defID := uuid.New()
+	duration, threshold := int64(30), int64(80)
+	enabled := true
+
+	def := &models.DBAlertDefinition{
+		ID:       defID,
+		Name:     "HighCPUUsage",
+		TenantID: tenantID,
+		Values:   models.DBAlertDefinitionValues{Duration: &duration, Threshold: &threshold, Enabled: &enabled},
+	}
+
+	t.Run("Sets changed values and reports the new version", func(t *testing.T) {
+		cr := newCustomResource("AlertDefinition", "high-cpu", 1, map[string]any{
+			"tenantID": tenantID,
+			"name":     "HighCPUUsage",
+			"values":   map[string]any{"threshold": "90"},
+		})
+		c, definitions, _, states := newController(cr)
+
+		newThreshold := int64(90)
+		states.On("GetLatestAlertDefinitionStates", mock.Anything).Return([]models.AlertDefinition{
+			{TenantID: tenantID, UUID: defID, Version: 2, State: models.DefinitionApplied},
+		}, nil).Once()
+		states.On("GetLatestReceiverStates", mock.Anything).Return([]models.Receiver{}, nil).Once()
+		definitions.On("GetLatestAlertDefinitionList", mock.Anything, tenantID, database.ListOptions{}).
+			Return([]*models.DBAlertDefinition{def}, int64(1), nil).Once()
+		definitions.On("SetAlertDefinitionValues", mock.Anything, tenantID, defID,
+			models.DBAlertDefinitionValues{Threshold: &newThreshold}).Return(nil).Once()
+
+		c.reconcile(context.Background())
+
+		status := getStatus(t, c, alertDefinitionResource, "high-cpu")
+		require.Equal(t, int64(1), status.ObservedGeneration)
+		require.Equal(t, &defID, status.ID)
+		require.Equal(t, int64(3), status.Version)
+		requireCondition(t, status, metav1.ConditionUnknown, string(models.DefinitionNew))
+		definitions.AssertExpectations(t)
+		states.AssertExpectations(t)
+	})
+
+	t.Run("Unchanged values report the state of the latest version", func(t *testing.T) {
+		cr := newCustomResource("AlertDefinition", "high-cpu", 1, map[string]any{
+			"tenantID": tenantID,
+			"name":     "HighCPUUsage",
+			"values":   map[string]any{"threshold": "80", "duration": "30s"},
+		})
+		c, definitions, _, states := newController(cr)
+
+		states.On("GetLatestAlertDefinitionStates", mock.Anything).Return([]models.AlertDefinition{
+			{TenantID: tenantID, UUID: defID, Version: 2, State: models.DefinitionApplied},
+		}, nil).Once()
+		states.On("GetLatestReceiverStates", mock.Anything).Return([]models.Receiver{}, nil).Once()
+		definitions.On("GetLatestAlertDefinitionList", mock.Anything, tenantID, database.ListOptions{}).
+			Return([]*models.DBAlertDefinition{def}, int64(1), nil).Once()
+
+		c.reconcile(context.Background())
+
+		status := getStatus(t, c, alertDefinitionResource, "high-cpu")
+		require.Equal(t, int64(2), status.Version)
+		requireCondition(t, status, metav1.ConditionTrue, string(models.DefinitionApplied))
+		definitions.AssertExpectations(t)
+	})
+
+	t.Run("Failed version is not retried until the spec changes", func(t *testing.T) {
+		cr := newCustomResource("AlertDefinition", "high-cpu", 2, map[string]any{
+			"tenantID": tenantID,
+			"name":     "HighCPUUsage",
+			"values":   map[string]any{"threshold": "90"},
+		})
+		require.NoError(t, unstructured.SetNestedField(cr.Object, int64(2), "status", "observedGeneration"))
+		c, definitions, _, states := newController(cr)
+
+		states.On("GetLatestAlertDefinitionStates", mock.Anything).Return([]models.AlertDefinition{
+			{TenantID: tenantID, UUID: defID, Version: 3, State: models.DefinitionError},
+		}, nil).Once()
+		states.On("GetLatestReceiverStates", mock.Anything).Return([]models.Receiver{}, nil).Once()
+		definitions.On("GetLatestAlertDefinitionList", mock.Anything, tenantID, database.ListOptions{}).
+			Return([]*models.DBAlertDefinition{def}, int64(1), nil).Once()
+
+		c.reconcile(context.Background())
+
+		status := getStatus(t, c, alertDefinitionResource, "high-cpu")
+		require.Equal(t, int64(3), status.Version)
+		requireCondition(t, status, metav1.ConditionFalse, string(models.DefinitionError))
+		definitions.AssertExpectations(t)
+	})
+
+	t.Run("States are those of the tenant of the custom resource", func(t *testing.T) {
+		// Alert definitions of different tenants share the UUID given by the rule they were created from.
+		otherTenantID := "other-tenant"
+		otherDef := *def
+		otherDef.TenantID = otherTenantID
+
+		cr := newCustomResource("AlertDefinition", "high-cpu", 1, map[string]any{
+			"tenantID": tenantID,
+			"name":     "HighCPUUsage",
+			"values":   map[string]any{"threshold": "80", "duration": "30s"},
+		})
+		otherCR := newCustomResource("AlertDefinition", "other-high-cpu", 1, map[string]any{
+			"tenantID": otherTenantID,
+			"name":     "HighCPUUsage",
+			"values":   map[string]any{"threshold": "80", "duration": "30s"},
+		})
+		c, definitions, _, states := newController(cr, otherCR)
+
+		states.On("GetLatestAlertDefinitionStates", mock.Anything).Return([]models.AlertDefinition{
+			{TenantID: tenantID, UUID: defID, Version: 2, State: models.DefinitionApplied},
+			{TenantID: otherTenantID, UUID: defID, Version: 5, State: models.DefinitionError},
+		}, nil).Once()
+		states.On("GetLatestReceiverStates", mock.Anything).Return([]models.Receiver{}, nil).Once()
+		definitions.On("GetLatestAlertDefinitionList", mock.Anything, tenantID, database.ListOptions{}).
+			Return([]*models.DBAlertDefinition{def}, int64(1), nil).Once()
+		definitions.On("GetLatestAlertDefinitionList", mock.Anything, otherTenantID, database.ListOptions{}).
+			Return([]*models.DBAlertDefinition{&otherDef}, int64(1), nil).Once()
+
+		c.reconcile(context.Background())
+
+		status := getStatus(t, c, alertDefinitionResource, "high-cpu")
+		require.Equal(t, int64(2), status.Version)
+		requireCondition(t, status, metav1.ConditionTrue, string(models.DefinitionApplied))
+
+		status = getStatus(t, c, alertDefinitionResource, "other-high-cpu")
+		require.Equal(t, int64(5), status.Version)
+		requireCondition(t, status, metav1.ConditionFalse, string(models.DefinitionError))
+		definitions.AssertExpectations(t)
+	})
+
+	t.Run("Alert definition not found", func(t *testing.T) {
+		cr := newCustomResource("AlertDefinition", "unknown", 1, map[string]any{
+			"tenantID": tenantID,
+			"name":     "Unknown",
+		})
+		c, definitions, _, states := newController(cr)
+
+		states.On("GetLatestAlertDefinitionStates", mock.Anything).Return([]models.AlertDefinition{}, nil).Once()
+		states.On("GetLatestReceiverStates", mock.Anything).Return([]models.Receiver{}, nil).Once()
+		definitions.On("GetLatestAlertDefinitionList", mock.Anything, tenantID, database.ListOptions{}).
+			Return([]*models.DBAlertDefinition{def}, int64(1), nil).Once()
+
+		c.reconcile(context.Background())
+
+		status := getStatus(t, c, alertDefinitionResource, "unknown")
+		require.Nil(t, status.ID)
+		requireCondition(t, status, metav1.ConditionFalse, reasonNotFound)
+	})
+
+	t.Run("Invalid and out of bounds values", func(t *testing.T) {
+		invalid := newCustomResource("AlertDefinition", "invalid", 1, map[string]any{
+			"tenantID": tenantID,
+			"name":     "HighCPUUsage",
+			"values":   map[string]any{"threshold": "high"},
+		})
+		outOfBounds := newCustomResource("AlertDefinition", "out-of-bounds", 1, map[string]any{
+			"tenantID": tenantID,
+			"name":     "HighCPUUsage",
+			"values":   map[string]any{"threshold": "1000"},
+		})
+		c, definitions, _, states := newController(invalid, outOfBounds)
+
+		states.On("GetLatestAlertDefinitionStates", mock.Anything).Return([]models.AlertDefinition{}, nil).Once()
+		states.On("GetLatestReceiverStates", mock.Anything).Return([]models.Receiver{}, nil).Once()
+		definitions.On("GetLatestAlertDefinitionList", mock.Anything, tenantID, database.ListOptions{}).
+			Return([]*models.DBAlertDefinition{def}, int64(1), nil).Twice()
+		definitions.On("SetAlertDefinitionValues", mock.Anything, tenantID, defID, mock.Anything).
+			Return(database.ErrValueOutOfBounds).Once()
+
+		c.reconcile(context.Background())
+
+		requireCondition(t, getStatus(t, c, alertDefinitionResource, "invalid"), metav1.ConditionFalse, reasonInvalid)
+		requireCondition(t, getStatus(t, c, alertDefinitionResource, "out-of-bounds"), metav1.ConditionFalse, reasonInvalid)
+		definitions.AssertExpectations(t)
+	})
+
+	t.Run("Failing to reconcile leaves the status unchanged", func(t *testing.T) {
+		cr := newCustomResource("AlertDefinition", "high-cpu", 1, map[string]any{
+			"tenantID": tenantID,
+			"name":     "HighCPUUsage",
+		})
+		c, definitions, _, states := newController(cr)
+
+		states.On("GetLatestAlertDefinitionStates", mock.Anything).Return([]models.AlertDefinition{}, nil).Once()
+		states.On("GetLatestReceiverStates", mock.Anything).Return([]models.Receiver{}, nil).Once()
+		definitions.On("GetLatestAlertDefinitionList", mock.Anything, tenantID, database.ListOptions{}).
+			Return([]*models.DBAlertDefinition(nil), int64(0), errors.New("mock error")).Once()
+
+		c.reconcile(context.Background())
+
+		require.Empty(t, getStatus(t, c, alertDefinitionResource, "high-cpu").Conditions)
+		definitions.AssertExpectations(t)
+	})
+}
+
+func TestController_ReconcileReceivers(t *testing.T) {
+	tenantID := "tenant"
+	recvID := uuid.New()
+
+	recv := &models.DBReceiver{
+		UUID:        recvID,
+		Name:        "alert-monitor-config",
+		TenantID:    tenantID,
+		To:          []string{"Jane Doe <jane.doe@example.com>"},
+		MinSeverity: models.SeverityNone,
+	}
+
+	t.Run("Sets changed recipients and reports the new version", func(t *testing.T) {
+		cr := newCustomResource("AlertReceiver", "receiver", 1, map[string]any{
+			"tenantID": tenantID,
+			"name":     "alert-monitor-config",
+			"emailConfig": map[string]any{
+				"to": map[string]any{"enabled": []any{"Jane Doe <jane.doe@example.com>", "John Doe <john.doe@example.com>"}},
+			},
+		})
+		c, _, receivers, states := newController(cr)
+
+		states.On("GetLatestAlertDefinitionStates", mock.Anything).Return([]models.AlertDefinition{}, nil).Once()
+		states.On("GetLatestReceiverStates", mock.Anything).Return([]models.Receiver{
+			{TenantID: tenantID, UUID: recvID, Version: 4, State: models.ReceiverApplied},
+		}, nil).Once()
+		receivers.On("GetLatestReceiverListWithEmailConfig", mock.Anything, tenantID, database.ListOptions{}).
+			Return([]*models.DBReceiver{recv}, int64(1), nil).Once()
+		receivers.On("SetReceiverValues", mock.Anything, tenantID, recvID, models.DBReceiverValues{
+			Recipients: []models.EmailAddress{
+				{FirstName: "Jane", LastName: "Doe", Email: "jane.doe@example.com"},
+				{FirstName: "John", LastName: "Doe", Email: "john.doe@example.com"},
+			},
+		}).Return(nil).Once()
+
+		c.reconcile(context.Background())
+
+		status := getStatus(t, c, alertReceiverResource, "receiver")
+		require.Equal(t, &recvID, status.ID)
+		require.Equal(t, int64(5), status.Version)
+		requireCondition(t, status, metav1.ConditionUnknown, string(models.ReceiverNew))
+		receivers.AssertExpectations(t)
+	})
+
+	t.Run("Unchanged recipients report the state of the latest version", func(t *testing.T) {
+		cr := newCustomResource("AlertReceiver", "receiver", 1, map[string]any{
+			"tenantID": tenantID,
+			"name":     "alert-monitor-config",
+			"emailConfig": map[string]any{
+				"to": map[string]any{"enabled": []any{"Jane Doe <jane.doe@example.com>"}},
+			},
+		})
+		c, _, receivers, states := newController(cr)
+
+		states.On("GetLatestAlertDefinitionStates", mock.Anything).Return([]models.AlertDefinition{}, nil).Once()
+		states.On("GetLatestReceiverStates", mock.Anything).Return([]models.Receiver{
+			{TenantID: tenantID, UUID: recvID, Version: 4, State: models.ReceiverPending},
+		}, nil).Once()
+		receivers.On("GetLatestReceiverListWithEmailConfig", mock.Anything, tenantID, database.ListOptions{}).
+			Return([]*models.DBReceiver{recv}, int64(1), nil).Once()
+
+		c.reconcile(context.Background())
+
+		status := getStatus(t, c, alertReceiverResource, "receiver")
+		require.Equal(t, int64(4), status.Version)
+		requireCondition(t, status, metav1.ConditionUnknown, string(models.ReceiverPending))
+		receivers.AssertExpectations(t)
+	})
+
+	t.Run("Unchanged sender and mail server report the state of the latest version", func(t *testing.T) {
+		cr := newCustomResource("AlertReceiver", "receiver", 1, map[string]any{
+			"tenantID": tenantID,
+			"name":     "alert-monitor-config",
+			"emailConfig": map[string]any{
+				"from":       "Tenant Alerts <alerts@example.com>",
+				"mailServer": "smtp.example.com:587",
+				"to":         map[string]any{"enabled": []any{"Jane Doe <jane.doe@example.com>"}},
+			},
+		})
+		c, _, receivers, states := newController(cr)
+
+		// The names of the sender address are the ones it was first created with.
+		overridden := *recv
+		overridden.From = "Open Edge Platform Alert <alerts@example.com>"
+		overridden.MailServer = "smtp.example.com:587"
+
+		states.On("GetLatestAlertDefinitionStates", mock.Anything).Return([]models.AlertDefinition{}, nil).Once()
+		states.On("GetLatestReceiverStates", mock.Anything).Return([]models.Receiver{
+			{TenantID: tenantID, UUID: recvID, Version: 4, State: models.ReceiverApplied},
+		}, nil).Once()
+		receivers.On("GetLatestReceiverListWithEmailConfig", mock.Anything, tenantID, database.ListOptions{}).
+			Return([]*models.DBReceiver{&overridden}, int64(1), nil).Once()
+
+		c.reconcile(context.Background())
+
+		status := getStatus(t, c, alertReceiverResource, "receiver")
+		require.Equal(t, int64(4), status.Version)
+		receivers.AssertNotCalled(t, "SetReceiverValues", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Sets changed mail server and reports the new version", func(t *testing.T) {
+		cr := newCustomResource("AlertReceiver", "receiver", 1, map[string]any{
+			"tenantID": tenantID,
+			"name":     "alert-monitor-config",
+			"emailConfig": map[string]any{
+				"mailServer": "smtp-eu.example.com:587",
+				"to":         map[string]any{"enabled": []any{"Jane Doe <jane.doe@example.com>"}},
+			},
+		})
+		c, _, receivers, states := newController(cr)
+
+		mailServer := "smtp-eu.example.com:587"
+		states.On("GetLatestAlertDefinitionStates", mock.Anything).Return([]models.AlertDefinition{}, nil).Once()
+		states.On("GetLatestReceiverStates", mock.Anything).Return([]models.Receiver{
+			{TenantID: tenantID, UUID: recvID, Version: 4, State: models.ReceiverApplied},
+		}, nil).Once()
+		receivers.On("GetLatestReceiverListWithEmailConfig", mock.Anything, tenantID, database.ListOptions{}).
+			Return([]*models.DBReceiver{recv}, int64(1), nil).Once()
+		receivers.On("SetReceiverValues", mock.Anything, tenantID, recvID, models.DBReceiverValues{
+			Recipients: []models.EmailAddress{{FirstName: "Jane", LastName: "Doe", Email: "jane.doe@example.com"}},
+			MailServer: &mailServer,
+		}).Return(nil).Once()
+
+		c.reconcile(context.Background())
+
+		require.Equal(t, int64(5), getStatus(t, c, alertReceiverResource, "receiver").Version)
+		receivers.AssertExpectations(t)
+	})
+
+	t.Run("Invalid recipient", func(t *testing.T) {
+		cr := newCustomResource("AlertReceiver", "receiver", 1, map[string]any{
+			"tenantID": tenantID,
+			"name":     "alert-monitor-config",
+			"emailConfig": map[string]any{
+				"to": map[string]any{"enabled": []any{"jane.doe@example.com"}},
+			},
+		})
+		c, _, receivers, states := newController(cr)
+
+		states.On("GetLatestAlertDefinitionStates", mock.Anything).Return([]models.AlertDefinition{}, nil).Once()
+		states.On("GetLatestReceiverStates", mock.Anything).Return([]models.Receiver{}, nil).Once()
+		receivers.On("GetLatestReceiverListWithEmailConfig", mock.Anything, tenantID, database.ListOptions{}).
+			Return([]*models.DBReceiver{recv}, int64(1), nil).Once()
+
+		c.reconcile(context.Background())
+
+		requireCondition(t, getStatus(t, c, alertReceiverResource, "receiver"), metav1.ConditionFalse, reasonInvalid)
+		receivers.AssertExpectations(t)
+	})
+}
+
+func TestSetStatus(t *testing.T) {
+	obj := newCustomResource("AlertDefinition", "high-cpu", 1, map[string]any{})
+	id := uuid.New()
+	status := resourceStatus{ObservedGeneration: 1, ID: &id, Version: 2}
+	setAppliedCondition(&status, metav1.ConditionTrue, string(models.DefinitionApplied), "version 2 is applied")
+
+	changed, err := setStatus(obj, status)
+	require.NoError(t, err)
+	require.True(t, changed)
+
+	res, err := fromUnstructured[struct{}](obj)
+	require.NoError(t, err)
+
+	changed, err = setStatus(obj, res.Status)
+	require.NoError(t, err)
+	require.False(t, changed)
+}
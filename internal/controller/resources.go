@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+)
+
+const (
+	// conditionApplied is the type of the status condition reporting whether the latest version of an alert definition or
+	// receiver has been applied.
+	conditionApplied = "Applied"
+
+	reasonNotFound = "NotFound"
+	reasonInvalid  = "Invalid"
+)
+
+var (
+	alertDefinitionResource = schema.GroupVersionResource{
+		Group:    "alerting.edge-orchestrator.intel.com",
+		Version:  "v1alpha1",
+		Resource: "alertdefinitions",
+	}
+	alertReceiverResource = schema.GroupVersionResource{
+		Group:    "alerting.edge-orchestrator.intel.com",
+		Version:  "v1alpha1",
+		Resource: "alertreceivers",
+	}
+)
+
+// alertDefinitionSpec is the spec of an AlertDefinition custom resource. It sets the values of the alert definition of the
+// tenant with the given name, given as in the body of alert definition patch requests of the REST API.
+type alertDefinitionSpec struct {
+	TenantID string `json:"tenantID"`
+	Name     string `json:"name"`
+	api.PatchProjectAlertDefinitionJSONBody
+}
+
+// alertReceiverSpec is the spec of an AlertReceiver custom resource. It sets the values of the receiver of the tenant with
+// the given name, given as in the body of receiver patch requests of the REST API.
+type alertReceiverSpec struct {
+	TenantID string `json:"tenantID"`
+	Name     string `json:"name"`
+	api.PatchProjectAlertReceiverJSONBody
+}
+
+// resourceStatus is the status of an AlertDefinition or AlertReceiver custom resource.
+type resourceStatus struct {
+	// ObservedGeneration is the generation of the spec last reconciled.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// ID is the UUID of the alert definition or receiver set by the spec.
+	ID *uuid.UUID `json:"id,omitempty"`
+	// Version is the latest version of the alert definition or receiver.
+	Version    int64              `json:"version,omitempty"`
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// resource is an AlertDefinition or AlertReceiver custom resource.
+type resource[T any] struct {
+	Metadata metav1.ObjectMeta `json:"metadata"`
+	Spec     T                 `json:"spec"`
+	Status   resourceStatus    `json:"status"`
+}
+
+// fromUnstructured converts an unstructured custom resource into a typed one.
+func fromUnstructured[T any](obj *unstructured.Unstructured) (*resource[T], error) {
+	data, err := json.Marshal(obj.Object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal custom resource: %w", err)
+	}
+
+	var res resource[T]
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal custom resource: %w", err)
+	}
+	return &res, nil
+}
+
+// setStatus sets the status of an unstructured custom resource. It returns whether the status changed.
+func setStatus(obj *unstructured.Unstructured, status resourceStatus) (bool, error) {
+	data, err := json.Marshal(status)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal status: %w", err)
+	}
+
+	var content map[string]any
+	if err := json.Unmarshal(data, &content); err != nil {
+		return false, fmt.Errorf("failed to unmarshal status: %w", err)
+	}
+
+	current, _, err := unstructured.NestedFieldCopy(obj.Object, "status")
+	if err != nil {
+		return false, fmt.Errorf("failed to get status: %w", err)
+	}
+	if equalJSON(current, content) {
+		return false, nil
+	}
+	return true, unstructured.SetNestedField(obj.Object, content, "status")
+}
+
+// equalJSON reports whether two values have the same JSON representation.
+func equalJSON(a, b any) bool {
+	dataA, errA := json.Marshal(a)
+	dataB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(dataA) == string(dataB)
+}
+
+// setAppliedCondition sets the Applied condition of a status, keeping its transition time if the condition status is unchanged.
+func setAppliedCondition(status *resourceStatus, conditionStatus metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               conditionApplied,
+		Status:             conditionStatus,
+		ObservedGeneration: status.ObservedGeneration,
+		Reason:             reason,
+		Message:            message,
+	})
+}
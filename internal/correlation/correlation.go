@@ -0,0 +1,37 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Package correlation propagates the correlation ID of API requests to the calls made to downstream services and to the
+// tasks created by the requests, so that a failed task can be traced back to the API call it originates from.
+package correlation
+
+import (
+	"context"
+	"net/http"
+)
+
+// Header is the header holding the correlation ID in incoming requests and in calls to downstream services.
+const Header = "X-Request-ID"
+
+type contextKey struct{}
+
+// NewContext returns a copy of the context holding the given correlation ID. The context is returned as is if the ID is empty.
+func NewContext(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the correlation ID held by the context, empty if there is none.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// SetHeader sets the correlation ID held by the context of the request, if any, as the header of the request.
+func SetHeader(req *http.Request) {
+	if id := FromContext(req.Context()); id != "" {
+		req.Header.Set(Header, id)
+	}
+}
@@ -0,0 +1,39 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package correlation
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestContext(t *testing.T) {
+	ctx := context.Background()
+	require.Empty(t, FromContext(ctx))
+	require.Equal(t, ctx, NewContext(ctx, ""))
+
+	ctx = NewContext(ctx, "request-id")
+	require.Equal(t, "request-id", FromContext(ctx))
+}
+
+func TestSetHeader(t *testing.T) {
+	t.Run("WithCorrelationID", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(NewContext(context.Background(), "request-id"), http.MethodGet, "http://mimir", nil)
+		require.NoError(t, err)
+
+		SetHeader(req)
+		require.Equal(t, "request-id", req.Header.Get(Header))
+	})
+
+	t.Run("WithoutCorrelationID", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "http://mimir", nil)
+		require.NoError(t, err)
+
+		SetHeader(req)
+		require.NotContains(t, req.Header, Header)
+	})
+}
@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+// GetKnownGoodAlertmanagerConfig gets the configuration manifest the given alertmanager instance last reloaded successfully.
+// An error wrapping gorm.ErrRecordNotFound is returned if none was recorded.
+func (d *DBService) GetKnownGoodAlertmanagerConfig(ctx context.Context, name string) ([]byte, error) {
+	var conf models.AlertmanagerConfig
+	if err := d.DB.WithContext(ctx).Where("name = ?", name).Take(&conf).Error; err != nil {
+		return nil, fmt.Errorf("failed to get known good configuration of alertmanager %q: %w", name, err)
+	}
+	return []byte(conf.Manifest), nil
+}
+
+// SetKnownGoodAlertmanagerConfig records the given configuration manifest as the one the given alertmanager instance last
+// reloaded successfully, along with the current time.
+func (d *DBService) SetKnownGoodAlertmanagerConfig(ctx context.Context, name string, manifest []byte) error {
+	conf := models.AlertmanagerConfig{
+		Name:       name,
+		Manifest:   string(manifest),
+		ReloadDate: clock.TimeNowFn().UTC(),
+	}
+
+	if err := d.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"manifest", "reload_date"}),
+	}).Create(&conf).Error; err != nil {
+		return fmt.Errorf("failed to set known good configuration of alertmanager %q: %w", name, err)
+	}
+	return nil
+}
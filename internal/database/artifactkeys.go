@@ -0,0 +1,152 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+// minArtifactKeySize is the minimum size of the keys of the key ring, which tenant keys of 32 bytes are derived from.
+const minArtifactKeySize = 32
+
+// ArtifactKeyRing holds the keys the content of applied artifacts is encrypted with. Content is encrypted with AES-256-GCM,
+// with a key derived by HKDF-SHA256 from a key of the key ring and the tenant of the artifact, so that the artifacts of a
+// tenant cannot be decrypted with the key of another. Content is authenticated along with the kind and name of its artifact,
+// so that it cannot be swapped with the content of another artifact.
+type ArtifactKeyRing struct {
+	activeKeyID string
+	keys        map[string][]byte
+}
+
+// NewArtifactKeyRing returns a key ring holding the given keys by ID, new artifacts being encrypted with the key of the given
+// active key ID.
+func NewArtifactKeyRing(activeKeyID string, keys map[string][]byte) (*ArtifactKeyRing, error) {
+	if _, ok := keys[activeKeyID]; !ok {
+		return nil, fmt.Errorf("active artifact encryption key %q is not in the key ring", activeKeyID)
+	}
+	for id, key := range keys {
+		if id == "" {
+			return nil, errors.New("artifact encryption key without ID")
+		}
+		if len(key) < minArtifactKeySize {
+			return nil, fmt.Errorf("artifact encryption key %q is shorter than %d bytes", id, minArtifactKeySize)
+		}
+	}
+	return &ArtifactKeyRing{activeKeyID: activeKeyID, keys: keys}, nil
+}
+
+// LoadArtifactKeyRing returns the key ring given by the ARTIFACT_ENCRYPTION_KEYS environment variable, a comma-separated list
+// of key IDs and base64-encoded keys, as in "id=key", new artifacts being encrypted with the key of the given active key ID.
+func LoadArtifactKeyRing(activeKeyID string) (*ArtifactKeyRing, error) {
+	value := os.Getenv("ARTIFACT_ENCRYPTION_KEYS")
+	if value == "" {
+		return nil, errors.New("artifact encryption keys are not set")
+	}
+
+	keys := make(map[string][]byte)
+	for _, entry := range strings.Split(value, ",") {
+		id, encoded, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			return nil, errors.New("invalid artifact encryption key, expected id=key")
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("invalid artifact encryption key %q: %w", id, err)
+		}
+		if _, ok := keys[id]; ok {
+			return nil, fmt.Errorf("duplicate artifact encryption key %q", id)
+		}
+		keys[id] = key
+	}
+	return NewArtifactKeyRing(activeKeyID, keys)
+}
+
+// ActiveKeyID returns the ID of the key new artifacts are encrypted with.
+func (r *ArtifactKeyRing) ActiveKeyID() string {
+	return r.activeKeyID
+}
+
+// KeyIDs returns the IDs of the keys of the key ring.
+func (r *ArtifactKeyRing) KeyIDs() []string {
+	ids := make([]string, 0, len(r.keys))
+	for id := range r.keys {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// seal encrypts the content of the artifact of the given kind and name with the active key, and returns the base64-encoded
+// nonce and ciphertext.
+func (r *ArtifactKeyRing) seal(kind models.AppliedArtifactKind, name string, content []byte) (string, error) {
+	aead, err := r.tenantAEAD(r.activeKeyID, name)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	sealed := aead.Seal(nonce, nonce, content, artifactAdditionalData(kind, name))
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// open decrypts the content of the given artifact, encrypted with the key of its key ID.
+func (r *ArtifactKeyRing) open(artifact models.AppliedArtifact) (string, error) {
+	aead, err := r.tenantAEAD(artifact.KeyID, artifact.Name)
+	if err != nil {
+		return "", err
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(artifact.Content)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", fmt.Errorf("invalid encrypted content of applied artifact %d", artifact.ID)
+	}
+	content, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():],
+		artifactAdditionalData(artifact.Kind, artifact.Name))
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt applied artifact %d: %w", artifact.ID, err)
+	}
+	return string(content), nil
+}
+
+// tenantAEAD returns the AEAD of the key derived from the key of the given ID for the tenant of the artifact of the given name.
+func (r *ArtifactKeyRing) tenantAEAD(keyID, name string) (cipher.AEAD, error) {
+	key, ok := r.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("unknown artifact encryption key %q", keyID)
+	}
+
+	tenantKey, err := hkdf.Key(sha256.New, key, nil, "applied-artifact:"+artifactTenant(name), 32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive artifact encryption key: %w", err)
+	}
+	block, err := aes.NewCipher(tenantKey)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// artifactTenant returns the tenant whose key encrypts the artifact of the given name: the tenant of Mimir rule groups, or the
+// namespace of alertmanager manifests, which hold the receivers of all tenants of their alertmanager instance.
+func artifactTenant(name string) string {
+	tenant, _, _ := strings.Cut(name, "/")
+	return tenant
+}
+
+func artifactAdditionalData(kind models.AppliedArtifactKind, name string) []byte {
+	return []byte(string(kind) + "\x00" + name)
+}
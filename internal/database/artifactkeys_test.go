@@ -0,0 +1,132 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+func TestLoadArtifactKeyRing(t *testing.T) {
+	key := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32))
+
+	t.Run("Valid key ring", func(t *testing.T) {
+		t.Setenv("ARTIFACT_ENCRYPTION_KEYS", "old="+key+", new="+key)
+		keys, err := LoadArtifactKeyRing("new")
+		require.NoError(t, err)
+		require.Equal(t, "new", keys.ActiveKeyID())
+		require.ElementsMatch(t, []string{"old", "new"}, keys.KeyIDs())
+	})
+
+	for name, tc := range map[string]struct {
+		keys      string
+		activeKey string
+	}{
+		"No keys":            {keys: "", activeKey: "new"},
+		"Missing active key": {keys: "old=" + key, activeKey: "new"},
+		"Key without ID":     {keys: key, activeKey: "new"},
+		"Invalid key":        {keys: "new=not base64", activeKey: "new"},
+		"Short key":          {keys: "new=" + base64.StdEncoding.EncodeToString([]byte("short")), activeKey: "new"},
+		"Duplicate key":      {keys: "new=" + key + ",new=" + key, activeKey: "new"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("ARTIFACT_ENCRYPTION_KEYS", tc.keys)
+			_, err := LoadArtifactKeyRing(tc.activeKey)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestAppliedArtifactEncryption(t *testing.T) {
+	conn, err := gorm.Open(sqlite.Open(":memory:"))
+	require.NoError(t, err)
+	require.NoError(t, conn.AutoMigrate(&models.AppliedArtifact{}))
+
+	oldKeys, err := NewArtifactKeyRing("old", map[string][]byte{"old": bytes.Repeat([]byte{1}, 32)})
+	require.NoError(t, err)
+	newKeys, err := NewArtifactKeyRing("new", map[string][]byte{
+		"old": bytes.Repeat([]byte{1}, 32),
+		"new": bytes.Repeat([]byte{2}, 32),
+	})
+	require.NoError(t, err)
+
+	plain := &DBService{DB: conn}
+	encrypted := &DBService{DB: conn, ArtifactKeys: oldKeys}
+	rotated := &DBService{DB: conn, ArtifactKeys: newKeys}
+
+	const content = "groups:\n  - name: host-cpu\n"
+	require.NoError(t, plain.RecordAppliedArtifact(t.Context(), models.ArtifactMimirRuleGroup, "tenant/host-cpu", []byte(content)))
+	require.NoError(t, encrypted.RecordAppliedArtifact(t.Context(), models.ArtifactMimirRuleGroup, "tenant/host-cpu",
+		[]byte(content+"  - name: host-memory\n")))
+	require.NoError(t, encrypted.RecordAppliedArtifact(t.Context(), models.ArtifactMimirRuleGroup, "other/host-cpu", []byte(content)))
+
+	var stored []models.AppliedArtifact
+	require.NoError(t, conn.Order("id").Find(&stored).Error)
+	require.Len(t, stored, 3)
+
+	t.Run("Content is encrypted with the active key", func(t *testing.T) {
+		require.Empty(t, stored[0].KeyID)
+		require.Equal(t, content, stored[0].Content)
+		require.Equal(t, "old", stored[1].KeyID)
+		require.NotContains(t, stored[1].Content, "host-cpu")
+		// The hash of the plain text is kept, so that unchanged content is still not recorded again.
+		require.Equal(t, stored[0].Hash, stored[2].Hash)
+	})
+
+	t.Run("Content is decrypted", func(t *testing.T) {
+		artifact, err := encrypted.GetAppliedArtifact(t.Context(), stored[1].ID)
+		require.NoError(t, err)
+		require.Equal(t, content+"  - name: host-memory\n", artifact.Content)
+
+		previous, err := encrypted.GetPreviousAppliedArtifact(t.Context(), *artifact)
+		require.NoError(t, err)
+		require.Equal(t, content, previous.Content)
+
+		_, err = plain.GetAppliedArtifact(t.Context(), stored[1].ID)
+		require.ErrorContains(t, err, "artifact encryption is not enabled")
+	})
+
+	t.Run("Content cannot be swapped between tenants", func(t *testing.T) {
+		require.NoError(t, conn.Model(&models.AppliedArtifact{}).Where("id = ?", stored[1].ID).
+			Update("content", stored[2].Content).Error)
+		_, err := encrypted.GetAppliedArtifact(t.Context(), stored[1].ID)
+		require.ErrorContains(t, err, "failed to decrypt applied artifact")
+
+		require.NoError(t, conn.Model(&models.AppliedArtifact{}).Where("id = ?", stored[1].ID).
+			Update("content", stored[1].Content).Error)
+	})
+
+	t.Run("Artifacts are re-encrypted with the active key", func(t *testing.T) {
+		n, err := rotated.RotateAppliedArtifactKeys(t.Context(), 2)
+		require.NoError(t, err)
+		require.Equal(t, int64(3), n)
+
+		var keyIDs []string
+		require.NoError(t, conn.Model(&models.AppliedArtifact{}).Order("id").Pluck("key_id", &keyIDs).Error)
+		require.Equal(t, []string{"new", "new", "new"}, keyIDs)
+
+		artifact, err := rotated.GetAppliedArtifact(t.Context(), stored[0].ID)
+		require.NoError(t, err)
+		require.Equal(t, content, artifact.Content)
+
+		_, err = encrypted.GetAppliedArtifact(t.Context(), stored[0].ID)
+		require.ErrorContains(t, err, `unknown artifact encryption key "new"`)
+
+		n, err = rotated.RotateAppliedArtifactKeys(t.Context(), 2)
+		require.NoError(t, err)
+		require.Zero(t, n)
+	})
+
+	t.Run("Rotation requires encryption", func(t *testing.T) {
+		_, err := plain.RotateAppliedArtifactKeys(t.Context(), 2)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,188 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+// RecordAppliedArtifact records the content of a configuration successfully applied downstream, along with its hash and the
+// current time. Nothing is recorded if the content is the same as the one last recorded for the artifact. The content is
+// encrypted with the active key of the artifact key ring, if set.
+func (d *DBService) RecordAppliedArtifact(ctx context.Context, kind models.AppliedArtifactKind, name string, content []byte) error {
+	hash := sha256.Sum256(content)
+	artifact := models.AppliedArtifact{
+		Kind:        kind,
+		Name:        name,
+		Hash:        hex.EncodeToString(hash[:]),
+		Content:     string(content),
+		AppliedDate: clock.TimeNowFn().UTC(),
+	}
+	if d.ArtifactKeys != nil {
+		sealed, err := d.ArtifactKeys.seal(kind, name, content)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt applied artifact %q: %w", name, err)
+		}
+		artifact.Content = sealed
+		artifact.KeyID = d.ArtifactKeys.ActiveKeyID()
+	}
+
+	tx := d.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	var latest models.AppliedArtifact
+	err := tx.Select("hash").Where("kind = ? AND name = ?", kind, name).Order("id desc").First(&latest).Error
+	switch {
+	case err == nil && latest.Hash == artifact.Hash:
+		return nil
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound):
+		return fmt.Errorf("failed to get latest applied artifact %q: %w", name, err)
+	}
+
+	if err := tx.Create(&artifact).Error; err != nil {
+		return fmt.Errorf("failed to record applied artifact %q: %w", name, err)
+	}
+	return tx.Commit().Error
+}
+
+// GetAppliedArtifacts gets the latest applied artifacts of the given kind and name, without their content, latest first. Empty
+// kind or name match any artifact.
+func (d *DBService) GetAppliedArtifacts(ctx context.Context, kind models.AppliedArtifactKind, name string, limit int) ([]models.AppliedArtifact, error) {
+	query := d.DB.WithContext(ctx).Select("id", "kind", "name", "hash", "applied_date")
+	if kind != "" {
+		query = query.Where("kind = ?", kind)
+	}
+	if name != "" {
+		query = query.Where("name = ?", name)
+	}
+
+	var artifacts []models.AppliedArtifact
+	if err := query.Order("id desc").Limit(limit).Find(&artifacts).Error; err != nil {
+		return nil, fmt.Errorf("failed to get applied artifacts: %w", err)
+	}
+	return artifacts, nil
+}
+
+// GetAppliedArtifact gets an applied artifact, including its content.
+func (d *DBService) GetAppliedArtifact(ctx context.Context, id int64) (*models.AppliedArtifact, error) {
+	var artifact models.AppliedArtifact
+	if err := d.DB.WithContext(ctx).Where("id = ?", id).Take(&artifact).Error; err != nil {
+		return nil, fmt.Errorf("failed to get applied artifact %d: %w", id, err)
+	}
+	if err := d.decryptAppliedArtifact(&artifact); err != nil {
+		return nil, err
+	}
+	return &artifact, nil
+}
+
+// GetPreviousAppliedArtifact gets the artifact of the same kind and name applied before the given one, including its content.
+// An error wrapping gorm.ErrRecordNotFound is returned if the given artifact is the first one applied.
+func (d *DBService) GetPreviousAppliedArtifact(ctx context.Context, artifact models.AppliedArtifact) (*models.AppliedArtifact, error) {
+	var previous models.AppliedArtifact
+	if err := d.DB.WithContext(ctx).
+		Where("kind = ? AND name = ?", artifact.Kind, artifact.Name).
+		Where("id < ?", artifact.ID).
+		Order("id desc").
+		First(&previous).Error; err != nil {
+		return nil, fmt.Errorf("failed to get artifact applied before %d: %w", artifact.ID, err)
+	}
+	if err := d.decryptAppliedArtifact(&previous); err != nil {
+		return nil, err
+	}
+	return &previous, nil
+}
+
+// decryptAppliedArtifact replaces the content of the given artifact with its plain text, if it is encrypted.
+func (d *DBService) decryptAppliedArtifact(artifact *models.AppliedArtifact) error {
+	if artifact.KeyID == "" {
+		return nil
+	}
+	if d.ArtifactKeys == nil {
+		return fmt.Errorf("applied artifact %d is encrypted, but artifact encryption is not enabled", artifact.ID)
+	}
+
+	content, err := d.ArtifactKeys.open(*artifact)
+	if err != nil {
+		return err
+	}
+	artifact.Content = content
+	return nil
+}
+
+// RotateAppliedArtifactKeys re-encrypts the applied artifacts encrypted with another key of the artifact key ring, or stored in
+// plain text, with the active key, batchSize at once. Artifacts encrypted with a key not in the key ring are left as is. The
+// number of re-encrypted artifacts is returned.
+func (d *DBService) RotateAppliedArtifactKeys(ctx context.Context, batchSize int) (int64, error) {
+	if d.ArtifactKeys == nil {
+		return 0, errors.New("artifact encryption is not enabled")
+	}
+
+	// Plain text artifacts have an empty key ID.
+	keyIDs := []string{""}
+	for _, id := range d.ArtifactKeys.KeyIDs() {
+		if id != d.ArtifactKeys.ActiveKeyID() {
+			keyIDs = append(keyIDs, id)
+		}
+	}
+
+	var rotated int64
+	for {
+		n, err := d.rotateAppliedArtifactBatch(ctx, keyIDs, batchSize)
+		rotated += n
+		if err != nil {
+			return rotated, err
+		}
+		if n < int64(batchSize) {
+			return rotated, nil
+		}
+	}
+}
+
+// rotateAppliedArtifactBatch re-encrypts up to batchSize applied artifacts encrypted with the given keys with the active key,
+// in a single transaction, and returns the number of re-encrypted artifacts. On PostgreSQL, the artifacts re-encrypted at the
+// same time by other replicas are skipped.
+func (d *DBService) rotateAppliedArtifactBatch(ctx context.Context, keyIDs []string, batchSize int) (int64, error) {
+	tx := d.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	query := tx
+	if supportsSkipLocked(tx) {
+		query = query.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate, Options: clause.LockingOptionsSkipLocked})
+	}
+	var artifacts []models.AppliedArtifact
+	if err := query.Where("key_id IN ?", keyIDs).Order("id").Limit(batchSize).Find(&artifacts).Error; err != nil {
+		return 0, fmt.Errorf("failed to get applied artifacts to re-encrypt: %w", err)
+	}
+
+	for _, artifact := range artifacts {
+		if err := d.decryptAppliedArtifact(&artifact); err != nil {
+			return 0, err
+		}
+		sealed, err := d.ArtifactKeys.seal(artifact.Kind, artifact.Name, []byte(artifact.Content))
+		if err != nil {
+			return 0, fmt.Errorf("failed to encrypt applied artifact %d: %w", artifact.ID, err)
+		}
+		if err := tx.Model(&models.AppliedArtifact{}).Where("id = ?", artifact.ID).Updates(map[string]any{
+			"content": sealed,
+			"key_id":  d.ArtifactKeys.ActiveKeyID(),
+		}).Error; err != nil {
+			return 0, fmt.Errorf("failed to re-encrypt applied artifact %d: %w", artifact.ID, err)
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return 0, err
+	}
+	return int64(len(artifacts)), nil
+}
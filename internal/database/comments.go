@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+// GetAlertComments gets the comments of the alert with the given fingerprint of a tenant, in the order they were added.
+func (d *DBService) GetAlertComments(ctx context.Context, tenantID api.TenantID, fingerprint string) ([]models.AlertComment, error) {
+	var comments []models.AlertComment
+	if err := d.DB.WithContext(ctx).
+		Where("tenant_id = ? AND fingerprint = ?", tenantID, fingerprint).
+		Order("id").
+		Find(&comments).Error; err != nil {
+		return nil, fmt.Errorf("failed to get comments of alert %q of tenant %q: %w", fingerprint, tenantID, err)
+	}
+	return comments, nil
+}
+
+// AddAlertComment adds the given comment to the alert it references, setting its ID. A reply must reference a comment of the
+// same alert, an error wrapping gorm.ErrRecordNotFound being returned otherwise.
+func (d *DBService) AddAlertComment(ctx context.Context, comment *models.AlertComment) error {
+	tx := d.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if comment.ParentID != nil {
+		var parent models.AlertComment
+		if err := tx.Where("tenant_id = ? AND fingerprint = ?", comment.TenantID, comment.Fingerprint).
+			Take(&parent, *comment.ParentID).Error; err != nil {
+			return fmt.Errorf("failed to get comment %d of alert %q of tenant %q: %w", *comment.ParentID, comment.Fingerprint,
+				comment.TenantID, err)
+		}
+	}
+
+	if err := tx.Create(comment).Error; err != nil {
+		return fmt.Errorf("failed to create comment of alert %q of tenant %q: %w", comment.Fingerprint, comment.TenantID, err)
+	}
+	return tx.Commit().Error
+}
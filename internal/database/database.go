@@ -17,12 +17,56 @@ import (
 	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
 )
 
+// SortField is a field list queries can be sorted by.
+type SortField string
+
+const (
+	SortByName      SortField = "name"
+	SortBySeverity  SortField = "severity"
+	SortByState     SortField = "state"
+	SortByUpdatedAt SortField = "updatedAt"
+)
+
+// SortOrder is the direction list queries are sorted in.
+type SortOrder string
+
+const (
+	SortAscending  SortOrder = "asc"
+	SortDescending SortOrder = "desc"
+)
+
+// ListOptions holds the pagination and sorting settings applied to list queries. A zero Limit means that no limit is applied.
+// Lists are sorted by name in ascending order unless specified otherwise.
+type ListOptions struct {
+	Limit  int
+	Offset int
+	SortBy SortField
+	Order  SortOrder
+}
+
+// TaskCleanupOptions holds the settings of the deletion of tasks past retention. A zero BatchSize means that the default
+// batch size is used. Invalid tasks are given to ArchiveInvalid, if set, before being deleted.
+type TaskCleanupOptions struct {
+	BatchSize      int
+	Pause          time.Duration
+	ArchiveInvalid func(ctx context.Context, tasks []models.Task) error
+}
+
+// PendingTaskOptions holds the settings of the taking of pending tasks. Up to CountLimit tasks are taken round-robin across
+// tenants, with at most TenantLimit tasks of a tenant unless zero. Tasks of the PausedKinds are not taken, and stay pending
+// until their kind is resumed.
+type PendingTaskOptions struct {
+	CountLimit  int
+	TenantLimit int
+	PausedKinds []models.TaskType
+}
+
 // AlertDefinitionHandlerManager is used to get a single alert definition or a list or alert definitions.
-// It also allows updating alert definition values such as duration, threshold, and enabled.
+// It also allows updating alert definition values such as duration, threshold, and enabled, and restoring their defaults.
 type AlertDefinitionHandlerManager interface {
-	// GetLatestAlertDefinitionList gets a list with the info on the latest version of alert definitions, including duration and threshold values
-	// as well as its enabled state.
-	GetLatestAlertDefinitionList(ctx context.Context, tenantID api.TenantID) ([]*models.DBAlertDefinition, error)
+	// GetLatestAlertDefinitionList gets a page of the list with the info on the latest version of alert definitions, including duration
+	// and threshold values as well as its enabled state. It also returns the total number of alert definitions regardless of pagination.
+	GetLatestAlertDefinitionList(ctx context.Context, tenantID api.TenantID, opts ListOptions) ([]*models.DBAlertDefinition, int64, error)
 
 	// GetLatestAlertDefinition gets the info on the latest version of alert definition, including its duration, threshold,
 	// and a flag specifying if the alert is enabled.
@@ -31,6 +75,10 @@ type AlertDefinitionHandlerManager interface {
 	// SetAlertDefinitionValues sets the duration and/or threshold values, and/or the enabled state of an alert definition
 	// given its UUID.
 	SetAlertDefinitionValues(ctx context.Context, tenantID api.TenantID, id uuid.UUID, values models.DBAlertDefinitionValues) error
+
+	// ResetAlertDefinitionValues restores the duration, threshold, and enabled state of an alert definition given its UUID to their
+	// defaults in the catalog, returning the values set.
+	ResetAlertDefinitionValues(ctx context.Context, tenantID api.TenantID, id uuid.UUID) (*models.DBAlertDefinitionValues, error)
 }
 
 // AlertDefinitionExecutorManager is used to get specific versions of alert definition.
@@ -45,18 +93,21 @@ type AlertDefinitionExecutorManager interface {
 }
 
 // ReceiverHandlerManager is used to get a versioned receiver or a list of versioned receivers. It also allows updating the list of email
-// recipients of a receiver, creating a new version. These operations only apply to the latest version of a receiver.
+// recipients, minimum severity, and quiet hours of a receiver, creating a new version. These operations only apply to the latest version of a receiver.
 type ReceiverHandlerManager interface {
-	// GetLatestReceiverListWithEmailConfig gets a list with information of receivers including its email configuration
-	// and its list of recipients.
-	GetLatestReceiverListWithEmailConfig(ctx context.Context, tenantID api.TenantID) ([]*models.DBReceiver, error)
+	// GetLatestReceiverListWithEmailConfig gets a page of the list with information of receivers including its email configuration
+	// and its list of recipients. It also returns the total number of receivers regardless of pagination.
+	GetLatestReceiverListWithEmailConfig(ctx context.Context, tenantID api.TenantID, opts ListOptions) ([]*models.DBReceiver, int64, error)
 
 	// GetLatestReceiverWithEmailConfig gets the information of a specific receiver, given its UUID, including its email configuration
 	// and its list of recipients.
 	GetLatestReceiverWithEmailConfig(ctx context.Context, tenantID api.TenantID, id uuid.UUID) (*models.DBReceiver, error)
 
-	// SetReceiverEmailRecipients sets the list of email recipients of a given receiver.
-	SetReceiverEmailRecipients(ctx context.Context, tenantID api.TenantID, id uuid.UUID, recipients []models.EmailAddress) error
+	// SetReceiverValues sets the list of email recipients, the minimum severity, and the quiet hours of a given receiver.
+	SetReceiverValues(ctx context.Context, tenantID api.TenantID, id uuid.UUID, values models.DBReceiverValues) error
+
+	// SetReceiversRecipients sets the list of email recipients of the given receivers at once.
+	SetReceiversRecipients(ctx context.Context, tenantID api.TenantID, recipients map[uuid.UUID][]models.EmailAddress) error
 }
 
 // ReceiverExecutorManager is used to get a specific version of a receiver as well as to set the state of a versioned receiver.
@@ -76,11 +127,14 @@ type TaskManager interface {
 
 	// DeleteNotPendingTasksExceedingDuration takes a duration and deletes tasks with Applied and Invalid state
 	// for which the time elapsed between the completion date and the current date exceeds the given duration.
-	DeleteNotPendingTasksExceedingDuration(ctx context.Context, dur time.Duration) error
+	// The summary of the deleted tasks is archived into the task history. Tasks are deleted in batches as given by the
+	// options, and the number of deleted tasks is returned.
+	DeleteNotPendingTasksExceedingDuration(ctx context.Context, dur time.Duration, opts TaskCleanupOptions) (int64, error)
 
-	// GetPendingTasks takes an owner UUID and a count. It returns a slice of tasks from database which have not been completed,
-	// and are not currently in Taken state. The slice has tasks with unique UUID and latest version.
-	GetPendingTasks(ctx context.Context, ownerUUID uuid.UUID, countLimit int) ([]models.Task, error)
+	// GetPendingTasks takes an owner UUID and the options of the tasks to take. It returns a slice of tasks from database which
+	// have not been completed, and are not currently in Taken state. The slice has tasks with unique UUID and latest version,
+	// taken round-robin across tenants, leaving out the tasks of paused kinds.
+	GetPendingTasks(ctx context.Context, ownerUUID uuid.UUID, opts PendingTaskOptions) ([]models.Task, error)
 
 	// SetOlderVersionsToInvalidState takes a slice of tasks, and sets tasks from database with same UUID and older versions as invalid.
 	SetOlderVersionsToInvalidState(ctx context.Context, tasks []models.Task) error
@@ -88,9 +142,10 @@ type TaskManager interface {
 	// SetTaskAsApplied takes a task and sets its state to Applied as well as the completion date.
 	SetTaskAsApplied(ctx context.Context, task models.Task) error
 
-	// SetTaskAsFailed takes a task and a retry limit. If the task retry count is less than the retry limit it sets the task
-	// to Error state, otherwise it sets the task to Invalid state.
-	SetTaskAsFailed(ctx context.Context, task models.Task, retryLimit int) error
+	// SetTaskAsFailed takes a task, a retry limit and the error the task failed with. If the task retry count is less than
+	// the retry limit it sets the task to Error state, otherwise it sets the task to Invalid state. The error is recorded as
+	// the error of the task.
+	SetTaskAsFailed(ctx context.Context, task models.Task, retryLimit int, cause error) error
 
 	// SetTaskAsInvalid takes a task and sets its status to Invalid and the completion date. It also sets the status of its
 	// secondary key (either alert definition or receiver) to Error.
@@ -98,6 +153,374 @@ type TaskManager interface {
 
 	// SetTaskStateToInvalid takes a task and sets its status to Invalid and the completion date.
 	SetTaskStateToInvalid(ctx context.Context, task models.Task) error
+
+	// RecordExecutorHeartbeat records the current time as the last heartbeat of the executor with the given UUID.
+	RecordExecutorHeartbeat(ctx context.Context, ownerUUID uuid.UUID) error
+
+	// RecordExecutorPoll records the current time as the last poll of the executor with the given UUID, and adds the given
+	// number of processed tasks to its count.
+	RecordExecutorPoll(ctx context.Context, ownerUUID uuid.UUID, processed int) error
+
+	// ReclaimOrphanedTasks sets the Taken tasks of registered executors without a heartbeat within the given timeout, and of
+	// the executor with the given UUID, back to New. It returns the number of reclaimed tasks.
+	ReclaimOrphanedTasks(ctx context.Context, ownerUUID uuid.UUID, heartbeatTimeout time.Duration) (int64, error)
+}
+
+// TenantManager is used to track the API activity of tenants, and to archive and unarchive the configuration of inactive tenants.
+type TenantManager interface {
+	// SetTenantActivity records the current time as the time of the last API activity of a tenant.
+	SetTenantActivity(ctx context.Context, tenantID api.TenantID) error
+
+	// GetInactiveTenants gets the tenants which are not archived and had no API activity within the given duration.
+	GetInactiveTenants(ctx context.Context, dur time.Duration) ([]api.TenantID, error)
+
+	// ArchiveTenant marks the configuration of a tenant as archived.
+	ArchiveTenant(ctx context.Context, tenantID api.TenantID) error
+
+	// UnarchiveTenant marks the configuration of an archived tenant as active, and queues its latest alert definitions and
+	// receivers to be applied again.
+	UnarchiveTenant(ctx context.Context, tenantID api.TenantID) error
+}
+
+// TenantTierManager is used to assign tenants the tier of service level they are entitled to.
+type TenantTierManager interface {
+	// GetTenantTier gets the tier of a tenant, empty if the tenant has no assigned tier.
+	GetTenantTier(ctx context.Context, tenantID api.TenantID) (string, error)
+
+	// SetTenantTier assigns a tier to a tenant, an empty tier unassigns it.
+	SetTenantTier(ctx context.Context, tenantID api.TenantID, tier string) error
+}
+
+// TenantMaintenanceManager is used to put tenants in maintenance mode, during which their alerts are silenced and the routes
+// of their receivers are muted.
+type TenantMaintenanceManager interface {
+	// GetTenantMaintenance gets the maintenance window of a tenant, nil if the tenant is not in maintenance mode.
+	GetTenantMaintenance(ctx context.Context, tenantID api.TenantID) (*models.MaintenanceWindow, error)
+
+	// SetTenantMaintenance puts a tenant in maintenance mode for the given window, a nil window takes it out of maintenance
+	// mode. The latest receivers of the tenant are queued to be applied again.
+	SetTenantMaintenance(ctx context.Context, tenantID api.TenantID, window *models.MaintenanceWindow) error
+}
+
+// EmailTemplateManager is used to get and set the versions of the template the HTML body of the emails of the receivers of
+// tenants is rendered with.
+type EmailTemplateManager interface {
+	// GetEmailTemplate gets the given version of the email template of a tenant, or its latest version if version is zero.
+	GetEmailTemplate(ctx context.Context, tenantID api.TenantID, version int64) (*models.EmailTemplate, error)
+
+	// SetEmailTemplate creates a new version of the email template of a tenant, an empty content restoring the template of the
+	// deployment. The latest receivers of the tenant are queued to be applied again.
+	SetEmailTemplate(ctx context.Context, tenantID api.TenantID, content string) (*models.EmailTemplate, error)
+}
+
+// AlertCommentManager is used to attach threaded comments to the alerts of tenants.
+type AlertCommentManager interface {
+	// GetAlertComments gets the comments of an alert of a tenant, in the order they were added.
+	GetAlertComments(ctx context.Context, tenantID api.TenantID, fingerprint string) ([]models.AlertComment, error)
+
+	// AddAlertComment adds a comment to an alert of a tenant. An error wrapping gorm.ErrRecordNotFound is returned if the
+	// comment replies to a comment which is not one of the alert.
+	AddAlertComment(ctx context.Context, comment *models.AlertComment) error
+}
+
+// EventHookManager is used to register the callback URLs tenants are notified at when their alert definitions and receivers
+// are applied or fail to be applied.
+type EventHookManager interface {
+	// GetEventHooks gets the event hooks of a tenant, in the order they were registered.
+	GetEventHooks(ctx context.Context, tenantID api.TenantID) ([]models.EventHook, error)
+
+	// GetEventHook gets an event hook of a tenant given its UUID.
+	GetEventHook(ctx context.Context, tenantID api.TenantID, id uuid.UUID) (*models.EventHook, error)
+
+	// CreateEventHook registers an event hook, unless its tenant already has the given maximum number of hooks.
+	CreateEventHook(ctx context.Context, hook *models.EventHook, maxHooks int) error
+
+	// UpdateEventHook sets the URL and events of an event hook.
+	UpdateEventHook(ctx context.Context, hook *models.EventHook) error
+
+	// DeleteEventHook deletes an event hook of a tenant given its UUID, along with its events pending delivery.
+	DeleteEventHook(ctx context.Context, tenantID api.TenantID, id uuid.UUID) error
+}
+
+// TenantShardManager is used to map tenants to the alertmanager shard holding their receivers and alerts.
+type TenantShardManager interface {
+	// GetTenantShard gets the alertmanager shard of a tenant out of the given number of shards.
+	GetTenantShard(ctx context.Context, tenantID api.TenantID, shards int) (int, error)
+}
+
+// ThresholdTuningManager is used to get the alert definitions which have threshold auto-tuning turned on, and to set the thresholds
+// computed for them.
+type ThresholdTuningManager interface {
+	// GetAutoTunedAlertDefinitions gets the info on the latest version of the enabled alert definitions of all tenants which have
+	// threshold auto-tuning turned on.
+	GetAutoTunedAlertDefinitions(ctx context.Context) ([]*models.DBAlertDefinition, error)
+
+	// SetAutoTunedThreshold sets the auto-tuned threshold of a given version of an alert definition, creating a new version. It returns
+	// whether a new version was created, which is not the case if the threshold is unchanged or the given version is not the latest one.
+	SetAutoTunedThreshold(ctx context.Context, tenantID api.TenantID, id uuid.UUID, version int64, threshold int64) (bool, error)
+}
+
+// EmailDeliveryRecorder is used to record the outcome of sending emails to the recipients of receivers, when emails are sent
+// by alerting monitor instead of alertmanager.
+type EmailDeliveryRecorder interface {
+	// RecordEmailDeliveries records the outcome of sending the email of a notification of a receiver to each of its recipients,
+	// and deletes the deliveries of the receiver recorded before the given retention period.
+	RecordEmailDeliveries(ctx context.Context, tenantID api.TenantID, id uuid.UUID, deliveries []models.EmailDelivery, retention time.Duration) error
+}
+
+// ConfigStateReporter is used to get the state of the latest version of the alert definitions and receivers of all tenants,
+// so that the health of their application can be exported as metrics and reported by the custom resource controller.
+type ConfigStateReporter interface {
+	// GetLatestAlertDefinitionStates gets the tenant, UUID, name, version, and state of the latest version of all alert definitions.
+	GetLatestAlertDefinitionStates(ctx context.Context) ([]models.AlertDefinition, error)
+
+	// GetLatestReceiverStates gets the tenant, UUID, name, version, and state of the latest version of all receivers.
+	GetLatestReceiverStates(ctx context.Context) ([]models.Receiver, error)
+}
+
+// AlertmanagerConfigStore is used to record the configuration manifest each alertmanager instance last reloaded successfully,
+// so that it can be restored if a newer one fails to reload.
+type AlertmanagerConfigStore interface {
+	// GetKnownGoodAlertmanagerConfig gets the configuration manifest the given alertmanager instance last reloaded successfully.
+	GetKnownGoodAlertmanagerConfig(ctx context.Context, name string) ([]byte, error)
+
+	// SetKnownGoodAlertmanagerConfig records the configuration manifest the given alertmanager instance last reloaded successfully.
+	SetKnownGoodAlertmanagerConfig(ctx context.Context, name string, manifest []byte) error
+}
+
+// AppliedArtifactManager is used to record the configurations successfully applied to alertmanager and Mimir, and to get them
+// back for comparison and rollback after an incident.
+type AppliedArtifactManager interface {
+	// RecordAppliedArtifact records the content of a configuration successfully applied downstream, unless unchanged.
+	RecordAppliedArtifact(ctx context.Context, kind models.AppliedArtifactKind, name string, content []byte) error
+
+	// GetAppliedArtifacts gets the latest applied artifacts of the given kind and name, without their content, latest first.
+	GetAppliedArtifacts(ctx context.Context, kind models.AppliedArtifactKind, name string, limit int) ([]models.AppliedArtifact, error)
+
+	// GetAppliedArtifact gets an applied artifact, including its content.
+	GetAppliedArtifact(ctx context.Context, id int64) (*models.AppliedArtifact, error)
+
+	// GetPreviousAppliedArtifact gets the artifact of the same kind and name applied before the given one, including its content.
+	GetPreviousAppliedArtifact(ctx context.Context, artifact models.AppliedArtifact) (*models.AppliedArtifact, error)
+}
+
+// AppliedArtifactKeyRotator is used to re-encrypt the applied artifacts with the active key of the artifact key ring.
+type AppliedArtifactKeyRotator interface {
+	// RotateAppliedArtifactKeys re-encrypts the applied artifacts encrypted with another key of the key ring, or stored in plain
+	// text, with the active key, batchSize at once. The number of re-encrypted artifacts is returned.
+	RotateAppliedArtifactKeys(ctx context.Context, batchSize int) (int64, error)
+}
+
+// TaskHistoryManager is used to get the history of the changes applied to alert definitions and receivers, including the
+// tasks deleted by the task retention.
+type TaskHistoryManager interface {
+	// GetTaskHistory gets the summary of the latest completed tasks of an alert definition or receiver given its UUID, latest first.
+	GetTaskHistory(ctx context.Context, tenantID api.TenantID, id uuid.UUID, limit int) ([]models.TaskHistory, error)
+
+	// GetTaskHistoryTrend gets the daily rollups of the completed tasks of an alert definition or receiver given its UUID, from
+	// the day of the given time, oldest first.
+	GetTaskHistoryTrend(ctx context.Context, tenantID api.TenantID, id uuid.UUID, since time.Time) ([]models.TaskHistoryRollup, error)
+}
+
+// TaskHistoryCompactor is used to bound the task history, by compacting it into daily rollups past its retention.
+type TaskHistoryCompactor interface {
+	// CompactTaskHistory compacts the task history completed before rawBefore into daily rollups, and deletes the rollups of the
+	// days before rollupBefore, unless it is zero. The number of compacted summaries and deleted rollups is returned.
+	CompactTaskHistory(ctx context.Context, rawBefore, rollupBefore time.Time, batchSize int) (int64, int64, error)
+}
+
+// NotificationEnqueuer is used to queue the notifications whose delivery failed for retry.
+type NotificationEnqueuer interface {
+	// EnqueueNotifications queues the given notifications for delivery, their first attempt being due immediately.
+	EnqueueNotifications(ctx context.Context, tasks []models.NotificationTask) error
+}
+
+// NotificationQueue is used to deliver the notifications queued for retry, and to manage the dead letters whose retries are
+// exhausted.
+type NotificationQueue interface {
+	// TakeDueNotifications takes up to the given number of notifications due for delivery on behalf of the given executor.
+	TakeDueNotifications(ctx context.Context, ownerUUID uuid.UUID, limit int, takenTimeout time.Duration) ([]models.NotificationTask, error)
+
+	// SetNotificationDelivered sets the given notification as delivered.
+	SetNotificationDelivered(ctx context.Context, task models.NotificationTask) error
+
+	// RetryNotification records the failed delivery of the given notification, and sets it to be retried at the given time.
+	RetryNotification(ctx context.Context, task models.NotificationTask, cause error, at time.Time) error
+
+	// DeadLetterNotification records the failed delivery of the given notification, and sets it as a dead letter.
+	DeadLetterNotification(ctx context.Context, task models.NotificationTask, cause error) error
+
+	// GetDeadLetterNotifications gets the latest dead letters of a tenant, or of all tenants if the tenant is empty.
+	GetDeadLetterNotifications(ctx context.Context, tenantID api.TenantID, limit int) ([]models.NotificationTask, error)
+
+	// RedriveNotification queues the dead letter with the given ID for delivery again.
+	RedriveNotification(ctx context.Context, id int64) error
+
+	// DeleteCompletedNotifications deletes the delivered and dead letter notifications completed before the given time.
+	DeleteCompletedNotifications(ctx context.Context, before time.Time) (int64, error)
+}
+
+// OperationReporter is used to report the progress of the operations of the API requests which apply changes asynchronously,
+// so that clients can await them.
+type OperationReporter interface {
+	// GetOperation gets the summary of the task of the given operation of a tenant.
+	GetOperation(ctx context.Context, tenantID api.TenantID, id uuid.UUID) (*models.TaskHistory, error)
+}
+
+// ExecutorRegistry is used to list the task executor instances, along with their heartbeat and the tasks they processed.
+type ExecutorRegistry interface {
+	// GetExecutors gets the registered executors, oldest first.
+	GetExecutors(ctx context.Context) ([]models.Executor, error)
+}
+
+// RecipientOffboardingManager is used to remove an email recipient from the receivers of all tenants once it is offboarded,
+// such as when an employee leaves the organization, and to get the receivers it was removed from.
+type RecipientOffboardingManager interface {
+	// OffboardRecipient removes the email recipient with the given address from the receivers of all tenants, and records the
+	// receivers it was removed from.
+	OffboardRecipient(ctx context.Context, email string) ([]models.RecipientOffboarding, error)
+
+	// GetRecipientOffboardings gets the records of the receivers the email recipient with the given address was removed from,
+	// latest first.
+	GetRecipientOffboardings(ctx context.Context, email string) ([]models.RecipientOffboarding, error)
+}
+
+// EmailVerificationManager is used to keep track of the verification of the email addresses of recipients, which are not
+// notified until their address is confirmed.
+type EmailVerificationManager interface {
+	// ClaimEmailVerifications gets the email addresses among the given ones that are pending verification and were not sent a
+	// verification email within the given period, and records that a verification email is sent to them now.
+	ClaimEmailVerifications(ctx context.Context, emails []string, resendAfter time.Duration) ([]models.EmailAddress, error)
+
+	// VerifyEmailAddress sets the given email address as verified, updating the receivers it is a recipient of so that it is
+	// notified.
+	VerifyEmailAddress(ctx context.Context, email string) error
+}
+
+// ConfigSnapshotManager is used to snapshot the alerting configuration of all tenants, and to restore it for disaster recovery
+// of the database.
+type ConfigSnapshotManager interface {
+	// GetConfigSnapshot gets every version of the alert definitions and receivers of all tenants, along with the tenants.
+	GetConfigSnapshot(ctx context.Context) (*models.ConfigSnapshot, error)
+
+	// RestoreConfigSnapshot replays a configuration snapshot, creating new versions of the alert definitions and receivers whose
+	// latest version differs from the one of the snapshot.
+	RestoreConfigSnapshot(ctx context.Context, snapshot *models.ConfigSnapshot) (RestoreResult, error)
+}
+
+// RuleEvaluationRecorder is used to record the evaluations of the rule groups of alert definitions by Mimir ruler over a
+// rolling window.
+type RuleEvaluationRecorder interface {
+	// RecordRuleEvaluations records evaluations of rule groups, and deletes the evaluations recorded before the given window.
+	RecordRuleEvaluations(ctx context.Context, evaluations []models.RuleEvaluation, window time.Duration) error
+}
+
+// RuleEvaluationReporter is used to get the health of the evaluation of the rule groups of alert definitions.
+type RuleEvaluationReporter interface {
+	// GetRuleEvaluationHealth summarizes the recorded evaluations of the rule groups of the given alert definitions of a tenant.
+	GetRuleEvaluationHealth(ctx context.Context, tenantID api.TenantID, ids []uuid.UUID) (map[uuid.UUID]models.RuleEvaluationHealth, error)
+}
+
+// AlertReportGenerator is used to generate the periodic reports of the alert volume of tenants.
+type AlertReportGenerator interface {
+	// GetActiveTenants gets the tenants which own alert definitions or receivers and are not archived.
+	GetActiveTenants(ctx context.Context) ([]api.TenantID, error)
+
+	// GetAlertVolume summarizes the alert volume of a tenant between the given start, inclusive, and end, exclusive, reporting
+	// the given number of noisiest alert groups.
+	GetAlertVolume(ctx context.Context, tenantID api.TenantID, start, end time.Time, top int) (*models.AlertVolume, error)
+
+	// HasAlertReport tells whether the report of a tenant for the period starting at the given time is already generated.
+	HasAlertReport(ctx context.Context, tenantID api.TenantID, periodStart time.Time) (bool, error)
+
+	// CreateAlertReport stores the given report, returning false if the report of the tenant for the same period is already
+	// stored.
+	CreateAlertReport(ctx context.Context, report *models.AlertReport) (bool, error)
+}
+
+// AlertReportReader is used to get the reports of the alert volume of a tenant.
+type AlertReportReader interface {
+	// GetAlertReports gets the reports of a tenant, the latest first, without their content.
+	GetAlertReports(ctx context.Context, tenantID api.TenantID) ([]models.AlertReport, error)
+
+	// GetAlertReport gets the report of a tenant with the given ID.
+	GetAlertReport(ctx context.Context, tenantID api.TenantID, id int64) (*models.AlertReport, error)
+}
+
+// sortList orders a list query by the sort field of the given list options. Name and UUID are used as tie-breakers
+// to keep the order stable across pages. The severity column holds the severity of the listed resource.
+func sortList(tx *gorm.DB, opts ListOptions, severityColumn string) (*gorm.DB, error) {
+	var expr string
+	switch opts.SortBy {
+	case "", SortByName:
+		expr = "name"
+	case SortBySeverity:
+		expr = severityRank(severityColumn)
+	case SortByState:
+		expr = "state"
+	case SortByUpdatedAt:
+		expr = "creation_date"
+	default:
+		return nil, fmt.Errorf("unsupported sort field %q", opts.SortBy)
+	}
+
+	switch opts.Order {
+	case "", SortAscending:
+	case SortDescending:
+		expr += " DESC"
+	default:
+		return nil, fmt.Errorf("unsupported sort order %q", opts.Order)
+	}
+
+	return tx.Order(expr).Order("name").Order("uuid"), nil
+}
+
+// severityRank returns an SQL expression ranking the severity held by the given column, so that severities
+// are sorted from the least to the most severe one. Unknown severities are ranked the lowest.
+func severityRank(column string) string {
+	return fmt.Sprintf("CASE %s WHEN '%s' THEN 1 WHEN '%s' THEN 2 WHEN '%s' THEN 3 ELSE 0 END",
+		column, models.SeverityInfo, models.SeverityWarning, models.SeverityCritical)
+}
+
+// paginate applies the limit and offset of the given list options to a query.
+func paginate(tx *gorm.DB, opts ListOptions) *gorm.DB {
+	if opts.Limit > 0 {
+		tx = tx.Limit(opts.Limit)
+	}
+	if opts.Offset > 0 {
+		tx = tx.Offset(opts.Offset)
+	}
+	return tx
+}
+
+// versionTimestamps gets the creation time of the first version of an alert definition or receiver, given by model, and the time
+// the latest version up to the given one was applied. The applied time is nil if none of these versions was applied.
+func versionTimestamps(tx *gorm.DB, model any, tenantID api.TenantID, id uuid.UUID, version int64) (time.Time, *time.Time, error) {
+	var versions []struct {
+		CreationDate time.Time
+		AppliedDate  *time.Time
+	}
+	if err := tx.
+		Model(model).
+		Select("creation_date", "applied_date").
+		Where("tenant_id = ?", tenantID).
+		Where("uuid = ?", id).
+		Where("version <= ?", version).
+		Order("version").
+		Find(&versions).Error; err != nil {
+		return time.Time{}, nil, fmt.Errorf("failed to retrieve versions of %q for tenant %q: %w", id, tenantID, err)
+	}
+	if len(versions) == 0 {
+		return time.Time{}, nil, fmt.Errorf("no versions of %q found for tenant %q: %w", id, tenantID, gorm.ErrRecordNotFound)
+	}
+
+	var appliedDate *time.Time
+	for _, v := range versions {
+		if v.AppliedDate != nil {
+			appliedDate = v.AppliedDate
+		}
+	}
+	return versions[0].CreationDate, appliedDate, nil
 }
 
 func ConnectDB() (*gorm.DB, error) {
@@ -112,5 +535,12 @@ func ConnectDB() (*gorm.DB, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to establish database connection: %w", err)
 	}
+
+	// Read-only queries of the API can be offloaded to a read replica, given by its DSN.
+	if replicaDSN := os.Getenv("PG_READ_REPLICA_DSN"); replicaDSN != "" {
+		if err := UseReadReplica(db, replicaDSN); err != nil {
+			return nil, err
+		}
+	}
 	return db, nil
 }
@@ -4,9 +4,30 @@
 package database
 
 import (
+	"context"
+	"fmt"
+	"time"
+
 	"gorm.io/gorm"
 )
 
 type DBService struct {
 	DB *gorm.DB
+	// ArtifactKeys encrypts the content of applied artifacts at rest. Content is stored in plain text if not set.
+	ArtifactKeys *ArtifactKeyRing
+	// EventHooks enables the queueing of the events of the state transitions of alert definitions and receivers to the event
+	// hooks of their tenant.
+	EventHooks bool
+	// AlertmanagerShards is the number of alertmanager instances tenants are sharded across, new tenants being assigned their
+	// shard when they are registered. New tenants are left without a shard if zero.
+	AlertmanagerShards int
+}
+
+// Now returns the current time of the database server, which the local clock is checked against for skew.
+func (d *DBService) Now(ctx context.Context) (time.Time, error) {
+	var now time.Time
+	if err := d.DB.WithContext(ctx).Raw("SELECT CURRENT_TIMESTAMP").Row().Scan(&now); err != nil {
+		return time.Time{}, fmt.Errorf("failed to get database time: %w", err)
+	}
+	return now, nil
 }
@@ -5,6 +5,7 @@ package database_test
 
 import (
 	"context"
+	"errors"
 	"strconv"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/correlation"
 	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
 	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
 )
@@ -32,10 +34,10 @@ var _ = Describe("Database", func() {
 	BeforeEach(func() {
 		dbConn, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"))
 		Expect(err).ToNot(HaveOccurred())
-		db = &database.DBService{dbConn}
+		db = &database.DBService{DB: dbConn}
 
 		clock.SetFakeClock()
-		clock.FakeClock.Set(time.Now())
+		clock.FakeClock.Set(time.Now().UTC())
 	})
 
 	AfterEach(func() {
@@ -56,6 +58,7 @@ var _ = Describe("Database", func() {
 				&models.AlertThreshold{},
 				&models.AlertDefinition{},
 				&models.Task{},
+				&models.Tenant{},
 			)).ShouldNot(HaveOccurred())
 		})
 
@@ -65,7 +68,7 @@ var _ = Describe("Database", func() {
 				defer cancel()
 
 				tenantID := "edgenode"
-				alertDefinitions, err := db.GetLatestAlertDefinitionList(ctx, tenantID)
+				alertDefinitions, _, err := db.GetLatestAlertDefinitionList(ctx, tenantID, database.ListOptions{})
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(alertDefinitions).To(BeEmpty())
 			})
@@ -184,10 +187,13 @@ labels:
 						Duration:  &dur,
 						Threshold: &thres,
 						Enabled:   &def.Enabled,
+						AutoTune:  &def.AutoTune,
 					},
-					Version:  def.Version,
-					Category: def.Category,
-					TenantID: def.TenantID,
+					Version:   def.Version,
+					Category:  def.Category,
+					TenantID:  def.TenantID,
+					CreatedAt: clock.FakeClock.Now(),
+					UpdatedAt: clock.FakeClock.Now(),
 				}
 
 				By("creating a newer version of the alert definition which was successfully applied")
@@ -228,10 +234,13 @@ labels:
 						Duration:  &latestDur,
 						Threshold: &latestThres,
 						Enabled:   &latestDef.Enabled,
+						AutoTune:  &latestDef.AutoTune,
 					},
-					Version:  latestDef.Version,
-					Category: latestDef.Category,
-					TenantID: latestDef.TenantID,
+					Version:   latestDef.Version,
+					Category:  latestDef.Category,
+					TenantID:  latestDef.TenantID,
+					CreatedAt: clock.FakeClock.Now(),
+					UpdatedAt: clock.FakeClock.Now(),
 				}
 
 				By("creating a newer version of the alert definition which was not successfully applied")
@@ -279,18 +288,44 @@ labels:
 				ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
 				defer cancel()
 
-				resList, err := db.GetLatestAlertDefinitionList(ctx, defTenantID)
+				resList, _, err := db.GetLatestAlertDefinitionList(ctx, defTenantID, database.ListOptions{})
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(resList).To(HaveLen(1))
 				Expect(resList[0]).To(Equal(defInfoModified))
 			})
 
+			It("Get the states of the latest versions of alert definitions", func() {
+				ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
+				defer cancel()
+
+				By("getting the state of the latest version, which could not be applied")
+				states, err := db.GetLatestAlertDefinitionStates(ctx)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(states).To(HaveLen(1))
+				Expect(states[0]).To(MatchFields(IgnoreExtras, Fields{
+					"TenantID": Equal(defInfoError.TenantID),
+					"UUID":     Equal(defInfoError.ID),
+					"Name":     Equal(defInfoError.Name),
+					"State":    Equal(models.DefinitionError),
+				}))
+
+				By("leaving out alert definitions of archived tenants")
+				archivedDate := clock.FakeClock.Now()
+				Expect(db.DB.WithContext(ctx).Create(&models.Tenant{
+					TenantID: defTenantID, LastActivityDate: clock.FakeClock.Now(), ArchivedDate: &archivedDate,
+				}).Error).ShouldNot(HaveOccurred())
+
+				states, err = db.GetLatestAlertDefinitionStates(ctx)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(states).To(BeEmpty())
+			})
+
 			It("Get empty list with latest versions of successfully applied alert definitions because there are no alert definitions matching the tenant ID",
 				func() {
 					ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
 					defer cancel()
 
-					resList, err := db.GetLatestAlertDefinitionList(ctx, "wrong_tenant")
+					resList, _, err := db.GetLatestAlertDefinitionList(ctx, "wrong_tenant", database.ListOptions{})
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(resList).To(BeEmpty())
 				})
@@ -328,6 +363,40 @@ labels:
 				Expect(res).To(Equal(defInfoModified))
 			})
 
+			It("Get the creation, update and application times of an alert definition", func() {
+				ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
+				defer cancel()
+
+				createdAt := clock.FakeClock.Now()
+
+				By("setting the application time of the first version")
+				appliedAt := createdAt.Add(10 * time.Second)
+				Expect(db.DB.WithContext(ctx).Model(&models.AlertDefinition{}).Where("id = ?", 1).
+					UpdateColumn("applied_date", appliedAt).Error).ShouldNot(HaveOccurred())
+
+				By("creating a new version of the alert definition later on")
+				updatedAt := createdAt.Add(time.Minute)
+				clock.FakeClock.Set(updatedAt)
+				newEnabled := false
+				Expect(db.SetAlertDefinitionValues(ctx, defTenantID, defUUID, models.DBAlertDefinitionValues{
+					Enabled: &newEnabled,
+				})).ShouldNot(HaveOccurred())
+
+				By("checking the times of the latest version")
+				res, err := db.GetLatestAlertDefinition(ctx, defTenantID, defUUID)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(res.CreatedAt).To(BeTemporally("==", createdAt))
+				Expect(res.UpdatedAt).To(BeTemporally("==", updatedAt))
+				Expect(res.AppliedAt).To(PointTo(BeTemporally("==", appliedAt)))
+
+				By("checking the times of the first version")
+				res, err = db.GetAlertDefinition(ctx, defTenantID, defUUID, defInfoInitial.Version)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(res.CreatedAt).To(BeTemporally("==", createdAt))
+				Expect(res.UpdatedAt).To(BeTemporally("==", createdAt))
+				Expect(res.AppliedAt).To(PointTo(BeTemporally("==", appliedAt)))
+			})
+
 			It("Fail to get a specific version of an alert definition because there is no alert definition with that version", func() {
 				ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
 				defer cancel()
@@ -347,7 +416,7 @@ labels:
 			})
 
 			It("Set the duration value of an alert definition", func() {
-				ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
+				ctx, cancel := context.WithTimeout(correlation.NewContext(context.Background(), "request-id"), dbQueryTimeout)
 				defer cancel()
 
 				By("setting the duration value of the definition")
@@ -378,7 +447,7 @@ labels:
 				Expect(res).To(Equal(&newDefInfo))
 
 				By("getting alert definition list")
-				resList, err := db.GetLatestAlertDefinitionList(ctx, defTenantID)
+				resList, _, err := db.GetLatestAlertDefinitionList(ctx, defTenantID, database.ListOptions{})
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(resList).To(Equal([]*models.DBAlertDefinition{&newDefInfo}))
 
@@ -392,6 +461,7 @@ labels:
 					"CreationDate":        BeTemporally("==", clock.FakeClock.Now()),
 					"State":               Equal(models.TaskNew),
 					"RetryCount":          Equal(int64(0)),
+					"CorrelationID":       Equal("request-id"),
 				}))
 			})
 
@@ -428,7 +498,7 @@ labels:
 				Expect(res).To(Equal(&newDefInfo))
 
 				By("getting the alert definition list")
-				resList, err := db.GetLatestAlertDefinitionList(ctx, defTenantID)
+				resList, _, err := db.GetLatestAlertDefinitionList(ctx, defTenantID, database.ListOptions{})
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(resList).To(Equal([]*models.DBAlertDefinition{&newDefInfo}))
 
@@ -466,7 +536,7 @@ labels:
 				Expect(res).To(Equal(&newDefInfo))
 
 				By("getting the alert definition list")
-				resList, err := db.GetLatestAlertDefinitionList(ctx, defTenantID)
+				resList, _, err := db.GetLatestAlertDefinitionList(ctx, defTenantID, database.ListOptions{})
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(resList).To(Equal([]*models.DBAlertDefinition{&newDefInfo}))
 
@@ -484,6 +554,108 @@ labels:
 				}))
 			})
 
+			It("Set an auto-tuned threshold of an alert definition", func() {
+				ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
+				defer cancel()
+
+				By("getting no auto-tuned alert definitions before turning auto-tuning on")
+				resList, err := db.GetAutoTunedAlertDefinitions(ctx)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(resList).To(BeEmpty())
+
+				By("setting the application time of the versions before auto-tuning")
+				appliedAt := clock.FakeClock.Now().Add(10 * time.Second)
+				Expect(db.DB.WithContext(ctx).Model(&models.AlertDefinition{}).
+					Where("tenant_id = ?", defTenantID).Where("uuid = ?", defUUID).
+					UpdateColumn("applied_date", appliedAt).Error).ShouldNot(HaveOccurred())
+
+				By("turning auto-tuning on")
+				autoTune := true
+				Expect(db.SetAlertDefinitionValues(ctx, defTenantID, defUUID, models.DBAlertDefinitionValues{
+					AutoTune: &autoTune,
+				})).ShouldNot(HaveOccurred())
+
+				resList, err = db.GetAutoTunedAlertDefinitions(ctx)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(resList).To(HaveLen(1))
+				Expect(resList[0].Version).To(Equal(defInfoError.Version + 1))
+				Expect(resList[0].Values.AutoTune).To(Equal(&autoTune))
+				Expect(resList[0].ThresholdAutoTuned).To(BeFalse())
+
+				latest, err := db.GetLatestAlertDefinition(ctx, defTenantID, defUUID)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(resList[0]).To(Equal(latest))
+				Expect(resList[0].AppliedAt).To(PointTo(BeTemporally("==", appliedAt)))
+
+				By("failing to set the threshold of a version which is not the latest one")
+				updated, err := db.SetAutoTunedThreshold(ctx, defTenantID, defUUID, defInfoError.Version, 50)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(updated).To(BeFalse())
+
+				By("setting a threshold above the maximum allowed, which is clamped")
+				updated, err = db.SetAutoTunedThreshold(ctx, defTenantID, defUUID, resList[0].Version, 250)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(updated).To(BeTrue())
+
+				res, err := db.GetLatestAlertDefinition(ctx, defTenantID, defUUID)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(res.Version).To(Equal(resList[0].Version + 1))
+				Expect(*res.Values.Threshold).To(Equal(int64(200)))
+				Expect(res.Values.AutoTune).To(Equal(&autoTune))
+				Expect(res.ThresholdAutoTuned).To(BeTrue())
+
+				By("not creating a new version if the threshold is unchanged")
+				updated, err = db.SetAutoTunedThreshold(ctx, defTenantID, defUUID, res.Version, 300)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(updated).To(BeFalse())
+
+				By("getting a task for every new version")
+				var tasks []models.Task
+				Expect(db.DB.WithContext(ctx).Find(&tasks).Error).ShouldNot(HaveOccurred())
+				Expect(tasks).To(HaveLen(2))
+
+				By("replacing the auto-tuned threshold with a threshold set by a user")
+				newThreshold := int64(30)
+				Expect(db.SetAlertDefinitionValues(ctx, defTenantID, defUUID, models.DBAlertDefinitionValues{
+					Threshold: &newThreshold,
+				})).ShouldNot(HaveOccurred())
+
+				res, err = db.GetLatestAlertDefinition(ctx, defTenantID, defUUID)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(*res.Values.Threshold).To(Equal(newThreshold))
+				Expect(res.Values.AutoTune).To(Equal(&autoTune))
+				Expect(res.ThresholdAutoTuned).To(BeFalse())
+			})
+
+			It("Reset the values of an alert definition to their defaults", func() {
+				ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
+				defer cancel()
+
+				By("setting the defaults of the latest version of the alert definition")
+				Expect(db.DB.WithContext(ctx).Model(&models.AlertDuration{}).Where("id = ?", 21).
+					Update("duration_default", 5).Error).ShouldNot(HaveOccurred())
+				Expect(db.DB.WithContext(ctx).Model(&models.AlertThreshold{}).Where("id = ?", 201).
+					Update("threshold_default", 50).Error).ShouldNot(HaveOccurred())
+
+				By("resetting the values of the definition")
+				values, err := db.ResetAlertDefinitionValues(ctx, defTenantID, defUUID)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(*values.Duration).To(Equal(int64(5)))
+				Expect(*values.Threshold).To(Equal(int64(50)))
+				Expect(*values.Enabled).To(BeTrue())
+
+				By("getting the alert definition")
+				res, err := db.GetLatestAlertDefinition(ctx, defTenantID, defUUID)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(res.Version).To(Equal(defInfoError.Version + 1))
+				Expect(res.Values.Duration).To(Equal(values.Duration))
+				Expect(res.Values.Threshold).To(Equal(values.Threshold))
+
+				By("failing to reset the values of an alert definition of another tenant")
+				_, err = db.ResetAlertDefinitionValues(ctx, "other-tenant", defUUID)
+				Expect(err).To(MatchError(gorm.ErrRecordNotFound))
+			})
+
 			It("Fail to set the duration value of an alert definition", func() {
 				ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
 				defer cancel()
@@ -496,6 +668,9 @@ labels:
 				Expect(err).To(MatchError(ContainSubstring("failed to set duration to new alert definition")))
 				Expect(err).To(MatchError(ContainSubstring("duration value out of valid range [3, 30]")))
 				Expect(err).To(MatchError(database.ErrValueOutOfBounds))
+				var oob *database.OutOfBoundsError
+				Expect(errors.As(err, &oob)).To(BeTrue())
+				Expect(*oob).To(Equal(database.OutOfBoundsError{Definition: defInfoModified.Name, Value: "duration", Requested: 45, Min: 3, Max: 30}))
 
 				By("checking that the alert definition was not modified")
 				res, err := db.GetLatestAlertDefinition(ctx, defTenantID, defUUID)
@@ -693,10 +868,13 @@ labels:
 						Duration:  &dur1,
 						Threshold: &thres1,
 						Enabled:   &def1.Enabled,
+						AutoTune:  &def1.AutoTune,
 					},
-					Version:  def1.Version,
-					Category: def1.Category,
-					TenantID: def1.TenantID,
+					Version:   def1.Version,
+					Category:  def1.Category,
+					TenantID:  def1.TenantID,
+					CreatedAt: clock.FakeClock.Now(),
+					UpdatedAt: clock.FakeClock.Now(),
 				}
 
 				By("creating second alert definition")
@@ -757,10 +935,13 @@ labels:
 						Duration:  &dur2,
 						Threshold: &thres2,
 						Enabled:   &def2.Enabled,
+						AutoTune:  &def2.AutoTune,
 					},
-					Version:  def2.Version,
-					Category: def2.Category,
-					TenantID: def2.TenantID,
+					Version:   def2.Version,
+					Category:  def2.Category,
+					TenantID:  def2.TenantID,
+					CreatedAt: clock.FakeClock.Now(),
+					UpdatedAt: clock.FakeClock.Now(),
 				}
 			})
 
@@ -768,7 +949,7 @@ labels:
 				ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
 				defer cancel()
 
-				resList, err := db.GetLatestAlertDefinitionList(ctx, defInfo1.TenantID)
+				resList, _, err := db.GetLatestAlertDefinitionList(ctx, defInfo1.TenantID, database.ListOptions{})
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(resList).To(HaveLen(1))
 				Expect(resList[0]).To(Equal(defInfo1))
@@ -778,7 +959,7 @@ labels:
 				ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
 				defer cancel()
 
-				resList, err := db.GetLatestAlertDefinitionList(ctx, defInfo2.TenantID)
+				resList, _, err := db.GetLatestAlertDefinitionList(ctx, defInfo2.TenantID, database.ListOptions{})
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(resList).To(HaveLen(1))
 				Expect(resList[0]).To(Equal(defInfo2))
@@ -788,7 +969,7 @@ labels:
 				ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
 				defer cancel()
 
-				resList, err := db.GetLatestAlertDefinitionList(ctx, "wrong_tenant")
+				resList, _, err := db.GetLatestAlertDefinitionList(ctx, "wrong_tenant", database.ListOptions{})
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(resList).To(BeEmpty())
 			})
@@ -821,6 +1002,135 @@ labels:
 			})
 		})
 
+		Context("With multiple alert definitions stored", func() {
+			tenantID := "edgenode"
+
+			BeforeEach(func() {
+				ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
+				defer cancel()
+
+				now := time.Now().UTC().Truncate(time.Second)
+				defs := []models.AlertDefinition{
+					{ID: 1, UUID: uuid.New(), Name: "alert-c", State: models.DefinitionApplied, Category: models.CategoryHealth,
+						Severity: "info", CreationDate: now.Add(-time.Hour)},
+					{ID: 2, UUID: uuid.New(), Name: "alert-a", State: models.DefinitionModified, Category: models.CategoryHealth,
+						Severity: "critical", CreationDate: now.Add(-2 * time.Hour)},
+					{ID: 3, UUID: uuid.New(), Name: "alert-b", State: models.DefinitionApplied, Category: models.CategoryPerformance,
+						Severity: "warning", CreationDate: now.Add(-3 * time.Hour)},
+					{ID: 4, UUID: uuid.New(), Name: "alert-maintenance", State: models.DefinitionApplied, Category: models.CategoryMaintenance,
+						Severity: "critical", CreationDate: now},
+				}
+				// Latest version of "alert-b" failed to be applied, so the previous one is expected to be listed.
+				defs = append(defs, models.AlertDefinition{
+					ID: 5, UUID: defs[2].UUID, Name: "alert-b", State: models.DefinitionError, Category: models.CategoryPerformance,
+					Severity: "warning", Version: 2, CreationDate: now,
+				})
+
+				for _, def := range defs {
+					if def.Version == 0 {
+						def.Version = 1
+					}
+					def.Enabled = true
+					def.TenantID = tenantID
+					Expect(db.DB.WithContext(ctx).Create(&def).Error).ShouldNot(HaveOccurred())
+					Expect(db.DB.WithContext(ctx).Create(&models.AlertDuration{
+						ID:                def.ID,
+						Name:              "duration",
+						Duration:          30,
+						DurationMin:       10,
+						DurationMax:       60,
+						AlertDefinitionID: def.ID,
+					}).Error).ShouldNot(HaveOccurred())
+					Expect(db.DB.WithContext(ctx).Create(&models.AlertThreshold{
+						ID:                def.ID,
+						Name:              "threshold",
+						Threshold:         50,
+						ThresholdMin:      10,
+						ThresholdMax:      100,
+						AlertDefinitionID: def.ID,
+					}).Error).ShouldNot(HaveOccurred())
+				}
+			})
+
+			It("Get the whole list ordered by name excluding maintenance alert definitions", func() {
+				ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
+				defer cancel()
+
+				resList, total, err := db.GetLatestAlertDefinitionList(ctx, tenantID, database.ListOptions{})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(total).To(Equal(int64(3)))
+				Expect(resList).To(HaveLen(3))
+				Expect(resList[0].Name).To(Equal("alert-a"))
+				Expect(resList[1].Name).To(Equal("alert-b"))
+				Expect(resList[1].Version).To(Equal(int64(1)))
+				Expect(resList[2].Name).To(Equal("alert-c"))
+			})
+
+			It("Get pages of the list", func() {
+				ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
+				defer cancel()
+
+				By("getting the first page")
+				resList, total, err := db.GetLatestAlertDefinitionList(ctx, tenantID, database.ListOptions{Limit: 2})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(total).To(Equal(int64(3)))
+				Expect(resList).To(HaveLen(2))
+				Expect(resList[0].Name).To(Equal("alert-a"))
+				Expect(resList[1].Name).To(Equal("alert-b"))
+
+				By("getting the second page")
+				resList, total, err = db.GetLatestAlertDefinitionList(ctx, tenantID, database.ListOptions{Limit: 2, Offset: 2})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(total).To(Equal(int64(3)))
+				Expect(resList).To(HaveLen(1))
+				Expect(resList[0].Name).To(Equal("alert-c"))
+
+				By("getting a page past the end of the list")
+				resList, total, err = db.GetLatestAlertDefinitionList(ctx, tenantID, database.ListOptions{Limit: 2, Offset: 4})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(total).To(Equal(int64(3)))
+				Expect(resList).To(BeEmpty())
+			})
+
+			DescribeTable("Get the list sorted by the given field",
+				func(opts database.ListOptions, namesExp []string) {
+					ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
+					defer cancel()
+
+					resList, total, err := db.GetLatestAlertDefinitionList(ctx, tenantID, opts)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(total).To(Equal(int64(3)))
+
+					names := make([]string, len(resList))
+					for i, res := range resList {
+						names[i] = res.Name
+					}
+					Expect(names).To(Equal(namesExp))
+				},
+				Entry("name descending", database.ListOptions{SortBy: database.SortByName, Order: database.SortDescending},
+					[]string{"alert-c", "alert-b", "alert-a"}),
+				Entry("severity ascending", database.ListOptions{SortBy: database.SortBySeverity},
+					[]string{"alert-c", "alert-b", "alert-a"}),
+				Entry("severity descending", database.ListOptions{SortBy: database.SortBySeverity, Order: database.SortDescending},
+					[]string{"alert-a", "alert-b", "alert-c"}),
+				Entry("state ascending with name as tie-breaker", database.ListOptions{SortBy: database.SortByState},
+					[]string{"alert-b", "alert-c", "alert-a"}),
+				Entry("update time descending", database.ListOptions{SortBy: database.SortByUpdatedAt, Order: database.SortDescending},
+					[]string{"alert-c", "alert-a", "alert-b"}),
+				Entry("update time ascending with pagination", database.ListOptions{SortBy: database.SortByUpdatedAt, Limit: 1, Offset: 1},
+					[]string{"alert-a"}),
+			)
+
+			It("Fail to get the list sorted by an unsupported field", func() {
+				ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
+				defer cancel()
+
+				resList, _, err := db.GetLatestAlertDefinitionList(ctx, tenantID, database.ListOptions{SortBy: "template"})
+				Expect(err).To(HaveOccurred())
+				Expect(resList).To(BeNil())
+			})
+		})
+
 		Context("Alert definition helpers", func() {
 			It("Get alert definition UUIDs", func() {
 				ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
@@ -870,6 +1180,8 @@ labels:
 				&models.Receiver{},
 				&models.EmailRecipient{},
 				&models.Task{},
+				&models.Tenant{},
+				&models.EmailTemplate{},
 			)).ShouldNot(HaveOccurred())
 		})
 
@@ -878,7 +1190,7 @@ labels:
 				ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
 				defer cancel()
 
-				receivers, err := db.GetLatestReceiverListWithEmailConfig(ctx, "edgenode")
+				receivers, _, err := db.GetLatestReceiverListWithEmailConfig(ctx, "edgenode", database.ListOptions{})
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(receivers).To(BeEmpty())
 			})
@@ -908,7 +1220,7 @@ labels:
 				defer cancel()
 
 				By("failing to set email recipients")
-				Expect(db.SetReceiverEmailRecipients(ctx, "edgenode", uuid.New(), []models.EmailAddress{})).To(MatchError(gorm.ErrRecordNotFound))
+				Expect(db.SetReceiverValues(ctx, "edgenode", uuid.New(), models.DBReceiverValues{})).To(MatchError(gorm.ErrRecordNotFound))
 
 				By("getting tasks for receiver when failed to set email recipients")
 				var tasks []models.Task
@@ -999,6 +1311,8 @@ labels:
 					From:       sender.String(),
 					To:         []string{recipient1.String()},
 					TenantID:   recv.TenantID,
+					CreatedAt:  clock.FakeClock.Now(),
+					UpdatedAt:  clock.FakeClock.Now(),
 				}
 
 				By("creating a newer version of the receiver")
@@ -1032,6 +1346,8 @@ labels:
 					From:       sender.String(),
 					To:         []string{recipient2.String()},
 					TenantID:   latestRecv.TenantID,
+					CreatedAt:  clock.FakeClock.Now(),
+					UpdatedAt:  clock.FakeClock.Now(),
 				}
 
 				By("creating a newer version of the receiver with 'Error' state")
@@ -1059,7 +1375,7 @@ labels:
 				ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
 				defer cancel()
 
-				recvs, err := db.GetLatestReceiverListWithEmailConfig(ctx, recvTenantID)
+				recvs, _, err := db.GetLatestReceiverListWithEmailConfig(ctx, recvTenantID, database.ListOptions{})
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(recvs).To(HaveLen(1))
 				Expect(recvs).To(Equal([]*models.DBReceiver{recvInfoModified}))
@@ -1070,7 +1386,7 @@ labels:
 					ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
 					defer cancel()
 
-					recvs, err := db.GetLatestReceiverListWithEmailConfig(ctx, "wrong_tenant")
+					recvs, _, err := db.GetLatestReceiverListWithEmailConfig(ctx, "wrong_tenant", database.ListOptions{})
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(recvs).To(BeEmpty())
 				})
@@ -1127,7 +1443,7 @@ labels:
 			})
 
 			It("Add the email recipients to an alert receiver", func() {
-				ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
+				ctx, cancel := context.WithTimeout(correlation.NewContext(context.Background(), "request-id"), dbQueryTimeout)
 				defer cancel()
 
 				By("updating the email recipient list of alert receiver.")
@@ -1149,7 +1465,7 @@ labels:
 						Email:     "third.user@email.com",
 					},
 				}
-				Expect(db.SetReceiverEmailRecipients(ctx, recvTenantID, recvUUID, newRecipients)).ShouldNot(HaveOccurred())
+				Expect(db.SetReceiverValues(ctx, recvTenantID, recvUUID, models.DBReceiverValues{Recipients: newRecipients})).ShouldNot(HaveOccurred())
 
 				newRecvInfo := *recvInfoModified
 				newRecvInfo.Version = recvInfoError.Version + 1
@@ -1171,11 +1487,12 @@ labels:
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(tasks).To(HaveLen(1))
 				Expect(tasks[0]).To(MatchFields(IgnoreExtras, Fields{
-					"ReceiverUUID": Equal(&recv.UUID),
-					"Version":      Equal(int64(recv.Version)),
-					"CreationDate": BeTemporally("==", clock.FakeClock.Now()),
-					"State":        Equal(models.TaskNew),
-					"RetryCount":   Equal(int64(0)),
+					"ReceiverUUID":  Equal(&recv.UUID),
+					"Version":       Equal(int64(recv.Version)),
+					"CreationDate":  BeTemporally("==", clock.FakeClock.Now()),
+					"State":         Equal(models.TaskNew),
+					"RetryCount":    Equal(int64(0)),
+					"CorrelationID": Equal("request-id"),
 				}))
 			})
 
@@ -1184,7 +1501,7 @@ labels:
 				defer cancel()
 
 				By("setting empty recipient list")
-				Expect(db.SetReceiverEmailRecipients(ctx, recvTenantID, recvUUID, []models.EmailAddress{})).ShouldNot(HaveOccurred())
+				Expect(db.SetReceiverValues(ctx, recvTenantID, recvUUID, models.DBReceiverValues{})).ShouldNot(HaveOccurred())
 
 				newRecvInfo := *recvInfoModified
 				newRecvInfo.Version = recvInfoError.Version + 1
@@ -1210,12 +1527,117 @@ labels:
 				}))
 			})
 
+			It("Set the minimum severity of an alert receiver", func() {
+				ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
+				defer cancel()
+
+				By("setting the minimum severity")
+				minSeverity := models.SeverityCritical
+				Expect(db.SetReceiverValues(ctx, recvTenantID, recvUUID, models.DBReceiverValues{
+					MinSeverity: &minSeverity,
+				})).ShouldNot(HaveOccurred())
+
+				By("getting updated alert receiver with minimum severity")
+				recv, err := db.GetLatestReceiverWithEmailConfig(ctx, recvTenantID, recvUUID)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(recv.MinSeverity).To(Equal(models.SeverityCritical))
+
+				By("keeping the minimum severity when it is not given")
+				Expect(db.SetReceiverValues(ctx, recvTenantID, recvUUID, models.DBReceiverValues{})).ShouldNot(HaveOccurred())
+
+				recv, err = db.GetLatestReceiverWithEmailConfig(ctx, recvTenantID, recvUUID)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(recv.MinSeverity).To(Equal(models.SeverityCritical))
+			})
+
+			It("Get the states of the latest versions of receivers", func() {
+				ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
+				defer cancel()
+
+				By("getting the state of the latest version, which could not be applied")
+				states, err := db.GetLatestReceiverStates(ctx)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(states).To(HaveLen(1))
+				Expect(states[0]).To(MatchFields(IgnoreExtras, Fields{
+					"TenantID": Equal(recvInfoError.TenantID),
+					"UUID":     Equal(recvInfoError.UUID),
+					"Name":     Equal(recvInfoError.Name),
+					"State":    Equal(models.ReceiverError),
+				}))
+
+				By("leaving out receivers of archived tenants")
+				archivedDate := clock.FakeClock.Now()
+				Expect(db.DB.WithContext(ctx).Create(&models.Tenant{
+					TenantID: recvTenantID, LastActivityDate: clock.FakeClock.Now(), ArchivedDate: &archivedDate,
+				}).Error).ShouldNot(HaveOccurred())
+
+				states, err = db.GetLatestReceiverStates(ctx)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(states).To(BeEmpty())
+			})
+
+			It("Set the Grafana OnCall routing key of an alert receiver", func() {
+				ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
+				defer cancel()
+
+				By("setting the routing key")
+				routingKey := "routing-key"
+				Expect(db.SetReceiverValues(ctx, recvTenantID, recvUUID, models.DBReceiverValues{
+					OnCallRoutingKey: &routingKey,
+				})).ShouldNot(HaveOccurred())
+
+				By("getting updated alert receiver with routing key")
+				recv, err := db.GetLatestReceiverWithEmailConfig(ctx, recvTenantID, recvUUID)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(recv.OnCallRoutingKey).To(Equal(routingKey))
+
+				By("keeping the routing key when it is not given")
+				Expect(db.SetReceiverValues(ctx, recvTenantID, recvUUID, models.DBReceiverValues{})).ShouldNot(HaveOccurred())
+
+				recv, err = db.GetLatestReceiverWithEmailConfig(ctx, recvTenantID, recvUUID)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(recv.OnCallRoutingKey).To(Equal(routingKey))
+
+				By("removing the routing key")
+				empty := ""
+				Expect(db.SetReceiverValues(ctx, recvTenantID, recvUUID, models.DBReceiverValues{
+					OnCallRoutingKey: &empty,
+				})).ShouldNot(HaveOccurred())
+
+				recv, err = db.GetLatestReceiverWithEmailConfig(ctx, recvTenantID, recvUUID)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(recv.OnCallRoutingKey).To(BeEmpty())
+			})
+
+			It("Set the email language of an alert receiver", func() {
+				ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
+				defer cancel()
+
+				By("setting the language")
+				language := "de"
+				Expect(db.SetReceiverValues(ctx, recvTenantID, recvUUID, models.DBReceiverValues{
+					Language: &language,
+				})).ShouldNot(HaveOccurred())
+
+				By("getting updated alert receiver with language")
+				recv, err := db.GetLatestReceiverWithEmailConfig(ctx, recvTenantID, recvUUID)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(recv.Language).To(Equal(language))
+
+				By("keeping the language when it is not given")
+				Expect(db.SetReceiverValues(ctx, recvTenantID, recvUUID, models.DBReceiverValues{})).ShouldNot(HaveOccurred())
+
+				recv, err = db.GetLatestReceiverWithEmailConfig(ctx, recvTenantID, recvUUID)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(recv.Language).To(Equal(language))
+			})
+
 			It("Fail to set email recipients by UUID because non existing tenantID", func() {
 				ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
 				defer cancel()
 
 				By("failing to set email recipients")
-				Expect(db.SetReceiverEmailRecipients(ctx, "wrong_tenant", recvUUID, []models.EmailAddress{})).To(MatchError(gorm.ErrRecordNotFound))
+				Expect(db.SetReceiverValues(ctx, "wrong_tenant", recvUUID, models.DBReceiverValues{})).To(MatchError(gorm.ErrRecordNotFound))
 
 				By("getting tasks for receiver when failed to set email recipients")
 				var tasks []models.Task
@@ -1316,6 +1738,8 @@ labels:
 				recvInfo1.Version = 1
 				receiverID := int64(10)
 				recvInfo1.TenantID = "tenant1"
+				recvInfo1.CreatedAt = clock.FakeClock.Now()
+				recvInfo1.UpdatedAt = clock.FakeClock.Now()
 				Expect(db.DB.WithContext(ctx).Create(&models.Receiver{
 					ID:            receiverID,
 					UUID:          recvInfo1.UUID,
@@ -1374,6 +1798,8 @@ labels:
 				recvInfo2.Version = 1
 				receiverID2 := int64(20)
 				recvInfo2.TenantID = "tenant2"
+				recvInfo2.CreatedAt = clock.FakeClock.Now()
+				recvInfo2.UpdatedAt = clock.FakeClock.Now()
 				Expect(db.DB.WithContext(ctx).Create(&models.Receiver{
 					ID:            receiverID2,
 					UUID:          recvInfo2.UUID,
@@ -1433,7 +1859,7 @@ labels:
 				ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
 				defer cancel()
 
-				recvs, err := db.GetLatestReceiverListWithEmailConfig(ctx, recvInfo1.TenantID)
+				recvs, _, err := db.GetLatestReceiverListWithEmailConfig(ctx, recvInfo1.TenantID, database.ListOptions{})
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(recvs).To(HaveLen(1))
 				Expect(recvs).To(Equal([]*models.DBReceiver{recvInfo1}))
@@ -1443,7 +1869,7 @@ labels:
 				ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
 				defer cancel()
 
-				recvs, err := db.GetLatestReceiverListWithEmailConfig(ctx, recvInfo2.TenantID)
+				recvs, _, err := db.GetLatestReceiverListWithEmailConfig(ctx, recvInfo2.TenantID, database.ListOptions{})
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(recvs).To(HaveLen(1))
 				Expect(recvs).To(Equal([]*models.DBReceiver{recvInfo2}))
@@ -1453,10 +1879,45 @@ labels:
 				ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
 				defer cancel()
 
-				recvs, err := db.GetLatestReceiverListWithEmailConfig(ctx, "wrong_tenant")
+				recvs, _, err := db.GetLatestReceiverListWithEmailConfig(ctx, "wrong_tenant", database.ListOptions{})
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(recvs).To(BeEmpty())
 			})
+
+			It("Paginate receiver list of first tenant", func() {
+				ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
+				defer cancel()
+
+				By("creating another receiver for the first tenant")
+				Expect(db.DB.WithContext(ctx).Create(&models.Receiver{
+					ID:            30,
+					UUID:          uuid.New(),
+					Name:          "another-receiver",
+					State:         models.ReceiverNew,
+					Version:       1,
+					EmailConfigID: 100,
+					TenantID:      recvInfo1.TenantID,
+				}).Error).ShouldNot(HaveOccurred())
+
+				By("getting the first page")
+				recvs, total, err := db.GetLatestReceiverListWithEmailConfig(ctx, recvInfo1.TenantID, database.ListOptions{Limit: 1})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(total).To(Equal(int64(2)))
+				Expect(recvs).To(HaveLen(1))
+				Expect(recvs[0].Name).To(Equal("another-receiver"))
+
+				By("getting the second page")
+				recvs, total, err = db.GetLatestReceiverListWithEmailConfig(ctx, recvInfo1.TenantID, database.ListOptions{Limit: 1, Offset: 1})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(total).To(Equal(int64(2)))
+				Expect(recvs).To(Equal([]*models.DBReceiver{recvInfo1}))
+
+				By("getting a page past the end of the list")
+				recvs, total, err = db.GetLatestReceiverListWithEmailConfig(ctx, recvInfo1.TenantID, database.ListOptions{Limit: 1, Offset: 2})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(total).To(Equal(int64(2)))
+				Expect(recvs).To(BeEmpty())
+			})
 		})
 	})
 
@@ -1466,6 +1927,9 @@ labels:
 				&models.AlertDefinition{},
 				&models.Receiver{},
 				&models.Task{},
+				&models.TaskHistory{},
+				&models.Tenant{},
+				&models.EmailTemplate{},
 			)).ShouldNot(HaveOccurred())
 
 			clock.SetFakeClock()
@@ -1506,7 +1970,8 @@ labels:
 				clock.FakeClock.Set(clock.FakeClock.Now().Add(11 * time.Second))
 
 				By("deleting not pending tasks exceeding the duration")
-				Expect(db.DeleteNotPendingTasksExceedingDuration(ctx, 10*time.Second)).ShouldNot(HaveOccurred())
+				_, err := db.DeleteNotPendingTasksExceedingDuration(ctx, 10*time.Second, database.TaskCleanupOptions{})
+				Expect(err).ShouldNot(HaveOccurred())
 
 				By("getting pending tasks from database")
 				var tasks []models.Task
@@ -1547,7 +2012,8 @@ labels:
 				clock.FakeClock.Set(clock.FakeClock.Now().Add(10 * time.Second))
 
 				By("deleting not pending tasks which exceed duration")
-				Expect(db.DeleteNotPendingTasksExceedingDuration(ctx, 10*time.Second)).ShouldNot(HaveOccurred())
+				_, err := db.DeleteNotPendingTasksExceedingDuration(ctx, 10*time.Second, database.TaskCleanupOptions{})
+				Expect(err).ShouldNot(HaveOccurred())
 
 				By("getting not pending tasks from database")
 				var tasks []models.Task
@@ -1604,13 +2070,37 @@ labels:
 				By("setting time which makes completion date of tasks to exceed duration")
 				clock.FakeClock.Set(timeNow.Add(30 * time.Second))
 
-				By("deleting not pending tasks which exceed duration")
-				Expect(db.DeleteNotPendingTasksExceedingDuration(ctx, 10*time.Second)).ShouldNot(HaveOccurred())
+				By("deleting not pending tasks which exceed duration in batches of two, archiving the invalid ones")
+				var archived []int64
+				deleted, err := db.DeleteNotPendingTasksExceedingDuration(ctx, 10*time.Second, database.TaskCleanupOptions{
+					BatchSize: 2,
+					Pause:     time.Millisecond,
+					ArchiveInvalid: func(_ context.Context, tasks []models.Task) error {
+						for _, task := range tasks {
+							archived = append(archived, task.ID)
+						}
+						return nil
+					},
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(deleted).To(Equal(int64(3)))
+				Expect(archived).To(Equal([]int64{6}))
 
 				By("getting empty slice of not pending tasks from database")
 				var tasks []models.Task
 				Expect(db.DB.WithContext(ctx).Find(&tasks).Error).ShouldNot(HaveOccurred())
 				Expect(tasks).To(BeEmpty())
+
+				By("getting the archived task history from database")
+				var history []models.TaskHistory
+				Expect(db.DB.WithContext(ctx).Order("id").Find(&history).Error).ShouldNot(HaveOccurred())
+				Expect(history).To(HaveLen(3))
+				Expect(history[1]).To(MatchFields(IgnoreExtras, Fields{
+					"Type":           Equal(models.TypeAlertDefinition),
+					"State":          Equal(models.TaskInvalid),
+					"StartDate":      BeTemporally("==", timeNow),
+					"CompletionDate": BeTemporally("==", timeNow.Add(10*time.Second)),
+				}))
 			})
 		})
 
@@ -1783,6 +2273,7 @@ labels:
 					"State":          Equal(models.TaskInvalid),
 					"CompletionDate": BeTemporally("~", clock.FakeClock.Now()),
 					"RetryCount":     Equal(takenTask.RetryCount),
+					"Error":          ContainSubstring("exceeded timeout"),
 				}))
 
 				By("checking that the receiver state is Error")
@@ -1825,7 +2316,7 @@ labels:
 				}).Error).ShouldNot(HaveOccurred())
 
 				By("getting empty slice of pending tasks")
-				tasks, err := db.GetPendingTasks(ctx, uuid.New(), 100)
+				tasks, err := db.GetPendingTasks(ctx, uuid.New(), database.PendingTaskOptions{CountLimit: 100})
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(tasks).To(BeEmpty())
 			})
@@ -1866,7 +2357,7 @@ labels:
 
 				By("getting only pending tasks according to count limit")
 				ownerUUID := uuid.New()
-				res, err := db.GetPendingTasks(ctx, ownerUUID, 2)
+				res, err := db.GetPendingTasks(ctx, ownerUUID, database.PendingTaskOptions{CountLimit: 2})
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(res).To(HaveLen(2))
 				Expect(res[0]).To(MatchFields(IgnoreExtras, Fields{
@@ -1920,7 +2411,7 @@ labels:
 
 				By("getting only the latest version of the task")
 				ownerUUID := uuid.New()
-				res, err := db.GetPendingTasks(ctx, ownerUUID, 100)
+				res, err := db.GetPendingTasks(ctx, ownerUUID, database.PendingTaskOptions{CountLimit: 100})
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(res).To(HaveLen(1))
 				Expect(res[0]).To(MatchFields(IgnoreExtras, Fields{
@@ -1932,6 +2423,41 @@ labels:
 				}))
 			})
 
+			It("Take no tasks of archived tenants", func() {
+				ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
+				defer cancel()
+
+				By("creating pending tasks of an active and an archived tenant")
+				Expect(db.DB.WithContext(ctx).Create(&models.Task{
+					ID:           1,
+					ReceiverUUID: uuidPtr(uuid.New()),
+					TenantID:     "archived",
+					State:        models.TaskNew,
+					Version:      1,
+				}).Error).ShouldNot(HaveOccurred())
+
+				task := models.Task{
+					ID:           2,
+					ReceiverUUID: uuidPtr(uuid.New()),
+					TenantID:     "active",
+					State:        models.TaskNew,
+					Version:      1,
+				}
+				Expect(db.DB.WithContext(ctx).Create(&task).Error).ShouldNot(HaveOccurred())
+
+				archivedDate := clock.FakeClock.Now()
+				Expect(db.DB.WithContext(ctx).Create(&[]models.Tenant{
+					{TenantID: "archived", LastActivityDate: clock.FakeClock.Now(), ArchivedDate: &archivedDate},
+					{TenantID: "active", LastActivityDate: clock.FakeClock.Now()},
+				}).Error).ShouldNot(HaveOccurred())
+
+				By("getting only the task of the active tenant")
+				res, err := db.GetPendingTasks(ctx, uuid.New(), database.PendingTaskOptions{CountLimit: 100})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(res).To(HaveLen(1))
+				Expect(res[0].ReceiverUUID).To(Equal(task.ReceiverUUID))
+			})
+
 			It("Take only tasks whose UUID does not belong to a task in Taken state", func() {
 				ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
 				defer cancel()
@@ -1955,7 +2481,7 @@ labels:
 				}).Error).ShouldNot(HaveOccurred())
 
 				By("getting no pending tasks")
-				tasks, err := db.GetPendingTasks(ctx, uuid.New(), 100)
+				tasks, err := db.GetPendingTasks(ctx, uuid.New(), database.PendingTaskOptions{CountLimit: 100})
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(tasks).To(BeEmpty())
 			})
@@ -2259,10 +2785,11 @@ labels:
 				var recvOut models.Receiver
 				Expect(db.DB.WithContext(ctx).First(&recvOut, recv.ID).Error).ShouldNot(HaveOccurred())
 				Expect(recvOut).To(MatchFields(IgnoreExtras, Fields{
-					"ID":      Equal(recv.ID),
-					"UUID":    Equal(recv.UUID),
-					"State":   Equal(models.ReceiverApplied),
-					"Version": Equal(recv.Version),
+					"ID":          Equal(recv.ID),
+					"UUID":        Equal(recv.UUID),
+					"State":       Equal(models.ReceiverApplied),
+					"Version":     Equal(recv.Version),
+					"AppliedDate": PointTo(BeTemporally("==", completionDate)),
 				}))
 			})
 
@@ -2347,10 +2874,11 @@ labels:
 				var defOut models.AlertDefinition
 				Expect(db.DB.WithContext(ctx).First(&defOut, def.ID).Error).ShouldNot(HaveOccurred())
 				Expect(defOut).To(MatchFields(IgnoreExtras, Fields{
-					"ID":      Equal(def.ID),
-					"UUID":    Equal(def.UUID),
-					"State":   Equal(models.DefinitionApplied),
-					"Version": Equal(def.Version),
+					"ID":          Equal(def.ID),
+					"UUID":        Equal(def.UUID),
+					"State":       Equal(models.DefinitionApplied),
+					"Version":     Equal(def.Version),
+					"AppliedDate": PointTo(BeTemporally("==", completionDate)),
 				}))
 			})
 
@@ -2405,7 +2933,7 @@ labels:
 				Expect(db.DB.WithContext(ctx).Create(&task).Error).ShouldNot(HaveOccurred())
 
 				By("failing to set the task as failed")
-				err := db.SetTaskAsFailed(ctx, task, 10)
+				err := db.SetTaskAsFailed(ctx, task, 10, errors.New("mock error"))
 				Expect(err).To(MatchError(ContainSubstring("failed to retrieve receiver")))
 				Expect(err).To(MatchError(gorm.ErrRecordNotFound))
 
@@ -2454,7 +2982,7 @@ labels:
 				clock.FakeClock.Set(completionDate)
 
 				By("setting the task as failed")
-				Expect(db.SetTaskAsFailed(ctx, task, 10)).ShouldNot(HaveOccurred())
+				Expect(db.SetTaskAsFailed(ctx, task, 10, errors.New("mock error"))).ShouldNot(HaveOccurred())
 
 				By("checking that the task state is Error since its retry count does not exceed the retry limit")
 				var taskOut models.Task
@@ -2464,6 +2992,7 @@ labels:
 					"ReceiverUUID": Equal(task.ReceiverUUID),
 					"State":        Equal(models.TaskError),
 					"RetryCount":   Equal(task.RetryCount + 1),
+					"Error":        Equal("mock error"),
 					"CreationDate": BeTemporally("==", task.CreationDate),
 					"StartDate":    BeTemporally("==", task.StartDate),
 				}))
@@ -2511,7 +3040,7 @@ labels:
 				clock.FakeClock.Set(completionDate)
 
 				By("setting the task as failed")
-				Expect(db.SetTaskAsFailed(ctx, task, 10)).ShouldNot(HaveOccurred())
+				Expect(db.SetTaskAsFailed(ctx, task, 10, errors.New("mock error"))).ShouldNot(HaveOccurred())
 
 				By("checking that the task state is Error since its retry count does not exceed the retry limit")
 				var taskOut models.Task
@@ -2553,7 +3082,7 @@ labels:
 				Expect(db.DB.WithContext(ctx).Create(&task).Error).ShouldNot(HaveOccurred())
 
 				By("failing to set the task as failed")
-				err := db.SetTaskAsFailed(ctx, task, 10)
+				err := db.SetTaskAsFailed(ctx, task, 10, errors.New("mock error"))
 				Expect(err).To(MatchError(ContainSubstring("failed to retrieve alert definition")))
 				Expect(err).To(MatchError(gorm.ErrRecordNotFound))
 
@@ -2603,7 +3132,7 @@ labels:
 				clock.FakeClock.Set(completionDate)
 
 				By("setting the task as failed")
-				Expect(db.SetTaskAsFailed(ctx, task, 10)).ShouldNot(HaveOccurred())
+				Expect(db.SetTaskAsFailed(ctx, task, 10, errors.New("mock error"))).ShouldNot(HaveOccurred())
 
 				By("checking that the task state is Error since its retry count does not exceed the retry limit")
 				var taskOut models.Task
@@ -2661,7 +3190,7 @@ labels:
 				clock.FakeClock.Set(completionDate)
 
 				By("setting the task as failed")
-				Expect(db.SetTaskAsFailed(ctx, task, retryLimit)).ShouldNot(HaveOccurred())
+				Expect(db.SetTaskAsFailed(ctx, task, retryLimit, errors.New("mock error"))).ShouldNot(HaveOccurred())
 
 				By("checking that the task state is Invalid since its retry count exceeds the retry limit")
 				var taskOut models.Task
@@ -2720,7 +3249,7 @@ labels:
 				clock.FakeClock.Set(completionDate)
 
 				By("setting the task as failed")
-				Expect(db.SetTaskAsFailed(ctx, task, retryLimit)).ShouldNot(HaveOccurred())
+				Expect(db.SetTaskAsFailed(ctx, task, retryLimit, errors.New("mock error"))).ShouldNot(HaveOccurred())
 
 				By("checking that the task state is Invalid since its retry count exceeds the retry limit")
 				var taskOut models.Task
@@ -2968,4 +3497,765 @@ labels:
 			})
 		})
 	})
+
+	Describe("Tenants", func() {
+		BeforeEach(func() {
+			Expect(db.DB.AutoMigrate(
+				&models.AlertDefinition{},
+				&models.Receiver{},
+				&models.Task{},
+				&models.Tenant{},
+				&models.EmailTemplate{},
+			)).ShouldNot(HaveOccurred())
+
+			clock.SetFakeClock()
+			clock.FakeClock.Set(time.Now().UTC())
+		})
+
+		It("Record the activity of a tenant", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
+			defer cancel()
+
+			By("recording the activity of a new tenant")
+			Expect(db.SetTenantActivity(ctx, "tenant")).Should(Succeed())
+
+			By("recording the activity of the tenant again later")
+			clock.FakeClock.Add(time.Hour)
+			Expect(db.SetTenantActivity(ctx, "tenant")).Should(Succeed())
+
+			var tenants []models.Tenant
+			Expect(db.DB.WithContext(ctx).Find(&tenants).Error).ShouldNot(HaveOccurred())
+			Expect(tenants).To(HaveLen(1))
+			Expect(tenants[0].TenantID).To(Equal("tenant"))
+			Expect(tenants[0].LastActivityDate).To(BeTemporally("==", clock.FakeClock.Now()))
+			Expect(tenants[0].IsArchived()).To(BeFalse())
+		})
+
+		It("Get inactive tenants, registering untracked tenants as active", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
+			defer cancel()
+
+			By("creating an alert definition of an untracked tenant")
+			Expect(db.DB.WithContext(ctx).Create(&models.AlertDefinition{
+				UUID:     uuid.New(),
+				Name:     "alert",
+				Version:  1,
+				State:    models.DefinitionApplied,
+				Category: models.CategoryHealth,
+				TenantID: "untracked",
+			}).Error).ShouldNot(HaveOccurred())
+
+			By("recording the activity of tenants")
+			Expect(db.SetTenantActivity(ctx, "inactive")).Should(Succeed())
+			Expect(db.SetTenantActivity(ctx, "archived")).Should(Succeed())
+			Expect(db.ArchiveTenant(ctx, "archived")).Should(Succeed())
+			clock.FakeClock.Add(48 * time.Hour)
+			Expect(db.SetTenantActivity(ctx, "active")).Should(Succeed())
+
+			By("getting tenants without activity within the last day")
+			sharded := &database.DBService{DB: db.DB, AlertmanagerShards: 4}
+			tenantIDs, err := sharded.GetInactiveTenants(ctx, 24*time.Hour)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(tenantIDs).To(Equal([]string{"inactive"}))
+
+			By("checking that the untracked tenant was registered with a shard")
+			var tenant models.Tenant
+			Expect(db.DB.WithContext(ctx).First(&tenant, "tenant_id = ?", "untracked").Error).ShouldNot(HaveOccurred())
+			Expect(tenant.LastActivityDate).To(BeTemporally("==", clock.FakeClock.Now()))
+			Expect(tenant.AlertmanagerShard).To(HaveValue(BeNumerically("<", 4)))
+		})
+
+		It("Get active tenants", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
+			defer cancel()
+
+			By("creating alert definitions and receivers of tenants")
+			for _, tenantID := range []string{"tenant", "archived"} {
+				Expect(db.DB.WithContext(ctx).Create(&models.AlertDefinition{
+					UUID:     uuid.New(),
+					Name:     "alert",
+					Version:  1,
+					State:    models.DefinitionApplied,
+					Category: models.CategoryHealth,
+					TenantID: tenantID,
+				}).Error).ShouldNot(HaveOccurred())
+			}
+			Expect(db.DB.WithContext(ctx).Create(&models.Receiver{
+				UUID:     uuid.New(),
+				Name:     "receiver",
+				Version:  1,
+				State:    models.ReceiverApplied,
+				TenantID: "receiver-only",
+			}).Error).ShouldNot(HaveOccurred())
+
+			By("archiving a tenant")
+			Expect(db.SetTenantActivity(ctx, "archived")).Should(Succeed())
+			Expect(db.ArchiveTenant(ctx, "archived")).Should(Succeed())
+
+			tenantIDs, err := db.GetActiveTenants(ctx)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(tenantIDs).To(Equal([]string{"receiver-only", "tenant"}))
+		})
+
+		It("Assign alertmanager shards to tenants when they are registered", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
+			defer cancel()
+
+			sharded := &database.DBService{DB: db.DB, AlertmanagerShards: 4}
+
+			By("serving a tenant which is not registered yet by the shard it would be assigned")
+			shard, err := db.GetTenantShard(ctx, "tenant", 4)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(shard).To(BeNumerically("<", 4))
+			var count int64
+			Expect(db.DB.WithContext(ctx).Model(&models.Tenant{}).Count(&count).Error).ShouldNot(HaveOccurred())
+			Expect(count).To(BeZero())
+
+			By("assigning the shard when the tenant is registered")
+			Expect(sharded.SetTenantActivity(ctx, "tenant")).Should(Succeed())
+			var tenant models.Tenant
+			Expect(db.DB.WithContext(ctx).First(&tenant, "tenant_id = ?", "tenant").Error).ShouldNot(HaveOccurred())
+			Expect(tenant.AlertmanagerShard).To(HaveValue(Equal(shard)))
+
+			By("keeping the assigned shard when the number of shards changes")
+			Expect(sharded.SetTenantActivity(ctx, "tenant")).Should(Succeed())
+			Expect(db.GetTenantShard(ctx, "tenant", 1)).To(Equal(shard))
+			Expect(db.GetTenantShard(ctx, "tenant", 8)).To(Equal(shard))
+
+			By("serving a tenant registered without shard by the shard it would be assigned")
+			Expect(db.SetTenantActivity(ctx, "tracked")).Should(Succeed())
+			Expect(db.GetTenantShard(ctx, "tracked", 1)).To(Equal(0))
+
+			By("failing without shards")
+			_, err = db.GetTenantShard(ctx, "tenant", 0)
+			Expect(err).Should(MatchError(ContainSubstring("invalid number of shards")))
+		})
+
+		It("Assign tiers to tenants", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
+			defer cancel()
+
+			By("getting no tier for a tenant not tracked yet")
+			Expect(db.GetTenantTier(ctx, "tenant")).To(BeEmpty())
+
+			By("assigning a tier to a new tenant")
+			Expect(db.SetTenantTier(ctx, "tenant", "premium")).Should(Succeed())
+			Expect(db.GetTenantTier(ctx, "tenant")).To(Equal("premium"))
+
+			By("keeping the tier when the activity of the tenant is recorded")
+			clock.FakeClock.Add(time.Hour)
+			Expect(db.SetTenantActivity(ctx, "tenant")).Should(Succeed())
+			Expect(db.GetTenantTier(ctx, "tenant")).To(Equal("premium"))
+
+			By("unassigning the tier of the tenant")
+			Expect(db.SetTenantTier(ctx, "tenant", "")).Should(Succeed())
+			Expect(db.GetTenantTier(ctx, "tenant")).To(BeEmpty())
+		})
+
+		It("Fail to archive a tenant twice", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
+			defer cancel()
+
+			Expect(db.SetTenantActivity(ctx, "tenant")).Should(Succeed())
+			Expect(db.ArchiveTenant(ctx, "tenant")).Should(Succeed())
+			Expect(db.ArchiveTenant(ctx, "tenant")).Should(MatchError(gorm.ErrRecordNotFound))
+		})
+
+		It("Unarchive a tenant, queueing the latest versions of its configuration", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
+			defer cancel()
+
+			By("creating two versions of an alert definition, the latest one without a task")
+			defUUID := uuid.New()
+			for version := int64(1); version <= 2; version++ {
+				Expect(db.DB.WithContext(ctx).Create(&models.AlertDefinition{
+					UUID:     defUUID,
+					Name:     "alert",
+					Version:  version,
+					State:    models.DefinitionApplied,
+					Category: models.CategoryHealth,
+					TenantID: "tenant",
+				}).Error).ShouldNot(HaveOccurred())
+			}
+			Expect(db.DB.WithContext(ctx).Create(&models.Task{
+				AlertDefinitionUUID: &defUUID,
+				TenantID:            "tenant",
+				Version:             1,
+				State:               models.TaskApplied,
+			}).Error).ShouldNot(HaveOccurred())
+
+			By("creating a receiver whose task failed")
+			recvUUID := uuid.New()
+			Expect(db.DB.WithContext(ctx).Create(&models.Receiver{
+				UUID:     recvUUID,
+				Name:     "receiver",
+				Version:  1,
+				State:    models.ReceiverApplied,
+				TenantID: "tenant",
+			}).Error).ShouldNot(HaveOccurred())
+			Expect(db.DB.WithContext(ctx).Create(&models.Task{
+				ReceiverUUID: &recvUUID,
+				TenantID:     "tenant",
+				Version:      1,
+				State:        models.TaskInvalid,
+				RetryCount:   10,
+			}).Error).ShouldNot(HaveOccurred())
+
+			By("failing to unarchive an active tenant")
+			Expect(db.SetTenantActivity(ctx, "tenant")).Should(Succeed())
+			Expect(db.UnarchiveTenant(ctx, "tenant")).Should(MatchError(database.ErrTenantNotArchived))
+
+			By("unarchiving the archived tenant")
+			Expect(db.ArchiveTenant(ctx, "tenant")).Should(Succeed())
+			clock.FakeClock.Add(time.Hour)
+			Expect(db.UnarchiveTenant(ctx, "tenant")).Should(Succeed())
+
+			var tenant models.Tenant
+			Expect(db.DB.WithContext(ctx).First(&tenant, "tenant_id = ?", "tenant").Error).ShouldNot(HaveOccurred())
+			Expect(tenant.IsArchived()).To(BeFalse())
+			Expect(tenant.LastActivityDate).To(BeTemporally("==", clock.FakeClock.Now()))
+
+			By("checking that the latest versions are queued")
+			var tasks []models.Task
+			Expect(db.DB.WithContext(ctx).Where("state = ?", models.TaskNew).Find(&tasks).Error).ShouldNot(HaveOccurred())
+			Expect(tasks).To(ConsistOf(
+				MatchFields(IgnoreExtras, Fields{
+					"AlertDefinitionUUID": Equal(&defUUID),
+					"Version":             BeEquivalentTo(2),
+				}),
+				MatchFields(IgnoreExtras, Fields{
+					"ReceiverUUID": Equal(&recvUUID),
+					"Version":      BeEquivalentTo(1),
+					"RetryCount":   BeEquivalentTo(0),
+				}),
+			))
+		})
+	})
+
+	Describe("Email deliveries", func() {
+		BeforeEach(func() {
+			Expect(db.DB.AutoMigrate(&models.EmailDelivery{})).ShouldNot(HaveOccurred())
+
+			clock.SetFakeClock()
+			clock.FakeClock.Set(time.Now().UTC())
+		})
+
+		It("Record email deliveries, deleting the expired ones of the receiver", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
+			defer cancel()
+
+			recvUUID := uuid.New()
+			otherUUID := uuid.New()
+			delivery := func(id uuid.UUID, recipient string, status models.EmailDeliveryStatus) models.EmailDelivery {
+				return models.EmailDelivery{
+					TenantID:     "tenant",
+					ReceiverUUID: id,
+					Recipient:    recipient,
+					GroupKey:     "{}:{alertname=\"HighCPUUsage\"}",
+					AlertCount:   1,
+					Status:       status,
+					Attempts:     1,
+					CreationDate: clock.FakeClock.Now(),
+				}
+			}
+
+			By("recording deliveries of two receivers")
+			Expect(db.RecordEmailDeliveries(ctx, "tenant", recvUUID, []models.EmailDelivery{
+				delivery(recvUUID, "foo@bar.com", models.EmailDeliverySent),
+			}, 24*time.Hour)).Should(Succeed())
+			Expect(db.RecordEmailDeliveries(ctx, "tenant", otherUUID, []models.EmailDelivery{
+				delivery(otherUUID, "foo@bar.com", models.EmailDeliverySent),
+			}, 24*time.Hour)).Should(Succeed())
+
+			By("recording deliveries of the first receiver after the retention period")
+			clock.FakeClock.Add(48 * time.Hour)
+			failed := delivery(recvUUID, "bar@foo.com", models.EmailDeliveryFailed)
+			failed.Attempts = 3
+			failed.Error = "550 mailbox unavailable"
+			Expect(db.RecordEmailDeliveries(ctx, "tenant", recvUUID, []models.EmailDelivery{
+				delivery(recvUUID, "foo@bar.com", models.EmailDeliverySent),
+				failed,
+			}, 24*time.Hour)).Should(Succeed())
+
+			By("checking that only the expired deliveries of the first receiver were deleted")
+			var deliveries []models.EmailDelivery
+			Expect(db.DB.WithContext(ctx).Order("id").Find(&deliveries).Error).ShouldNot(HaveOccurred())
+			Expect(deliveries).To(ConsistOf(
+				MatchFields(IgnoreExtras, Fields{
+					"ReceiverUUID": Equal(otherUUID),
+					"Recipient":    Equal("foo@bar.com"),
+				}),
+				MatchFields(IgnoreExtras, Fields{
+					"ReceiverUUID": Equal(recvUUID),
+					"Recipient":    Equal("foo@bar.com"),
+					"Status":       Equal(models.EmailDeliverySent),
+				}),
+				MatchFields(IgnoreExtras, Fields{
+					"ReceiverUUID": Equal(recvUUID),
+					"Recipient":    Equal("bar@foo.com"),
+					"Status":       Equal(models.EmailDeliveryFailed),
+					"Attempts":     Equal(3),
+					"Error":        Equal("550 mailbox unavailable"),
+				}),
+			))
+		})
+	})
+
+	Describe("Email templates", func() {
+		BeforeEach(func() {
+			Expect(db.DB.AutoMigrate(&models.Receiver{}, &models.Task{}, &models.EmailTemplate{})).ShouldNot(HaveOccurred())
+
+			clock.SetFakeClock()
+			clock.FakeClock.Set(time.Now().UTC())
+		})
+
+		It("Set versions of the email template, requeuing the latest receivers of the tenant", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
+			defer cancel()
+
+			recvUUID := uuid.New()
+			Expect(db.DB.Create(&[]models.Receiver{
+				{UUID: recvUUID, Name: "receiver", State: models.ReceiverApplied, Version: 1, TenantID: "tenant"},
+				{UUID: recvUUID, Name: "receiver", State: models.ReceiverApplied, Version: 2, TenantID: "tenant"},
+				{UUID: uuid.New(), Name: "receiver", State: models.ReceiverApplied, Version: 1, TenantID: "other"},
+			}).Error).ShouldNot(HaveOccurred())
+
+			By("checking that the tenant has no email template")
+			_, err := db.GetEmailTemplate(ctx, "tenant", 0)
+			Expect(err).Should(MatchError(gorm.ErrRecordNotFound))
+
+			By("setting two versions of the email template")
+			tmpl, err := db.SetEmailTemplate(ctx, "tenant", "<p>first</p>")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(tmpl.Version).To(Equal(int64(1)))
+			tmpl, err = db.SetEmailTemplate(ctx, "tenant", "<p>second</p>")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(tmpl.Version).To(Equal(int64(2)))
+
+			By("getting the versions of the email template")
+			tmpl, err = db.GetEmailTemplate(ctx, "tenant", 0)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(tmpl.Content).To(Equal("<p>second</p>"))
+			tmpl, err = db.GetEmailTemplate(ctx, "tenant", 1)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(tmpl.Content).To(Equal("<p>first</p>"))
+			_, err = db.GetEmailTemplate(ctx, "tenant", 3)
+			Expect(err).Should(MatchError(gorm.ErrRecordNotFound))
+			_, err = db.GetEmailTemplate(ctx, "other", 0)
+			Expect(err).Should(MatchError(gorm.ErrRecordNotFound))
+
+			By("checking that only the latest receiver of the tenant was requeued")
+			var tasks []models.Task
+			Expect(db.DB.WithContext(ctx).Find(&tasks).Error).ShouldNot(HaveOccurred())
+			Expect(tasks).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"ReceiverUUID": PointTo(Equal(recvUUID)),
+				"Version":      Equal(int64(2)),
+				"TenantID":     Equal("tenant"),
+				"State":        Equal(models.TaskNew),
+			})))
+		})
+	})
+
+	Describe("Alert comments", func() {
+		BeforeEach(func() {
+			Expect(db.DB.AutoMigrate(&models.AlertComment{})).ShouldNot(HaveOccurred())
+
+			clock.SetFakeClock()
+			clock.FakeClock.Set(time.Now().UTC())
+		})
+
+		It("Add threaded comments to alerts, replies referencing comments of the same alert", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
+			defer cancel()
+
+			comment := func(tenantID, fingerprint, content string, parentID *int64) *models.AlertComment {
+				return &models.AlertComment{
+					TenantID:     tenantID,
+					Fingerprint:  fingerprint,
+					ParentID:     parentID,
+					Author:       "user",
+					Content:      content,
+					CreationDate: clock.FakeClock.Now(),
+				}
+			}
+
+			By("adding comments to alerts of two tenants")
+			first := comment("tenant", "fingerprint", "first", nil)
+			Expect(db.AddAlertComment(ctx, first)).Should(Succeed())
+			Expect(first.ID).ShouldNot(BeZero())
+			Expect(db.AddAlertComment(ctx, comment("tenant", "other", "other alert", nil))).Should(Succeed())
+			Expect(db.AddAlertComment(ctx, comment("other", "fingerprint", "other tenant", nil))).Should(Succeed())
+
+			By("replying to the first comment")
+			Expect(db.AddAlertComment(ctx, comment("tenant", "fingerprint", "reply", &first.ID))).Should(Succeed())
+
+			By("checking that comments of other alerts cannot be replied to")
+			Expect(db.AddAlertComment(ctx, comment("tenant", "other", "reply", &first.ID))).Should(MatchError(gorm.ErrRecordNotFound))
+			Expect(db.AddAlertComment(ctx, comment("other", "fingerprint", "reply", &first.ID))).Should(MatchError(gorm.ErrRecordNotFound))
+
+			By("getting the comments of the alert")
+			comments, err := db.GetAlertComments(ctx, "tenant", "fingerprint")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(comments).To(HaveExactElements(
+				MatchFields(IgnoreExtras, Fields{
+					"ID":       Equal(first.ID),
+					"ParentID": BeNil(),
+					"Content":  Equal("first"),
+				}),
+				MatchFields(IgnoreExtras, Fields{
+					"ParentID": PointTo(Equal(first.ID)),
+					"Content":  Equal("reply"),
+				}),
+			))
+		})
+	})
+
+	Describe("Alertmanager configs", func() {
+		BeforeEach(func() {
+			Expect(db.DB.AutoMigrate(&models.AlertmanagerConfig{})).ShouldNot(HaveOccurred())
+		})
+
+		It("Set and get the known good configuration of alertmanager instances", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
+			defer cancel()
+
+			By("failing to get a configuration which was not recorded")
+			_, err := db.GetKnownGoodAlertmanagerConfig(ctx, "orch-infra/alert-monitor-config")
+			Expect(err).To(MatchError(gorm.ErrRecordNotFound))
+
+			By("recording configurations of two instances, replacing the first one")
+			Expect(db.SetKnownGoodAlertmanagerConfig(ctx, "orch-infra/alert-monitor-config", []byte("receivers: []"))).Should(Succeed())
+			Expect(db.SetKnownGoodAlertmanagerConfig(ctx, "orch-infra/alert-monitor-config-1", []byte("route: {}"))).Should(Succeed())
+			clock.FakeClock.Add(time.Hour)
+			Expect(db.SetKnownGoodAlertmanagerConfig(ctx, "orch-infra/alert-monitor-config", []byte("receivers: [null]"))).Should(Succeed())
+
+			manifest, err := db.GetKnownGoodAlertmanagerConfig(ctx, "orch-infra/alert-monitor-config")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(string(manifest)).To(Equal("receivers: [null]"))
+
+			manifest, err = db.GetKnownGoodAlertmanagerConfig(ctx, "orch-infra/alert-monitor-config-1")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(string(manifest)).To(Equal("route: {}"))
+
+			var conf models.AlertmanagerConfig
+			Expect(db.DB.WithContext(ctx).Where("name = ?", "orch-infra/alert-monitor-config").Take(&conf).Error).ShouldNot(HaveOccurred())
+			Expect(conf.ReloadDate).To(BeTemporally("~", clock.FakeClock.Now(), time.Second))
+		})
+	})
+
+	Describe("Applied artifacts", func() {
+		BeforeEach(func() {
+			Expect(db.DB.AutoMigrate(&models.AppliedArtifact{})).ShouldNot(HaveOccurred())
+		})
+
+		It("Record applied artifacts and get them back", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
+			defer cancel()
+
+			const manifestName = "orch-infra/alert-monitor-config"
+			const ruleGroupName = "edgenode/2f0a3e1c-0d9b-4a53-9a0e-7f0c3bde2a11"
+
+			By("recording an alertmanager manifest twice with the same content and once with a new one")
+			Expect(db.RecordAppliedArtifact(ctx, models.ArtifactAlertmanagerManifest, manifestName, []byte("receivers: []"))).Should(Succeed())
+			clock.FakeClock.Add(time.Minute)
+			Expect(db.RecordAppliedArtifact(ctx, models.ArtifactAlertmanagerManifest, manifestName, []byte("receivers: []"))).Should(Succeed())
+			clock.FakeClock.Add(time.Minute)
+			Expect(db.RecordAppliedArtifact(ctx, models.ArtifactAlertmanagerManifest, manifestName, []byte("receivers: [null]"))).Should(Succeed())
+
+			By("recording a Mimir rule group with the same content as the first manifest")
+			Expect(db.RecordAppliedArtifact(ctx, models.ArtifactMimirRuleGroup, ruleGroupName, []byte("receivers: []"))).Should(Succeed())
+
+			By("listing the artifacts of a kind and name, latest first and without content")
+			artifacts, err := db.GetAppliedArtifacts(ctx, models.ArtifactAlertmanagerManifest, manifestName, 10)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(artifacts).To(HaveLen(2))
+			Expect(artifacts[0].Content).To(BeEmpty())
+			Expect(artifacts[0].AppliedDate).To(BeTemporally("~", clock.FakeClock.Now(), time.Second))
+			Expect(artifacts[1].AppliedDate).To(BeTemporally("~", clock.FakeClock.Now().Add(-2*time.Minute), time.Second))
+			// SHA-256 hash of "receivers: []".
+			Expect(artifacts[1].Hash).To(Equal("19ecc9d8bcb8058ebc41617b6a8baa7692ad793e640f961825542929985d83bd"))
+
+			By("listing the artifacts of all kinds")
+			artifacts, err = db.GetAppliedArtifacts(ctx, "", "", 10)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(artifacts).To(HaveLen(3))
+			Expect(artifacts[0].Kind).To(Equal(models.ArtifactMimirRuleGroup))
+
+			artifacts, err = db.GetAppliedArtifacts(ctx, "", "", 1)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(artifacts).To(HaveLen(1))
+
+			By("getting the content of the latest manifest and of the one applied before it")
+			artifacts, err = db.GetAppliedArtifacts(ctx, models.ArtifactAlertmanagerManifest, manifestName, 10)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			latest, err := db.GetAppliedArtifact(ctx, artifacts[0].ID)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(latest.Content).To(Equal("receivers: [null]"))
+
+			previous, err := db.GetPreviousAppliedArtifact(ctx, *latest)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(previous.Content).To(Equal("receivers: []"))
+			Expect(previous.Hash).To(Equal(artifacts[1].Hash))
+
+			_, err = db.GetPreviousAppliedArtifact(ctx, *previous)
+			Expect(err).To(MatchError(gorm.ErrRecordNotFound))
+
+			_, err = db.GetAppliedArtifact(ctx, 100)
+			Expect(err).To(MatchError(gorm.ErrRecordNotFound))
+		})
+	})
+
+	Describe("Rule evaluations", func() {
+		BeforeEach(func() {
+			Expect(db.DB.AutoMigrate(&models.RuleEvaluation{})).ShouldNot(HaveOccurred())
+		})
+
+		It("Record rule evaluations over a rolling window and summarize them", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
+			defer cancel()
+
+			defUUID := uuid.New()
+			otherUUID := uuid.New()
+			evaluation := func(id uuid.UUID, duration float64, evalErr string) models.RuleEvaluation {
+				return models.RuleEvaluation{
+					TenantID:            "tenant",
+					AlertDefinitionUUID: id,
+					DurationSeconds:     duration,
+					Error:               evalErr,
+					EvaluationDate:      clock.FakeClock.Now(),
+				}
+			}
+
+			By("recording evaluations of two alert definitions")
+			Expect(db.RecordRuleEvaluations(ctx, []models.RuleEvaluation{
+				evaluation(defUUID, 10, ""),
+				evaluation(otherUUID, 0.5, ""),
+			}, time.Hour)).Should(Succeed())
+
+			By("recording evaluations of the first alert definition after the window, skipping the one already recorded")
+			clock.FakeClock.Add(2 * time.Hour)
+			first := evaluation(defUUID, 1, "")
+			clock.FakeClock.Add(time.Minute)
+			second := evaluation(defUUID, 3, "query timed out")
+			Expect(db.RecordRuleEvaluations(ctx, []models.RuleEvaluation{first, second}, time.Hour)).Should(Succeed())
+			Expect(db.RecordRuleEvaluations(ctx, []models.RuleEvaluation{second}, time.Hour)).Should(Succeed())
+
+			By("summarizing the evaluations recorded within the window")
+			health, err := db.GetRuleEvaluationHealth(ctx, "tenant", []uuid.UUID{defUUID, otherUUID})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(health).To(HaveLen(1))
+			Expect(health[defUUID]).To(MatchAllFields(Fields{
+				"Evaluations":        Equal(2),
+				"Failures":           Equal(1),
+				"AvgDurationSeconds": BeNumerically("~", 2),
+				"MaxDurationSeconds": BeNumerically("~", 3),
+				"LastEvaluationDate": BeTemporally("~", clock.FakeClock.Now(), time.Second),
+				"LastError":          Equal("query timed out"),
+			}))
+
+			By("summarizing no evaluations for another tenant")
+			health, err = db.GetRuleEvaluationHealth(ctx, "other", []uuid.UUID{defUUID})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(health).To(BeEmpty())
+		})
+	})
+
+	Describe("Configuration snapshots", func() {
+		defUUID := uuid.New()
+		recvUUID := uuid.New()
+
+		BeforeEach(func() {
+			Expect(db.DB.AutoMigrate(
+				&models.AlertDuration{},
+				&models.AlertThreshold{},
+				&models.AlertDefinition{},
+				&models.EmailAddress{},
+				&models.EmailConfig{},
+				&models.Receiver{},
+				&models.EmailRecipient{},
+				&models.Task{},
+				&models.Tenant{},
+				&models.EmailTemplate{},
+			)).ShouldNot(HaveOccurred())
+
+			ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
+			defer cancel()
+
+			By("creating a tenant with an applied alert definition and receiver")
+			shard := 2
+			Expect(db.DB.WithContext(ctx).Create(&models.Tenant{
+				TenantID:          "tenant",
+				LastActivityDate:  clock.FakeClock.Now(),
+				AlertmanagerShard: &shard,
+			}).Error).ShouldNot(HaveOccurred())
+
+			appliedDate := clock.FakeClock.Now()
+			def := models.AlertDefinition{
+				UUID:  defUUID,
+				Name:  "HighCPUUsage",
+				State: models.DefinitionApplied,
+				Template: `alert: HighCPUUsage
+expr: cpu_usage > 10
+for: 1m
+labels:
+  duration: 1m
+  threshold: "10"
+`,
+				Category:    models.CategoryPerformance,
+				Severity:    "critical",
+				Version:     1,
+				TenantID:    "tenant",
+				Enabled:     true,
+				AppliedDate: &appliedDate,
+			}
+			Expect(db.DB.WithContext(ctx).Create(&def).Error).ShouldNot(HaveOccurred())
+			Expect(db.DB.WithContext(ctx).Create(&models.AlertDuration{
+				Name: "duration", Duration: 60, DurationMin: 30, DurationMax: 600, AlertDefinitionID: def.ID,
+			}).Error).ShouldNot(HaveOccurred())
+			Expect(db.DB.WithContext(ctx).Create(&models.AlertThreshold{
+				Name: "threshold", Threshold: 10, ThresholdMin: 0, ThresholdMax: 100, AlertDefinitionID: def.ID,
+			}).Error).ShouldNot(HaveOccurred())
+
+			from := models.EmailAddress{Email: "alerts@example.com", FirstName: "Alert", LastName: "Monitor"}
+			Expect(db.DB.WithContext(ctx).Create(&from).Error).ShouldNot(HaveOccurred())
+			ec := models.EmailConfig{MailServer: "smtp.example.com:587", From: from.ID}
+			Expect(db.DB.WithContext(ctx).Create(&ec).Error).ShouldNot(HaveOccurred())
+			recv := models.Receiver{
+				UUID:          recvUUID,
+				Name:          "receiver",
+				State:         models.ReceiverApplied,
+				Version:       1,
+				EmailConfigID: ec.ID,
+				TenantID:      "tenant",
+				AppliedDate:   &appliedDate,
+			}
+			Expect(db.DB.WithContext(ctx).Create(&recv).Error).ShouldNot(HaveOccurred())
+
+			By("creating a second version of both")
+			threshold := int64(20)
+			Expect(db.SetAlertDefinitionValues(ctx, "tenant", defUUID, models.DBAlertDefinitionValues{Threshold: &threshold})).Should(Succeed())
+			Expect(db.SetReceiverValues(ctx, "tenant", recvUUID, models.DBReceiverValues{
+				Recipients: []models.EmailAddress{{Email: "foo@bar.com", FirstName: "Foo", LastName: "Bar"}},
+			})).Should(Succeed())
+		})
+
+		// deleteAll empties the tables holding the alerting configuration, as after losing the database.
+		deleteAll := func(ctx context.Context) {
+			for _, model := range []any{
+				&models.AlertDuration{}, &models.AlertThreshold{}, &models.AlertDefinition{}, &models.EmailAddress{},
+				&models.EmailConfig{}, &models.Receiver{}, &models.EmailRecipient{}, &models.Task{}, &models.Tenant{},
+			} {
+				Expect(db.DB.WithContext(ctx).Session(&gorm.Session{AllowGlobalUpdate: true}).Delete(model).Error).ShouldNot(HaveOccurred())
+			}
+		}
+
+		It("Snapshot every version of the alert definitions and receivers of all tenants", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
+			defer cancel()
+
+			snapshot, err := db.GetConfigSnapshot(ctx)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(snapshot.FormatVersion).To(Equal(models.SnapshotFormatVersion))
+			Expect(snapshot.Tenants).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"TenantID":          Equal("tenant"),
+				"AlertmanagerShard": PointTo(Equal(2)),
+			})))
+
+			Expect(snapshot.AlertDefinitions).To(HaveLen(2))
+			Expect(snapshot.AlertDefinitions[0].Definition.Version).To(BeEquivalentTo(1))
+			Expect(snapshot.AlertDefinitions[0].Definition.ID).To(BeZero())
+			Expect(snapshot.AlertDefinitions[0].Threshold.Threshold).To(BeEquivalentTo(10))
+			Expect(snapshot.AlertDefinitions[1].Definition.Version).To(BeEquivalentTo(2))
+			Expect(snapshot.AlertDefinitions[1].Threshold.Threshold).To(BeEquivalentTo(20))
+			Expect(snapshot.AlertDefinitions[1].Duration.Duration).To(BeEquivalentTo(60))
+
+			Expect(snapshot.Receivers).To(HaveLen(2))
+			Expect(snapshot.Receivers[0].Recipients).To(BeEmpty())
+			Expect(snapshot.Receivers[1]).To(MatchFields(IgnoreExtras, Fields{
+				"MailServer": Equal("smtp.example.com:587"),
+				"From":       Equal(models.EmailAddress{Email: "alerts@example.com", FirstName: "Alert", LastName: "Monitor"}),
+				"Recipients": Equal([]models.EmailAddress{{Email: "foo@bar.com", FirstName: "Foo", LastName: "Bar"}}),
+			}))
+		})
+
+		It("Restore a snapshot into an empty database, keeping the version numbers", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
+			defer cancel()
+
+			snapshot, err := db.GetConfigSnapshot(ctx)
+			Expect(err).ShouldNot(HaveOccurred())
+			deleteAll(ctx)
+
+			res, err := db.RestoreConfigSnapshot(ctx, snapshot)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(res).To(Equal(database.RestoreResult{Created: 2}))
+
+			By("checking that the latest versions are restored and queued to be applied")
+			def, err := db.GetAlertDefinition(ctx, "tenant", defUUID, 2)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(def.State).To(Equal(models.DefinitionNew))
+			Expect(*def.Values.Threshold).To(BeEquivalentTo(20))
+
+			recv, err := db.GetReceiverWithEmailConfig(ctx, "tenant", recvUUID, 2)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(recv.State).To(Equal(models.ReceiverNew))
+			Expect(recv.From).To(Equal("Alert Monitor <alerts@example.com>"))
+			Expect(recv.To).To(Equal([]string{"Foo Bar <foo@bar.com>"}))
+
+			var tasks []models.Task
+			Expect(db.DB.WithContext(ctx).Find(&tasks).Error).ShouldNot(HaveOccurred())
+			Expect(tasks).To(ConsistOf(
+				MatchFields(IgnoreExtras, Fields{"AlertDefinitionUUID": Equal(&defUUID), "Version": BeEquivalentTo(2), "State": Equal(models.TaskNew)}),
+				MatchFields(IgnoreExtras, Fields{"ReceiverUUID": Equal(&recvUUID), "Version": BeEquivalentTo(2), "State": Equal(models.TaskNew)}),
+			))
+
+			By("checking that older versions keep their state and the tenant its shard")
+			def, err = db.GetAlertDefinition(ctx, "tenant", defUUID, 1)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(def.State).To(Equal(models.DefinitionApplied))
+
+			shard, err := db.GetTenantShard(ctx, "tenant", 4)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(shard).To(Equal(2))
+		})
+
+		It("Restore a snapshot over a diverged database by creating new versions", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
+			defer cancel()
+
+			snapshot, err := db.GetConfigSnapshot(ctx)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			By("restoring the snapshot over the same configuration")
+			res, err := db.RestoreConfigSnapshot(ctx, snapshot)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(res).To(Equal(database.RestoreResult{Unchanged: 2}))
+
+			By("changing the alert definition after the snapshot was taken")
+			threshold := int64(30)
+			Expect(db.SetAlertDefinitionValues(ctx, "tenant", defUUID, models.DBAlertDefinitionValues{Threshold: &threshold})).Should(Succeed())
+
+			res, err = db.RestoreConfigSnapshot(ctx, snapshot)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(res).To(Equal(database.RestoreResult{Updated: 1, Unchanged: 1}))
+
+			def, err := db.GetAlertDefinition(ctx, "tenant", defUUID, 4)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(def.State).To(Equal(models.DefinitionModified))
+			Expect(*def.Values.Threshold).To(BeEquivalentTo(20))
+
+			var task models.Task
+			Expect(db.DB.WithContext(ctx).Where("alert_definition_uuid = ?", defUUID).Where("version = ?", 4).Take(&task).Error).ShouldNot(HaveOccurred())
+			Expect(task.State).To(Equal(models.TaskNew))
+		})
+
+		It("Fail to restore a snapshot of an unsupported format", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), dbQueryTimeout)
+			defer cancel()
+
+			_, err := db.RestoreConfigSnapshot(ctx, &models.ConfigSnapshot{FormatVersion: models.SnapshotFormatVersion + 1})
+			Expect(err).To(MatchError(ContainSubstring("unsupported snapshot format version")))
+		})
+	})
 })
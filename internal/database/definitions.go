@@ -7,12 +7,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 
 	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
 	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/correlation"
 	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
 	"github.com/open-edge-platform/o11y-alerting-monitor/internal/rules"
 )
@@ -21,27 +23,71 @@ var (
 	ErrValueOutOfBounds = errors.New("value out of bounds")
 )
 
-// GetLatestAlertDefinitionList gets the list with the info on the latest version of alert definitions including their duration, threshold,
-// and a flag specifying if the alerts are enabled. Alert definitions with state 'Error' are excluded.
-func (d *DBService) GetLatestAlertDefinitionList(ctx context.Context, tenantID api.TenantID) ([]*models.DBAlertDefinition, error) {
-	tx := d.DB.WithContext(ctx).Begin()
+// OutOfBoundsError tells which value of an alert definition is out of bounds along with its allowed range. It matches
+// ErrValueOutOfBounds.
+type OutOfBoundsError struct {
+	// Definition is the name of the alert definition.
+	Definition string
+	// Value is the name of the value, either duration or threshold.
+	Value string
+	// Requested is the value which was requested to be set.
+	Requested int64
+	Min       int64
+	Max       int64
+}
+
+func (e *OutOfBoundsError) Error() string {
+	return ErrValueOutOfBounds.Error()
+}
+
+func (e *OutOfBoundsError) Is(target error) bool {
+	return target == ErrValueOutOfBounds
+}
+
+// GetLatestAlertDefinitionList gets a page of the list with the info on the latest version of alert definitions including their duration,
+// threshold, and a flag specifying if the alerts are enabled, sorted as given by the list options. Alert definitions with state 'Error' and maintenance
+// alert definitions are excluded. The total number of alert definitions, regardless of pagination, is returned as well. It is read
+// from the read replica, if any.
+func (d *DBService) GetLatestAlertDefinitionList(ctx context.Context, tenantID api.TenantID, opts ListOptions) ([]*models.DBAlertDefinition, int64, error) {
+	tx := d.reader(ctx).Begin()
 	defer tx.Rollback()
 
-	definitionUUIDs, err := GetAlertDefinitionUUIDs(tx, tenantID)
+	query := tx.Model(&models.AlertDefinition{}).
+		Where("tenant_id = ?", tenantID).
+		Where("COALESCE(category, '') != ?", models.CategoryMaintenance).
+		Where("version = (?)", tx.Model(&models.AlertDefinition{}).
+			Select("MAX(latest.version)").
+			Table("alert_definitions latest").
+			Where("latest.tenant_id = alert_definitions.tenant_id").
+			Where("latest.uuid = alert_definitions.uuid").
+			Where("latest.state != ?", models.DefinitionError),
+		)
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count alert definitions for tenant %q: %w", tenantID, err)
+	}
+
+	sorted, err := sortList(query, opts, "severity")
 	if err != nil {
-		return nil, fmt.Errorf("failed to get list of alert definition UUIDs for tenant %q: %w", tenantID, err)
+		return nil, 0, fmt.Errorf("failed to sort alert definitions for tenant %q: %w", tenantID, err)
 	}
 
-	definitions := make([]*models.DBAlertDefinition, len(definitionUUIDs))
-	for i, definitionUUID := range definitionUUIDs {
-		d, err := d.GetLatestAlertDefinition(ctx, tenantID, definitionUUID)
+	var ads []models.AlertDefinition
+	if err := paginate(sorted, opts).Find(&ads).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to get list of alert definitions for tenant %q: %w", tenantID, err)
+	}
+
+	definitions := make([]*models.DBAlertDefinition, len(ads))
+	for i, ad := range ads {
+		def, err := getDBAlertDefinition(tx, ad.UUID, ad)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get alert definition %q for tenant %q: %w", definitionUUID, tenantID, err)
+			return nil, 0, fmt.Errorf("failed to get alert definition %q for tenant %q: %w", ad.UUID, tenantID, err)
 		}
-		definitions[i] = d
+		definitions[i] = def
 	}
 
-	return definitions, nil
+	return definitions, total, nil
 }
 
 // GetAlertDefinitionUUIDs is a helper function that gets the list with unique alert definition UUIDs.
@@ -91,21 +137,31 @@ func (d *DBService) GetAlertDefinition(ctx context.Context, tenantID api.TenantI
 
 func getDBAlertDefinition(tx *gorm.DB, id uuid.UUID, ad models.AlertDefinition) (*models.DBAlertDefinition, error) {
 	res := &models.DBAlertDefinition{
-		ID:       ad.UUID,
-		Name:     ad.Name,
-		State:    ad.State,
-		Template: ad.Template,
-		Interval: ad.AlertInterval,
-		Version:  ad.Version,
-		Category: ad.Category,
-		TenantID: ad.TenantID,
+		ID:        ad.UUID,
+		Name:      ad.Name,
+		State:     ad.State,
+		Template:  ad.Template,
+		Interval:  ad.AlertInterval,
+		Version:   ad.Version,
+		Category:  ad.Category,
+		TenantID:  ad.TenantID,
+		UpdatedAt: ad.CreationDate,
+
+		ThresholdAutoTuned: ad.ThresholdAutoTuned,
+	}
+
+	createdAt, appliedAt, err := versionTimestamps(tx, &models.AlertDefinition{}, ad.TenantID, id, ad.Version)
+	if err != nil {
+		return nil, err
 	}
+	res.CreatedAt = createdAt
+	res.AppliedAt = appliedAt
 
 	row := tx.
 		Table("alert_definitions adef").
 		Joins("INNER JOIN alert_durations adur ON adur.alert_definition_id = adef.id").
 		Joins("INNER JOIN alert_thresholds athr ON athr.alert_definition_id = adef.id").
-		Select("adur.duration, athr.threshold, adef.enabled").
+		Select("adur.duration, athr.threshold, adef.enabled, adef.auto_tune").
 		Where("adef.tenant_id = ?", ad.TenantID).
 		Where("adef.uuid = ?", id).
 		Where("adef.version = ?", ad.Version).
@@ -115,6 +171,7 @@ func getDBAlertDefinition(tx *gorm.DB, id uuid.UUID, ad models.AlertDefinition)
 		&res.Values.Duration,
 		&res.Values.Threshold,
 		&res.Values.Enabled,
+		&res.Values.AutoTune,
 	); err != nil {
 		return nil, err
 	}
@@ -122,24 +179,228 @@ func getDBAlertDefinition(tx *gorm.DB, id uuid.UUID, ad models.AlertDefinition)
 	return res, nil
 }
 
-// SetAlertDefinitionValues sets values such as duration, threshold, and enabled state of an alert definition given its UUID.
-// It also creates a new task for task executor, linked to the newly created definition.
+// SetAlertDefinitionValues sets values such as duration, threshold, enabled state, and threshold auto-tuning of an alert definition given its UUID.
+// It also creates a new task for task executor, linked to the newly created definition. It is retried if it conflicts with a concurrent update.
 func (d *DBService) SetAlertDefinitionValues(ctx context.Context, tenantID api.TenantID, id uuid.UUID, values models.DBAlertDefinitionValues) error {
+	return d.retryTx(ctx, func(tx *gorm.DB) error {
+		// Get the latest version of the alert definition by UUID and tenantID, if exists.
+		var definition models.AlertDefinition
+		if err := tx.Where("tenant_id = ?", tenantID).Where("uuid = ?", id).Order("version desc").First(&definition).Error; err != nil {
+			return fmt.Errorf("failed to retrieve latest version of alert definition for tenant %q: %w", tenantID, err)
+		}
+
+		// A threshold set by a user replaces the auto-tuned one.
+		autoTuned := definition.ThresholdAutoTuned && values.Threshold == nil
+		return createAlertDefinitionVersion(tx, definition, values, autoTuned)
+	})
+}
+
+// ResetAlertDefinitionValues restores the duration, threshold, and enabled state of an alert definition given its UUID to their
+// defaults in the catalog, creating a new version along with a task for task executor. Alert definitions of the catalog are enabled
+// by default, and values whose default is not known are left unchanged. The values set are returned. It is retried if it conflicts
+// with a concurrent update.
+func (d *DBService) ResetAlertDefinitionValues(ctx context.Context, tenantID api.TenantID, id uuid.UUID) (*models.DBAlertDefinitionValues, error) {
+	var (
+		definition models.AlertDefinition
+		duration   models.AlertDuration
+		threshold  models.AlertThreshold
+		values     models.DBAlertDefinitionValues
+	)
+	if err := d.retryTx(ctx, func(tx *gorm.DB) error {
+		// Get the latest version of the alert definition by UUID and tenantID, if exists.
+		if err := tx.Where("tenant_id = ?", tenantID).Where("uuid = ?", id).Order("version desc").First(&definition).Error; err != nil {
+			return fmt.Errorf("failed to retrieve latest version of alert definition for tenant %q: %w", tenantID, err)
+		}
+
+		if err := tx.Where("alert_definition_id = ?", definition.ID).Take(&duration).Error; err != nil {
+			return fmt.Errorf("failed to retrieve duration for alert definition ID %v: %w", definition.ID, err)
+		}
+		if err := tx.Where("alert_definition_id = ?", definition.ID).Take(&threshold).Error; err != nil {
+			return fmt.Errorf("failed to retrieve threshold for alert definition ID %v: %w", definition.ID, err)
+		}
+
+		enabled := true
+		values = models.DBAlertDefinitionValues{
+			Duration:  duration.DurationDefault,
+			Threshold: threshold.ThresholdDefault,
+			Enabled:   &enabled,
+		}
+		return createAlertDefinitionVersion(tx, definition, values, false)
+	}); err != nil {
+		return nil, err
+	}
+
+	if values.Duration == nil {
+		values.Duration = &duration.Duration
+	}
+	if values.Threshold == nil {
+		values.Threshold = &threshold.Threshold
+	}
+	values.AutoTune = &definition.AutoTune
+	return &values, nil
+}
+
+// GetAutoTunedAlertDefinitions gets the info on the latest version of the enabled alert definitions of all tenants which have
+// threshold auto-tuning turned on. Alert definitions whose latest version has state 'Error' are excluded, since they need to be fixed
+// by a user first, as well as the alert definitions of archived tenants.
+func (d *DBService) GetAutoTunedAlertDefinitions(ctx context.Context) ([]*models.DBAlertDefinition, error) {
 	tx := d.DB.WithContext(ctx).Begin()
 	defer tx.Rollback()
 
-	// Get the latest version of the alert definition by UUID and tenantID, if exists.
-	var definition models.AlertDefinition
-	if err := tx.Where("tenant_id = ?", tenantID).Where("uuid = ?", id).Order("version desc").First(&definition).Error; err != nil {
-		return fmt.Errorf("failed to retrieve latest version of alert definition for tenant %q: %w", tenantID, err)
+	// The values and the timestamps, as computed by versionTimestamps, are joined so that all definitions are read in a
+	// single query: the creation date is the one of the first version, and the applied date the one of the last applied version.
+	var rows []struct {
+		models.AlertDefinition
+		Duration  int64
+		Threshold int64
+		CreatedAt time.Time
+		AppliedAt *time.Time
+	}
+	if err := tx.
+		Table("alert_definitions adef").
+		Joins("INNER JOIN alert_durations adur ON adur.alert_definition_id = adef.id").
+		Joins("INNER JOIN alert_thresholds athr ON athr.alert_definition_id = adef.id").
+		Joins("INNER JOIN alert_definitions created ON created.tenant_id = adef.tenant_id AND created.uuid = adef.uuid AND created.version = (?)",
+			tx.Table("alert_definitions v").
+				Select("MIN(v.version)").
+				Where("v.tenant_id = adef.tenant_id").
+				Where("v.uuid = adef.uuid"),
+		).
+		Joins("LEFT JOIN alert_definitions applied ON applied.tenant_id = adef.tenant_id AND applied.uuid = adef.uuid AND applied.version = (?)",
+			tx.Table("alert_definitions v").
+				Select("MAX(v.version)").
+				Where("v.tenant_id = adef.tenant_id").
+				Where("v.uuid = adef.uuid").
+				Where("v.applied_date IS NOT NULL"),
+		).
+		Select("adef.*, adur.duration, athr.threshold, created.creation_date AS created_at, applied.applied_date AS applied_at").
+		Where("adef.version = (?)", tx.
+			Table("alert_definitions latest").
+			Select("MAX(latest.version)").
+			Where("latest.tenant_id = adef.tenant_id").
+			Where("latest.uuid = adef.uuid"),
+		).
+		Where("adef.state != ?", models.DefinitionError).
+		Where("adef.auto_tune = ?", true).
+		Where("adef.enabled = ?", true).
+		Where("NOT EXISTS (?)", tx.Model(&models.Tenant{}).
+			Select("1").
+			Where("tenants.tenant_id = adef.tenant_id").
+			Where("tenants.archived_date IS NOT NULL"),
+		).
+		Order("adef.tenant_id").
+		Order("adef.uuid").
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to get list of auto-tuned alert definitions: %w", err)
 	}
 
-	// Set enabled field for the new alert definition.
-	var enabledValue bool
+	definitions := make([]*models.DBAlertDefinition, len(rows))
+	for i, row := range rows {
+		definitions[i] = &models.DBAlertDefinition{
+			ID:        row.UUID,
+			Name:      row.Name,
+			State:     row.State,
+			Template:  row.Template,
+			Interval:  row.AlertInterval,
+			Version:   row.Version,
+			Category:  row.Category,
+			TenantID:  row.TenantID,
+			CreatedAt: row.CreatedAt,
+			UpdatedAt: row.CreationDate,
+			AppliedAt: row.AppliedAt,
+			Values: models.DBAlertDefinitionValues{
+				Duration:  &row.Duration,
+				Threshold: &row.Threshold,
+				Enabled:   &row.Enabled,
+				AutoTune:  &row.AutoTune,
+			},
+
+			ThresholdAutoTuned: row.ThresholdAutoTuned,
+		}
+	}
+
+	return definitions, nil
+}
+
+// GetLatestAlertDefinitionStates gets the tenant, UUID, name, version, and state of the latest version of the alert definitions of all
+// tenants, regardless of whether it was successfully applied. Alert definitions of archived tenants are left out.
+func (d *DBService) GetLatestAlertDefinitionStates(ctx context.Context) ([]models.AlertDefinition, error) {
+	tx := d.DB.WithContext(ctx)
+
+	var ads []models.AlertDefinition
+	if err := tx.Model(&models.AlertDefinition{}).
+		Select("tenant_id", "uuid", "name", "version", "state").
+		Where("version = (?)", tx.Model(&models.AlertDefinition{}).
+			Select("MAX(latest.version)").
+			Table("alert_definitions latest").
+			Where("latest.tenant_id = alert_definitions.tenant_id").
+			Where("latest.uuid = alert_definitions.uuid"),
+		).
+		Where("NOT EXISTS (?)", tx.Model(&models.Tenant{}).
+			Select("1").
+			Where("tenants.tenant_id = alert_definitions.tenant_id").
+			Where("tenants.archived_date IS NOT NULL"),
+		).
+		Order("tenant_id").
+		Order("uuid").
+		Find(&ads).Error; err != nil {
+		return nil, fmt.Errorf("failed to get states of alert definitions: %w", err)
+	}
+	return ads, nil
+}
+
+// SetAutoTunedThreshold sets the threshold of an alert definition to a value computed by auto-tuning, creating a new version
+// labeled as auto-tuned along with a task for task executor. The threshold is clamped to the allowed minimum and maximum of the
+// alert definition. Nothing is done if the given version is no longer the latest one, if auto-tuning has been turned off in the
+// meantime, or if the threshold is unchanged. It returns whether a new version was created. It is retried if it conflicts with a
+// concurrent update.
+func (d *DBService) SetAutoTunedThreshold(ctx context.Context, tenantID api.TenantID, id uuid.UUID, version int64, threshold int64) (bool, error) {
+	created := false
+	err := d.retryTx(ctx, func(tx *gorm.DB) error {
+		created = false
+
+		var definition models.AlertDefinition
+		if err := tx.Where("tenant_id = ?", tenantID).Where("uuid = ?", id).Order("version desc").First(&definition).Error; err != nil {
+			return fmt.Errorf("failed to retrieve latest version of alert definition for tenant %q: %w", tenantID, err)
+		}
+		if definition.Version != version || !definition.AutoTune {
+			return nil
+		}
+
+		var current models.AlertThreshold
+		if err := tx.Where("alert_definition_id = ?", definition.ID).Take(&current).Error; err != nil {
+			return fmt.Errorf("failed to retrieve threshold for alert definition ID %v: %w", definition.ID, err)
+		}
+
+		tuned := min(max(threshold, current.ThresholdMin), current.ThresholdMax)
+		if tuned == current.Threshold {
+			return nil
+		}
+
+		if err := createAlertDefinitionVersion(tx, definition, models.DBAlertDefinitionValues{Threshold: &tuned}, true); err != nil {
+			return err
+		}
+		created = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return created, nil
+}
+
+// createAlertDefinitionVersion is a helper function that creates a new version of the given alert definition with the given values
+// set, along with its duration, threshold, and a task for task executor. Values that are nil remain unchanged. The autoTuned argument
+// tells whether the threshold of the new version was set by auto-tuning.
+func createAlertDefinitionVersion(tx *gorm.DB, definition models.AlertDefinition, values models.DBAlertDefinitionValues, autoTuned bool) error {
+	// Set enabled and auto-tune fields for the new alert definition.
+	enabledValue := definition.Enabled
 	if values.Enabled != nil {
 		enabledValue = *values.Enabled
-	} else {
-		enabledValue = definition.Enabled
+	}
+	autoTuneValue := definition.AutoTune
+	if values.AutoTune != nil {
+		autoTuneValue = *values.AutoTune
 	}
 
 	tmpl, err := rules.UpdateTemplateWithValues(definition.Template, values.Duration, values.Threshold)
@@ -149,17 +410,19 @@ func (d *DBService) SetAlertDefinitionValues(ctx context.Context, tenantID api.T
 
 	// Create new alert definition with enabled field set and bumped version.
 	newDefinition := models.AlertDefinition{
-		UUID:          definition.UUID,
-		Name:          definition.Name,
-		State:         models.DefinitionModified,
-		Template:      tmpl,
-		Category:      definition.Category,
-		Context:       definition.Context,
-		Severity:      definition.Severity,
-		AlertInterval: definition.AlertInterval,
-		Enabled:       enabledValue,
-		Version:       definition.Version + 1,
-		TenantID:      definition.TenantID,
+		UUID:               definition.UUID,
+		Name:               definition.Name,
+		State:              models.DefinitionModified,
+		Template:           tmpl,
+		Category:           definition.Category,
+		Context:            definition.Context,
+		Severity:           definition.Severity,
+		AlertInterval:      definition.AlertInterval,
+		Enabled:            enabledValue,
+		Version:            definition.Version + 1,
+		TenantID:           definition.TenantID,
+		AutoTune:           autoTuneValue,
+		ThresholdAutoTuned: autoTuned,
 	}
 	if err := tx.Create(&newDefinition).Error; err != nil {
 		return fmt.Errorf("failed to create new alert definition with bumped version %v: %w", newDefinition.Version, err)
@@ -167,11 +430,13 @@ func (d *DBService) SetAlertDefinitionValues(ctx context.Context, tenantID api.T
 
 	// Create new alert duration and associate it to the new alert definition.
 	if err := setAlertDefinitionDuration(tx, definition.ID, newDefinition.ID, values.Duration); err != nil {
+		setOutOfBoundsDefinition(err, definition.Name)
 		return fmt.Errorf("failed to set duration to new alert definition ID %v: %w", newDefinition.ID, err)
 	}
 
 	// Create new alert threshold and associate it to the new alert definition.
 	if err := setAlertDefinitionThreshold(tx, definition.ID, newDefinition.ID, values.Threshold); err != nil {
+		setOutOfBoundsDefinition(err, definition.Name)
 		return fmt.Errorf("failed to set threshold to new alert definition ID %v: %w", newDefinition.ID, err)
 	}
 
@@ -181,13 +446,14 @@ func (d *DBService) SetAlertDefinitionValues(ctx context.Context, tenantID api.T
 		TenantID:            newDefinition.TenantID,
 		Version:             newDefinition.Version,
 		CreationDate:        clock.TimeNowFn(),
+		CorrelationID:       correlation.FromContext(tx.Statement.Context),
+		OperationID:         OperationFromContext(tx.Statement.Context),
 	}
 
 	if err := tx.Create(&task).Error; err != nil {
 		return fmt.Errorf("failed to create a new task for alert definition ID %v version %v: %w", newDefinition.ID, newDefinition.Version, err)
 	}
-
-	return tx.Commit().Error
+	return nil
 }
 
 // SetAlertDefinitionState updates the `State` column of specific alert definition version.
@@ -215,6 +481,31 @@ func setAlertDefinitionState(tx *gorm.DB, tenantID api.TenantID, id uuid.UUID, v
 	return nil
 }
 
+// setAlertDefinitionApplied sets the state of a specific alert definition version to 'Applied' and records the time it was applied.
+func setAlertDefinitionApplied(tx *gorm.DB, tenantID api.TenantID, id uuid.UUID, version int64, appliedDate time.Time) error {
+	var definition models.AlertDefinition
+
+	if err := tx.Where("tenant_id = ?", tenantID).Where("uuid = ?", id).Where("version = ?", version).Take(&definition).Error; err != nil {
+		return fmt.Errorf("failed to retrieve alert definition for tenant %q: %w", tenantID, err)
+	}
+
+	if err := tx.Model(&definition).Updates(map[string]any{
+		"state":        models.DefinitionApplied,
+		"applied_date": appliedDate.UTC(),
+	}).Error; err != nil {
+		return fmt.Errorf("failed to update alert definition state: %w", err)
+	}
+	return nil
+}
+
+// setOutOfBoundsDefinition sets the name of the alert definition a value is out of bounds of, if the error is an OutOfBoundsError.
+func setOutOfBoundsDefinition(err error, name string) {
+	var oob *OutOfBoundsError
+	if errors.As(err, &oob) {
+		oob.Definition = name
+	}
+}
+
 // setAlertDefinitionDuration is a helper function that creates a new alert duration. It populates its content with the alert duration
 // associated to fromID foreign key. The duration value is set to the value argument, if not nil. Otherwise remains unchanged. Eventually
 // it associates the newly created duration with the alert definition ID specified by toID argument. Additionally checks that the value to
@@ -233,7 +524,8 @@ func setAlertDefinitionDuration(tx *gorm.DB, fromID, toID int64, value *int64) e
 	}
 
 	if durationValue < duration.DurationMin || durationValue > duration.DurationMax {
-		return fmt.Errorf("duration value out of valid range [%d, %d] seconds: %w", duration.DurationMin, duration.DurationMax, ErrValueOutOfBounds)
+		return fmt.Errorf("duration value out of valid range [%d, %d] seconds: %w", duration.DurationMin, duration.DurationMax,
+			&OutOfBoundsError{Value: "duration", Requested: durationValue, Min: duration.DurationMin, Max: duration.DurationMax})
 	}
 
 	// Create new duration and associate it with the new alert definition's foreign key.
@@ -243,6 +535,7 @@ func setAlertDefinitionDuration(tx *gorm.DB, fromID, toID int64, value *int64) e
 		DurationMin:       duration.DurationMin,
 		DurationMax:       duration.DurationMax,
 		AlertDefinitionID: toID,
+		DurationDefault:   duration.DurationDefault,
 	}
 	if err := tx.Create(&newDuration).Error; err != nil {
 		return errors.New("failed to create duration with new value set")
@@ -269,7 +562,8 @@ func setAlertDefinitionThreshold(tx *gorm.DB, fromID, toID int64, value *int64)
 	}
 
 	if thresholdValue < threshold.ThresholdMin || thresholdValue > threshold.ThresholdMax {
-		return fmt.Errorf("threshold value out of valid range [%d, %d]: %w", threshold.ThresholdMin, threshold.ThresholdMax, ErrValueOutOfBounds)
+		return fmt.Errorf("threshold value out of valid range [%d, %d]: %w", threshold.ThresholdMin, threshold.ThresholdMax,
+			&OutOfBoundsError{Value: "threshold", Requested: thresholdValue, Min: threshold.ThresholdMin, Max: threshold.ThresholdMax})
 	}
 
 	// Create new threshold and associate it with the new alert definition's foreign key.
@@ -279,6 +573,7 @@ func setAlertDefinitionThreshold(tx *gorm.DB, fromID, toID int64, value *int64)
 		ThresholdMin:      threshold.ThresholdMin,
 		ThresholdMax:      threshold.ThresholdMax,
 		AlertDefinitionID: toID,
+		ThresholdDefault:  threshold.ThresholdDefault,
 	}
 	if err := tx.Create(&newThreshold).Error; err != nil {
 		return errors.New("failed to create threshold with new value set")
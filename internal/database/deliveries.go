@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+// RecordEmailDeliveries records the outcome of sending the email of a notification of a receiver to each of its recipients,
+// and deletes the deliveries of the receiver recorded before the given retention period.
+func (d *DBService) RecordEmailDeliveries(ctx context.Context, tenantID api.TenantID, id uuid.UUID, deliveries []models.EmailDelivery,
+	retention time.Duration,
+) error {
+	tx := d.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if len(deliveries) != 0 {
+		if err := tx.Create(&deliveries).Error; err != nil {
+			return fmt.Errorf("failed to record email deliveries of receiver %q: %w", id, err)
+		}
+	}
+
+	if err := tx.
+		Where("tenant_id = ? AND receiver_uuid = ?", tenantID, id).
+		Where("creation_date < ?", clock.TimeNowFn().UTC().Add(-retention)).
+		Delete(&models.EmailDelivery{}).Error; err != nil {
+		return fmt.Errorf("failed to delete expired email deliveries of receiver %q: %w", id, err)
+	}
+
+	return tx.Commit().Error
+}
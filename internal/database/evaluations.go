@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm/clause"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+// RecordRuleEvaluations records the given evaluations of the rule groups of alert definitions, and deletes the evaluations of
+// all alert definitions recorded before the given window. Evaluations already recorded are skipped, since the same evaluation
+// is reported by Mimir ruler until the rule group is evaluated again.
+func (d *DBService) RecordRuleEvaluations(ctx context.Context, evaluations []models.RuleEvaluation, window time.Duration) error {
+	tx := d.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if len(evaluations) != 0 {
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&evaluations).Error; err != nil {
+			return fmt.Errorf("failed to record rule evaluations: %w", err)
+		}
+	}
+
+	if err := tx.
+		Where("evaluation_date < ?", clock.TimeNowFn().UTC().Add(-window)).
+		Delete(&models.RuleEvaluation{}).Error; err != nil {
+		return fmt.Errorf("failed to delete expired rule evaluations: %w", err)
+	}
+
+	return tx.Commit().Error
+}
+
+// GetRuleEvaluationHealth summarizes the recorded evaluations of the rule groups of the given alert definitions of a tenant.
+// Alert definitions without recorded evaluations are left out of the returned map.
+func (d *DBService) GetRuleEvaluationHealth(ctx context.Context, tenantID api.TenantID, ids []uuid.UUID) (map[uuid.UUID]models.RuleEvaluationHealth, error) {
+	var evaluations []models.RuleEvaluation
+	if err := d.DB.WithContext(ctx).
+		Where("tenant_id = ? AND alert_definition_uuid IN ?", tenantID, ids).
+		Order("evaluation_date").
+		Find(&evaluations).Error; err != nil {
+		return nil, fmt.Errorf("failed to get rule evaluations of tenant %q: %w", tenantID, err)
+	}
+
+	health := make(map[uuid.UUID]models.RuleEvaluationHealth)
+	for _, e := range evaluations {
+		h := health[e.AlertDefinitionUUID]
+		h.AvgDurationSeconds += (e.DurationSeconds - h.AvgDurationSeconds) / float64(h.Evaluations+1)
+		h.Evaluations++
+		h.MaxDurationSeconds = max(h.MaxDurationSeconds, e.DurationSeconds)
+		h.LastEvaluationDate = e.EvaluationDate
+		if e.Error != "" {
+			h.Failures++
+			h.LastError = e.Error
+		}
+		health[e.AlertDefinitionUUID] = h
+	}
+	return health, nil
+}
@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+// RecordExecutorHeartbeat records the current time as the last heartbeat of the executor with the given UUID, registering
+// the executor on its first heartbeat.
+func (d *DBService) RecordExecutorHeartbeat(ctx context.Context, ownerUUID uuid.UUID) error {
+	now := clock.TimeNowFn().UTC()
+	if err := d.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "uuid"}},
+		DoUpdates: clause.AssignmentColumns([]string{"heartbeat_date"}),
+	}).Create(&models.Executor{
+		UUID:          ownerUUID,
+		StartDate:     now,
+		HeartbeatDate: now,
+	}).Error; err != nil {
+		return fmt.Errorf("failed to record heartbeat of executor %v: %w", ownerUUID, err)
+	}
+	return nil
+}
+
+// RecordExecutorPoll records the current time as the last time the executor with the given UUID polled the pending tasks, and
+// adds the given number of tasks it processed to its count. Nothing is recorded for executors which are not registered.
+func (d *DBService) RecordExecutorPoll(ctx context.Context, ownerUUID uuid.UUID, processed int) error {
+	if err := d.DB.WithContext(ctx).Model(&models.Executor{}).Where("uuid = ?", ownerUUID).Updates(map[string]any{
+		"last_poll_date":  clock.TimeNowFn().UTC(),
+		"processed_tasks": gorm.Expr("processed_tasks + ?", processed),
+	}).Error; err != nil {
+		return fmt.Errorf("failed to record poll of executor %v: %w", ownerUUID, err)
+	}
+	return nil
+}
+
+// GetExecutors gets the registered executors, oldest first.
+func (d *DBService) GetExecutors(ctx context.Context) ([]models.Executor, error) {
+	var executors []models.Executor
+	if err := d.DB.WithContext(ctx).Order("start_date").Find(&executors).Error; err != nil {
+		return nil, fmt.Errorf("failed to get executors: %w", err)
+	}
+	return executors, nil
+}
+
+// ReclaimOrphanedTasks sets the Taken tasks of registered executors without a heartbeat within the given timeout back to New,
+// so that they are taken again right away instead of once they exceed the task timeout. It is run by the executor with the
+// given UUID as it starts, so its own Taken tasks, left by a previous run with the same UUID, are reclaimed as well. Tasks of
+// owners which are not registered are left to the task timeout, as they may be taken by executors running without heartbeats.
+// Executors without a recent heartbeat are removed, and the number of reclaimed tasks is returned.
+func (d *DBService) ReclaimOrphanedTasks(ctx context.Context, ownerUUID uuid.UUID, heartbeatTimeout time.Duration) (int64, error) {
+	var reclaimed int64
+	err := d.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		stale := tx.Model(&models.Executor{}).
+			Select("uuid").
+			Where("heartbeat_date < ?", clock.TimeNowFn().UTC().Add(-heartbeatTimeout)).
+			Where("uuid != ?", ownerUUID)
+
+		res := tx.Model(&models.Task{}).
+			Where("state = ?", models.TaskTaken).
+			Where("owner_uuid = ? OR owner_uuid IN (?)", ownerUUID, stale).
+			Update("state", models.TaskNew)
+		if res.Error != nil {
+			return fmt.Errorf("failed to reclaim tasks of dead executors: %w", res.Error)
+		}
+		reclaimed = res.RowsAffected
+
+		if err := tx.Where("uuid IN (?)", stale).Delete(&models.Executor{}).Error; err != nil {
+			return fmt.Errorf("failed to remove dead executors: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return reclaimed, nil
+}
@@ -0,0 +1,203 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+// rollupKey identifies the daily rollup of the task history of an alert definition or receiver.
+type rollupKey struct {
+	tenantID string
+	uuid     uuid.UUID
+	day      time.Time
+}
+
+// rollupMergeAssignments merge the rollup being inserted into the rollup of the same day already stored, if any, so that
+// rollups compacted by several replicas at once add up.
+var rollupMergeAssignments = clause.Assignments(map[string]any{
+	"applied":              gorm.Expr("task_history_rollups.applied + excluded.applied"),
+	"invalid":              gorm.Expr("task_history_rollups.invalid + excluded.invalid"),
+	"retries":              gorm.Expr("task_history_rollups.retries + excluded.retries"),
+	"last_version":         gorm.Expr(greatestExpr("last_version")),
+	"total_queue_duration": gorm.Expr("task_history_rollups.total_queue_duration + excluded.total_queue_duration"),
+	"total_apply_duration": gorm.Expr("task_history_rollups.total_apply_duration + excluded.total_apply_duration"),
+	"max_apply_duration":   gorm.Expr(greatestExpr("max_apply_duration")),
+})
+
+// greatestExpr returns the expression of the greatest value of the given column between the rollup stored and the rollup
+// being inserted. GREATEST is not used, as it is not supported by SQLite.
+func greatestExpr(column string) string {
+	return fmt.Sprintf("CASE WHEN excluded.%[1]s > task_history_rollups.%[1]s THEN excluded.%[1]s ELSE task_history_rollups.%[1]s END",
+		column)
+}
+
+// CompactTaskHistory compacts the task history completed before rawBefore into daily rollups per alert definition and
+// receiver, and deletes the rollups of the days before rollupBefore, unless it is zero. The task history is compacted in
+// batches of the given size, each in its own transaction, skipping on PostgreSQL the summaries locked by replicas compacting
+// at the same time. The number of compacted summaries and deleted rollups is returned, including those of the batches
+// compacted before an error.
+func (d *DBService) CompactTaskHistory(ctx context.Context, rawBefore, rollupBefore time.Time, batchSize int) (int64, int64, error) {
+	if batchSize <= 0 {
+		batchSize = taskHistoryBatchSize
+	}
+
+	var compacted int64
+	for {
+		n, err := d.compactTaskHistoryBatch(ctx, rawBefore, batchSize)
+		compacted += n
+		if err != nil {
+			return compacted, 0, err
+		}
+		if n < int64(batchSize) {
+			break
+		}
+		if err := ctx.Err(); err != nil {
+			return compacted, 0, err
+		}
+	}
+
+	if rollupBefore.IsZero() {
+		return compacted, 0, nil
+	}
+	res := d.DB.WithContext(ctx).Where("day < ?", models.RollupDay(rollupBefore)).Delete(&models.TaskHistoryRollup{})
+	if res.Error != nil {
+		return compacted, 0, fmt.Errorf("failed to delete expired task history rollups: %w", res.Error)
+	}
+	return compacted, res.RowsAffected, nil
+}
+
+// compactTaskHistoryBatch compacts up to the given number of summaries of the task history completed before the given time,
+// oldest first, into the daily rollups, deletes them, and returns the number of compacted summaries.
+func (d *DBService) compactTaskHistoryBatch(ctx context.Context, before time.Time, batchSize int) (int64, error) {
+	tx := d.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	query := tx
+	if supportsSkipLocked(tx) {
+		query = query.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate, Options: clause.LockingOptionsSkipLocked})
+	}
+	var history []models.TaskHistory
+	if err := query.
+		Where("completion_date < ?", before).
+		Order("id").
+		Limit(batchSize).
+		Find(&history).Error; err != nil {
+		return 0, fmt.Errorf("failed to get task history to compact: %w", err)
+	}
+	if len(history) == 0 {
+		return 0, nil
+	}
+
+	ids := make([]int64, 0, len(history))
+	for _, h := range history {
+		ids = append(ids, h.ID)
+	}
+	rollups := rollUpTaskHistory(history)
+
+	if err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tenant_id"}, {Name: "uuid"}, {Name: "day"}},
+		DoUpdates: rollupMergeAssignments,
+	}).Create(&rollups).Error; err != nil {
+		return 0, fmt.Errorf("failed to store task history rollups: %w", err)
+	}
+	if err := tx.Where("id IN ?", ids).Delete(&models.TaskHistory{}).Error; err != nil {
+		return 0, fmt.Errorf("failed to delete compacted task history: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return 0, err
+	}
+	return int64(len(history)), nil
+}
+
+// rollUpTaskHistory aggregates the given summaries of completed tasks into daily rollups, ordered by alert definition or
+// receiver and day.
+func rollUpTaskHistory(history []models.TaskHistory) []models.TaskHistoryRollup {
+	rollups := make(map[rollupKey]*models.TaskHistoryRollup)
+	for _, h := range history {
+		key := rollupKey{tenantID: h.TenantID, uuid: h.UUID, day: models.RollupDay(h.CompletionDate)}
+		rollup, ok := rollups[key]
+		if !ok {
+			r := models.NewTaskHistoryRollup(h)
+			rollup = &r
+			rollups[key] = rollup
+		}
+		rollup.Add(h)
+	}
+
+	list := make([]models.TaskHistoryRollup, 0, len(rollups))
+	for _, key := range slices.SortedFunc(maps.Keys(rollups), func(a, b rollupKey) int {
+		return cmp.Or(
+			cmp.Compare(a.tenantID, b.tenantID),
+			cmp.Compare(a.uuid.String(), b.uuid.String()),
+			a.day.Compare(b.day),
+		)
+	}) {
+		list = append(list, *rollups[key])
+	}
+	return list
+}
+
+// GetTaskHistoryTrend gets the daily rollups of the completed tasks of an alert definition or receiver given its UUID, from
+// the day of the given time, oldest first. The task history not compacted yet and the completed tasks not yet deleted are
+// rolled up along with the stored rollups. It is read from the read replica, if any.
+func (d *DBService) GetTaskHistoryTrend(ctx context.Context, tenantID api.TenantID, id uuid.UUID, since time.Time) ([]models.TaskHistoryRollup, error) {
+	since = models.RollupDay(since)
+
+	var stored []models.TaskHistoryRollup
+	if err := d.reader(ctx).
+		Where("tenant_id = ? AND uuid = ?", tenantID, id).
+		Where("day >= ?", since).
+		Find(&stored).Error; err != nil {
+		return nil, fmt.Errorf("failed to get task history rollups of %q for tenant %q: %w", id, tenantID, err)
+	}
+
+	var history []models.TaskHistory
+	if err := d.reader(ctx).
+		Where("tenant_id = ? AND uuid = ?", tenantID, id).
+		Where("completion_date >= ?", since).
+		Find(&history).Error; err != nil {
+		return nil, fmt.Errorf("failed to get task history of %q for tenant %q: %w", id, tenantID, err)
+	}
+
+	var tasks []models.Task
+	if err := d.reader(ctx).
+		Where("(alert_definition_uuid = ? OR receiver_uuid = ?)", id, id).
+		Where("tenant_id = ?", tenantID).
+		Where("state IN (?,?)", models.TaskApplied, models.TaskInvalid).
+		Where("completion_date >= ?", since).
+		Find(&tasks).Error; err != nil {
+		return nil, fmt.Errorf("failed to get completed tasks of %q for tenant %q: %w", id, tenantID, err)
+	}
+	for _, task := range tasks {
+		history = append(history, models.NewTaskHistory(task))
+	}
+
+	trend := rollUpTaskHistory(history)
+	for _, rollup := range stored {
+		i := slices.IndexFunc(trend, func(r models.TaskHistoryRollup) bool { return r.Day.Equal(rollup.Day) })
+		if i < 0 {
+			trend = append(trend, rollup)
+			continue
+		}
+		trend[i].Merge(rollup)
+	}
+	slices.SortFunc(trend, func(a, b models.TaskHistoryRollup) int {
+		return a.Day.Compare(b.Day)
+	})
+	return trend, nil
+}
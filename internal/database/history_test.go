@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+func TestCompactTaskHistory(t *testing.T) {
+	conn, err := gorm.Open(sqlite.Open(":memory:"))
+	require.NoError(t, err)
+	require.NoError(t, conn.AutoMigrate(&models.Task{}, &models.TaskHistory{}, &models.TaskHistoryRollup{}))
+	d := &DBService{DB: conn}
+
+	tenantID := "edgenode"
+	id := uuid.New()
+	day := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	entry := func(version int64, state models.TaskState, completion time.Time, retries int64, apply time.Duration) models.TaskHistory {
+		start := completion.Add(-apply)
+		return models.TaskHistory{TenantID: tenantID, Type: models.TypeReceiver, UUID: id, Version: version, State: state,
+			RetryCount: retries, CreationDate: start.Add(-2 * time.Second), StartDate: start, CompletionDate: completion}
+	}
+	require.NoError(t, conn.Create([]models.TaskHistory{
+		entry(1, models.TaskApplied, day.Add(time.Hour), 0, 5*time.Second),
+		entry(2, models.TaskInvalid, day.Add(2*time.Hour), 3, time.Second),
+		entry(3, models.TaskApplied, day.Add(26*time.Hour), 1, 3*time.Second),
+		// Within the raw retention, so not compacted.
+		entry(4, models.TaskApplied, day.Add(30*24*time.Hour), 0, time.Second),
+	}).Error)
+	// A rollup of the first day compacted before, into which the history of the day is merged.
+	require.NoError(t, conn.Create(&models.TaskHistoryRollup{TenantID: tenantID, Type: models.TypeReceiver, UUID: id, Day: day,
+		Applied: 2, TotalQueueDuration: 4 * time.Second, TotalApplyDuration: 20 * time.Second, MaxApplyDuration: 10 * time.Second}).Error)
+	// A rollup past the rollup retention.
+	require.NoError(t, conn.Create(&models.TaskHistoryRollup{TenantID: tenantID, Type: models.TypeReceiver, UUID: id,
+		Day: day.AddDate(-1, 0, 0), Applied: 1}).Error)
+
+	t.Run("History past its retention is compacted into daily rollups", func(t *testing.T) {
+		compacted, expired, err := d.CompactTaskHistory(t.Context(), day.AddDate(0, 0, 2), day.AddDate(0, -6, 0), 2)
+		require.NoError(t, err)
+		require.Equal(t, int64(3), compacted)
+		require.Equal(t, int64(1), expired)
+
+		var history []models.TaskHistory
+		require.NoError(t, conn.Find(&history).Error)
+		require.Len(t, history, 1)
+		require.Equal(t, int64(4), history[0].Version)
+
+		var rollups []models.TaskHistoryRollup
+		require.NoError(t, conn.Order("day").Find(&rollups).Error)
+		require.Len(t, rollups, 2)
+
+		require.True(t, day.Equal(rollups[0].Day))
+		require.Equal(t, int64(3), rollups[0].Applied)
+		require.Equal(t, int64(1), rollups[0].Invalid)
+		require.Equal(t, int64(3), rollups[0].Retries)
+		require.Equal(t, int64(2), rollups[0].LastVersion)
+		require.Equal(t, 8*time.Second, rollups[0].TotalQueueDuration)
+		require.Equal(t, 26*time.Second, rollups[0].TotalApplyDuration)
+		require.Equal(t, 10*time.Second, rollups[0].MaxApplyDuration)
+
+		require.True(t, day.AddDate(0, 0, 1).Equal(rollups[1].Day))
+		require.Equal(t, int64(1), rollups[1].Applied)
+		require.Equal(t, int64(3), rollups[1].LastVersion)
+	})
+
+	t.Run("Nothing to compact", func(t *testing.T) {
+		compacted, expired, err := d.CompactTaskHistory(t.Context(), day.AddDate(0, 0, 2), time.Time{}, 2)
+		require.NoError(t, err)
+		require.Zero(t, compacted)
+		require.Zero(t, expired)
+	})
+
+	t.Run("Trend includes the rollups and the history not compacted yet", func(t *testing.T) {
+		receiverID := id
+		require.NoError(t, conn.Create(&models.Task{ReceiverUUID: &receiverID, TenantID: tenantID, Version: 5,
+			State: models.TaskApplied, CompletionDate: day.Add(30*24*time.Hour + time.Hour)}).Error)
+
+		trend, err := d.GetTaskHistoryTrend(t.Context(), tenantID, id, day.Add(12*time.Hour))
+		require.NoError(t, err)
+		require.Len(t, trend, 3)
+		require.True(t, day.Equal(trend[0].Day), "rollups are selected from the day of the given time")
+		require.True(t, day.AddDate(0, 0, 1).Equal(trend[1].Day))
+		require.True(t, day.AddDate(0, 0, 30).Equal(trend[2].Day))
+		require.Equal(t, int64(2), trend[2].Applied)
+		require.Equal(t, int64(5), trend[2].LastVersion)
+
+		trend, err = d.GetTaskHistoryTrend(t.Context(), "other", id, day)
+		require.NoError(t, err)
+		require.Empty(t, trend)
+	})
+}
@@ -0,0 +1,194 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+var (
+	ErrEventHookLimitExceeded = errors.New("event hook limit exceeded")
+)
+
+// GetEventHooks gets the event hooks of a tenant, in the order they were registered.
+func (d *DBService) GetEventHooks(ctx context.Context, tenantID api.TenantID) ([]models.EventHook, error) {
+	var hooks []models.EventHook
+	if err := d.DB.WithContext(ctx).
+		Where("tenant_id = ?", tenantID).
+		Order("creation_date, id").
+		Find(&hooks).Error; err != nil {
+		return nil, fmt.Errorf("failed to get event hooks of tenant %q: %w", tenantID, err)
+	}
+	return hooks, nil
+}
+
+// GetEventHook gets an event hook of a tenant given its UUID. An error wrapping gorm.ErrRecordNotFound is returned if the tenant
+// has no such hook.
+func (d *DBService) GetEventHook(ctx context.Context, tenantID api.TenantID, id uuid.UUID) (*models.EventHook, error) {
+	var hook models.EventHook
+	if err := d.DB.WithContext(ctx).
+		Where("tenant_id = ?", tenantID).
+		Where("uuid = ?", id).
+		Take(&hook).Error; err != nil {
+		return nil, fmt.Errorf("failed to get event hook %q of tenant %q: %w", id, tenantID, err)
+	}
+	return &hook, nil
+}
+
+// CreateEventHook registers the given event hook, unless its tenant already has the given maximum number of hooks, in which
+// case ErrEventHookLimitExceeded is returned. The number of hooks is not limited if the maximum is zero.
+func (d *DBService) CreateEventHook(ctx context.Context, hook *models.EventHook, maxHooks int) error {
+	tx := d.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if maxHooks > 0 {
+		var count int64
+		if err := tx.Model(&models.EventHook{}).Where("tenant_id = ?", hook.TenantID).Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to count event hooks of tenant %q: %w", hook.TenantID, err)
+		}
+		if count >= int64(maxHooks) {
+			return fmt.Errorf("tenant %q has %d event hooks: %w", hook.TenantID, count, ErrEventHookLimitExceeded)
+		}
+	}
+
+	hook.CreationDate = clock.TimeNowFn().UTC()
+	if err := tx.Create(hook).Error; err != nil {
+		return fmt.Errorf("failed to create event hook of tenant %q: %w", hook.TenantID, err)
+	}
+	return tx.Commit().Error
+}
+
+// UpdateEventHook sets the URL and events of the event hook of its tenant with its UUID. An error wrapping
+// gorm.ErrRecordNotFound is returned if the tenant has no such hook.
+func (d *DBService) UpdateEventHook(ctx context.Context, hook *models.EventHook) error {
+	res := d.DB.WithContext(ctx).Model(&models.EventHook{}).
+		Where("tenant_id = ?", hook.TenantID).
+		Where("uuid = ?", hook.UUID).
+		Updates(map[string]any{
+			"url":    hook.URL,
+			"events": hook.Events,
+		})
+	if res.Error != nil {
+		return fmt.Errorf("failed to update event hook %q of tenant %q: %w", hook.UUID, hook.TenantID, res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("failed to update event hook %q of tenant %q: %w", hook.UUID, hook.TenantID, gorm.ErrRecordNotFound)
+	}
+	return nil
+}
+
+// DeleteEventHook deletes an event hook of a tenant given its UUID, along with its events pending delivery. An error wrapping
+// gorm.ErrRecordNotFound is returned if the tenant has no such hook.
+func (d *DBService) DeleteEventHook(ctx context.Context, tenantID api.TenantID, id uuid.UUID) error {
+	tx := d.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	res := tx.Where("tenant_id = ?", tenantID).Where("uuid = ?", id).Delete(&models.EventHook{})
+	if res.Error != nil {
+		return fmt.Errorf("failed to delete event hook %q of tenant %q: %w", id, tenantID, res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("failed to delete event hook %q of tenant %q: %w", id, tenantID, gorm.ErrRecordNotFound)
+	}
+
+	if err := tx.
+		Where("channel = ?", models.NotificationHook).
+		Where("receiver_uuid = ?", id).
+		Where("state IN ?", []models.TaskState{models.TaskNew, models.TaskError, models.TaskTaken}).
+		Delete(&models.NotificationTask{}).Error; err != nil {
+		return fmt.Errorf("failed to delete pending events of event hook %q: %w", id, err)
+	}
+	return tx.Commit().Error
+}
+
+// enqueueHookEvent queues the given event of the alert definition or receiver of a task for delivery to the event hooks of its
+// tenant which subscribe to it, along with the error of the task if any. Events are not queued if the version of the alert
+// definition or receiver is already in the state of the event, so that the retries of a failed task do not call the hooks
+// again, nor if event hooks are not enabled. It is called within the transaction setting the state, before it is set.
+func (d *DBService) enqueueHookEvent(tx *gorm.DB, task models.Task, event models.EventHookEvent, cause string) error {
+	if !d.EventHooks {
+		return nil
+	}
+
+	var hooks []models.EventHook
+	if err := tx.Where("tenant_id = ?", task.TenantID).Order("creation_date, id").Find(&hooks).Error; err != nil {
+		return fmt.Errorf("failed to get event hooks of tenant %q: %w", task.TenantID, err)
+	}
+	hooks = slices.DeleteFunc(hooks, func(hook models.EventHook) bool { return !hook.Subscribes(event) })
+	if len(hooks) == 0 {
+		return nil
+	}
+
+	payload := models.EventHookPayload{
+		Event:     event,
+		ProjectID: task.TenantID,
+		ID:        task.GetTaskUUID(),
+		Version:   task.Version,
+		Error:     cause,
+		Timestamp: clock.TimeNowFn().UTC(),
+	}
+	switch task.GetTaskType() {
+	case models.TypeAlertDefinition:
+		var definition models.AlertDefinition
+		if err := tx.Where("tenant_id = ?", task.TenantID).Where("uuid = ?", task.AlertDefinitionUUID).
+			Where("version = ?", task.Version).Take(&definition).Error; err != nil {
+			return fmt.Errorf("failed to retrieve alert definition for tenant %q: %w", task.TenantID, err)
+		}
+		state := models.DefinitionApplied
+		if event == models.EventDefinitionError {
+			state = models.DefinitionError
+		}
+		if definition.State == state {
+			return nil
+		}
+		payload.Name, payload.State = definition.Name, string(state)
+	case models.TypeReceiver:
+		var recv models.Receiver
+		if err := tx.Where("tenant_id = ?", task.TenantID).Where("uuid = ?", task.ReceiverUUID).
+			Where("version = ?", task.Version).Take(&recv).Error; err != nil {
+			return fmt.Errorf("failed to retrieve receiver for tenant %q: %w", task.TenantID, err)
+		}
+		state := models.ReceiverApplied
+		if event == models.EventReceiverError {
+			state = models.ReceiverError
+		}
+		if recv.State == state {
+			return nil
+		}
+		payload.Name, payload.State = recv.Name, string(state)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event %q: %w", event, err)
+	}
+	notifications := make([]models.NotificationTask, 0, len(hooks))
+	for _, hook := range hooks {
+		notifications = append(notifications, models.NotificationTask{
+			State:           models.TaskNew,
+			TenantID:        task.TenantID,
+			ReceiverUUID:    hook.UUID,
+			Channel:         models.NotificationHook,
+			Recipient:       hook.URL,
+			Payload:         string(body),
+			CreationDate:    payload.Timestamp,
+			NextAttemptDate: payload.Timestamp,
+		})
+	}
+	if err := tx.Create(&notifications).Error; err != nil {
+		return fmt.Errorf("failed to enqueue event %q of tenant %q: %w", event, task.TenantID, err)
+	}
+	return nil
+}
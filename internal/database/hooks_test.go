@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+func TestEventHooks(t *testing.T) {
+	conn, err := gorm.Open(sqlite.Open(":memory:"))
+	require.NoError(t, err)
+	require.NoError(t, conn.AutoMigrate(&models.EventHook{}, &models.NotificationTask{}))
+	d := &DBService{DB: conn}
+
+	hook := models.EventHook{UUID: uuid.New(), TenantID: "tenant", URL: "https://ci.example.com/hooks"}
+	require.NoError(t, d.CreateEventHook(t.Context(), &hook, 2))
+	require.NoError(t, d.CreateEventHook(t.Context(), &models.EventHook{UUID: uuid.New(), TenantID: "tenant", URL: "https://ci.example.com/other"}, 2))
+
+	t.Run("Hooks of a tenant are limited", func(t *testing.T) {
+		err := d.CreateEventHook(t.Context(), &models.EventHook{UUID: uuid.New(), TenantID: "tenant", URL: "https://ci.example.com/another"}, 2)
+		require.ErrorIs(t, err, ErrEventHookLimitExceeded)
+
+		require.NoError(t, d.CreateEventHook(t.Context(), &models.EventHook{UUID: uuid.New(), TenantID: "other", URL: "https://ci.example.com/hooks"}, 2))
+	})
+
+	t.Run("Hooks are scoped to their tenant", func(t *testing.T) {
+		hooks, err := d.GetEventHooks(t.Context(), "tenant")
+		require.NoError(t, err)
+		require.Len(t, hooks, 2)
+		require.Equal(t, hook.UUID, hooks[0].UUID)
+
+		_, err = d.GetEventHook(t.Context(), "other", hook.UUID)
+		require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+		err = d.UpdateEventHook(t.Context(), &models.EventHook{UUID: hook.UUID, TenantID: "other", URL: "https://ci.example.com/hooks"})
+		require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	})
+
+	t.Run("Deleted hooks lose their pending events", func(t *testing.T) {
+		require.NoError(t, conn.Create(&models.NotificationTask{State: models.TaskNew, TenantID: "tenant", ReceiverUUID: hook.UUID,
+			Channel: models.NotificationHook, Recipient: hook.URL, Payload: "{}"}).Error)
+
+		require.NoError(t, d.DeleteEventHook(t.Context(), "tenant", hook.UUID))
+		require.ErrorIs(t, d.DeleteEventHook(t.Context(), "tenant", hook.UUID), gorm.ErrRecordNotFound)
+
+		var count int64
+		require.NoError(t, conn.Model(&models.NotificationTask{}).Where("receiver_uuid = ?", hook.UUID).Count(&count).Error)
+		require.Zero(t, count)
+	})
+}
+
+func TestEventHooks_Enqueue(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	clock.SetFakeClock()
+	defer clock.UnsetFakeClock()
+	clock.FakeClock.Set(now)
+
+	conn, err := gorm.Open(sqlite.Open(":memory:"))
+	require.NoError(t, err)
+	require.NoError(t, conn.AutoMigrate(&models.EventHook{}, &models.NotificationTask{}, &models.AlertDefinition{}, &models.Task{}))
+	d := &DBService{DB: conn, EventHooks: true}
+
+	all := models.EventHook{UUID: uuid.New(), TenantID: "tenant", URL: "https://ci.example.com/all"}
+	errorsOnly := models.EventHook{UUID: uuid.New(), TenantID: "tenant", URL: "https://ci.example.com/errors",
+		Events: string(models.EventDefinitionError)}
+	for _, hook := range []*models.EventHook{&all, &errorsOnly} {
+		require.NoError(t, d.CreateEventHook(t.Context(), hook, 0))
+	}
+
+	definitionUUID := uuid.New()
+	require.NoError(t, conn.Create(&models.AlertDefinition{ID: 1, UUID: definitionUUID, Name: "HighCPUUsage", TenantID: "tenant",
+		Category: models.CategoryPerformance, Version: 1, State: models.DefinitionNew}).Error)
+	task := models.Task{ID: 1, AlertDefinitionUUID: &definitionUUID, TenantID: "tenant", Version: 1, State: models.TaskTaken}
+	require.NoError(t, conn.Create(&task).Error)
+
+	events := func(t *testing.T) []models.NotificationTask {
+		var tasks []models.NotificationTask
+		require.NoError(t, conn.Where("channel = ?", models.NotificationHook).Order("id").Find(&tasks).Error)
+		return tasks
+	}
+
+	t.Run("Failures are sent to the hooks subscribing to them once", func(t *testing.T) {
+		require.NoError(t, d.SetTaskAsFailed(t.Context(), task, 1, errors.New("ruler unavailable")))
+		task.RetryCount++
+		require.NoError(t, d.SetTaskAsFailed(t.Context(), task, 1, errors.New("ruler unavailable")))
+
+		tasks := events(t)
+		require.Len(t, tasks, 2)
+		require.Equal(t, []string{all.URL, errorsOnly.URL}, []string{tasks[0].Recipient, tasks[1].Recipient})
+		require.Equal(t, errorsOnly.UUID, tasks[1].ReceiverUUID)
+
+		var payload models.EventHookPayload
+		require.NoError(t, json.Unmarshal([]byte(tasks[0].Payload), &payload))
+		require.Equal(t, models.EventHookPayload{
+			Event:     models.EventDefinitionError,
+			ProjectID: "tenant",
+			ID:        definitionUUID,
+			Name:      "HighCPUUsage",
+			Version:   1,
+			State:     string(models.DefinitionError),
+			Error:     "ruler unavailable",
+			Timestamp: now,
+		}, payload)
+	})
+
+	t.Run("Events are not sent if hooks are not enabled", func(t *testing.T) {
+		require.NoError(t, (&DBService{DB: conn}).SetTaskAsApplied(t.Context(), task))
+		require.Len(t, events(t), 2)
+	})
+}
@@ -0,0 +1,20 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import "time"
+
+// AlertComment is a comment users attach to an alert of a tenant for its triage, the alert being identified by its
+// alertmanager fingerprint. Comments are threaded, a reply referencing the comment it answers by ParentID. AlertStartsAt is the
+// time the alert started firing when the comment was added, the first comment of an alert acknowledging it.
+type AlertComment struct {
+	ID            int64     `gorm:"primaryKey;autoIncrement"`
+	TenantID      string    `gorm:"not null;index:idx_alert_comments_fingerprint,priority:1"`
+	Fingerprint   string    `gorm:"not null;index:idx_alert_comments_fingerprint,priority:2"`
+	ParentID      *int64    `gorm:"default:null"`
+	Author        string    `gorm:"not null;default:''"`
+	Content       string    `gorm:"not null"`
+	CreationDate  time.Time `gorm:"not null"`
+	AlertStartsAt *time.Time
+}
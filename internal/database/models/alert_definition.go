@@ -5,9 +5,12 @@ package models
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
 )
 
 type AlertDefinitionState string
@@ -56,6 +59,8 @@ type AlertDuration struct {
 	DurationMin       int64
 	DurationMax       int64
 	AlertDefinitionID int64 `gorm:"not null;uniqueIndex:idx_duration_alert_id_name"`
+	// DurationDefault is the duration of the alert definition in the catalog, nil if it is not known.
+	DurationDefault *int64
 }
 
 type AlertThreshold struct {
@@ -67,6 +72,8 @@ type AlertThreshold struct {
 	ThresholdType     string
 	ThresholdUnit     string
 	AlertDefinitionID int64 `gorm:"not null;uniqueIndex:idx_threshold_alert_id_name"`
+	// ThresholdDefault is the threshold of the alert definition in the catalog, nil if it is not known.
+	ThresholdDefault *int64
 }
 
 type AlertDefinition struct {
@@ -82,9 +89,20 @@ type AlertDefinition struct {
 	Severity      string `gorm:"not null;uniqueIndex:idx_name_severity_version_tenant"`
 	AlertInterval int64
 	TenantID      string `gorm:"not null;default:edgenode;uniqueIndex:idx_def_uuid_version_tenant;uniqueIndex:idx_name_severity_version_tenant"`
+	// CreationDate is the time the version was created, that is, the time the alert definition was last updated.
+	CreationDate time.Time `gorm:"default:current_timestamp"`
+	// AppliedDate is the time the version was successfully applied, nil if it has not been applied.
+	AppliedDate *time.Time
+	// AutoTune tells whether the threshold is periodically recomputed from the statistics of the alerting metric.
+	AutoTune bool `gorm:"not null;default:false"`
+	// ThresholdAutoTuned tells whether the threshold of the version was set by auto-tuning rather than by a user.
+	ThresholdAutoTuned bool `gorm:"not null;default:false"`
 }
 
 func (d *AlertDefinition) BeforeCreate(*gorm.DB) error {
+	if d.CreationDate.IsZero() {
+		d.CreationDate = clock.TimeNowFn().UTC()
+	}
 	if err := d.Category.Validate(); err != nil {
 		return err
 	}
@@ -103,6 +121,7 @@ type DBAlertDefinitionValues struct {
 	Duration  *int64 // in seconds.
 	Threshold *int64
 	Enabled   *bool
+	AutoTune  *bool
 }
 
 // DBAlertDefinition represents the info of an alert definition.
@@ -116,4 +135,12 @@ type DBAlertDefinition struct {
 	Version  int64
 	Category AlertDefinitionCategory
 	TenantID string
+	// ThresholdAutoTuned tells whether the threshold was set by auto-tuning rather than by a user.
+	ThresholdAutoTuned bool
+	// CreatedAt is the creation time of the first version of the alert definition.
+	CreatedAt time.Time
+	// UpdatedAt is the creation time of this version of the alert definition.
+	UpdatedAt time.Time
+	// AppliedAt is the time the latest applied version, up to this one, took effect. It is nil if none was applied.
+	AppliedAt *time.Time
 }
@@ -152,12 +152,13 @@ func (s *AlertDefinitionSuite) TestAfterUpdate() {
 			var adOut AlertDefinition
 			s.Require().NoError(s.db.Find(&adOut, ad.ID).Error)
 			s.Require().Equal(AlertDefinition{
-				ID:       ad.ID,
-				UUID:     ad.UUID,
-				Category: ad.Category,
-				State:    state,
-				Version:  ad.Version,
-				TenantID: ad.TenantID,
+				ID:           ad.ID,
+				UUID:         ad.UUID,
+				Category:     ad.Category,
+				State:        state,
+				Version:      ad.Version,
+				TenantID:     ad.TenantID,
+				CreationDate: ad.CreationDate,
 			}, adOut)
 		}
 
@@ -169,12 +170,13 @@ func (s *AlertDefinitionSuite) TestAfterUpdate() {
 			var adOut AlertDefinition
 			s.Require().NoError(s.db.Find(&adOut, ad.ID).Error)
 			s.Require().Equal(AlertDefinition{
-				ID:       ad.ID,
-				UUID:     ad.UUID,
-				Category: category,
-				State:    ad.State,
-				Version:  ad.Version,
-				TenantID: ad.TenantID,
+				ID:           ad.ID,
+				UUID:         ad.UUID,
+				Category:     category,
+				State:        ad.State,
+				Version:      ad.Version,
+				TenantID:     ad.TenantID,
+				CreationDate: ad.CreationDate,
 			}, adOut)
 		}
 	})
@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import "time"
+
+// AlertReport is the summary of the alert volume of a tenant over a period, generated once per period. Content is the JSON
+// encoded AlertVolume of the period.
+type AlertReport struct {
+	ID           int64     `gorm:"primaryKey;autoIncrement"`
+	TenantID     string    `gorm:"not null;uniqueIndex:idx_alert_reports_period,priority:1"`
+	PeriodStart  time.Time `gorm:"not null;uniqueIndex:idx_alert_reports_period,priority:2"`
+	PeriodEnd    time.Time `gorm:"not null"`
+	Content      string    `gorm:"not null"`
+	CreationDate time.Time `gorm:"not null"`
+}
+
+// AlertVolume summarizes the notifications sent for the alerts of a tenant over a period, as recorded by the email deliveries
+// to recipients, and how fast alerts were acknowledged, as recorded by the first comment attached to them.
+type AlertVolume struct {
+	// SentNotifications and FailedNotifications are the number of emails sent or failed to be sent to recipients.
+	SentNotifications   int64 `json:"sentNotifications"`
+	FailedNotifications int64 `json:"failedNotifications"`
+	// TopAlerts are the alert groups which were notified the most, the noisiest first.
+	TopAlerts []NoisyAlert `json:"topAlerts"`
+	// AcknowledgedAlerts is the number of alerts acknowledged over the period, and MeanTimeToAcknowledge the mean time between
+	// they started firing and their acknowledgement.
+	AcknowledgedAlerts    int64         `json:"acknowledgedAlerts"`
+	MeanTimeToAcknowledge time.Duration `json:"meanTimeToAcknowledge"`
+}
+
+// NoisyAlert is an alert group of a tenant along with the number of emails sent to recipients for it over a period. Group
+// is the labels the alerts of the group share.
+type NoisyAlert struct {
+	Group         string `json:"group"`
+	Notifications int64  `json:"notifications"`
+}
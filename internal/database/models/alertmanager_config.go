@@ -0,0 +1,16 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"time"
+)
+
+// AlertmanagerConfig is the configuration manifest an alertmanager instance last reloaded successfully, which is restored if
+// a newer one fails to reload. Name identifies the instance by the namespace and name of the secret holding its configuration.
+type AlertmanagerConfig struct {
+	Name       string    `gorm:"primaryKey"`
+	Manifest   string    `gorm:"not null"`
+	ReloadDate time.Time `gorm:"not null"`
+}
@@ -0,0 +1,29 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"time"
+)
+
+type AppliedArtifactKind string
+
+const (
+	ArtifactAlertmanagerManifest AppliedArtifactKind = "AlertmanagerManifest"
+	ArtifactMimirRuleGroup       AppliedArtifactKind = "MimirRuleGroup"
+)
+
+// AppliedArtifact is a rendered configuration successfully applied downstream, kept so that the configurations applied over
+// time can be compared and rolled back to after an incident. Name identifies the artifact within its kind: the namespace and
+// name of the secret holding an alertmanager manifest, or the tenant and name of a Mimir rule group. Hash is the hex-encoded
+// SHA-256 hash of the content. KeyID is the ID of the key the content is encrypted with, or empty if it is stored in plain text.
+type AppliedArtifact struct {
+	ID          int64               `gorm:"primaryKey;autoIncrement"`
+	Kind        AppliedArtifactKind `gorm:"not null;index:idx_applied_artifacts_name,priority:1"`
+	Name        string              `gorm:"not null;index:idx_applied_artifacts_name,priority:2"`
+	Hash        string              `gorm:"not null"`
+	Content     string              `gorm:"not null"`
+	AppliedDate time.Time           `gorm:"not null;index:idx_applied_artifacts_name,priority:3"`
+	KeyID       string              `gorm:"not null;default:''"`
+}
@@ -0,0 +1,33 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EmailDeliveryStatus string
+
+const (
+	EmailDeliverySent   EmailDeliveryStatus = "Sent"
+	EmailDeliveryFailed EmailDeliveryStatus = "Failed"
+)
+
+// EmailDelivery records the outcome of sending the email of an alertmanager notification to a single recipient of a receiver,
+// when emails are sent by alerting monitor instead of alertmanager. GroupKey identifies the alert group of the notification,
+// Attempts is the number of times sending was attempted, and Error is the error of the last attempt if it failed.
+type EmailDelivery struct {
+	ID           int64               `gorm:"primaryKey;autoIncrement"`
+	TenantID     string              `gorm:"not null;index:idx_email_deliveries_receiver,priority:1"`
+	ReceiverUUID uuid.UUID           `gorm:"type:uuid;not null;index:idx_email_deliveries_receiver,priority:2"`
+	Recipient    string              `gorm:"not null"`
+	GroupKey     string              `gorm:"not null;default:''"`
+	AlertCount   int                 `gorm:"not null;default:0"`
+	Status       EmailDeliveryStatus `gorm:"not null"`
+	Attempts     int                 `gorm:"not null;default:0"`
+	Error        string              `gorm:"not null;default:''"`
+	CreationDate time.Time           `gorm:"not null;index:idx_email_deliveries_receiver,priority:3"`
+}
@@ -0,0 +1,17 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import "time"
+
+// EmailTemplate is a version of the template the HTML body of the emails of the receivers of a tenant is rendered with. It is
+// an alertmanager template which may use the templates of the deployment, such as "alert.monitor.mail". An empty Content
+// restores the template of the deployment.
+type EmailTemplate struct {
+	ID           int64     `gorm:"primaryKey;autoIncrement"`
+	TenantID     string    `gorm:"not null;uniqueIndex:idx_email_templates_version,priority:1"`
+	Version      int64     `gorm:"not null;uniqueIndex:idx_email_templates_version,priority:2"`
+	Content      string    `gorm:"not null;default:''"`
+	CreationDate time.Time `gorm:"not null"`
+}
@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventHookEvent is an event of the state transitions of alert definitions and receivers the hooks of tenants are called on.
+type EventHookEvent string
+
+const (
+	EventDefinitionApplied EventHookEvent = "definition.applied"
+	EventDefinitionError   EventHookEvent = "definition.error"
+	EventReceiverApplied   EventHookEvent = "receiver.applied"
+	EventReceiverError     EventHookEvent = "receiver.error"
+)
+
+// EventHook is a callback URL a tenant registers to be called when its alert definitions and receivers transition to the
+// Applied or Error state. Events is the comma-separated list of events the hook is called on, all of them if empty. The secret
+// the calls of the hook are signed with is derived from its UUID, so that it is not stored.
+type EventHook struct {
+	ID           int64     `gorm:"primaryKey;autoIncrement"`
+	UUID         uuid.UUID `gorm:"type:uuid;not null;uniqueIndex"`
+	TenantID     string    `gorm:"not null;index"`
+	URL          string    `gorm:"not null"`
+	Events       string    `gorm:"not null;default:''"`
+	CreationDate time.Time `gorm:"not null"`
+}
+
+// Subscribes tells whether the hook is called on the given event.
+func (h EventHook) Subscribes(event EventHookEvent) bool {
+	if h.Events == "" {
+		return true
+	}
+	for _, e := range strings.Split(h.Events, ",") {
+		if EventHookEvent(e) == event {
+			return true
+		}
+	}
+	return false
+}
+
+// EventHookPayload is the JSON body the hooks of a tenant are called with on an event of one of its alert definitions or
+// receivers. ID, Name and Version identify the version of the alert definition or receiver which transitioned to State, and
+// Error is the error of the failed attempt to apply it, if any.
+type EventHookPayload struct {
+	Event     EventHookEvent `json:"event"`
+	ProjectID string         `json:"projectId"`
+	ID        uuid.UUID      `json:"id"`
+	Name      string         `json:"name"`
+	Version   int64          `json:"version"`
+	State     string         `json:"state"`
+	Error     string         `json:"error,omitempty"`
+	Timestamp time.Time      `json:"timestamp"`
+}
@@ -0,0 +1,22 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Executor is a task executor instance, identified by the UUID it takes tasks with. HeartbeatDate is the last time the
+// executor told it is alive, tasks taken by executors without a recent heartbeat are reclaimed by the other executors.
+// ProcessedTasks is the number of tasks the executor processed since it started, and LastPollDate the last time it polled
+// the pending tasks, nil if it has not polled yet.
+type Executor struct {
+	UUID           uuid.UUID `gorm:"type:uuid;primaryKey"`
+	StartDate      time.Time `gorm:"not null"`
+	HeartbeatDate  time.Time `gorm:"not null"`
+	ProcessedTasks int64     `gorm:"not null;default:0"`
+	LastPollDate   *time.Time
+}
@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type NotificationChannel string
+
+const (
+	NotificationEmail  NotificationChannel = "email"
+	NotificationOnCall NotificationChannel = "oncall"
+	NotificationHook   NotificationChannel = "hook"
+)
+
+// NotificationTask is a notification sent by alerting monitor on behalf of alertmanager, queued for retry after its delivery
+// failed, so that it is not lost during an outage of the downstream service. Its state follows the states of tasks: New and
+// Error tasks are pending until NextAttemptDate, Taken tasks are being delivered by the executor replica OwnerUUID, Applied
+// tasks are delivered and Invalid tasks are dead letters, whose retries are exhausted. Recipient is the email address, the
+// Grafana OnCall routing key or the callback URL the notification is delivered to, and Payload the notification, as given by
+// its channel. The ReceiverUUID of the events delivered to event hooks is the UUID of their hook. Error is the error of the
+// last failed delivery.
+type NotificationTask struct {
+	ID              int64               `gorm:"primaryKey;autoIncrement"`
+	OwnerUUID       uuid.UUID           `gorm:"type:uuid"`
+	State           TaskState           `gorm:"not null;index:idx_notification_tasks_pending,priority:1"`
+	TenantID        string              `gorm:"not null"`
+	ReceiverUUID    uuid.UUID           `gorm:"type:uuid;not null"`
+	Channel         NotificationChannel `gorm:"not null"`
+	Recipient       string              `gorm:"not null;default:''"`
+	Payload         string              `gorm:"not null"`
+	RetryCount      int64               `gorm:"not null;default:0"`
+	Error           string              `gorm:"not null;default:''"`
+	CreationDate    time.Time           `gorm:"not null"`
+	NextAttemptDate time.Time           `gorm:"not null;index:idx_notification_tasks_pending,priority:2"`
+	StartDate       time.Time
+	CompletionDate  time.Time
+}
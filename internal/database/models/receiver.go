@@ -4,10 +4,15 @@
 package models
 
 import (
+	"errors"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
 )
 
 type EmailAddress struct {
@@ -15,6 +20,11 @@ type EmailAddress struct {
 	Email     string `gorm:"not null;unique"`
 	FirstName string `gorm:"not null"`
 	LastName  string `gorm:"not null"`
+	// VerificationPending tells whether the address was added as a recipient while email verification was enabled and has
+	// not been confirmed yet, in which case it is not notified.
+	VerificationPending bool `gorm:"not null;default:false"`
+	// VerificationSentDate is the time the last verification email was sent to the address, nil if none was sent.
+	VerificationSentDate *time.Time
 }
 
 func (e EmailAddress) String() string {
@@ -50,35 +60,207 @@ func (rs ReceiverState) Validate() error {
 	return nil
 }
 
+// ReceiverSeverity represents the minimum severity of alerts that are routed to a receiver.
+// An empty value means that alerts of any severity are routed.
+type ReceiverSeverity string
+
+const (
+	SeverityNone     ReceiverSeverity = ""
+	SeverityInfo     ReceiverSeverity = "info"
+	SeverityWarning  ReceiverSeverity = "warning"
+	SeverityCritical ReceiverSeverity = "critical"
+)
+
+// severityLevels lists the known severities ordered from the lowest to the highest.
+var severityLevels = []ReceiverSeverity{SeverityInfo, SeverityWarning, SeverityCritical}
+
+func (s ReceiverSeverity) Validate() error {
+	switch s {
+	case SeverityNone:
+	case SeverityInfo:
+	case SeverityWarning:
+	case SeverityCritical:
+	default:
+		return fmt.Errorf("unknown receiver severity: %q", s)
+	}
+	return nil
+}
+
+// AtLeast returns the list of severities that are equal or higher than the given one.
+// It returns nil for SeverityNone or unknown severities.
+func (s ReceiverSeverity) AtLeast() []ReceiverSeverity {
+	for i, level := range severityLevels {
+		if level == s {
+			return severityLevels[i:]
+		}
+	}
+	return nil
+}
+
+// Rank returns the rank of the severity among the known ones, from 0 for the lowest one, or -1 for SeverityNone and unknown
+// severities.
+func (s ReceiverSeverity) Rank() int {
+	return slices.Index(severityLevels, s)
+}
+
+// QuietHoursTimeFormat is the layout of quiet hours start and end times.
+const QuietHoursTimeFormat = "15:04"
+
+// QuietHours represents a daily time window, in the given location, during which non-critical notifications of a receiver
+// are muted. Start and End are formatted as HH:MM, a window where End is before Start spans midnight. An empty Start and
+// End means that no quiet hours are set.
+type QuietHours struct {
+	Start    string `gorm:"not null;default:''"`
+	End      string `gorm:"not null;default:''"`
+	Location string `gorm:"not null;default:''"`
+}
+
+// IsSet returns true if quiet hours are configured.
+func (q QuietHours) IsSet() bool {
+	return q.Start != "" || q.End != ""
+}
+
+func (q QuietHours) Validate() error {
+	if !q.IsSet() {
+		if q.Location != "" {
+			return errors.New("quiet hours location set without start and end times")
+		}
+		return nil
+	}
+
+	start, err := time.Parse(QuietHoursTimeFormat, q.Start)
+	if err != nil {
+		return fmt.Errorf("invalid quiet hours start time %q: %w", q.Start, err)
+	}
+
+	end, err := time.Parse(QuietHoursTimeFormat, q.End)
+	if err != nil {
+		return fmt.Errorf("invalid quiet hours end time %q: %w", q.End, err)
+	}
+
+	if start.Equal(end) {
+		return fmt.Errorf("quiet hours start and end times are equal: %q", q.Start)
+	}
+
+	if _, err := time.LoadLocation(q.Location); err != nil {
+		return fmt.Errorf("invalid quiet hours location %q: %w", q.Location, err)
+	}
+	return nil
+}
+
 type Receiver struct {
-	ID            int64         `gorm:"primaryKey;autoIncrement"`
-	UUID          uuid.UUID     `gorm:"type:uuid;not null;uniqueIndex:idx_recv_uuid_version_tenant"`
-	Name          string        `gorm:"not null;uniqueIndex:idx_name_version_tenant"`
-	State         ReceiverState `gorm:"not null,type:enum('New','Modified','Pending','Applied','Error'),default:New"`
-	Version       int64         `gorm:"not null;uniqueIndex:idx_recv_uuid_version_tenant;uniqueIndex:idx_name_version_tenant"`
-	EmailConfigID int64         `gorm:"not null"`
-	TenantID      string        `gorm:"not null;default:edgenode;uniqueIndex:idx_recv_uuid_version_tenant;uniqueIndex:idx_name_version_tenant"`
+	ID            int64            `gorm:"primaryKey;autoIncrement"`
+	UUID          uuid.UUID        `gorm:"type:uuid;not null;uniqueIndex:idx_recv_uuid_version_tenant"`
+	Name          string           `gorm:"not null;uniqueIndex:idx_name_version_tenant"`
+	State         ReceiverState    `gorm:"not null,type:enum('New','Modified','Pending','Applied','Error'),default:New"`
+	Version       int64            `gorm:"not null;uniqueIndex:idx_recv_uuid_version_tenant;uniqueIndex:idx_name_version_tenant"`
+	EmailConfigID int64            `gorm:"not null"`
+	TenantID      string           `gorm:"not null;default:edgenode;uniqueIndex:idx_recv_uuid_version_tenant;uniqueIndex:idx_name_version_tenant"`
+	MinSeverity   ReceiverSeverity `gorm:"not null;default:''"`
+	QuietHours    QuietHours       `gorm:"embedded;embeddedPrefix:quiet_hours_"`
+	// CreationDate is the time the version was created, that is, the time the receiver was last updated.
+	CreationDate time.Time `gorm:"default:current_timestamp"`
+	// AppliedDate is the time the version was successfully applied, nil if it has not been applied.
+	AppliedDate *time.Time
+	// OnCallRoutingKey is the key of the Grafana OnCall integration alerts are relayed to, empty if alerts are not relayed.
+	OnCallRoutingKey string `gorm:"not null;default:''"`
+	// Language is the language of the localized email template of the deployment the emails of the receiver are rendered
+	// with, empty for the default template.
+	Language string `gorm:"not null;default:''"`
+	// Disabled tells whether the notifications of the receiver are paused, in which case it has no route while its recipients
+	// are kept. It is stored negated so that receivers are enabled by default.
+	Disabled bool `gorm:"not null;default:false"`
+	// Matchers are the label matchers appended to the route of the receiver, one per line, empty if there are none.
+	Matchers string `gorm:"not null;default:''"`
 }
 
 func (r *Receiver) BeforeCreate(*gorm.DB) error {
+	if r.CreationDate.IsZero() {
+		r.CreationDate = clock.TimeNowFn().UTC()
+	}
+	if err := r.MinSeverity.Validate(); err != nil {
+		return err
+	}
+	if err := r.QuietHours.Validate(); err != nil {
+		return err
+	}
 	return r.State.Validate()
 }
 
 func (r *Receiver) AfterUpdate(*gorm.DB) error {
+	if err := r.MinSeverity.Validate(); err != nil {
+		return err
+	}
+	if err := r.QuietHours.Validate(); err != nil {
+		return err
+	}
 	return r.State.Validate()
 }
 
 // DBReceiver represents info of an alert receiver, including mail server, sender address,
 // and the list of email recipients.
 type DBReceiver struct {
-	UUID       uuid.UUID
-	State      ReceiverState
-	Name       string
-	Version    int
-	MailServer string
-	From       string
-	To         []string
-	TenantID   string
+	UUID        uuid.UUID
+	State       ReceiverState
+	Name        string
+	Version     int
+	MailServer  string
+	From        string
+	To          []string
+	TenantID    string
+	MinSeverity ReceiverSeverity
+	QuietHours  QuietHours
+	// OnCallRoutingKey is the key of the Grafana OnCall integration alerts are relayed to, empty if alerts are not relayed.
+	OnCallRoutingKey string
+	// CreatedAt is the creation time of the first version of the receiver.
+	CreatedAt time.Time
+	// UpdatedAt is the creation time of this version of the receiver.
+	UpdatedAt time.Time
+	// AppliedAt is the time the latest applied version, up to this one, took effect. It is nil if none was applied.
+	AppliedAt *time.Time
+	// Maintenance is the maintenance window of the tenant the route of the receiver is muted during, nil if the tenant is not
+	// in maintenance mode.
+	Maintenance *MaintenanceWindow
+	// EmailTemplate is the template the HTML body of the emails of the receiver is rendered with, empty for the template of
+	// the deployment.
+	EmailTemplate string
+	// Language is the language of the localized email template of the deployment the emails of the receiver are rendered
+	// with, empty for the default template. It does not apply to the email template of the tenant.
+	Language string
+	// Unverified lists the recipients of To whose email address is pending verification, which are not notified.
+	Unverified []string
+	// Disabled tells whether the notifications of the receiver are paused, in which case it has no route.
+	Disabled bool
+	// Matchers are the label matchers appended to the route of the receiver, so that it is only notified of the alerts
+	// matching all of them.
+	Matchers []string
+}
+
+// Notified returns the recipients of the receiver that are notified, which are those whose email address is not pending
+// verification.
+func (r DBReceiver) Notified() []string {
+	if len(r.Unverified) == 0 {
+		return r.To
+	}
+	return slices.DeleteFunc(slices.Clone(r.To), func(to string) bool {
+		return slices.Contains(r.Unverified, to)
+	})
+}
+
+// DBReceiverValues represent the values of an alert receiver that can be modified.
+type DBReceiverValues struct {
+	Recipients       []EmailAddress
+	MinSeverity      *ReceiverSeverity
+	QuietHours       *QuietHours
+	OnCallRoutingKey *string
+	Language         *string
+	// Sender and MailServer override the sender address and mail server of the emails of the receiver, if given.
+	Sender     *EmailAddress
+	MailServer *string
+	// Disabled pauses or resumes the notifications of the receiver, if given.
+	Disabled *bool
+	// Matchers replace the label matchers appended to the route of the receiver, if given.
+	Matchers *[]string
 }
 
 type EmailRecipient struct {
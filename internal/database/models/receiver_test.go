@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
@@ -48,6 +49,28 @@ func (s *ReceiverSuite) TestBeforeCreate() {
 		}).Error, fmt.Sprintf("unknown receiver state: %q", invalidState))
 	})
 
+	s.Run("InvalidMinSeverity", func() {
+		invalidSeverity := ReceiverSeverity("urgent")
+		s.Require().ErrorContains(s.db.Create(&Receiver{
+			UUID:        uuid.New(),
+			State:       ReceiverNew,
+			TenantID:    "edgenode",
+			MinSeverity: invalidSeverity,
+		}).Error, fmt.Sprintf("unknown receiver severity: %q", invalidSeverity))
+	})
+
+	s.Run("InvalidQuietHours", func() {
+		s.Require().ErrorContains(s.db.Create(&Receiver{
+			UUID:     uuid.New(),
+			State:    ReceiverNew,
+			TenantID: "edgenode",
+			QuietHours: QuietHours{
+				Start: "22:00",
+				End:   "25:00",
+			},
+		}).Error, "invalid quiet hours end time")
+	})
+
 	s.Run("Succeeded", func() {
 		states := []ReceiverState{ReceiverNew, ReceiverModified, ReceiverPending, ReceiverApplied, ReceiverError}
 
@@ -101,11 +124,37 @@ func (s *ReceiverSuite) TestAfterUpdate() {
 			var recvOut Receiver
 			s.Require().NoError(s.db.Find(&recvOut, recv.ID).Error)
 			s.Require().Equal(Receiver{
-				ID:       recv.ID,
-				UUID:     recv.UUID,
-				State:    state,
-				TenantID: recv.TenantID,
+				ID:           recv.ID,
+				UUID:         recv.UUID,
+				State:        state,
+				TenantID:     recv.TenantID,
+				CreationDate: recv.CreationDate,
 			}, recvOut)
 		}
 	})
 }
+
+func TestQuietHoursValidate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		quietHours QuietHours
+		err        string
+	}{
+		"NotSet":               {quietHours: QuietHours{}},
+		"SpanningMidnight":     {quietHours: QuietHours{Start: "22:00", End: "06:00", Location: "Europe/Warsaw"}},
+		"WithinDay":            {quietHours: QuietHours{Start: "12:00", End: "13:30"}},
+		"LocationWithoutTimes": {quietHours: QuietHours{Location: "UTC"}, err: "location set without start and end times"},
+		"InvalidStart":         {quietHours: QuietHours{Start: "noon", End: "13:00"}, err: "invalid quiet hours start time"},
+		"MissingEnd":           {quietHours: QuietHours{Start: "12:00"}, err: "invalid quiet hours end time"},
+		"EqualTimes":           {quietHours: QuietHours{Start: "12:00", End: "12:00"}, err: "start and end times are equal"},
+		"InvalidLocation":      {quietHours: QuietHours{Start: "12:00", End: "13:00", Location: "Mars/Olympus"}, err: "invalid quiet hours location"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := tc.quietHours.Validate()
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
@@ -0,0 +1,22 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RecipientOffboarding records a receiver an email recipient was removed from as the recipient was offboarded, such as when
+// an employee leaves the organization. Version is the version of the receiver created without the recipient.
+type RecipientOffboarding struct {
+	ID           int64     `gorm:"primaryKey;autoIncrement"`
+	Email        string    `gorm:"not null;index"`
+	TenantID     string    `gorm:"not null"`
+	ReceiverUUID uuid.UUID `gorm:"type:uuid;not null"`
+	ReceiverName string    `gorm:"not null"`
+	Version      int64     `gorm:"not null"`
+	CreationDate time.Time `gorm:"not null"`
+}
@@ -0,0 +1,33 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RuleEvaluation is an evaluation of the rule group of an alert definition by Mimir ruler, sampled periodically and kept over
+// a rolling window so that expensive or failing expressions can be found. DurationSeconds is the time the evaluation took, and
+// Error is the error of the evaluation if it failed.
+type RuleEvaluation struct {
+	ID                  int64     `gorm:"primaryKey;autoIncrement"`
+	TenantID            string    `gorm:"not null;uniqueIndex:idx_rule_evaluations_definition,priority:1"`
+	AlertDefinitionUUID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_rule_evaluations_definition,priority:2"`
+	DurationSeconds     float64   `gorm:"not null"`
+	Error               string    `gorm:"not null;default:''"`
+	EvaluationDate      time.Time `gorm:"not null;uniqueIndex:idx_rule_evaluations_definition,priority:3"`
+}
+
+// RuleEvaluationHealth summarizes the evaluations of the rule group of an alert definition recorded over the rolling window.
+// LastError is the error of the latest failed evaluation, if any.
+type RuleEvaluationHealth struct {
+	Evaluations        int
+	Failures           int
+	AvgDurationSeconds float64
+	MaxDurationSeconds float64
+	LastEvaluationDate time.Time
+	LastError          string
+}
@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"time"
+)
+
+// SnapshotFormatVersion is the version of the format of configuration snapshots, bumped on incompatible changes.
+const SnapshotFormatVersion = 1
+
+// ConfigSnapshot is a snapshot of the alerting configuration of all tenants, holding every version of their alert definitions
+// and receivers, taken for disaster recovery of the database.
+type ConfigSnapshot struct {
+	FormatVersion    int                      `json:"formatVersion"`
+	CreatedAt        time.Time                `json:"createdAt"`
+	Tenants          []Tenant                 `json:"tenants"`
+	AlertDefinitions []AlertDefinitionVersion `json:"alertDefinitions"`
+	Receivers        []ReceiverVersion        `json:"receivers"`
+}
+
+// AlertDefinitionVersion is a version of an alert definition, along with its duration and threshold.
+type AlertDefinitionVersion struct {
+	Definition AlertDefinition `json:"definition"`
+	Duration   AlertDuration   `json:"duration"`
+	Threshold  AlertThreshold  `json:"threshold"`
+}
+
+// ReceiverVersion is a version of a receiver, along with its email configuration and recipients.
+type ReceiverVersion struct {
+	Receiver   Receiver       `json:"receiver"`
+	MailServer string         `json:"mailServer"`
+	From       EmailAddress   `json:"from"`
+	Recipients []EmailAddress `json:"recipients"`
+}
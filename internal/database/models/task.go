@@ -42,6 +42,8 @@ type TaskType string
 const (
 	TypeReceiver        TaskType = "Receiver"
 	TypeAlertDefinition TaskType = "AlertDefinition"
+	// TypeNotification is the kind of the notification tasks queued for retry, see NotificationTask.
+	TypeNotification TaskType = "Notification"
 )
 
 type TaskUUIDTenantID struct {
@@ -61,6 +63,13 @@ type Task struct {
 	StartDate           time.Time
 	CompletionDate      time.Time
 	RetryCount          int64 `gorm:"default:0"`
+	// CorrelationID is the correlation ID of the API request which created the task, empty if it was not created by a request.
+	CorrelationID string `gorm:"not null;default:''"`
+	// Error is the error of the last failed attempt to execute the task, empty if no attempt failed.
+	Error string `gorm:"not null;default:''"`
+	// OperationID is the ID of the operation of the API request which created the task, clients await the task by. It is nil if
+	// the task was not created by a request returning an operation.
+	OperationID *uuid.UUID `gorm:"type:uuid;uniqueIndex"`
 }
 
 func (t *Task) GetTaskUUID() uuid.UUID {
@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TaskHistory is the summary of a completed task, archived before the task is deleted by the task retention, so that the
+// history of the changes applied to an alert definition or receiver remains available. OwnerUUID is the executor replica
+// which took the task, CorrelationID is the correlation ID of the API request which created it and OperationID the ID of its
+// operation, if any. Error is the error of the last failed attempt to execute the task.
+type TaskHistory struct {
+	ID             int64     `gorm:"primaryKey;autoIncrement"`
+	TenantID       string    `gorm:"not null;index:idx_task_history_entity,priority:1"`
+	Type           TaskType  `gorm:"not null"`
+	UUID           uuid.UUID `gorm:"type:uuid;not null;index:idx_task_history_entity,priority:2"`
+	Version        int64     `gorm:"not null"`
+	State          TaskState `gorm:"not null"`
+	OwnerUUID      uuid.UUID `gorm:"type:uuid"`
+	CorrelationID  string    `gorm:"not null;default:''"`
+	RetryCount     int64     `gorm:"not null;default:0"`
+	CreationDate   time.Time `gorm:"not null"`
+	StartDate      time.Time
+	CompletionDate time.Time  `gorm:"not null;index:idx_task_history_entity,priority:3"`
+	OperationID    *uuid.UUID `gorm:"type:uuid;index"`
+	Error          string     `gorm:"not null;default:''"`
+}
+
+// TableName overrides the pluralized table name of task histories.
+func (TaskHistory) TableName() string {
+	return "task_history"
+}
+
+// NewTaskHistory returns the summary of a completed task.
+func NewTaskHistory(task Task) TaskHistory {
+	return TaskHistory{
+		TenantID:       task.TenantID,
+		Type:           task.GetTaskType(),
+		UUID:           task.GetTaskUUID(),
+		Version:        task.Version,
+		State:          task.State,
+		OwnerUUID:      task.OwnerUUID,
+		CorrelationID:  task.CorrelationID,
+		RetryCount:     task.RetryCount,
+		CreationDate:   task.CreationDate,
+		StartDate:      task.StartDate,
+		CompletionDate: task.CompletionDate,
+		OperationID:    task.OperationID,
+		Error:          task.Error,
+	}
+}
+
+// QueueDuration returns the time the task waited before being taken by an executor.
+func (h TaskHistory) QueueDuration() time.Duration {
+	if h.StartDate.IsZero() {
+		return 0
+	}
+	return h.StartDate.Sub(h.CreationDate)
+}
+
+// ApplyDuration returns the time the task took to complete once taken by an executor.
+func (h TaskHistory) ApplyDuration() time.Duration {
+	if h.StartDate.IsZero() {
+		return 0
+	}
+	return h.CompletionDate.Sub(h.StartDate)
+}
+
+// TaskHistoryRollup is the daily summary of the completed tasks of an alert definition or receiver, into which the task
+// history is compacted past its retention. Day is the UTC midnight of the day the tasks were completed. Applied and Invalid
+// count the tasks per state and Retries their retries. LastVersion is the latest version completed that day. The queue and
+// apply durations are totals, so that rollups can be merged and averaged.
+type TaskHistoryRollup struct {
+	ID                 int64         `gorm:"primaryKey;autoIncrement"`
+	TenantID           string        `gorm:"not null;uniqueIndex:idx_task_history_rollups_day,priority:1"`
+	Type               TaskType      `gorm:"not null"`
+	UUID               uuid.UUID     `gorm:"type:uuid;not null;uniqueIndex:idx_task_history_rollups_day,priority:2"`
+	Day                time.Time     `gorm:"not null;uniqueIndex:idx_task_history_rollups_day,priority:3"`
+	Applied            int64         `gorm:"not null;default:0"`
+	Invalid            int64         `gorm:"not null;default:0"`
+	Retries            int64         `gorm:"not null;default:0"`
+	LastVersion        int64         `gorm:"not null;default:0"`
+	TotalQueueDuration time.Duration `gorm:"not null;default:0"`
+	TotalApplyDuration time.Duration `gorm:"not null;default:0"`
+	MaxApplyDuration   time.Duration `gorm:"not null;default:0"`
+}
+
+// RollupDay returns the day of the daily rollup the task history completed at the given time belongs to.
+func RollupDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+// NewTaskHistoryRollup returns the empty daily rollup of the task history of the day the given summary belongs to.
+func NewTaskHistoryRollup(h TaskHistory) TaskHistoryRollup {
+	return TaskHistoryRollup{
+		TenantID: h.TenantID,
+		Type:     h.Type,
+		UUID:     h.UUID,
+		Day:      RollupDay(h.CompletionDate),
+	}
+}
+
+// Add adds the given summary of a completed task to the rollup.
+func (r *TaskHistoryRollup) Add(h TaskHistory) {
+	switch h.State {
+	case TaskApplied:
+		r.Applied++
+	case TaskInvalid:
+		r.Invalid++
+	}
+	r.Retries += h.RetryCount
+	r.LastVersion = max(r.LastVersion, h.Version)
+	r.TotalQueueDuration += h.QueueDuration()
+	r.TotalApplyDuration += h.ApplyDuration()
+	r.MaxApplyDuration = max(r.MaxApplyDuration, h.ApplyDuration())
+}
+
+// Merge merges the given rollup of the same day into the rollup.
+func (r *TaskHistoryRollup) Merge(other TaskHistoryRollup) {
+	r.Applied += other.Applied
+	r.Invalid += other.Invalid
+	r.Retries += other.Retries
+	r.LastVersion = max(r.LastVersion, other.LastVersion)
+	r.TotalQueueDuration += other.TotalQueueDuration
+	r.TotalApplyDuration += other.TotalApplyDuration
+	r.MaxApplyDuration = max(r.MaxApplyDuration, other.MaxApplyDuration)
+}
+
+// Completed returns the number of completed tasks of the rollup.
+func (r TaskHistoryRollup) Completed() int64 {
+	return r.Applied + r.Invalid
+}
@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"time"
+)
+
+// Tenant tracks the API activity of a tenant. A tenant with a non-nil ArchivedDate has its receivers and alert definitions
+// removed from Alertmanager and Mimir, and its pending tasks are not executed until it is unarchived.
+// AlertmanagerShard is the alertmanager instance holding the receivers of the tenant, it is assigned when the tenant is registered.
+// Tier is the service level the tenant is entitled to, empty if the tenant is of the default tier.
+// MaintenanceStart and MaintenanceEnd bound the maintenance mode of the tenant, during which its alerts are silenced by the
+// alertmanager silence given by MaintenanceSilenceID and the routes of its receivers are muted.
+type Tenant struct {
+	TenantID             string    `gorm:"primaryKey"`
+	LastActivityDate     time.Time `gorm:"not null"`
+	ArchivedDate         *time.Time
+	AlertmanagerShard    *int
+	Tier                 string `gorm:"not null;default:''"`
+	MaintenanceStart     *time.Time
+	MaintenanceEnd       *time.Time
+	MaintenanceSilenceID string `gorm:"not null;default:''"`
+}
+
+// IsArchived tells whether the configuration of the tenant is archived.
+func (t Tenant) IsArchived() bool {
+	return t.ArchivedDate != nil
+}
+
+// MaintenanceWindow is the period a tenant is in maintenance mode, and the ID of the alertmanager silence silencing its alerts
+// meanwhile.
+type MaintenanceWindow struct {
+	Start     time.Time
+	End       time.Time
+	SilenceID string
+}
+
+// IsActive tells whether the maintenance window is not over at the given time.
+func (w MaintenanceWindow) IsActive(now time.Time) bool {
+	return now.Before(w.End)
+}
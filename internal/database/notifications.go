@@ -0,0 +1,168 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+// EnqueueNotifications queues the given notifications for delivery, their first attempt being due immediately.
+func (d *DBService) EnqueueNotifications(ctx context.Context, tasks []models.NotificationTask) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	now := clock.TimeNowFn().UTC()
+	for i := range tasks {
+		tasks[i].State = models.TaskNew
+		tasks[i].CreationDate = now
+		tasks[i].NextAttemptDate = now
+	}
+	if err := d.DB.WithContext(ctx).Create(&tasks).Error; err != nil {
+		return fmt.Errorf("failed to enqueue notifications: %w", err)
+	}
+	return nil
+}
+
+// TakeDueNotifications takes up to the given number of notifications due for delivery on behalf of the given executor,
+// oldest due first. Notifications are due once their next attempt date has passed, or once they have been taken for longer
+// than the given timeout, by an executor which died while delivering them. On PostgreSQL, the notifications taken at the
+// same time by other executors are skipped.
+func (d *DBService) TakeDueNotifications(ctx context.Context, ownerUUID uuid.UUID, limit int, takenTimeout time.Duration) ([]models.NotificationTask, error) {
+	tx := d.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	now := clock.TimeNowFn().UTC()
+	query := tx
+	if supportsSkipLocked(tx) {
+		query = query.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate, Options: clause.LockingOptionsSkipLocked})
+	}
+	var tasks []models.NotificationTask
+	if err := query.
+		Where("(state IN (?,?) AND next_attempt_date <= ?) OR (state = ? AND start_date < ?)",
+			models.TaskNew, models.TaskError, now, models.TaskTaken, now.Add(-takenTimeout)).
+		Order("next_attempt_date").
+		Limit(limit).
+		Find(&tasks).Error; err != nil {
+		return nil, fmt.Errorf("failed to get due notifications: %w", err)
+	}
+	if len(tasks) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]int64, 0, len(tasks))
+	for i := range tasks {
+		tasks[i].State = models.TaskTaken
+		tasks[i].OwnerUUID = ownerUUID
+		tasks[i].StartDate = now
+		ids = append(ids, tasks[i].ID)
+	}
+	if err := tx.Model(&models.NotificationTask{}).Where("id IN ?", ids).Updates(map[string]any{
+		"state":      models.TaskTaken,
+		"owner_uuid": ownerUUID,
+		"start_date": now,
+	}).Error; err != nil {
+		return nil, fmt.Errorf("failed to take due notifications: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// SetNotificationDelivered sets the given notification as delivered.
+func (d *DBService) SetNotificationDelivered(ctx context.Context, task models.NotificationTask) error {
+	return d.completeNotification(ctx, task, map[string]any{
+		"state":           models.TaskApplied,
+		"completion_date": clock.TimeNowFn().UTC(),
+	})
+}
+
+// RetryNotification records the failed delivery of the given notification, and sets it to be retried at the given time.
+func (d *DBService) RetryNotification(ctx context.Context, task models.NotificationTask, cause error, at time.Time) error {
+	return d.completeNotification(ctx, task, map[string]any{
+		"state":             models.TaskError,
+		"retry_count":       gorm.Expr("retry_count + 1"),
+		"error":             cause.Error(),
+		"next_attempt_date": at.UTC(),
+	})
+}
+
+// DeadLetterNotification records the failed delivery of the given notification, whose retries are exhausted, and sets it as
+// a dead letter, which is not retried unless redriven.
+func (d *DBService) DeadLetterNotification(ctx context.Context, task models.NotificationTask, cause error) error {
+	return d.completeNotification(ctx, task, map[string]any{
+		"state":           models.TaskInvalid,
+		"retry_count":     gorm.Expr("retry_count + 1"),
+		"error":           cause.Error(),
+		"completion_date": clock.TimeNowFn().UTC(),
+	})
+}
+
+// completeNotification applies the given updates to a notification taken by its owner, unless another executor took it
+// since, after the owner was deemed dead.
+func (d *DBService) completeNotification(ctx context.Context, task models.NotificationTask, updates map[string]any) error {
+	if err := d.DB.WithContext(ctx).Model(&models.NotificationTask{}).
+		Where("id = ? AND state = ? AND owner_uuid = ?", task.ID, models.TaskTaken, task.OwnerUUID).
+		Updates(updates).Error; err != nil {
+		return fmt.Errorf("failed to update notification %d: %w", task.ID, err)
+	}
+	return nil
+}
+
+// GetDeadLetterNotifications gets the latest dead letters of a tenant, or of all tenants if the tenant is empty, latest first.
+func (d *DBService) GetDeadLetterNotifications(ctx context.Context, tenantID api.TenantID, limit int) ([]models.NotificationTask, error) {
+	query := d.reader(ctx).Where("state = ?", models.TaskInvalid)
+	if tenantID != "" {
+		query = query.Where("tenant_id = ?", tenantID)
+	}
+
+	var tasks []models.NotificationTask
+	if err := query.Order("completion_date desc").Limit(limit).Find(&tasks).Error; err != nil {
+		return nil, fmt.Errorf("failed to get dead letter notifications: %w", err)
+	}
+	return tasks, nil
+}
+
+// RedriveNotification queues the dead letter with the given ID for delivery again, its retries being reset.
+// gorm.ErrRecordNotFound is returned if there is no such dead letter.
+func (d *DBService) RedriveNotification(ctx context.Context, id int64) error {
+	res := d.DB.WithContext(ctx).Model(&models.NotificationTask{}).
+		Where("id = ? AND state = ?", id, models.TaskInvalid).
+		Updates(map[string]any{
+			"state":             models.TaskNew,
+			"retry_count":       0,
+			"next_attempt_date": clock.TimeNowFn().UTC(),
+		})
+	if res.Error != nil {
+		return fmt.Errorf("failed to redrive notification %d: %w", id, res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// DeleteCompletedNotifications deletes the delivered and dead letter notifications completed before the given time, and
+// returns the number of deleted notifications.
+func (d *DBService) DeleteCompletedNotifications(ctx context.Context, before time.Time) (int64, error) {
+	res := d.DB.WithContext(ctx).
+		Where("state IN (?,?) AND completion_date < ?", models.TaskApplied, models.TaskInvalid, before.UTC()).
+		Delete(&models.NotificationTask{})
+	if res.Error != nil {
+		return 0, fmt.Errorf("failed to delete completed notifications: %w", res.Error)
+	}
+	return res.RowsAffected, nil
+}
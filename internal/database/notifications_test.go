@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+func TestNotificationQueue(t *testing.T) {
+	conn, err := gorm.Open(sqlite.Open(":memory:"))
+	require.NoError(t, err)
+	require.NoError(t, conn.AutoMigrate(&models.NotificationTask{}))
+	d := &DBService{DB: conn}
+
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	clock.SetFakeClock()
+	defer clock.UnsetFakeClock()
+	clock.FakeClock.Set(now)
+
+	owner := uuid.New()
+	receiverID := uuid.New()
+	notification := func(tenantID, recipient string) models.NotificationTask {
+		return models.NotificationTask{TenantID: tenantID, ReceiverUUID: receiverID, Channel: models.NotificationEmail,
+			Recipient: recipient, Payload: "{}"}
+	}
+	require.NoError(t, d.EnqueueNotifications(t.Context(), []models.NotificationTask{
+		notification("tenant", "foo@bar.com"),
+		notification("tenant", "bar@foo.com"),
+		notification("other", "baz@foo.com"),
+	}))
+
+	t.Run("Due notifications are taken once", func(t *testing.T) {
+		tasks, err := d.TakeDueNotifications(t.Context(), owner, 2, time.Minute)
+		require.NoError(t, err)
+		require.Len(t, tasks, 2)
+		require.Equal(t, models.TaskTaken, tasks[0].State)
+		require.Equal(t, owner, tasks[0].OwnerUUID)
+
+		tasks, err = d.TakeDueNotifications(t.Context(), owner, 10, time.Minute)
+		require.NoError(t, err)
+		require.Len(t, tasks, 1)
+
+		tasks, err = d.TakeDueNotifications(t.Context(), owner, 10, time.Minute)
+		require.NoError(t, err)
+		require.Empty(t, tasks)
+	})
+
+	var taken []models.NotificationTask
+	t.Run("Notifications left taken are taken again after the timeout", func(t *testing.T) {
+		clock.FakeClock.Set(now.Add(2 * time.Minute))
+		taken, err = d.TakeDueNotifications(t.Context(), owner, 10, time.Minute)
+		require.NoError(t, err)
+		require.Len(t, taken, 3)
+	})
+
+	t.Run("Notifications are delivered, retried or dead lettered", func(t *testing.T) {
+		require.NoError(t, d.SetNotificationDelivered(t.Context(), taken[0]))
+		require.NoError(t, d.RetryNotification(t.Context(), taken[1], errors.New("421 Service not available"), now.Add(time.Hour)))
+		require.NoError(t, d.DeadLetterNotification(t.Context(), taken[2], errors.New("421 Service not available")))
+
+		var tasks []models.NotificationTask
+		require.NoError(t, conn.Order("id").Find(&tasks).Error)
+		require.Equal(t, models.TaskApplied, tasks[0].State)
+		require.Equal(t, models.TaskError, tasks[1].State)
+		require.Equal(t, int64(1), tasks[1].RetryCount)
+		require.Equal(t, "421 Service not available", tasks[1].Error)
+		require.Equal(t, models.TaskInvalid, tasks[2].State)
+
+		// The retried notification is not due yet.
+		due, err := d.TakeDueNotifications(t.Context(), owner, 10, time.Minute)
+		require.NoError(t, err)
+		require.Empty(t, due)
+	})
+
+	t.Run("Notifications taken again by another owner are not updated", func(t *testing.T) {
+		stale := taken[1]
+		stale.OwnerUUID = uuid.New()
+		require.NoError(t, d.SetNotificationDelivered(t.Context(), stale))
+
+		var task models.NotificationTask
+		require.NoError(t, conn.First(&task, stale.ID).Error)
+		require.Equal(t, models.TaskError, task.State)
+	})
+
+	t.Run("Dead letters are listed and redriven", func(t *testing.T) {
+		letters, err := d.GetDeadLetterNotifications(t.Context(), "other", 10)
+		require.NoError(t, err)
+		require.Len(t, letters, 1)
+		require.Equal(t, "baz@foo.com", letters[0].Recipient)
+
+		letters, err = d.GetDeadLetterNotifications(t.Context(), "tenant", 10)
+		require.NoError(t, err)
+		require.Empty(t, letters)
+
+		require.NoError(t, d.RedriveNotification(t.Context(), taken[2].ID))
+		require.ErrorIs(t, d.RedriveNotification(t.Context(), taken[2].ID), gorm.ErrRecordNotFound)
+
+		due, err := d.TakeDueNotifications(t.Context(), owner, 10, time.Minute)
+		require.NoError(t, err)
+		require.Len(t, due, 1)
+		require.Equal(t, taken[2].ID, due[0].ID)
+		require.Zero(t, due[0].RetryCount)
+	})
+
+	t.Run("Completed notifications past retention are deleted", func(t *testing.T) {
+		deleted, err := d.DeleteCompletedNotifications(t.Context(), now.Add(time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, int64(1), deleted)
+
+		var count int64
+		require.NoError(t, conn.Model(&models.NotificationTask{}).Count(&count).Error)
+		require.Equal(t, int64(2), count)
+	})
+}
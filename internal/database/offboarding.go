@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+// OffboardRecipient removes the email recipient with the given address from the latest version of the receivers of all
+// tenants, creating a new version of each of them along with its task, and records the receivers it was removed from. The
+// pending tasks of their previous versions are set as invalid. Either the recipient is removed from all receivers or from
+// none. It is retried if it conflicts with a concurrent update. The records of the receivers are returned, sorted by tenant
+// and name.
+func (d *DBService) OffboardRecipient(ctx context.Context, email string) ([]models.RecipientOffboarding, error) {
+	var records []models.RecipientOffboarding
+	err := d.retryTx(ctx, func(tx *gorm.DB) error {
+		records = nil
+
+		recvs, err := getLatestReceiversWithRecipient(tx, email)
+		if err != nil {
+			return err
+		}
+
+		for _, recv := range recvs {
+			recipients, err := getReceiverRecipients(tx, recv)
+			if err != nil {
+				return err
+			}
+			recipients = slices.DeleteFunc(recipients, func(r models.EmailAddress) bool { return r.Email == email })
+
+			version, err := setReceiverValues(ctx, tx, recv.TenantID, recv.UUID, models.DBReceiverValues{Recipients: recipients})
+			if err != nil {
+				return fmt.Errorf("failed to remove recipient %q from receiver %q for tenant %q: %w", email, recv.UUID, recv.TenantID, err)
+			}
+			if err := invalidatePendingReceiverTasks(tx, recv.TenantID, recv.UUID, version); err != nil {
+				return err
+			}
+
+			records = append(records, models.RecipientOffboarding{
+				Email:        email,
+				TenantID:     recv.TenantID,
+				ReceiverUUID: recv.UUID,
+				ReceiverName: recv.Name,
+				Version:      version,
+				CreationDate: clock.TimeNowFn().UTC(),
+			})
+		}
+
+		if len(records) == 0 {
+			return nil
+		}
+		if err := tx.Create(&records).Error; err != nil {
+			return fmt.Errorf("failed to record offboarding of recipient %q: %w", email, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// GetRecipientOffboardings gets the records of the receivers the email recipient with the given address was removed from as
+// it was offboarded, latest first.
+func (d *DBService) GetRecipientOffboardings(ctx context.Context, email string) ([]models.RecipientOffboarding, error) {
+	var records []models.RecipientOffboarding
+	if err := d.DB.WithContext(ctx).
+		Where("email = ?", email).
+		Order("creation_date desc, id desc").
+		Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("failed to get offboardings of recipient %q: %w", email, err)
+	}
+	return records, nil
+}
+
+// getLatestReceiversWithRecipient gets the latest version of the receivers of all tenants the email recipient with the given
+// address is a recipient of, sorted by tenant and name.
+func getLatestReceiversWithRecipient(tx *gorm.DB, email string) ([]models.Receiver, error) {
+	var recvs []models.Receiver
+	if err := tx.Model(&models.Receiver{}).
+		Where("version = (?)", tx.Model(&models.Receiver{}).
+			Select("MAX(latest.version)").
+			Table("receivers latest").
+			Where("latest.tenant_id = receivers.tenant_id").
+			Where("latest.uuid = receivers.uuid"),
+		).
+		Where("id IN (?)", tx.Table("email_recipients er").
+			Select("er.receiver_id").
+			Joins("INNER JOIN email_addresses ea ON ea.id = er.email_address_id").
+			Where("ea.email = ?", email),
+		).
+		Order("tenant_id, name").
+		Find(&recvs).Error; err != nil {
+		return nil, fmt.Errorf("failed to get receivers with recipient %q: %w", email, err)
+	}
+	return recvs, nil
+}
+
+// getReceiverRecipients gets the email recipients of the given version of a receiver, in the order they were added.
+func getReceiverRecipients(tx *gorm.DB, recv models.Receiver) ([]models.EmailAddress, error) {
+	var recipients []models.EmailAddress
+	if err := tx.
+		Table("email_addresses ea").
+		Joins("INNER JOIN email_recipients er ON ea.id = er.email_address_id").
+		Where("er.receiver_id = ?", recv.ID).
+		Order("er.id").
+		Find(&recipients).Error; err != nil {
+		return nil, fmt.Errorf("failed to get email recipients of receiver %q for tenant %q: %w", recv.UUID, recv.TenantID, err)
+	}
+	return recipients, nil
+}
@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+type operationContextKey struct{}
+
+// NewOperationContext returns a copy of the context holding the given operation ID, which is recorded on the task created by
+// the change made with the context, so that the task can be awaited by its operation.
+func NewOperationContext(ctx context.Context, id uuid.UUID) context.Context {
+	return context.WithValue(ctx, operationContextKey{}, id)
+}
+
+// OperationFromContext returns the operation ID held by the context, nil if there is none.
+func OperationFromContext(ctx context.Context) *uuid.UUID {
+	id, ok := ctx.Value(operationContextKey{}).(uuid.UUID)
+	if !ok {
+		return nil
+	}
+	return &id
+}
+
+// GetOperation gets the summary of the task of the given operation of a tenant, whether still in the tasks or archived into
+// the task history. It returns gorm.ErrRecordNotFound if the tenant has no such operation.
+func (d *DBService) GetOperation(ctx context.Context, tenantID api.TenantID, id uuid.UUID) (*models.TaskHistory, error) {
+	var task models.Task
+	err := d.DB.WithContext(ctx).
+		Where("tenant_id = ? AND operation_id = ?", tenantID, id).
+		Take(&task).Error
+	if err == nil {
+		history := models.NewTaskHistory(task)
+		return &history, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to get task of operation %q for tenant %q: %w", id, tenantID, err)
+	}
+
+	var archived models.TaskHistory
+	if err := d.DB.WithContext(ctx).
+		Where("tenant_id = ? AND operation_id = ?", tenantID, id).
+		Take(&archived).Error; err != nil {
+		return nil, fmt.Errorf("failed to get archived task of operation %q for tenant %q: %w", id, tenantID, err)
+	}
+	return &archived, nil
+}
@@ -4,51 +4,67 @@
 package database
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 
 	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
 	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/correlation"
 	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
 )
 
-// GetLatestReceiverListWithEmailConfig gets the list with the info of the latest version of alert receivers including their mail server,
-// sender, and list of email recipients. Receivers with state 'Error' are excluded.
-func (d *DBService) GetLatestReceiverListWithEmailConfig(ctx context.Context, tenantID api.TenantID) ([]*models.DBReceiver, error) {
-	tx := d.DB.WithContext(ctx).Begin()
+// GetLatestReceiverListWithEmailConfig gets a page of the list with the info of the latest version of alert receivers including their
+// mail server, sender, and list of email recipients, sorted as given by the list options. Receivers with state 'Error' are excluded. The total number
+// of receivers, regardless of pagination, is returned as well. It is read from the read replica, if any.
+func (d *DBService) GetLatestReceiverListWithEmailConfig(ctx context.Context, tenantID api.TenantID, opts ListOptions) ([]*models.DBReceiver, int64, error) {
+	tx := d.reader(ctx).Begin()
 	defer tx.Rollback()
 
-	recvUUIDs, err := GetReceiverUUIDs(tx, tenantID)
+	query := tx.Model(&models.Receiver{}).
+		Where("tenant_id = ?", tenantID).
+		Where("version = (?)", tx.Model(&models.Receiver{}).
+			Select("MAX(latest.version)").
+			Table("receivers latest").
+			Where("latest.tenant_id = receivers.tenant_id").
+			Where("latest.uuid = receivers.uuid").
+			Where("latest.state != ?", models.ReceiverError),
+		)
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count receivers for tenant %q: %w", tenantID, err)
+	}
+
+	sorted, err := sortList(query, opts, "min_severity")
 	if err != nil {
-		return nil, fmt.Errorf("failed to get list of receiver UUIDs for tenant %q: %w", tenantID, err)
-	}
-
-	receivers := make([]*models.DBReceiver, len(recvUUIDs))
-	for i, recvUUID := range recvUUIDs {
-		// Get the receiver by UUID and tenantID, if exists, with the latest version.
-		var recv models.Receiver
-		if err := tx.
-			Where("tenant_id = ?", tenantID).
-			Where("uuid = ?", recvUUID).
-			Where("state != ?", models.ReceiverError).
-			Order("version desc").
-			First(&recv).Error; err != nil {
-			return nil, err
-		}
+		return nil, 0, fmt.Errorf("failed to sort receivers for tenant %q: %w", tenantID, err)
+	}
 
+	var recvs []models.Receiver
+	if err := paginate(sorted, opts).Find(&recvs).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to get list of receivers for tenant %q: %w", tenantID, err)
+	}
+
+	receivers := make([]*models.DBReceiver, len(recvs))
+	for i, recv := range recvs {
 		dbRecv, err := getReceiverWithEmailConfig(tx, recv)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get receiver %q for tenant %q: %w", recvUUID, tenantID, err)
+			return nil, 0, fmt.Errorf("failed to get receiver %q for tenant %q: %w", recv.UUID, tenantID, err)
 		}
 		receivers[i] = dbRecv
 	}
 
-	return receivers, nil
+	return receivers, total, nil
 }
 
 // GetReceiverUUIDs is a helper function that gets the list with unique alert receiver UUIDs.
@@ -152,32 +168,191 @@ func getReceiverWithEmailConfig(tx *gorm.DB, recv models.Receiver) (*models.DBRe
 	}
 
 	to := make([]string, len(recipients))
+	var unverified []string
 	for i, r := range recipients {
 		to[i] = r.String()
+		if r.VerificationPending {
+			unverified = append(unverified, to[i])
+		}
+	}
+
+	createdAt, appliedAt, err := versionTimestamps(tx, &models.Receiver{}, recv.TenantID, recv.UUID, recv.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	maintenance, err := getTenantMaintenance(tx, recv.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	emailTemplate, err := getEmailTemplateContent(tx, recv.TenantID)
+	if err != nil {
+		return nil, err
 	}
 
 	return &models.DBReceiver{
-		UUID:       recv.UUID,
-		State:      recv.State,
-		Name:       recv.Name,
-		Version:    int(recv.Version),
-		MailServer: mailServer,
-		From:       fmt.Sprintf("%s %s <%s>", from.firstName, from.lastName, from.email),
-		To:         to,
-		TenantID:   recv.TenantID,
+		UUID:        recv.UUID,
+		State:       recv.State,
+		Name:        recv.Name,
+		Version:     int(recv.Version),
+		MailServer:  mailServer,
+		From:        fmt.Sprintf("%s %s <%s>", from.firstName, from.lastName, from.email),
+		To:          to,
+		TenantID:    recv.TenantID,
+		MinSeverity: recv.MinSeverity,
+		QuietHours:  recv.QuietHours,
+		CreatedAt:   createdAt,
+		UpdatedAt:   recv.CreationDate,
+		AppliedAt:   appliedAt,
+		Maintenance: maintenance,
+
+		OnCallRoutingKey: recv.OnCallRoutingKey,
+		EmailTemplate:    emailTemplate,
+		Language:         recv.Language,
+		Unverified:       unverified,
+		Disabled:         recv.Disabled,
+		Matchers:         splitMatchers(recv.Matchers),
 	}, nil
 }
 
-// SetReceiverEmailRecipients sets the list of email recipients of an alert receiver.
-// It also creates a new task for task executor, linked to the newly created receiver.
-func (d *DBService) SetReceiverEmailRecipients(ctx context.Context, tenantID api.TenantID, id uuid.UUID, recipients []models.EmailAddress) error {
-	tx := d.DB.Begin().WithContext(ctx)
-	defer tx.Rollback()
+// splitMatchers returns the route matchers of a receiver stored one per line, nil if there are none.
+func splitMatchers(matchers string) []string {
+	if matchers == "" {
+		return nil
+	}
+	return strings.Split(matchers, "\n")
+}
+
+// SetReceiverValues sets the list of email recipients and, if given, the minimum severity, quiet hours, Grafana OnCall routing key, email language, sender address, mail server, disabled state, and route matchers of an alert receiver.
+// Values that are not given remain unchanged. It also creates a new task for task executor, linked to the newly created receiver.
+// It is retried if it conflicts with a concurrent update.
+func (d *DBService) SetReceiverValues(ctx context.Context, tenantID api.TenantID, id uuid.UUID, values models.DBReceiverValues) error {
+	return d.retryTx(ctx, func(tx *gorm.DB) error {
+		_, err := setReceiverValues(ctx, tx, tenantID, id, values)
+		return err
+	})
+}
 
+// SetReceiversRecipients sets the list of email recipients of the given receivers of a tenant at once, creating a new version
+// of each of them along with its task. The pending tasks of their previous versions are set as invalid, so that only the
+// latest version of each receiver is left to apply. Either all receivers are updated or none is. It is retried if it
+// conflicts with a concurrent update.
+func (d *DBService) SetReceiversRecipients(ctx context.Context, tenantID api.TenantID, recipients map[uuid.UUID][]models.EmailAddress) error {
+	// Receivers are updated in a stable order, so that concurrent updates do not deadlock.
+	ids := slices.SortedFunc(maps.Keys(recipients), func(a, b uuid.UUID) int {
+		return bytes.Compare(a[:], b[:])
+	})
+
+	return d.retryTx(ctx, func(tx *gorm.DB) error {
+		for _, id := range ids {
+			version, err := setReceiverValues(ctx, tx, tenantID, id, models.DBReceiverValues{Recipients: recipients[id]})
+			if err != nil {
+				return fmt.Errorf("failed to set recipients of receiver %q for tenant %q: %w", id, tenantID, err)
+			}
+
+			if err := invalidatePendingReceiverTasks(tx, tenantID, id, version); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// overrideEmailConfig returns the ID of the email configuration of the given one with the given sender address and mail
+// server, if given, creating it if it does not exist. Email configurations are shared by the receivers with the same ones.
+func overrideEmailConfig(tx *gorm.DB, id int64, sender *models.EmailAddress, mailServer *string) (int64, error) {
+	var current models.EmailConfig
+	if err := tx.First(&current, id).Error; err != nil {
+		return 0, fmt.Errorf("failed to get email configuration %d: %w", id, err)
+	}
+
+	override := models.EmailConfig{MailServer: current.MailServer, From: current.From}
+	if mailServer != nil {
+		override.MailServer = *mailServer
+	}
+	if sender != nil {
+		// The names of an existing address are kept, as addresses are unique by email.
+		from := models.EmailAddress{Email: sender.Email}
+		if err := tx.Where(models.EmailAddress{Email: sender.Email}).Attrs(models.EmailAddress{
+			FirstName: sender.FirstName,
+			LastName:  sender.LastName,
+		}).FirstOrCreate(&from).Error; err != nil {
+			return 0, fmt.Errorf("failed to get sender email address %q: %w", sender.Email, err)
+		}
+		override.From = from.ID
+	}
+	if override.MailServer == current.MailServer && override.From == current.From {
+		return current.ID, nil
+	}
+
+	if err := tx.Where(models.EmailConfig{MailServer: override.MailServer, From: override.From}).FirstOrCreate(&override).Error; err != nil {
+		return 0, fmt.Errorf("failed to get email configuration: %w", err)
+	}
+	return override.ID, nil
+}
+
+// invalidatePendingReceiverTasks sets the pending tasks of the versions of a receiver older than the given one as invalid, as
+// applying the given version supersedes them.
+func invalidatePendingReceiverTasks(tx *gorm.DB, tenantID api.TenantID, id uuid.UUID, version int64) error {
+	if err := tx.Model(&models.Task{}).
+		Where("tenant_id = ? AND receiver_uuid = ?", tenantID, id).
+		Where("state IN (?,?)", models.TaskNew, models.TaskError).
+		Where("version < ?", version).
+		Updates(models.Task{
+			State:          models.TaskInvalid,
+			CompletionDate: clock.TimeNowFn(),
+		}).Error; err != nil {
+		return fmt.Errorf("failed to set pending tasks of receiver %q for tenant %q as invalid: %w", id, tenantID, err)
+	}
+	return nil
+}
+
+// setReceiverValues creates the next version of the latest version of a receiver with the given values applied, along with its
+// task, and returns the version created.
+func setReceiverValues(ctx context.Context, tx *gorm.DB, tenantID api.TenantID, id uuid.UUID, values models.DBReceiverValues) (int64, error) {
 	// Get the receiver by UUID and tenantID, if exists, with the latest version.
 	var recv models.Receiver
 	if err := tx.Where("tenant_id = ?", tenantID).Where("uuid = ?", id).Order("version desc").First(&recv).Error; err != nil {
-		return err
+		return 0, err
+	}
+
+	minSeverity := recv.MinSeverity
+	if values.MinSeverity != nil {
+		minSeverity = *values.MinSeverity
+	}
+
+	quietHours := recv.QuietHours
+	if values.QuietHours != nil {
+		quietHours = *values.QuietHours
+	}
+
+	onCallRoutingKey := recv.OnCallRoutingKey
+	if values.OnCallRoutingKey != nil {
+		onCallRoutingKey = *values.OnCallRoutingKey
+	}
+
+	language := recv.Language
+	if values.Language != nil {
+		language = *values.Language
+	}
+
+	disabled := recv.Disabled
+	if values.Disabled != nil {
+		disabled = *values.Disabled
+	}
+
+	matchers := recv.Matchers
+	if values.Matchers != nil {
+		matchers = strings.Join(*values.Matchers, "\n")
+	}
+
+	emailConfigID := recv.EmailConfigID
+	if values.Sender != nil || values.MailServer != nil {
+		var err error
+		if emailConfigID, err = overrideEmailConfig(tx, recv.EmailConfigID, values.Sender, values.MailServer); err != nil {
+			return 0, err
+		}
 	}
 
 	// Create new receiver with bumped version.
@@ -185,44 +360,56 @@ func (d *DBService) SetReceiverEmailRecipients(ctx context.Context, tenantID api
 		UUID:          recv.UUID,
 		Name:          recv.Name,
 		State:         models.ReceiverModified,
-		EmailConfigID: recv.EmailConfigID,
+		EmailConfigID: emailConfigID,
 		Version:       recv.Version + 1,
 		TenantID:      recv.TenantID,
+		MinSeverity:   minSeverity,
+		QuietHours:    quietHours,
+
+		OnCallRoutingKey: onCallRoutingKey,
+		Language:         language,
+		Disabled:         disabled,
+		Matchers:         matchers,
 	}
 	if err := tx.Create(&newRecv).Error; err != nil {
-		return err
+		return 0, err
 	}
 
-	for _, r := range recipients {
+	for _, r := range values.Recipients {
 		recipient := r
 
-		// Check if email is within the email_addresses table, if not insert.
+		// Check if email is within the email_addresses table, if not insert. Addresses inserted while email verification is
+		// enabled are pending verification.
 		if err := tx.Where(models.EmailAddress{
 			Email: recipient.Email,
+		}).Attrs(models.EmailAddress{
+			VerificationPending: emailVerificationFromContext(ctx),
 		}).FirstOrCreate(&recipient).Error; err != nil {
-			return err
+			return 0, err
 		}
 
 		if err := tx.Create(&models.EmailRecipient{
 			ReceiverID:     newRecv.ID,
 			EmailAddressID: recipient.ID,
 		}).Error; err != nil {
-			return err
+			return 0, err
 		}
 	}
 
 	task := models.Task{
-		State:        models.TaskNew,
-		ReceiverUUID: &newRecv.UUID,
-		TenantID:     newRecv.TenantID,
-		Version:      newRecv.Version,
-		CreationDate: clock.TimeNowFn(),
+		State:         models.TaskNew,
+		ReceiverUUID:  &newRecv.UUID,
+		TenantID:      newRecv.TenantID,
+		Version:       newRecv.Version,
+		CreationDate:  clock.TimeNowFn(),
+		CorrelationID: correlation.FromContext(ctx),
+		OperationID:   OperationFromContext(ctx),
 	}
 	if err := tx.Create(&task).Error; err != nil {
-		return fmt.Errorf("failed to create a new task for receiver with uuid %v version %v for tenant %q: %w", newRecv.UUID, newRecv.Version, tenantID, err)
+		return 0, fmt.Errorf("failed to create a new task for receiver with uuid %v version %v for tenant %q: %w", newRecv.UUID, newRecv.Version, tenantID, err)
 	}
 
-	return tx.Commit().Error
+	return newRecv.Version, nil
 }
 
 // SetReceiverState sets the state of the specific version of a given receiver.
@@ -256,3 +443,53 @@ func setReceiverState(tx *gorm.DB, tenantID api.TenantID, id uuid.UUID, version
 
 	return nil
 }
+
+// setReceiverApplied sets the state of a specific receiver version to 'Applied' and records the time it was applied.
+func setReceiverApplied(tx *gorm.DB, tenantID api.TenantID, id uuid.UUID, version int64, appliedDate time.Time) error {
+	var recv models.Receiver
+	if err := tx.
+		Where("tenant_id = ?", tenantID).
+		Where("uuid = ?", id).
+		Where("version = ?", version).
+		Take(&recv).Error; err != nil {
+		return fmt.Errorf("failed to retrieve receiver for tenant %q: %w", tenantID, err)
+	}
+
+	if err := tx.
+		Model(&recv).
+		Updates(map[string]any{
+			"state":        models.ReceiverApplied,
+			"applied_date": appliedDate.UTC(),
+		}).Error; err != nil {
+		return fmt.Errorf("failed to update receiver state: %w", err)
+	}
+
+	return nil
+}
+
+// GetLatestReceiverStates gets the tenant, UUID, name, version, and state of the latest version of the receivers of all tenants,
+// regardless of whether it was successfully applied. Receivers of archived tenants are left out.
+func (d *DBService) GetLatestReceiverStates(ctx context.Context) ([]models.Receiver, error) {
+	tx := d.DB.WithContext(ctx)
+
+	var recvs []models.Receiver
+	if err := tx.Model(&models.Receiver{}).
+		Select("tenant_id", "uuid", "name", "version", "state").
+		Where("version = (?)", tx.Model(&models.Receiver{}).
+			Select("MAX(latest.version)").
+			Table("receivers latest").
+			Where("latest.tenant_id = receivers.tenant_id").
+			Where("latest.uuid = receivers.uuid"),
+		).
+		Where("NOT EXISTS (?)", tx.Model(&models.Tenant{}).
+			Select("1").
+			Where("tenants.tenant_id = receivers.tenant_id").
+			Where("tenants.archived_date IS NOT NULL"),
+		).
+		Order("tenant_id").
+		Order("uuid").
+		Find(&recvs).Error; err != nil {
+		return nil, fmt.Errorf("failed to get states of receivers: %w", err)
+	}
+	return recvs, nil
+}
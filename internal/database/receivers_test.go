@@ -0,0 +1,229 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+func TestSetReceiversRecipients(t *testing.T) {
+	conn, err := gorm.Open(sqlite.Open(":memory:"))
+	require.NoError(t, err)
+	require.NoError(t, conn.AutoMigrate(&models.EmailAddress{}, &models.EmailConfig{}, &models.Receiver{}, &models.EmailRecipient{}, &models.Task{}))
+
+	tenantID := "edgenode"
+	config := models.EmailConfig{MailServer: "smtp.example.com:587", From: 1}
+	require.NoError(t, conn.Create(&config).Error)
+
+	// The first receiver has a pending change not applied yet, the second one has none.
+	pending := uuid.New()
+	idle := uuid.New()
+	for _, recv := range []models.Receiver{
+		{UUID: pending, Name: "pending", Version: 1, State: models.ReceiverApplied, EmailConfigID: config.ID, TenantID: tenantID},
+		{UUID: pending, Name: "pending", Version: 2, State: models.ReceiverModified, EmailConfigID: config.ID, TenantID: tenantID},
+		{UUID: idle, Name: "idle", Version: 1, State: models.ReceiverApplied, EmailConfigID: config.ID, TenantID: tenantID},
+	} {
+		require.NoError(t, conn.Create(&recv).Error)
+	}
+	require.NoError(t, conn.Create(&models.Task{State: models.TaskError, ReceiverUUID: &pending, TenantID: tenantID, Version: 2, RetryCount: 1}).Error)
+
+	d := &DBService{DB: conn}
+	alice := models.EmailAddress{FirstName: "alice", LastName: "smith", Email: "alice@example.com"}
+	require.NoError(t, d.SetReceiversRecipients(context.Background(), tenantID, map[uuid.UUID][]models.EmailAddress{
+		pending: {alice},
+		idle:    {alice},
+	}))
+
+	for id, version := range map[uuid.UUID]int64{pending: 3, idle: 2} {
+		var recv models.Receiver
+		require.NoError(t, conn.Where("uuid = ?", id).Order("version desc").First(&recv).Error)
+		require.Equal(t, version, recv.Version)
+
+		var recipients int64
+		require.NoError(t, conn.Model(&models.EmailRecipient{}).Where("receiver_id = ?", recv.ID).Count(&recipients).Error)
+		require.Equal(t, int64(1), recipients)
+	}
+
+	// Only the task of the latest version of each receiver is left to apply.
+	var tasks []models.Task
+	require.NoError(t, conn.Order("id").Find(&tasks).Error)
+	require.Len(t, tasks, 3)
+	require.Equal(t, models.TaskInvalid, tasks[0].State)
+	require.False(t, tasks[0].CompletionDate.IsZero())
+	for _, task := range tasks[1:] {
+		require.Equal(t, models.TaskNew, task.State)
+	}
+
+	t.Run("Unknown receiver updates none", func(t *testing.T) {
+		err := d.SetReceiversRecipients(context.Background(), tenantID, map[uuid.UUID][]models.EmailAddress{
+			idle:       nil,
+			uuid.New(): nil,
+		})
+		require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+		var recv models.Receiver
+		require.NoError(t, conn.Where("uuid = ?", idle).Order("version desc").First(&recv).Error)
+		require.Equal(t, int64(2), recv.Version)
+	})
+}
+
+func TestSetReceiverValuesEmailOverride(t *testing.T) {
+	conn, err := gorm.Open(sqlite.Open(":memory:"))
+	require.NoError(t, err)
+	require.NoError(t, conn.AutoMigrate(&models.EmailAddress{}, &models.EmailConfig{}, &models.Receiver{}, &models.EmailRecipient{}, &models.Task{}))
+
+	tenantID := "edgenode"
+	sender := models.EmailAddress{FirstName: "Open Edge Platform", LastName: "Alert", Email: "alerts@example.com"}
+	require.NoError(t, conn.Create(&sender).Error)
+	config := models.EmailConfig{MailServer: "smtp.example.com:587", From: sender.ID}
+	require.NoError(t, conn.Create(&config).Error)
+
+	first, second := uuid.New(), uuid.New()
+	for _, id := range []uuid.UUID{first, second} {
+		recv := models.Receiver{UUID: id, Name: id.String(), Version: 1, State: models.ReceiverApplied, EmailConfigID: config.ID, TenantID: tenantID}
+		require.NoError(t, conn.Create(&recv).Error)
+	}
+
+	d := &DBService{DB: conn}
+	latestEmailConfig := func(t *testing.T, id uuid.UUID) models.EmailConfig {
+		t.Helper()
+		var recv models.Receiver
+		require.NoError(t, conn.Where("uuid = ?", id).Order("version desc").First(&recv).Error)
+		var emailConfig models.EmailConfig
+		require.NoError(t, conn.First(&emailConfig, recv.EmailConfigID).Error)
+		return emailConfig
+	}
+
+	mailServer := "smtp-eu.example.com:587"
+	override := models.DBReceiverValues{
+		Sender:     &models.EmailAddress{FirstName: "Tenant", LastName: "Alerts", Email: "tenant@example.com"},
+		MailServer: &mailServer,
+	}
+	require.NoError(t, d.SetReceiverValues(context.Background(), tenantID, first, override))
+
+	overridden := latestEmailConfig(t, first)
+	require.NotEqual(t, config.ID, overridden.ID)
+	require.Equal(t, mailServer, overridden.MailServer)
+	var from models.EmailAddress
+	require.NoError(t, conn.First(&from, overridden.From).Error)
+	require.Equal(t, "tenant@example.com", from.Email)
+
+	t.Run("Same override shares email configuration", func(t *testing.T) {
+		require.NoError(t, d.SetReceiverValues(context.Background(), tenantID, second, override))
+		require.Equal(t, overridden.ID, latestEmailConfig(t, second).ID)
+	})
+
+	t.Run("Unchanged override keeps email configuration", func(t *testing.T) {
+		require.NoError(t, d.SetReceiverValues(context.Background(), tenantID, first, models.DBReceiverValues{MailServer: &mailServer}))
+		require.Equal(t, overridden.ID, latestEmailConfig(t, first).ID)
+	})
+
+	t.Run("Sender override keeps mail server", func(t *testing.T) {
+		require.NoError(t, d.SetReceiverValues(context.Background(), tenantID, first, models.DBReceiverValues{Sender: &sender}))
+		emailConfig := latestEmailConfig(t, first)
+		require.Equal(t, mailServer, emailConfig.MailServer)
+		require.Equal(t, sender.ID, emailConfig.From)
+	})
+}
+
+func TestSetReceiverValuesDisabled(t *testing.T) {
+	conn, err := gorm.Open(sqlite.Open(":memory:"))
+	require.NoError(t, err)
+	require.NoError(t, conn.AutoMigrate(&models.EmailAddress{}, &models.EmailConfig{}, &models.Receiver{}, &models.EmailRecipient{}, &models.Task{},
+		&models.Tenant{}, &models.EmailTemplate{}))
+
+	tenantID := "edgenode"
+	sender := models.EmailAddress{FirstName: "Open Edge Platform", LastName: "Alert", Email: "alerts@example.com"}
+	require.NoError(t, conn.Create(&sender).Error)
+	config := models.EmailConfig{MailServer: "smtp.example.com:587", From: sender.ID}
+	require.NoError(t, conn.Create(&config).Error)
+
+	id := uuid.New()
+	require.NoError(t, conn.Create(&models.Receiver{
+		UUID: id, Name: "receiver", Version: 1, State: models.ReceiverApplied, EmailConfigID: config.ID, TenantID: tenantID,
+	}).Error)
+
+	d := &DBService{DB: conn}
+	alice := models.EmailAddress{FirstName: "alice", LastName: "smith", Email: "alice@example.com"}
+	disabled := true
+	require.NoError(t, d.SetReceiverValues(context.Background(), tenantID, id, models.DBReceiverValues{
+		Recipients: []models.EmailAddress{alice},
+		Disabled:   &disabled,
+	}))
+
+	recv, err := d.GetLatestReceiverWithEmailConfig(context.Background(), tenantID, id)
+	require.NoError(t, err)
+	require.True(t, recv.Disabled)
+	require.Equal(t, []string{alice.String()}, recv.To)
+
+	// The receiver remains disabled, along with its recipients, until it is enabled again.
+	require.NoError(t, d.SetReceiverValues(context.Background(), tenantID, id, models.DBReceiverValues{Recipients: []models.EmailAddress{alice}}))
+	recv, err = d.GetLatestReceiverWithEmailConfig(context.Background(), tenantID, id)
+	require.NoError(t, err)
+	require.True(t, recv.Disabled)
+
+	disabled = false
+	require.NoError(t, d.SetReceiverValues(context.Background(), tenantID, id, models.DBReceiverValues{
+		Recipients: []models.EmailAddress{alice},
+		Disabled:   &disabled,
+	}))
+	recv, err = d.GetLatestReceiverWithEmailConfig(context.Background(), tenantID, id)
+	require.NoError(t, err)
+	require.False(t, recv.Disabled)
+}
+
+func TestSetReceiverValuesMatchers(t *testing.T) {
+	conn, err := gorm.Open(sqlite.Open(":memory:"))
+	require.NoError(t, err)
+	require.NoError(t, conn.AutoMigrate(&models.EmailAddress{}, &models.EmailConfig{}, &models.Receiver{}, &models.EmailRecipient{}, &models.Task{},
+		&models.Tenant{}, &models.EmailTemplate{}))
+
+	tenantID := "edgenode"
+	sender := models.EmailAddress{FirstName: "Open Edge Platform", LastName: "Alert", Email: "alerts@example.com"}
+	require.NoError(t, conn.Create(&sender).Error)
+	config := models.EmailConfig{MailServer: "smtp.example.com:587", From: sender.ID}
+	require.NoError(t, conn.Create(&config).Error)
+
+	id := uuid.New()
+	require.NoError(t, conn.Create(&models.Receiver{
+		UUID: id, Name: "receiver", Version: 1, State: models.ReceiverApplied, EmailConfigID: config.ID, TenantID: tenantID,
+	}).Error)
+
+	d := &DBService{DB: conn}
+	recv, err := d.GetLatestReceiverWithEmailConfig(context.Background(), tenantID, id)
+	require.NoError(t, err)
+	require.Nil(t, recv.Matchers)
+
+	alice := models.EmailAddress{FirstName: "alice", LastName: "smith", Email: "alice@example.com"}
+	matchers := []string{`cluster_name="edge-1"`, `host_uuid=~"a.*"`}
+	require.NoError(t, d.SetReceiverValues(context.Background(), tenantID, id, models.DBReceiverValues{
+		Recipients: []models.EmailAddress{alice},
+		Matchers:   &matchers,
+	}))
+	recv, err = d.GetLatestReceiverWithEmailConfig(context.Background(), tenantID, id)
+	require.NoError(t, err)
+	require.Equal(t, matchers, recv.Matchers)
+
+	// The matchers are kept until they are replaced, an empty list removing them.
+	require.NoError(t, d.SetReceiverValues(context.Background(), tenantID, id, models.DBReceiverValues{Recipients: []models.EmailAddress{alice}}))
+	recv, err = d.GetLatestReceiverWithEmailConfig(context.Background(), tenantID, id)
+	require.NoError(t, err)
+	require.Equal(t, matchers, recv.Matchers)
+
+	require.NoError(t, d.SetReceiverValues(context.Background(), tenantID, id, models.DBReceiverValues{
+		Recipients: []models.EmailAddress{alice},
+		Matchers:   &[]string{},
+	}))
+	recv, err = d.GetLatestReceiverWithEmailConfig(context.Background(), tenantID, id)
+	require.NoError(t, err)
+	require.Nil(t, recv.Matchers)
+}
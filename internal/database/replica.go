@@ -0,0 +1,39 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// readReplicaResolver is the name of the resolver routing read-only queries to the read replica.
+const readReplicaResolver = "read_replica"
+
+// UseReadReplica registers the database of the given DSN as a read replica of the given connection. Only the read-only
+// queries opting in through the reader of DBService, such as the lists and histories served to dashboards, are routed to
+// it, so that reads which must see the latest writes, such as the ones of task executor, keep going to the primary.
+func UseReadReplica(db *gorm.DB, dsn string) error {
+	return useReadReplica(db, postgres.Open(dsn))
+}
+
+func useReadReplica(db *gorm.DB, replica gorm.Dialector) error {
+	resolver := dbresolver.Register(dbresolver.Config{
+		Replicas: []gorm.Dialector{replica},
+	}, readReplicaResolver)
+	if err := db.Use(resolver); err != nil {
+		return fmt.Errorf("failed to register read replica: %w", err)
+	}
+	return nil
+}
+
+// reader returns a session for read-only queries, which are routed to the read replica if one is registered, and to the
+// primary otherwise. Writes issued through it still go to the primary.
+func (d *DBService) reader(ctx context.Context) *gorm.DB {
+	return d.DB.WithContext(ctx).Clauses(dbresolver.Use(readReplicaResolver))
+}
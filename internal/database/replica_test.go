@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+func TestReadReplica(t *testing.T) {
+	dir := t.TempDir()
+	tenantID := "edgenode"
+	id := uuid.New()
+
+	open := func(t *testing.T, name string, version int64) *gorm.DB {
+		conn, err := gorm.Open(sqlite.Open(filepath.Join(dir, name)))
+		require.NoError(t, err)
+		require.NoError(t, conn.AutoMigrate(&models.Task{}, &models.TaskHistory{}))
+		require.NoError(t, conn.Create(&models.TaskHistory{
+			TenantID:       tenantID,
+			Type:           models.TypeAlertDefinition,
+			UUID:           id,
+			Version:        version,
+			State:          models.TaskApplied,
+			CreationDate:   time.Now(),
+			CompletionDate: time.Now(),
+		}).Error)
+		return conn
+	}
+	primary := open(t, "primary.db", 1)
+	open(t, "replica.db", 2)
+
+	require.NoError(t, useReadReplica(primary, sqlite.Open(filepath.Join(dir, "replica.db"))))
+	d := &DBService{DB: primary}
+
+	history, err := d.GetTaskHistory(context.Background(), tenantID, id, 10)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	require.Equal(t, int64(2), history[0].Version, "history should be read from the replica")
+
+	var versions []int64
+	require.NoError(t, d.DB.Model(&models.TaskHistory{}).Pluck("version", &versions).Error)
+	require.Equal(t, []int64{1}, versions, "other reads should stay on the primary")
+
+	require.NoError(t, d.reader(context.Background()).Model(&models.TaskHistory{}).Where("version = ?", 1).
+		Update("state", models.TaskInvalid).Error)
+	var state models.TaskState
+	require.NoError(t, d.DB.Model(&models.TaskHistory{}).Select("state").Where("version = ?", 1).Scan(&state).Error)
+	require.Equal(t, models.TaskInvalid, state, "writes should stay on the primary")
+}
@@ -0,0 +1,157 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+// GetAlertVolume summarizes the alert volume of a tenant between the given start, inclusive, and end, exclusive. The given
+// number of noisiest alert groups are reported. An alert is acknowledged by the first comment attached to it while it fires.
+func (d *DBService) GetAlertVolume(ctx context.Context, tenantID api.TenantID, start, end time.Time, top int) (*models.AlertVolume, error) {
+	tx := d.DB.WithContext(ctx)
+	volume := &models.AlertVolume{TopAlerts: []models.NoisyAlert{}}
+
+	var counts []struct {
+		Status models.EmailDeliveryStatus
+		Count  int64
+	}
+	if err := tx.Model(&models.EmailDelivery{}).
+		Select("status, COUNT(*) AS count").
+		Where("tenant_id = ? AND creation_date >= ? AND creation_date < ?", tenantID, start, end).
+		Group("status").
+		Scan(&counts).Error; err != nil {
+		return nil, fmt.Errorf("failed to count email deliveries of tenant %q: %w", tenantID, err)
+	}
+	for _, c := range counts {
+		switch c.Status {
+		case models.EmailDeliverySent:
+			volume.SentNotifications = c.Count
+		case models.EmailDeliveryFailed:
+			volume.FailedNotifications = c.Count
+		}
+	}
+
+	var groups []struct {
+		GroupKey      string
+		Notifications int64
+	}
+	if err := tx.Model(&models.EmailDelivery{}).
+		Select("group_key, COUNT(*) AS notifications").
+		Where("tenant_id = ? AND creation_date >= ? AND creation_date < ?", tenantID, start, end).
+		Where("status = ?", models.EmailDeliverySent).
+		Group("group_key").
+		Order("notifications DESC").Order("group_key").
+		Limit(top).
+		Scan(&groups).Error; err != nil {
+		return nil, fmt.Errorf("failed to get noisiest alerts of tenant %q: %w", tenantID, err)
+	}
+	for _, g := range groups {
+		volume.TopAlerts = append(volume.TopAlerts, models.NoisyAlert{
+			Group:         alertGroupLabels(g.GroupKey),
+			Notifications: g.Notifications,
+		})
+	}
+
+	// The comments of the alerts commented over the period are needed to find whether their first comment was added within it.
+	var comments []models.AlertComment
+	if err := tx.
+		Select("fingerprint, alert_starts_at, creation_date").
+		Where("tenant_id = ? AND alert_starts_at IS NOT NULL AND creation_date < ?", tenantID, end).
+		Where("fingerprint IN (?)", tx.Model(&models.AlertComment{}).
+			Select("fingerprint").
+			Where("tenant_id = ? AND creation_date >= ? AND creation_date < ?", tenantID, start, end)).
+		Order("creation_date").
+		Find(&comments).Error; err != nil {
+		return nil, fmt.Errorf("failed to get alert comments of tenant %q: %w", tenantID, err)
+	}
+
+	type alertInstance struct {
+		fingerprint string
+		startsAt    int64
+	}
+	acknowledged := make(map[alertInstance]bool)
+	var total time.Duration
+	for _, c := range comments {
+		instance := alertInstance{fingerprint: c.Fingerprint, startsAt: c.AlertStartsAt.UnixNano()}
+		if acknowledged[instance] {
+			continue
+		}
+		acknowledged[instance] = true
+
+		if c.CreationDate.Before(start) {
+			continue
+		}
+		volume.AcknowledgedAlerts++
+		total += max(c.CreationDate.Sub(*c.AlertStartsAt), 0)
+	}
+	if volume.AcknowledgedAlerts > 0 {
+		volume.MeanTimeToAcknowledge = total / time.Duration(volume.AcknowledgedAlerts)
+	}
+	return volume, nil
+}
+
+// alertGroupLabels returns the labels shared by the alerts of the group with the given alertmanager group key, which follows
+// the key of the route the group belongs to.
+func alertGroupLabels(groupKey string) string {
+	for i := len(groupKey) - 1; i > 0; i-- {
+		if groupKey[i] == '{' && groupKey[i-1] == ':' {
+			return groupKey[i:]
+		}
+	}
+	return groupKey
+}
+
+// HasAlertReport tells whether the report of a tenant for the period starting at the given time is already generated.
+func (d *DBService) HasAlertReport(ctx context.Context, tenantID api.TenantID, periodStart time.Time) (bool, error) {
+	var count int64
+	if err := d.DB.WithContext(ctx).Model(&models.AlertReport{}).
+		Where("tenant_id = ? AND period_start = ?", tenantID, periodStart).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check report of tenant %q: %w", tenantID, err)
+	}
+	return count > 0, nil
+}
+
+// CreateAlertReport stores the given report, setting its ID. Nothing is stored if the report of the tenant for the same period
+// is already stored, by another replica, in which case false is returned.
+func (d *DBService) CreateAlertReport(ctx context.Context, report *models.AlertReport) (bool, error) {
+	res := d.DB.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(report)
+	if res.Error != nil {
+		return false, fmt.Errorf("failed to create report of tenant %q: %w", report.TenantID, res.Error)
+	}
+	return res.RowsAffected > 0, nil
+}
+
+// GetAlertReports gets the reports of a tenant, the latest first, without their content.
+func (d *DBService) GetAlertReports(ctx context.Context, tenantID api.TenantID) ([]models.AlertReport, error) {
+	var reports []models.AlertReport
+	if err := d.DB.WithContext(ctx).
+		Select("id, tenant_id, period_start, period_end, creation_date").
+		Where("tenant_id = ?", tenantID).
+		Order("period_start DESC").
+		Find(&reports).Error; err != nil {
+		return nil, fmt.Errorf("failed to get reports of tenant %q: %w", tenantID, err)
+	}
+	return reports, nil
+}
+
+// GetAlertReport gets the report of a tenant with the given ID. An error wrapping gorm.ErrRecordNotFound is returned if the
+// tenant has no such report.
+func (d *DBService) GetAlertReport(ctx context.Context, tenantID api.TenantID, id int64) (*models.AlertReport, error) {
+	var report models.AlertReport
+	if err := d.DB.WithContext(ctx).
+		Where("tenant_id = ?", tenantID).
+		Take(&report, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get report %d of tenant %q: %w", id, tenantID, err)
+	}
+	return &report, nil
+}
@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+func TestAlertReports(t *testing.T) {
+	conn, err := gorm.Open(sqlite.Open(":memory:"))
+	require.NoError(t, err)
+	require.NoError(t, conn.AutoMigrate(&models.EmailDelivery{}, &models.AlertComment{}, &models.AlertReport{}))
+	d := &DBService{DB: conn}
+
+	tenantID := "edgenode"
+	start := time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 7)
+	id := uuid.New()
+
+	delivery := func(groupKey string, status models.EmailDeliveryStatus, date time.Time) models.EmailDelivery {
+		return models.EmailDelivery{TenantID: tenantID, ReceiverUUID: id, Recipient: "alice@example.com", GroupKey: groupKey,
+			AlertCount: 1, Status: status, Attempts: 1, CreationDate: date}
+	}
+	require.NoError(t, conn.Create([]models.EmailDelivery{
+		delivery(`{}/{severity="critical"}:{alertname="HighCPU"}`, models.EmailDeliverySent, start.Add(time.Hour)),
+		delivery(`{}/{severity="critical"}:{alertname="HighCPU"}`, models.EmailDeliverySent, start.Add(2*time.Hour)),
+		delivery(`{}/{severity="critical"}:{alertname="HighCPU"}`, models.EmailDeliverySent, start.Add(3*time.Hour)),
+		delivery(`{}:{alertname="DiskFull"}`, models.EmailDeliverySent, start.Add(time.Hour)),
+		delivery(`{}:{alertname="DiskFull"}`, models.EmailDeliveryFailed, start.Add(time.Hour)),
+		delivery(`{}:{alertname="LowMemory"}`, models.EmailDeliverySent, start.Add(4*time.Hour)),
+		// Deliveries out of the period, or of another tenant, are not counted.
+		delivery(`{}:{alertname="DiskFull"}`, models.EmailDeliverySent, start.Add(-time.Hour)),
+		delivery(`{}:{alertname="DiskFull"}`, models.EmailDeliverySent, end),
+		{TenantID: "other", ReceiverUUID: id, Recipient: "bob@example.com", GroupKey: `{}:{alertname="DiskFull"}`,
+			Status: models.EmailDeliverySent, CreationDate: start.Add(time.Hour)},
+	}).Error)
+
+	startsAt := func(d time.Duration) *time.Time {
+		t := start.Add(d)
+		return &t
+	}
+	require.NoError(t, conn.Create([]models.AlertComment{
+		// Acknowledged after 10 minutes, then commented again.
+		{TenantID: tenantID, Fingerprint: "a", Content: "ack", AlertStartsAt: startsAt(time.Hour), CreationDate: start.Add(70 * time.Minute)},
+		{TenantID: tenantID, Fingerprint: "a", Content: "fixed", AlertStartsAt: startsAt(time.Hour), CreationDate: start.Add(3 * time.Hour)},
+		// Acknowledged after 30 minutes.
+		{TenantID: tenantID, Fingerprint: "b", Content: "ack", AlertStartsAt: startsAt(2 * time.Hour), CreationDate: start.Add(150 * time.Minute)},
+		// Acknowledged before the period, commented again within it.
+		{TenantID: tenantID, Fingerprint: "c", Content: "ack", AlertStartsAt: startsAt(-2 * time.Hour), CreationDate: start.Add(-time.Hour)},
+		{TenantID: tenantID, Fingerprint: "c", Content: "still firing", AlertStartsAt: startsAt(-2 * time.Hour), CreationDate: start.Add(time.Hour)},
+		// Commented without the start of the alert.
+		{TenantID: tenantID, Fingerprint: "d", Content: "ack", CreationDate: start.Add(time.Hour)},
+	}).Error)
+
+	t.Run("Alert volume", func(t *testing.T) {
+		volume, err := d.GetAlertVolume(context.Background(), tenantID, start, end, 2)
+		require.NoError(t, err)
+		require.Equal(t, &models.AlertVolume{
+			SentNotifications:   5,
+			FailedNotifications: 1,
+			TopAlerts: []models.NoisyAlert{
+				{Group: `{alertname="HighCPU"}`, Notifications: 3},
+				{Group: `{alertname="DiskFull"}`, Notifications: 1},
+			},
+			AcknowledgedAlerts:    2,
+			MeanTimeToAcknowledge: 20 * time.Minute,
+		}, volume)
+	})
+
+	t.Run("Alert volume without notifications", func(t *testing.T) {
+		volume, err := d.GetAlertVolume(context.Background(), tenantID, end, end.AddDate(0, 0, 7), 2)
+		require.NoError(t, err)
+		require.Equal(t, &models.AlertVolume{
+			SentNotifications: 1,
+			TopAlerts:         []models.NoisyAlert{{Group: `{alertname="DiskFull"}`, Notifications: 1}},
+		}, volume)
+	})
+
+	t.Run("Report is stored once per period", func(t *testing.T) {
+		report := models.AlertReport{TenantID: tenantID, PeriodStart: start, PeriodEnd: end, Content: "{}", CreationDate: end}
+		created, err := d.CreateAlertReport(context.Background(), &report)
+		require.NoError(t, err)
+		require.True(t, created)
+
+		exists, err := d.HasAlertReport(context.Background(), tenantID, start)
+		require.NoError(t, err)
+		require.True(t, exists)
+
+		created, err = d.CreateAlertReport(context.Background(),
+			&models.AlertReport{TenantID: tenantID, PeriodStart: start, PeriodEnd: end, Content: "{}", CreationDate: end})
+		require.NoError(t, err)
+		require.False(t, created)
+
+		later := models.AlertReport{TenantID: tenantID, PeriodStart: end, PeriodEnd: end.AddDate(0, 0, 7), Content: "{}",
+			CreationDate: end.AddDate(0, 0, 7)}
+		created, err = d.CreateAlertReport(context.Background(), &later)
+		require.NoError(t, err)
+		require.True(t, created)
+
+		reports, err := d.GetAlertReports(context.Background(), tenantID)
+		require.NoError(t, err)
+		require.Len(t, reports, 2)
+		require.Equal(t, later.ID, reports[0].ID)
+		require.Equal(t, report.ID, reports[1].ID)
+		require.Empty(t, reports[0].Content)
+
+		got, err := d.GetAlertReport(context.Background(), tenantID, report.ID)
+		require.NoError(t, err)
+		require.Equal(t, "{}", got.Content)
+
+		_, err = d.GetAlertReport(context.Background(), "other", report.ID)
+		require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+		exists, err = d.HasAlertReport(context.Background(), "other", start)
+		require.NoError(t, err)
+		require.False(t, exists)
+	})
+}
@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// txMaxRetries is the number of retries of a transaction conflicting with a concurrent one, after the first attempt.
+	txMaxRetries = 4
+	// txInitialBackoff is the wait before the first retry of a transaction, doubled on every retry up to txMaxBackoff.
+	txInitialBackoff = 20 * time.Millisecond
+	txMaxBackoff     = 500 * time.Millisecond
+
+	// Postgres error codes of transactions aborted because of a concurrent transaction.
+	sqlStateSerializationFailure = "40001"
+	sqlStateDeadlockDetected     = "40P01"
+)
+
+// retryTx runs fn in a transaction, which is committed if fn succeeds and rolled back otherwise. The transaction is retried
+// when it conflicts with a concurrent one, such as concurrent updates of the same alert definition or receiver bumping its
+// version, until it succeeds, fails otherwise, or the retries are exhausted. The wait between attempts doubles up to the
+// maximum backoff, and is randomly shortened by up to half so that concurrent requests do not retry in lockstep.
+func (d *DBService) retryTx(ctx context.Context, fn func(tx *gorm.DB) error) error {
+	backoff := txInitialBackoff
+	for attempt := 0; ; attempt++ {
+		err := d.DB.WithContext(ctx).Transaction(fn)
+		if err == nil || attempt >= txMaxRetries || !isTxConflict(err) {
+			return err
+		}
+
+		wait := backoff/2 + rand.N(backoff/2+1) //nolint:gosec // Jitter does not need a secure random source.
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait):
+		}
+
+		backoff = min(backoff*2, txMaxBackoff)
+	}
+}
+
+// isTxConflict tells whether a transaction failed because of a concurrent transaction, and is thus worth retrying: Postgres
+// aborted it on a serialization failure or a deadlock, or a new version it inserted collides with the same version inserted
+// concurrently.
+func isTxConflict(err error) bool {
+	var sqlErr interface{ SQLState() string }
+	if errors.As(err, &sqlErr) {
+		switch sqlErr.SQLState() {
+		case sqlStateSerializationFailure, sqlStateDeadlockDetected:
+			return true
+		}
+	}
+	return errors.Is(err, gorm.ErrDuplicatedKey)
+}
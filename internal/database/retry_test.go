@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type sqlStateError string
+
+func (e sqlStateError) Error() string    { return "sql state " + string(e) }
+func (e sqlStateError) SQLState() string { return string(e) }
+
+func TestIsTxConflict(t *testing.T) {
+	for name, tc := range map[string]struct {
+		err      error
+		conflict bool
+	}{
+		"serialization failure": {err: fmt.Errorf("failed: %w", sqlStateError(sqlStateSerializationFailure)), conflict: true},
+		"deadlock":              {err: sqlStateError(sqlStateDeadlockDetected), conflict: true},
+		"duplicated version":    {err: fmt.Errorf("failed to create alert definition: %w", gorm.ErrDuplicatedKey), conflict: true},
+		"other sql state":       {err: sqlStateError("23502")},
+		"record not found":      {err: gorm.ErrRecordNotFound},
+	} {
+		require.Equal(t, tc.conflict, isTxConflict(tc.err), name)
+	}
+}
+
+func TestRetryTx(t *testing.T) {
+	conn, err := gorm.Open(sqlite.Open(":memory:"))
+	require.NoError(t, err)
+	d := &DBService{DB: conn}
+
+	t.Run("Conflicting transaction is retried until it succeeds", func(t *testing.T) {
+		attempts := 0
+		require.NoError(t, d.retryTx(context.Background(), func(*gorm.DB) error {
+			attempts++
+			if attempts < 3 {
+				return sqlStateError(sqlStateSerializationFailure)
+			}
+			return nil
+		}))
+		require.Equal(t, 3, attempts)
+	})
+
+	t.Run("Conflicting transaction is retried up to the limit", func(t *testing.T) {
+		attempts := 0
+		err := d.retryTx(context.Background(), func(*gorm.DB) error {
+			attempts++
+			return sqlStateError(sqlStateDeadlockDetected)
+		})
+		require.Equal(t, sqlStateError(sqlStateDeadlockDetected), err)
+		require.Equal(t, txMaxRetries+1, attempts)
+	})
+
+	t.Run("Other errors are not retried", func(t *testing.T) {
+		attempts := 0
+		err := d.retryTx(context.Background(), func(*gorm.DB) error {
+			attempts++
+			return gorm.ErrRecordNotFound
+		})
+		require.True(t, errors.Is(err, gorm.ErrRecordNotFound))
+		require.Equal(t, 1, attempts)
+	})
+
+	t.Run("Canceled context stops the retries", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		attempts := 0
+		err := d.retryTx(ctx, func(*gorm.DB) error {
+			attempts++
+			cancel()
+			return sqlStateError(sqlStateSerializationFailure)
+		})
+		require.Error(t, err)
+		require.Equal(t, 1, attempts)
+	})
+}
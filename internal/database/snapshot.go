@@ -0,0 +1,415 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+// RestoreResult holds the number of alert definitions and receivers created, updated with a new version, and left unchanged
+// by restoring a configuration snapshot.
+type RestoreResult struct {
+	Created   int
+	Updated   int
+	Unchanged int
+}
+
+// GetConfigSnapshot gets every version of the alert definitions and receivers of all tenants, along with the tenants, within a
+// single transaction so that the snapshot is consistent. Database IDs are left out, as they are meaningless once restored.
+func (d *DBService) GetConfigSnapshot(ctx context.Context) (*models.ConfigSnapshot, error) {
+	tx := d.DB.WithContext(ctx).Begin(&sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	defer tx.Rollback()
+
+	snapshot := &models.ConfigSnapshot{
+		FormatVersion: models.SnapshotFormatVersion,
+		CreatedAt:     clock.TimeNowFn().UTC(),
+	}
+
+	if err := tx.Order("tenant_id").Find(&snapshot.Tenants).Error; err != nil {
+		return nil, fmt.Errorf("failed to get tenants: %w", err)
+	}
+
+	definitions, err := getAlertDefinitionVersions(tx)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.AlertDefinitions = definitions
+
+	receivers, err := getReceiverVersions(tx)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.Receivers = receivers
+
+	return snapshot, nil
+}
+
+// getAlertDefinitionVersions gets every version of the alert definitions of all tenants along with their duration and threshold.
+func getAlertDefinitionVersions(tx *gorm.DB) ([]models.AlertDefinitionVersion, error) {
+	var definitions []models.AlertDefinition
+	if err := tx.Order("tenant_id").Order("uuid").Order("version").Find(&definitions).Error; err != nil {
+		return nil, fmt.Errorf("failed to get alert definitions: %w", err)
+	}
+
+	var durations []models.AlertDuration
+	if err := tx.Find(&durations).Error; err != nil {
+		return nil, fmt.Errorf("failed to get alert durations: %w", err)
+	}
+	durationByDefinition := make(map[int64]models.AlertDuration, len(durations))
+	for _, dur := range durations {
+		durationByDefinition[dur.AlertDefinitionID] = dur
+	}
+
+	var thresholds []models.AlertThreshold
+	if err := tx.Find(&thresholds).Error; err != nil {
+		return nil, fmt.Errorf("failed to get alert thresholds: %w", err)
+	}
+	thresholdByDefinition := make(map[int64]models.AlertThreshold, len(thresholds))
+	for _, thr := range thresholds {
+		thresholdByDefinition[thr.AlertDefinitionID] = thr
+	}
+
+	versions := make([]models.AlertDefinitionVersion, len(definitions))
+	for i, def := range definitions {
+		dur, ok := durationByDefinition[def.ID]
+		if !ok {
+			return nil, fmt.Errorf("no duration found for alert definition %q version %d of tenant %q", def.UUID, def.Version, def.TenantID)
+		}
+		thr, ok := thresholdByDefinition[def.ID]
+		if !ok {
+			return nil, fmt.Errorf("no threshold found for alert definition %q version %d of tenant %q", def.UUID, def.Version, def.TenantID)
+		}
+
+		def.ID, dur.ID, dur.AlertDefinitionID, thr.ID, thr.AlertDefinitionID = 0, 0, 0, 0, 0
+		versions[i] = models.AlertDefinitionVersion{Definition: def, Duration: dur, Threshold: thr}
+	}
+	return versions, nil
+}
+
+// getReceiverVersions gets every version of the receivers of all tenants along with their email configuration and recipients.
+func getReceiverVersions(tx *gorm.DB) ([]models.ReceiverVersion, error) {
+	var receivers []models.Receiver
+	if err := tx.Order("tenant_id").Order("uuid").Order("version").Find(&receivers).Error; err != nil {
+		return nil, fmt.Errorf("failed to get receivers: %w", err)
+	}
+
+	var configs []models.EmailConfig
+	if err := tx.Find(&configs).Error; err != nil {
+		return nil, fmt.Errorf("failed to get email configs: %w", err)
+	}
+	configByID := make(map[int64]models.EmailConfig, len(configs))
+	for _, ec := range configs {
+		configByID[ec.ID] = ec
+	}
+
+	var addresses []models.EmailAddress
+	if err := tx.Find(&addresses).Error; err != nil {
+		return nil, fmt.Errorf("failed to get email addresses: %w", err)
+	}
+	addressByID := make(map[int64]models.EmailAddress, len(addresses))
+	for _, ea := range addresses {
+		id := ea.ID
+		ea.ID = 0
+		addressByID[id] = ea
+	}
+
+	var recipients []models.EmailRecipient
+	if err := tx.Order("id").Find(&recipients).Error; err != nil {
+		return nil, fmt.Errorf("failed to get email recipients: %w", err)
+	}
+	recipientsByReceiver := make(map[int64][]models.EmailAddress)
+	for _, er := range recipients {
+		recipientsByReceiver[er.ReceiverID] = append(recipientsByReceiver[er.ReceiverID], addressByID[er.EmailAddressID])
+	}
+
+	versions := make([]models.ReceiverVersion, len(receivers))
+	for i, recv := range receivers {
+		ec, ok := configByID[recv.EmailConfigID]
+		if !ok {
+			return nil, fmt.Errorf("no email config found for receiver %q version %d of tenant %q", recv.UUID, recv.Version, recv.TenantID)
+		}
+
+		to := recipientsByReceiver[recv.ID]
+		if to == nil {
+			to = []models.EmailAddress{}
+		}
+
+		recv.ID, recv.EmailConfigID = 0, 0
+		versions[i] = models.ReceiverVersion{
+			Receiver:   recv,
+			MailServer: ec.MailServer,
+			From:       addressByID[ec.From],
+			Recipients: to,
+		}
+	}
+	return versions, nil
+}
+
+// RestoreConfigSnapshot replays a configuration snapshot so that the latest version of every alert definition and receiver it
+// holds is the latest version in the database, keeping versioning consistent:
+//   - alert definitions and receivers missing from the database are created with every version of the snapshot, and the latest
+//     one is queued to be applied;
+//   - those whose latest version differs from the one of the snapshot get a new version with the values of the snapshot, which
+//     is queued to be applied;
+//   - those whose latest version matches the one of the snapshot are left unchanged.
+//
+// Existing versions are never rewritten, and tenants already present keep their archival state and alertmanager shard.
+func (d *DBService) RestoreConfigSnapshot(ctx context.Context, snapshot *models.ConfigSnapshot) (RestoreResult, error) {
+	var res RestoreResult
+	if snapshot.FormatVersion != models.SnapshotFormatVersion {
+		return res, fmt.Errorf("unsupported snapshot format version: %d", snapshot.FormatVersion)
+	}
+
+	tx := d.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if len(snapshot.Tenants) != 0 {
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&snapshot.Tenants).Error; err != nil {
+			return res, fmt.Errorf("failed to restore tenants: %w", err)
+		}
+	}
+
+	for _, versions := range groupVersions(snapshot.AlertDefinitions, func(v models.AlertDefinitionVersion) (string, uuid.UUID, int64) {
+		return v.Definition.TenantID, v.Definition.UUID, v.Definition.Version
+	}) {
+		if err := restoreAlertDefinition(tx, versions, &res); err != nil {
+			return RestoreResult{}, err
+		}
+	}
+
+	for _, versions := range groupVersions(snapshot.Receivers, func(v models.ReceiverVersion) (string, uuid.UUID, int64) {
+		return v.Receiver.TenantID, v.Receiver.UUID, v.Receiver.Version
+	}) {
+		if err := restoreReceiver(tx, versions, &res); err != nil {
+			return RestoreResult{}, err
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return RestoreResult{}, err
+	}
+	return res, nil
+}
+
+// groupVersions groups the versions of alert definitions or receivers by tenant and UUID, in ascending order of version.
+func groupVersions[T any](versions []T, key func(T) (string, uuid.UUID, int64)) [][]T {
+	type owner struct {
+		tenantID string
+		id       uuid.UUID
+	}
+
+	var owners []owner
+	groups := make(map[owner][]T)
+	for _, v := range versions {
+		tenantID, id, _ := key(v)
+		o := owner{tenantID: tenantID, id: id}
+		if _, ok := groups[o]; !ok {
+			owners = append(owners, o)
+		}
+		groups[o] = append(groups[o], v)
+	}
+
+	res := make([][]T, len(owners))
+	for i, o := range owners {
+		res[i] = groups[o]
+		slices.SortFunc(res[i], func(a, b T) int {
+			_, _, va := key(a)
+			_, _, vb := key(b)
+			return cmp.Compare(va, vb)
+		})
+	}
+	return res
+}
+
+// restoreAlertDefinition restores the given versions of an alert definition, in ascending order of version.
+func restoreAlertDefinition(tx *gorm.DB, versions []models.AlertDefinitionVersion, res *RestoreResult) error {
+	latest := versions[len(versions)-1]
+	tenantID, id := latest.Definition.TenantID, latest.Definition.UUID
+
+	var current models.AlertDefinition
+	err := tx.Where("tenant_id = ?", tenantID).Where("uuid = ?", id).Order("version desc").First(&current).Error
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		for _, v := range versions[:len(versions)-1] {
+			if err := createRestoredAlertDefinition(tx, v, v.Definition.Version, v.Definition.State); err != nil {
+				return err
+			}
+		}
+		if err := createRestoredAlertDefinition(tx, latest, latest.Definition.Version, models.DefinitionNew); err != nil {
+			return err
+		}
+		res.Created++
+		return requeueTask(tx, models.Task{AlertDefinitionUUID: &id, TenantID: tenantID, Version: latest.Definition.Version})
+	case err != nil:
+		return fmt.Errorf("failed to retrieve latest version of alert definition %q for tenant %q: %w", id, tenantID, err)
+	}
+
+	var dur models.AlertDuration
+	if err := tx.Where("alert_definition_id = ?", current.ID).Take(&dur).Error; err != nil {
+		return fmt.Errorf("failed to retrieve duration for alert definition ID %v: %w", current.ID, err)
+	}
+	var thr models.AlertThreshold
+	if err := tx.Where("alert_definition_id = ?", current.ID).Take(&thr).Error; err != nil {
+		return fmt.Errorf("failed to retrieve threshold for alert definition ID %v: %w", current.ID, err)
+	}
+
+	if current.Template == latest.Definition.Template &&
+		current.Enabled == latest.Definition.Enabled &&
+		current.AutoTune == latest.Definition.AutoTune &&
+		dur.Duration == latest.Duration.Duration &&
+		thr.Threshold == latest.Threshold.Threshold {
+		res.Unchanged++
+		return nil
+	}
+
+	version := current.Version + 1
+	if err := createRestoredAlertDefinition(tx, latest, version, models.DefinitionModified); err != nil {
+		return err
+	}
+	res.Updated++
+	return requeueTask(tx, models.Task{AlertDefinitionUUID: &id, TenantID: tenantID, Version: version})
+}
+
+// createRestoredAlertDefinition creates the given version of an alert definition, along with its duration and threshold, with
+// the given version number and state. Versions which are not applied yet are created without applied date.
+func createRestoredAlertDefinition(tx *gorm.DB, v models.AlertDefinitionVersion, version int64, state models.AlertDefinitionState) error {
+	def := v.Definition
+	def.ID = 0
+	def.Version = version
+	def.State = state
+	if state == models.DefinitionNew || state == models.DefinitionModified {
+		def.AppliedDate = nil
+	}
+	if version != v.Definition.Version {
+		def.CreationDate = clock.TimeNowFn().UTC()
+	}
+	if err := tx.Create(&def).Error; err != nil {
+		return fmt.Errorf("failed to restore alert definition %q version %d for tenant %q: %w", def.UUID, def.Version, def.TenantID, err)
+	}
+
+	dur := v.Duration
+	dur.ID = 0
+	dur.AlertDefinitionID = def.ID
+	if err := tx.Create(&dur).Error; err != nil {
+		return fmt.Errorf("failed to restore duration of alert definition %q version %d: %w", def.UUID, def.Version, err)
+	}
+
+	thr := v.Threshold
+	thr.ID = 0
+	thr.AlertDefinitionID = def.ID
+	if err := tx.Create(&thr).Error; err != nil {
+		return fmt.Errorf("failed to restore threshold of alert definition %q version %d: %w", def.UUID, def.Version, err)
+	}
+	return nil
+}
+
+// restoreReceiver restores the given versions of a receiver, in ascending order of version.
+func restoreReceiver(tx *gorm.DB, versions []models.ReceiverVersion, res *RestoreResult) error {
+	latest := versions[len(versions)-1]
+	tenantID, id := latest.Receiver.TenantID, latest.Receiver.UUID
+
+	var current models.Receiver
+	err := tx.Where("tenant_id = ?", tenantID).Where("uuid = ?", id).Order("version desc").First(&current).Error
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		for _, v := range versions[:len(versions)-1] {
+			if err := createRestoredReceiver(tx, v, v.Receiver.Version, v.Receiver.State); err != nil {
+				return err
+			}
+		}
+		if err := createRestoredReceiver(tx, latest, latest.Receiver.Version, models.ReceiverNew); err != nil {
+			return err
+		}
+		res.Created++
+		return requeueTask(tx, models.Task{ReceiverUUID: &id, TenantID: tenantID, Version: latest.Receiver.Version})
+	case err != nil:
+		return fmt.Errorf("failed to retrieve latest version of receiver %q for tenant %q: %w", id, tenantID, err)
+	}
+
+	recv, err := getReceiverWithEmailConfig(tx, current)
+	if err != nil {
+		return err
+	}
+
+	to := make([]string, len(latest.Recipients))
+	for i, r := range latest.Recipients {
+		to[i] = r.String()
+	}
+	slices.Sort(to)
+	currentTo := slices.Sorted(slices.Values(recv.To))
+
+	if recv.MailServer == latest.MailServer &&
+		recv.From == latest.From.String() &&
+		slices.Equal(currentTo, to) &&
+		current.MinSeverity == latest.Receiver.MinSeverity &&
+		current.QuietHours == latest.Receiver.QuietHours &&
+		current.OnCallRoutingKey == latest.Receiver.OnCallRoutingKey &&
+		current.Language == latest.Receiver.Language &&
+		current.Disabled == latest.Receiver.Disabled &&
+		current.Matchers == latest.Receiver.Matchers {
+		res.Unchanged++
+		return nil
+	}
+
+	version := current.Version + 1
+	if err := createRestoredReceiver(tx, latest, version, models.ReceiverModified); err != nil {
+		return err
+	}
+	res.Updated++
+	return requeueTask(tx, models.Task{ReceiverUUID: &id, TenantID: tenantID, Version: version})
+}
+
+// createRestoredReceiver creates the given version of a receiver, along with its email configuration and recipients, with the
+// given version number and state. Versions which are not applied yet are created without applied date.
+func createRestoredReceiver(tx *gorm.DB, v models.ReceiverVersion, version int64, state models.ReceiverState) error {
+	from := v.From
+	from.ID = 0
+	if err := tx.Where(models.EmailAddress{Email: from.Email}).FirstOrCreate(&from).Error; err != nil {
+		return fmt.Errorf("failed to restore sender email address of receiver %q: %w", v.Receiver.UUID, err)
+	}
+
+	ec := models.EmailConfig{MailServer: v.MailServer, From: from.ID}
+	if err := tx.Where(&ec).FirstOrCreate(&ec).Error; err != nil {
+		return fmt.Errorf("failed to restore email config of receiver %q: %w", v.Receiver.UUID, err)
+	}
+
+	recv := v.Receiver
+	recv.ID = 0
+	recv.EmailConfigID = ec.ID
+	recv.Version = version
+	recv.State = state
+	if state == models.ReceiverNew || state == models.ReceiverModified {
+		recv.AppliedDate = nil
+	}
+	if version != v.Receiver.Version {
+		recv.CreationDate = clock.TimeNowFn().UTC()
+	}
+	if err := tx.Create(&recv).Error; err != nil {
+		return fmt.Errorf("failed to restore receiver %q version %d for tenant %q: %w", recv.UUID, recv.Version, recv.TenantID, err)
+	}
+
+	for _, r := range v.Recipients {
+		recipient := r
+		recipient.ID = 0
+		if err := tx.Where(models.EmailAddress{Email: recipient.Email}).FirstOrCreate(&recipient).Error; err != nil {
+			return fmt.Errorf("failed to restore recipient of receiver %q: %w", recv.UUID, err)
+		}
+		if err := tx.Create(&models.EmailRecipient{ReceiverID: recv.ID, EmailAddressID: recipient.ID}).Error; err != nil {
+			return fmt.Errorf("failed to restore recipient of receiver %q: %w", recv.UUID, err)
+		}
+	}
+	return nil
+}
@@ -7,18 +7,24 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
 	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
 	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
 )
 
+// taskHistoryBatchSize is the number of tasks archived into the task history and deleted at once, unless configured otherwise.
+const taskHistoryBatchSize = 500
+
 // SetTakenTasksExceedingDurationAsFailed looks for tasks which have Taken state and the time lapsed between the current time and the start time
 // exceeds the given duration. If any are found, it sets them as failed which depends on the retry count. If the retry count of the task does not
-// exceed the given retry limit, the task is set to Error state, otherwise it is set to Invalid state.
+// exceed the given retry limit, the task is set to Error state, otherwise it is set to Invalid state. The timeout is recorded as the error of the tasks.
 func (d *DBService) SetTakenTasksExceedingDurationAsFailed(ctx context.Context, dur time.Duration, retryLimit int) error {
 	tx := d.DB.WithContext(ctx).Begin()
 	defer tx.Rollback()
@@ -34,7 +40,7 @@ func (d *DBService) SetTakenTasksExceedingDurationAsFailed(ctx context.Context,
 	}
 
 	for _, task := range tasks {
-		if err := setTaskAsFailed(tx, task, retryLimit); err != nil {
+		if err := d.setTaskAsFailed(tx, task, retryLimit, fmt.Sprintf("task exceeded timeout of %v", dur)); err != nil {
 			return fmt.Errorf("failed to set task as failed: %w", err)
 		}
 	}
@@ -44,58 +50,199 @@ func (d *DBService) SetTakenTasksExceedingDurationAsFailed(ctx context.Context,
 
 // DeleteNotPendingTasksExceedingDuration takes a duration and deletes tasks with Applied and Invalid state
 // for which the time elapsed between the completion date and the current date exceeds the given duration.
-func (d *DBService) DeleteNotPendingTasksExceedingDuration(ctx context.Context, dur time.Duration) error {
+// The summary of the deleted tasks is archived into the task history. Tasks are deleted in batches of the given size, each
+// in its own transaction and followed by the given pause, so that the tasks table is not locked for long on big installs.
+// The Invalid tasks of a batch are given to the archive function of the options, if set, before being deleted, and the
+// batch is not deleted if they cannot be archived. The number of deleted tasks is returned, including those of the batches
+// deleted before an error.
+func (d *DBService) DeleteNotPendingTasksExceedingDuration(ctx context.Context, dur time.Duration, opts TaskCleanupOptions) (int64, error) {
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = taskHistoryBatchSize
+	}
+	timeDelta := clock.TimeNowFn().Add(-dur)
+
+	var deleted int64
+	for {
+		n, err := d.deleteNotPendingTasksBatch(ctx, timeDelta, batchSize, opts.ArchiveInvalid)
+		deleted += n
+		if err != nil || n < int64(batchSize) {
+			return deleted, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return deleted, ctx.Err()
+		case <-time.After(opts.Pause):
+		}
+	}
+}
+
+// deleteNotPendingTasksBatch archives into the task history and deletes up to the given number of tasks with Applied and
+// Invalid state completed before the given time, oldest first, and returns the number of deleted tasks. The Invalid tasks
+// are given to the archive function first, if not nil.
+func (d *DBService) deleteNotPendingTasksBatch(ctx context.Context, before time.Time, batchSize int,
+	archiveInvalid func(context.Context, []models.Task) error) (int64, error) {
 	tx := d.DB.WithContext(ctx).Begin()
 	defer tx.Rollback()
 
-	timeDelta := clock.TimeNowFn().Add(-dur)
+	var tasks []models.Task
 	if err := tx.
 		Where("state IN (?,?)", models.TaskApplied, models.TaskInvalid).
-		Where("completion_date < ?", timeDelta).
-		Delete(&models.Task{}).Error; err != nil {
-		return err
+		Where("completion_date < ?", before).
+		Order("id").
+		Limit(batchSize).
+		Find(&tasks).Error; err != nil {
+		return 0, err
+	}
+	if len(tasks) == 0 {
+		return 0, nil
 	}
 
-	return tx.Commit().Error
+	if archiveInvalid != nil {
+		invalid := slices.DeleteFunc(slices.Clone(tasks), func(task models.Task) bool {
+			return task.State != models.TaskInvalid
+		})
+		if len(invalid) > 0 {
+			if err := archiveInvalid(ctx, invalid); err != nil {
+				return 0, fmt.Errorf("failed to archive invalid tasks: %w", err)
+			}
+		}
+	}
+
+	history := make([]models.TaskHistory, 0, len(tasks))
+	ids := make([]int64, 0, len(tasks))
+	for _, task := range tasks {
+		history = append(history, models.NewTaskHistory(task))
+		ids = append(ids, task.ID)
+	}
+
+	if err := tx.Create(&history).Error; err != nil {
+		return 0, fmt.Errorf("failed to archive task history: %w", err)
+	}
+	if err := tx.Where("id IN ?", ids).Delete(&models.Task{}).Error; err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return 0, err
+	}
+	return int64(len(tasks)), nil
+}
+
+// GetTaskHistory gets the summary of the latest completed tasks of an alert definition or receiver given its UUID, latest
+// first. Both the tasks archived into the task history and the completed tasks not yet deleted are included. It is read
+// from the read replica, if any.
+func (d *DBService) GetTaskHistory(ctx context.Context, tenantID api.TenantID, id uuid.UUID, limit int) ([]models.TaskHistory, error) {
+	var archived []models.TaskHistory
+	if err := d.reader(ctx).
+		Where("tenant_id = ? AND uuid = ?", tenantID, id).
+		Order("completion_date desc").
+		Limit(limit).
+		Find(&archived).Error; err != nil {
+		return nil, fmt.Errorf("failed to get task history of %q for tenant %q: %w", id, tenantID, err)
+	}
+
+	var tasks []models.Task
+	if err := d.reader(ctx).
+		Where("(alert_definition_uuid = ? OR receiver_uuid = ?)", id, id).
+		Where("tenant_id = ?", tenantID).
+		Where("state IN (?,?)", models.TaskApplied, models.TaskInvalid).
+		Order("completion_date desc").
+		Limit(limit).
+		Find(&tasks).Error; err != nil {
+		return nil, fmt.Errorf("failed to get completed tasks of %q for tenant %q: %w", id, tenantID, err)
+	}
+
+	history := make([]models.TaskHistory, 0, len(tasks)+len(archived))
+	for _, task := range tasks {
+		history = append(history, models.NewTaskHistory(task))
+	}
+	history = append(history, archived...)
+
+	slices.SortStableFunc(history, func(a, b models.TaskHistory) int {
+		return b.CompletionDate.Compare(a.CompletionDate)
+	})
+	if len(history) > limit {
+		history = history[:limit]
+	}
+	return history, nil
+}
+
+// paused tells whether the tasks of the given kind are paused.
+func (o PendingTaskOptions) paused(kind models.TaskType) bool {
+	return slices.Contains(o.PausedKinds, kind)
 }
 
 // GetTaskUUIDTenantIDPairs is a helper function that returns a slice of unique pairs of tasks UUIDs and tenants of tasks which are in pending state,
-// either New or Error. If a task is in Taken state, its UUID is not included in the result. The slice has a maximum
-// length of countLimit elements, and the UUIDs are ordered based on task ID in the tasks table of the database connection.
-func GetTaskUUIDTenantIDPairs(tx *gorm.DB, countLimit int) ([]models.TaskUUIDTenantID, error) {
+// either New or Error. If a task is in Taken state, or belongs to an archived tenant, its UUID is not included in the result. The slice has a maximum
+// length of CountLimit elements of the options. Tasks of the kinds paused by the options are left pending, their UUIDs not being included.
+//
+// The UUIDs are taken round-robin across tenants, so that a tenant with many pending tasks, such as one doing a bulk import, does not starve the
+// other tenants: the oldest pending UUID of every tenant comes first, then the second oldest of every tenant, and so on, UUIDs being ordered based
+// on the ID of their oldest pending task within each round. At most TenantLimit UUIDs of a tenant are returned, unless TenantLimit is zero.
+func GetTaskUUIDTenantIDPairs(tx *gorm.DB, opts PendingTaskOptions) ([]models.TaskUUIDTenantID, error) {
 	var uuids []models.TaskUUIDTenantID
 
+	tenantLimit := opts.TenantLimit
+	if tenantLimit <= 0 {
+		tenantLimit = opts.CountLimit
+	}
+
 	txx := tx.Raw(`
-		SELECT DISTINCT
+		SELECT
 			uuid, tenant_id
 		FROM
 			(
 				SELECT
-					id, alert_definition_uuid AS uuid, tenant_id
-				FROM
-					tasks
-				WHERE
-					alert_definition_uuid IS NOT NULL AND state IN ('New','Error')
-				UNION ALL
-				SELECT
-					id, receiver_uuid AS uuid, tenant_id
+					uuid, tenant_id, first_id,
+					ROW_NUMBER() OVER (PARTITION BY tenant_id ORDER BY first_id) AS tenant_rank
 				FROM
-					tasks
-				WHERE
-					receiver_uuid IS NOT NULL AND state IN ('New','Error')
-				ORDER BY id
-			)
-		AS uuids
-		WHERE NOT EXISTS
-			(
-				SELECT 1
-				FROM
-					tasks t
-				WHERE
-					(t.alert_definition_uuid = uuids.uuid OR t.receiver_uuid = uuids.uuid) AND t.state = 'Taken'
+					(
+						SELECT
+							uuid, tenant_id, MIN(id) AS first_id
+						FROM
+							(
+								SELECT
+									id, alert_definition_uuid AS uuid, tenant_id
+								FROM
+									tasks
+								WHERE
+									alert_definition_uuid IS NOT NULL AND state IN ('New','Error') AND NOT ?
+								UNION ALL
+								SELECT
+									id, receiver_uuid AS uuid, tenant_id
+								FROM
+									tasks
+								WHERE
+									receiver_uuid IS NOT NULL AND state IN ('New','Error') AND NOT ?
+							)
+						AS uuids
+						WHERE NOT EXISTS
+							(
+								SELECT 1
+								FROM
+									tasks t
+								WHERE
+									(t.alert_definition_uuid = uuids.uuid OR t.receiver_uuid = uuids.uuid) AND t.state = 'Taken'
+							)
+						AND NOT EXISTS
+							(
+								SELECT 1
+								FROM
+									tenants a
+								WHERE
+									a.tenant_id = uuids.tenant_id AND a.archived_date IS NOT NULL
+							)
+						GROUP BY uuid, tenant_id
+					)
+				AS pending
 			)
+		AS ranked
+		WHERE tenant_rank <= ?
+		ORDER BY tenant_rank, first_id
 		LIMIT ?;
-	`, countLimit).Scan(&uuids)
+	`, opts.paused(models.TypeAlertDefinition), opts.paused(models.TypeReceiver), tenantLimit, opts.CountLimit).Scan(&uuids)
 
 	if err := txx.Error; err != nil {
 		return nil, err
@@ -104,30 +251,48 @@ func GetTaskUUIDTenantIDPairs(tx *gorm.DB, countLimit int) ([]models.TaskUUIDTen
 	return uuids, nil
 }
 
-// GetPendingTasks takes an owner UUID and a count. It returns a slice of tasks from database which have not been completed,
-// and are not currently in Taken state. The slice has tasks with unique UUID and latest version. The state, start_date, and
+// GetPendingTasks takes an owner UUID and the options of the tasks to take. It returns a slice of tasks from database which have
+// not been completed, and are not currently in Taken state. The slice has tasks with unique UUID and latest version, taken
+// round-robin across tenants as given by the options, and leaves out the tasks of paused kinds. The state, start_date, and
 // owner_uuid columns of the returned tasks are also updated within the database.
-func (d *DBService) GetPendingTasks(ctx context.Context, ownerUUID uuid.UUID, count int) ([]models.Task, error) {
+//
+// On Postgres, each UUID is locked with a transaction-level advisory lock and its pending tasks with SELECT ... FOR UPDATE
+// SKIP LOCKED, so that executor replicas taking tasks concurrently take disjoint tasks: UUIDs locked by another replica, or
+// which it has already taken, are skipped instead of being taken twice.
+func (d *DBService) GetPendingTasks(ctx context.Context, ownerUUID uuid.UUID, opts PendingTaskOptions) ([]models.Task, error) {
 	tx := d.DB.WithContext(ctx).Begin()
 	defer tx.Rollback()
 
-	taskUUIDTenantIDPairs, err := GetTaskUUIDTenantIDPairs(tx, count)
+	taskUUIDTenantIDPairs, err := GetTaskUUIDTenantIDPairs(tx, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	tasks := make([]models.Task, 0, count)
+	skipLocked := supportsSkipLocked(tx)
+
+	tasks := make([]models.Task, 0, len(taskUUIDTenantIDPairs))
 	for _, pair := range taskUUIDTenantIDPairs {
-		// Get latest version of a task by UUID.
 		var task models.Task
-		err := tx.
-			Where("(alert_definition_uuid = ? OR receiver_uuid = ?)", pair.UUID, pair.UUID).
-			Where("tenant_id = ?", pair.TenantID).
-			Where("state IN (?,?)", models.TaskNew, models.TaskError).
-			Order("version desc").
-			First(&task).Error
-		if err != nil {
-			return nil, err
+		if skipLocked {
+			locked, ok, err := lockLatestPendingTask(tx, pair)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+			task = locked
+		} else {
+			// Get latest version of a task by UUID.
+			err := tx.
+				Where("(alert_definition_uuid = ? OR receiver_uuid = ?)", pair.UUID, pair.UUID).
+				Where("tenant_id = ?", pair.TenantID).
+				Where("state IN (?,?)", models.TaskNew, models.TaskError).
+				Order("version desc").
+				First(&task).Error
+			if err != nil {
+				return nil, err
+			}
 		}
 
 		// Set values of task to taken.
@@ -150,6 +315,49 @@ func (d *DBService) GetPendingTasks(ctx context.Context, ownerUUID uuid.UUID, co
 	return tasks, nil
 }
 
+// supportsSkipLocked reports whether the database of the connection supports row-level locking with SKIP LOCKED.
+func supportsSkipLocked(tx *gorm.DB) bool {
+	return tx.Dialector.Name() == "postgres"
+}
+
+// lockLatestPendingTask locks the given UUID and tenant, and every pending task of it, and returns the latest version among
+// them. False is returned if there is no task to take, either because the UUID is locked by another transaction, or because
+// a task of the UUID was taken since the pending UUIDs were listed.
+//
+// Row locks alone do not serialize transactions taking the same UUID: a version added after another transaction locked the
+// pending tasks is not locked, and the task it takes is not seen as Taken until it commits. The UUID is therefore locked
+// first with an advisory lock, released as the transaction ends, which another transaction does not wait for but skips.
+func lockLatestPendingTask(tx *gorm.DB, pair models.TaskUUIDTenantID) (models.Task, bool, error) {
+	var locked bool
+	err := tx.Raw("SELECT pg_try_advisory_xact_lock(hashtext(?), hashtext(?))", pair.TenantID, pair.UUID.String()).Scan(&locked).Error
+	if err != nil {
+		return models.Task{}, false, fmt.Errorf("failed to lock %q for tenant %q: %w", pair.UUID, pair.TenantID, err)
+	}
+	if !locked {
+		return models.Task{}, false, nil
+	}
+
+	var tasks []models.Task
+	err = tx.
+		Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate, Options: clause.LockingOptionsSkipLocked}).
+		Where("(alert_definition_uuid = ? OR receiver_uuid = ?)", pair.UUID, pair.UUID).
+		Where("tenant_id = ?", pair.TenantID).
+		Where("state IN (?,?)", models.TaskNew, models.TaskError).
+		Where(`NOT EXISTS (
+			SELECT 1 FROM tasks t
+			WHERE (t.alert_definition_uuid = ? OR t.receiver_uuid = ?) AND t.tenant_id = ? AND t.state = ?
+		)`, pair.UUID, pair.UUID, pair.TenantID, models.TaskTaken).
+		Order("version desc").
+		Find(&tasks).Error
+	if err != nil {
+		return models.Task{}, false, fmt.Errorf("failed to lock pending tasks of %q for tenant %q: %w", pair.UUID, pair.TenantID, err)
+	}
+	if len(tasks) == 0 {
+		return models.Task{}, false, nil
+	}
+	return tasks[0], true, nil
+}
+
 // SetOlderVersionsToInvalidState takes a slice of tasks, and sets tasks from database with same UUID and older versions as invalid.
 func (d *DBService) SetOlderVersionsToInvalidState(ctx context.Context, tasks []models.Task) error {
 	tx := d.DB.WithContext(ctx).Begin()
@@ -180,9 +388,10 @@ func (d *DBService) SetTaskAsApplied(ctx context.Context, task models.Task) erro
 	tx := d.DB.WithContext(ctx).Begin()
 	defer tx.Rollback()
 
+	completionDate := clock.TimeNowFn()
 	if err := tx.Model(&task).Updates(models.Task{
 		State:          models.TaskApplied,
-		CompletionDate: clock.TimeNowFn(),
+		CompletionDate: completionDate,
 	}).Error; err != nil {
 		return fmt.Errorf("failed to set task %q with version %d for tenant %q as Applied: %w",
 			task.GetTaskUUID(), task.Version, task.TenantID, err)
@@ -190,12 +399,18 @@ func (d *DBService) SetTaskAsApplied(ctx context.Context, task models.Task) erro
 
 	switch task.GetTaskType() {
 	case models.TypeAlertDefinition:
-		if err := setAlertDefinitionState(tx, task.TenantID, *task.AlertDefinitionUUID, task.Version, models.DefinitionApplied); err != nil {
+		if err := d.enqueueHookEvent(tx, task, models.EventDefinitionApplied, ""); err != nil {
+			return err
+		}
+		if err := setAlertDefinitionApplied(tx, task.TenantID, *task.AlertDefinitionUUID, task.Version, completionDate); err != nil {
 			return fmt.Errorf("failed to set alert definition %q with version %v for tenant %q to state 'Applied': %w",
 				task.AlertDefinitionUUID.String(), task.Version, task.TenantID, err)
 		}
 	case models.TypeReceiver:
-		if err := setReceiverState(tx, task.TenantID, *task.ReceiverUUID, task.Version, models.ReceiverApplied); err != nil {
+		if err := d.enqueueHookEvent(tx, task, models.EventReceiverApplied, ""); err != nil {
+			return err
+		}
+		if err := setReceiverApplied(tx, task.TenantID, *task.ReceiverUUID, task.Version, completionDate); err != nil {
 			return fmt.Errorf("failed to set receiver %q with version %v for tenant %q to state 'Applied': %w",
 				task.ReceiverUUID.String(), task.Version, task.TenantID, err)
 		}
@@ -204,24 +419,26 @@ func (d *DBService) SetTaskAsApplied(ctx context.Context, task models.Task) erro
 	return tx.Commit().Error
 }
 
-// SetTaskAsFailed takes a task and a retry limit. If the task retry count is less than the retry limit it sets the task
-// to Error state, otherwise it sets the task to Invalid state.
-func (d *DBService) SetTaskAsFailed(ctx context.Context, task models.Task, retryLimit int) error {
+// SetTaskAsFailed takes a task, a retry limit and the error the task failed with. If the task retry count is less than
+// the retry limit it sets the task to Error state, otherwise it sets the task to Invalid state. The error is recorded as
+// the error of the task.
+func (d *DBService) SetTaskAsFailed(ctx context.Context, task models.Task, retryLimit int, cause error) error {
 	tx := d.DB.WithContext(ctx).Begin()
 	defer tx.Rollback()
 
-	if err := setTaskAsFailed(tx, task, retryLimit); err != nil {
+	if err := d.setTaskAsFailed(tx, task, retryLimit, cause.Error()); err != nil {
 		return err
 	}
 
 	return tx.Commit().Error
 }
 
-func setTaskAsFailed(tx *gorm.DB, task models.Task, retryLimit int) error {
+func (d *DBService) setTaskAsFailed(tx *gorm.DB, task models.Task, retryLimit int, cause string) error {
 	if task.RetryCount < int64(retryLimit) {
 		if err := tx.Model(&task).Updates(models.Task{
 			State:      models.TaskError,
 			RetryCount: task.RetryCount + 1,
+			Error:      cause,
 		}).Error; err != nil {
 			return fmt.Errorf("failed to set task %q with version %d for tenant %q as Error",
 				task.GetTaskUUID(), task.Version, task.TenantID)
@@ -229,6 +446,7 @@ func setTaskAsFailed(tx *gorm.DB, task models.Task, retryLimit int) error {
 	} else if err := tx.Model(&task).Updates(models.Task{
 		State:          models.TaskInvalid,
 		CompletionDate: clock.TimeNowFn(),
+		Error:          cause,
 	}).Error; err != nil {
 		return fmt.Errorf("failed to set task %q with version %d for tenant %q as Invalid: %w",
 			task.GetTaskUUID(), task.Version, task.TenantID, err)
@@ -236,11 +454,17 @@ func setTaskAsFailed(tx *gorm.DB, task models.Task, retryLimit int) error {
 
 	switch task.GetTaskType() {
 	case models.TypeAlertDefinition:
+		if err := d.enqueueHookEvent(tx, task, models.EventDefinitionError, cause); err != nil {
+			return err
+		}
 		if err := setAlertDefinitionState(tx, task.TenantID, *task.AlertDefinitionUUID, task.Version, models.DefinitionError); err != nil {
 			return fmt.Errorf("failed to set alert definition %q with version %v for tenant %q to state 'Error': %w",
 				task.AlertDefinitionUUID.String(), task.Version, task.TenantID, err)
 		}
 	case models.TypeReceiver:
+		if err := d.enqueueHookEvent(tx, task, models.EventReceiverError, cause); err != nil {
+			return err
+		}
 		if err := setReceiverState(tx, task.TenantID, *task.ReceiverUUID, task.Version, models.ReceiverError); err != nil {
 			return fmt.Errorf("failed to set receiver %q with version %v for tenant %q to state 'Error': %w",
 				task.ReceiverUUID.String(), task.Version, task.TenantID, err)
@@ -266,11 +490,17 @@ func (d *DBService) SetTaskAsInvalid(ctx context.Context, task models.Task) erro
 
 	switch task.GetTaskType() {
 	case models.TypeAlertDefinition:
+		if err := d.enqueueHookEvent(tx, task, models.EventDefinitionError, task.Error); err != nil {
+			return err
+		}
 		if err := setAlertDefinitionState(tx, task.TenantID, *task.AlertDefinitionUUID, task.Version, models.DefinitionError); err != nil {
 			return fmt.Errorf("failed to set alert definition %q with version %v for tenant %q to state 'Error': %w",
 				task.AlertDefinitionUUID.String(), task.Version, task.TenantID, err)
 		}
 	case models.TypeReceiver:
+		if err := d.enqueueHookEvent(tx, task, models.EventReceiverError, task.Error); err != nil {
+			return err
+		}
 		if err := setReceiverState(tx, task.TenantID, *task.ReceiverUUID, task.Version, models.ReceiverError); err != nil {
 			return fmt.Errorf("failed to set receiver %q with version %v for tenant %q to state 'Error': %w",
 				task.ReceiverUUID.String(), task.Version, task.TenantID, err)
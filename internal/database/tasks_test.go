@@ -0,0 +1,212 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"context"
+	"flag"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+// Postgres tests are skipped unless -postgres is given, e.g. go test ./internal/database -run Postgres -postgres=<DSN>.
+var postgresDSN = flag.String("postgres", "", "DSN of an empty PostgreSQL database to run the Postgres tests against")
+
+func TestGetPendingTasksFairness(t *testing.T) {
+	open := func(t *testing.T) *DBService {
+		conn, err := gorm.Open(sqlite.Open(":memory:"))
+		require.NoError(t, err)
+		require.NoError(t, conn.AutoMigrate(&models.Tenant{}, &models.Task{}))
+
+		// A bulk import of tenant "bulk", followed by a receiver change of tenant "urgent".
+		id := int64(1)
+		for range 5 {
+			definitionUUID := uuid.New()
+			require.NoError(t, conn.Create(&models.Task{
+				ID:                  id,
+				AlertDefinitionUUID: &definitionUUID,
+				TenantID:            "bulk",
+				State:               models.TaskNew,
+			}).Error)
+			id++
+		}
+		receiverUUID := uuid.New()
+		require.NoError(t, conn.Create(&models.Task{
+			ID:           id,
+			ReceiverUUID: &receiverUUID,
+			TenantID:     "urgent",
+			State:        models.TaskNew,
+		}).Error)
+		return &DBService{DB: conn}
+	}
+
+	tenants := func(tasks []models.Task) []string {
+		ids := make([]string, 0, len(tasks))
+		for _, task := range tasks {
+			ids = append(ids, task.TenantID)
+		}
+		return ids
+	}
+
+	t.Run("Tenants are taken round-robin", func(t *testing.T) {
+		d := open(t)
+		tasks, err := d.GetPendingTasks(context.Background(), uuid.New(), PendingTaskOptions{CountLimit: 3})
+		require.NoError(t, err)
+		require.Equal(t, []string{"bulk", "urgent", "bulk"}, tenants(tasks))
+		require.Equal(t, int64(1), tasks[0].ID, "oldest task of a tenant should be taken first")
+	})
+
+	t.Run("Tasks of a tenant are limited", func(t *testing.T) {
+		d := open(t)
+		tasks, err := d.GetPendingTasks(context.Background(), uuid.New(), PendingTaskOptions{CountLimit: 10, TenantLimit: 2})
+		require.NoError(t, err)
+		require.Equal(t, []string{"bulk", "urgent", "bulk"}, tenants(tasks))
+
+		tasks, err = d.GetPendingTasks(context.Background(), uuid.New(), PendingTaskOptions{CountLimit: 10, TenantLimit: 2})
+		require.NoError(t, err)
+		require.Equal(t, []string{"bulk", "bulk"}, tenants(tasks), "remaining tasks should be taken on the next cycle")
+	})
+
+	t.Run("Tasks of paused kinds are left pending", func(t *testing.T) {
+		d := open(t)
+		tasks, err := d.GetPendingTasks(context.Background(), uuid.New(), PendingTaskOptions{
+			CountLimit:  10,
+			PausedKinds: []models.TaskType{models.TypeAlertDefinition},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"urgent"}, tenants(tasks))
+
+		tasks, err = d.GetPendingTasks(context.Background(), uuid.New(), PendingTaskOptions{
+			CountLimit:  10,
+			PausedKinds: []models.TaskType{models.TypeReceiver},
+		})
+		require.NoError(t, err)
+		require.Len(t, tasks, 5, "alert definition tasks should be taken once resumed")
+	})
+}
+
+func TestGetPendingTasksWithoutSkipLocked(t *testing.T) {
+	conn, err := gorm.Open(sqlite.Open(":memory:"))
+	require.NoError(t, err)
+	require.NoError(t, conn.AutoMigrate(&models.Tenant{}, &models.Task{}))
+	require.False(t, supportsSkipLocked(conn))
+
+	pending, taken := uuid.New(), uuid.New()
+	require.NoError(t, conn.Create(&[]models.Task{
+		{ID: 1, AlertDefinitionUUID: &pending, TenantID: "edgenode", Version: 1, State: models.TaskNew},
+		{ID: 2, AlertDefinitionUUID: &pending, TenantID: "edgenode", Version: 2, State: models.TaskError},
+		{ID: 3, AlertDefinitionUUID: &taken, TenantID: "edgenode", Version: 1, State: models.TaskTaken},
+		{ID: 4, AlertDefinitionUUID: &taken, TenantID: "edgenode", Version: 2, State: models.TaskNew},
+	}).Error)
+
+	d := &DBService{DB: conn}
+	ownerUUID := uuid.New()
+	tasks, err := d.GetPendingTasks(context.Background(), ownerUUID, PendingTaskOptions{CountLimit: 10})
+	require.NoError(t, err)
+	require.Len(t, tasks, 1, "tasks of a UUID already taken should be left pending")
+	require.Equal(t, int64(2), tasks[0].ID, "latest version of a UUID should be taken")
+
+	var task models.Task
+	require.NoError(t, conn.Take(&task, tasks[0].ID).Error)
+	require.Equal(t, models.TaskTaken, task.State)
+	require.Equal(t, ownerUUID, task.OwnerUUID)
+
+	tasks, err = d.GetPendingTasks(context.Background(), uuid.New(), PendingTaskOptions{CountLimit: 10})
+	require.NoError(t, err)
+	require.Empty(t, tasks)
+}
+
+func TestGetPendingTasksPostgres(t *testing.T) {
+	if *postgresDSN == "" {
+		t.Skip("no PostgreSQL database given with -postgres")
+	}
+
+	conn, err := gorm.Open(postgres.Open(*postgresDSN), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	require.NoError(t, conn.AutoMigrate(&models.Tenant{}, &models.Task{}))
+	t.Cleanup(func() {
+		require.NoError(t, conn.Migrator().DropTable(&models.Task{}, &models.Tenant{}))
+	})
+	require.True(t, supportsSkipLocked(conn))
+
+	t.Run("A UUID is taken by one of two concurrent transactions", func(t *testing.T) {
+		definitionUUID := uuid.New()
+		pair := models.TaskUUIDTenantID{UUID: definitionUUID, TenantID: "edgenode"}
+		require.NoError(t, conn.Create(&models.Task{
+			AlertDefinitionUUID: &definitionUUID, TenantID: "edgenode", Version: 1, State: models.TaskNew,
+		}).Error)
+
+		first := conn.Begin()
+		defer first.Rollback()
+		task, ok, err := lockLatestPendingTask(first, pair)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, int64(1), task.Version)
+
+		// A version added once the first transaction locked the pending tasks is not locked by it.
+		require.NoError(t, conn.Create(&models.Task{
+			AlertDefinitionUUID: &definitionUUID, TenantID: "edgenode", Version: 2, State: models.TaskNew,
+		}).Error)
+
+		second := conn.Begin()
+		defer second.Rollback()
+		_, ok, err = lockLatestPendingTask(second, pair)
+		require.NoError(t, err)
+		require.False(t, ok, "a UUID locked by another transaction should be skipped")
+
+		require.NoError(t, first.Rollback().Error)
+		task, ok, err = lockLatestPendingTask(second, pair)
+		require.NoError(t, err)
+		require.True(t, ok, "a UUID should be taken once the other transaction ends")
+		require.Equal(t, int64(2), task.Version)
+	})
+
+	t.Run("Concurrent executors take disjoint tasks", func(t *testing.T) {
+		for range 20 {
+			definitionUUID := uuid.New()
+			for version := range int64(2) {
+				require.NoError(t, conn.Create(&models.Task{
+					AlertDefinitionUUID: &definitionUUID, TenantID: "concurrent", Version: version + 1, State: models.TaskNew,
+				}).Error)
+			}
+		}
+
+		d := &DBService{DB: conn}
+		results := make([][]models.Task, 4)
+		var wg sync.WaitGroup
+		for i := range results {
+			wg.Go(func() {
+				tasks, err := d.GetPendingTasks(context.Background(), uuid.New(), PendingTaskOptions{CountLimit: 20})
+				assert.NoError(t, err)
+				results[i] = tasks
+			})
+		}
+		wg.Wait()
+
+		taken := map[uuid.UUID]int{}
+		for _, tasks := range results {
+			for _, task := range tasks {
+				taken[task.GetTaskUUID()]++
+			}
+		}
+		for taskUUID, count := range taken {
+			require.Equal(t, 1, count, "UUID %v taken more than once", taskUUID)
+		}
+
+		var count int64
+		require.NoError(t, conn.Model(&models.Task{}).Where("tenant_id = ? AND state = ?", "concurrent", models.TaskTaken).
+			Count(&count).Error)
+		require.Equal(t, int64(len(taken)), count)
+	})
+}
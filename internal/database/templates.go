@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+// GetEmailTemplate gets the given version of the email template of a tenant, or its latest version if version is zero. An error
+// wrapping gorm.ErrRecordNotFound is returned if there is no such version.
+func (d *DBService) GetEmailTemplate(ctx context.Context, tenantID api.TenantID, version int64) (*models.EmailTemplate, error) {
+	query := d.DB.WithContext(ctx).Where("tenant_id = ?", tenantID)
+	if version != 0 {
+		query = query.Where("version = ?", version)
+	}
+
+	var tmpl models.EmailTemplate
+	if err := query.Order("version DESC").Take(&tmpl).Error; err != nil {
+		return nil, fmt.Errorf("failed to get email template of tenant %q: %w", tenantID, err)
+	}
+	return &tmpl, nil
+}
+
+// SetEmailTemplate creates a new version of the email template of a tenant with the given content, an empty content restoring the
+// template of the deployment. The latest versions of the receivers of the tenant are queued to be applied again, so that their
+// emails are rendered with the new template.
+func (d *DBService) SetEmailTemplate(ctx context.Context, tenantID api.TenantID, content string) (*models.EmailTemplate, error) {
+	tx := d.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	var version int64
+	if err := tx.Model(&models.EmailTemplate{}).
+		Select("COALESCE(MAX(version), 0)").
+		Where("tenant_id = ?", tenantID).
+		Scan(&version).Error; err != nil {
+		return nil, fmt.Errorf("failed to get latest email template version of tenant %q: %w", tenantID, err)
+	}
+
+	tmpl := models.EmailTemplate{
+		TenantID:     tenantID,
+		Version:      version + 1,
+		Content:      content,
+		CreationDate: clock.TimeNowFn().UTC(),
+	}
+	if err := tx.Create(&tmpl).Error; err != nil {
+		return nil, fmt.Errorf("failed to create email template of tenant %q: %w", tenantID, err)
+	}
+
+	receivers, err := latestVersions(tx, &models.Receiver{}, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get receivers of tenant %q: %w", tenantID, err)
+	}
+	for _, recv := range receivers {
+		if err := requeueTask(tx, models.Task{ReceiverUUID: &recv.UUID, TenantID: tenantID, Version: recv.Version}); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+	return &tmpl, nil
+}
+
+// getEmailTemplateContent is a helper function that gets the content of the latest email template of a tenant, empty if the
+// tenant uses the template of the deployment. It accepts a pointer to DB GORM definition to allow query executions within the
+// same transaction.
+func getEmailTemplateContent(tx *gorm.DB, tenantID api.TenantID) (string, error) {
+	var templates []models.EmailTemplate
+	if err := tx.Where("tenant_id = ?", tenantID).
+		Order("version DESC").
+		Limit(1).
+		Find(&templates).Error; err != nil {
+		return "", fmt.Errorf("failed to get email template of tenant %q: %w", tenantID, err)
+	}
+	if len(templates) == 0 {
+		return "", nil
+	}
+	return templates[0].Content, nil
+}
@@ -0,0 +1,344 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/correlation"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+var (
+	ErrTenantNotArchived = errors.New("tenant is not archived")
+)
+
+// SetTenantActivity records the current time as the time of the last API activity of a tenant.
+func (d *DBService) SetTenantActivity(ctx context.Context, tenantID api.TenantID) error {
+	tenant := d.newTenant(tenantID, clock.TimeNowFn().UTC())
+
+	if err := d.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tenant_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"last_activity_date"}),
+	}).Create(&tenant).Error; err != nil {
+		return fmt.Errorf("failed to set activity of tenant %q: %w", tenantID, err)
+	}
+	return nil
+}
+
+// GetInactiveTenants gets the tenants which are not archived and had no API activity within the given duration. Tenants owning
+// alert definitions or receivers which are not tracked yet are registered first with the current time as their last activity.
+func (d *DBService) GetInactiveTenants(ctx context.Context, dur time.Duration) ([]api.TenantID, error) {
+	tx := d.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	var untracked []api.TenantID
+	if err := tx.Raw(`
+		SELECT owners.tenant_id
+		FROM
+			(
+				SELECT tenant_id FROM alert_definitions
+				UNION
+				SELECT tenant_id FROM receivers
+			)
+		AS owners
+		WHERE NOT EXISTS (SELECT 1 FROM tenants t WHERE t.tenant_id = owners.tenant_id);
+	`).Scan(&untracked).Error; err != nil {
+		return nil, fmt.Errorf("failed to get untracked tenants: %w", err)
+	}
+
+	now := clock.TimeNowFn().UTC()
+	if len(untracked) > 0 {
+		tenants := make([]models.Tenant, len(untracked))
+		for i, tenantID := range untracked {
+			tenants[i] = d.newTenant(tenantID, now)
+		}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&tenants).Error; err != nil {
+			return nil, fmt.Errorf("failed to register tenants: %w", err)
+		}
+	}
+
+	var tenantIDs []api.TenantID
+	if err := tx.Model(&models.Tenant{}).
+		Where("archived_date IS NULL").
+		Where("last_activity_date < ?", now.Add(-dur)).
+		Order("tenant_id").
+		Pluck("tenant_id", &tenantIDs).Error; err != nil {
+		return nil, fmt.Errorf("failed to get inactive tenants: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+	return tenantIDs, nil
+}
+
+// GetActiveTenants gets the tenants which own alert definitions or receivers and are not archived.
+func (d *DBService) GetActiveTenants(ctx context.Context) ([]api.TenantID, error) {
+	var tenantIDs []api.TenantID
+	if err := d.DB.WithContext(ctx).Raw(`
+		SELECT owners.tenant_id
+		FROM
+			(
+				SELECT tenant_id FROM alert_definitions
+				UNION
+				SELECT tenant_id FROM receivers
+			)
+		AS owners
+		WHERE NOT EXISTS (SELECT 1 FROM tenants t WHERE t.tenant_id = owners.tenant_id AND t.archived_date IS NOT NULL)
+		ORDER BY owners.tenant_id;
+	`).Scan(&tenantIDs).Error; err != nil {
+		return nil, fmt.Errorf("failed to get active tenants: %w", err)
+	}
+	return tenantIDs, nil
+}
+
+// ArchiveTenant marks the configuration of a tenant as archived. Pending tasks of archived tenants are not executed.
+func (d *DBService) ArchiveTenant(ctx context.Context, tenantID api.TenantID) error {
+	res := d.DB.WithContext(ctx).
+		Model(&models.Tenant{}).
+		Where("tenant_id = ?", tenantID).
+		Where("archived_date IS NULL").
+		Update("archived_date", clock.TimeNowFn().UTC())
+	if res.Error != nil {
+		return fmt.Errorf("failed to archive tenant %q: %w", tenantID, res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("no active tenant %q found: %w", tenantID, gorm.ErrRecordNotFound)
+	}
+	return nil
+}
+
+// UnarchiveTenant marks the configuration of an archived tenant as active, and records the current time as its last activity.
+// The latest versions of the alert definitions and receivers of the tenant are queued to be applied again.
+func (d *DBService) UnarchiveTenant(ctx context.Context, tenantID api.TenantID) error {
+	tx := d.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	res := tx.Model(&models.Tenant{}).
+		Where("tenant_id = ?", tenantID).
+		Where("archived_date IS NOT NULL").
+		Updates(map[string]any{
+			"archived_date":      nil,
+			"last_activity_date": clock.TimeNowFn().UTC(),
+		})
+	if res.Error != nil {
+		return fmt.Errorf("failed to unarchive tenant %q: %w", tenantID, res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("failed to unarchive tenant %q: %w", tenantID, ErrTenantNotArchived)
+	}
+
+	definitions, err := latestVersions(tx, &models.AlertDefinition{}, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get alert definitions of tenant %q: %w", tenantID, err)
+	}
+	for _, def := range definitions {
+		if err := requeueTask(tx, models.Task{AlertDefinitionUUID: &def.UUID, TenantID: tenantID, Version: def.Version}); err != nil {
+			return err
+		}
+	}
+
+	receivers, err := latestVersions(tx, &models.Receiver{}, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get receivers of tenant %q: %w", tenantID, err)
+	}
+	for _, recv := range receivers {
+		if err := requeueTask(tx, models.Task{ReceiverUUID: &recv.UUID, TenantID: tenantID, Version: recv.Version}); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+// GetTenantShard gets the alertmanager shard of a tenant, which is assigned when the tenant is registered. A tenant which is not
+// registered yet, or was registered without a shard, is served by the shard it would be assigned out of the given number of shards.
+func (d *DBService) GetTenantShard(ctx context.Context, tenantID api.TenantID, shards int) (int, error) {
+	if shards < 1 {
+		return 0, fmt.Errorf("invalid number of shards: %d", shards)
+	}
+
+	var tenants []models.Tenant
+	if err := d.DB.WithContext(ctx).
+		Where("tenant_id = ?", tenantID).
+		Limit(1).
+		Find(&tenants).Error; err != nil {
+		return 0, fmt.Errorf("failed to get shard of tenant %q: %w", tenantID, err)
+	}
+	if len(tenants) == 0 || tenants[0].AlertmanagerShard == nil {
+		return tenantShard(tenantID, shards), nil
+	}
+	return *tenants[0].AlertmanagerShard, nil
+}
+
+// GetTenantTier gets the tier of a tenant, empty if the tenant has no assigned tier or is not tracked yet.
+func (d *DBService) GetTenantTier(ctx context.Context, tenantID api.TenantID) (string, error) {
+	var tiers []string
+	if err := d.DB.WithContext(ctx).
+		Model(&models.Tenant{}).
+		Where("tenant_id = ?", tenantID).
+		Pluck("tier", &tiers).Error; err != nil {
+		return "", fmt.Errorf("failed to get tier of tenant %q: %w", tenantID, err)
+	}
+	if len(tiers) == 0 {
+		return "", nil
+	}
+	return tiers[0], nil
+}
+
+// SetTenantTier assigns a tier to a tenant, registering the tenant with the current time as its last activity if it is not
+// tracked yet. An empty tier unassigns the tier of the tenant.
+func (d *DBService) SetTenantTier(ctx context.Context, tenantID api.TenantID, tier string) error {
+	tenant := d.newTenant(tenantID, clock.TimeNowFn().UTC())
+	tenant.Tier = tier
+
+	if err := d.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tenant_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"tier"}),
+	}).Create(&tenant).Error; err != nil {
+		return fmt.Errorf("failed to set tier of tenant %q: %w", tenantID, err)
+	}
+	return nil
+}
+
+// GetTenantMaintenance gets the maintenance window of a tenant, nil if the tenant is not in maintenance mode or its maintenance
+// window is over.
+func (d *DBService) GetTenantMaintenance(ctx context.Context, tenantID api.TenantID) (*models.MaintenanceWindow, error) {
+	return getTenantMaintenance(d.DB.WithContext(ctx), tenantID)
+}
+
+// SetTenantMaintenance puts a tenant in maintenance mode for the given window, registering the tenant with the current time as
+// its last activity if it is not tracked yet. A nil window takes the tenant out of maintenance mode. The latest versions of the
+// receivers of the tenant are queued to be applied again, so that their routes are muted or unmuted accordingly.
+func (d *DBService) SetTenantMaintenance(ctx context.Context, tenantID api.TenantID, window *models.MaintenanceWindow) error {
+	tx := d.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	tenant := d.newTenant(tenantID, clock.TimeNowFn().UTC())
+	if window != nil {
+		start, end := window.Start.UTC(), window.End.UTC()
+		tenant.MaintenanceStart = &start
+		tenant.MaintenanceEnd = &end
+		tenant.MaintenanceSilenceID = window.SilenceID
+	}
+
+	if err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tenant_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"maintenance_start", "maintenance_end", "maintenance_silence_id"}),
+	}).Create(&tenant).Error; err != nil {
+		return fmt.Errorf("failed to set maintenance mode of tenant %q: %w", tenantID, err)
+	}
+
+	receivers, err := latestVersions(tx, &models.Receiver{}, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get receivers of tenant %q: %w", tenantID, err)
+	}
+	for _, recv := range receivers {
+		if err := requeueTask(tx, models.Task{ReceiverUUID: &recv.UUID, TenantID: tenantID, Version: recv.Version}); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+// getTenantMaintenance is a helper function that gets the maintenance window of a tenant, nil if there is none or it is over.
+// It accepts a pointer to DB GORM definition to allow query executions within the same transaction.
+func getTenantMaintenance(tx *gorm.DB, tenantID api.TenantID) (*models.MaintenanceWindow, error) {
+	var tenants []models.Tenant
+	if err := tx.Where("tenant_id = ?", tenantID).
+		Where("maintenance_end IS NOT NULL").
+		Limit(1).
+		Find(&tenants).Error; err != nil {
+		return nil, fmt.Errorf("failed to get maintenance mode of tenant %q: %w", tenantID, err)
+	}
+	if len(tenants) == 0 || tenants[0].MaintenanceStart == nil {
+		return nil, nil
+	}
+
+	window := &models.MaintenanceWindow{
+		Start:     *tenants[0].MaintenanceStart,
+		End:       *tenants[0].MaintenanceEnd,
+		SilenceID: tenants[0].MaintenanceSilenceID,
+	}
+	if !window.IsActive(clock.TimeNowFn()) {
+		return nil, nil
+	}
+	return window, nil
+}
+
+// newTenant returns a tenant to be registered with the given time as its last activity. The tenant is assigned the alertmanager
+// shard given by the hash of its ID modulo the number of shards, which is kept afterwards so that adding shards does not move it.
+func (d *DBService) newTenant(tenantID api.TenantID, now time.Time) models.Tenant {
+	tenant := models.Tenant{
+		TenantID:         tenantID,
+		LastActivityDate: now,
+	}
+	if d.AlertmanagerShards > 0 {
+		shard := tenantShard(tenantID, d.AlertmanagerShards)
+		tenant.AlertmanagerShard = &shard
+	}
+	return tenant
+}
+
+// tenantShard deterministically maps a tenant to one of the given number of shards.
+func tenantShard(tenantID api.TenantID, shards int) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(tenantID))
+	return int(h.Sum32()) % shards
+}
+
+type uuidVersion struct {
+	UUID    uuid.UUID
+	Version int64
+}
+
+// latestVersions gets the UUIDs and latest versions of the alert definitions or receivers, given by model, of a tenant.
+func latestVersions(tx *gorm.DB, model any, tenantID api.TenantID) ([]uuidVersion, error) {
+	var versions []uuidVersion
+	err := tx.Model(model).
+		Select("uuid", "MAX(version) AS version").
+		Where("tenant_id = ?", tenantID).
+		Group("uuid").
+		Find(&versions).Error
+	return versions, err
+}
+
+// requeueTask sets the task of the given alert definition or receiver version back to New state, creating it if it was
+// already deleted.
+func requeueTask(tx *gorm.DB, task models.Task) error {
+	var existing models.Task
+	err := tx.Where(&task).First(&existing).Error
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		task.State = models.TaskNew
+		task.CreationDate = clock.TimeNowFn()
+		task.CorrelationID = correlation.FromContext(tx.Statement.Context)
+		if err := tx.Create(&task).Error; err != nil {
+			return fmt.Errorf("failed to create task for %q version %v: %w", task.GetTaskUUID(), task.Version, err)
+		}
+	case err != nil:
+		return fmt.Errorf("failed to get task for %q version %v: %w", task.GetTaskUUID(), task.Version, err)
+	default:
+		if err := tx.Model(&existing).Updates(map[string]any{
+			"state":          models.TaskNew,
+			"retry_count":    0,
+			"correlation_id": correlation.FromContext(tx.Statement.Context),
+		}).Error; err != nil {
+			return fmt.Errorf("failed to requeue task for %q version %v: %w", task.GetTaskUUID(), task.Version, err)
+		}
+	}
+	return nil
+}
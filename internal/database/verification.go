@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+type emailVerificationContextKey struct{}
+
+// NewEmailVerificationContext returns a copy of the context with email verification enabled, so that the email addresses
+// first added as recipients by the change made with the context are pending verification.
+func NewEmailVerificationContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, emailVerificationContextKey{}, true)
+}
+
+// emailVerificationFromContext returns whether email verification is enabled by the context.
+func emailVerificationFromContext(ctx context.Context) bool {
+	enabled, _ := ctx.Value(emailVerificationContextKey{}).(bool)
+	return enabled
+}
+
+// ClaimEmailVerifications gets the email addresses among the given ones that are pending verification and were not sent a
+// verification email within the given period, and records that a verification email is sent to them now.
+func (d *DBService) ClaimEmailVerifications(ctx context.Context, emails []string, resendAfter time.Duration) ([]models.EmailAddress, error) {
+	if len(emails) == 0 {
+		return nil, nil
+	}
+
+	var addresses []models.EmailAddress
+	err := d.retryTx(ctx, func(tx *gorm.DB) error {
+		now := clock.TimeNowFn().UTC()
+		if err := tx.
+			Where("email IN ?", emails).
+			Where("verification_pending = ?", true).
+			Where("verification_sent_date IS NULL OR verification_sent_date <= ?", now.Add(-resendAfter)).
+			Order("email").
+			Find(&addresses).Error; err != nil {
+			return fmt.Errorf("failed to get email addresses pending verification: %w", err)
+		}
+		if len(addresses) == 0 {
+			return nil
+		}
+
+		ids := make([]int64, len(addresses))
+		for i, a := range addresses {
+			ids[i] = a.ID
+		}
+		if err := tx.Model(&models.EmailAddress{}).
+			Where("id IN ?", ids).
+			Update("verification_sent_date", now).Error; err != nil {
+			return fmt.Errorf("failed to record verification emails: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return addresses, nil
+}
+
+// VerifyEmailAddress sets the given email address as verified. A new version of the latest version of the receivers of all
+// tenants it is a recipient of is created along with its task, so that the configuration notifying it is applied, and the
+// pending tasks of their previous versions are set as invalid. Verifying an address already verified changes nothing. It
+// returns gorm.ErrRecordNotFound if there is no such address. It is retried if it conflicts with a concurrent update.
+func (d *DBService) VerifyEmailAddress(ctx context.Context, email string) error {
+	return d.retryTx(ctx, func(tx *gorm.DB) error {
+		var address models.EmailAddress
+		if err := tx.Where("email = ?", email).Take(&address).Error; err != nil {
+			return fmt.Errorf("failed to get email address %q: %w", email, err)
+		}
+		if !address.VerificationPending {
+			return nil
+		}
+
+		if err := tx.Model(&address).Update("verification_pending", false).Error; err != nil {
+			return fmt.Errorf("failed to verify email address %q: %w", email, err)
+		}
+
+		recvs, err := getLatestReceiversWithRecipient(tx, email)
+		if err != nil {
+			return err
+		}
+		for _, recv := range recvs {
+			recipients, err := getReceiverRecipients(tx, recv)
+			if err != nil {
+				return err
+			}
+
+			version, err := setReceiverValues(ctx, tx, recv.TenantID, recv.UUID, models.DBReceiverValues{Recipients: recipients})
+			if err != nil {
+				return fmt.Errorf("failed to update receiver %q for tenant %q with verified recipient %q: %w", recv.UUID, recv.TenantID, email, err)
+			}
+			if err := invalidatePendingReceiverTasks(tx, recv.TenantID, recv.UUID, version); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
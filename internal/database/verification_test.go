@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+func TestEmailVerification(t *testing.T) {
+	conn, err := gorm.Open(sqlite.Open(":memory:"))
+	require.NoError(t, err)
+	require.NoError(t, conn.AutoMigrate(&models.EmailAddress{}, &models.EmailConfig{}, &models.Receiver{}, &models.EmailRecipient{},
+		&models.Task{}, &models.Tenant{}, &models.EmailTemplate{}))
+
+	tenantID := "edgenode"
+	sender := models.EmailAddress{FirstName: "alerts", LastName: "sender", Email: "alerts@example.com"}
+	require.NoError(t, conn.Create(&sender).Error)
+	config := models.EmailConfig{MailServer: "smtp.example.com:587", From: sender.ID}
+	require.NoError(t, conn.Create(&config).Error)
+
+	id := uuid.New()
+	require.NoError(t, conn.Create(&models.Receiver{UUID: id, Name: "receiver", Version: 1, State: models.ReceiverApplied,
+		EmailConfigID: config.ID, TenantID: tenantID}).Error)
+
+	d := &DBService{DB: conn}
+	alice := models.EmailAddress{FirstName: "alice", LastName: "smith", Email: "alice@example.com"}
+	bob := models.EmailAddress{FirstName: "bob", LastName: "jones", Email: "bob@example.com"}
+
+	// Alice is added without verification, Bob is added while email verification is enabled.
+	require.NoError(t, d.SetReceiverValues(context.Background(), tenantID, id, models.DBReceiverValues{Recipients: []models.EmailAddress{alice}}))
+	require.NoError(t, d.SetReceiverValues(NewEmailVerificationContext(context.Background()), tenantID, id,
+		models.DBReceiverValues{Recipients: []models.EmailAddress{alice, bob}}))
+
+	recv, err := d.GetLatestReceiverWithEmailConfig(context.Background(), tenantID, id)
+	require.NoError(t, err)
+	require.Equal(t, []string{alice.String(), bob.String()}, recv.To)
+	require.Equal(t, []string{bob.String()}, recv.Unverified)
+	require.Equal(t, []string{alice.String()}, recv.Notified())
+
+	t.Run("Verification email is only sent once per period", func(t *testing.T) {
+		claimed, err := d.ClaimEmailVerifications(context.Background(), []string{alice.Email, bob.Email}, time.Hour)
+		require.NoError(t, err)
+		require.Len(t, claimed, 1)
+		require.Equal(t, bob.Email, claimed[0].Email)
+
+		claimed, err = d.ClaimEmailVerifications(context.Background(), []string{bob.Email}, time.Hour)
+		require.NoError(t, err)
+		require.Empty(t, claimed)
+
+		claimed, err = d.ClaimEmailVerifications(context.Background(), []string{bob.Email}, 0)
+		require.NoError(t, err)
+		require.Len(t, claimed, 1)
+	})
+
+	t.Run("Verified recipient is notified by a new version of its receivers", func(t *testing.T) {
+		require.NoError(t, d.VerifyEmailAddress(context.Background(), bob.Email))
+
+		recv, err := d.GetLatestReceiverWithEmailConfig(context.Background(), tenantID, id)
+		require.NoError(t, err)
+		require.Equal(t, 4, recv.Version)
+		require.Equal(t, []string{alice.String(), bob.String()}, recv.To)
+		require.Empty(t, recv.Unverified)
+
+		var tasks []models.Task
+		require.NoError(t, conn.Where("state = ?", models.TaskNew).Find(&tasks).Error)
+		require.Len(t, tasks, 1)
+		require.Equal(t, int64(4), tasks[0].Version)
+
+		// Verifying an address already verified changes nothing.
+		require.NoError(t, d.VerifyEmailAddress(context.Background(), bob.Email))
+		recv, err = d.GetLatestReceiverWithEmailConfig(context.Background(), tenantID, id)
+		require.NoError(t, err)
+		require.Equal(t, 4, recv.Version)
+	})
+
+	t.Run("Unknown email address", func(t *testing.T) {
+		require.ErrorIs(t, d.VerifyEmailAddress(context.Background(), "carol@example.com"), gorm.ErrRecordNotFound)
+	})
+}
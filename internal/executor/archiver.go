@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"gorm.io/gorm"
+
+	am "github.com/open-edge-platform/o11y-alerting-monitor/internal/alertmanager"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/app"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/mimir"
+)
+
+// tenantArchiver periodically archives the configuration of tenants which had no API activity and no active alerts within
+// the configured inactivity period. The receivers and alert definitions of archived tenants are removed from alertmanager
+// and Mimir, which keeps the size of the alertmanager configuration bounded.
+type tenantArchiver struct {
+	archivalConfig config.TenantArchivalConfig
+	logger         *slog.Logger
+	quit           chan struct{}
+
+	tenants database.TenantManager
+
+	receiversCfg   am.TenantConfigRemover
+	definitionsCfg mimir.TenantRulesRemover
+}
+
+// NewTenantArchiver creates a new tenantArchiver, initializing the archival configuration, the connection to the database
+// where tenant activity is stored, and the structs that allow removing tenant configuration from alertmanager and Mimir.
+func NewTenantArchiver(cfg config.Config, dbConn *gorm.DB, loglevel string, alertManager am.NotificationBackend) *tenantArchiver {
+	opts := setLogLvl(loglevel)
+	return &tenantArchiver{
+		archivalConfig: cfg.TenantArchival,
+		logger:         slog.New(slog.NewTextHandler(os.Stdout, &opts)),
+		quit:           make(chan struct{}),
+
+		tenants: &database.DBService{DB: dbConn, AlertmanagerShards: cfg.AlertManager.ShardCount()},
+
+		receiversCfg:   alertManager,
+		definitionsCfg: &mimir.Mimir{Config: &cfg.Mimir},
+	}
+}
+
+// Start allows the receiver to start archiving inactive tenants periodically by means of a ticker. Nothing is done if
+// the inactivity period is not set.
+// NOTE: Once this method is invoked, to stop archiving tenants, we need to explicitly call Stop method from the receiver.
+func (ta *tenantArchiver) Start(ctx context.Context) {
+	if ta.archivalConfig.InactivityPeriod <= 0 || ta.archivalConfig.CheckInterval <= 0 {
+		ta.logger.Info("Tenant archival is disabled")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(ta.archivalConfig.CheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ta.quit:
+				ta.logger.Info("Received signal: stopping tenant archiver")
+				return
+			case <-ticker.C:
+				ta.archiveInactiveTenants(ctx)
+			}
+		}
+	}()
+}
+
+// Stop allows the receiver to stop archiving tenants.
+func (ta *tenantArchiver) Stop() {
+	close(ta.quit)
+}
+
+// archiveInactiveTenants archives the tenants which had no API activity within the inactivity period, unless they have
+// active alerts. The default tenant is never archived.
+func (ta *tenantArchiver) archiveInactiveTenants(ctx context.Context) {
+	tenantIDs, err := ta.tenants.GetInactiveTenants(ctx, ta.archivalConfig.InactivityPeriod)
+	if err != nil {
+		ta.logger.Error("failed to get inactive tenants", slog.Any("error", err))
+		return
+	}
+
+	for _, tenantID := range tenantIDs {
+		if tenantID == app.DefaultTenantID {
+			continue
+		}
+
+		if err := ta.archiveTenant(ctx, tenantID); err != nil {
+			ta.logger.Error(fmt.Sprintf("failed to archive tenant %q", tenantID), slog.Any("error", err))
+		}
+	}
+}
+
+// archiveTenant marks the configuration of a tenant as archived and removes it from alertmanager and Mimir. If the
+// configuration cannot be removed, the tenant is unarchived so that its configuration is applied again.
+func (ta *tenantArchiver) archiveTenant(ctx context.Context, tenantID string) error {
+	active, err := ta.receiversCfg.HasActiveAlerts(ctx, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to check active alerts: %w", err)
+	}
+	if active {
+		ta.logger.Debug(fmt.Sprintf("skipping archival of tenant %q with active alerts", tenantID))
+		return nil
+	}
+
+	// Another instance may have archived the tenant in the meantime.
+	if err := ta.tenants.ArchiveTenant(ctx, tenantID); errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	removeErr := errors.Join(
+		ta.receiversCfg.RemoveTenantConfig(ctx, tenantID),
+		ta.definitionsCfg.DeleteTenantRules(ctx, tenantID),
+	)
+	if removeErr != nil {
+		if err := ta.tenants.UnarchiveTenant(ctx, tenantID); err != nil {
+			return errors.Join(removeErr, fmt.Errorf("failed to revert archival: %w", err))
+		}
+		return removeErr
+	}
+
+	ta.logger.Info(fmt.Sprintf("archived configuration of inactive tenant %q", tenantID))
+	return nil
+}
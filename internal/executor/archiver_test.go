@@ -0,0 +1,153 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package executor
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+type TenantConfigRemoverMock struct {
+	mock.Mock
+}
+
+func (m *TenantConfigRemoverMock) HasActiveAlerts(ctx context.Context, tenantID string) (bool, error) {
+	args := m.Called(ctx, tenantID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *TenantConfigRemoverMock) RemoveTenantConfig(ctx context.Context, tenantID string) error {
+	args := m.Called(ctx, tenantID)
+	return args.Error(0)
+}
+
+type TenantRulesRemoverMock struct {
+	mock.Mock
+}
+
+func (m *TenantRulesRemoverMock) DeleteTenantRules(ctx context.Context, tenant string) error {
+	args := m.Called(ctx, tenant)
+	return args.Error(0)
+}
+
+type TenantArchiverSuite struct {
+	suite.Suite
+
+	db    *gorm.DB
+	dbSrv *database.DBService
+}
+
+func (s *TenantArchiverSuite) SetupSubTest() {
+	clock.SetFakeClock()
+	clock.FakeClock.Set(time.Now().UTC())
+
+	var err error
+	s.db, err = gorm.Open(sqlite.Open("file:archiver?mode=memory&cache=shared"), &gorm.Config{})
+	s.Require().NoError(err)
+
+	s.Require().NoError(s.db.AutoMigrate(
+		&models.AlertDefinition{},
+		&models.Receiver{},
+		&models.Task{},
+		&models.Tenant{},
+		&models.EmailTemplate{},
+	))
+
+	s.dbSrv = &database.DBService{DB: s.db}
+
+	// Both tenants have been inactive for two days.
+	ctx := s.T().Context()
+	s.Require().NoError(s.dbSrv.SetTenantActivity(ctx, "edgenode"))
+	s.Require().NoError(s.dbSrv.SetTenantActivity(ctx, "tenant"))
+	clock.FakeClock.Add(48 * time.Hour)
+}
+
+func (s *TenantArchiverSuite) TearDownSubTest() {
+	clock.UnsetFakeClock()
+
+	s.db.Exec("DELETE FROM tenants")
+
+	dbConn, err := s.db.DB()
+	s.Require().NoError(err)
+	dbConn.Close()
+}
+
+func TestTenantArchiver(t *testing.T) {
+	suite.Run(t, new(TenantArchiverSuite))
+}
+
+func (s *TenantArchiverSuite) newArchiver(amMock *TenantConfigRemoverMock, mimirMock *TenantRulesRemoverMock) *tenantArchiver {
+	return &tenantArchiver{
+		archivalConfig: config.TenantArchivalConfig{
+			InactivityPeriod: 24 * time.Hour,
+		},
+		logger:         slog.New(slog.NewTextHandler(os.Stdout, nil)),
+		tenants:        s.dbSrv,
+		receiversCfg:   amMock,
+		definitionsCfg: mimirMock,
+	}
+}
+
+func (s *TenantArchiverSuite) isArchived(tenantID string) bool {
+	var tenant models.Tenant
+	s.Require().NoError(s.db.First(&tenant, "tenant_id = ?", tenantID).Error)
+	return tenant.IsArchived()
+}
+
+func (s *TenantArchiverSuite) TestArchiveInactiveTenants() {
+	s.Run("Archives inactive tenant except the default tenant", func() {
+		amMock := new(TenantConfigRemoverMock)
+		amMock.On("HasActiveAlerts", mock.Anything, "tenant").Return(false, nil)
+		amMock.On("RemoveTenantConfig", mock.Anything, "tenant").Return(nil)
+		mimirMock := new(TenantRulesRemoverMock)
+		mimirMock.On("DeleteTenantRules", mock.Anything, "tenant").Return(nil)
+
+		s.newArchiver(amMock, mimirMock).archiveInactiveTenants(s.T().Context())
+
+		amMock.AssertExpectations(s.T())
+		mimirMock.AssertExpectations(s.T())
+		s.True(s.isArchived("tenant"))
+		s.False(s.isArchived("edgenode"))
+	})
+
+	s.Run("Does not archive tenant with active alerts", func() {
+		amMock := new(TenantConfigRemoverMock)
+		amMock.On("HasActiveAlerts", mock.Anything, "tenant").Return(true, nil)
+		mimirMock := new(TenantRulesRemoverMock)
+
+		s.newArchiver(amMock, mimirMock).archiveInactiveTenants(s.T().Context())
+
+		amMock.AssertExpectations(s.T())
+		amMock.AssertNotCalled(s.T(), "RemoveTenantConfig", mock.Anything, mock.Anything)
+		mimirMock.AssertNotCalled(s.T(), "DeleteTenantRules", mock.Anything, mock.Anything)
+		s.False(s.isArchived("tenant"))
+	})
+
+	s.Run("Reverts archival if configuration cannot be removed", func() {
+		amMock := new(TenantConfigRemoverMock)
+		amMock.On("HasActiveAlerts", mock.Anything, "tenant").Return(false, nil)
+		amMock.On("RemoveTenantConfig", mock.Anything, "tenant").Return(nil)
+		mimirMock := new(TenantRulesRemoverMock)
+		mimirMock.On("DeleteTenantRules", mock.Anything, "tenant").Return(errors.New("mock error"))
+
+		err := s.newArchiver(amMock, mimirMock).archiveTenant(s.T().Context(), "tenant")
+
+		s.Require().ErrorContains(err, "mock error")
+		s.False(s.isArchived("tenant"))
+	})
+}
@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package executor
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+)
+
+// defaultArtifactRotationBatchSize is the number of artifacts re-encrypted per transaction if not configured.
+const defaultArtifactRotationBatchSize = 100
+
+// artifactKeyRotator periodically re-encrypts the applied artifacts which are not encrypted with the active key of the
+// artifact key ring, so that the keys rotated out of the key ring can be removed, and that the artifacts recorded before
+// encryption was enabled are encrypted.
+type artifactKeyRotator struct {
+	encryptionConfig config.ArtifactEncryptionConfig
+	logger           *slog.Logger
+	quit             chan struct{}
+
+	artifacts database.AppliedArtifactKeyRotator
+}
+
+// NewArtifactKeyRotator creates a new artifactKeyRotator, initializing the artifact encryption configuration and the
+// connection to the database where the applied artifacts are stored, encrypted with the given key ring.
+func NewArtifactKeyRotator(cfg config.Config, dbConn *gorm.DB, loglevel string, keys *database.ArtifactKeyRing) *artifactKeyRotator {
+	opts := setLogLvl(loglevel)
+	encryptionConfig := cfg.ArtifactEncryption
+	if encryptionConfig.BatchSize <= 0 {
+		encryptionConfig.BatchSize = defaultArtifactRotationBatchSize
+	}
+	return &artifactKeyRotator{
+		encryptionConfig: encryptionConfig,
+		logger:           slog.New(slog.NewTextHandler(os.Stdout, &opts)),
+		quit:             make(chan struct{}),
+
+		artifacts: &database.DBService{DB: dbConn, AlertmanagerShards: cfg.AlertManager.ShardCount(), ArtifactKeys: keys},
+	}
+}
+
+// Start allows the receiver to start re-encrypting the applied artifacts, once immediately and then periodically by means of
+// a ticker. Nothing is done if artifact encryption is disabled or the rotation interval is not set.
+// NOTE: Once this method is invoked, to stop re-encrypting the applied artifacts, we need to explicitly call Stop method from
+// the receiver.
+func (ar *artifactKeyRotator) Start(ctx context.Context) {
+	if !ar.encryptionConfig.Enabled || ar.encryptionConfig.RotationInterval <= 0 {
+		ar.logger.Info("Applied artifact key rotation is disabled")
+		return
+	}
+
+	go func() {
+		ar.Rotate(ctx)
+
+		ticker := time.NewTicker(ar.encryptionConfig.RotationInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ar.quit:
+				ar.logger.Info("Received signal: stopping applied artifact key rotator")
+				return
+			case <-ticker.C:
+				ar.Rotate(ctx)
+			}
+		}
+	}()
+}
+
+// Stop allows the receiver to stop re-encrypting the applied artifacts.
+func (ar *artifactKeyRotator) Stop() {
+	close(ar.quit)
+}
+
+// Rotate re-encrypts the applied artifacts which are not encrypted with the active key.
+func (ar *artifactKeyRotator) Rotate(ctx context.Context) {
+	rotated, err := ar.artifacts.RotateAppliedArtifactKeys(ctx, ar.encryptionConfig.BatchSize)
+	if err != nil {
+		ar.logger.Error("failed to re-encrypt applied artifacts", slog.Any("error", err), slog.Int64("rotated", rotated))
+		return
+	}
+	if rotated > 0 {
+		ar.logger.Info("re-encrypted applied artifacts", slog.Int64("rotated", rotated))
+	}
+}
@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package executor
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+)
+
+type AppliedArtifactKeyRotatorMock struct {
+	mock.Mock
+}
+
+func (m *AppliedArtifactKeyRotatorMock) RotateAppliedArtifactKeys(ctx context.Context, batchSize int) (int64, error) {
+	args := m.Called(ctx, batchSize)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func TestArtifactKeyRotator_Rotate(t *testing.T) {
+	newRotator := func(artifacts *AppliedArtifactKeyRotatorMock) *artifactKeyRotator {
+		return &artifactKeyRotator{
+			encryptionConfig: config.ArtifactEncryptionConfig{Enabled: true, ActiveKeyID: "new", BatchSize: 50},
+			logger:           slog.New(slog.NewTextHandler(io.Discard, nil)),
+			quit:             make(chan struct{}),
+			artifacts:        artifacts,
+		}
+	}
+
+	t.Run("Artifacts re-encrypted", func(t *testing.T) {
+		artifacts := &AppliedArtifactKeyRotatorMock{}
+		artifacts.On("RotateAppliedArtifactKeys", mock.Anything, 50).Return(int64(120), nil).Once()
+
+		newRotator(artifacts).Rotate(t.Context())
+		artifacts.AssertExpectations(t)
+	})
+
+	t.Run("Failed to re-encrypt artifacts", func(t *testing.T) {
+		artifacts := &AppliedArtifactKeyRotatorMock{}
+		artifacts.On("RotateAppliedArtifactKeys", mock.Anything, 50).
+			Return(int64(50), errors.New(`unknown artifact encryption key "old"`)).Once()
+
+		newRotator(artifacts).Rotate(t.Context())
+		artifacts.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package executor
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+)
+
+// historyCompactor periodically compacts the task history past its retention into daily rollups, and deletes the rollups past
+// theirs, so that the storage of the task history stays bounded while its long-term trends remain available.
+type historyCompactor struct {
+	retentionConfig config.HistoryRetentionConfig
+	logger          *slog.Logger
+	quit            chan struct{}
+
+	history database.TaskHistoryCompactor
+}
+
+// NewHistoryCompactor creates a new historyCompactor, initializing the history retention configuration and the connection to
+// the database where the task history is stored.
+func NewHistoryCompactor(cfg config.Config, dbConn *gorm.DB, loglevel string) *historyCompactor {
+	opts := setLogLvl(loglevel)
+	return &historyCompactor{
+		retentionConfig: cfg.HistoryRetention,
+		logger:          slog.New(slog.NewTextHandler(os.Stdout, &opts)),
+		quit:            make(chan struct{}),
+
+		history: &database.DBService{DB: dbConn, AlertmanagerShards: cfg.AlertManager.ShardCount()},
+	}
+}
+
+// Start allows the receiver to start compacting the task history periodically by means of a ticker. Nothing is done if the
+// compaction interval is not set.
+// NOTE: Once this method is invoked, to stop compacting the task history, we need to explicitly call Stop method from the receiver.
+func (hc *historyCompactor) Start(ctx context.Context) {
+	if hc.retentionConfig.CompactionInterval <= 0 {
+		hc.logger.Info("Task history compaction is disabled")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(hc.retentionConfig.CompactionInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-hc.quit:
+				hc.logger.Info("Received signal: stopping task history compactor")
+				return
+			case <-ticker.C:
+				hc.Compact(ctx)
+			}
+		}
+	}()
+}
+
+// Stop allows the receiver to stop compacting the task history.
+func (hc *historyCompactor) Stop() {
+	close(hc.quit)
+}
+
+// Compact compacts the task history completed before its raw retention into daily rollups, and deletes the rollups past the
+// rollup retention, if set.
+func (hc *historyCompactor) Compact(ctx context.Context) {
+	now := clock.TimeNowFn()
+	var rollupBefore time.Time
+	if hc.retentionConfig.RollupRetention > 0 {
+		rollupBefore = now.Add(-hc.retentionConfig.RollupRetention)
+	}
+
+	compacted, expired, err := hc.history.CompactTaskHistory(ctx, now.Add(-hc.retentionConfig.RawRetention), rollupBefore,
+		hc.retentionConfig.BatchSize)
+	if err != nil {
+		hc.logger.Error("failed to compact task history", slog.Any("error", err), slog.Int64("compacted", compacted))
+		return
+	}
+	if compacted > 0 || expired > 0 {
+		hc.logger.Info("compacted task history", slog.Int64("compacted", compacted), slog.Int64("expiredRollups", expired))
+	}
+}
@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package executor
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+)
+
+type TaskHistoryCompactorMock struct {
+	mock.Mock
+}
+
+func (m *TaskHistoryCompactorMock) CompactTaskHistory(ctx context.Context, rawBefore, rollupBefore time.Time, batchSize int) (int64, int64, error) {
+	args := m.Called(ctx, rawBefore, rollupBefore, batchSize)
+	return args.Get(0).(int64), args.Get(1).(int64), args.Error(2)
+}
+
+func TestHistoryCompactor_Compact(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	clock.SetFakeClock()
+	defer clock.UnsetFakeClock()
+	clock.FakeClock.Set(now)
+
+	newCompactor := func(retention config.HistoryRetentionConfig, history *TaskHistoryCompactorMock) *historyCompactor {
+		return &historyCompactor{
+			retentionConfig: retention,
+			logger:          slog.New(slog.NewTextHandler(io.Discard, nil)),
+			quit:            make(chan struct{}),
+			history:         history,
+		}
+	}
+
+	t.Run("History and rollups past their retention", func(t *testing.T) {
+		history := &TaskHistoryCompactorMock{}
+		history.On("CompactTaskHistory", mock.Anything, now.Add(-720*time.Hour), now.Add(-8760*time.Hour), 100).
+			Return(int64(3), int64(1), nil).Once()
+
+		newCompactor(config.HistoryRetentionConfig{RawRetention: 720 * time.Hour, RollupRetention: 8760 * time.Hour, BatchSize: 100},
+			history).Compact(t.Context())
+		history.AssertExpectations(t)
+	})
+
+	t.Run("Rollups kept forever", func(t *testing.T) {
+		history := &TaskHistoryCompactorMock{}
+		history.On("CompactTaskHistory", mock.Anything, now.Add(-720*time.Hour), time.Time{}, 0).
+			Return(int64(0), int64(0), errors.New("database unavailable")).Once()
+
+		newCompactor(config.HistoryRetentionConfig{RawRetention: 720 * time.Hour}, history).Compact(t.Context())
+		history.AssertExpectations(t)
+	})
+}
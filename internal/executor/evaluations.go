@@ -0,0 +1,142 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/mimir"
+)
+
+// slowRuleEvaluations counts the evaluations of the rule groups of alert definitions which took longer than the slow threshold.
+var slowRuleEvaluations = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "alerting_monitor_slow_rule_evaluations_total",
+	Help: "Number of evaluations of the rules of alert definitions taking longer than the slow threshold.",
+}, []string{"tenant"})
+
+// evaluationMonitor periodically scrapes the last evaluation of the rule groups of alert definitions from Mimir ruler, and
+// records them over a rolling window so that the evaluation health of alert definitions can be reported. Evaluations taking
+// longer than the slow threshold are logged and counted, to alert administrators of expressions too expensive to evaluate.
+type evaluationMonitor struct {
+	evaluationConfig config.RuleEvaluationConfig
+	logger           *slog.Logger
+	quit             chan struct{}
+
+	definitions database.ConfigStateReporter
+	evaluations database.RuleEvaluationRecorder
+	ruler       mimir.RuleEvaluationQuerier
+}
+
+// NewEvaluationMonitor creates a new evaluationMonitor, initializing the monitoring configuration, the connection to the database
+// where alert definitions and their evaluations are stored, and the struct that allows querying rule groups from Mimir.
+func NewEvaluationMonitor(cfg config.Config, dbConn *gorm.DB, loglevel string) *evaluationMonitor {
+	opts := setLogLvl(loglevel)
+	return &evaluationMonitor{
+		evaluationConfig: cfg.RuleEvaluation,
+		logger:           slog.New(slog.NewTextHandler(os.Stdout, &opts)),
+		quit:             make(chan struct{}),
+
+		definitions: &database.DBService{DB: dbConn, AlertmanagerShards: cfg.AlertManager.ShardCount()},
+		evaluations: &database.DBService{DB: dbConn, AlertmanagerShards: cfg.AlertManager.ShardCount()},
+		ruler:       &mimir.Mimir{Config: &cfg.Mimir},
+	}
+}
+
+// Start allows the receiver to start scraping rule evaluations periodically by means of a ticker. Nothing is done if
+// the scrape interval is not set.
+// NOTE: Once this method is invoked, to stop scraping rule evaluations, we need to explicitly call Stop method from the receiver.
+func (em *evaluationMonitor) Start(ctx context.Context) {
+	if em.evaluationConfig.ScrapeInterval <= 0 {
+		em.logger.Info("Rule evaluation monitoring is disabled")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(em.evaluationConfig.ScrapeInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-em.quit:
+				em.logger.Info("Received signal: stopping rule evaluation monitor")
+				return
+			case <-ticker.C:
+				em.scrapeEvaluations(ctx)
+			}
+		}
+	}()
+}
+
+// Stop allows the receiver to stop scraping rule evaluations.
+func (em *evaluationMonitor) Stop() {
+	close(em.quit)
+}
+
+// scrapeEvaluations records the last evaluation of the rule groups of the alert definitions of all tenants. A tenant whose rule
+// groups cannot be scraped does not stop the evaluations of other tenants from being recorded.
+func (em *evaluationMonitor) scrapeEvaluations(ctx context.Context) {
+	definitions, err := em.definitions.GetLatestAlertDefinitionStates(ctx)
+	if err != nil {
+		em.logger.Error("failed to get alert definitions", slog.Any("error", err))
+		return
+	}
+
+	tenants := make(map[string]map[uuid.UUID]string)
+	for _, def := range definitions {
+		if tenants[def.TenantID] == nil {
+			tenants[def.TenantID] = make(map[uuid.UUID]string)
+		}
+		tenants[def.TenantID][def.UUID] = def.Name
+	}
+
+	var evaluations []models.RuleEvaluation
+	for tenant, names := range tenants {
+		groups, err := em.ruler.GetRuleGroupEvaluations(ctx, tenant)
+		if err != nil {
+			em.logger.Error(fmt.Sprintf("failed to get rule group evaluations of tenant %q", tenant), slog.Any("error", err))
+			continue
+		}
+
+		for _, group := range groups {
+			id, err := uuid.Parse(group.Name)
+			if err != nil {
+				continue
+			}
+			name, ok := names[id]
+			if !ok {
+				continue
+			}
+
+			if em.evaluationConfig.SlowThreshold > 0 && group.Duration > em.evaluationConfig.SlowThreshold {
+				slowRuleEvaluations.WithLabelValues(tenant).Inc()
+				em.logger.Warn(fmt.Sprintf("rule of alert definition %q (%q) for tenant %q is too expensive to evaluate", name, id, tenant),
+					slog.Duration("duration", group.Duration), slog.Duration("threshold", em.evaluationConfig.SlowThreshold))
+			}
+
+			evaluations = append(evaluations, models.RuleEvaluation{
+				TenantID:            tenant,
+				AlertDefinitionUUID: id,
+				DurationSeconds:     group.Duration.Seconds(),
+				Error:               group.Error,
+				EvaluationDate:      group.LastEvaluation.UTC(),
+			})
+		}
+	}
+
+	if err := em.evaluations.RecordRuleEvaluations(ctx, evaluations, em.evaluationConfig.Window); err != nil {
+		em.logger.Error("failed to record rule evaluations", slog.Any("error", err))
+	}
+}
@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package executor
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/mimir"
+)
+
+type ConfigStateMock struct {
+	mock.Mock
+}
+
+func (m *ConfigStateMock) GetLatestAlertDefinitionStates(ctx context.Context) ([]models.AlertDefinition, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]models.AlertDefinition), args.Error(1)
+}
+
+func (m *ConfigStateMock) GetLatestReceiverStates(ctx context.Context) ([]models.Receiver, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]models.Receiver), args.Error(1)
+}
+
+type RuleEvaluationRecorderMock struct {
+	mock.Mock
+}
+
+func (m *RuleEvaluationRecorderMock) RecordRuleEvaluations(ctx context.Context, evaluations []models.RuleEvaluation, window time.Duration) error {
+	args := m.Called(ctx, evaluations, window)
+	return args.Error(0)
+}
+
+type RuleEvaluationQuerierMock struct {
+	mock.Mock
+}
+
+func (m *RuleEvaluationQuerierMock) GetRuleGroupEvaluations(ctx context.Context, tenant string) ([]mimir.RuleGroupEvaluation, error) {
+	args := m.Called(ctx, tenant)
+	return args.Get(0).([]mimir.RuleGroupEvaluation), args.Error(1)
+}
+
+func TestEvaluationMonitor_ScrapeEvaluations(t *testing.T) {
+	evaluationConfig := config.RuleEvaluationConfig{
+		Window:        time.Hour,
+		SlowThreshold: 5 * time.Second,
+	}
+	lastEvaluation := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	fastUUID := uuid.New()
+	slowUUID := uuid.New()
+	otherUUID := uuid.New()
+
+	dbMock := new(ConfigStateMock)
+	dbMock.On("GetLatestAlertDefinitionStates", mock.Anything).Return([]models.AlertDefinition{
+		{TenantID: "tenant", UUID: fastUUID, Name: "HighCPUUsage"},
+		{TenantID: "tenant", UUID: slowUUID, Name: "HighMemoryUsage"},
+		{TenantID: "other", UUID: otherUUID, Name: "HighCPUUsage"},
+	}, nil).Once()
+
+	mimirMock := new(RuleEvaluationQuerierMock)
+	mimirMock.On("GetRuleGroupEvaluations", mock.Anything, "tenant").Return([]mimir.RuleGroupEvaluation{
+		{Name: fastUUID.String(), Duration: 100 * time.Millisecond, LastEvaluation: lastEvaluation},
+		{Name: slowUUID.String(), Duration: 8 * time.Second, LastEvaluation: lastEvaluation, Error: "query timed out"},
+		// Rule groups not belonging to an alert definition are skipped.
+		{Name: "recording-rules", Duration: time.Second, LastEvaluation: lastEvaluation},
+		{Name: uuid.NewString(), Duration: time.Second, LastEvaluation: lastEvaluation},
+	}, nil).Once()
+	mimirMock.On("GetRuleGroupEvaluations", mock.Anything, "other").Return([]mimir.RuleGroupEvaluation(nil), errors.New("mock error")).Once()
+
+	recorderMock := new(RuleEvaluationRecorderMock)
+	recorderMock.On("RecordRuleEvaluations", mock.Anything, []models.RuleEvaluation{
+		{TenantID: "tenant", AlertDefinitionUUID: fastUUID, DurationSeconds: 0.1, EvaluationDate: lastEvaluation},
+		{TenantID: "tenant", AlertDefinitionUUID: slowUUID, DurationSeconds: 8, Error: "query timed out", EvaluationDate: lastEvaluation},
+	}, time.Hour).Return(nil).Once()
+
+	slowBefore := testutil.ToFloat64(slowRuleEvaluations.WithLabelValues("tenant"))
+
+	monitor := &evaluationMonitor{
+		evaluationConfig: evaluationConfig,
+		logger:           slog.New(slog.NewTextHandler(os.Stdout, nil)),
+		definitions:      dbMock,
+		evaluations:      recorderMock,
+		ruler:            mimirMock,
+	}
+	monitor.scrapeEvaluations(t.Context())
+
+	// A tenant whose rule groups cannot be scraped does not stop the evaluations of other tenants from being recorded.
+	dbMock.AssertExpectations(t)
+	mimirMock.AssertExpectations(t)
+	recorderMock.AssertExpectations(t)
+	require.InDelta(t, slowBefore+1, testutil.ToFloat64(slowRuleEvaluations.WithLabelValues("tenant")), 0)
+}
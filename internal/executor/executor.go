@@ -6,21 +6,55 @@ package executor
 import (
 	"context"
 	"errors"
+	"expvar"
 	"fmt"
 	"log/slog"
 	"os"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	"gorm.io/gorm"
 
 	am "github.com/open-edge-platform/o11y-alerting-monitor/internal/alertmanager"
 	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/correlation"
 	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
 	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
 	"github.com/open-edge-platform/o11y-alerting-monitor/internal/mimir"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/snapshot"
 )
 
+// Timing of the task loop, published under "executor" in /debug/vars. A start time later than the finish time of the last
+// iteration tells that the executor hangs while processing tasks, rather than being idle.
+var (
+	loopIterations     = new(expvar.Int)
+	loopLastStartedAt  = new(expvar.String)
+	loopLastFinishedAt = new(expvar.String)
+	loopLastDuration   = new(expvar.Float)
+)
+
+// Task cleanup metrics, telling how many tasks past retention are deleted.
+var (
+	cleanedUpTasks = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "alerting_monitor_cleaned_up_tasks_total",
+		Help: "Number of tasks past retention deleted and archived into the task history.",
+	})
+	lastCleanedUpTasks = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "alerting_monitor_last_cleaned_up_tasks",
+		Help: "Number of tasks past retention deleted by the last cleanup.",
+	})
+)
+
+func init() {
+	stats := expvar.NewMap("executor")
+	stats.Set("iterations", loopIterations)
+	stats.Set("lastStartedAt", loopLastStartedAt)
+	stats.Set("lastFinishedAt", loopLastFinishedAt)
+	stats.Set("lastDurationSeconds", loopLastDuration)
+}
+
 // asyncExecutor represents a mechanism that allows to process tasks asynchronously. It supports two types of tasks:
 // receiver and definition tasks. Receiver tasks are related to configuration of alertmanager receivers and routing actions,
 // whereas definition tasks are related to configuration of alert definitions of mimir.
@@ -36,34 +70,61 @@ type asyncExecutor struct {
 
 	receiversCfg   am.AlertmanagerConfigurator
 	definitionsCfg mimir.DefinitionConfigUpdater
+
+	// invalidTasks archives Invalid tasks before they are deleted, nil if they are not archived.
+	invalidTasks *invalidTaskArchiver
 }
 
 // NewAsyncExecutor creates a new asyncExecutor, initializing the UUID of the corresponding instance, configuration parameters,
 // connection to the database where tasks are stored, and the struct that allows to reconfigure alertmanager config.
 func NewAsyncExecutor(
-	ownerUUID uuid.UUID, cfg config.Config, dbConn *gorm.DB, loglevel string, alertManager *am.AlertManager) *asyncExecutor {
+	ownerUUID uuid.UUID, cfg config.Config, dbConn *gorm.DB, loglevel string, alertManager am.NotificationBackend,
+	artifactKeys *database.ArtifactKeyRing,
+) *asyncExecutor {
 	opts := setLogLvl(loglevel)
+	var invalidTasks *invalidTaskArchiver
+	if cfg.TaskArchive.Enabled {
+		invalidTasks = &invalidTaskArchiver{store: snapshot.New(cfg.Snapshot), prefix: cfg.TaskArchive.Prefix}
+	}
 	return &asyncExecutor{
 		ownerUUID:      ownerUUID,
 		executorConfig: cfg.TaskExecutor,
 		logger:         slog.New(slog.NewTextHandler(os.Stdout, &opts)),
 		quit:           make(chan struct{}),
 
-		definitionsCfg: &mimir.Mimir{Config: &cfg.Mimir},
-		receiversCfg:   alertManager,
+		definitionsCfg: &mimir.Mimir{
+			Config:     &cfg.Mimir,
+			Applied:    &database.DBService{DB: dbConn, AlertmanagerShards: cfg.AlertManager.ShardCount(), ArtifactKeys: artifactKeys},
+			TierLevels: cfg.TenantTiers,
+			Tiers:      &database.DBService{DB: dbConn, AlertmanagerShards: cfg.AlertManager.ShardCount()},
+		},
+		receiversCfg: alertManager,
+
+		definitions: &database.DBService{DB: dbConn, AlertmanagerShards: cfg.AlertManager.ShardCount()},
+		receivers:   &database.DBService{DB: dbConn, AlertmanagerShards: cfg.AlertManager.ShardCount()},
+		tasks:       &database.DBService{DB: dbConn, AlertmanagerShards: cfg.AlertManager.ShardCount(), EventHooks: cfg.EventHooks.Enabled},
 
-		definitions: &database.DBService{DB: dbConn},
-		receivers:   &database.DBService{DB: dbConn},
-		tasks:       &database.DBService{DB: dbConn},
+		invalidTasks: invalidTasks,
 	}
 }
 
 // Start allows the receiver to start processing tasks stored into the database. Tasks are processed periodically by means of a ticker.
 // NOTE: Once this method is invoked, to stop processing tasks, we need to explicitly call Stop method from the receiver.
 func (ae *asyncExecutor) Start(ctx context.Context) {
+	if len(ae.executorConfig.PausedKinds) > 0 {
+		ae.logger.Warn("tasks of paused kinds are not applied", slog.Any("paused_kinds", ae.executorConfig.PausedKinds))
+	}
+
+	heartbeats := ae.executorConfig.HeartbeatTimeout > 0
+
 	go func() {
 		i := 0
 
+		if heartbeats {
+			ae.reclaimOrphanedTasks(ctx)
+			go ae.sendHeartbeats(ctx)
+		}
+
 		processTicker := time.NewTicker(ae.executorConfig.PoolingRate)
 		defer processTicker.Stop()
 
@@ -74,7 +135,17 @@ func (ae *asyncExecutor) Start(ctx context.Context) {
 				return
 			case <-processTicker.C:
 				// TODO: What if ticker is exceeded? Skips it.
-				ae.processTasks(ctx)
+				start := time.Now()
+				loopLastStartedAt.Set(start.Format(time.RFC3339Nano))
+				processed := ae.processTasks(ctx)
+				if heartbeats {
+					if err := ae.tasks.RecordExecutorPoll(ctx, ae.ownerUUID, processed); err != nil {
+						ae.logger.Error("failed to record executor poll", slog.Any("error", err))
+					}
+				}
+				loopIterations.Add(1)
+				loopLastDuration.Set(time.Since(start).Seconds())
+				loopLastFinishedAt.Set(time.Now().Format(time.RFC3339Nano))
 
 				if i%30 == 0 {
 					if err := ae.tasks.SetTakenTasksExceedingDurationAsFailed(ctx, ae.executorConfig.TaskTimeout, ae.executorConfig.RetryLimit); err != nil {
@@ -86,10 +157,7 @@ func (ae *asyncExecutor) Start(ctx context.Context) {
 				// needs to pass quit channel to stop.
 				// Delete (check) old tasks every 1000th loop run
 				if i == 5 {
-					err := ae.tasks.DeleteNotPendingTasksExceedingDuration(ctx, ae.executorConfig.RetentionTime)
-					if err != nil {
-						ae.logger.Error("failed to clean up not pending tasks", slog.Any("error", err))
-					}
+					ae.cleanUpTasks(ctx)
 				}
 
 				i = (i + 1) % 1000
@@ -98,6 +166,74 @@ func (ae *asyncExecutor) Start(ctx context.Context) {
 	}()
 }
 
+// reclaimOrphanedTasks sets the tasks left Taken by dead executors, and by a previous run of this executor, back to New, and
+// then records the first heartbeat of the executor so that other executors starting do not reclaim its tasks.
+func (ae *asyncExecutor) reclaimOrphanedTasks(ctx context.Context) {
+	reclaimed, err := ae.tasks.ReclaimOrphanedTasks(ctx, ae.ownerUUID, ae.executorConfig.HeartbeatTimeout)
+	if err != nil {
+		ae.logger.Error("failed to reclaim tasks of dead executors", slog.Any("error", err))
+	} else if reclaimed > 0 {
+		ae.logger.Info("reclaimed tasks of dead executors", slog.Int64("reclaimed", reclaimed))
+	}
+
+	if err := ae.tasks.RecordExecutorHeartbeat(ctx, ae.ownerUUID); err != nil {
+		ae.logger.Error("failed to record executor heartbeat", slog.Any("error", err))
+	}
+}
+
+// sendHeartbeats records a heartbeat of the executor every heartbeat interval until it is stopped. Heartbeats are sent apart
+// from the processing of tasks, so that a slow task does not get the executor considered dead and its tasks reclaimed.
+func (ae *asyncExecutor) sendHeartbeats(ctx context.Context) {
+	ticker := time.NewTicker(ae.executorConfig.Heartbeat())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ae.quit:
+			return
+		case <-ticker.C:
+			if err := ae.tasks.RecordExecutorHeartbeat(ctx, ae.ownerUUID); err != nil {
+				ae.logger.Error("failed to record executor heartbeat", slog.Any("error", err))
+			}
+		}
+	}
+}
+
+// cleanUpTasks deletes the tasks past retention in batches, archiving Invalid tasks first if enabled, and reports the number
+// of deleted tasks, including those deleted before an error, through the task cleanup metrics and a log entry.
+func (ae *asyncExecutor) cleanUpTasks(ctx context.Context) {
+	opts := database.TaskCleanupOptions{
+		BatchSize: ae.executorConfig.CleanupBatchSize,
+		Pause:     ae.executorConfig.CleanupBatchPause,
+	}
+	if ae.invalidTasks != nil {
+		opts.ArchiveInvalid = ae.invalidTasks.archive
+	}
+
+	deleted, err := ae.tasks.DeleteNotPendingTasksExceedingDuration(ctx, ae.executorConfig.RetentionTime, opts)
+	cleanedUpTasks.Add(float64(deleted))
+	lastCleanedUpTasks.Set(float64(deleted))
+	if err != nil {
+		ae.logger.Error("failed to clean up not pending tasks", slog.Int64("deleted", deleted), slog.Any("error", err))
+		return
+	}
+	ae.logger.Info("cleaned up not pending tasks", slog.Int64("deleted", deleted))
+}
+
+// pendingTaskOptions returns the options of the pending tasks taken at once, the tasks of paused kinds being left pending.
+func (ae *asyncExecutor) pendingTaskOptions() database.PendingTaskOptions {
+	opts := database.PendingTaskOptions{
+		CountLimit:  ae.executorConfig.UUIDLimit,
+		TenantLimit: ae.executorConfig.TenantLimit,
+	}
+	for _, kind := range []models.TaskType{models.TypeAlertDefinition, models.TypeReceiver} {
+		if ae.executorConfig.KindPaused(string(kind)) {
+			opts.PausedKinds = append(opts.PausedKinds, kind)
+		}
+	}
+	return opts
+}
+
 // Stop allows the receiver to stop processing tasks.
 func (ae *asyncExecutor) Stop() {
 	close(ae.quit)
@@ -105,16 +241,16 @@ func (ae *asyncExecutor) Stop() {
 
 // processTasks fetches tasks from database which are pending and attempt to execute them. A task is considered to be pending
 // if its state is either 'New' or 'Error'. It also checks if there are older versions of the taken tasks in the database. If so,
-// they are set to 'Invalid' state.
-func (ae *asyncExecutor) processTasks(ctx context.Context) {
-	takenTasks, err := ae.tasks.GetPendingTasks(ctx, ae.ownerUUID, ae.executorConfig.UUIDLimit)
+// they are set to 'Invalid' state. The number of processed tasks, either successfully or not, is returned.
+func (ae *asyncExecutor) processTasks(ctx context.Context) int {
+	takenTasks, err := ae.tasks.GetPendingTasks(ctx, ae.ownerUUID, ae.pendingTaskOptions())
 	if err != nil {
 		ae.logger.Error("failed to get pending tasks", slog.Any("error", err))
-		return
+		return 0
 	}
 
 	if len(takenTasks) == 0 {
-		return
+		return 0
 	}
 
 	if err := ae.tasks.SetOlderVersionsToInvalidState(ctx, takenTasks); err != nil {
@@ -124,13 +260,17 @@ func (ae *asyncExecutor) processTasks(ctx context.Context) {
 	for _, task := range takenTasks {
 		t := task
 
-		if err := ae.executeTask(ctx, &t); err != nil {
+		// The correlation ID of the request which created the task is propagated to downstream services and logged on failure,
+		// so that the failure can be traced back to the request.
+		if err := ae.executeTask(correlation.NewContext(ctx, t.CorrelationID), &t); err != nil {
 			ae.logger.Error(
 				fmt.Sprintf("failed to execute task %q with version %d", t.GetTaskUUID(), t.Version),
+				slog.String("request_id", t.CorrelationID),
 				slog.Any("error", err),
 			)
 		}
 	}
+	return len(takenTasks)
 }
 
 // executeTask attempts to execute a given task with a specific timeout.
@@ -156,7 +296,7 @@ func (ae *asyncExecutor) executeTask(ctx context.Context, task *models.Task) err
 	for {
 		select {
 		case <-ctxWithTimeout.Done():
-			if err := ae.tasks.SetTaskAsFailed(ctx, *task, ae.executorConfig.RetryLimit); err != nil {
+			if err := ae.tasks.SetTaskAsFailed(ctx, *task, ae.executorConfig.RetryLimit, ctxWithTimeout.Err()); err != nil {
 				ae.logger.Error("failed to handle task exceeding timeout", slog.Any("error", err))
 			}
 
@@ -180,7 +320,7 @@ func (ae *asyncExecutor) handleReceiverTask(ctx context.Context, task *models.Ta
 			fmt.Sprintf("failed to retrieve receiver %q with version %d", task.ReceiverUUID.String(), task.Version),
 			slog.Any("error", err),
 		)
-		return ae.tasks.SetTaskAsFailed(ctx, *task, ae.executorConfig.RetryLimit)
+		return ae.tasks.SetTaskAsFailed(ctx, *task, ae.executorConfig.RetryLimit, err)
 	}
 
 	if err := ae.receivers.SetReceiverState(ctx, r.TenantID, r.UUID, int64(r.Version), models.ReceiverPending); err != nil {
@@ -188,7 +328,7 @@ func (ae *asyncExecutor) handleReceiverTask(ctx context.Context, task *models.Ta
 			fmt.Sprintf("failed to set receiver %q with version %d state to 'Pending'", r.UUID.String(), r.Version),
 			slog.Any("error", err),
 		)
-		return ae.tasks.SetTaskAsFailed(ctx, *task, ae.executorConfig.RetryLimit)
+		return ae.tasks.SetTaskAsFailed(ctx, *task, ae.executorConfig.RetryLimit, err)
 	}
 
 	err = ae.receiversCfg.UpdateReceiverConfig(ctx, *r)
@@ -197,7 +337,7 @@ func (ae *asyncExecutor) handleReceiverTask(ctx context.Context, task *models.Ta
 			fmt.Sprintf("failed to apply receiver %q and version %d due to internal error", r.UUID.String(), r.Version),
 			slog.Any("error", err),
 		)
-		return ae.tasks.SetTaskAsFailed(ctx, *task, ae.executorConfig.RetryLimit)
+		return ae.tasks.SetTaskAsFailed(ctx, *task, ae.executorConfig.RetryLimit, err)
 	}
 
 	return ae.tasks.SetTaskAsApplied(ctx, *task)
@@ -216,7 +356,7 @@ func (ae *asyncExecutor) handleDefinitionTask(ctx context.Context, task *models.
 			fmt.Sprintf("failed to retrieve alert definition %q with version %d", task.AlertDefinitionUUID.String(), task.Version),
 			slog.Any("error", err),
 		)
-		return ae.tasks.SetTaskAsFailed(ctx, *task, ae.executorConfig.RetryLimit)
+		return ae.tasks.SetTaskAsFailed(ctx, *task, ae.executorConfig.RetryLimit, err)
 	}
 	err = ae.definitions.SetAlertDefinitionState(ctx, alertDef.TenantID, alertDef.ID, alertDef.Version, models.DefinitionPending)
 	if err != nil {
@@ -224,7 +364,7 @@ func (ae *asyncExecutor) handleDefinitionTask(ctx context.Context, task *models.
 			fmt.Sprintf("failed to set alert definition %q with version %d state to 'Pending'", alertDef.ID.String(), alertDef.Version),
 			slog.Any("error", err),
 		)
-		return ae.tasks.SetTaskAsFailed(ctx, *task, ae.executorConfig.RetryLimit)
+		return ae.tasks.SetTaskAsFailed(ctx, *task, ae.executorConfig.RetryLimit, err)
 	}
 
 	err = ae.definitionsCfg.UpdateDefinitionConfig(ctx, alertDef)
@@ -233,7 +373,7 @@ func (ae *asyncExecutor) handleDefinitionTask(ctx context.Context, task *models.
 			fmt.Sprintf("failed to update Mimir alert definition %q with version %d", alertDef.ID.String(), alertDef.Version),
 			slog.Any("error", err),
 		)
-		return ae.tasks.SetTaskAsFailed(ctx, *task, ae.executorConfig.RetryLimit)
+		return ae.tasks.SetTaskAsFailed(ctx, *task, ae.executorConfig.RetryLimit, err)
 	}
 
 	return ae.tasks.SetTaskAsApplied(ctx, *task)
@@ -12,7 +12,9 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
@@ -80,6 +82,10 @@ func (s *ExecuteReceiverTaskSuite) SetupSubTest() {
 		&models.Receiver{},
 		&models.EmailRecipient{},
 		&models.Task{},
+		&models.TaskHistory{},
+		&models.Tenant{},
+		&models.EmailTemplate{},
+		&models.Executor{},
 	))
 
 	s.dbSrv = database.DBService{DB: s.db}
@@ -119,6 +125,8 @@ func (s *ExecuteReceiverTaskSuite) SetupSubTest() {
 	recvInfo.Name = "receiver"
 	recvInfo.TenantID = "edgenode"
 	recvInfo.Version = 5
+	recvInfo.CreatedAt = clock.FakeClock.Now().UTC()
+	recvInfo.UpdatedAt = clock.FakeClock.Now().UTC()
 	receiverID := int64(10)
 	recv := models.Receiver{
 		ID:            receiverID,
@@ -176,6 +184,7 @@ func (s *ExecuteReceiverTaskSuite) TearDownSubTest() {
 	clock.UnsetFakeClock()
 
 	s.db.Exec("DELETE FROM tasks")
+	s.db.Exec("DELETE FROM executors")
 	s.db.Exec("DELETE FROM email_recipients")
 	s.db.Exec("DELETE FROM receivers")
 	s.db.Exec("DELETE FROM email_configs")
@@ -225,6 +234,7 @@ func (s *ExecuteReceiverTaskSuite) TestExecuteTask() {
 			CreationDate: s.task.CreationDate,
 			RetryCount:   1,
 			TenantID:     s.task.TenantID,
+			Error:        "mock error",
 		}, taskOut)
 
 		// Check receiver status was set to error as well.
@@ -239,6 +249,8 @@ func (s *ExecuteReceiverTaskSuite) TestExecuteTask() {
 			To:         s.recv.To,
 			State:      models.ReceiverError,
 			TenantID:   s.recv.TenantID,
+			CreatedAt:  s.recv.CreatedAt,
+			UpdatedAt:  s.recv.UpdatedAt,
 		}, recvInfoOut)
 
 		s.Require().True(mReceivers.AssertExpectations(s.T()))
@@ -276,6 +288,7 @@ func (s *ExecuteReceiverTaskSuite) TestExecuteTask() {
 				CreationDate: s.task.CreationDate,
 				RetryCount:   s.task.RetryCount + 1,
 				TenantID:     s.task.TenantID,
+				Error:        "context deadline exceeded",
 				// StartDate:    clock.FakeClock.Now().UTC(),
 			},
 		}, res)
@@ -331,6 +344,9 @@ func (s *ExecuteReceiverTaskSuite) TestExecuteTask() {
 			To:         s.recv.To,
 			State:      models.ReceiverApplied,
 			TenantID:   s.recv.TenantID,
+			CreatedAt:  s.recv.CreatedAt,
+			UpdatedAt:  s.recv.UpdatedAt,
+			AppliedAt:  &completionDate,
 		}, recvInfoOut)
 
 		s.Require().True(mReceivers.AssertExpectations(s.T()))
@@ -542,6 +558,7 @@ func (s *ExecuteReceiverTaskSuite) TestExecutor() {
 				RetryCount:     takenTask.RetryCount,
 				Version:        takenTask.Version,
 				TenantID:       takenTask.TenantID,
+				Error:          "task exceeded timeout of 30s",
 			},
 		}, res)
 
@@ -673,6 +690,7 @@ func (s *ExecuteReceiverTaskSuite) TestExecutor() {
 				StartDate:      clock.FakeClock.Now().UTC(),
 				CompletionDate: clock.FakeClock.Now().UTC(),
 				TenantID:       s.task.TenantID,
+				Error:          "mock error",
 			},
 		}, res)
 
@@ -726,9 +744,83 @@ func (s *ExecuteReceiverTaskSuite) TestExecutor() {
 				StartDate:      clock.FakeClock.Now().UTC(),
 				CompletionDate: clock.FakeClock.Now().UTC(),
 				TenantID:       s.task.TenantID,
+				Error:          "mock error",
 			},
 		}, res)
 
+		s.Require().True(mReceivers.AssertExpectations(s.T()))
+	})
+	s.Run("Tasks of an executor busy with a slow task are not reclaimed by an executor starting", func() {
+		started, release := make(chan struct{}), make(chan struct{})
+		mReceivers := &RecvConfigMock{}
+		mReceivers.On("UpdateReceiverConfig", mock.Anything, *s.recv).Run(func(mock.Arguments) {
+			close(started)
+			<-release
+		}).Return(nil).Once()
+
+		executorConfig := config.TaskExecutorConfig{
+			UUIDLimit:         2,
+			RetryLimit:        5,
+			PoolingRate:       10 * time.Millisecond,
+			TaskTimeout:       time.Hour,
+			RetentionTime:     time.Hour,
+			HeartbeatTimeout:  time.Minute,
+			HeartbeatInterval: 10 * time.Millisecond,
+		}
+		busy := &asyncExecutor{
+			ownerUUID:      uuid.New(),
+			executorConfig: executorConfig,
+			logger:         slog.New(slog.NewTextHandler(os.Stdout, nil)),
+			quit:           make(chan struct{}),
+
+			tasks:     &database.DBService{DB: s.db},
+			receivers: &database.DBService{DB: s.db},
+
+			receiversCfg: mReceivers,
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+		defer cancel()
+
+		busy.Start(ctx)
+		defer busy.Stop()
+		select {
+		case <-started:
+		case <-time.After(5 * time.Second):
+			s.FailNow("the task was not taken")
+		}
+
+		// The slow task outlasts the heartbeat timeout, during which heartbeats keep being sent.
+		clock.FakeClock.Add(2 * executorConfig.HeartbeatTimeout)
+		s.Require().Eventually(func() bool {
+			var executor models.Executor
+			err := s.db.WithContext(ctx).Take(&executor, "uuid = ?", busy.ownerUUID).Error
+			return err == nil && executor.HeartbeatDate.Equal(clock.FakeClock.Now().UTC())
+		}, 5*time.Second, 10*time.Millisecond)
+
+		starting := &asyncExecutor{
+			ownerUUID:      uuid.New(),
+			executorConfig: executorConfig,
+			logger:         slog.New(slog.NewTextHandler(os.Stdout, nil)),
+			tasks:          &database.DBService{DB: s.db},
+		}
+		starting.reclaimOrphanedTasks(ctx)
+
+		var task models.Task
+		s.Require().NoError(s.db.WithContext(ctx).Take(&task, s.task.ID).Error)
+		s.Require().Equal(models.TaskTaken, task.State)
+		s.Require().Equal(busy.ownerUUID, task.OwnerUUID)
+
+		var executors int64
+		s.Require().NoError(s.db.WithContext(ctx).Model(&models.Executor{}).Count(&executors).Error)
+		s.Require().Equal(int64(2), executors)
+
+		close(release)
+		s.Require().Eventually(func() bool {
+			err := s.db.WithContext(ctx).Take(&task, s.task.ID).Error
+			return err == nil && task.State == models.TaskApplied
+		}, 5*time.Second, 10*time.Millisecond)
+
 		s.Require().True(mReceivers.AssertExpectations(s.T()))
 	})
 }
@@ -758,9 +850,12 @@ func (s *ExecuteDefinitionTaskTestSuite) SetupSubTest() {
 
 	s.Require().NoError(s.db.AutoMigrate(
 		&models.Task{},
+		&models.TaskHistory{},
 		&models.AlertDefinition{},
 		&models.AlertThreshold{},
 		&models.AlertDuration{},
+		&models.Tenant{},
+		&models.EmailTemplate{},
 	))
 
 	// TODO: To be removed.
@@ -814,10 +909,13 @@ func (s *ExecuteDefinitionTaskTestSuite) SetupSubTest() {
 			Duration:  &duration.Duration,
 			Threshold: &threshold.Threshold,
 			Enabled:   &def.Enabled,
+			AutoTune:  &def.AutoTune,
 		},
-		Interval: def.AlertInterval,
-		Version:  def.Version,
-		TenantID: def.TenantID,
+		Interval:  def.AlertInterval,
+		Version:   def.Version,
+		TenantID:  def.TenantID,
+		CreatedAt: clock.FakeClock.Now().UTC(),
+		UpdatedAt: clock.FakeClock.Now().UTC(),
 	}
 
 	defTask := &models.Task{
@@ -934,6 +1032,7 @@ func (s *ExecuteDefinitionTaskTestSuite) TestProcessTasks() {
 				CreationDate:        s.task.CreationDate,
 				StartDate:           clock.FakeClock.Now().UTC(),
 				TenantID:            s.task.TenantID,
+				Error:               "mock error",
 			},
 		}, res)
 
@@ -1088,20 +1187,23 @@ func (s *ExecuteDefinitionTaskTestSuite) TestExecuteTask() {
 			CreationDate:        s.task.CreationDate,
 			RetryCount:          1,
 			TenantID:            s.task.TenantID,
+			Error:               "mock error",
 		}, updatedTask)
 
 		defInfoOut, err := aExec.definitions.GetAlertDefinition(ctx, s.def.TenantID, s.def.ID, s.def.Version)
 		s.Require().NoError(err)
 		s.Require().Equal(&models.DBAlertDefinition{
-			ID:       s.def.ID,
-			Name:     s.def.Name,
-			State:    models.DefinitionError,
-			Template: s.def.Template,
-			Category: s.def.Category,
-			Values:   s.def.Values,
-			Interval: s.def.Interval,
-			Version:  s.def.Version,
-			TenantID: s.def.TenantID,
+			ID:        s.def.ID,
+			Name:      s.def.Name,
+			State:     models.DefinitionError,
+			Template:  s.def.Template,
+			Category:  s.def.Category,
+			Values:    s.def.Values,
+			Interval:  s.def.Interval,
+			Version:   s.def.Version,
+			TenantID:  s.def.TenantID,
+			CreatedAt: s.def.CreatedAt,
+			UpdatedAt: s.def.UpdatedAt,
 		}, defInfoOut)
 
 		s.Require().True(mDefinitions.AssertExpectations(s.T()))
@@ -1149,17 +1251,158 @@ func (s *ExecuteDefinitionTaskTestSuite) TestExecuteTask() {
 		defInfoOut, err := aExec.definitions.GetAlertDefinition(ctx, s.def.TenantID, s.def.ID, s.def.Version)
 		s.Require().NoError(err)
 		s.Require().Equal(&models.DBAlertDefinition{
-			ID:       s.def.ID,
-			Name:     s.def.Name,
-			State:    models.DefinitionApplied,
-			Template: s.def.Template,
-			Category: s.def.Category,
-			Values:   s.def.Values,
-			Interval: s.def.Interval,
-			Version:  s.def.Version,
-			TenantID: s.def.TenantID,
+			ID:        s.def.ID,
+			Name:      s.def.Name,
+			State:     models.DefinitionApplied,
+			Template:  s.def.Template,
+			Category:  s.def.Category,
+			Values:    s.def.Values,
+			Interval:  s.def.Interval,
+			Version:   s.def.Version,
+			TenantID:  s.def.TenantID,
+			CreatedAt: s.def.CreatedAt,
+			UpdatedAt: s.def.UpdatedAt,
+			AppliedAt: &completionDate,
 		}, defInfoOut)
 
 		s.Require().True(mDefinitions.AssertExpectations(s.T()))
 	})
 }
+
+func TestCleanUpTasks(t *testing.T) {
+	conn, err := gorm.Open(sqlite.Open(":memory:"))
+	require.NoError(t, err)
+	require.NoError(t, conn.AutoMigrate(&models.Task{}, &models.TaskHistory{}))
+
+	clock.SetFakeClock()
+	defer clock.UnsetFakeClock()
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	clock.FakeClock.Set(now)
+
+	for i := range 5 {
+		require.NoError(t, conn.Create(&models.Task{
+			AlertDefinitionUUID: uuidPtr(uuid.New()),
+			TenantID:            "edgenode",
+			State:               models.TaskApplied,
+			StartDate:           now.Add(-2 * time.Hour),
+			CompletionDate:      now.Add(-time.Hour - time.Duration(i)*time.Minute),
+		}).Error)
+	}
+	require.NoError(t, conn.Create(&models.Task{
+		AlertDefinitionUUID: uuidPtr(uuid.New()),
+		TenantID:            "edgenode",
+		State:               models.TaskApplied,
+		StartDate:           now.Add(-time.Minute),
+		CompletionDate:      now,
+	}).Error)
+
+	aExec := &asyncExecutor{
+		executorConfig: config.TaskExecutorConfig{
+			RetentionTime:    30 * time.Minute,
+			CleanupBatchSize: 2,
+		},
+		tasks:  &database.DBService{DB: conn},
+		logger: slog.New(slog.NewTextHandler(os.Stdout, nil)),
+	}
+	before := promtestutil.ToFloat64(cleanedUpTasks)
+	aExec.cleanUpTasks(t.Context())
+
+	require.InDelta(t, 5, promtestutil.ToFloat64(cleanedUpTasks)-before, 0)
+	require.InDelta(t, 5, promtestutil.ToFloat64(lastCleanedUpTasks), 0)
+
+	var remaining, archived int64
+	require.NoError(t, conn.Model(&models.Task{}).Count(&remaining).Error)
+	require.NoError(t, conn.Model(&models.TaskHistory{}).Count(&archived).Error)
+	require.Equal(t, int64(1), remaining)
+	require.Equal(t, int64(5), archived)
+}
+
+func TestReclaimOrphanedTasks(t *testing.T) {
+	conn, err := gorm.Open(sqlite.Open(":memory:"))
+	require.NoError(t, err)
+	require.NoError(t, conn.AutoMigrate(&models.Task{}, &models.Executor{}))
+
+	clock.SetFakeClock()
+	defer clock.UnsetFakeClock()
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	clock.FakeClock.Set(now)
+
+	self, alive, dead, unregistered := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	require.NoError(t, conn.Create(&[]models.Executor{
+		{UUID: alive, StartDate: now.Add(-time.Hour), HeartbeatDate: now.Add(-10 * time.Second)},
+		{UUID: dead, StartDate: now.Add(-time.Hour), HeartbeatDate: now.Add(-30 * time.Minute)},
+	}).Error)
+
+	tasks := map[uuid.UUID]models.TaskState{}
+	for owner, state := range map[uuid.UUID]models.TaskState{
+		self:         models.TaskTaken,
+		alive:        models.TaskTaken,
+		dead:         models.TaskTaken,
+		unregistered: models.TaskTaken,
+		uuid.Nil:     models.TaskApplied,
+	} {
+		task := models.Task{
+			AlertDefinitionUUID: uuidPtr(uuid.New()),
+			TenantID:            "edgenode",
+			OwnerUUID:           owner,
+			State:               state,
+			StartDate:           now.Add(-time.Minute),
+		}
+		require.NoError(t, conn.Create(&task).Error)
+		tasks[owner] = state
+	}
+
+	aExec := &asyncExecutor{
+		ownerUUID:      self,
+		executorConfig: config.TaskExecutorConfig{HeartbeatTimeout: time.Minute},
+		tasks:          &database.DBService{DB: conn},
+		logger:         slog.New(slog.NewTextHandler(os.Stdout, nil)),
+	}
+	aExec.reclaimOrphanedTasks(t.Context())
+
+	expected := map[uuid.UUID]models.TaskState{}
+	for owner := range tasks {
+		expected[owner] = models.TaskNew
+	}
+	expected[alive] = models.TaskTaken
+	// Tasks of owners which are not registered may be taken by executors running without heartbeats.
+	expected[unregistered] = models.TaskTaken
+	expected[uuid.Nil] = models.TaskApplied
+
+	var reclaimed []models.Task
+	require.NoError(t, conn.Find(&reclaimed).Error)
+	states := map[uuid.UUID]models.TaskState{}
+	for _, task := range reclaimed {
+		states[task.OwnerUUID] = task.State
+	}
+	require.Equal(t, expected, states)
+
+	var executors []models.Executor
+	require.NoError(t, conn.Order("heartbeat_date").Find(&executors).Error)
+	require.Len(t, executors, 2)
+	require.Equal(t, alive, executors[0].UUID)
+	require.Equal(t, self, executors[1].UUID)
+	require.True(t, now.Equal(executors[1].HeartbeatDate))
+
+	clock.FakeClock.Add(20 * time.Second)
+	require.NoError(t, aExec.tasks.RecordExecutorHeartbeat(t.Context(), self))
+	var executor models.Executor
+	require.NoError(t, conn.Take(&executor, "uuid = ?", self).Error)
+	require.True(t, now.Equal(executor.StartDate))
+	require.True(t, now.Add(20*time.Second).Equal(executor.HeartbeatDate))
+}
+
+func TestPendingTaskOptions(t *testing.T) {
+	aExec := &asyncExecutor{
+		executorConfig: config.TaskExecutorConfig{
+			UUIDLimit:   3,
+			TenantLimit: 2,
+			PausedKinds: []string{"AlertDefinition", "Unknown"},
+		},
+	}
+	require.Equal(t, database.PendingTaskOptions{
+		CountLimit:  3,
+		TenantLimit: 2,
+		PausedKinds: []models.TaskType{models.TypeAlertDefinition},
+	}, aExec.pendingTaskOptions())
+}
@@ -0,0 +1,202 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/mail"
+	"os"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/notify/email"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/report"
+)
+
+const (
+	// defaultReportCheckInterval is the interval between checks for reports to generate if not configured.
+	defaultReportCheckInterval = time.Hour
+	// defaultReportTopAlerts is the number of noisiest alert groups of reports if not configured.
+	defaultReportTopAlerts = 10
+)
+
+// reportMailer sends an email with an HTML body.
+type reportMailer interface {
+	Send(ctx context.Context, to *mail.Address, subject, body string) error
+}
+
+// alertReporter periodically generates the report of the alert volume of the last week of every active tenant. A report is
+// generated once per tenant and week across replicas, the replica storing it first being the one emailing it to the recipients
+// of the receivers of the tenant, if enabled.
+type alertReporter struct {
+	reportsConfig config.ReportsConfig
+	logger        *slog.Logger
+	quit          chan struct{}
+
+	reports   database.AlertReportGenerator
+	receivers database.ReceiverHandlerManager
+	mailer    reportMailer
+}
+
+// NewAlertReporter creates a new alertReporter, initializing the reports configuration, the connection to the database where
+// reports are stored, and the mailer reports are emailed with if enabled. Reports are still generated if the mailer cannot be
+// created.
+func NewAlertReporter(cfg config.Config, dbConn *gorm.DB, loglevel string) *alertReporter {
+	opts := setLogLvl(loglevel)
+	ar := &alertReporter{
+		reportsConfig: cfg.Reports,
+		logger:        slog.New(slog.NewTextHandler(os.Stdout, &opts)),
+		quit:          make(chan struct{}),
+
+		reports:   &database.DBService{DB: dbConn, AlertmanagerShards: cfg.AlertManager.ShardCount()},
+		receivers: &database.DBService{DB: dbConn, AlertmanagerShards: cfg.AlertManager.ShardCount()},
+	}
+	if ar.reportsConfig.CheckInterval <= 0 {
+		ar.reportsConfig.CheckInterval = defaultReportCheckInterval
+	}
+	if ar.reportsConfig.TopAlerts <= 0 {
+		ar.reportsConfig.TopAlerts = defaultReportTopAlerts
+	}
+
+	if cfg.Reports.Enabled && cfg.Reports.Email {
+		mailer, err := email.NewMailer(cfg, cfg.Reports.Timeout)
+		if err != nil {
+			ar.logger.Error("failed to create mailer, reports are not emailed", slog.Any("error", err))
+		} else {
+			ar.mailer = mailer
+		}
+	}
+	return ar
+}
+
+// Start allows the receiver to start generating reports periodically by means of a ticker. Nothing is done if reports are not
+// enabled.
+// NOTE: Once this method is invoked, to stop generating reports, we need to explicitly call Stop method from the receiver.
+func (ar *alertReporter) Start(ctx context.Context) {
+	if !ar.reportsConfig.Enabled {
+		ar.logger.Info("Alert reports are disabled")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(ar.reportsConfig.CheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ar.quit:
+				ar.logger.Info("Received signal: stopping alert reporter")
+				return
+			case <-ticker.C:
+				ar.generateReports(ctx)
+			}
+		}
+	}()
+}
+
+// Stop allows the receiver to stop generating reports.
+func (ar *alertReporter) Stop() {
+	close(ar.quit)
+}
+
+// generateReports generates the report of the last week of every active tenant lacking it. A tenant whose report cannot be
+// generated does not stop the reports of other tenants from being generated.
+func (ar *alertReporter) generateReports(ctx context.Context) {
+	tenants, err := ar.reports.GetActiveTenants(ctx)
+	if err != nil {
+		ar.logger.Error("failed to get active tenants", slog.Any("error", err))
+		return
+	}
+
+	start, end := report.LastWeek(clock.TimeNowFn())
+	for _, tenant := range tenants {
+		if err := ar.generateReport(ctx, tenant, start, end); err != nil {
+			ar.logger.Error(fmt.Sprintf("failed to generate report of tenant %q", tenant), slog.Any("error", err))
+		}
+	}
+}
+
+// generateReport generates the report of a tenant for the given period, unless already generated, and emails it if enabled.
+func (ar *alertReporter) generateReport(ctx context.Context, tenantID api.TenantID, start, end time.Time) error {
+	exists, err := ar.reports.HasAlertReport(ctx, tenantID, start)
+	if err != nil || exists {
+		return err
+	}
+
+	volume, err := ar.reports.GetAlertVolume(ctx, tenantID, start, end, ar.reportsConfig.TopAlerts)
+	if err != nil {
+		return err
+	}
+	content, err := json.Marshal(volume)
+	if err != nil {
+		return fmt.Errorf("failed to encode report: %w", err)
+	}
+
+	stored := models.AlertReport{
+		TenantID:     tenantID,
+		PeriodStart:  start,
+		PeriodEnd:    end,
+		Content:      string(content),
+		CreationDate: clock.TimeNowFn().UTC(),
+	}
+	created, err := ar.reports.CreateAlertReport(ctx, &stored)
+	if err != nil || !created {
+		return err
+	}
+	ar.logger.Info(fmt.Sprintf("generated report %d of tenant %q", stored.ID, tenantID))
+
+	if ar.mailer != nil {
+		ar.emailReport(ctx, stored)
+	}
+	return nil
+}
+
+// emailReport sends the given report to each recipient of the receivers of its tenant who is notified, that is not pending
+// verification of their email address. Recipients the report could not be sent to are only logged.
+func (ar *alertReporter) emailReport(ctx context.Context, stored models.AlertReport) {
+	rpt, err := report.New(stored)
+	if err != nil {
+		ar.logger.Error("failed to decode report", slog.Any("error", err))
+		return
+	}
+	body, err := rpt.HTML()
+	if err != nil {
+		ar.logger.Error("failed to render report", slog.Any("error", err))
+		return
+	}
+
+	receivers, _, err := ar.receivers.GetLatestReceiverListWithEmailConfig(ctx, stored.TenantID, database.ListOptions{})
+	if err != nil {
+		ar.logger.Error(fmt.Sprintf("failed to get receivers of tenant %q", stored.TenantID), slog.Any("error", err))
+		return
+	}
+
+	sent := make(map[string]bool)
+	for _, recv := range receivers {
+		for _, recipient := range recv.Notified() {
+			to, err := mail.ParseAddress(recipient)
+			if err != nil {
+				ar.logger.Error(fmt.Sprintf("invalid recipient %q of receiver %q", recipient, recv.UUID), slog.Any("error", err))
+				continue
+			}
+			if sent[to.Address] {
+				continue
+			}
+			sent[to.Address] = true
+
+			if err := ar.mailer.Send(ctx, to, rpt.Subject(), string(body)); err != nil {
+				ar.logger.Error(fmt.Sprintf("failed to email report %d of tenant %q", stored.ID, stored.TenantID), slog.Any("error", err))
+			}
+		}
+	}
+}
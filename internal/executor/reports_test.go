@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/mail"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+type ReportMailerMock struct {
+	mock.Mock
+}
+
+func (m *ReportMailerMock) Send(ctx context.Context, to *mail.Address, subject, body string) error {
+	args := m.Called(ctx, to, subject, body)
+	return args.Error(0)
+}
+
+func TestAlertReporter_GenerateReports(t *testing.T) {
+	clock.SetFakeClock()
+	defer clock.UnsetFakeClock()
+	clock.FakeClock.Set(time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC))
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.AlertDefinition{}, &models.EmailAddress{}, &models.EmailConfig{}, &models.Receiver{},
+		&models.EmailRecipient{}, &models.Tenant{}, &models.EmailTemplate{}, &models.EmailDelivery{}, &models.AlertComment{},
+		&models.AlertReport{}))
+
+	tenantID := "edgenode"
+	sender := models.EmailAddress{FirstName: "alerts", LastName: "sender", Email: "alerts@example.com"}
+	require.NoError(t, db.Create(&sender).Error)
+	emailConfig := models.EmailConfig{MailServer: "smtp.example.com:587", From: sender.ID}
+	require.NoError(t, db.Create(&emailConfig).Error)
+	recv := models.Receiver{UUID: uuid.New(), Name: "receiver", Version: 1, State: models.ReceiverApplied,
+		EmailConfigID: emailConfig.ID, TenantID: tenantID}
+	require.NoError(t, db.Create(&recv).Error)
+
+	// Alice is notified, Bob is pending verification of his email address.
+	alice := models.EmailAddress{FirstName: "alice", LastName: "smith", Email: "alice@example.com"}
+	bob := models.EmailAddress{FirstName: "bob", LastName: "jones", Email: "bob@example.com", VerificationPending: true}
+	require.NoError(t, db.Create([]*models.EmailAddress{&alice, &bob}).Error)
+	require.NoError(t, db.Create([]models.EmailRecipient{
+		{ReceiverID: recv.ID, EmailAddressID: alice.ID},
+		{ReceiverID: recv.ID, EmailAddressID: bob.ID},
+	}).Error)
+
+	require.NoError(t, db.Create(&models.EmailDelivery{TenantID: tenantID, ReceiverUUID: recv.UUID, Recipient: alice.Email,
+		GroupKey: `{}:{alertname="HighCPU"}`, AlertCount: 1, Status: models.EmailDeliverySent, Attempts: 1,
+		CreationDate: time.Date(2026, 10, 7, 12, 0, 0, 0, time.UTC)}).Error)
+
+	mailer := &ReportMailerMock{}
+	mailer.On("Send", mock.Anything, &mail.Address{Name: "alice smith", Address: alice.Email},
+		"Alert report of project edgenode from 2026-10-05 to 2026-10-11", mock.Anything).Return(nil).Once()
+
+	ar := &alertReporter{
+		reportsConfig: config.ReportsConfig{Enabled: true, CheckInterval: time.Hour, TopAlerts: 10, Email: true},
+		logger:        slog.New(slog.NewTextHandler(io.Discard, nil)),
+		quit:          make(chan struct{}),
+		reports:       &database.DBService{DB: db},
+		receivers:     &database.DBService{DB: db},
+		mailer:        mailer,
+	}
+
+	// The report of a week is generated and emailed once, however often reports are checked.
+	ar.generateReports(t.Context())
+	ar.generateReports(t.Context())
+	mailer.AssertExpectations(t)
+
+	var reports []models.AlertReport
+	require.NoError(t, db.Find(&reports).Error)
+	require.Len(t, reports, 1)
+	require.Equal(t, tenantID, reports[0].TenantID)
+	require.True(t, time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC).Equal(reports[0].PeriodStart))
+	require.True(t, time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC).Equal(reports[0].PeriodEnd))
+
+	var volume models.AlertVolume
+	require.NoError(t, json.Unmarshal([]byte(reports[0].Content), &volume))
+	require.Equal(t, int64(1), volume.SentNotifications)
+	require.Equal(t, []models.NoisyAlert{{Group: `{alertname="HighCPU"}`, Notifications: 1}}, volume.TopAlerts)
+}
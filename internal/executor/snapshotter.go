@@ -0,0 +1,163 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/snapshot"
+)
+
+// snapshotStore reads and writes configuration snapshots in an object store.
+type snapshotStore interface {
+	Prefix() string
+	Put(ctx context.Context, key string, data []byte) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	Latest(ctx context.Context) (string, error)
+}
+
+// configSnapshotter periodically snapshots the alerting configuration of all tenants, including every version of their alert
+// definitions and receivers, to an S3-compatible object store, and restores it for disaster recovery of the database.
+type configSnapshotter struct {
+	snapshotConfig config.SnapshotConfig
+	logger         *slog.Logger
+	quit           chan struct{}
+
+	configs database.ConfigSnapshotManager
+	store   snapshotStore
+}
+
+// NewConfigSnapshotter creates a new configSnapshotter, initializing the snapshot configuration, the connection to the database
+// where the alerting configuration is stored, and the object store where snapshots are written.
+func NewConfigSnapshotter(cfg config.Config, dbConn *gorm.DB, loglevel string) *configSnapshotter {
+	opts := setLogLvl(loglevel)
+	return &configSnapshotter{
+		snapshotConfig: cfg.Snapshot,
+		logger:         slog.New(slog.NewTextHandler(os.Stdout, &opts)),
+		quit:           make(chan struct{}),
+
+		configs: &database.DBService{DB: dbConn, AlertmanagerShards: cfg.AlertManager.ShardCount()},
+		store:   snapshot.New(cfg.Snapshot),
+	}
+}
+
+// Start allows the receiver to start taking snapshots periodically by means of a ticker. Nothing is done if the snapshot
+// interval is not set.
+// NOTE: Once this method is invoked, to stop taking snapshots, we need to explicitly call Stop method from the receiver.
+func (cs *configSnapshotter) Start(ctx context.Context) {
+	if cs.snapshotConfig.Interval <= 0 {
+		cs.logger.Info("Configuration snapshots are disabled")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(cs.snapshotConfig.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-cs.quit:
+				cs.logger.Info("Received signal: stopping configuration snapshotter")
+				return
+			case <-ticker.C:
+				if key, err := cs.TakeSnapshot(ctx); err != nil {
+					cs.logger.Error("failed to take configuration snapshot", slog.Any("error", err))
+				} else {
+					cs.logger.Info(fmt.Sprintf("took configuration snapshot %q", key))
+				}
+			}
+		}
+	}()
+}
+
+// Stop allows the receiver to stop taking snapshots.
+func (cs *configSnapshotter) Stop() {
+	close(cs.quit)
+}
+
+// TakeSnapshot snapshots the alerting configuration of all tenants to the object store and returns the key of the snapshot.
+// Snapshots taken by several replicas within the same interval are written under the same key.
+func (cs *configSnapshotter) TakeSnapshot(ctx context.Context) (string, error) {
+	snap, err := cs.configs.GetConfigSnapshot(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode configuration snapshot: %w", err)
+	}
+
+	key := snapshot.Key(cs.store.Prefix(), clock.TimeNowFn(), cs.snapshotConfig.Interval)
+	if err := cs.store.Put(ctx, key, data); err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+// RestoreSnapshot replays the snapshot with the given key, or the latest one if the key is "latest", into the database. The
+// restored alert definitions and receivers are queued to be applied by the task executor.
+func (cs *configSnapshotter) RestoreSnapshot(ctx context.Context, key string) (database.RestoreResult, error) {
+	if key == "latest" {
+		latest, err := cs.store.Latest(ctx)
+		if err != nil {
+			return database.RestoreResult{}, err
+		}
+		key = latest
+	}
+
+	data, err := cs.store.Get(ctx, key)
+	if err != nil {
+		return database.RestoreResult{}, err
+	}
+	if err := cs.verifySnapshot(ctx, key, data); err != nil {
+		return database.RestoreResult{}, err
+	}
+
+	var snap models.ConfigSnapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return database.RestoreResult{}, fmt.Errorf("failed to decode configuration snapshot %q: %w", key, err)
+	}
+
+	res, err := cs.configs.RestoreConfigSnapshot(ctx, &snap)
+	if err != nil {
+		return database.RestoreResult{}, fmt.Errorf("failed to restore configuration snapshot %q: %w", key, err)
+	}
+
+	cs.logger.Info(fmt.Sprintf("restored configuration snapshot %q taken at %v", key, snap.CreatedAt),
+		slog.Int("created", res.Created), slog.Int("updated", res.Updated), slog.Int("unchanged", res.Unchanged))
+	return res, nil
+}
+
+// verifySnapshot checks the detached signature of the snapshot with the given key against the trusted keys, if any are
+// configured, so that only snapshots of known provenance are restored.
+func (cs *configSnapshotter) verifySnapshot(ctx context.Context, key string, data []byte) error {
+	if cs.snapshotConfig.TrustedKeys == "" {
+		return nil
+	}
+
+	verifier, err := snapshot.NewVerifier(cs.snapshotConfig.TrustedKeys)
+	if err != nil {
+		return err
+	}
+	signature, err := cs.store.Get(ctx, key+snapshot.SignatureSuffix)
+	if err != nil {
+		return fmt.Errorf("failed to get signature of configuration snapshot %q: %w", key, err)
+	}
+	if err := verifier.Verify(data, signature); err != nil {
+		return fmt.Errorf("failed to verify configuration snapshot %q: %w", key, err)
+	}
+	return nil
+}
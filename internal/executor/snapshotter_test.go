@@ -0,0 +1,190 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package executor
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/snapshot"
+)
+
+type SnapshotStoreMock struct {
+	mock.Mock
+}
+
+func (m *SnapshotStoreMock) Prefix() string {
+	args := m.Called()
+	return args.String(0)
+}
+
+func (m *SnapshotStoreMock) Put(ctx context.Context, key string, data []byte) error {
+	args := m.Called(ctx, key, data)
+	return args.Error(0)
+}
+
+func (m *SnapshotStoreMock) Get(ctx context.Context, key string) ([]byte, error) {
+	args := m.Called(ctx, key)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+func (m *SnapshotStoreMock) Latest(ctx context.Context) (string, error) {
+	args := m.Called(ctx)
+	return args.String(0), args.Error(1)
+}
+
+func newTestSnapshotter(t *testing.T, store snapshotStore) *configSnapshotter {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open("file:snapshotter?mode=memory&cache=shared"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(
+		&models.AlertDuration{},
+		&models.AlertThreshold{},
+		&models.AlertDefinition{},
+		&models.EmailAddress{},
+		&models.EmailConfig{},
+		&models.Receiver{},
+		&models.EmailRecipient{},
+		&models.Task{},
+		&models.Tenant{},
+		&models.EmailTemplate{},
+	))
+	t.Cleanup(func() {
+		sqlDB, err := db.DB()
+		require.NoError(t, err)
+		require.NoError(t, sqlDB.Close())
+	})
+
+	return &configSnapshotter{
+		snapshotConfig: config.SnapshotConfig{Interval: time.Hour},
+		logger:         slog.New(slog.NewTextHandler(io.Discard, nil)),
+		quit:           make(chan struct{}),
+		configs:        &database.DBService{DB: db},
+		store:          store,
+	}
+}
+
+func TestConfigSnapshotter_TakeSnapshot(t *testing.T) {
+	clock.SetFakeClock()
+	defer clock.UnsetFakeClock()
+	clock.FakeClock.Set(time.Date(2026, 10, 16, 14, 35, 0, 0, time.UTC))
+
+	storeMock := new(SnapshotStoreMock)
+	storeMock.On("Prefix").Return("alerting/")
+	storeMock.On("Put", mock.Anything, "alerting/snapshot-20261016T140000Z.json", mock.Anything).Return(nil)
+
+	cs := newTestSnapshotter(t, storeMock)
+	require.NoError(t, cs.configs.(*database.DBService).SetTenantActivity(t.Context(), "tenant"))
+
+	key, err := cs.TakeSnapshot(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, "alerting/snapshot-20261016T140000Z.json", key)
+
+	var snap models.ConfigSnapshot
+	require.NoError(t, json.Unmarshal(storeMock.Calls[1].Arguments.Get(2).([]byte), &snap))
+	require.Equal(t, models.SnapshotFormatVersion, snap.FormatVersion)
+	require.Len(t, snap.Tenants, 1)
+	require.Equal(t, "tenant", snap.Tenants[0].TenantID)
+}
+
+func TestConfigSnapshotter_RestoreSnapshot(t *testing.T) {
+	recvUUID := uuid.New()
+	snap := models.ConfigSnapshot{
+		FormatVersion: models.SnapshotFormatVersion,
+		Receivers: []models.ReceiverVersion{{
+			Receiver:   models.Receiver{UUID: recvUUID, Name: "receiver", Version: 3, TenantID: "tenant", State: models.ReceiverApplied},
+			MailServer: "smtp.example.com:587",
+			From:       models.EmailAddress{Email: "alerts@example.com", FirstName: "Alert", LastName: "Monitor"},
+			Recipients: []models.EmailAddress{{Email: "foo@bar.com", FirstName: "Foo", LastName: "Bar"}},
+		}},
+	}
+	data, err := json.Marshal(snap)
+	require.NoError(t, err)
+
+	t.Run("Latest", func(t *testing.T) {
+		storeMock := new(SnapshotStoreMock)
+		storeMock.On("Latest", mock.Anything).Return("alerting/snapshot-20261016T140000Z.json", nil)
+		storeMock.On("Get", mock.Anything, "alerting/snapshot-20261016T140000Z.json").Return(data, nil)
+
+		cs := newTestSnapshotter(t, storeMock)
+		res, err := cs.RestoreSnapshot(t.Context(), "latest")
+		require.NoError(t, err)
+		require.Equal(t, database.RestoreResult{Created: 1}, res)
+
+		recv, err := cs.configs.(*database.DBService).GetReceiverWithEmailConfig(t.Context(), "tenant", recvUUID, 3)
+		require.NoError(t, err)
+		require.Equal(t, models.ReceiverNew, recv.State)
+		require.Equal(t, []string{"Foo Bar <foo@bar.com>"}, recv.To)
+	})
+
+	t.Run("NoSnapshot", func(t *testing.T) {
+		storeMock := new(SnapshotStoreMock)
+		storeMock.On("Latest", mock.Anything).Return("", snapshot.ErrNoSnapshot)
+
+		_, err := newTestSnapshotter(t, storeMock).RestoreSnapshot(t.Context(), "latest")
+		require.ErrorIs(t, err, snapshot.ErrNoSnapshot)
+	})
+
+	t.Run("InvalidSnapshot", func(t *testing.T) {
+		storeMock := new(SnapshotStoreMock)
+		storeMock.On("Get", mock.Anything, "alerting/snapshot-20261016T140000Z.json").Return([]byte(`{`), nil)
+
+		_, err := newTestSnapshotter(t, storeMock).RestoreSnapshot(t.Context(), "alerting/snapshot-20261016T140000Z.json")
+		require.ErrorContains(t, err, "failed to decode configuration snapshot")
+	})
+
+	t.Run("Signed", func(t *testing.T) {
+		pub, key, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		der, err := x509.MarshalPKIXPublicKey(pub)
+		require.NoError(t, err)
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "cosign.pub"), pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600))
+
+		storeMock := new(SnapshotStoreMock)
+		storeMock.On("Get", mock.Anything, "alerting/snapshot-20261016T140000Z.json").Return(data, nil)
+		cs := newTestSnapshotter(t, storeMock)
+		cs.snapshotConfig.TrustedKeys = filepath.Join(dir, "*.pub")
+
+		// Snapshots without a signature are not restored.
+		storeMock.On("Get", mock.Anything, "alerting/snapshot-20261016T140000Z.json.sig").Return(nil, snapshot.ErrNoSnapshot).Once()
+		_, err = cs.RestoreSnapshot(t.Context(), "alerting/snapshot-20261016T140000Z.json")
+		require.ErrorIs(t, err, snapshot.ErrNoSnapshot)
+
+		// Nor are snapshots signed for other contents.
+		otherSig := []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(key, []byte(`{}`))))
+		storeMock.On("Get", mock.Anything, "alerting/snapshot-20261016T140000Z.json.sig").Return(otherSig, nil).Once()
+		_, err = cs.RestoreSnapshot(t.Context(), "alerting/snapshot-20261016T140000Z.json")
+		require.ErrorIs(t, err, snapshot.ErrInvalidSignature)
+
+		sig := []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(key, data)))
+		storeMock.On("Get", mock.Anything, "alerting/snapshot-20261016T140000Z.json.sig").Return(sig, nil).Once()
+		res, err := cs.RestoreSnapshot(t.Context(), "alerting/snapshot-20261016T140000Z.json")
+		require.NoError(t, err)
+		require.Equal(t, database.RestoreResult{Created: 1}, res)
+	})
+}
@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+// archivedInvalidTasks counts the Invalid tasks archived to the object store before being deleted.
+var archivedInvalidTasks = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "alerting_monitor_archived_invalid_tasks_total",
+	Help: "Number of invalid tasks archived to the object store before being deleted.",
+})
+
+// objectWriter writes objects to an object store.
+type objectWriter interface {
+	Put(ctx context.Context, key string, data []byte) error
+}
+
+// archivedTask is an Invalid task as archived to the object store, along with the error of its last failed attempt.
+type archivedTask struct {
+	ID             int64           `json:"id"`
+	TenantID       string          `json:"tenantId"`
+	Type           models.TaskType `json:"type"`
+	UUID           uuid.UUID       `json:"uuid"`
+	Version        int64           `json:"version"`
+	OwnerUUID      uuid.UUID       `json:"ownerUuid"`
+	CorrelationID  string          `json:"correlationId,omitempty"`
+	RetryCount     int64           `json:"retryCount"`
+	CreationDate   time.Time       `json:"creationDate"`
+	StartDate      time.Time       `json:"startDate"`
+	CompletionDate time.Time       `json:"completionDate"`
+	Error          string          `json:"error,omitempty"`
+}
+
+// invalidTaskArchiver archives Invalid tasks to the object store before they are deleted past retention, for long-term
+// forensic retention of the failures of the executor.
+type invalidTaskArchiver struct {
+	store  objectWriter
+	prefix string
+}
+
+// archive writes the given tasks as a JSON array to the object store, under a key made of the archival time and of the ID of
+// the first task, so that the archives of the batches of a cleanup do not overwrite each other.
+func (a *invalidTaskArchiver) archive(ctx context.Context, tasks []models.Task) error {
+	archived := make([]archivedTask, 0, len(tasks))
+	for _, task := range tasks {
+		archived = append(archived, archivedTask{
+			ID:             task.ID,
+			TenantID:       task.TenantID,
+			Type:           task.GetTaskType(),
+			UUID:           task.GetTaskUUID(),
+			Version:        task.Version,
+			OwnerUUID:      task.OwnerUUID,
+			CorrelationID:  task.CorrelationID,
+			RetryCount:     task.RetryCount,
+			CreationDate:   task.CreationDate,
+			StartDate:      task.StartDate,
+			CompletionDate: task.CompletionDate,
+			Error:          task.Error,
+		})
+	}
+
+	data, err := json.Marshal(archived)
+	if err != nil {
+		return fmt.Errorf("failed to marshal invalid tasks: %w", err)
+	}
+
+	key := fmt.Sprintf("%sinvalid-tasks-%s-%d.json", a.prefix, clock.TimeNowFn().UTC().Format("20060102T150405Z"), tasks[0].ID)
+	if err := a.store.Put(ctx, key, data); err != nil {
+		return err
+	}
+	archivedInvalidTasks.Add(float64(len(tasks)))
+	return nil
+}
@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+type objectStoreMock struct {
+	objects map[string][]byte
+	err     error
+}
+
+func (m *objectStoreMock) Put(_ context.Context, key string, data []byte) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.objects[key] = data
+	return nil
+}
+
+func TestArchiveInvalidTasks(t *testing.T) {
+	conn, err := gorm.Open(sqlite.Open(":memory:"))
+	require.NoError(t, err)
+	require.NoError(t, conn.AutoMigrate(&models.Task{}, &models.TaskHistory{}))
+
+	clock.SetFakeClock()
+	defer clock.UnsetFakeClock()
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	clock.FakeClock.Set(now)
+
+	definitionID := uuid.New()
+	require.NoError(t, conn.Create(&models.Task{
+		ID:                  1,
+		AlertDefinitionUUID: &definitionID,
+		TenantID:            "edgenode",
+		Version:             2,
+		State:               models.TaskInvalid,
+		CompletionDate:      now.Add(-time.Hour),
+		RetryCount:          10,
+		CorrelationID:       "f0e1d2c3",
+		Error:               "failed to update rule group: mock error",
+	}).Error)
+	require.NoError(t, conn.Create(&models.Task{
+		ID:                  2,
+		AlertDefinitionUUID: uuidPtr(uuid.New()),
+		TenantID:            "edgenode",
+		State:               models.TaskApplied,
+		CompletionDate:      now.Add(-time.Hour),
+	}).Error)
+
+	newExecutor := func(store *objectStoreMock) *asyncExecutor {
+		return &asyncExecutor{
+			executorConfig: config.TaskExecutorConfig{RetentionTime: 30 * time.Minute},
+			tasks:          &database.DBService{DB: conn},
+			logger:         slog.New(slog.NewTextHandler(os.Stdout, nil)),
+			invalidTasks:   &invalidTaskArchiver{store: store, prefix: "alerting-monitor/tasks/"},
+		}
+	}
+	countTasks := func() int64 {
+		var count int64
+		require.NoError(t, conn.Model(&models.Task{}).Count(&count).Error)
+		return count
+	}
+
+	t.Run("Object store unavailable - tasks are not deleted", func(t *testing.T) {
+		store := &objectStoreMock{objects: map[string][]byte{}, err: errors.New("mock error")}
+		newExecutor(store).cleanUpTasks(t.Context())
+
+		require.Empty(t, store.objects)
+		require.Equal(t, int64(2), countTasks())
+	})
+
+	t.Run("Invalid tasks are archived before being deleted", func(t *testing.T) {
+		store := &objectStoreMock{objects: map[string][]byte{}}
+		newExecutor(store).cleanUpTasks(t.Context())
+
+		require.Zero(t, countTasks())
+		data, ok := store.objects["alerting-monitor/tasks/invalid-tasks-20250310T120000Z-1.json"]
+		require.True(t, ok)
+
+		var archived []archivedTask
+		require.NoError(t, json.Unmarshal(data, &archived))
+		require.Equal(t, []archivedTask{{
+			ID:             1,
+			TenantID:       "edgenode",
+			Type:           models.TypeAlertDefinition,
+			UUID:           definitionID,
+			Version:        2,
+			CorrelationID:  "f0e1d2c3",
+			RetryCount:     10,
+			CreationDate:   archived[0].CreationDate,
+			CompletionDate: now.Add(-time.Hour),
+			Error:          "failed to update rule group: mock error",
+		}}, archived)
+	})
+}
@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/mimir"
+)
+
+// thresholdTuner periodically recomputes the threshold of alert definitions which have auto-tuning turned on, from the
+// statistics of their metric queried from Mimir. A changed threshold is written as a new version of the alert definition
+// labeled as auto-tuned, along with a task which applies it.
+type thresholdTuner struct {
+	tuneConfig config.ThresholdAutoTuneConfig
+	logger     *slog.Logger
+	quit       chan struct{}
+
+	definitions database.ThresholdTuningManager
+	metrics     mimir.MetricStatsQuerier
+}
+
+// NewThresholdTuner creates a new thresholdTuner, initializing the auto-tuning configuration, the connection to the database
+// where alert definitions are stored, and the struct that allows querying metric statistics from Mimir.
+func NewThresholdTuner(cfg config.Config, dbConn *gorm.DB, loglevel string) *thresholdTuner {
+	opts := setLogLvl(loglevel)
+	return &thresholdTuner{
+		tuneConfig: cfg.ThresholdAutoTune,
+		logger:     slog.New(slog.NewTextHandler(os.Stdout, &opts)),
+		quit:       make(chan struct{}),
+
+		definitions: &database.DBService{DB: dbConn, AlertmanagerShards: cfg.AlertManager.ShardCount()},
+		metrics:     &mimir.Mimir{Config: &cfg.Mimir},
+	}
+}
+
+// Start allows the receiver to start recomputing thresholds periodically by means of a ticker. Nothing is done if
+// the check interval is not set.
+// NOTE: Once this method is invoked, to stop recomputing thresholds, we need to explicitly call Stop method from the receiver.
+func (tt *thresholdTuner) Start(ctx context.Context) {
+	if tt.tuneConfig.CheckInterval <= 0 {
+		tt.logger.Info("Threshold auto-tuning is disabled")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(tt.tuneConfig.CheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-tt.quit:
+				tt.logger.Info("Received signal: stopping threshold tuner")
+				return
+			case <-ticker.C:
+				tt.tuneThresholds(ctx)
+			}
+		}
+	}()
+}
+
+// Stop allows the receiver to stop recomputing thresholds.
+func (tt *thresholdTuner) Stop() {
+	close(tt.quit)
+}
+
+// tuneThresholds recomputes the threshold of every alert definition which has auto-tuning turned on.
+func (tt *thresholdTuner) tuneThresholds(ctx context.Context) {
+	definitions, err := tt.definitions.GetAutoTunedAlertDefinitions(ctx)
+	if err != nil {
+		tt.logger.Error("failed to get auto-tuned alert definitions", slog.Any("error", err))
+		return
+	}
+
+	for _, def := range definitions {
+		if err := tt.tuneThreshold(ctx, def); err != nil {
+			tt.logger.Error(fmt.Sprintf("failed to tune threshold of alert definition %q for tenant %q", def.ID, def.TenantID), slog.Any("error", err))
+		}
+	}
+}
+
+// tuneThreshold sets the threshold of an alert definition to the configured quantile of its metric over the lookback
+// period, plus the configured margin. Alert definitions whose metric has no samples are left unchanged.
+func (tt *thresholdTuner) tuneThreshold(ctx context.Context, def *models.DBAlertDefinition) error {
+	value, err := tt.metrics.QueryThresholdQuantile(ctx, def, tt.tuneConfig.Quantile, tt.tuneConfig.Lookback)
+	if errors.Is(err, mimir.ErrNoMetricData) {
+		tt.logger.Debug(fmt.Sprintf("skipping auto-tuning of alert definition %q for tenant %q without metric data", def.ID, def.TenantID))
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	threshold := int64(math.Ceil(value * (1 + tt.tuneConfig.Margin)))
+	updated, err := tt.definitions.SetAutoTunedThreshold(ctx, def.TenantID, def.ID, def.Version, threshold)
+	if err != nil {
+		return err
+	}
+
+	if updated {
+		tt.logger.Info(fmt.Sprintf("auto-tuned threshold of alert definition %q for tenant %q", def.ID, def.TenantID), slog.Int64("threshold", threshold))
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/mimir"
+)
+
+type ThresholdTuningMock struct {
+	mock.Mock
+}
+
+func (m *ThresholdTuningMock) GetAutoTunedAlertDefinitions(ctx context.Context) ([]*models.DBAlertDefinition, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]*models.DBAlertDefinition), args.Error(1)
+}
+
+func (m *ThresholdTuningMock) SetAutoTunedThreshold(ctx context.Context, tenantID api.TenantID, id uuid.UUID, version int64, threshold int64) (bool, error) {
+	args := m.Called(ctx, tenantID, id, version, threshold)
+	return args.Bool(0), args.Error(1)
+}
+
+type MetricStatsQuerierMock struct {
+	mock.Mock
+}
+
+func (m *MetricStatsQuerierMock) QueryThresholdQuantile(
+	ctx context.Context, alertDef *models.DBAlertDefinition, quantile float64, lookback time.Duration,
+) (float64, error) {
+	args := m.Called(ctx, alertDef, quantile, lookback)
+	return args.Get(0).(float64), args.Error(1)
+}
+
+func TestThresholdTuner_TuneThresholds(t *testing.T) {
+	tuneConfig := config.ThresholdAutoTuneConfig{
+		Lookback: 168 * time.Hour,
+		Quantile: 0.99,
+		Margin:   0.1,
+	}
+
+	def1 := &models.DBAlertDefinition{ID: uuid.New(), TenantID: "tenant", Version: 3}
+	def2 := &models.DBAlertDefinition{ID: uuid.New(), TenantID: "tenant", Version: 1}
+	def3 := &models.DBAlertDefinition{ID: uuid.New(), TenantID: "other", Version: 2}
+
+	dbMock := new(ThresholdTuningMock)
+	dbMock.On("GetAutoTunedAlertDefinitions", mock.Anything).Return([]*models.DBAlertDefinition{def1, def2, def3}, nil).Once()
+	dbMock.On("SetAutoTunedThreshold", mock.Anything, "tenant", def1.ID, int64(3), int64(78)).Return(true, nil).Once()
+
+	mimirMock := new(MetricStatsQuerierMock)
+	mimirMock.On("QueryThresholdQuantile", mock.Anything, def1, 0.99, 168*time.Hour).Return(70.5, nil).Once()
+	mimirMock.On("QueryThresholdQuantile", mock.Anything, def2, 0.99, 168*time.Hour).Return(0.0, fmt.Errorf("mock: %w", mimir.ErrNoMetricData)).Once()
+	mimirMock.On("QueryThresholdQuantile", mock.Anything, def3, 0.99, 168*time.Hour).Return(0.0, errors.New("mock error")).Once()
+
+	tuner := &thresholdTuner{
+		tuneConfig:  tuneConfig,
+		logger:      slog.New(slog.NewTextHandler(os.Stdout, nil)),
+		definitions: dbMock,
+		metrics:     mimirMock,
+	}
+	tuner.tuneThresholds(t.Context())
+
+	// Only the alert definition with metric data gets a new threshold, failures do not stop other definitions from being tuned.
+	dbMock.AssertExpectations(t)
+	mimirMock.AssertExpectations(t)
+	dbMock.AssertNumberOfCalls(t, "SetAutoTunedThreshold", 1)
+}
+
+func TestThresholdTuner_TuneThreshold(t *testing.T) {
+	def := &models.DBAlertDefinition{ID: uuid.New(), TenantID: "tenant", Version: 1}
+
+	t.Run("Fails to set threshold", func(t *testing.T) {
+		dbMock := new(ThresholdTuningMock)
+		dbMock.On("SetAutoTunedThreshold", mock.Anything, "tenant", def.ID, int64(1), int64(10)).Return(false, errors.New("mock error")).Once()
+		mimirMock := new(MetricStatsQuerierMock)
+		mimirMock.On("QueryThresholdQuantile", mock.Anything, def, 0.5, time.Hour).Return(10.0, nil).Once()
+
+		tuner := &thresholdTuner{
+			tuneConfig:  config.ThresholdAutoTuneConfig{Lookback: time.Hour, Quantile: 0.5},
+			logger:      slog.New(slog.NewTextHandler(os.Stdout, nil)),
+			definitions: dbMock,
+			metrics:     mimirMock,
+		}
+		require.ErrorContains(t, tuner.tuneThreshold(t.Context(), def), "mock error")
+		dbMock.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,334 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Package mailrelay implements the SMTP relay alertmanager sends emails through when they are signed. The relay signs every
+// email with S/MIME before sending it to the mail server, authenticating to it and requiring TLS on behalf of alertmanager.
+// Alertmanager authenticates to the relay, which only relays emails from the sender of the deployment, so that other clients
+// cannot send emails signed with the certificate of the organization.
+package mailrelay
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/md5" //nolint:gosec // CRAM-MD5 is the only mechanism alertmanager authenticates with over plain connections.
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/notify/email"
+)
+
+const (
+	// Username is the user name alertmanager authenticates to the relay with, along with the secret given by the
+	// EMAIL_SIGNING_RELAY_SECRET environment variable.
+	Username = "alertmanager"
+
+	defaultTimeout = time.Minute
+	// maxMessageSize is the maximum size of an email accepted by the relay.
+	maxMessageSize = 10 << 20
+)
+
+// deliverFunc sends an email to the given recipients.
+type deliverFunc func(ctx context.Context, from string, to []string, msg []byte) error
+
+// Relay is an SMTP server accepting the emails of alertmanager, which are signed and sent to the mail server given by the
+// SMART_HOST and SMART_PORT environment variables, with the optional SMTP_USERNAME and SMTP_PASSWORD credentials. Clients must
+// authenticate with CRAM-MD5 (RFC 2195) as Username, and can only send emails from the sender given by the FROM_MAIL
+// environment variable.
+type Relay struct {
+	conf     config.EmailSigningConfig
+	logger   *slog.Logger
+	listener net.Listener
+	signer   *Signer
+	deliver  deliverFunc
+	secret   []byte
+	from     string
+}
+
+// New creates a new Relay listening on the configured address, loading the signing certificate and key, the secret clients
+// authenticate with and the sender of emails.
+func New(cfg config.Config, loglevel string) (*Relay, error) {
+	secret := os.Getenv("EMAIL_SIGNING_RELAY_SECRET")
+	if secret == "" {
+		return nil, errors.New("email signing relay secret is not set")
+	}
+	from, err := mail.ParseAddress(os.Getenv("FROM_MAIL"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender of signed emails: %w", err)
+	}
+
+	signer, err := NewSigner(cfg.EmailSigning.CertificateFile, cfg.EmailSigning.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	conf := cfg.EmailSigning
+	if conf.Timeout <= 0 {
+		conf.Timeout = defaultTimeout
+	}
+
+	server, err := email.NewMailServer(cfg.AlertManager.RequireTLS, cfg.AlertManager.InsecureSkipVerify, conf.Timeout, cfg.MailFailover)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create mail server of the email signing relay: %w", err)
+	}
+
+	listener, err := net.Listen("tcp", cfg.EmailSigning.ListenAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %q: %w", cfg.EmailSigning.ListenAddress, err)
+	}
+
+	opts := setLogLvl(loglevel)
+	return &Relay{
+		conf:     conf,
+		logger:   slog.New(slog.NewTextHandler(os.Stdout, &opts)),
+		listener: listener,
+		signer:   signer,
+		deliver:  server.Send,
+		secret:   []byte(secret),
+		from:     from.Address,
+	}, nil
+}
+
+// Start allows the relay to start accepting SMTP sessions.
+// NOTE: Once this method is invoked, to stop accepting sessions, we need to explicitly call Stop method from the relay.
+func (r *Relay) Start(ctx context.Context) {
+	go func() {
+		for {
+			conn, err := r.listener.Accept()
+			if errors.Is(err, net.ErrClosed) {
+				r.logger.Info("Received signal: stopping email signing relay")
+				return
+			}
+			if err != nil {
+				r.logger.Error("failed to accept SMTP connection", slog.Any("error", err))
+				continue
+			}
+			go r.serve(ctx, conn)
+		}
+	}()
+}
+
+// Stop allows the relay to stop accepting SMTP sessions. Sessions in progress are not interrupted.
+func (r *Relay) Stop() {
+	if err := r.listener.Close(); err != nil {
+		r.logger.Error("failed to close email signing relay listener", slog.Any("error", err))
+	}
+}
+
+// serve handles an SMTP session (RFC 5321) of alertmanager, which only requires the commands below.
+func (r *Relay) serve(ctx context.Context, conn net.Conn) {
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(r.conf.Timeout)); err != nil {
+		r.logger.Error("failed to set SMTP session deadline", slog.Any("error", err))
+		return
+	}
+
+	text := textproto.NewConn(conn)
+	reply := func(code int, msg string) bool {
+		if err := text.PrintfLine("%d %s", code, msg); err != nil {
+			r.logger.Warn("failed to reply to SMTP client", slog.Any("error", err))
+			return false
+		}
+		return true
+	}
+
+	if !reply(220, "alerting-monitor ESMTP email signing relay") {
+		return
+	}
+
+	// from is only valid once a MAIL command was accepted.
+	var from string
+	var to []string
+	mail, authenticated := false, false
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				r.logger.Warn("failed to read SMTP command", slog.Any("error", err))
+			}
+			return
+		}
+
+		verb, arg, _ := strings.Cut(line, " ")
+		ok := true
+		switch strings.ToUpper(verb) {
+		case "EHLO":
+			if err := text.PrintfLine("250-alerting-monitor"); err != nil {
+				return
+			}
+			if err := text.PrintfLine("250-AUTH CRAM-MD5"); err != nil {
+				return
+			}
+			ok = reply(250, fmt.Sprintf("SIZE %d", maxMessageSize))
+		case "HELO":
+			ok = reply(250, "alerting-monitor")
+		case "AUTH":
+			switch {
+			case authenticated:
+				ok = reply(503, "already authenticated")
+			case !strings.EqualFold(strings.TrimSpace(arg), "CRAM-MD5"):
+				ok = reply(504, "authentication mechanism not supported")
+			default:
+				authenticated, ok = r.authenticate(text, reply)
+			}
+		case "MAIL":
+			addr, found := parsePath(arg, "FROM:")
+			switch {
+			case !found:
+				ok = reply(501, "syntax error in MAIL command")
+			case !authenticated:
+				ok = reply(530, "authentication required")
+			case !strings.EqualFold(addr, r.from):
+				r.logger.Warn("rejected email from sender other than the one of the deployment", slog.String("from", addr))
+				ok = reply(550, "sender not allowed")
+			default:
+				from, to, mail = addr, nil, true
+				ok = reply(250, "OK")
+			}
+		case "RCPT":
+			addr, found := parsePath(arg, "TO:")
+			switch {
+			case !found || addr == "":
+				ok = reply(501, "syntax error in RCPT command")
+			case !mail:
+				ok = reply(503, "MAIL command required first")
+			default:
+				to = append(to, addr)
+				ok = reply(250, "OK")
+			}
+		case "DATA":
+			if len(to) == 0 {
+				ok = reply(503, "RCPT command required first")
+				break
+			}
+			if !reply(354, "end data with <CR><LF>.<CR><LF>") {
+				return
+			}
+			code, msg := r.relay(ctx, from, to, text.DotReader())
+			from, to, mail = "", nil, false
+			ok = reply(code, msg)
+		case "RSET":
+			from, to, mail = "", nil, false
+			ok = reply(250, "OK")
+		case "NOOP":
+			ok = reply(250, "OK")
+		case "QUIT":
+			reply(221, "bye")
+			return
+		default:
+			ok = reply(502, "command not implemented")
+		}
+		if !ok {
+			return
+		}
+	}
+}
+
+// authenticate runs a CRAM-MD5 exchange (RFC 2195) with the client, returning whether the client authenticated as Username
+// with the secret of the relay, and whether the session can go on.
+func (r *Relay) authenticate(text *textproto.Conn, reply func(int, string) bool) (bool, bool) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		r.logger.Error("failed to generate SMTP authentication challenge", slog.Any("error", err))
+		return false, reply(454, "temporary authentication failure")
+	}
+	challenge := fmt.Sprintf("<%s@alerting-monitor>", hex.EncodeToString(nonce))
+	if !reply(334, base64.StdEncoding.EncodeToString([]byte(challenge))) {
+		return false, false
+	}
+
+	line, err := text.ReadLine()
+	if err != nil {
+		return false, false
+	}
+	if line == "*" {
+		return false, reply(501, "authentication cancelled")
+	}
+	resp, err := base64.StdEncoding.DecodeString(line)
+	if err != nil {
+		return false, reply(501, "invalid authentication response")
+	}
+
+	mac := hmac.New(md5.New, r.secret)
+	mac.Write([]byte(challenge))
+	expected := hex.EncodeToString(mac.Sum(nil))
+	user, digest, _ := strings.Cut(string(resp), " ")
+	if user != Username || !hmac.Equal([]byte(digest), []byte(expected)) {
+		r.logger.Warn("rejected SMTP client failing to authenticate", slog.String("user", user))
+		return false, reply(535, "authentication failed")
+	}
+	return true, reply(235, "authentication successful")
+}
+
+// relay reads the email of an SMTP session, signs it and sends it to the mail server, returning the reply to the session.
+func (r *Relay) relay(ctx context.Context, from string, to []string, data io.Reader) (int, string) {
+	msg, err := io.ReadAll(io.LimitReader(data, maxMessageSize+1))
+	if err != nil {
+		r.logger.Warn("failed to read email", slog.Any("error", err))
+		return 451, "failed to read email"
+	}
+	if len(msg) > maxMessageSize {
+		// The rest of the email is discarded so that the session can go on.
+		_, _ = io.Copy(io.Discard, data)
+		return 552, "email exceeds maximum size"
+	}
+
+	signed, err := r.signer.Sign(msg)
+	if err != nil {
+		r.logger.Error("failed to sign email", slog.Any("error", err))
+		return 554, "failed to sign email"
+	}
+
+	if err := r.deliver(ctx, from, to, signed); err != nil {
+		r.logger.Error("failed to send signed email to mail server", slog.Int("recipients", len(to)), slog.Any("error", err))
+		return 451, "failed to send email to mail server"
+	}
+
+	r.logger.Debug("Relayed signed email", slog.Int("recipients", len(to)))
+	return 250, "OK"
+}
+
+// parsePath returns the address of the path argument of a MAIL or RCPT command, ignoring its parameters.
+func parsePath(arg, prefix string) (string, bool) {
+	if len(arg) < len(prefix) || !strings.EqualFold(arg[:len(prefix)], prefix) {
+		return "", false
+	}
+	path, _, _ := strings.Cut(strings.TrimSpace(arg[len(prefix):]), " ")
+	if !strings.HasPrefix(path, "<") || !strings.HasSuffix(path, ">") {
+		return "", false
+	}
+	return path[1 : len(path)-1], true
+}
+
+func setLogLvl(logLvl string) slog.HandlerOptions {
+	switch logLvl {
+	case "debug":
+		return slog.HandlerOptions{
+			Level: slog.LevelDebug,
+		}
+	case "warn":
+		return slog.HandlerOptions{
+			Level: slog.LevelWarn,
+		}
+	case "error":
+		return slog.HandlerOptions{
+			Level: slog.LevelError,
+		}
+	default:
+		return slog.HandlerOptions{
+			Level: slog.LevelInfo,
+		}
+	}
+}
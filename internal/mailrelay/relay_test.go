@@ -0,0 +1,153 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package mailrelay
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"net/smtp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+)
+
+const testSecret = "relay-secret"
+
+// testAuth authenticates to the test relay as alertmanager does.
+var testAuth = smtp.CRAMMD5Auth(Username, testSecret)
+
+type delivery struct {
+	from string
+	to   []string
+	msg  []byte
+}
+
+// newTestRelay starts a relay on a random local port, which hands signed emails to the given delivery function.
+func newTestRelay(t *testing.T, deliver deliverFunc) (*Relay, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	signer, err := NewSigner(writeTestCertificate(t, key))
+	require.NoError(t, err)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	relay := &Relay{
+		conf:     config.EmailSigningConfig{Timeout: 5 * time.Second},
+		logger:   slog.New(slog.NewTextHandler(io.Discard, nil)),
+		listener: listener,
+		signer:   signer,
+		deliver:  deliver,
+		secret:   []byte(testSecret),
+		from:     "alerts@example.com",
+	}
+	relay.Start(context.Background())
+	t.Cleanup(relay.Stop)
+
+	return relay, listener.Addr().String()
+}
+
+func TestRelay(t *testing.T) {
+	t.Run("SignsAndDeliversEmail", func(t *testing.T) {
+		deliveries := make(chan delivery, 1)
+		relay, addr := newTestRelay(t, func(_ context.Context, from string, to []string, msg []byte) error {
+			deliveries <- delivery{from, to, msg}
+			return nil
+		})
+
+		err := smtp.SendMail(addr, testAuth, "alerts@example.com", []string{"foo@bar.com", "bar@foo.com"}, []byte(testEmail))
+		require.NoError(t, err)
+
+		d := <-deliveries
+		require.Equal(t, "alerts@example.com", d.from)
+		require.Equal(t, []string{"foo@bar.com", "bar@foo.com"}, d.to)
+		content := verifySigned(t, relay.signer, d.msg)
+		require.Contains(t, content, "<html>alert</html>")
+	})
+
+	t.Run("MailServerFails", func(t *testing.T) {
+		_, addr := newTestRelay(t, func(context.Context, string, []string, []byte) error {
+			return errors.New("connection refused")
+		})
+
+		err := smtp.SendMail(addr, testAuth, "alerts@example.com", []string{"foo@bar.com"}, []byte(testEmail))
+		require.ErrorContains(t, err, "failed to send email to mail server")
+	})
+
+	t.Run("EmailCannotBeSigned", func(t *testing.T) {
+		_, addr := newTestRelay(t, func(context.Context, string, []string, []byte) error {
+			return nil
+		})
+
+		err := smtp.SendMail(addr, testAuth, "alerts@example.com", []string{"foo@bar.com"}, []byte("Subject: no body"))
+		require.ErrorContains(t, err, "failed to sign email")
+	})
+
+	t.Run("RecipientBeforeSender", func(t *testing.T) {
+		_, addr := newTestRelay(t, nil)
+
+		client, err := smtp.Dial(addr)
+		require.NoError(t, err)
+		defer client.Close()
+
+		require.ErrorContains(t, client.Rcpt("foo@bar.com"), "MAIL command required first")
+		require.NoError(t, client.Quit())
+	})
+
+	t.Run("NotAuthenticated", func(t *testing.T) {
+		_, addr := newTestRelay(t, nil)
+
+		err := smtp.SendMail(addr, nil, "alerts@example.com", []string{"foo@bar.com"}, []byte(testEmail))
+		require.ErrorContains(t, err, "authentication required")
+	})
+
+	t.Run("InvalidSecret", func(t *testing.T) {
+		_, addr := newTestRelay(t, nil)
+
+		err := smtp.SendMail(addr, smtp.CRAMMD5Auth(Username, "other-secret"), "alerts@example.com", []string{"foo@bar.com"},
+			[]byte(testEmail))
+		require.ErrorContains(t, err, "authentication failed")
+	})
+
+	t.Run("SenderNotAllowed", func(t *testing.T) {
+		_, addr := newTestRelay(t, nil)
+
+		err := smtp.SendMail(addr, testAuth, "ceo@example.com", []string{"foo@bar.com"}, []byte(testEmail))
+		require.ErrorContains(t, err, "sender not allowed")
+	})
+}
+
+func TestParsePath(t *testing.T) {
+	testCases := []struct {
+		arg      string
+		prefix   string
+		expected string
+		found    bool
+	}{
+		{arg: "FROM:<alerts@example.com>", prefix: "FROM:", expected: "alerts@example.com", found: true},
+		{arg: "from: <alerts@example.com> BODY=8BITMIME", prefix: "FROM:", expected: "alerts@example.com", found: true},
+		{arg: "FROM:<>", prefix: "FROM:", expected: "", found: true},
+		{arg: "TO:foo@bar.com", prefix: "TO:", found: false},
+		{arg: "<foo@bar.com>", prefix: "TO:", found: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.arg, func(t *testing.T) {
+			addr, found := parsePath(tc.arg, tc.prefix)
+			require.Equal(t, tc.found, found)
+			require.Equal(t, tc.expected, addr)
+		})
+	}
+}
@@ -0,0 +1,293 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package mailrelay
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Object identifiers of the CMS (RFC 5652) structures and algorithms used to sign emails.
+var (
+	oidData                   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1}
+	oidSignedData             = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}
+	oidAttributeContentType   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 3}
+	oidAttributeMessageDigest = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 4}
+	oidAttributeSigningTime   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 5}
+	oidDigestSHA256           = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}
+	oidEncryptionRSA          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 1}
+	oidSignatureECDSASHA256   = asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 2}
+)
+
+type algorithmIdentifier struct {
+	Algorithm  asn1.ObjectIdentifier
+	Parameters asn1.RawValue `asn1:"optional"`
+}
+
+type contentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue `asn1:"optional"`
+}
+
+type issuerAndSerialNumber struct {
+	Issuer       asn1.RawValue
+	SerialNumber *big.Int
+}
+
+type attribute struct {
+	Type   asn1.ObjectIdentifier
+	Values asn1.RawValue
+}
+
+type signerInfo struct {
+	Version            int
+	SID                issuerAndSerialNumber
+	DigestAlgorithm    algorithmIdentifier
+	SignedAttrs        asn1.RawValue
+	SignatureAlgorithm algorithmIdentifier
+	Signature          []byte
+}
+
+type signedData struct {
+	Version          int
+	DigestAlgorithms []algorithmIdentifier `asn1:"set"`
+	EncapContentInfo contentInfo
+	Certificates     asn1.RawValue
+	SignerInfos      []signerInfo `asn1:"set"`
+}
+
+// Signer signs emails with S/MIME (RFC 8551), wrapping them in a multipart/signed message holding a detached CMS signature.
+type Signer struct {
+	certificate *x509.Certificate
+	chain       [][]byte
+	key         crypto.Signer
+	now         func() time.Time
+}
+
+// NewSigner creates a new Signer from the PEM encoded certificate, optionally followed by its intermediate certificates, and
+// private key files. Only RSA and ECDSA keys are supported.
+func NewSigner(certFile, keyFile string) (*Signer, error) {
+	pair, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load signing certificate: %w", err)
+	}
+
+	cert, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signing certificate: %w", err)
+	}
+
+	switch pair.PrivateKey.(type) {
+	case *rsa.PrivateKey, *ecdsa.PrivateKey:
+	default:
+		return nil, fmt.Errorf("unsupported signing key type %T", pair.PrivateKey)
+	}
+
+	return &Signer{
+		certificate: cert,
+		chain:       pair.Certificate,
+		key:         pair.PrivateKey.(crypto.Signer),
+		now:         time.Now,
+	}, nil
+}
+
+// Sign returns the given email signed with S/MIME. The MIME headers of the email are moved to the signed part, the other
+// headers are kept as headers of the signed message.
+func (s *Signer) Sign(msg []byte) ([]byte, error) {
+	msg = canonicalize(msg)
+
+	end := bytes.Index(msg, []byte("\r\n\r\n"))
+	if end < 0 {
+		return nil, errors.New("email has no body")
+	}
+
+	var outer, inner []string
+	for _, field := range splitHeaderFields(string(msg[:end])) {
+		name, _, _ := strings.Cut(field, ":")
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch {
+		case name == "mime-version":
+		case strings.HasPrefix(name, "content-"):
+			inner = append(inner, field)
+		default:
+			outer = append(outer, field)
+		}
+	}
+	if len(inner) == 0 {
+		inner = []string{"Content-Type: text/plain; charset=us-ascii"}
+	}
+
+	content := []byte(strings.Join(inner, "\r\n") + "\r\n\r\n")
+	content = append(content, msg[end+4:]...)
+
+	signature, err := s.detachedSignature(content)
+	if err != nil {
+		return nil, err
+	}
+
+	boundary, err := randomBoundary()
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	for _, field := range outer {
+		buf.WriteString(field + "\r\n")
+	}
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&buf, "Content-Type: multipart/signed; protocol=\"application/pkcs7-signature\"; micalg=sha-256; boundary=%q\r\n\r\n", boundary)
+	buf.WriteString("This is a cryptographically signed message in MIME format.\r\n\r\n")
+	fmt.Fprintf(&buf, "--%s\r\n", boundary)
+	buf.Write(content)
+	fmt.Fprintf(&buf, "\r\n--%s\r\n", boundary)
+	buf.WriteString("Content-Type: application/pkcs7-signature; name=\"smime.p7s\"\r\n")
+	buf.WriteString("Content-Transfer-Encoding: base64\r\n")
+	buf.WriteString("Content-Disposition: attachment; filename=\"smime.p7s\"\r\n\r\n")
+	encoded := base64.StdEncoding.EncodeToString(signature)
+	for len(encoded) > 76 {
+		buf.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	buf.WriteString(encoded + "\r\n")
+	fmt.Fprintf(&buf, "--%s--\r\n", boundary)
+
+	return buf.Bytes(), nil
+}
+
+// detachedSignature returns the DER encoded CMS SignedData of the given content, which is not embedded in it.
+func (s *Signer) detachedSignature(content []byte) ([]byte, error) {
+	digest := sha256.Sum256(content)
+
+	attrs, err := signedAttributes(digest[:], s.now())
+	if err != nil {
+		return nil, err
+	}
+
+	// The signature is computed over the DER encoding of the signed attributes as a SET OF, while they are stored with
+	// an implicit context specific tag.
+	attrsSet, err := asn1.Marshal(asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSet, IsCompound: true, Bytes: attrs})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal signed attributes: %w", err)
+	}
+	attrsDigest := sha256.Sum256(attrsSet)
+
+	signature, err := s.key.Sign(rand.Reader, attrsDigest[:], crypto.SHA256)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign email: %w", err)
+	}
+
+	signatureAlgorithm := algorithmIdentifier{Algorithm: oidEncryptionRSA, Parameters: asn1.NullRawValue}
+	if _, ok := s.key.(*ecdsa.PrivateKey); ok {
+		signatureAlgorithm = algorithmIdentifier{Algorithm: oidSignatureECDSASHA256}
+	}
+	digestAlgorithm := algorithmIdentifier{Algorithm: oidDigestSHA256, Parameters: asn1.NullRawValue}
+
+	sd := signedData{
+		Version:          1,
+		DigestAlgorithms: []algorithmIdentifier{digestAlgorithm},
+		EncapContentInfo: contentInfo{ContentType: oidData},
+		Certificates:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: bytes.Join(s.chain, nil)},
+		SignerInfos: []signerInfo{
+			{
+				Version: 1,
+				SID: issuerAndSerialNumber{
+					Issuer:       asn1.RawValue{FullBytes: s.certificate.RawIssuer},
+					SerialNumber: s.certificate.SerialNumber,
+				},
+				DigestAlgorithm:    digestAlgorithm,
+				SignedAttrs:        asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: attrs},
+				SignatureAlgorithm: signatureAlgorithm,
+				Signature:          signature,
+			},
+		},
+	}
+
+	sdData, err := asn1.Marshal(sd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal signed data: %w", err)
+	}
+
+	data, err := asn1.Marshal(contentInfo{
+		ContentType: oidSignedData,
+		Content:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: sdData},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal content info: %w", err)
+	}
+	return data, nil
+}
+
+// signedAttributes returns the concatenated DER encoding of the content type, signing time and message digest attributes,
+// sorted as required by the DER encoding of a SET OF.
+func signedAttributes(digest []byte, signingTime time.Time) ([]byte, error) {
+	values := []struct {
+		oid   asn1.ObjectIdentifier
+		value any
+	}{
+		{oidAttributeContentType, oidData},
+		{oidAttributeSigningTime, signingTime.UTC()},
+		{oidAttributeMessageDigest, digest},
+	}
+
+	encoded := make([][]byte, 0, len(values))
+	for _, v := range values {
+		value, err := asn1.Marshal(v.value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal attribute %v: %w", v.oid, err)
+		}
+		attr, err := asn1.Marshal(attribute{
+			Type:   v.oid,
+			Values: asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSet, IsCompound: true, Bytes: value},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal attribute %v: %w", v.oid, err)
+		}
+		encoded = append(encoded, attr)
+	}
+
+	slices.SortFunc(encoded, bytes.Compare)
+	return bytes.Join(encoded, nil), nil
+}
+
+// splitHeaderFields splits the header section of an email into its fields, keeping folded lines with their field.
+func splitHeaderFields(header string) []string {
+	var fields []string
+	for _, line := range strings.Split(header, "\r\n") {
+		if len(fields) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			fields[len(fields)-1] += "\r\n" + line
+			continue
+		}
+		fields = append(fields, line)
+	}
+	return fields
+}
+
+// canonicalize returns the given email with CRLF line endings, as the signature is computed over its canonical form.
+func canonicalize(msg []byte) []byte {
+	msg = bytes.ReplaceAll(msg, []byte("\r\n"), []byte("\n"))
+	return bytes.ReplaceAll(msg, []byte("\n"), []byte("\r\n"))
+}
+
+// randomBoundary returns a random boundary of a multipart message.
+func randomBoundary() (string, error) {
+	var buf [24]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return "", fmt.Errorf("failed to generate boundary: %w", err)
+	}
+	return fmt.Sprintf("----=_signed_%x", buf), nil
+}
@@ -0,0 +1,167 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package mailrelay
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testEmail = "From: alerts@example.com\n" +
+	"To: foo@bar.com\n" +
+	"Subject: [FIRING:1] HighCPUUsage\n" +
+	"MIME-Version: 1.0\n" +
+	"Content-Type: text/html; charset=UTF-8\n" +
+	"Content-Transfer-Encoding: quoted-printable\n" +
+	"X-Folded: first\n" +
+	" second\n" +
+	"\n" +
+	"<html>alert</html>\n"
+
+// writeTestCertificate writes a self-signed certificate and its private key to PEM files, returning their paths.
+func writeTestCertificate(t *testing.T, key crypto.Signer) (string, string) {
+	t.Helper()
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(42),
+		Subject:      pkix.Name{CommonName: "alerts@example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageEmailProtection},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return certFile, keyFile
+}
+
+// verifySigned checks that the given email is signed by the given signer, returning its signed content.
+func verifySigned(t *testing.T, signer *Signer, msg []byte) string {
+	t.Helper()
+
+	matches := regexp.MustCompile(`boundary="([^"]+)"`).FindSubmatch(msg)
+	require.Len(t, matches, 2)
+	delimiter := "--" + string(matches[1])
+
+	parts := strings.Split(string(msg), delimiter)
+	require.Len(t, parts, 4)
+	content := strings.TrimPrefix(strings.TrimSuffix(parts[1], "\r\n"), "\r\n")
+	_, encoded, found := strings.Cut(strings.TrimSuffix(parts[2], "\r\n"), "\r\n\r\n")
+	require.True(t, found)
+
+	der, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(encoded, "\r\n", ""))
+	require.NoError(t, err)
+
+	var ci contentInfo
+	_, err = asn1.Unmarshal(der, &ci)
+	require.NoError(t, err)
+	require.True(t, ci.ContentType.Equal(oidSignedData))
+
+	var sd signedData
+	_, err = asn1.Unmarshal(ci.Content.Bytes, &sd)
+	require.NoError(t, err)
+	require.Equal(t, signer.certificate.Raw, sd.Certificates.Bytes)
+	require.Len(t, sd.SignerInfos, 1)
+
+	si := sd.SignerInfos[0]
+	require.Equal(t, signer.certificate.SerialNumber, si.SID.SerialNumber)
+
+	// The message digest attribute must match the digest of the signed content.
+	digest := sha256.Sum256([]byte(content))
+	var attrs []attribute
+	_, err = asn1.UnmarshalWithParams(si.SignedAttrs.FullBytes, &attrs, "set,tag:0")
+	require.NoError(t, err)
+	var messageDigest []byte
+	for _, attr := range attrs {
+		if attr.Type.Equal(oidAttributeMessageDigest) {
+			_, err = asn1.Unmarshal(attr.Values.Bytes, &messageDigest)
+			require.NoError(t, err)
+		}
+	}
+	require.Equal(t, digest[:], messageDigest)
+
+	attrsSet, err := asn1.Marshal(asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSet, IsCompound: true, Bytes: si.SignedAttrs.Bytes})
+	require.NoError(t, err)
+
+	algorithm := x509.SHA256WithRSA
+	if si.SignatureAlgorithm.Algorithm.Equal(oidSignatureECDSASHA256) {
+		algorithm = x509.ECDSAWithSHA256
+	}
+	require.NoError(t, signer.certificate.CheckSignature(algorithm, attrsSet, si.Signature))
+
+	return content
+}
+
+func TestSigner_Sign(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	for name, key := range map[string]crypto.Signer{"RSA": rsaKey, "ECDSA": ecdsaKey} {
+		t.Run(name, func(t *testing.T) {
+			signer, err := NewSigner(writeTestCertificate(t, key))
+			require.NoError(t, err)
+
+			signed, err := signer.Sign([]byte(testEmail))
+			require.NoError(t, err)
+
+			header, _, found := bytes.Cut(signed, []byte("\r\n\r\n"))
+			require.True(t, found)
+			require.Contains(t, string(header), "Subject: [FIRING:1] HighCPUUsage\r\n")
+			require.Contains(t, string(header), "X-Folded: first\r\n second\r\n")
+			require.Contains(t, string(header), `Content-Type: multipart/signed; protocol="application/pkcs7-signature"; micalg=sha-256;`)
+			require.NotContains(t, string(header), "text/html")
+
+			content := verifySigned(t, signer, signed)
+			require.Equal(t, "Content-Type: text/html; charset=UTF-8\r\n"+
+				"Content-Transfer-Encoding: quoted-printable\r\n"+
+				"\r\n"+
+				"<html>alert</html>\r\n", content)
+		})
+	}
+
+	t.Run("EmailWithoutBody", func(t *testing.T) {
+		signer, err := NewSigner(writeTestCertificate(t, ecdsaKey))
+		require.NoError(t, err)
+
+		_, err = signer.Sign([]byte("Subject: test\r\n"))
+		require.ErrorContains(t, err, "email has no body")
+	})
+}
+
+func TestNewSigner(t *testing.T) {
+	t.Run("MissingFiles", func(t *testing.T) {
+		_, err := NewSigner("/nonexistent/tls.crt", "/nonexistent/tls.key")
+		require.ErrorContains(t, err, "failed to load signing certificate")
+	})
+}
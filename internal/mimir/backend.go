@@ -0,0 +1,376 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package mimir
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/app"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/correlation"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/rules"
+)
+
+// Rule backends selectable by the backend of the Mimir configuration.
+const (
+	BackendMimir      = "mimir"
+	BackendCortex     = "cortex"
+	BackendThanos     = "thanos"
+	BackendPrometheus = "prometheus"
+)
+
+// ErrRuleGroupNotFound is returned by rule backends when a rule group does not exist.
+var ErrRuleGroupNotFound = errors.New("rule group not found")
+
+// ruleFilesMu serializes the changes to rule files, which hold the rule groups of all alert definitions of a tenant.
+var ruleFilesMu sync.Mutex
+
+// RuleBackend stores the rule groups of alert definitions in the namespace of alert definitions of the ruler of a metric stack,
+// per tenant, so that the same definition pipeline drives different metric stacks.
+type RuleBackend interface {
+	// PostRuleGroup creates or replaces a rule group of the given tenant.
+	PostRuleGroup(ctx context.Context, rg rules.RuleGroup, tenant string) error
+	// GetRuleGroup gets a rule group of the given tenant. ErrRuleGroupNotFound is returned if it does not exist.
+	GetRuleGroup(ctx context.Context, name string, tenant string) (rules.RuleGroup, error)
+	// DeleteRuleGroup deletes a rule group of the given tenant. A rule group that does not exist is not considered an error.
+	DeleteRuleGroup(ctx context.Context, name string, tenant string) error
+	// DeleteTenantRules deletes the rule groups of all alert definitions of the given tenant.
+	DeleteTenantRules(ctx context.Context, tenant string) error
+	// APIPrefix is the path prefix of the Prometheus HTTP API served by the ruler and the querier.
+	APIPrefix() string
+	// IsRuleFile tells whether rule groups reported in the given file by the rules API of the ruler are the ones of alert
+	// definitions of the given tenant.
+	IsRuleFile(file string, tenant string) bool
+}
+
+// NewRuleBackend returns the rule backend selected by the given configuration, Mimir ruler if none is selected.
+func NewRuleBackend(cfg *config.MimirConfig) (RuleBackend, error) {
+	switch cfg.Backend {
+	case "", BackendMimir:
+		return &rulerAPIBackend{
+			name:      "mimir",
+			rulesURL:  fmt.Sprintf("%v/prometheus/config/v1/rules/%v", cfg.RulerURL, cfg.Namespace),
+			apiPrefix: "/prometheus",
+			namespace: cfg.Namespace,
+		}, nil
+	case BackendCortex:
+		return &rulerAPIBackend{
+			name:      "cortex",
+			rulesURL:  fmt.Sprintf("%v/api/v1/rules/%v", cfg.RulerURL, cfg.Namespace),
+			apiPrefix: "/prometheus",
+			namespace: cfg.Namespace,
+		}, nil
+	case BackendThanos, BackendPrometheus:
+		if cfg.RulesDir == "" {
+			return nil, fmt.Errorf("rules directory of %v backend is not set", cfg.Backend)
+		}
+		return &ruleFilesBackend{
+			name:      cfg.Backend,
+			dir:       cfg.RulesDir,
+			namespace: cfg.Namespace,
+			reloadURL: cfg.RulerURL + "/-/reload",
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown rule backend %q, expected one of: %v, %v, %v, %v", cfg.Backend,
+			BackendMimir, BackendCortex, BackendThanos, BackendPrometheus)
+	}
+}
+
+// rulerAPIBackend stores rule groups through the ruler configuration API of Mimir or Cortex, whose namespaces are per tenant
+// given by the X-Scope-OrgID header.
+type rulerAPIBackend struct {
+	name      string
+	rulesURL  string
+	apiPrefix string
+	namespace string
+}
+
+func (b *rulerAPIBackend) PostRuleGroup(ctx context.Context, rg rules.RuleGroup, tenant string) error {
+	data, err := yaml.Marshal(rg)
+	if err != nil {
+		return err
+	}
+
+	_, err = SendRequest(ctx, b.rulesURL, http.MethodPost, tenant, data)
+	return err
+}
+
+func (b *rulerAPIBackend) GetRuleGroup(ctx context.Context, name string, tenant string) (rules.RuleGroup, error) {
+	req, err := createHTTPRequest(ctx, b.rulesURL+"/"+name, http.MethodGet, tenant, nil)
+	if err != nil {
+		return rules.RuleGroup{}, fmt.Errorf("error creating http request: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return rules.RuleGroup{}, fmt.Errorf("error doing http request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return rules.RuleGroup{}, ErrRuleGroupNotFound
+	default:
+		return rules.RuleGroup{}, fmt.Errorf("failed to get rule group %q, got unexpected status code: %v", name, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return rules.RuleGroup{}, err
+	}
+
+	var rg rules.RuleGroup
+	if err := yaml.Unmarshal(body, &rg); err != nil {
+		return rules.RuleGroup{}, fmt.Errorf("failed to unmarshal received data: %w", err)
+	}
+	return rg, nil
+}
+
+func (b *rulerAPIBackend) DeleteRuleGroup(ctx context.Context, name string, tenant string) error {
+	statusCode, err := sendRequestForStatus(ctx, b.rulesURL+"/"+name, http.MethodDelete, tenant)
+	if err != nil {
+		return err
+	}
+
+	switch statusCode {
+	case http.StatusOK, http.StatusAccepted, http.StatusNotFound:
+		return nil
+	default:
+		return fmt.Errorf("failed to delete rule group %q from %v, got unexpected status code: %v", name, b.name, statusCode)
+	}
+}
+
+// DeleteTenantRules deletes the namespace of the given tenant. A namespace that does not exist is not considered an error,
+// since the tenant has no rules left to delete.
+func (b *rulerAPIBackend) DeleteTenantRules(ctx context.Context, tenant string) error {
+	statusCode, err := sendRequestForStatus(ctx, b.rulesURL, http.MethodDelete, tenant)
+	if err != nil {
+		return err
+	}
+
+	switch statusCode {
+	case http.StatusOK, http.StatusAccepted, http.StatusNotFound:
+		return nil
+	default:
+		return fmt.Errorf("failed to delete rule groups of tenant %q, got unexpected status code: %v", tenant, statusCode)
+	}
+}
+
+func (b *rulerAPIBackend) APIPrefix() string {
+	return b.apiPrefix
+}
+
+// IsRuleFile tells whether the file is the namespace of alert definitions, the rules API only reporting the rule groups of
+// the tenant of the request.
+func (b *rulerAPIBackend) IsRuleFile(file string, _ string) bool {
+	return file == b.namespace
+}
+
+// ruleFilesBackend stores rule groups in rule files loaded by Thanos Ruler or Prometheus, which are told to reload them
+// through their lifecycle API. The rule groups of the alert definitions of a tenant are held by the file named after the
+// namespace in the directory of the tenant, which the rule files of the ruler must match. Neither isolates tenants, so their
+// isolation relies on the tenant label matched by the expressions of alert definitions.
+type ruleFilesBackend struct {
+	name      string
+	dir       string
+	namespace string
+	reloadURL string
+}
+
+// ruleFile is the content of a rule file.
+type ruleFile struct {
+	Groups []rules.RuleGroup `yaml:"groups"`
+}
+
+// PostRuleGroup writes the rule group to the rule file of the given tenant. The durations of its rules are written in the
+// format the rules API of Mimir returns them in, which rule groups are compared in once posted.
+func (b *ruleFilesBackend) PostRuleGroup(ctx context.Context, rg rules.RuleGroup, tenant string) error {
+	rg.Rules = slices.Clone(rg.Rules)
+	for i, rule := range rg.Rules {
+		if rule.For == "" {
+			continue
+		}
+		dur, err := time.ParseDuration(rule.For)
+		if err != nil {
+			return fmt.Errorf("failed to parse duration %v: %w", rule.For, err)
+		}
+		rg.Rules[i].For = app.FormatDuration(dur)
+	}
+
+	return b.updateRuleFile(ctx, tenant, func(f *ruleFile) bool {
+		i := slices.IndexFunc(f.Groups, func(group rules.RuleGroup) bool { return group.Name == rg.Name })
+		if i < 0 {
+			f.Groups = append(f.Groups, rg)
+		} else {
+			f.Groups[i] = rg
+		}
+		return true
+	})
+}
+
+func (b *ruleFilesBackend) GetRuleGroup(_ context.Context, name string, tenant string) (rules.RuleGroup, error) {
+	path, err := b.path(tenant)
+	if err != nil {
+		return rules.RuleGroup{}, err
+	}
+
+	ruleFilesMu.Lock()
+	defer ruleFilesMu.Unlock()
+
+	f, err := readRuleFile(path)
+	if err != nil {
+		return rules.RuleGroup{}, err
+	}
+
+	i := slices.IndexFunc(f.Groups, func(group rules.RuleGroup) bool { return group.Name == name })
+	if i < 0 {
+		return rules.RuleGroup{}, ErrRuleGroupNotFound
+	}
+	return f.Groups[i], nil
+}
+
+func (b *ruleFilesBackend) DeleteRuleGroup(ctx context.Context, name string, tenant string) error {
+	return b.updateRuleFile(ctx, tenant, func(f *ruleFile) bool {
+		n := len(f.Groups)
+		f.Groups = slices.DeleteFunc(f.Groups, func(group rules.RuleGroup) bool { return group.Name == name })
+		return len(f.Groups) != n
+	})
+}
+
+// DeleteTenantRules deletes the rule file of the given tenant. A rule file that does not exist is not considered an error,
+// since the tenant has no rules left to delete.
+func (b *ruleFilesBackend) DeleteTenantRules(ctx context.Context, tenant string) error {
+	path, err := b.path(tenant)
+	if err != nil {
+		return err
+	}
+
+	ruleFilesMu.Lock()
+	defer ruleFilesMu.Unlock()
+
+	if err := os.Remove(path); errors.Is(err, fs.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to delete rule groups of tenant %q: %w", tenant, err)
+	}
+	return b.reload(ctx)
+}
+
+func (b *ruleFilesBackend) APIPrefix() string {
+	return ""
+}
+
+// IsRuleFile tells whether the file is the rule file of the given tenant, as loaded by the ruler from a directory which may
+// be mounted at a different path than the one rule files are written to.
+func (b *ruleFilesBackend) IsRuleFile(file string, tenant string) bool {
+	return strings.HasSuffix(file, "/"+tenant+"/"+b.namespace+".yaml")
+}
+
+// path returns the path of the rule file of the given tenant.
+func (b *ruleFilesBackend) path(tenant string) (string, error) {
+	if tenant == "" || tenant == "." || tenant == ".." || strings.ContainsAny(tenant, `/\`) {
+		return "", fmt.Errorf("invalid tenant %q for rule files", tenant)
+	}
+	return filepath.Join(b.dir, tenant, b.namespace+".yaml"), nil
+}
+
+// updateRuleFile applies the given change to the rule file of the given tenant, and tells the ruler to reload its rule files
+// if the change reports that it modified the rule file. The rule file is replaced atomically, so that the ruler never loads
+// a partially written one.
+func (b *ruleFilesBackend) updateRuleFile(ctx context.Context, tenant string, change func(*ruleFile) bool) error {
+	path, err := b.path(tenant)
+	if err != nil {
+		return err
+	}
+
+	ruleFilesMu.Lock()
+	defer ruleFilesMu.Unlock()
+
+	f, err := readRuleFile(path)
+	if err != nil {
+		return err
+	}
+	if !change(&f) {
+		return nil
+	}
+
+	data, err := yaml.Marshal(f)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create rules directory of tenant %q: %w", tenant, err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+b.namespace+"-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create rule file of tenant %q: %w", tenant, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write rule file of tenant %q: %w", tenant, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write rule file of tenant %q: %w", tenant, err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return fmt.Errorf("failed to write rule file of tenant %q: %w", tenant, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace rule file of tenant %q: %w", tenant, err)
+	}
+	return b.reload(ctx)
+}
+
+// reload tells the ruler to reload its rule files. The ruler fails to reload if any rule file is invalid.
+func (b *ruleFilesBackend) reload(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.reloadURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create new http request: %w", err)
+	}
+	correlation.SetHeader(req)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reload rules of %v: %w", b.name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to reload rules of %v, got unexpected status code: %v", b.name, resp.StatusCode)
+	}
+	return nil
+}
+
+// readRuleFile reads the rule file at the given path, empty if it does not exist.
+func readRuleFile(path string) (ruleFile, error) {
+	var f ruleFile
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return f, nil
+	} else if err != nil {
+		return f, fmt.Errorf("failed to read rule file %q: %w", path, err)
+	}
+
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return f, fmt.Errorf("failed to unmarshal rule file %q: %w", path, err)
+	}
+	return f, nil
+}
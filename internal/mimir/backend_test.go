@@ -0,0 +1,170 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package mimir
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/rules"
+)
+
+func TestNewRuleBackend(t *testing.T) {
+	tests := map[string]struct {
+		cfg           config.MimirConfig
+		apiPrefix     string
+		expectedError error
+	}{
+		"Mimir by default": {
+			cfg:       config.MimirConfig{},
+			apiPrefix: "/prometheus",
+		},
+		"Cortex": {
+			cfg:       config.MimirConfig{Backend: BackendCortex},
+			apiPrefix: "/prometheus",
+		},
+		"Thanos Ruler": {
+			cfg: config.MimirConfig{Backend: BackendThanos, RulesDir: "/etc/thanos/rules"},
+		},
+		"Prometheus without rules directory": {
+			cfg:           config.MimirConfig{Backend: BackendPrometheus},
+			expectedError: errors.New("rules directory of prometheus backend is not set"),
+		},
+		"Unknown backend": {
+			cfg:           config.MimirConfig{Backend: "victoriametrics"},
+			expectedError: errors.New(`unknown rule backend "victoriametrics"`),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			backend, err := NewRuleBackend(&test.cfg)
+			if test.expectedError != nil {
+				require.ErrorContains(t, err, test.expectedError.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.apiPrefix, backend.APIPrefix())
+		})
+	}
+}
+
+func TestRulerAPIBackendCortex(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "testTenant", r.Header.Get("X-Scope-OrgID"))
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		if r.Method == http.MethodGet {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	backend, err := NewRuleBackend(&config.MimirConfig{Backend: BackendCortex, Namespace: "alerting", RulerURL: server.URL})
+	require.NoError(t, err)
+
+	require.NoError(t, backend.PostRuleGroup(t.Context(), rules.RuleGroup{Name: "group"}, "testTenant"))
+	_, err = backend.GetRuleGroup(t.Context(), "group", "testTenant")
+	require.ErrorIs(t, err, ErrRuleGroupNotFound)
+	require.NoError(t, backend.DeleteTenantRules(t.Context(), "testTenant"))
+
+	require.Equal(t, []string{
+		"POST /api/v1/rules/alerting",
+		"GET /api/v1/rules/alerting/group",
+		"DELETE /api/v1/rules/alerting",
+	}, paths)
+}
+
+func TestRuleFilesBackend(t *testing.T) {
+	reloadStatus := http.StatusOK
+	reloads := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/-/reload", r.URL.Path)
+		reloads++
+		w.WriteHeader(reloadStatus)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	backend, err := NewRuleBackend(&config.MimirConfig{
+		Backend:   BackendPrometheus,
+		RulesDir:  dir,
+		Namespace: "alerting",
+		RulerURL:  server.URL,
+	})
+	require.NoError(t, err)
+
+	group := rules.RuleGroup{
+		Name:     "01e74407-0327-4e36-93cb-85801c098ba5",
+		Interval: "15s",
+		Rules:    []rules.Rule{{Alert: "HighCPUUsage", Expr: "cpu_usage > 80", For: "1m0s"}},
+	}
+	path := filepath.Join(dir, "testTenant", "alerting.yaml")
+
+	t.Run("Rule groups are written to the rule file of the tenant", func(t *testing.T) {
+		require.NoError(t, backend.PostRuleGroup(t.Context(), group, "testTenant"))
+		require.NoError(t, backend.PostRuleGroup(t.Context(), rules.RuleGroup{Name: "other"}, "testTenant"))
+		require.Equal(t, 2, reloads)
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		var f ruleFile
+		require.NoError(t, yaml.Unmarshal(data, &f))
+		require.Len(t, f.Groups, 2)
+
+		received, err := backend.GetRuleGroup(t.Context(), group.Name, "testTenant")
+		require.NoError(t, err)
+		require.Equal(t, "1m", received.Rules[0].For)
+		require.Equal(t, "1m0s", group.Rules[0].For)
+
+		_, err = backend.GetRuleGroup(t.Context(), group.Name, "otherTenant")
+		require.ErrorIs(t, err, ErrRuleGroupNotFound)
+
+		require.True(t, backend.IsRuleFile("/etc/prometheus/rules/testTenant/alerting.yaml", "testTenant"))
+		require.False(t, backend.IsRuleFile("/etc/prometheus/rules/otherTenant/alerting.yaml", "testTenant"))
+	})
+
+	t.Run("Deleted rule groups are removed from the rule file", func(t *testing.T) {
+		require.NoError(t, backend.DeleteRuleGroup(t.Context(), group.Name, "testTenant"))
+		_, err := backend.GetRuleGroup(t.Context(), group.Name, "testTenant")
+		require.ErrorIs(t, err, ErrRuleGroupNotFound)
+		require.Equal(t, 3, reloads)
+
+		// Deleting a rule group that does not exist does not reload the rules.
+		require.NoError(t, backend.DeleteRuleGroup(t.Context(), group.Name, "testTenant"))
+		require.Equal(t, 3, reloads)
+	})
+
+	t.Run("Failed reload is an error", func(t *testing.T) {
+		reloadStatus = http.StatusInternalServerError
+		defer func() { reloadStatus = http.StatusOK }()
+
+		err := backend.PostRuleGroup(t.Context(), group, "testTenant")
+		require.ErrorContains(t, err, "failed to reload rules of prometheus, got unexpected status code: 500")
+	})
+
+	t.Run("Rule file of the tenant is deleted", func(t *testing.T) {
+		require.NoError(t, backend.DeleteTenantRules(t.Context(), "testTenant"))
+		require.NoFileExists(t, path)
+		require.NoError(t, backend.DeleteTenantRules(t.Context(), "testTenant"))
+	})
+
+	t.Run("Tenants escaping the rules directory are rejected", func(t *testing.T) {
+		err := backend.PostRuleGroup(t.Context(), group, "..")
+		require.ErrorContains(t, err, `invalid tenant ".." for rule files`)
+		err = backend.PostRuleGroup(t.Context(), group, "../tenant")
+		require.ErrorContains(t, err, `invalid tenant "../tenant" for rule files`)
+	})
+}
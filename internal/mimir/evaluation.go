@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package mimir
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// RuleGroupEvaluation is the last evaluation of a rule group by Mimir ruler. Error is the last error of the rules of the group,
+// empty if all of them were evaluated successfully.
+type RuleGroupEvaluation struct {
+	Name           string
+	Duration       time.Duration
+	LastEvaluation time.Time
+	Error          string
+}
+
+// RuleEvaluationQuerier facilitates querying the last evaluation of the rule groups of alert definitions from Mimir ruler.
+type RuleEvaluationQuerier interface {
+	GetRuleGroupEvaluations(ctx context.Context, tenant string) ([]RuleGroupEvaluation, error)
+}
+
+// GetRuleGroupEvaluations gets the last evaluation of the rule groups of the given tenant in the namespace of alert definitions
+// from Mimir ruler. Rule groups which were not evaluated yet are left out.
+func (mu *Mimir) GetRuleGroupEvaluations(ctx context.Context, tenant string) ([]RuleGroupEvaluation, error) {
+	backend, err := mu.backend()
+	if err != nil {
+		return nil, err
+	}
+
+	urlRaw := fmt.Sprintf("%v%v/api/v1/rules?type=alert", mu.Config.RulerURL, backend.APIPrefix())
+	out, err := SendRequest(ctx, urlRaw, http.MethodGet, tenant, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error while trying to get rule groups from mimir: %w", err)
+	}
+
+	var resp struct {
+		Status string `json:"status"`
+		Data   struct {
+			Groups []struct {
+				Name           string    `json:"name"`
+				File           string    `json:"file"`
+				EvaluationTime float64   `json:"evaluationTime"`
+				LastEvaluation time.Time `json:"lastEvaluation"`
+				Rules          []struct {
+					Health    string `json:"health"`
+					LastError string `json:"lastError"`
+				} `json:"rules"`
+			} `json:"groups"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(out, &resp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal received data: %w", err)
+	}
+	if resp.Status != "success" {
+		return nil, fmt.Errorf("query of rule groups failed with status %q", resp.Status)
+	}
+
+	evaluations := make([]RuleGroupEvaluation, 0, len(resp.Data.Groups))
+	for _, group := range resp.Data.Groups {
+		if !backend.IsRuleFile(group.File, tenant) || group.LastEvaluation.IsZero() {
+			continue
+		}
+
+		evaluation := RuleGroupEvaluation{
+			Name:           group.Name,
+			Duration:       time.Duration(group.EvaluationTime * float64(time.Second)),
+			LastEvaluation: group.LastEvaluation,
+		}
+		for _, rule := range group.Rules {
+			if rule.Health == "err" {
+				evaluation.Error = rule.LastError
+			}
+		}
+		evaluations = append(evaluations, evaluation)
+	}
+	return evaluations, nil
+}
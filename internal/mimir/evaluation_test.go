@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package mimir
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+)
+
+func TestGetRuleGroupEvaluations(t *testing.T) {
+	const rulesOutput = `{"status":"success","data":{"groups":[
+{"name":"2f0a3e1c-0d9b-4a53-9a0e-7f0c3bde2a11","file":"alerting-monitor","evaluationTime":0.25,"lastEvaluation":"2026-10-16T12:00:00Z",
+ "rules":[{"health":"ok","lastError":""}]},
+{"name":"9b1deb4d-3b7d-4bad-9bdd-2b0d7b3dcb6d","file":"alerting-monitor","evaluationTime":12.5,"lastEvaluation":"2026-10-16T12:00:30Z",
+ "rules":[{"health":"err","lastError":"query timed out"}]},
+{"name":"c56a4180-65aa-42ec-a945-5fd21dec0538","file":"alerting-monitor","evaluationTime":0,"lastEvaluation":"0001-01-01T00:00:00Z",
+ "rules":[{"health":"unknown","lastError":""}]},
+{"name":"recording-rules","file":"other","evaluationTime":1,"lastEvaluation":"2026-10-16T12:00:00Z","rules":[]}
+]}}`
+
+	tests := map[string]struct {
+		statusCode    int
+		mimirOutput   string
+		expected      []RuleGroupEvaluation
+		expectedError error
+	}{
+		"Evaluations of the rule groups in the namespace": {
+			statusCode:  http.StatusOK,
+			mimirOutput: rulesOutput,
+			expected: []RuleGroupEvaluation{
+				{
+					Name:           "2f0a3e1c-0d9b-4a53-9a0e-7f0c3bde2a11",
+					Duration:       250 * time.Millisecond,
+					LastEvaluation: time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC),
+				},
+				{
+					Name:           "9b1deb4d-3b7d-4bad-9bdd-2b0d7b3dcb6d",
+					Duration:       12500 * time.Millisecond,
+					LastEvaluation: time.Date(2026, 10, 16, 12, 0, 30, 0, time.UTC),
+					Error:          "query timed out",
+				},
+			},
+		},
+		"Mimir responds with status code 500": {
+			statusCode:    http.StatusInternalServerError,
+			expectedError: errors.New("got unexpected status code: 500"),
+		},
+		"Query fails": {
+			statusCode:    http.StatusOK,
+			mimirOutput:   `{"status":"error"}`,
+			expectedError: errors.New(`query of rule groups failed with status "error"`),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/prometheus/api/v1/rules", r.URL.Path)
+				require.Equal(t, "testTenant", r.Header.Get("X-Scope-OrgID"))
+				w.WriteHeader(test.statusCode)
+				_, err := w.Write([]byte(test.mimirOutput))
+				require.NoError(t, err)
+			}))
+			defer server.Close()
+
+			mu := &Mimir{
+				Config: &config.MimirConfig{
+					RulerURL:  server.URL,
+					Namespace: "alerting-monitor",
+				},
+			}
+
+			evaluations, err := mu.GetRuleGroupEvaluations(t.Context(), "testTenant")
+			if test.expectedError != nil {
+				require.ErrorContains(t, err, test.expectedError.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, evaluations, len(test.expected))
+			for i, expected := range test.expected {
+				require.Equal(t, expected.Name, evaluations[i].Name)
+				require.Equal(t, expected.Duration, evaluations[i].Duration)
+				require.True(t, expected.LastEvaluation.Equal(evaluations[i].LastEvaluation))
+				require.Equal(t, expected.Error, evaluations[i].Error)
+			}
+		})
+	}
+}
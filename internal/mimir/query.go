@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package mimir
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/app"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/rules"
+)
+
+// statsResolution is the resolution of the subquery sampling the metric an alert definition compares against its threshold.
+const statsResolution = 5 * time.Minute
+
+// ErrNoMetricData is returned when Mimir holds no samples of the metric an alert definition compares against its threshold.
+var ErrNoMetricData = errors.New("no metric data")
+
+// MetricStatsQuerier facilitates querying the historical statistics of the metric an alert definition compares against its threshold.
+type MetricStatsQuerier interface {
+	QueryThresholdQuantile(ctx context.Context, alertDef *models.DBAlertDefinition, quantile float64, lookback time.Duration) (float64, error)
+}
+
+// QueryThresholdQuantile queries Mimir for the given quantile, over the lookback period, of the metric the alert definition compares
+// against its threshold. The highest quantile among all series of the metric is returned.
+func (mu *Mimir) QueryThresholdQuantile(ctx context.Context, alertDef *models.DBAlertDefinition, quantile float64, lookback time.Duration) (float64, error) {
+	ruleGroup, err := ConvertToRuleGroup(alertDef)
+	if err != nil {
+		return 0, err
+	}
+
+	operand, err := rules.ThresholdOperand(ruleGroup.Rules[0].Expr)
+	if err != nil {
+		return 0, err
+	}
+
+	query := fmt.Sprintf("max(quantile_over_time(%v, (%v)[%v:%v]))",
+		strconv.FormatFloat(quantile, 'f', -1, 64), operand, app.FormatDuration(lookback), app.FormatDuration(statsResolution))
+	backend, err := mu.backend()
+	if err != nil {
+		return 0, err
+	}
+
+	urlRaw := fmt.Sprintf("%v%v/api/v1/query?%v", mu.Config.QueryURL, backend.APIPrefix(), url.Values{"query": {query}}.Encode())
+
+	out, err := SendRequest(ctx, urlRaw, http.MethodGet, alertDef.TenantID, nil)
+	if err != nil {
+		return 0, fmt.Errorf("error while trying to query metric statistics from mimir: %w", err)
+	}
+
+	var resp struct {
+		Status string `json:"status"`
+		Data   struct {
+			Result []struct {
+				Value [2]any `json:"value"`
+			} `json:"result"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(out, &resp); err != nil {
+		return 0, fmt.Errorf("failed to unmarshal received data: %w", err)
+	}
+	if resp.Status != "success" {
+		return 0, fmt.Errorf("query of metric statistics failed with status %q", resp.Status)
+	}
+	if len(resp.Data.Result) == 0 {
+		return 0, fmt.Errorf("query %q: %w", query, ErrNoMetricData)
+	}
+
+	raw, ok := resp.Data.Result[0].Value[1].(string)
+	if !ok {
+		return 0, fmt.Errorf("unexpected sample value %v", resp.Data.Result[0].Value[1])
+	}
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse sample value %q: %w", raw, err)
+	}
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, fmt.Errorf("query %q: %w", query, ErrNoMetricData)
+	}
+
+	return value, nil
+}
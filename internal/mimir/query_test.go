@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package mimir
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/rules"
+)
+
+func TestQueryThresholdQuantile(t *testing.T) {
+	duration := int64(30)
+	threshold := int64(80)
+	enabled := true
+	alertDef := &models.DBAlertDefinition{
+		ID:       uuid.New(),
+		TenantID: "testTenant",
+		Template: `alert: HighMemoryUsage
+expr: avg_over_time(mem_used_percent{}[5m]) >= [[ .Threshold ]]
+for: '[[ .Duration ]]'
+labels:
+  threshold: "80"
+  duration: 30s
+`,
+		Values: models.DBAlertDefinitionValues{
+			Duration:  &duration,
+			Threshold: &threshold,
+			Enabled:   &enabled,
+		},
+	}
+
+	tests := map[string]struct {
+		alertDef      *models.DBAlertDefinition
+		statusCode    int
+		mimirOutput   string
+		expected      float64
+		expectedError error
+	}{
+		"Quantile of metric": {
+			alertDef:    alertDef,
+			statusCode:  http.StatusOK,
+			mimirOutput: `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,"72.25"]}]}}`,
+			expected:    72.25,
+		},
+		"No metric data": {
+			alertDef:      alertDef,
+			statusCode:    http.StatusOK,
+			mimirOutput:   `{"status":"success","data":{"resultType":"vector","result":[]}}`,
+			expectedError: ErrNoMetricData,
+		},
+		"Mimir responds with status code 500": {
+			alertDef:      alertDef,
+			statusCode:    http.StatusInternalServerError,
+			expectedError: errors.New("got unexpected status code: 500"),
+		},
+		"Malformed response": {
+			alertDef:      alertDef,
+			statusCode:    http.StatusOK,
+			mimirOutput:   `{"status":`,
+			expectedError: errors.New("failed to unmarshal received data"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/prometheus/api/v1/query", r.URL.Path)
+				require.Equal(t, "testTenant", r.Header.Get("X-Scope-OrgID"))
+				require.Equal(t, "max(quantile_over_time(0.99, (avg_over_time(mem_used_percent[5m]))[168h:5m]))", r.URL.Query().Get("query"))
+				w.WriteHeader(test.statusCode)
+				_, err := w.Write([]byte(test.mimirOutput))
+				require.NoError(t, err)
+			}))
+			defer server.Close()
+
+			mu := &Mimir{
+				Config: &config.MimirConfig{
+					QueryURL: server.URL,
+				},
+			}
+
+			value, err := mu.QueryThresholdQuantile(t.Context(), test.alertDef, 0.99, 168*time.Hour)
+			if test.expectedError != nil {
+				require.ErrorContains(t, err, test.expectedError.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.InDelta(t, test.expected, value, 0)
+		})
+	}
+
+	t.Run("Expression without threshold comparison", func(t *testing.T) {
+		def := *alertDef
+		def.Template = `alert: HostStatusError
+expr: edge_host_status{status="Error"} == [[ .Threshold ]]
+labels:
+  threshold: "1"
+`
+
+		mu := &Mimir{Config: &config.MimirConfig{}}
+		_, err := mu.QueryThresholdQuantile(t.Context(), &def, 0.99, 168*time.Hour)
+		require.ErrorIs(t, err, rules.ErrNoThresholdComparison)
+	})
+}
@@ -6,8 +6,10 @@ package mimir
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"reflect"
@@ -17,6 +19,7 @@ import (
 
 	"github.com/open-edge-platform/o11y-alerting-monitor/internal/app"
 	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/correlation"
 	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
 	"github.com/open-edge-platform/o11y-alerting-monitor/internal/rules"
 )
@@ -26,56 +29,195 @@ type DefinitionConfigUpdater interface {
 	UpdateDefinitionConfig(ctx context.Context, alertDef *models.DBAlertDefinition) error
 }
 
-// Mimir instance is responsible for facilitating communication of alerting monitor with Mimir.
-// Implements the DefinitionConfigUpdater interface.
+// TenantRulesRemover facilitates removing all Mimir rules of a tenant.
+type TenantRulesRemover interface {
+	DeleteTenantRules(ctx context.Context, tenant string) error
+}
+
+// AppliedRuleGroupRecorder records the rule groups successfully posted to Mimir, so that they can be compared and rolled back
+// to after an incident.
+type AppliedRuleGroupRecorder interface {
+	RecordAppliedArtifact(ctx context.Context, kind models.AppliedArtifactKind, name string, content []byte) error
+}
+
+// TenantTierGetter gets the tier of tenants, whose service level bounds the evaluation interval of their rule groups.
+type TenantTierGetter interface {
+	GetTenantTier(ctx context.Context, tenantID string) (string, error)
+}
+
+// Mimir instance is responsible for facilitating communication of alerting monitor with Mimir, or the metric stack whose
+// rule backend is selected by Config. Backend overrides the rule backend selected by Config, if set.
+// Implements the DefinitionConfigUpdater and TenantRulesRemover interfaces. Posted rule groups are recorded by Applied, if set.
+// The evaluation interval of rule groups is raised to the minimum of the service level in TierLevels of the tier of their
+// tenant given by Tiers, if set.
 type Mimir struct {
-	Config *config.MimirConfig
+	Config     *config.MimirConfig
+	Backend    RuleBackend
+	Applied    AppliedRuleGroupRecorder
+	TierLevels config.TenantTiersConfig
+	Tiers      TenantTierGetter
 }
 
 // UpdateDefinitionConfig updates Mimir Ruler rule groups based on the passed alert definition
-// and verifes if changes are indeed present.
+// and verifes if changes are indeed present. The rule group of a disabled alert definition is deleted, so that
+// its rule is not evaluated at all, and it is posted again once the alert definition is enabled.
 func (mu *Mimir) UpdateDefinitionConfig(ctx context.Context, alertDef *models.DBAlertDefinition) error {
+	if alertDef.Values.Enabled != nil && !*alertDef.Values.Enabled {
+		return mu.deleteRuleGroup(ctx, alertDef.ID.String(), alertDef.TenantID)
+	}
+
 	ruleGroup, err := ConvertToRuleGroup(alertDef)
 	if err != nil {
 		return err
 	}
 
+	if err := mu.checkExpressionCost(ruleGroup.Rules[0].Expr, alertDef.TenantID); err != nil {
+		return fmt.Errorf("alert definition %q: %w", alertDef.ID, err)
+	}
+
+	if err := mu.applyTierInterval(ctx, ruleGroup, alertDef.TenantID); err != nil {
+		return err
+	}
+
 	err = mu.postRuleGroup(ctx, *ruleGroup, alertDef.TenantID)
 	if err != nil {
 		return err
 	}
 
 	// verify if post was updated
-	err = mu.compareRuleGroup(ctx, *ruleGroup, alertDef.TenantID)
+	if err := mu.compareRuleGroup(ctx, *ruleGroup, alertDef.TenantID); err != nil {
+		return err
+	}
+	return mu.recordRuleGroup(ctx, *ruleGroup, alertDef.TenantID)
+}
+
+// checkExpressionCost checks the estimated cost of a rendered expression against the limits of the tier of the given tenant.
+// An expression exceeding them is rejected if the limits are enforced, and only logged otherwise.
+func (mu *Mimir) checkExpressionCost(expr string, tenant string) error {
+	limits, ok := mu.Config.ExpressionCost.TenantLimits(tenant)
+	if !ok {
+		return nil
+	}
+
+	cost, err := rules.EstimateCost(expr)
+	if err != nil {
+		return err
+	}
+
+	err = cost.Check(limits)
+	if err != nil && !mu.Config.ExpressionCost.Enforce {
+		slog.Warn(fmt.Sprintf("expression of tenant %q is likely to overload the ruler", tenant), slog.String("expr", expr), slog.Any("error", err))
+		return nil
+	}
 	return err
 }
 
-// POST rule group to Mimir.
-func (mu *Mimir) postRuleGroup(ctx context.Context, rg rules.RuleGroup, tenant string) error {
-	alertYaml, err := yaml.Marshal(rg)
+// applyTierInterval raises the evaluation interval of a rule group to the minimum of the service level of the tier of the given
+// tenant. A rule group without interval is evaluated at the default interval of the ruler, which is assumed to be shorter.
+func (mu *Mimir) applyTierInterval(ctx context.Context, rg *rules.RuleGroup, tenant string) error {
+	if mu.Tiers == nil {
+		return nil
+	}
+
+	tier, err := mu.Tiers.GetTenantTier(ctx, tenant)
 	if err != nil {
 		return err
 	}
 
-	urlRaw := fmt.Sprintf("%v/prometheus/config/v1/rules/%v", mu.Config.RulerURL, mu.Config.Namespace)
+	level, ok := mu.TierLevels.Tier(tier)
+	if !ok || level.MinEvaluationInterval == 0 {
+		return nil
+	}
 
-	_, err = SendRequest(ctx, urlRaw, http.MethodPost, tenant, alertYaml)
-	return err
+	var interval time.Duration
+	if rg.Interval != "" {
+		if interval, err = time.ParseDuration(rg.Interval); err != nil {
+			return fmt.Errorf("invalid interval of rule group %q: %w", rg.Name, err)
+		}
+	}
+	if interval < level.MinEvaluationInterval {
+		rg.Interval = level.MinEvaluationInterval.String()
+	}
+	return nil
 }
 
-// This function compares the rule group found in Mimir to the one passed as an argument.
-func (mu *Mimir) compareRuleGroup(ctx context.Context, rg rules.RuleGroup, tenant string) error {
-	// GET rule group from Mimir
-	urlRaw := fmt.Sprintf("%v/prometheus/config/v1/rules/%v/%v", mu.Config.RulerURL, mu.Config.Namespace, rg.Name)
-	out, err := SendRequest(ctx, urlRaw, http.MethodGet, tenant, nil)
+// recordRuleGroup records the given rule group as applied for the given tenant. Nothing is done if no recorder is set.
+func (mu *Mimir) recordRuleGroup(ctx context.Context, rg rules.RuleGroup, tenant string) error {
+	if mu.Applied == nil {
+		return nil
+	}
+
+	data, err := yaml.Marshal(rg)
 	if err != nil {
+		return err
+	}
+
+	if err := mu.Applied.RecordAppliedArtifact(ctx, models.ArtifactMimirRuleGroup, tenant+"/"+rg.Name, data); err != nil {
+		return fmt.Errorf("failed to record applied rule group %q: %w", rg.Name, err)
+	}
+	return nil
+}
+
+// DeleteTenantRules deletes the rule groups of all alert definitions of the given tenant from the rule backend.
+func (mu *Mimir) DeleteTenantRules(ctx context.Context, tenant string) error {
+	backend, err := mu.backend()
+	if err != nil {
+		return err
+	}
+	return backend.DeleteTenantRules(ctx, tenant)
+}
+
+// backend returns the rule backend of Mimir, the one selected by its configuration if not set.
+func (mu *Mimir) backend() (RuleBackend, error) {
+	if mu.Backend != nil {
+		return mu.Backend, nil
+	}
+	return NewRuleBackend(mu.Config)
+}
+
+// deleteRuleGroup deletes a rule group from the rule backend and verifies that it is not present anymore. A rule group that
+// does not exist is not considered an error.
+func (mu *Mimir) deleteRuleGroup(ctx context.Context, name string, tenant string) error {
+	backend, err := mu.backend()
+	if err != nil {
+		return err
+	}
+
+	if err := backend.DeleteRuleGroup(ctx, name, tenant); err != nil {
+		return err
+	}
+
+	// verify if rule group was deleted
+	_, err = backend.GetRuleGroup(ctx, name, tenant)
+	switch {
+	case errors.Is(err, ErrRuleGroupNotFound):
+		return nil
+	case err != nil:
 		return fmt.Errorf("error while trying to receive rule group from mimir: %w", err)
+	default:
+		return fmt.Errorf("rule group %q is still present in Mimir after being deleted", name)
+	}
+}
+
+// POST rule group to the rule backend.
+func (mu *Mimir) postRuleGroup(ctx context.Context, rg rules.RuleGroup, tenant string) error {
+	backend, err := mu.backend()
+	if err != nil {
+		return err
+	}
+	return backend.PostRuleGroup(ctx, rg, tenant)
+}
+
+// This function compares the rule group found in the rule backend to the one passed as an argument.
+func (mu *Mimir) compareRuleGroup(ctx context.Context, rg rules.RuleGroup, tenant string) error {
+	backend, err := mu.backend()
+	if err != nil {
+		return err
 	}
 
-	var receivedRuleGroup rules.RuleGroup
-	err = yaml.Unmarshal(out, &receivedRuleGroup)
+	receivedRuleGroup, err := backend.GetRuleGroup(ctx, rg.Name, tenant)
 	if err != nil {
-		return fmt.Errorf("failed to unmarshal received data: %w", err)
+		return fmt.Errorf("error while trying to receive rule group from mimir: %w", err)
 	}
 
 	if len(receivedRuleGroup.Rules) != 1 {
@@ -115,6 +257,7 @@ func createHTTPRequest(ctx context.Context, endpoint string, method string, tena
 	}
 
 	req.Header.Add("X-Scope-OrgID", tenant)
+	correlation.SetHeader(req)
 	return req, nil
 }
 
@@ -143,6 +286,22 @@ func SendRequest(ctx context.Context, urlRaw string, method string, tenant strin
 	return body, nil
 }
 
+// sendRequestForStatus sends an http request without body to the specified URL, and returns the status code of the response.
+func sendRequestForStatus(ctx context.Context, urlRaw string, method string, tenant string) (int, error) {
+	req, err := createHTTPRequest(ctx, urlRaw, method, tenant, nil)
+	if err != nil {
+		return 0, fmt.Errorf("error creating http request: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("error doing http request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode, nil
+}
+
 // ParseDurationToSeconds returns the number of seconds from a time formatted string.
 func ParseDurationToSeconds(durationStr string) (int64, error) {
 	duration, err := time.ParseDuration(durationStr)
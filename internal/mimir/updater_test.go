@@ -4,15 +4,23 @@
 package mimir
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
 
 	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/correlation"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
 	"github.com/open-edge-platform/o11y-alerting-monitor/internal/rules"
 )
 
@@ -96,7 +104,7 @@ func TestCompareRuleGroup(t *testing.T) {
 				Namespace: "test",
 				RulerURL:  server.URL,
 			}
-			mimir := Mimir{&mimirConfig}
+			mimir := Mimir{Config: &mimirConfig}
 			tenantID := "test"
 
 			err := mimir.compareRuleGroup(t.Context(), test.input, tenantID)
@@ -151,6 +159,12 @@ func TestCreateHTTPRequest(t *testing.T) {
 			}
 		})
 	}
+
+	t.Run("Request with correlation ID", func(t *testing.T) {
+		req, err := createHTTPRequest(correlation.NewContext(ctx, "request-id"), "http://example.com", "GET", "testTenant", nil)
+		require.NoError(t, err)
+		require.Equal(t, "request-id", req.Header.Get(correlation.Header))
+	})
 }
 
 func TestSendRequest(t *testing.T) {
@@ -205,6 +219,321 @@ func TestSendRequest(t *testing.T) {
 	}
 }
 
+func TestDeleteTenantRules(t *testing.T) {
+	tests := map[string]struct {
+		statusCode    int
+		expectedError error
+	}{
+		"namespace deleted": {
+			statusCode: http.StatusAccepted,
+		},
+		"namespace not found": {
+			statusCode: http.StatusNotFound,
+		},
+		"unexpected status code": {
+			statusCode:    http.StatusInternalServerError,
+			expectedError: errors.New("got unexpected status code: 500"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, http.MethodDelete, r.Method)
+				require.Equal(t, "/prometheus/config/v1/rules/alerting", r.URL.Path)
+				require.Equal(t, "testTenant", r.Header.Get("X-Scope-OrgID"))
+				w.WriteHeader(test.statusCode)
+			}))
+			defer server.Close()
+
+			mu := &Mimir{
+				Config: &config.MimirConfig{
+					Namespace: "alerting",
+					RulerURL:  server.URL,
+				},
+			}
+
+			err := mu.DeleteTenantRules(t.Context(), "testTenant")
+			if test.expectedError != nil {
+				require.ErrorContains(t, err, test.expectedError.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUpdateDefinitionConfigDisabled(t *testing.T) {
+	id := uuid.New()
+	enabled := false
+	alertDef := &models.DBAlertDefinition{
+		ID:       id,
+		TenantID: "testTenant",
+		Values: models.DBAlertDefinitionValues{
+			Enabled: &enabled,
+		},
+	}
+
+	tests := map[string]struct {
+		deleteStatusCode int
+		getStatusCode    int
+		expectedError    error
+	}{
+		"rule group deleted": {
+			deleteStatusCode: http.StatusAccepted,
+			getStatusCode:    http.StatusNotFound,
+		},
+		"rule group not found": {
+			deleteStatusCode: http.StatusNotFound,
+			getStatusCode:    http.StatusNotFound,
+		},
+		"rule group still present": {
+			deleteStatusCode: http.StatusAccepted,
+			getStatusCode:    http.StatusOK,
+			expectedError:    fmt.Errorf("rule group %q is still present in Mimir after being deleted", id),
+		},
+		"unexpected status code": {
+			deleteStatusCode: http.StatusInternalServerError,
+			expectedError:    errors.New("got unexpected status code: 500"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/prometheus/config/v1/rules/alerting/"+id.String(), r.URL.Path)
+				require.Equal(t, "testTenant", r.Header.Get("X-Scope-OrgID"))
+				switch r.Method {
+				case http.MethodDelete:
+					w.WriteHeader(test.deleteStatusCode)
+				case http.MethodGet:
+					w.WriteHeader(test.getStatusCode)
+				default:
+					t.Errorf("unexpected method %v", r.Method)
+				}
+			}))
+			defer server.Close()
+
+			mu := &Mimir{
+				Config: &config.MimirConfig{
+					Namespace: "alerting",
+					RulerURL:  server.URL,
+				},
+			}
+
+			err := mu.UpdateDefinitionConfig(t.Context(), alertDef)
+			if test.expectedError != nil {
+				require.ErrorContains(t, err, test.expectedError.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+// appliedRecorder records the content of the rule groups recorded as applied by their name.
+type appliedRecorder map[string][]byte
+
+func (r appliedRecorder) RecordAppliedArtifact(_ context.Context, kind models.AppliedArtifactKind, name string, content []byte) error {
+	if kind != models.ArtifactMimirRuleGroup {
+		return fmt.Errorf("unexpected artifact kind %q", kind)
+	}
+	r[name] = content
+	return nil
+}
+
+func TestUpdateDefinitionConfigRecordsRuleGroup(t *testing.T) {
+	id := uuid.New()
+	duration, threshold, enabled := int64(60), int64(80), true
+	alertDef := &models.DBAlertDefinition{
+		ID:       id,
+		Name:     "HighCPUUsage",
+		Interval: 15,
+		Template: validAlertDefTemplate,
+		TenantID: "testTenant",
+		Values: models.DBAlertDefinitionValues{
+			Duration:  &duration,
+			Threshold: &threshold,
+			Enabled:   &enabled,
+		},
+	}
+
+	// The mocked ruler returns the rule group last posted to it.
+	var posted []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			posted = body
+			w.WriteHeader(http.StatusAccepted)
+		case http.MethodGet:
+			_, err := w.Write(posted)
+			require.NoError(t, err)
+		default:
+			t.Errorf("unexpected method %v", r.Method)
+		}
+	}))
+	defer server.Close()
+
+	applied := make(appliedRecorder)
+	mu := &Mimir{
+		Config: &config.MimirConfig{
+			Namespace: "alerting",
+			RulerURL:  server.URL,
+		},
+		Applied: applied,
+	}
+
+	require.NoError(t, mu.UpdateDefinitionConfig(t.Context(), alertDef))
+	require.Contains(t, applied, "testTenant/"+id.String())
+
+	var recorded rules.RuleGroup
+	require.NoError(t, yaml.Unmarshal(applied["testTenant/"+id.String()], &recorded))
+	require.Equal(t, id.String(), recorded.Name)
+	require.Len(t, recorded.Rules, 1)
+	require.Equal(t, "cpu_usage > 80", recorded.Rules[0].Expr)
+}
+
+func TestUpdateDefinitionConfigExpressionCost(t *testing.T) {
+	duration, threshold, enabled := int64(60), int64(80), true
+	alertDef := &models.DBAlertDefinition{
+		ID:       uuid.New(),
+		Name:     "HighCPUUsage",
+		Interval: 15,
+		Template: validAlertDefTemplate,
+		TenantID: "testTenant",
+		Values: models.DBAlertDefinitionValues{
+			Duration:  &duration,
+			Threshold: &threshold,
+			Enabled:   &enabled,
+		},
+	}
+
+	var posted []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			posted = body
+			w.WriteHeader(http.StatusAccepted)
+		case http.MethodGet:
+			_, err := w.Write(posted)
+			require.NoError(t, err)
+		}
+	}))
+	defer server.Close()
+
+	// The expression "cpu_usage > 80" has a single series selector.
+	expressionCost := config.ExpressionCostConfig{
+		DefaultTier: "free",
+		TenantTiers: map[string]string{"testTenant": "restricted"},
+		Tiers: map[string]config.ExpressionCostLimits{
+			"restricted": {MaxSelectors: 1},
+		},
+	}
+
+	t.Run("Expression within limits", func(t *testing.T) {
+		posted = nil
+		mu := &Mimir{Config: &config.MimirConfig{Namespace: "alerting", RulerURL: server.URL, ExpressionCost: expressionCost}}
+		require.NoError(t, mu.UpdateDefinitionConfig(t.Context(), alertDef))
+		require.NotNil(t, posted)
+	})
+
+	expressionCost.Tiers = map[string]config.ExpressionCostLimits{"restricted": {MaxSelectors: 1, MaxRange: time.Minute}}
+	template := strings.Replace(validAlertDefTemplate, "cpu_usage", "sum(rate(cpu_usage[5m])) + sum(rate(cpu_usage_other[5m]))", 1)
+	expensive := *alertDef
+	expensive.Template = template
+
+	t.Run("Expression exceeding limits is only warned about", func(t *testing.T) {
+		posted = nil
+		mu := &Mimir{Config: &config.MimirConfig{Namespace: "alerting", RulerURL: server.URL, ExpressionCost: expressionCost}}
+		require.NoError(t, mu.UpdateDefinitionConfig(t.Context(), &expensive))
+		require.NotNil(t, posted)
+	})
+
+	t.Run("Expression exceeding enforced limits is rejected", func(t *testing.T) {
+		posted = nil
+		enforced := expressionCost
+		enforced.Enforce = true
+		mu := &Mimir{Config: &config.MimirConfig{Namespace: "alerting", RulerURL: server.URL, ExpressionCost: enforced}}
+		err := mu.UpdateDefinitionConfig(t.Context(), &expensive)
+		require.ErrorIs(t, err, rules.ErrExpressionTooExpensive)
+		require.Nil(t, posted)
+	})
+}
+
+// tenantTiers maps tenants to their tier.
+type tenantTiers map[string]string
+
+func (t tenantTiers) GetTenantTier(_ context.Context, tenantID string) (string, error) {
+	return t[tenantID], nil
+}
+
+func TestUpdateDefinitionConfigTierInterval(t *testing.T) {
+	duration, threshold, enabled := int64(60), int64(80), true
+	alertDef := &models.DBAlertDefinition{
+		ID:       uuid.New(),
+		Name:     "HighCPUUsage",
+		Interval: 15,
+		Template: validAlertDefTemplate,
+		TenantID: "testTenant",
+		Values: models.DBAlertDefinitionValues{
+			Duration:  &duration,
+			Threshold: &threshold,
+			Enabled:   &enabled,
+		},
+	}
+
+	var posted []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			posted = body
+			w.WriteHeader(http.StatusAccepted)
+		case http.MethodGet:
+			_, err := w.Write(posted)
+			require.NoError(t, err)
+		}
+	}))
+	defer server.Close()
+
+	mu := &Mimir{
+		Config: &config.MimirConfig{Namespace: "alerting", RulerURL: server.URL},
+		TierLevels: config.TenantTiersConfig{
+			DefaultTier: "basic",
+			Tiers: map[string]config.TierConfig{
+				"basic":   {MinEvaluationInterval: time.Minute},
+				"premium": {MinEvaluationInterval: 10 * time.Second},
+			},
+		},
+		Tiers: tenantTiers{"premiumTenant": "premium"},
+	}
+
+	tests := map[string]struct {
+		tenant   string
+		interval string
+	}{
+		"Interval is raised to the minimum of the tier":  {tenant: "testTenant", interval: "1m0s"},
+		"Interval above the minimum of the tier is kept": {tenant: "premiumTenant", interval: "15s"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			def := *alertDef
+			def.TenantID = test.tenant
+			require.NoError(t, mu.UpdateDefinitionConfig(t.Context(), &def))
+
+			var rg rules.RuleGroup
+			require.NoError(t, yaml.Unmarshal(posted, &rg))
+			require.Equal(t, test.interval, rg.Interval)
+		})
+	}
+}
+
 func TestParseDuration(t *testing.T) {
 	tests := []struct {
 		input          string
@@ -0,0 +1,269 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Package network configures the connections of alerting monitor to the downstream services, alertmanager, Mimir and the mail
+// server, over IPv4, IPv6 or both and through HTTP proxies, and validates their addresses.
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+)
+
+const (
+	// IP families of the downstream connections.
+	FamilyDual = "dual"
+	FamilyIPv4 = "ipv4"
+	FamilyIPv6 = "ipv6"
+
+	// Timeouts of the dialer, as for the default transport of net/http.
+	dialTimeout   = 30 * time.Second
+	dialKeepAlive = 30 * time.Second
+)
+
+var (
+	defaultDialerMu sync.RWMutex
+	defaultDialer   = &Dialer{dialer: net.Dialer{Timeout: dialTimeout, KeepAlive: dialKeepAlive}}
+)
+
+// Dialer dials the downstream services over the configured IP family. Over both families, the addresses of both are raced as
+// described by RFC 6555 (Happy Eyeballs), the secondary family being tried after the fallback delay.
+type Dialer struct {
+	dialer net.Dialer
+	family string
+}
+
+// NewDialer returns the dialer of the given network configuration.
+func NewDialer(conf config.NetworkConfig) (*Dialer, error) {
+	family := conf.IPFamily
+	switch family {
+	case "":
+		family = FamilyDual
+	case FamilyDual, FamilyIPv4, FamilyIPv6:
+	default:
+		return nil, fmt.Errorf("invalid IP family %q, expected %s, %s or %s", family, FamilyDual, FamilyIPv4, FamilyIPv6)
+	}
+
+	return &Dialer{
+		dialer: net.Dialer{
+			Timeout:   dialTimeout,
+			KeepAlive: dialKeepAlive,
+			// A negative delay disables the fallback to the secondary family, zero being the default delay of 300ms.
+			FallbackDelay: conf.FallbackDelay,
+		},
+		family: family,
+	}, nil
+}
+
+// DialContext connects to the given address, restricting TCP and UDP connections to the IP family of the dialer.
+func (d *Dialer) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	switch network {
+	case "tcp", "udp":
+		switch d.family {
+		case FamilyIPv4:
+			network += "4"
+		case FamilyIPv6:
+			network += "6"
+		}
+	}
+	return d.dialer.DialContext(ctx, network, addr)
+}
+
+// Configure makes the connections to the downstream services use the dialer of the network configuration: the ones of the
+// default transport of net/http, which the clients of alertmanager, Mimir, Keycloak and the other services over HTTP use, and
+// the ones of DialContext, which the clients of the mail server use. Requests of the default transport also go through the
+// proxies of the proxy configuration.
+func Configure(conf config.Config) error {
+	d, err := NewDialer(conf.Network)
+	if err != nil {
+		return err
+	}
+	proxy, err := NewProxy(conf)
+	if err != nil {
+		return err
+	}
+
+	defaultDialerMu.Lock()
+	defaultDialer = d
+	defaultDialerMu.Unlock()
+
+	if transport, ok := http.DefaultTransport.(*http.Transport); ok {
+		transport.DialContext = d.DialContext
+		transport.Proxy = proxy
+	}
+	return nil
+}
+
+// DialContext connects to the given address with the dialer of the network configuration.
+func DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	defaultDialerMu.RLock()
+	d := defaultDialer
+	defaultDialerMu.RUnlock()
+	return d.DialContext(ctx, network, addr)
+}
+
+// ValidateDownstreams validates the network configuration, including its proxies, and the addresses of the downstream services
+// of the given configuration. Empty optional addresses are not validated.
+func ValidateDownstreams(conf config.Config) error {
+	if _, err := NewDialer(conf.Network); err != nil {
+		return err
+	}
+
+	type downstreamURL struct {
+		name     string
+		url      string
+		optional bool
+	}
+	urls := []downstreamURL{
+		{name: "alertmanager.url", url: conf.AlertManager.URL},
+		{name: "alertmanager.staging.url", url: conf.AlertManager.Staging.URL, optional: true},
+		{name: "alertmanager.onCallRelayURL", url: conf.AlertManager.OnCallRelayURL, optional: true},
+		{name: "alertmanager.emailRelayURL", url: conf.AlertManager.EmailRelayURL, optional: true},
+		{name: "alertmanager.grafana.url", url: conf.AlertManager.Grafana.URL, optional: true},
+		{name: "mimir.rulerURL", url: conf.Mimir.RulerURL},
+		{name: "mimir.queryURL", url: conf.Mimir.QueryURL, optional: true},
+	}
+	for i, shard := range conf.AlertManager.Shards {
+		urls = append(urls, downstreamURL{name: fmt.Sprintf("alertmanager.shards[%d].url", i), url: shard.URL})
+	}
+	for _, u := range urls {
+		if u.optional && u.url == "" {
+			continue
+		}
+		if err := ValidateURL(u.url); err != nil {
+			return fmt.Errorf("invalid %s %q: %w", u.name, u.url, err)
+		}
+	}
+
+	if host := conf.AlertManager.SigningRelayHost; host != "" {
+		if err := ValidateHostPort(host); err != nil {
+			return fmt.Errorf("invalid alertmanager.signingRelayHost %q: %w", host, err)
+		}
+	}
+
+	for i, server := range conf.EmailOverride.MailServers {
+		if err := ValidateHostPort(server); err != nil {
+			return fmt.Errorf("invalid emailOverride.mailServers[%d] %q: %w", i, server, err)
+		}
+	}
+
+	for i, server := range conf.MailFailover.Servers {
+		if err := ValidateMailServer(server.Host, strconv.Itoa(server.Port)); err != nil {
+			return fmt.Errorf("invalid mailFailover.servers[%d]: %w", i, err)
+		}
+	}
+
+	_, err := NewProxy(conf)
+	return err
+}
+
+// ValidateMailServer validates the host and port of a mail server, such as the ones given by the SMART_HOST and SMART_PORT
+// environment variables.
+func ValidateMailServer(host, port string) error {
+	if err := ValidateHost(host); err != nil {
+		return fmt.Errorf("invalid mail server host: %w", err)
+	}
+	if err := ValidatePort(port); err != nil {
+		return fmt.Errorf("invalid mail server port: %w", err)
+	}
+	return nil
+}
+
+// ValidateURL validates the URL of a downstream service, which must be absolute with the http or https scheme. IPv6 literals
+// must be enclosed in brackets, as in http://[fd00::1]:9093, since they are otherwise mistaken for a host and port.
+func ValidateURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid scheme %q, expected http or https", u.Scheme)
+	}
+	if u.Host == "" {
+		return errors.New("missing host")
+	}
+	return validateAuthority(u.Host, false)
+}
+
+// ValidateHostPort validates the address of a downstream service given as host:port, such as a mail server. IPv6 literals
+// must be enclosed in brackets, as in [fd00::1]:25.
+func ValidateHostPort(addr string) error {
+	return validateAuthority(addr, true)
+}
+
+// ValidateHost validates a host given apart from its port, such as the host of the mail server, which is either a name or
+// an IP literal. IPv6 literals must not be enclosed in brackets, since they are added when joined with the port.
+func ValidateHost(host string) error {
+	switch {
+	case host == "":
+		return errors.New("missing host")
+	case strings.HasPrefix(host, "["):
+		return fmt.Errorf("host %q must not be enclosed in brackets", host)
+	case strings.Contains(host, ":"):
+		if ip := net.ParseIP(stripZone(host)); ip == nil {
+			return fmt.Errorf("invalid IPv6 literal %q", host)
+		}
+	}
+	return nil
+}
+
+// ValidatePort validates a port number.
+func ValidatePort(port string) error {
+	n, err := strconv.Atoi(port)
+	if err != nil || n < 1 || n > 65535 {
+		return fmt.Errorf("invalid port %q", port)
+	}
+	return nil
+}
+
+// validateAuthority validates the host and port of an address, whose IPv6 literal must be enclosed in brackets. The port is
+// optional unless required.
+func validateAuthority(authority string, requirePort bool) error {
+	if strings.HasPrefix(authority, "[") {
+		end := strings.Index(authority, "]")
+		if end < 0 {
+			return fmt.Errorf("missing closing bracket of IPv6 literal in %q", authority)
+		}
+		if literal := stripZone(authority[1:end]); !strings.Contains(literal, ":") || net.ParseIP(literal) == nil {
+			return fmt.Errorf("invalid IPv6 literal in %q", authority)
+		}
+		rest := authority[end+1:]
+		if rest == "" && !requirePort {
+			return nil
+		}
+		if !strings.HasPrefix(rest, ":") {
+			return fmt.Errorf("invalid address %q", authority)
+		}
+		return ValidatePort(rest[1:])
+	}
+
+	if strings.Count(authority, ":") > 1 {
+		return fmt.Errorf("IPv6 literal of %q must be enclosed in brackets", authority)
+	}
+	host, port, found := strings.Cut(authority, ":")
+	if host == "" {
+		return fmt.Errorf("missing host in %q", authority)
+	}
+	if found || requirePort {
+		return ValidatePort(port)
+	}
+	return nil
+}
+
+// stripZone strips the zone of a scoped IPv6 literal, as in fe80::1%eth0.
+func stripZone(host string) string {
+	if i := strings.LastIndex(host, "%"); i >= 0 {
+		return host[:i]
+	}
+	return host
+}
@@ -0,0 +1,170 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package network
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+)
+
+func TestValidateURL(t *testing.T) {
+	for name, tc := range map[string]struct {
+		url   string
+		valid bool
+	}{
+		"Host name":                  {url: "http://alertmanager:9093", valid: true},
+		"IPv4 literal":               {url: "http://10.0.0.1:9093/api", valid: true},
+		"IPv6 literal":               {url: "http://[fd00::1]:9093", valid: true},
+		"IPv6 literal without port":  {url: "https://[fd00::1]/prometheus", valid: true},
+		"Scoped IPv6 literal":        {url: "https://[fe80::1%25eth0]:8443", valid: true},
+		"IPv4-mapped IPv6 literal":   {url: "http://[::ffff:10.0.0.1]:9093", valid: true},
+		"Bracketed IPv4 literal":     {url: "http://[10.0.0.1]:9093"},
+		"IPv6 literal not bracketed": {url: "http://fd00::1:9093"},
+		"Invalid IPv6 literal":       {url: "http://[fd00::g]:9093"},
+		"Invalid port":               {url: "http://[fd00::1]:port"},
+		"Invalid scheme":             {url: "ftp://[fd00::1]:21"},
+		"Missing host":               {url: "http:///api"},
+		"Relative URL":               {url: "alertmanager:9093"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateURL(tc.url)
+			if tc.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateHostPort(t *testing.T) {
+	require.NoError(t, ValidateHostPort("smtp.example.com:25"))
+	require.NoError(t, ValidateHostPort("[fd00::25]:25"))
+	require.NoError(t, ValidateHostPort("[fe80::25%eth0]:25"))
+
+	require.ErrorContains(t, ValidateHostPort("fd00::25"), "must be enclosed in brackets")
+	require.Error(t, ValidateHostPort("[fd00::25]"))
+	require.Error(t, ValidateHostPort("[fd00::25:25"))
+	require.Error(t, ValidateHostPort("smtp.example.com"))
+	require.Error(t, ValidateHostPort(":25"))
+}
+
+func TestValidateMailServer(t *testing.T) {
+	require.NoError(t, ValidateMailServer("smtp.example.com", "587"))
+	require.NoError(t, ValidateMailServer("10.0.0.25", "25"))
+	require.NoError(t, ValidateMailServer("fd00::25", "25"))
+
+	require.ErrorContains(t, ValidateMailServer("[fd00::25]", "25"), "invalid mail server host")
+	require.ErrorContains(t, ValidateMailServer("fd00::g", "25"), "invalid mail server host")
+	require.ErrorContains(t, ValidateMailServer("", "25"), "invalid mail server host")
+	require.ErrorContains(t, ValidateMailServer("fd00::25", "smtp"), "invalid mail server port")
+	require.ErrorContains(t, ValidateMailServer("fd00::25", "65536"), "invalid mail server port")
+}
+
+func TestValidateDownstreams(t *testing.T) {
+	valid := func() config.Config {
+		var conf config.Config
+		conf.Network.IPFamily = FamilyDual
+		conf.AlertManager.URL = "http://[fd00::1]:9093"
+		conf.AlertManager.Shards = []config.AlertManagerShardConfig{{URL: "http://[fd00::2]:9093"}}
+		conf.AlertManager.SigningRelayHost = "[fd00::3]:8443"
+		conf.Mimir.RulerURL = "http://10.0.0.1:8080"
+		conf.Mimir.QueryURL = "https://[fd00::4]/prometheus"
+		conf.MailFailover.Servers = []config.MailServerConfig{{Host: "fd00::25", Port: 25}}
+		return conf
+	}
+
+	t.Run("Valid downstreams", func(t *testing.T) {
+		require.NoError(t, ValidateDownstreams(valid()))
+	})
+
+	for name, tc := range map[string]struct {
+		modify func(conf *config.Config)
+		err    string
+	}{
+		"Invalid IP family": {
+			modify: func(conf *config.Config) { conf.Network.IPFamily = "ipv5" },
+			err:    "invalid IP family",
+		},
+		"Missing alertmanager URL": {
+			modify: func(conf *config.Config) { conf.AlertManager.URL = "" },
+			err:    "invalid alertmanager.url",
+		},
+		"IPv6 literal of shard not bracketed": {
+			modify: func(conf *config.Config) { conf.AlertManager.Shards[0].URL = "http://fd00::2:9093" },
+			err:    "invalid alertmanager.shards[0].url",
+		},
+		"IPv6 literal of signing relay not bracketed": {
+			modify: func(conf *config.Config) { conf.AlertManager.SigningRelayHost = "fd00::3" },
+			err:    "invalid alertmanager.signingRelayHost",
+		},
+		"IPv6 literal of failover mail server bracketed": {
+			modify: func(conf *config.Config) { conf.MailFailover.Servers[0].Host = "[fd00::25]" },
+			err:    "invalid mailFailover.servers[0]",
+		},
+		"Invalid Mimir query URL": {
+			modify: func(conf *config.Config) { conf.Mimir.QueryURL = "[fd00::4]/prometheus" },
+			err:    "invalid mimir.queryURL",
+		},
+		"Invalid proxy": {
+			modify: func(conf *config.Config) { conf.Network.Proxy.HTTPProxy = "http://" },
+			err:    "invalid network.proxy.httpProxy",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			conf := valid()
+			tc.modify(&conf)
+			require.ErrorContains(t, ValidateDownstreams(conf), tc.err)
+		})
+	}
+}
+
+func TestDialer_DialContext(t *testing.T) {
+	listener, err := net.Listen("tcp", "[::1]:0")
+	if err != nil {
+		t.Skipf("IPv6 loopback is not available: %v", err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	for _, tc := range []struct {
+		family string
+		dials  bool
+	}{
+		{family: "", dials: true},
+		{family: FamilyDual, dials: true},
+		{family: FamilyIPv6, dials: true},
+		{family: FamilyIPv4},
+	} {
+		t.Run("Family "+tc.family, func(t *testing.T) {
+			d, err := NewDialer(config.NetworkConfig{IPFamily: tc.family})
+			require.NoError(t, err)
+
+			conn, err := d.DialContext(t.Context(), "tcp", listener.Addr().String())
+			if !tc.dials {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			conn.Close()
+		})
+	}
+
+	t.Run("Invalid family", func(t *testing.T) {
+		_, err := NewDialer(config.NetworkConfig{IPFamily: "ipv5"})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package network
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/http/httpproxy"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+)
+
+// ProxyDirect is the proxy of the downstream services called without proxy.
+const ProxyDirect = "direct"
+
+// ProxyFunc returns the URL of the proxy of a request, or nil if it is not proxied, as the Proxy of http.Transport.
+type ProxyFunc func(req *http.Request) (*url.URL, error)
+
+// NewProxy returns the proxy function of the given configuration. Requests to the hosts of a downstream service with its own
+// proxy go through it, the others through the proxies of the proxy configuration, or of the environment if none is set.
+func NewProxy(conf config.Config) (ProxyFunc, error) {
+	p := conf.Network.Proxy
+
+	proxyConf := httpproxy.FromEnvironment()
+	if p.HTTPProxy != "" || p.HTTPSProxy != "" || p.NoProxy != "" {
+		for name, proxy := range map[string]string{"httpProxy": p.HTTPProxy, "httpsProxy": p.HTTPSProxy} {
+			if proxy == "" {
+				continue
+			}
+			if _, err := parseProxyURL(proxy); err != nil {
+				return nil, fmt.Errorf("invalid network.proxy.%s %q: %w", name, proxy, err)
+			}
+		}
+		proxyConf = &httpproxy.Config{HTTPProxy: p.HTTPProxy, HTTPSProxy: p.HTTPSProxy, NoProxy: p.NoProxy}
+	}
+	defaultProxy := proxyConf.ProxyFunc()
+
+	hosts := downstreamURLs(conf)
+	// The proxies of the hosts of the downstream services, nil for the ones called directly.
+	overrides := make(map[string]*url.URL)
+	owners := make(map[string]string)
+	for _, name := range slices.Sorted(maps.Keys(p.Downstreams)) {
+		urls, ok := hosts[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown downstream %q of network.proxy.downstreams, expected one of %s", name,
+				strings.Join(slices.Sorted(maps.Keys(hosts)), ", "))
+		}
+
+		var proxyURL *url.URL
+		if proxy := p.Downstreams[name]; proxy != ProxyDirect {
+			var err error
+			if proxyURL, err = parseProxyURL(proxy); err != nil {
+				return nil, fmt.Errorf("invalid proxy of downstream %q %q: %w", name, proxy, err)
+			}
+		}
+
+		for _, rawURL := range urls {
+			if rawURL == "" {
+				continue
+			}
+			u, err := url.Parse(rawURL)
+			if err != nil {
+				return nil, fmt.Errorf("invalid URL of downstream %q %q: %w", name, rawURL, err)
+			}
+			addr := canonicalAddr(u)
+			if owner, ok := owners[addr]; ok && owner != name && !sameProxy(overrides[addr], proxyURL) {
+				return nil, fmt.Errorf("downstreams %q and %q share host %s but have different proxies", owner, name, addr)
+			}
+			overrides[addr] = proxyURL
+			owners[addr] = name
+		}
+	}
+
+	return func(req *http.Request) (*url.URL, error) {
+		if proxyURL, ok := overrides[canonicalAddr(req.URL)]; ok {
+			return proxyURL, nil
+		}
+		return defaultProxy(req.URL)
+	}, nil
+}
+
+// downstreamURLs returns the URLs of the downstream services called over HTTP, by the name their proxy is configured with.
+func downstreamURLs(conf config.Config) map[string][]string {
+	alertmanager := []string{conf.AlertManager.URL, conf.AlertManager.Staging.URL}
+	for _, shard := range conf.AlertManager.Shards {
+		alertmanager = append(alertmanager, shard.URL)
+	}
+	return map[string][]string{
+		"alertmanager":   alertmanager,
+		"mimir":          {conf.Mimir.RulerURL, conf.Mimir.QueryURL},
+		"grafana":        {conf.AlertManager.Grafana.URL},
+		"keycloak":       {conf.Authentication.OidcServer},
+		"oncall":         {conf.OnCall.URL},
+		"tenantMetadata": {conf.TenantMetadata.URL},
+		"snapshot":       {conf.Snapshot.Endpoint},
+	}
+}
+
+// parseProxyURL parses the URL of a proxy, which must be absolute with the http, https or socks5 scheme.
+func parseProxyURL(rawURL string) (*url.URL, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("invalid scheme %q, expected http, https or socks5", u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, errors.New("missing host")
+	}
+	return u, nil
+}
+
+// canonicalAddr returns the lowercase host and port of a URL, the port defaulting to the one of its scheme.
+func canonicalAddr(u *url.URL) string {
+	port := u.Port()
+	if port == "" {
+		switch u.Scheme {
+		case "https":
+			port = "443"
+		default:
+			port = "80"
+		}
+	}
+	return net.JoinHostPort(strings.ToLower(u.Hostname()), port)
+}
+
+// sameProxy reports whether two proxies, nil for none, are the same.
+func sameProxy(a, b *url.URL) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.String() == b.String()
+}
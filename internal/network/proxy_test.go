@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package network
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+)
+
+func TestNewProxy(t *testing.T) {
+	var conf config.Config
+	conf.AlertManager.URL = "http://alertmanager:9093"
+	conf.AlertManager.Shards = []config.AlertManagerShardConfig{{URL: "http://[fd00::2]:9093"}}
+	conf.Mimir.RulerURL = "http://mimir-ruler:8080"
+	conf.Authentication.OidcServer = "https://Keycloak.example.com"
+	conf.Network.Proxy = config.ProxyConfig{
+		HTTPProxy:  "http://proxy.example.com:3128",
+		HTTPSProxy: "http://proxy.example.com:3129",
+		NoProxy:    "mimir-ruler,.svc.cluster.local",
+		Downstreams: map[string]string{
+			"alertmanager": ProxyDirect,
+			"keycloak":     "http://keycloak-proxy.example.com:3128",
+		},
+	}
+
+	proxy, err := NewProxy(conf)
+	require.NoError(t, err)
+
+	for url, expected := range map[string]string{
+		"http://alertmanager:9093/api/v2/alerts":               "",
+		"http://[fd00::2]:9093/api/v2/alerts":                  "",
+		"https://keycloak.example.com/realms/master":           "http://keycloak-proxy.example.com:3128",
+		"https://keycloak.example.com:443/realms/master":       "http://keycloak-proxy.example.com:3128",
+		"http://mimir-ruler:8080/prometheus/config/v1/rules":   "",
+		"http://tenants.svc.cluster.local/api/v1/tenants":      "",
+		"http://metadata.example.com/api/v1/tenants":           "http://proxy.example.com:3128",
+		"https://metadata.example.com/api/v1/tenants":          "http://proxy.example.com:3129",
+		"http://alertmanager:9094/api/v2/alerts":               "http://proxy.example.com:3128",
+		"https://keycloak.example.com:8443/realms/master/test": "http://proxy.example.com:3129",
+	} {
+		t.Run(url, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			proxyURL, err := proxy(req)
+			require.NoError(t, err)
+			if expected == "" {
+				require.Nil(t, proxyURL)
+				return
+			}
+			require.NotNil(t, proxyURL)
+			require.Equal(t, expected, proxyURL.String())
+		})
+	}
+
+	for name, tc := range map[string]struct {
+		proxy config.ProxyConfig
+		err   string
+	}{
+		"Invalid proxy": {
+			proxy: config.ProxyConfig{HTTPSProxy: "proxy.example.com:3128"},
+			err:   "invalid network.proxy.httpsProxy",
+		},
+		"Unknown downstream": {
+			proxy: config.ProxyConfig{Downstreams: map[string]string{"vault": ProxyDirect}},
+			err:   `unknown downstream "vault"`,
+		},
+		"Invalid proxy of downstream": {
+			proxy: config.ProxyConfig{Downstreams: map[string]string{"mimir": "ftp://proxy.example.com"}},
+			err:   `invalid proxy of downstream "mimir"`,
+		},
+		"Downstreams sharing host with different proxies": {
+			proxy: config.ProxyConfig{Downstreams: map[string]string{
+				"alertmanager": ProxyDirect,
+				"grafana":      "http://proxy.example.com:3128",
+			}},
+			err: `downstreams "alertmanager" and "grafana" share host alertmanager:9093`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			invalid := conf
+			invalid.AlertManager.Grafana.URL = "http://alertmanager:9093"
+			invalid.Network.Proxy = tc.proxy
+			_, err := NewProxy(invalid)
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
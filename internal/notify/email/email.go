@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Package email sends the emails of alertmanager notifications on behalf of alertmanager. Alertmanager sends notifications
+// of receivers to a webhook of alerting monitor, whose payload is rendered with the same email template as alertmanager
+// and sent to each recipient of the receiver separately, so that the delivery to each recipient is retried and recorded.
+package email
+
+import (
+	"slices"
+	"time"
+)
+
+const (
+	alertStatusFiring   = "firing"
+	alertStatusResolved = "resolved"
+)
+
+// Data is the payload alertmanager sends to webhook receivers. It also is the data emails are rendered with, which provides
+// the same fields and methods as the data alertmanager renders its email templates with.
+type Data struct {
+	Receiver          string `json:"receiver"`
+	Status            string `json:"status"`
+	Alerts            Alerts `json:"alerts"`
+	GroupLabels       KV     `json:"groupLabels"`
+	CommonLabels      KV     `json:"commonLabels"`
+	CommonAnnotations KV     `json:"commonAnnotations"`
+	ExternalURL       string `json:"externalURL"`
+	// GroupKey identifies the alert group of the notification.
+	GroupKey string `json:"groupKey"`
+}
+
+// Alert is a single alert of a notification.
+type Alert struct {
+	Status       string    `json:"status"`
+	Labels       KV        `json:"labels"`
+	Annotations  KV        `json:"annotations"`
+	StartsAt     time.Time `json:"startsAt"`
+	EndsAt       time.Time `json:"endsAt"`
+	GeneratorURL string    `json:"generatorURL"`
+	Fingerprint  string    `json:"fingerprint"`
+}
+
+// Alerts is a list of alerts.
+type Alerts []Alert
+
+// Firing returns the firing alerts of the list.
+func (as Alerts) Firing() []Alert {
+	return as.withStatus(alertStatusFiring)
+}
+
+// Resolved returns the resolved alerts of the list.
+func (as Alerts) Resolved() []Alert {
+	return as.withStatus(alertStatusResolved)
+}
+
+func (as Alerts) withStatus(status string) []Alert {
+	var alerts []Alert
+	for _, a := range as {
+		if a.Status == status {
+			alerts = append(alerts, a)
+		}
+	}
+	return alerts
+}
+
+// Pair is a label or annotation name and value.
+type Pair struct {
+	Name  string
+	Value string
+}
+
+// Pairs is a list of label or annotation names and values.
+type Pairs []Pair
+
+// Names returns the names of the pairs.
+func (ps Pairs) Names() []string {
+	names := make([]string, len(ps))
+	for i, p := range ps {
+		names[i] = p.Name
+	}
+	return names
+}
+
+// Values returns the values of the pairs.
+func (ps Pairs) Values() []string {
+	values := make([]string, len(ps))
+	for i, p := range ps {
+		values[i] = p.Value
+	}
+	return values
+}
+
+// KV is a set of labels or annotations.
+type KV map[string]string
+
+// SortedPairs returns the pairs of the set sorted by name, the alert name first.
+func (kv KV) SortedPairs() Pairs {
+	pairs := make(Pairs, 0, len(kv))
+	for name, value := range kv {
+		pairs = append(pairs, Pair{Name: name, Value: value})
+	}
+	slices.SortFunc(pairs, func(a, b Pair) int {
+		switch {
+		case a.Name == b.Name:
+			return 0
+		case a.Name == "alertname":
+			return -1
+		case b.Name == "alertname":
+			return 1
+		case a.Name < b.Name:
+			return -1
+		default:
+			return 1
+		}
+	})
+	return pairs
+}
+
+// Remove returns a copy of the set without the given names.
+func (kv KV) Remove(names []string) KV {
+	res := make(KV, len(kv))
+	for name, value := range kv {
+		if !slices.Contains(names, name) {
+			res[name] = value
+		}
+	}
+	return res
+}
+
+// Names returns the sorted names of the set.
+func (kv KV) Names() []string {
+	return kv.SortedPairs().Names()
+}
+
+// Values returns the values of the set, sorted by name.
+func (kv KV) Values() []string {
+	return kv.SortedPairs().Values()
+}
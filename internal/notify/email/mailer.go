@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package email
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"os"
+	"time"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+)
+
+// Mailer sends the emails written by alerting monitor itself, such as verification emails and reports, from the sender given
+// by the FROM_MAIL environment variable through the mail server of the environment.
+type Mailer struct {
+	server mailSender
+	from   *mail.Address
+}
+
+// NewMailer creates a new Mailer, loading the sender and the mail server from the environment. The given timeout applies to
+// sending an email to a single recipient.
+func NewMailer(cfg config.Config, timeout time.Duration) (*Mailer, error) {
+	from, err := mail.ParseAddress(os.Getenv("FROM_MAIL"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender of emails: %w", err)
+	}
+
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	server, err := NewMailServer(cfg.AlertManager.RequireTLS, cfg.AlertManager.InsecureSkipVerify, timeout, cfg.MailFailover)
+	if err != nil {
+		return nil, err
+	}
+	return &Mailer{server: server, from: from}, nil
+}
+
+// Send sends an email with the given subject and HTML body to the given recipient.
+func (m *Mailer) Send(ctx context.Context, to *mail.Address, subject, body string) error {
+	msg, err := newMessage(m.from, to, subject, body)
+	if err != nil {
+		return err
+	}
+	if err := m.server.Send(ctx, m.from.Address, []string{to.Address}, msg); err != nil {
+		return fmt.Errorf("failed to send email to %q: %w", to.Address, err)
+	}
+	return nil
+}
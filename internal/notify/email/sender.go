@@ -0,0 +1,274 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package email
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	mathrand "math/rand/v2"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+// defaultTimeout is the timeout of sending an email to a single recipient if not configured.
+const defaultTimeout = time.Minute
+
+// ErrNotDelivered is returned when the email of a notification could not be sent to any recipient of a receiver.
+var ErrNotDelivered = errors.New("email was not delivered to any recipient")
+
+// mailSender sends an email to the given recipients.
+type mailSender interface {
+	Send(ctx context.Context, from string, to []string, msg []byte) error
+}
+
+// queuedEmail is the payload of an email queued for retry, rendered for its recipient.
+type queuedEmail struct {
+	From    string `json:"from"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// Sender sends the emails of the notifications of receivers to each of their recipients separately, retrying transient
+// errors and recording the outcome for each recipient.
+type Sender struct {
+	server     mailSender
+	template   *Template
+	conf       config.EmailRelayConfig
+	deliveries database.EmailDeliveryRecorder
+	// queue queues the emails which could not be sent because of transient errors for retry. They are not queued if nil.
+	queue  database.NotificationEnqueuer
+	logger *slog.Logger
+}
+
+// NewSender creates a new Sender, loading the email templates and the mail server from the environment. The emails which
+// cannot be sent because of transient errors are queued for retry in the given queue, unless it is nil.
+func NewSender(cfg config.Config, deliveries database.EmailDeliveryRecorder, queue database.NotificationEnqueuer,
+	logger *slog.Logger) (*Sender, error) {
+	tmpl, err := NewTemplate(cfg.EmailRelay.TemplateFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	conf := cfg.EmailRelay
+	if conf.Timeout <= 0 {
+		conf.Timeout = defaultTimeout
+	}
+
+	server, err := NewMailServer(cfg.AlertManager.RequireTLS, cfg.AlertManager.InsecureSkipVerify, conf.Timeout, cfg.MailFailover)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Sender{
+		server:     server,
+		template:   tmpl,
+		conf:       conf,
+		deliveries: deliveries,
+		queue:      queue,
+		logger:     logger,
+	}, nil
+}
+
+// Send sends the email of a notification to each recipient of the given receiver, except those pending verification of their
+// email address. Recipients the email could not be sent to are only logged and recorded, as retrying the notification would
+// send it again to the other recipients, unless the email is queued for retry to them. An error wrapping ErrNotDelivered is
+// returned if the email could neither be sent nor queued for any recipient, so that alertmanager retries it.
+func (s *Sender) Send(ctx context.Context, recv *models.DBReceiver, data Data) error {
+	to := recv.Notified()
+	if len(to) == 0 {
+		return nil
+	}
+
+	from, err := mail.ParseAddress(recv.From)
+	if err != nil {
+		return fmt.Errorf("invalid sender %q: %w", recv.From, err)
+	}
+
+	subject, body, err := s.template.Render(data, recv.EmailTemplate, recv.Language)
+	if err != nil {
+		return err
+	}
+
+	deliveries := make([]models.EmailDelivery, 0, len(to))
+	var queued []models.NotificationTask
+	var lastErr error
+	for _, recipient := range to {
+		delivery := models.EmailDelivery{
+			TenantID:     recv.TenantID,
+			ReceiverUUID: recv.UUID,
+			Recipient:    recipient,
+			GroupKey:     data.GroupKey,
+			AlertCount:   len(data.Alerts),
+			Status:       models.EmailDeliverySent,
+		}
+
+		delivery.Attempts, err = s.sendTo(ctx, from, recipient, subject, body)
+		delivery.CreationDate = clock.TimeNowFn().UTC()
+		if err != nil {
+			lastErr = err
+			delivery.Status = models.EmailDeliveryFailed
+			delivery.Error = err.Error()
+			s.logger.Error("Failed to send email", slog.String("tenant", recv.TenantID), slog.String("receiver", recv.UUID.String()),
+				slog.String("recipient", recipient), slog.Int("attempts", delivery.Attempts), slog.Any("error", err))
+			if s.queue != nil && isTransient(err) {
+				queued = append(queued, newQueuedEmail(recv, recipient, from, subject, body))
+			}
+		} else {
+			s.logger.Info("Sent email", slog.String("tenant", recv.TenantID), slog.String("receiver", recv.UUID.String()),
+				slog.String("recipient", recipient), slog.Int("attempts", delivery.Attempts))
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := s.deliveries.RecordEmailDeliveries(ctx, recv.TenantID, recv.UUID, deliveries, s.conf.DeliveryRetention); err != nil {
+		s.logger.Warn("Failed to record email deliveries", slog.String("receiver", recv.UUID.String()), slog.Any("error", err))
+	}
+
+	if len(queued) > 0 {
+		if err := s.queue.EnqueueNotifications(ctx, queued); err != nil {
+			s.logger.Error("Failed to queue emails for retry", slog.String("receiver", recv.UUID.String()), slog.Any("error", err))
+		} else {
+			s.logger.Info("Queued emails for retry", slog.String("tenant", recv.TenantID), slog.String("receiver", recv.UUID.String()),
+				slog.Int("recipients", len(queued)))
+			return nil
+		}
+	}
+
+	for _, d := range deliveries {
+		if d.Status == models.EmailDeliverySent {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %w", ErrNotDelivered, lastErr)
+}
+
+// newQueuedEmail returns the notification task queuing the given email for retry to the given recipient.
+func newQueuedEmail(recv *models.DBReceiver, recipient string, from *mail.Address, subject, body string) models.NotificationTask {
+	// The payload holds strings only, it cannot fail to be encoded.
+	payload, _ := json.Marshal(queuedEmail{From: from.String(), Subject: subject, Body: body})
+	return models.NotificationTask{
+		TenantID:     recv.TenantID,
+		ReceiverUUID: recv.UUID,
+		Channel:      models.NotificationEmail,
+		Recipient:    recipient,
+		Payload:      string(payload),
+	}
+}
+
+// Redeliver sends an email queued for retry to its recipient, in a single attempt as retries are handled by the queue, and
+// records the outcome.
+func (s *Sender) Redeliver(ctx context.Context, task models.NotificationTask) error {
+	var queued queuedEmail
+	if err := json.Unmarshal([]byte(task.Payload), &queued); err != nil {
+		return fmt.Errorf("invalid queued email: %w", err)
+	}
+	from, err := mail.ParseAddress(queued.From)
+	if err != nil {
+		return fmt.Errorf("invalid sender %q: %w", queued.From, err)
+	}
+	to, err := mail.ParseAddress(task.Recipient)
+	if err != nil {
+		return fmt.Errorf("invalid recipient %q: %w", task.Recipient, err)
+	}
+	msg, err := newMessage(from, to, queued.Subject, queued.Body)
+	if err != nil {
+		return err
+	}
+
+	delivery := models.EmailDelivery{
+		TenantID:     task.TenantID,
+		ReceiverUUID: task.ReceiverUUID,
+		Recipient:    task.Recipient,
+		Status:       models.EmailDeliverySent,
+		Attempts:     1,
+	}
+	sendErr := s.server.Send(ctx, from.Address, []string{to.Address}, msg)
+	delivery.CreationDate = clock.TimeNowFn().UTC()
+	if sendErr != nil {
+		delivery.Status = models.EmailDeliveryFailed
+		delivery.Error = sendErr.Error()
+	}
+	if err := s.deliveries.RecordEmailDeliveries(ctx, task.TenantID, task.ReceiverUUID, []models.EmailDelivery{delivery},
+		s.conf.DeliveryRetention); err != nil {
+		s.logger.Warn("Failed to record email delivery", slog.String("receiver", task.ReceiverUUID.String()), slog.Any("error", err))
+	}
+	return sendErr
+}
+
+// sendTo sends an email to a single recipient, retrying transient errors as given by the retry configuration. It returns
+// the number of attempts.
+func (s *Sender) sendTo(ctx context.Context, from *mail.Address, recipient, subject, body string) (int, error) {
+	to, err := mail.ParseAddress(recipient)
+	if err != nil {
+		return 0, fmt.Errorf("invalid recipient %q: %w", recipient, err)
+	}
+
+	msg, err := newMessage(from, to, subject, body)
+	if err != nil {
+		return 0, err
+	}
+
+	backoff := s.conf.Retry.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		err := s.server.Send(ctx, from.Address, []string{to.Address}, msg)
+		if err == nil || attempt > s.conf.Retry.MaxRetries || !isTransient(err) {
+			return attempt, err
+		}
+
+		wait := backoff/2 + mathrand.N(backoff/2+1) //nolint:gosec // Jitter does not need a secure random source.
+		select {
+		case <-ctx.Done():
+			return attempt, err
+		case <-time.After(wait):
+		}
+
+		backoff *= 2
+		if s.conf.Retry.MaxBackoff > 0 && backoff > s.conf.Retry.MaxBackoff {
+			backoff = s.conf.Retry.MaxBackoff
+		}
+	}
+}
+
+// newMessage returns an HTML email with the given subject and body, encoded as quoted-printable.
+func newMessage(from, to *mail.Address, subject, body string) ([]byte, error) {
+	var id [16]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return nil, fmt.Errorf("failed to generate message ID: %w", err)
+	}
+	_, domain, _ := strings.Cut(from.Address, "@")
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from.String())
+	fmt.Fprintf(&buf, "To: %s\r\n", to.String())
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", clock.TimeNowFn().Format(time.RFC1123Z))
+	fmt.Fprintf(&buf, "Message-Id: <%x@%s>\r\n", id, domain)
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/html; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+
+	w := quotedprintable.NewWriter(&buf)
+	if _, err := w.Write([]byte(body)); err != nil {
+		return nil, fmt.Errorf("failed to encode email body: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode email body: %w", err)
+	}
+	buf.WriteString("\r\n")
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,307 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package email
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+type MailSenderMock struct {
+	mock.Mock
+}
+
+func (m *MailSenderMock) Send(ctx context.Context, from string, to []string, msg []byte) error {
+	args := m.Called(ctx, from, to, msg)
+	return args.Error(0)
+}
+
+type EmailDeliveryRecorderMock struct {
+	mock.Mock
+}
+
+func (m *EmailDeliveryRecorderMock) RecordEmailDeliveries(ctx context.Context, tenantID api.TenantID, id uuid.UUID,
+	deliveries []models.EmailDelivery, retention time.Duration) error {
+	args := m.Called(ctx, tenantID, id, deliveries, retention)
+	return args.Error(0)
+}
+
+type NotificationEnqueuerMock struct {
+	mock.Mock
+}
+
+func (m *NotificationEnqueuerMock) EnqueueNotifications(ctx context.Context, tasks []models.NotificationTask) error {
+	args := m.Called(ctx, tasks)
+	return args.Error(0)
+}
+
+func newTestSender(t *testing.T, server mailSender, deliveries *EmailDeliveryRecorderMock) *Sender {
+	t.Helper()
+
+	tmpl, err := NewTemplate(writeTestTemplate(t, testTemplate))
+	require.NoError(t, err)
+
+	return &Sender{
+		server:   server,
+		template: tmpl,
+		conf: config.EmailRelayConfig{
+			Retry: config.RetryConfig{
+				MaxRetries:     2,
+				InitialBackoff: time.Millisecond,
+				MaxBackoff:     time.Millisecond,
+			},
+			DeliveryRetention: 24 * time.Hour,
+		},
+		deliveries: deliveries,
+		logger:     slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+}
+
+func TestSender_Send(t *testing.T) {
+	recv := &models.DBReceiver{
+		UUID:     uuid.MustParse("2e2ccb6c-1c83-4e5d-9b2f-8f0e5c3a1d44"),
+		TenantID: "tenant",
+		From:     "Alerts <alerts@example.com>",
+		To:       []string{"Foo <foo@bar.com>", "bar@foo.com"},
+	}
+
+	// statuses returns the status and attempts of the given deliveries, by recipient.
+	statuses := func(deliveries []models.EmailDelivery) map[string]string {
+		res := make(map[string]string, len(deliveries))
+		for _, d := range deliveries {
+			res[d.Recipient] = string(d.Status) + "/" + strconv.Itoa(d.Attempts)
+		}
+		return res
+	}
+
+	t.Run("SentToAllRecipients", func(t *testing.T) {
+		serverMock := new(MailSenderMock)
+		serverMock.On("Send", mock.Anything, "alerts@example.com", mock.Anything, mock.Anything).Return(nil)
+		recorderMock := new(EmailDeliveryRecorderMock)
+		recorderMock.On("RecordEmailDeliveries", mock.Anything, "tenant", recv.UUID, mock.Anything, 24*time.Hour).Return(nil)
+
+		require.NoError(t, newTestSender(t, serverMock, recorderMock).Send(context.Background(), recv, testData()))
+
+		serverMock.AssertNumberOfCalls(t, "Send", 2)
+		serverMock.AssertCalled(t, "Send", mock.Anything, "alerts@example.com", []string{"foo@bar.com"}, mock.Anything)
+		serverMock.AssertCalled(t, "Send", mock.Anything, "alerts@example.com", []string{"bar@foo.com"}, mock.Anything)
+
+		deliveries := recorderMock.Calls[0].Arguments.Get(3).([]models.EmailDelivery)
+		require.Equal(t, map[string]string{"Foo <foo@bar.com>": "Sent/1", "bar@foo.com": "Sent/1"}, statuses(deliveries))
+		require.Equal(t, `{}:{alertname="HighCPUUsage"}`, deliveries[0].GroupKey)
+		require.Equal(t, 2, deliveries[0].AlertCount)
+	})
+
+	t.Run("SentWithTemplateOfTenant", func(t *testing.T) {
+		serverMock := new(MailSenderMock)
+		serverMock.On("Send", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		recorderMock := new(EmailDeliveryRecorderMock)
+		recorderMock.On("RecordEmailDeliveries", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+		withTemplate := *recv
+		withTemplate.EmailTemplate = `<p>Tenant template</p>`
+		require.NoError(t, newTestSender(t, serverMock, recorderMock).Send(context.Background(), &withTemplate, testData()))
+
+		msg := serverMock.Calls[0].Arguments.Get(3).([]byte)
+		require.Contains(t, string(msg), "<p>Tenant template</p>")
+		require.NotContains(t, string(msg), "<h1>")
+	})
+
+	t.Run("TransientErrorRetried", func(t *testing.T) {
+		busy := &textproto.Error{Code: 450, Msg: "Mailbox busy"}
+		serverMock := new(MailSenderMock)
+		serverMock.On("Send", mock.Anything, mock.Anything, []string{"foo@bar.com"}, mock.Anything).Return(busy).Once()
+		serverMock.On("Send", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		recorderMock := new(EmailDeliveryRecorderMock)
+		recorderMock.On("RecordEmailDeliveries", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+		require.NoError(t, newTestSender(t, serverMock, recorderMock).Send(context.Background(), recv, testData()))
+
+		deliveries := recorderMock.Calls[0].Arguments.Get(3).([]models.EmailDelivery)
+		require.Equal(t, map[string]string{"Foo <foo@bar.com>": "Sent/2", "bar@foo.com": "Sent/1"}, statuses(deliveries))
+	})
+
+	t.Run("SomeRecipientsFailed", func(t *testing.T) {
+		serverMock := new(MailSenderMock)
+		serverMock.On("Send", mock.Anything, mock.Anything, []string{"foo@bar.com"}, mock.Anything).
+			Return(&textproto.Error{Code: 550, Msg: "No such user"})
+		serverMock.On("Send", mock.Anything, mock.Anything, []string{"bar@foo.com"}, mock.Anything).
+			Return(&textproto.Error{Code: 450, Msg: "Mailbox busy"}).Once()
+		serverMock.On("Send", mock.Anything, mock.Anything, []string{"bar@foo.com"}, mock.Anything).Return(nil)
+		recorderMock := new(EmailDeliveryRecorderMock)
+		recorderMock.On("RecordEmailDeliveries", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+		require.NoError(t, newTestSender(t, serverMock, recorderMock).Send(context.Background(), recv, testData()))
+
+		// Permanent errors are not retried.
+		deliveries := recorderMock.Calls[0].Arguments.Get(3).([]models.EmailDelivery)
+		require.Equal(t, map[string]string{"Foo <foo@bar.com>": "Failed/1", "bar@foo.com": "Sent/2"}, statuses(deliveries))
+		require.Contains(t, deliveries[0].Error, "No such user")
+	})
+
+	t.Run("AllRecipientsFailed", func(t *testing.T) {
+		serverMock := new(MailSenderMock)
+		serverMock.On("Send", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(&textproto.Error{Code: 421, Msg: "Service not available"})
+		recorderMock := new(EmailDeliveryRecorderMock)
+		recorderMock.On("RecordEmailDeliveries", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+		err := newTestSender(t, serverMock, recorderMock).Send(context.Background(), recv, testData())
+		require.ErrorIs(t, err, ErrNotDelivered)
+		require.ErrorContains(t, err, "Service not available")
+
+		deliveries := recorderMock.Calls[0].Arguments.Get(3).([]models.EmailDelivery)
+		require.Equal(t, map[string]string{"Foo <foo@bar.com>": "Failed/3", "bar@foo.com": "Failed/3"}, statuses(deliveries))
+	})
+
+	t.Run("TransientFailuresQueued", func(t *testing.T) {
+		serverMock := new(MailSenderMock)
+		serverMock.On("Send", mock.Anything, mock.Anything, []string{"foo@bar.com"}, mock.Anything).
+			Return(&textproto.Error{Code: 550, Msg: "No such user"})
+		serverMock.On("Send", mock.Anything, mock.Anything, []string{"bar@foo.com"}, mock.Anything).
+			Return(&textproto.Error{Code: 421, Msg: "Service not available"})
+		recorderMock := new(EmailDeliveryRecorderMock)
+		recorderMock.On("RecordEmailDeliveries", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		queueMock := new(NotificationEnqueuerMock)
+		queueMock.On("EnqueueNotifications", mock.Anything, mock.Anything).Return(nil).Once()
+
+		sender := newTestSender(t, serverMock, recorderMock)
+		sender.queue = queueMock
+		require.NoError(t, sender.Send(context.Background(), recv, testData()))
+
+		// Permanent errors are not queued.
+		tasks := queueMock.Calls[0].Arguments.Get(1).([]models.NotificationTask)
+		require.Len(t, tasks, 1)
+		require.Equal(t, "bar@foo.com", tasks[0].Recipient)
+		require.Equal(t, models.NotificationEmail, tasks[0].Channel)
+		require.Equal(t, recv.UUID, tasks[0].ReceiverUUID)
+		require.Contains(t, tasks[0].Payload, `"from":"\"Alerts\" \u003calerts@example.com\u003e"`)
+	})
+
+	t.Run("QueueFailed", func(t *testing.T) {
+		serverMock := new(MailSenderMock)
+		serverMock.On("Send", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(&textproto.Error{Code: 421, Msg: "Service not available"})
+		recorderMock := new(EmailDeliveryRecorderMock)
+		recorderMock.On("RecordEmailDeliveries", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		queueMock := new(NotificationEnqueuerMock)
+		queueMock.On("EnqueueNotifications", mock.Anything, mock.Anything).Return(errors.New("database unavailable")).Once()
+
+		sender := newTestSender(t, serverMock, recorderMock)
+		sender.queue = queueMock
+		require.ErrorIs(t, sender.Send(context.Background(), recv, testData()), ErrNotDelivered)
+	})
+
+	t.Run("RecipientPendingVerificationSkipped", func(t *testing.T) {
+		serverMock := new(MailSenderMock)
+		serverMock.On("Send", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		recorderMock := new(EmailDeliveryRecorderMock)
+		recorderMock.On("RecordEmailDeliveries", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+		withUnverified := *recv
+		withUnverified.Unverified = []string{"bar@foo.com"}
+		require.NoError(t, newTestSender(t, serverMock, recorderMock).Send(context.Background(), &withUnverified, testData()))
+
+		serverMock.AssertNumberOfCalls(t, "Send", 1)
+		deliveries := recorderMock.Calls[0].Arguments.Get(3).([]models.EmailDelivery)
+		require.Equal(t, map[string]string{"Foo <foo@bar.com>": "Sent/1"}, statuses(deliveries))
+	})
+
+	t.Run("ReceiverWithoutRecipients", func(t *testing.T) {
+		serverMock := new(MailSenderMock)
+		recorderMock := new(EmailDeliveryRecorderMock)
+
+		require.NoError(t, newTestSender(t, serverMock, recorderMock).Send(context.Background(), &models.DBReceiver{}, testData()))
+		serverMock.AssertNotCalled(t, "Send", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		recorderMock.AssertNotCalled(t, "RecordEmailDeliveries", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestSender_Redeliver(t *testing.T) {
+	task := models.NotificationTask{
+		TenantID:     "tenant",
+		ReceiverUUID: uuid.MustParse("2e2ccb6c-1c83-4e5d-9b2f-8f0e5c3a1d44"),
+		Channel:      models.NotificationEmail,
+		Recipient:    "Foo <foo@bar.com>",
+		Payload:      `{"from":"Alerts <alerts@example.com>","subject":"[FIRING:1] HighCPUUsage","body":"<p>alert</p>"}`,
+	}
+
+	t.Run("Sent", func(t *testing.T) {
+		serverMock := new(MailSenderMock)
+		serverMock.On("Send", mock.Anything, "alerts@example.com", []string{"foo@bar.com"}, mock.Anything).Return(nil).Once()
+		recorderMock := new(EmailDeliveryRecorderMock)
+		recorderMock.On("RecordEmailDeliveries", mock.Anything, "tenant", task.ReceiverUUID, mock.Anything, 24*time.Hour).Return(nil)
+
+		require.NoError(t, newTestSender(t, serverMock, recorderMock).Redeliver(context.Background(), task))
+
+		msg := serverMock.Calls[0].Arguments.Get(3).([]byte)
+		require.Contains(t, string(msg), "<p>alert</p>")
+		deliveries := recorderMock.Calls[0].Arguments.Get(3).([]models.EmailDelivery)
+		require.Len(t, deliveries, 1)
+		require.Equal(t, models.EmailDeliverySent, deliveries[0].Status)
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		serverMock := new(MailSenderMock)
+		serverMock.On("Send", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(&textproto.Error{Code: 421, Msg: "Service not available"}).Once()
+		recorderMock := new(EmailDeliveryRecorderMock)
+		recorderMock.On("RecordEmailDeliveries", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+		// Retries are handled by the queue.
+		require.ErrorContains(t, newTestSender(t, serverMock, recorderMock).Redeliver(context.Background(), task), "Service not available")
+		serverMock.AssertNumberOfCalls(t, "Send", 1)
+		deliveries := recorderMock.Calls[0].Arguments.Get(3).([]models.EmailDelivery)
+		require.Equal(t, models.EmailDeliveryFailed, deliveries[0].Status)
+	})
+
+	t.Run("InvalidPayload", func(t *testing.T) {
+		invalid := task
+		invalid.Payload = "{"
+		require.ErrorContains(t, newTestSender(t, new(MailSenderMock), new(EmailDeliveryRecorderMock)).Redeliver(context.Background(), invalid),
+			"invalid queued email")
+	})
+}
+
+func TestNewMessage(t *testing.T) {
+	from := &mail.Address{Name: "Alerts", Address: "alerts@example.com"}
+	to := &mail.Address{Address: "foo@bar.com"}
+
+	msg, err := newMessage(from, to, "[FIRING:1] Übertemperatur", "<p>alert</p>")
+	require.NoError(t, err)
+
+	parsed, err := mail.ReadMessage(bytes.NewReader(msg))
+	require.NoError(t, err)
+	require.Equal(t, `"Alerts" <alerts@example.com>`, parsed.Header.Get("From"))
+	require.Equal(t, "<foo@bar.com>", parsed.Header.Get("To"))
+	require.Equal(t, "text/html; charset=UTF-8", parsed.Header.Get("Content-Type"))
+	require.True(t, strings.HasSuffix(parsed.Header.Get("Message-Id"), "@example.com>"))
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	require.NoError(t, err)
+	require.Equal(t, "[FIRING:1] Übertemperatur", subject)
+
+	body, err := io.ReadAll(quotedprintable.NewReader(parsed.Body))
+	require.NoError(t, err)
+	require.Equal(t, "<p>alert</p>\r\n", string(body))
+}
@@ -0,0 +1,230 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package email
+
+import (
+	"cmp"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/network"
+)
+
+// MailServer sends emails to the mail server given by the SMART_HOST and SMART_PORT environment variables, with the optional
+// SMTP_USERNAME and SMTP_PASSWORD credentials, which is the mail server alertmanager would otherwise send them to. Emails fail
+// over to the mail servers of the failover configuration when it is unavailable.
+type MailServer struct {
+	// hosts are the mail servers emails are sent to, ordered by priority.
+	hosts              []*mailHost
+	username           string
+	password           string
+	requireTLS         bool
+	insecureSkipVerify bool
+	timeout            time.Duration
+	cooldown           time.Duration
+
+	mu sync.Mutex
+}
+
+// mailHost is a mail server emails are sent to.
+type mailHost struct {
+	addr string
+	host string
+	// failedUntil is the end of the cooldown of a mail server an email failed to be sent to, until which it is tried last.
+	failedUntil time.Time
+}
+
+// sessionError is an error setting up the SMTP session with a mail server, before sending an email, which is specific to
+// the mail server, such as failing to connect or to authenticate to it.
+type sessionError struct {
+	err error
+}
+
+func (e *sessionError) Error() string {
+	return e.err.Error()
+}
+
+func (e *sessionError) Unwrap() error {
+	return e.err
+}
+
+// NewMailServer creates a new MailServer from the environment and the given failover configuration. The connection to the
+// mail server is upgraded with STARTTLS when supported, which is required if requireTLS is set.
+func NewMailServer(requireTLS, insecureSkipVerify bool, timeout time.Duration, failover config.MailFailoverConfig) (*MailServer, error) {
+	host, port := os.Getenv("SMART_HOST"), os.Getenv("SMART_PORT")
+	if host == "" || port == "" {
+		return nil, errors.New("mail server is not set")
+	}
+	if err := network.ValidateMailServer(host, port); err != nil {
+		return nil, err
+	}
+
+	hosts := []*mailHost{{addr: net.JoinHostPort(host, port), host: host}}
+	servers := slices.Clone(failover.Servers)
+	slices.SortStableFunc(servers, func(a, b config.MailServerConfig) int {
+		return cmp.Compare(a.Priority, b.Priority)
+	})
+	for _, server := range servers {
+		port := strconv.Itoa(server.Port)
+		if err := network.ValidateMailServer(server.Host, port); err != nil {
+			return nil, fmt.Errorf("invalid failover mail server: %w", err)
+		}
+		hosts = append(hosts, &mailHost{addr: net.JoinHostPort(server.Host, port), host: server.Host})
+	}
+
+	return &MailServer{
+		hosts:              hosts,
+		username:           os.Getenv("SMTP_USERNAME"),
+		password:           os.Getenv("SMTP_PASSWORD"),
+		requireTLS:         requireTLS,
+		insecureSkipVerify: insecureSkipVerify,
+		timeout:            timeout,
+		cooldown:           failover.Cooldown,
+	}, nil
+}
+
+// Send sends an email to the given recipients, upgrading the connection with STARTTLS when supported by the mail server.
+// The email fails over to the next mail server when the SMTP session cannot be set up with a mail server, or when it fails
+// with a transient error. The mail servers in cooldown are tried last. Permanent negative replies to the email, such as an
+// unknown recipient, are returned without failing over, since the other mail servers would reply the same.
+func (s *MailServer) Send(ctx context.Context, from string, to []string, msg []byte) error {
+	hosts := s.order()
+	errs := make([]error, 0, len(hosts))
+	for i, h := range hosts {
+		err := s.sendTo(ctx, h, from, to, msg)
+		if err == nil {
+			s.setFailedUntil(h, time.Time{})
+			return nil
+		}
+		if len(hosts) == 1 {
+			return err
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", h.addr, err))
+
+		var sessionErr *sessionError
+		if !isTransient(err) && !errors.As(err, &sessionErr) {
+			break
+		}
+		s.setFailedUntil(h, clock.TimeNowFn().Add(s.cooldown))
+		if i < len(hosts)-1 {
+			slog.Warn("Failed to send email to mail server, failing over to the next one", slog.String("mailServer", h.addr),
+				slog.Any("error", err))
+		}
+	}
+	return fmt.Errorf("failed to send email to mail servers: %w", errors.Join(errs...))
+}
+
+// order returns the mail servers in the order they are tried: by priority, the ones in cooldown last.
+func (s *MailServer) order() []*mailHost {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := clock.TimeNowFn()
+	hosts := make([]*mailHost, 0, len(s.hosts))
+	var cooling []*mailHost
+	for _, h := range s.hosts {
+		if h.failedUntil.After(now) {
+			cooling = append(cooling, h)
+		} else {
+			hosts = append(hosts, h)
+		}
+	}
+	return append(hosts, cooling...)
+}
+
+func (s *MailServer) setFailedUntil(h *mailHost, failedUntil time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	h.failedUntil = failedUntil
+}
+
+// sendTo sends an email to the given mail server.
+func (s *MailServer) sendTo(ctx context.Context, h *mailHost, from string, to []string, msg []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	conn, err := network.DialContext(ctx, "tcp", h.addr)
+	if err != nil {
+		return &sessionError{fmt.Errorf("failed to connect to mail server: %w", err)}
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			conn.Close()
+			return fmt.Errorf("failed to set mail server deadline: %w", err)
+		}
+	}
+
+	client, err := smtp.NewClient(conn, h.host)
+	if err != nil {
+		conn.Close()
+		return &sessionError{fmt.Errorf("failed to start SMTP session: %w", err)}
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		//nolint:gosec // Skipping verification is only allowed when configured so, as for alertmanager.
+		if err := client.StartTLS(&tls.Config{ServerName: h.host, InsecureSkipVerify: s.insecureSkipVerify}); err != nil {
+			return &sessionError{fmt.Errorf("failed to start TLS: %w", err)}
+		}
+	} else if s.requireTLS {
+		return &sessionError{errors.New("mail server does not support STARTTLS")}
+	}
+
+	if s.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.username, s.password, h.host)); err != nil {
+			return &sessionError{fmt.Errorf("failed to authenticate to mail server: %w", err)}
+		}
+	}
+
+	if err := client.Mail(from); err != nil {
+		return fmt.Errorf("failed to set sender: %w", err)
+	}
+	for _, rcpt := range to {
+		if err := client.Rcpt(rcpt); err != nil {
+			return fmt.Errorf("failed to set recipient: %w", err)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("failed to start email data: %w", err)
+	}
+	if _, err := w.Write(msg); err != nil {
+		return fmt.Errorf("failed to write email data: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to send email data: %w", err)
+	}
+	// The email is accepted once its data is, so it is neither retried nor failed over if the session fails to quit, which
+	// would send it twice.
+	if err := client.Quit(); err != nil {
+		slog.Debug("Failed to quit SMTP session", slog.String("mailServer", h.addr), slog.Any("error", err))
+	}
+	return nil
+}
+
+// isTransient tells whether an error returned while sending an email is worth retrying, which is the case of network
+// failures and transient negative replies (4yz) of the mail server. Permanent negative replies (5yz), such as an unknown
+// recipient, are not retried.
+func isTransient(err error) bool {
+	var protoErr *textproto.Error
+	if errors.As(err, &protoErr) {
+		return protoErr.Code >= 400 && protoErr.Code < 500
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,281 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package email
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+)
+
+// fakeMailServer is a mail server accepting a single SMTP session, which replies to RCPT commands with rcptReply.
+type fakeMailServer struct {
+	addr      string
+	rcptReply string
+	received  chan string
+}
+
+func newFakeMailServer(t *testing.T, rcptReply string) *fakeMailServer {
+	t.Helper()
+	return listenFakeMailServer(t, "127.0.0.1:0", rcptReply)
+}
+
+// listenFakeMailServer returns a fake mail server listening on the given address, skipping the test if it cannot listen on
+// it, as on hosts without IPv6.
+func listenFakeMailServer(t *testing.T, addr, rcptReply string) *fakeMailServer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		t.Skipf("cannot listen on %s: %v", addr, err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	s := &fakeMailServer{addr: listener.Addr().String(), rcptReply: rcptReply, received: make(chan string, 1)}
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		s.serve(textproto.NewConn(conn))
+	}()
+	return s
+}
+
+func (s *fakeMailServer) serve(conn *textproto.Conn) {
+	reply := func(line string) { _ = conn.PrintfLine("%s", line) }
+
+	reply("220 localhost ESMTP")
+	for {
+		line, err := conn.ReadLine()
+		if err != nil {
+			return
+		}
+		switch verb, _, _ := strings.Cut(strings.ToUpper(line), " "); verb {
+		case "EHLO", "HELO":
+			reply("250 localhost")
+		case "MAIL", "RSET", "NOOP":
+			reply("250 OK")
+		case "RCPT":
+			reply(s.rcptReply)
+		case "DATA":
+			reply("354 Start mail input")
+			data, err := conn.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			s.received <- string(data)
+			reply("250 OK")
+		case "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+func (s *fakeMailServer) mailServer(t *testing.T) *MailServer {
+	t.Helper()
+
+	host, _, err := net.SplitHostPort(s.addr)
+	require.NoError(t, err)
+	return &MailServer{hosts: []*mailHost{{addr: s.addr, host: host}}, timeout: 5 * time.Second}
+}
+
+func TestNewMailServer(t *testing.T) {
+	t.Run("MailServerSet", func(t *testing.T) {
+		t.Setenv("SMART_HOST", "smtp.example.com")
+		t.Setenv("SMART_PORT", "587")
+		t.Setenv("SMTP_USERNAME", "user")
+		t.Setenv("SMTP_PASSWORD", "password")
+
+		server, err := NewMailServer(true, false, time.Minute, config.MailFailoverConfig{})
+		require.NoError(t, err)
+		require.Equal(t, &MailServer{
+			hosts:      []*mailHost{{addr: "smtp.example.com:587", host: "smtp.example.com"}},
+			username:   "user",
+			password:   "password",
+			requireTLS: true,
+			timeout:    time.Minute,
+		}, server)
+	})
+
+	t.Run("IPv6MailServer", func(t *testing.T) {
+		t.Setenv("SMART_HOST", "fd00::25")
+		t.Setenv("SMART_PORT", "25")
+
+		server, err := NewMailServer(false, false, time.Minute, config.MailFailoverConfig{})
+		require.NoError(t, err)
+		require.Equal(t, []*mailHost{{addr: "[fd00::25]:25", host: "fd00::25"}}, server.hosts)
+	})
+
+	t.Run("FailoverMailServers", func(t *testing.T) {
+		t.Setenv("SMART_HOST", "smtp.example.com")
+		t.Setenv("SMART_PORT", "587")
+
+		server, err := NewMailServer(false, false, time.Minute, config.MailFailoverConfig{
+			Servers: []config.MailServerConfig{
+				{Host: "fd00::25", Port: 25, Priority: 20},
+				{Host: "smtp-backup.example.com", Port: 587, Priority: 10},
+			},
+			Cooldown: 5 * time.Minute,
+		})
+		require.NoError(t, err)
+		require.Equal(t, []*mailHost{
+			{addr: "smtp.example.com:587", host: "smtp.example.com"},
+			{addr: "smtp-backup.example.com:587", host: "smtp-backup.example.com"},
+			{addr: "[fd00::25]:25", host: "fd00::25"},
+		}, server.hosts)
+		require.Equal(t, 5*time.Minute, server.cooldown)
+
+		_, err = NewMailServer(false, false, time.Minute, config.MailFailoverConfig{
+			Servers: []config.MailServerConfig{{Host: "[fd00::25]", Port: 25}},
+		})
+		require.ErrorContains(t, err, "invalid failover mail server")
+	})
+
+	t.Run("InvalidMailServer", func(t *testing.T) {
+		t.Setenv("SMART_HOST", "[fd00::25]")
+		t.Setenv("SMART_PORT", "25")
+		_, err := NewMailServer(false, false, time.Minute, config.MailFailoverConfig{})
+		require.ErrorContains(t, err, "invalid mail server host")
+
+		t.Setenv("SMART_HOST", "smtp.example.com")
+		t.Setenv("SMART_PORT", "smtp")
+		_, err = NewMailServer(false, false, time.Minute, config.MailFailoverConfig{})
+		require.ErrorContains(t, err, "invalid mail server port")
+	})
+
+	t.Run("MailServerNotSet", func(t *testing.T) {
+		t.Setenv("SMART_HOST", "")
+		t.Setenv("SMART_PORT", "")
+
+		_, err := NewMailServer(true, false, time.Minute, config.MailFailoverConfig{})
+		require.ErrorContains(t, err, "mail server is not set")
+	})
+}
+
+func TestMailServer_Send(t *testing.T) {
+	t.Run("DeliversEmail", func(t *testing.T) {
+		fake := newFakeMailServer(t, "250 OK")
+
+		err := fake.mailServer(t).Send(context.Background(), "alerts@example.com", []string{"foo@bar.com"}, []byte("Subject: test\r\n\r\nbody\r\n"))
+		require.NoError(t, err)
+		require.Contains(t, <-fake.received, "body")
+	})
+
+	t.Run("DeliversEmailOverIPv6", func(t *testing.T) {
+		fake := listenFakeMailServer(t, "[::1]:0", "250 OK")
+
+		err := fake.mailServer(t).Send(context.Background(), "alerts@example.com", []string{"foo@bar.com"}, []byte("Subject: test\r\n\r\nbody\r\n"))
+		require.NoError(t, err)
+		require.Contains(t, <-fake.received, "body")
+	})
+
+	t.Run("TLSRequired", func(t *testing.T) {
+		fake := newFakeMailServer(t, "250 OK")
+		server := fake.mailServer(t)
+		server.requireTLS = true
+
+		err := server.Send(context.Background(), "alerts@example.com", []string{"foo@bar.com"}, []byte("Subject: test\r\n\r\nbody\r\n"))
+		require.ErrorContains(t, err, "mail server does not support STARTTLS")
+	})
+
+	t.Run("RecipientRejected", func(t *testing.T) {
+		fake := newFakeMailServer(t, "550 No such user")
+
+		err := fake.mailServer(t).Send(context.Background(), "alerts@example.com", []string{"foo@bar.com"}, []byte("Subject: test\r\n\r\nbody\r\n"))
+		var protoErr *textproto.Error
+		require.ErrorAs(t, err, &protoErr)
+		require.Equal(t, 550, protoErr.Code)
+	})
+}
+
+func TestMailServer_SendFailover(t *testing.T) {
+	msg := []byte("Subject: test\r\n\r\nbody\r\n")
+	failover := func(t *testing.T, fakes ...*fakeMailServer) *MailServer {
+		t.Helper()
+		server := fakes[0].mailServer(t)
+		for _, fake := range fakes[1:] {
+			server.hosts = append(server.hosts, fake.mailServer(t).hosts...)
+		}
+		server.cooldown = time.Minute
+		return server
+	}
+
+	t.Run("FailsOverTransientErrors", func(t *testing.T) {
+		busy := newFakeMailServer(t, "451 Try again later")
+		backup := newFakeMailServer(t, "250 OK")
+		server := failover(t, busy, backup)
+
+		require.NoError(t, server.Send(context.Background(), "alerts@example.com", []string{"foo@bar.com"}, msg))
+		require.Contains(t, <-backup.received, "body")
+		// The failed mail server is tried last until the end of its cooldown.
+		require.Equal(t, []string{backup.addr, busy.addr}, []string{server.order()[0].addr, server.order()[1].addr})
+	})
+
+	t.Run("FailsOverUnavailableMailServer", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		unavailable := &fakeMailServer{addr: listener.Addr().String()}
+		listener.Close()
+		backup := newFakeMailServer(t, "250 OK")
+
+		require.NoError(t, failover(t, unavailable, backup).Send(context.Background(), "alerts@example.com", []string{"foo@bar.com"}, msg))
+		require.Contains(t, <-backup.received, "body")
+	})
+
+	t.Run("DoesNotFailOverPermanentErrors", func(t *testing.T) {
+		rejecting := newFakeMailServer(t, "550 No such user")
+		backup := newFakeMailServer(t, "250 OK")
+		server := failover(t, rejecting, backup)
+
+		err := server.Send(context.Background(), "alerts@example.com", []string{"foo@bar.com"}, msg)
+		var protoErr *textproto.Error
+		require.ErrorAs(t, err, &protoErr)
+		require.Equal(t, 550, protoErr.Code)
+		require.Empty(t, backup.received)
+		require.Equal(t, rejecting.addr, server.order()[0].addr)
+	})
+
+	t.Run("AllMailServersFail", func(t *testing.T) {
+		busy := newFakeMailServer(t, "451 Try again later")
+		other := newFakeMailServer(t, "452 Insufficient storage")
+
+		err := failover(t, busy, other).Send(context.Background(), "alerts@example.com", []string{"foo@bar.com"}, msg)
+		require.ErrorContains(t, err, busy.addr)
+		require.ErrorContains(t, err, other.addr)
+		require.True(t, isTransient(err))
+	})
+}
+
+func TestIsTransient(t *testing.T) {
+	testCases := map[string]struct {
+		err       error
+		transient bool
+	}{
+		"MailboxBusy":       {err: fmt.Errorf("failed to set recipient: %w", &textproto.Error{Code: 450, Msg: "Mailbox busy"}), transient: true},
+		"NoSuchUser":        {err: fmt.Errorf("failed to set recipient: %w", &textproto.Error{Code: 550, Msg: "No such user"})},
+		"ConnectionRefused": {err: fmt.Errorf("failed to connect to mail server: %w", &net.OpError{Op: "dial", Err: errors.New("connection refused")}), transient: true},
+		"DeadlineExceeded":  {err: context.DeadlineExceeded, transient: true},
+		"TLSRequired":       {err: errors.New("mail server does not support STARTTLS")},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.transient, isTransient(tc.err))
+		})
+	}
+}
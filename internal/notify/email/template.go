@@ -0,0 +1,291 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package email
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"regexp"
+	"strings"
+	texttemplate "text/template"
+	"text/template/parse"
+	"time"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+const (
+	// htmlTemplateName is the name of the template of the HTML body of emails, as set in the email configs of alertmanager.
+	htmlTemplateName = "alert.monitor.mail"
+
+	// subjectTemplateName is the name of the template of the subject of emails, which is also available to the HTML body.
+	subjectTemplateName = "__subject"
+
+	// customTemplateName is the name template texts given in place of the email template of the deployment are parsed under.
+	customTemplateName = "__custom"
+
+	// subjectTemplate defines the default subject of alertmanager emails.
+	subjectTemplate = `{{ define "__subject" }}[{{ .Status | toUpper }}{{ if eq .Status "firing" }}:{{ .Alerts.Firing | len }}{{ end }}] ` +
+		`{{ .GroupLabels.SortedPairs.Values | join " " }} ` +
+		`{{ if gt (len .CommonLabels) (len .GroupLabels) }}({{ with .CommonLabels.Remove .GroupLabels.Names }}{{ .Values | join " " }}{{ end }}){{ end }}{{ end }}`
+
+	// defaultRenderTimeout is the maximum time rendering the subject or the HTML body of an email takes, so that a template
+	// looping over the alerts excessively does not stall its caller.
+	defaultRenderTimeout = 2 * time.Second
+	// defaultMaxRenderSize is the maximum size in bytes of the rendered subject or HTML body of an email.
+	defaultMaxRenderSize = 1024 * 1024
+)
+
+// ErrRenderLimit is returned when rendering an email exceeds the time or size limits of rendering.
+var ErrRenderLimit = errors.New("email rendering limit exceeded")
+
+// templateFuncs are the functions alertmanager provides to templates. As templates are also given by tenants, these are the
+// only functions available to them besides the builtin functions of Go templates, and none of them accesses the environment or
+// files.
+var templateFuncs = map[string]any{
+	"toUpper":   strings.ToUpper,
+	"toLower":   strings.ToLower,
+	"title":     cases.Title(language.AmericanEnglish).String,
+	"trimSpace": strings.TrimSpace,
+	"join": func(sep string, s []string) string {
+		return strings.Join(s, sep)
+	},
+	"match": regexp.MatchString,
+	"safeHtml": func(text string) htmltemplate.HTML {
+		return htmltemplate.HTML(text) //nolint:gosec // Templates are provided by the operator, as for alertmanager.
+	},
+	"reReplaceAll": func(pattern, repl, text string) string {
+		return regexp.MustCompile(pattern).ReplaceAllString(text, repl)
+	},
+	"stringSlice": func(s ...string) []string {
+		return s
+	},
+}
+
+// Template renders the subject and HTML body of emails.
+type Template struct {
+	subject *texttemplate.Template
+	html    *htmltemplate.Template
+	// base holds the same templates as html but is never executed, since HTML templates can only be cloned until they are.
+	// Template texts given in place of the template of the deployment are parsed into clones of it.
+	base *htmltemplate.Template
+	// renderTimeout and maxRenderSize are the limits of rendering the subject or the HTML body of an email.
+	renderTimeout time.Duration
+	maxRenderSize int
+}
+
+// NewTemplate creates a new Template from the alertmanager template files matching the given pattern, which must define the
+// HTML body of emails.
+func NewTemplate(pattern string) (*Template, error) {
+	subject, err := texttemplate.New("subject").Funcs(templateFuncs).Parse(subjectTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse subject template: %w", err)
+	}
+
+	html, err := htmltemplate.New("").Funcs(templateFuncs).Parse(subjectTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse subject template: %w", err)
+	}
+	if html, err = html.ParseGlob(pattern); err != nil {
+		return nil, fmt.Errorf("failed to parse email templates: %w", err)
+	}
+	if html.Lookup(htmlTemplateName) == nil {
+		return nil, fmt.Errorf("email templates do not define %q", htmlTemplateName)
+	}
+
+	base, err := html.Clone()
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone email templates: %w", err)
+	}
+
+	return &Template{
+		subject:       subject,
+		html:          html,
+		base:          base,
+		renderTimeout: defaultRenderTimeout,
+		maxRenderSize: defaultMaxRenderSize,
+	}, nil
+}
+
+// LocalizedTemplateName returns the name of the template of the HTML body of emails in the given language, as defined by the
+// localized email templates of the deployment.
+func LocalizedTemplateName(language string) string {
+	return htmlTemplateName + "." + language
+}
+
+// ValidateHTML checks that the given template text of the HTML body of emails can be parsed, without the templates of the
+// deployment it may use.
+func ValidateHTML(text string) error {
+	html, err := htmltemplate.New("").Funcs(templateFuncs).Parse(subjectTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to parse subject template: %w", err)
+	}
+	if _, err := html.New(customTemplateName).Parse(text); err != nil {
+		return fmt.Errorf("failed to parse email template: %w", err)
+	}
+	return checkRanges(text)
+}
+
+// checkRanges rejects the template texts ranging over integers, which would loop over no data, as given by number literals or
+// the variables they are assigned to. Templates otherwise only loop over the alerts of notifications, and those looping
+// excessively anyway are bounded by the rendering limits.
+func checkRanges(text string) error {
+	tmpl, err := texttemplate.New(customTemplateName).Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return fmt.Errorf("failed to parse email template: %w", err)
+	}
+
+	numbers := make(map[string]bool)
+	var walk func(node parse.Node) error
+	walk = func(node parse.Node) error {
+		switch n := node.(type) {
+		case *parse.ListNode:
+			if n == nil {
+				return nil
+			}
+			for _, child := range n.Nodes {
+				if err := walk(child); err != nil {
+					return err
+				}
+			}
+		case *parse.ActionNode:
+			for _, v := range n.Pipe.Decl {
+				numbers[v.Ident[0]] = isNumber(n.Pipe)
+			}
+		case *parse.IfNode:
+			return walkBranch(walk, &n.BranchNode)
+		case *parse.WithNode:
+			return walkBranch(walk, &n.BranchNode)
+		case *parse.RangeNode:
+			if isNumber(n.Pipe) || rangesOverVariable(n.Pipe, numbers) {
+				return fmt.Errorf("email template must not range over an integer: %s", n.Pipe)
+			}
+			return walkBranch(walk, &n.BranchNode)
+		}
+		return nil
+	}
+
+	for _, t := range tmpl.Templates() {
+		if t.Tree == nil {
+			continue
+		}
+		if err := walk(t.Tree.Root); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkBranch(walk func(parse.Node) error, branch *parse.BranchNode) error {
+	if err := walk(branch.List); err != nil {
+		return err
+	}
+	return walk(branch.ElseList)
+}
+
+// isNumber tells whether the given pipeline is a number literal.
+func isNumber(pipe *parse.PipeNode) bool {
+	if len(pipe.Cmds) != 1 || len(pipe.Cmds[0].Args) != 1 {
+		return false
+	}
+	_, ok := pipe.Cmds[0].Args[0].(*parse.NumberNode)
+	return ok
+}
+
+// rangesOverVariable tells whether the given pipeline is one of the given variables assigned a number literal.
+func rangesOverVariable(pipe *parse.PipeNode, numbers map[string]bool) bool {
+	if len(pipe.Cmds) != 1 || len(pipe.Cmds[0].Args) != 1 {
+		return false
+	}
+	v, ok := pipe.Cmds[0].Args[0].(*parse.VariableNode)
+	return ok && len(v.Ident) == 1 && numbers[v.Ident[0]]
+}
+
+// Render returns the subject and HTML body of the email of a notification. The body is rendered with the given template text,
+// which may use the templates of the deployment, or with the email template of the deployment if empty: the localized one of
+// the given language if defined, the default one otherwise.
+func (t *Template) Render(data Data, text, language string) (string, string, error) {
+	subject, err := t.execute(t.subject, subjectTemplateName, data)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to render email subject: %w", err)
+	}
+
+	var tmpl templateExecutor = t.html
+	name := htmlTemplateName
+	if localized := LocalizedTemplateName(language); language != "" && t.html.Lookup(localized) != nil {
+		name = localized
+	}
+	if text != "" {
+		clone, err := t.base.Clone()
+		if err != nil {
+			return "", "", fmt.Errorf("failed to clone email templates: %w", err)
+		}
+		if _, err := clone.New(customTemplateName).Parse(text); err != nil {
+			return "", "", fmt.Errorf("failed to parse email template: %w", err)
+		}
+		if err := checkRanges(text); err != nil {
+			return "", "", err
+		}
+		tmpl, name = clone, customTemplateName
+	}
+
+	html, err := t.execute(tmpl, name, data)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to render email body: %w", err)
+	}
+
+	return strings.TrimSpace(subject), html, nil
+}
+
+// templateExecutor executes the templates of either the text or the HTML template package.
+type templateExecutor interface {
+	ExecuteTemplate(w io.Writer, name string, data any) error
+}
+
+// execute executes the template of the given name within the rendering limits. An error wrapping ErrRenderLimit is returned
+// if the output exceeds the maximum size or the execution exceeds the timeout. Go templates cannot be interrupted, so the
+// execution is left to finish in the background on timeout, which it does on its next write at the latest.
+func (t *Template) execute(tmpl templateExecutor, name string, data Data) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), t.renderTimeout)
+	defer cancel()
+
+	out := &limitedWriter{ctx: ctx, limit: t.maxRenderSize}
+	done := make(chan error, 1)
+	go func() {
+		done <- tmpl.ExecuteTemplate(out, name, data)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return "", err
+		}
+		return out.buf.String(), nil
+	case <-ctx.Done():
+		return "", fmt.Errorf("%w: rendering took longer than %s", ErrRenderLimit, t.renderTimeout)
+	}
+}
+
+// limitedWriter buffers the output of a template, failing writes once the output exceeds its limit or its context is done,
+// which aborts the execution of the template.
+type limitedWriter struct {
+	ctx   context.Context
+	limit int
+	buf   bytes.Buffer
+}
+
+func (w *limitedWriter) Write(p []byte) (int, error) {
+	if err := w.ctx.Err(); err != nil {
+		return 0, fmt.Errorf("%w: rendering timed out", ErrRenderLimit)
+	}
+	if w.buf.Len()+len(p) > w.limit {
+		return 0, fmt.Errorf("%w: output is larger than %d bytes", ErrRenderLimit, w.limit)
+	}
+	return w.buf.Write(p)
+}
@@ -0,0 +1,178 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package email
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testTemplate = `{{ define "alert.monitor.mail" }}<h1>{{ template "__subject" . }}</h1>` +
+	`{{ range .Alerts.Firing }}<p>{{ .Annotations.description }}</p>{{ end }}{{ end }}`
+
+// writeTestTemplate writes the given template to a temporary directory and returns the pattern matching it.
+func writeTestTemplate(t *testing.T, content string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "email.tmpl"), []byte(content), 0o600))
+	return filepath.Join(dir, "*.tmpl")
+}
+
+func testData() Data {
+	return Data{
+		Receiver: "tenant-receiver-1",
+		Status:   alertStatusFiring,
+		Alerts: Alerts{
+			{Status: alertStatusFiring, Annotations: KV{"description": "CPU usage <above> 90%"}},
+			{Status: alertStatusResolved, Annotations: KV{"description": "CPU usage above 80%"}},
+		},
+		GroupLabels:  KV{"alertname": "HighCPUUsage"},
+		CommonLabels: KV{"alertname": "HighCPUUsage", "host_uuid": "host-1"},
+		GroupKey:     `{}:{alertname="HighCPUUsage"}`,
+	}
+}
+
+func TestNewTemplate(t *testing.T) {
+	t.Run("TemplateFilesNotFound", func(t *testing.T) {
+		_, err := NewTemplate(filepath.Join(t.TempDir(), "*.tmpl"))
+		require.ErrorContains(t, err, "failed to parse email templates")
+	})
+
+	t.Run("EmailTemplateNotDefined", func(t *testing.T) {
+		_, err := NewTemplate(writeTestTemplate(t, `{{ define "other" }}{{ end }}`))
+		require.ErrorContains(t, err, `email templates do not define "alert.monitor.mail"`)
+	})
+}
+
+func TestTemplate_Render(t *testing.T) {
+	tmpl, err := NewTemplate(writeTestTemplate(t, testTemplate))
+	require.NoError(t, err)
+
+	t.Run("TemplateOfDeployment", func(t *testing.T) {
+		subject, body, err := tmpl.Render(testData(), "", "")
+		require.NoError(t, err)
+		require.Equal(t, "[FIRING:1] HighCPUUsage (host-1)", subject)
+		require.Equal(t, "<h1>[FIRING:1] HighCPUUsage (host-1)</h1><p>CPU usage &lt;above&gt; 90%</p>", body)
+	})
+
+	t.Run("CustomTemplate", func(t *testing.T) {
+		subject, body, err := tmpl.Render(testData(), `<div>{{ .Alerts.Firing | len }} firing</div>{{ template "alert.monitor.mail" . }}`, "")
+		require.NoError(t, err)
+		require.Equal(t, "[FIRING:1] HighCPUUsage (host-1)", subject)
+		require.Equal(t, "<div>1 firing</div><h1>[FIRING:1] HighCPUUsage (host-1)</h1><p>CPU usage &lt;above&gt; 90%</p>", body)
+
+		// The template of the deployment is still rendered once a custom template was.
+		_, body, err = tmpl.Render(testData(), "", "")
+		require.NoError(t, err)
+		require.Equal(t, "<h1>[FIRING:1] HighCPUUsage (host-1)</h1><p>CPU usage &lt;above&gt; 90%</p>", body)
+	})
+
+	t.Run("LocalizedTemplate", func(t *testing.T) {
+		tmpl, err := NewTemplate(writeTestTemplate(t, testTemplate+
+			`{{ define "alert.monitor.mail.de" }}<h1>{{ .Alerts.Firing | len }} aktive Alarme</h1>{{ end }}`))
+		require.NoError(t, err)
+
+		_, body, err := tmpl.Render(testData(), "", "de")
+		require.NoError(t, err)
+		require.Equal(t, "<h1>1 aktive Alarme</h1>", body)
+
+		// Languages without a localized template are rendered with the default template.
+		_, body, err = tmpl.Render(testData(), "", "fr")
+		require.NoError(t, err)
+		require.Equal(t, "<h1>[FIRING:1] HighCPUUsage (host-1)</h1><p>CPU usage &lt;above&gt; 90%</p>", body)
+
+		// The template of the tenant takes precedence over the localized template.
+		_, body, err = tmpl.Render(testData(), `<div>custom</div>`, "de")
+		require.NoError(t, err)
+		require.Equal(t, "<div>custom</div>", body)
+	})
+
+	t.Run("InvalidCustomTemplate", func(t *testing.T) {
+		_, _, err := tmpl.Render(testData(), `{{ template "undefined" . }}`, "")
+		require.ErrorContains(t, err, "failed to render email body")
+
+		_, _, err = tmpl.Render(testData(), `{{ .Alerts`, "")
+		require.ErrorContains(t, err, "failed to parse email template")
+	})
+}
+
+func TestTemplate_RenderLimits(t *testing.T) {
+	// recursive renders itself until the output or the depth of templates exceeds the limits.
+	recursive := `{{ define "loop" }}` + strings.Repeat("a", 1024) + `{{ template "loop" . }}{{ end }}{{ template "loop" . }}`
+
+	t.Run("OutputTooLarge", func(t *testing.T) {
+		tmpl, err := NewTemplate(writeTestTemplate(t, testTemplate))
+		require.NoError(t, err)
+
+		_, _, err = tmpl.Render(testData(), recursive, "")
+		require.ErrorIs(t, err, ErrRenderLimit)
+		require.ErrorContains(t, err, "output is larger than 1048576 bytes")
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		tmpl, err := NewTemplate(writeTestTemplate(t, testTemplate))
+		require.NoError(t, err)
+		tmpl.renderTimeout = time.Nanosecond
+
+		_, _, err = tmpl.Render(testData(), recursive, "")
+		require.ErrorIs(t, err, ErrRenderLimit)
+	})
+
+	t.Run("RangeOverInteger", func(t *testing.T) {
+		tmpl, err := NewTemplate(writeTestTemplate(t, testTemplate))
+		require.NoError(t, err)
+
+		for _, text := range []string{
+			`{{ range 1000000000 }}{{ end }}`,
+			`{{ $n := 1000000000 }}{{ if true }}{{ range $n }}{{ end }}{{ end }}`,
+			`{{ define "nested" }}{{ with .Alerts }}{{ range 10 }}{{ end }}{{ end }}{{ end }}`,
+		} {
+			_, _, err = tmpl.Render(testData(), text, "")
+			require.ErrorContains(t, err, "must not range over an integer", text)
+			require.ErrorContains(t, ValidateHTML(text), "must not range over an integer", text)
+		}
+
+		// Ranging over the alerts is allowed.
+		_, body, err := tmpl.Render(testData(), `{{ range $i, $a := .Alerts }}{{ $i }}{{ end }}`, "")
+		require.NoError(t, err)
+		require.Equal(t, "01", body)
+	})
+}
+
+func TestTemplateFuncs(t *testing.T) {
+	// No function beyond those of alertmanager is given to the templates of tenants.
+	names := make([]string, 0, len(templateFuncs))
+	for name := range templateFuncs {
+		names = append(names, name)
+	}
+	require.ElementsMatch(t, []string{"toUpper", "toLower", "title", "trimSpace", "join", "match", "safeHtml", "reReplaceAll",
+		"stringSlice"}, names)
+}
+
+func TestValidateHTML(t *testing.T) {
+	require.NoError(t, ValidateHTML(`<div>{{ .Alerts.Firing | len }} firing</div>{{ template "alert.monitor.mail" . }}`))
+	require.ErrorContains(t, ValidateHTML(`{{ .Alerts`), "failed to parse email template")
+	require.ErrorContains(t, ValidateHTML(`{{ undefinedFunc }}`), "failed to parse email template")
+}
+
+func TestKV(t *testing.T) {
+	kv := KV{"severity": "critical", "alertname": "HighCPUUsage", "host_uuid": "host-1"}
+
+	require.Equal(t, []string{"alertname", "host_uuid", "severity"}, kv.Names())
+	require.Equal(t, []string{"HighCPUUsage", "host-1", "critical"}, kv.Values())
+	require.Equal(t, KV{"host_uuid": "host-1"}, kv.Remove([]string{"alertname", "severity"}))
+}
+
+func TestAlerts(t *testing.T) {
+	alerts := testData().Alerts
+
+	require.Equal(t, []Alert{alerts[0]}, alerts.Firing())
+	require.Equal(t, []Alert{alerts[1]}, alerts.Resolved())
+}
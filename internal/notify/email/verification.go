@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package email
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/mail"
+	"time"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+)
+
+const verificationSubject = "Confirm your email address for alert notifications"
+
+// verificationTemplate is the HTML body of the email asking a recipient to confirm their email address.
+var verificationTemplate = template.Must(template.New("verification").Parse(`<p>Hello {{ .Name }},</p>
+<p>Your email address was added as a recipient of alert notifications. Notifications are sent to it once it is confirmed.</p>
+<p><a href="{{ .Link }}">Confirm your email address</a></p>
+<p>The link expires on {{ .Expiry.Format "2006-01-02 15:04 MST" }}. You may ignore this email if you do not expect alert notifications.</p>
+`))
+
+// Verifier sends the emails asking new recipients of receivers to confirm their email address.
+type Verifier struct {
+	mailer *Mailer
+}
+
+// NewVerifier creates a new Verifier, loading the sender and the mail server from the environment.
+func NewVerifier(cfg config.Config) (*Verifier, error) {
+	mailer, err := NewMailer(cfg, cfg.EmailVerification.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	return &Verifier{mailer: mailer}, nil
+}
+
+// SendVerification sends the email asking the given recipient to confirm their email address by following the given link,
+// which expires at the given time.
+func (v *Verifier) SendVerification(ctx context.Context, to *mail.Address, link string, expiry time.Time) error {
+	if to == nil {
+		return errors.New("no recipient to verify")
+	}
+
+	name := to.Name
+	if name == "" {
+		name = to.Address
+	}
+
+	var body bytes.Buffer
+	if err := verificationTemplate.Execute(&body, struct {
+		Name   string
+		Link   string
+		Expiry time.Time
+	}{Name: name, Link: link, Expiry: expiry.UTC()}); err != nil {
+		return fmt.Errorf("failed to render verification email: %w", err)
+	}
+
+	if err := v.mailer.Send(ctx, to, verificationSubject, body.String()); err != nil {
+		return fmt.Errorf("failed to send verification email: %w", err)
+	}
+	return nil
+}
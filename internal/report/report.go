@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Package report renders the weekly reports of the alert volume of tenants, as HTML for emails and as CSV for spreadsheets.
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"strconv"
+	"time"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+// dateFormat is the format of the bounds of the period of reports.
+const dateFormat = "2006-01-02"
+
+// htmlTemplate is the HTML body of reports.
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"date": func(t time.Time) string { return t.Format(dateFormat) },
+}).Parse(`<h2>Alert report of project {{ .TenantID }}</h2>
+<p>From {{ date .PeriodStart }} to {{ date .LastDay }}</p>
+<table>
+<tr><th align="left">Notifications sent</th><td>{{ .SentNotifications }}</td></tr>
+<tr><th align="left">Notifications failed</th><td>{{ .FailedNotifications }}</td></tr>
+<tr><th align="left">Alerts acknowledged</th><td>{{ .AcknowledgedAlerts }}</td></tr>
+<tr><th align="left">Mean time to acknowledge</th><td>{{ if .AcknowledgedAlerts }}{{ .MeanTimeToAcknowledge }}{{ else }}-{{ end }}</td></tr>
+</table>
+<h3>Noisiest alerts</h3>
+{{- if .TopAlerts }}
+<table>
+<tr><th align="left">Alert group</th><th align="left">Notifications</th></tr>
+{{- range .TopAlerts }}
+<tr><td>{{ .Group }}</td><td>{{ .Notifications }}</td></tr>
+{{- end }}
+</table>
+{{- else }}
+<p>No alerts were notified.</p>
+{{- end }}
+`))
+
+// Report is the report of the alert volume of a tenant over a week.
+type Report struct {
+	ID           int64
+	TenantID     string
+	PeriodStart  time.Time
+	PeriodEnd    time.Time
+	CreationDate time.Time
+	models.AlertVolume
+}
+
+// New decodes the given stored report.
+func New(r models.AlertReport) (*Report, error) {
+	report := &Report{
+		ID:           r.ID,
+		TenantID:     r.TenantID,
+		PeriodStart:  r.PeriodStart.UTC(),
+		PeriodEnd:    r.PeriodEnd.UTC(),
+		CreationDate: r.CreationDate.UTC(),
+	}
+	if err := json.Unmarshal([]byte(r.Content), &report.AlertVolume); err != nil {
+		return nil, fmt.Errorf("failed to decode report %d of tenant %q: %w", r.ID, r.TenantID, err)
+	}
+	return report, nil
+}
+
+// LastDay returns the last day of the period of the report.
+func (r *Report) LastDay() time.Time {
+	return r.PeriodEnd.AddDate(0, 0, -1)
+}
+
+// Subject returns the subject of the email of the report.
+func (r *Report) Subject() string {
+	return fmt.Sprintf("Alert report of project %s from %s to %s", r.TenantID, r.PeriodStart.Format(dateFormat),
+		r.LastDay().Format(dateFormat))
+}
+
+// LastWeek returns the bounds of the last full week before the given time, weeks starting on Monday at midnight UTC.
+func LastWeek(now time.Time) (start, end time.Time) {
+	now = now.UTC()
+	daysSinceMonday := (int(now.Weekday()) + 6) % 7
+	end = time.Date(now.Year(), now.Month(), now.Day()-daysSinceMonday, 0, 0, 0, 0, time.UTC)
+	return end.AddDate(0, 0, -7), end
+}
+
+// HTML renders the report as an HTML document.
+func (r *Report) HTML() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := htmlTemplate.Execute(&buf, r); err != nil {
+		return nil, fmt.Errorf("failed to render report %d as HTML: %w", r.ID, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// CSV renders the report as CSV, with a row per figure of the summary followed by a row per noisiest alert group.
+func (r *Report) CSV() ([]byte, error) {
+	rows := [][]string{
+		{"section", "name", "value"},
+		{"period", "start", r.PeriodStart.Format(time.RFC3339)},
+		{"period", "end", r.PeriodEnd.Format(time.RFC3339)},
+		{"summary", "sentNotifications", strconv.FormatInt(r.SentNotifications, 10)},
+		{"summary", "failedNotifications", strconv.FormatInt(r.FailedNotifications, 10)},
+		{"summary", "acknowledgedAlerts", strconv.FormatInt(r.AcknowledgedAlerts, 10)},
+		{"summary", "meanTimeToAcknowledgeSeconds", strconv.FormatFloat(r.MeanTimeToAcknowledge.Seconds(), 'f', -1, 64)},
+	}
+	for _, a := range r.TopAlerts {
+		rows = append(rows, []string{"topAlert", a.Group, strconv.FormatInt(a.Notifications, 10)})
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.WriteAll(rows); err != nil {
+		return nil, fmt.Errorf("failed to render report %d as CSV: %w", r.ID, err)
+	}
+	return buf.Bytes(), nil
+}
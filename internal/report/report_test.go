@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+func TestLastWeek(t *testing.T) {
+	monday := time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)
+	for _, now := range []time.Time{
+		monday,
+		monday.Add(36 * time.Hour),
+		monday.AddDate(0, 0, 7).Add(-time.Nanosecond),
+		// Sunday 23:00 UTC-2 is already Monday in UTC.
+		time.Date(2026, 10, 11, 23, 0, 0, 0, time.FixedZone("UTC-2", -2*3600)),
+	} {
+		start, end := LastWeek(now)
+		require.Equal(t, monday.AddDate(0, 0, -7), start, now)
+		require.Equal(t, monday, end, now)
+	}
+}
+
+func TestReport(t *testing.T) {
+	rpt, err := New(models.AlertReport{
+		ID:          7,
+		TenantID:    "edgenode",
+		PeriodStart: time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC),
+		PeriodEnd:   time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC),
+		Content: `{"sentNotifications":5,"failedNotifications":1,"topAlerts":[{"group":"{alertname=\"HighCPU\"}","notifications":3}],` +
+			`"acknowledgedAlerts":2,"meanTimeToAcknowledge":1200000000000}`,
+	})
+	require.NoError(t, err)
+	require.Equal(t, models.AlertVolume{
+		SentNotifications:     5,
+		FailedNotifications:   1,
+		TopAlerts:             []models.NoisyAlert{{Group: `{alertname="HighCPU"}`, Notifications: 3}},
+		AcknowledgedAlerts:    2,
+		MeanTimeToAcknowledge: 20 * time.Minute,
+	}, rpt.AlertVolume)
+	require.Equal(t, "Alert report of project edgenode from 2026-10-05 to 2026-10-11", rpt.Subject())
+
+	t.Run("HTML", func(t *testing.T) {
+		body, err := rpt.HTML()
+		require.NoError(t, err)
+		require.Contains(t, string(body), "<p>From 2026-10-05 to 2026-10-11</p>")
+		require.Contains(t, string(body), "<tr><th align=\"left\">Mean time to acknowledge</th><td>20m0s</td></tr>")
+		// Labels of alert groups are escaped.
+		require.Contains(t, string(body), "<tr><td>{alertname=&#34;HighCPU&#34;}</td><td>3</td></tr>")
+	})
+
+	t.Run("CSV", func(t *testing.T) {
+		body, err := rpt.CSV()
+		require.NoError(t, err)
+		require.Equal(t, `section,name,value
+period,start,2026-10-05T00:00:00Z
+period,end,2026-10-12T00:00:00Z
+summary,sentNotifications,5
+summary,failedNotifications,1
+summary,acknowledgedAlerts,2
+summary,meanTimeToAcknowledgeSeconds,1200
+topAlert,"{alertname=""HighCPU""}",3
+`, string(body))
+	})
+
+	t.Run("Invalid content", func(t *testing.T) {
+		_, err := New(models.AlertReport{ID: 8, TenantID: "edgenode", Content: "not json"})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+)
+
+// ErrExpressionTooExpensive is returned when the estimated cost of an expression exceeds the configured limits.
+var ErrExpressionTooExpensive = errors.New("expression too expensive")
+
+// Cost is the statically estimated cost of evaluating an expression. Selectors is the number of series selectors, Range is the
+// longest range of data looked back over, including the ranges of enclosing subqueries, and RegexMatchers is the number of
+// regular expression label matchers.
+type Cost struct {
+	Selectors     int
+	Range         time.Duration
+	RegexMatchers int
+}
+
+// EstimateCost estimates the cost of evaluating the given rendered expression from its syntax tree.
+func EstimateCost(expr string) (Cost, error) {
+	promParser := parser.NewParser(parser.Options{})
+	node, err := promParser.ParseExpr(expr)
+	if err != nil {
+		return Cost{}, fmt.Errorf("promql parser failed to parse: %w", err)
+	}
+
+	var cost Cost
+	parser.Inspect(node, func(n parser.Node, path []parser.Node) error {
+		var rng time.Duration
+		switch n := n.(type) {
+		case *parser.VectorSelector:
+			cost.Selectors++
+			for _, m := range n.LabelMatchers {
+				if m.Type == labels.MatchRegexp || m.Type == labels.MatchNotRegexp {
+					cost.RegexMatchers++
+				}
+			}
+			return nil
+		case *parser.MatrixSelector:
+			rng = n.Range
+		case *parser.SubqueryExpr:
+			rng = n.Range
+		default:
+			return nil
+		}
+
+		for _, p := range path {
+			if s, ok := p.(*parser.SubqueryExpr); ok {
+				rng += s.Range
+			}
+		}
+		cost.Range = max(cost.Range, rng)
+		return nil
+	})
+	return cost, nil
+}
+
+// Check verifies that the cost does not exceed the given limits. An error wrapping ErrExpressionTooExpensive and listing the
+// exceeded limits is returned otherwise.
+func (c Cost) Check(limits config.ExpressionCostLimits) error {
+	var exceeded []string
+	if limits.MaxSelectors > 0 && c.Selectors > limits.MaxSelectors {
+		exceeded = append(exceeded, fmt.Sprintf("%d series selectors exceed the limit of %d", c.Selectors, limits.MaxSelectors))
+	}
+	if limits.MaxRange > 0 && c.Range > limits.MaxRange {
+		exceeded = append(exceeded, fmt.Sprintf("range of %v exceeds the limit of %v", c.Range, limits.MaxRange))
+	}
+	if limits.MaxRegexMatchers > 0 && c.RegexMatchers > limits.MaxRegexMatchers {
+		exceeded = append(exceeded, fmt.Sprintf("%d regex matchers exceed the limit of %d", c.RegexMatchers, limits.MaxRegexMatchers))
+	}
+
+	if len(exceeded) != 0 {
+		return fmt.Errorf("%s: %w", strings.Join(exceeded, ", "), ErrExpressionTooExpensive)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package rules
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+)
+
+func TestEstimateCost(t *testing.T) {
+	tests := map[string]struct {
+		expr     string
+		expected Cost
+	}{
+		"Instant selector": {
+			expr:     `edge_host_status{status="HOST_STATUS_ERROR"} == 1`,
+			expected: Cost{Selectors: 1},
+		},
+		"Range and regex matchers": {
+			expr:     `avg_over_time(mem_used_percent{hostGuid=~"host-.*",projectId!~"test"}[5m]) >= 80`,
+			expected: Cost{Selectors: 1, Range: 5 * time.Minute, RegexMatchers: 2},
+		},
+		"Several selectors": {
+			expr:     `sum(rate(http_errors_total[10m])) / sum(rate(http_requests_total[1h])) > 0.05`,
+			expected: Cost{Selectors: 2, Range: time.Hour},
+		},
+		"Range of subquery added to enclosed ranges": {
+			expr:     `max_over_time(rate(cpu_seconds_total{mode=~"user|system"}[5m])[7d:5m]) > 0.9`,
+			expected: Cost{Selectors: 1, Range: 7*24*time.Hour + 5*time.Minute, RegexMatchers: 1},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cost, err := EstimateCost(test.expr)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, cost)
+		})
+	}
+
+	t.Run("Invalid expression", func(t *testing.T) {
+		_, err := EstimateCost(`cpu_usage >`)
+		require.ErrorContains(t, err, "promql parser failed to parse")
+	})
+}
+
+func TestCost_Check(t *testing.T) {
+	cost := Cost{Selectors: 3, Range: 48 * time.Hour, RegexMatchers: 1}
+
+	require.NoError(t, cost.Check(config.ExpressionCostLimits{}))
+	require.NoError(t, cost.Check(config.ExpressionCostLimits{MaxSelectors: 3, MaxRange: 48 * time.Hour, MaxRegexMatchers: 1}))
+
+	err := cost.Check(config.ExpressionCostLimits{MaxSelectors: 2, MaxRange: 24 * time.Hour, MaxRegexMatchers: 1})
+	require.ErrorIs(t, err, ErrExpressionTooExpensive)
+	require.ErrorContains(t, err, "3 series selectors exceed the limit of 2, range of 48h0m0s exceeds the limit of 24h0m0s")
+}
@@ -0,0 +1,219 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package rules
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// TemplateFunction documents a function available to the expression templates of alert definitions, in addition to the
+// builtin functions of Go templates.
+type TemplateFunction struct {
+	Name        string
+	Signature   string
+	Description string
+	Example     string
+	fn          any
+}
+
+// unit is a unit values can be converted from and to, given by its factor to the base unit of its dimension.
+type unit struct {
+	dimension string
+	factor    float64
+}
+
+var units = map[string]unit{
+	"ns":      {"time", float64(time.Nanosecond) / float64(time.Second)},
+	"us":      {"time", float64(time.Microsecond) / float64(time.Second)},
+	"ms":      {"time", float64(time.Millisecond) / float64(time.Second)},
+	"s":       {"time", 1},
+	"m":       {"time", 60},
+	"h":       {"time", 3600},
+	"d":       {"time", 86400},
+	"B":       {"bytes", 1},
+	"KB":      {"bytes", 1e3},
+	"MB":      {"bytes", 1e6},
+	"GB":      {"bytes", 1e9},
+	"TB":      {"bytes", 1e12},
+	"KiB":     {"bytes", 1 << 10},
+	"MiB":     {"bytes", 1 << 20},
+	"GiB":     {"bytes", 1 << 30},
+	"TiB":     {"bytes", 1 << 40},
+	"percent": {"ratio", 0.01},
+	"ratio":   {"ratio", 1},
+}
+
+var templateFunctions = []TemplateFunction{
+	{
+		Name:        "convert",
+		Signature:   "convert VALUE FROM TO",
+		Description: "Converts a value between units of the same dimension: ns, us, ms, s, m, h and d for time, B, KB, MB, GB, TB, KiB, MiB, GiB and TiB for bytes, and percent and ratio for ratios.",
+		Example:     `node_memory_MemAvailable_bytes < [[ convert .Threshold "MiB" "B" ]]`,
+		fn:          convert,
+	},
+	{
+		Name:        "humanizeDuration",
+		Signature:   "humanizeDuration VALUE",
+		Description: "Formats a duration, given as a number of seconds or as a duration such as 90s, as a PromQL duration such as 1m30s.",
+		Example:     `rate(http_requests_total[ [[ humanizeDuration .Duration ]] ]) > [[ .Threshold ]]`,
+		fn:          humanizeDuration,
+	},
+	{
+		Name:        "ceil",
+		Signature:   "ceil VALUE",
+		Description: "Rounds a number up to the nearest integer.",
+		Example:     `memory_used_megabytes > [[ ceil (convert .Threshold "GiB" "MB") ]]`,
+		fn:          ceil,
+	},
+	{
+		Name:        "floor",
+		Signature:   "floor VALUE",
+		Description: "Rounds a number down to the nearest integer.",
+		Example:     `disk_free_gibibytes < [[ floor (convert .Threshold "GB" "GiB") ]]`,
+		fn:          floor,
+	},
+	{
+		Name:        "sanitizeLabel",
+		Signature:   "sanitizeLabel NAME",
+		Description: "Turns a string into a valid label name, replacing invalid characters with underscores.",
+		Example:     `sum by ([[ sanitizeLabel "host-name" ]]) (up) < [[ .Threshold ]]`,
+		fn:          sanitizeLabel,
+	},
+}
+
+// TemplateFunctions returns the functions available to the expression templates of alert definitions.
+func TemplateFunctions() []TemplateFunction {
+	functions := make([]TemplateFunction, len(templateFunctions))
+	copy(functions, templateFunctions)
+	return functions
+}
+
+// templateFuncMap returns the function map of the expression templates of alert definitions.
+func templateFuncMap() template.FuncMap {
+	funcs := make(template.FuncMap, len(templateFunctions))
+	for _, f := range templateFunctions {
+		funcs[f.Name] = f.fn
+	}
+	return funcs
+}
+
+// toNumber returns the number given as a number or as its string representation, such as the threshold of templates.
+func toNumber(value any) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case int:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case string:
+		n, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid number %q", v)
+		}
+		return n, nil
+	default:
+		return 0, fmt.Errorf("invalid number %v of type %T", value, value)
+	}
+}
+
+// formatNumber formats a number as a PromQL number literal, without exponent.
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+func convert(value any, from, to string) (string, error) {
+	n, err := toNumber(value)
+	if err != nil {
+		return "", err
+	}
+	fromUnit, ok := units[from]
+	if !ok {
+		return "", fmt.Errorf("unknown unit %q", from)
+	}
+	toUnit, ok := units[to]
+	if !ok {
+		return "", fmt.Errorf("unknown unit %q", to)
+	}
+	if fromUnit.dimension != toUnit.dimension {
+		return "", fmt.Errorf("cannot convert %s to %s", from, to)
+	}
+	return formatNumber(n * fromUnit.factor / toUnit.factor), nil
+}
+
+func humanizeDuration(value any) (string, error) {
+	var d time.Duration
+	if s, ok := value.(string); ok {
+		if parsed, err := time.ParseDuration(s); err == nil {
+			d = parsed
+		}
+	}
+	if d == 0 {
+		seconds, err := toNumber(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid duration %v", value)
+		}
+		d = time.Duration(seconds * float64(time.Second))
+	}
+	if d < 0 {
+		return "", fmt.Errorf("negative duration %v", value)
+	}
+	if d < time.Second {
+		return fmt.Sprintf("%dms", d.Milliseconds()), nil
+	}
+
+	var builder strings.Builder
+	for _, u := range []struct {
+		suffix string
+		size   time.Duration
+	}{{"d", 24 * time.Hour}, {"h", time.Hour}, {"m", time.Minute}, {"s", time.Second}, {"ms", time.Millisecond}} {
+		if n := d / u.size; n > 0 {
+			fmt.Fprintf(&builder, "%d%s", n, u.suffix)
+			d -= n * u.size
+		}
+	}
+	return builder.String(), nil
+}
+
+func ceil(value any) (string, error) {
+	n, err := toNumber(value)
+	if err != nil {
+		return "", err
+	}
+	return formatNumber(math.Ceil(n)), nil
+}
+
+func floor(value any) (string, error) {
+	n, err := toNumber(value)
+	if err != nil {
+		return "", err
+	}
+	return formatNumber(math.Floor(n)), nil
+}
+
+func sanitizeLabel(name string) string {
+	var builder strings.Builder
+	for i, r := range name {
+		switch {
+		case r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z'):
+			builder.WriteRune(r)
+		case r >= '0' && r <= '9':
+			if i == 0 {
+				builder.WriteRune('_')
+			}
+			builder.WriteRune(r)
+		default:
+			builder.WriteRune('_')
+		}
+	}
+	if builder.Len() == 0 {
+		return "_"
+	}
+	return builder.String()
+}
@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package rules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateFunctions(t *testing.T) {
+	tests := map[string]struct {
+		expression    string
+		expected      string
+		expectedError string
+	}{
+		"Convert units": {
+			expression: `mem_available_bytes < [[ convert .Threshold "MiB" "B" ]]`,
+			expected:   `mem_available_bytes < 89128960`,
+		},
+		"Convert percent to ratio": {
+			expression: `cpu_usage_ratio > [[ convert .Threshold "percent" "ratio" ]]`,
+			expected:   `cpu_usage_ratio > 0.85`,
+		},
+		"Convert between dimensions": {
+			expression:    `up < [[ convert .Threshold "s" "MB" ]]`,
+			expectedError: "cannot convert s to MB",
+		},
+		"Convert unknown unit": {
+			expression:    `up < [[ convert .Threshold "parsec" "m" ]]`,
+			expectedError: `unknown unit "parsec"`,
+		},
+		"Humanize duration": {
+			expression: `rate(http_requests_total[ [[ humanizeDuration .Duration ]] ]) > 1`,
+			expected:   `rate(http_requests_total[ 1h30m ]) > 1`,
+		},
+		"Humanize seconds": {
+			expression: `rate(http_requests_total[ [[ humanizeDuration 172830 ]] ]) > 1`,
+			expected:   `rate(http_requests_total[ 2d30s ]) > 1`,
+		},
+		"Humanize invalid duration": {
+			expression:    `rate(http_requests_total[ [[ humanizeDuration "soon" ]] ]) > 1`,
+			expectedError: "invalid duration soon",
+		},
+		"Round up and down": {
+			expression: `mem_used_megabytes > [[ ceil (convert .Threshold "GiB" "MB") ]] and disk_free_gibibytes < [[ floor (convert .Threshold "GB" "GiB") ]]`,
+			expected:   `mem_used_megabytes > 91269 and disk_free_gibibytes < 79`,
+		},
+		"Round invalid number": {
+			expression:    `up > [[ ceil .Duration ]]`,
+			expectedError: `invalid number "1h30m"`,
+		},
+		"Sanitize label": {
+			expression: `sum by ([[ sanitizeLabel "host-name.domain" ]], [[ sanitizeLabel "1st" ]]) (up) < 1`,
+			expected:   `sum by (host_name_domain, _1st) (up) < 1`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := ParseExpression(TemplateData{Threshold: "85", Duration: "1h30m"}, test.expression)
+			if test.expectedError != "" {
+				require.ErrorContains(t, err, test.expectedError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected, result)
+			}
+		})
+	}
+}
+
+func TestTemplateFunctions_Examples(t *testing.T) {
+	for _, f := range TemplateFunctions() {
+		_, err := ParseExpression(TemplateData{Threshold: "85", Duration: "5m"}, f.Example)
+		require.NoError(t, err, "example of %s", f.Name)
+	}
+}
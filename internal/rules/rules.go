@@ -5,11 +5,12 @@ package rules
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
-	"html/template"
 	"os"
 	"strconv"
 	"strings"
+	"text/template"
 	"time"
 
 	"github.com/prometheus/prometheus/promql/parser"
@@ -101,13 +102,14 @@ type TemplateData struct {
 	Duration  string
 }
 
-// ParseExpression parses duration and threshold taken from `TemplateData` into the expression template.
+// ParseExpression parses duration and threshold taken from `TemplateData` into the expression template. The functions given
+// by TemplateFunctions are available to the template.
 func ParseExpression(data TemplateData, expr string) (string, error) {
 	// Replace characters to have template
 	expr = strings.ReplaceAll(expr, "[[", "{{")
 	expr = strings.ReplaceAll(expr, "]]", "}}")
 
-	tmpl, err := template.New("Expr").Parse(expr)
+	tmpl, err := template.New("Expr").Funcs(templateFuncMap()).Parse(expr)
 	if err != nil {
 		return "", fmt.Errorf("failed to parse template: %w", err)
 	}
@@ -126,6 +128,39 @@ func ParseExpression(data TemplateData, expr string) (string, error) {
 	return tpl.String(), nil
 }
 
+// ErrNoThresholdComparison is returned when an expression does not compare a metric against an upper threshold.
+var ErrNoThresholdComparison = errors.New("no threshold comparison found")
+
+// ThresholdOperand returns the operand compared against the threshold in the given rendered expression, that is, the left-hand
+// side of the first '>' or '>=' comparison with a number. It allows querying the statistics of the metric the threshold applies to.
+func ThresholdOperand(expr string) (string, error) {
+	promParser := parser.NewParser(parser.Options{})
+	node, err := promParser.ParseExpr(expr)
+	if err != nil {
+		return "", fmt.Errorf("promql parser failed to parse: %w", err)
+	}
+
+	var operand parser.Expr
+	parser.Inspect(node, func(n parser.Node, _ []parser.Node) error {
+		b, ok := n.(*parser.BinaryExpr)
+		if operand != nil || !ok || (b.Op != parser.GTR && b.Op != parser.GTE) {
+			return nil
+		}
+		rhs := b.RHS
+		for p, ok := rhs.(*parser.ParenExpr); ok; p, ok = rhs.(*parser.ParenExpr) {
+			rhs = p.Expr
+		}
+		if _, ok := rhs.(*parser.NumberLiteral); ok {
+			operand = b.LHS
+		}
+		return nil
+	})
+	if operand == nil {
+		return "", fmt.Errorf("expression %q: %w", expr, ErrNoThresholdComparison)
+	}
+	return operand.String(), nil
+}
+
 // UpdateTemplateWithValues updates the Template part of Alert Definition,
 // with new duration or threshold, if given.
 func UpdateTemplateWithValues(rule string, duration, threshold *int64) (string, error) {
@@ -184,3 +184,40 @@ labels:
 		})
 	}
 }
+
+func TestThresholdOperand(t *testing.T) {
+	tests := map[string]struct {
+		expression    string
+		expected      string
+		expectedError error
+	}{
+		"Top level comparison": {
+			expression: `avg_over_time(mem_used_percent{}[5m]) >= 80`,
+			expected:   `avg_over_time(mem_used_percent[5m])`,
+		},
+		"Nested comparison": {
+			expression: `count by (host) (avg_over_time(disk_used_percent{}[5m]) > (90))`,
+			expected:   `avg_over_time(disk_used_percent[5m])`,
+		},
+		"Equality comparison": {
+			expression:    `edge_host_status{status="Error"} == 1`,
+			expectedError: ErrNoThresholdComparison,
+		},
+		"Invalid expression": {
+			expression:    `avg_over_time(`,
+			expectedError: errors.New("promql parser failed to parse"),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			operand, err := ThresholdOperand(tc.expression)
+			if tc.expectedError != nil {
+				require.ErrorContains(t, err, tc.expectedError.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, operand)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package snapshot
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// SignatureSuffix is appended to the key of a snapshot to get the key of its detached signature.
+const SignatureSuffix = ".sig"
+
+var ErrInvalidSignature = errors.New("invalid snapshot signature")
+
+// Verifier verifies the detached signatures of snapshots against trusted public keys. Signatures are those written by
+// cosign sign-blob: the base64-encoded ECDSA, RSA PKCS #1 v1.5 or Ed25519 signature of the snapshot, hashed with SHA-256
+// except for Ed25519.
+type Verifier struct {
+	keys []crypto.PublicKey
+}
+
+// NewVerifier creates a new Verifier trusting the PEM-encoded public keys of the files matching the given pattern, as
+// written by cosign generate-key-pair or openssl pkey -pubout.
+func NewVerifier(pattern string) (*Verifier, error) {
+	files, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern of trusted keys %q: %w", pattern, err)
+	}
+
+	v := &Verifier{}
+	for _, file := range files {
+		// Directories are skipped, such as those of the atomic updates of Kubernetes volumes.
+		if info, err := os.Stat(file); err == nil && info.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read trusted key %q: %w", file, err)
+		}
+		block, _ := pem.Decode(data)
+		if block == nil {
+			return nil, fmt.Errorf("trusted key %q is not PEM-encoded", file)
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse trusted key %q: %w", file, err)
+		}
+		switch key.(type) {
+		case *ecdsa.PublicKey, *rsa.PublicKey, ed25519.PublicKey:
+		default:
+			return nil, fmt.Errorf("unsupported type of trusted key %q: %T", file, key)
+		}
+		v.keys = append(v.keys, key)
+	}
+	if len(v.keys) == 0 {
+		return nil, fmt.Errorf("no trusted keys match %q", pattern)
+	}
+	return v, nil
+}
+
+// Verify checks that the given signature of a snapshot was made by one of the trusted keys, returning ErrInvalidSignature
+// otherwise.
+func (v *Verifier) Verify(data, signature []byte) error {
+	sig, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(signature)))
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+
+	digest := sha256.Sum256(data)
+	for _, key := range v.keys {
+		var ok bool
+		switch key := key.(type) {
+		case *ecdsa.PublicKey:
+			ok = ecdsa.VerifyASN1(key, digest[:], sig)
+		case *rsa.PublicKey:
+			ok = rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig) == nil
+		case ed25519.PublicKey:
+			ok = ed25519.Verify(key, data, sig)
+		}
+		if ok {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: not signed by a trusted key", ErrInvalidSignature)
+}
@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package snapshot
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// writePublicKey writes the PEM-encoded public key to the given file of dir.
+func writePublicKey(t *testing.T, dir, name string, key crypto.PublicKey) {
+	t.Helper()
+
+	der, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600))
+}
+
+func TestVerifier(t *testing.T) {
+	data := []byte(`{"formatVersion":1}`)
+	digest := sha256.Sum256(data)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	edPub, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	untrusted, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	writePublicKey(t, dir, "ecdsa.pub", &ecKey.PublicKey)
+	writePublicKey(t, dir, "rsa.pub", &rsaKey.PublicKey)
+	writePublicKey(t, dir, "ed25519.pub", edPub)
+
+	v, err := NewVerifier(filepath.Join(dir, "*.pub"))
+	require.NoError(t, err)
+
+	sign := func(sig []byte, err error) []byte {
+		require.NoError(t, err)
+		return []byte(base64.StdEncoding.EncodeToString(sig) + "\n")
+	}
+
+	t.Run("Signed by a trusted key", func(t *testing.T) {
+		for name, sig := range map[string][]byte{
+			"ECDSA":   sign(ecdsa.SignASN1(rand.Reader, ecKey, digest[:])),
+			"RSA":     sign(rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, digest[:])),
+			"Ed25519": sign(ed25519.Sign(edKey, data), nil),
+		} {
+			t.Run(name, func(t *testing.T) {
+				require.NoError(t, v.Verify(data, sig))
+			})
+		}
+	})
+
+	t.Run("Invalid signature", func(t *testing.T) {
+		for name, sig := range map[string][]byte{
+			"Untrusted key":     sign(ecdsa.SignASN1(rand.Reader, untrusted, digest[:])),
+			"Other snapshot":    sign(ed25519.Sign(edKey, []byte(`{}`)), nil),
+			"Not base64":        []byte("not a signature"),
+			"Missing signature": nil,
+		} {
+			t.Run(name, func(t *testing.T) {
+				require.ErrorIs(t, v.Verify(data, sig), ErrInvalidSignature)
+			})
+		}
+	})
+
+	t.Run("Directories are skipped", func(t *testing.T) {
+		require.NoError(t, os.Mkdir(filepath.Join(dir, "..data"), 0o700))
+		_, err := NewVerifier(filepath.Join(dir, "*"))
+		require.NoError(t, err)
+	})
+
+	t.Run("Invalid trusted keys", func(t *testing.T) {
+		_, err := NewVerifier(filepath.Join(dir, "*.pem"))
+		require.ErrorContains(t, err, "no trusted keys match")
+
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "invalid.pem"), []byte("not a key"), 0o600))
+		_, err = NewVerifier(filepath.Join(dir, "*.pem"))
+		require.ErrorContains(t, err, "is not PEM-encoded")
+	})
+}
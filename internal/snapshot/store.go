@@ -0,0 +1,203 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Package snapshot stores snapshots of the alerting configuration in an S3-compatible object store, so that the alerting
+// database can be restored after a disaster.
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+)
+
+const (
+	keyPrefix     = "snapshot-"
+	keySuffix     = ".json"
+	keyTimeFormat = "20060102T150405Z"
+)
+
+var ErrNoSnapshot = errors.New("no snapshot found")
+
+// Key returns the key of the snapshot taken at the given time, truncated to the given interval so that replicas taking a
+// snapshot in the same interval overwrite the same object. Keys sort in the order snapshots were taken.
+func Key(prefix string, t time.Time, interval time.Duration) string {
+	if interval > 0 {
+		t = t.Truncate(interval)
+	}
+	return prefix + keyPrefix + t.UTC().Format(keyTimeFormat) + keySuffix
+}
+
+// Store reads and writes snapshots in a bucket of an S3-compatible object store, addressed path-style as Endpoint/Bucket/key.
+// Requests are signed with the access key given by the SNAPSHOT_ACCESS_KEY_ID and SNAPSHOT_SECRET_ACCESS_KEY environment
+// variables.
+type Store struct {
+	client      *http.Client
+	signer      *v4.Signer
+	credentials aws.Credentials
+	endpoint    string
+	bucket      string
+	prefix      string
+	region      string
+}
+
+// New creates a new Store from the snapshot configuration.
+func New(conf config.SnapshotConfig) *Store {
+	region := conf.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	return &Store{
+		client: &http.Client{Timeout: conf.Timeout},
+		signer: v4.NewSigner(),
+		credentials: aws.Credentials{
+			AccessKeyID:     os.Getenv("SNAPSHOT_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("SNAPSHOT_SECRET_ACCESS_KEY"),
+		},
+		endpoint: strings.TrimSuffix(conf.Endpoint, "/"),
+		bucket:   conf.Bucket,
+		prefix:   conf.Prefix,
+		region:   region,
+	}
+}
+
+// Prefix returns the prefix of the keys of the snapshots in the bucket.
+func (s *Store) Prefix() string {
+	return s.prefix
+}
+
+// Put writes a snapshot under the given key, replacing any snapshot with the same key.
+func (s *Store) Put(ctx context.Context, key string, data []byte) error {
+	resp, err := s.do(ctx, http.MethodPut, key, nil, data)
+	if err != nil {
+		return fmt.Errorf("failed to put snapshot %q: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to put snapshot %q: got unexpected status code: %d", key, resp.StatusCode)
+	}
+	return nil
+}
+
+// Get reads the snapshot with the given key.
+func (s *Store) Get(ctx context.Context, key string) ([]byte, error) {
+	resp, err := s.do(ctx, http.MethodGet, key, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get snapshot %q: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("failed to get snapshot %q: %w", key, ErrNoSnapshot)
+	default:
+		return nil, fmt.Errorf("failed to get snapshot %q: got unexpected status code: %d", key, resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot %q: %w", key, err)
+	}
+	return data, nil
+}
+
+type listBucketResult struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// Latest returns the key of the latest snapshot, or ErrNoSnapshot if there is none.
+func (s *Store) Latest(ctx context.Context) (string, error) {
+	var keys []string
+	query := url.Values{"list-type": {"2"}, "prefix": {s.prefix + keyPrefix}}
+	for {
+		page, err := s.list(ctx, query)
+		if err != nil {
+			return "", err
+		}
+		for _, obj := range page.Contents {
+			if strings.HasSuffix(obj.Key, keySuffix) {
+				keys = append(keys, obj.Key)
+			}
+		}
+		if !page.IsTruncated || page.NextContinuationToken == "" {
+			break
+		}
+		query.Set("continuation-token", page.NextContinuationToken)
+	}
+
+	if len(keys) == 0 {
+		return "", ErrNoSnapshot
+	}
+	return slices.Max(keys), nil
+}
+
+// list gets a page of the objects of the bucket matching the given query.
+func (s *Store) list(ctx context.Context, query url.Values) (*listBucketResult, error) {
+	resp, err := s.do(ctx, http.MethodGet, "", query, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to list snapshots: got unexpected status code: %d", resp.StatusCode)
+	}
+
+	var page listBucketResult
+	if err := xml.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, fmt.Errorf("failed to parse list of snapshots: %w", err)
+	}
+	return &page, nil
+}
+
+// do sends a request signed with AWS signature version 4 for the given key of the bucket, or for the bucket itself if the
+// key is empty.
+func (s *Store) do(ctx context.Context, method, key string, query url.Values, body []byte) (*http.Response, error) {
+	u, err := url.Parse(s.endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint %q: %w", s.endpoint, err)
+	}
+	u = u.JoinPath(s.bucket, key)
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	hash := sha256.Sum256(body)
+	payloadHash := hex.EncodeToString(hash[:])
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if err := s.signer.SignHTTP(ctx, s.credentials, req, payloadHash, "s3", s.region, clock.TimeNowFn().UTC()); err != nil {
+		return nil, fmt.Errorf("failed to sign request: %w", err)
+	}
+
+	return s.client.Do(req)
+}
@@ -0,0 +1,134 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package snapshot
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+)
+
+// fakeS3 is an in-memory S3-compatible object store serving a single bucket, listing at most one object per page.
+type fakeS3 struct {
+	t      *testing.T
+	bucket string
+
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	require.True(f.t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=access-key/"))
+	require.NotEmpty(f.t, r.Header.Get("X-Amz-Content-Sha256"))
+
+	key, ok := strings.CutPrefix(r.URL.Path, "/"+f.bucket)
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	key = strings.TrimPrefix(key, "/")
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodPut:
+		data, err := io.ReadAll(r.Body)
+		require.NoError(f.t, err)
+		f.objects[key] = data
+	case key == "" && r.URL.Query().Get("list-type") == "2":
+		var keys []string
+		for k := range f.objects {
+			if strings.HasPrefix(k, r.URL.Query().Get("prefix")) && k > r.URL.Query().Get("continuation-token") {
+				keys = append(keys, k)
+			}
+		}
+		slices.Sort(keys)
+		if len(keys) == 0 {
+			fmt.Fprint(w, `<ListBucketResult></ListBucketResult>`)
+			return
+		}
+		fmt.Fprintf(w, `<ListBucketResult><Contents><Key>%s</Key></Contents><IsTruncated>%t</IsTruncated>`+
+			`<NextContinuationToken>%s</NextContinuationToken></ListBucketResult>`, keys[0], len(keys) > 1, keys[0])
+	default:
+		data, ok := f.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(data)
+	}
+}
+
+func newTestStore(t *testing.T) (*Store, *fakeS3) {
+	t.Helper()
+
+	s3 := &fakeS3{t: t, bucket: "backups", objects: make(map[string][]byte)}
+	server := httptest.NewServer(s3)
+	t.Cleanup(server.Close)
+
+	t.Setenv("SNAPSHOT_ACCESS_KEY_ID", "access-key")
+	t.Setenv("SNAPSHOT_SECRET_ACCESS_KEY", "secret-key")
+	return New(config.SnapshotConfig{
+		Endpoint: server.URL,
+		Bucket:   "backups",
+		Prefix:   "alerting/",
+		Timeout:  time.Second,
+	}), s3
+}
+
+func TestKey(t *testing.T) {
+	at := time.Date(2026, 10, 16, 14, 35, 12, 0, time.UTC)
+	require.Equal(t, "alerting/snapshot-20261016T143512Z.json", Key("alerting/", at, 0))
+	require.Equal(t, "alerting/snapshot-20261016T140000Z.json", Key("alerting/", at, time.Hour))
+	require.Equal(t, "snapshot-20261016T000000Z.json", Key("", at, 24*time.Hour))
+}
+
+func TestStore(t *testing.T) {
+	store, s3 := newTestStore(t)
+	ctx := t.Context()
+
+	t.Run("NoSnapshot", func(t *testing.T) {
+		_, err := store.Latest(ctx)
+		require.ErrorIs(t, err, ErrNoSnapshot)
+
+		_, err = store.Get(ctx, "alerting/snapshot-20261016T000000Z.json")
+		require.ErrorIs(t, err, ErrNoSnapshot)
+	})
+
+	t.Run("PutAndGet", func(t *testing.T) {
+		require.NoError(t, store.Put(ctx, "alerting/snapshot-20261015T000000Z.json", []byte(`{"formatVersion":1}`)))
+
+		data, err := store.Get(ctx, "alerting/snapshot-20261015T000000Z.json")
+		require.NoError(t, err)
+		require.JSONEq(t, `{"formatVersion":1}`, string(data))
+	})
+
+	t.Run("Latest", func(t *testing.T) {
+		require.NoError(t, store.Put(ctx, "alerting/snapshot-20261016T000000Z.json", []byte(`{}`)))
+		require.NoError(t, store.Put(ctx, "alerting/snapshot-20261014T000000Z.json", []byte(`{}`)))
+		s3.objects["other/snapshot-20261017T000000Z.json"] = []byte(`{}`)
+
+		key, err := store.Latest(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "alerting/snapshot-20261016T000000Z.json", key)
+	})
+
+	t.Run("UnexpectedStatus", func(t *testing.T) {
+		store := New(config.SnapshotConfig{Endpoint: store.endpoint, Bucket: "missing", Timeout: time.Second})
+
+		err := store.Put(ctx, "snapshot-20261016T000000Z.json", []byte(`{}`))
+		require.ErrorContains(t, err, "got unexpected status code: 404")
+	})
+}
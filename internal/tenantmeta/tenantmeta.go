@@ -0,0 +1,140 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tenantmeta fetches the metadata of tenants, such as the display name of their project, from the orchestrator, so
+// that their alerts are annotated with it and read "Project Factory-Munich" rather than the tenant ID.
+package tenantmeta
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/correlation"
+)
+
+// Annotations holding the metadata of the tenant of an alert.
+const (
+	AnnotationProjectName = "project_name"
+	AnnotationRegion      = "project_region"
+	AnnotationSupportURL  = "project_support_url"
+)
+
+// Metadata is the metadata of a tenant, as returned by the orchestrator.
+type Metadata struct {
+	DisplayName string `json:"displayName"`
+	Region      string `json:"region"`
+	SupportURL  string `json:"supportURL"`
+}
+
+// Annotate adds the metadata to the given annotations and returns them, allocating them if nil. Annotations already set,
+// for instance by the alert definition, are kept as is.
+func (m Metadata) Annotate(annotations map[string]string) map[string]string {
+	for name, value := range map[string]string{
+		AnnotationProjectName: m.DisplayName,
+		AnnotationRegion:      m.Region,
+		AnnotationSupportURL:  m.SupportURL,
+	} {
+		if value == "" {
+			continue
+		}
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		if _, ok := annotations[name]; !ok {
+			annotations[name] = value
+		}
+	}
+	return annotations
+}
+
+type cacheEntry struct {
+	metadata Metadata
+	expiry   time.Time
+}
+
+// Client fetches the metadata of tenants from the orchestrator and caches it. The optional TENANT_METADATA_TOKEN environment
+// variable holds the token the client authenticates to the orchestrator with.
+type Client struct {
+	client *http.Client
+	url    string
+	token  string
+	ttl    time.Duration
+
+	mu    sync.Mutex
+	cache map[string]cacheEntry
+}
+
+// New creates a new Client fetching the metadata of a tenant from the configured URL with the tenant ID appended.
+func New(conf config.TenantMetadataConfig) *Client {
+	return &Client{
+		client: &http.Client{Timeout: conf.Timeout},
+		url:    strings.TrimSuffix(conf.URL, "/"),
+		token:  os.Getenv("TENANT_METADATA_TOKEN"),
+		ttl:    conf.CacheTTL,
+		cache:  make(map[string]cacheEntry),
+	}
+}
+
+// Get returns the metadata of the given tenant, fetching it from the orchestrator unless cached. The metadata cached last is
+// returned if it has expired and cannot be fetched again.
+func (c *Client) Get(ctx context.Context, tenantID string) (Metadata, error) {
+	now := clock.TimeNowFn()
+
+	c.mu.Lock()
+	entry, cached := c.cache[tenantID]
+	c.mu.Unlock()
+	if cached && now.Before(entry.expiry) {
+		return entry.metadata, nil
+	}
+
+	metadata, err := c.fetch(ctx, tenantID)
+	if err != nil {
+		if cached {
+			return entry.metadata, nil
+		}
+		return Metadata{}, err
+	}
+
+	c.mu.Lock()
+	c.cache[tenantID] = cacheEntry{metadata: metadata, expiry: now.Add(c.ttl)}
+	c.mu.Unlock()
+	return metadata, nil
+}
+
+// fetch gets the metadata of the given tenant from the orchestrator.
+func (c *Client) fetch(ctx context.Context, tenantID string) (Metadata, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/%s", c.url, url.PathEscape(tenantID)), nil)
+	if err != nil {
+		return Metadata{}, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	correlation.SetHeader(req)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return Metadata{}, fmt.Errorf("failed to get metadata of tenant %q: %w", tenantID, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Metadata{}, fmt.Errorf("failed to get metadata of tenant %q: got unexpected status code: %d", tenantID, resp.StatusCode)
+	}
+
+	var metadata Metadata
+	if err := json.NewDecoder(resp.Body).Decode(&metadata); err != nil {
+		return Metadata{}, fmt.Errorf("failed to parse metadata of tenant %q: %w", tenantID, err)
+	}
+	return metadata, nil
+}
@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package tenantmeta
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/clock"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+)
+
+func TestMetadata_Annotate(t *testing.T) {
+	metadata := Metadata{DisplayName: "Factory-Munich", SupportURL: "https://support.example.com"}
+
+	t.Run("AnnotationsNotSet", func(t *testing.T) {
+		require.Equal(t, map[string]string{
+			"project_name":        "Factory-Munich",
+			"project_support_url": "https://support.example.com",
+		}, metadata.Annotate(nil))
+	})
+
+	t.Run("AnnotationsSetByDefinition", func(t *testing.T) {
+		annotations := map[string]string{"description": "CPU usage above 90%", "project_name": "Munich"}
+		require.Equal(t, map[string]string{
+			"description":         "CPU usage above 90%",
+			"project_name":        "Munich",
+			"project_support_url": "https://support.example.com",
+		}, metadata.Annotate(annotations))
+	})
+
+	t.Run("EmptyMetadata", func(t *testing.T) {
+		require.Nil(t, Metadata{}.Annotate(nil))
+	})
+}
+
+func TestClient_Get(t *testing.T) {
+	clock.SetFakeClock()
+	defer clock.UnsetFakeClock()
+
+	var requests atomic.Int32
+	var status atomic.Int32
+	status.Store(http.StatusOK)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		require.Equal(t, "/v1/projects/tenant-1", r.URL.Path)
+		require.Equal(t, "Bearer metadata-token", r.Header.Get("Authorization"))
+
+		w.WriteHeader(int(status.Load()))
+		_, _ = w.Write([]byte(`{"displayName":"Factory-Munich","region":"eu-central","supportURL":"https://support.example.com"}`))
+	}))
+	defer server.Close()
+
+	t.Setenv("TENANT_METADATA_TOKEN", "metadata-token")
+	client := New(config.TenantMetadataConfig{URL: server.URL + "/v1/projects/", Timeout: time.Second, CacheTTL: time.Minute})
+
+	expected := Metadata{DisplayName: "Factory-Munich", Region: "eu-central", SupportURL: "https://support.example.com"}
+
+	metadata, err := client.Get(context.Background(), "tenant-1")
+	require.NoError(t, err)
+	require.Equal(t, expected, metadata)
+
+	// Metadata is cached until it expires.
+	metadata, err = client.Get(context.Background(), "tenant-1")
+	require.NoError(t, err)
+	require.Equal(t, expected, metadata)
+	require.EqualValues(t, 1, requests.Load())
+
+	// Expired metadata is returned if it cannot be fetched again.
+	clock.FakeClock.Add(2 * time.Minute)
+	status.Store(http.StatusServiceUnavailable)
+	metadata, err = client.Get(context.Background(), "tenant-1")
+	require.NoError(t, err)
+	require.Equal(t, expected, metadata)
+	require.EqualValues(t, 2, requests.Load())
+
+	// Metadata never fetched cannot be returned.
+	client = New(config.TenantMetadataConfig{URL: server.URL + "/v1/projects", Timeout: time.Second, CacheTTL: time.Minute})
+	_, err = client.Get(context.Background(), "tenant-1")
+	require.ErrorContains(t, err, "got unexpected status code: 503")
+}
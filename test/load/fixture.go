@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package load
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+)
+
+// seedBatchSize is the number of rows inserted per statement when seeding.
+const seedBatchSize = 500
+
+// definitionTemplate is the template of the seeded alert definitions.
+const definitionTemplate = `alert: HighCPUUsage
+expr: cpu_usage > [[ .Threshold ]]
+for: 30s
+annotations:
+  description: CPU usage has exceeded
+  summary: High CPU usage detected
+labels:
+  alert_category: performance
+  alert_context: host
+  duration: 30s
+  host_uuid: '{{$labels.hostGuid}}'
+  threshold: "80"
+`
+
+// Fixture holds the tenants and alert definitions seeded into a database.
+type Fixture struct {
+	Tenants []string
+	// Definitions holds the UUIDs of the alert definitions of each tenant.
+	Definitions map[string][]uuid.UUID
+}
+
+// Models returns the models whose tables are used by the seeded fixture.
+func Models() []any {
+	return []any{
+		&models.AlertDefinition{},
+		&models.AlertThreshold{},
+		&models.AlertDuration{},
+		&models.Task{},
+		&models.TaskHistory{},
+		&models.EmailAddress{},
+		&models.EmailConfig{},
+		&models.Receiver{},
+		&models.EmailRecipient{},
+		&models.Tenant{},
+		&models.EmailTemplate{},
+	}
+}
+
+// Seed seeds the given number of tenants, each with the given number of alert definitions along with their threshold, duration,
+// and a new task applying them, as the management service does when initializing tenants.
+func Seed(ctx context.Context, db *gorm.DB, tenants, definitionsPerTenant int) (*Fixture, error) {
+	fixture := &Fixture{
+		Tenants:     make([]string, 0, tenants),
+		Definitions: make(map[string][]uuid.UUID, tenants),
+	}
+
+	defs := make([]models.AlertDefinition, 0, tenants*definitionsPerTenant)
+	for t := range tenants {
+		tenantID := fmt.Sprintf("tenant-%04d", t)
+		fixture.Tenants = append(fixture.Tenants, tenantID)
+
+		for d := range definitionsPerTenant {
+			id := uuid.New()
+			fixture.Definitions[tenantID] = append(fixture.Definitions[tenantID], id)
+			defs = append(defs, models.AlertDefinition{
+				UUID:          id,
+				Name:          fmt.Sprintf("alert-definition-%04d", d),
+				Version:       1,
+				State:         models.DefinitionNew,
+				Template:      definitionTemplate,
+				Category:      models.CategoryPerformance,
+				Context:       "host",
+				Severity:      "high",
+				AlertInterval: 30,
+				Enabled:       true,
+				TenantID:      tenantID,
+			})
+		}
+	}
+
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.CreateInBatches(defs, seedBatchSize).Error; err != nil {
+			return fmt.Errorf("failed to seed alert definitions: %w", err)
+		}
+
+		thresholds := make([]models.AlertThreshold, 0, len(defs))
+		durations := make([]models.AlertDuration, 0, len(defs))
+		tasks := make([]models.Task, 0, len(defs))
+		for i := range defs {
+			thresholds = append(thresholds, models.AlertThreshold{
+				Name:              "Threshold",
+				Threshold:         80,
+				ThresholdMin:      0,
+				ThresholdMax:      100,
+				ThresholdType:     "threshold",
+				ThresholdUnit:     "%",
+				AlertDefinitionID: defs[i].ID,
+			})
+			durations = append(durations, models.AlertDuration{
+				Name:              "Duration",
+				Duration:          30,
+				DurationMin:       15,
+				DurationMax:       3600,
+				AlertDefinitionID: defs[i].ID,
+			})
+			tasks = append(tasks, models.Task{
+				State:               models.TaskNew,
+				AlertDefinitionUUID: &defs[i].UUID,
+				TenantID:            defs[i].TenantID,
+				Version:             1,
+			})
+		}
+
+		if err := tx.CreateInBatches(thresholds, seedBatchSize).Error; err != nil {
+			return fmt.Errorf("failed to seed thresholds: %w", err)
+		}
+		if err := tx.CreateInBatches(durations, seedBatchSize).Error; err != nil {
+			return fmt.Errorf("failed to seed durations: %w", err)
+		}
+		if err := tx.CreateInBatches(tasks, seedBatchSize).Error; err != nil {
+			return fmt.Errorf("failed to seed tasks: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fixture, nil
+}
@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Package load provides a load generator and fixtures to stress the alerting monitor at scale, so that regressions in the
+// latest-version queries, the handlers and the task executor show up before they reach production.
+package load
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Options configures a load run in the manner of k6 executors: VUs virtual users run the scenario concurrently in a loop until
+// Duration elapses or Iterations have been run across all users, whichever comes first. A zero Duration or Iterations disables
+// the corresponding limit, but at least one of them has to be set.
+type Options struct {
+	VUs        int
+	Duration   time.Duration
+	Iterations int
+}
+
+// Scenario is run by a virtual user on every iteration. vu is the index of the virtual user and iteration the index of the
+// iteration across all virtual users.
+type Scenario func(ctx context.Context, vu, iteration int) error
+
+// Result summarizes a load run. Latency percentiles include failed iterations.
+type Result struct {
+	Iterations int
+	Failures   int
+	Elapsed    time.Duration
+	P50        time.Duration
+	P95        time.Duration
+	P99        time.Duration
+	Max        time.Duration
+	// FirstError is the error of the first failed iteration, nil if none failed.
+	FirstError error
+}
+
+// Rate returns the number of iterations run per second.
+func (r Result) Rate() float64 {
+	if r.Elapsed <= 0 {
+		return 0
+	}
+	return float64(r.Iterations) / r.Elapsed.Seconds()
+}
+
+func (r Result) String() string {
+	return fmt.Sprintf("iterations=%d failures=%d rate=%.1f/s p50=%s p95=%s p99=%s max=%s",
+		r.Iterations, r.Failures, r.Rate(), r.P50, r.P95, r.P99, r.Max)
+}
+
+// Run runs a scenario with the given options and returns the summary of the run.
+func Run(ctx context.Context, opts Options, scenario Scenario) (Result, error) {
+	if opts.VUs <= 0 {
+		return Result{}, fmt.Errorf("invalid number of virtual users: %d", opts.VUs)
+	}
+	if opts.Duration <= 0 && opts.Iterations <= 0 {
+		return Result{}, fmt.Errorf("either duration or iterations must be set")
+	}
+
+	if opts.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Duration)
+		defer cancel()
+	}
+
+	var (
+		next      atomic.Int64
+		failures  atomic.Int64
+		mu        sync.Mutex
+		firstErr  error
+		latencies = make([][]time.Duration, opts.VUs)
+		wg        sync.WaitGroup
+	)
+
+	start := time.Now()
+	for vu := range opts.VUs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				iteration := int(next.Add(1) - 1)
+				if opts.Iterations > 0 && iteration >= opts.Iterations {
+					return
+				}
+
+				iterStart := time.Now()
+				err := scenario(ctx, vu, iteration)
+				latencies[vu] = append(latencies[vu], time.Since(iterStart))
+
+				if err != nil {
+					failures.Add(1)
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = err
+					}
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	all := slices.Concat(latencies...)
+	slices.Sort(all)
+	return Result{
+		Iterations: len(all),
+		Failures:   int(failures.Load()),
+		Elapsed:    time.Since(start),
+		P50:        percentile(all, 0.50),
+		P95:        percentile(all, 0.95),
+		P99:        percentile(all, 0.99),
+		Max:        percentile(all, 1),
+		FirstError: firstErr,
+	}, nil
+}
+
+// percentile returns the given quantile of sorted latencies, using the nearest-rank method.
+func percentile(sorted []time.Duration, quantile float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(quantile*float64(len(sorted))+0.5) - 1
+	return sorted[min(max(rank, 0), len(sorted)-1)]
+}
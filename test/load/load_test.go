@@ -0,0 +1,430 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package load
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/open-edge-platform/o11y-alerting-monitor/api/v1"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/app"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/config"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/database/models"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/executor"
+	"github.com/open-edge-platform/o11y-alerting-monitor/internal/rules"
+)
+
+// Load tests are skipped unless -load is given, e.g. go test ./test/load -run TestLoad -load -load.duration=1m.
+// Benchmarks use the same scale, e.g. go test ./test/load -run '^$' -bench . -benchmem.
+var (
+	loadEnabled     = flag.Bool("load", false, "run the load tests")
+	loadTenants     = flag.Int("load.tenants", 1000, "number of seeded tenants")
+	loadDefinitions = flag.Int("load.definitions", 10, "number of seeded alert definitions per tenant")
+	loadAlerts      = flag.Int("load.alerts", 100, "number of alerts returned by the fake alertmanager")
+	loadVUs         = flag.Int("load.vus", 50, "number of virtual users")
+	loadDuration    = flag.Duration("load.duration", 30*time.Second, "duration of each load scenario")
+	loadPostgres    = flag.String("load.postgres", "", "DSN of an empty PostgreSQL database to seed instead of an in-memory SQLite one")
+)
+
+// newSeededDB creates a database seeded with the configured number of tenants and alert definitions. The SQLite database is
+// file-backed, so that it survives connections being discarded when requests are cancelled. SQLite does not allow concurrent
+// writers, so its connections are limited to one and requests queue on it; latencies are only representative of production
+// when seeding PostgreSQL.
+func newSeededDB(tb testing.TB) (*gorm.DB, *Fixture) {
+	tb.Helper()
+
+	dialector := sqlite.Open(filepath.Join(tb.TempDir(), "load.db") + "?_journal_mode=WAL")
+	if *loadPostgres != "" {
+		dialector = postgres.Open(*loadPostgres)
+	}
+	db, err := gorm.Open(dialector, &gorm.Config{Logger: logger.Discard, TranslateError: true})
+	require.NoError(tb, err)
+
+	sqlDB, err := db.DB()
+	require.NoError(tb, err)
+	if *loadPostgres == "" {
+		sqlDB.SetMaxOpenConns(1)
+	}
+	tb.Cleanup(func() {
+		require.NoError(tb, sqlDB.Close())
+	})
+
+	require.NoError(tb, db.AutoMigrate(Models()...))
+
+	fixture, err := Seed(context.Background(), db, *loadTenants, *loadDefinitions)
+	require.NoError(tb, err)
+	return db, fixture
+}
+
+// newAlertmanager creates a fake alertmanager returning the configured number of alerts.
+func newAlertmanager(tb testing.TB) *httptest.Server {
+	tb.Helper()
+
+	alerts := make([]string, 0, *loadAlerts)
+	for i := range *loadAlerts {
+		alerts = append(alerts, fmt.Sprintf(`{
+			"annotations": {"am_uuid": "%s", "summary": "High CPU usage detected", "description": "CPU usage has exceeded"},
+			"labels": {"alertname": "HighCPUUsage", "host_uuid": "%s", "projectId": "tenant"},
+			"startsAt": "2025-01-01T00:00:00Z",
+			"endsAt": "2025-01-01T01:00:00Z",
+			"updatedAt": "2025-01-01T00:00:00Z",
+			"fingerprint": "%016x",
+			"status": {"state": "active", "silencedBy": [], "inhibitedBy": []},
+			"receivers": [{"name": "default"}]
+		}`, uuid.New(), uuid.New(), i))
+	}
+	body := []byte("[" + strings.Join(alerts, ",") + "]")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	}))
+	tb.Cleanup(srv.Close)
+	return srv
+}
+
+// newMimirRuler creates a fake Mimir ruler storing the posted rule groups per tenant, so that they can be verified by the executor.
+func newMimirRuler(tb testing.TB) *httptest.Server {
+	tb.Helper()
+
+	var mu sync.Mutex
+	groups := make(map[string][]byte)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenant := r.Header.Get("X-Scope-OrgID")
+
+		switch r.Method {
+		case http.MethodPost:
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			var group rules.RuleGroup
+			if err := yaml.Unmarshal(body, &group); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			mu.Lock()
+			groups[tenant+"/"+group.Name] = body
+			mu.Unlock()
+			w.WriteHeader(http.StatusAccepted)
+		case http.MethodGet:
+			name := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+
+			mu.Lock()
+			body, ok := groups[tenant+"/"+name]
+			mu.Unlock()
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(body)
+		default:
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+	tb.Cleanup(srv.Close)
+	return srv
+}
+
+// newAPIServer creates a server of the REST API backed by the given database, without authentication.
+func newAPIServer(tb testing.TB, conf config.Config, db *gorm.DB) *httptest.Server {
+	tb.Helper()
+
+	e := echo.New()
+	api.RegisterHandlers(e, app.NewServerInterfaceHandler(conf, db, nil, nil))
+
+	srv := httptest.NewServer(e)
+	tb.Cleanup(srv.Close)
+	return srv
+}
+
+func doRequest(ctx context.Context, client *http.Client, method, url, tenantID string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("ActiveProjectID", tenantID)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if _, err := io.Copy(io.Discard, resp.Body); err != nil {
+		return err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("%s %s: unexpected status code %d", method, url, resp.StatusCode)
+	}
+	return nil
+}
+
+// runScenario runs a scenario with the configured virtual users and duration, failing the test if any iteration fails.
+func runScenario(t *testing.T, scenario Scenario) {
+	t.Helper()
+
+	res, err := Run(context.Background(), Options{VUs: *loadVUs, Duration: *loadDuration}, func(ctx context.Context, vu, iteration int) error {
+		err := scenario(ctx, vu, iteration)
+		// Iterations interrupted by the end of the run are not failures.
+		if ctx.Err() != nil {
+			return nil
+		}
+		return err
+	})
+	require.NoError(t, err)
+
+	t.Log(res)
+	require.NoError(t, res.FirstError)
+}
+
+func TestLoad(t *testing.T) {
+	if !*loadEnabled {
+		t.Skip("load tests are only run with -load")
+	}
+
+	db, fixture := newSeededDB(t)
+	alertmanager := newAlertmanager(t)
+	conf := config.Config{AlertManager: config.AlertManagerConfig{URL: alertmanager.URL}}
+	srv := newAPIServer(t, conf, db)
+	client := srv.Client()
+
+	t.Run("GetAlerts", func(t *testing.T) {
+		runScenario(t, func(ctx context.Context, _, iteration int) error {
+			tenantID := fixture.Tenants[iteration%len(fixture.Tenants)]
+			return doRequest(ctx, client, http.MethodGet, srv.URL+"/api/v1/alerts", tenantID, nil)
+		})
+	})
+
+	t.Run("GetAlertDefinitions", func(t *testing.T) {
+		runScenario(t, func(ctx context.Context, _, iteration int) error {
+			tenantID := fixture.Tenants[iteration%len(fixture.Tenants)]
+			return doRequest(ctx, client, http.MethodGet, srv.URL+"/api/v1/alerts/definitions", tenantID, nil)
+		})
+	})
+
+	t.Run("PatchAlertDefinition", func(t *testing.T) {
+		runScenario(t, func(ctx context.Context, _, iteration int) error {
+			tenantID := fixture.Tenants[iteration%len(fixture.Tenants)]
+			defs := fixture.Definitions[tenantID]
+			id := defs[(iteration/len(fixture.Tenants))%len(defs)]
+
+			body := fmt.Sprintf(`{"values":{"threshold":"%d"}}`, 1+iteration%100)
+			return doRequest(ctx, client, http.MethodPatch, srv.URL+"/api/v1/alerts/definitions/"+id.String(), tenantID, []byte(body))
+		})
+	})
+}
+
+func TestLoadExecutor(t *testing.T) {
+	if !*loadEnabled {
+		t.Skip("load tests are only run with -load")
+	}
+
+	db, fixture := newSeededDB(t)
+	ruler := newMimirRuler(t)
+
+	conf := config.Config{
+		Mimir: config.MimirConfig{RulerURL: ruler.URL, Namespace: "alerting-monitor"},
+		TaskExecutor: config.TaskExecutorConfig{
+			UUIDLimit:     *loadVUs,
+			RetryLimit:    1,
+			TaskTimeout:   time.Minute,
+			RetentionTime: time.Hour,
+			PoolingRate:   10 * time.Millisecond,
+		},
+	}
+
+	ctx := context.Background()
+	ae := executor.NewAsyncExecutor(uuid.New(), conf, db, "error", nil, nil)
+
+	start := time.Now()
+	ae.Start(ctx)
+	defer ae.Stop()
+
+	total := 0
+	for _, defs := range fixture.Definitions {
+		total += len(defs)
+	}
+
+	require.Eventually(t, func() bool {
+		var pending int64
+		err := db.WithContext(ctx).Model(&models.Task{}).
+			Where("state IN ?", []models.TaskState{models.TaskNew, models.TaskTaken}).
+			Count(&pending).Error
+		return err == nil && pending == 0
+	}, *loadDuration*10, 100*time.Millisecond)
+
+	elapsed := time.Since(start)
+	t.Logf("applied %d alert definitions in %s (%.1f/s)", total, elapsed, float64(total)/elapsed.Seconds())
+
+	var failed int64
+	require.NoError(t, db.WithContext(ctx).Model(&models.Task{}).Where("state = ?", models.TaskError).Count(&failed).Error)
+	require.Zero(t, failed)
+}
+
+func BenchmarkGetLatestAlertDefinitionList(b *testing.B) {
+	db, fixture := newSeededDB(b)
+	dbService := &database.DBService{DB: db}
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := range b.N {
+		tenantID := fixture.Tenants[i%len(fixture.Tenants)]
+		if _, _, err := dbService.GetLatestAlertDefinitionList(ctx, tenantID, database.ListOptions{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetLatestAlertDefinition(b *testing.B) {
+	db, fixture := newSeededDB(b)
+	dbService := &database.DBService{DB: db}
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := range b.N {
+		tenantID := fixture.Tenants[i%len(fixture.Tenants)]
+		defs := fixture.Definitions[tenantID]
+		if _, err := dbService.GetLatestAlertDefinition(ctx, tenantID, defs[i%len(defs)]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSetAlertDefinitionValues(b *testing.B) {
+	db, fixture := newSeededDB(b)
+	dbService := &database.DBService{DB: db}
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := range b.N {
+		tenantID := fixture.Tenants[i%len(fixture.Tenants)]
+		defs := fixture.Definitions[tenantID]
+		threshold := int64(1 + i%100)
+		err := dbService.SetAlertDefinitionValues(ctx, tenantID, defs[i%len(defs)], models.DBAlertDefinitionValues{Threshold: &threshold})
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetPendingTasks(b *testing.B) {
+	db, _ := newSeededDB(b)
+	dbService := &database.DBService{DB: db}
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for range b.N {
+		// Taken tasks are not returned again, so they are released after every iteration to keep the number of pending tasks.
+		tasks, err := dbService.GetPendingTasks(ctx, uuid.New(), database.PendingTaskOptions{CountLimit: 10})
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.StopTimer()
+		if err := db.WithContext(ctx).Model(&models.Task{}).Where("state = ?", models.TaskTaken).
+			Update("state", models.TaskNew).Error; err != nil {
+			b.Fatal(err)
+		}
+		if len(tasks) == 0 {
+			b.Fatal(errors.New("no pending tasks"))
+		}
+		b.StartTimer()
+	}
+}
+
+func BenchmarkGetAlerts(b *testing.B) {
+	db, fixture := newSeededDB(b)
+	alertmanager := newAlertmanager(b)
+	srv := newAPIServer(b, config.Config{AlertManager: config.AlertManagerConfig{URL: alertmanager.URL}}, db)
+	client := srv.Client()
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := range b.N {
+		tenantID := fixture.Tenants[i%len(fixture.Tenants)]
+		if err := doRequest(ctx, client, http.MethodGet, srv.URL+"/api/v1/alerts", tenantID, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	t.Run("Iterations", func(t *testing.T) {
+		var count atomic.Int64
+		res, err := Run(context.Background(), Options{VUs: 4, Iterations: 100}, func(context.Context, int, int) error {
+			if count.Add(1)%10 == 0 {
+				return errors.New("mock error")
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, 100, res.Iterations)
+		require.Equal(t, 10, res.Failures)
+		require.EqualError(t, res.FirstError, "mock error")
+		require.LessOrEqual(t, res.P50, res.P95)
+		require.LessOrEqual(t, res.P95, res.P99)
+		require.LessOrEqual(t, res.P99, res.Max)
+	})
+
+	t.Run("Duration", func(t *testing.T) {
+		res, err := Run(context.Background(), Options{VUs: 2, Duration: 50 * time.Millisecond}, func(ctx context.Context, _, _ int) error {
+			select {
+			case <-ctx.Done():
+			case <-time.After(time.Millisecond):
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		require.Positive(t, res.Iterations)
+		require.Zero(t, res.Failures)
+		require.GreaterOrEqual(t, res.Elapsed, 50*time.Millisecond)
+	})
+
+	t.Run("Invalid options", func(t *testing.T) {
+		_, err := Run(context.Background(), Options{VUs: 1}, func(context.Context, int, int) error { return nil })
+		require.Error(t, err)
+
+		_, err = Run(context.Background(), Options{Iterations: 1}, func(context.Context, int, int) error { return nil })
+		require.Error(t, err)
+	})
+}
+
+func TestPercentile(t *testing.T) {
+	sorted := make([]time.Duration, 100)
+	for i := range sorted {
+		sorted[i] = time.Duration(i+1) * time.Millisecond
+	}
+
+	require.Equal(t, 50*time.Millisecond, percentile(sorted, 0.5))
+	require.Equal(t, 99*time.Millisecond, percentile(sorted, 0.99))
+	require.Equal(t, 100*time.Millisecond, percentile(sorted, 1))
+	require.Zero(t, percentile(nil, 0.5))
+}